@@ -2,29 +2,49 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"log/slog"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/dukex/operion/pkg/eventbus"
 	"github.com/dukex/operion/pkg/events"
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/payloads"
 	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/workflow"
 )
 
+// heartbeatCheckInterval is how often scheduled workflows are checked for missed runs.
+const heartbeatCheckInterval = 30 * time.Second
+
 // Activator consumes source events and triggers workflows based on registered triggers.
 type Activator struct {
 	id             string
 	eventBus       eventbus.EventBus
 	sourceEventBus eventbus.SourceEventBus
 	persistence    persistence.Persistence
+	heartbeats     *workflow.HeartbeatMonitor
+	executions     *workflow.ExecutionService
+	debounces      *debouncer
 	logger         *slog.Logger
 	restartCount   int
+	shardIndex     int
+	shardCount     int
+	payloads       *payloads.Offloader
+
+	// sharedExecutions serializes the activations of workflows sharing their running execution
+	sharedExecutions sync.Mutex
 }
 
+// ErrInvalidShard is returned when the shard index is not within the shard count.
+var ErrInvalidShard = errors.New("shard index must be between 0 and the shard count")
+
 // NewActivator creates a new Activator instance.
 func NewActivator(
 	id string,
@@ -33,13 +53,52 @@ func NewActivator(
 	sourceEventBus eventbus.SourceEventBus,
 	logger *slog.Logger,
 ) *Activator {
-	return &Activator{
+	activator := &Activator{
 		id:             id,
 		eventBus:       eventBus,
 		sourceEventBus: sourceEventBus,
 		persistence:    persistence,
+		heartbeats:     workflow.NewHeartbeatMonitor(persistence, eventBus),
+		executions:     workflow.NewExecutionService(persistence, eventBus, nil),
 		logger:         logger.With("module", "activator"),
+		shardCount:     1,
+	}
+	activator.debounces = newDebouncer(persistence.DebounceRepository(), activator.activateDebounced, activator.logger)
+
+	return activator
+}
+
+// ConfigureSharding makes the activator handle only the workflows of the given shard, out of
+// shardCount activators sharing the same source events. It must be called before Start.
+func (a *Activator) ConfigureSharding(shardIndex, shardCount int) error {
+	if shardCount < 1 || shardIndex < 0 || shardIndex >= shardCount {
+		return fmt.Errorf("%w: index %d, count %d", ErrInvalidShard, shardIndex, shardCount)
 	}
+
+	a.shardIndex = shardIndex
+	a.shardCount = shardCount
+	a.logger = a.logger.With("shard_index", shardIndex, "shard_count", shardCount)
+
+	return nil
+}
+
+// ConfigurePayloadOffloading sets the offloader moving large trigger data out of execution contexts
+// to an object store. A nil offloader stores trigger data inline. It must be called before Start.
+func (a *Activator) ConfigurePayloadOffloading(offloader *payloads.Offloader) {
+	a.payloads = offloader
+}
+
+// WorkflowShard returns the shard owning workflowID, out of shardCount shards.
+func WorkflowShard(workflowID string, shardCount int) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(workflowID))
+
+	return int(hash.Sum32() % uint32(shardCount)) //nolint:gosec // shardCount is validated to be positive
+}
+
+// ownsWorkflow reports whether this activator's shard handles workflowID.
+func (a *Activator) ownsWorkflow(workflowID string) bool {
+	return a.shardCount <= 1 || WorkflowShard(workflowID, a.shardCount) == a.shardIndex
 }
 
 // Start begins the activator service.
@@ -98,14 +157,44 @@ func (a *Activator) restart(ctx context.Context, cancel context.CancelFunc) {
 func (a *Activator) run(ctx context.Context) {
 	a.logger.Info("Starting source event consumption")
 
+	if err := a.debounces.restore(ctx, a.ownsWorkflow); err != nil {
+		a.logger.Error("Failed to restore pending debounces", "error", err)
+	}
+
 	// Set up source event subscription
 	a.processSourceEvents(ctx)
 
+	go a.monitorHeartbeats(ctx)
+
 	// Wait for context cancellation - the subscription runs in background goroutines
 	<-ctx.Done()
 	a.logger.Info("Activator context cancelled, stopping...")
 }
 
+// monitorHeartbeats periodically alerts on the scheduled workflows of this shard that missed a run.
+func (a *Activator) monitorHeartbeats(ctx context.Context) {
+	ticker := time.NewTicker(heartbeatCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			alerts, err := a.heartbeats.Check(ctx, now.UTC(), a.ownsWorkflow)
+			if err != nil {
+				a.logger.Error("Failed to check workflow heartbeats", "error", err)
+
+				continue
+			}
+
+			if alerts > 0 {
+				a.logger.Warn("Scheduled workflows missed their heartbeat", "count", alerts)
+			}
+		}
+	}
+}
+
 // processSourceEvents handles incoming source events and triggers workflows.
 func (a *Activator) processSourceEvents(ctx context.Context) {
 	a.logger.Info("Setting up source event subscription")
@@ -143,9 +232,17 @@ func (a *Activator) handleSourceEvent(ctx context.Context, sourceEvent *events.S
 		"provider_id", sourceEvent.ProviderID,
 		"event_type", sourceEvent.EventType,
 	)
+	if sourceEvent.CorrelationID != "" {
+		logger = logger.With("correlation_id", sourceEvent.CorrelationID)
+	}
 
 	logger.Info("Processing source event")
 
+	// Executions triggered by this event carry the correlation ID of the request behind it
+	// and the ordering key of the event
+	ctx = events.WithCorrelationID(ctx, sourceEvent.CorrelationID)
+	ctx = events.WithOrderingKey(ctx, sourceEvent.OrderingKey)
+
 	// Validate the source event
 	if err := sourceEvent.Validate(); err != nil {
 		logger.Error("Invalid source event", "error", err)
@@ -163,8 +260,36 @@ func (a *Activator) handleSourceEvent(ctx context.Context, sourceEvent *events.S
 
 	logger.Info("Found matching trigger nodes", "count", len(matchingTriggerNodes))
 
-	// Publish NodeActivation event for each matching trigger node
+	// Publish NodeActivation event for each matching trigger node owned by this shard
 	for _, matchInfo := range matchingTriggerNodes {
+		if !a.ownsWorkflow(matchInfo.WorkflowID) {
+			logger.Debug("Skipping trigger of workflow owned by another shard",
+				"workflow_id", matchInfo.WorkflowID,
+				"trigger_node_id", matchInfo.TriggerNode.ID)
+
+			continue
+		}
+
+		if !a.eventMatchesTrigger(logger, matchInfo, sourceEvent) {
+			continue
+		}
+
+		settings, err := triggerDebounce(matchInfo.TriggerNode)
+		if err != nil {
+			logger.Error("Skipping trigger with invalid debounce",
+				"workflow_id", matchInfo.WorkflowID,
+				"trigger_node_id", matchInfo.TriggerNode.ID,
+				"error", err)
+
+			continue
+		}
+
+		if settings != nil {
+			a.debounceActivation(ctx, logger, matchInfo, settings, sourceEvent)
+
+			continue
+		}
+
 		logger.Info("Will process trigger " + matchInfo.TriggerNode.ID)
 
 		if err := a.publishNodeActivation(ctx, matchInfo.WorkflowID, matchInfo.TriggerNode.ID, sourceEvent.EventData); err != nil {
@@ -175,9 +300,54 @@ func (a *Activator) handleSourceEvent(ctx context.Context, sourceEvent *events.S
 		}
 	}
 
+	// Resume the executions of this shard waiting for this event
+	received, err := a.executions.ReceiveSourceEvent(ctx, sourceEvent, a.ownsWorkflow)
+	if err != nil {
+		logger.Error("Failed to resume executions waiting for the event", "error", err)
+
+		return err
+	}
+
+	if received > 0 {
+		logger.Info("Resumed executions waiting for the event", "count", received)
+	}
+
 	return nil
 }
 
+// debounceActivation holds back the activation of a debounced trigger node until no event with
+// the same key arrived for the debounce interval.
+func (a *Activator) debounceActivation(ctx context.Context, logger *slog.Logger, matchInfo *models.TriggerNodeMatch, settings *debounceSettings, sourceEvent *events.SourceEvent) {
+	logger = logger.With("workflow_id", matchInfo.WorkflowID, "trigger_node_id", matchInfo.TriggerNode.ID)
+
+	key, err := settings.renderKey(sourceEvent.EventData)
+	if err != nil {
+		logger.Error("Failed to render debounce key", "error", err)
+
+		return
+	}
+
+	pending, err := a.debounces.add(ctx, matchInfo.WorkflowID, matchInfo.TriggerNode.ID, key, settings.interval, sourceEvent.EventData, sourceEvent.CorrelationID)
+	if err != nil {
+		logger.Error("Failed to debounce trigger activation", "error", err)
+
+		return
+	}
+
+	logger.Info("Debounced trigger activation",
+		"debounce_key", key,
+		"event_count", pending.EventCount,
+		"fire_at", pending.FireAt)
+}
+
+// activateDebounced activates the trigger node of a debounce whose interval elapsed, with the
+// data of its latest event.
+func (a *Activator) activateDebounced(ctx context.Context, pending *models.PendingDebounce) error {
+	ctx = events.WithCorrelationID(ctx, pending.CorrelationID)
+
+	return a.publishNodeActivation(ctx, pending.WorkflowID, pending.TriggerNodeID, pending.EventData)
+}
+
 // findTriggerNodesForSourceEvent queries the database for trigger nodes that match a source event.
 func (a *Activator) findTriggerNodesForSourceEvent(ctx context.Context, sourceEvent *events.SourceEvent) ([]*models.TriggerNodeMatch, error) {
 	// Use the node repository to find trigger nodes by source ID, event type, and provider ID
@@ -198,16 +368,13 @@ func (a *Activator) findTriggerNodesForSourceEvent(ctx context.Context, sourceEv
 	return matchingTriggerNodes, nil
 }
 
-// publishNodeActivation publishes a NodeActivation event for a specific trigger node.
+// publishNodeActivation publishes a NodeActivation event for a specific trigger node, in a new
+// execution or, for workflows sharing executions, in the running execution of the workflow.
 func (a *Activator) publishNodeActivation(ctx context.Context, workflowID, triggerNodeID string, sourceData map[string]any) error {
 	logger := a.logger.With("workflow_id", workflowID, "trigger_node_id", triggerNodeID)
 	logger.InfoContext(ctx, "Publishing NodeActivation event")
 
-	// Generate execution ID for this workflow execution
-	executionID := a.eventBus.GenerateID(ctx)
-	logger.InfoContext(ctx, "Generated execution ID", "execution_id", executionID)
-
-	// Load workflow to get variables
+	// Load workflow to get variables and trigger mode
 	workflow, err := a.persistence.WorkflowRepository().GetByID(ctx, workflowID)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to load workflow", "error", err)
@@ -222,24 +389,41 @@ func (a *Activator) publishNodeActivation(ctx context.Context, workflowID, trigg
 		return err
 	}
 
-	// Copy variables from workflow, handle nil case
-	var workflowVariables map[string]any
-	if workflow.Variables != nil {
-		workflowVariables = workflow.Variables
+	var executionCtx *models.ExecutionContext
+
+	if workflow.SharesExecutions() {
+		// Looking up and creating the shared execution must not interleave between events
+		a.sharedExecutions.Lock()
+		defer a.sharedExecutions.Unlock()
+
+		executionCtx, err = a.runningExecution(ctx, workflowID)
+		if err != nil {
+			logger.ErrorContext(ctx, "Failed to look up the running execution", "error", err)
+
+			return fmt.Errorf("failed to look up the running execution of workflow %s: %w", workflowID, err)
+		}
+	}
+
+	if executionCtx != nil {
+		logger.InfoContext(ctx, "Activating trigger in the running execution", "execution_id", executionCtx.ID)
 	} else {
-		workflowVariables = make(map[string]any)
+		executionCtx = a.newExecutionContext(ctx, workflow)
+		logger.InfoContext(ctx, "Generated execution ID", "execution_id", executionCtx.ID)
 	}
 
-	// Create execution context for this workflow execution
-	executionCtx := &models.ExecutionContext{
-		ID:          executionID,
-		WorkflowID:  workflowID,
-		Status:      models.ExecutionStatusRunning,
-		NodeResults: make(map[string]models.NodeResult),
-		TriggerData: sourceData,
-		Variables:   workflowVariables,
-		Metadata:    make(map[string]any),
-		CreatedAt:   time.Now(),
+	executionID := executionCtx.ID
+	executionCtx.TriggerData = sourceData
+	executionCtx.TriggerDataRef = ""
+
+	// Large trigger data is stored as a reference; the activation below still carries it inline
+	if a.payloads != nil {
+		ref, err := a.payloads.Offload(ctx, executionID, "trigger_data", sourceData)
+		if err != nil {
+			logger.WarnContext(ctx, "Failed to offload trigger data, storing it inline", "error", err)
+		} else if ref != "" {
+			executionCtx.TriggerData = nil
+			executionCtx.TriggerDataRef = ref
+		}
 	}
 
 	// Save execution context before publishing the event
@@ -265,10 +449,28 @@ func (a *Activator) publishNodeActivation(ctx context.Context, workflowID, trigg
 		SourcePort:  "", // External source
 	}
 	event.ID = a.eventBus.GenerateID(ctx)
+	event.CorrelationID = executionCtx.CorrelationID
+	event.OrderingKey = executionCtx.OrderingKey
+
+	// High-priority workflows are routed to the high-priority topic by the event bus
+	event.Priority = workflow.Priority()
+
+	// The activation is counted as pending before it is published, so the worker completes the
+	// execution once it handled every activation
+	activations := a.persistence.ExecutionContextRepository()
+	if _, err := activations.AddPendingActivations(ctx, executionID, 1); err != nil {
+		logger.Error("Failed to count pending activation", "error", err)
+
+		return fmt.Errorf("failed to count pending activation: %w", err)
+	}
 
 	if err := a.eventBus.Publish(ctx, triggerNodeID+":"+executionID, event); err != nil {
 		logger.Error("Failed to publish NodeActivation event", "error", err)
 
+		if _, countErr := activations.AddPendingActivations(ctx, executionID, -1); countErr != nil {
+			logger.Warn("Failed to uncount pending activation", "error", countErr)
+		}
+
 		return err
 	}
 
@@ -277,6 +479,58 @@ func (a *Activator) publishNodeActivation(ctx context.Context, workflowID, trigg
 	return nil
 }
 
+// newExecutionContext creates the context of a new execution of workflow, correlated by the
+// correlation ID in ctx or, when not traced back to a request, by its own ID.
+func (a *Activator) newExecutionContext(ctx context.Context, workflow *models.Workflow) *models.ExecutionContext {
+	executionID := a.eventBus.GenerateID(ctx)
+
+	correlationID := events.CorrelationID(ctx)
+	if correlationID == "" {
+		correlationID = executionID
+	}
+
+	// Copy variables from workflow, handle nil case
+	variables := workflow.Variables
+	if variables == nil {
+		variables = make(map[string]any)
+	}
+
+	return &models.ExecutionContext{
+		ID:            executionID,
+		WorkflowID:    workflow.ID,
+		CorrelationID: correlationID,
+		OrderingKey:   events.OrderingKey(ctx),
+		Status:        models.ExecutionStatusRunning,
+		NodeResults:   make(map[string]models.NodeResult),
+		Variables:     variables,
+		Metadata:      make(map[string]any),
+		CreatedAt:     time.Now(),
+	}
+}
+
+// runningExecution returns the most recent execution of workflowID that has not ended, or nil
+// when every execution of the workflow ended.
+func (a *Activator) runningExecution(ctx context.Context, workflowID string) (*models.ExecutionContext, error) {
+	executions, err := a.persistence.ExecutionContextRepository().GetExecutionsByWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var running *models.ExecutionContext
+
+	for _, execution := range executions {
+		if execution.Status.IsTerminal() {
+			continue
+		}
+
+		if running == nil || execution.CreatedAt.After(running.CreatedAt) {
+			running = execution
+		}
+	}
+
+	return running, nil
+}
+
 // stop gracefully shuts down the activator.
 func (a *Activator) stop(cancel context.CancelFunc) {
 	a.logger.Info("Stopping activator")
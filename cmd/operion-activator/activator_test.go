@@ -4,12 +4,18 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/dukex/operion/pkg/eventbus"
 	"github.com/dukex/operion/pkg/events"
 	"github.com/dukex/operion/pkg/mocks"
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/payloads"
+	"github.com/dukex/operion/pkg/template"
+	"github.com/dukex/operion/pkg/workflow"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -23,10 +29,19 @@ func createTestActivator() (*Activator, *mocks.MockPersistence, *mocks.MockEvent
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
 	activator := NewActivator("test-activator", mockPersistence, mockEventBus, mockSourceEventBus, logger)
+	allowEventWaitLookups(mockPersistence)
 
 	return activator, mockPersistence, mockEventBus, mockSourceEventBus
 }
 
+// allowEventWaitLookups lets the activator look for executions waiting on source events, finding none.
+func allowEventWaitLookups(mockPersistence *mocks.MockPersistence) {
+	mockPersistence.GetMockExecutionContextRepository().
+		On("GetExecutionsByStatus", mock.Anything, models.ExecutionStatusPaused).
+		Return([]*models.ExecutionContext{}, nil).
+		Maybe()
+}
+
 // Helper function to create a standard source event for testing.
 func createTestSourceEvent() *events.SourceEvent {
 	return &events.SourceEvent{
@@ -106,6 +121,7 @@ func TestActivator_HandleSourceEvent_ValidEvent(t *testing.T) {
 
 	// Mock saving execution context
 	mockPersistence.GetMockExecutionContextRepository().On("SaveExecutionContext", mock.Anything, mock.AnythingOfType("*models.ExecutionContext")).Return(nil)
+	mockPersistence.GetMockExecutionContextRepository().On("AddPendingActivations", mock.Anything, "event-123", 1).Return(1, nil).Once()
 
 	// Mock event publishing
 	mockEventBus.On("GenerateID", context.Background()).Return("event-123")
@@ -146,6 +162,7 @@ func TestActivator_HandleSourceEvent_NoMatchingTriggers(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
 	activator := NewActivator("test-activator", mockPersistence, mockEventBus, mockSourceEventBus, logger)
+	allowEventWaitLookups(mockPersistence)
 
 	sourceEvent := &events.SourceEvent{
 		SourceID:   "source-123",
@@ -172,6 +189,7 @@ func TestActivator_HandleSourceEvent_DatabaseError(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
 	activator := NewActivator("test-activator", mockPersistence, mockEventBus, mockSourceEventBus, logger)
+	allowEventWaitLookups(mockPersistence)
 
 	sourceEvent := &events.SourceEvent{
 		SourceID:   "source-123",
@@ -198,6 +216,7 @@ func TestActivator_HandleSourceEvent_MultipleMatchingTriggers(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
 	activator := NewActivator("test-activator", mockPersistence, mockEventBus, mockSourceEventBus, logger)
+	allowEventWaitLookups(mockPersistence)
 
 	sourceEvent := &events.SourceEvent{
 		SourceID:   "source-123",
@@ -254,6 +273,7 @@ func TestActivator_HandleSourceEvent_MultipleMatchingTriggers(t *testing.T) {
 
 	// Mock saving execution contexts for both workflows
 	mockPersistence.GetMockExecutionContextRepository().On("SaveExecutionContext", mock.Anything, mock.AnythingOfType("*models.ExecutionContext")).Return(nil).Twice()
+	mockPersistence.GetMockExecutionContextRepository().On("AddPendingActivations", mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
 
 	// Mock event publishing for both workflows - each publishNodeActivation call needs 2 GenerateID calls
 	mockEventBus.On("GenerateID", context.Background()).Return("event-123").Once() // execution ID for first workflow
@@ -278,6 +298,7 @@ func TestActivator_HandleSourceEvent_PublishFailure(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
 	activator := NewActivator("test-activator", mockPersistence, mockEventBus, mockSourceEventBus, logger)
+	allowEventWaitLookups(mockPersistence)
 
 	sourceEvent := &events.SourceEvent{
 		SourceID:   "source-123",
@@ -313,6 +334,7 @@ func TestActivator_HandleSourceEvent_PublishFailure(t *testing.T) {
 
 	// Mock saving execution context
 	mockPersistence.GetMockExecutionContextRepository().On("SaveExecutionContext", mock.Anything, mock.AnythingOfType("*models.ExecutionContext")).Return(nil)
+	mockPersistence.GetMockExecutionContextRepository().On("AddPendingActivations", mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
 
 	// Mock event publishing failure
 	mockEventBus.On("GenerateID", context.Background()).Return("event-123")
@@ -434,6 +456,7 @@ func TestActivator_PublishWorkflowTriggered_Success(t *testing.T) {
 
 	// Mock saving execution context
 	mockPersistence.GetMockExecutionContextRepository().On("SaveExecutionContext", mock.Anything, mock.AnythingOfType("*models.ExecutionContext")).Return(nil)
+	mockPersistence.GetMockExecutionContextRepository().On("AddPendingActivations", mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
 
 	mockEventBus.On("GenerateID", context.Background()).Return("event-123")
 	mockEventBus.On("Publish", mock.Anything, "trigger-123:event-123", mock.MatchedBy(func(event events.NodeActivation) bool {
@@ -472,6 +495,7 @@ func TestActivator_PublishWorkflowTriggered_EventBusFailure(t *testing.T) {
 
 	// Mock saving execution context
 	mockPersistence.GetMockExecutionContextRepository().On("SaveExecutionContext", mock.Anything, mock.AnythingOfType("*models.ExecutionContext")).Return(nil)
+	mockPersistence.GetMockExecutionContextRepository().On("AddPendingActivations", mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
 
 	mockEventBus.On("GenerateID", context.Background()).Return("event-123")
 	mockEventBus.On("Publish", mock.Anything, "trigger-123:event-123", mock.AnythingOfType("events.NodeActivation")).Return(assert.AnError)
@@ -508,6 +532,7 @@ func TestActivator_PublishWorkflowTriggered_ValidEventStructure(t *testing.T) {
 
 	// Mock saving execution context
 	mockPersistence.GetMockExecutionContextRepository().On("SaveExecutionContext", mock.Anything, mock.AnythingOfType("*models.ExecutionContext")).Return(nil)
+	mockPersistence.GetMockExecutionContextRepository().On("AddPendingActivations", mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
 
 	mockEventBus.On("GenerateID", context.Background()).Return("event-456")
 
@@ -691,6 +716,7 @@ func TestActivator_EventHandlingFlow_Integration(t *testing.T) {
 	mockPersistence.GetMockWorkflowRepository().On("GetByID", mock.Anything, "workflow-integration").Return(testWorkflow, nil)
 
 	mockPersistence.GetMockExecutionContextRepository().On("SaveExecutionContext", mock.Anything, mock.AnythingOfType("*models.ExecutionContext")).Return(nil)
+	mockPersistence.GetMockExecutionContextRepository().On("AddPendingActivations", mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
 	mockEventBus.On("GenerateID", context.Background()).Return("event-integration")
 	mockEventBus.On("Publish", mock.Anything, "trigger-integration:event-integration", mock.AnythingOfType("events.NodeActivation")).Return(nil)
 
@@ -702,3 +728,194 @@ func TestActivator_EventHandlingFlow_Integration(t *testing.T) {
 	mockPersistence.GetMockExecutionContextRepository().AssertExpectations(t)
 	mockEventBus.AssertExpectations(t)
 }
+
+func TestActivator_ConfigureSharding_Validation(t *testing.T) {
+	activator, _, _, _ := createTestActivator()
+
+	require.NoError(t, activator.ConfigureSharding(1, 2))
+	assert.ErrorIs(t, activator.ConfigureSharding(2, 2), ErrInvalidShard)
+	assert.ErrorIs(t, activator.ConfigureSharding(-1, 2), ErrInvalidShard)
+	assert.ErrorIs(t, activator.ConfigureSharding(0, 0), ErrInvalidShard)
+}
+
+func TestActivator_HandleSourceEvent_OnlyOwningShardActivates(t *testing.T) {
+	const shardCount = 2
+
+	sourceEvent := createTestSourceEvent()
+	publishes := make([]int, shardCount)
+
+	for shard := range shardCount {
+		activator, mockPersistence, mockEventBus, _ := createTestActivator()
+		require.NoError(t, activator.ConfigureSharding(shard, shardCount))
+
+		// The source event matches triggers of workflows on both shards
+		matches := append(
+			createTestTriggerNodeMatches("workflow-a", "trigger-a", "source-123"),
+			createTestTriggerNodeMatches("workflow-b", "trigger-b", "source-123")...,
+		)
+		mockPersistence.GetMockNodeRepository().On("FindTriggerNodesBySourceEventAndProvider", mock.Anything, "source-123", "ScheduleDue", "scheduler", models.WorkflowStatusPublished).Return(matches, nil)
+		mockPersistence.GetMockWorkflowRepository().On("GetByID", mock.Anything, mock.Anything).Return(&models.Workflow{ID: "workflow"}, nil).Maybe()
+		mockPersistence.GetMockExecutionContextRepository().On("SaveExecutionContext", mock.Anything, mock.Anything).Return(nil).Maybe()
+		mockPersistence.GetMockExecutionContextRepository().On("AddPendingActivations", mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
+		mockEventBus.On("GenerateID", mock.Anything).Return("event-id").Maybe()
+		mockEventBus.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+		require.NoError(t, activator.handleSourceEvent(context.Background(), sourceEvent))
+
+		for _, workflowID := range []string{"workflow-a", "workflow-b"} {
+			published := false
+
+			for _, call := range mockEventBus.Calls {
+				if call.Method != "Publish" {
+					continue
+				}
+
+				if activation, ok := call.Arguments.Get(2).(events.NodeActivation); ok && activation.WorkflowID == workflowID {
+					published = true
+				}
+			}
+
+			assert.Equal(t, WorkflowShard(workflowID, shardCount) == shard, published,
+				"workflow %s activated on shard %d", workflowID, shard)
+
+			if published {
+				publishes[shard]++
+			}
+		}
+	}
+
+	// Each workflow is activated exactly once across the shards
+	assert.Equal(t, 2, publishes[0]+publishes[1])
+}
+
+func TestActivator_HandleSourceEvent_OffloadsLargeTriggerData(t *testing.T) {
+	activator, mockPersistence, mockEventBus, _ := createTestActivator()
+	storeDir := t.TempDir()
+	offloader := payloads.NewOffloader(payloads.NewFileStore(storeDir), 1024)
+	activator.ConfigurePayloadOffloading(offloader)
+
+	sourceEvent := createTestSourceEvent()
+	sourceEvent.EventData = map[string]any{"order_id": "order-1", "document": strings.Repeat("x", 4096)}
+	triggerMatches := createTestTriggerNodeMatches("workflow-123", "trigger-123", "source-123")
+
+	mockPersistence.GetMockNodeRepository().On("FindTriggerNodesBySourceEventAndProvider", mock.Anything, "source-123", "ScheduleDue", "scheduler", models.WorkflowStatusPublished).Return(triggerMatches, nil)
+	mockPersistence.GetMockWorkflowRepository().On("GetByID", mock.Anything, "workflow-123").Return(&models.Workflow{ID: "workflow-123"}, nil)
+
+	var saved *models.ExecutionContext
+
+	mockPersistence.GetMockExecutionContextRepository().On("SaveExecutionContext", mock.Anything, mock.AnythingOfType("*models.ExecutionContext")).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(*models.ExecutionContext) }).
+		Return(nil)
+	mockPersistence.GetMockExecutionContextRepository().On("AddPendingActivations", mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
+	mockPersistence.GetMockExecutionContextRepository().On("DeleteExecutionContext", mock.Anything, "event-123").Return(nil)
+
+	// The activation still carries the trigger data inline
+	mockEventBus.On("GenerateID", context.Background()).Return("event-123")
+	mockEventBus.On("Publish", mock.Anything, "trigger-123:event-123", mock.MatchedBy(func(event events.NodeActivation) bool {
+		data, ok := event.InputData.(map[string]any)
+
+		return ok && data["order_id"] == "order-1"
+	})).Return(nil)
+
+	require.NoError(t, activator.handleSourceEvent(context.Background(), sourceEvent))
+	require.NotNil(t, saved)
+
+	require.NotEmpty(t, saved.TriggerDataRef, "execution context holds a reference to the trigger data")
+	assert.Nil(t, saved.TriggerData)
+
+	blob := filepath.Join(storeDir, filepath.FromSlash(saved.TriggerDataRef))
+	require.FileExists(t, blob)
+
+	// Templates resolve the reference transparently
+	saved.ResolvePayload = offloader.Resolver(context.Background(), saved.ID)
+
+	orderID, err := template.RenderWithContext("{{ .trigger_data.order_id }}", saved)
+	require.NoError(t, err)
+	assert.Equal(t, "order-1", orderID)
+
+	// Deleting the execution removes its offloaded payloads
+	executionService := workflow.NewExecutionService(mockPersistence, mockEventBus, nil)
+	executionService.ConfigurePayloadOffloading(offloader)
+
+	require.NoError(t, executionService.DeleteExecution(context.Background(), "event-123"))
+	assert.NoFileExists(t, blob)
+
+	mockPersistence.GetMockExecutionContextRepository().AssertExpectations(t)
+	mockEventBus.AssertExpectations(t)
+}
+
+func TestActivator_HandleSourceEvent_CarriesOrderingKey(t *testing.T) {
+	activator, mockPersistence, mockEventBus, _ := createTestActivator()
+	triggerMatches := createTestTriggerNodeMatches("workflow-123", "trigger-123", "source-123")
+
+	mockPersistence.GetMockNodeRepository().On("FindTriggerNodesBySourceEventAndProvider", mock.Anything, "source-123", "ScheduleDue", "scheduler", models.WorkflowStatusPublished).Return(triggerMatches, nil)
+	mockPersistence.GetMockWorkflowRepository().On("GetByID", mock.Anything, "workflow-123").Return(&models.Workflow{ID: "workflow-123"}, nil)
+
+	var saved []*models.ExecutionContext
+
+	mockPersistence.GetMockExecutionContextRepository().On("SaveExecutionContext", mock.Anything, mock.AnythingOfType("*models.ExecutionContext")).
+		Run(func(args mock.Arguments) { saved = append(saved, args.Get(1).(*models.ExecutionContext)) }).
+		Return(nil)
+	mockPersistence.GetMockExecutionContextRepository().On("AddPendingActivations", mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
+	mockEventBus.On("GenerateID", mock.Anything).Return("event-id")
+	mockEventBus.On("Publish", mock.Anything, mock.Anything, mock.AnythingOfType("events.NodeActivation")).Return(nil)
+
+	for _, key := range []string{"customer-7", "customer-7", "customer-9"} {
+		sourceEvent := createTestSourceEvent()
+		sourceEvent.OrderingKey = key
+
+		require.NoError(t, activator.handleSourceEvent(context.Background(), sourceEvent))
+	}
+
+	var keys []string
+
+	for _, call := range mockEventBus.Calls {
+		if call.Method != "Publish" {
+			continue
+		}
+
+		keys = append(keys, call.Arguments.Get(2).(events.NodeActivation).OrderingKey)
+	}
+
+	// Events with the same key lead to activations sharing it, different keys stay distinct
+	assert.Equal(t, []string{"customer-7", "customer-7", "customer-9"}, keys)
+
+	require.Len(t, saved, 3)
+	assert.Equal(t, "customer-7", saved[0].OrderingKey)
+	assert.Equal(t, "customer-9", saved[2].OrderingKey)
+}
+
+func TestActivator_HandleSourceEvent_RoutesHighPriorityWorkflows(t *testing.T) {
+	activator, mockPersistence, mockEventBus, _ := createTestActivator()
+
+	mockPersistence.GetMockNodeRepository().On("FindTriggerNodesBySourceEventAndProvider", mock.Anything, "source-123", "ScheduleDue", "scheduler", models.WorkflowStatusPublished).
+		Return(append(createTestTriggerNodeMatches("workflow-urgent", "trigger-urgent", "source-123"),
+			createTestTriggerNodeMatches("workflow-bulk", "trigger-bulk", "source-123")...), nil)
+	mockPersistence.GetMockWorkflowRepository().On("GetByID", mock.Anything, "workflow-urgent").
+		Return(&models.Workflow{ID: "workflow-urgent", Metadata: map[string]any{models.PriorityMetadataKey: 10.0}}, nil)
+	mockPersistence.GetMockWorkflowRepository().On("GetByID", mock.Anything, "workflow-bulk").
+		Return(&models.Workflow{ID: "workflow-bulk"}, nil)
+	mockPersistence.GetMockExecutionContextRepository().On("SaveExecutionContext", mock.Anything, mock.AnythingOfType("*models.ExecutionContext")).Return(nil)
+	mockPersistence.GetMockExecutionContextRepository().On("AddPendingActivations", mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
+	mockEventBus.On("GenerateID", mock.Anything).Return("event-id")
+	mockEventBus.On("Publish", mock.Anything, mock.Anything, mock.AnythingOfType("events.NodeActivation")).Return(nil)
+
+	require.NoError(t, activator.handleSourceEvent(context.Background(), createTestSourceEvent()))
+
+	topics := make(map[string]string)
+
+	for _, call := range mockEventBus.Calls {
+		if call.Method != "Publish" {
+			continue
+		}
+
+		activation := call.Arguments.Get(2).(events.NodeActivation)
+		topics[activation.WorkflowID] = eventbus.TopicFor(activation, 5)
+	}
+
+	// The urgent workflow's activation goes to the high-priority topic, the bulk one stays on the events topic
+	assert.Equal(t, map[string]string{
+		"workflow-urgent": events.HighPriorityTopic,
+		"workflow-bulk":   events.Topic,
+	}, topics)
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/template"
+)
+
+// ErrInvalidDebounce is returned when the debounce configuration of a trigger node is invalid.
+var ErrInvalidDebounce = errors.New("invalid debounce configuration")
+
+// debounceClaimLease is how long the replica activating a debounce holds its claim: another
+// replica activates the debounce once the claim lapsed without the debounce being deleted.
+const debounceClaimLease = time.Minute
+
+// debounceSettings is the debounce configuration of a trigger node, set as
+// {"debounce": {"interval": "30s", "key": "{{.trigger_data.path}}"}} in its config: the node
+// is activated once no event with the same key arrived for the interval.
+type debounceSettings struct {
+	interval time.Duration
+	key      string
+}
+
+// triggerDebounce returns the debounce settings of a trigger node, or nil when its events
+// activate it right away.
+func triggerDebounce(node *models.WorkflowNode) (*debounceSettings, error) {
+	value, exists := node.Config["debounce"]
+	if !exists || value == nil {
+		return nil, nil //nolint:nilnil // no debounce configured
+	}
+
+	config, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: debounce must be an object", ErrInvalidDebounce)
+	}
+
+	rawInterval, _ := config["interval"].(string)
+
+	interval, err := time.ParseDuration(rawInterval)
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("%w: interval '%s' must be a positive duration such as '30s'", ErrInvalidDebounce, rawInterval)
+	}
+
+	key, ok := config["key"].(string)
+	if _, exists := config["key"]; exists && !ok {
+		return nil, fmt.Errorf("%w: key must be a template string", ErrInvalidDebounce)
+	}
+
+	if _, err := template.Parse(key); err != nil {
+		return nil, fmt.Errorf("%w: key: %w", ErrInvalidDebounce, err)
+	}
+
+	return &debounceSettings{interval: interval, key: key}, nil
+}
+
+// renderKey renders the debounce key template with the event data as .trigger_data. Without a
+// key template, every event of the trigger node shares one debounce.
+func (s *debounceSettings) renderKey(eventData map[string]any) (string, error) {
+	if s.key == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.Parse(s.key)
+	if err != nil {
+		return "", err
+	}
+
+	var key strings.Builder
+	if err := tmpl.Execute(&key, map[string]any{"trigger_data": eventData}); err != nil {
+		return "", fmt.Errorf("failed to render debounce key: %w", err)
+	}
+
+	return key.String(), nil
+}
+
+// debouncer holds back the activations of debounced trigger nodes. Each event restarts the
+// timer of its key; when a timer elapses the trigger node is activated once with the data of
+// the latest event. Pending debounces are persisted, so they survive a restart, and claimed
+// before they fire, so a single activator replica activates each.
+type debouncer struct {
+	repository persistence.DebounceRepository
+	activate   func(ctx context.Context, pending *models.PendingDebounce) error
+	logger     *slog.Logger
+
+	mu      sync.Mutex
+	ctx     context.Context //nolint:containedctx // timers activate nodes under the activator's context
+	pending map[string]*models.PendingDebounce
+	timers  map[string]*time.Timer
+}
+
+func newDebouncer(
+	repository persistence.DebounceRepository,
+	activate func(ctx context.Context, pending *models.PendingDebounce) error,
+	logger *slog.Logger,
+) *debouncer {
+	return &debouncer{
+		repository: repository,
+		activate:   activate,
+		logger:     logger,
+		pending:    make(map[string]*models.PendingDebounce),
+		timers:     make(map[string]*time.Timer),
+	}
+}
+
+// restore resumes the timers of the persisted debounces accepted by owns, firing the overdue
+// ones right away, and stops all timers when ctx is cancelled.
+func (d *debouncer) restore(ctx context.Context, owns func(workflowID string) bool) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.ctx = ctx
+
+	go func() {
+		<-ctx.Done()
+		d.stop()
+	}()
+
+	persisted, err := d.repository.GetPendingDebounces(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load pending debounces: %w", err)
+	}
+
+	for _, pending := range persisted {
+		if !owns(pending.WorkflowID) {
+			continue
+		}
+
+		d.pending[pending.ID] = pending
+		d.schedule(pending)
+	}
+
+	if len(d.pending) > 0 {
+		d.logger.Info("Restored pending debounces", "count", len(d.pending))
+	}
+
+	return nil
+}
+
+// add records an event for the trigger node and key, replacing the event data of the pending
+// debounce and pushing back its activation by interval.
+func (d *debouncer) add(ctx context.Context, workflowID, triggerNodeID, key string, interval time.Duration, eventData map[string]any, correlationID string) (*models.PendingDebounce, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	id := workflowID + ":" + triggerNodeID + ":" + key
+	now := time.Now().UTC()
+
+	pending := &models.PendingDebounce{
+		ID:            id,
+		WorkflowID:    workflowID,
+		TriggerNodeID: triggerNodeID,
+		Key:           key,
+		EventData:     eventData,
+		CorrelationID: correlationID,
+		EventCount:    1,
+		FireAt:        now.Add(interval),
+		UpdatedAt:     now,
+	}
+
+	if existing, ok := d.pending[id]; ok {
+		pending.EventCount = existing.EventCount + 1
+	}
+
+	if err := d.repository.SavePendingDebounce(ctx, pending); err != nil {
+		return nil, fmt.Errorf("failed to save pending debounce: %w", err)
+	}
+
+	d.pending[id] = pending
+	d.schedule(pending)
+
+	return pending, nil
+}
+
+// schedule (re)starts the timer of a pending debounce. d.mu must be held.
+func (d *debouncer) schedule(pending *models.PendingDebounce) {
+	if timer, ok := d.timers[pending.ID]; ok {
+		timer.Stop()
+	}
+
+	id := pending.ID
+	d.timers[id] = time.AfterFunc(time.Until(pending.FireAt), func() { d.fire(id) })
+}
+
+// fire activates the trigger node of a pending debounce whose timer elapsed. Every replica that
+// received an event for the debounce, or restored it, holds a timer for it, so the replica first
+// claims the debounce in the persistence: only the one whose claim succeeds activates it, with the
+// latest event data stored by any replica. Others retry once the stored debounce is due again or
+// its claim lapses, in case the replica holding it stops first. The debounce is only deleted once
+// the activation is published, so a failed one is retried after its claim lapses.
+func (d *debouncer) fire(id string) {
+	d.mu.Lock()
+
+	pending, ok := d.pending[id]
+	if !ok || time.Now().Before(pending.FireAt) {
+		// Reset by a newer event after this timer had already started
+		d.mu.Unlock()
+
+		return
+	}
+
+	ctx := d.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if ctx.Err() != nil {
+		d.mu.Unlock()
+
+		return
+	}
+
+	delete(d.pending, id)
+	delete(d.timers, id)
+	d.mu.Unlock()
+
+	logger := d.logger.With(
+		"workflow_id", pending.WorkflowID,
+		"trigger_node_id", pending.TriggerNodeID,
+		"debounce_key", pending.Key,
+	)
+
+	now := time.Now().UTC()
+
+	stored, claimed, err := d.repository.ClaimPendingDebounce(ctx, id, now, debounceClaimLease)
+	if err != nil {
+		logger.Error("Failed to claim pending debounce", "error", err)
+		d.retry(pending, now.Add(debounceClaimLease))
+
+		return
+	}
+
+	if !claimed {
+		if stored != nil {
+			d.retry(stored, retryAt(stored))
+		}
+
+		return
+	}
+
+	logger = logger.With("event_count", stored.EventCount)
+
+	if err := d.activate(ctx, stored); err != nil {
+		logger.Error("Failed to activate debounced trigger node", "error", err)
+		d.retry(stored, retryAt(stored))
+
+		return
+	}
+
+	// A newer event arriving during the activation pushed back the debounce, which is kept
+	if err := d.repository.DeletePendingDebounce(ctx, id, stored.FireAt); err != nil {
+		logger.Error("Failed to delete pending debounce", "error", err)
+	}
+}
+
+// retry schedules the timer of a debounce again at the given time, unless a newer event restarted
+// it meanwhile.
+func (d *debouncer) retry(pending *models.PendingDebounce, at time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, restarted := d.pending[pending.ID]; restarted || (d.ctx != nil && d.ctx.Err() != nil) {
+		return
+	}
+
+	retried := *pending
+	retried.FireAt = at
+
+	d.pending[pending.ID] = &retried
+	d.schedule(&retried)
+}
+
+// retryAt returns when a stored debounce can be claimed: once it is due and its claim lapsed.
+func retryAt(stored *models.PendingDebounce) time.Time {
+	if stored.ClaimedUntil != nil && stored.ClaimedUntil.After(stored.FireAt) {
+		return *stored.ClaimedUntil
+	}
+
+	return stored.FireAt
+}
+
+// stop stops all timers, leaving their debounces persisted for the next start.
+func (d *debouncer) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id, timer := range d.timers {
+		timer.Stop()
+		delete(d.timers, id)
+		delete(d.pending, id)
+	}
+}
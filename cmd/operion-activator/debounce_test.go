@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/mocks"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// publishedActivations records the NodeActivation events published through a mock event bus.
+type publishedActivations struct {
+	mu          sync.Mutex
+	activations []events.NodeActivation
+}
+
+func (p *publishedActivations) all() []events.NodeActivation {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]events.NodeActivation(nil), p.activations...)
+}
+
+// createDebounceActivator creates an activator on file persistence holding a published workflow
+// whose trigger node debounces file events by path.
+func createDebounceActivator(t *testing.T, root string, interval string) (*Activator, persistence.Persistence, *publishedActivations) {
+	t.Helper()
+
+	p := file.NewPersistence(root)
+	sourceID, providerID, eventType := "source-files", "filewatch", "FileSaved"
+
+	workflow := &models.Workflow{
+		ID:     "workflow-debounce",
+		Name:   "Process saved files",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:         "on-save",
+				Name:       "On save",
+				Type:       "trigger:webhook",
+				Category:   models.CategoryTypeTrigger,
+				SourceID:   &sourceID,
+				ProviderID: &providerID,
+				EventType:  &eventType,
+				Enabled:    true,
+				Config: map[string]any{
+					"debounce": map[string]any{"interval": interval, "key": "{{.trigger_data.path}}"},
+				},
+			},
+		},
+	}
+	require.NoError(t, p.WorkflowRepository().Save(t.Context(), workflow))
+
+	published := &publishedActivations{}
+	eventBus := &mocks.MockEventBus{}
+	eventBus.On("GenerateID", mock.Anything).Return("id")
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.AnythingOfType("events.NodeActivation")).
+		Run(func(args mock.Arguments) {
+			published.mu.Lock()
+			defer published.mu.Unlock()
+
+			published.activations = append(published.activations, args.Get(2).(events.NodeActivation))
+		}).
+		Return(nil)
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	activator := NewActivator("test-activator", p, eventBus, &mocks.MockSourceEventBus{}, logger)
+
+	return activator, p, published
+}
+
+func fileSavedEvent(path string, revision int) *events.SourceEvent {
+	return &events.SourceEvent{
+		SourceID:   "source-files",
+		ProviderID: "filewatch",
+		EventType:  "FileSaved",
+		EventData:  map[string]any{"path": path, "revision": revision},
+	}
+}
+
+func TestActivator_Debounce_OneActivationAfterEventsQuietDown(t *testing.T) {
+	activator, p, published := createDebounceActivator(t, t.TempDir(), "150ms")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	require.NoError(t, activator.debounces.restore(ctx, activator.ownsWorkflow))
+
+	for revision := 1; revision <= 3; revision++ {
+		require.NoError(t, activator.handleSourceEvent(ctx, fileSavedEvent("report.csv", revision)))
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	assert.Empty(t, published.all(), "no activation while events keep arriving")
+
+	require.Eventually(t, func() bool { return len(published.all()) > 0 }, 2*time.Second, 10*time.Millisecond)
+
+	// Nothing else fires once the window elapsed
+	time.Sleep(300 * time.Millisecond)
+
+	activations := published.all()
+	require.Len(t, activations, 1)
+	assert.Equal(t, "on-save", activations[0].NodeID)
+	assert.Equal(t, "workflow-debounce", activations[0].WorkflowID)
+	assert.Equal(t, map[string]any{"path": "report.csv", "revision": float64(3)}, activations[0].InputData,
+		"the latest event, as stored, is activated")
+
+	pending, err := p.DebounceRepository().GetPendingDebounces(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestActivator_Debounce_KeysDebounceSeparately(t *testing.T) {
+	activator, _, published := createDebounceActivator(t, t.TempDir(), "100ms")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	require.NoError(t, activator.debounces.restore(ctx, activator.ownsWorkflow))
+
+	require.NoError(t, activator.handleSourceEvent(ctx, fileSavedEvent("a.csv", 1)))
+	require.NoError(t, activator.handleSourceEvent(ctx, fileSavedEvent("b.csv", 1)))
+	require.NoError(t, activator.handleSourceEvent(ctx, fileSavedEvent("a.csv", 2)))
+
+	require.Eventually(t, func() bool { return len(published.all()) == 2 }, 2*time.Second, 10*time.Millisecond)
+
+	paths := []any{}
+	for _, activation := range published.all() {
+		paths = append(paths, activation.InputData.(map[string]any)["path"])
+	}
+
+	assert.ElementsMatch(t, []any{"a.csv", "b.csv"}, paths)
+}
+
+func TestActivator_Debounce_PendingSurvivesRestart(t *testing.T) {
+	root := t.TempDir()
+	activator, p, published := createDebounceActivator(t, root, "200ms")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	require.NoError(t, activator.debounces.restore(ctx, activator.ownsWorkflow))
+
+	require.NoError(t, activator.handleSourceEvent(ctx, fileSavedEvent("report.csv", 1)))
+	require.NoError(t, activator.handleSourceEvent(ctx, fileSavedEvent("report.csv", 2)))
+
+	// Shut down before the window elapses
+	cancel()
+	time.Sleep(300 * time.Millisecond)
+	assert.Empty(t, published.all())
+
+	pending, err := p.DebounceRepository().GetPendingDebounces(t.Context())
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, 2, pending[0].EventCount)
+
+	restarted, _, republished := createDebounceActivator(t, root, "200ms")
+	require.NoError(t, restarted.debounces.restore(t.Context(), restarted.ownsWorkflow))
+
+	require.Eventually(t, func() bool { return len(republished.all()) == 1 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, float64(2), republished.all()[0].InputData.(map[string]any)["revision"])
+
+	pending, err = p.DebounceRepository().GetPendingDebounces(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestActivator_Debounce_SingleReplicaActivates(t *testing.T) {
+	root := t.TempDir()
+	first, p, firstPublished := createDebounceActivator(t, root, "150ms")
+	second, _, secondPublished := createDebounceActivator(t, root, "150ms")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	require.NoError(t, first.debounces.restore(ctx, first.ownsWorkflow))
+	require.NoError(t, second.debounces.restore(ctx, second.ownsWorkflow))
+
+	// Each replica receives an event for the same key and starts its own timer
+	require.NoError(t, first.handleSourceEvent(ctx, fileSavedEvent("report.csv", 1)))
+	require.NoError(t, second.handleSourceEvent(ctx, fileSavedEvent("report.csv", 2)))
+
+	activations := func() []events.NodeActivation {
+		return append(firstPublished.all(), secondPublished.all()...)
+	}
+
+	require.Eventually(t, func() bool { return len(activations()) > 0 }, 2*time.Second, 10*time.Millisecond)
+
+	// The other timer elapsing does not activate the debounce again
+	time.Sleep(300 * time.Millisecond)
+
+	require.Len(t, activations(), 1)
+	assert.Equal(t, map[string]any{"path": "report.csv", "revision": float64(2)}, activations()[0].InputData,
+		"the latest event stored by any replica is activated")
+
+	pending, err := p.DebounceRepository().GetPendingDebounces(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestTriggerDebounce_Config(t *testing.T) {
+	settings, err := triggerDebounce(&models.WorkflowNode{Config: map[string]any{}})
+	require.NoError(t, err)
+	assert.Nil(t, settings)
+
+	settings, err = triggerDebounce(&models.WorkflowNode{Config: map[string]any{
+		"debounce": map[string]any{"interval": "30s"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, settings.interval)
+
+	key, err := settings.renderKey(map[string]any{"path": "a.csv"})
+	require.NoError(t, err)
+	assert.Empty(t, key)
+
+	for _, config := range []any{
+		"30s",
+		map[string]any{},
+		map[string]any{"interval": "soon"},
+		map[string]any{"interval": "-1s"},
+		map[string]any{"interval": "1s", "key": 42},
+		map[string]any{"interval": "1s", "key": "{{.trigger_data.path"},
+	} {
+		_, err := triggerDebounce(&models.WorkflowNode{Config: map[string]any{"debounce": config}})
+		assert.ErrorIs(t, err, ErrInvalidDebounce, "config %v", config)
+	}
+}
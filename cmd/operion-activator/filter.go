@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+	"github.com/dukex/operion/pkg/workflow"
+)
+
+// ErrInvalidFilter is returned when the filter expression of a trigger node is invalid.
+var ErrInvalidFilter = errors.New("invalid trigger filter")
+
+// triggerFilter returns the filter expression of a trigger node, set as
+// {"filter": "{{ gt .trigger_data.total 100.0 }}"} in its config, or "" when every event
+// activates it.
+func triggerFilter(node *models.WorkflowNode) (string, error) {
+	value, exists := node.Config["filter"]
+	if !exists || value == nil {
+		return "", nil
+	}
+
+	filter, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: filter must be a template string", ErrInvalidFilter)
+	}
+
+	if _, err := template.Parse(filter); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+
+	return filter, nil
+}
+
+// eventPassesFilter evaluates a trigger filter with the event data as .trigger_data. An empty
+// filter lets every event through.
+func eventPassesFilter(filter string, eventData map[string]any) (bool, error) {
+	if filter == "" {
+		return true, nil
+	}
+
+	result, err := workflow.TestCondition("", filter, map[string]any{"trigger_data": eventData})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate trigger filter: %w", err)
+	}
+
+	return result.Result, nil
+}
+
+// eventMatchesTrigger reports whether the filter of a matched trigger node lets the source event
+// activate it. Events are skipped when the filter is invalid or fails to evaluate.
+func (a *Activator) eventMatchesTrigger(logger *slog.Logger, matchInfo *models.TriggerNodeMatch, sourceEvent *events.SourceEvent) bool {
+	logger = logger.With("workflow_id", matchInfo.WorkflowID, "trigger_node_id", matchInfo.TriggerNode.ID)
+
+	filter, err := triggerFilter(matchInfo.TriggerNode)
+	if err != nil {
+		logger.Error("Skipping trigger with invalid filter", "error", err)
+
+		return false
+	}
+
+	passes, err := eventPassesFilter(filter, sourceEvent.EventData)
+	if err != nil {
+		logger.Error("Skipping trigger whose filter failed", "error", err)
+
+		return false
+	}
+
+	if !passes {
+		logger.Info("Event filtered out by trigger filter")
+	}
+
+	return passes
+}
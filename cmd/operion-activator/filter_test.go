@@ -0,0 +1,111 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/mocks"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// createFilterActivator creates an activator on file persistence holding a published workflow
+// whose trigger node only handles the orders matching filter.
+func createFilterActivator(t *testing.T, filter any) (*Activator, *publishedActivations) {
+	t.Helper()
+
+	p := file.NewPersistence(t.TempDir())
+	sourceID, providerID, eventType := "source-orders", "webhook", "OrderPlaced"
+
+	workflow := &models.Workflow{
+		ID:     "workflow-large-orders",
+		Name:   "Review large orders",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:         "on-order",
+				Name:       "On order",
+				Type:       "trigger:webhook",
+				Category:   models.CategoryTypeTrigger,
+				SourceID:   &sourceID,
+				ProviderID: &providerID,
+				EventType:  &eventType,
+				Enabled:    true,
+				Config:     map[string]any{"filter": filter},
+			},
+		},
+	}
+	require.NoError(t, p.WorkflowRepository().Save(t.Context(), workflow))
+
+	published := &publishedActivations{}
+	eventBus := &mocks.MockEventBus{}
+	eventBus.On("GenerateID", mock.Anything).Return("id")
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.AnythingOfType("events.NodeActivation")).
+		Run(func(args mock.Arguments) {
+			published.mu.Lock()
+			defer published.mu.Unlock()
+
+			published.activations = append(published.activations, args.Get(2).(events.NodeActivation))
+		}).
+		Return(nil)
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	return NewActivator("test-activator", p, eventBus, &mocks.MockSourceEventBus{}, logger), published
+}
+
+func orderPlacedEvent(total float64) *events.SourceEvent {
+	return &events.SourceEvent{
+		SourceID:   "source-orders",
+		ProviderID: "webhook",
+		EventType:  "OrderPlaced",
+		EventData:  map[string]any{"total": total},
+	}
+}
+
+func TestActivator_Filter_OnlyMatchingEventsActivate(t *testing.T) {
+	activator, published := createFilterActivator(t, "{{ gt .trigger_data.total 100.0 }}")
+
+	for _, total := range []float64{50, 150, 100, 250} {
+		require.NoError(t, activator.handleSourceEvent(t.Context(), orderPlacedEvent(total)))
+	}
+
+	activations := published.all()
+	require.Len(t, activations, 2)
+	assert.Equal(t, "on-order", activations[0].NodeID)
+	assert.Equal(t, map[string]any{"total": 150.0}, activations[0].InputData)
+	assert.Equal(t, map[string]any{"total": 250.0}, activations[1].InputData)
+}
+
+func TestActivator_Filter_InvalidFilterSkipsTrigger(t *testing.T) {
+	for name, filter := range map[string]any{
+		"not a string":     true,
+		"unparseable":      "{{ gt .trigger_data.total",
+		"evaluation error": `{{ gt .trigger_data.total "100" }}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			activator, published := createFilterActivator(t, filter)
+
+			require.NoError(t, activator.handleSourceEvent(t.Context(), orderPlacedEvent(150)))
+			assert.Empty(t, published.all())
+		})
+	}
+}
+
+func TestTriggerFilter(t *testing.T) {
+	filter, err := triggerFilter(&models.WorkflowNode{Config: map[string]any{}})
+	require.NoError(t, err)
+	assert.Empty(t, filter)
+
+	_, err = triggerFilter(&models.WorkflowNode{Config: map[string]any{"filter": 42}})
+	require.ErrorIs(t, err, ErrInvalidFilter)
+
+	passes, err := eventPassesFilter("", map[string]any{})
+	require.NoError(t, err)
+	assert.True(t, passes)
+}
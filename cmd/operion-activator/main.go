@@ -8,6 +8,7 @@ import (
 
 	"github.com/dukex/operion/pkg/cmd"
 	"github.com/dukex/operion/pkg/log"
+	"github.com/dukex/operion/pkg/payloads"
 	trc "github.com/dukex/operion/pkg/tracer"
 	"github.com/google/uuid"
 	cli "github.com/urfave/cli/v3"
@@ -41,6 +42,29 @@ func main() {
 				Required: true,
 				Sources:  cli.EnvVars("EVENT_BUS_TYPE"),
 			},
+			&cli.IntFlag{
+				Name:    "shard-index",
+				Usage:   "Shard handled by this activator, from 0 to shard-count - 1",
+				Value:   0,
+				Sources: cli.EnvVars("ACTIVATOR_SHARD_INDEX"),
+			},
+			&cli.IntFlag{
+				Name:    "shard-count",
+				Usage:   "Number of activators sharing source events; each workflow is handled by one shard",
+				Value:   1,
+				Sources: cli.EnvVars("ACTIVATOR_SHARD_COUNT"),
+			},
+			&cli.StringFlag{
+				Name:    "payload-store",
+				Usage:   "Object store holding large execution payloads (file:///dir or s3://bucket/prefix), empty to store them inline",
+				Sources: cli.EnvVars("PAYLOAD_STORE"),
+			},
+			&cli.IntFlag{
+				Name:    "payload-offload-threshold",
+				Usage:   "Size in bytes of JSON above which trigger data and node results are offloaded to the payload store",
+				Value:   payloads.DefaultThreshold,
+				Sources: cli.EnvVars("PAYLOAD_OFFLOAD_THRESHOLD"),
+			},
 			&cli.StringFlag{
 				Name:    "log-level",
 				Usage:   "Log level (debug, info, warn, error)",
@@ -61,6 +85,16 @@ func main() {
 				}
 			}()
 
+			meterProvider, err := trc.InitMeter(ctx, "operion-activator")
+			if err != nil {
+				return fmt.Errorf("failed to initialize meter: %w", err)
+			}
+			defer func() {
+				if err := meterProvider.Shutdown(ctx); err != nil {
+					slog.Error("Failed to shutdown meter provider", "error", err)
+				}
+			}()
+
 			activatorID := command.String("activator-id")
 			if activatorID == "" {
 				activatorID = "activator-" + uuid.New().String()[:8]
@@ -94,6 +128,11 @@ func main() {
 				}
 			}()
 
+			offloader, err := cmd.NewPayloadOffloader(ctx, command.String("payload-store"), command.Int("payload-offload-threshold"))
+			if err != nil {
+				return err
+			}
+
 			activator := NewActivator(
 				activatorID,
 				persistence,
@@ -102,6 +141,12 @@ func main() {
 				logger,
 			)
 
+			if err := activator.ConfigureSharding(command.Int("shard-index"), command.Int("shard-count")); err != nil {
+				return err
+			}
+
+			activator.ConfigurePayloadOffloading(offloader)
+
 			activator.Start(ctx)
 
 			return nil
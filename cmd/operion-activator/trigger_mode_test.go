@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/mocks"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// createTriggerModeActivator creates an activator on file persistence holding a published workflow
+// in mode with two trigger nodes, one on orders placed through a webhook and one on a schedule.
+func createTriggerModeActivator(t *testing.T, mode models.TriggerMode) (*Activator, persistence.Persistence, *publishedActivations) {
+	t.Helper()
+
+	p := file.NewPersistence(t.TempDir())
+	trigger := func(id, sourceID, providerID, eventType string) *models.WorkflowNode {
+		return &models.WorkflowNode{
+			ID:         id,
+			Name:       id,
+			Type:       "trigger:" + providerID,
+			Category:   models.CategoryTypeTrigger,
+			SourceID:   &sourceID,
+			ProviderID: &providerID,
+			EventType:  &eventType,
+			Enabled:    true,
+		}
+	}
+
+	workflow := &models.Workflow{
+		ID:          "workflow-sync",
+		Name:        "Sync orders",
+		Status:      models.WorkflowStatusPublished,
+		TriggerMode: mode,
+		Nodes: []*models.WorkflowNode{
+			trigger("on-order", "source-orders", "webhook", "OrderPlaced"),
+			trigger("every-hour", "source-hourly", "scheduler", "ScheduleDue"),
+		},
+	}
+	require.NoError(t, p.WorkflowRepository().Save(t.Context(), workflow))
+
+	published := &publishedActivations{}
+	eventBus := &mocks.MockEventBus{}
+
+	for i := range 8 {
+		eventBus.On("GenerateID", mock.Anything).Return(fmt.Sprintf("id-%d", i+1)).Once()
+	}
+
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.AnythingOfType("events.NodeActivation")).
+		Run(func(args mock.Arguments) {
+			published.mu.Lock()
+			defer published.mu.Unlock()
+
+			published.activations = append(published.activations, args.Get(2).(events.NodeActivation))
+		}).
+		Return(nil)
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	return NewActivator("test-activator", p, eventBus, &mocks.MockSourceEventBus{}, logger), p, published
+}
+
+// fireBothTriggers sends an order placed event and then a schedule due event.
+func fireBothTriggers(t *testing.T, activator *Activator) {
+	t.Helper()
+
+	require.NoError(t, activator.handleSourceEvent(t.Context(), &events.SourceEvent{
+		SourceID:   "source-orders",
+		ProviderID: "webhook",
+		EventType:  "OrderPlaced",
+		EventData:  map[string]any{"order_id": "o-1"},
+	}))
+	require.NoError(t, activator.handleSourceEvent(t.Context(), &events.SourceEvent{
+		SourceID:   "source-hourly",
+		ProviderID: "scheduler",
+		EventType:  "ScheduleDue",
+		EventData:  map[string]any{"schedule_id": "hourly"},
+	}))
+}
+
+func TestActivator_TriggerMode_IndependentStartsAnExecutionPerTrigger(t *testing.T) {
+	for _, mode := range []models.TriggerMode{"", models.TriggerModeIndependent} {
+		t.Run(string(mode), func(t *testing.T) {
+			activator, p, published := createTriggerModeActivator(t, mode)
+
+			fireBothTriggers(t, activator)
+
+			executions, err := p.ExecutionContextRepository().GetExecutionsByWorkflow(t.Context(), "workflow-sync")
+			require.NoError(t, err)
+			assert.Len(t, executions, 2)
+
+			activations := published.all()
+			require.Len(t, activations, 2)
+			assert.Equal(t, "on-order", activations[0].NodeID)
+			assert.Equal(t, "every-hour", activations[1].NodeID)
+			assert.NotEqual(t, activations[0].ExecutionID, activations[1].ExecutionID)
+		})
+	}
+}
+
+func TestActivator_TriggerMode_SharedLatestJoinsTheRunningExecution(t *testing.T) {
+	activator, p, published := createTriggerModeActivator(t, models.TriggerModeSharedLatest)
+
+	fireBothTriggers(t, activator)
+
+	executions, err := p.ExecutionContextRepository().GetExecutionsByWorkflow(t.Context(), "workflow-sync")
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+
+	shared := executions[0]
+	assert.Equal(t, map[string]any{"schedule_id": "hourly"}, shared.TriggerData, "the latest event replaces the trigger data")
+
+	activations := published.all()
+	require.Len(t, activations, 2)
+	assert.Equal(t, shared.ID, activations[0].ExecutionID)
+	assert.Equal(t, shared.ID, activations[1].ExecutionID)
+	assert.Equal(t, shared.CorrelationID, activations[1].CorrelationID)
+
+	// Once the shared execution ended, the next trigger starts a new one
+	shared.Status = models.ExecutionStatusCompleted
+	require.NoError(t, p.ExecutionContextRepository().SaveExecutionContext(t.Context(), shared))
+
+	fireBothTriggers(t, activator)
+
+	executions, err = p.ExecutionContextRepository().GetExecutionsByWorkflow(t.Context(), "workflow-sync")
+	require.NoError(t, err)
+	assert.Len(t, executions, 2)
+
+	activations = published.all()
+	require.Len(t, activations, 4)
+	assert.NotEqual(t, shared.ID, activations[2].ExecutionID)
+	assert.Equal(t, activations[2].ExecutionID, activations[3].ExecutionID)
+}
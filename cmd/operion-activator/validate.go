@@ -114,6 +114,20 @@ func NewValidateCommand() *cli.Command {
 						continue
 					}
 
+					if _, err := triggerFilter(triggerNode); err != nil {
+						_, _ = fmt.Fprintf(os.Stdout, "    ❌ INVALID: %v\n", err)
+						invalidTriggerNodes++
+
+						continue
+					}
+
+					if _, err := triggerDebounce(triggerNode); err != nil {
+						_, _ = fmt.Fprintf(os.Stdout, "    ❌ INVALID: %v\n", err)
+						invalidTriggerNodes++
+
+						continue
+					}
+
 					// TODO: Add validation for source event types once source events are defined
 					// This could validate that the trigger configuration includes valid event types
 					// that the activator can process
@@ -2,9 +2,14 @@
 package main
 
 import (
+	"fmt"
 	"log/slog"
+	"net/url"
 	"strconv"
+	"time"
 
+	"github.com/dukex/operion/pkg/eventbus"
+	"github.com/dukex/operion/pkg/payloads"
 	"github.com/dukex/operion/pkg/persistence"
 	"github.com/dukex/operion/pkg/registry"
 	"github.com/dukex/operion/pkg/web"
@@ -19,30 +24,128 @@ import (
 type API struct {
 	logger      *slog.Logger
 	persistence persistence.Persistence
+	eventBus    eventbus.EventBus
 	registry    *registry.Registry
 	validate    *validator.Validate
+	payloads    *payloads.Offloader
+	cors        CORSConfig
+	bodyLimit   int
+	adminToken  string
+
+	streamPollInterval      time.Duration
+	streamHeartbeatInterval time.Duration
 }
 
 func NewAPI(
 	logger *slog.Logger,
 	persistence persistence.Persistence,
+	eventBus eventbus.EventBus,
 	registry *registry.Registry,
 ) *API {
 	return &API{
 		persistence: persistence,
+		eventBus:    eventBus,
 		logger:      logger,
 		registry:    registry,
 		validate:    validator.New(validator.WithRequiredStructEnabled()),
+		bodyLimit:   web.DefaultBodyLimit,
+
+		streamPollInterval:      web.DefaultStreamPollInterval,
+		streamHeartbeatInterval: web.DefaultStreamHeartbeatInterval,
 	}
 }
 
+// ConfigureStreaming sets how often execution streams check for changes and send heartbeats.
+// It must be called before App.
+func (a *API) ConfigureStreaming(pollInterval, heartbeatInterval time.Duration) {
+	a.streamPollInterval = pollInterval
+	a.streamHeartbeatInterval = heartbeatInterval
+}
+
+// ConfigurePayloadOffloading sets the offloader holding the large payloads of executions, deleted
+// with their execution. It must be called before App.
+func (a *API) ConfigurePayloadOffloading(offloader *payloads.Offloader) {
+	a.payloads = offloader
+}
+
+// ConfigureAdminToken sets the bearer token of admin-only requests, such as listing and restoring
+// deleted workflows. It must be called before App.
+func (a *API) ConfigureAdminToken(token string) {
+	a.adminToken = token
+}
+
+// CORSConfig lists the cross-origin requests the API accepts. Without allowed origins every
+// cross-origin request is refused; "*" allows any origin.
+type CORSConfig struct {
+	AllowOrigins []string
+	AllowMethods []string
+	AllowHeaders []string
+}
+
+// ConfigureCORS sets the cross-origin requests the API accepts. It must be called before App.
+func (a *API) ConfigureCORS(config CORSConfig) error {
+	for _, origin := range config.AllowOrigins {
+		if origin == "*" {
+			continue
+		}
+
+		parsed, err := url.Parse(origin)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" ||
+			(parsed.Path != "" && parsed.Path != "/") {
+			return fmt.Errorf("invalid CORS origin '%s', expected scheme://host[:port]", origin)
+		}
+	}
+
+	a.cors = config
+
+	return nil
+}
+
+// ConfigureBodyLimit sets the maximum size in bytes of create and update request bodies; larger
+// ones are rejected with 413. A limit of 0 or less accepts bodies of any size. It must be called
+// before App.
+func (a *API) ConfigureBodyLimit(limit int) {
+	a.bodyLimit = limit
+}
+
+// corsHandler returns the CORS middleware for the configured policy.
+func (a *API) corsHandler() fiber.Handler {
+	config := cors.Config{
+		AllowOrigins: a.cors.AllowOrigins,
+		AllowMethods: a.cors.AllowMethods,
+		AllowHeaders: a.cors.AllowHeaders,
+	}
+
+	// The middleware allows every origin when none is listed
+	if len(config.AllowOrigins) == 0 {
+		config.AllowOriginsFunc = func(string) bool { return false }
+	}
+
+	return cors.New(config)
+}
+
 func (a *API) App() *fiber.App {
 	workflowRepository := workflow.NewRepository(a.persistence)
 
-	handlers := web.NewAPIHandlers(workflowRepository, a.validate, a.registry)
+	executionService := workflow.NewExecutionService(a.persistence, a.eventBus, a.registry)
+	executionService.ConfigurePayloadOffloading(a.payloads)
+
+	nodeService := workflow.NewNodeService(a.persistence, a.registry)
+
+	heartbeatMonitor := workflow.NewHeartbeatMonitor(a.persistence, a.eventBus)
+
+	handlers := web.NewAPIHandlers(workflowRepository, executionService, nodeService, heartbeatMonitor, a.validate, a.registry)
+	handlers.ConfigureStreaming(a.streamPollInterval, a.streamHeartbeatInterval)
+	handlers.ConfigureAdminToken(a.adminToken)
 
-	app := fiber.New()
-	app.Use(cors.New())
+	config := fiber.Config{}
+	if a.bodyLimit > fiber.DefaultBodyLimit {
+		config.BodyLimit = a.bodyLimit
+	}
+
+	app := fiber.New(config)
+	app.Use(a.corsHandler())
+	app.Use(web.BodyLimit(a.bodyLimit))
 	app.Use(logger.New(logger.Config{
 		DisableColors: true,
 	}))
@@ -56,7 +159,16 @@ func (a *API) App() *fiber.App {
 
 	w := app.Group("/workflows")
 	w.Get("/", handlers.GetWorkflows)
+	w.Get("/heartbeats", handlers.GetWorkflowHeartbeats)
 	w.Get("/:id", handlers.GetWorkflow)
+	w.Get("/:id/stats", handlers.GetWorkflowStats)
+	w.Get("/:id/docs", handlers.GetWorkflowDocs)
+	w.Post("/import", handlers.ImportWorkflow)
+	w.Post("/:id/restore", handlers.RestoreWorkflow)
+	w.Post("/:id/nodes", handlers.CreateWorkflowNode)
+	w.Patch("/:id/nodes/:nodeId", handlers.PatchWorkflowNode)
+	w.Get("/:id/nodes/:nodeId/context", handlers.GetNodeTemplateContext)
+	w.Patch("/:id/layout", handlers.PatchWorkflowLayout)
 
 	// 	// w.Post("/", handlers.CreateWorkflow)
 	// 	// w.Patch("/:id", handlers.PatchWorkflow)
@@ -64,6 +176,22 @@ func (a *API) App() *fiber.App {
 	// 	// w.Patch("/:id/steps", handlers.PatchWorkflowSteps)
 	// 	// w.Patch("/:id/triggers", handlers.PatchWorkflowTriggers)
 
+	g := app.Group("/workflow-groups")
+	g.Get("/", handlers.GetWorkflowGroups)
+	g.Get("/:groupId", handlers.GetWorkflowGroup)
+
+	e := app.Group("/executions")
+	e.Get("/compare", handlers.CompareExecutions)
+	e.Get("/:id", handlers.GetExecution)
+	e.Delete("/:id", handlers.DeleteExecution)
+	e.Get("/:id/node-results", handlers.GetExecutionNodeResults)
+	e.Get("/:id/stream", handlers.StreamExecution)
+	e.Post("/:id/resume-from/:nodeId", handlers.ResumeExecutionFromNode)
+	e.Patch("/:id/variables", handlers.PatchExecutionVariables)
+
+	app.Post("/approvals/:token", handlers.DecideApproval)
+	app.Post("/conditions/test", handlers.TestCondition)
+
 	app.Get("/health", handlers.HealthCheck)
 
 	return app
@@ -1,30 +1,46 @@
 package main
 
 import (
+	"bufio"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/dukex/operion/pkg/mocks"
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/payloads"
 	"github.com/dukex/operion/pkg/persistence/file"
 	"github.com/dukex/operion/pkg/registry"
+	"github.com/dukex/operion/pkg/web"
 	"github.com/dukex/operion/pkg/workflow"
 	"github.com/gofiber/fiber/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
 func setupTestApp(tempDir string) *fiber.App {
 	persistence := file.NewPersistence(tempDir)
 
+	eventBus := &mocks.MockEventBus{}
+	eventBus.On("GenerateID", mock.Anything).Return(uuid.New().String())
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
 	app := NewAPI(
 		slog.Default(),
 		persistence,
+		eventBus,
 		registry.NewRegistry(slog.Default()),
 	)
 
@@ -239,6 +255,13 @@ func TestAPI_GetWorkflow_NotFound(t *testing.T) {
 	defer func() { _ = resp.Body.Close() }()
 
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	var errResp web.ErrorResponse
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	assert.Equal(t, web.ErrorCodeNotFound, errResp.Error.Code)
+	assert.Equal(t, "Workflow not found", errResp.Error.Message)
+	assert.Empty(t, errResp.Error.Fields)
 }
 
 func TestAPI_GetWorkflow_InvalidID(t *testing.T) {
@@ -259,19 +282,100 @@ func TestAPI_GetWorkflow_InvalidID(t *testing.T) {
 
 func TestAPI_CORS_Headers(t *testing.T) {
 	t.Parallel()
-	tempDir := t.TempDir()
-	app := setupTestApp(tempDir)
 
-	req := httptest.NewRequest(http.MethodOptions, "/workflows", nil)
+	api := NewAPI(slog.Default(), file.NewPersistence(t.TempDir()), &mocks.MockEventBus{}, registry.NewRegistry(slog.Default()))
+	require.NoError(t, api.ConfigureCORS(CORSConfig{
+		AllowOrigins: []string{"http://localhost:3000"},
+		AllowMethods: []string{http.MethodGet},
+		AllowHeaders: []string{"Content-Type"},
+	}))
+
+	app := api.App()
+
+	preflight := func(origin string) *http.Response {
+		req := httptest.NewRequest(http.MethodOptions, "/workflows", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "GET")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	allowed := preflight("http://localhost:3000")
+	assert.Equal(t, http.StatusNoContent, allowed.StatusCode)
+	assert.Equal(t, "http://localhost:3000", allowed.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET", allowed.Header.Get("Access-Control-Allow-Methods"))
+
+	disallowed := preflight("http://evil.example.com")
+	assert.Empty(t, disallowed.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestAPI_CORS_DefaultRefusesCrossOrigin(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t.TempDir())
+
+	req := httptest.NewRequest(http.MethodGet, "/workflows", nil)
 	req.Header.Set("Origin", "http://localhost:3000")
-	req.Header.Set("Access-Control-Request-Method", "GET")
 	resp, err := app.Test(req)
 	require.NoError(t, err)
 
 	defer func() { _ = resp.Body.Close() }()
 
-	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
-	assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestAPI_ConfigureCORS_InvalidOrigin(t *testing.T) {
+	t.Parallel()
+
+	api := NewAPI(slog.Default(), file.NewPersistence(t.TempDir()), &mocks.MockEventBus{}, registry.NewRegistry(slog.Default()))
+
+	require.Error(t, api.ConfigureCORS(CORSConfig{AllowOrigins: []string{"localhost:3000"}}))
+	require.Error(t, api.ConfigureCORS(CORSConfig{AllowOrigins: []string{"https://example.com/app"}}))
+	require.NoError(t, api.ConfigureCORS(CORSConfig{AllowOrigins: []string{"*", "https://*.example.com"}}))
+}
+
+func TestAPI_BodyLimit(t *testing.T) {
+	t.Parallel()
+
+	api := NewAPI(slog.Default(), file.NewPersistence(t.TempDir()), &mocks.MockEventBus{}, registry.NewRegistry(slog.Default()))
+	api.ConfigureBodyLimit(256)
+
+	app := api.App()
+
+	importWorkflow := func(description string) *http.Response {
+		body := `{"name": "Imported", "description": "` + description + `", "nodes": []}`
+		req := httptest.NewRequest(http.MethodPost, "/workflows/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	assert.Equal(t, http.StatusCreated, importWorkflow("small").StatusCode)
+
+	resp := importWorkflow(strings.Repeat("x", 512))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+	var errResp web.ErrorResponse
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	assert.Equal(t, web.ErrorCodeTooLarge, errResp.Error.Code)
+
+	// Reads are not limited
+	req := httptest.NewRequest(http.MethodGet, "/workflows", nil)
+	getResp, err := app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = getResp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, getResp.StatusCode)
 }
 
 func TestAPI_ContentType_JSON(t *testing.T) {
@@ -480,3 +584,1340 @@ func TestAPI_Integration_WorkflowLifecycle(t *testing.T) {
 }
 
 // Helper function to create string pointers.
+
+func TestAPI_ResumeExecutionFromNode(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	workflow1 := &models.Workflow{
+		ID:     "resume-workflow",
+		Name:   "Resume Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "node1", Name: "First", Type: "log", Category: models.CategoryTypeAction, Enabled: true},
+			{ID: "node2", Name: "Second", Type: "log", Category: models.CategoryTypeAction, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "conn1", SourcePort: "node1:success", TargetPort: "node2:main"},
+		},
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow1))
+
+	execution := &models.ExecutionContext{
+		ID:         "exec-1",
+		WorkflowID: workflow1.ID,
+		Status:     models.ExecutionStatusFailed,
+		NodeResults: map[string]models.NodeResult{
+			models.MakeNodeResultKey("node1", "success"): {NodeID: "node1", Data: map[string]any{}, Status: "success"},
+		},
+	}
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), execution))
+
+	app := setupTestApp(tempDir)
+
+	req := httptest.NewRequest(http.MethodPost, "/executions/exec-1/resume-from/node2", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var resumed models.ExecutionContext
+
+	err = json.NewDecoder(resp.Body).Decode(&resumed)
+	require.NoError(t, err)
+	assert.NotEqual(t, execution.ID, resumed.ID)
+	assert.Equal(t, workflow1.ID, resumed.WorkflowID)
+	assert.Equal(t, "exec-1", resumed.Metadata["resumed_from_execution_id"])
+
+	req = httptest.NewRequest(http.MethodPost, "/executions/missing/resume-from/node2", nil)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPI_ResumeExecutionFromNode_WithoutEventBus(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+
+	app := NewAPI(slog.Default(), file.NewPersistence(tempDir), nil, registry.NewRegistry(slog.Default())).App()
+
+	req := httptest.NewRequest(http.MethodPost, "/executions/exec-1/resume-from/node2", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	// Endpoints not publishing on the event bus are served as usual
+	req = httptest.NewRequest(http.MethodGet, "/workflows", nil)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestAPI_GetExecution(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	completedAt := time.Date(2025, 6, 1, 12, 5, 0, 0, time.UTC)
+	execution := &models.ExecutionContext{
+		ID:         "exec-completed",
+		WorkflowID: "workflow-1",
+		Status:     models.ExecutionStatusCompleted,
+		NodeResults: map[string]models.NodeResult{
+			models.MakeNodeResultKey("fetch", "success"): {NodeID: "fetch", Data: map[string]any{"count": 2.0}, Status: "success"},
+			models.MakeNodeResultKey("log", "success"):   {NodeID: "log", Data: map[string]any{}, Status: "success"},
+		},
+		TriggerData: map[string]any{"webhook": map[string]any{"token": "secret", "ids": []any{1.0, 2.0}}},
+		Variables:   map[string]any{"env": "production"},
+		CreatedAt:   time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		CompletedAt: &completedAt,
+	}
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), execution))
+
+	app := setupTestApp(tempDir)
+
+	get := func(target string) (int, map[string]any) {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+		return resp.StatusCode, body
+	}
+
+	// Full view with every node result
+	status, body := get("/executions/exec-completed")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "completed", body["status"])
+	assert.Equal(t, "2025-06-01T12:05:00Z", body["completed_at"])
+	assert.Len(t, body["node_results"], 2)
+	assert.Equal(t, map[string]any{"env": "production"}, body["variables"])
+	assert.Equal(t, map[string]any{"webhook": map[string]any{"token": "secret", "ids": []any{1.0, 2.0}}}, body["trigger_data"])
+
+	// Lightweight view without the heavy fields
+	status, body = get("/executions/exec-completed?include=none")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "completed", body["status"])
+	assert.NotContains(t, body, "trigger_data")
+	assert.NotContains(t, body, "variables")
+	assert.Nil(t, body["node_results"])
+
+	status, body = get("/executions/exec-completed?include=node_results")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, body["node_results"], 2)
+	assert.NotContains(t, body, "trigger_data")
+
+	// Redacted trigger data keeps its shape
+	_, body = get("/executions/exec-completed?redact_trigger_data=true")
+	assert.Equal(t, map[string]any{"webhook": map[string]any{"token": "[REDACTED]", "ids": []any{"[REDACTED]", "[REDACTED]"}}}, body["trigger_data"])
+
+	status, _ = get("/executions/exec-completed?include=everything")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = get("/executions/missing")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAPI_CompareExecutions(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	run := func(id string, total float64) *models.ExecutionContext {
+		return &models.ExecutionContext{
+			ID:         id,
+			WorkflowID: "workflow-1",
+			Status:     models.ExecutionStatusCompleted,
+			NodeResults: map[string]models.NodeResult{
+				models.MakeNodeResultKey("fetch", "success"): {NodeID: "fetch", Data: map[string]any{"count": 2.0}, Status: "success"},
+				models.MakeNodeResultKey("price", "success"): {NodeID: "price", Data: map[string]any{"total": total}, Status: "success"},
+			},
+			TriggerData: map[string]any{"webhook": map[string]any{"order_id": "42"}},
+			CreatedAt:   time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		}
+	}
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), run("exec-a", 19.9)))
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), run("exec-b", 21.5)))
+
+	app := setupTestApp(tempDir)
+
+	get := func(target string) (int, map[string]any) {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+		return resp.StatusCode, body
+	}
+
+	status, body := get("/executions/compare?a=exec-a&b=exec-b")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, false, body["identical"])
+	assert.Equal(t, []any{map[string]any{
+		"node_id": "price",
+		"differences": []any{
+			map[string]any{"path": "success.data.total", "a": 19.9, "b": 21.5},
+		},
+	}}, body["nodes"])
+	assert.Equal(t, []any{}, body["only_in_a"])
+	assert.Equal(t, []any{}, body["only_in_b"])
+	assert.Equal(t, []any{}, body["trigger_data"])
+
+	status, _ = get("/executions/compare?a=exec-a")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = get("/executions/compare?a=exec-a&b=missing")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAPI_GetExecutionNodeResults(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	nodeResults := make(map[string]models.NodeResult)
+	for i := range 250 {
+		nodeID := fmt.Sprintf("item-%03d", i)
+		result := models.NodeResult{NodeID: nodeID, Data: map[string]any{"index": float64(i)}, Status: string(models.NodeStatusSuccess)}
+
+		if i%50 == 7 {
+			result.Status = string(models.NodeStatusError)
+			result.Error = "item rejected"
+		}
+
+		nodeResults[models.MakeNodeResultKey(nodeID, "success")] = result
+	}
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          "exec-large",
+		WorkflowID:  "workflow-1",
+		Status:      models.ExecutionStatusFailed,
+		NodeResults: nodeResults,
+		CreatedAt:   time.Now().UTC(),
+	}))
+
+	app := setupTestApp(tempDir)
+
+	get := func(target string) (*http.Response, []models.NodeResultEntry) {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, target, nil))
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		var entries []models.NodeResultEntry
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&entries))
+		}
+
+		return resp, entries
+	}
+
+	// Only the failed results
+	resp, failed := get("/executions/exec-large/node-results?status=error")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("X-Next-Cursor"))
+	require.Len(t, failed, 5)
+
+	for i, entry := range failed {
+		assert.Equal(t, fmt.Sprintf("item-%03d", i*50+7), entry.NodeID)
+		assert.Equal(t, "success", entry.Port)
+		assert.Equal(t, "item rejected", entry.Error)
+	}
+
+	// Paging through every result
+	var (
+		seen  []string
+		pages int
+	)
+
+	for target := "/executions/exec-large/node-results?limit=100"; target != ""; pages++ {
+		resp, entries := get(target)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		for _, entry := range entries {
+			seen = append(seen, entry.NodeID)
+		}
+
+		target = ""
+		if cursor := resp.Header.Get("X-Next-Cursor"); cursor != "" {
+			target = "/executions/exec-large/node-results?limit=100&cursor=" + cursor
+		}
+	}
+
+	assert.Equal(t, 3, pages)
+	require.Len(t, seen, 250)
+	assert.True(t, sort.StringsAreSorted(seen))
+	assert.Equal(t, "item-249", seen[249])
+
+	resp, _ = get("/executions/exec-large/node-results?limit=5000")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = get("/executions/exec-large/node-results?cursor=!!")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = get("/executions/missing/node-results")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPI_DeleteExecution(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	storeDir := t.TempDir()
+	offloader := payloads.NewOffloader(payloads.NewFileStore(storeDir), 16)
+
+	triggerDataRef, err := offloader.Offload(t.Context(), "exec-1", "trigger_data", map[string]any{"body": strings.Repeat("x", 64)})
+	require.NoError(t, err)
+	require.NotEmpty(t, triggerDataRef)
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:             "exec-1",
+		WorkflowID:     "workflow-1",
+		Status:         models.ExecutionStatusCompleted,
+		TriggerDataRef: triggerDataRef,
+		CreatedAt:      time.Now().UTC(),
+	}))
+
+	eventBus := &mocks.MockEventBus{}
+	api := NewAPI(slog.Default(), persistence, eventBus, registry.NewRegistry(slog.Default()))
+	api.ConfigurePayloadOffloading(offloader)
+
+	app := api.App()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/executions/exec-1", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	_, err = persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-1")
+	require.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(storeDir, filepath.FromSlash(triggerDataRef)))
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodDelete, "/executions/exec-1", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPI_GetWorkflows_Paginated(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	repo := workflow.NewRepository(persistence)
+	for _, name := range []string{"First", "Second", "Third"} {
+		_, err := repo.Create(t.Context(), &models.Workflow{Name: name})
+		require.NoError(t, err)
+	}
+
+	app := setupTestApp(tempDir)
+
+	req := httptest.NewRequest(http.MethodGet, "/workflows?limit=2", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var firstPage models.WorkflowPage
+
+	err = json.NewDecoder(resp.Body).Decode(&firstPage)
+	require.NoError(t, err)
+	assert.Len(t, firstPage.Workflows, 2)
+	require.NotEmpty(t, firstPage.NextCursor)
+
+	req = httptest.NewRequest(http.MethodGet, "/workflows?limit=2&cursor="+firstPage.NextCursor, nil)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	var secondPage models.WorkflowPage
+
+	err = json.NewDecoder(resp.Body).Decode(&secondPage)
+	require.NoError(t, err)
+	require.Len(t, secondPage.Workflows, 1)
+	assert.Empty(t, secondPage.NextCursor)
+	assert.NotEqual(t, firstPage.Workflows[0].ID, secondPage.Workflows[0].ID)
+	assert.NotEqual(t, firstPage.Workflows[1].ID, secondPage.Workflows[0].ID)
+
+	req = httptest.NewRequest(http.MethodGet, "/workflows?limit=2&cursor=invalid", nil)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAPI_GetWorkflowStats(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), &models.Workflow{
+		ID:   "stats-workflow",
+		Name: "Stats Workflow",
+	}))
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	durations := map[string]time.Duration{"exec-1": 100 * time.Millisecond, "exec-2": 300 * time.Millisecond}
+
+	for id, duration := range durations {
+		completedAt := from.Add(time.Hour + duration)
+		require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+			ID:          id,
+			WorkflowID:  "stats-workflow",
+			Status:      models.ExecutionStatusCompleted,
+			CreatedAt:   from.Add(time.Hour),
+			CompletedAt: &completedAt,
+		}))
+	}
+
+	app := setupTestApp(tempDir)
+
+	req := httptest.NewRequest(http.MethodGet, "/workflows/stats-workflow/stats?from=2025-01-01T00:00:00Z&to=2025-01-01T02:00:00Z", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var stats models.ExecutionStats
+
+	err = json.NewDecoder(resp.Body).Decode(&stats)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Total)
+	assert.InDelta(t, 1.0, stats.SuccessRate, 0.0001)
+	assert.InDelta(t, 200, stats.DurationP50Ms, 0.0001)
+	assert.InDelta(t, 1, stats.ThroughputPerHour, 0.0001)
+
+	req = httptest.NewRequest(http.MethodGet, "/workflows/stats-workflow/stats?from=yesterday", nil)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	req = httptest.NewRequest(http.MethodGet, "/workflows/missing/stats", nil)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPI_GetWorkflowDocs(t *testing.T) {
+	t.Parallel()
+	persistence := file.NewPersistence(t.TempDir())
+
+	providerID := "webhook"
+	eventType := "WebhookReceived"
+
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), &models.Workflow{
+		ID:          "docs-workflow",
+		Name:        "Docs Workflow",
+		Description: "Logs every webhook call",
+		Nodes: []*models.WorkflowNode{
+			{
+				ID: "trigger", Name: "Webhook", Type: models.NodeTypeTriggerWebhook, Category: models.CategoryTypeTrigger,
+				Config: map[string]any{"webhook_path": "/calls"}, ProviderID: &providerID, EventType: &eventType, Enabled: true,
+			},
+			{ID: "log", Name: "Log", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "called"}, Enabled: true},
+		},
+		Connections: []*models.Connection{{ID: "c1", SourcePort: "trigger:success", TargetPort: "log:main"}},
+	}))
+
+	reg := registry.NewRegistry(slog.Default())
+	reg.RegisterDefaultNodes()
+
+	app := NewAPI(slog.Default(), persistence, &mocks.MockEventBus{}, reg).App()
+
+	req := httptest.NewRequest(http.MethodGet, "/workflows/docs-workflow/docs", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var docs models.WorkflowDocs
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&docs))
+	require.Len(t, docs.Triggers, 1)
+	assert.Equal(t, "WebhookReceived", docs.Triggers[0].EventType)
+	require.Len(t, docs.Nodes, 1)
+	assert.Equal(t, "log", docs.Nodes[0].Type)
+	require.Len(t, docs.Nodes[0].Inputs, 1)
+	assert.Equal(t, []string{"trigger:success"}, docs.Nodes[0].Inputs[0].Connections)
+	assert.NotEmpty(t, docs.Nodes[0].Outputs)
+
+	req = httptest.NewRequest(http.MethodGet, "/workflows/missing/docs", nil)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPI_GetNodeTemplateContext(t *testing.T) {
+	t.Parallel()
+	persistence := file.NewPersistence(t.TempDir())
+
+	providerID := "webhook"
+
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), &models.Workflow{
+		ID:          "context-workflow",
+		Name:        "Context Workflow",
+		Description: "Fetches and logs every webhook call",
+		Variables:   map[string]any{"channel": "#orders"},
+		Nodes: []*models.WorkflowNode{
+			{
+				ID: "trigger", Type: models.NodeTypeTriggerWebhook, Category: models.CategoryTypeTrigger,
+				Config: map[string]any{"webhook_path": "/calls"}, ProviderID: &providerID, Enabled: true,
+			},
+			{
+				ID: "fetch", Type: "httprequest", Category: models.CategoryTypeAction,
+				Config: map[string]any{"url": "https://api.example.com", "method": "GET"}, Enabled: true,
+			},
+			{ID: "log", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "called"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "c1", SourcePort: "trigger:success", TargetPort: "fetch:main"},
+			{ID: "c2", SourcePort: "fetch:success", TargetPort: "log:main"},
+		},
+	}))
+
+	reg := registry.NewRegistry(slog.Default())
+	reg.RegisterDefaultNodes()
+
+	app := NewAPI(slog.Default(), persistence, &mocks.MockEventBus{}, reg).App()
+
+	req := httptest.NewRequest(http.MethodGet, "/workflows/context-workflow/nodes/fetch/context", nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var templateContext models.TemplateContext
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&templateContext))
+	assert.Equal(t, "fetch", templateContext.NodeID)
+
+	paths := make([]string, 0, len(templateContext.Paths))
+	for _, path := range templateContext.Paths {
+		paths = append(paths, path.Path)
+	}
+
+	assert.Contains(t, paths, "trigger_data.body")
+	assert.Contains(t, paths, "node_results."+models.MakeNodeResultKey("trigger", "success")+".body")
+	assert.Contains(t, paths, "variables.channel")
+	assert.NotContains(t, paths, "node_results."+models.MakeNodeResultKey("fetch", "success")+".status_code",
+		"the node's own outputs are not available to it")
+
+	for _, target := range []string{"/workflows/missing/nodes/fetch/context", "/workflows/context-workflow/nodes/missing/context"} {
+		req = httptest.NewRequest(http.MethodGet, target, nil)
+		resp, err = app.Test(req)
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, target)
+	}
+}
+
+func TestAPI_ImportWorkflow_YAML(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t.TempDir())
+
+	jsonDefinition := `{
+		"name": "Imported workflow",
+		"description": "Logs every event",
+		"nodes": [
+			{"id": "log", "type": "log", "category": "action", "name": "Log", "config": {"message": "hi"}, "enabled": true},
+			{"id": "done", "type": "log", "category": "action", "name": "Done", "config": {"message": "done"}, "enabled": true}
+		],
+		"connections": [{"id": "conn-1", "source_port": "log:success", "target_port": "done:main"}]
+	}`
+	yamlDefinition := `
+name: Imported workflow
+description: Logs every event
+nodes:
+  - {id: log, type: log, category: action, name: Log, config: {message: hi}, enabled: true}
+  - {id: done, type: log, category: action, name: Done, config: {message: done}, enabled: true}
+connections:
+  - {id: conn-1, source_port: log, target_port: done}
+`
+
+	importWorkflow := func(body, contentType string) *models.Workflow {
+		req := httptest.NewRequest(http.MethodPost, "/workflows/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var imported models.Workflow
+
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&imported))
+
+		return &imported
+	}
+
+	fromJSON := importWorkflow(jsonDefinition, "application/json")
+	fromYAML := importWorkflow(yamlDefinition, "application/yaml")
+
+	assert.NotEqual(t, fromJSON.ID, fromYAML.ID)
+	assert.Equal(t, models.WorkflowStatusDraft, fromYAML.Status)
+	assert.Equal(t, fromJSON.Nodes, fromYAML.Nodes)
+	assert.Equal(t, fromJSON.Connections, fromYAML.Connections)
+
+	req := httptest.NewRequest(http.MethodPost, "/workflows/import", strings.NewReader("name: [unterminated"))
+	req.Header.Set("Content-Type", "application/yaml")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAPI_ImportWorkflow_ValidationErrorEnvelope(t *testing.T) {
+	t.Parallel()
+	app := setupTestApp(t.TempDir())
+
+	req := httptest.NewRequest(http.MethodPost, "/workflows/import", strings.NewReader(`{"description": "No name", "nodes": []}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var errResp web.ErrorResponse
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	assert.Equal(t, web.ErrorCodeValidation, errResp.Error.Code)
+	assert.NotEmpty(t, errResp.Error.Message)
+	require.Len(t, errResp.Error.Fields, 1)
+	assert.Equal(t, web.FieldError{Field: "Name", Rule: "required", Message: "Name is required"}, errResp.Error.Fields[0])
+}
+
+func TestAPI_DecideApproval(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	workflow1 := &models.Workflow{
+		ID:     "approval-workflow",
+		Name:   "Approval Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "approve", Name: "Approve", Type: "approval", Category: models.CategoryTypeAction, Enabled: true},
+		},
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow1))
+
+	execution := &models.ExecutionContext{
+		ID:          "exec-1",
+		WorkflowID:  workflow1.ID,
+		Status:      models.ExecutionStatusPaused,
+		NodeResults: map[string]models.NodeResult{},
+		Approvals: []models.ApprovalRequest{
+			{Token: "token-1", NodeID: "approve", TimeoutPort: "timeout", Status: models.ApprovalStatusPending},
+		},
+	}
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), execution))
+
+	app := setupTestApp(tempDir)
+
+	decide := func(token, body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/approvals/"+token, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	resp := decide("token-1", `{"decision": "maybe"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = decide("missing", `{"decision": "approve"}`)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = decide("token-1", `{"decision": "reject", "comment": "not now"}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var resumed models.ExecutionContext
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&resumed))
+	assert.Equal(t, models.ExecutionStatusRunning, resumed.Status)
+	assert.Equal(t, models.ApprovalStatusRejected, resumed.Approvals[0].Status)
+	assert.Equal(t, "not now", resumed.Approvals[0].Comment)
+	assert.Contains(t, resumed.NodeResults, models.MakeNodeResultKey("approve", "rejected"))
+}
+
+func TestAPI_PatchExecutionVariables(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:         "exec-paused",
+		WorkflowID: "approval-workflow",
+		Status:     models.ExecutionStatusPaused,
+		Variables:  map[string]any{"address": "1 Wrong St", "priority": "low"},
+	}))
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:         "exec-running",
+		WorkflowID: "approval-workflow",
+		Status:     models.ExecutionStatusRunning,
+	}))
+
+	app := setupTestApp(tempDir)
+
+	patch := func(id, body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPatch, "/executions/"+id+"/variables", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	resp := patch("exec-paused", `{"variables": {}}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = patch("missing", `{"variables": {"address": "2 Right Ave"}}`)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = patch("exec-running", `{"variables": {"address": "2 Right Ave"}}`)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = patch("exec-paused", `{"variables": {"address": "2 Right Ave"}, "changed_by": "operator"}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var patched models.ExecutionContext
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&patched))
+	assert.Equal(t, map[string]any{"address": "2 Right Ave", "priority": "low"}, patched.Variables)
+	require.Len(t, patched.VariableChanges, 1)
+	assert.Equal(t, "operator", patched.VariableChanges[0].ChangedBy)
+	assert.Equal(t, map[string]any{"address": "1 Wrong St"}, patched.VariableChanges[0].Previous)
+
+	running, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-running")
+	require.NoError(t, err)
+	assert.Empty(t, running.Variables)
+}
+
+func TestAPI_PatchWorkflowNode(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	workflow1 := &models.Workflow{
+		ID:     "patch-workflow",
+		Name:   "Patch Workflow",
+		Status: models.WorkflowStatusDraft,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:       "log1",
+				Name:     "Log",
+				Type:     "log",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"message": "hello", "level": "info"},
+				Enabled:  true,
+			},
+		},
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow1))
+
+	app := setupTestApp(tempDir)
+
+	patch := func(path, body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPatch, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	resp := patch("/workflows/patch-workflow/nodes/log1", `{"config": {"level": null, "message": "bye"}}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var node models.WorkflowNode
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&node))
+	assert.Equal(t, map[string]any{"message": "bye"}, node.Config)
+	assert.Equal(t, "Log", node.Name)
+
+	resp = patch("/workflows/patch-workflow/nodes/missing", `{"name": "Other"}`)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = patch("/workflows/patch-workflow/nodes/log1", `["not", "an", "object"]`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = patch("/workflows/patch-workflow/nodes/log1", `{"id": "renamed"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAPI_CreateWorkflowNode(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), &models.Workflow{
+		ID:     "node-workflow",
+		Name:   "Node Workflow",
+		Status: models.WorkflowStatusDraft,
+	}))
+
+	reg := registry.NewRegistry(slog.Default())
+	reg.RegisterDefaultNodes()
+
+	app := NewAPI(slog.Default(), persistence, &mocks.MockEventBus{}, reg).App()
+
+	create := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/workflows/node-workflow/nodes", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	// The log node schema requires a message and declares level as a string
+	resp := create(`{"id": "log1", "name": "Log", "type": "log", "category": "action", "config": {"level": 3}}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var errResp web.ErrorResponse
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	assert.Equal(t, web.ErrorCodeValidation, errResp.Error.Code)
+	assert.Equal(t, []web.FieldError{
+		{Field: "config.message", Rule: "required", Message: "message is required"},
+		{Field: "config.level", Rule: "type", Message: "level must be of type string"},
+	}, errResp.Error.Fields)
+
+	_, err := persistence.NodeRepository().GetNodeByWorkflow(t.Context(), "node-workflow", "log1")
+	require.Error(t, err)
+
+	resp = create(`{"id": "log1", "name": "Log", "type": "log", "category": "action", "config": {"message": "hello"}, "enabled": true}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	stored, err := persistence.NodeRepository().GetNodeByWorkflow(t.Context(), "node-workflow", "log1")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", stored.Config["message"])
+
+	resp = create(`{"id": "log2", "name": "Mystery", "type": "mystery", "category": "action"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = create(`{"id": "log3", "type": "log", "category": "action", "config": {"message": "hello"}}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAPI_PatchWorkflowLayout(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	workflow1 := &models.Workflow{
+		ID:     "layout-workflow",
+		Name:   "Layout Workflow",
+		Status: models.WorkflowStatusDraft,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:        "fetch",
+				Name:      "Fetch",
+				Type:      "httprequest",
+				Category:  models.CategoryTypeAction,
+				Config:    map[string]any{"url": "https://example.com", "method": "GET"},
+				PositionX: 100,
+				PositionY: 100,
+				Enabled:   true,
+			},
+			{
+				ID:        "log1",
+				Name:      "Log",
+				Type:      "log",
+				Category:  models.CategoryTypeAction,
+				Config:    map[string]any{"message": "hello"},
+				PositionX: 300,
+				PositionY: 100,
+				Enabled:   true,
+			},
+			{
+				ID:        "log2",
+				Name:      "Log Again",
+				Type:      "log",
+				Category:  models.CategoryTypeAction,
+				Config:    map[string]any{"message": "again"},
+				PositionX: 500,
+				PositionY: 100,
+				Enabled:   false,
+			},
+		},
+		Connections: []*models.Connection{
+			{ID: "conn1", SourcePort: "fetch:success", TargetPort: "log1:main"},
+			{ID: "conn2", SourcePort: "log1:success", TargetPort: "log2:main"},
+		},
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow1))
+
+	before, err := persistence.WorkflowRepository().GetByID(t.Context(), "layout-workflow")
+	require.NoError(t, err)
+
+	app := setupTestApp(tempDir)
+
+	patch := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPatch, "/workflows/layout-workflow/layout", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	resp := patch(`{"fetch": {"x": 40, "y": 220}, "log2": {"x": 640, "y": -20}}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var positions map[string]models.NodePosition
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&positions))
+	assert.Equal(t, map[string]models.NodePosition{
+		"fetch": {X: 40, Y: 220},
+		"log2":  {X: 640, Y: -20},
+	}, positions)
+
+	after, err := persistence.WorkflowRepository().GetByID(t.Context(), "layout-workflow")
+	require.NoError(t, err)
+	require.Len(t, after.Nodes, 3)
+
+	expected := map[string]models.NodePosition{
+		"fetch": {X: 40, Y: 220},
+		"log1":  {X: 300, Y: 100},
+		"log2":  {X: 640, Y: -20},
+	}
+
+	for i, node := range after.Nodes {
+		assert.Equal(t, expected[node.ID], models.NodePosition{X: node.PositionX, Y: node.PositionY}, node.ID)
+
+		moved := *node
+		moved.PositionX, moved.PositionY = before.Nodes[i].PositionX, before.Nodes[i].PositionY
+		assert.Equal(t, before.Nodes[i], &moved, "only the position of %s changes", node.ID)
+	}
+
+	assert.Equal(t, before.Connections, after.Connections)
+	assert.Equal(t, before.Status, after.Status)
+
+	resp = patch(`{"log1": {"x": 0, "y": 0}, "missing": {"x": 1, "y": 1}}`)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	unchanged, err := persistence.NodeRepository().GetNodeByWorkflow(t.Context(), "layout-workflow", "log1")
+	require.NoError(t, err)
+	assert.Equal(t, 300, unchanged.PositionX, "no position changes when a node is missing")
+
+	resp = patch(`{"log1": {"x": 10}}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = patch(`{}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = patch(`[{"x": 1, "y": 1}]`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	req := httptest.NewRequest(http.MethodPatch, "/workflows/missing/layout", strings.NewReader(`{"fetch": {"x": 1, "y": 1}}`))
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPI_StreamExecution(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	startedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	execution := &models.ExecutionContext{
+		ID:         "exec-running",
+		WorkflowID: "workflow-1",
+		Status:     models.ExecutionStatusRunning,
+		NodeResults: map[string]models.NodeResult{
+			models.MakeNodeResultKey("fetch", "success"): {NodeID: "fetch", Data: map[string]any{"count": 2.0}, Status: "success", Timestamp: startedAt},
+		},
+		CreatedAt: startedAt,
+	}
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), execution))
+
+	eventBus := &mocks.MockEventBus{}
+	api := NewAPI(slog.Default(), persistence, eventBus, registry.NewRegistry(slog.Default()))
+	api.ConfigureStreaming(10*time.Millisecond, 20*time.Millisecond)
+	app := api.App()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() { _ = app.Listener(listener, fiber.ListenConfig{DisableStartupMessage: true}) }()
+
+	t.Cleanup(func() { _ = app.Shutdown() })
+
+	resp, err := http.Get("http://" + listener.Addr().String() + "/executions/exec-running/stream")
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	heartbeats := 0
+
+	// nextEvent reads the next event of the stream, counting the heartbeat comments before it
+	nextEvent := func() (string, map[string]any) {
+		for {
+			var name, data string
+
+			for {
+				line, err := reader.ReadString('\n')
+				require.NoError(t, err)
+
+				line = strings.TrimSuffix(line, "\n")
+				if line == "" {
+					break
+				}
+
+				switch {
+				case line == ": heartbeat":
+					heartbeats++
+				case strings.HasPrefix(line, "event: "):
+					name = strings.TrimPrefix(line, "event: ")
+				case strings.HasPrefix(line, "data: "):
+					data = strings.TrimPrefix(line, "data: ")
+				}
+			}
+
+			// Reads racing the test's writes of the execution file surface as error events
+			if name == "" || name == "error" {
+				continue
+			}
+
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(data), &payload))
+
+			return name, payload
+		}
+	}
+
+	name, payload := nextEvent()
+	assert.Equal(t, "status", name)
+	assert.Equal(t, "running", payload["status"])
+
+	name, payload = nextEvent()
+	assert.Equal(t, "node_finished", name)
+	assert.Equal(t, "fetch", payload["node_id"])
+	assert.Equal(t, "success", payload["port"])
+
+	// A node finishes while the client is connected
+	time.Sleep(50 * time.Millisecond)
+
+	execution.NodeResults[models.MakeNodeResultKey("notify", "success")] = models.NodeResult{
+		NodeID: "notify", Data: map[string]any{}, Status: "success", Timestamp: startedAt.Add(time.Second),
+	}
+	require.NoError(t, persistence.ExecutionContextRepository().UpdateExecutionContext(t.Context(), execution))
+
+	name, payload = nextEvent()
+	assert.Equal(t, "node_finished", name)
+	assert.Equal(t, "notify", payload["node_id"])
+	assert.Positive(t, heartbeats, "idle streams send heartbeats")
+
+	// The stream ends with the execution
+	execution.Status = models.ExecutionStatusCompleted
+	require.NoError(t, persistence.ExecutionContextRepository().UpdateExecutionContext(t.Context(), execution))
+
+	name, payload = nextEvent()
+	assert.Equal(t, "status", name)
+	assert.Equal(t, "completed", payload["status"])
+
+	name, payload = nextEvent()
+	assert.Equal(t, "end", name)
+	assert.Equal(t, "completed", payload["status"])
+
+	_, err = reader.ReadString('\n')
+	assert.ErrorIs(t, err, io.EOF)
+
+	// Unknown executions have no stream
+	missing, err := app.Test(httptest.NewRequest(http.MethodGet, "/executions/missing/stream", nil))
+	require.NoError(t, err)
+
+	defer func() { _ = missing.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, missing.StatusCode)
+}
+
+func TestAPI_WorkflowGroups(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+	workflows := persistence.WorkflowRepository()
+
+	published, err := workflow.NewRepository(persistence).Create(t.Context(), &models.Workflow{
+		Name:   "Order Sync",
+		Status: models.WorkflowStatusPublished,
+	})
+	require.NoError(t, err)
+
+	draft, err := workflows.CreateDraftFromPublished(t.Context(), published.WorkflowGroupID)
+	require.NoError(t, err)
+
+	previous := &models.Workflow{
+		ID:              "order-sync-v0",
+		Name:            "Order Sync (old)",
+		Status:          models.WorkflowStatusUnpublished,
+		WorkflowGroupID: published.WorkflowGroupID,
+		CreatedAt:       published.CreatedAt.Add(-time.Hour),
+	}
+	require.NoError(t, workflows.Save(t.Context(), previous))
+
+	onlyDraft, err := workflow.NewRepository(persistence).Create(t.Context(), &models.Workflow{Name: "Invoice Reminder"})
+	require.NoError(t, err)
+
+	app := setupTestApp(tempDir)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/workflow-groups", nil))
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var groups []models.WorkflowGroup
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&groups))
+	require.Len(t, groups, 2)
+
+	groupsByID := make(map[string]models.WorkflowGroup)
+	for _, group := range groups {
+		groupsByID[group.ID] = group
+	}
+
+	orderSync := groupsByID[published.WorkflowGroupID]
+	assert.Equal(t, "Order Sync", orderSync.Name)
+	require.NotNil(t, orderSync.Published)
+	assert.Equal(t, published.ID, orderSync.Published.ID)
+	assert.Equal(t, models.WorkflowStatusPublished, orderSync.Published.Status)
+	require.NotNil(t, orderSync.Draft)
+	assert.Equal(t, draft.ID, orderSync.Draft.ID)
+	assert.Equal(t, models.WorkflowStatusDraft, orderSync.Draft.Status)
+
+	invoiceReminder := groupsByID[onlyDraft.ID]
+	assert.Nil(t, invoiceReminder.Published)
+	require.NotNil(t, invoiceReminder.Draft)
+	assert.Equal(t, onlyDraft.ID, invoiceReminder.Draft.ID)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/workflow-groups/"+published.WorkflowGroupID, nil))
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var detail models.WorkflowGroupDetail
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&detail))
+	assert.Equal(t, published.ID, detail.Published.ID)
+	assert.Equal(t, draft.ID, detail.Draft.ID)
+	require.Len(t, detail.History, 1)
+	assert.Equal(t, previous.ID, detail.History[0].ID)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/workflow-groups/missing", nil))
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPI_TestCondition(t *testing.T) {
+	t.Parallel()
+
+	app := NewAPI(slog.Default(), file.NewPersistence(t.TempDir()), &mocks.MockEventBus{}, registry.NewRegistry(slog.Default())).App()
+
+	testCondition := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/conditions/test", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	for name, tc := range map[string]struct {
+		expression string
+		expected   bool
+	}{
+		"true":  {expression: `{{ gt .trigger_data.total 100.0 }}`, expected: true},
+		"false": {expression: `{{ eq .trigger_data.status \"paid\" }}`, expected: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := testCondition(`{"language": "template", "expression": "` + tc.expression + `",
+				"context": {"trigger_data": {"total": 150, "status": "pending"}}}`)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var result workflow.ConditionTestResult
+
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, tc.expected, result.Result)
+			assert.Equal(t, tc.expected, result.Value)
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		body   string
+		status int
+		code   string
+	}{
+		"template error":       {body: `{"expression": "{{ .trigger_data.total "}`, status: http.StatusUnprocessableEntity, code: web.ErrorCodeEvaluation},
+		"unsupported language": {body: `{"language": "cel", "expression": "total > 100"}`, status: http.StatusBadRequest, code: web.ErrorCodeValidation},
+		"missing expression":   {body: `{"language": "template"}`, status: http.StatusBadRequest, code: web.ErrorCodeValidation},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := testCondition(tc.body)
+			assert.Equal(t, tc.status, resp.StatusCode)
+
+			var errResp web.ErrorResponse
+
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+			assert.Equal(t, tc.code, errResp.Error.Code)
+			assert.NotEmpty(t, errResp.Error.Message)
+		})
+	}
+}
+
+func TestAPI_DeletedWorkflows_ListAndRestore(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	persistence := file.NewPersistence(tempDir)
+
+	repo := workflow.NewRepository(persistence)
+
+	kept, err := repo.Create(t.Context(), &models.Workflow{Name: "Kept"})
+	require.NoError(t, err)
+
+	removed, err := repo.Create(t.Context(), &models.Workflow{Name: "Removed"})
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(t.Context(), removed.ID))
+
+	api := NewAPI(slog.Default(), persistence, &mocks.MockEventBus{}, registry.NewRegistry(slog.Default()))
+	api.ConfigureAdminToken("admin-secret")
+	app := api.App()
+
+	request := func(method, target, token string) *http.Response {
+		req := httptest.NewRequest(method, target, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	listWorkflows := func(target, token string) []*models.Workflow {
+		resp := request(http.MethodGet, target, token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var page models.WorkflowPage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+
+		return page.Workflows
+	}
+
+	// The deleted workflow is hidden from the normal listing
+	active := listWorkflows("/workflows?limit=10", "")
+	require.Len(t, active, 1)
+	assert.Equal(t, kept.ID, active[0].ID)
+
+	// Only admins list deleted workflows
+	assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/workflows?include_deleted=true", "").StatusCode)
+	assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/workflows?include_deleted=true", "wrong").StatusCode)
+
+	all := listWorkflows("/workflows?include_deleted=true", "admin-secret")
+	require.Len(t, all, 2)
+
+	for _, listed := range all {
+		assert.Equal(t, listed.ID == removed.ID, listed.DeletedAt != nil)
+	}
+
+	// Only admins restore workflows
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPost, "/workflows/"+removed.ID+"/restore", "").StatusCode)
+
+	resp := request(http.MethodPost, "/workflows/"+removed.ID+"/restore", "admin-secret")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var restored models.Workflow
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&restored))
+	assert.Equal(t, removed.ID, restored.ID)
+	assert.Nil(t, restored.DeletedAt)
+
+	// The restored workflow is back in the active listing
+	assert.Len(t, listWorkflows("/workflows?limit=10", ""), 2)
+
+	assert.Equal(t, http.StatusConflict, request(http.MethodPost, "/workflows/"+removed.ID+"/restore", "admin-secret").StatusCode)
+	assert.Equal(t, http.StatusNotFound, request(http.MethodPost, "/workflows/missing/restore", "admin-secret").StatusCode)
+}
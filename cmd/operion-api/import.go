@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dukex/operion/pkg/cmd"
+	"github.com/dukex/operion/pkg/log"
+	"github.com/dukex/operion/pkg/workflow"
+	"github.com/go-playground/validator/v10"
+	cli "github.com/urfave/cli/v3"
+)
+
+var ErrMissingDefinitionFile = errors.New("a workflow definition file is required")
+
+// NewImportCommand creates the command importing a JSON or YAML workflow definition file.
+func NewImportCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "Import a workflow from a JSON or YAML definition file",
+		ArgsUsage: "<file>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "database-url",
+				Usage:    "Database connection URL for persistence",
+				Required: true,
+				Sources:  cli.EnvVars("DATABASE_URL"),
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Definition format (json, yaml). Defaults to the file extension",
+			},
+		},
+		Action: func(ctx context.Context, command *cli.Command) error {
+			logger := log.WithModule("api").With("action", "import")
+
+			path := command.Args().First()
+			if path == "" {
+				return ErrMissingDefinitionFile
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read definition: %w", err)
+			}
+
+			definition, err := workflow.DecodeDefinition(data, isYAMLDefinition(path, command.String("format")))
+			if err != nil {
+				return err
+			}
+
+			if err := workflow.PrepareImport(definition); err != nil {
+				return err
+			}
+
+			if err := validator.New(validator.WithRequiredStructEnabled()).Struct(definition); err != nil {
+				return fmt.Errorf("%w: %w", workflow.ErrInvalidDefinition, err)
+			}
+
+			persistence := cmd.NewPersistence(ctx, logger, command.String("database-url"))
+			defer func() {
+				if err := persistence.Close(ctx); err != nil {
+					logger.ErrorContext(ctx, "Failed to close persistence", "error", err)
+				}
+			}()
+
+			imported, err := workflow.NewRepository(persistence).Create(ctx, definition)
+			if err != nil {
+				return fmt.Errorf("failed to save workflow: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(os.Stdout, "Imported workflow %s (%s)\n", imported.ID, imported.Name)
+
+			return nil
+		},
+	}
+}
+
+// isYAMLDefinition reports whether a definition file should be decoded as YAML.
+func isYAMLDefinition(path, format string) bool {
+	if format != "" {
+		return strings.EqualFold(format, "yaml") || strings.EqualFold(format, "yml")
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+
+	return ext == ".yaml" || ext == ".yml"
+}
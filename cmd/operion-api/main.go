@@ -5,7 +5,10 @@ import (
 	"os"
 
 	"github.com/dukex/operion/pkg/cmd"
+	"github.com/dukex/operion/pkg/eventbus"
 	"github.com/dukex/operion/pkg/log"
+	"github.com/dukex/operion/pkg/payloads"
+	"github.com/dukex/operion/pkg/web"
 	cli "github.com/urfave/cli/v3"
 )
 
@@ -18,6 +21,9 @@ func main() {
 		Name:                  "operion-api",
 		Usage:                 "Create and manage workflows",
 		EnableShellCompletion: true,
+		Commands: []*cli.Command{
+			NewImportCommand(),
+		},
 		Flags: []cli.Flag{
 			&cli.IntFlag{
 				Name:    "port",
@@ -32,12 +38,56 @@ func main() {
 				Required: true,
 				Sources:  cli.EnvVars("DATABASE_URL"),
 			},
+			&cli.StringFlag{
+				Name:    "event-bus",
+				Usage:   "Event bus type (kafka), empty to serve the API without resuming executions or deciding approvals",
+				Sources: cli.EnvVars("EVENT_BUS_TYPE"),
+			},
 			&cli.StringFlag{
 				Name:     "plugins-path",
 				Usage:    "Path to the directory containing action plugins",
 				Value:    "./plugins",
 				Required: false,
 			},
+			&cli.StringFlag{
+				Name:    "payload-store",
+				Usage:   "Object store holding large execution payloads (file:///dir or s3://bucket/prefix), empty to store them inline",
+				Sources: cli.EnvVars("PAYLOAD_STORE"),
+			},
+			&cli.IntFlag{
+				Name:    "payload-offload-threshold",
+				Usage:   "Size in bytes of JSON above which trigger data and node results are offloaded to the payload store",
+				Value:   payloads.DefaultThreshold,
+				Sources: cli.EnvVars("PAYLOAD_OFFLOAD_THRESHOLD"),
+			},
+			&cli.StringSliceFlag{
+				Name:    "cors-allow-origins",
+				Usage:   "Origins allowed to make cross-origin requests (comma-separated, \"*\" for any), none by default",
+				Sources: cli.EnvVars("CORS_ALLOW_ORIGINS"),
+			},
+			&cli.StringSliceFlag{
+				Name:    "cors-allow-methods",
+				Usage:   "Methods allowed in cross-origin requests (comma-separated)",
+				Value:   []string{"GET", "POST", "PATCH", "DELETE"},
+				Sources: cli.EnvVars("CORS_ALLOW_METHODS"),
+			},
+			&cli.StringSliceFlag{
+				Name:    "cors-allow-headers",
+				Usage:   "Request headers allowed in cross-origin requests (comma-separated)",
+				Value:   []string{"Content-Type"},
+				Sources: cli.EnvVars("CORS_ALLOW_HEADERS"),
+			},
+			&cli.IntFlag{
+				Name:    "body-limit",
+				Usage:   "Maximum size in bytes of create and update request bodies, 0 for no limit",
+				Value:   web.DefaultBodyLimit,
+				Sources: cli.EnvVars("API_BODY_LIMIT"),
+			},
+			&cli.StringFlag{
+				Name:    "admin-token",
+				Usage:   "Bearer token of admin-only requests, such as listing and restoring deleted workflows",
+				Sources: cli.EnvVars("API_ADMIN_TOKEN"),
+			},
 			&cli.StringFlag{
 				Name:    "log-level",
 				Usage:   "Log level (debug, info, warn, error)",
@@ -60,13 +110,53 @@ func main() {
 				}
 			}()
 
+			registry.RegisterPersistenceNodes(persistence)
+
+			// Without an event bus, the endpoints publishing node activations answer 503
+			var eventBus eventbus.EventBus
+
+			if eventBusType := command.String("event-bus"); eventBusType != "" {
+				bus, err := cmd.NewEventBus(ctx, eventBusType, logger)
+				if err != nil {
+					return err
+				}
+
+				defer func() {
+					err := bus.Close(ctx)
+					if err != nil {
+						logger.ErrorContext(ctx, "Failed to close event bus", "error", err)
+					}
+				}()
+
+				eventBus = bus
+			}
+
+			offloader, err := cmd.NewPayloadOffloader(ctx, command.String("payload-store"), command.Int("payload-offload-threshold"))
+			if err != nil {
+				return err
+			}
+
 			api := NewAPI(
 				logger,
 				persistence,
+				eventBus,
 				registry,
 			)
 
-			err := api.Start(command.Int("port"))
+			api.ConfigurePayloadOffloading(offloader)
+			api.ConfigureBodyLimit(command.Int("body-limit"))
+			api.ConfigureAdminToken(command.String("admin-token"))
+
+			err = api.ConfigureCORS(CORSConfig{
+				AllowOrigins: command.StringSlice("cors-allow-origins"),
+				AllowMethods: command.StringSlice("cors-allow-methods"),
+				AllowHeaders: command.StringSlice("cors-allow-headers"),
+			})
+			if err != nil {
+				return err
+			}
+
+			err = api.Start(command.Int("port"))
 			if err != nil {
 				logger.ErrorContext(ctx, "Failed to start event-driven worker", "error", err)
 			}
@@ -49,6 +49,12 @@ func main() {
 				Value:   "",
 				Sources: cli.EnvVars("SOURCE_PROVIDERS"),
 			},
+			&cli.IntFlag{
+				Name:    "start-concurrency",
+				Usage:   "Maximum number of source providers initialized and configured at the same time",
+				Value:   DefaultStartConcurrency,
+				Sources: cli.EnvVars("SOURCE_START_CONCURRENCY"),
+			},
 			&cli.StringFlag{
 				Name:     "event-bus",
 				Usage:    "Event bus type (kafka, rabbitmq, etc.)",
@@ -75,6 +81,16 @@ func main() {
 				}
 			}()
 
+			meterProvider, err := trc.InitMeter(ctx, "operion-source-manager")
+			if err != nil {
+				return fmt.Errorf("failed to initialize meter: %w", err)
+			}
+			defer func() {
+				if err := meterProvider.Shutdown(ctx); err != nil {
+					slog.Error("Failed to shutdown meter provider", "error", err)
+				}
+			}()
+
 			managerID := command.String("manager-id")
 			if managerID == "" {
 				managerID = "source-manager-" + uuid.New().String()[:8]
@@ -119,6 +135,7 @@ func main() {
 				registry,
 				providerFilter,
 			)
+			manager.SetStartConcurrency(command.Int("start-concurrency"))
 
 			manager.Start(ctx)
 
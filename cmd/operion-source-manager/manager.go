@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -16,18 +18,27 @@ import (
 	"github.com/dukex/operion/pkg/persistence"
 	"github.com/dukex/operion/pkg/protocol"
 	"github.com/dukex/operion/pkg/registry"
+	trc "github.com/dukex/operion/pkg/tracer"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/sync/errgroup"
 )
 
+// DefaultStartConcurrency is how many providers are started at the same time by default.
+const DefaultStartConcurrency = 4
+
 type ProviderManager struct {
 	id               string
 	sourceEventBus   eventbus.SourceEventBus
 	runningProviders map[string]protocol.Provider
 	providerMutex    sync.RWMutex
+	workflowMutex    sync.Mutex
 	logger           *slog.Logger
 	persistence      persistence.Persistence
 	registry         *registry.Registry
 	restartCount     int
 	providerFilter   []string
+	startConcurrency int
+	metrics          *trc.SourceMetrics
 }
 
 func NewProviderManager(
@@ -38,6 +49,11 @@ func NewProviderManager(
 	registry *registry.Registry,
 	providerFilter []string,
 ) *ProviderManager {
+	metrics, err := trc.NewSourceMetrics(otel.GetMeterProvider())
+	if err != nil {
+		logger.Warn("Failed to create source metrics, source events will not be measured", "error", err)
+	}
+
 	return &ProviderManager{
 		id:               id,
 		logger:           logger.With("module", "operion-source-manager", "manager_id", id),
@@ -47,9 +63,17 @@ func NewProviderManager(
 		sourceEventBus:   sourceEventBus,
 		runningProviders: make(map[string]protocol.Provider),
 		providerFilter:   providerFilter,
+		startConcurrency: DefaultStartConcurrency,
+		metrics:          metrics,
 	}
 }
 
+// SetStartConcurrency sets how many providers are initialized and configured at the same time.
+// Values below one start providers one at a time.
+func (spm *ProviderManager) SetStartConcurrency(limit int) {
+	spm.startConcurrency = max(limit, 1)
+}
+
 func (spm *ProviderManager) Start(ctx context.Context) {
 	spmCtx, cancel := context.WithCancel(ctx)
 
@@ -99,12 +123,10 @@ func (spm *ProviderManager) restart(ctx context.Context, cancel context.CancelFu
 }
 
 func (spm *ProviderManager) run(ctx context.Context, cancel context.CancelFunc) {
-	// Start source providers with lifecycle management
+	// Start source providers with lifecycle management. Providers are independent, a provider
+	// that fails to start does not stop the others.
 	if err := spm.startProviders(ctx); err != nil {
-		spm.logger.Error("Failed to start source providers", "error", err)
-		spm.restart(ctx, cancel)
-
-		return
+		spm.logger.Error("Failed to start some source providers", "error", err)
 	}
 
 	spm.logger.Info("Source provider manager started successfully")
@@ -138,26 +160,38 @@ func (spm *ProviderManager) startProviders(ctx context.Context) error {
 		"filtered_count", len(providersToStart),
 		"filter", spm.providerFilter)
 
-	var wg sync.WaitGroup
-	for _, factory := range providersToStart {
-		wg.Add(1)
+	var (
+		group    errgroup.Group
+		errsMu   sync.Mutex
+		startErr []error
+	)
 
-		go func(factory protocol.ProviderFactory) {
-			defer wg.Done()
+	group.SetLimit(spm.startConcurrency)
 
+	for _, factory := range providersToStart {
+		group.Go(func() error {
 			if err := spm.startProvider(ctx, factory); err != nil {
 				spm.logger.Error("Failed to start provider",
 					"provider_id", factory.ID(),
 					"error", err)
+
+				errsMu.Lock()
+				startErr = append(startErr, fmt.Errorf("provider %s: %w", factory.ID(), err))
+				errsMu.Unlock()
+
+				// Errors are collected rather than returned, so the group keeps starting the others
+				return nil
 			}
 
 			spm.logger.Info("Started provider", "provider_id", factory.ID())
-		}(factory)
+
+			return nil
+		})
 	}
 
-	wg.Wait()
+	_ = group.Wait()
 
-	return nil
+	return errors.Join(startErr...)
 }
 
 func (spm *ProviderManager) startProvider(ctx context.Context, factory protocol.ProviderFactory) error {
@@ -167,6 +201,8 @@ func (spm *ProviderManager) startProvider(ctx context.Context, factory protocol.
 	config := map[string]any{}
 	sourceConfigs := []map[string]any{config}
 
+	var errs []error
+
 	// Start a provider instance for each source configuration
 	for _, config := range sourceConfigs {
 		// Generate provider instance key using provider ID
@@ -179,12 +215,16 @@ func (spm *ProviderManager) startProvider(ctx context.Context, factory protocol.
 				"instance_key", instanceKey,
 				"error", err)
 
+			errs = append(errs, err)
+
 			continue
 		}
 
 		// Execute complete lifecycle if provider supports it
 		if lifecycle, ok := provider.(protocol.ProviderLifecycle); ok {
 			if err := spm.executeProviderLifecycle(ctx, lifecycle, providerID, instanceKey); err != nil {
+				errs = append(errs, err)
+
 				continue
 			}
 		}
@@ -194,7 +234,7 @@ func (spm *ProviderManager) startProvider(ctx context.Context, factory protocol.
 		spm.providerMutex.Unlock()
 
 		// Create callback for the provider
-		callback := spm.createSourceEventCallback()
+		callback := protocol.InstrumentSourceEventCallback(spm.createSourceEventCallback(), spm.metrics)
 
 		// Step 4: Start the provider
 		if err := provider.Start(ctx, callback); err != nil {
@@ -207,6 +247,8 @@ func (spm *ProviderManager) startProvider(ctx context.Context, factory protocol.
 			delete(spm.runningProviders, instanceKey)
 			spm.providerMutex.Unlock()
 
+			errs = append(errs, err)
+
 			continue
 		}
 
@@ -215,7 +257,7 @@ func (spm *ProviderManager) startProvider(ctx context.Context, factory protocol.
 			"instance_key", instanceKey)
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 func (spm *ProviderManager) createSourceEventCallback() protocol.SourceEventCallback {
@@ -229,6 +271,8 @@ func (spm *ProviderManager) createSourceEventCallback() protocol.SourceEventCall
 
 		// Create SourceEvent
 		sourceEvent := events.NewSourceEvent(sourceID, providerType, eventType, data)
+		sourceEvent.CorrelationID = events.CorrelationID(ctx)
+		sourceEvent.OrderingKey = events.OrderingKey(ctx)
 
 		// Validate the event
 		if err := sourceEvent.Validate(); err != nil {
@@ -241,7 +285,8 @@ func (spm *ProviderManager) createSourceEventCallback() protocol.SourceEventCall
 		logger.Info("Publishing source event",
 			"source_id", sourceEvent.SourceID,
 			"provider_id", sourceEvent.ProviderID,
-			"event_type", sourceEvent.EventType)
+			"event_type", sourceEvent.EventType,
+			"correlation_id", sourceEvent.CorrelationID)
 
 		if err := spm.sourceEventBus.PublishSourceEvent(ctx, sourceEvent); err != nil {
 			logger.Error("Failed to publish source event", "error", err)
@@ -353,9 +398,44 @@ func (spm *ProviderManager) updateWorkflowTriggerNodes(workflow *models.Workflow
 	return workflowUpdated, updated
 }
 
+// updateWorkflowTriggerErrors replaces the trigger errors a provider reported on each workflow,
+// clearing errors from previous runs for triggers that are now configured.
+func (spm *ProviderManager) updateWorkflowTriggerErrors(
+	ctx context.Context,
+	workflows []*models.Workflow,
+	providerID string,
+	configureErr *protocol.ConfigureError,
+) error {
+	for _, workflow := range workflows {
+		var triggerErrors []models.TriggerError
+		if configureErr != nil {
+			triggerErrors = configureErr.Workflows[workflow.ID]
+		}
+
+		if !workflow.ReplaceTriggerErrors(providerID, triggerErrors) {
+			continue
+		}
+
+		if err := spm.persistence.WorkflowRepository().Save(ctx, workflow); err != nil {
+			return err
+		}
+
+		for _, triggerErr := range triggerErrors {
+			spm.logger.Warn("Trigger configuration failed",
+				"workflow_id", workflow.ID,
+				"trigger_node_id", triggerErr.TriggerID,
+				"provider_id", providerID,
+				"error", triggerErr.Message)
+		}
+	}
+
+	return nil
+}
+
 func (spm *ProviderManager) executeProviderLifecycle(ctx context.Context, lifecycle protocol.ProviderLifecycle, providerID, instanceKey string) error {
 	deps := protocol.Dependencies{
-		Logger: spm.logger,
+		Logger:  spm.logger,
+		Metrics: spm.metrics,
 	}
 
 	// Step 1: Initialize dependencies
@@ -381,7 +461,17 @@ func (spm *ProviderManager) executeProviderLifecycle(ctx context.Context, lifecy
 
 	// Configure provider and get triggerID -> sourceID mapping
 	triggerToSourceMap, err := lifecycle.Configure(workflows)
-	if err != nil {
+
+	var configureErr *protocol.ConfigureError
+
+	switch {
+	case errors.As(err, &configureErr):
+		// Triggers that failed are reported per workflow, the remaining triggers are still configured
+		spm.logger.Warn("Provider failed to configure some triggers",
+			"provider_id", providerID,
+			"instance_key", instanceKey,
+			"error", err)
+	case err != nil:
 		spm.logger.Error("Failed to configure provider",
 			"provider_id", providerID,
 			"instance_key", instanceKey,
@@ -390,9 +480,13 @@ func (spm *ProviderManager) executeProviderLifecycle(ctx context.Context, lifecy
 		return err
 	}
 
-	// Update triggers with their sourceID mappings
-	if err := spm.updateTriggersWithSourceIDs(ctx, triggerToSourceMap); err != nil {
-		spm.logger.Error("Failed to update triggers with source IDs",
+	if err := spm.saveConfiguration(ctx, providerID, instanceKey, triggerToSourceMap, configureErr); err != nil {
+		return err
+	}
+
+	// Step 3: Prepare for startup
+	if err := lifecycle.Prepare(ctx); err != nil {
+		spm.logger.Error("Failed to prepare provider",
 			"provider_id", providerID,
 			"instance_key", instanceKey,
 			"error", err)
@@ -400,9 +494,44 @@ func (spm *ProviderManager) executeProviderLifecycle(ctx context.Context, lifecy
 		return err
 	}
 
-	// Step 3: Prepare for startup
-	if err := lifecycle.Prepare(ctx); err != nil {
-		spm.logger.Error("Failed to prepare provider",
+	return nil
+}
+
+// saveConfiguration stores the trigger errors and source IDs a provider configured on the workflows.
+// Providers are configured concurrently, so workflows are saved one provider at a time and reloaded
+// first: a provider does not overwrite what another one saved since it was configured.
+func (spm *ProviderManager) saveConfiguration(
+	ctx context.Context,
+	providerID, instanceKey string,
+	triggerToSourceMap map[string]string,
+	configureErr *protocol.ConfigureError,
+) error {
+	spm.workflowMutex.Lock()
+	defer spm.workflowMutex.Unlock()
+
+	workflows, err := spm.persistence.WorkflowRepository().GetAll(ctx)
+	if err != nil {
+		spm.logger.Error("Failed to reload workflows after configuration",
+			"provider_id", providerID,
+			"instance_key", instanceKey,
+			"error", err)
+
+		return err
+	}
+
+	// Store trigger configuration errors against their workflows
+	if err := spm.updateWorkflowTriggerErrors(ctx, workflows, providerID, configureErr); err != nil {
+		spm.logger.Error("Failed to update workflow trigger errors",
+			"provider_id", providerID,
+			"instance_key", instanceKey,
+			"error", err)
+
+		return err
+	}
+
+	// Update triggers with their sourceID mappings
+	if err := spm.updateTriggersWithSourceIDs(ctx, triggerToSourceMap); err != nil {
+		spm.logger.Error("Failed to update triggers with source IDs",
 			"provider_id", providerID,
 			"instance_key", instanceKey,
 			"error", err)
@@ -2,13 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/dukex/operion/pkg/events"
 	"github.com/dukex/operion/pkg/mocks"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/dukex/operion/pkg/protocol"
 	"github.com/dukex/operion/pkg/registry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -311,3 +316,144 @@ func TestProviderManager_StartProviders_NoMatchingFilter(t *testing.T) {
 	err := manager.startProviders(context.Background())
 	assert.NoError(t, err)
 }
+
+func TestProviderManager_UpdateWorkflowTriggerErrors(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	filePersistence := file.NewPersistence(t.TempDir())
+
+	manager := NewProviderManager("test-manager", filePersistence, &mocks.MockSourceEventBus{}, logger, registry.NewRegistry(logger), nil)
+
+	workflows := []*models.Workflow{
+		{ID: "workflow-1", Name: "Valid", Status: models.WorkflowStatusPublished},
+		{ID: "workflow-2", Name: "Invalid", Status: models.WorkflowStatusPublished},
+	}
+	for _, workflow := range workflows {
+		require.NoError(t, filePersistence.WorkflowRepository().Save(ctx, workflow))
+	}
+
+	configureErr := protocol.NewConfigureError("kafka")
+	configureErr.Add("workflow-2", "trigger-invalid", errors.New("topic is required"))
+
+	err := manager.updateWorkflowTriggerErrors(ctx, workflows, "kafka", configureErr)
+	require.NoError(t, err)
+
+	valid, err := filePersistence.WorkflowRepository().GetByID(ctx, "workflow-1")
+	require.NoError(t, err)
+	assert.Empty(t, valid.TriggerErrors)
+
+	invalid, err := filePersistence.WorkflowRepository().GetByID(ctx, "workflow-2")
+	require.NoError(t, err)
+	require.Len(t, invalid.TriggerErrors, 1)
+	assert.Equal(t, "trigger-invalid", invalid.TriggerErrors[0].TriggerID)
+	assert.Equal(t, "kafka", invalid.TriggerErrors[0].ProviderID)
+	assert.Equal(t, "topic is required", invalid.TriggerErrors[0].Message)
+
+	// A later successful configuration clears the stored errors
+	err = manager.updateWorkflowTriggerErrors(ctx, []*models.Workflow{invalid}, "kafka", nil)
+	require.NoError(t, err)
+
+	invalid, err = filePersistence.WorkflowRepository().GetByID(ctx, "workflow-2")
+	require.NoError(t, err)
+	assert.Empty(t, invalid.TriggerErrors)
+}
+
+// concurrencyTracker records how many providers are configuring at the same time.
+type concurrencyTracker struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (c *concurrencyTracker) enter() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.inFlight++
+	c.maxInFlight = max(c.maxInFlight, c.inFlight)
+}
+
+func (c *concurrencyTracker) leave() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.inFlight--
+}
+
+// lifecycleProvider is a provider whose Configure takes a while and optionally fails.
+type lifecycleProvider struct {
+	mocks.MockProvider
+
+	tracker      *concurrencyTracker
+	configureErr error
+}
+
+func (p *lifecycleProvider) Initialize(ctx context.Context, deps protocol.Dependencies) error {
+	return nil
+}
+
+func (p *lifecycleProvider) Configure(workflows []*models.Workflow) (map[string]string, error) {
+	p.tracker.enter()
+	defer p.tracker.leave()
+
+	time.Sleep(50 * time.Millisecond)
+
+	return nil, p.configureErr
+}
+
+func (p *lifecycleProvider) Prepare(ctx context.Context) error {
+	return nil
+}
+
+type lifecycleProviderFactory struct {
+	id       string
+	provider *lifecycleProvider
+}
+
+func (f *lifecycleProviderFactory) Create(config map[string]any, logger *slog.Logger) (protocol.Provider, error) {
+	return f.provider, nil
+}
+
+func (f *lifecycleProviderFactory) ID() string             { return f.id }
+func (f *lifecycleProviderFactory) Name() string           { return f.id }
+func (f *lifecycleProviderFactory) Description() string    { return f.id }
+func (f *lifecycleProviderFactory) Schema() map[string]any { return map[string]any{} }
+func (f *lifecycleProviderFactory) EventTypes() []string   { return []string{} }
+
+func TestProviderManager_StartProviders_ConfiguresConcurrently(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	testRegistry := registry.NewRegistry(logger)
+	tracker := &concurrencyTracker{}
+
+	providerIDs := []string{"alpha", "beta", "gamma", "delta", "epsilon", "zeta"}
+	for _, id := range providerIDs {
+		provider := &lifecycleProvider{tracker: tracker}
+		if id == "gamma" {
+			provider.configureErr = errors.New("broker unreachable")
+		} else {
+			provider.On("Start", mock.Anything, mock.Anything).Return(nil)
+		}
+
+		testRegistry.RegisterProvider(&lifecycleProviderFactory{id: id, provider: provider})
+	}
+
+	manager := NewProviderManager("test-manager", file.NewPersistence(t.TempDir()), &mocks.MockSourceEventBus{}, logger, testRegistry, nil)
+	manager.SetStartConcurrency(3)
+
+	started := time.Now()
+	err := manager.startProviders(context.Background())
+	elapsed := time.Since(started)
+
+	// The failing provider is reported, attributed to its ID
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "provider gamma: broker unreachable")
+
+	// The other providers were configured and are running
+	assert.Len(t, manager.runningProviders, len(providerIDs)-1)
+	assert.NotContains(t, manager.runningProviders, "gamma")
+
+	// Providers were configured concurrently, within the limit
+	assert.Greater(t, tracker.maxInFlight, 1)
+	assert.LessOrEqual(t, tracker.maxInFlight, 3)
+	assert.Less(t, elapsed, time.Duration(len(providerIDs))*50*time.Millisecond)
+}
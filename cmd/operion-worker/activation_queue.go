@@ -0,0 +1,167 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+)
+
+// ActivationQueue is a bounded priority queue of node activations waiting for a worker slot.
+//
+// Activations with a higher priority are dequeued first. To keep low-priority activations
+// from starving, an activation gains one priority level for every aging interval it waits,
+// so an activation enqueued long enough ago runs before newer, more urgent ones. Once the queue
+// holds its capacity, Push blocks until an activation is dequeued, which holds back the consumer
+// of the event bus.
+type ActivationQueue struct {
+	mu     sync.Mutex
+	items  activationHeap
+	seq    uint64
+	aging  time.Duration
+	now    func() time.Time
+	notify chan struct{}
+	slots  chan struct{}
+}
+
+// NewActivationQueue creates an empty queue holding up to capacity activations, whose activations
+// gain one priority level per aging interval.
+func NewActivationQueue(aging time.Duration, capacity int) *ActivationQueue {
+	if capacity < 1 {
+		capacity = DefaultQueueCapacity
+	}
+
+	return &ActivationQueue{
+		aging:  aging,
+		now:    time.Now,
+		notify: make(chan struct{}, 1),
+		slots:  make(chan struct{}, capacity),
+	}
+}
+
+// Push enqueues an activation with the given priority, blocking while the queue is full. done is
+// called by the worker once the activation is processed. It returns false when ctx is done first.
+func (q *ActivationQueue) Push(ctx context.Context, activation *events.NodeActivation, priority int, done func()) bool {
+	select {
+	case q.slots <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+
+	q.mu.Lock()
+
+	q.seq++
+	heap.Push(&q.items, &queuedActivation{
+		activation: activation,
+		done:       done,
+		rank:       q.rank(priority, q.now()),
+		seq:        q.seq,
+	})
+
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// Pop blocks until an activation is available and dequeues the most urgent one, with the function
+// to call once it is processed. It returns false when ctx is done.
+func (q *ActivationQueue) Pop(ctx context.Context) (*events.NodeActivation, func(), bool) {
+	for {
+		q.mu.Lock()
+
+		if q.items.Len() > 0 {
+			item, _ := heap.Pop(&q.items).(*queuedActivation)
+			remaining := q.items.Len()
+
+			q.mu.Unlock()
+
+			<-q.slots
+
+			// Wake another waiter for the remaining activations
+			if remaining > 0 {
+				select {
+				case q.notify <- struct{}{}:
+				default:
+				}
+			}
+
+			done := item.done
+			if done == nil {
+				done = func() {}
+			}
+
+			return item.activation, done, true
+		}
+
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, false
+		case <-q.notify:
+		}
+	}
+}
+
+// Len returns the number of queued activations.
+func (q *ActivationQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.items.Len()
+}
+
+// rank orders activations, lowest first. Aging raises every waiting activation's priority at
+// the same rate, so comparing "priority + waited/aging" between two activations reduces to
+// comparing "priority - enqueuedAt/aging", a value that does not change while they wait.
+func (q *ActivationQueue) rank(priority int, enqueuedAt time.Time) int64 {
+	if q.aging <= 0 {
+		return -int64(priority)
+	}
+
+	return enqueuedAt.UnixNano() - int64(priority)*q.aging.Nanoseconds()
+}
+
+type queuedActivation struct {
+	activation *events.NodeActivation
+	done       func()
+	rank       int64
+	seq        uint64
+}
+
+// activationHeap implements heap.Interface ordered by rank, then by arrival.
+type activationHeap []*queuedActivation
+
+func (h activationHeap) Len() int { return len(h) }
+
+func (h activationHeap) Less(i, j int) bool {
+	if h[i].rank != h[j].rank {
+		return h[i].rank < h[j].rank
+	}
+
+	return h[i].seq < h[j].seq
+}
+
+func (h activationHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *activationHeap) Push(x any) {
+	item, _ := x.(*queuedActivation)
+	*h = append(*h, item)
+}
+
+func (h *activationHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+
+	return item
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestQueue returns a queue with a manual clock.
+func newTestQueue(aging time.Duration) (*ActivationQueue, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	queue := NewActivationQueue(aging, 10)
+	queue.now = func() time.Time { return now }
+
+	return queue, &now
+}
+
+func popNodeIDs(t *testing.T, queue *ActivationQueue, count int) []string {
+	t.Helper()
+
+	ids := make([]string, 0, count)
+
+	for range count {
+		activation, _, ok := queue.Pop(t.Context())
+		require.True(t, ok)
+
+		ids = append(ids, activation.NodeID)
+	}
+
+	return ids
+}
+
+func TestActivationQueue_HigherPriorityFirst(t *testing.T) {
+	queue, _ := newTestQueue(time.Minute)
+
+	queue.Push(t.Context(), &events.NodeActivation{NodeID: "low-1"}, 0, nil)
+	queue.Push(t.Context(), &events.NodeActivation{NodeID: "high-1"}, 10, nil)
+	queue.Push(t.Context(), &events.NodeActivation{NodeID: "normal"}, 5, nil)
+	queue.Push(t.Context(), &events.NodeActivation{NodeID: "high-2"}, 10, nil)
+	queue.Push(t.Context(), &events.NodeActivation{NodeID: "low-2"}, 0, nil)
+
+	assert.Equal(t, 5, queue.Len())
+	assert.Equal(t, []string{"high-1", "high-2", "normal", "low-1", "low-2"}, popNodeIDs(t, queue, 5))
+	assert.Zero(t, queue.Len())
+}
+
+func TestActivationQueue_AgingAvoidsStarvation(t *testing.T) {
+	queue, now := newTestQueue(time.Minute)
+
+	queue.Push(t.Context(), &events.NodeActivation{NodeID: "low"}, 0, nil)
+
+	// Two levels apart: a high-priority activation arriving within two aging intervals still wins
+	*now = now.Add(90 * time.Second)
+	queue.Push(t.Context(), &events.NodeActivation{NodeID: "high-early"}, 2, nil)
+
+	// Once the low-priority activation has waited more than two intervals, it runs first
+	*now = now.Add(60 * time.Second)
+	queue.Push(t.Context(), &events.NodeActivation{NodeID: "high-late"}, 2, nil)
+
+	assert.Equal(t, []string{"high-early", "low", "high-late"}, popNodeIDs(t, queue, 3))
+}
+
+func TestActivationQueue_PopBlocksUntilPush(t *testing.T) {
+	queue, _ := newTestQueue(time.Minute)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	_, _, ok := queue.Pop(ctx)
+	assert.False(t, ok, "Pop returns when the context is done")
+
+	popped := make(chan string, 1)
+
+	go func() {
+		activation, _, ok := queue.Pop(t.Context())
+		if ok {
+			popped <- activation.NodeID
+		}
+	}()
+
+	queue.Push(t.Context(), &events.NodeActivation{NodeID: "late"}, 0, nil)
+
+	select {
+	case nodeID := <-popped:
+		assert.Equal(t, "late", nodeID)
+	case <-time.After(time.Second):
+		t.Fatal("Pop did not return after Push")
+	}
+}
+
+func TestActivationQueue_PushBlocksWhenFull(t *testing.T) {
+	queue := NewActivationQueue(time.Minute, 1)
+
+	require.True(t, queue.Push(t.Context(), &events.NodeActivation{NodeID: "first"}, 0, nil))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.False(t, queue.Push(ctx, &events.NodeActivation{NodeID: "second"}, 0, nil), "Push waits for a free slot")
+
+	pushed := make(chan bool, 1)
+
+	go func() {
+		pushed <- queue.Push(t.Context(), &events.NodeActivation{NodeID: "third"}, 0, nil)
+	}()
+
+	assert.Equal(t, []string{"first"}, popNodeIDs(t, queue, 1))
+
+	select {
+	case ok := <-pushed:
+		assert.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("Push did not return after Pop")
+	}
+
+	assert.Equal(t, 1, queue.Len())
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/alerting"
+	"github.com/dukex/operion/pkg/models"
+)
+
+// ConfigureAlerter sets the alerter notified when an execution fails, following the alerting
+// policy in the metadata of its workflow. A nil alerter disables the alerts.
+func (w *WorkerManager) ConfigureAlerter(alerter alerting.Alerter) {
+	if alerter == nil {
+		w.notifier = nil
+
+		return
+	}
+
+	w.notifier = alerting.NewNotifier(alerter, w.persistence.ExecutionContextRepository(), w.persistence.AlertRepository())
+}
+
+// executionFinished exports the record of an execution the worker moved to a terminal status and
+// alerts about it when it failed, unless its workflow (and so its alerting policy) is unknown. A
+// failed alert is logged and does not fail the execution.
+func (w *WorkerManager) executionFinished(ctx context.Context, wf *models.Workflow, execCtx *models.ExecutionContext) {
+	w.exportExecution(ctx, execCtx)
+
+	if w.notifier == nil || wf == nil {
+		return
+	}
+
+	alerted, err := w.notifier.ExecutionFinished(ctx, wf, execCtx)
+	if err != nil {
+		w.logger.ErrorContext(ctx, "Failed to alert on execution failure",
+			"workflow_id", execCtx.WorkflowID,
+			"execution_id", execCtx.ID,
+			"error", err)
+
+		return
+	}
+
+	if alerted {
+		w.logger.InfoContext(ctx, "Alerted on execution failure",
+			"workflow_id", execCtx.WorkflowID,
+			"execution_id", execCtx.ID)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/alerting"
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/dukex/operion/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAlerter struct {
+	mu     sync.Mutex
+	alerts []alerting.Alert
+}
+
+func (a *mockAlerter) Alert(_ context.Context, alert alerting.Alert) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.alerts = append(a.alerts, alert)
+
+	return nil
+}
+
+func TestWorkerManager_FailureRateAlerting(t *testing.T) {
+	tests := []struct {
+		name      string
+		completed int
+		failed    int
+		alerts    int
+	}{
+		// 2 completed then 4 failures: the rate reaches 50% on the second failure
+		{name: "above threshold", completed: 2, failed: 4, alerts: 1},
+		// 9 completed then 1 failure: 10%
+		{name: "below threshold", completed: 9, failed: 1, alerts: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			persistence := file.NewPersistence(t.TempDir())
+			logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			reg := registry.NewRegistry(logger)
+			reg.RegisterDefaultNodes()
+
+			workflow := &models.Workflow{
+				ID:     "alerting-workflow",
+				Name:   "Alerting Workflow",
+				Status: models.WorkflowStatusPublished,
+				Metadata: map[string]any{alerting.MetadataKey: map[string]any{
+					"policy": "failure_rate", "threshold": 0.5, "window": "1h", "min_executions": 4,
+				}},
+				Nodes: []*models.WorkflowNode{
+					{
+						ID: "fail", Type: "terminate", Category: models.CategoryTypeAction, Enabled: true,
+						Config: map[string]any{"status": "failed", "message": "payment gateway down"},
+					},
+				},
+			}
+			require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+
+			now := time.Now().UTC()
+			for i := range tt.completed {
+				completedAt := now
+				require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+					ID:          fmt.Sprintf("exec-completed-%d", i),
+					WorkflowID:  workflow.ID,
+					NodeResults: make(map[string]models.NodeResult),
+					Status:      models.ExecutionStatusCompleted,
+					CreatedAt:   now.Add(-time.Minute),
+					CompletedAt: &completedAt,
+				}))
+			}
+
+			eventBus := &MockEventBus{}
+			wm := NewWorkerManager("alerting-worker", persistence, eventBus, logger, reg)
+
+			alerter := &mockAlerter{}
+			wm.ConfigureAlerter(alerter)
+
+			for i := range tt.failed {
+				executionID := fmt.Sprintf("exec-failed-%d", i)
+				require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+					ID:          executionID,
+					WorkflowID:  workflow.ID,
+					NodeResults: make(map[string]models.NodeResult),
+					Status:      models.ExecutionStatusRunning,
+					CreatedAt:   time.Now().UTC(),
+				}))
+
+				require.NoError(t, wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+					BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+					WorkflowID:  workflow.ID,
+					ExecutionID: executionID,
+					NodeID:      "fail",
+					InputPort:   "main",
+					InputData:   map[string]any{},
+				}))
+
+				execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), executionID)
+				require.NoError(t, err)
+				require.Equal(t, models.ExecutionStatusFailed, execCtx.Status)
+			}
+
+			require.Len(t, alerter.alerts, tt.alerts)
+
+			if tt.alerts > 0 {
+				alert := alerter.alerts[0]
+				assert.Equal(t, workflow.ID, alert.WorkflowID)
+				assert.Equal(t, "exec-failed-1", alert.ExecutionID)
+				assert.Equal(t, "payment gateway down", alert.ErrorMessage)
+				assert.Equal(t, alerting.PolicyFailureRate, alert.Policy)
+				assert.InDelta(t, 0.5, alert.FailureRate, 0.001)
+				assert.Equal(t, 4, alert.Executions)
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+)
+
+// batchedPersistence is the persistence of the worker, whose execution contexts go through a
+// write buffer when node result batching is configured.
+type batchedPersistence struct {
+	persistence.Persistence
+
+	executions *bufferedExecutionContexts
+}
+
+func newBatchedPersistence(p persistence.Persistence) *batchedPersistence {
+	return &batchedPersistence{
+		Persistence: p,
+		executions:  &bufferedExecutionContexts{ExecutionContextRepository: p.ExecutionContextRepository()},
+	}
+}
+
+func (p *batchedPersistence) ExecutionContextRepository() persistence.ExecutionContextRepository {
+	return p.executions
+}
+
+// bufferedExecutionContexts buffers the updates of running executions and writes them on Flush,
+// so a workflow of many nodes is persisted once per flush instead of once per node. Reads of a
+// buffered execution return the buffered context. Updates moving an execution out of running
+// (paused, or a terminal status) are written through at once, since other processes act on them.
+//
+// A worker crash loses the updates buffered since the last flush: the persisted execution misses
+// the node results of nodes that already ran, and as their activations were committed once the
+// nodes ran, those nodes do not run again. Other workers read the persisted context, so batching
+// is only consistent when every activation of an execution is handled by the same worker.
+//
+// mu only guards the buffered contexts: writes to the persistence run without it, so a slow write
+// never holds back the updates of other executions.
+type bufferedExecutionContexts struct {
+	persistence.ExecutionContextRepository
+
+	mu      sync.Mutex
+	enabled bool
+	pending map[string][]byte
+
+	// writeTimeout bounds each write when degraded mode is configured, 0 waits for the persistence.
+	writeTimeout time.Duration
+	// maxDegraded bounds the executions buffered because their writes timed out.
+	maxDegraded int
+	degraded    map[string]bool
+	// degradedExecutions mirrors len(degraded), so metrics are read without waiting for writes.
+	degradedExecutions atomic.Int64
+}
+
+// enable starts buffering the updates of running executions.
+func (b *bufferedExecutionContexts) enable() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.enabled = true
+	b.pending = make(map[string][]byte)
+}
+
+// buffered returns a copy of the buffered context of an execution, nil when none is buffered.
+func (b *bufferedExecutionContexts) buffered(executionID string) (*models.ExecutionContext, error) {
+	b.mu.Lock()
+	data, ok := b.pending[executionID]
+	b.mu.Unlock()
+
+	if !ok {
+		return nil, nil
+	}
+
+	var execCtx models.ExecutionContext
+	if err := json.Unmarshal(data, &execCtx); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal buffered execution context %s: %w", executionID, err)
+	}
+
+	return &execCtx, nil
+}
+
+func (b *bufferedExecutionContexts) GetExecutionContext(ctx context.Context, executionID string) (*models.ExecutionContext, error) {
+	execCtx, err := b.buffered(executionID)
+	if err != nil || execCtx != nil {
+		return execCtx, err
+	}
+
+	return b.ExecutionContextRepository.GetExecutionContext(ctx, executionID)
+}
+
+func (b *bufferedExecutionContexts) UpdateExecutionContext(ctx context.Context, execCtx *models.ExecutionContext) error {
+	// Without batching nor degraded mode, updates go straight to the persistence
+	if b.passThrough() {
+		return b.ExecutionContextRepository.UpdateExecutionContext(ctx, execCtx)
+	}
+
+	b.mu.Lock()
+
+	// Updates of a degraded execution queue behind its buffered context, so writes stay in order,
+	// and updates of running executions wait for the next flush when batching
+	if b.degraded[execCtx.ID] || (b.enabled && execCtx.Status == models.ExecutionStatusRunning) {
+		defer b.mu.Unlock()
+
+		return b.buffer(execCtx)
+	}
+
+	delete(b.pending, execCtx.ID)
+	b.mu.Unlock()
+
+	err := b.withWriteTimeout(ctx, func(ctx context.Context) error {
+		return b.ExecutionContextRepository.UpdateExecutionContext(ctx, execCtx)
+	})
+	if !b.timedOut(ctx, err) {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.degraded) >= b.maxDegraded {
+		return err
+	}
+
+	b.setDegraded(execCtx.ID, true)
+
+	return b.buffer(execCtx)
+}
+
+// passThrough reports whether neither batching nor degraded mode is configured, both of which are
+// set before the worker starts.
+func (b *bufferedExecutionContexts) passThrough() bool {
+	return !b.enabled && b.writeTimeout <= 0
+}
+
+// buffer keeps the context of an execution until the next flush. The caller must hold b.mu.
+func (b *bufferedExecutionContexts) buffer(execCtx *models.ExecutionContext) error {
+	data, err := json.Marshal(execCtx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal execution context %s: %w", execCtx.ID, err)
+	}
+
+	b.pending[execCtx.ID] = data
+
+	return nil
+}
+
+// forget drops the buffered context of an execution, which is written or deleted directly.
+func (b *bufferedExecutionContexts) forget(executionID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.pending, executionID)
+	b.setDegraded(executionID, false)
+}
+
+func (b *bufferedExecutionContexts) SaveExecutionContext(ctx context.Context, execCtx *models.ExecutionContext) error {
+	if !b.passThrough() {
+		b.forget(execCtx.ID)
+	}
+
+	return b.ExecutionContextRepository.SaveExecutionContext(ctx, execCtx)
+}
+
+func (b *bufferedExecutionContexts) DeleteExecutionContext(ctx context.Context, executionID string) error {
+	if !b.passThrough() {
+		b.forget(executionID)
+	}
+
+	return b.ExecutionContextRepository.DeleteExecutionContext(ctx, executionID)
+}
+
+func (b *bufferedExecutionContexts) GetExecutionsByWorkflow(ctx context.Context, workflowID string) ([]*models.ExecutionContext, error) {
+	executions, err := b.ExecutionContextRepository.GetExecutionsByWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.withBuffered(executions)
+}
+
+func (b *bufferedExecutionContexts) GetExecutionsByStatus(ctx context.Context, status models.ExecutionStatus) ([]*models.ExecutionContext, error) {
+	executions, err := b.ExecutionContextRepository.GetExecutionsByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.withBuffered(executions)
+}
+
+func (b *bufferedExecutionContexts) GetExecutionByApprovalToken(ctx context.Context, token string) (*models.ExecutionContext, error) {
+	execCtx, err := b.ExecutionContextRepository.GetExecutionByApprovalToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if buffered, err := b.buffered(execCtx.ID); err != nil || buffered != nil {
+		return buffered, err
+	}
+
+	return execCtx, nil
+}
+
+func (b *bufferedExecutionContexts) GetExecutionsWithExpiredApprovals(ctx context.Context, now time.Time) ([]*models.ExecutionContext, error) {
+	executions, err := b.ExecutionContextRepository.GetExecutionsWithExpiredApprovals(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.withBuffered(executions)
+}
+
+// ClaimRetry takes the retry from the buffered context of an execution when it has one, as the
+// next flush overwrites its persisted context, and from the persistence otherwise.
+func (b *bufferedExecutionContexts) ClaimRetry(ctx context.Context, executionID string, budget int) (bool, error) {
+	b.mu.Lock()
+
+	data, ok := b.pending[executionID]
+	if !ok {
+		b.mu.Unlock()
+
+		return b.ExecutionContextRepository.ClaimRetry(ctx, executionID, budget)
+	}
+
+	defer b.mu.Unlock()
+
+	var execCtx models.ExecutionContext
+	if err := json.Unmarshal(data, &execCtx); err != nil {
+		return false, fmt.Errorf("failed to unmarshal buffered execution context %s: %w", executionID, err)
+	}
+
+	if !execCtx.UseRetry(budget) {
+		return false, nil
+	}
+
+	return true, b.buffer(&execCtx)
+}
+
+// withBuffered replaces the persisted executions that have buffered updates with their buffered context.
+func (b *bufferedExecutionContexts) withBuffered(executions []*models.ExecutionContext) ([]*models.ExecutionContext, error) {
+	for i, execution := range executions {
+		execCtx, err := b.buffered(execution.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if execCtx != nil {
+			executions[i] = execCtx
+		}
+	}
+
+	return executions, nil
+}
+
+func (b *bufferedExecutionContexts) ListNodeResults(ctx context.Context, executionID string, query models.NodeResultQuery) (*models.NodeResultPage, error) {
+	execCtx, err := b.buffered(executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if execCtx == nil {
+		return b.ExecutionContextRepository.ListNodeResults(ctx, executionID, query)
+	}
+
+	return models.PageNodeResults(execCtx.NodeResults, query)
+}
+
+// Flush writes the buffered execution contexts, each with a single save (one upsert statement in
+// PostgreSQL). Contexts that fail to save stay buffered for the next flush; after a write timeout
+// the rest are left for the next flush too, as the persistence is still degraded. The contexts
+// are written without holding b.mu, and a context updated again while it was written stays
+// buffered with the update.
+func (b *bufferedExecutionContexts) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	pending := maps.Clone(b.pending)
+	b.mu.Unlock()
+
+	var errs []error
+
+	for executionID, data := range pending {
+		var execCtx models.ExecutionContext
+		if err := json.Unmarshal(data, &execCtx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to unmarshal buffered execution context %s: %w", executionID, err))
+
+			continue
+		}
+
+		err := b.withWriteTimeout(ctx, func(ctx context.Context) error {
+			return b.ExecutionContextRepository.SaveExecutionContext(ctx, &execCtx)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush execution context %s: %w", executionID, err))
+
+			if b.timedOut(ctx, err) {
+				break
+			}
+
+			continue
+		}
+
+		b.mu.Lock()
+
+		if current, ok := b.pending[executionID]; ok && bytes.Equal(current, data) {
+			delete(b.pending, executionID)
+			b.setDegraded(executionID, false)
+		}
+
+		b.mu.Unlock()
+	}
+
+	return errors.Join(errs...)
+}
+
+// ConfigureNodeResultBatching buffers the execution context updates of running executions and
+// writes them every interval, instead of after each node; paused and finished executions are
+// written at once. A zero interval persists after each node. See bufferedExecutionContexts for
+// the crash-safety tradeoff. It must be called before Start.
+func (w *WorkerManager) ConfigureNodeResultBatching(interval time.Duration) {
+	w.batchInterval = interval
+
+	if interval > 0 {
+		w.persistence.executions.enable()
+	}
+}
+
+// flushNodeResults writes the buffered execution contexts every batch interval until ctx is done.
+func (w *WorkerManager) flushNodeResults(ctx context.Context) {
+	ticker := time.NewTicker(w.batchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.persistence.executions.Flush(ctx); err != nil {
+				w.logger.ErrorContext(ctx, "Failed to flush execution contexts", "error", err)
+			}
+		}
+	}
+}
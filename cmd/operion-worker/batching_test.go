@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/persistence/memory"
+	"github.com/dukex/operion/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingPersistence counts the writes of execution contexts.
+type countingPersistence struct {
+	persistence.Persistence
+
+	executions *countingExecutionContexts
+}
+
+func (p *countingPersistence) ExecutionContextRepository() persistence.ExecutionContextRepository {
+	return p.executions
+}
+
+type countingExecutionContexts struct {
+	persistence.ExecutionContextRepository
+
+	writes atomic.Int64
+}
+
+func (c *countingExecutionContexts) SaveExecutionContext(ctx context.Context, execCtx *models.ExecutionContext) error {
+	c.writes.Add(1)
+
+	return c.ExecutionContextRepository.SaveExecutionContext(ctx, execCtx)
+}
+
+func (c *countingExecutionContexts) UpdateExecutionContext(ctx context.Context, execCtx *models.ExecutionContext) error {
+	c.writes.Add(1)
+
+	return c.ExecutionContextRepository.UpdateExecutionContext(ctx, execCtx)
+}
+
+// setupChainWorkflow stores a workflow of length transform nodes, each rendering the result of the
+// node before it, and returns a worker running on persistence counting execution context writes.
+func setupChainWorkflow(tb testing.TB, length int) (*WorkerManager, *MockEventBus, *countingPersistence) {
+	tb.Helper()
+
+	p := memory.NewPersistence()
+	counting := &countingPersistence{
+		Persistence: p,
+		executions:  &countingExecutionContexts{ExecutionContextRepository: p.ExecutionContextRepository()},
+	}
+
+	workflow := &models.Workflow{
+		ID:     "chain-workflow",
+		Name:   "Chain Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "node-0", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": "0"}, Enabled: true},
+		},
+	}
+
+	for i := 1; i < length; i++ {
+		previous := models.MakeNodeResultKey(fmt.Sprintf("node-%d", i-1), "success")
+		workflow.Nodes = append(workflow.Nodes, &models.WorkflowNode{
+			ID: fmt.Sprintf("node-%d", i), Type: "transform", Category: models.CategoryTypeAction, Enabled: true,
+			Config: map[string]any{"expression": fmt.Sprintf(`{{index .node_results %q "result"}} %d`, previous, i)},
+		})
+		workflow.Connections = append(workflow.Connections, &models.Connection{
+			ID:         fmt.Sprintf("conn-%d", i),
+			SourcePort: fmt.Sprintf("node-%d:success", i-1),
+			TargetPort: fmt.Sprintf("node-%d:main", i),
+		})
+	}
+
+	require.NoError(tb, p.WorkflowRepository().Save(context.Background(), workflow))
+
+	logger := slog.New(slog.DiscardHandler)
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	eventBus := &MockEventBus{}
+
+	return NewWorkerManager("batch-worker", counting, eventBus, logger, reg), eventBus, counting
+}
+
+// runChain runs an execution of the chain workflow through every node, flushing buffered results.
+func runChain(tb testing.TB, wm *WorkerManager, eventBus *MockEventBus, executionID string) {
+	tb.Helper()
+
+	ctx := context.Background()
+
+	require.NoError(tb, wm.persistence.ExecutionContextRepository().SaveExecutionContext(ctx, &models.ExecutionContext{
+		ID:          executionID,
+		WorkflowID:  "chain-workflow",
+		NodeResults: make(map[string]models.NodeResult),
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	eventBus.publishedEvents = nil
+
+	_, err := wm.persistence.ExecutionContextRepository().AddPendingActivations(ctx, executionID, 1)
+	require.NoError(tb, err)
+
+	require.NoError(tb, wm.handleNodeActivation(ctx, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, "chain-workflow"),
+		WorkflowID:  "chain-workflow",
+		ExecutionID: executionID,
+		NodeID:      "node-0",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	}))
+
+	for processed := 0; processed < len(activatedNodes(eventBus)); processed++ {
+		require.NoError(tb, wm.handleNodeActivation(ctx, activatedNodes(eventBus)[processed]))
+	}
+
+	require.NoError(tb, wm.persistence.executions.Flush(ctx))
+}
+
+func TestWorkerManager_NodeResultBatching_PersistsFinalContext(t *testing.T) {
+	wm, eventBus, counting := setupChainWorkflow(t, 5)
+	wm.ConfigureNodeResultBatching(time.Hour)
+
+	runChain(t, wm, eventBus, "exec-batched")
+
+	// The execution was created and written once when it completed, not updated after each of the 5 nodes
+	assert.Equal(t, int64(2), counting.executions.writes.Load())
+
+	execCtx, err := counting.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-batched")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusCompleted, execCtx.Status)
+	require.Len(t, execCtx.NodeResults, 5)
+
+	// Each node read the buffered result of the node before it
+	last := execCtx.NodeResults[models.MakeNodeResultKey("node-4", "success")]
+	assert.Equal(t, "0 1 2 3 4", last.Data["result"])
+}
+
+func TestWorkerManager_NodeResultBatching_WritesThroughOutOfRunning(t *testing.T) {
+	wm, _, counting := setupChainWorkflow(t, 1)
+	wm.ConfigureNodeResultBatching(time.Hour)
+
+	executions := wm.persistence.ExecutionContextRepository()
+
+	require.NoError(t, executions.SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID: "exec-paused", WorkflowID: "chain-workflow", Status: models.ExecutionStatusRunning,
+	}))
+
+	execCtx, err := executions.GetExecutionContext(t.Context(), "exec-paused")
+	require.NoError(t, err)
+
+	execCtx.Variables = map[string]any{"step": "buffered"}
+	require.NoError(t, executions.UpdateExecutionContext(t.Context(), execCtx))
+
+	stored, err := counting.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-paused")
+	require.NoError(t, err)
+	assert.Empty(t, stored.Variables, "running updates stay buffered")
+
+	execCtx.Status = models.ExecutionStatusPaused
+	require.NoError(t, executions.UpdateExecutionContext(t.Context(), execCtx))
+
+	stored, err = counting.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-paused")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusPaused, stored.Status)
+	assert.Equal(t, "buffered", stored.Variables["step"])
+
+	// Nothing is left to flush
+	require.NoError(t, wm.persistence.executions.Flush(t.Context()))
+	assert.Equal(t, int64(2), counting.executions.writes.Load())
+}
+
+// blockingExecutionContexts holds every write of an execution until release is closed.
+type blockingExecutionContexts struct {
+	persistence.ExecutionContextRepository
+
+	blockedID string
+	blocked   chan struct{}
+	release   chan struct{}
+}
+
+func (b *blockingExecutionContexts) UpdateExecutionContext(ctx context.Context, execCtx *models.ExecutionContext) error {
+	if execCtx.ID == b.blockedID {
+		close(b.blocked)
+		<-b.release
+	}
+
+	return b.ExecutionContextRepository.UpdateExecutionContext(ctx, execCtx)
+}
+
+func TestBufferedExecutionContexts_SlowWriteDoesNotHoldOtherExecutions(t *testing.T) {
+	stored := memory.NewPersistence().ExecutionContextRepository()
+	blocking := &blockingExecutionContexts{
+		ExecutionContextRepository: stored,
+		blockedID:                  "exec-slow",
+		blocked:                    make(chan struct{}),
+		release:                    make(chan struct{}),
+	}
+
+	executions := &bufferedExecutionContexts{ExecutionContextRepository: blocking}
+	executions.enable()
+
+	for _, executionID := range []string{"exec-slow", "exec-fast"} {
+		require.NoError(t, stored.SaveExecutionContext(t.Context(), &models.ExecutionContext{ID: executionID, Status: models.ExecutionStatusRunning}))
+	}
+
+	// A finished execution is written through, and its write hangs
+	written := make(chan error, 1)
+
+	go func() {
+		written <- executions.UpdateExecutionContext(t.Context(), &models.ExecutionContext{ID: "exec-slow", Status: models.ExecutionStatusCompleted})
+	}()
+
+	<-blocking.blocked
+
+	// Meanwhile other executions are buffered and flushed
+	require.NoError(t, executions.UpdateExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:     "exec-fast",
+		Status: models.ExecutionStatusRunning,
+		NodeResults: map[string]models.NodeResult{
+			models.MakeNodeResultKey("node", "success"): {NodeID: "node", Status: string(models.NodeStatusSuccess)},
+		},
+	}))
+	require.NoError(t, executions.Flush(t.Context()))
+
+	flushed, err := stored.GetExecutionContext(t.Context(), "exec-fast")
+	require.NoError(t, err)
+	assert.Len(t, flushed.NodeResults, 1)
+
+	close(blocking.release)
+	require.NoError(t, <-written)
+}
+
+func BenchmarkWorkerManager_NodeResultPersistence(b *testing.B) {
+	for _, bench := range []struct {
+		name     string
+		interval time.Duration
+	}{
+		{name: "per-node", interval: 0},
+		{name: "batched", interval: time.Hour},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			wm, eventBus, counting := setupChainWorkflow(b, 20)
+			wm.ConfigureNodeResultBatching(bench.interval)
+
+			for i := range b.N {
+				runChain(b, wm, eventBus, fmt.Sprintf("exec-%d", i))
+			}
+
+			b.ReportMetric(float64(counting.executions.writes.Load())/float64(b.N), "writes/op")
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+)
+
+// publishActivation publishes a node activation of an execution, counting it as pending until a
+// worker handled it. The count is taken before publishing, so the execution cannot complete
+// between the publish and the count, and given back when the publish fails.
+func (w *WorkerManager) publishActivation(ctx context.Context, activation *events.NodeActivation) error {
+	repo := w.persistence.ExecutionContextRepository()
+
+	if _, err := repo.AddPendingActivations(ctx, activation.ExecutionID, 1); err != nil {
+		return err
+	}
+
+	if err := w.eventBus.Publish(ctx, activation.NodeID+":"+activation.ExecutionID, activation); err != nil {
+		if _, countErr := repo.AddPendingActivations(ctx, activation.ExecutionID, -1); countErr != nil {
+			w.logger.WarnContext(ctx, "Failed to uncount unpublished activation",
+				"execution_id", activation.ExecutionID,
+				"node_id", activation.NodeID,
+				"error", countErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// activationHandled counts an activation as handled, once the worker is done with it and with the
+// activations it led to, and completes its execution when no activation of it is pending anymore.
+// Activations published before pending activations were counted take the count below zero and
+// complete nothing.
+func (w *WorkerManager) activationHandled(ctx context.Context, activation *events.NodeActivation) {
+	pending, err := w.persistence.ExecutionContextRepository().AddPendingActivations(ctx, activation.ExecutionID, -1)
+	if err != nil {
+		w.logger.WarnContext(ctx, "Failed to count handled activation",
+			"execution_id", activation.ExecutionID,
+			"node_id", activation.NodeID,
+			"error", err)
+
+		return
+	}
+
+	if pending == 0 {
+		w.completeExecution(ctx, activation)
+	}
+}
+
+// completeExecution marks an execution completed once its last pending activation was handled.
+// Only a running execution completes: a paused execution waits for a decision or an event, and a
+// terminated one already finished.
+func (w *WorkerManager) completeExecution(ctx context.Context, activation *events.NodeActivation) {
+	logger := w.logger.With("workflow_id", activation.WorkflowID, "execution_id", activation.ExecutionID)
+
+	execCtx, err := w.persistence.ExecutionContextRepository().GetExecutionContext(ctx, activation.ExecutionID)
+	if err != nil || execCtx == nil {
+		logger.ErrorContext(ctx, "Failed to get execution context for completion", "error", err)
+
+		return
+	}
+
+	if execCtx.Status != models.ExecutionStatusRunning {
+		return
+	}
+
+	execCtx.Status = models.ExecutionStatusCompleted
+
+	completedAt := time.Now().UTC()
+	execCtx.CompletedAt = &completedAt
+
+	if err := w.persistence.ExecutionContextRepository().UpdateExecutionContext(ctx, execCtx); err != nil {
+		logger.ErrorContext(ctx, "Failed to update completed execution context", "error", err)
+
+		return
+	}
+
+	logger.InfoContext(ctx, "Execution completed")
+
+	w.publishExecutionTerminatedEvent(ctx, execCtx, models.Termination{
+		NodeID: activation.NodeID,
+		Status: models.ExecutionStatusCompleted,
+	})
+
+	wf, err := w.pinnedWorkflow(ctx, activation.WorkflowID)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to get workflow of completed execution", "error", err)
+	}
+
+	w.executionFinished(ctx, wf, execCtx)
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// defaultPersistenceRetryBackoff is the first backoff between retries when none is configured.
+const defaultPersistenceRetryBackoff = time.Second
+
+// maxPersistenceRetryBackoff caps the backoff between retries of writes that timed out.
+const maxPersistenceRetryBackoff = 30 * time.Second
+
+// pgQueryCanceled is the SQLSTATE of PostgreSQL statements cancelled on request.
+const pgQueryCanceled = "57014"
+
+// enableDegradedMode bounds each execution context write by writeTimeout. A write that times out
+// is buffered, for at most maxDegraded executions at a time, and the execution continues as if it
+// was written: reads return the buffered context and later updates replace it until a flush
+// writes it. Once maxDegraded executions are buffered, timeouts fail the write as before.
+//
+// Buffered contexts only live in the worker: a crash, or a shutdown while the persistence is still
+// degraded, loses them (up to maxDegraded executions, each missing the updates since its write
+// timed out), and other workers, the API and the activator read the persisted context meanwhile.
+func (b *bufferedExecutionContexts) enableDegradedMode(writeTimeout time.Duration, maxDegraded int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.writeTimeout = writeTimeout
+	b.maxDegraded = maxDegraded
+	b.degraded = make(map[string]bool)
+
+	if b.pending == nil {
+		b.pending = make(map[string][]byte)
+	}
+}
+
+// withWriteTimeout runs write with the write timeout, if any, applied to ctx.
+func (b *bufferedExecutionContexts) withWriteTimeout(ctx context.Context, write func(ctx context.Context) error) error {
+	if b.writeTimeout <= 0 {
+		return write(ctx)
+	}
+
+	writeCtx, cancel := context.WithTimeout(ctx, b.writeTimeout)
+	defer cancel()
+
+	return write(writeCtx)
+}
+
+// timedOut reports whether err is a write timeout, as opposed to a failure or the cancellation of ctx.
+// lib/pq reports a statement cancelled at the write timeout as a query_canceled error rather than
+// the deadline of its context, so both count as timeouts.
+func (b *bufferedExecutionContexts) timedOut(ctx context.Context, err error) bool {
+	if b.writeTimeout <= 0 || ctx.Err() != nil {
+		return false
+	}
+
+	var pqErr *pq.Error
+
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &pqErr) && pqErr.Code == pgQueryCanceled)
+}
+
+// setDegraded marks or unmarks an execution as buffered because its write timed out. The caller
+// must hold b.mu.
+func (b *bufferedExecutionContexts) setDegraded(executionID string, degraded bool) {
+	if degraded {
+		b.degraded[executionID] = true
+	} else {
+		delete(b.degraded, executionID)
+	}
+
+	b.degradedExecutions.Store(int64(len(b.degraded)))
+}
+
+// degradedCount returns the number of executions buffered because their writes timed out.
+func (b *bufferedExecutionContexts) degradedCount() int {
+	return int(b.degradedExecutions.Load())
+}
+
+// ConfigurePersistenceDegradedMode bounds execution context writes by writeTimeout. Writes that
+// time out are buffered for up to maxDegraded executions and retried with a backoff starting at
+// retryBackoff, doubling up to maxPersistenceRetryBackoff, so workflows keep running while the
+// persistence is slow. See enableDegradedMode for the data loss bounds. A zero writeTimeout
+// disables degraded mode. It must be called before Start.
+func (w *WorkerManager) ConfigurePersistenceDegradedMode(writeTimeout time.Duration, maxDegraded int, retryBackoff time.Duration) {
+	if writeTimeout <= 0 {
+		return
+	}
+
+	if retryBackoff <= 0 {
+		retryBackoff = defaultPersistenceRetryBackoff
+	}
+
+	w.persistenceRetryBackoff = retryBackoff
+	w.persistence.executions.enableDegradedMode(writeTimeout, maxDegraded)
+}
+
+// retryDegradedWrites flushes the buffered execution contexts while writes have timed out, backing
+// off while the persistence is still degraded, until ctx is done.
+func (w *WorkerManager) retryDegradedWrites(ctx context.Context) {
+	backoff := w.persistenceRetryBackoff
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		degraded := w.persistence.executions.degradedCount()
+		if degraded == 0 {
+			backoff = w.persistenceRetryBackoff
+
+			continue
+		}
+
+		if err := w.persistence.executions.Flush(ctx); err != nil {
+			backoff = min(backoff*2, maxPersistenceRetryBackoff)
+			w.logger.WarnContext(ctx, "Persistence degraded, retrying buffered execution contexts",
+				"degraded_executions", w.persistence.executions.degradedCount(),
+				"retry_in", backoff,
+				"error", err)
+
+			continue
+		}
+
+		w.logger.InfoContext(ctx, "Persistence recovered, buffered execution contexts written", "executions", degraded)
+
+		backoff = w.persistenceRetryBackoff
+	}
+}
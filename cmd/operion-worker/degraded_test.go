@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyExecutionContexts stalls every other write until its context is done, like a degraded
+// database timing out intermittently. Stalled writes fail as lib/pq fails statements it cancels.
+type flakyExecutionContexts struct {
+	persistence.ExecutionContextRepository
+
+	writes atomic.Int64
+	stalls atomic.Int64
+
+	// stallAll stalls every write instead of every other one
+	stallAll bool
+}
+
+func (f *flakyExecutionContexts) stall(ctx context.Context) error {
+	if f.writes.Add(1)%2 == 0 && !f.stallAll {
+		return nil
+	}
+
+	f.stalls.Add(1)
+	<-ctx.Done()
+
+	return fmt.Errorf("failed to update execution context: %w", &pq.Error{
+		Severity: "ERROR",
+		Code:     "57014",
+		Message:  "canceling statement due to user request",
+	})
+}
+
+func (f *flakyExecutionContexts) SaveExecutionContext(ctx context.Context, execCtx *models.ExecutionContext) error {
+	if err := f.stall(ctx); err != nil {
+		return err
+	}
+
+	return f.ExecutionContextRepository.SaveExecutionContext(ctx, execCtx)
+}
+
+func (f *flakyExecutionContexts) UpdateExecutionContext(ctx context.Context, execCtx *models.ExecutionContext) error {
+	if err := f.stall(ctx); err != nil {
+		return err
+	}
+
+	return f.ExecutionContextRepository.UpdateExecutionContext(ctx, execCtx)
+}
+
+// setupFlakyChainWorkflow returns a worker running the chain workflow on persistence whose
+// execution context writes time out intermittently, and the underlying execution contexts.
+func setupFlakyChainWorkflow(t *testing.T, length int) (*WorkerManager, *MockEventBus, persistence.ExecutionContextRepository) {
+	t.Helper()
+
+	wm, eventBus, counting := setupChainWorkflow(t, length)
+	stored := counting.executions.ExecutionContextRepository
+
+	flaky := &flakyExecutionContexts{ExecutionContextRepository: stored}
+	wm.persistence.executions.ExecutionContextRepository = flaky
+
+	return wm, eventBus, stored
+}
+
+func TestWorkerManager_PersistenceDegraded_WorkflowContinuesAndRetries(t *testing.T) {
+	wm, eventBus, stored := setupFlakyChainWorkflow(t, 5)
+	wm.ConfigurePersistenceDegradedMode(20*time.Millisecond, 10, 10*time.Millisecond)
+
+	require.NoError(t, stored.SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          "exec-degraded",
+		WorkflowID:  "chain-workflow",
+		NodeResults: make(map[string]models.NodeResult),
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	require.NoError(t, wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, "chain-workflow"),
+		WorkflowID:  "chain-workflow",
+		ExecutionID: "exec-degraded",
+		NodeID:      "node-0",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	}))
+
+	for processed := 0; processed < len(activatedNodes(eventBus)); processed++ {
+		require.NoError(t, wm.handleNodeActivation(t.Context(), activatedNodes(eventBus)[processed]))
+	}
+
+	// Every node ran, reading the buffered results of the nodes before it
+	execCtx, err := wm.persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-degraded")
+	require.NoError(t, err)
+	require.Len(t, execCtx.NodeResults, 5)
+	assert.Equal(t, "0 1 2 3 4", execCtx.NodeResults[models.MakeNodeResultKey("node-4", "success")].Data["result"])
+	assert.Equal(t, 1, wm.persistence.executions.degradedCount())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	go wm.retryDegradedWrites(ctx)
+
+	// The buffered context is written once a retry gets through
+	assert.Eventually(t, func() bool {
+		return wm.persistence.executions.degradedCount() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	persisted, err := stored.GetExecutionContext(t.Context(), "exec-degraded")
+	require.NoError(t, err)
+	require.Len(t, persisted.NodeResults, 5)
+	assert.Equal(t, "0 1 2 3 4", persisted.NodeResults[models.MakeNodeResultKey("node-4", "success")].Data["result"])
+}
+
+func TestWorkerManager_PersistenceDegraded_BoundsBufferedExecutions(t *testing.T) {
+	wm, _, stored := setupFlakyChainWorkflow(t, 1)
+	wm.ConfigurePersistenceDegradedMode(10*time.Millisecond, 1, time.Hour)
+
+	executions := wm.persistence.ExecutionContextRepository()
+
+	for _, executionID := range []string{"exec-1", "exec-2"} {
+		require.NoError(t, stored.SaveExecutionContext(t.Context(), &models.ExecutionContext{
+			ID: executionID, WorkflowID: "chain-workflow", Status: models.ExecutionStatusRunning,
+		}))
+	}
+
+	// The first write times out and is buffered
+	require.NoError(t, executions.UpdateExecutionContext(t.Context(), &models.ExecutionContext{
+		ID: "exec-1", WorkflowID: "chain-workflow", Status: models.ExecutionStatusRunning,
+		Variables: map[string]any{"step": "buffered"},
+	}))
+
+	// The next update of a buffered execution queues behind it, even though the write would succeed
+	require.NoError(t, executions.UpdateExecutionContext(t.Context(), &models.ExecutionContext{
+		ID: "exec-1", WorkflowID: "chain-workflow", Status: models.ExecutionStatusCompleted,
+		Variables: map[string]any{"step": "latest"},
+	}))
+
+	execCtx, err := executions.GetExecutionContext(t.Context(), "exec-1")
+	require.NoError(t, err)
+	assert.Equal(t, "latest", execCtx.Variables["step"])
+
+	// A healthy write goes through, a timeout beyond the bound fails
+	require.NoError(t, executions.UpdateExecutionContext(t.Context(), &models.ExecutionContext{
+		ID: "exec-2", WorkflowID: "chain-workflow", Status: models.ExecutionStatusRunning,
+	}))
+	var pqErr *pq.Error
+
+	require.ErrorAs(t, executions.UpdateExecutionContext(t.Context(), &models.ExecutionContext{
+		ID: "exec-2", WorkflowID: "chain-workflow", Status: models.ExecutionStatusRunning,
+	}), &pqErr)
+	assert.Equal(t, 1, wm.persistence.executions.degradedCount())
+}
+
+func TestWorkerManager_PersistenceDegraded_TimeoutsDoNotQueueBehindEachOther(t *testing.T) {
+	const writers = 5
+
+	wm, _, stored := setupFlakyChainWorkflow(t, 1)
+	wm.ConfigurePersistenceDegradedMode(100*time.Millisecond, writers, time.Hour)
+
+	// Every write stalls until it times out
+	flaky, _ := wm.persistence.executions.ExecutionContextRepository.(*flakyExecutionContexts)
+	flaky.stallAll = true
+
+	var wg sync.WaitGroup
+
+	start := time.Now()
+
+	for i := range writers {
+		executionID := fmt.Sprintf("exec-%d", i)
+		require.NoError(t, stored.SaveExecutionContext(t.Context(), &models.ExecutionContext{
+			ID: executionID, WorkflowID: "chain-workflow", Status: models.ExecutionStatusRunning,
+		}))
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, wm.persistence.ExecutionContextRepository().UpdateExecutionContext(t.Context(), &models.ExecutionContext{
+				ID: executionID, WorkflowID: "chain-workflow", Status: models.ExecutionStatusRunning,
+			}))
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Less(t, time.Since(start), 3*100*time.Millisecond, "concurrent writes time out together")
+	assert.Equal(t, writers, wm.persistence.executions.degradedCount())
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukex/operion/pkg/executionsink"
+	"github.com/dukex/operion/pkg/models"
+)
+
+// ConfigureExecutionSink sets the sink receiving a record of every execution the worker moves to a
+// terminal status, for analytics in a data warehouse. A nil sink disables the export.
+func (w *WorkerManager) ConfigureExecutionSink(sink executionsink.Sink) {
+	w.sink = sink
+}
+
+// exportExecution sends the record of a finished execution to the configured sink. It is only
+// called from executionFinished, which every terminal transition of the worker goes through, so
+// completed, failed and cancelled executions are all exported. A failed export is logged and does
+// not fail the execution.
+func (w *WorkerManager) exportExecution(ctx context.Context, execCtx *models.ExecutionContext) {
+	if w.sink == nil || !execCtx.Status.IsTerminal() {
+		return
+	}
+
+	record := executionsink.NewRecord(execCtx, time.Now())
+
+	if err := w.sink.Export(ctx, record); err != nil {
+		w.logger.ErrorContext(ctx, "Failed to export execution record",
+			"workflow_id", execCtx.WorkflowID,
+			"execution_id", execCtx.ID,
+			"status", execCtx.Status,
+			"error", err)
+	}
+}
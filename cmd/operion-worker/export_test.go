@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/executionsink"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSink records the execution records exported to it.
+type recordingSink struct {
+	mu      sync.Mutex
+	records []executionsink.Record
+}
+
+func (s *recordingSink) Export(ctx context.Context, record executionsink.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, record)
+
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	return nil
+}
+
+func TestWorkerManager_ExportExecution_Completed(t *testing.T) {
+	wm, eventBus, persistence := setupRepeatWorkflow(t, &models.Workflow{
+		ID:     "export-workflow",
+		Name:   "Export Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "fetch", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": "fetched"}, Enabled: true},
+			{ID: "notify", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "notified"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "conn-1", SourcePort: "fetch:success", TargetPort: "notify:main"},
+		},
+	})
+
+	sink := &recordingSink{}
+	wm.ConfigureExecutionSink(sink)
+
+	ranNodes := runActivations(t, wm, eventBus, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, "export-workflow"),
+		WorkflowID:  "export-workflow",
+		ExecutionID: "exec-export-workflow",
+		NodeID:      "fetch",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	})
+	assert.Equal(t, []string{"fetch", "notify"}, ranNodes)
+
+	// The execution completed once its last node was handled, and was exported once
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-export-workflow")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusCompleted, execCtx.Status)
+	require.NotNil(t, execCtx.CompletedAt)
+
+	require.Len(t, sink.records, 1)
+	record := sink.records[0]
+
+	assert.Equal(t, "exec-export-workflow", record.ExecutionID)
+	assert.Equal(t, "export-workflow", record.WorkflowID)
+	assert.Equal(t, "completed", record.Status)
+	assert.Equal(t, execCtx.CompletedAt.UTC(), record.CompletedAt.UTC())
+	assert.Equal(t, 2, record.NodeCount)
+	assert.Equal(t, 0, record.FailedNodeCount)
+
+	var completed *events.WorkflowExecutionCompleted
+
+	for _, event := range eventBus.publishedEvents {
+		if event, ok := event.(*events.WorkflowExecutionCompleted); ok {
+			completed = event
+		}
+	}
+
+	require.NotNil(t, completed)
+	assert.Equal(t, "exec-export-workflow", completed.ExecutionID)
+	assert.Equal(t, 2, completed.NodesExecuted)
+}
+
+func TestWorkerManager_ExportExecution_RolledBackExecutionFailed(t *testing.T) {
+	sink := &recordingSink{}
+
+	setupSagaWith(t, map[string]map[string]any{
+		"charge": {"expression": `{"refunded": true}`},
+	}, func(wm *WorkerManager) { wm.ConfigureExecutionSink(sink) })
+
+	require.Len(t, sink.records, 1)
+	record := sink.records[0]
+
+	assert.Equal(t, "exec-saga-workflow", record.ExecutionID)
+	assert.Equal(t, "saga-workflow", record.WorkflowID)
+	assert.Equal(t, "failed", record.Status)
+	assert.Equal(t, "node ship failed, execution rolled back", record.ErrorMessage)
+	assert.False(t, record.CompletedAt.Before(record.StartedAt))
+	assert.Equal(t, record.CompletedAt.Sub(record.StartedAt).Milliseconds(), record.DurationMs)
+
+	// reserve, charge, the failed ship and the compensation of charge
+	assert.Equal(t, 4, record.NodeCount)
+	assert.Equal(t, 1, record.FailedNodeCount)
+
+	outcomes := make(map[string]executionsink.NodeOutcome)
+	for _, outcome := range record.Nodes {
+		outcomes[outcome.NodeID+"::"+outcome.Port] = outcome
+	}
+
+	assert.Equal(t, "error", outcomes["ship::error"].Status)
+	assert.Equal(t, "ship failed", outcomes["ship::error"].Error)
+	assert.Equal(t, "success", outcomes["charge-compensation::success"].Status)
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dukex/operion/pkg/eventbus"
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/dukex/operion/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// updateGolden rewrites the golden files from the current executor output:
+//
+//	go test ./cmd/operion-worker -run TestGoldenWorkflows -update
+var updateGolden = flag.Bool("update", false, "rewrite the golden files of TestGoldenWorkflows")
+
+// goldenFixturesDir holds the workflow fixtures (<name>.json) and their golden outputs
+// (<name>.golden.json).
+var goldenFixturesDir = filepath.Join("testdata", "golden")
+
+// goldenOmittedFields are the event fields that change from run to run or repeat the fixture.
+var goldenOmittedFields = []string{"id", "timestamp", "completed_at", "duration_ms", "workflow_id", "execution_id"}
+
+// goldenFixture is a workflow run through the executor: its trigger node is activated with the
+// trigger data, like the activator does, and every activation it leads to runs in order.
+type goldenFixture struct {
+	Description string          `json:"description"`
+	Workflow    models.Workflow `json:"workflow"`
+	TriggerData map[string]any  `json:"trigger_data"`
+}
+
+// goldenOutput is what a fixture is compared on: the events the executor published, in order,
+// and the node results and status of the execution once no activation is left.
+type goldenOutput struct {
+	Events          []map[string]any            `json:"events"`
+	NodeResults     map[string]goldenNodeResult `json:"node_results"`
+	ExecutionStatus models.ExecutionStatus      `json:"execution_status"`
+}
+
+// goldenNodeResult is a node result without its timestamp.
+type goldenNodeResult struct {
+	Status string         `json:"status"`
+	Data   map[string]any `json:"data"`
+	Error  string         `json:"error,omitempty"`
+}
+
+func TestGoldenWorkflows(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join(goldenFixturesDir, "*.json"))
+	require.NoError(t, err)
+
+	for _, fixturePath := range fixtures {
+		if strings.HasSuffix(fixturePath, ".golden.json") {
+			continue
+		}
+
+		name := strings.TrimSuffix(filepath.Base(fixturePath), ".json")
+
+		t.Run(name, func(t *testing.T) {
+			var fixture goldenFixture
+			readGoldenJSON(t, fixturePath, &fixture)
+
+			actual := runGoldenFixture(t, &fixture)
+
+			goldenPath := filepath.Join(goldenFixturesDir, name+".golden.json")
+			if *updateGolden {
+				encoded, err := json.MarshalIndent(actual, "", "  ")
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(goldenPath, append(encoded, '\n'), 0o600))
+
+				return
+			}
+
+			expected, err := os.ReadFile(goldenPath)
+			require.NoError(t, err, "missing golden file, run the test with -update to create it")
+
+			encoded, err := json.Marshal(actual)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(encoded), "%s: %s", name, fixture.Description)
+		})
+	}
+}
+
+// runGoldenFixture runs the fixture's workflow through a worker and returns its output.
+func runGoldenFixture(t *testing.T, fixture *goldenFixture) *goldenOutput {
+	t.Helper()
+
+	persistence := file.NewPersistence(t.TempDir())
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	workflow := &fixture.Workflow
+	executionID := "exec-" + workflow.ID
+
+	var triggerNodeID string
+
+	for _, node := range workflow.Nodes {
+		if node.Category == models.CategoryTypeTrigger {
+			triggerNodeID = node.ID
+
+			break
+		}
+	}
+
+	require.NotEmpty(t, triggerNodeID, "the fixture workflow has no trigger node")
+
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          executionID,
+		WorkflowID:  workflow.ID,
+		TriggerData: fixture.TriggerData,
+		Variables:   workflow.Variables,
+		NodeResults: make(map[string]models.NodeResult),
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	eventBus := &MockEventBus{}
+	wm := NewWorkerManager("golden-worker", persistence, eventBus, logger, reg)
+
+	runActivations(t, wm, eventBus, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: executionID,
+		NodeID:      triggerNodeID,
+		InputPort:   "external",
+		InputData:   fixture.TriggerData,
+	})
+
+	require.Contains(t, completedNodes(eventBus), triggerNodeID, "the trigger node did not run")
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), executionID)
+	require.NoError(t, err)
+
+	output := &goldenOutput{
+		Events:          make([]map[string]any, 0, len(eventBus.publishedEvents)),
+		NodeResults:     make(map[string]goldenNodeResult, len(execCtx.NodeResults)),
+		ExecutionStatus: execCtx.Status,
+	}
+
+	for _, event := range eventBus.publishedEvents {
+		output.Events = append(output.Events, normalizeGoldenEvent(t, event))
+	}
+
+	for key, result := range execCtx.NodeResults {
+		output.NodeResults[key] = goldenNodeResult{Status: result.Status, Data: result.Data, Error: result.Error}
+	}
+
+	return output
+}
+
+// normalizeGoldenEvent returns the JSON fields of event with its type and without the omitted
+// fields. The worker leaves the type of the events it publishes to their GetType method.
+func normalizeGoldenEvent(t *testing.T, event any) map[string]any {
+	t.Helper()
+
+	var fields map[string]any
+	readGoldenBytes(t, event, &fields)
+
+	for _, field := range goldenOmittedFields {
+		delete(fields, field)
+	}
+
+	if typed, ok := event.(eventbus.Event); ok {
+		fields["type"] = string(typed.GetType())
+	}
+
+	return fields
+}
+
+// readGoldenBytes round-trips value through JSON into target, so numbers compare as decoded.
+func readGoldenBytes(t *testing.T, value, target any) {
+	t.Helper()
+
+	encoded, err := json.Marshal(value)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(encoded, target))
+}
+
+// readGoldenJSON decodes the JSON file at path into target.
+func readGoldenJSON(t *testing.T, path string, target any) {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(content, target))
+}
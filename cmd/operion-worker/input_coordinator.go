@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/dukex/operion/pkg/models"
@@ -57,14 +59,23 @@ func (ic *InputCoordinator) AddInput(
 			ExecutionID:     executionID,
 			NodeExecutionID: nodeExecutionID,
 			ReceivedInputs:  make(map[string]models.NodeResult),
+			ReceivedFrom:    make(map[string][]string),
 			Requirements:    requirements,
 			CreatedAt:       time.Now().UTC(),
 			LastUpdatedAt:   time.Now().UTC(),
 		}
 	}
 
-	// Add the new input
-	state.ReceivedInputs[port] = result
+	if state.ReceivedFrom == nil {
+		state.ReceivedFrom = make(map[string][]string)
+	}
+
+	// Add the new input, next to the inputs of other upstream nodes joined on the same port
+	state.ReceivedInputs[port] = joinInput(state.Requirements.Sources[port], state.ReceivedInputs[port], result)
+	if !slices.Contains(state.ReceivedFrom[port], result.NodeID) {
+		state.ReceivedFrom[port] = append(state.ReceivedFrom[port], result.NodeID)
+	}
+
 	state.LastUpdatedAt = time.Now().UTC()
 
 	// Save updated state
@@ -87,39 +98,27 @@ func (ic *InputCoordinator) AddInput(
 }
 
 // IsNodeReady determines if a node has sufficient inputs to execute
-// based on its requirements.
+// based on its requirements, including every upstream node of a join.
 func (ic *InputCoordinator) IsNodeReady(state *models.NodeInputState) bool {
-	requirements := state.Requirements
-
-	switch requirements.WaitMode {
-	case models.WaitModeAll:
-		// All required ports must have inputs
-		for _, requiredPort := range requirements.RequiredPorts {
-			if _, hasInput := state.ReceivedInputs[requiredPort]; !hasInput {
-				return false
-			}
-		}
-
-		return true
+	return state.Requirements.SatisfiedBy(state.ReceivedInputs) &&
+		state.Requirements.SourcesSatisfiedBy(state.ReceivedFrom)
+}
 
-	case models.WaitModeAny:
-		// At least one required port must have input
-		for _, requiredPort := range requirements.RequiredPorts {
-			if _, hasInput := state.ReceivedInputs[requiredPort]; hasInput {
-				return true
-			}
-		}
+// joinInput returns the input of a port fed by sources once result arrives on it. A port joining
+// several upstream nodes collects their data keyed by source node ID, so no input overwrites
+// another; other ports keep the latest input as is.
+func joinInput(sources []string, received, result models.NodeResult) models.NodeResult {
+	if len(sources) < 2 {
+		return result
+	}
 
-		return false
+	data := make(map[string]any, len(sources))
+	maps.Copy(data, received.Data)
+	data[result.NodeID] = result.Data
 
-	case models.WaitModeFirst:
-		// Ready if we have any input at all
-		return len(state.ReceivedInputs) > 0
+	result.Data = data
 
-	default:
-		// Unknown wait mode, default to any
-		return len(state.ReceivedInputs) > 0
-	}
+	return result
 }
 
 // CleanupNodeExecution removes input state after successful node execution.
@@ -415,3 +415,38 @@ func TestInputCoordinator_InvalidInput(t *testing.T) {
 	require.NoError(t, err)  // Should handle gracefully
 	assert.False(t, isReady) // Should not be ready due to missing required port
 }
+
+func TestInputCoordinator_AddInput_JoinCollectsInputsPerSource(t *testing.T) {
+	persistence := file.NewPersistence(t.TempDir())
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	coordinator := NewInputCoordinator(persistence, logger)
+
+	requirements := models.InputRequirements{
+		RequiredPorts: []string{"main"},
+		WaitMode:      models.WaitModeAll,
+		Sources:       map[string][]string{"main": {"audit", "no", "yes"}},
+		Exclusive:     map[string][]string{"no": {"yes"}, "yes": {"no"}},
+	}
+
+	add := func(source string) (*models.NodeInputState, bool) {
+		state, isReady, err := coordinator.AddInput(t.Context(), "join", testExecutionID, testNodeExecID, "main", models.NodeResult{
+			NodeID: source,
+			Data:   map[string]any{"from": source},
+			Status: string(models.NodeStatusSuccess),
+		}, requirements)
+		require.NoError(t, err)
+
+		return state, isReady
+	}
+
+	_, isReady := add("audit")
+	assert.False(t, isReady)
+
+	// "no" is behind the other branch of "yes" and never fires
+	state, isReady := add("yes")
+	assert.True(t, isReady)
+	assert.Equal(t, map[string]any{
+		"audit": map[string]any{"from": "audit"},
+		"yes":   map[string]any{"from": "yes"},
+	}, state.ReceivedInputs["main"].Data)
+}
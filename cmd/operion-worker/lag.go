@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dukex/operion/pkg/eventbus"
+)
+
+const (
+	// lagShutdownTimeout is how long the lag server waits for in-flight requests on shutdown.
+	lagShutdownTimeout = 5 * time.Second
+
+	// lagReadHeaderTimeout bounds how long the lag server waits for request headers.
+	lagReadHeaderTimeout = 5 * time.Second
+)
+
+// Backlog is the node activations a worker has not processed yet, for autoscalers to scale the
+// number of workers to.
+type Backlog struct {
+	// Queued is the number of activations received and waiting for a free slot
+	Queued int64 `json:"queued"`
+	// InFlight is the number of activations being processed
+	InFlight int64 `json:"in_flight"`
+	// ConsumerLag is the number of activations published but not yet received, when the event bus reports it
+	ConsumerLag int64 `json:"consumer_lag"`
+	// Total is the sum of the queued, in flight and lagging activations
+	Total int64 `json:"total"`
+}
+
+// Backlog returns the node activations the worker has not processed yet.
+func (w *WorkerManager) Backlog(ctx context.Context) (Backlog, error) {
+	backlog := Backlog{
+		Queued:   int64(w.queue.Len()),
+		InFlight: w.inFlight.Load(),
+	}
+
+	if reporter, ok := w.eventBus.(eventbus.LagReporter); ok {
+		lag, err := reporter.Lag(ctx)
+		if err != nil {
+			return Backlog{}, err
+		}
+
+		backlog.ConsumerLag = lag
+	}
+
+	backlog.Total = backlog.Queued + backlog.InFlight + backlog.ConsumerLag
+
+	return backlog, nil
+}
+
+// backlogCounts returns the backlog by state, as reported by the worker backlog metric.
+func (w *WorkerManager) backlogCounts(ctx context.Context) (map[string]int64, error) {
+	backlog, err := w.Backlog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]int64{
+		"queued":       backlog.Queued,
+		"in_flight":    backlog.InFlight,
+		"consumer_lag": backlog.ConsumerLag,
+	}, nil
+}
+
+// LagHandler returns the HTTP handler serving the backlog of the worker on GET /lag.
+func (w *WorkerManager) LagHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /lag", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+
+		backlog, err := w.Backlog(r.Context())
+		if err != nil {
+			w.logger.ErrorContext(r.Context(), "Failed to get worker backlog", "error", err)
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(rw).Encode(map[string]string{"error": err.Error()})
+
+			return
+		}
+
+		_ = json.NewEncoder(rw).Encode(backlog)
+	})
+
+	return mux
+}
+
+// serveLag serves the lag endpoint on addr until ctx is done.
+func (w *WorkerManager) serveLag(ctx context.Context, addr string) {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           w.LagHandler(),
+		ReadHeaderTimeout: lagReadHeaderTimeout,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), lagShutdownTimeout)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			w.logger.Error("Failed to shutdown lag server", "error", err)
+		}
+	}()
+
+	w.logger.InfoContext(ctx, "Serving worker lag", "addr", addr)
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		w.logger.ErrorContext(ctx, "Lag server failed", "addr", addr, "error", err)
+	}
+}
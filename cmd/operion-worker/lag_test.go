@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/dukex/operion/pkg/registry"
+	trc "github.com/dukex/operion/pkg/tracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// laggingEventBus is a mock event bus reporting a fixed consumer lag.
+type laggingEventBus struct {
+	MockEventBus
+
+	lag int64
+	err error
+}
+
+func (m *laggingEventBus) Lag(ctx context.Context) (int64, error) {
+	return m.lag, m.err
+}
+
+func newLaggingWorker(t *testing.T, eventBus *laggingEventBus, queued int) *WorkerManager {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	wm := NewWorkerManager("lagging-worker", file.NewPersistence(t.TempDir()), eventBus, logger, registry.NewRegistry(logger))
+
+	for range queued {
+		require.NoError(t, wm.enqueueNodeActivation(t.Context(), &events.NodeActivation{
+			BaseEvent:  events.NewBaseEvent(events.NodeActivationEvent, "orders"),
+			WorkflowID: "orders",
+			NodeID:     "log",
+		}))
+	}
+
+	return wm
+}
+
+func TestWorkerManager_LagEndpoint(t *testing.T) {
+	wm := newLaggingWorker(t, &laggingEventBus{lag: 42}, 3)
+
+	recorder := httptest.NewRecorder()
+	wm.LagHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/lag", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var backlog Backlog
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &backlog))
+	assert.Equal(t, Backlog{Queued: 3, ConsumerLag: 42, Total: 45}, backlog)
+
+	// Event buses that cannot report their lag only count the internal queue
+	plain := NewWorkerManager("plain-worker", file.NewPersistence(t.TempDir()), &MockEventBus{}, slog.Default(), registry.NewRegistry(slog.Default()))
+
+	backlog, err := plain.Backlog(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, Backlog{}, backlog)
+}
+
+func TestWorkerManager_LagEndpoint_EventBusError(t *testing.T) {
+	wm := newLaggingWorker(t, &laggingEventBus{err: errors.New("broker unreachable")}, 0)
+
+	recorder := httptest.NewRecorder()
+	wm.LagHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/lag", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "broker unreachable")
+}
+
+func TestWorkerManager_BacklogMetric(t *testing.T) {
+	wm := newLaggingWorker(t, &laggingEventBus{lag: 42}, 3)
+
+	reader := sdkmetric.NewManualReader()
+	require.NoError(t, trc.ObserveWorkerBacklog(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), "lagging-worker", wm.backlogCounts))
+
+	var collected metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &collected))
+	require.Len(t, collected.ScopeMetrics, 1)
+	require.Len(t, collected.ScopeMetrics[0].Metrics, 1)
+
+	exported := collected.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, trc.WorkerBacklogMetric, exported.Name)
+
+	gauge, ok := exported.Data.(metricdata.Gauge[int64])
+	require.True(t, ok)
+
+	states := make(map[string]int64)
+
+	for _, point := range gauge.DataPoints {
+		state, _ := point.Attributes.Value("backlog.state")
+		states[state.AsString()] = point.Value
+	}
+
+	assert.Equal(t, map[string]int64{"queued": 3, "in_flight": 0, "consumer_lag": 42}, states)
+}
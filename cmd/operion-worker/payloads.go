@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/payloads"
+)
+
+// ConfigurePayloadOffloading sets the offloader moving large node results out of execution contexts
+// to an object store. Templates resolve the references transparently. A nil offloader stores every
+// result inline.
+func (w *WorkerManager) ConfigurePayloadOffloading(offloader *payloads.Offloader) {
+	w.payloads = offloader
+	w.executionService.ConfigurePayloadOffloading(offloader)
+}
+
+// offloadResult returns result with its data offloaded under key when it is too large to be stored
+// inline. A failed offload is logged and keeps the data inline.
+func (w *WorkerManager) offloadResult(
+	ctx context.Context,
+	execCtx *models.ExecutionContext,
+	key string,
+	result models.NodeResult,
+) models.NodeResult {
+	if w.payloads == nil {
+		return result
+	}
+
+	offloaded, err := w.payloads.OffloadResult(ctx, execCtx.ID, key, result)
+	if err != nil {
+		w.logger.WarnContext(ctx, "Failed to offload node result, storing it inline",
+			"workflow_id", execCtx.WorkflowID,
+			"execution_id", execCtx.ID,
+			"result", key,
+			"error", err)
+
+		return result
+	}
+
+	return offloaded
+}
+
+// resolveResult returns result of the execution with its offloaded data loaded back.
+func (w *WorkerManager) resolveResult(ctx context.Context, executionID string, result models.NodeResult) (models.NodeResult, error) {
+	if w.payloads == nil {
+		return result, nil
+	}
+
+	return w.payloads.ResolveResult(ctx, executionID, result)
+}
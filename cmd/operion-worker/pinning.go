@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+)
+
+// ErrPinnedWorkflowNotFound is the failure of executions whose workflow version no longer exists.
+var ErrPinnedWorkflowNotFound = errors.New("workflow version of the execution no longer exists")
+
+// ErrPinnedNodeNotFound is the failure of executions activating a node their workflow version
+// does not have.
+var ErrPinnedNodeNotFound = errors.New("node not found in the workflow version of the execution")
+
+// pinnedWorkflow loads the workflow version an execution runs, nil when it no longer exists.
+// Executions are pinned to the version they started on (the workflow ID of their activations):
+// publishing a newer version or deleting it does not change the graph they run.
+func (w *WorkerManager) pinnedWorkflow(ctx context.Context, workflowID string) (*models.Workflow, error) {
+	wf, err := w.persistence.WorkflowRepository().GetByIDIncludingDeleted(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow %s: %w", workflowID, err)
+	}
+
+	return wf, nil
+}
+
+// workflowNode returns the node of wf with the given ID, nil when there is none.
+func workflowNode(wf *models.Workflow, nodeID string) *models.WorkflowNode {
+	for _, node := range wf.Nodes {
+		if node.ID == nodeID {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// sourceConnections returns the connections of wf leaving the node with the given ID.
+func sourceConnections(wf *models.Workflow, sourceNodeID string) []*models.Connection {
+	var connections []*models.Connection
+
+	for _, conn := range wf.Connections {
+		if nodeID, _, ok := models.ParsePortID(conn.SourcePort); ok && nodeID == sourceNodeID {
+			connections = append(connections, conn)
+		}
+	}
+
+	return connections
+}
+
+// failMissingWorkflow fails the execution of an activation whose workflow version was removed,
+// instead of running a node of another version. The execution is terminated, so activations
+// still in flight skip their node.
+func (w *WorkerManager) failMissingWorkflow(ctx context.Context, logger *slog.Logger, activation *events.NodeActivation) error {
+	failure := fmt.Errorf("%w: %s", ErrPinnedWorkflowNotFound, activation.WorkflowID)
+	logger.ErrorContext(ctx, "Workflow version of the execution not found", "error", failure)
+
+	execCtx, err := w.persistence.ExecutionContextRepository().GetExecutionContext(ctx, activation.ExecutionID)
+	if err != nil || execCtx == nil || execCtx.Status.IsTerminal() {
+		return w.publishNodeCompletionEvent(ctx, activation, nil, failure)
+	}
+
+	// The workflow version is gone along with its alerting policy
+	w.failExecution(ctx, logger, nil, execCtx, activation.NodeID, failure)
+
+	return w.publishNodeCompletionEvent(ctx, activation, nil, failure)
+}
+
+// failMissingNode fails the execution of an activation for a node its workflow version does not
+// have, as failMissingWorkflow does for a removed version, instead of leaving it running with
+// nothing left to run.
+func (w *WorkerManager) failMissingNode(
+	ctx context.Context,
+	logger *slog.Logger,
+	wf *models.Workflow,
+	activation *events.NodeActivation,
+) error {
+	failure := fmt.Errorf("%w: %s", ErrPinnedNodeNotFound, activation.NodeID)
+	logger.ErrorContext(ctx, "Node not found", "error", failure)
+
+	execCtx, err := w.persistence.ExecutionContextRepository().GetExecutionContext(ctx, activation.ExecutionID)
+	if err != nil || execCtx == nil || execCtx.Status.IsTerminal() {
+		return w.publishNodeCompletionEvent(ctx, activation, nil, failure)
+	}
+
+	w.failExecution(ctx, logger, wf, execCtx, activation.NodeID, failure)
+
+	return w.publishNodeCompletionEvent(ctx, activation, nil, failure)
+}
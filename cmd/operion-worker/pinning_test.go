@@ -0,0 +1,161 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/dukex/operion/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupPinnedExecution saves a published "fetch" -> "report" workflow and an execution of it whose
+// "fetch" node ran, and returns the worker manager running it and the activation of "report".
+func setupPinnedExecution(t *testing.T, root string) (*WorkerManager, *MockEventBus, persistence.Persistence, *events.NodeActivation) {
+	t.Helper()
+
+	persistence := file.NewPersistence(root)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	workflow := &models.Workflow{
+		ID:     "pinned-v1",
+		Name:   "Pinned Workflow",
+		Status: models.WorkflowStatusDraft,
+		Nodes: []*models.WorkflowNode{
+			{ID: "fetch", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": "fetched"}, Enabled: true},
+			{ID: "report", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": "v1 report"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "conn-1", SourcePort: "fetch:success", TargetPort: "report:main"},
+		},
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+	require.NoError(t, persistence.WorkflowRepository().PublishWorkflow(t.Context(), workflow.ID))
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          "exec-pinned",
+		WorkflowID:  workflow.ID,
+		NodeResults: make(map[string]models.NodeResult),
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	eventBus := &MockEventBus{}
+	wm := NewWorkerManager("pinned-worker", persistence, eventBus, logger, reg)
+
+	_, err := persistence.ExecutionContextRepository().AddPendingActivations(t.Context(), "exec-pinned", 1)
+	require.NoError(t, err)
+
+	require.NoError(t, wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-pinned",
+		NodeID:      "fetch",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	}))
+
+	activations := activatedNodes(eventBus)
+	require.Len(t, activations, 1)
+	require.Equal(t, "report", activations[0].NodeID)
+
+	return wm, eventBus, persistence, activations[0]
+}
+
+func TestWorkerManager_ExecutionStaysOnPinnedVersion(t *testing.T) {
+	wm, eventBus, persistence, next := setupPinnedExecution(t, t.TempDir())
+	workflows := persistence.WorkflowRepository()
+
+	// A new version is published mid-run, with another report and a node after it, and the
+	// version the execution started on is deleted
+	draft, err := workflows.CreateDraftFromPublished(t.Context(), "pinned-v1")
+	require.NoError(t, err)
+
+	draft.Nodes = []*models.WorkflowNode{
+		{ID: "fetch", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": "fetched"}, Enabled: true},
+		{ID: "report", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": "v2 report"}, Enabled: true},
+		{ID: "notify", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "v2 only"}, Enabled: true},
+	}
+	draft.Connections = []*models.Connection{
+		{ID: "conn-1", SourcePort: "fetch:success", TargetPort: "report:main"},
+		{ID: "conn-2", SourcePort: "report:success", TargetPort: "notify:main"},
+	}
+	require.NoError(t, workflows.Save(t.Context(), draft))
+	require.NoError(t, workflows.PublishWorkflow(t.Context(), draft.ID))
+	require.NoError(t, workflows.Delete(t.Context(), "pinned-v1"))
+
+	require.NoError(t, wm.handleNodeActivation(t.Context(), next))
+
+	// The execution finishes on the version it started on
+	assert.Len(t, activatedNodes(eventBus), 1)
+	assert.Equal(t, []string{"fetch", "report"}, completedNodes(eventBus))
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-pinned")
+	require.NoError(t, err)
+	assert.Equal(t, "v1 report", execCtx.NodeResults[models.MakeNodeResultKey("report", "success")].Data["result"])
+	assert.Equal(t, models.ExecutionStatusCompleted, execCtx.Status)
+}
+
+func TestWorkerManager_PinnedVersionRemovedFailsExecution(t *testing.T) {
+	root := t.TempDir()
+	wm, eventBus, persistence, next := setupPinnedExecution(t, root)
+
+	// The version the execution started on is removed for good
+	require.NoError(t, os.Remove(filepath.Join(root, "workflows", "pinned-v1.json")))
+
+	require.NoError(t, wm.handleNodeActivation(t.Context(), next))
+
+	assert.Len(t, activatedNodes(eventBus), 1)
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-pinned")
+	require.NoError(t, err)
+	assert.NotContains(t, execCtx.NodeResults, models.MakeNodeResultKey("report", "success"), "no node of another version runs")
+	assert.Equal(t, models.ExecutionStatusFailed, execCtx.Status)
+	assert.Contains(t, execCtx.ErrorMessage, ErrPinnedWorkflowNotFound.Error())
+	assert.NotNil(t, execCtx.CompletedAt)
+
+	var failed *events.WorkflowExecutionFailed
+
+	for _, event := range eventBus.publishedEvents {
+		if event, ok := event.(*events.WorkflowExecutionFailed); ok {
+			failed = event
+		}
+	}
+
+	require.NotNil(t, failed)
+	assert.Equal(t, "exec-pinned", failed.ExecutionID)
+	assert.Contains(t, failed.Error.Message, "pinned-v1")
+}
+
+func TestWorkerManager_PinnedVersionMissingNodeFailsExecution(t *testing.T) {
+	wm, eventBus, persistence, next := setupPinnedExecution(t, t.TempDir())
+
+	// The activation names a node the version the execution runs does not have
+	next.NodeID = "notify"
+
+	require.NoError(t, wm.handleNodeActivation(t.Context(), next))
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-pinned")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusFailed, execCtx.Status)
+	assert.Contains(t, execCtx.ErrorMessage, ErrPinnedNodeNotFound.Error())
+	assert.NotNil(t, execCtx.CompletedAt)
+
+	var failed *events.WorkflowExecutionFailed
+
+	for _, event := range eventBus.publishedEvents {
+		if event, ok := event.(*events.WorkflowExecutionFailed); ok {
+			failed = event
+		}
+	}
+
+	require.NotNil(t, failed)
+	assert.Equal(t, "notify", failed.Error.NodeID)
+}
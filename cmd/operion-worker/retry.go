@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+)
+
+// retryNode reschedules a node that failed, while the retries of its retry policy and the retry
+// budget of the execution allow it, and reports whether it did. The retry is a new activation of
+// the node, due once the delay of the policy passed: workers hold it outside the queue until then,
+// so no worker slot waits for it, and the node's coordinated inputs are kept for it. Each retry is
+// taken from the retry budget of the execution, shared by all its nodes, with an atomic update of
+// the persisted execution: once the budget is spent, the failure is routed without retrying.
+func (w *WorkerManager) retryNode(
+	ctx context.Context,
+	logger *slog.Logger,
+	node *models.WorkflowNode,
+	activation *events.NodeActivation,
+	outputs map[string]models.NodeResult,
+	err error,
+) bool {
+	if node.Retry == nil || activation.Attempt >= node.Retry.MaxRetries || !nodeFailed(outputs, err) {
+		return false
+	}
+
+	budget := w.retryBudget(ctx, activation.WorkflowID)
+
+	claimed, claimErr := w.persistence.ExecutionContextRepository().ClaimRetry(ctx, activation.ExecutionID, budget)
+	if claimErr != nil {
+		logger.WarnContext(ctx, "Failed to claim node retry, failing node without retrying", "error", claimErr)
+
+		return false
+	}
+
+	if !claimed {
+		logger.WarnContext(ctx, "Retry budget of execution spent, failing node without retrying", "retry_budget", budget)
+
+		return false
+	}
+
+	retry := *activation
+	retry.BaseEvent = events.NewBaseEvent(events.NodeActivationEvent, activation.WorkflowID)
+	retry.CorrelationID = events.CorrelationID(ctx)
+	retry.Attempt = activation.Attempt + 1
+	retry.NotBefore = nil
+
+	delay, _ := time.ParseDuration(node.Retry.Delay)
+	if delay > 0 {
+		notBefore := time.Now().UTC().Add(delay)
+		retry.NotBefore = &notBefore
+	}
+
+	if publishErr := w.publishActivation(ctx, &retry); publishErr != nil {
+		logger.ErrorContext(ctx, "Failed to reschedule node retry", "error", publishErr)
+
+		return false
+	}
+
+	logger.InfoContext(ctx, "Rescheduled failed node",
+		"retry", retry.Attempt,
+		"max_retries", node.Retry.MaxRetries,
+		"delay", delay)
+
+	return true
+}
+
+// nodeFailed reports whether a node execution failed, either with an error or on an error port.
+func nodeFailed(outputs map[string]models.NodeResult, err error) bool {
+	return err != nil || outputsError(outputs) != nil
+}
+
+// retryBudget returns the retry budget of the executions of a workflow, 0 (no limit) when the
+// workflow cannot be loaded.
+func (w *WorkerManager) retryBudget(ctx context.Context, workflowID string) int {
+	wf, err := w.pinnedWorkflow(ctx, workflowID)
+	if err != nil || wf == nil {
+		w.logger.WarnContext(ctx, "Failed to get workflow retry budget", "workflow_id", workflowID, "error", err)
+
+		return 0
+	}
+
+	return wf.RetryBudget
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/nodes/transform"
+	"github.com/dukex/operion/pkg/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyFactory creates transform nodes failing on their error port the first "failures" times
+// they execute, counting the attempts per node ID.
+type flakyFactory struct {
+	protocol.NodeFactory
+
+	attempts map[string]int
+}
+
+func (f flakyFactory) ID() string {
+	return "flaky"
+}
+
+func (f flakyFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	node, err := f.NodeFactory.Create(ctx, id, config)
+	failures, _ := config["failures"].(float64) // numbers are decoded from the persisted workflow
+
+	return flakyNode{Node: node, attempts: f.attempts, failures: int(failures)}, err
+}
+
+type flakyNode struct {
+	protocol.Node
+
+	attempts map[string]int
+	failures int
+}
+
+func (n flakyNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	n.attempts[n.ID()]++
+
+	if n.attempts[n.ID()] <= n.failures {
+		return map[string]models.NodeResult{
+			"error": {NodeID: n.ID(), Status: string(models.NodeStatusError), Error: n.ID() + " is unavailable"},
+		}, nil
+	}
+
+	return n.Node.Execute(ctx, inputs)
+}
+
+func TestWorkerManager_RetryBudget_SharedAcrossNodes(t *testing.T) {
+	flaky := func(id string, failures int) *models.WorkflowNode {
+		return &models.WorkflowNode{
+			ID:       id,
+			Type:     "flaky",
+			Category: models.CategoryTypeAction,
+			Config:   map[string]any{"expression": `{"node": "` + id + `"}`, "failures": failures},
+			Enabled:  true,
+			Retry:    &models.RetryPolicy{MaxRetries: 2},
+		}
+	}
+
+	workflow := &models.Workflow{
+		ID:          "retry-workflow",
+		Name:        "Retry Workflow",
+		Status:      models.WorkflowStatusPublished,
+		RetryBudget: 3,
+		Nodes: []*models.WorkflowNode{
+			flaky("fetch", 1),
+			flaky("enrich", 2),
+			flaky("store", 5),
+		},
+		Connections: []*models.Connection{
+			{ID: "fetch-enrich", SourcePort: "fetch:success", TargetPort: "enrich:main"},
+			{ID: "enrich-store", SourcePort: "enrich:success", TargetPort: "store:main"},
+		},
+	}
+
+	wm, eventBus, persistence := setupRepeatWorkflow(t, workflow)
+
+	attempts := map[string]int{}
+	wm.registry.RegisterNode(flakyFactory{NodeFactory: transform.NewTransformNodeFactory(), attempts: attempts})
+
+	ranNodes := runActivations(t, wm, eventBus, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-retry-workflow",
+		NodeID:      "fetch",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	})
+	// Each retry is a new activation of the node
+	require.Equal(t, []string{"fetch", "fetch", "enrich", "enrich", "enrich", "store"}, ranNodes)
+
+	// fetch and enrich spend the budget of 3 retries; store could retry twice but fails right away
+	assert.Equal(t, map[string]int{"fetch": 2, "enrich": 3, "store": 1}, attempts)
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-retry-workflow")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, execCtx.Retries())
+	assert.Equal(t, string(models.NodeStatusSuccess), execCtx.NodeResults[models.MakeNodeResultKey("enrich", "success")].Status)
+	assert.Equal(t, "store is unavailable", execCtx.NodeResults[models.MakeNodeResultKey("store", "error")].Error)
+}
+
+func TestWorkerManager_RetryBudget_ZeroLimitsOnlyPerNode(t *testing.T) {
+	workflow := &models.Workflow{
+		ID:     "unbudgeted-workflow",
+		Name:   "Unbudgeted Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:       "fetch",
+				Type:     "flaky",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"expression": `{}`, "failures": 10},
+				Enabled:  true,
+				Retry:    &models.RetryPolicy{MaxRetries: 4},
+			},
+		},
+	}
+
+	wm, eventBus, _ := setupRepeatWorkflow(t, workflow)
+
+	attempts := map[string]int{}
+	wm.registry.RegisterNode(flakyFactory{NodeFactory: transform.NewTransformNodeFactory(), attempts: attempts})
+
+	runActivations(t, wm, eventBus, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-unbudgeted-workflow",
+		NodeID:      "fetch",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	})
+
+	assert.Equal(t, 5, attempts["fetch"])
+}
+
+func TestWorkerManager_Retry_ReschedulesActivationAfterDelay(t *testing.T) {
+	workflow := &models.Workflow{
+		ID:     "delayed-retry-workflow",
+		Name:   "Delayed Retry Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:       "fetch",
+				Type:     "flaky",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"expression": `{}`, "failures": 1},
+				Enabled:  true,
+				Retry:    &models.RetryPolicy{MaxRetries: 1, Delay: "1m"},
+			},
+		},
+	}
+
+	wm, eventBus, persistence := setupRepeatWorkflow(t, workflow)
+
+	attempts := map[string]int{}
+	wm.registry.RegisterNode(flakyFactory{NodeFactory: transform.NewTransformNodeFactory(), attempts: attempts})
+
+	before := time.Now()
+
+	require.NoError(t, wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-delayed-retry-workflow",
+		NodeID:      "fetch",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	}))
+
+	// The failure is not routed: the node is activated again, due after the delay
+	assert.Equal(t, 1, attempts["fetch"])
+
+	activations := activatedNodes(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "fetch", activations[0].NodeID)
+	assert.Equal(t, 1, activations[0].Attempt)
+	require.NotNil(t, activations[0].NotBefore)
+	assert.WithinDuration(t, before.Add(time.Minute), *activations[0].NotBefore, 5*time.Second)
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-delayed-retry-workflow")
+	require.NoError(t, err)
+	assert.Equal(t, 1, execCtx.Retries())
+	assert.Empty(t, execCtx.NodeResults)
+
+	// Workers hold the retry outside the queue until it is due
+	require.NoError(t, wm.enqueueNodeActivation(t.Context(), activations[0]))
+	assert.Zero(t, wm.queue.Len())
+
+	// Once due, the retry runs the node with the inputs kept for it
+	require.NoError(t, wm.handleNodeActivation(t.Context(), activations[0]))
+	assert.Equal(t, 2, attempts["fetch"])
+
+	execCtx, err = persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-delayed-retry-workflow")
+	require.NoError(t, err)
+	assert.Equal(t, string(models.NodeStatusSuccess), execCtx.NodeResults[models.MakeNodeResultKey("fetch", "success")].Status)
+}
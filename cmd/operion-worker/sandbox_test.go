@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/dukex/operion/pkg/registry"
+	"github.com/dukex/operion/pkg/sandbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSandboxedWorkflow builds the crash plugin of pkg/sandbox, registers it and saves a
+// workflow whose "crasher" node runs in it, with its error port connected to "on-crash".
+func setupSandboxedWorkflow(t *testing.T) (*WorkerManager, *MockEventBus, persistence.Persistence) {
+	t.Helper()
+
+	pluginPath := filepath.Join(t.TempDir(), "crash")
+	output, err := exec.Command("go", "build", "-o", pluginPath, "../../pkg/sandbox/testdata/crashplugin").CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	factory, err := sandbox.NewProcessNodeFactory(pluginPath, sandbox.Options{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	t.Cleanup(factory.Close)
+
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+	reg.RegisterNode(factory)
+
+	persistence := file.NewPersistence(t.TempDir())
+
+	workflow := &models.Workflow{
+		ID:     "sandboxed-workflow",
+		Name:   "Sandboxed Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "crasher", Type: "crash", Category: models.CategoryTypeAction, Config: map[string]any{}, Enabled: true},
+			{ID: "on-crash", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "crashed"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "conn-error", SourcePort: "crasher:error", TargetPort: "on-crash:main"},
+		},
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+
+	eventBus := &MockEventBus{}
+
+	return NewWorkerManager("sandbox-worker", persistence, eventBus, logger, reg), eventBus, persistence
+}
+
+func activateCrasher(t *testing.T, wm *WorkerManager, persistence persistence.Persistence, executionID string, input map[string]any) {
+	t.Helper()
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          executionID,
+		WorkflowID:  "sandboxed-workflow",
+		NodeResults: make(map[string]models.NodeResult),
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	require.NoError(t, wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, "sandboxed-workflow"),
+		WorkflowID:  "sandboxed-workflow",
+		ExecutionID: executionID,
+		NodeID:      "crasher",
+		InputPort:   "main",
+		InputData:   input,
+	}))
+}
+
+func TestWorkerManager_SandboxedNodeCrash_RoutesToFailure(t *testing.T) {
+	wm, eventBus, persistence := setupSandboxedWorkflow(t)
+
+	activateCrasher(t, wm, persistence, "exec-crash", map[string]any{"panic": true})
+
+	activations := activatedNodes(eventBus)
+	require.Len(t, activations, 1, "the crash fails the node instead of the worker")
+	assert.Equal(t, "on-crash", activations[0].NodeID)
+	assert.Equal(t, "error", activations[0].SourcePort)
+
+	inputData, ok := activations[0].InputData.(map[string]any)
+	require.True(t, ok)
+	assert.Contains(t, inputData["error"], sandbox.ErrPluginCrashed.Error())
+	assert.Equal(t, true, inputData["crashed"])
+
+	// The worker keeps executing sandboxed nodes, in a restarted plugin process
+	activateCrasher(t, wm, persistence, "exec-after-crash", map[string]any{"item": "book"})
+
+	assert.Len(t, activatedNodes(eventBus), 1, "the success port is not connected")
+	assert.Contains(t, completedNodes(eventBus), "crasher")
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-after-crash")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"item": "book"}, execCtx.NodeResults[models.MakeNodeResultKey("crasher", "success")].Data["input"])
+}
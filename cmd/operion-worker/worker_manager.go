@@ -6,23 +6,80 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/dukex/operion/pkg/alerting"
 	"github.com/dukex/operion/pkg/eventbus"
 	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/executionsink"
+	"github.com/dukex/operion/pkg/log"
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/payloads"
 	"github.com/dukex/operion/pkg/persistence"
 	"github.com/dukex/operion/pkg/registry"
+	"github.com/dukex/operion/pkg/template"
+	trc "github.com/dukex/operion/pkg/tracer"
+	"github.com/dukex/operion/pkg/workflow"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// errorHandlerInputPort is the input port receiving unhandled failures on a workflow's error handler node.
+	errorHandlerInputPort = "main"
+
+	// repeatInputPort is the input port a node emitting on an unconnected repeat port is activated on again.
+	repeatInputPort = "main"
+
+	// compensationInputPort is the input port receiving the result of the node a compensation undoes.
+	compensationInputPort = "main"
+
+	// approvalPauseReason is the pause reason of executions waiting on an approval node.
+	approvalPauseReason = "approval"
+
+	// eventWaitPauseReason is the pause reason of executions waiting on an await event node.
+	eventWaitPauseReason = "event"
+
+	// approvalExpiryInterval is how often expired approval requests and event waits are resumed on
+	// their timeout port.
+	approvalExpiryInterval = 30 * time.Second
+
+	// DefaultConcurrency is the number of node activations processed at the same time.
+	DefaultConcurrency = 1
+
+	// DefaultPriorityAging is how long a queued activation waits to gain one priority level.
+	DefaultPriorityAging = 30 * time.Second
+
+	// DefaultQueueCapacity is how many activations wait for a worker slot before the worker stops
+	// consuming the event bus.
+	DefaultQueueCapacity = 100
+
+	// DefaultLagAddr is the address serving the worker backlog on GET /lag.
+	DefaultLagAddr = ":8090"
 )
 
 type WorkerManager struct {
 	id               string
 	logger           *slog.Logger
-	persistence      persistence.Persistence
+	persistence      *batchedPersistence
 	registry         *registry.Registry
 	eventBus         eventbus.EventBus
 	inputCoordinator *InputCoordinator
+	executionService *workflow.ExecutionService
+	queue            *ActivationQueue
+	concurrency      int
+	metrics          *trc.NodeMetrics
+	pruneResults     bool
+	inFlight         atomic.Int64
+	lagAddr          string
+	sink             executionsink.Sink
+	notifier         *alerting.Notifier
+	payloads         *payloads.Offloader
+	missingKeys      string
+	batchInterval    time.Duration
+
+	persistenceRetryBackoff time.Duration
 }
 
 func NewWorkerManager(
@@ -32,25 +89,87 @@ func NewWorkerManager(
 	logger *slog.Logger,
 	registry *registry.Registry,
 ) *WorkerManager {
-	return &WorkerManager{
+	metrics, err := trc.NewNodeMetrics(otel.GetMeterProvider())
+	if err != nil {
+		logger.Warn("Failed to create node metrics, node executions will not be measured", "error", err)
+	}
+
+	batched := newBatchedPersistence(persistence)
+
+	worker := &WorkerManager{
 		id:               id,
 		logger:           logger.With("module", "operion-worker", "worker_id", id),
-		persistence:      persistence,
+		persistence:      batched,
 		registry:         registry,
 		eventBus:         eventBus,
 		inputCoordinator: NewInputCoordinator(persistence, logger),
+		executionService: workflow.NewExecutionService(batched, eventBus, registry),
+		queue:            NewActivationQueue(DefaultPriorityAging, DefaultQueueCapacity),
+		concurrency:      DefaultConcurrency,
+		metrics:          metrics,
 	}
+
+	if err := trc.ObserveWorkerBacklog(otel.GetMeterProvider(), id, worker.backlogCounts); err != nil {
+		logger.Warn("Failed to register worker backlog metric", "error", err)
+	}
+
+	if err := trc.ObservePersistenceDegraded(otel.GetMeterProvider(), id, batched.executions.degradedCount); err != nil {
+		logger.Warn("Failed to register persistence degraded metric", "error", err)
+	}
+
+	return worker
+}
+
+// ConfigureScheduling sets how many activations are processed at the same time, how long a
+// queued activation waits to gain one priority level and how many activations wait for a worker
+// slot at most. It must be called before Start.
+func (w *WorkerManager) ConfigureScheduling(concurrency int, aging time.Duration, capacity int) {
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+
+	w.concurrency = concurrency
+	w.queue = NewActivationQueue(aging, capacity)
+}
+
+// ConfigureLagEndpoint sets the address serving the backlog of the worker on GET /lag, for
+// autoscalers to scale workers to it. An empty address disables it. It must be called before Start.
+func (w *WorkerManager) ConfigureLagEndpoint(addr string) {
+	w.lagAddr = addr
+}
+
+// ConfigureNodeResultPruning sets whether nodes execute with only the node results still read by
+// pending nodes of the workflow. Persisted execution contexts always keep every result.
+func (w *WorkerManager) ConfigureNodeResultPruning(enabled bool) {
+	w.pruneResults = enabled
+}
+
+// ConfigureTemplateMissingKeys sets how node templates treat references to missing fields:
+// template.MissingKeyStrict fails them and template.MissingKeyLenient renders them empty. It must
+// be called before Start.
+func (w *WorkerManager) ConfigureTemplateMissingKeys(mode string) error {
+	if err := template.ValidateMissingKeyMode(mode); err != nil {
+		return err
+	}
+
+	w.missingKeys = mode
+
+	return nil
 }
 
 func (w *WorkerManager) Start(ctx context.Context) error {
 	w.logger.InfoContext(ctx, "Starting worker manager with node-based architecture", "worker_id", w.id)
 
 	// Updated for node-based architecture: handle node activations instead of step availability
-	err := w.eventBus.Handle(ctx, events.NodeActivationEvent, w.handleNodeActivation)
+	err := w.eventBus.Handle(ctx, events.NodeActivationEvent, w.enqueueNodeActivation)
 	if err != nil {
 		return err
 	}
 
+	for range w.concurrency {
+		go w.processActivations(ctx)
+	}
+
 	err = w.eventBus.Subscribe(ctx)
 	if err != nil {
 		w.logger.ErrorContext(ctx, "Failed to subscribe to event bus", "error", err)
@@ -58,6 +177,20 @@ func (w *WorkerManager) Start(ctx context.Context) error {
 		return err
 	}
 
+	go w.expireApprovals(ctx)
+
+	if w.lagAddr != "" {
+		go w.serveLag(ctx, w.lagAddr)
+	}
+
+	if w.batchInterval > 0 {
+		go w.flushNodeResults(ctx)
+	}
+
+	if w.persistenceRetryBackoff > 0 {
+		go w.retryDegradedWrites(ctx)
+	}
+
 	w.logger.InfoContext(ctx, "Worker started successfully with node-based execution")
 
 	sigChan := make(chan os.Signal, 1)
@@ -66,9 +199,69 @@ func (w *WorkerManager) Start(ctx context.Context) error {
 	<-sigChan
 	w.logger.InfoContext(ctx, "Shutting down worker...")
 
+	if err := w.persistence.executions.Flush(context.WithoutCancel(ctx)); err != nil {
+		w.logger.ErrorContext(ctx, "Failed to flush execution contexts", "error", err)
+	}
+
 	return nil
 }
 
+// enqueueNodeActivation queues a node activation event with its priority, blocking while the
+// queue is full. The event is acknowledged once the activation is processed, so activations
+// queued or in flight when the worker stops are delivered again.
+func (w *WorkerManager) enqueueNodeActivation(ctx context.Context, event any) error {
+	nodeActivationEvent, ok := event.(*events.NodeActivation)
+	if !ok {
+		w.logger.ErrorContext(ctx, "Invalid event type for NodeActivation")
+
+		return nil
+	}
+
+	done := eventbus.DeferAck(ctx)
+
+	// A rescheduled activation waits for its due time outside the queue, holding no slot. It is
+	// acknowledged once processed, so it is delivered again when the worker stops first
+	if nodeActivationEvent.NotBefore != nil {
+		if wait := time.Until(*nodeActivationEvent.NotBefore); wait > 0 {
+			time.AfterFunc(wait, func() {
+				w.queue.Push(ctx, nodeActivationEvent, nodeActivationEvent.Priority, done)
+			})
+
+			return nil
+		}
+	}
+
+	if !w.queue.Push(ctx, nodeActivationEvent, nodeActivationEvent.Priority, done) {
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// processActivations handles queued node activations, most urgent first, until ctx is done.
+func (w *WorkerManager) processActivations(ctx context.Context) {
+	for {
+		activation, done, ok := w.queue.Pop(ctx)
+		if !ok {
+			return
+		}
+
+		w.inFlight.Add(1)
+		err := w.handleNodeActivation(ctx, activation)
+		w.inFlight.Add(-1)
+
+		if err != nil {
+			w.logger.ErrorContext(ctx, "Failed to handle node activation",
+				"workflow_id", activation.WorkflowID,
+				"execution_id", activation.ExecutionID,
+				"node_id", activation.NodeID,
+				"error", err)
+		}
+
+		done()
+	}
+}
+
 // handleNodeActivation handles node activation events - this is the core of the new node-based execution
 // Each worker can execute nodes independently and coordinate through direct node-to-node activation.
 func (w *WorkerManager) handleNodeActivation(ctx context.Context, event any) error {
@@ -79,6 +272,16 @@ func (w *WorkerManager) handleNodeActivation(ctx context.Context, event any) err
 		return nil
 	}
 
+	// Log lines and events of this activation carry the correlation ID of its execution, and the
+	// activations it leads to its ordering key and priority
+	ctx = events.WithCorrelationID(ctx, nodeActivationEvent.CorrelationID)
+	ctx = events.WithOrderingKey(ctx, nodeActivationEvent.OrderingKey)
+	ctx = events.WithPriority(ctx, nodeActivationEvent.Priority)
+
+	// The execution completes once this was its last pending activation, after the activations it
+	// leads to were counted
+	defer w.activationHandled(ctx, nodeActivationEvent)
+
 	logger := w.logger.With(
 		"workflow_id", nodeActivationEvent.WorkflowID,
 		"execution_id", nodeActivationEvent.ExecutionID,
@@ -87,23 +290,28 @@ func (w *WorkerManager) handleNodeActivation(ctx context.Context, event any) err
 
 	logger.InfoContext(ctx, "Processing node activation event")
 
-	// 1. Get node definition from NodeRepository
-	node, err := w.persistence.NodeRepository().GetNodeByWorkflow(ctx, nodeActivationEvent.WorkflowID, nodeActivationEvent.NodeID)
+	// 1. Get node definition from the workflow version the execution is pinned to
+	wf, err := w.pinnedWorkflow(ctx, nodeActivationEvent.WorkflowID)
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to get node definition", "error", err)
 
 		return w.publishNodeCompletionEvent(ctx, nodeActivationEvent, nil, err)
 	}
 
-	if node == nil {
-		err = fmt.Errorf("node not found: %s", nodeActivationEvent.NodeID)
-		logger.ErrorContext(ctx, "Node not found", "error", err)
+	if wf == nil {
+		return w.failMissingWorkflow(ctx, logger, nodeActivationEvent)
+	}
 
-		return w.publishNodeCompletionEvent(ctx, nodeActivationEvent, nil, err)
+	node := workflowNode(wf, nodeActivationEvent.NodeID)
+	if node == nil {
+		return w.failMissingNode(ctx, logger, wf, nodeActivationEvent)
 	}
 
+	// From here on, the activation logs at the node's log level
+	logger = withNodeLogLevel(logger, node)
+
 	// 2. Get node input requirements
-	requirements := w.getNodeInputRequirements(ctx, node)
+	requirements := w.getNodeInputRequirements(ctx, wf, node)
 
 	// 3. Check if node has a pending execution (FIFO for loops)
 	pendingExecution, err := w.inputCoordinator.GetPendingNodeExecution(
@@ -188,20 +396,107 @@ func (w *WorkerManager) handleNodeActivation(ctx context.Context, event any) err
 		return w.publishNodeCompletionEvent(ctx, nodeActivationEvent, nil, err)
 	}
 
-	// 7. Execute node with all collected inputs
-	outputs, err := w.executeNodeWithInputs(ctx, node, inputState.ReceivedInputs, execCtx)
+	execCtx.Templates = wf.Templates
+
+	// A node terminated the execution: activations still in flight run nothing
+	if execCtx.Terminated() {
+		logger.InfoContext(ctx, "Execution terminated, skipping node", "status", execCtx.Status)
+
+		if err := w.inputCoordinator.CleanupNodeExecution(ctx, nodeExecutionID); err != nil {
+			logger.WarnContext(ctx, "Failed to cleanup input state", "error", err)
+		}
+
+		return nil
+	}
+
+	// 7. Execute node with all collected inputs. Inputs failing the node's input schema route to its
+	// invalid-input port without executing it, and failures rescheduled for a retry within the retry
+	// budget end the activation, keeping the inputs for the retry
+	outputs, err := workflow.ValidateInputs(node, inputState.ReceivedInputs)
+	if outputs != nil {
+		logger.InfoContext(ctx, "Node inputs do not satisfy its input schema", "error", outputs[models.InvalidInputPort].Error)
+	} else if err == nil {
+		outputs, err = w.executeNodeWithInputs(ctx, node, inputState.ReceivedInputs, w.liveExecutionContext(ctx, execCtx, node))
+		if w.retryNode(ctx, logger, node, nodeActivationEvent, outputs, err) {
+			return nil
+		}
+	}
+
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to execute node", "error", err)
 
+		// A failed execution produces no ports to wire, so it is always unhandled
+		w.handleUnhandledFailure(ctx, nodeActivationEvent.WorkflowID, nodeActivationEvent.ExecutionID,
+			nodeActivationEvent.NodeID, "", nil, err.Error())
+
 		return w.publishNodeCompletionEvent(ctx, nodeActivationEvent, nil, err)
 	}
 
 	logger.InfoContext(ctx, "Node executed successfully", "node_execution_id", nodeExecutionID, "output_ports", len(outputs))
 
-	// 8. Store results in execution context
+	// 8. Store results in execution context, applying state updates and pausing the execution on approval
+	// requests and event waits
+	var (
+		approvals   []models.ApprovalRequest
+		waits       []models.EventWait
+		termination *models.Termination
+	)
+
 	for port, result := range outputs {
 		logger.DebugContext(ctx, "Node output result", "node_id", nodeActivationEvent.NodeID, "port", port, "result", result)
-		execCtx.NodeResults[nodeActivationEvent.NodeID+"::"+port] = result
+
+		if result.Status == string(models.NodeStatusPaused) {
+			var request models.ApprovalRequest
+			if models.DecodeResultData(result.Data, models.ApprovalDataKey, &request) {
+				approvals = append(approvals, request)
+			}
+
+			var wait models.EventWait
+			if models.DecodeResultData(result.Data, models.EventWaitDataKey, &wait) {
+				waits = append(waits, wait)
+			}
+
+			continue
+		}
+
+		var requested models.Termination
+		if models.DecodeResultData(result.Data, models.TerminationDataKey, &requested) {
+			termination = &requested
+		}
+
+		var update models.StateUpdate
+		if models.DecodeResultData(result.Data, models.StateUpdateDataKey, &update) {
+			execCtx.SetState(update.Name, update.Value)
+		}
+
+		key := models.MakeNodeResultKey(nodeActivationEvent.NodeID, port)
+		execCtx.NodeResults[key] = w.offloadResult(ctx, execCtx, key, result)
+	}
+
+	// Record the nodes that completed, for a rollback to compensate them
+	if port, completed := workflow.CompletedPort(outputs); completed {
+		execCtx.MarkCompleted(models.MakeNodeResultKey(nodeActivationEvent.NodeID, port))
+	}
+
+	// Count the repeats of looping nodes, starting over once they complete without repeating
+	if _, repeats := outputs[models.RepeatOutputPort]; repeats {
+		execCtx.SetIteration(nodeActivationEvent.NodeID, execCtx.Iteration(nodeActivationEvent.NodeID)+1)
+	} else {
+		execCtx.SetIteration(nodeActivationEvent.NodeID, 0)
+	}
+
+	if len(approvals) > 0 {
+		execCtx.Approvals = append(execCtx.Approvals, approvals...)
+		execCtx.Status = models.ExecutionStatusPaused
+	}
+
+	if len(waits) > 0 {
+		execCtx.EventWaits = append(execCtx.EventWaits, waits...)
+		execCtx.Status = models.ExecutionStatusPaused
+	}
+
+	if termination != nil {
+		execCtx.Terminate(*termination, time.Now().UTC())
 	}
 
 	// Update execution context in persistence
@@ -217,18 +512,63 @@ func (w *WorkerManager) handleNodeActivation(ctx context.Context, event any) err
 		logger.WarnContext(ctx, "Failed to cleanup input state", "error", err)
 	}
 
-	// 10. Activate next nodes
-	err = w.activateNextNodes(ctx, nodeActivationEvent.WorkflowID, nodeActivationEvent.ExecutionID, nodeActivationEvent.NodeID, outputs)
-	if err != nil {
-		logger.ErrorContext(ctx, "Failed to activate next nodes", "error", err)
+	for _, request := range approvals {
+		w.publishExecutionPausedEvent(ctx, execCtx, request.NodeID, approvalPauseReason, approvalData(request))
+	}
 
-		return err
+	for _, wait := range waits {
+		w.publishExecutionPausedEvent(ctx, execCtx, wait.NodeID, eventWaitPauseReason, nil)
+	}
+
+	// A terminated execution ends here, whatever the connections leaving the node
+	if termination != nil {
+		logger.InfoContext(ctx, "Execution terminated", "status", termination.Status, "message", termination.Message)
+
+		w.publishExecutionTerminatedEvent(ctx, execCtx, *termination)
+		w.executionFinished(ctx, wf, execCtx)
+
+		return w.publishNodeCompletionEvent(ctx, nodeActivationEvent, outputs, nil)
+	}
+
+	// 10. Activate next nodes. A connection transform that fails would leave its target waiting
+	// forever, so it fails the execution instead
+	if err := w.activateNextNodes(ctx, wf, nodeActivationEvent.ExecutionID, nodeActivationEvent.NodeID, outputs); err != nil {
+		logger.ErrorContext(ctx, "Failed to transform connection data", "error", err)
+
+		w.failExecution(ctx, logger, wf, execCtx, nodeActivationEvent.NodeID, err)
+
+		return w.publishNodeCompletionEvent(ctx, nodeActivationEvent, outputs, err)
 	}
 
 	// 11. Publish node completion event
 	return w.publishNodeCompletionEvent(ctx, nodeActivationEvent, outputs, nil)
 }
 
+// liveExecutionContext returns the execution context a node executes with. With pruning enabled,
+// results no pending node reads are left out; they stay in the persisted execution context.
+func (w *WorkerManager) liveExecutionContext(
+	ctx context.Context,
+	execCtx *models.ExecutionContext,
+	node *models.WorkflowNode,
+) *models.ExecutionContext {
+	if !w.pruneResults {
+		return execCtx
+	}
+
+	wf, err := w.pinnedWorkflow(ctx, execCtx.WorkflowID)
+	if err != nil || wf == nil {
+		w.logger.WarnContext(ctx, "Failed to get workflow, executing node with every node result",
+			"workflow_id", execCtx.WorkflowID, "error", err)
+
+		return execCtx
+	}
+
+	live := *execCtx
+	live.NodeResults = workflow.LiveNodeResults(wf, execCtx.NodeResults, node.ID)
+
+	return &live
+}
+
 // executeNodeWithInputs creates and executes a node with the provided inputs.
 func (w *WorkerManager) executeNodeWithInputs(
 	ctx context.Context,
@@ -242,29 +582,70 @@ func (w *WorkerManager) executeNodeWithInputs(
 		return nil, fmt.Errorf("failed to create node instance: %w", err)
 	}
 
-	// Execute the node with collected inputs
-	outputs, err := nodeInstance.Execute(*execCtx, inputs)
+	// Execute the node with collected inputs, layering its variable overrides on the workflow variables.
+	// The node gets its own copy of variables and state, so it can only write state through its results.
+	nodeCtx := execCtx.WithVariableOverrides(node.VariableOverrides)
+	isolatedCtx := nodeCtx.Isolated()
+	isolatedCtx.Logger = withNodeLogLevel(w.logger.With(
+		"workflow_id", execCtx.WorkflowID,
+		"execution_id", execCtx.ID,
+		"node_id", node.ID,
+	), node)
+
+	if w.payloads != nil {
+		isolatedCtx.ResolvePayload = w.payloads.Resolver(ctx, execCtx.ID)
+	}
+
+	isolatedCtx.MissingKeys = w.missingKeys
+
+	startedAt := time.Now()
+	outputs, err := nodeInstance.Execute(isolatedCtx, inputs)
+
+	if err == nil {
+		err = nodeCtx.CheckVariables(isolatedCtx)
+	}
+
 	if err != nil {
+		w.metrics.RecordExecution(ctx, node.Type, string(models.NodeStatusError), time.Since(startedAt))
+
 		return nil, fmt.Errorf("node execution failed: %w", err)
 	}
 
-	return outputs, nil
+	w.metrics.RecordExecution(ctx, node.Type, string(models.NodeStatusSuccess), time.Since(startedAt))
+
+	// Reshape the raw results with the node's output template before they are stored and passed on
+	return workflow.ApplyOutputTemplate(node, outputs)
+}
+
+// withNodeLogLevel returns logger overriding its level with the log level of node, if it has one.
+func withNodeLogLevel(logger *slog.Logger, node *models.WorkflowNode) *slog.Logger {
+	level, ok := log.ParseLevel(node.LogLevel)
+	if !ok {
+		return logger
+	}
+
+	return log.WithLevel(logger, level)
 }
 
 // activateNextNodes queries connections and activates connected nodes - implements direct worker-to-worker coordination.
-func (w *WorkerManager) activateNextNodes(ctx context.Context, publishedWorkflowID, executionID, sourceNodeID string, outputs map[string]models.NodeResult) error {
+// It returns the error of a connection transform that fails, without activating any node.
+func (w *WorkerManager) activateNextNodes(ctx context.Context, wf *models.Workflow, executionID, sourceNodeID string, outputs map[string]models.NodeResult) error {
+	publishedWorkflowID := wf.ID
+
 	// Get all connections from this node
-	connections, err := w.persistence.ConnectionRepository().GetConnectionsBySourceNode(ctx, publishedWorkflowID, sourceNodeID)
-	if err != nil {
-		return fmt.Errorf("failed to get connections for node %s: %w", sourceNodeID, err)
-	}
+	connections := sourceConnections(wf, sourceNodeID)
 
 	w.logger.InfoContext(ctx, "Found connections to activate",
 		"source_node", sourceNodeID,
 		"connections_count", len(connections),
 	)
 
-	// For each connection, check if we have output on the required port and activate target node
+	connectedPorts := make(map[string]bool, len(connections))
+
+	// Transform the data of every connection before activating any target, so a failed transform
+	// activates nothing
+	var activations []*events.NodeActivation
+
 	for _, conn := range connections {
 		// Parse port IDs to extract node and port names
 		_, sourcePortName, sourceOK := models.ParsePortID(conn.SourcePort)
@@ -274,6 +655,8 @@ func (w *WorkerManager) activateNextNodes(ctx context.Context, publishedWorkflow
 			continue
 		}
 
+		connectedPorts[sourcePortName] = true
+
 		targetNodeID, targetPortName, targetOK := models.ParsePortID(conn.TargetPort)
 		if !targetOK {
 			w.logger.WarnContext(ctx, "Invalid target port ID format", "port_id", conn.TargetPort)
@@ -281,46 +664,480 @@ func (w *WorkerManager) activateNextNodes(ctx context.Context, publishedWorkflow
 			continue
 		}
 
-		if output, hasOutput := outputs[sourcePortName]; hasOutput {
-			// Prepare activation event for target node
-			activationEvent := &events.NodeActivation{
-				BaseEvent: events.BaseEvent{
-					ID:        fmt.Sprintf("node-activation-%d", time.Now().UnixNano()),
-					Timestamp: time.Now(),
-				},
-				ExecutionID: executionID,
-				NodeID:      targetNodeID,
-				WorkflowID:  publishedWorkflowID,
-				InputPort:   targetPortName,
-				InputData:   output.Data,
-				SourceNode:  sourceNodeID,
-				SourcePort:  sourcePortName,
-			}
+		output, hasOutput := outputs[sourcePortName]
+		if !hasOutput {
+			continue
+		}
+
+		inputData, err := workflow.ApplyConnectionTransform(conn, output.Data)
+		if err != nil {
+			return err
+		}
 
-			// Publish activation event - this implements direct worker-to-worker coordination via Kafka
-			eventKey := activationEvent.NodeID + ":" + activationEvent.ExecutionID
+		activations = append(activations, &events.NodeActivation{
+			BaseEvent: events.BaseEvent{
+				ID:            fmt.Sprintf("node-activation-%d", time.Now().UnixNano()),
+				Timestamp:     time.Now(),
+				CorrelationID: events.CorrelationID(ctx),
+			},
+			ExecutionID: executionID,
+			NodeID:      targetNodeID,
+			WorkflowID:  publishedWorkflowID,
+			InputPort:   targetPortName,
+			InputData:   inputData,
+			SourceNode:  sourceNodeID,
+			SourcePort:  sourcePortName,
+			OrderingKey: events.OrderingKey(ctx),
+			Priority:    events.Priority(ctx),
+			NotBefore:   output.NotBefore,
+		})
+	}
 
-			err = w.eventBus.Publish(ctx, eventKey, activationEvent)
-			if err != nil {
-				w.logger.ErrorContext(ctx, "Failed to activate next node",
-					"target_node", targetNodeID,
-					"source_port", sourcePortName,
-					"target_port", targetPortName,
-					"error", err)
+	for _, activationEvent := range activations {
+		// Publish activation event - this implements direct worker-to-worker coordination via Kafka
+		if err := w.publishActivation(ctx, activationEvent); err != nil {
+			w.logger.ErrorContext(ctx, "Failed to activate next node",
+				"target_node", activationEvent.NodeID,
+				"source_port", activationEvent.SourcePort,
+				"target_port", activationEvent.InputPort,
+				"error", err)
 
-				continue
-			}
+			continue
+		}
+
+		w.logger.InfoContext(ctx, "Activated next node",
+			"target_node", activationEvent.NodeID,
+			"source_port", activationEvent.SourcePort,
+			"target_port", activationEvent.InputPort)
+	}
+
+	// A repeat port without an outgoing connection activates the node itself again
+	if output, repeats := outputs[models.RepeatOutputPort]; repeats && !connectedPorts[models.RepeatOutputPort] {
+		activationEvent := &events.NodeActivation{
+			BaseEvent: events.BaseEvent{
+				ID:            fmt.Sprintf("node-activation-%d", time.Now().UnixNano()),
+				Timestamp:     time.Now(),
+				CorrelationID: events.CorrelationID(ctx),
+			},
+			ExecutionID: executionID,
+			NodeID:      sourceNodeID,
+			WorkflowID:  publishedWorkflowID,
+			InputPort:   repeatInputPort,
+			InputData:   output.Data,
+			SourceNode:  sourceNodeID,
+			SourcePort:  models.RepeatOutputPort,
+			OrderingKey: events.OrderingKey(ctx),
+			Priority:    events.Priority(ctx),
+			NotBefore:   output.NotBefore,
+		}
+
+		if err := w.publishActivation(ctx, activationEvent); err != nil {
+			w.logger.ErrorContext(ctx, "Failed to repeat node", "node_id", sourceNodeID, "error", err)
+		}
+	}
+
+	// Failures on ports without an outgoing connection go to the workflow's catch-all error handler
+	for port, output := range outputs {
+		if output.Status != string(models.NodeStatusError) || connectedPorts[port] {
+			continue
+		}
+
+		errorMessage := output.Error
+		if errorMessage == "" {
+			errorMessage, _ = output.Data["error"].(string)
+		}
+
+		w.handleUnhandledFailure(ctx, publishedWorkflowID, executionID, sourceNodeID, port, output.Data, errorMessage)
+	}
+
+	return nil
+}
+
+// failExecution terminates the execution as failed by nodeID, so activations still in flight
+// skip their node, and reports it finished.
+func (w *WorkerManager) failExecution(
+	ctx context.Context,
+	logger *slog.Logger,
+	wf *models.Workflow,
+	execCtx *models.ExecutionContext,
+	nodeID string,
+	failure error,
+) {
+	termination := models.Termination{
+		NodeID:  nodeID,
+		Status:  models.ExecutionStatusFailed,
+		Message: failure.Error(),
+	}
+	execCtx.Terminate(termination, time.Now().UTC())
+
+	if err := w.persistence.ExecutionContextRepository().UpdateExecutionContext(ctx, execCtx); err != nil {
+		logger.ErrorContext(ctx, "Failed to update execution context", "error", err)
+
+		return
+	}
+
+	w.publishExecutionTerminatedEvent(ctx, execCtx, termination)
+	w.executionFinished(ctx, wf, execCtx)
+}
+
+// handleUnhandledFailure rolls the execution back when its workflow rolls back on failure, then
+// activates the workflow's catch-all error handler with the failure. A failure neither rolled back
+// nor handled fails the execution.
+func (w *WorkerManager) handleUnhandledFailure(
+	ctx context.Context,
+	publishedWorkflowID, executionID, failedNodeID, failedPort string,
+	result map[string]any,
+	errorMessage string,
+) {
+	rolledBack := w.rollback(ctx, publishedWorkflowID, executionID, failedNodeID)
+	handled := w.activateErrorHandler(ctx, publishedWorkflowID, executionID, failedNodeID, failedPort, result, errorMessage)
+
+	if rolledBack || handled {
+		return
+	}
+
+	logger := w.logger.With("workflow_id", publishedWorkflowID, "execution_id", executionID, "node_id", failedNodeID)
+
+	execCtx, err := w.persistence.ExecutionContextRepository().GetExecutionContext(ctx, executionID)
+	if err != nil || execCtx == nil {
+		logger.ErrorContext(ctx, "Failed to get execution context of unhandled failure", "error", err)
+
+		return
+	}
+
+	if execCtx.Status.IsTerminal() {
+		return
+	}
+
+	wf, err := w.pinnedWorkflow(ctx, publishedWorkflowID)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to get workflow of unhandled failure", "error", err)
+	}
+
+	w.failExecution(ctx, logger, wf, execCtx, failedNodeID, fmt.Errorf("node %s failed: %s", failedNodeID, errorMessage))
+}
+
+// rollback runs the compensations of the nodes completed in the execution, the most recent first,
+// and marks the execution failed, reporting whether it did. A compensation that fails is recorded
+// and does not stop the rollback: the nodes completed before it are still compensated. An
+// execution rolls back once.
+func (w *WorkerManager) rollback(ctx context.Context, publishedWorkflowID, executionID, failedNodeID string) bool {
+	wf, err := w.pinnedWorkflow(ctx, publishedWorkflowID)
+	if err != nil || wf == nil {
+		w.logger.WarnContext(ctx, "Failed to get workflow for rollback",
+			"workflow_id", publishedWorkflowID,
+			"error", err)
+
+		return false
+	}
+
+	if !wf.RollbackOnFailure {
+		return false
+	}
+
+	execCtx, err := w.persistence.ExecutionContextRepository().GetExecutionContext(ctx, executionID)
+	if err != nil || execCtx == nil {
+		w.logger.ErrorContext(ctx, "Failed to get execution context for rollback",
+			"execution_id", executionID,
+			"error", err)
+
+		return false
+	}
+
+	if execCtx.RolledBack() {
+		return false
+	}
+
+	execCtx.Templates = wf.Templates
+
+	logger := w.logger.With("workflow_id", publishedWorkflowID, "execution_id", executionID, "failed_node", failedNodeID)
+	logger.InfoContext(ctx, "Rolling back execution")
+
+	steps := workflow.CompensationSteps(wf, execCtx)
+	outcomes := make([]any, 0, len(steps))
+
+	for _, step := range steps {
+		compensationNode := step.CompensationNode()
+		outcome := map[string]any{"node_id": step.Node.ID, "status": models.CompensationStatusCompensated}
+
+		// The compensation receives the result the node completed with on its main input
+		var outputs map[string]models.NodeResult
+
+		result, err := w.resolveResult(ctx, executionID, step.Result)
+		if err == nil {
+			outputs, err = w.executeNodeWithInputs(ctx, compensationNode,
+				map[string]models.NodeResult{compensationInputPort: result}, execCtx)
+		}
+
+		if err == nil {
+			err = outputsError(outputs)
+		}
+
+		if err != nil {
+			outcome["status"] = models.CompensationStatusFailed
+			outcome["error"] = err.Error()
+
+			logger.ErrorContext(ctx, "Failed to compensate node", "node_id", step.Node.ID, "error", err)
+		} else {
+			logger.InfoContext(ctx, "Compensated node", "node_id", step.Node.ID)
+		}
+
+		for port, result := range outputs {
+			key := models.MakeNodeResultKey(compensationNode.ID, port)
+			execCtx.NodeResults[key] = w.offloadResult(ctx, execCtx, key, result)
+		}
+
+		outcomes = append(outcomes, outcome)
+	}
+
+	if execCtx.Metadata == nil {
+		execCtx.Metadata = make(map[string]any)
+	}
+
+	execCtx.Metadata[models.CompensationsMetadataKey] = outcomes
+	execCtx.Status = models.ExecutionStatusFailed
+	execCtx.ErrorMessage = fmt.Sprintf("node %s failed, execution rolled back", failedNodeID)
+
+	completedAt := time.Now().UTC()
+	execCtx.CompletedAt = &completedAt
+
+	if err := w.persistence.ExecutionContextRepository().UpdateExecutionContext(ctx, execCtx); err != nil {
+		logger.ErrorContext(ctx, "Failed to update rolled back execution context", "error", err)
+
+		return false
+	}
+
+	w.executionFinished(ctx, wf, execCtx)
+
+	return true
+}
+
+// outputsError returns the error of the first output on an error status, if any.
+func outputsError(outputs map[string]models.NodeResult) error {
+	for port, output := range outputs {
+		if output.Status != string(models.NodeStatusError) {
+			continue
+		}
 
-			w.logger.InfoContext(ctx, "Activated next node",
-				"target_node", targetNodeID,
-				"source_port", sourcePortName,
-				"target_port", targetPortName)
+		errorMessage := output.Error
+		if errorMessage == "" {
+			errorMessage, _ = output.Data["error"].(string)
 		}
+
+		return fmt.Errorf("failed on port %s: %s", port, errorMessage)
 	}
 
 	return nil
 }
 
+// activateErrorHandler activates the workflow's catch-all error handler node with an unhandled
+// node failure, reporting whether it did. It does nothing when the workflow has no error handler
+// or the handler itself failed.
+func (w *WorkerManager) activateErrorHandler(
+	ctx context.Context,
+	publishedWorkflowID, executionID, failedNodeID, failedPort string,
+	result map[string]any,
+	errorMessage string,
+) bool {
+	workflow, err := w.pinnedWorkflow(ctx, publishedWorkflowID)
+	if err != nil || workflow == nil {
+		w.logger.WarnContext(ctx, "Failed to get workflow for error handling",
+			"workflow_id", publishedWorkflowID,
+			"error", err)
+
+		return false
+	}
+
+	handlerNodeID := workflow.ErrorHandlerNodeID
+	if handlerNodeID == "" || handlerNodeID == failedNodeID {
+		return false
+	}
+
+	activationEvent := &events.NodeActivation{
+		BaseEvent: events.BaseEvent{
+			ID:            fmt.Sprintf("node-activation-%d", time.Now().UnixNano()),
+			Timestamp:     time.Now(),
+			CorrelationID: events.CorrelationID(ctx),
+		},
+		ExecutionID: executionID,
+		NodeID:      handlerNodeID,
+		WorkflowID:  publishedWorkflowID,
+		InputPort:   errorHandlerInputPort,
+		InputData: map[string]any{
+			"node_id": failedNodeID,
+			"port":    failedPort,
+			"error":   errorMessage,
+			"result":  result,
+		},
+		SourceNode:  failedNodeID,
+		SourcePort:  failedPort,
+		OrderingKey: events.OrderingKey(ctx),
+		Priority:    events.Priority(ctx),
+	}
+
+	if err := w.publishActivation(ctx, activationEvent); err != nil {
+		w.logger.ErrorContext(ctx, "Failed to activate error handler node",
+			"error_handler_node", handlerNodeID,
+			"failed_node", failedNodeID,
+			"error", err)
+
+		return false
+	}
+
+	w.logger.InfoContext(ctx, "Activated error handler node",
+		"error_handler_node", handlerNodeID,
+		"failed_node", failedNodeID,
+		"failed_port", failedPort)
+
+	return true
+}
+
+// approvalData returns the approval details of a paused event for an approval request.
+func approvalData(request models.ApprovalRequest) map[string]any {
+	data := map[string]any{
+		"token":   request.Token,
+		"message": request.Message,
+	}
+
+	if request.ExpiresAt != nil {
+		data["expires_at"] = request.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return data
+}
+
+// publishExecutionPausedEvent announces that an execution is paused at nodeID, waiting for a
+// decision on an approval request or for an external event.
+func (w *WorkerManager) publishExecutionPausedEvent(
+	ctx context.Context,
+	execCtx *models.ExecutionContext,
+	nodeID, reason string,
+	approvalData map[string]any,
+) {
+	pausedEvent := &events.WorkflowExecutionPaused{
+		BaseEvent:    events.NewBaseEvent(events.WorkflowExecutionPausedEvent, execCtx.WorkflowID),
+		ExecutionID:  execCtx.ID,
+		Status:       string(models.ExecutionStatusPaused),
+		PauseReason:  reason,
+		PausedAtNode: nodeID,
+		ApprovalData: approvalData,
+	}
+	pausedEvent.CorrelationID = events.CorrelationID(ctx)
+
+	eventKey := nodeID + ":" + execCtx.ID
+
+	if err := w.eventBus.Publish(ctx, eventKey, pausedEvent); err != nil {
+		w.logger.ErrorContext(ctx, "Failed to publish execution paused event",
+			"execution_id", execCtx.ID,
+			"node_id", nodeID,
+			"error", err)
+
+		return
+	}
+
+	w.logger.InfoContext(ctx, "Execution paused",
+		"execution_id", execCtx.ID,
+		"node_id", nodeID,
+		"pause_reason", reason)
+}
+
+// publishExecutionTerminatedEvent announces that the execution ended, terminated by a node or
+// completed after its last node, with the completed, failed or cancelled event of the termination's
+// status.
+func (w *WorkerManager) publishExecutionTerminatedEvent(
+	ctx context.Context,
+	execCtx *models.ExecutionContext,
+	termination models.Termination,
+) {
+	var durationMs int64
+	if execCtx.CompletedAt != nil && !execCtx.CreatedAt.IsZero() {
+		durationMs = execCtx.CompletedAt.Sub(execCtx.CreatedAt).Milliseconds()
+	}
+
+	nodesExecuted := len(execCtx.NodeResults)
+
+	baseEvent := func(eventType events.EventType) events.BaseEvent {
+		base := events.NewBaseEvent(eventType, execCtx.WorkflowID)
+		base.CorrelationID = events.CorrelationID(ctx)
+
+		return base
+	}
+
+	var event eventbus.Event
+
+	switch termination.Status {
+	case models.ExecutionStatusFailed:
+		event = &events.WorkflowExecutionFailed{
+			BaseEvent:     baseEvent(events.WorkflowExecutionFailedEvent),
+			ExecutionID:   execCtx.ID,
+			Status:        string(execCtx.Status),
+			DurationMs:    durationMs,
+			Error:         events.WorkflowError{NodeID: termination.NodeID, Message: termination.Message},
+			NodesExecuted: nodesExecuted,
+		}
+	case models.ExecutionStatusCancelled:
+		event = &events.WorkflowExecutionCancelled{
+			BaseEvent:     baseEvent(events.WorkflowExecutionCancelledEvent),
+			ExecutionID:   execCtx.ID,
+			Status:        string(execCtx.Status),
+			DurationMs:    durationMs,
+			Reason:        termination.Message,
+			CancelledBy:   termination.NodeID,
+			NodesExecuted: nodesExecuted,
+		}
+	default:
+		event = &events.WorkflowExecutionCompleted{
+			BaseEvent:     baseEvent(events.WorkflowExecutionCompletedEvent),
+			ExecutionID:   execCtx.ID,
+			Status:        string(execCtx.Status),
+			DurationMs:    durationMs,
+			NodesExecuted: nodesExecuted,
+		}
+	}
+
+	if err := w.eventBus.Publish(ctx, termination.NodeID+":"+execCtx.ID, event); err != nil {
+		w.logger.ErrorContext(ctx, "Failed to publish execution terminated event",
+			"execution_id", execCtx.ID,
+			"node_id", termination.NodeID,
+			"error", err)
+	}
+}
+
+// expireApprovals periodically resumes approval requests that expired without a decision and
+// event waits that expired without their event.
+func (w *WorkerManager) expireApprovals(ctx context.Context) {
+	ticker := time.NewTicker(approvalExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.expirePausedNodes(ctx, now.UTC())
+		}
+	}
+}
+
+// expirePausedNodes resumes the approval requests and event waits expired by now.
+func (w *WorkerManager) expirePausedNodes(ctx context.Context, now time.Time) {
+	expired, err := w.executionService.ExpireApprovals(ctx, now)
+	if err != nil {
+		w.logger.ErrorContext(ctx, "Failed to expire approval requests", "error", err)
+	}
+
+	if expired > 0 {
+		w.logger.InfoContext(ctx, "Expired approval requests", "count", expired)
+	}
+
+	expired, err = w.executionService.ExpireEventWaits(ctx, now)
+	if err != nil {
+		w.logger.ErrorContext(ctx, "Failed to expire event waits", "error", err)
+	} else if expired > 0 {
+		w.logger.InfoContext(ctx, "Expired event waits", "count", expired)
+	}
+}
+
 // publishNodeCompletionEvent publishes a node completion event for workflow orchestration.
 func (w *WorkerManager) publishNodeCompletionEvent(ctx context.Context, nodeActivation *events.NodeActivation, outputs map[string]models.NodeResult, execError error) error {
 	status := models.NodeStatusSuccess
@@ -334,8 +1151,9 @@ func (w *WorkerManager) publishNodeCompletionEvent(ctx context.Context, nodeActi
 
 	completionEvent := &events.NodeCompletion{
 		BaseEvent: events.BaseEvent{
-			ID:        fmt.Sprintf("node-completion-%d", time.Now().UnixNano()),
-			Timestamp: time.Now(),
+			ID:            fmt.Sprintf("node-completion-%d", time.Now().UnixNano()),
+			Timestamp:     time.Now(),
+			CorrelationID: events.CorrelationID(ctx),
 		},
 		WorkflowID:   nodeActivation.WorkflowID,
 		ExecutionID:  nodeActivation.ExecutionID,
@@ -363,20 +1181,30 @@ func convertNodeResultsToOutputData(outputs map[string]models.NodeResult) map[st
 
 // getNodeInputRequirements gets input requirements for a node by creating an instance.
 // This allows nodes to declare their coordination needs via the NodeInputRequirements interface.
-func (w *WorkerManager) getNodeInputRequirements(ctx context.Context, node *models.WorkflowNode) models.InputRequirements {
+func (w *WorkerManager) getNodeInputRequirements(ctx context.Context, wf *models.Workflow, node *models.WorkflowNode) models.InputRequirements {
+	requirements := models.DefaultInputRequirements()
+
 	// Create the node instance (lightweight operation for purely functional nodes)
 	nodeImpl, err := w.registry.CreateNode(ctx, node.Type, node.ID, node.Config)
 	if err != nil {
 		w.logger.WarnContext(ctx, "Could not create node for requirements, using defaults",
 			"node_type", node.Type, "error", err)
 
-		return models.DefaultInputRequirements()
+		return requirements
 	}
 
 	// Get requirements from the node itself
 	if reqNode, ok := nodeImpl.(models.NodeInputRequirements); ok {
-		return reqNode.InputRequirements()
+		requirements = reqNode.InputRequirements()
+	}
+
+	if !node.Join || requirements.WaitMode != models.WaitModeAll {
+		return requirements
 	}
 
-	return models.DefaultInputRequirements()
+	// Join nodes wait for every upstream node connected to them that can fire in this execution
+	requirements.Sources = workflow.InputSources(wf, node.ID)
+	requirements.Exclusive = workflow.ExclusiveSources(wf, node.ID)
+
+	return requirements
 }
@@ -1,16 +1,28 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/dukex/operion/pkg/eventbus"
 	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/log"
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/nodes/transform"
+	"github.com/dukex/operion/pkg/persistence"
 	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/dukex/operion/pkg/protocol"
 	"github.com/dukex/operion/pkg/registry"
+	"github.com/dukex/operion/pkg/template"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -57,7 +69,7 @@ func TestNewWorkerManager(t *testing.T) {
 	// Verify worker manager is created correctly
 	assert.NotNil(t, wm)
 	assert.Equal(t, workerID, wm.id)
-	assert.Equal(t, persistence, wm.persistence)
+	assert.Equal(t, persistence, wm.persistence.Persistence)
 	assert.Equal(t, registry, wm.registry)
 	assert.Equal(t, eventBus, wm.eventBus)
 	assert.NotNil(t, wm.logger)
@@ -209,3 +221,1626 @@ func (r *MockWorkflowRepository) Create(workflow *models.Workflow) error {
 
 	return nil
 }
+
+func TestWorkerManager_ExecuteNode_VariableOverrides(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	wm := NewWorkerManager("override-test-worker", file.NewPersistence(t.TempDir()), &MockEventBus{}, logger, reg)
+
+	execCtx := &models.ExecutionContext{
+		ID:          "exec-override",
+		WorkflowID:  "override-workflow",
+		NodeResults: make(map[string]models.NodeResult),
+		Variables:   map[string]any{"timeout": 30},
+	}
+
+	shared := &models.WorkflowNode{
+		ID:       "shared",
+		Type:     "transform",
+		Category: models.CategoryTypeAction,
+		Config:   map[string]any{"expression": "{{.variables.timeout}}"},
+	}
+
+	overridden := &models.WorkflowNode{
+		ID:                "overridden",
+		Type:              "transform",
+		Category:          models.CategoryTypeAction,
+		Config:            map[string]any{"expression": "{{.variables.timeout}}"},
+		VariableOverrides: map[string]any{"timeout": 120},
+	}
+
+	overriddenOutputs, err := wm.executeNodeWithInputs(t.Context(), overridden, map[string]models.NodeResult{}, execCtx)
+	require.NoError(t, err)
+	assert.InDelta(t, 120, overriddenOutputs["success"].Data["result"], 0)
+
+	sharedOutputs, err := wm.executeNodeWithInputs(t.Context(), shared, map[string]models.NodeResult{}, execCtx)
+	require.NoError(t, err)
+	assert.InDelta(t, 30, sharedOutputs["success"].Data["result"], 0)
+
+	assert.Equal(t, 30, execCtx.Variables["timeout"], "override must not leak into the shared execution context")
+}
+
+// setupErrorHandlerWorkflow saves a workflow whose "fetch" node always fails and returns a
+// worker manager ready to execute it.
+func setupErrorHandlerWorkflow(t *testing.T, connections []*models.Connection) (*WorkerManager, *MockEventBus) {
+	t.Helper()
+
+	persistence := file.NewPersistence(t.TempDir())
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	workflow := &models.Workflow{
+		ID:                 "error-handler-workflow",
+		Name:               "Error Handler Workflow",
+		Status:             models.WorkflowStatusPublished,
+		ErrorHandlerNodeID: "catch-all",
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:       "fetch",
+				Type:     "transform",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"expression": "{{.missing"},
+				Enabled:  true,
+			},
+			{
+				ID:       "on-fetch-error",
+				Type:     "log",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"message": "fetch failed"},
+				Enabled:  true,
+			},
+			{
+				ID:       "catch-all",
+				Type:     "log",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"message": "unhandled failure"},
+				Enabled:  true,
+			},
+		},
+		Connections: connections,
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          "exec-error-handler",
+		WorkflowID:  workflow.ID,
+		NodeResults: make(map[string]models.NodeResult),
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	eventBus := &MockEventBus{}
+
+	return NewWorkerManager("error-handler-worker", persistence, eventBus, logger, reg), eventBus
+}
+
+// activatedNodes returns the node activations published on the event bus.
+func activatedNodes(eventBus *MockEventBus) []*events.NodeActivation {
+	var activations []*events.NodeActivation
+
+	for _, event := range eventBus.publishedEvents {
+		if activation, ok := event.(*events.NodeActivation); ok {
+			activations = append(activations, activation)
+		}
+	}
+
+	return activations
+}
+
+func activateFetchNode(t *testing.T, wm *WorkerManager) {
+	t.Helper()
+
+	err := wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, "error-handler-workflow"),
+		WorkflowID:  "error-handler-workflow",
+		ExecutionID: "exec-error-handler",
+		NodeID:      "fetch",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	})
+	require.NoError(t, err)
+}
+
+func TestWorkerManager_UnhandledFailure_RoutesToErrorHandler(t *testing.T) {
+	wm, eventBus := setupErrorHandlerWorkflow(t, []*models.Connection{})
+
+	activateFetchNode(t, wm)
+
+	activations := activatedNodes(eventBus)
+	require.Len(t, activations, 1)
+
+	activation := activations[0]
+	assert.Equal(t, "catch-all", activation.NodeID)
+	assert.Equal(t, "main", activation.InputPort)
+	assert.Equal(t, "fetch", activation.SourceNode)
+	assert.Equal(t, "error", activation.SourcePort)
+
+	inputData, ok := activation.InputData.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "fetch", inputData["node_id"])
+	assert.Equal(t, "error", inputData["port"])
+	assert.Contains(t, inputData["error"], "transformation failed")
+	assert.NotNil(t, inputData["result"], "the failing node's result is forwarded")
+}
+
+func TestWorkerManager_HandledFailure_UsesFailureConnection(t *testing.T) {
+	wm, eventBus := setupErrorHandlerWorkflow(t, []*models.Connection{
+		{ID: "conn-error", SourcePort: "fetch:error", TargetPort: "on-fetch-error:main"},
+	})
+
+	activateFetchNode(t, wm)
+
+	activations := activatedNodes(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "on-fetch-error", activations[0].NodeID)
+	assert.Equal(t, "error", activations[0].SourcePort)
+}
+
+func TestWorkerManager_UnhandledFailure_FailsExecution(t *testing.T) {
+	wm, eventBus, persistence := setupRepeatWorkflow(t, &models.Workflow{
+		ID:     "failing-workflow",
+		Name:   "Failing Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "fetch", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": "{{.missing"}, Enabled: true},
+			{ID: "notify", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "fetched"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "conn-1", SourcePort: "fetch:success", TargetPort: "notify:main"},
+		},
+	})
+
+	sink := &recordingSink{}
+	wm.ConfigureExecutionSink(sink)
+
+	// The failure has no failure connection, no error handler and no rollback
+	ranNodes := runActivations(t, wm, eventBus, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, "failing-workflow"),
+		WorkflowID:  "failing-workflow",
+		ExecutionID: "exec-failing-workflow",
+		NodeID:      "fetch",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	})
+	assert.Equal(t, []string{"fetch"}, ranNodes)
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-failing-workflow")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusFailed, execCtx.Status)
+	assert.Contains(t, execCtx.ErrorMessage, "node fetch failed")
+	assert.NotNil(t, execCtx.CompletedAt)
+
+	require.Len(t, sink.records, 1)
+	assert.Equal(t, "failed", sink.records[0].Status)
+
+	var failed *events.WorkflowExecutionFailed
+
+	for _, event := range eventBus.publishedEvents {
+		if event, ok := event.(*events.WorkflowExecutionFailed); ok {
+			failed = event
+		}
+	}
+
+	require.NotNil(t, failed)
+	assert.Equal(t, "fetch", failed.Error.NodeID)
+}
+
+func TestWorkerManager_ApprovalNode_PausesUntilDecision(t *testing.T) {
+	persistence := file.NewPersistence(t.TempDir())
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	workflow := &models.Workflow{
+		ID:     "approval-workflow",
+		Name:   "Approval Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "approve", Type: "approval", Category: models.CategoryTypeAction, Config: map[string]any{"timeout": "1h"}, Enabled: true},
+			{ID: "ship", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "ship"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "conn-approved", SourcePort: "approve:approved", TargetPort: "ship:main"},
+		},
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          "exec-approval",
+		WorkflowID:  workflow.ID,
+		NodeResults: make(map[string]models.NodeResult),
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	eventBus := &MockEventBus{}
+	wm := NewWorkerManager("approval-worker", persistence, eventBus, logger, reg)
+
+	err := wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-approval",
+		NodeID:      "approve",
+		InputPort:   "main",
+		InputData:   map[string]any{"order_id": "42"},
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, activatedNodes(eventBus), "nothing runs until the request is decided")
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-approval")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusPaused, execCtx.Status)
+	require.Len(t, execCtx.Approvals, 1)
+	assert.NotNil(t, execCtx.Approvals[0].ExpiresAt)
+
+	var paused *events.WorkflowExecutionPaused
+
+	for _, event := range eventBus.publishedEvents {
+		if e, ok := event.(*events.WorkflowExecutionPaused); ok {
+			paused = e
+		}
+	}
+
+	require.NotNil(t, paused)
+	assert.Equal(t, "approve", paused.PausedAtNode)
+	assert.Equal(t, execCtx.Approvals[0].Token, paused.ApprovalData["token"])
+
+	_, err = wm.executionService.DecideApproval(t.Context(), execCtx.Approvals[0].Token, true, "")
+	require.NoError(t, err)
+
+	activations := activatedNodes(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "ship", activations[0].NodeID)
+	assert.Equal(t, "42", activations[0].InputData.(map[string]any)["order_id"])
+}
+
+func TestWorkerManager_ApprovalNode_ResumesWithPatchedVariables(t *testing.T) {
+	persistence := file.NewPersistence(t.TempDir())
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	workflow := &models.Workflow{
+		ID:     "approval-workflow",
+		Name:   "Approval Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "approve", Type: "approval", Category: models.CategoryTypeAction, Config: map[string]any{}, Enabled: true},
+			{ID: "ship", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": "ship to {{.variables.address}}"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "conn-approved", SourcePort: "approve:approved", TargetPort: "ship:main"},
+		},
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          "exec-approval",
+		WorkflowID:  workflow.ID,
+		NodeResults: make(map[string]models.NodeResult),
+		Variables:   map[string]any{"address": "1 Wrong St"},
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	eventBus := &MockEventBus{}
+	wm := NewWorkerManager("approval-worker", persistence, eventBus, logger, reg)
+
+	require.NoError(t, wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-approval",
+		NodeID:      "approve",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	}))
+
+	// The operator corrects the address while the execution awaits approval
+	_, err := wm.executionService.PatchVariables(t.Context(), "exec-approval", map[string]any{"address": "2 Right Ave"}, "operator")
+	require.NoError(t, err)
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-approval")
+	require.NoError(t, err)
+	require.Len(t, execCtx.Approvals, 1)
+
+	_, err = wm.executionService.DecideApproval(t.Context(), execCtx.Approvals[0].Token, true, "")
+	require.NoError(t, err)
+
+	activations := activatedNodes(eventBus)
+	require.Len(t, activations, 1)
+	require.NoError(t, wm.handleNodeActivation(t.Context(), activations[0]))
+
+	execCtx, err = persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-approval")
+	require.NoError(t, err)
+	assert.Equal(t, "ship to 2 Right Ave", execCtx.NodeResults[models.MakeNodeResultKey("ship", "success")].Data["result"])
+}
+
+func TestWorkerManager_TerminateNode_EndsExecution(t *testing.T) {
+	tests := []struct {
+		status        models.ExecutionStatus
+		terminalEvent events.EventType
+		errorMessage  string
+	}{
+		{status: models.ExecutionStatusCompleted, terminalEvent: events.WorkflowExecutionCompletedEvent},
+		{status: models.ExecutionStatusFailed, terminalEvent: events.WorkflowExecutionFailedEvent, errorMessage: "order 42: nothing to do"},
+		{status: models.ExecutionStatusCancelled, terminalEvent: events.WorkflowExecutionCancelledEvent, errorMessage: "order 42: nothing to do"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			persistence := file.NewPersistence(t.TempDir())
+			logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+			reg := registry.NewRegistry(logger)
+			reg.RegisterDefaultNodes()
+
+			workflow := &models.Workflow{
+				ID:     "terminate-workflow",
+				Name:   "Terminate Workflow",
+				Status: models.WorkflowStatusPublished,
+				Nodes: []*models.WorkflowNode{
+					{
+						ID: "stop", Type: "terminate", Category: models.CategoryTypeAction, Enabled: true,
+						Config: map[string]any{"status": string(tt.status), "message": "order {{.trigger_data.order_id}}: nothing to do"},
+					},
+					{ID: "after", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "after"}, Enabled: true},
+					{ID: "parallel", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "parallel"}, Enabled: true},
+				},
+				Connections: []*models.Connection{
+					{ID: "conn-terminated", SourcePort: "stop:terminated", TargetPort: "after:main"},
+				},
+			}
+			require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+
+			require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+				ID:          "exec-terminate",
+				WorkflowID:  workflow.ID,
+				TriggerData: map[string]any{"order_id": "42"},
+				NodeResults: make(map[string]models.NodeResult),
+				Status:      models.ExecutionStatusRunning,
+				CreatedAt:   time.Now().UTC(),
+			}))
+
+			eventBus := &MockEventBus{}
+			wm := NewWorkerManager("terminate-worker", persistence, eventBus, logger, reg)
+
+			sink := &recordingSink{}
+			wm.ConfigureExecutionSink(sink)
+
+			activate := func(nodeID string) {
+				err := wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+					BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+					WorkflowID:  workflow.ID,
+					ExecutionID: "exec-terminate",
+					NodeID:      nodeID,
+					InputPort:   "main",
+					InputData:   map[string]any{},
+				})
+				require.NoError(t, err)
+			}
+
+			activate("stop")
+
+			assert.Empty(t, activatedNodes(eventBus), "no node runs after the termination")
+
+			execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-terminate")
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, execCtx.Status)
+			assert.NotNil(t, execCtx.CompletedAt)
+			assert.Equal(t, tt.errorMessage, execCtx.ErrorMessage)
+			assert.True(t, execCtx.Terminated())
+			assert.Equal(t, map[string]any{
+				"node_id": "stop",
+				"status":  string(tt.status),
+				"message": "order 42: nothing to do",
+			}, execCtx.Metadata[models.TerminationMetadataKey])
+
+			var terminalEvents []events.EventType
+
+			for _, event := range eventBus.publishedEvents {
+				switch e := event.(type) {
+				case *events.WorkflowExecutionCompleted:
+					terminalEvents = append(terminalEvents, e.GetType())
+				case *events.WorkflowExecutionFailed:
+					terminalEvents = append(terminalEvents, e.GetType())
+				case *events.WorkflowExecutionCancelled:
+					terminalEvents = append(terminalEvents, e.GetType())
+				}
+			}
+
+			assert.Equal(t, []events.EventType{tt.terminalEvent}, terminalEvents)
+
+			require.Len(t, sink.records, 1)
+			assert.Equal(t, string(tt.status), sink.records[0].Status)
+
+			// A branch activated before the termination does not run anymore
+			activate("parallel")
+
+			execCtx, err = persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-terminate")
+			require.NoError(t, err)
+			assert.NotContains(t, execCtx.NodeResults, models.MakeNodeResultKey("parallel", "success"))
+			assert.Equal(t, tt.status, execCtx.Status)
+		})
+	}
+}
+
+func TestWorkerManager_ProcessesHigherPriorityWorkflowsFirst(t *testing.T) {
+	persistence := file.NewPersistence(t.TempDir())
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	for _, wf := range []*models.Workflow{
+		{ID: "urgent", Name: "Urgent", Metadata: map[string]any{"priority": 10}},
+		{ID: "routine", Name: "Routine"},
+	} {
+		wf.Status = models.WorkflowStatusPublished
+		wf.Nodes = []*models.WorkflowNode{
+			{ID: "log", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": wf.ID}, Enabled: true},
+		}
+		require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), wf))
+	}
+
+	eventBus := &MockEventBus{}
+	wm := NewWorkerManager("constrained-worker", persistence, eventBus, logger, reg)
+	wm.ConfigureScheduling(1, time.Hour, DefaultQueueCapacity)
+
+	// A saturated worker: activations pile up before any of them is processed. They carry the
+	// priority of their workflow
+	priorities := map[string]int{"urgent": 10}
+	enqueued := []string{"routine", "routine", "urgent", "routine", "urgent"}
+	for i, workflowID := range enqueued {
+		executionID := fmt.Sprintf("%s-%d", workflowID, i)
+
+		require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+			ID:          executionID,
+			WorkflowID:  workflowID,
+			NodeResults: make(map[string]models.NodeResult),
+			Status:      models.ExecutionStatusRunning,
+		}))
+
+		require.NoError(t, wm.enqueueNodeActivation(t.Context(), &events.NodeActivation{
+			BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflowID),
+			WorkflowID:  workflowID,
+			ExecutionID: executionID,
+			NodeID:      "log",
+			InputPort:   "main",
+			InputData:   map[string]any{},
+			Priority:    priorities[workflowID],
+		}))
+	}
+
+	// With a done context the loop drains the queue and returns
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	wm.processActivations(ctx)
+
+	var processed []string
+
+	for _, event := range eventBus.publishedEvents {
+		if completion, ok := event.(*events.NodeCompletion); ok {
+			processed = append(processed, completion.ExecutionID)
+		}
+	}
+
+	assert.Equal(t, []string{"urgent-2", "urgent-4", "routine-0", "routine-1", "routine-3"}, processed)
+}
+
+func TestWorkerManager_AcknowledgesActivationsOnceProcessed(t *testing.T) {
+	workflow := &models.Workflow{
+		ID:     "ack-workflow",
+		Name:   "Ack Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "log", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "ack"}, Enabled: true},
+		},
+	}
+
+	wm, eventBus, _ := setupRepeatWorkflow(t, workflow)
+
+	var acknowledged atomic.Bool
+
+	acknowledgement := eventbus.NewAcknowledgement(func() { acknowledged.Store(true) })
+
+	require.NoError(t, wm.enqueueNodeActivation(eventbus.WithAcknowledgement(t.Context(), acknowledgement), &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-ack-workflow",
+		NodeID:      "log",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	}))
+
+	// Queued, not processed: the bus must not acknowledge it yet
+	assert.True(t, acknowledgement.Deferred())
+	assert.False(t, acknowledged.Load())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	wm.processActivations(ctx)
+
+	assert.Equal(t, []string{"log"}, completedNodes(eventBus))
+	assert.True(t, acknowledged.Load())
+}
+
+func TestWorkerManager_ConnectionTransforms_ReshapeDataPerTarget(t *testing.T) {
+	persistence := file.NewPersistence(t.TempDir())
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	workflow := &models.Workflow{
+		ID:     "edge-transform-workflow",
+		Name:   "Edge Transform Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:       "order",
+				Type:     "transform",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"expression": `{"customer": {"name": "Ada", "email": "ada@example.com"}, "total": 42}`},
+				Enabled:  true,
+			},
+			{ID: "greet", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "greet"}, Enabled: true},
+			{ID: "bill", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "bill"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{
+				ID:         "to-greet",
+				SourcePort: "order:success",
+				TargetPort: "greet:main",
+				Transform:  `{"greeting": "Hello {{ .data.result.customer.name }}"}`,
+			},
+			{
+				ID:         "to-bill",
+				SourcePort: "order:success",
+				TargetPort: "bill:main",
+				Transform:  `{"email": "{{ .data.result.customer.email }}", "amount": {{ .data.result.total }}}`,
+			},
+		},
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          "exec-edge-transform",
+		WorkflowID:  workflow.ID,
+		NodeResults: make(map[string]models.NodeResult),
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	eventBus := &MockEventBus{}
+	wm := NewWorkerManager("edge-transform-worker", persistence, eventBus, logger, reg)
+
+	err := wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-edge-transform",
+		NodeID:      "order",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	})
+	require.NoError(t, err)
+
+	inputs := make(map[string]any)
+	for _, activation := range activatedNodes(eventBus) {
+		inputs[activation.NodeID] = activation.InputData
+	}
+
+	assert.Equal(t, map[string]any{"greeting": "Hello Ada"}, inputs["greet"])
+	assert.Equal(t, map[string]any{"email": "ada@example.com", "amount": float64(42)}, inputs["bill"])
+}
+
+func TestWorkerManager_ConnectionTransforms_FailureFailsExecution(t *testing.T) {
+	persistence := file.NewPersistence(t.TempDir())
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	workflow := &models.Workflow{
+		ID:     "failed-transform-workflow",
+		Name:   "Failed Transform Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:       "order",
+				Type:     "transform",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"expression": `{"total": 42}`},
+				Enabled:  true,
+			},
+			{ID: "greet", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "greet"}, Enabled: true},
+			{ID: "bill", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "bill"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "to-greet", SourcePort: "order:success", TargetPort: "greet:main"},
+			{ID: "to-bill", SourcePort: "order:success", TargetPort: "bill:main", Transform: `{{ .data.result.total.amount }}`},
+		},
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          "exec-failed-transform",
+		WorkflowID:  workflow.ID,
+		NodeResults: make(map[string]models.NodeResult),
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	eventBus := &MockEventBus{}
+	wm := NewWorkerManager("failed-transform-worker", persistence, eventBus, logger, reg)
+
+	sink := &recordingSink{}
+	wm.ConfigureExecutionSink(sink)
+
+	err := wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-failed-transform",
+		NodeID:      "order",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	})
+	require.NoError(t, err)
+
+	// No target is activated and the execution fails instead of waiting for them
+	assert.Empty(t, activatedNodes(eventBus))
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-failed-transform")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusFailed, execCtx.Status)
+	assert.Contains(t, execCtx.ErrorMessage, "failed to transform data on connection to-bill")
+
+	// The failure is exported like any finished execution
+	require.Len(t, sink.records, 1)
+	assert.Equal(t, "failed", sink.records[0].Status)
+	assert.Equal(t, "exec-failed-transform", sink.records[0].ExecutionID)
+}
+
+func TestWorkerManager_OutputTemplate_ReshapesStoredResult(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-1")
+		_, _ = fmt.Fprint(w, `{"data": {"user": {"id": 7, "email": "ada@example.com", "roles": ["admin"]}}, "meta": {"took_ms": 12}}`)
+	}))
+	defer api.Close()
+
+	workflow := &models.Workflow{
+		ID:     "output-template-workflow",
+		Name:   "Output Template Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:             "fetch",
+				Type:           "httprequest",
+				Category:       models.CategoryTypeAction,
+				Config:         map[string]any{"url": api.URL},
+				OutputTemplate: `{"user_id": {{ .result.json.data.user.id }}, "email": "{{ .result.json.data.user.email }}"}`,
+				Enabled:        true,
+			},
+			{ID: "notify", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "notify"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "fetch-notify", SourcePort: "fetch:success", TargetPort: "notify:main"},
+		},
+	}
+
+	wm, eventBus, persistence := setupRepeatWorkflow(t, workflow)
+
+	ranNodes := runActivations(t, wm, eventBus, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-output-template-workflow",
+		NodeID:      "fetch",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	})
+	assert.Equal(t, []string{"fetch", "notify"}, ranNodes)
+
+	reduced := map[string]any{"user_id": float64(7), "email": "ada@example.com"}
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-output-template-workflow")
+	require.NoError(t, err)
+	assert.Equal(t, reduced, execCtx.NodeResults[models.MakeNodeResultKey("fetch", "success")].Data)
+
+	// The downstream node receives the reduced result instead of the verbose response
+	for _, activation := range activatedNodes(eventBus) {
+		if activation.NodeID == "notify" {
+			assert.Equal(t, reduced, activation.InputData)
+		}
+	}
+}
+
+func TestWorkerManager_NodeLogLevel_OnlyOverriddenNodeLogsDebug(t *testing.T) {
+	workflow := &models.Workflow{
+		ID:     "log-level-workflow",
+		Name:   "Log Level Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "quiet", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": `{"step": "quiet"}`}, Enabled: true},
+			{
+				ID:       "loud",
+				Type:     "transform",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"expression": `{"step": "{{ .node_results.quiet.result.step }}-loud"}`},
+				LogLevel: "debug",
+				Enabled:  true,
+			},
+		},
+		Connections: []*models.Connection{
+			{ID: "quiet-loud", SourcePort: "quiet:success", TargetPort: "loud:main"},
+		},
+	}
+
+	wm, eventBus, _ := setupRepeatWorkflow(t, workflow)
+
+	// The worker runs at info
+	var output bytes.Buffer
+	wm.logger = slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	runActivations(t, wm, eventBus, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-log-level-workflow",
+		NodeID:      "quiet",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	})
+
+	debugMessages := make(map[string][]string)
+
+	decoder := json.NewDecoder(&output)
+	for decoder.More() {
+		var record map[string]any
+		require.NoError(t, decoder.Decode(&record))
+
+		if record["level"] == slog.LevelDebug.String() {
+			nodeID, _ := record["node_id"].(string)
+			debugMessages[nodeID] = append(debugMessages[nodeID], record["msg"].(string))
+		}
+	}
+
+	require.Len(t, debugMessages, 1, "only the node at debug logs debug records: %v", debugMessages)
+	assert.Contains(t, debugMessages["loud"], "Template evaluated")
+	assert.Contains(t, debugMessages["loud"], "Node output result")
+}
+
+func TestWorkerManager_NodeResultPruning_LongChain(t *testing.T) {
+	persistence := file.NewPersistence(t.TempDir())
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	const length = 8
+
+	// Every step outputs how many node results it was executed with
+	workflow := &models.Workflow{
+		ID:     "long-chain-workflow",
+		Name:   "Long Chain Workflow",
+		Status: models.WorkflowStatusPublished,
+	}
+
+	for i := 1; i <= length; i++ {
+		expression := "{{ len .node_results }}"
+		if i == length {
+			expression += `{{ if index .node_results "step-1::success" }}{{ end }}`
+		}
+
+		workflow.Nodes = append(workflow.Nodes, &models.WorkflowNode{
+			ID:       fmt.Sprintf("step-%d", i),
+			Type:     "transform",
+			Category: models.CategoryTypeAction,
+			Config:   map[string]any{"expression": expression},
+			Enabled:  true,
+		})
+
+		if i > 1 {
+			workflow.Connections = append(workflow.Connections, &models.Connection{
+				ID:         fmt.Sprintf("conn-%d", i),
+				SourcePort: fmt.Sprintf("step-%d:success", i-1),
+				TargetPort: fmt.Sprintf("step-%d:main", i),
+			})
+		}
+	}
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          "exec-long-chain",
+		WorkflowID:  workflow.ID,
+		NodeResults: make(map[string]models.NodeResult),
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	eventBus := &MockEventBus{}
+	wm := NewWorkerManager("long-chain-worker", persistence, eventBus, logger, reg)
+	wm.ConfigureNodeResultPruning(true)
+
+	activation := &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-long-chain",
+		NodeID:      "step-1",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	}
+
+	for i := 1; i <= length; i++ {
+		require.Equal(t, fmt.Sprintf("step-%d", i), activation.NodeID)
+		require.NoError(t, wm.handleNodeActivation(t.Context(), activation))
+
+		activations := activatedNodes(eventBus)
+		activation = activations[len(activations)-1]
+	}
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-long-chain")
+	require.NoError(t, err)
+
+	// Persisted history keeps every result
+	require.Len(t, execCtx.NodeResults, length)
+
+	liveCounts := make([]any, 0, length)
+	for i := 1; i <= length; i++ {
+		liveCounts = append(liveCounts, execCtx.NodeResults[models.MakeNodeResultKey(fmt.Sprintf("step-%d", i), "success")].Data["result"])
+	}
+
+	// Each step sees its predecessor's result and the first step's, which the last step references
+	assert.Equal(t, []any{float64(0), float64(1), float64(2), float64(2), float64(2), float64(2), float64(2), float64(2)}, liveCounts)
+}
+
+// runActivations handles the first activation and every activation published after it, in order,
+// and returns the IDs of the nodes that ran.
+func runActivations(t *testing.T, wm *WorkerManager, eventBus *MockEventBus, first *events.NodeActivation) []string {
+	t.Helper()
+
+	processed := len(activatedNodes(eventBus))
+	ranNodes := []string{first.NodeID}
+
+	// The first activation is counted pending as the activator counts it when publishing it
+	_, err := wm.persistence.ExecutionContextRepository().AddPendingActivations(t.Context(), first.ExecutionID, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, wm.handleNodeActivation(t.Context(), first))
+
+	for activations := activatedNodes(eventBus); processed < len(activations); activations = activatedNodes(eventBus) {
+		activation := activations[processed]
+		processed++
+
+		ranNodes = append(ranNodes, activation.NodeID)
+		require.NoError(t, wm.handleNodeActivation(t.Context(), activation))
+		require.Less(t, processed, 100, "loop did not end")
+	}
+
+	return ranNodes
+}
+
+func setupRepeatWorkflow(t *testing.T, workflow *models.Workflow) (*WorkerManager, *MockEventBus, persistence.Persistence) {
+	t.Helper()
+
+	persistence := file.NewPersistence(t.TempDir())
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	reg := registry.NewRegistry(logger)
+	reg.RegisterDefaultNodes()
+
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+	require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+		ID:          "exec-" + workflow.ID,
+		WorkflowID:  workflow.ID,
+		NodeResults: make(map[string]models.NodeResult),
+		Status:      models.ExecutionStatusRunning,
+	}))
+
+	eventBus := &MockEventBus{}
+
+	return NewWorkerManager("repeat-worker", persistence, eventBus, logger, reg), eventBus, persistence
+}
+
+func TestWorkerManager_RepeatUntil_PollsUntilConditionHolds(t *testing.T) {
+	var polls atomic.Int32
+
+	counter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"count": %d}`, polls.Add(1))
+	}))
+	defer counter.Close()
+
+	workflow := &models.Workflow{
+		ID:     "poll-workflow",
+		Name:   "Poll Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "poll", Type: "httprequest", Category: models.CategoryTypeAction, Config: map[string]any{"url": counter.URL}, Enabled: true},
+			{
+				ID:       "until",
+				Type:     "repeatuntil",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"condition": `{{ ge (index .node_results "poll::success").json.count 4.0 }}`},
+				Enabled:  true,
+			},
+			{ID: "finished", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "finished"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "poll-until", SourcePort: "poll:success", TargetPort: "until:main"},
+			{ID: "until-repeat", SourcePort: "until:repeat", TargetPort: "poll:main"},
+			{ID: "until-done", SourcePort: "until:done", TargetPort: "finished:main"},
+		},
+	}
+
+	wm, eventBus, persistence := setupRepeatWorkflow(t, workflow)
+
+	ranNodes := runActivations(t, wm, eventBus, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-poll-workflow",
+		NodeID:      "poll",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	})
+
+	// Three repeats poll the counter again before the fourth poll satisfies the condition
+	assert.Equal(t, []string{"poll", "until", "poll", "until", "poll", "until", "poll", "until", "finished"}, ranNodes)
+	assert.Equal(t, int32(4), polls.Load())
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-poll-workflow")
+	require.NoError(t, err)
+
+	done := execCtx.NodeResults[models.MakeNodeResultKey("until", "done")]
+	assert.Equal(t, float64(4), done.Data["iterations"])
+	assert.Equal(t, false, done.Data["max_iterations_reached"])
+	assert.Zero(t, execCtx.Iteration("until"), "the iteration count starts over once the loop is done")
+}
+
+func TestWorkerManager_RepeatUntil_MaxIterationsForcesExit(t *testing.T) {
+	workflow := &models.Workflow{
+		ID:     "capped-workflow",
+		Name:   "Capped Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:       "until",
+				Type:     "repeatuntil",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"condition": "false", "max_iterations": 3.0, "delay": 60000.0},
+				Enabled:  true,
+			},
+			{ID: "finished", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "finished"}, Enabled: true},
+		},
+		// The repeat port is left unconnected, so the node activates itself again
+		Connections: []*models.Connection{
+			{ID: "until-done", SourcePort: "until:done", TargetPort: "finished:main"},
+		},
+	}
+
+	wm, eventBus, persistence := setupRepeatWorkflow(t, workflow)
+
+	ranNodes := runActivations(t, wm, eventBus, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-capped-workflow",
+		NodeID:      "until",
+		InputPort:   "main",
+		InputData:   map[string]any{"job": "export"},
+	})
+
+	assert.Equal(t, []string{"until", "until", "until", "finished"}, ranNodes)
+
+	// Iterations are rescheduled after the delay instead of the node sleeping through it
+	for _, activation := range activatedNodes(eventBus) {
+		if activation.NodeID == "until" {
+			assert.NotNil(t, activation.NotBefore)
+		} else {
+			assert.Nil(t, activation.NotBefore)
+		}
+	}
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-capped-workflow")
+	require.NoError(t, err)
+
+	done := execCtx.NodeResults[models.MakeNodeResultKey("until", "done")]
+	assert.Equal(t, float64(3), done.Data["iterations"])
+	assert.Equal(t, true, done.Data["max_iterations_reached"])
+	assert.Equal(t, "export", done.Data["job"])
+}
+
+func TestWorkerManager_PropagatesCorrelationID(t *testing.T) {
+	workflow := &models.Workflow{
+		ID:     "correlated-workflow",
+		Name:   "Correlated Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "first", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "first"}, Enabled: true},
+			{ID: "second", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "second"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "first-second", SourcePort: "first:success", TargetPort: "second:main"},
+		},
+	}
+
+	wm, eventBus, _ := setupRepeatWorkflow(t, workflow)
+
+	var logs bytes.Buffer
+
+	wm.logger = slog.New(log.NewCorrelationHandler(slog.NewTextHandler(&logs, nil)))
+
+	activation := &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-correlated-workflow",
+		NodeID:      "first",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	}
+	activation.CorrelationID = "corr-123"
+	activation.OrderingKey = "customer-7"
+
+	ranNodes := runActivations(t, wm, eventBus, activation)
+	assert.Equal(t, []string{"first", "second"}, ranNodes)
+
+	completions := 0
+
+	for _, event := range eventBus.publishedEvents {
+		switch e := event.(type) {
+		case *events.NodeActivation:
+			assert.Equal(t, "corr-123", e.CorrelationID)
+			assert.Equal(t, "customer-7", e.OrderingKey)
+		case *events.NodeCompletion:
+			assert.Equal(t, "corr-123", e.CorrelationID)
+
+			completions++
+		}
+	}
+
+	assert.Equal(t, 2, completions)
+	assert.Contains(t, logs.String(), "correlation_id=corr-123")
+}
+
+// completedNodes returns the IDs of the nodes whose completion was published on the event bus.
+func completedNodes(eventBus *MockEventBus) []string {
+	var nodeIDs []string
+
+	for _, event := range eventBus.publishedEvents {
+		if completion, ok := event.(*events.NodeCompletion); ok {
+			nodeIDs = append(nodeIDs, completion.NodeID)
+		}
+	}
+
+	return nodeIDs
+}
+
+func TestWorkerManager_JoinWaitsForEveryUpstreamNode(t *testing.T) {
+	logNode := func(id string) *models.WorkflowNode {
+		return &models.WorkflowNode{ID: id, Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": id}, Enabled: true}
+	}
+
+	join := logNode("join")
+	join.Join = true
+
+	workflow := &models.Workflow{
+		ID:     "join-workflow",
+		Name:   "Join Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes:  []*models.WorkflowNode{logNode("start"), logNode("a"), logNode("b"), logNode("c"), join},
+		Connections: []*models.Connection{
+			{ID: "start-a", SourcePort: "start:success", TargetPort: "a:main"},
+			{ID: "start-b", SourcePort: "start:success", TargetPort: "b:main"},
+			{ID: "start-c", SourcePort: "start:success", TargetPort: "c:main"},
+			{ID: "a-join", SourcePort: "a:success", TargetPort: "join:main"},
+			{ID: "b-join", SourcePort: "b:success", TargetPort: "join:main"},
+			{ID: "c-join", SourcePort: "c:success", TargetPort: "join:main"},
+		},
+	}
+
+	wm, eventBus, _ := setupRepeatWorkflow(t, workflow)
+
+	require.NoError(t, wm.handleNodeActivation(t.Context(), &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-join-workflow",
+		NodeID:      "start",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	}))
+
+	// Run a, b and c, then deliver their outputs to the join one at a time
+	upstream := activatedNodes(eventBus)
+	require.Len(t, upstream, 3)
+
+	for _, activation := range upstream {
+		require.NoError(t, wm.handleNodeActivation(t.Context(), activation))
+	}
+
+	joins := activatedNodes(eventBus)[3:]
+	require.Len(t, joins, 3)
+
+	for i, activation := range joins {
+		assert.NotContains(t, completedNodes(eventBus), "join", "join ran after %d of 3 upstream nodes", i)
+		require.NoError(t, wm.handleNodeActivation(t.Context(), activation))
+	}
+
+	assert.Equal(t, []string{"start", "a", "b", "c", "join"}, completedNodes(eventBus))
+}
+
+func TestWorkerManager_JoinSkipsExclusiveBranches(t *testing.T) {
+	logNode := func(id string) *models.WorkflowNode {
+		return &models.WorkflowNode{ID: id, Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": id}, Enabled: true}
+	}
+
+	join := logNode("join")
+	join.Join = true
+
+	workflow := &models.Workflow{
+		ID:     "branch-join-workflow",
+		Name:   "Branch Join Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			logNode("start"),
+			{ID: "check", Type: "conditional", Category: models.CategoryTypeAction, Config: map[string]any{"condition": "true"}, Enabled: true},
+			logNode("yes"), logNode("no"), logNode("audit"), join,
+		},
+		Connections: []*models.Connection{
+			{ID: "start-check", SourcePort: "start:success", TargetPort: "check:main"},
+			{ID: "start-audit", SourcePort: "start:success", TargetPort: "audit:main"},
+			{ID: "check-yes", SourcePort: "check:true", TargetPort: "yes:main"},
+			{ID: "check-no", SourcePort: "check:false", TargetPort: "no:main"},
+			{ID: "yes-join", SourcePort: "yes:success", TargetPort: "join:main"},
+			{ID: "no-join", SourcePort: "no:success", TargetPort: "join:main"},
+			{ID: "audit-join", SourcePort: "audit:success", TargetPort: "join:main"},
+		},
+	}
+
+	wm, eventBus, persistence := setupRepeatWorkflow(t, workflow)
+
+	// Run every activation until none is left; the join must not wait for the branch not taken
+	queue := []*events.NodeActivation{{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-branch-join-workflow",
+		NodeID:      "start",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	}}
+
+	for seen := 0; len(queue) > 0; {
+		require.NoError(t, wm.handleNodeActivation(t.Context(), queue[0]))
+
+		published := activatedNodes(eventBus)
+		queue = append(queue[1:], published[seen:]...)
+		seen = len(published)
+	}
+
+	assert.Contains(t, completedNodes(eventBus), "join")
+	assert.NotContains(t, completedNodes(eventBus), "no")
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-branch-join-workflow")
+	require.NoError(t, err)
+	assert.Contains(t, execCtx.NodeResults, models.MakeNodeResultKey("join", "success"))
+}
+
+// variableWriterFactory creates transform nodes that also try to overwrite a workflow variable.
+type variableWriterFactory struct {
+	protocol.NodeFactory
+}
+
+func (f variableWriterFactory) ID() string {
+	return "variablewriter"
+}
+
+func (f variableWriterFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	node, err := f.NodeFactory.Create(ctx, id, config)
+
+	return variableWriterNode{Node: node}, err
+}
+
+type variableWriterNode struct {
+	protocol.Node
+}
+
+func (n variableWriterNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	ctx.Variables["api_base_url"] = "https://attacker.example.com"
+
+	return n.Node.Execute(ctx, inputs)
+}
+
+func TestWorkerManager_StateIsWritableVariablesAreNot(t *testing.T) {
+	workflow := &models.Workflow{
+		ID:     "state-workflow",
+		Name:   "State Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "count", Type: "setvariable", Category: models.CategoryTypeAction, Config: map[string]any{"name": "state.counter", "operation": "increment"}, Enabled: true},
+			{ID: "count-again", Type: "setvariable", Category: models.CategoryTypeAction, Config: map[string]any{"name": "counter", "operation": "increment"}, Enabled: true},
+			{
+				ID:       "report",
+				Type:     "transform",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"expression": `{"counter": {{ .state.counter }}, "api": "{{ .variables.api_base_url }}"}`},
+				Enabled:  true,
+			},
+			{ID: "tamper", Type: "variablewriter", Category: models.CategoryTypeAction, Config: map[string]any{"expression": "{}"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "c1", SourcePort: "count:success", TargetPort: "count-again:main"},
+			{ID: "c2", SourcePort: "count-again:success", TargetPort: "report:main"},
+			{ID: "c3", SourcePort: "report:success", TargetPort: "tamper:main"},
+		},
+	}
+
+	wm, eventBus, persistence := setupRepeatWorkflow(t, workflow)
+	wm.registry.RegisterNode(variableWriterFactory{NodeFactory: transform.NewTransformNodeFactory()})
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-state-workflow")
+	require.NoError(t, err)
+
+	execCtx.Variables = map[string]any{"api_base_url": "https://api.example.com"}
+	require.NoError(t, persistence.ExecutionContextRepository().UpdateExecutionContext(t.Context(), execCtx))
+
+	ranNodes := runActivations(t, wm, eventBus, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-state-workflow",
+		NodeID:      "count",
+		InputPort:   "main",
+		InputData:   map[string]any{},
+	})
+	assert.Equal(t, []string{"count", "count-again", "report", "tamper"}, ranNodes)
+
+	execCtx, err = persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-state-workflow")
+	require.NoError(t, err)
+
+	// Set-variable nodes update state.counter, which later nodes read
+	assert.Equal(t, float64(2), execCtx.State["counter"])
+	assert.Equal(t, map[string]any{"counter": float64(2), "api": "https://api.example.com"},
+		execCtx.NodeResults[models.MakeNodeResultKey("report", "success")].Data["result"])
+
+	// A node changing variables.api_base_url fails and the variable keeps its value
+	assert.Equal(t, "https://api.example.com", execCtx.Variables["api_base_url"])
+	assert.NotContains(t, execCtx.NodeResults, models.MakeNodeResultKey("tamper", "success"))
+
+	var tamperError string
+
+	for _, event := range eventBus.publishedEvents {
+		if completion, ok := event.(*events.NodeCompletion); ok && completion.NodeID == "tamper" {
+			tamperError = completion.ErrorMessage
+		}
+	}
+
+	assert.Contains(t, tamperError, models.ErrVariablesImmutable.Error())
+}
+
+// sagaCall is an execution of a saga step node, with the data it received on its main input.
+type sagaCall struct {
+	NodeID string
+	Input  map[string]any
+}
+
+// sagaStepFactory creates transform nodes recording their executions, which fail when configured with "fail".
+type sagaStepFactory struct {
+	protocol.NodeFactory
+
+	calls *[]sagaCall
+}
+
+func (f sagaStepFactory) ID() string {
+	return "sagastep"
+}
+
+func (f sagaStepFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	node, err := f.NodeFactory.Create(ctx, id, config)
+	fail, _ := config["fail"].(bool)
+
+	return sagaStepNode{Node: node, calls: f.calls, fail: fail}, err
+}
+
+type sagaStepNode struct {
+	protocol.Node
+
+	calls *[]sagaCall
+	fail  bool
+}
+
+func (n sagaStepNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	*n.calls = append(*n.calls, sagaCall{NodeID: n.ID(), Input: inputs["main"].Data})
+
+	if n.fail {
+		return map[string]models.NodeResult{
+			"error": {NodeID: n.ID(), Status: string(models.NodeStatusError), Error: n.ID() + " failed"},
+		}, nil
+	}
+
+	return n.Node.Execute(ctx, inputs)
+}
+
+// setupSaga sets up a reserve, charge and ship saga whose compensations are configured by compensate.
+func setupSaga(t *testing.T, compensate map[string]map[string]any) (*WorkerManager, *MockEventBus, persistence.Persistence, *[]sagaCall) {
+	t.Helper()
+
+	return setupSagaWith(t, compensate, nil)
+}
+
+// setupSagaWith sets up the saga of setupSaga, letting configure set up the worker before it runs.
+func setupSagaWith(
+	t *testing.T,
+	compensate map[string]map[string]any,
+	configure func(wm *WorkerManager),
+) (*WorkerManager, *MockEventBus, persistence.Persistence, *[]sagaCall) {
+	t.Helper()
+
+	step := func(id, expression string, fail bool) *models.WorkflowNode {
+		node := &models.WorkflowNode{
+			ID:       id,
+			Type:     "sagastep",
+			Category: models.CategoryTypeAction,
+			Config:   map[string]any{"expression": expression, "fail": fail},
+			Enabled:  true,
+		}
+
+		if config, ok := compensate[id]; ok {
+			node.Compensation = &models.Compensation{Type: "sagastep", Config: config}
+		}
+
+		return node
+	}
+
+	workflow := &models.Workflow{
+		ID:                "saga-workflow",
+		Name:              "Saga Workflow",
+		Status:            models.WorkflowStatusPublished,
+		RollbackOnFailure: true,
+		Nodes: []*models.WorkflowNode{
+			step("reserve", `{"reservation_id": "r-1"}`, false),
+			step("charge", `{"charge_id": "c-1"}`, false),
+			step("ship", `{}`, true),
+		},
+		Connections: []*models.Connection{
+			{ID: "reserve-charge", SourcePort: "reserve:success", TargetPort: "charge:main"},
+			{ID: "charge-ship", SourcePort: "charge:success", TargetPort: "ship:main"},
+		},
+	}
+
+	wm, eventBus, persistence := setupRepeatWorkflow(t, workflow)
+
+	calls := &[]sagaCall{}
+	wm.registry.RegisterNode(sagaStepFactory{NodeFactory: transform.NewTransformNodeFactory(), calls: calls})
+
+	if configure != nil {
+		configure(wm)
+	}
+
+	ranNodes := runActivations(t, wm, eventBus, &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+		WorkflowID:  workflow.ID,
+		ExecutionID: "exec-saga-workflow",
+		NodeID:      "reserve",
+		InputPort:   "main",
+		InputData:   map[string]any{"order_id": "o-1"},
+	})
+	require.Equal(t, []string{"reserve", "charge", "ship"}, ranNodes)
+
+	return wm, eventBus, persistence, calls
+}
+
+func TestWorkerManager_Rollback_CompensatesCompletedNodesInReverse(t *testing.T) {
+	_, _, persistence, calls := setupSaga(t, map[string]map[string]any{
+		"reserve": {"expression": `{"released": "{{ (index .node_results "reserve::success").result.reservation_id }}"}`},
+		"charge":  {"expression": `{"refunded": true}`},
+	})
+
+	// The compensations run after the failing node, the last completed node first, each with its original result
+	require.Len(t, *calls, 5)
+	assert.Equal(t, sagaCall{NodeID: "charge-compensation", Input: map[string]any{"result": map[string]any{"charge_id": "c-1"}}}, (*calls)[3])
+	assert.Equal(t, sagaCall{NodeID: "reserve-compensation", Input: map[string]any{"result": map[string]any{"reservation_id": "r-1"}}}, (*calls)[4])
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-saga-workflow")
+	require.NoError(t, err)
+
+	assert.Equal(t, models.ExecutionStatusFailed, execCtx.Status)
+	assert.Equal(t, "node ship failed, execution rolled back", execCtx.ErrorMessage)
+	assert.Equal(t, []string{"reserve::success", "charge::success"}, execCtx.CompletedNodes())
+	assert.Equal(t, map[string]any{"result": map[string]any{"released": "r-1"}},
+		execCtx.NodeResults[models.MakeNodeResultKey("reserve-compensation", "success")].Data)
+	assert.Equal(t, []any{
+		map[string]any{"node_id": "charge", "status": models.CompensationStatusCompensated},
+		map[string]any{"node_id": "reserve", "status": models.CompensationStatusCompensated},
+	}, execCtx.Metadata[models.CompensationsMetadataKey])
+}
+
+func TestWorkerManager_Rollback_FailedCompensationDoesNotStopRollback(t *testing.T) {
+	_, _, persistence, calls := setupSaga(t, map[string]map[string]any{
+		"reserve": {"expression": `{"released": true}`},
+		"charge":  {"expression": `{}`, "fail": true},
+	})
+
+	require.Len(t, *calls, 5)
+	assert.Equal(t, "charge-compensation", (*calls)[3].NodeID)
+	assert.Equal(t, "reserve-compensation", (*calls)[4].NodeID)
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-saga-workflow")
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{
+		map[string]any{
+			"node_id": "charge",
+			"status":  models.CompensationStatusFailed,
+			"error":   "failed on port error: charge-compensation failed",
+		},
+		map[string]any{"node_id": "reserve", "status": models.CompensationStatusCompensated},
+	}, execCtx.Metadata[models.CompensationsMetadataKey])
+}
+
+func TestWorkerManager_TemplateMissingKeys(t *testing.T) {
+	for mode, expectedPort := range map[string]string{
+		template.MissingKeyDefault: "true",
+		template.MissingKeyLenient: "false",
+		template.MissingKeyStrict:  "error",
+	} {
+		t.Run("mode "+mode, func(t *testing.T) {
+			workflow := &models.Workflow{
+				ID:     "missing-keys-workflow",
+				Status: models.WorkflowStatusPublished,
+				Nodes: []*models.WorkflowNode{
+					{
+						ID:       "check",
+						Type:     "conditional",
+						Category: models.CategoryTypeAction,
+						Config:   map[string]any{"condition": "{{ .trigger_data.webhook.nonexistent.field }}"},
+						Enabled:  true,
+					},
+				},
+			}
+
+			wm, eventBus, persistence := setupRepeatWorkflow(t, workflow)
+			require.NoError(t, wm.ConfigureTemplateMissingKeys(mode))
+
+			runActivations(t, wm, eventBus, &events.NodeActivation{
+				BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+				WorkflowID:  workflow.ID,
+				ExecutionID: "exec-" + workflow.ID,
+				NodeID:      "check",
+				InputPort:   "main",
+				InputData:   map[string]any{},
+			})
+
+			execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-"+workflow.ID)
+			require.NoError(t, err)
+			assert.Contains(t, execCtx.NodeResults, models.MakeNodeResultKey("check", expectedPort))
+		})
+	}
+
+	wm, _, _ := setupRepeatWorkflow(t, &models.Workflow{ID: "invalid-mode"})
+	assert.ErrorIs(t, wm.ConfigureTemplateMissingKeys("zero"), template.ErrInvalidMissingKeyMode)
+}
+
+func TestWorkerManager_NodesShareTemplatePartial(t *testing.T) {
+	workflow := &models.Workflow{
+		ID:     "partials-workflow",
+		Name:   "Partials Workflow",
+		Status: models.WorkflowStatusPublished,
+		Templates: map[string]string{
+			"summary": "execution {{ .execution.id }} v1",
+		},
+		Nodes: []*models.WorkflowNode{
+			{ID: "email", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": `email: {{ template "summary" . }}`}, Enabled: true},
+			{ID: "sms", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": `sms: {{ template "summary" . }}`}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "conn-email-sms", SourcePort: "email:success", TargetPort: "sms:main"},
+		},
+	}
+
+	wm, eventBus, persistence := setupRepeatWorkflow(t, workflow)
+
+	run := func(executionID string) (string, string) {
+		if executionID != "exec-"+workflow.ID {
+			require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+				ID:          executionID,
+				WorkflowID:  workflow.ID,
+				NodeResults: make(map[string]models.NodeResult),
+				Status:      models.ExecutionStatusRunning,
+			}))
+		}
+
+		ran := runActivations(t, wm, eventBus, &events.NodeActivation{
+			BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+			WorkflowID:  workflow.ID,
+			ExecutionID: executionID,
+			NodeID:      "email",
+			InputPort:   "main",
+			InputData:   map[string]any{},
+		})
+		require.Equal(t, []string{"email", "sms"}, ran)
+
+		execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), executionID)
+		require.NoError(t, err)
+
+		email := execCtx.NodeResults[models.MakeNodeResultKey("email", "success")].Data["result"]
+		sms := execCtx.NodeResults[models.MakeNodeResultKey("sms", "success")].Data["result"]
+
+		return fmt.Sprint(email), fmt.Sprint(sms)
+	}
+
+	email, sms := run("exec-" + workflow.ID)
+	assert.Equal(t, "email: execution exec-partials-workflow v1", email)
+	assert.Equal(t, "sms: execution exec-partials-workflow v1", sms)
+
+	workflow.Templates["summary"] = "execution {{ .execution.id }} v2"
+	require.NoError(t, persistence.WorkflowRepository().Save(t.Context(), workflow))
+
+	email, sms = run("exec-edited")
+	assert.Equal(t, "email: execution exec-edited v2", email)
+	assert.Equal(t, "sms: execution exec-edited v2", sms)
+}
+
+func TestWorkerManager_InputSchema_RoutesInvalidInput(t *testing.T) {
+	workflow := &models.Workflow{
+		ID:     "signup-workflow",
+		Name:   "Signup Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:       "register",
+				Type:     "flaky",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"expression": `{"registered": true}`},
+				Enabled:  true,
+				InputSchema: map[string]any{
+					"type":     "object",
+					"required": []any{"main"},
+					"properties": map[string]any{
+						"main": map[string]any{
+							"type":       "object",
+							"required":   []any{"email"},
+							"properties": map[string]any{"email": map[string]any{"type": "string"}},
+						},
+					},
+				},
+			},
+			{ID: "welcome", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": `{}`}, Enabled: true},
+			{ID: "reject", Type: "transform", Category: models.CategoryTypeAction, Config: map[string]any{"expression": `{}`}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "register-welcome", SourcePort: "register:success", TargetPort: "welcome:main"},
+			{ID: "register-reject", SourcePort: "register:invalid-input", TargetPort: "reject:main"},
+		},
+	}
+
+	wm, eventBus, persistence := setupRepeatWorkflow(t, workflow)
+
+	attempts := map[string]int{}
+	wm.registry.RegisterNode(flakyFactory{NodeFactory: transform.NewTransformNodeFactory(), attempts: attempts})
+
+	run := func(executionID string, input map[string]any) []string {
+		require.NoError(t, persistence.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+			ID:          executionID,
+			WorkflowID:  workflow.ID,
+			NodeResults: make(map[string]models.NodeResult),
+			Status:      models.ExecutionStatusRunning,
+		}))
+
+		return runActivations(t, wm, eventBus, &events.NodeActivation{
+			BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, workflow.ID),
+			WorkflowID:  workflow.ID,
+			ExecutionID: executionID,
+			NodeID:      "register",
+			InputPort:   "main",
+			InputData:   input,
+		})
+	}
+
+	// Invalid input skips the action and routes to invalid-input
+	require.Equal(t, []string{"register", "reject"}, run("exec-invalid", map[string]any{"email": 42}))
+	assert.Zero(t, attempts["register"])
+
+	execCtx, err := persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-invalid")
+	require.NoError(t, err)
+
+	invalid := execCtx.NodeResults[models.MakeNodeResultKey("register", models.InvalidInputPort)]
+	assert.Equal(t, string(models.NodeStatusError), invalid.Status)
+	assert.Contains(t, invalid.Error, "email")
+	assert.NotContains(t, execCtx.NodeResults, models.MakeNodeResultKey("register", "success"))
+
+	// Valid input executes the action and proceeds on its normal ports
+	require.Equal(t, []string{"register", "welcome"}, run("exec-valid", map[string]any{"email": "ada@example.com"}))
+	assert.Equal(t, 1, attempts["register"])
+
+	execCtx, err = persistence.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-valid")
+	require.NoError(t, err)
+	assert.Equal(t, string(models.NodeStatusSuccess), execCtx.NodeResults[models.MakeNodeResultKey("register", "success")].Status)
+	assert.NotContains(t, execCtx.NodeResults, models.MakeNodeResultKey("register", models.InvalidInputPort))
+}
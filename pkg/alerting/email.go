@@ -0,0 +1,164 @@
+package alerting
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSMTPPort = "25"
+
+	// emailTimeout bounds the whole SMTP exchange of an alert, from dialing to the final QUIT.
+	emailTimeout = 30 * time.Second
+)
+
+// EmailAlerter emails the text of every alert through an SMTP server.
+type EmailAlerter struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+	send func(ctx context.Context, addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailAlerter creates an alerter sending alerts from the from address to the to addresses
+// through the SMTP server at addr (host:port). A nil auth sends without authentication.
+func NewEmailAlerter(addr string, auth smtp.Auth, from string, to []string) *EmailAlerter {
+	return &EmailAlerter{addr: addr, auth: auth, from: from, to: to, send: sendMail}
+}
+
+func newEmailAlerterFromURL(parsed *url.URL) (*EmailAlerter, error) {
+	query := parsed.Query()
+
+	from := query.Get("from")
+	if from == "" {
+		return nil, errors.New("smtp alerter URL requires a from address")
+	}
+
+	var to []string
+
+	for _, recipient := range strings.Split(query.Get("to"), ",") {
+		if recipient = strings.TrimSpace(recipient); recipient != "" {
+			to = append(to, recipient)
+		}
+	}
+
+	if len(to) == 0 {
+		return nil, errors.New("smtp alerter URL requires at least one to address")
+	}
+
+	host, port := parsed.Hostname(), parsed.Port()
+	if port == "" {
+		port = defaultSMTPPort
+	}
+
+	var auth smtp.Auth
+	if parsed.User != nil {
+		password, _ := parsed.User.Password()
+		auth = smtp.PlainAuth("", parsed.User.Username(), password, host)
+	}
+
+	return NewEmailAlerter(net.JoinHostPort(host, port), auth, from, to), nil
+}
+
+// Alert emails the alert text. The SMTP exchange stops when ctx is done or after emailTimeout.
+func (a *EmailAlerter) Alert(ctx context.Context, alert Alert) error {
+	ctx, cancel := context.WithTimeout(ctx, emailTimeout)
+	defer cancel()
+
+	subject := fmt.Sprintf("[operion] Workflow %s execution %s", alert.WorkflowID, alert.Status)
+
+	var msg strings.Builder
+
+	fmt.Fprintf(&msg, "From: %s\r\n", a.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(a.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(alert.Text(), "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	if err := a.send(ctx, a.addr, a.auth, a.from, a.to, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send alert email: %w", err)
+	}
+
+	return nil
+}
+
+// sendMail sends msg like smtp.SendMail, upgrading to TLS when the server offers STARTTLS, over a
+// connection closed once ctx is done, so a stalled server cannot hold the alert forever.
+func sendMail(ctx context.Context, addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	if auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp server does not support AUTH")
+		}
+
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(msg); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,110 @@
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// StatsSource provides the execution statistics of a workflow the failure rate policy checks.
+type StatsSource interface {
+	GetExecutionStats(ctx context.Context, workflowID string, from, to time.Time) (*models.ExecutionStats, error)
+}
+
+// CooldownStore records the alerts of the failure rate policy, so that a workflow alerts at most
+// once per window across worker instances.
+type CooldownStore interface {
+	ClaimAlert(ctx context.Context, workflowID string, now time.Time, cooldown time.Duration) (bool, error)
+}
+
+// Notifier applies the alerting policy of a workflow to its failed executions and sends the
+// resulting alerts through an alerter.
+type Notifier struct {
+	alerter   Alerter
+	stats     StatsSource
+	cooldowns CooldownStore
+	now       func() time.Time
+}
+
+// NewNotifier creates a notifier sending alerts through alerter, checking failure rates in stats
+// and recording the failure rate alerts in cooldowns.
+func NewNotifier(alerter Alerter, stats StatsSource, cooldowns CooldownStore) *Notifier {
+	return &Notifier{
+		alerter:   alerter,
+		stats:     stats,
+		cooldowns: cooldowns,
+		now:       time.Now,
+	}
+}
+
+// ExecutionFinished alerts about a failed or timed out execution of workflow when its alerting
+// policy asks for it, and reports whether an alert was sent. Workflows without an alerting policy
+// never alert. The failure rate policy alerts at most once per window for a workflow, across the
+// worker instances sharing the cooldown store.
+func (n *Notifier) ExecutionFinished(ctx context.Context, workflow *models.Workflow, execCtx *models.ExecutionContext) (bool, error) {
+	if execCtx.Status != models.ExecutionStatusFailed && execCtx.Status != models.ExecutionStatusTimeout {
+		return false, nil
+	}
+
+	policy, err := ParsePolicy(workflow.Metadata)
+	if err != nil || policy == nil {
+		return false, err
+	}
+
+	now := n.now().UTC()
+	alert := Alert{
+		WorkflowID:   workflow.ID,
+		WorkflowName: workflow.Name,
+		ExecutionID:  execCtx.ID,
+		Status:       string(execCtx.Status),
+		ErrorMessage: execCtx.ErrorMessage,
+		Policy:       policy.Mode,
+		FailedAt:     now,
+	}
+
+	if execCtx.CompletedAt != nil {
+		alert.FailedAt = execCtx.CompletedAt.UTC()
+	}
+
+	if policy.Mode == PolicyFailureRate {
+		send, err := n.checkFailureRate(ctx, workflow.ID, policy, now, &alert)
+		if err != nil || !send {
+			return false, err
+		}
+	}
+
+	if err := n.alerter.Alert(ctx, alert); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// checkFailureRate reports whether the failure rate of the executions of the workflow started in
+// the policy window reaches the threshold, and no alert was sent for the workflow in the window.
+// The window is claimed in the cooldown store before the alert is sent, so concurrent failures
+// alert only once.
+func (n *Notifier) checkFailureRate(ctx context.Context, workflowID string, policy *Policy, now time.Time, alert *Alert) (bool, error) {
+	// The upper bound is exclusive; include executions started at now
+	stats, err := n.stats.GetExecutionStats(ctx, workflowID, now.Add(-policy.Window), now.Add(time.Nanosecond))
+	if err != nil {
+		return false, fmt.Errorf("failed to get execution stats: %w", err)
+	}
+
+	if stats.Total < policy.MinExecutions || stats.FailureRate < policy.Threshold {
+		return false, nil
+	}
+
+	claimed, err := n.cooldowns.ClaimAlert(ctx, workflowID, now, policy.Window)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	alert.FailureRate = stats.FailureRate
+	alert.Executions = stats.Total
+	alert.Window = policy.Window.String()
+
+	return true, nil
+}
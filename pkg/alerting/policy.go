@@ -0,0 +1,103 @@
+package alerting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MetadataKey is the workflow metadata key holding the alerting policy of the workflow.
+const MetadataKey = "alerting"
+
+// DefaultWindow is the failure rate window of a policy without one.
+const DefaultWindow = time.Hour
+
+// ErrInvalidPolicy is returned for a malformed alerting policy in the workflow metadata.
+var ErrInvalidPolicy = errors.New("invalid alerting policy")
+
+// PolicyMode selects when a failed execution triggers an alert.
+type PolicyMode string
+
+const (
+	// PolicyEveryFailure alerts on every failed execution.
+	PolicyEveryFailure PolicyMode = "every_failure"
+	// PolicyFailureRate alerts once per window when the failure rate of the executions started in
+	// the window reaches the threshold.
+	PolicyFailureRate PolicyMode = "failure_rate"
+)
+
+// Policy is the alerting policy of a workflow, set in its metadata, for example:
+//
+//	"alerting": {"policy": "failure_rate", "threshold": 0.5, "window": "1h", "min_executions": 10}
+type Policy struct {
+	Mode          PolicyMode
+	Threshold     float64
+	Window        time.Duration
+	MinExecutions int
+}
+
+type rawPolicy struct {
+	Policy        PolicyMode `json:"policy"`
+	Threshold     float64    `json:"threshold"`
+	Window        string     `json:"window"`
+	MinExecutions int        `json:"min_executions"`
+}
+
+// ParsePolicy reads the alerting policy from the metadata of a workflow. It returns nil for a
+// workflow without alerting.
+func ParsePolicy(metadata map[string]any) (*Policy, error) {
+	value, ok := metadata[MetadataKey]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+
+	var raw rawPolicy
+	if err := json.Unmarshal(encoded, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+
+	policy := &Policy{
+		Mode:          raw.Policy,
+		Threshold:     raw.Threshold,
+		Window:        DefaultWindow,
+		MinExecutions: raw.MinExecutions,
+	}
+
+	if policy.Mode == "" {
+		policy.Mode = PolicyEveryFailure
+	}
+
+	switch policy.Mode {
+	case PolicyEveryFailure:
+		return policy, nil
+	case PolicyFailureRate:
+	default:
+		return nil, fmt.Errorf("%w: unknown policy '%s' (supported: %s, %s)",
+			ErrInvalidPolicy, raw.Policy, PolicyEveryFailure, PolicyFailureRate)
+	}
+
+	if policy.Threshold <= 0 || policy.Threshold > 1 {
+		return nil, fmt.Errorf("%w: threshold must be in (0, 1], got %v", ErrInvalidPolicy, raw.Threshold)
+	}
+
+	if policy.MinExecutions < 0 {
+		return nil, fmt.Errorf("%w: min_executions must not be negative", ErrInvalidPolicy)
+	}
+
+	if raw.Window != "" {
+		window, err := time.ParseDuration(raw.Window)
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("%w: invalid window '%s'", ErrInvalidPolicy, raw.Window)
+		}
+
+		policy.Window = window
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,75 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// WebhookAlerter posts every alert as JSON to a URL.
+type WebhookAlerter struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookAlerter creates an alerter posting alerts to url.
+func NewWebhookAlerter(url string) *WebhookAlerter {
+	return &WebhookAlerter{url: url, client: &http.Client{Timeout: webhookTimeout}}
+}
+
+// Alert posts the alert as a JSON object.
+func (a *WebhookAlerter) Alert(ctx context.Context, alert Alert) error {
+	return postJSON(ctx, a.client, a.url, alert)
+}
+
+// SlackAlerter posts the text of every alert to a Slack incoming webhook.
+type SlackAlerter struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlackAlerter creates an alerter posting alerts to the Slack incoming webhook url.
+func NewSlackAlerter(url string) *SlackAlerter {
+	return &SlackAlerter{url: url, client: &http.Client{Timeout: webhookTimeout}}
+}
+
+// Alert posts the alert text as a Slack message.
+func (a *SlackAlerter) Alert(ctx context.Context, alert Alert) error {
+	return postJSON(ctx, a.client, a.url, map[string]string{"text": alert.Text()})
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return fmt.Errorf("alert webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
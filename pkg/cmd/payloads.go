@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dukex/operion/pkg/payloads"
+)
+
+// NewPayloadOffloader creates the offloader writing payloads larger than threshold bytes to the
+// store of storeURL. An empty URL disables offloading and returns nil.
+func NewPayloadOffloader(ctx context.Context, storeURL string, threshold int) (*payloads.Offloader, error) {
+	if storeURL == "" {
+		return nil, nil //nolint:nilnil // offloading is disabled
+	}
+
+	offloader, err := payloads.New(ctx, storeURL, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payload store: %w", err)
+	}
+
+	return offloader, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/dukex/operion/pkg/persistence"
 	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/dukex/operion/pkg/persistence/memory"
 	"github.com/dukex/operion/pkg/persistence/postgresql"
 )
 
@@ -15,6 +16,7 @@ type Provider int
 const (
 	FileProvider Provider = iota
 	PostgresqlProvider
+	MemoryProvider
 )
 
 // NewPersistence creates a new persistence layer based on the provided database URL.
@@ -32,6 +34,8 @@ func NewPersistence(ctx context.Context, logger *slog.Logger, databaseURL string
 		}
 
 		return persistence
+	case MemoryProvider:
+		return memory.NewPersistence()
 	case FileProvider:
 		return file.NewPersistence(databaseURL)
 	default:
@@ -47,6 +51,8 @@ func parsePersistenceProvider(databaseURL string) Provider {
 	switch provider {
 	case "postgres", "postgresql":
 		return PostgresqlProvider
+	case "memory":
+		return MemoryProvider
 	default:
 		return FileProvider
 	}
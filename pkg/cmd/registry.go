@@ -5,8 +5,12 @@ import (
 	"context"
 	"log/slog"
 
+	gcpPubSubProvider "github.com/dukex/operion/pkg/providers/gcp-pubsub"
+	httpPollProvider "github.com/dukex/operion/pkg/providers/http-poll"
 	kafkaProvider "github.com/dukex/operion/pkg/providers/kafka"
 	"github.com/dukex/operion/pkg/providers/scheduler"
+	slackEventsProvider "github.com/dukex/operion/pkg/providers/slack-events"
+	snsProvider "github.com/dukex/operion/pkg/providers/sns"
 	webhookSource "github.com/dukex/operion/pkg/providers/webhook"
 	"github.com/dukex/operion/pkg/registry"
 )
@@ -31,6 +35,18 @@ func registerNativeProviders(reg *registry.Registry) {
 
 	kafkaSourceProvider := kafkaProvider.NewKafkaProviderFactory()
 	reg.RegisterProvider(kafkaSourceProvider)
+
+	httpPollSourceProvider := httpPollProvider.NewHTTPPollProviderFactory()
+	reg.RegisterProvider(httpPollSourceProvider)
+
+	slackEventsSourceProvider := slackEventsProvider.NewSlackEventsProviderFactory()
+	reg.RegisterProvider(slackEventsSourceProvider)
+
+	gcpPubSubSourceProvider := gcpPubSubProvider.NewPubSubProviderFactory()
+	reg.RegisterProvider(gcpPubSubSourceProvider)
+
+	snsSourceProvider := snsProvider.NewSNSProviderFactory()
+	reg.RegisterProvider(snsSourceProvider)
 }
 
 func NewRegistry(ctx context.Context, log *slog.Logger, pluginsPath string) *registry.Registry {
@@ -0,0 +1,53 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+)
+
+type acknowledgementKey struct{}
+
+// Acknowledgement is how an event bus acknowledges an event once its handler is done with it.
+// A handler processing the event after returning takes it over with DeferAck, so the bus does not
+// acknowledge the event before it is processed and redelivers it after a crash.
+type Acknowledgement struct {
+	mu       sync.Mutex
+	ack      func()
+	deferred bool
+}
+
+// NewAcknowledgement returns the acknowledgement of an event, acknowledged by calling ack.
+func NewAcknowledgement(ack func()) *Acknowledgement {
+	return &Acknowledgement{ack: ack}
+}
+
+// WithAcknowledgement returns a copy of ctx carrying the acknowledgement of the event it is handled with.
+func WithAcknowledgement(ctx context.Context, acknowledgement *Acknowledgement) context.Context {
+	return context.WithValue(ctx, acknowledgementKey{}, acknowledgement)
+}
+
+// Deferred reports whether the handler took over acknowledging the event.
+func (a *Acknowledgement) Deferred() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.deferred
+}
+
+// DeferAck takes over acknowledging the event handled with ctx: the bus no longer acknowledges it
+// when the handler returns, and the returned function does. Events of buses without
+// acknowledgements are acknowledged as before and the returned function does nothing.
+func DeferAck(ctx context.Context) func() {
+	acknowledgement, ok := ctx.Value(acknowledgementKey{}).(*Acknowledgement)
+	if !ok {
+		return func() {}
+	}
+
+	acknowledgement.mu.Lock()
+	acknowledgement.deferred = true
+	acknowledgement.mu.Unlock()
+
+	var once sync.Once
+
+	return func() { once.Do(acknowledgement.ack) }
+}
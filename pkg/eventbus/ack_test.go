@@ -0,0 +1,26 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeferAck_TakesOverAcknowledgement(t *testing.T) {
+	acks := 0
+	acknowledgement := NewAcknowledgement(func() { acks++ })
+
+	ack := DeferAck(WithAcknowledgement(t.Context(), acknowledgement))
+
+	assert.True(t, acknowledgement.Deferred())
+	assert.Zero(t, acks)
+
+	ack()
+	ack()
+
+	assert.Equal(t, 1, acks, "an event is acknowledged once")
+}
+
+func TestDeferAck_WithoutAcknowledgement(t *testing.T) {
+	assert.NotPanics(t, func() { DeferAck(t.Context())() })
+}
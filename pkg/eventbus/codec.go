@@ -0,0 +1,466 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dukex/operion/pkg/events"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Codec names, used in configuration and in the codec metadata of published messages.
+const (
+	CodecJSON     = "json"
+	CodecProtobuf = "protobuf"
+)
+
+var (
+	// ErrUnsupportedCodec is returned for a codec name that is not known.
+	ErrUnsupportedCodec = errors.New("unsupported event codec")
+	// ErrUnsupportedEvent is returned by a codec that cannot encode or decode an event type.
+	ErrUnsupportedEvent = errors.New("event type not supported by codec")
+)
+
+// Codec serializes events published on a bus.
+type Codec interface {
+	Name() string
+	Marshal(event any) ([]byte, error)
+	Unmarshal(data []byte, event any) error
+}
+
+// CodecByName returns the codec for name. An empty name selects JSON.
+func CodecByName(name string) (Codec, error) {
+	switch name {
+	case "", CodecJSON:
+		return JSONCodec{}, nil
+	case CodecProtobuf:
+		return ProtobufCodec{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q (supported: json, protobuf)", ErrUnsupportedCodec, name)
+	}
+}
+
+// EncodeEvent encodes event with codec, falling back to JSON for event types the codec does not
+// support. It returns the name of the codec used, to be sent along with the payload.
+func EncodeEvent(codec Codec, event any) ([]byte, string, error) {
+	payload, err := codec.Marshal(event)
+	if errors.Is(err, ErrUnsupportedEvent) {
+		codec = JSONCodec{}
+		payload, err = codec.Marshal(event)
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return payload, codec.Name(), nil
+}
+
+// DecodeEvent decodes data with the named codec. Messages without a codec name are JSON, as
+// published before codecs were configurable.
+func DecodeEvent(name string, data []byte, event any) error {
+	codec, err := CodecByName(name)
+	if err != nil {
+		return err
+	}
+
+	return codec.Unmarshal(data, event)
+}
+
+// JSONCodec encodes events as JSON. It supports every event type.
+type JSONCodec struct{}
+
+// Name returns the codec name.
+func (JSONCodec) Name() string {
+	return CodecJSON
+}
+
+// Marshal encodes event as JSON.
+func (JSONCodec) Marshal(event any) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+// Unmarshal decodes JSON data into event.
+func (JSONCodec) Unmarshal(data []byte, event any) error {
+	return json.Unmarshal(data, event)
+}
+
+// ProtobufCodec encodes node activations and source events in the protobuf wire format described
+// by events.proto, for smaller payloads and consumers outside Go. Free-form data such as input
+// data, event data and metadata is carried as google.protobuf.Struct and Value, so it decodes
+// with the same types as JSON.
+type ProtobufCodec struct{}
+
+// Field numbers of the NodeActivation message.
+const (
+	nodeActivationID            protowire.Number = 1
+	nodeActivationType          protowire.Number = 2
+	nodeActivationTimestamp     protowire.Number = 3
+	nodeActivationWorkflowID    protowire.Number = 4
+	nodeActivationWorkerID      protowire.Number = 5
+	nodeActivationMetadata      protowire.Number = 6
+	nodeActivationExecutionID   protowire.Number = 7
+	nodeActivationNodeID        protowire.Number = 8
+	nodeActivationInputPort     protowire.Number = 9
+	nodeActivationInputData     protowire.Number = 10
+	nodeActivationSourceNode    protowire.Number = 11
+	nodeActivationSourcePort    protowire.Number = 12
+	nodeActivationCorrelationID protowire.Number = 13
+	nodeActivationOrderingKey   protowire.Number = 14
+	nodeActivationPriority      protowire.Number = 15
+	nodeActivationAttempt       protowire.Number = 16
+	nodeActivationNotBefore     protowire.Number = 17
+)
+
+// Field numbers of the SourceEvent message.
+const (
+	sourceEventSourceID      protowire.Number = 1
+	sourceEventProviderID    protowire.Number = 2
+	sourceEventEventType     protowire.Number = 3
+	sourceEventEventData     protowire.Number = 4
+	sourceEventCorrelationID protowire.Number = 5
+	sourceEventOrderingKey   protowire.Number = 6
+)
+
+// Name returns the codec name.
+func (ProtobufCodec) Name() string {
+	return CodecProtobuf
+}
+
+// Marshal encodes a node activation or a source event.
+func (ProtobufCodec) Marshal(event any) ([]byte, error) {
+	switch e := event.(type) {
+	case *events.NodeActivation:
+		return marshalNodeActivation(e)
+	case events.NodeActivation:
+		return marshalNodeActivation(&e)
+	case *events.SourceEvent:
+		return marshalSourceEvent(e)
+	case events.SourceEvent:
+		return marshalSourceEvent(&e)
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedEvent, event)
+	}
+}
+
+// Unmarshal decodes data into a node activation or a source event.
+func (ProtobufCodec) Unmarshal(data []byte, event any) error {
+	switch e := event.(type) {
+	case *events.NodeActivation:
+		return unmarshalNodeActivation(data, e)
+	case *events.SourceEvent:
+		return unmarshalSourceEvent(data, e)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedEvent, event)
+	}
+}
+
+func marshalNodeActivation(event *events.NodeActivation) ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+
+	b = appendString(b, nodeActivationID, event.ID)
+	b = appendString(b, nodeActivationType, string(event.Type))
+
+	if !event.Timestamp.IsZero() {
+		if b, err = appendMessage(b, nodeActivationTimestamp, timestamppb.New(event.Timestamp)); err != nil {
+			return nil, err
+		}
+	}
+
+	// The workflow ID of the activation shadows the one of the base event, as in JSON
+	b = appendString(b, nodeActivationWorkflowID, event.WorkflowID)
+	b = appendString(b, nodeActivationWorkerID, event.WorkerID)
+
+	if len(event.Metadata) > 0 {
+		if b, err = appendStruct(b, nodeActivationMetadata, event.Metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	b = appendString(b, nodeActivationExecutionID, event.ExecutionID)
+	b = appendString(b, nodeActivationNodeID, event.NodeID)
+	b = appendString(b, nodeActivationInputPort, event.InputPort)
+
+	if event.InputData != nil {
+		if b, err = appendValue(b, nodeActivationInputData, event.InputData); err != nil {
+			return nil, err
+		}
+	}
+
+	b = appendString(b, nodeActivationSourceNode, event.SourceNode)
+	b = appendString(b, nodeActivationSourcePort, event.SourcePort)
+	b = appendString(b, nodeActivationCorrelationID, event.CorrelationID)
+	b = appendString(b, nodeActivationOrderingKey, event.OrderingKey)
+
+	if event.Priority != 0 {
+		b = protowire.AppendTag(b, nodeActivationPriority, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(event.Priority)))
+	}
+
+	if event.Attempt != 0 {
+		b = protowire.AppendTag(b, nodeActivationAttempt, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(event.Attempt)) //nolint:gosec // attempts are never negative
+	}
+
+	if event.NotBefore != nil {
+		if b, err = appendMessage(b, nodeActivationNotBefore, timestamppb.New(*event.NotBefore)); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+func unmarshalNodeActivation(data []byte, event *events.NodeActivation) error {
+	fields, err := consumeFields(data)
+	if err != nil {
+		return err
+	}
+
+	*event = events.NodeActivation{}
+	event.ID = string(fields[nodeActivationID])
+	event.Type = events.EventType(fields[nodeActivationType])
+	event.WorkflowID = string(fields[nodeActivationWorkflowID])
+	event.WorkerID = string(fields[nodeActivationWorkerID])
+	event.ExecutionID = string(fields[nodeActivationExecutionID])
+	event.NodeID = string(fields[nodeActivationNodeID])
+	event.InputPort = string(fields[nodeActivationInputPort])
+	event.SourceNode = string(fields[nodeActivationSourceNode])
+	event.SourcePort = string(fields[nodeActivationSourcePort])
+	event.CorrelationID = string(fields[nodeActivationCorrelationID])
+	event.OrderingKey = string(fields[nodeActivationOrderingKey])
+
+	if raw, ok := fields[nodeActivationPriority]; ok {
+		priority, n := protowire.ConsumeVarint(raw)
+		if n < 0 {
+			return fmt.Errorf("invalid priority: %w", protowire.ParseError(n))
+		}
+
+		event.Priority = int(protowire.DecodeZigZag(priority))
+	}
+
+	if raw, ok := fields[nodeActivationAttempt]; ok {
+		attempt, n := protowire.ConsumeVarint(raw)
+		if n < 0 {
+			return fmt.Errorf("invalid attempt: %w", protowire.ParseError(n))
+		}
+
+		event.Attempt = int(attempt) //nolint:gosec // attempts are small
+	}
+
+	if raw, ok := fields[nodeActivationNotBefore]; ok {
+		var notBefore timestamppb.Timestamp
+		if err := proto.Unmarshal(raw, &notBefore); err != nil {
+			return fmt.Errorf("invalid not before: %w", err)
+		}
+
+		due := notBefore.AsTime()
+		event.NotBefore = &due
+	}
+
+	if raw, ok := fields[nodeActivationTimestamp]; ok {
+		var timestamp timestamppb.Timestamp
+		if err := proto.Unmarshal(raw, &timestamp); err != nil {
+			return fmt.Errorf("invalid timestamp: %w", err)
+		}
+
+		event.Timestamp = timestamp.AsTime()
+	}
+
+	if raw, ok := fields[nodeActivationMetadata]; ok {
+		if event.Metadata, err = consumeStruct(raw); err != nil {
+			return fmt.Errorf("invalid metadata: %w", err)
+		}
+	}
+
+	if raw, ok := fields[nodeActivationInputData]; ok {
+		var value structpb.Value
+		if err := proto.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("invalid input data: %w", err)
+		}
+
+		event.InputData = value.AsInterface()
+	}
+
+	return nil
+}
+
+func marshalSourceEvent(event *events.SourceEvent) ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+
+	b = appendString(b, sourceEventSourceID, event.SourceID)
+	b = appendString(b, sourceEventProviderID, event.ProviderID)
+	b = appendString(b, sourceEventEventType, event.EventType)
+	b = appendString(b, sourceEventCorrelationID, event.CorrelationID)
+	b = appendString(b, sourceEventOrderingKey, event.OrderingKey)
+
+	if event.EventData != nil {
+		if b, err = appendStruct(b, sourceEventEventData, event.EventData); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+func unmarshalSourceEvent(data []byte, event *events.SourceEvent) error {
+	fields, err := consumeFields(data)
+	if err != nil {
+		return err
+	}
+
+	*event = events.SourceEvent{
+		SourceID:      string(fields[sourceEventSourceID]),
+		ProviderID:    string(fields[sourceEventProviderID]),
+		EventType:     string(fields[sourceEventEventType]),
+		CorrelationID: string(fields[sourceEventCorrelationID]),
+		OrderingKey:   string(fields[sourceEventOrderingKey]),
+	}
+
+	if raw, ok := fields[sourceEventEventData]; ok {
+		if event.EventData, err = consumeStruct(raw); err != nil {
+			return fmt.Errorf("invalid event data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// appendString appends a string field, omitting empty strings as proto3 does.
+func appendString(b []byte, num protowire.Number, value string) []byte {
+	if value == "" {
+		return b
+	}
+
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+
+	return protowire.AppendString(b, value)
+}
+
+// appendMessage appends an embedded message field.
+func appendMessage(b []byte, num protowire.Number, message proto.Message) ([]byte, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+
+	return protowire.AppendBytes(b, data), nil
+}
+
+// appendStruct appends a map as a google.protobuf.Struct.
+func appendStruct(b []byte, num protowire.Number, data map[string]any) ([]byte, error) {
+	normalized, err := normalize(data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, _ := normalized.(map[string]any)
+
+	message, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return appendMessage(b, num, message)
+}
+
+// appendValue appends free-form data as a google.protobuf.Value.
+func appendValue(b []byte, num protowire.Number, data any) ([]byte, error) {
+	normalized, err := normalize(data)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := structpb.NewValue(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	return appendMessage(b, num, message)
+}
+
+// normalize passes data through JSON, so it is encoded with the types a JSON consumer sees.
+func normalize(data any) (any, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized any
+	if err := json.Unmarshal(encoded, &normalized); err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+// consumeStruct decodes a google.protobuf.Struct into a map.
+func consumeStruct(data []byte) (map[string]any, error) {
+	var message structpb.Struct
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return nil, err
+	}
+
+	return message.AsMap(), nil
+}
+
+// consumeFields reads the length-delimited and varint fields of a message, keeping the last
+// occurrence of each (varints in their encoded form) and skipping fields of other wire types,
+// which none of the messages use.
+func consumeFields(data []byte) (map[protowire.Number][]byte, error) {
+	fields := make(map[protowire.Number][]byte)
+
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+
+		data = data[n:]
+
+		if typ == protowire.VarintType {
+			_, n = protowire.ConsumeVarint(data)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+
+			fields[num] = data[:n]
+			data = data[n:]
+
+			continue
+		}
+
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+
+			data = data[n:]
+
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+
+		fields[num] = value
+		data = data[n:]
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,153 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNodeActivation() *events.NodeActivation {
+	notBefore := time.Date(2025, 6, 1, 12, 35, 0, 0, time.UTC)
+
+	return &events.NodeActivation{
+		BaseEvent: events.BaseEvent{
+			ID:            "01970000-0000-7000-8000-000000000001",
+			Type:          events.NodeActivationEvent,
+			Timestamp:     time.Date(2025, 6, 1, 12, 30, 0, 123456789, time.UTC),
+			WorkerID:      "worker-1",
+			CorrelationID: "corr-1",
+			Metadata:      map[string]any{"trace_id": "abc123", "attempt": 2.0},
+		},
+		ExecutionID: "01970000-0000-7000-8000-000000000002",
+		NodeID:      "fetch_orders",
+		WorkflowID:  "orders-sync",
+		InputPort:   "main",
+		InputData: map[string]any{
+			"order_id": "42",
+			"total":    99.5,
+			"paid":     true,
+			"items":    []any{"book", "pen"},
+			"customer": map[string]any{"name": "Ada", "vip": nil},
+		},
+		SourceNode:  "trigger",
+		SourcePort:  "success",
+		OrderingKey: "customer-7",
+		Priority:    -3,
+		Attempt:     2,
+		NotBefore:   &notBefore,
+	}
+}
+
+func testSourceEvent() *events.SourceEvent {
+	sourceEvent := events.NewSourceEvent("source-1", "webhook", "webhook_received", map[string]any{
+		"headers": map[string]any{"Content-Type": "application/json"},
+		"body":    map[string]any{"order_id": "42", "amounts": []any{1.0, 2.5}},
+	})
+	sourceEvent.CorrelationID = "corr-1"
+	sourceEvent.OrderingKey = "customer-7"
+
+	return sourceEvent
+}
+
+func TestCodecByName(t *testing.T) {
+	for name, expected := range map[string]string{"": CodecJSON, "json": CodecJSON, "protobuf": CodecProtobuf} {
+		codec, err := CodecByName(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, codec.Name())
+	}
+
+	_, err := CodecByName("avro")
+	assert.ErrorIs(t, err, ErrUnsupportedCodec)
+}
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	for _, codec := range []Codec{JSONCodec{}, ProtobufCodec{}} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			activation := testNodeActivation()
+
+			payload, err := codec.Marshal(activation)
+			require.NoError(t, err)
+
+			var decodedActivation events.NodeActivation
+			require.NoError(t, codec.Unmarshal(payload, &decodedActivation))
+			assert.True(t, activation.Timestamp.Equal(decodedActivation.Timestamp))
+
+			decodedActivation.Timestamp = activation.Timestamp
+			assert.Equal(t, *activation, decodedActivation)
+
+			sourceEvent := testSourceEvent()
+
+			payload, err = codec.Marshal(sourceEvent)
+			require.NoError(t, err)
+
+			var decodedSourceEvent events.SourceEvent
+			require.NoError(t, codec.Unmarshal(payload, &decodedSourceEvent))
+			assert.Equal(t, *sourceEvent, decodedSourceEvent)
+		})
+	}
+}
+
+func TestEncodeEvent_FallsBackToJSON(t *testing.T) {
+	event := &events.WorkflowFinished{
+		BaseEvent:   events.BaseEvent{ID: "event-1", Type: events.WorkflowFinishedEvent},
+		ExecutionID: "exec-1",
+	}
+
+	payload, codecName, err := EncodeEvent(ProtobufCodec{}, event)
+	require.NoError(t, err)
+	assert.Equal(t, CodecJSON, codecName)
+
+	var decoded events.WorkflowFinished
+	require.NoError(t, DecodeEvent(codecName, payload, &decoded))
+	assert.Equal(t, "exec-1", decoded.ExecutionID)
+
+	// Messages published before codecs were configurable carry no codec name
+	require.NoError(t, DecodeEvent("", payload, &decoded))
+}
+
+func TestCodecs_Compatibility(t *testing.T) {
+	activation := testNodeActivation()
+
+	// A JSON producer and a JSON consumer interoperate through the codec name sent along
+	jsonPayload, codecName, err := EncodeEvent(JSONCodec{}, activation)
+	require.NoError(t, err)
+	assert.Equal(t, CodecJSON, codecName)
+
+	var fromJSON events.NodeActivation
+	require.NoError(t, DecodeEvent(codecName, jsonPayload, &fromJSON))
+
+	protobufPayload, codecName, err := EncodeEvent(ProtobufCodec{}, activation)
+	require.NoError(t, err)
+	assert.Equal(t, CodecProtobuf, codecName)
+
+	var fromProtobuf events.NodeActivation
+	require.NoError(t, DecodeEvent(codecName, protobufPayload, &fromProtobuf))
+
+	// Both codecs decode to the same event
+	assert.True(t, fromJSON.Timestamp.Equal(fromProtobuf.Timestamp))
+
+	fromProtobuf.Timestamp = fromJSON.Timestamp
+	assert.Equal(t, fromJSON, fromProtobuf)
+
+	// and protobuf payloads are smaller
+	assert.Less(t, len(protobufPayload), len(jsonPayload))
+
+	sourceJSON, err := JSONCodec{}.Marshal(testSourceEvent())
+	require.NoError(t, err)
+
+	sourceProtobuf, err := ProtobufCodec{}.Marshal(testSourceEvent())
+	require.NoError(t, err)
+
+	assert.Less(t, len(sourceProtobuf), len(sourceJSON))
+}
+
+func TestProtobufCodec_InvalidPayload(t *testing.T) {
+	var activation events.NodeActivation
+	assert.Error(t, ProtobufCodec{}.Unmarshal([]byte{0x0a, 0xff}, &activation))
+
+	var finished events.WorkflowFinished
+	assert.ErrorIs(t, ProtobufCodec{}.Unmarshal(nil, &finished), ErrUnsupportedEvent)
+}
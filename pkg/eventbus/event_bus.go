@@ -22,6 +22,12 @@ type EventSubscriber interface {
 
 type EventHandler func(ctx context.Context, event any) error
 
+// LagReporter is implemented by event buses that can report the backlog of their subscriber.
+type LagReporter interface {
+	// Lag returns how many published events the subscriber has not consumed yet.
+	Lag(ctx context.Context) (int64, error)
+}
+
 type EventBus interface {
 	EventPublisher
 	EventSubscriber
@@ -2,13 +2,14 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"io"
 	"log/slog"
+	"sync"
 
 	"github.com/dukex/operion/pkg/eventbus"
 	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/tracer"
 	kafkago "github.com/segmentio/kafka-go"
 )
 
@@ -17,10 +18,12 @@ func consumeEvents(
 	logger *slog.Logger,
 	reader *kafkago.Reader,
 	handlers map[events.EventType]eventbus.EventHandler,
+	verifier *verifier,
 ) {
 	const maxRetries = 3
 
 	retryCount := 0
+	commits := newCommitter(logger, reader)
 
 	for {
 		message, err := reader.FetchMessage(ctx)
@@ -51,27 +54,46 @@ func consumeEvents(
 
 		logger.InfoContext(ctx, "Received message", "key", string(message.Key), "topic", message.Topic)
 
-		// Extract event type from headers
-		var eventType events.EventType
+		commits.track(message)
+
+		// Extract event type and codec from headers
+		var (
+			eventType events.EventType
+			codecName string
+			signature string
+		)
+
 		for _, header := range message.Headers {
-			if header.Key == events.EventTypeMetadataKey {
+			switch header.Key {
+			case events.EventTypeMetadataKey:
 				eventType = events.EventType(header.Value)
-
-				break
+			case events.EventCodecMetadataKey:
+				codecName = string(header.Value)
+			case events.EventSignatureMetadataKey:
+				signature = string(header.Value)
 			}
 		}
 
+		// Forged or altered messages never reach a handler
+		if err := verifier.verify(ctx, signature, message, eventType, codecName); err != nil {
+			logger.WarnContext(ctx, "Rejected event", "error", err, "event_type", eventType, "key", string(message.Key))
+
+			commits.done(ctx, message)
+
+			continue
+		}
+
 		logger.InfoContext(ctx, "Processing message", "event_type", eventType)
 
 		handler, exists := handlers[eventType]
 		if !exists {
 			// [EXCEPTION] WorkflowFinishedEvent is intentionally ignored if no handler is present.
 			// This event type does not require processing or logging when unhandled.
-			if eventType == events.WorkflowFinishedEvent {
-				continue
+			if eventType != events.WorkflowFinishedEvent {
+				logger.ErrorContext(ctx, "No handler found for event type", "event_type", eventType)
 			}
 
-			logger.ErrorContext(ctx, "No handler found for event type", "event_type", eventType)
+			commits.done(ctx, message)
 
 			continue
 		}
@@ -80,45 +102,126 @@ func consumeEvents(
 		if err != nil {
 			logger.ErrorContext(ctx, "Failed to extract event", "error", err, "event_type", eventType)
 
-			err := reader.CommitMessages(ctx, message)
-			if err != nil {
-				logger.ErrorContext(ctx, "Failed to commit message", "error", err)
-			}
+			commits.done(ctx, message)
 
 			continue
 		}
 
-		err = json.Unmarshal(message.Value, event)
+		err = eventbus.DecodeEvent(codecName, message.Value, event)
 		if err != nil {
-			logger.ErrorContext(ctx, "Failed to unmarshal event", "error", err, "event_type", eventType)
+			logger.ErrorContext(ctx, "Failed to unmarshal event", "error", err, "event_type", eventType, "codec", codecName)
 
-			err := reader.CommitMessages(ctx, message)
-			if err != nil {
-				logger.ErrorContext(ctx, "Failed to commit message", "error", err)
-			}
+			commits.done(ctx, message)
 
 			continue
 		}
 
-		handlerErr := handler(ctx, event)
+		// Handlers processing the event after returning acknowledge it themselves, once processed
+		acknowledgement := eventbus.NewAcknowledgement(func() { commits.done(ctx, message) })
+
+		handlerErr := handler(eventbus.WithAcknowledgement(ctx, acknowledgement), event)
 		if handlerErr != nil {
 			logger.ErrorContext(ctx, "Failed to handle event", "error", handlerErr, "event_type", eventType)
+		} else {
+			logger.InfoContext(ctx, "Successfully handled event", "event_type", eventType)
+		}
 
-			err := reader.CommitMessages(ctx, message)
-			if err != nil {
-				logger.ErrorContext(ctx, "Failed to commit message", "error", err)
-			}
-
-			continue
+		if !acknowledgement.Deferred() {
+			commits.done(ctx, message)
 		}
+	}
+}
 
-		logger.InfoContext(ctx, "Successfully handled event", "event_type", eventType)
+// committer commits consumed messages once they are processed. Committing a message commits every
+// earlier message of its partition, so it only commits up to the last message of a partition all
+// messages before which are processed too: a message processed out of order is committed with the
+// earlier ones once they are done, and none is committed before it is processed.
+type committer struct {
+	mu      sync.Mutex
+	logger  *slog.Logger
+	reader  *kafkago.Reader
+	pending map[int][]*trackedMessage
+}
 
-		err = reader.CommitMessages(ctx, message)
-		if err != nil {
-			logger.ErrorContext(ctx, "Failed to commit message", "error", err)
+type trackedMessage struct {
+	offset    int64
+	processed bool
+}
+
+func newCommitter(logger *slog.Logger, reader *kafkago.Reader) *committer {
+	return &committer{
+		logger:  logger,
+		reader:  reader,
+		pending: make(map[int][]*trackedMessage),
+	}
+}
+
+// track records a fetched message, in fetch order, as not processed yet.
+func (c *committer) track(message kafkago.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending[message.Partition] = append(c.pending[message.Partition], &trackedMessage{offset: message.Offset})
+}
+
+// done marks message processed and commits the messages of its partition processed in order.
+func (c *committer) done(ctx context.Context, message kafkago.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending := c.pending[message.Partition]
+	for _, tracked := range pending {
+		if tracked.offset == message.Offset {
+			tracked.processed = true
+
+			break
 		}
 	}
+
+	processed := 0
+	for processed < len(pending) && pending[processed].processed {
+		processed++
+	}
+
+	if processed == 0 {
+		return
+	}
+
+	c.pending[message.Partition] = pending[processed:]
+
+	// The lock is held while committing, so commits of a partition never go backwards
+	last := message
+	last.Offset = pending[processed-1].offset
+
+	if err := c.reader.CommitMessages(ctx, last); err != nil {
+		c.logger.ErrorContext(ctx, "Failed to commit message", "error", err)
+	}
+}
+
+// verifier checks the signatures of consumed messages, recording the rejected ones.
+type verifier struct {
+	signer  *eventbus.Signer
+	metrics *tracer.EventBusMetrics
+}
+
+// verify checks the signature of a message. It accepts every message on a nil verifier.
+func (v *verifier) verify(ctx context.Context, signature string, message kafkago.Message, eventType events.EventType, codecName string) error {
+	if v == nil {
+		return nil
+	}
+
+	err := v.signer.Verify(signature, eventbus.SignedMessage{
+		Topic:     message.Topic,
+		Key:       string(message.Key),
+		EventType: string(eventType),
+		Codec:     codecName,
+		Payload:   message.Value,
+	})
+	if err != nil {
+		v.metrics.RecordRejected(ctx, "events", string(eventType), eventbus.RejectReason(err))
+	}
+
+	return err
 }
 
 func extractEvent(eventType events.EventType) (any, error) {
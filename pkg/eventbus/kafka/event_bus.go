@@ -4,21 +4,37 @@ package kafka
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"strings"
 
 	"github.com/dukex/operion/pkg/eventbus"
 	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/tracer"
 	"github.com/google/uuid"
 	kafkago "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
 )
 
 type kafkaEventBus struct {
 	logger   *slog.Logger
 	writer   *kafkago.Writer
 	reader   *kafkago.Reader
+	codec    eventbus.Codec
+	signer   *eventbus.Signer
+	metrics  *tracer.EventBusMetrics
 	handlers map[events.EventType]eventbus.EventHandler
+
+	// highPriorityReader consumes the high-priority topic. It is consumed whether or not this
+	// process routes priorities, as other publishers may, so no activation is left unconsumed
+	highPriorityReader    *kafkago.Reader
+	routesPriority        bool
+	highPriorityThreshold int
+
+	// readerStats and highPriorityStats accumulate the statistics of the readers, read by Lag
+	readerStats       *readerStats
+	highPriorityStats *readerStats
 }
 
 func NewEventBus(ctx context.Context, logger *slog.Logger) (eventbus.EventBus, error) {
@@ -29,10 +45,32 @@ func NewEventBus(ctx context.Context, logger *slog.Logger) (eventbus.EventBus, e
 		return nil, errors.New("no Kafka brokers configured")
 	}
 
-	writer := kafkago.NewWriter(kafkago.WriterConfig{
-		Brokers: splitBrokers,
-		Topic:   events.Topic,
-	})
+	producerConfig, err := parseProducerConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	codec, err := eventbus.CodecByName(os.Getenv(envCodec))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", envCodec, err)
+	}
+
+	metrics, err := tracer.NewEventBusMetrics(otel.GetMeterProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	highPriorityThreshold, routesPriority, err := eventbus.HighPriorityThresholdFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := eventbus.SignerFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	writer := newWriter(splitBrokers, producerConfig)
 
 	groupID := os.Getenv("KAFKA_GROUP_ID")
 	if groupID == "" {
@@ -45,26 +83,72 @@ func NewEventBus(ctx context.Context, logger *slog.Logger) (eventbus.EventBus, e
 		GroupID: groupID,
 	})
 
-	return &kafkaEventBus{
+	highPriorityReader := kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers: splitBrokers,
+		Topic:   events.HighPriorityTopic,
+		GroupID: groupID,
+	})
+
+	bus := &kafkaEventBus{
 		logger:   logger,
 		writer:   writer,
 		reader:   reader,
+		codec:    codec,
+		signer:   signer,
+		metrics:  metrics,
 		handlers: make(map[events.EventType]eventbus.EventHandler),
-	}, nil
+
+		highPriorityReader:    highPriorityReader,
+		routesPriority:        routesPriority,
+		highPriorityThreshold: highPriorityThreshold,
+
+		readerStats:       newReaderStats(reader),
+		highPriorityStats: newReaderStats(highPriorityReader),
+	}
+
+	return bus, nil
 }
 
 func (k *kafkaEventBus) Publish(ctx context.Context, key string, event eventbus.Event) error {
-	return publishEvent(ctx, k.logger, k.writer, key, event)
+	return publishEvent(ctx, k.logger, k.writer, k.codec, k.signer, k.topicFor(event), key, event)
+}
+
+// topicFor returns the topic of event, the events topic unless priority routing is enabled.
+func (k *kafkaEventBus) topicFor(event eventbus.Event) string {
+	if !k.routesPriority {
+		return events.Topic
+	}
+
+	return eventbus.TopicFor(event, k.highPriorityThreshold)
 }
 
 func (k *kafkaEventBus) Subscribe(ctx context.Context) error {
 	k.logger.InfoContext(ctx, "Subscribing to events")
 
-	go consumeEvents(ctx, k.logger, k.reader, k.handlers)
+	go consumeEvents(ctx, k.logger, k.reader, k.handlers, k.verifier())
+
+	// The high-priority topic is consumed on its own, so its activations are not held behind
+	// the backlog of the events topic
+	go consumeEvents(ctx, k.logger, k.highPriorityReader, k.handlers, k.verifier())
 
 	return nil
 }
 
+// verifier returns the signature check of consumed messages, nil when the bus does not sign them.
+func (k *kafkaEventBus) verifier() *verifier {
+	if k.signer == nil {
+		return nil
+	}
+
+	return &verifier{signer: k.signer, metrics: k.metrics}
+}
+
+// Lag returns the consumer lag of the readers, the messages of their topics after the last one
+// they fetched. It reads the stats of the readers through snapshots, leaving their counters to other readers.
+func (k *kafkaEventBus) Lag(ctx context.Context) (int64, error) {
+	return max(k.readerStats.Snapshot().Lag, 0) + max(k.highPriorityStats.Snapshot().Lag, 0), nil
+}
+
 func (k *kafkaEventBus) Close(ctx context.Context) error {
 	k.logger.InfoContext(ctx, "Closing Kafka event bus")
 
@@ -80,6 +164,12 @@ func (k *kafkaEventBus) Close(ctx context.Context) error {
 		return err
 	}
 
+	if err := k.highPriorityReader.Close(); err != nil {
+		k.logger.ErrorContext(ctx, "Failed to close Kafka high-priority reader", "error", err)
+
+		return err
+	}
+
 	return nil
 }
 
@@ -385,6 +385,154 @@ func TestKafkaEventBus_MultipleEventTypes(t *testing.T) {
 	assert.True(t, receivedTypes[events.NodeActivationEvent])
 }
 
+func TestKafkaEventBus_SignedEvents(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", brokers)
+	t.Setenv("KAFKA_GROUP_ID", "test-signed-events")
+	t.Setenv(eventbus.EnvSigningSecret, "shared-secret")
+
+	bus, err := NewEventBus(context.Background(), logger)
+	require.NoError(t, err)
+
+	defer func() {
+		err := bus.Close(context.Background())
+		assert.NoError(t, err)
+	}()
+
+	received := make(chan *events.NodeActivation, 10)
+	err = bus.Handle(context.Background(), events.NodeActivationEvent, func(ctx context.Context, event any) error {
+		received <- event.(*events.NodeActivation)
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, bus.Subscribe(context.Background()))
+
+	time.Sleep(2 * time.Second)
+
+	kafkaBus := bus.(*kafkaEventBus)
+
+	// A tampered activation carries the signature of the activation it was copied from
+	original, err := json.Marshal(events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, "wf-1"),
+		ExecutionID: "exec-1",
+		NodeID:      "notify",
+	})
+	require.NoError(t, err)
+
+	tampered, err := json.Marshal(events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, "wf-1"),
+		ExecutionID: "exec-1",
+		NodeID:      "delete-everything",
+	})
+	require.NoError(t, err)
+
+	err = kafkaBus.writer.WriteMessages(context.Background(), kafkago.Message{
+		Topic: events.Topic,
+		Key:   []byte("delete-everything:exec-1"),
+		Value: tampered,
+		Headers: []kafkago.Header{
+			{Key: events.EventTypeMetadataKey, Value: []byte(events.NodeActivationEvent)},
+			{Key: events.EventCodecMetadataKey, Value: []byte(eventbus.CodecJSON)},
+			{Key: events.EventSignatureMetadataKey, Value: []byte(kafkaBus.signer.Sign(eventbus.SignedMessage{
+				Topic:     events.Topic,
+				Key:       "delete-everything:exec-1",
+				EventType: string(events.NodeActivationEvent),
+				Codec:     eventbus.CodecJSON,
+				Payload:   original,
+			}))},
+		},
+	})
+	require.NoError(t, err)
+
+	signed := &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, "wf-1"),
+		ExecutionID: "exec-2",
+		NodeID:      "notify",
+	}
+	require.NoError(t, bus.Publish(context.Background(), "notify:exec-2", signed))
+
+	select {
+	case activation := <-received:
+		assert.Equal(t, "exec-2", activation.ExecutionID)
+		assert.Equal(t, "notify", activation.NodeID)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Did not receive the signed event within timeout")
+	}
+
+	assert.Empty(t, received, "the tampered event never reaches the handler")
+}
+
+func TestKafkaEventBus_HighPriorityRouting(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", brokers)
+	t.Setenv("KAFKA_GROUP_ID", "test-high-priority")
+	t.Setenv(eventbus.EnvHighPriorityThreshold, "5")
+
+	bus, err := NewEventBus(context.Background(), logger)
+	require.NoError(t, err)
+
+	defer func() {
+		err := bus.Close(context.Background())
+		assert.NoError(t, err)
+	}()
+
+	received := make(chan *events.NodeActivation, 10)
+	err = bus.Handle(context.Background(), events.NodeActivationEvent, func(ctx context.Context, event any) error {
+		received <- event.(*events.NodeActivation)
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, bus.Subscribe(context.Background()))
+
+	time.Sleep(2 * time.Second)
+
+	urgent := &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, "wf-urgent"),
+		ExecutionID: "exec-urgent",
+		NodeID:      "page-oncall",
+		Priority:    10,
+	}
+	require.NoError(t, bus.Publish(context.Background(), "page-oncall:exec-urgent", urgent))
+
+	bulk := &events.NodeActivation{
+		BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, "wf-bulk"),
+		ExecutionID: "exec-bulk",
+		NodeID:      "sync",
+	}
+	require.NoError(t, bus.Publish(context.Background(), "sync:exec-bulk", bulk))
+
+	// Both topics are consumed
+	executions := make(map[string]bool)
+
+	for range 2 {
+		select {
+		case activation := <-received:
+			executions[activation.ExecutionID] = true
+		case <-time.After(10 * time.Second):
+			t.Fatal("Did not receive both activations within timeout")
+		}
+	}
+
+	assert.Equal(t, map[string]bool{"exec-urgent": true, "exec-bulk": true}, executions)
+
+	// Only the urgent activation was published to the high-priority topic
+	reader := kafkago.NewReader(kafkago.ReaderConfig{Brokers: []string{brokers}, Topic: events.HighPriorityTopic, Partition: 0})
+	defer func() { _ = reader.Close() }()
+
+	fetchCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	message, err := reader.FetchMessage(fetchCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "page-oncall:exec-urgent", string(message.Key))
+
+	emptyCtx, cancelEmpty := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelEmpty()
+
+	_, err = reader.FetchMessage(emptyCtx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the bulk activation stays on the events topic")
+}
+
 func TestKafkaEventBus_Close(t *testing.T) {
 	t.Setenv("KAFKA_BROKERS", brokers)
 
@@ -409,7 +557,7 @@ func TestPublishEvent(t *testing.T) {
 	kafkaBus := bus.(*kafkaEventBus)
 	testEvent := createTestEvent(events.WorkflowTriggeredEvent)
 
-	err = publishEvent(context.Background(), logger, kafkaBus.writer, "test-key", testEvent)
+	err = publishEvent(context.Background(), logger, kafkaBus.writer, kafkaBus.codec, nil, events.Topic, "test-key", testEvent)
 	assert.NoError(t, err)
 }
 
@@ -459,7 +607,7 @@ func TestConsumeEvents_ErrorHandling(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	go consumeEvents(ctx, logger, kafkaBus.reader, kafkaBus.handlers)
+	go consumeEvents(ctx, logger, kafkaBus.reader, kafkaBus.handlers, nil)
 
 	cancel()
 	time.Sleep(100 * time.Millisecond)
@@ -538,6 +686,11 @@ func createTopics(brokers string) {
 			NumPartitions:     1,
 			ReplicationFactor: 1,
 		},
+		{
+			Topic:             events.HighPriorityTopic,
+			NumPartitions:     1,
+			ReplicationFactor: 1,
+		},
 	}
 
 	err = controllerConn.CreateTopics(topicConfigs...)
@@ -0,0 +1,90 @@
+package kafka
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Environment variables used to tune the producer. Unset values keep the kafka-go defaults.
+const (
+	envCompression = "KAFKA_COMPRESSION"
+	envLingerMs    = "KAFKA_LINGER_MS"
+	envBatchSize   = "KAFKA_BATCH_SIZE"
+	envBatchBytes  = "KAFKA_BATCH_BYTES"
+
+	// envCodec selects the serialization of published events: json (default) or protobuf.
+	envCodec = "KAFKA_CODEC"
+)
+
+// ProducerConfig holds the batching and compression settings of the event bus writer.
+// Zero values leave the kafka-go defaults untouched (no compression, 100 messages,
+// 1MB and 1s per batch).
+type ProducerConfig struct {
+	Compression kafkago.Compression
+	Linger      time.Duration
+	BatchSize   int
+	BatchBytes  int
+}
+
+// parseProducerConfig reads the producer settings from the environment.
+func parseProducerConfig() (ProducerConfig, error) {
+	var config ProducerConfig
+
+	if value := os.Getenv(envCompression); value != "" {
+		if err := config.Compression.UnmarshalText([]byte(value)); err != nil {
+			return config, fmt.Errorf("invalid %s: %q (supported: none, gzip, snappy, lz4, zstd)", envCompression, value)
+		}
+	}
+
+	if value := os.Getenv(envLingerMs); value != "" {
+		ms, err := strconv.Atoi(value)
+		if err != nil || ms < 0 {
+			return config, fmt.Errorf("invalid %s: %q", envLingerMs, value)
+		}
+
+		config.Linger = time.Duration(ms) * time.Millisecond
+	}
+
+	if value := os.Getenv(envBatchSize); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 0 {
+			return config, fmt.Errorf("invalid %s: %q", envBatchSize, value)
+		}
+
+		config.BatchSize = size
+	}
+
+	if value := os.Getenv(envBatchBytes); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 0 {
+			return config, fmt.Errorf("invalid %s: %q", envBatchBytes, value)
+		}
+
+		config.BatchBytes = size
+	}
+
+	return config, nil
+}
+
+// newWriter creates the event bus writer for brokers.
+//
+// Messages are partitioned by a hash of their key, so events published with the
+// same key land on the same partition and are consumed in publish order. Events
+// with different keys carry no ordering guarantee relative to each other. The writer has no
+// topic of its own, each message names the topic it is published to.
+func newWriter(brokers []string, config ProducerConfig) *kafkago.Writer {
+	writer := kafkago.NewWriter(kafkago.WriterConfig{
+		Brokers:      brokers,
+		Balancer:     &kafkago.Hash{},
+		BatchSize:    config.BatchSize,
+		BatchBytes:   config.BatchBytes,
+		BatchTimeout: config.Linger,
+	})
+	writer.Compression = config.Compression
+
+	return writer
+}
@@ -0,0 +1,169 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/eventbus"
+	"github.com/dukex/operion/pkg/events"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProducerConfig(t *testing.T) {
+	t.Setenv("KAFKA_COMPRESSION", "zstd")
+	t.Setenv("KAFKA_LINGER_MS", "5")
+	t.Setenv("KAFKA_BATCH_SIZE", "500")
+	t.Setenv("KAFKA_BATCH_BYTES", "2097152")
+
+	config, err := parseProducerConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, kafkago.Zstd, config.Compression)
+	assert.Equal(t, 5*time.Millisecond, config.Linger)
+	assert.Equal(t, 500, config.BatchSize)
+	assert.Equal(t, 2097152, config.BatchBytes)
+}
+
+func TestParseProducerConfig_Defaults(t *testing.T) {
+	config, err := parseProducerConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, ProducerConfig{}, config)
+}
+
+func TestParseProducerConfig_Invalid(t *testing.T) {
+	for env, value := range map[string]string{
+		"KAFKA_COMPRESSION": "brotli",
+		"KAFKA_LINGER_MS":   "soon",
+		"KAFKA_BATCH_SIZE":  "-1",
+		"KAFKA_BATCH_BYTES": "lots",
+	} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, value)
+
+			_, err := parseProducerConfig()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewEventBus_InvalidProducerConfig(t *testing.T) {
+	t.Setenv("KAFKA_BROKERS", brokers)
+	t.Setenv("KAFKA_COMPRESSION", "brotli")
+
+	bus, err := NewEventBus(context.Background(), logger)
+	assert.Error(t, err)
+	assert.Nil(t, bus)
+}
+
+func TestPublishEvent_CompressionRoundTrip(t *testing.T) {
+	for _, codec := range []kafkago.Compression{kafkago.Snappy, kafkago.Lz4, kafkago.Zstd} {
+		t.Run(codec.String(), func(t *testing.T) {
+			ctx := context.Background()
+
+			offset := lastOffset(t)
+
+			writer := newWriter([]string{brokers}, ProducerConfig{
+				Compression: codec,
+				Linger:      10 * time.Millisecond,
+			})
+
+			defer func() {
+				assert.NoError(t, writer.Close())
+			}()
+
+			testEvent := createTestEvent(events.WorkflowTriggeredEvent)
+			err := publishEvent(ctx, logger, writer, eventbus.JSONCodec{}, nil, events.Topic, "compressed-"+codec.String(), testEvent)
+			require.NoError(t, err)
+
+			reader := kafkago.NewReader(kafkago.ReaderConfig{
+				Brokers:   []string{brokers},
+				Topic:     events.Topic,
+				Partition: 0,
+			})
+
+			defer func() {
+				assert.NoError(t, reader.Close())
+			}()
+
+			require.NoError(t, reader.SetOffset(offset))
+
+			readCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+
+			message, err := reader.ReadMessage(readCtx)
+			require.NoError(t, err)
+
+			assert.Equal(t, "compressed-"+codec.String(), string(message.Key))
+
+			var received events.WorkflowTriggered
+
+			require.NoError(t, json.Unmarshal(message.Value, &received))
+			assert.Equal(t, testEvent.(*events.WorkflowTriggered).TriggerID, received.TriggerID)
+			assert.Equal(t, testEvent.(*events.WorkflowTriggered).TriggerData, received.TriggerData)
+		})
+	}
+}
+
+// BenchmarkPublishEvent compares publishing a burst of events one produce request
+// at a time against letting the writer batch concurrent publishes.
+func BenchmarkPublishEvent(b *testing.B) {
+	configs := map[string]ProducerConfig{
+		"unbatched": {BatchSize: 1},
+		"batched":   {BatchSize: 100, Linger: 5 * time.Millisecond},
+		"batched_lz4": {
+			BatchSize:   100,
+			Linger:      5 * time.Millisecond,
+			Compression: kafkago.Lz4,
+		},
+	}
+
+	for name, config := range configs {
+		b.Run(name, func(b *testing.B) {
+			writer := newWriter([]string{brokers}, config)
+
+			defer func() {
+				_ = writer.Close()
+			}()
+
+			testEvent := createTestEvent(events.NodeActivationEvent)
+
+			// Simulate a burst of activations published from many goroutines
+			b.SetParallelism(50)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					i++
+
+					err := publishEvent(context.Background(), logger, writer, eventbus.JSONCodec{}, nil, events.Topic, "bench-"+strconv.Itoa(i), testEvent)
+					if err != nil {
+						b.Error(err)
+					}
+				}
+			})
+		})
+	}
+}
+
+// lastOffset returns the offset the next message written to the event topic will get.
+func lastOffset(t *testing.T) int64 {
+	t.Helper()
+
+	conn, err := kafkago.DialLeader(context.Background(), "tcp", brokers, events.Topic, 0)
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, conn.Close())
+	}()
+
+	offset, err := conn.ReadLastOffset()
+	require.NoError(t, err)
+
+	return offset
+}
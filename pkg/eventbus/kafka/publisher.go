@@ -2,7 +2,6 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 
 	"github.com/dukex/operion/pkg/eventbus"
@@ -15,12 +14,15 @@ func publishEvent(
 	logger *slog.Logger,
 
 	writer *kafkago.Writer,
+	codec eventbus.Codec,
+	signer *eventbus.Signer,
+	topic string,
 	key string,
 	event eventbus.Event,
 ) error {
-	logger.InfoContext(ctx, "Publishing event", "key", key, "event_type", event.GetType())
+	logger.InfoContext(ctx, "Publishing event", "key", key, "event_type", event.GetType(), "topic", topic)
 
-	payload, err := json.Marshal(event)
+	payload, codecName, err := eventbus.EncodeEvent(codec, event)
 	if err != nil {
 		return err
 	}
@@ -32,11 +34,30 @@ func publishEvent(
 		}, {
 			Key:   events.EventTypeMetadataKey,
 			Value: []byte(event.GetType()),
+		}, {
+			Key:   events.EventCodecMetadataKey,
+			Value: []byte(codecName),
 		},
 	}
 
+	if signer != nil {
+		signature := signer.Sign(eventbus.SignedMessage{
+			Topic:     topic,
+			Key:       key,
+			EventType: string(event.GetType()),
+			Codec:     codecName,
+			Payload:   payload,
+		})
+
+		headers = append(headers, kafkago.Header{
+			Key:   events.EventSignatureMetadataKey,
+			Value: []byte(signature),
+		})
+	}
+
 	publishCtx := context.WithoutCancel(ctx)
 	err = writer.WriteMessages(publishCtx, kafkago.Message{
+		Topic:   topic,
 		Key:     []byte(key),
 		Value:   payload,
 		Headers: headers,
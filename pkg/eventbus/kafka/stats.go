@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"sync"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// readerStats accumulates the statistics of a reader. The Stats of a reader resets its counters
+// on every call, so each caller would only see the counts since the previous one: readerStats
+// takes them all and returns snapshots of the totals, until Reset is called.
+type readerStats struct {
+	stats func() kafkago.ReaderStats
+
+	mu     sync.Mutex
+	totals kafkago.ReaderStats
+}
+
+// newReaderStats accumulates the statistics of reader.
+func newReaderStats(reader *kafkago.Reader) *readerStats {
+	return &readerStats{stats: reader.Stats}
+}
+
+// Snapshot returns the counters accumulated since the last reset, and the current gauges and
+// durations of the reader, such as its lag.
+func (s *readerStats) Snapshot() kafkago.ReaderStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := s.stats()
+	current.Dials += s.totals.Dials
+	current.Fetches += s.totals.Fetches
+	current.Messages += s.totals.Messages
+	current.Bytes += s.totals.Bytes
+	current.Rebalances += s.totals.Rebalances
+	current.Timeouts += s.totals.Timeouts
+	current.Errors += s.totals.Errors
+	current.DeprecatedFetchesWithTypo = current.Fetches
+
+	s.totals = current
+
+	return current
+}
+
+// Reset starts the counters over from zero.
+func (s *readerStats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats()
+	s.totals = kafkago.ReaderStats{}
+}
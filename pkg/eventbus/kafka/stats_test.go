@@ -0,0 +1,34 @@
+package kafka
+
+import (
+	"testing"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReaderStats_SnapshotsAccumulateUntilReset(t *testing.T) {
+	// Like a reader, the source resets its counters on every read
+	pending := kafkago.ReaderStats{Messages: 3, Lag: 10}
+	stats := &readerStats{stats: func() kafkago.ReaderStats {
+		current := pending
+		pending = kafkago.ReaderStats{Lag: current.Lag}
+
+		return current
+	}}
+
+	first := stats.Snapshot()
+	assert.Equal(t, int64(3), first.Messages)
+	assert.Equal(t, int64(10), first.Lag)
+
+	pending.Messages, pending.Lag = 2, 4
+
+	second := stats.Snapshot()
+	assert.Equal(t, int64(5), second.Messages, "a second reader sees the messages counted before")
+	assert.Equal(t, int64(4), second.Lag)
+
+	stats.Reset()
+
+	assert.Zero(t, stats.Snapshot().Messages)
+	assert.Equal(t, int64(4), stats.Snapshot().Lag)
+}
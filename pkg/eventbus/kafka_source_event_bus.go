@@ -2,27 +2,51 @@ package eventbus
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/dukex/operion/pkg/channels/kafka"
 	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/tracer"
+	"go.opentelemetry.io/otel"
 )
 
 const SourceEventsTopic = "operion.source-events"
 
+// envSourceEventsCodec selects the serialization of published source events: json (default) or protobuf.
+const envSourceEventsCodec = "KAFKA_SOURCE_EVENTS_CODEC"
+
 // kafkaSourceEventBus implements SourceEventBus using Kafka via Watermill.
 type kafkaSourceEventBus struct {
 	publisher  message.Publisher
 	subscriber message.Subscriber
 	handlers   []SourceEventHandler
+	codec      Codec
+	signer     *Signer
+	metrics    *tracer.EventBusMetrics
 	logger     *slog.Logger
 }
 
 // NewKafkaSourceEventBus creates a new Kafka-based source event bus.
 func NewKafkaSourceEventBus(logger *slog.Logger) (SourceEventBus, error) {
+	codec, err := CodecByName(os.Getenv(envSourceEventsCodec))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", envSourceEventsCodec, err)
+	}
+
+	metrics, err := tracer.NewEventBusMetrics(otel.GetMeterProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := SignerFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
 	pub, sub, err := kafka.CreateChannel(watermill.NewSlogLogger(logger), "source-events")
 	if err != nil {
 		return nil, err
@@ -32,6 +56,9 @@ func NewKafkaSourceEventBus(logger *slog.Logger) (SourceEventBus, error) {
 		publisher:  pub,
 		subscriber: sub,
 		handlers:   make([]SourceEventHandler, 0),
+		codec:      codec,
+		signer:     signer,
+		metrics:    metrics,
 		logger:     logger.With("module", "kafka-source-event-bus"),
 	}, nil
 }
@@ -42,8 +69,8 @@ func (k *kafkaSourceEventBus) PublishSourceEvent(ctx context.Context, sourceEven
 		return err
 	}
 
-	// Serialize the source event to JSON
-	payload, err := json.Marshal(sourceEvent)
+	// Serialize the source event with the configured codec
+	payload, codecName, err := EncodeEvent(k.codec, sourceEvent)
 	if err != nil {
 		k.logger.Error("Failed to marshal source event", "error", err, "source_id", sourceEvent.SourceID)
 
@@ -56,6 +83,17 @@ func (k *kafkaSourceEventBus) PublishSourceEvent(ctx context.Context, sourceEven
 	msg.Metadata.Set("source_id", sourceEvent.SourceID)
 	msg.Metadata.Set("provider_id", sourceEvent.ProviderID)
 	msg.Metadata.Set("event_type", sourceEvent.EventType)
+	msg.Metadata.Set(events.EventCodecMetadataKey, codecName)
+
+	if k.signer != nil {
+		msg.Metadata.Set(events.EventSignatureMetadataKey, k.signer.Sign(SignedMessage{
+			Topic:     SourceEventsTopic,
+			Key:       sourceEvent.SourceID,
+			EventType: sourceEvent.EventType,
+			Codec:     codecName,
+			Payload:   payload,
+		}))
+	}
 
 	k.logger.Debug("Publishing source event to Kafka",
 		"source_id", sourceEvent.SourceID,
@@ -107,9 +145,17 @@ func (k *kafkaSourceEventBus) SubscribeToSourceEvents(ctx context.Context) error
 		for msg := range messages {
 			k.logger.Debug("Received message from Kafka", "message_id", msg.UUID)
 
+			// Forged or altered messages are dropped for good rather than redelivered
+			if err := k.verify(ctx, msg); err != nil {
+				k.logger.Warn("Rejected source event", "error", err, "message_id", msg.UUID)
+				msg.Ack()
+
+				continue
+			}
+
 			// Deserialize the source event
 			var sourceEvent events.SourceEvent
-			if err := json.Unmarshal(msg.Payload, &sourceEvent); err != nil {
+			if err := DecodeEvent(msg.Metadata.Get(events.EventCodecMetadataKey), msg.Payload, &sourceEvent); err != nil {
 				k.logger.Error("Failed to unmarshal source event", "error", err, "message_id", msg.UUID)
 				msg.Nack()
 
@@ -151,6 +197,29 @@ func (k *kafkaSourceEventBus) SubscribeToSourceEvents(ctx context.Context) error
 	return nil
 }
 
+// verify checks the signature of a consumed message when the bus signs messages, recording
+// rejected messages.
+func (k *kafkaSourceEventBus) verify(ctx context.Context, msg *message.Message) error {
+	if k.signer == nil {
+		return nil
+	}
+
+	eventType := msg.Metadata.Get("event_type")
+
+	err := k.signer.Verify(msg.Metadata.Get(events.EventSignatureMetadataKey), SignedMessage{
+		Topic:     SourceEventsTopic,
+		Key:       msg.Metadata.Get("key"),
+		EventType: eventType,
+		Codec:     msg.Metadata.Get(events.EventCodecMetadataKey),
+		Payload:   msg.Payload,
+	})
+	if err != nil {
+		k.metrics.RecordRejected(ctx, "source-events", eventType, RejectReason(err))
+	}
+
+	return err
+}
+
 // Close shuts down the Kafka source event bus.
 func (k *kafkaSourceEventBus) Close() error {
 	k.logger.Info("Closing Kafka source event bus")
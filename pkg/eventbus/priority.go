@@ -0,0 +1,54 @@
+package eventbus
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/dukex/operion/pkg/events"
+)
+
+// EnvHighPriorityThreshold is the environment variable holding the lowest workflow priority whose
+// node activations are published to the high-priority topic. Priority routing is disabled when
+// it is unset; consumers read the high-priority topic either way, so publishers and workers
+// configured differently lose no activation.
+const EnvHighPriorityThreshold = "KAFKA_HIGH_PRIORITY_THRESHOLD"
+
+// HighPriorityThresholdFromEnv reads the high-priority threshold from KAFKA_HIGH_PRIORITY_THRESHOLD,
+// reporting false when it is unset.
+func HighPriorityThresholdFromEnv() (int, bool, error) {
+	value := os.Getenv(EnvHighPriorityThreshold)
+	if value == "" {
+		return 0, false, nil
+	}
+
+	threshold, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid %s: %q", EnvHighPriorityThreshold, value)
+	}
+
+	return threshold, true, nil
+}
+
+// TopicFor returns the topic an event is published to when priority routing is enabled: node
+// activations with a priority at or above highPriorityThreshold go to the high-priority topic,
+// which workers consume on their own, so urgent workflows are not queued behind bulk ones on the
+// bus. Every other event goes to the events topic.
+func TopicFor(event Event, highPriorityThreshold int) string {
+	var priority int
+
+	switch activation := event.(type) {
+	case *events.NodeActivation:
+		priority = activation.Priority
+	case events.NodeActivation:
+		priority = activation.Priority
+	default:
+		return events.Topic
+	}
+
+	if priority >= highPriorityThreshold {
+		return events.HighPriorityTopic
+	}
+
+	return events.Topic
+}
@@ -0,0 +1,45 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicFor(t *testing.T) {
+	activation := func(priority int) *events.NodeActivation {
+		return &events.NodeActivation{
+			BaseEvent: events.NewBaseEvent(events.NodeActivationEvent, "wf-1"),
+			NodeID:    "notify",
+			Priority:  priority,
+		}
+	}
+
+	assert.Equal(t, events.HighPriorityTopic, TopicFor(activation(10), 5))
+	assert.Equal(t, events.HighPriorityTopic, TopicFor(*activation(5), 5))
+	assert.Equal(t, events.Topic, TopicFor(activation(4), 5))
+	assert.Equal(t, events.Topic, TopicFor(activation(0), 5))
+	assert.Equal(t, events.Topic, TopicFor(&events.WorkflowFinished{}, -10), "only node activations are routed by priority")
+}
+
+func TestHighPriorityThresholdFromEnv(t *testing.T) {
+	t.Setenv(EnvHighPriorityThreshold, "")
+
+	_, enabled, err := HighPriorityThresholdFromEnv()
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	t.Setenv(EnvHighPriorityThreshold, "5")
+
+	threshold, enabled, err := HighPriorityThresholdFromEnv()
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Equal(t, 5, threshold)
+
+	t.Setenv(EnvHighPriorityThreshold, "urgent")
+
+	_, _, err = HighPriorityThresholdFromEnv()
+	assert.Error(t, err)
+}
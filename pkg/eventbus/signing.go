@@ -0,0 +1,156 @@
+package eventbus
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukex/operion/pkg/tracer"
+)
+
+// EnvSigningSecret holds the secret shared by publishers and consumers to sign the messages of the
+// event bus and the source event bus. Unset, messages are neither signed nor verified.
+const EnvSigningSecret = "KAFKA_SIGNING_SECRET"
+
+// EnvSignatureMaxAge holds how long a signed message stays valid (a duration such as "15m"),
+// DefaultSignatureMaxAge when unset. Consumers lagging further behind reject the messages.
+const EnvSignatureMaxAge = "KAFKA_SIGNATURE_MAX_AGE"
+
+// DefaultSignatureMaxAge is how long a signed message stays valid when EnvSignatureMaxAge is unset.
+const DefaultSignatureMaxAge = 15 * time.Minute
+
+var (
+	// ErrUnsignedEvent is returned when verifying a message without a signature.
+	ErrUnsignedEvent = errors.New("event is not signed")
+	// ErrInvalidSignature is returned when verifying a message whose signature does not match.
+	ErrInvalidSignature = errors.New("event signature is invalid")
+	// ErrStaleSignature is returned when verifying a message signed longer ago than the maximum age,
+	// such as a replayed one.
+	ErrStaleSignature = errors.New("event signature is stale")
+)
+
+// SignedMessage is the part of a message covered by its signature.
+type SignedMessage struct {
+	Topic     string
+	Key       string
+	EventType string
+	Codec     string
+	Payload   []byte
+}
+
+// Signer signs published messages with an HMAC-SHA256 of their signing time, topic, key, event
+// type, codec and payload, and verifies the signature of consumed messages, so that only holders
+// of the secret can publish events consumers act on, and a captured message can neither be
+// replayed once stale nor moved to another topic or key.
+type Signer struct {
+	secret []byte
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// NewSigner creates a signer with the shared secret, accepting messages signed within maxAge.
+func NewSigner(secret string, maxAge time.Duration) *Signer {
+	return &Signer{secret: []byte(secret), maxAge: maxAge, now: time.Now}
+}
+
+// SignerFromEnv creates a signer with the secret of EnvSigningSecret and the maximum age of
+// EnvSignatureMaxAge, or returns nil when the secret is unset.
+func SignerFromEnv() (*Signer, error) {
+	secret := os.Getenv(EnvSigningSecret)
+	if secret == "" {
+		return nil, nil
+	}
+
+	maxAge := DefaultSignatureMaxAge
+
+	if value := os.Getenv(EnvSignatureMaxAge); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid %s: %q", EnvSignatureMaxAge, value)
+		}
+
+		maxAge = parsed
+	}
+
+	return NewSigner(secret, maxAge), nil
+}
+
+// Sign returns the signature of a message: its signing time in Unix seconds and the hex encoded
+// HMAC, separated by a dot.
+func (s *Signer) Sign(msg SignedMessage) string {
+	signedAt := s.now().Unix()
+
+	return strconv.FormatInt(signedAt, 10) + "." + hex.EncodeToString(s.mac(signedAt, msg))
+}
+
+// Verify checks the signature of a message, returning ErrUnsignedEvent when it has none,
+// ErrInvalidSignature when it was not signed with the secret or the message changed since, and
+// ErrStaleSignature when it was signed further than the maximum age from now.
+func (s *Signer) Verify(signature string, msg SignedMessage) error {
+	if signature == "" {
+		return ErrUnsignedEvent
+	}
+
+	timestamp, mac, ok := strings.Cut(signature, ".")
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	decoded, err := hex.DecodeString(mac)
+	if err != nil || !hmac.Equal(decoded, s.mac(signedAt, msg)) {
+		return ErrInvalidSignature
+	}
+
+	// Signing clocks ahead of the consumer are allowed the same leeway
+	age := s.now().Sub(time.Unix(signedAt, 0))
+	if age > s.maxAge || age < -s.maxAge {
+		return ErrStaleSignature
+	}
+
+	return nil
+}
+
+// mac computes the HMAC of a message, length-prefixing each part so that moving bytes between
+// them changes the signature.
+func (s *Signer) mac(signedAt int64, msg SignedMessage) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+
+	_ = binary.Write(mac, binary.BigEndian, signedAt)
+	writePart(mac, []byte(msg.Topic))
+	writePart(mac, []byte(msg.Key))
+	writePart(mac, []byte(msg.EventType))
+	writePart(mac, []byte(msg.Codec))
+	writePart(mac, msg.Payload)
+
+	return mac.Sum(nil)
+}
+
+func writePart(mac hash.Hash, part []byte) {
+	_ = binary.Write(mac, binary.BigEndian, uint64(len(part)))
+	_, _ = mac.Write(part)
+}
+
+// RejectReason returns the tracer.EventBusMetrics reason of a Verify error.
+func RejectReason(err error) string {
+	switch {
+	case errors.Is(err, ErrUnsignedEvent):
+		return tracer.RejectReasonUnsigned
+	case errors.Is(err, ErrStaleSignature):
+		return tracer.RejectReasonStaleSignature
+	default:
+		return tracer.RejectReasonInvalidSignature
+	}
+}
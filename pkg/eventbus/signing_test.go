@@ -0,0 +1,201 @@
+package eventbus
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/tracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestSigner_SignAndVerify(t *testing.T) {
+	signer := NewSigner("shared-secret", time.Minute)
+	msg := SignedMessage{
+		Topic:     events.Topic,
+		Key:       "fetch:exec-1",
+		EventType: string(events.NodeActivationEvent),
+		Codec:     CodecJSON,
+		Payload:   []byte(`{"node_id":"fetch","execution_id":"exec-1"}`),
+	}
+
+	signature := signer.Sign(msg)
+	require.NoError(t, signer.Verify(signature, msg))
+
+	changed := func(change func(*SignedMessage)) SignedMessage {
+		changedMsg := msg
+		change(&changedMsg)
+
+		return changedMsg
+	}
+
+	require.ErrorIs(t, signer.Verify(signature, changed(func(m *SignedMessage) {
+		m.Payload = []byte(`{"node_id":"delete-all","execution_id":"exec-1"}`)
+	})), ErrInvalidSignature)
+	require.ErrorIs(t, signer.Verify(signature, changed(func(m *SignedMessage) { m.EventType = string(events.NodeCompletionEvent) })), ErrInvalidSignature)
+	require.ErrorIs(t, signer.Verify(signature, changed(func(m *SignedMessage) { m.Codec = CodecProtobuf })), ErrInvalidSignature)
+	require.ErrorIs(t, signer.Verify(signature, changed(func(m *SignedMessage) { m.Topic = events.HighPriorityTopic })), ErrInvalidSignature)
+	require.ErrorIs(t, signer.Verify(signature, changed(func(m *SignedMessage) { m.Key = "fetch:exec-2" })), ErrInvalidSignature)
+	require.ErrorIs(t, signer.Verify("not-hex", msg), ErrInvalidSignature)
+	require.ErrorIs(t, signer.Verify("1.not-hex", msg), ErrInvalidSignature)
+	require.ErrorIs(t, signer.Verify("", msg), ErrUnsignedEvent)
+
+	// Moving the signing time changes the signature
+	timestamp, mac, _ := strings.Cut(signature, ".")
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	require.NoError(t, err)
+	require.ErrorIs(t, signer.Verify(strconv.FormatInt(signedAt-1, 10)+"."+mac, msg), ErrInvalidSignature)
+
+	forged := NewSigner("guessed-secret", time.Minute).Sign(msg)
+	require.ErrorIs(t, signer.Verify(forged, msg), ErrInvalidSignature)
+}
+
+func TestSigner_RejectsStaleSignatures(t *testing.T) {
+	signer := NewSigner("shared-secret", time.Minute)
+	msg := SignedMessage{Topic: events.Topic, Key: "fetch:exec-1", EventType: string(events.NodeActivationEvent), Codec: CodecJSON}
+
+	signedAt := time.Now()
+	signer.now = func() time.Time { return signedAt }
+	signature := signer.Sign(msg)
+
+	signer.now = func() time.Time { return signedAt.Add(30 * time.Second) }
+	require.NoError(t, signer.Verify(signature, msg))
+
+	// A replay past the maximum age is rejected
+	signer.now = func() time.Time { return signedAt.Add(2 * time.Minute) }
+	err := signer.Verify(signature, msg)
+	require.ErrorIs(t, err, ErrStaleSignature)
+	assert.Equal(t, tracer.RejectReasonStaleSignature, RejectReason(err))
+
+	// So is a message signed by a clock too far ahead
+	signer.now = func() time.Time { return signedAt.Add(-2 * time.Minute) }
+	require.ErrorIs(t, signer.Verify(signature, msg), ErrStaleSignature)
+}
+
+func TestSignerFromEnv(t *testing.T) {
+	t.Setenv(EnvSigningSecret, "")
+
+	signer, err := SignerFromEnv()
+	require.NoError(t, err)
+	assert.Nil(t, signer)
+
+	t.Setenv(EnvSigningSecret, "shared-secret")
+
+	signer, err = SignerFromEnv()
+	require.NoError(t, err)
+	require.NotNil(t, signer)
+	assert.Equal(t, DefaultSignatureMaxAge, signer.maxAge)
+
+	msg := SignedMessage{EventType: "a", Codec: "b"}
+	require.NoError(t, NewSigner("shared-secret", time.Minute).Verify(signer.Sign(msg), msg))
+
+	t.Setenv(EnvSignatureMaxAge, "1h")
+
+	signer, err = SignerFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, signer.maxAge)
+
+	t.Setenv(EnvSignatureMaxAge, "soon")
+
+	_, err = SignerFromEnv()
+	require.Error(t, err)
+}
+
+// createSigningSourceEventBus creates a source event bus signing its messages over an in-memory
+// channel, recording its metrics in reader.
+func createSigningSourceEventBus(t *testing.T) (*kafkaSourceEventBus, *gochannel.GoChannel, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	channel := gochannel.NewGoChannel(gochannel.Config{BlockPublishUntilSubscriberAck: true}, watermill.NopLogger{})
+	t.Cleanup(func() { _ = channel.Close() })
+
+	reader := sdkmetric.NewManualReader()
+	metrics, err := tracer.NewEventBusMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+
+	return &kafkaSourceEventBus{
+		publisher:  channel,
+		subscriber: channel,
+		codec:      JSONCodec{},
+		signer:     NewSigner("shared-secret", DefaultSignatureMaxAge),
+		metrics:    metrics,
+		logger:     slog.New(slog.DiscardHandler),
+	}, channel, reader
+}
+
+func TestKafkaSourceEventBus_RejectsTamperedEvents(t *testing.T) {
+	bus, channel, reader := createSigningSourceEventBus(t)
+
+	received := make(chan *events.SourceEvent, 3)
+	require.NoError(t, bus.HandleSourceEvents(func(_ context.Context, sourceEvent *events.SourceEvent) error {
+		received <- sourceEvent
+
+		return nil
+	}))
+	require.NoError(t, bus.SubscribeToSourceEvents(t.Context()))
+
+	// A tampered message keeps the signature of the original payload
+	tampered := message.NewMessage(watermill.NewUUID(), []byte(`{"source_id":"orders","provider_id":"webhook","event_type":"OrderPlaced","event_data":{"total":1}}`))
+	tampered.Metadata.Set("key", "orders")
+	tampered.Metadata.Set("event_type", "OrderPlaced")
+	tampered.Metadata.Set(events.EventCodecMetadataKey, CodecJSON)
+	tampered.Metadata.Set(events.EventSignatureMetadataKey, bus.signer.Sign(SignedMessage{
+		Topic:     SourceEventsTopic,
+		Key:       "orders",
+		EventType: "OrderPlaced",
+		Codec:     CodecJSON,
+		Payload:   []byte(`{"source_id":"orders","provider_id":"webhook","event_type":"OrderPlaced","event_data":{"total":1000}}`),
+	}))
+	require.NoError(t, channel.Publish(SourceEventsTopic, tampered))
+
+	unsigned := message.NewMessage(watermill.NewUUID(), []byte(`{"source_id":"orders","provider_id":"webhook","event_type":"OrderPlaced","event_data":{}}`))
+	unsigned.Metadata.Set("event_type", "OrderPlaced")
+	require.NoError(t, channel.Publish(SourceEventsTopic, unsigned))
+
+	require.NoError(t, bus.PublishSourceEvent(t.Context(), &events.SourceEvent{
+		SourceID:   "orders",
+		ProviderID: "webhook",
+		EventType:  "OrderPlaced",
+		EventData:  map[string]any{"total": 42.0},
+	}))
+
+	select {
+	case sourceEvent := <-received:
+		assert.Equal(t, map[string]any{"total": 42.0}, sourceEvent.EventData)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the signed event was not handled")
+	}
+
+	assert.Empty(t, received, "rejected events never reach the handler")
+
+	var collected metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &collected))
+	require.Len(t, collected.ScopeMetrics, 1)
+	require.Len(t, collected.ScopeMetrics[0].Metrics, 1)
+
+	rejected, ok := collected.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+
+	reasons := make(map[string]int64)
+
+	for _, point := range rejected.DataPoints {
+		reason, _ := point.Attributes.Value(attribute.Key("reject.reason"))
+		reasons[reason.AsString()] = point.Value
+	}
+
+	assert.Equal(t, map[string]int64{
+		tracer.RejectReasonInvalidSignature: 1,
+		tracer.RejectReasonUnsigned:         1,
+	}, reasons)
+}
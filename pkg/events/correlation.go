@@ -0,0 +1,75 @@
+package events
+
+import (
+	"context"
+	"regexp"
+)
+
+// CorrelationIDHeader is the HTTP header carrying a correlation ID in requests and responses.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// correlationIDPattern matches the correlation IDs accepted from callers: up to 128 letters, digits,
+// dots, underscores, colons and dashes, which covers UUIDs and the trace IDs of common tracers.
+var correlationIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// IsValidCorrelationID reports whether a correlation ID sent by a caller is safe to propagate to
+// logs, events and responses.
+func IsValidCorrelationID(correlationID string) bool {
+	return correlationIDPattern.MatchString(correlationID)
+}
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context carrying the correlation ID of the execution or request it serves.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	if correlationID == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or an empty string.
+func CorrelationID(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+
+	return correlationID
+}
+
+type orderingKeyKey struct{}
+
+// WithOrderingKey returns a context carrying the ordering key of the source event or execution it
+// serves, such as the key of the Kafka message behind it.
+func WithOrderingKey(ctx context.Context, orderingKey string) context.Context {
+	if orderingKey == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, orderingKeyKey{}, orderingKey)
+}
+
+// OrderingKey returns the ordering key carried by ctx, or an empty string.
+func OrderingKey(ctx context.Context) string {
+	orderingKey, _ := ctx.Value(orderingKeyKey{}).(string)
+
+	return orderingKey
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context carrying the priority of the execution it serves, so the node
+// activations it leads to keep the priority of their workflow.
+func WithPriority(ctx context.Context, priority int) context.Context {
+	if priority == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// Priority returns the priority carried by ctx, or 0.
+func Priority(ctx context.Context) int {
+	priority, _ := ctx.Value(priorityKey{}).(int)
+
+	return priority
+}
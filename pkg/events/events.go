@@ -14,9 +14,12 @@ type EventType string
 const Topic = "operion.events"                               // Legacy topic for workflow events
 const NodeActivationTopic = "operion.node.activations"       // Topic for node activations
 const WorkflowExecutionTopic = "operion.workflow.executions" // Topic for workflow execution events
+const HighPriorityTopic = "operion.events.high-priority"     // Topic for activations of high-priority workflows
 
 const EventMetadataKey = "key"
 const EventTypeMetadataKey = "event_type"
+const EventCodecMetadataKey = "codec"
+const EventSignatureMetadataKey = "signature" // Set when KAFKA_SIGNING_SECRET signs messages
 
 const (
 	// Workflow lifecycle events.
@@ -39,15 +42,19 @@ const (
 	WorkflowExecutionPausedEvent    EventType = "workflow.execution.paused"
 	WorkflowExecutionResumedEvent   EventType = "workflow.execution.resumed"
 	WorkflowVariablesUpdatedEvent   EventType = "workflow.variables.updated"
+
+	// Monitoring events.
+	WorkflowHeartbeatMissedEvent EventType = "workflow.heartbeat.missed"
 )
 
 type BaseEvent struct {
-	ID         string         `json:"id"`
-	Type       EventType      `json:"type"`
-	Timestamp  time.Time      `json:"timestamp"`
-	WorkflowID string         `json:"workflow_id"`
-	WorkerID   string         `json:"worker_id,omitempty"`
-	Metadata   map[string]any `json:"metadata,omitempty"`
+	ID            string         `json:"id"`
+	Type          EventType      `json:"type"`
+	Timestamp     time.Time      `json:"timestamp"`
+	WorkflowID    string         `json:"workflow_id"`
+	WorkerID      string         `json:"worker_id,omitempty"`
+	CorrelationID string         `json:"correlation_id,omitempty"`
+	Metadata      map[string]any `json:"metadata,omitempty"`
 }
 
 type WorkflowTriggered struct {
@@ -97,6 +104,21 @@ type NodeActivation struct {
 	InputData   any    `json:"input_data"`
 	SourceNode  string `json:"source_node"`
 	SourcePort  string `json:"source_port"`
+
+	// OrderingKey is the ordering key of the execution's source event: activations with the same
+	// key come from events that were emitted in order.
+	OrderingKey string `json:"ordering_key,omitempty"`
+
+	// Priority is the priority of the execution's workflow, higher first. Activations at or above
+	// the high-priority threshold of the event bus are published to HighPriorityTopic.
+	Priority int `json:"priority,omitempty"`
+
+	// Attempt counts the retries of the node this activation runs again after it failed, 0 for its
+	// first execution.
+	Attempt int `json:"attempt,omitempty"`
+
+	// NotBefore is when a rescheduled activation is due: workers hold it until then before queueing it.
+	NotBefore *time.Time `json:"not_before,omitempty"`
 }
 
 func (n NodeActivation) GetType() EventType {
@@ -271,6 +293,22 @@ func (w WorkflowExecutionResumed) GetType() EventType {
 	return WorkflowExecutionResumedEvent
 }
 
+// WorkflowHeartbeatMissed alerts that a scheduled workflow did not run within the grace period
+// after it was expected to.
+type WorkflowHeartbeatMissed struct {
+	BaseEvent
+
+	TriggerNodeID  string     `json:"trigger_node_id"`
+	CronExpression string     `json:"cron_expression"`
+	LastRunAt      *time.Time `json:"last_run_at,omitempty"`
+	ExpectedRunAt  time.Time  `json:"expected_run_at"`
+	GracePeriodMs  int64      `json:"grace_period_ms"`
+}
+
+func (w WorkflowHeartbeatMissed) GetType() EventType {
+	return WorkflowHeartbeatMissedEvent
+}
+
 func NewBaseEvent(eventType EventType, workflowID string) BaseEvent {
 	return BaseEvent{
 		ID:         uuid.New().String(),
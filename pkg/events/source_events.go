@@ -32,6 +32,14 @@ type SourceEvent struct {
 	// This data will be passed to triggered workflows as trigger data.
 	// Each source provider plugin defines its own event data schema.
 	EventData map[string]any `json:"event_data"`
+
+	// CorrelationID identifies the request that caused the event, such as a webhook delivery.
+	// Executions triggered by the event carry it, so their logs and events can be traced back to it.
+	CorrelationID string `json:"correlation_id,omitempty"`
+
+	// OrderingKey groups events that must be processed in the order they were emitted, such as
+	// the key of a Kafka message. Executions triggered by the event and their node activations carry it.
+	OrderingKey string `json:"ordering_key,omitempty"`
 }
 
 // NewSourceEvent creates a new SourceEvent with the provided parameters.
@@ -0,0 +1,58 @@
+package executionsink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// messageWriter writes the messages of the Kafka sink; *kafkago.Writer in production.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, messages ...kafkago.Message) error
+	Close() error
+}
+
+// KafkaSink publishes records as JSON to a Kafka topic, keyed by execution ID.
+type KafkaSink struct {
+	writer messageWriter
+}
+
+// NewKafkaSink creates a sink publishing to topic on the comma-separated brokers.
+func NewKafkaSink(brokers, topic string) (*KafkaSink, error) {
+	topic = strings.Trim(topic, "/")
+
+	if brokers == "" || topic == "" {
+		return nil, errors.New("kafka execution sink requires brokers and a topic, e.g. kafka://localhost:9092/executions")
+	}
+
+	return &KafkaSink{
+		writer: &kafkago.Writer{
+			Addr:     kafkago.TCP(strings.Split(brokers, ",")...),
+			Topic:    topic,
+			Balancer: &kafkago.Hash{},
+		},
+	}, nil
+}
+
+// Export publishes the record.
+func (s *KafkaSink) Export(ctx context.Context, record Record) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal execution record: %w", err)
+	}
+
+	if err := s.writer.WriteMessages(ctx, kafkago.Message{Key: []byte(record.ExecutionID), Value: value}); err != nil {
+		return fmt.Errorf("failed to publish execution record: %w", err)
+	}
+
+	return nil
+}
+
+// Close flushes and closes the writer.
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,120 @@
+package executionsink
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	_ "github.com/lib/pq"
+)
+
+const (
+	// DefaultTable is the Postgres table holding execution records when none is configured.
+	DefaultTable = "operion_execution_records"
+
+	// tableQueryParam is the sink URL query parameter naming the Postgres table.
+	tableQueryParam = "sink_table"
+)
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// execer runs the statements of the Postgres sink; *sql.DB in production.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// PostgresSink inserts a row per execution into a Postgres table.
+type PostgresSink struct {
+	db    execer
+	close func() error
+	table string
+}
+
+// NewPostgresSink creates a sink writing to table, creating the table if needed.
+func NewPostgresSink(ctx context.Context, url, table string) (*PostgresSink, error) {
+	if table == "" {
+		table = DefaultTable
+	}
+
+	if !identifierPattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name '%s'", table)
+	}
+
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
+	}
+
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		execution_id TEXT PRIMARY KEY,
+		workflow_id TEXT NOT NULL,
+		correlation_id TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL,
+		error_message TEXT NOT NULL DEFAULT '',
+		started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+		completed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+		duration_ms BIGINT NOT NULL,
+		node_count INTEGER NOT NULL,
+		failed_node_count INTEGER NOT NULL,
+		nodes JSONB NOT NULL
+	)`, table)
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("failed to create execution records table: %w", err)
+	}
+
+	return &PostgresSink{db: db, close: db.Close, table: table}, nil
+}
+
+// Export inserts the record, replacing an earlier record of the same execution.
+func (s *PostgresSink) Export(ctx context.Context, record Record) error {
+	nodes, err := json.Marshal(record.Nodes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal node outcomes: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (execution_id, workflow_id, correlation_id, status, error_message, started_at, completed_at, duration_ms, node_count, failed_node_count, nodes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (execution_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			error_message = EXCLUDED.error_message,
+			completed_at = EXCLUDED.completed_at,
+			duration_ms = EXCLUDED.duration_ms,
+			node_count = EXCLUDED.node_count,
+			failed_node_count = EXCLUDED.failed_node_count,
+			nodes = EXCLUDED.nodes
+	`, s.table)
+
+	_, err = s.db.ExecContext(ctx, query,
+		record.ExecutionID,
+		record.WorkflowID,
+		record.CorrelationID,
+		record.Status,
+		record.ErrorMessage,
+		record.StartedAt,
+		record.CompletedAt,
+		record.DurationMs,
+		record.NodeCount,
+		record.FailedNodeCount,
+		nodes,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert execution record: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the database connection.
+func (s *PostgresSink) Close() error {
+	if s.close == nil {
+		return nil
+	}
+
+	return s.close()
+}
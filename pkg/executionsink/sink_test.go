@@ -0,0 +1,156 @@
+package executionsink
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var startedAt = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func failedExecution() *models.ExecutionContext {
+	completedAt := startedAt.Add(2 * time.Second)
+
+	return &models.ExecutionContext{
+		ID:           "exec-1",
+		WorkflowID:   "workflow-1",
+		Status:       models.ExecutionStatusFailed,
+		ErrorMessage: "node charge failed",
+		CreatedAt:    startedAt,
+		CompletedAt:  &completedAt,
+		NodeResults: map[string]models.NodeResult{
+			"reserve::success": {Status: string(models.NodeStatusSuccess), Timestamp: startedAt.Add(time.Second)},
+			"charge::error":    {Status: string(models.NodeStatusError), Error: "card declined", Timestamp: completedAt},
+			"charge::failure":  {Status: string(models.NodeStatusError), Error: "card declined", Timestamp: completedAt},
+			"not-a-result-key": {Status: string(models.NodeStatusSuccess)},
+		},
+	}
+}
+
+func TestNewRecord_FailedExecution(t *testing.T) {
+	record := NewRecord(failedExecution(), time.Now())
+
+	assert.Equal(t, Record{
+		ExecutionID:     "exec-1",
+		WorkflowID:      "workflow-1",
+		Status:          "failed",
+		ErrorMessage:    "node charge failed",
+		StartedAt:       startedAt,
+		CompletedAt:     startedAt.Add(2 * time.Second),
+		DurationMs:      2000,
+		NodeCount:       2,
+		FailedNodeCount: 1,
+		Nodes: []NodeOutcome{
+			{NodeID: "reserve", Port: "success", Status: "success", CompletedAt: startedAt.Add(time.Second)},
+			{NodeID: "charge", Port: "error", Status: "error", Error: "card declined", CompletedAt: startedAt.Add(2 * time.Second)},
+			{NodeID: "charge", Port: "failure", Status: "error", Error: "card declined", CompletedAt: startedAt.Add(2 * time.Second)},
+		},
+	}, record)
+}
+
+func TestNewRecord_WithoutCompletionTimeUsesNow(t *testing.T) {
+	execCtx := &models.ExecutionContext{ID: "exec-2", Status: models.ExecutionStatusCompleted, CreatedAt: startedAt}
+
+	record := NewRecord(execCtx, startedAt.Add(time.Minute))
+
+	assert.Equal(t, startedAt.Add(time.Minute), record.CompletedAt)
+	assert.Equal(t, int64(60000), record.DurationMs)
+	assert.Empty(t, record.Nodes)
+}
+
+// fakeWriter records the messages written to it.
+type fakeWriter struct {
+	messages []kafkago.Message
+	closed   bool
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, messages ...kafkago.Message) error {
+	w.messages = append(w.messages, messages...)
+
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.closed = true
+
+	return nil
+}
+
+func TestKafkaSink_PublishesRecordKeyedByExecution(t *testing.T) {
+	writer := &fakeWriter{}
+	sink := &KafkaSink{writer: writer}
+
+	record := NewRecord(failedExecution(), time.Now())
+	require.NoError(t, sink.Export(t.Context(), record))
+	require.NoError(t, sink.Close())
+
+	require.Len(t, writer.messages, 1)
+	assert.Equal(t, "exec-1", string(writer.messages[0].Key))
+	assert.True(t, writer.closed)
+
+	var published map[string]any
+	require.NoError(t, json.Unmarshal(writer.messages[0].Value, &published))
+
+	assert.Equal(t, "workflow-1", published["workflow_id"])
+	assert.Equal(t, "failed", published["status"])
+	assert.InDelta(t, 2000, published["duration_ms"], 0)
+	assert.InDelta(t, 1, published["failed_node_count"], 0)
+	assert.Len(t, published["nodes"], 3)
+}
+
+// fakeExecer records the statements executed by the Postgres sink.
+type fakeExecer struct {
+	query string
+	args  []any
+}
+
+func (e *fakeExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	e.query = query
+	e.args = args
+
+	return nil, nil //nolint:nilnil // the sink ignores the result
+}
+
+func TestPostgresSink_InsertsRecordRow(t *testing.T) {
+	db := &fakeExecer{}
+	sink := &PostgresSink{db: db, table: "analytics.executions"}
+
+	require.NoError(t, sink.Export(t.Context(), NewRecord(failedExecution(), time.Now())))
+
+	assert.Contains(t, db.query, "INSERT INTO analytics.executions")
+	require.Len(t, db.args, 11)
+	assert.Equal(t, []any{"exec-1", "workflow-1", "", "failed", "node charge failed"}, db.args[:5])
+	assert.Equal(t, []any{int64(2000), 2, 1}, db.args[7:10])
+
+	var nodes []NodeOutcome
+	require.NoError(t, json.Unmarshal(db.args[10].([]byte), &nodes))
+	assert.Len(t, nodes, 3)
+}
+
+func TestNew_InvalidSinks(t *testing.T) {
+	_, err := New(t.Context(), "s3://bucket/executions")
+	require.ErrorIs(t, err, ErrUnsupportedSink)
+
+	_, err = New(t.Context(), "kafka://localhost:9092")
+	require.Error(t, err)
+
+	_, err = New(t.Context(), "postgres://localhost/operion?sink_table=drop-records")
+	require.ErrorContains(t, err, "invalid table name")
+}
+
+func TestNew_KafkaSink(t *testing.T) {
+	sink, err := New(t.Context(), "kafka://broker-1:9092,broker-2:9092/executions")
+	require.NoError(t, err)
+
+	writer, ok := sink.(*KafkaSink).writer.(*kafkago.Writer)
+	require.True(t, ok)
+	assert.Equal(t, "executions", writer.Topic)
+	assert.Equal(t, "broker-1:9092,broker-2:9092", writer.Addr.String())
+}
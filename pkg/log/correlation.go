@@ -0,0 +1,38 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dukex/operion/pkg/events"
+)
+
+// CorrelationHandler adds the correlation ID carried by the context to every record logged
+// with one, so all lines of an execution can be found by its correlation ID.
+type CorrelationHandler struct {
+	slog.Handler
+}
+
+// NewCorrelationHandler wraps handler with correlation ID logging.
+func NewCorrelationHandler(handler slog.Handler) *CorrelationHandler {
+	return &CorrelationHandler{Handler: handler}
+}
+
+// Handle adds the correlation ID of ctx to the record before handling it.
+func (h *CorrelationHandler) Handle(ctx context.Context, record slog.Record) error {
+	if correlationID := events.CorrelationID(ctx); correlationID != "" {
+		record.AddAttrs(slog.String("correlation_id", correlationID))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a correlation handler whose wrapped handler has the attributes.
+func (h *CorrelationHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &CorrelationHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a correlation handler whose wrapped handler has the group.
+func (h *CorrelationHandler) WithGroup(name string) slog.Handler {
+	return &CorrelationHandler{Handler: h.Handler.WithGroup(name)}
+}
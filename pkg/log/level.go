@@ -0,0 +1,45 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LevelHandler overrides the minimum level of the handler it wraps, in either direction, so a
+// single component can log at debug while the rest of the process stays at info.
+type LevelHandler struct {
+	slog.Handler
+
+	level slog.Level
+}
+
+// NewLevelHandler wraps handler so records are handled from level up, whatever the level of handler.
+func NewLevelHandler(handler slog.Handler, level slog.Level) *LevelHandler {
+	// Wrapping a level handler again replaces its level rather than stacking both
+	if levelHandler, ok := handler.(*LevelHandler); ok {
+		handler = levelHandler.Handler
+	}
+
+	return &LevelHandler{Handler: handler, level: level}
+}
+
+// WithLevel returns a logger writing to the handler of logger from level up.
+func WithLevel(logger *slog.Logger, level slog.Level) *slog.Logger {
+	return slog.New(NewLevelHandler(logger.Handler(), level))
+}
+
+// Enabled reports whether level is at or above the level of the handler. The level of the wrapped
+// handler is not consulted: handlers such as slog.TextHandler only check it in Enabled.
+func (h *LevelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+// WithAttrs returns a level handler whose wrapped handler has the attributes.
+func (h *LevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LevelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+// WithGroup returns a level handler whose wrapped handler has the group.
+func (h *LevelHandler) WithGroup(name string) slog.Handler {
+	return &LevelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
@@ -6,24 +6,28 @@ import (
 )
 
 func Setup(logLevel string) {
-	var level slog.Level
+	level, _ := ParseLevel(logLevel)
 
-	switch logLevel {
+	slog.SetDefault(slog.New(NewCorrelationHandler(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: level,
+	}))))
+}
+
+// ParseLevel returns the slog level named debug, info, warn or error. Other names are reported
+// as not ok and map to info.
+func ParseLevel(name string) (slog.Level, bool) {
+	switch name {
 	case "debug":
-		level = slog.LevelDebug
+		return slog.LevelDebug, true
 	case "info":
-		level = slog.LevelInfo
+		return slog.LevelInfo, true
 	case "warn":
-		level = slog.LevelWarn
+		return slog.LevelWarn, true
 	case "error":
-		level = slog.LevelError
+		return slog.LevelError, true
 	default:
-		level = slog.LevelInfo
+		return slog.LevelInfo, false
 	}
-
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: level,
-	})))
 }
 
 func WithModule(module string) *slog.Logger {
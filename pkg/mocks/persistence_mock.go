@@ -24,6 +24,15 @@ func (m *MockWorkflowRepository) GetAll(ctx context.Context) ([]*models.Workflow
 	return args.Get(0).([]*models.Workflow), args.Error(1)
 }
 
+func (m *MockWorkflowRepository) ListWorkflows(ctx context.Context, opts models.ListOptions) (*models.WorkflowPage, error) {
+	args := m.Called(ctx, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.WorkflowPage), args.Error(1)
+}
+
 func (m *MockWorkflowRepository) Save(ctx context.Context, workflow *models.Workflow) error {
 	args := m.Called(ctx, workflow)
 
@@ -45,6 +54,21 @@ func (m *MockWorkflowRepository) Delete(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
+func (m *MockWorkflowRepository) GetByIDIncludingDeleted(ctx context.Context, id string) (*models.Workflow, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.Workflow), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) Restore(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
+
 func (m *MockWorkflowRepository) GetWorkflowVersions(ctx context.Context, workflowGroupID string) ([]*models.Workflow, error) {
 	args := m.Called(ctx, workflowGroupID)
 	if args.Get(0) == nil {
@@ -161,6 +185,14 @@ func (m *MockPersistence) InputCoordinationRepository() persistence.InputCoordin
 	return &MockInputCoordinationRepository{}
 }
 
+func (m *MockPersistence) DebounceRepository() persistence.DebounceRepository {
+	return &MockDebounceRepository{}
+}
+
+func (m *MockPersistence) AlertRepository() persistence.AlertRepository {
+	return &MockAlertRepository{}
+}
+
 // Stub mock repository implementations (not fully implemented during transition)
 
 type MockNodeRepository struct {
@@ -183,6 +215,10 @@ func (nr *MockNodeRepository) UpdateNode(ctx context.Context, workflowID string,
 	return errors.New("mock node repository not implemented during transition")
 }
 
+func (nr *MockNodeRepository) UpdateNodePositions(ctx context.Context, workflowID string, positions map[string]models.NodePosition) error {
+	return errors.New("mock node repository not implemented during transition")
+}
+
 func (nr *MockNodeRepository) DeleteNode(ctx context.Context, workflowID, nodeID string) error {
 	return errors.New("mock node repository not implemented during transition")
 }
@@ -247,6 +283,12 @@ func (ecr *MockExecutionContextRepository) UpdateExecutionContext(ctx context.Co
 	return args.Error(0)
 }
 
+func (ecr *MockExecutionContextRepository) DeleteExecutionContext(ctx context.Context, executionID string) error {
+	args := ecr.Called(ctx, executionID)
+
+	return args.Error(0)
+}
+
 func (ecr *MockExecutionContextRepository) GetExecutionsByWorkflow(ctx context.Context, workflowID string) ([]*models.ExecutionContext, error) {
 	args := ecr.Called(ctx, workflowID)
 	if args.Get(0) == nil {
@@ -265,6 +307,69 @@ func (ecr *MockExecutionContextRepository) GetExecutionsByStatus(ctx context.Con
 	return args.Get(0).([]*models.ExecutionContext), args.Error(1)
 }
 
+func (ecr *MockExecutionContextRepository) GetExecutionByApprovalToken(ctx context.Context, token string) (*models.ExecutionContext, error) {
+	args := ecr.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.ExecutionContext), args.Error(1)
+}
+
+func (ecr *MockExecutionContextRepository) GetExecutionsWithExpiredApprovals(ctx context.Context, now time.Time) ([]*models.ExecutionContext, error) {
+	args := ecr.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*models.ExecutionContext), args.Error(1)
+}
+
+func (ecr *MockExecutionContextRepository) ClaimApproval(ctx context.Context, executionID string, request models.ApprovalRequest) (bool, error) {
+	args := ecr.Called(ctx, executionID, request)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (ecr *MockExecutionContextRepository) ClaimRetry(ctx context.Context, executionID string, budget int) (bool, error) {
+	args := ecr.Called(ctx, executionID, budget)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (ecr *MockExecutionContextRepository) AddPendingActivations(ctx context.Context, executionID string, delta int) (int, error) {
+	args := ecr.Called(ctx, executionID, delta)
+
+	return args.Int(0), args.Error(1)
+}
+
+func (ecr *MockExecutionContextRepository) GetExecutionStats(ctx context.Context, workflowID string, from, to time.Time) (*models.ExecutionStats, error) {
+	args := ecr.Called(ctx, workflowID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.ExecutionStats), args.Error(1)
+}
+
+func (ecr *MockExecutionContextRepository) GetLastRunAt(ctx context.Context, workflowID string) (*time.Time, error) {
+	args := ecr.Called(ctx, workflowID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (ecr *MockExecutionContextRepository) ListNodeResults(ctx context.Context, executionID string, query models.NodeResultQuery) (*models.NodeResultPage, error) {
+	args := ecr.Called(ctx, executionID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*models.NodeResultPage), args.Error(1)
+}
+
 type MockInputCoordinationRepository struct{}
 
 func (icr *MockInputCoordinationRepository) SaveInputState(ctx context.Context, state *models.NodeInputState) error {
@@ -286,3 +391,33 @@ func (icr *MockInputCoordinationRepository) DeleteInputState(ctx context.Context
 func (icr *MockInputCoordinationRepository) CleanupExpiredStates(ctx context.Context, maxAge time.Duration) error {
 	return errors.New("mock input coordination repository not implemented during transition")
 }
+
+// MockDebounceRepository has no pending debounces and ignores writes.
+type MockDebounceRepository struct{}
+
+func (dr *MockDebounceRepository) SavePendingDebounce(ctx context.Context, pending *models.PendingDebounce) error {
+	return nil
+}
+
+func (dr *MockDebounceRepository) GetPendingDebounces(ctx context.Context) ([]*models.PendingDebounce, error) {
+	return nil, nil
+}
+
+func (dr *MockDebounceRepository) ClaimPendingDebounce(ctx context.Context, id string, now time.Time, lease time.Duration) (*models.PendingDebounce, bool, error) {
+	return nil, false, nil
+}
+
+func (dr *MockDebounceRepository) DeletePendingDebounce(ctx context.Context, id string, fireAt time.Time) error {
+	return nil
+}
+
+// MockAlertRepository claims every alert.
+type MockAlertRepository struct{}
+
+func (ar *MockAlertRepository) ClaimAlert(ctx context.Context, workflowID string, now time.Time, cooldown time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (ar *MockAlertRepository) ClaimHeartbeatAlert(ctx context.Context, workflowID, triggerNodeID string, expectedRunAt time.Time) (bool, error) {
+	return true, nil
+}
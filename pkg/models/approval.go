@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// ApprovalDataKey is the NodeResult data key holding the ApprovalRequest of a paused node, encoded
+// with EncodeResultData.
+const ApprovalDataKey = "approval"
+
+// ApprovalStatus is the state of a human approval request.
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "pending"
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+	ApprovalStatusExpired  ApprovalStatus = "expired"
+)
+
+// ApprovalRequest is a node waiting for a human decision. The execution is paused until the
+// request is approved, rejected, or expires, and then resumes from the node's decision port.
+type ApprovalRequest struct {
+	Token       string         `json:"token"`
+	NodeID      string         `json:"node_id"`
+	Message     string         `json:"message,omitempty"`
+	Data        map[string]any `json:"data,omitempty"`
+	TimeoutPort string         `json:"timeout_port"`
+	Status      ApprovalStatus `json:"status"`
+	Comment     string         `json:"comment,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
+	DecidedAt   *time.Time     `json:"decided_at,omitempty"`
+}
+
+// IsExpired reports whether the request is still pending past its expiry.
+func (r *ApprovalRequest) IsExpired(now time.Time) bool {
+	return r.Status == ApprovalStatusPending && r.ExpiresAt != nil && !now.Before(*r.ExpiresAt)
+}
+
+// Port returns the output port the execution resumes on for the request's decision:
+// "approved", "rejected", or the timeout port once expired.
+func (r *ApprovalRequest) Port() string {
+	if r.Status == ApprovalStatusExpired {
+		return r.TimeoutPort
+	}
+
+	return string(r.Status)
+}
+
+// PendingApproval returns the pending approval request with the given token, or nil.
+func (c *ExecutionContext) PendingApproval(token string) *ApprovalRequest {
+	for i := range c.Approvals {
+		if c.Approvals[i].Token == token && c.Approvals[i].Status == ApprovalStatusPending {
+			return &c.Approvals[i]
+		}
+	}
+
+	return nil
+}
+
+// HasPendingApprovals reports whether any approval request of the execution awaits a decision.
+func (c *ExecutionContext) HasPendingApprovals() bool {
+	for _, approval := range c.Approvals {
+		if approval.Status == ApprovalStatusPending {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasExpiredApprovals reports whether any approval request of the execution is pending past its expiry.
+func (c *ExecutionContext) HasExpiredApprovals(now time.Time) bool {
+	for i := range c.Approvals {
+		if c.Approvals[i].IsExpired(now) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// PendingDebounce is a trigger activation held back by a debounced trigger node until its
+// source events quiet down. Each new event for the same key replaces the event data and
+// pushes FireAt back by the debounce interval.
+type PendingDebounce struct {
+	// ID identifies the debounce: workflow ID, trigger node ID and key joined by ":".
+	ID            string         `json:"id"`
+	WorkflowID    string         `json:"workflow_id"`
+	TriggerNodeID string         `json:"trigger_node_id"`
+	Key           string         `json:"key"`
+	EventData     map[string]any `json:"event_data"`
+	CorrelationID string         `json:"correlation_id,omitempty"`
+	EventCount    int            `json:"event_count"`
+	FireAt        time.Time      `json:"fire_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	// ClaimedUntil is when the claim of the activator replica activating the debounce lapses.
+	ClaimedUntil *time.Time `json:"claimed_until,omitempty"`
+}
+
+// Claimable reports whether the debounce is due by now and not claimed by an activator replica.
+func (d *PendingDebounce) Claimable(now time.Time) bool {
+	return !now.Before(d.FireAt) && (d.ClaimedUntil == nil || !now.Before(*d.ClaimedUntil))
+}
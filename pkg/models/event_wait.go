@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// EventWaitDataKey is the NodeResult data key holding the EventWait of a paused node, encoded
+// with EncodeResultData.
+const EventWaitDataKey = "event_wait"
+
+const (
+	// EventWaitPortReceived is the port an event wait resumes on when its event arrives.
+	EventWaitPortReceived = "received"
+
+	// EventWaitPortTimeout is the port an event wait resumes on when it expires.
+	EventWaitPortTimeout = "timeout"
+)
+
+// EventWaitStatus is the state of a node waiting for an external event.
+type EventWaitStatus string
+
+const (
+	EventWaitStatusPending  EventWaitStatus = "pending"
+	EventWaitStatusReceived EventWaitStatus = "received"
+	EventWaitStatusExpired  EventWaitStatus = "expired"
+)
+
+// EventWait is a node waiting for a source event. The execution is paused until an event of
+// the provider and type arrives whose EventKey, rendered over the event data as .event_data,
+// equals the CorrelationKey rendered when the node executed, or until the wait expires.
+type EventWait struct {
+	ID             string          `json:"id"`
+	NodeID         string          `json:"node_id"`
+	ProviderID     string          `json:"provider_id"`
+	EventType      string          `json:"event_type"`
+	EventKey       string          `json:"event_key"`
+	CorrelationKey string          `json:"correlation_key"`
+	Data           map[string]any  `json:"data,omitempty"`
+	Status         EventWaitStatus `json:"status"`
+	CreatedAt      time.Time       `json:"created_at"`
+	ExpiresAt      *time.Time      `json:"expires_at,omitempty"`
+	ResolvedAt     *time.Time      `json:"resolved_at,omitempty"`
+}
+
+// IsExpired reports whether the wait is still pending past its expiry.
+func (w *EventWait) IsExpired(now time.Time) bool {
+	return w.Status == EventWaitStatusPending && w.ExpiresAt != nil && !now.Before(*w.ExpiresAt)
+}
+
+// Awaits reports whether the wait is pending on events of the provider and type.
+func (w *EventWait) Awaits(providerID, eventType string) bool {
+	return w.Status == EventWaitStatusPending && w.ProviderID == providerID && w.EventType == eventType
+}
+
+// Port returns the output port the execution resumes on: "received", or "timeout" once expired.
+func (w *EventWait) Port() string {
+	if w.Status == EventWaitStatusExpired {
+		return EventWaitPortTimeout
+	}
+
+	return EventWaitPortReceived
+}
+
+// HasPendingEventWaits reports whether any node of the execution still waits for an event.
+func (c *ExecutionContext) HasPendingEventWaits() bool {
+	for _, wait := range c.EventWaits {
+		if wait.Status == EventWaitStatusPending {
+			return true
+		}
+	}
+
+	return false
+}
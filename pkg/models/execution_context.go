@@ -1,6 +1,12 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"log/slog"
+	"maps"
+	"reflect"
+	"time"
+)
 
 // ExecutionStatus represents the lifecycle state of a workflow execution.
 type ExecutionStatus string
@@ -14,16 +20,312 @@ const (
 	ExecutionStatusPaused    ExecutionStatus = "paused"
 )
 
+// IsTerminal reports whether an execution with the status has ended and will not change anymore.
+func (s ExecutionStatus) IsTerminal() bool {
+	switch s {
+	case ExecutionStatusCompleted, ExecutionStatusFailed, ExecutionStatusCancelled, ExecutionStatusTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// Ran reports whether an execution with the status counts as a run of its workflow, which it does
+// unless it failed, timed out or was cancelled.
+func (s ExecutionStatus) Ran() bool {
+	switch s {
+	case ExecutionStatusFailed, ExecutionStatusCancelled, ExecutionStatusTimeout:
+		return false
+	default:
+		return true
+	}
+}
+
 // ExecutionContext represents the state of a node-based workflow execution.
 type ExecutionContext struct {
-	ID           string                `json:"id"`
-	WorkflowID   string                `json:"workflow_id"             validate:"required"`
-	Status       ExecutionStatus       `json:"status"`
-	NodeResults  map[string]NodeResult `json:"node_results"`
-	TriggerData  map[string]any        `json:"trigger_data,omitempty"`
-	Variables    map[string]any        `json:"variables,omitempty"`
-	Metadata     map[string]any        `json:"metadata,omitempty"`
-	ErrorMessage string                `json:"error_message,omitempty"`
-	CreatedAt    time.Time             `json:"created_at"`
-	CompletedAt  *time.Time            `json:"completed_at,omitempty"`
+	ID              string                `json:"id"`
+	WorkflowID      string                `json:"workflow_id"              validate:"required"` // Workflow version the execution is pinned to
+	CorrelationID   string                `json:"correlation_id,omitempty"`
+	OrderingKey     string                `json:"ordering_key,omitempty"` // Ordering key of the source event behind the execution
+	Status          ExecutionStatus       `json:"status"`
+	NodeResults     map[string]NodeResult `json:"node_results"`
+	TriggerData     map[string]any        `json:"trigger_data,omitempty"`
+	TriggerDataRef  string                `json:"trigger_data_ref,omitempty"` // Object holding TriggerData when it was offloaded
+	Variables       map[string]any        `json:"variables,omitempty"`
+	State           map[string]any        `json:"state,omitempty"`
+	Metadata        map[string]any        `json:"metadata,omitempty"`
+	ErrorMessage    string                `json:"error_message,omitempty"`
+	Approvals       []ApprovalRequest     `json:"approvals,omitempty"`
+	EventWaits      []EventWait           `json:"event_waits,omitempty"`
+	VariableChanges []VariableChange      `json:"variable_changes,omitempty"` // Audit trail of operator changes to Variables
+	CreatedAt       time.Time             `json:"created_at"`
+	CompletedAt     *time.Time            `json:"completed_at,omitempty"`
+
+	// Logger is the node-scoped logger of the node executing with the context, honoring the node's
+	// log level. It is set by the worker for the execution of a node only and never persisted.
+	Logger *slog.Logger `json:"-"`
+
+	// ResolvePayload loads the offloaded trigger data and node results referenced by
+	// TriggerDataRef and NodeResult.PayloadRef for templates. It is set by the worker for the
+	// execution of a node only and never persisted.
+	ResolvePayload func(ref string) (map[string]any, error) `json:"-"`
+
+	// MissingKeys is how templates rendered with the context treat references to missing fields:
+	// "strict" fails them, "lenient" renders them empty and "" renders them as "<no value>". It is
+	// set by the worker for the execution of a node only and never persisted.
+	MissingKeys string `json:"-"`
+
+	// Templates are the named partials of the workflow, which templates rendered with the context
+	// include with {{template "name"}}. It is set by the worker for the execution of a node only
+	// and never persisted.
+	Templates map[string]string `json:"-"`
+}
+
+// VariableChange records an operator's change to the variables of a paused execution.
+type VariableChange struct {
+	Values    map[string]any `json:"values"`
+	Previous  map[string]any `json:"previous,omitempty"` // Replaced values; keys missing here were added
+	ChangedBy string         `json:"changed_by,omitempty"`
+	ChangedAt time.Time      `json:"changed_at"`
+}
+
+// WithVariableOverrides returns a copy of the execution context whose variables are the
+// workflow variables with the given overrides layered on top. Overrides take precedence
+// over workflow variables of the same name; the receiver's variables are not modified.
+func (c ExecutionContext) WithVariableOverrides(overrides map[string]any) ExecutionContext {
+	if len(overrides) == 0 {
+		return c
+	}
+
+	variables := make(map[string]any, len(c.Variables)+len(overrides))
+	maps.Copy(variables, c.Variables)
+	maps.Copy(variables, overrides)
+
+	c.Variables = variables
+
+	return c
+}
+
+// StateUpdateDataKey is the NodeResult data key holding the StateUpdate of a set-variable node,
+// encoded with EncodeResultData.
+const StateUpdateDataKey = "state_update"
+
+// ErrVariablesImmutable is returned when a node changes the workflow variables it executes with.
+var ErrVariablesImmutable = errors.New("workflow variables are read-only at runtime, use a setvariable node to write to state")
+
+// StateUpdate sets a value in the mutable state of an execution.
+type StateUpdate struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+// Isolated returns a copy of the execution context whose variables and state are deep copies,
+// so a node executing with it cannot change those of the execution.
+func (c ExecutionContext) Isolated() ExecutionContext {
+	c.Variables, _ = deepCopy(c.Variables).(map[string]any)
+	c.State, _ = deepCopy(c.State).(map[string]any)
+
+	return c
+}
+
+// CheckVariables returns ErrVariablesImmutable when the variables of isolated, a context returned
+// by Isolated, no longer match those of the receiver.
+func (c ExecutionContext) CheckVariables(isolated ExecutionContext) error {
+	if len(c.Variables) == 0 && len(isolated.Variables) == 0 {
+		return nil
+	}
+
+	if !reflect.DeepEqual(c.Variables, isolated.Variables) {
+		return ErrVariablesImmutable
+	}
+
+	return nil
+}
+
+// SetState sets name in the mutable state of the execution.
+func (c *ExecutionContext) SetState(name string, value any) {
+	if c.State == nil {
+		c.State = make(map[string]any)
+	}
+
+	c.State[name] = value
+}
+
+// deepCopy copies the maps and slices of value, so the copy shares no mutable data with it.
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if v == nil {
+			return v
+		}
+
+		copied := make(map[string]any, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+
+		return copied
+	case []any:
+		if v == nil {
+			return v
+		}
+
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+
+		return copied
+	default:
+		return v
+	}
+}
+
+const (
+	// RepeatOutputPort is the output port a node emits on to run again. When no connection
+	// leaves the port, the worker activates the node itself again with the repeat data.
+	RepeatOutputPort = "repeat"
+
+	// InvalidInputPort is the output port a node with an input schema is routed to, without
+	// executing, when its inputs do not satisfy the schema.
+	InvalidInputPort = "invalid-input"
+
+	// IterationsMetadataKey is the execution metadata key holding, per node ID, how many times
+	// the node repeated. The count is reset once the node completes without repeating.
+	IterationsMetadataKey = "iterations"
+
+	// CompletedNodesMetadataKey is the execution metadata key listing, in completion order, the
+	// result keys of the nodes that completed successfully. Rollbacks compensate them in reverse.
+	CompletedNodesMetadataKey = "completed_nodes"
+
+	// CompensationsMetadataKey is the execution metadata key holding the outcome of each
+	// compensation run by the rollback of the execution, once it rolled back.
+	CompensationsMetadataKey = "compensations"
+
+	// RetriesMetadataKey is the execution metadata key holding how many node retries the
+	// execution used, across all its nodes.
+	RetriesMetadataKey = "retries"
+)
+
+// Compensation outcomes.
+const (
+	CompensationStatusCompensated = "compensated"
+	CompensationStatusFailed      = "failed"
+)
+
+// CompletedNodes returns the result keys of the nodes that completed successfully, in completion order.
+func (c ExecutionContext) CompletedNodes() []string {
+	var keys []string
+
+	switch completed := c.Metadata[CompletedNodesMetadataKey].(type) {
+	case []string:
+		keys = append(keys, completed...)
+	case []any:
+		for _, key := range completed {
+			if key, ok := key.(string); ok {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// MarkCompleted records that the node of resultKey completed successfully with that result. A node
+// completing again, e.g. in a loop, keeps its first position.
+func (c *ExecutionContext) MarkCompleted(resultKey string) {
+	nodeID, _, _ := ParseNodeResultKey(resultKey)
+
+	completed := c.CompletedNodes()
+	for _, key := range completed {
+		if completedID, _, _ := ParseNodeResultKey(key); completedID == nodeID {
+			return
+		}
+	}
+
+	if c.Metadata == nil {
+		c.Metadata = make(map[string]any)
+	}
+
+	c.Metadata[CompletedNodesMetadataKey] = append(completed, resultKey)
+}
+
+// RolledBack reports whether the execution already ran its rollback.
+func (c ExecutionContext) RolledBack() bool {
+	_, rolledBack := c.Metadata[CompensationsMetadataKey]
+
+	return rolledBack
+}
+
+// Iteration returns how many times nodeID repeated in the execution.
+func (c ExecutionContext) Iteration(nodeID string) int {
+	iterations, _ := c.Metadata[IterationsMetadataKey].(map[string]any)
+
+	switch count := iterations[nodeID].(type) {
+	case int:
+		return count
+	case float64:
+		return int(count)
+	default:
+		return 0
+	}
+}
+
+// SetIteration records how many times nodeID repeated in the execution; zero removes the count.
+func (c *ExecutionContext) SetIteration(nodeID string, iteration int) {
+	iterations, _ := c.Metadata[IterationsMetadataKey].(map[string]any)
+
+	if iteration == 0 {
+		if iterations == nil {
+			return
+		}
+
+		delete(iterations, nodeID)
+
+		if len(iterations) == 0 {
+			delete(c.Metadata, IterationsMetadataKey)
+		}
+
+		return
+	}
+
+	if c.Metadata == nil {
+		c.Metadata = make(map[string]any)
+	}
+
+	if iterations == nil {
+		iterations = make(map[string]any)
+		c.Metadata[IterationsMetadataKey] = iterations
+	}
+
+	iterations[nodeID] = iteration
+}
+
+// Retries returns how many node retries the execution used, across all its nodes.
+func (c ExecutionContext) Retries() int {
+	switch count := c.Metadata[RetriesMetadataKey].(type) {
+	case int:
+		return count
+	case float64:
+		return int(count)
+	default:
+		return 0
+	}
+}
+
+// UseRetry takes one retry from the retry budget of the execution, reporting false without
+// taking it once budget retries were used. A budget of 0 or less does not limit retries.
+func (c *ExecutionContext) UseRetry(budget int) bool {
+	retries := c.Retries()
+	if budget > 0 && retries >= budget {
+		return false
+	}
+
+	if c.Metadata == nil {
+		c.Metadata = make(map[string]any)
+	}
+
+	c.Metadata[RetriesMetadataKey] = retries + 1
+
+	return true
 }
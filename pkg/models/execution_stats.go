@@ -0,0 +1,66 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ExecutionStats aggregates the executions of a workflow created within a time window. The
+// duration percentiles only cover executions with a completion time.
+type ExecutionStats struct {
+	WorkflowID        string                  `json:"workflow_id"`
+	From              time.Time               `json:"from"`
+	To                time.Time               `json:"to"`
+	Total             int                     `json:"total"`
+	CountsByStatus    map[ExecutionStatus]int `json:"counts_by_status"`
+	SuccessRate       float64                 `json:"success_rate"`
+	FailureRate       float64                 `json:"failure_rate"`
+	DurationP50Ms     float64                 `json:"duration_p50_ms"`
+	DurationP95Ms     float64                 `json:"duration_p95_ms"`
+	ThroughputPerHour float64                 `json:"throughput_per_hour"`
+}
+
+// NewExecutionStats creates empty statistics for a workflow and time window.
+func NewExecutionStats(workflowID string, from, to time.Time) *ExecutionStats {
+	return &ExecutionStats{
+		WorkflowID:     workflowID,
+		From:           from,
+		To:             to,
+		CountsByStatus: make(map[ExecutionStatus]int),
+	}
+}
+
+// ComputeRates derives the success rate, failure rate and throughput from the status counts.
+func (s *ExecutionStats) ComputeRates() {
+	s.Total = 0
+	for _, count := range s.CountsByStatus {
+		s.Total += count
+	}
+
+	if s.Total > 0 {
+		s.SuccessRate = float64(s.CountsByStatus[ExecutionStatusCompleted]) / float64(s.Total)
+		s.FailureRate = float64(s.CountsByStatus[ExecutionStatusFailed]) / float64(s.Total)
+	}
+
+	if hours := s.To.Sub(s.From).Hours(); hours > 0 {
+		s.ThroughputPerHour = float64(s.Total) / hours
+	}
+}
+
+// Percentile returns the p-th percentile (0 to 1) of values using linear interpolation
+// between the closest ranks, matching PostgreSQL's percentile_cont.
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
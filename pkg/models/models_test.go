@@ -487,3 +487,16 @@ func TestProvider_CommonProviderIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestResultData_RoundTrip(t *testing.T) {
+	data, err := EncodeResultData(Termination{NodeID: "stop", Status: ExecutionStatusFailed, Message: "invalid"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"node_id": "stop", "status": "failed", "message": "invalid"}, data)
+
+	var termination Termination
+	require.True(t, DecodeResultData(map[string]any{TerminationDataKey: data}, TerminationDataKey, &termination))
+	assert.Equal(t, Termination{NodeID: "stop", Status: ExecutionStatusFailed, Message: "invalid"}, termination)
+
+	assert.False(t, DecodeResultData(map[string]any{}, TerminationDataKey, &termination))
+	assert.False(t, DecodeResultData(map[string]any{TerminationDataKey: "stop"}, TerminationDataKey, &termination))
+}
@@ -2,6 +2,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
@@ -18,6 +19,10 @@ const (
 	NodeTypeTriggerWebhook   = "trigger:webhook"
 	NodeTypeTriggerScheduler = "trigger:scheduler"
 	NodeTypeTriggerKafka     = "trigger:kafka"
+	NodeTypeTriggerHTTPPoll  = "trigger:httppoll"
+	NodeTypeTriggerSlack     = "trigger:slack"
+	NodeTypeTriggerGCPPubSub = "trigger:gcppubsub"
+	NodeTypeTriggerSNS       = "trigger:sns"
 )
 
 // Connection connects two ports directly (fully normalized).
@@ -25,6 +30,10 @@ type Connection struct {
 	ID         string `json:"id"`
 	SourcePort string `json:"source_port" validate:"required"` // References Port.ID: "{node_id}:{port_name}"
 	TargetPort string `json:"target_port" validate:"required"` // References Port.ID: "{node_id}:{port_name}"
+
+	// Transform is an optional template that reshapes the data flowing along the connection
+	// before it reaches the target node. The source output is available as .data.
+	Transform string `json:"transform,omitempty"`
 }
 
 // WorkflowNode represents a node instance in a workflow.
@@ -40,6 +49,50 @@ type WorkflowNode struct {
 	SourceID   *string        `json:"source_id,omitempty"`   // For trigger nodes only
 	ProviderID *string        `json:"provider_id,omitempty"` // For trigger nodes only
 	EventType  *string        `json:"event_type,omitempty"`  // For trigger nodes only
+
+	// VariableOverrides replaces workflow variables of the same name for this node only.
+	VariableOverrides map[string]any `json:"variable_overrides,omitempty"`
+
+	// OutputTemplate reshapes the data of the node's success result; the raw data is available as .result.
+	OutputTemplate string `json:"output_template,omitempty"`
+
+	// LogLevel overrides the worker log level while this node executes: debug, info, warn or error.
+	LogLevel string `json:"log_level,omitempty" validate:"omitempty,oneof=debug info warn error"`
+
+	// Compensation undoes the side effects of the node when a later node fails in a workflow that
+	// rolls back on failure.
+	Compensation *Compensation `json:"compensation,omitempty"`
+
+	// Retry re-executes the node when it fails, within the retry budget of its workflow.
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// InputSchema is a JSON schema the inputs of the node, keyed by input port, must satisfy for
+	// the node to execute. Inputs failing it are routed to InvalidInputPort instead.
+	InputSchema map[string]any `json:"input_schema,omitempty"`
+
+	// Join makes a node waiting for all of its inputs also wait for every upstream node connected
+	// to it that can fire in the same execution, collecting their outputs per input port.
+	Join bool `json:"join,omitempty"`
+}
+
+// RetryPolicy is how often the worker re-executes a failed node before routing the failure:
+// up to MaxRetries times, waiting Delay (a duration such as "500ms") before each retry.
+type RetryPolicy struct {
+	MaxRetries int    `json:"max_retries"     validate:"min=0"`
+	Delay      string `json:"delay,omitempty"`
+}
+
+// Compensation is the action run to undo the side effects of a completed node: a node of Type
+// created with Config, whose main input is the result the compensated node completed with.
+type Compensation struct {
+	Type   string         `json:"type"             validate:"required"`
+	Config map[string]any `json:"config,omitempty"`
+}
+
+// NodePosition is the editor position of a node, as stored in PositionX and PositionY.
+type NodePosition struct {
+	X int `json:"x"`
+	Y int `json:"y"`
 }
 
 // Helper methods for category checking.
@@ -53,11 +106,69 @@ func (n *WorkflowNode) IsTriggerNode() bool {
 
 // NodeResult represents the result of a node execution.
 type NodeResult struct {
-	NodeID    string         `json:"node_id"`
-	Data      map[string]any `json:"data"`
-	Status    string         `json:"status"`
-	Timestamp time.Time      `json:"timestamp"`
-	Error     string         `json:"error,omitempty"`
+	NodeID     string         `json:"node_id"`
+	Data       map[string]any `json:"data"`
+	PayloadRef string         `json:"payload_ref,omitempty"` // Object holding Data when it was offloaded
+	Status     string         `json:"status"`
+	Timestamp  time.Time      `json:"timestamp"`
+	Error      string         `json:"error,omitempty"`
+
+	// NotBefore delays the activations of the nodes connected to the port of the result: the
+	// worker reschedules them for then instead of the node waiting in the meantime.
+	NotBefore *time.Time `json:"not_before,omitempty"`
+}
+
+// nodeResultKeySeparator separates the node ID from the port name in ExecutionContext.NodeResults keys.
+const nodeResultKeySeparator = "::"
+
+// nodeResultKeyEscape escapes a colon or itself in the node ID and port name of a NodeResults key.
+const nodeResultKeyEscape = '\\'
+
+// nodeResultKeyEscaper escapes the node ID and port name of a NodeResults key, so neither can
+// contain the separator. IDs and names without a colon or backslash are left unchanged.
+var nodeResultKeyEscaper = strings.NewReplacer(`\`, `\\`, ":", `\:`)
+
+// MakeNodeResultKey creates the ExecutionContext.NodeResults key for a node output port, in
+// format "{node_id}::{port_name}" with colons and backslashes of either part escaped by a backslash.
+func MakeNodeResultKey(nodeID, portName string) string {
+	return nodeResultKeyEscaper.Replace(nodeID) + nodeResultKeySeparator + nodeResultKeyEscaper.Replace(portName)
+}
+
+// ParseNodeResultKey parses a NodeResults key made by MakeNodeResultKey into its node ID and
+// port name. It reports false when the key has no unescaped separator or ends in an escape.
+func ParseNodeResultKey(key string) (string, string, bool) {
+	var (
+		nodeID strings.Builder
+		part   strings.Builder
+	)
+
+	separated := false
+
+	for i := 0; i < len(key); i++ {
+		switch {
+		case key[i] == nodeResultKeyEscape:
+			if i+1 == len(key) {
+				return "", "", false
+			}
+
+			i++
+			part.WriteByte(key[i])
+		case !separated && strings.HasPrefix(key[i:], nodeResultKeySeparator):
+			nodeID.WriteString(part.String())
+			part.Reset()
+
+			separated = true
+			i += len(nodeResultKeySeparator) - 1
+		default:
+			part.WriteByte(key[i])
+		}
+	}
+
+	if !separated {
+		return "", "", false
+	}
+
+	return nodeID.String(), part.String(), true
 }
 
 // NodeStatus defines the possible states of a node execution.
@@ -68,4 +179,7 @@ const (
 	NodeStatusRunning NodeStatus = "running"
 	NodeStatusSuccess NodeStatus = "success"
 	NodeStatusError   NodeStatus = "error"
+
+	// NodeStatusPaused marks a node result that pauses the execution until it is resumed.
+	NodeStatusPaused NodeStatus = "paused"
 )
@@ -1,7 +1,10 @@
 // Package models provides core domain models for node input coordination.
 package models
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // NodeInputRequirements interface allows nodes to declare their input coordination needs.
 // This is optional - nodes that don't implement this use protocol.GetDefaultInputRequirements().
@@ -15,6 +18,15 @@ type InputRequirements struct {
 	OptionalPorts []string       `json:"optional_ports"` // May receive inputs on these ports
 	WaitMode      InputWaitMode  `json:"wait_mode"`      // How to handle multiple inputs
 	Timeout       *time.Duration `json:"timeout"`        // Optional timeout for input collection
+
+	// Sources lists, per input port, the upstream nodes connected to it. Derived from the workflow
+	// graph for join nodes, it makes WaitModeAll wait for every one of them rather than for the
+	// first input.
+	Sources map[string][]string `json:"sources,omitempty"`
+
+	// Exclusive lists, per upstream node of Sources, the upstream nodes behind another branch of
+	// the same conditional. Once one of them has sent an input, the others are no longer waited for.
+	Exclusive map[string][]string `json:"exclusive,omitempty"`
 }
 
 // InputWaitMode defines different strategies for waiting for inputs.
@@ -39,6 +51,71 @@ func DefaultInputRequirements() InputRequirements {
 	}
 }
 
+// SatisfiedBy reports whether the received inputs, keyed by port name, are
+// sufficient to execute a node with these requirements.
+func (r InputRequirements) SatisfiedBy(received map[string]NodeResult) bool {
+	switch r.WaitMode {
+	case WaitModeAll:
+		// All required ports must have inputs
+		for _, requiredPort := range r.RequiredPorts {
+			if _, hasInput := received[requiredPort]; !hasInput {
+				return false
+			}
+		}
+
+		return true
+
+	case WaitModeAny:
+		// At least one required port must have input
+		for _, requiredPort := range r.RequiredPorts {
+			if _, hasInput := received[requiredPort]; hasInput {
+				return true
+			}
+		}
+
+		return false
+
+	case WaitModeFirst:
+		// Ready if we have any input at all
+		return len(received) > 0
+
+	default:
+		// Unknown wait mode, default to any
+		return len(received) > 0
+	}
+}
+
+// SourcesSatisfiedBy reports whether every upstream node of Sources has sent an input, given the
+// source nodes received per port. Upstream nodes exclusive with one that has sent an input never
+// fire and are not waited for. It only applies to WaitModeAll; other modes are always satisfied.
+func (r InputRequirements) SourcesSatisfiedBy(receivedFrom map[string][]string) bool {
+	if r.WaitMode != WaitModeAll {
+		return true
+	}
+
+	received := func(source string) bool {
+		for _, sources := range receivedFrom {
+			if slices.Contains(sources, source) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for port, sources := range r.Sources {
+		for _, source := range sources {
+			if slices.Contains(receivedFrom[port], source) || slices.ContainsFunc(r.Exclusive[source], received) {
+				continue
+			}
+
+			return false
+		}
+	}
+
+	return true
+}
+
 // NodeInputState tracks the input collection state for a specific node execution.
 // This supports loops by having separate state for each node execution instance.
 type NodeInputState struct {
@@ -46,6 +123,7 @@ type NodeInputState struct {
 	ExecutionID     string                `json:"execution_id"`      // Workflow execution ID
 	NodeExecutionID string                `json:"node_execution_id"` // Individual node execution ID (for loops)
 	ReceivedInputs  map[string]NodeResult `json:"received_inputs"`   // Inputs collected so far
+	ReceivedFrom    map[string][]string   `json:"received_from"`     // Source nodes of the inputs, per port
 	Requirements    InputRequirements     `json:"requirements"`      // Node's input requirements
 	CreatedAt       time.Time             `json:"created_at"`        // When input collection started
 	LastUpdatedAt   time.Time             `json:"last_updated_at"`   // When last input was added
@@ -298,3 +298,35 @@ func TestNodeResult_WithError(t *testing.T) {
 	assert.Equal(t, original.Error, deserialized.Error)
 	assert.WithinDuration(t, original.Timestamp, deserialized.Timestamp, time.Second)
 }
+
+func TestNodeResultKey_RoundTrip(t *testing.T) {
+	cases := []struct{ nodeID, port string }{
+		{"fetch", "success"},
+		{"ns::fetch", "success"},
+		{"fetch", "http::200"},
+		{"a:b", ":c:"},
+		{`C:\temp\`, `\:`},
+		{"::", "::"},
+		{"", ""},
+		{"node|1/2 #3", "port.with-dots"},
+	}
+
+	for _, c := range cases {
+		key := MakeNodeResultKey(c.nodeID, c.port)
+
+		nodeID, port, ok := ParseNodeResultKey(key)
+		require.True(t, ok, "key %q", key)
+		assert.Equal(t, c.nodeID, nodeID, "key %q", key)
+		assert.Equal(t, c.port, port, "key %q", key)
+	}
+
+	assert.Equal(t, "fetch::success", MakeNodeResultKey("fetch", "success"), "plain IDs keep the documented format")
+	assert.NotEqual(t, MakeNodeResultKey("a::b", "c"), MakeNodeResultKey("a", "b::c"))
+}
+
+func TestParseNodeResultKey_Invalid(t *testing.T) {
+	for _, key := range []string{"fetch", `fetch\::success`, `fetch::success\`} {
+		_, _, ok := ParseNodeResultKey(key)
+		assert.False(t, ok, "key %q", key)
+	}
+}
@@ -0,0 +1,150 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// ListOptions controls pagination of list queries.
+// Cursor takes precedence over Offset; a Limit of zero returns all remaining items.
+type ListOptions struct {
+	Limit  int    `json:"limit,omitempty"`
+	Offset int    `json:"offset,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+	// IncludeDeleted lists soft-deleted workflows along with active ones.
+	IncludeDeleted bool `json:"include_deleted,omitempty"`
+}
+
+// WorkflowPage is a page of workflows ordered by creation time, newest first.
+type WorkflowPage struct {
+	Workflows  []*Workflow `json:"workflows"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// Cursor identifies the last item of a page by its position in the (created_at, id) ordering.
+type Cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+}
+
+// EncodeCursor returns the opaque representation of a cursor.
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses an opaque cursor created by EncodeCursor.
+func DecodeCursor(encoded string) (Cursor, error) {
+	var cursor Cursor
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return cursor, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// Includes reports whether an item sorts after the cursor in newest-first order,
+// that is, whether it belongs to the pages following the cursor.
+func (c Cursor) Includes(createdAt time.Time, id string) bool {
+	if createdAt.Equal(c.CreatedAt) {
+		return id < c.ID
+	}
+
+	return createdAt.Before(c.CreatedAt)
+}
+
+// NodeResultQuery filters and pages the node results of an execution, ordered by their
+// NodeResults key. A Limit of zero returns all remaining results.
+type NodeResultQuery struct {
+	// Status keeps only the results with this status, such as NodeStatusError, empty for all.
+	Status string `json:"status,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// NodeResultEntry is a node result with the port it was produced on and its NodeResults key.
+type NodeResultEntry struct {
+	Key  string `json:"key"`
+	Port string `json:"port"`
+	NodeResult
+}
+
+// NodeResultPage is a page of node results ordered by key.
+type NodeResultPage struct {
+	Results    []NodeResultEntry `json:"results"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// NewNodeResultEntry returns the entry of the node result stored under key.
+func NewNodeResultEntry(key string, result NodeResult) NodeResultEntry {
+	_, port, _ := ParseNodeResultKey(key)
+
+	return NodeResultEntry{Key: key, Port: port, NodeResult: result}
+}
+
+// EncodeNodeResultCursor returns the opaque cursor of a page ending with the result stored under key.
+func EncodeNodeResultCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// DecodeNodeResultCursor returns the key of the last result before the page a cursor created by
+// EncodeNodeResultCursor points to.
+func DecodeNodeResultCursor(encoded string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(key) == 0 {
+		return "", ErrInvalidCursor
+	}
+
+	return string(key), nil
+}
+
+// PageNodeResults returns the page of results selected by query, for stores holding the node
+// results of an execution in memory.
+func PageNodeResults(results map[string]NodeResult, query NodeResultQuery) (*NodeResultPage, error) {
+	var after string
+
+	if query.Cursor != "" {
+		key, err := DecodeNodeResultCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		after = key
+	}
+
+	keys := make([]string, 0, len(results))
+
+	for key, result := range results {
+		if (query.Status == "" || result.Status == query.Status) && key > after {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	page := &NodeResultPage{Results: make([]NodeResultEntry, 0, len(keys))}
+
+	if query.Limit > 0 && len(keys) > query.Limit {
+		keys = keys[:query.Limit]
+		page.NextCursor = EncodeNodeResultCursor(keys[len(keys)-1])
+	}
+
+	for _, key := range keys {
+		page.Results = append(page.Results, NewNodeResultEntry(key, results[key]))
+	}
+
+	return page, nil
+}
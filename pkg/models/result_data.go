@@ -0,0 +1,39 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EncodeResultData encodes value, such as the Termination a node requests, as the JSON object it
+// is stored as in NodeResult data, so that the data holds the same plain maps and values before
+// and after it was persisted.
+func EncodeResultData(value any) (map[string]any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result data: %w", err)
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return nil, fmt.Errorf("failed to encode result data: %w", err)
+	}
+
+	return data, nil
+}
+
+// DecodeResultData decodes the object under key in NodeResult data into value, reporting whether
+// data holds one that decodes.
+func DecodeResultData(data map[string]any, key string, value any) bool {
+	object, ok := data[key].(map[string]any)
+	if !ok {
+		return false
+	}
+
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(encoded, value) == nil
+}
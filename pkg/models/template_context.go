@@ -0,0 +1,18 @@
+package models
+
+// TemplateContext lists the template context paths available to the config of a workflow node,
+// inferred from the schemas of its upstream nodes and the workflow variables.
+type TemplateContext struct {
+	WorkflowID string                `json:"workflow_id"`
+	NodeID     string                `json:"node_id"`
+	Paths      []TemplateContextPath `json:"paths"`
+}
+
+// TemplateContextPath is a path of the template context, with the template expression reading it.
+type TemplateContextPath struct {
+	Path        string `json:"path"`                  // Dotted path, e.g. trigger_data.body.id
+	Expression  string `json:"expression"`            // Template reading the path, e.g. {{.trigger_data.body.id}}
+	Type        string `json:"type,omitempty"`        // JSON schema type of the value, when known
+	Description string `json:"description,omitempty"` // Description from the schema
+	NodeID      string `json:"node_id,omitempty"`     // Upstream node producing the value
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+const (
+	// TerminationDataKey is the NodeResult data key holding the Termination requested by a node,
+	// encoded with EncodeResultData.
+	TerminationDataKey = "termination"
+
+	// TerminationMetadataKey is the execution metadata key holding the Termination that ended the
+	// execution, once a node terminated it.
+	TerminationMetadataKey = "termination"
+)
+
+// Termination is a node ending its execution on purpose with Status and an optional Message,
+// whatever the connections leaving the node.
+type Termination struct {
+	NodeID  string          `json:"node_id"`
+	Status  ExecutionStatus `json:"status"`
+	Message string          `json:"message,omitempty"`
+}
+
+// IsTerminationStatus reports whether a node can end an execution with the status: completed,
+// failed or cancelled.
+func IsTerminationStatus(status ExecutionStatus) bool {
+	switch status {
+	case ExecutionStatusCompleted, ExecutionStatusFailed, ExecutionStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Terminate ends the execution with the termination's status at now, recording the termination in
+// the execution metadata. The message of a failed or cancelled termination is also the error message.
+func (c *ExecutionContext) Terminate(termination Termination, now time.Time) {
+	if c.Metadata == nil {
+		c.Metadata = make(map[string]any)
+	}
+
+	c.Metadata[TerminationMetadataKey] = termination
+	c.Status = termination.Status
+	c.CompletedAt = &now
+
+	if termination.Status != ExecutionStatusCompleted {
+		c.ErrorMessage = termination.Message
+	}
+}
+
+// Terminated reports whether a node terminated the execution.
+func (c ExecutionContext) Terminated() bool {
+	_, terminated := c.Metadata[TerminationMetadataKey]
+
+	return terminated
+}
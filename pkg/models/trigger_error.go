@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// TriggerError describes a trigger node that its provider failed to configure.
+type TriggerError struct {
+	TriggerID  string    `json:"trigger_id"`
+	ProviderID string    `json:"provider_id"`
+	Message    string    `json:"message"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ReplaceTriggerErrors replaces the trigger errors reported by a provider with errs,
+// keeping the errors reported by other providers. It reports whether the stored errors changed.
+func (w *Workflow) ReplaceTriggerErrors(providerID string, errs []TriggerError) bool {
+	kept := make([]TriggerError, 0, len(w.TriggerErrors)+len(errs))
+	removed := 0
+
+	for _, existing := range w.TriggerErrors {
+		if existing.ProviderID == providerID {
+			removed++
+
+			continue
+		}
+
+		kept = append(kept, existing)
+	}
+
+	if removed == 0 && len(errs) == 0 {
+		return false
+	}
+
+	kept = append(kept, errs...)
+
+	if len(kept) == 0 {
+		w.TriggerErrors = nil
+	} else {
+		w.TriggerErrors = kept
+	}
+
+	return true
+}
@@ -12,20 +12,74 @@ const (
 	WorkflowStatusUnpublished WorkflowStatus = "unpublished" // Historical, not executable
 )
 
+// TriggerMode defines how the trigger nodes of a workflow share executions.
+type TriggerMode string
+
+const (
+	// TriggerModeIndependent starts a new execution for every trigger activation.
+	TriggerModeIndependent TriggerMode = "independent"
+	// TriggerModeSharedLatest activates the triggers within the running execution of the workflow,
+	// if any, replacing its trigger data with the latest event.
+	TriggerModeSharedLatest TriggerMode = "shared-latest"
+)
+
+// WorkflowGroup is a logical workflow: the versions sharing a WorkflowGroupID.
+type WorkflowGroup struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`       // Name of the current version
+	Published *Workflow `json:"published"`  // Executable version, if any
+	Draft     *Workflow `json:"draft"`      // Version being edited, if any
+	UpdatedAt time.Time `json:"updated_at"` // Last update of any version
+}
+
+// WorkflowGroupDetail is a workflow group with its previously published versions.
+type WorkflowGroupDetail struct {
+	WorkflowGroup
+
+	History []*Workflow `json:"history"` // Unpublished versions, newest first
+}
+
 // Workflow represents a node-based workflow with simplified versioning support.
 type Workflow struct {
-	ID              string          `json:"id"`
-	Name            string          `json:"name"                   validate:"required,min=3"`
-	Description     string          `json:"description"            validate:"required"`
-	Status          WorkflowStatus  `json:"status"                 validate:"required"`
-	WorkflowGroupID string          `json:"workflow_group_id"` // Stable ID linking all versions
-	Nodes           []*WorkflowNode `json:"nodes"`             // Node instances in the workflow
-	Connections     []*Connection   `json:"connections"`       // Connections between nodes
-	Variables       map[string]any  `json:"variables"`
-	Metadata        map[string]any  `json:"metadata,omitempty"`
-	Owner           string          `json:"owner"`
-	CreatedAt       time.Time       `json:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at"`
-	PublishedAt     *time.Time      `json:"published_at,omitempty"`
-	DeletedAt       *time.Time      `json:"deleted_at,omitempty"`
+	ID                 string            `json:"id"`
+	Name               string            `json:"name"                   validate:"required,min=3"`
+	Description        string            `json:"description"            validate:"required"`
+	Status             WorkflowStatus    `json:"status"                 validate:"required"`
+	WorkflowGroupID    string            `json:"workflow_group_id"` // Stable ID linking all versions
+	Nodes              []*WorkflowNode   `json:"nodes"`             // Node instances in the workflow
+	Connections        []*Connection     `json:"connections"`       // Connections between nodes
+	Variables          map[string]any    `json:"variables"`
+	Metadata           map[string]any    `json:"metadata,omitempty"`
+	Owner              string            `json:"owner"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+	PublishedAt        *time.Time        `json:"published_at,omitempty"`
+	DeletedAt          *time.Time        `json:"deleted_at,omitempty"`
+	TriggerErrors      []TriggerError    `json:"trigger_errors,omitempty"`        // Triggers their provider failed to configure
+	ErrorHandlerNodeID string            `json:"error_handler_node_id,omitempty"` // Catch-all node receiving unhandled node failures
+	RollbackOnFailure  bool              `json:"rollback_on_failure,omitempty"`   // Compensate completed nodes on unhandled node failures
+	RetryBudget        int               `json:"retry_budget,omitempty"`          // Max node retries across an execution, 0 for no limit
+	Templates          map[string]string `json:"templates,omitempty"`             // Named partials node configs include with {{template "name"}}
+	TriggerMode        TriggerMode       `json:"trigger_mode,omitempty"`          // How trigger activations share executions, independent when empty
+}
+
+// SharesExecutions reports whether trigger activations of the workflow join its running execution.
+func (w *Workflow) SharesExecutions() bool {
+	return w.TriggerMode == TriggerModeSharedLatest
+}
+
+// PriorityMetadataKey is the workflow metadata key holding the priority of its executions.
+// Higher values are processed first; workflows without it have priority 0.
+const PriorityMetadataKey = "priority"
+
+// Priority returns the priority set in the workflow metadata, or 0 when unset.
+func (w *Workflow) Priority() int {
+	switch priority := w.Metadata[PriorityMetadataKey].(type) {
+	case int:
+		return priority
+	case float64:
+		return int(priority)
+	default:
+		return 0
+	}
 }
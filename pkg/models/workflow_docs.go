@@ -0,0 +1,44 @@
+package models
+
+// WorkflowDocs describes the trigger data a workflow expects and what each of its nodes produces,
+// derived from the schemas of the registered node types and the workflow's connections.
+type WorkflowDocs struct {
+	WorkflowID  string       `json:"workflow_id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Triggers    []TriggerDoc `json:"triggers"`
+	Nodes       []NodeDoc    `json:"nodes"`
+}
+
+// TriggerDoc describes a trigger node: the event it starts the workflow on and the trigger data
+// it receives with that event.
+type TriggerDoc struct {
+	NodeID      string         `json:"node_id"`
+	Name        string         `json:"name"`
+	Type        string         `json:"type"`
+	ProviderID  string         `json:"provider_id,omitempty"`
+	EventType   string         `json:"event_type,omitempty"`
+	TriggerData map[string]any `json:"trigger_data,omitempty"` // Schema of the event data
+	Outputs     []PortDoc      `json:"outputs"`
+}
+
+// NodeDoc describes an action node: its configuration schema and the data its ports receive and
+// produce.
+type NodeDoc struct {
+	NodeID       string         `json:"node_id"`
+	Name         string         `json:"name"`
+	Type         string         `json:"type"`
+	Description  string         `json:"description,omitempty"`
+	ConfigSchema map[string]any `json:"config_schema,omitempty"`
+	Inputs       []PortDoc      `json:"inputs"`
+	Outputs      []PortDoc      `json:"outputs"`
+	Error        string         `json:"error,omitempty"` // Why the ports could not be described
+}
+
+// PortDoc describes a node port and the ports of other nodes connected to it.
+type PortDoc struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Schema      map[string]any `json:"schema,omitempty"`
+	Connections []string       `json:"connections,omitempty"`
+}
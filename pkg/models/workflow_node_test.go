@@ -629,3 +629,41 @@ func TestExecutionContext_CompleteLifecycle_JSONSerialization(t *testing.T) {
 	assert.Equal(t, original.Metadata["environment"], deserialized.Metadata["environment"])
 	assert.Equal(t, original.Metadata["region"], deserialized.Metadata["region"])
 }
+
+func TestExecutionContext_WithVariableOverrides(t *testing.T) {
+	execCtx := ExecutionContext{
+		ID:        "exec-1",
+		Variables: map[string]any{"timeout": 30, "region": "us-east-1"},
+	}
+
+	scoped := execCtx.WithVariableOverrides(map[string]any{"timeout": 120})
+
+	assert.Equal(t, 120, scoped.Variables["timeout"], "node override takes precedence")
+	assert.Equal(t, "us-east-1", scoped.Variables["region"], "other workflow variables are kept")
+	assert.Equal(t, 30, execCtx.Variables["timeout"], "shared variables are not mutated")
+	assert.Equal(t, "exec-1", scoped.ID)
+
+	unchanged := execCtx.WithVariableOverrides(nil)
+	assert.Equal(t, execCtx.Variables, unchanged.Variables)
+}
+
+func TestExecutionContext_Isolated(t *testing.T) {
+	execCtx := ExecutionContext{
+		ID:        "exec-1",
+		Variables: map[string]any{"api_base_url": "https://api.example.com", "regions": []any{"us-east-1"}},
+		State:     map[string]any{"counter": float64(1)},
+	}
+
+	isolated := execCtx.Isolated()
+	require.NoError(t, execCtx.CheckVariables(isolated))
+
+	isolated.State["counter"] = float64(2)
+	assert.InDelta(t, 1, execCtx.State["counter"], 0, "state is copied")
+
+	isolated.Variables["regions"].([]any)[0] = "eu-west-1"
+	assert.Equal(t, []any{"us-east-1"}, execCtx.Variables["regions"], "nested values are copied")
+	require.ErrorIs(t, execCtx.CheckVariables(isolated), ErrVariablesImmutable)
+
+	execCtx.SetState("last_order_id", "42")
+	assert.Equal(t, "42", execCtx.State["last_order_id"])
+}
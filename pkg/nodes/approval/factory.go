@@ -0,0 +1,70 @@
+// Package approval provides approval node factory for registry integration.
+package approval
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// ApprovalNodeFactory creates ApprovalNode instances.
+type ApprovalNodeFactory struct{}
+
+// Create creates a new ApprovalNode instance.
+func (f *ApprovalNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewApprovalNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *ApprovalNodeFactory) ID() string {
+	return "approval"
+}
+
+// Name returns the factory name.
+func (f *ApprovalNodeFactory) Name() string {
+	return "Approval"
+}
+
+// Description returns the factory description.
+func (f *ApprovalNodeFactory) Description() string {
+	return "Pauses the execution until a human approves or rejects it through POST /approvals/:token"
+}
+
+// Schema returns the JSON schema for Approval node configuration.
+func (f *ApprovalNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"message": map[string]any{
+				"type":        "string",
+				"description": "Message shown to the approver. Supports templating.",
+				"examples": []string{
+					"Refund of {{.trigger_data.amount}} for order {{.trigger_data.order_id}}",
+				},
+			},
+			"timeout": map[string]any{
+				"type":        "string",
+				"description": "How long the request waits for a decision. No expiry when empty.",
+				"examples":    []string{"1h", "72h"},
+			},
+			"on_timeout": map[string]any{
+				"type":        "string",
+				"description": "Port the execution resumes on when the request expires",
+				"enum":        []string{OutputPortTimeout, OutputPortRejected},
+				"default":     OutputPortTimeout,
+			},
+		},
+		"examples": []map[string]any{
+			{
+				"message":    "Approve deployment of {{.trigger_data.version}}?",
+				"timeout":    "24h",
+				"on_timeout": OutputPortRejected,
+			},
+		},
+	}
+}
+
+// NewApprovalNodeFactory creates a new factory instance.
+func NewApprovalNodeFactory() protocol.NodeFactory {
+	return &ApprovalNodeFactory{}
+}
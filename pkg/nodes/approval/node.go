@@ -0,0 +1,275 @@
+// Package approval provides a node that pauses an execution until a human approves or rejects it.
+package approval
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortApproved = "approved"
+	OutputPortRejected = "rejected"
+	OutputPortTimeout  = "timeout"
+	OutputPortError    = "error"
+	InputPortMain      = "main"
+
+	// OutputPortPending carries the approval request that pauses the execution.
+	// It is consumed by the worker and never activates other nodes.
+	OutputPortPending = "pending"
+)
+
+// ApprovalNode implements the Node interface for human approval steps.
+type ApprovalNode struct {
+	id        string
+	message   string
+	timeout   time.Duration
+	onTimeout string
+	now       func() time.Time
+}
+
+// NewApprovalNode creates a new approval node.
+func NewApprovalNode(id string, config map[string]any) (*ApprovalNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	message, _ := config["message"].(string)
+
+	var timeout time.Duration
+	if raw, ok := config["timeout"].(string); ok && raw != "" {
+		timeout, _ = time.ParseDuration(raw)
+	}
+
+	onTimeout, _ := config["on_timeout"].(string)
+	if onTimeout == "" {
+		onTimeout = OutputPortTimeout
+	}
+
+	return &ApprovalNode{
+		id:        id,
+		message:   message,
+		timeout:   timeout,
+		onTimeout: onTimeout,
+		now:       time.Now,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *ApprovalNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *ApprovalNode) Type() string {
+	return "approval"
+}
+
+// Execute creates an approval request with a unique token and pauses the execution.
+// The execution resumes on the approved, rejected, or timeout port once the request is decided.
+func (n *ApprovalNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	data := make(map[string]any)
+	if input, ok := inputs[InputPortMain]; ok {
+		maps.Copy(data, input.Data)
+	}
+
+	message := ""
+	if n.message != "" {
+		rendered, err := template.RenderWithContext(n.message, &ctx)
+		if err != nil {
+			return n.createErrorResult(fmt.Sprintf("failed to render message template: %v", err)), nil
+		}
+
+		message = fmt.Sprintf("%v", rendered)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to generate approval token: %v", err)), nil
+	}
+
+	now := n.now().UTC()
+
+	request := models.ApprovalRequest{
+		Token:       token,
+		NodeID:      n.id,
+		Message:     message,
+		Data:        data,
+		TimeoutPort: n.onTimeout,
+		Status:      models.ApprovalStatusPending,
+		CreatedAt:   now,
+	}
+
+	if n.timeout > 0 {
+		expiresAt := now.Add(n.timeout)
+		request.ExpiresAt = &expiresAt
+	}
+
+	encoded, err := models.EncodeResultData(request)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortPending: {
+			NodeID: n.id,
+			Data: map[string]any{
+				models.ApprovalDataKey: encoded,
+			},
+			Status: string(models.NodeStatusPaused),
+		},
+	}, nil
+}
+
+// generateToken returns a random, URL-safe approval token.
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *ApprovalNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *ApprovalNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Data awaiting approval",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *ApprovalNode) OutputPorts() []models.OutputPort {
+	decisionSchema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"approval": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"decision":   map[string]any{"type": "string"},
+					"comment":    map[string]any{"type": "string"},
+					"decided_at": map[string]any{"type": "string"},
+				},
+			},
+		},
+	}
+
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortApproved),
+				NodeID:      n.id,
+				Name:        OutputPortApproved,
+				Description: "Input data with the decision when the request is approved",
+				Schema:      decisionSchema,
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortRejected),
+				NodeID:      n.id,
+				Name:        OutputPortRejected,
+				Description: "Input data with the decision when the request is rejected",
+				Schema:      decisionSchema,
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortTimeout),
+				NodeID:      n.id,
+				Name:        OutputPortTimeout,
+				Description: "Input data when the request expires without a decision",
+				Schema:      decisionSchema,
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the request cannot be created",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the approval node.
+func (n *ApprovalNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *ApprovalNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the approval fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	if message, exists := config["message"]; exists {
+		if _, ok := message.(string); !ok {
+			return errors.New("field 'message' must be a string")
+		}
+	}
+
+	if raw, exists := config["timeout"]; exists {
+		s, ok := raw.(string)
+		if !ok {
+			return errors.New("field 'timeout' must be a duration string")
+		}
+
+		if s != "" {
+			timeout, err := time.ParseDuration(s)
+			if err != nil || timeout <= 0 {
+				return fmt.Errorf("invalid timeout '%s'", s)
+			}
+		}
+	}
+
+	if raw, exists := config["on_timeout"]; exists {
+		onTimeout, ok := raw.(string)
+		if !ok || (onTimeout != "" && onTimeout != OutputPortTimeout && onTimeout != OutputPortRejected) {
+			return fmt.Errorf("field 'on_timeout' must be '%s' or '%s'", OutputPortTimeout, OutputPortRejected)
+		}
+	}
+
+	return nil
+}
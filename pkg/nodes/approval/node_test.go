@@ -0,0 +1,146 @@
+package approval
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+func TestNewApprovalNode(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{"message": 42},
+		{"timeout": "soon"},
+		{"timeout": "-1h"},
+		{"on_timeout": "approved"},
+	}
+
+	for _, config := range invalidConfigs {
+		if _, err := NewApprovalNode("approve", config); err == nil {
+			t.Errorf("Expected error for config %v", config)
+		}
+	}
+
+	node, err := NewApprovalNode("approve", map[string]any{})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if node.onTimeout != OutputPortTimeout {
+		t.Errorf("Expected default on_timeout %s, got: %s", OutputPortTimeout, node.onTimeout)
+	}
+}
+
+func TestApprovalNode_Execute_PausesWithRequest(t *testing.T) {
+	node, err := NewApprovalNode("approve", map[string]any{
+		"message":    "Refund order {{.trigger_data.order_id}}?",
+		"timeout":    "2h",
+		"on_timeout": OutputPortRejected,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	node.now = func() time.Time { return now }
+
+	ctx := models.ExecutionContext{
+		ID:          "exec-1",
+		WorkflowID:  "refunds",
+		TriggerData: map[string]any{"order_id": "42"},
+	}
+	inputs := map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{"order_id": "42"}},
+	}
+
+	results, err := node.Execute(ctx, inputs)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	result, ok := results[OutputPortPending]
+	if !ok || len(results) != 1 {
+		t.Fatalf("Expected only the pending port, got: %v", results)
+	}
+
+	if result.Status != string(models.NodeStatusPaused) {
+		t.Errorf("Expected paused status, got: %s", result.Status)
+	}
+
+	var request models.ApprovalRequest
+	if !models.DecodeResultData(result.Data, models.ApprovalDataKey, &request) {
+		t.Fatalf("Expected an approval request, got: %v", result.Data)
+	}
+
+	if len(request.Token) != 64 {
+		t.Errorf("Expected a 64 character token, got: %q", request.Token)
+	}
+
+	if request.NodeID != "approve" || request.Status != models.ApprovalStatusPending {
+		t.Errorf("Unexpected request: %+v", request)
+	}
+
+	if request.Message != "Refund order 42?" {
+		t.Errorf("Expected rendered message, got: %q", request.Message)
+	}
+
+	if request.Data["order_id"] != "42" {
+		t.Errorf("Expected input data to be kept, got: %v", request.Data)
+	}
+
+	if request.ExpiresAt == nil || !request.ExpiresAt.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("Expected expiry in 2h, got: %v", request.ExpiresAt)
+	}
+
+	if request.TimeoutPort != OutputPortRejected {
+		t.Errorf("Expected timeout port %s, got: %s", OutputPortRejected, request.TimeoutPort)
+	}
+
+	again, _ := node.Execute(ctx, inputs)
+	if again[OutputPortPending].Data[models.ApprovalDataKey].(map[string]any)["token"] == request.Token {
+		t.Error("Expected a unique token per request")
+	}
+}
+
+func TestApprovalNode_Execute_NoTimeout(t *testing.T) {
+	node, err := NewApprovalNode("approve", map[string]any{})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	results, err := node.Execute(models.ExecutionContext{}, map[string]models.NodeResult{})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	var request models.ApprovalRequest
+	if !models.DecodeResultData(results[OutputPortPending].Data, models.ApprovalDataKey, &request) {
+		t.Fatalf("Expected an approval request, got: %v", results[OutputPortPending].Data)
+	}
+
+	if request.ExpiresAt != nil {
+		t.Errorf("Expected no expiry, got: %v", request.ExpiresAt)
+	}
+
+	if request.TimeoutPort != OutputPortTimeout {
+		t.Errorf("Expected timeout port %s, got: %s", OutputPortTimeout, request.TimeoutPort)
+	}
+}
+
+func TestApprovalNodeFactory_Create(t *testing.T) {
+	factory := NewApprovalNodeFactory()
+
+	if _, err := factory.Create(context.Background(), "approve", map[string]any{"timeout": "never"}); err == nil {
+		t.Error("Expected error for invalid timeout")
+	}
+
+	node, err := factory.Create(context.Background(), "approve", map[string]any{"timeout": "24h"})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if node.(*ApprovalNode).timeout != 24*time.Hour {
+		t.Errorf("Expected timeout 24h, got: %s", node.(*ApprovalNode).timeout)
+	}
+}
@@ -0,0 +1,83 @@
+// Package assertion provides assert node factory for registry integration.
+package assertion
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// AssertNodeFactory creates AssertNode instances.
+type AssertNodeFactory struct{}
+
+// Create creates a new AssertNode instance.
+func (f *AssertNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewAssertNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *AssertNodeFactory) ID() string {
+	return "assert"
+}
+
+// Name returns the factory name.
+func (f *AssertNodeFactory) Name() string {
+	return "Assert"
+}
+
+// Description returns the factory description.
+func (f *AssertNodeFactory) Description() string {
+	return "Checks conditions over the execution context and fails with every message of the ones that do not hold"
+}
+
+// Schema returns the JSON schema for Assert node configuration.
+func (f *AssertNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"assertions": map[string]any{
+				"type":        "array",
+				"description": "Conditions that must all hold, each with the message reported when it does not",
+				"minItems":    1,
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"condition": map[string]any{
+							"type":        "string",
+							"description": "Condition that must hold. Supports templating",
+							"examples": []string{
+								`{{gt (len (index .node_results "fetch::success").json.items) 0}}`,
+								`{{eq (index .node_results "fetch::success").status_code 200.0}}`,
+							},
+						},
+						"message": map[string]any{
+							"type":        "string",
+							"description": "Message reported when the condition does not hold",
+						},
+					},
+					"required": []string{"condition"},
+				},
+			},
+		},
+		"required": []string{"assertions"},
+		"examples": []map[string]any{
+			{
+				"assertions": []map[string]any{
+					{
+						"condition": `{{eq (index .node_results "fetch::success").status_code 200.0}}`,
+						"message":   "orders API did not return 200",
+					},
+					{
+						"condition": `{{gt (len (index .node_results "fetch::success").json.items) 0}}`,
+						"message":   "orders API returned no items",
+					},
+				},
+			},
+		},
+	}
+}
+
+// NewAssertNodeFactory creates a new factory instance.
+func NewAssertNodeFactory() protocol.NodeFactory {
+	return &AssertNodeFactory{}
+}
@@ -0,0 +1,197 @@
+// Package assertion provides an assert node that gates a workflow on conditions over the execution context.
+package assertion
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/nodes/conditional"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortFailure = "failure"
+	InputPortMain     = "main"
+)
+
+// Assertion is a condition that must hold, with the message reported when it does not.
+type Assertion struct {
+	Condition string
+	Message   string
+}
+
+// AssertNode implements the Node interface for inline checks. It evaluates every assertion and
+// fails with all their messages when any does not hold, so it can gate a workflow on data quality
+// or, routed to an alerting node, act as a monitor.
+type AssertNode struct {
+	id         string
+	assertions []Assertion
+}
+
+// NewAssertNode creates a new assert node.
+func NewAssertNode(id string, config map[string]any) (*AssertNode, error) {
+	assertions, err := parseAssertions(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AssertNode{
+		id:         id,
+		assertions: assertions,
+	}, nil
+}
+
+// parseAssertions reads the "assertions" list from the configuration.
+func parseAssertions(config map[string]any) ([]Assertion, error) {
+	items, ok := config["assertions"].([]any)
+	if !ok || len(items) == 0 {
+		return nil, errors.New("missing required field 'assertions'")
+	}
+
+	assertions := make([]Assertion, 0, len(items))
+
+	for i, item := range items {
+		entry, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("assertion %d must be an object", i)
+		}
+
+		condition, ok := entry["condition"].(string)
+		if !ok || condition == "" {
+			return nil, fmt.Errorf("assertion %d is missing 'condition'", i)
+		}
+
+		message, _ := entry["message"].(string)
+		if message == "" {
+			message = "assertion failed: " + condition
+		}
+
+		assertions = append(assertions, Assertion{Condition: condition, Message: message})
+	}
+
+	return assertions, nil
+}
+
+// ID returns the node ID.
+func (n *AssertNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *AssertNode) Type() string {
+	return "assert"
+}
+
+// Execute evaluates every assertion and routes the input to the success port when all hold,
+// or to the failure port with the messages of the ones that do not.
+func (n *AssertNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	var failures []string
+
+	for _, assertion := range n.assertions {
+		result, err := template.RenderWithContext(assertion.Condition, &ctx)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s (condition evaluation failed: %v)", assertion.Message, err))
+
+			continue
+		}
+
+		if !conditional.IsTrue(result) {
+			failures = append(failures, assertion.Message)
+		}
+	}
+
+	if len(failures) > 0 {
+		errorMessage := strings.Join(failures, "; ")
+
+		return map[string]models.NodeResult{
+			OutputPortFailure: {
+				NodeID: n.id,
+				Data: map[string]any{
+					"error":    errorMessage,
+					"failures": failures,
+					"success":  false,
+				},
+				Status: string(models.NodeStatusError),
+				Error:  errorMessage,
+			},
+		}, nil
+	}
+
+	data := make(map[string]any)
+	if input, ok := inputs[InputPortMain]; ok {
+		maps.Copy(data, input.Data)
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// InputPorts returns the input ports for the node.
+func (n *AssertNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Data passed on to the success port when every assertion holds",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *AssertNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Execution path taken when every assertion holds, carrying the main input",
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortFailure),
+				NodeID:      n.id,
+				Name:        OutputPortFailure,
+				Description: "Messages of the assertions that do not hold; unconnected, it goes to the workflow's error handler",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":    map[string]any{"type": "string"},
+						"failures": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+						"success":  map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the assert node.
+func (n *AssertNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *AssertNode) Validate(config map[string]any) error {
+	_, err := parseAssertions(config)
+
+	return err
+}
@@ -0,0 +1,98 @@
+package assertion
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExecution() models.ExecutionContext {
+	return models.ExecutionContext{
+		ID:          "exec-1",
+		NodeResults: map[string]models.NodeResult{},
+		Variables:   map[string]any{"count": 3.0, "status": "ok"},
+	}
+}
+
+func assertions(items ...map[string]any) map[string]any {
+	list := make([]any, 0, len(items))
+	for _, item := range items {
+		list = append(list, item)
+	}
+
+	return map[string]any{"assertions": list}
+}
+
+func TestNewAssertNode(t *testing.T) {
+	node, err := NewAssertNode("check", assertions(map[string]any{"condition": "true"}))
+	require.NoError(t, err)
+	assert.Equal(t, "assert", node.Type())
+	assert.Equal(t, "assertion failed: true", node.assertions[0].Message)
+
+	_, err = NewAssertNode("check", map[string]any{})
+	require.ErrorContains(t, err, "missing required field 'assertions'")
+
+	_, err = NewAssertNode("check", assertions(map[string]any{"message": "no condition"}))
+	assert.ErrorContains(t, err, "assertion 0 is missing 'condition'")
+}
+
+func TestAssertNode_Execute_AllPass(t *testing.T) {
+	node, err := NewAssertNode("check", assertions(
+		map[string]any{"condition": `{{gt .variables.count 0.0}}`, "message": "count must be positive"},
+		map[string]any{"condition": `{{eq .variables.status "ok"}}`, "message": "status must be ok"},
+	))
+	require.NoError(t, err)
+
+	inputs := map[string]models.NodeResult{InputPortMain: {Data: map[string]any{"order_id": "42"}}}
+
+	results, err := node.Execute(newExecution(), inputs)
+	require.NoError(t, err)
+	require.Contains(t, results, OutputPortSuccess)
+	assert.Equal(t, string(models.NodeStatusSuccess), results[OutputPortSuccess].Status)
+	assert.Equal(t, map[string]any{"order_id": "42"}, results[OutputPortSuccess].Data)
+}
+
+func TestAssertNode_Execute_OneFails(t *testing.T) {
+	node, err := NewAssertNode("check", assertions(
+		map[string]any{"condition": `{{gt .variables.count 0.0}}`, "message": "count must be positive"},
+		map[string]any{"condition": `{{eq .variables.status "failed"}}`, "message": "status must be failed"},
+	))
+	require.NoError(t, err)
+
+	results, err := node.Execute(newExecution(), map[string]models.NodeResult{})
+	require.NoError(t, err)
+	require.Contains(t, results, OutputPortFailure)
+
+	failure := results[OutputPortFailure]
+	assert.Equal(t, string(models.NodeStatusError), failure.Status)
+	assert.Equal(t, "status must be failed", failure.Error)
+	assert.Equal(t, []string{"status must be failed"}, failure.Data["failures"])
+}
+
+func TestAssertNode_Execute_MultipleFail(t *testing.T) {
+	node, err := NewAssertNode("check", assertions(
+		map[string]any{"condition": `{{gt .variables.count 10.0}}`, "message": "count must exceed 10"},
+		map[string]any{"condition": `{{eq .variables.status "ok"}}`, "message": "status must be ok"},
+		map[string]any{"condition": `{{lt .variables.count 1.0}}`, "message": "count must be below 1"},
+	))
+	require.NoError(t, err)
+
+	results, err := node.Execute(newExecution(), map[string]models.NodeResult{})
+	require.NoError(t, err)
+	require.Contains(t, results, OutputPortFailure)
+
+	failure := results[OutputPortFailure]
+	assert.Equal(t, []string{"count must exceed 10", "count must be below 1"}, failure.Data["failures"])
+	assert.Equal(t, "count must exceed 10; count must be below 1", failure.Error)
+	assert.Equal(t, false, failure.Data["success"])
+}
+
+func TestAssertNode_Validate(t *testing.T) {
+	node := &AssertNode{id: "check"}
+
+	require.NoError(t, node.Validate(assertions(map[string]any{"condition": "true", "message": "ok"})))
+	require.ErrorContains(t, node.Validate(map[string]any{"assertions": []any{}}), "missing required field 'assertions'")
+	assert.ErrorContains(t, node.Validate(map[string]any{"assertions": []any{"true"}}), "assertion 0 must be an object")
+}
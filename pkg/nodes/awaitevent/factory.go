@@ -0,0 +1,80 @@
+// Package awaitevent provides await event node factory for registry integration.
+package awaitevent
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// AwaitEventNodeFactory creates AwaitEventNode instances.
+type AwaitEventNodeFactory struct{}
+
+// Create creates a new AwaitEventNode instance.
+func (f *AwaitEventNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewAwaitEventNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *AwaitEventNodeFactory) ID() string {
+	return "awaitevent"
+}
+
+// Name returns the factory name.
+func (f *AwaitEventNodeFactory) Name() string {
+	return "Await Event"
+}
+
+// Description returns the factory description.
+func (f *AwaitEventNodeFactory) Description() string {
+	return "Pauses the execution until a source event with a matching correlation key arrives, or the timeout elapses"
+}
+
+// Schema returns the JSON schema for Await Event node configuration.
+func (f *AwaitEventNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"provider_id": map[string]any{
+				"type":        "string",
+				"description": "Source provider of the awaited event",
+				"examples":    []string{"webhook", "kafka"},
+			},
+			"event_type": map[string]any{
+				"type":        "string",
+				"description": "Type of the awaited event",
+				"examples":    []string{"WebhookReceived", "MessageReceived"},
+			},
+			"correlation_key": map[string]any{
+				"type":        "string",
+				"description": "Key identifying the awaited event, rendered when the node executes. Supports templating.",
+				"examples":    []string{"{{.trigger_data.order_id}}"},
+			},
+			"event_key": map[string]any{
+				"type":        "string",
+				"description": "Template rendered over each event of the provider and type, with its data as .event_data; the event is received when it equals the correlation key",
+				"examples":    []string{"{{.event_data.body.order_id}}"},
+			},
+			"timeout": map[string]any{
+				"type":        "string",
+				"description": "How long to wait for the event before resuming on the timeout port. No expiry when empty.",
+				"examples":    []string{"15m", "24h"},
+			},
+		},
+		"required": []string{"provider_id", "event_type", "correlation_key", "event_key"},
+		"examples": []map[string]any{
+			{
+				"provider_id":     "webhook",
+				"event_type":      "WebhookReceived",
+				"correlation_key": "{{.trigger_data.order_id}}",
+				"event_key":       "{{.event_data.body.order_id}}",
+				"timeout":         "1h",
+			},
+		},
+	}
+}
+
+// NewAwaitEventNodeFactory creates a new factory instance.
+func NewAwaitEventNodeFactory() protocol.NodeFactory {
+	return &AwaitEventNodeFactory{}
+}
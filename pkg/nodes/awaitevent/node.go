@@ -0,0 +1,259 @@
+// Package awaitevent provides a node that pauses an execution until a correlated source event arrives.
+package awaitevent
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortReceived = models.EventWaitPortReceived
+	OutputPortTimeout  = models.EventWaitPortTimeout
+	OutputPortError    = "error"
+	InputPortMain      = "main"
+
+	// OutputPortWaiting carries the event wait that pauses the execution.
+	// It is consumed by the worker and never activates other nodes.
+	OutputPortWaiting = "waiting"
+)
+
+// AwaitEventNode implements the Node interface for steps waiting on an external event.
+type AwaitEventNode struct {
+	id             string
+	providerID     string
+	eventType      string
+	correlationKey string
+	eventKey       string
+	timeout        time.Duration
+	now            func() time.Time
+}
+
+// NewAwaitEventNode creates a new await event node.
+func NewAwaitEventNode(id string, config map[string]any) (*AwaitEventNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	providerID, _ := config["provider_id"].(string)
+	eventType, _ := config["event_type"].(string)
+	correlationKey, _ := config["correlation_key"].(string)
+	eventKey, _ := config["event_key"].(string)
+
+	var timeout time.Duration
+	if raw, ok := config["timeout"].(string); ok && raw != "" {
+		timeout, _ = time.ParseDuration(raw)
+	}
+
+	return &AwaitEventNode{
+		id:             id,
+		providerID:     providerID,
+		eventType:      eventType,
+		correlationKey: correlationKey,
+		eventKey:       eventKey,
+		timeout:        timeout,
+		now:            time.Now,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *AwaitEventNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *AwaitEventNode) Type() string {
+	return "awaitevent"
+}
+
+// Execute renders the correlation key and pauses the execution on an event wait. The execution
+// resumes on the received port when a matching event arrives, or on the timeout port.
+func (n *AwaitEventNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	data := make(map[string]any)
+	if input, ok := inputs[InputPortMain]; ok {
+		maps.Copy(data, input.Data)
+	}
+
+	rendered, err := template.RenderWithContext(n.correlationKey, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render correlation key: %v", err)), nil
+	}
+
+	correlationKey := fmt.Sprintf("%v", rendered)
+	if correlationKey == "" || correlationKey == "<no value>" {
+		return n.createErrorResult("correlation key rendered empty"), nil
+	}
+
+	id, err := generateID()
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to generate event wait ID: %v", err)), nil
+	}
+
+	now := n.now().UTC()
+
+	wait := models.EventWait{
+		ID:             id,
+		NodeID:         n.id,
+		ProviderID:     n.providerID,
+		EventType:      n.eventType,
+		EventKey:       n.eventKey,
+		CorrelationKey: correlationKey,
+		Data:           data,
+		Status:         models.EventWaitStatusPending,
+		CreatedAt:      now,
+	}
+
+	if n.timeout > 0 {
+		expiresAt := now.Add(n.timeout)
+		wait.ExpiresAt = &expiresAt
+	}
+
+	encoded, err := models.EncodeResultData(wait)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortWaiting: {
+			NodeID: n.id,
+			Data: map[string]any{
+				models.EventWaitDataKey: encoded,
+			},
+			Status: string(models.NodeStatusPaused),
+		},
+	}, nil
+}
+
+// generateID returns a random event wait ID.
+func generateID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *AwaitEventNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *AwaitEventNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Data carried over to the output once the wait ends",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *AwaitEventNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortReceived),
+				NodeID:      n.id,
+				Name:        OutputPortReceived,
+				Description: "Input data with the data of the received event as 'event'",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"event": map[string]any{"type": "object"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortTimeout),
+				NodeID:      n.id,
+				Name:        OutputPortTimeout,
+				Description: "Input data when no matching event arrives before the timeout",
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the wait cannot be registered",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the await event node.
+func (n *AwaitEventNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *AwaitEventNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the await event fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	for _, field := range []string{"provider_id", "event_type", "correlation_key", "event_key"} {
+		value, ok := config[field].(string)
+		if !ok || value == "" {
+			return fmt.Errorf("field '%s' is required", field)
+		}
+	}
+
+	for _, field := range []string{"correlation_key", "event_key"} {
+		if _, err := template.Parse(config[field].(string)); err != nil {
+			return fmt.Errorf("invalid '%s' template: %w", field, err)
+		}
+	}
+
+	if raw, exists := config["timeout"]; exists {
+		s, ok := raw.(string)
+		if !ok {
+			return errors.New("field 'timeout' must be a duration string")
+		}
+
+		if s != "" {
+			timeout, err := time.ParseDuration(s)
+			if err != nil || timeout <= 0 {
+				return fmt.Errorf("invalid timeout '%s'", s)
+			}
+		}
+	}
+
+	return nil
+}
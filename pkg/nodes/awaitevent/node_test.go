@@ -0,0 +1,135 @@
+package awaitevent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+func validConfig() map[string]any {
+	return map[string]any{
+		"provider_id":     "webhook",
+		"event_type":      "WebhookReceived",
+		"correlation_key": "{{.trigger_data.order_id}}",
+		"event_key":       "{{.event_data.body.order_id}}",
+		"timeout":         "1h",
+	}
+}
+
+func TestNewAwaitEventNode(t *testing.T) {
+	invalid := []func(config map[string]any){
+		func(config map[string]any) { delete(config, "provider_id") },
+		func(config map[string]any) { config["event_type"] = "" },
+		func(config map[string]any) { delete(config, "correlation_key") },
+		func(config map[string]any) { config["event_key"] = 42 },
+		func(config map[string]any) { config["event_key"] = "{{.event_data" },
+		func(config map[string]any) { config["timeout"] = "soon" },
+		func(config map[string]any) { config["timeout"] = "-1h" },
+	}
+
+	for i, mutate := range invalid {
+		config := validConfig()
+		mutate(config)
+
+		if _, err := NewAwaitEventNode("wait", config); err == nil {
+			t.Errorf("Expected error for config %d: %v", i, config)
+		}
+	}
+
+	if _, err := NewAwaitEventNode("wait", validConfig()); err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+}
+
+func TestAwaitEventNode_Execute_PausesWithEventWait(t *testing.T) {
+	node, err := NewAwaitEventNode("wait", validConfig())
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	node.now = func() time.Time { return now }
+
+	ctx := models.ExecutionContext{
+		ID:          "exec-1",
+		WorkflowID:  "orders",
+		TriggerData: map[string]any{"order_id": "42"},
+	}
+	inputs := map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{"order_id": "42"}},
+	}
+
+	results, err := node.Execute(ctx, inputs)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	result, ok := results[OutputPortWaiting]
+	if !ok || len(results) != 1 {
+		t.Fatalf("Expected only the waiting port, got: %v", results)
+	}
+
+	if result.Status != string(models.NodeStatusPaused) {
+		t.Errorf("Expected paused status, got: %s", result.Status)
+	}
+
+	var wait models.EventWait
+	if !models.DecodeResultData(result.Data, models.EventWaitDataKey, &wait) {
+		t.Fatalf("Expected an event wait, got: %v", result.Data)
+	}
+
+	if wait.ID == "" || wait.NodeID != "wait" || wait.Status != models.EventWaitStatusPending {
+		t.Errorf("Unexpected event wait: %+v", wait)
+	}
+
+	if wait.ProviderID != "webhook" || wait.EventType != "WebhookReceived" || wait.EventKey != "{{.event_data.body.order_id}}" {
+		t.Errorf("Unexpected event selection: %+v", wait)
+	}
+
+	if wait.CorrelationKey != "42" {
+		t.Errorf("Expected correlation key 42, got: %s", wait.CorrelationKey)
+	}
+
+	if wait.Data["order_id"] != "42" {
+		t.Errorf("Expected input data to be kept, got: %v", wait.Data)
+	}
+
+	if wait.ExpiresAt == nil || !wait.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("Expected expiry in one hour, got: %v", wait.ExpiresAt)
+	}
+}
+
+func TestAwaitEventNode_Execute_EmptyCorrelationKey(t *testing.T) {
+	node, err := NewAwaitEventNode("wait", validConfig())
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	results, err := node.Execute(models.ExecutionContext{TriggerData: map[string]any{}}, map[string]models.NodeResult{})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if _, ok := results[OutputPortError]; !ok {
+		t.Errorf("Expected the error port, got: %v", results)
+	}
+}
+
+func TestAwaitEventNodeFactory(t *testing.T) {
+	factory := NewAwaitEventNodeFactory()
+
+	if factory.ID() != "awaitevent" {
+		t.Errorf("Expected ID awaitevent, got: %s", factory.ID())
+	}
+
+	node, err := factory.Create(context.Background(), "wait", validConfig())
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if node.Type() != "awaitevent" || len(node.OutputPorts()) != 3 {
+		t.Errorf("Unexpected node: %s with %d output ports", node.Type(), len(node.OutputPorts()))
+	}
+}
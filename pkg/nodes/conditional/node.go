@@ -57,7 +57,7 @@ func (n *ConditionalNode) Execute(ctx models.ExecutionContext, inputs map[string
 	}
 
 	// Convert result to boolean
-	isTrue := n.evaluateCondition(result)
+	isTrue := IsTrue(result)
 
 	// Route to appropriate output port
 	if isTrue {
@@ -85,8 +85,8 @@ func (n *ConditionalNode) Execute(ctx models.ExecutionContext, inputs map[string
 	}
 }
 
-// evaluateCondition converts various types to boolean.
-func (n *ConditionalNode) evaluateCondition(value any) bool {
+// IsTrue converts the evaluated value of a condition to a boolean.
+func IsTrue(value any) bool {
 	switch v := value.(type) {
 	case bool:
 		return v
@@ -0,0 +1,143 @@
+// Package convertcurrency provides currency conversion node factory for registry integration.
+package convertcurrency
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// ConvertCurrencyNodeFactory creates ConvertCurrencyNode instances, sharing one rates cache per
+// rates service and refresh interval.
+type ConvertCurrencyNodeFactory struct {
+	mu     sync.Mutex
+	caches map[string]*CachedRatesSource
+}
+
+// Create creates a new ConvertCurrencyNode instance.
+func (f *ConvertCurrencyNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	rates, err := f.rates(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConvertCurrencyNode(id, config, rates)
+}
+
+// rates returns the cached rates source of the configured service, creating it on first use. The
+// rates URL is expanded from environment variables, so a service API key can stay out of
+// workflow definitions.
+func (f *ConvertCurrencyNodeFactory) rates(config map[string]any) (*CachedRatesSource, error) {
+	ratesURL, _ := config["rates_url"].(string)
+	ratesURL = os.ExpandEnv(ratesURL)
+
+	refresh, err := parseRefresh(config["refresh"])
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := ratesURL + "|" + refresh.String()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if cache, exists := f.caches[cacheKey]; exists {
+		return cache, nil
+	}
+
+	source, err := NewServiceRatesSource(ratesURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := NewCachedRatesSource(source, refresh)
+	f.caches[cacheKey] = cache
+
+	return cache, nil
+}
+
+// ID returns the factory ID.
+func (f *ConvertCurrencyNodeFactory) ID() string {
+	return "convertcurrency"
+}
+
+// Name returns the factory name.
+func (f *ConvertCurrencyNodeFactory) Name() string {
+	return "Convert Currency"
+}
+
+// Description returns the factory description.
+func (f *ConvertCurrencyNodeFactory) Description() string {
+	return "Converts an amount between currencies with exchange rates fetched from a rates service and cached for a refresh interval"
+}
+
+// Schema returns the JSON schema for currency conversion node configuration.
+func (f *ConvertCurrencyNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"amount": map[string]any{
+				"type":        []string{"number", "string"},
+				"description": "Amount to convert. Supports templating.",
+				"examples":    []any{"{{.trigger_data.body.total}}", 100},
+			},
+			"from": map[string]any{
+				"type":        "string",
+				"description": "ISO 4217 code of the currency of the amount. Supports templating.",
+				"examples":    []string{"USD", "{{.trigger_data.body.currency}}"},
+			},
+			"to": map[string]any{
+				"type":        "string",
+				"description": "ISO 4217 code of the currency to convert to. Supports templating.",
+				"examples":    []string{"EUR"},
+			},
+			"rates_url": map[string]any{
+				"type":        "string",
+				"description": "URL of a rates service answering GET with a JSON object whose rates field maps currency codes to rates, {base} is replaced with the from currency. Environment variables are expanded.",
+				"examples":    []string{"https://open.er-api.com/v6/latest/{base}", "https://api.frankfurter.app/latest?from={base}"},
+			},
+			"refresh": map[string]any{
+				"type":        "string",
+				"description": "How long fetched rates are reused before being fetched again",
+				"default":     defaultRefresh.String(),
+				"examples":    []string{"15m", "24h"},
+			},
+			"precision": map[string]any{
+				"type":        "integer",
+				"description": "Decimal places the converted amount is rounded to, not rounded when unset",
+				"minimum":     0,
+				"maximum":     10,
+				"examples":    []int{2},
+			},
+			"timeout": map[string]any{
+				"type":        "number",
+				"description": "Timeout in seconds of getting the rates from the rates service",
+				"default":     defaultServiceTimeout.Seconds(),
+			},
+		},
+		"required": []string{"amount", "from", "to", "rates_url"},
+		"examples": []map[string]any{
+			{
+				"amount":    "{{.trigger_data.body.total}}",
+				"from":      "{{.trigger_data.body.currency}}",
+				"to":        "EUR",
+				"rates_url": "https://open.er-api.com/v6/latest/{base}",
+				"refresh":   "1h",
+				"precision": 2,
+			},
+		},
+	}
+}
+
+// NewConvertCurrencyNodeFactory creates a new factory instance.
+func NewConvertCurrencyNodeFactory() protocol.NodeFactory {
+	return &ConvertCurrencyNodeFactory{
+		caches: make(map[string]*CachedRatesSource),
+	}
+}
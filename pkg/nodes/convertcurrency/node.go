@@ -0,0 +1,334 @@
+// Package convertcurrency provides a node that converts amounts between currencies with the rates
+// of a rates service.
+package convertcurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/nodes/transform"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// defaultRefresh is how long rates are reused when no refresh is configured.
+const defaultRefresh = time.Hour
+
+// currencyCode matches ISO 4217 style currency codes.
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ConvertCurrencyNode implements the Node interface for converting an amount between currencies.
+type ConvertCurrencyNode struct {
+	id        string
+	amount    any
+	from      string
+	to        string
+	precision *int
+	timeout   time.Duration
+	rates     RatesSource
+}
+
+// NewConvertCurrencyNode creates a new currency conversion node reading rates from rates.
+func NewConvertCurrencyNode(id string, config map[string]any, rates RatesSource) (*ConvertCurrencyNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	from, _ := config["from"].(string)
+	to, _ := config["to"].(string)
+
+	node := &ConvertCurrencyNode{
+		id:      id,
+		amount:  config["amount"],
+		from:    from,
+		to:      to,
+		timeout: defaultServiceTimeout,
+		rates:   rates,
+	}
+
+	if timeout, ok := config["timeout"].(float64); ok {
+		node.timeout = time.Duration(timeout * float64(time.Second))
+	}
+
+	if precision, ok := toInt(config["precision"]); ok {
+		node.precision = &precision
+	}
+
+	return node, nil
+}
+
+// ID returns the node ID.
+func (n *ConvertCurrencyNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *ConvertCurrencyNode) Type() string {
+	return "convertcurrency"
+}
+
+// Execute converts the rendered amount from one currency to the other with the rates of the
+// from currency, fetched within the timeout. Unknown currency codes go to the error port.
+func (n *ConvertCurrencyNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	amount, err := n.renderAmount(&ctx)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	from, err := n.renderCode("from", n.from, &ctx)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	to, err := n.renderCode("to", n.to, &ctx)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	ratesCtx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+
+	rates, err := n.rates.Rates(ratesCtx, from)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to get rates of %s: %v", from, err)), nil
+	}
+
+	rate, ok := rates.Rates[to]
+	if !ok && to == from {
+		rate, ok = 1, true
+	}
+
+	if !ok {
+		return n.createErrorResult(fmt.Sprintf("%v '%s'", ErrUnknownCurrency, to)), nil
+	}
+
+	converted := amount * rate
+	if n.precision != nil {
+		scale := math.Pow10(*n.precision)
+		converted = math.Round(converted*scale) / scale
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"result": map[string]any{
+					"amount":          converted,
+					"currency":        to,
+					"rate":            rate,
+					"original_amount": amount,
+					"from":            from,
+					"rates_date":      rates.FetchedAt.UTC().Format(time.RFC3339),
+				},
+			},
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// renderAmount returns the configured amount, rendering it when it is a template.
+func (n *ConvertCurrencyNode) renderAmount(ctx *models.ExecutionContext) (float64, error) {
+	text, ok := n.amount.(string)
+	if !ok {
+		amount, _ := transform.ToFloat(n.amount)
+
+		return amount, nil
+	}
+
+	rendered, err := template.RenderStringWithContext(text, ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to render amount template: %w", err)
+	}
+
+	amount, err := strconv.ParseFloat(strings.TrimSpace(rendered), 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, fmt.Errorf("amount '%s' is not a number", rendered)
+	}
+
+	return amount, nil
+}
+
+// renderCode renders a currency code template, returning it upper-cased.
+func (n *ConvertCurrencyNode) renderCode(field, code string, ctx *models.ExecutionContext) (string, error) {
+	rendered, err := template.RenderStringWithContext(code, ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", field, err)
+	}
+
+	rendered = strings.ToUpper(strings.TrimSpace(rendered))
+	if !currencyCode.MatchString(rendered) {
+		return "", fmt.Errorf("%w '%s'", ErrUnknownCurrency, rendered)
+	}
+
+	return rendered, nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *ConvertCurrencyNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *ConvertCurrencyNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Triggers the conversion; the amount and currencies are read from the execution context through their templates",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *ConvertCurrencyNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "The converted amount and the rate used",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"result": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"amount":          map[string]any{"type": "number", "description": "Converted amount"},
+								"currency":        map[string]any{"type": "string", "description": "Currency of the converted amount"},
+								"rate":            map[string]any{"type": "number", "description": "Units of currency per unit of from"},
+								"original_amount": map[string]any{"type": "number"},
+								"from":            map[string]any{"type": "string"},
+								"rates_date":      map[string]any{"type": "string", "description": "When the rates used were fetched"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information for unknown currency codes, invalid amounts or unavailable rates",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the currency conversion node.
+func (n *ConvertCurrencyNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *ConvertCurrencyNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the currency conversion fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	switch amount := config["amount"].(type) {
+	case string:
+		if amount == "" {
+			return errors.New("missing required field 'amount'")
+		}
+	default:
+		if _, ok := transform.ToFloat(amount); !ok {
+			return errors.New("field 'amount' must be a number or a template")
+		}
+	}
+
+	for _, field := range []string{"from", "to"} {
+		if code, ok := config[field].(string); !ok || code == "" {
+			return fmt.Errorf("missing required field '%s'", field)
+		}
+	}
+
+	if ratesURL, ok := config["rates_url"].(string); !ok || ratesURL == "" {
+		return errors.New("missing required field 'rates_url'")
+	}
+
+	if refresh, exists := config["refresh"]; exists {
+		if _, err := parseRefresh(refresh); err != nil {
+			return err
+		}
+	}
+
+	if precision, exists := config["precision"]; exists {
+		if value, ok := toInt(precision); !ok || value < 0 || value > 10 {
+			return errors.New("field 'precision' must be an integer between 0 and 10")
+		}
+	}
+
+	if timeout, exists := config["timeout"]; exists {
+		if value, ok := timeout.(float64); !ok || value <= 0 {
+			return errors.New("field 'timeout' must be a positive number of seconds")
+		}
+	}
+
+	return nil
+}
+
+// parseRefresh reads the "refresh" config, a positive duration such as "15m".
+func parseRefresh(raw any) (time.Duration, error) {
+	if raw == nil {
+		return defaultRefresh, nil
+	}
+
+	text, _ := raw.(string)
+
+	refresh, err := time.ParseDuration(text)
+	if err != nil || refresh <= 0 {
+		return 0, fmt.Errorf("field 'refresh' must be a positive duration such as '15m', got %v", raw)
+	}
+
+	return refresh, nil
+}
+
+func toInt(value any) (int, bool) {
+	number, ok := transform.ToFloat(value)
+	if !ok || number != math.Trunc(number) {
+		return 0, false
+	}
+
+	return int(number), true
+}
@@ -0,0 +1,220 @@
+package convertcurrency
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRatesSource is an in-memory RatesSource for tests, standing in for a rates service.
+type fakeRatesSource struct {
+	rates   map[string]map[string]float64
+	fetches []string
+}
+
+func (s *fakeRatesSource) Rates(_ context.Context, base string) (*Rates, error) {
+	s.fetches = append(s.fetches, base)
+
+	rates, ok := s.rates[base]
+	if !ok {
+		return nil, fmt.Errorf("%w '%s'", ErrUnknownCurrency, base)
+	}
+
+	return &Rates{Base: base, Rates: rates}, nil
+}
+
+func newFakeRatesSource() *fakeRatesSource {
+	return &fakeRatesSource{
+		rates: map[string]map[string]float64{
+			"USD": {"EUR": 0.92, "BRL": 5.05, "JPY": 151.3},
+			"EUR": {"USD": 1.087, "BRL": 5.49},
+		},
+	}
+}
+
+func executeConversion(t *testing.T, config map[string]any, rates RatesSource, order map[string]any) map[string]models.NodeResult {
+	t.Helper()
+
+	config["rates_url"] = "https://rates.example.com/{base}"
+
+	node, err := NewConvertCurrencyNode("convert", config, rates)
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{
+		TriggerData: map[string]any{"order": order},
+	}, map[string]models.NodeResult{
+		InputPortMain: {NodeID: "webhook", Data: map[string]any{}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	return results
+}
+
+// convertHundred converts 100 from one currency to the other, returning the converted amount.
+func convertHundred(t *testing.T, rates RatesSource, from, to string) float64 {
+	t.Helper()
+
+	results := executeConversion(t, map[string]any{"amount": 100, "from": from, "to": to}, rates, nil)
+	require.Contains(t, results, OutputPortSuccess)
+
+	return results[OutputPortSuccess].Data["result"].(map[string]any)["amount"].(float64)
+}
+
+func TestNewConvertCurrencyNode_InvalidConfig(t *testing.T) {
+	valid := func() map[string]any {
+		return map[string]any{"amount": 10.0, "from": "USD", "to": "EUR", "rates_url": "https://rates.example.com/{base}"}
+	}
+
+	for name, change := range map[string]func(map[string]any){
+		"missing amount":    func(c map[string]any) { delete(c, "amount") },
+		"empty amount":      func(c map[string]any) { c["amount"] = "" },
+		"missing from":      func(c map[string]any) { delete(c, "from") },
+		"missing to":        func(c map[string]any) { delete(c, "to") },
+		"missing rates_url": func(c map[string]any) { delete(c, "rates_url") },
+		"invalid refresh":   func(c map[string]any) { c["refresh"] = "often" },
+		"negative refresh":  func(c map[string]any) { c["refresh"] = "-1m" },
+		"invalid precision": func(c map[string]any) { c["precision"] = 2.5 },
+		"invalid timeout":   func(c map[string]any) { c["timeout"] = "soon" },
+		"negative timeout":  func(c map[string]any) { c["timeout"] = -1.0 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := valid()
+			change(config)
+
+			_, err := NewConvertCurrencyNode("convert", config, newFakeRatesSource())
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewConvertCurrencyNodeFactory().Create(t.Context(), "convert", map[string]any{
+		"amount": 10.0, "from": "USD", "to": "EUR", "rates_url": "https://rates.example.com/latest",
+	})
+	assert.ErrorContains(t, err, "rates_url must contain the {base} placeholder")
+}
+
+func TestConvertCurrencyNode_Converts(t *testing.T) {
+	rates := newFakeRatesSource()
+
+	results := executeConversion(t, map[string]any{
+		"amount":    "{{.trigger_data.order.total}}",
+		"from":      "{{.trigger_data.order.currency}}",
+		"to":        "brl",
+		"precision": 2,
+	}, rates, map[string]any{"total": 123.45, "currency": "usd"})
+
+	require.Contains(t, results, OutputPortSuccess)
+
+	result := results[OutputPortSuccess].Data["result"].(map[string]any)
+	assert.InDelta(t, 623.42, result["amount"], 1e-9, "123.45 USD at 5.05 rounded to cents")
+	assert.Equal(t, "BRL", result["currency"])
+	assert.InDelta(t, 5.05, result["rate"], 1e-9)
+	assert.InDelta(t, 123.45, result["original_amount"], 1e-9)
+	assert.Equal(t, "USD", result["from"])
+
+	results = executeConversion(t, map[string]any{"amount": 200, "from": "EUR", "to": "USD"}, rates, nil)
+	require.Contains(t, results, OutputPortSuccess)
+	assert.InDelta(t, 217.4, results[OutputPortSuccess].Data["result"].(map[string]any)["amount"], 1e-9)
+
+	results = executeConversion(t, map[string]any{"amount": 50, "from": "EUR", "to": "EUR"}, rates, nil)
+	require.Contains(t, results, OutputPortSuccess)
+	assert.InDelta(t, 50.0, results[OutputPortSuccess].Data["result"].(map[string]any)["amount"], 1e-9)
+}
+
+func TestConvertCurrencyNode_UnknownCurrencyRoutesToError(t *testing.T) {
+	for name, config := range map[string]map[string]any{
+		"unknown to":     {"amount": 10, "from": "USD", "to": "XYZ"},
+		"unknown from":   {"amount": 10, "from": "XYZ", "to": "USD"},
+		"malformed code": {"amount": 10, "from": "US Dollar", "to": "EUR"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			results := executeConversion(t, config, newFakeRatesSource(), nil)
+
+			require.Contains(t, results, OutputPortError)
+			result := results[OutputPortError]
+			assert.Equal(t, string(models.NodeStatusError), result.Status)
+			assert.Contains(t, result.Error, "unknown currency code")
+			assert.Equal(t, false, result.Data["success"])
+		})
+	}
+
+	results := executeConversion(t, map[string]any{"amount": "{{.trigger_data.order.total}}", "from": "USD", "to": "EUR"},
+		newFakeRatesSource(), map[string]any{"total": "lots"})
+	require.Contains(t, results, OutputPortError)
+	assert.Equal(t, "amount 'lots' is not a number", results[OutputPortError].Error)
+}
+
+// stalledRatesSource never answers, returning once the context of the request is done.
+type stalledRatesSource struct{}
+
+func (stalledRatesSource) Rates(ctx context.Context, _ string) (*Rates, error) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func TestConvertCurrencyNode_RatesTimeoutRoutesToError(t *testing.T) {
+	results := executeConversion(t, map[string]any{"amount": 10, "from": "USD", "to": "EUR", "timeout": 0.01}, stalledRatesSource{}, nil)
+
+	require.Contains(t, results, OutputPortError)
+	assert.Equal(t, "failed to get rates of USD: context deadline exceeded", results[OutputPortError].Error)
+}
+
+func TestCachedRatesSource_CachesWithinRefresh(t *testing.T) {
+	rates := newFakeRatesSource()
+	cache := NewCachedRatesSource(rates, 10*time.Minute)
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	assert.InDelta(t, 92.0, convertHundred(t, cache, "USD", "EUR"), 1e-9)
+	assert.InDelta(t, 15130.0, convertHundred(t, cache, "USD", "JPY"), 1e-9)
+
+	// Rates changing at the source are not seen until the refresh interval elapsed
+	rates.rates["USD"] = map[string]float64{"EUR": 0.95}
+	now = now.Add(9 * time.Minute)
+
+	assert.InDelta(t, 92.0, convertHundred(t, cache, "USD", "EUR"), 1e-9)
+	assert.Equal(t, []string{"USD"}, rates.fetches)
+
+	now = now.Add(time.Minute)
+
+	assert.InDelta(t, 95.0, convertHundred(t, cache, "USD", "EUR"), 1e-9)
+	assert.Equal(t, []string{"USD", "USD"}, rates.fetches)
+
+	// Each base currency is fetched and cached on its own
+	assert.InDelta(t, 108.7, convertHundred(t, cache, "EUR", "USD"), 1e-9)
+	assert.InDelta(t, 108.7, convertHundred(t, cache, "EUR", "USD"), 1e-9)
+	assert.Equal(t, []string{"USD", "USD", "EUR"}, rates.fetches)
+}
+
+func TestServiceRatesSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/latest/USD" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"base_code":"USD","rates":{"USD":1,"EUR":0.92}}`))
+	}))
+	defer server.Close()
+
+	source, err := NewServiceRatesSource(server.URL + "/latest/{base}")
+	require.NoError(t, err)
+
+	rates, err := source.Rates(t.Context(), "USD")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"USD": 1, "EUR": 0.92}, rates.Rates)
+
+	_, err = source.Rates(t.Context(), "XYZ")
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+}
@@ -0,0 +1,147 @@
+package convertcurrency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// basePlaceholder is replaced with the base currency in the URL of a rates service.
+const basePlaceholder = "{base}"
+
+// defaultServiceTimeout bounds a request to a rates service, and getting the rates of a conversion
+// when the node configures no timeout.
+const defaultServiceTimeout = 10 * time.Second
+
+// ErrUnknownCurrency is returned for a currency code the rates source has no rates for.
+var ErrUnknownCurrency = errors.New("unknown currency code")
+
+// Rates are the exchange rates of a base currency: one unit of Base is worth Rates[code] units
+// of each currency.
+type Rates struct {
+	Base      string
+	Rates     map[string]float64
+	FetchedAt time.Time
+}
+
+// RatesSource provides exchange rates.
+type RatesSource interface {
+	// Rates returns the rates of base, or ErrUnknownCurrency when the source does not know it.
+	Rates(ctx context.Context, base string) (*Rates, error)
+}
+
+// ServiceRatesSource fetches rates from an HTTP service answering a GET with a JSON object whose
+// "rates" field maps currency codes to rates, the format of most exchange rate APIs.
+type ServiceRatesSource struct {
+	url    string
+	client *http.Client
+}
+
+// NewServiceRatesSource creates a source requesting ratesURL, in which {base} is replaced with the
+// base currency.
+func NewServiceRatesSource(ratesURL string) (*ServiceRatesSource, error) {
+	if !strings.Contains(ratesURL, basePlaceholder) {
+		return nil, fmt.Errorf("rates_url must contain the %s placeholder", basePlaceholder)
+	}
+
+	if _, err := url.Parse(strings.ReplaceAll(ratesURL, basePlaceholder, "USD")); err != nil {
+		return nil, fmt.Errorf("invalid rates_url: %w", err)
+	}
+
+	return &ServiceRatesSource{
+		url:    ratesURL,
+		client: &http.Client{Timeout: defaultServiceTimeout},
+	}, nil
+}
+
+// Rates fetches the rates of base. A 404 response means the service does not know base.
+func (s *ServiceRatesSource) Rates(ctx context.Context, base string) (*Rates, error) {
+	requestURL := strings.ReplaceAll(s.url, basePlaceholder, url.PathEscape(base))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w '%s'", ErrUnknownCurrency, base)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return nil, fmt.Errorf("rates service returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var body struct {
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, errors.New("rates service response is not a JSON object with numeric rates")
+	}
+
+	if body.Rates == nil {
+		return nil, errors.New("rates service response has no rates")
+	}
+
+	return &Rates{Base: base, Rates: body.Rates, FetchedAt: time.Now()}, nil
+}
+
+// CachedRatesSource keeps the rates of each base currency of a source for a refresh interval,
+// fetching them again on the first request after it elapsed.
+type CachedRatesSource struct {
+	source  RatesSource
+	refresh time.Duration
+	now     func() time.Time
+
+	mu     sync.Mutex
+	cached map[string]*Rates
+}
+
+// NewCachedRatesSource caches the rates of source for refresh.
+func NewCachedRatesSource(source RatesSource, refresh time.Duration) *CachedRatesSource {
+	return &CachedRatesSource{
+		source:  source,
+		refresh: refresh,
+		now:     time.Now,
+		cached:  make(map[string]*Rates),
+	}
+}
+
+// Rates returns the cached rates of base, fetching them when they are missing or older than the
+// refresh interval. Fetches are serialized, so concurrent requests share a single one.
+func (c *CachedRatesSource) Rates(ctx context.Context, base string) (*Rates, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if rates, ok := c.cached[base]; ok && c.now().Sub(rates.FetchedAt) < c.refresh {
+		return rates, nil
+	}
+
+	rates, err := c.source.Rates(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := *rates
+	cached.FetchedAt = c.now()
+	c.cached[base] = &cached
+
+	return &cached, nil
+}
@@ -0,0 +1,235 @@
+// Package dedupe provides a node that skips items already handled by previous executions.
+package dedupe
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortNew       = "new"
+	OutputPortDuplicate = "duplicate"
+	OutputPortError     = "error"
+	InputPortMain       = "main"
+)
+
+// DefaultTTL is how long a key is remembered when no ttl is configured.
+const DefaultTTL = 24 * time.Hour
+
+// DedupeNode implements the Node interface for deduplicating items across executions.
+type DedupeNode struct {
+	id    string
+	key   string
+	ttl   time.Duration
+	store SeenStore
+}
+
+// NewDedupeNode creates a new dedupe node recording seen keys in store.
+func NewDedupeNode(id string, config map[string]any, store SeenStore) (*DedupeNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	key, _ := config["key"].(string)
+
+	ttl := DefaultTTL
+	if raw, ok := config["ttl"].(string); ok && raw != "" {
+		ttl, _ = time.ParseDuration(raw)
+	}
+
+	return &DedupeNode{
+		id:    id,
+		key:   key,
+		ttl:   ttl,
+		store: store,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *DedupeNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *DedupeNode) Type() string {
+	return "dedupe"
+}
+
+// Execute routes the input to the duplicate port when its key was seen within the TTL,
+// otherwise records the key and routes the input to the new port.
+func (n *DedupeNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	data := make(map[string]any)
+	if input, ok := inputs[InputPortMain]; ok {
+		maps.Copy(data, input.Data)
+	}
+
+	key, err := n.computeKey(ctx, data)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	// Keys are scoped to the workflow so workflows never see each other's items
+	seen, err := n.store.MarkSeen(context.Background(), ctx.WorkflowID+":"+key, n.ttl)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to check key %s: %v", key, err)), nil
+	}
+
+	port := OutputPortNew
+	if seen {
+		port = OutputPortDuplicate
+	}
+
+	return map[string]models.NodeResult{
+		port: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// computeKey renders the configured key template, or hashes the input data when no key is configured.
+func (n *DedupeNode) computeKey(ctx models.ExecutionContext, data map[string]any) (string, error) {
+	if n.key == "" {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode input: %w", err)
+		}
+
+		sum := sha256.Sum256(encoded)
+
+		return hex.EncodeToString(sum[:]), nil
+	}
+
+	rendered, err := template.RenderWithContext(n.key, &ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to render key template: %w", err)
+	}
+
+	key := fmt.Sprintf("%v", rendered)
+	if key == "" {
+		return "", errors.New("rendered key is empty")
+	}
+
+	return key, nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *DedupeNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *DedupeNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Item to deduplicate",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *DedupeNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortNew),
+				NodeID:      n.id,
+				Name:        OutputPortNew,
+				Description: "Items not seen within the TTL; their key is recorded",
+				Schema: map[string]any{
+					"type": "object",
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortDuplicate),
+				NodeID:      n.id,
+				Name:        OutputPortDuplicate,
+				Description: "Items whose key was already seen within the TTL",
+				Schema: map[string]any{
+					"type": "object",
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the key cannot be computed or checked",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the dedupe node.
+func (n *DedupeNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *DedupeNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the deduplication fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	if key, exists := config["key"]; exists {
+		if _, ok := key.(string); !ok {
+			return errors.New("field 'key' must be a string")
+		}
+	}
+
+	if raw, exists := config["ttl"]; exists {
+		s, ok := raw.(string)
+		if !ok {
+			return errors.New("field 'ttl' must be a duration string")
+		}
+
+		if s != "" {
+			ttl, err := time.ParseDuration(s)
+			if err != nil || ttl <= 0 {
+				return fmt.Errorf("invalid ttl '%s'", s)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package dedupe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// fakeStore is an in-memory SeenStore with a controllable clock.
+type fakeStore struct {
+	now     time.Time
+	expires map[string]time.Time
+	err     error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		now:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		expires: make(map[string]time.Time),
+	}
+}
+
+func (s *fakeStore) MarkSeen(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+
+	if expiresAt, exists := s.expires[key]; exists && s.now.Before(expiresAt) {
+		return true, nil
+	}
+
+	s.expires[key] = s.now.Add(ttl)
+
+	return false, nil
+}
+
+func executeDedupe(t *testing.T, node *DedupeNode, workflowID string, orderID string) (string, models.NodeResult) {
+	t.Helper()
+
+	ctx := models.ExecutionContext{
+		WorkflowID:  workflowID,
+		TriggerData: map[string]any{"order_id": orderID},
+	}
+	inputs := map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{"order_id": orderID}},
+	}
+
+	results, err := node.Execute(ctx, inputs)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("Expected exactly one output port, got: %v", results)
+	}
+
+	for port, result := range results {
+		return port, result
+	}
+
+	return "", models.NodeResult{}
+}
+
+func TestNewDedupeNode(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{"key": 42},
+		{"ttl": "soon"},
+		{"ttl": "-1h"},
+	}
+
+	for _, config := range invalidConfigs {
+		if _, err := NewDedupeNode("dedupe", config, newFakeStore()); err == nil {
+			t.Errorf("Expected error for config %v", config)
+		}
+	}
+
+	node, err := NewDedupeNode("dedupe", map[string]any{}, newFakeStore())
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if node.ttl != DefaultTTL {
+		t.Errorf("Expected default ttl %s, got: %s", DefaultTTL, node.ttl)
+	}
+}
+
+func TestDedupeNode_Execute_SameKeyTwice(t *testing.T) {
+	node, err := NewDedupeNode("dedupe", map[string]any{"key": "{{.trigger_data.order_id}}", "ttl": "1h"}, newFakeStore())
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	port, result := executeDedupe(t, node, "orders", "order-1")
+	if port != OutputPortNew {
+		t.Errorf("Expected first item to route to 'new', got: %s", port)
+	}
+
+	if result.Data["order_id"] != "order-1" {
+		t.Errorf("Expected input data to pass through, got: %v", result.Data)
+	}
+
+	if port, _ := executeDedupe(t, node, "orders", "order-1"); port != OutputPortDuplicate {
+		t.Errorf("Expected repeated item to route to 'duplicate', got: %s", port)
+	}
+
+	if port, _ := executeDedupe(t, node, "orders", "order-2"); port != OutputPortNew {
+		t.Errorf("Expected a different key to route to 'new', got: %s", port)
+	}
+
+	if port, _ := executeDedupe(t, node, "other-workflow", "order-1"); port != OutputPortNew {
+		t.Errorf("Expected keys to be scoped per workflow, got: %s", port)
+	}
+}
+
+func TestDedupeNode_Execute_TTLExpiry(t *testing.T) {
+	store := newFakeStore()
+
+	node, err := NewDedupeNode("dedupe", map[string]any{"key": "{{.trigger_data.order_id}}", "ttl": "1h"}, store)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if port, _ := executeDedupe(t, node, "orders", "order-1"); port != OutputPortNew {
+		t.Fatalf("Expected 'new', got: %s", port)
+	}
+
+	store.now = store.now.Add(59 * time.Minute)
+	if port, _ := executeDedupe(t, node, "orders", "order-1"); port != OutputPortDuplicate {
+		t.Errorf("Expected 'duplicate' within the TTL, got: %s", port)
+	}
+
+	store.now = store.now.Add(2 * time.Minute)
+	if port, _ := executeDedupe(t, node, "orders", "order-1"); port != OutputPortNew {
+		t.Errorf("Expected 'new' after the TTL expired, got: %s", port)
+	}
+}
+
+func TestDedupeNode_Execute_DefaultKeyHashesInput(t *testing.T) {
+	node, err := NewDedupeNode("dedupe", map[string]any{}, newFakeStore())
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if port, _ := executeDedupe(t, node, "orders", "order-1"); port != OutputPortNew {
+		t.Errorf("Expected 'new', got: %s", port)
+	}
+
+	if port, _ := executeDedupe(t, node, "orders", "order-1"); port != OutputPortDuplicate {
+		t.Errorf("Expected identical input to be a duplicate, got: %s", port)
+	}
+
+	if port, _ := executeDedupe(t, node, "orders", "order-2"); port != OutputPortNew {
+		t.Errorf("Expected different input to be new, got: %s", port)
+	}
+}
+
+func TestDedupeNode_Execute_StoreError(t *testing.T) {
+	store := newFakeStore()
+	store.err = errors.New("connection refused")
+
+	node, err := NewDedupeNode("dedupe", map[string]any{"key": "{{.trigger_data.order_id}}"}, store)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if port, _ := executeDedupe(t, node, "orders", "order-1"); port != OutputPortError {
+		t.Errorf("Expected 'error' when the store fails, got: %s", port)
+	}
+}
+
+func TestDedupeNodeFactory_Create(t *testing.T) {
+	factory := NewDedupeNodeFactory()
+
+	_, err := factory.Create(context.Background(), "dedupe", map[string]any{
+		"backend":        "memcached",
+		"connection_url": "memcached://localhost",
+	})
+	if err == nil {
+		t.Error("Expected error for unsupported backend")
+	}
+
+	_, err = factory.Create(context.Background(), "dedupe", map[string]any{
+		"backend":        BackendPostgres,
+		"connection_url": "postgres://localhost/operion",
+		"table":          "keys; DROP TABLE workflows",
+	})
+	if err == nil {
+		t.Error("Expected error for invalid table name")
+	}
+
+	node, err := factory.Create(context.Background(), "dedupe", map[string]any{
+		"backend":        BackendRedis,
+		"connection_url": "redis://localhost:6379/0",
+		"ttl":            "2h",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if node.(*DedupeNode).ttl != 2*time.Hour {
+		t.Errorf("Expected ttl 2h, got: %s", node.(*DedupeNode).ttl)
+	}
+}
@@ -0,0 +1,110 @@
+package dedupe
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	BackendRedis    = "redis"
+	BackendPostgres = "postgres"
+
+	// DefaultTable is the Postgres table holding seen keys when none is configured.
+	DefaultTable = "operion_dedupe_keys"
+)
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// SeenStore is a persistent set of keys that expire after a TTL.
+type SeenStore interface {
+	// MarkSeen atomically records key for ttl and reports whether it was already recorded
+	// and not yet expired. An already seen key keeps its original expiry.
+	MarkSeen(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// RedisStore keeps seen keys in Redis, relying on key expiry for the TTL.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a store connected to the Redis instance at url (redis://host:port/db).
+func NewRedisStore(url string) (*RedisStore, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis connection_url: %w", err)
+	}
+
+	return &RedisStore{client: redis.NewClient(options)}, nil
+}
+
+// MarkSeen records key with SET NX, so only the first caller within the TTL sees it as new.
+func (s *RedisStore) MarkSeen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	created, err := s.client.SetNX(ctx, key, time.Now().UTC().Unix(), ttl).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return !created, nil
+}
+
+// PostgresStore keeps seen keys in a Postgres table with their expiry time.
+type PostgresStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewPostgresStore creates a store keeping seen keys in table, creating the table if needed.
+func NewPostgresStore(ctx context.Context, url, table string) (*PostgresStore, error) {
+	if !identifierPattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name '%s'", table)
+	}
+
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
+	}
+
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		key TEXT PRIMARY KEY,
+		expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+	)`, table)
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("failed to create dedupe table: %w", err)
+	}
+
+	return &PostgresStore{db: db, table: table}, nil
+}
+
+// MarkSeen inserts key, replacing it only when the stored entry has expired.
+func (s *PostgresStore) MarkSeen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]s (key, expires_at)
+		VALUES ($1, NOW() + $2 * INTERVAL '1 millisecond')
+		ON CONFLICT (key) DO UPDATE SET expires_at = EXCLUDED.expires_at
+		WHERE %[1]s.expires_at <= NOW()
+		RETURNING key
+	`, s.table)
+
+	var inserted string
+
+	err := s.db.QueryRowContext(ctx, query, key, ttl.Milliseconds()).Scan(&inserted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
@@ -0,0 +1,79 @@
+// Package encode provides encode node factory for registry integration.
+package encode
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// EncodeNodeFactory creates EncodeNode instances.
+type EncodeNodeFactory struct{}
+
+// Create creates a new EncodeNode instance.
+func (f *EncodeNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewEncodeNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *EncodeNodeFactory) ID() string {
+	return "encode"
+}
+
+// Name returns the factory name.
+func (f *EncodeNodeFactory) Name() string {
+	return "Encode / Decode"
+}
+
+// Description returns the factory description.
+func (f *EncodeNodeFactory) Description() string {
+	return "Converts text to or from base64, base64url, hex, URL encoding or gzip, to bridge APIs with different encoding expectations"
+}
+
+// Schema returns the JSON schema for encode node configuration.
+func (f *EncodeNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"input": map[string]any{
+				"type":        "string",
+				"description": "Text to convert. Supports templating.",
+				"examples":    []string{"{{.node_results.fetch_file.body}}"},
+			},
+			"encoding": map[string]any{
+				"type":        "string",
+				"description": "Encoding to convert to or from. Gzip data is carried as standard base64.",
+				"enum":        Encodings,
+			},
+			"operation": map[string]any{
+				"type":        "string",
+				"description": "Direction of the conversion",
+				"enum":        []string{OperationEncode, OperationDecode},
+				"default":     OperationEncode,
+			},
+			"max_output_bytes": map[string]any{
+				"type":        "integer",
+				"description": "Maximum size in bytes of the text a gzip decode inflates to; larger output routes to the error port",
+				"default":     DefaultMaxOutputBytes,
+				"minimum":     1,
+			},
+		},
+		"required": []string{"input", "encoding"},
+		"examples": []map[string]any{
+			{
+				"input":     "{{.trigger_data.payload}}",
+				"encoding":  "base64",
+				"operation": "decode",
+			},
+			{
+				"input":    "{{.node_results.build_report.result}}",
+				"encoding": "gzip",
+			},
+		},
+	}
+}
+
+// NewEncodeNodeFactory creates a new factory instance.
+func NewEncodeNodeFactory() protocol.NodeFactory {
+	return &EncodeNodeFactory{}
+}
@@ -0,0 +1,306 @@
+// Package encode provides a node that converts data between encodings such as base64 and gzip.
+package encode
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"slices"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+const (
+	OperationEncode = "encode"
+	OperationDecode = "decode"
+)
+
+// DefaultMaxOutputBytes bounds the text a gzip decode may inflate to when the node sets no
+// max_output_bytes.
+const DefaultMaxOutputBytes = 10 << 20
+
+// ErrOutputTooLarge is the failure of a gzip decode inflating past the maximum output size.
+var ErrOutputTooLarge = errors.New("decoded output exceeds max_output_bytes")
+
+// Encodings are the supported encodings. Gzip data is carried as standard base64, so gzip encode
+// yields base64 of the compressed input and gzip decode expects it.
+var Encodings = []string{"base64", "base64url", "hex", "url", "gzip"}
+
+// EncodeNode implements the Node interface for encoding or decoding its input.
+type EncodeNode struct {
+	id             string
+	input          string
+	encoding       string
+	operation      string
+	maxOutputBytes int64
+}
+
+// NewEncodeNode creates a new encode node.
+func NewEncodeNode(id string, config map[string]any) (*EncodeNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	input, _ := config["input"].(string)
+	encoding, _ := config["encoding"].(string)
+
+	operation, _ := config["operation"].(string)
+	if operation == "" {
+		operation = OperationEncode
+	}
+
+	maxOutputBytes, _ := maxOutputBytesSetting(config["max_output_bytes"])
+
+	return &EncodeNode{
+		id:             id,
+		input:          input,
+		encoding:       encoding,
+		operation:      operation,
+		maxOutputBytes: maxOutputBytes,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *EncodeNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *EncodeNode) Type() string {
+	return "encode"
+}
+
+// Execute renders the input and encodes or decodes it, placing the text in the result.
+func (n *EncodeNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	input, err := template.RenderStringWithContext(n.input, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render input template: %v", err)), nil
+	}
+
+	var result string
+	if n.operation == OperationDecode {
+		result, err = decode(n.encoding, input, n.maxOutputBytes)
+	} else {
+		result, err = encode(n.encoding, input)
+	}
+
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to %s %s: %v", n.operation, n.encoding, err)), nil
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   map[string]any{"result": result},
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+func encode(encoding, input string) (string, error) {
+	switch encoding {
+	case "base64":
+		return base64.StdEncoding.EncodeToString([]byte(input)), nil
+	case "base64url":
+		return base64.URLEncoding.EncodeToString([]byte(input)), nil
+	case "hex":
+		return hex.EncodeToString([]byte(input)), nil
+	case "url":
+		return url.QueryEscape(input), nil
+	case "gzip":
+		var compressed bytes.Buffer
+
+		writer := gzip.NewWriter(&compressed)
+		if _, err := writer.Write([]byte(input)); err != nil {
+			return "", err
+		}
+
+		if err := writer.Close(); err != nil {
+			return "", err
+		}
+
+		return base64.StdEncoding.EncodeToString(compressed.Bytes()), nil
+	}
+
+	return "", fmt.Errorf("unsupported encoding '%s'", encoding)
+}
+
+// decode decodes input, failing with ErrOutputTooLarge when gzip data inflates past
+// maxOutputBytes. The other encodings never decode to more than their input.
+func decode(encoding, input string, maxOutputBytes int64) (string, error) {
+	switch encoding {
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(input)
+
+		return string(decoded), err
+	case "base64url":
+		decoded, err := base64.URLEncoding.DecodeString(input)
+
+		return string(decoded), err
+	case "hex":
+		decoded, err := hex.DecodeString(input)
+
+		return string(decoded), err
+	case "url":
+		return url.QueryUnescape(input)
+	case "gzip":
+		compressed, err := base64.StdEncoding.DecodeString(input)
+		if err != nil {
+			return "", err
+		}
+
+		reader, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return "", err
+		}
+		defer func() { _ = reader.Close() }()
+
+		decompressed, err := io.ReadAll(io.LimitReader(reader, maxOutputBytes+1))
+		if err != nil {
+			return "", err
+		}
+
+		if int64(len(decompressed)) > maxOutputBytes {
+			return "", fmt.Errorf("%w (%d bytes)", ErrOutputTooLarge, maxOutputBytes)
+		}
+
+		return string(decompressed), nil
+	}
+
+	return "", fmt.Errorf("unsupported encoding '%s'", encoding)
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *EncodeNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *EncodeNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Triggers the conversion; the input is read from the execution context through its template",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *EncodeNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "The encoded or decoded text",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"result": map[string]any{"type": "string"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the input cannot be rendered or is not validly encoded",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the encode node.
+func (n *EncodeNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *EncodeNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	if _, ok := config["input"].(string); !ok {
+		return errors.New("missing required field 'input'")
+	}
+
+	encoding, _ := config["encoding"].(string)
+	if !slices.Contains(Encodings, encoding) {
+		return fmt.Errorf("encoding '%s' must be one of %v", encoding, Encodings)
+	}
+
+	if operation, exists := config["operation"]; exists {
+		if operation != OperationEncode && operation != OperationDecode {
+			return fmt.Errorf("operation '%v' must be '%s' or '%s'", operation, OperationEncode, OperationDecode)
+		}
+	}
+
+	if _, ok := maxOutputBytesSetting(config["max_output_bytes"]); !ok {
+		return fmt.Errorf("max_output_bytes '%v' must be a positive whole number", config["max_output_bytes"])
+	}
+
+	return nil
+}
+
+// maxOutputBytesSetting converts a max_output_bytes value to bytes, DefaultMaxOutputBytes when
+// unset, reporting whether it is a positive whole number.
+func maxOutputBytesSetting(value any) (int64, bool) {
+	switch v := value.(type) {
+	case nil:
+		return DefaultMaxOutputBytes, true
+	case int:
+		return maxOutputBytesSetting(float64(v))
+	case float64:
+		if v != math.Trunc(v) || v < 1 || v > math.MaxInt64/2 {
+			return 0, false
+		}
+
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
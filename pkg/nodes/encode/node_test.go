@@ -0,0 +1,127 @@
+package encode
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func execute(t *testing.T, config map[string]any, value string) map[string]models.NodeResult {
+	t.Helper()
+
+	node, err := NewEncodeNode("convert", config)
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{
+		TriggerData: map[string]any{"value": value},
+	}, map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	return results
+}
+
+func TestNewEncodeNode_InvalidConfig(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{},
+		{"input": "{{.trigger_data.value}}"},
+		{"input": "{{.trigger_data.value}}", "encoding": "rot13"},
+		{"input": "{{.trigger_data.value}}", "encoding": "hex", "operation": "reverse"},
+		{"input": "{{.trigger_data.value}}", "encoding": "gzip", "max_output_bytes": 0},
+		{"input": "{{.trigger_data.value}}", "encoding": "gzip", "max_output_bytes": 1.5},
+		{"input": "{{.trigger_data.value}}", "encoding": "gzip", "max_output_bytes": "1MB"},
+	}
+
+	for _, config := range invalidConfigs {
+		_, err := NewEncodeNode("convert", config)
+		assert.Error(t, err, "config %v", config)
+	}
+}
+
+func TestEncodeNode_RoundTrip(t *testing.T) {
+	original := "héllo wörld & friends/?=+ {\"a\": 1}"
+
+	for _, encoding := range Encodings {
+		t.Run(encoding, func(t *testing.T) {
+			encoded := execute(t, map[string]any{"input": "{{.trigger_data.value}}", "encoding": encoding}, original)
+			require.Contains(t, encoded, OutputPortSuccess)
+
+			text, _ := encoded[OutputPortSuccess].Data["result"].(string)
+			assert.NotEqual(t, original, text)
+
+			decoded := execute(t, map[string]any{
+				"input": "{{.trigger_data.value}}", "encoding": encoding, "operation": OperationDecode,
+			}, text)
+			require.Contains(t, decoded, OutputPortSuccess)
+			assert.Equal(t, original, decoded[OutputPortSuccess].Data["result"])
+		})
+	}
+}
+
+func TestEncodeNode_KnownEncodings(t *testing.T) {
+	expected := map[string]string{
+		"base64":    "Pz8/",
+		"base64url": "Pz8_",
+		"hex":       "3f3f3f",
+		"url":       "%3F%3F%3F",
+	}
+
+	for encoding, want := range expected {
+		results := execute(t, map[string]any{"input": "{{.trigger_data.value}}", "encoding": encoding}, "???")
+		assert.Equal(t, want, results[OutputPortSuccess].Data["result"], encoding)
+	}
+}
+
+func TestEncodeNode_InvalidInputRoutesToError(t *testing.T) {
+	for encoding, input := range map[string]string{
+		"base64":    "not base64!",
+		"base64url": "a+b/",
+		"hex":       "xyz",
+		"url":       "%zz",
+		"gzip":      "aGVsbG8=",
+	} {
+		t.Run(encoding, func(t *testing.T) {
+			results := execute(t, map[string]any{
+				"input": "{{.trigger_data.value}}", "encoding": encoding, "operation": OperationDecode,
+			}, input)
+			require.Contains(t, results, OutputPortError)
+
+			result := results[OutputPortError]
+			assert.Equal(t, string(models.NodeStatusError), result.Status)
+			assert.Contains(t, result.Error, "failed to decode "+encoding)
+			assert.Equal(t, false, result.Data["success"])
+		})
+	}
+}
+
+func TestEncodeNode_GzipDecodeStopsAtMaxOutputBytes(t *testing.T) {
+	// 1 MiB of zeros compresses to about a kilobyte
+	var compressed bytes.Buffer
+
+	writer := gzip.NewWriter(&compressed)
+	_, err := writer.Write(make([]byte, 1<<20))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	input := base64.StdEncoding.EncodeToString(compressed.Bytes())
+
+	results := execute(t, map[string]any{
+		"input": "{{.trigger_data.value}}", "encoding": "gzip", "operation": OperationDecode, "max_output_bytes": 1024,
+	}, input)
+	require.Contains(t, results, OutputPortError)
+	assert.Contains(t, results[OutputPortError].Error, ErrOutputTooLarge.Error())
+
+	// A stream of exactly the maximum size decodes
+	results = execute(t, map[string]any{
+		"input": "{{.trigger_data.value}}", "encoding": "gzip", "operation": OperationDecode, "max_output_bytes": float64(1 << 20),
+	}, input)
+	require.Contains(t, results, OutputPortSuccess)
+	assert.Len(t, results[OutputPortSuccess].Data["result"], 1<<20)
+}
@@ -0,0 +1,147 @@
+// Package geoip provides GeoIP node factory for registry integration.
+package geoip
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// GeoIPNodeFactory creates GeoIPNode instances, sharing one resolver per database file or
+// lookup service.
+type GeoIPNodeFactory struct {
+	mu        sync.Mutex
+	resolvers map[string]Resolver
+}
+
+// Create creates a new GeoIPNode instance.
+func (f *GeoIPNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	resolver, err := f.resolver(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGeoIPNode(id, config, resolver)
+}
+
+// resolver returns the resolver for the configured source, opening it on first use. The
+// database path and service URL are expanded from environment variables, so a service API
+// key can stay out of workflow definitions.
+func (f *GeoIPNodeFactory) resolver(config map[string]any) (Resolver, error) {
+	source, _ := config["source"].(string)
+	if source == "" {
+		source = SourceDatabase
+	}
+
+	location, _ := config["database"].(string)
+	if source == SourceService {
+		location, _ = config["service_url"].(string)
+	}
+
+	location = os.ExpandEnv(location)
+	cacheKey := source + "|" + location
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if resolver, exists := f.resolvers[cacheKey]; exists {
+		return resolver, nil
+	}
+
+	var (
+		resolver Resolver
+		err      error
+	)
+
+	if source == SourceService {
+		resolver, err = NewServiceResolver(location)
+	} else {
+		resolver, err = NewDatabaseResolver(location)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	f.resolvers[cacheKey] = resolver
+
+	return resolver, nil
+}
+
+// ID returns the factory ID.
+func (f *GeoIPNodeFactory) ID() string {
+	return "geoip"
+}
+
+// Name returns the factory name.
+func (f *GeoIPNodeFactory) Name() string {
+	return "GeoIP"
+}
+
+// Description returns the factory description.
+func (f *GeoIPNodeFactory) Description() string {
+	return "Enriches data with the country, city, coordinates and ASN of an IP address from a MaxMind database or a lookup service"
+}
+
+// Schema returns the JSON schema for GeoIP node configuration.
+func (f *GeoIPNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"ip": map[string]any{
+				"type":        "string",
+				"description": "IP address to locate. Supports templating.",
+				"examples":    []string{"{{.trigger_data.headers.X-Forwarded-For}}", "{{.trigger_data.body.client_ip}}"},
+			},
+			"source": map[string]any{
+				"type":        "string",
+				"description": "Where locations are resolved from",
+				"enum":        []string{SourceDatabase, SourceService},
+				"default":     SourceDatabase,
+			},
+			"database": map[string]any{
+				"type":        "string",
+				"description": "Path of a MaxMind database file, such as GeoLite2-City or GeoLite2-ASN (database source only). Environment variables are expanded.",
+				"examples":    []string{"/var/lib/geoip/GeoLite2-City.mmdb"},
+			},
+			"service_url": map[string]any{
+				"type":        "string",
+				"description": "URL of a lookup service answering GET with a JSON object, {ip} is replaced with the address (service source only). Environment variables are expanded.",
+				"examples":    []string{"https://ipinfo.io/{ip}/json?token=${IPINFO_TOKEN}"},
+			},
+			"target_field": map[string]any{
+				"type":        "string",
+				"description": "Field of the input data to store the location under",
+				"default":     defaultTargetField,
+				"examples":    []string{"client_location"},
+			},
+		},
+		"required": []string{"ip"},
+		"examples": []map[string]any{
+			{
+				"ip":       "{{.trigger_data.body.client_ip}}",
+				"source":   SourceDatabase,
+				"database": "${GEOIP_DATABASE_PATH}",
+			},
+			{
+				"ip":           "{{.trigger_data.body.client_ip}}",
+				"source":       SourceService,
+				"service_url":  "https://ipinfo.io/{ip}/json?token=${IPINFO_TOKEN}",
+				"target_field": "client_location",
+			},
+		},
+	}
+}
+
+// NewGeoIPNodeFactory creates a new factory instance.
+func NewGeoIPNodeFactory() protocol.NodeFactory {
+	return &GeoIPNodeFactory{
+		resolvers: make(map[string]Resolver),
+	}
+}
@@ -0,0 +1,251 @@
+// Package geoip provides a node that enriches data with the location of an IP address.
+package geoip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/netip"
+	"strings"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess  = "success"
+	OutputPortNotFound = "not_found"
+	OutputPortError    = "error"
+	InputPortMain      = "main"
+)
+
+// Reasons an address is routed to the not_found port.
+const (
+	ReasonPrivate  = "private"
+	ReasonNoRecord = "no_record"
+)
+
+// defaultTargetField is the field the location is stored under when none is configured.
+const defaultTargetField = "geoip"
+
+// GeoIPNode implements the Node interface for enriching data with the location of an IP address.
+type GeoIPNode struct {
+	id          string
+	ip          string
+	targetField string
+	resolver    Resolver
+}
+
+// NewGeoIPNode creates a new GeoIP node resolving addresses with resolver.
+func NewGeoIPNode(id string, config map[string]any, resolver Resolver) (*GeoIPNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	ip, _ := config["ip"].(string)
+
+	targetField, _ := config["target_field"].(string)
+	if targetField == "" {
+		targetField = defaultTargetField
+	}
+
+	return &GeoIPNode{
+		id:          id,
+		ip:          ip,
+		targetField: targetField,
+		resolver:    resolver,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *GeoIPNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *GeoIPNode) Type() string {
+	return "geoip"
+}
+
+// Execute resolves the rendered IP address and stores its location in the input data.
+// Private and reserved addresses, which have no location, go to the not_found port without
+// a lookup.
+func (n *GeoIPNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	rendered, err := template.RenderWithContext(n.ip, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render ip template: %v", err)), nil
+	}
+
+	ip := strings.TrimSpace(fmt.Sprintf("%v", rendered))
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("invalid IP address '%s'", ip)), nil
+	}
+
+	addr = addr.Unmap()
+
+	data := make(map[string]any)
+	if input, ok := inputs[InputPortMain]; ok {
+		maps.Copy(data, input.Data)
+	}
+
+	if !isPublic(addr) {
+		return n.createNotFoundResult(data, addr, ReasonPrivate), nil
+	}
+
+	record, found, err := n.resolver.Lookup(context.Background(), addr)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("lookup failed for %s: %v", addr, err)), nil
+	}
+
+	if !found {
+		return n.createNotFoundResult(data, addr, ReasonNoRecord), nil
+	}
+
+	location := map[string]any{"ip": addr.String()}
+	maps.Copy(location, record)
+	data[n.targetField] = location
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// isPublic reports whether addr is a globally routable unicast address.
+func isPublic(addr netip.Addr) bool {
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
+
+// createNotFoundResult creates a NodeResult for the not_found output port, recording in the
+// target field why addr has no location.
+func (n *GeoIPNode) createNotFoundResult(data map[string]any, addr netip.Addr, reason string) map[string]models.NodeResult {
+	data[n.targetField] = map[string]any{
+		"ip":     addr.String(),
+		"reason": reason,
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortNotFound: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *GeoIPNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *GeoIPNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Data to enrich with the location of the IP address",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *GeoIPNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Input data with the location of the address under the target field",
+				Schema: map[string]any{
+					"type": "object",
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortNotFound),
+				NodeID:      n.id,
+				Name:        OutputPortNotFound,
+				Description: "Input data with the reason under the target field, when the address is private or has no record",
+				Schema: map[string]any{
+					"type": "object",
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the address is invalid or the lookup fails",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the GeoIP node.
+func (n *GeoIPNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *GeoIPNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the GeoIP fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	if ip, ok := config["ip"].(string); !ok || ip == "" {
+		return errors.New("missing required field 'ip'")
+	}
+
+	source, _ := config["source"].(string)
+
+	switch source {
+	case "", SourceDatabase:
+		if database, ok := config["database"].(string); !ok || database == "" {
+			return errors.New("missing required field 'database' for database source")
+		}
+	case SourceService:
+		if serviceURL, ok := config["service_url"].(string); !ok || serviceURL == "" {
+			return errors.New("missing required field 'service_url' for service source")
+		}
+	default:
+		return fmt.Errorf("unsupported source '%s' (supported: database, service)", source)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package geoip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"path/filepath"
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver is an in-memory Resolver for tests, standing in for a GeoLite2 database.
+type fakeResolver struct {
+	records map[netip.Addr]map[string]any
+	lookups []netip.Addr
+}
+
+func (r *fakeResolver) Lookup(_ context.Context, addr netip.Addr) (map[string]any, bool, error) {
+	r.lookups = append(r.lookups, addr)
+	record, found := r.records[addr]
+
+	return record, found, nil
+}
+
+func newFakeResolver() *fakeResolver {
+	return &fakeResolver{
+		records: map[netip.Addr]map[string]any{
+			netip.MustParseAddr("81.2.69.142"): {
+				"country_code":    "GB",
+				"country":         "United Kingdom",
+				"city":            "London",
+				"latitude":        51.5142,
+				"longitude":       -0.0931,
+				"asn":             uint(20712),
+				"as_organization": "Andrews & Arnold Ltd",
+			},
+		},
+	}
+}
+
+func executeGeoIP(t *testing.T, config map[string]any, resolver Resolver, ip string) map[string]models.NodeResult {
+	t.Helper()
+
+	node, err := NewGeoIPNode("locate", config, resolver)
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{
+		TriggerData: map[string]any{"client_ip": ip},
+	}, map[string]models.NodeResult{
+		InputPortMain: {NodeID: "webhook", Data: map[string]any{"event": "login"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	return results
+}
+
+var databaseConfig = map[string]any{
+	"ip":       "{{.trigger_data.client_ip}}",
+	"database": "GeoLite2-City.mmdb",
+}
+
+func TestNewGeoIPNode_InvalidConfig(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{"database": "GeoLite2-City.mmdb"},
+		{"ip": "{{.trigger_data.client_ip}}"},
+		{"ip": "{{.trigger_data.client_ip}}", "source": SourceService},
+		{"ip": "{{.trigger_data.client_ip}}", "source": "whois", "database": "GeoLite2-City.mmdb"},
+	}
+
+	for _, config := range invalidConfigs {
+		_, err := NewGeoIPNode("locate", config, newFakeResolver())
+		assert.Error(t, err, "config %v", config)
+	}
+}
+
+func TestGeoIPNode_Execute_PublicIP(t *testing.T) {
+	results := executeGeoIP(t, databaseConfig, newFakeResolver(), "81.2.69.142")
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok, "results: %v", results)
+	assert.Equal(t, "login", result.Data["event"], "the input is passed through")
+	assert.Equal(t, map[string]any{
+		"ip":              "81.2.69.142",
+		"country_code":    "GB",
+		"country":         "United Kingdom",
+		"city":            "London",
+		"latitude":        51.5142,
+		"longitude":       -0.0931,
+		"asn":             uint(20712),
+		"as_organization": "Andrews & Arnold Ltd",
+	}, result.Data[defaultTargetField])
+}
+
+func TestGeoIPNode_Execute_PrivateIP(t *testing.T) {
+	for _, ip := range []string{"10.1.2.3", "192.168.0.10", "127.0.0.1", "fd00::1", "::ffff:172.16.0.1"} {
+		resolver := newFakeResolver()
+		results := executeGeoIP(t, databaseConfig, resolver, ip)
+
+		result, ok := results[OutputPortNotFound]
+		require.True(t, ok, "ip %s results: %v", ip, results)
+		assert.Equal(t, ReasonPrivate, result.Data[defaultTargetField].(map[string]any)["reason"])
+		assert.Empty(t, resolver.lookups, "private addresses are not looked up")
+	}
+}
+
+func TestGeoIPNode_Execute_NoRecord(t *testing.T) {
+	results := executeGeoIP(t, map[string]any{
+		"ip":           "{{.trigger_data.client_ip}}",
+		"database":     "GeoLite2-City.mmdb",
+		"target_field": "client_location",
+	}, newFakeResolver(), "8.8.8.8")
+
+	result, ok := results[OutputPortNotFound]
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"ip": "8.8.8.8", "reason": ReasonNoRecord}, result.Data["client_location"])
+}
+
+func TestGeoIPNode_Execute_InvalidIP(t *testing.T) {
+	results := executeGeoIP(t, databaseConfig, newFakeResolver(), "not-an-ip")
+
+	result, ok := results[OutputPortError]
+	require.True(t, ok)
+	assert.Equal(t, "invalid IP address 'not-an-ip'", result.Error)
+}
+
+func TestGeoIPNodeFactory_ServiceSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.URL.Query().Get("token"))
+
+		if r.URL.Path != "/8.8.8.8/json" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"country": "US", "org": "AS15169 Google LLC", "loc": "37.4056,-122.0775"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("GEOIP_TEST_TOKEN", "secret")
+
+	node, err := NewGeoIPNodeFactory().Create(t.Context(), "locate", map[string]any{
+		"ip":          "{{.trigger_data.client_ip}}",
+		"source":      SourceService,
+		"service_url": server.URL + "/{ip}/json?token=${GEOIP_TEST_TOKEN}",
+	})
+	require.NoError(t, err)
+
+	execute := func(ip string) map[string]models.NodeResult {
+		results, err := node.Execute(models.ExecutionContext{
+			TriggerData: map[string]any{"client_ip": ip},
+		}, map[string]models.NodeResult{InputPortMain: {NodeID: "webhook", Data: map[string]any{}}})
+		require.NoError(t, err)
+
+		return results
+	}
+
+	result, ok := execute("8.8.8.8")[OutputPortSuccess]
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{
+		"ip":      "8.8.8.8",
+		"country": "US",
+		"org":     "AS15169 Google LLC",
+		"loc":     "37.4056,-122.0775",
+	}, result.Data[defaultTargetField])
+
+	_, ok = execute("1.1.1.1")[OutputPortNotFound]
+	assert.True(t, ok, "a 404 from the service means no record")
+}
+
+func TestGeoIPNodeFactory_MissingDatabase(t *testing.T) {
+	_, err := NewGeoIPNodeFactory().Create(t.Context(), "locate", map[string]any{
+		"ip":       "{{.trigger_data.client_ip}}",
+		"database": filepath.Join(t.TempDir(), "missing.mmdb"),
+	})
+	assert.ErrorContains(t, err, "failed to open GeoIP database")
+}
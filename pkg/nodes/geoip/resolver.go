@@ -0,0 +1,179 @@
+package geoip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang/v2"
+)
+
+const (
+	SourceDatabase = "database"
+	SourceService  = "service"
+)
+
+// ipPlaceholder is replaced with the looked-up address in the URL of a lookup service.
+const ipPlaceholder = "{ip}"
+
+// defaultServiceTimeout bounds a request to a lookup service.
+const defaultServiceTimeout = 10 * time.Second
+
+// Resolver resolves the location of IP addresses.
+type Resolver interface {
+	// Lookup returns the record of addr, or false when the source has none.
+	Lookup(ctx context.Context, addr netip.Addr) (map[string]any, bool, error)
+}
+
+// databaseRecord holds the fields read from a MaxMind database. City, Country and ASN
+// databases each fill a subset of them.
+type databaseRecord struct {
+	Country struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"country"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Location struct {
+		Latitude  *float64 `maxminddb:"latitude"`
+		Longitude *float64 `maxminddb:"longitude"`
+	} `maxminddb:"location"`
+	ASN          uint   `maxminddb:"autonomous_system_number"`
+	Organization string `maxminddb:"autonomous_system_organization"`
+}
+
+// DatabaseResolver resolves addresses from a MaxMind (GeoIP2 or GeoLite2) database file.
+type DatabaseResolver struct {
+	reader *maxminddb.Reader
+}
+
+// NewDatabaseResolver opens the MaxMind database at path.
+func NewDatabaseResolver(path string) (*DatabaseResolver, error) {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database %s: %w", path, err)
+	}
+
+	return &DatabaseResolver{reader: reader}, nil
+}
+
+// Lookup returns the country, city, coordinates and ASN the database holds for addr.
+// Fields the database does not provide are left out.
+func (r *DatabaseResolver) Lookup(_ context.Context, addr netip.Addr) (map[string]any, bool, error) {
+	result := r.reader.Lookup(addr)
+	if err := result.Err(); err != nil {
+		return nil, false, err
+	}
+
+	if !result.Found() {
+		return nil, false, nil
+	}
+
+	var record databaseRecord
+	if err := result.Decode(&record); err != nil {
+		return nil, false, fmt.Errorf("failed to decode GeoIP record: %w", err)
+	}
+
+	return record.fields(), true, nil
+}
+
+// fields returns the non-empty fields of the record.
+func (r databaseRecord) fields() map[string]any {
+	fields := make(map[string]any)
+
+	if r.Country.ISOCode != "" {
+		fields["country_code"] = r.Country.ISOCode
+	}
+
+	if name := r.Country.Names["en"]; name != "" {
+		fields["country"] = name
+	}
+
+	if name := r.City.Names["en"]; name != "" {
+		fields["city"] = name
+	}
+
+	if r.Location.Latitude != nil && r.Location.Longitude != nil {
+		fields["latitude"] = *r.Location.Latitude
+		fields["longitude"] = *r.Location.Longitude
+	}
+
+	if r.ASN != 0 {
+		fields["asn"] = r.ASN
+	}
+
+	if r.Organization != "" {
+		fields["as_organization"] = r.Organization
+	}
+
+	return fields
+}
+
+// ServiceResolver resolves addresses through an HTTP lookup service answering a GET with
+// a JSON object.
+type ServiceResolver struct {
+	url    string
+	client *http.Client
+}
+
+// NewServiceResolver creates a resolver requesting serviceURL, in which {ip} is replaced
+// with the looked-up address.
+func NewServiceResolver(serviceURL string) (*ServiceResolver, error) {
+	if !strings.Contains(serviceURL, ipPlaceholder) {
+		return nil, fmt.Errorf("service_url must contain the %s placeholder", ipPlaceholder)
+	}
+
+	if _, err := url.Parse(strings.ReplaceAll(serviceURL, ipPlaceholder, "127.0.0.1")); err != nil {
+		return nil, fmt.Errorf("invalid service_url: %w", err)
+	}
+
+	return &ServiceResolver{
+		url:    serviceURL,
+		client: &http.Client{Timeout: defaultServiceTimeout},
+	}, nil
+}
+
+// Lookup returns the JSON object the service answers for addr. A 404 response means the
+// service has no record of addr.
+func (r *ServiceResolver) Lookup(ctx context.Context, addr netip.Addr) (map[string]any, bool, error) {
+	requestURL := strings.ReplaceAll(r.url, ipPlaceholder, url.PathEscape(addr.String()))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return nil, false, fmt.Errorf("lookup service returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var record map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
+		return nil, false, errors.New("lookup service response is not a JSON object")
+	}
+
+	return record, true, nil
+}
@@ -0,0 +1,71 @@
+// Package getexecution provides get execution node factory for registry integration.
+package getexecution
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// GetExecutionNodeFactory creates GetExecutionNode instances.
+type GetExecutionNodeFactory struct {
+	repository persistence.ExecutionContextRepository
+}
+
+// Create creates a new GetExecutionNode instance.
+func (f *GetExecutionNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewGetExecutionNode(id, config, f.repository)
+}
+
+// ID returns the factory ID.
+func (f *GetExecutionNodeFactory) ID() string {
+	return "getexecution"
+}
+
+// Name returns the factory name.
+func (f *GetExecutionNodeFactory) Name() string {
+	return "Get Execution"
+}
+
+// Description returns the factory description.
+func (f *GetExecutionNodeFactory) Description() string {
+	return "Reads the node results of another workflow's most recent execution"
+}
+
+// Schema returns the JSON schema for Get Execution node configuration.
+func (f *GetExecutionNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"workflow_id": map[string]any{
+				"type":        "string",
+				"description": "ID of the workflow whose latest execution should be read. Supports templating.",
+				"examples": []string{
+					"daily-report-workflow",
+					"{{.variables.upstream_workflow_id}}",
+				},
+			},
+			"status": map[string]any{
+				"type":        "string",
+				"description": "Only consider executions with this status. Defaults to any finished execution.",
+				"enum":        []string{"completed", "failed", "cancelled", "timeout", "running", "paused"},
+				"examples":    []string{"completed", "failed"},
+			},
+		},
+		"required": []string{"workflow_id"},
+		"examples": []map[string]any{
+			{
+				"workflow_id": "daily-report-workflow",
+				"status":      "completed",
+			},
+		},
+	}
+}
+
+// NewGetExecutionNodeFactory creates a new factory instance reading from the given repository.
+func NewGetExecutionNodeFactory(repository persistence.ExecutionContextRepository) protocol.NodeFactory {
+	return &GetExecutionNodeFactory{
+		repository: repository,
+	}
+}
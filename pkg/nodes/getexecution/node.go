@@ -0,0 +1,230 @@
+// Package getexecution provides a node that reads the latest execution of another workflow.
+package getexecution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// GetExecutionNode implements the Node interface for reading another workflow's latest execution.
+type GetExecutionNode struct {
+	id         string
+	workflowID string
+	status     models.ExecutionStatus
+	repository persistence.ExecutionContextRepository
+}
+
+// NewGetExecutionNode creates a new get execution node.
+func NewGetExecutionNode(
+	id string,
+	config map[string]any,
+	repository persistence.ExecutionContextRepository,
+) (*GetExecutionNode, error) {
+	// Parse workflow_id (required)
+	workflowID, ok := config["workflow_id"].(string)
+	if !ok || workflowID == "" {
+		return nil, errors.New("missing required field 'workflow_id'")
+	}
+
+	// Parse status (optional, defaults to any terminal status)
+	var status models.ExecutionStatus
+	if s, ok := config["status"].(string); ok {
+		status = models.ExecutionStatus(s)
+	}
+
+	return &GetExecutionNode{
+		id:         id,
+		workflowID: workflowID,
+		status:     status,
+		repository: repository,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *GetExecutionNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *GetExecutionNode) Type() string {
+	return "getexecution"
+}
+
+// Execute looks up the most recent matching execution of the configured workflow.
+func (n *GetExecutionNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	renderedWorkflowID, err := template.RenderWithContext(n.workflowID, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render workflow_id template: %v", err)), nil
+	}
+
+	workflowID := fmt.Sprintf("%v", renderedWorkflowID)
+
+	executions, err := n.repository.GetExecutionsByWorkflow(context.Background(), workflowID)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to get executions: %v", err)), nil
+	}
+
+	var latest *models.ExecutionContext
+
+	for _, execution := range executions {
+		// Never report the execution this node is running in
+		if execution.ID == ctx.ID || !n.matchesStatus(execution.Status) {
+			continue
+		}
+
+		if latest == nil || execution.CreatedAt.After(latest.CreatedAt) {
+			latest = execution
+		}
+	}
+
+	if latest == nil {
+		return n.createErrorResult("no executions found for workflow " + workflowID), nil
+	}
+
+	nodeResults := make(map[string]any, len(latest.NodeResults))
+	for key, result := range latest.NodeResults {
+		nodeResults[key] = result.Data
+	}
+
+	data := map[string]any{
+		"execution_id": latest.ID,
+		"workflow_id":  latest.WorkflowID,
+		"status":       string(latest.Status),
+		"node_results": nodeResults,
+		"created_at":   latest.CreatedAt,
+	}
+
+	if latest.CompletedAt != nil {
+		data["completed_at"] = *latest.CompletedAt
+	}
+
+	if latest.ErrorMessage != "" {
+		data["error_message"] = latest.ErrorMessage
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// matchesStatus reports whether an execution status satisfies the configured filter.
+// Without a filter, only executions that have finished are considered.
+func (n *GetExecutionNode) matchesStatus(status models.ExecutionStatus) bool {
+	if n.status != "" {
+		return status == n.status
+	}
+
+	return status != models.ExecutionStatusRunning && status != models.ExecutionStatusPaused
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *GetExecutionNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *GetExecutionNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Main input for triggering the execution lookup",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *GetExecutionNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Latest execution of the target workflow",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"execution_id":  map[string]any{"type": "string"},
+						"workflow_id":   map[string]any{"type": "string"},
+						"status":        map[string]any{"type": "string"},
+						"node_results":  map[string]any{"type": "object", "description": "Node result data keyed by node and port"},
+						"created_at":    map[string]any{"type": "string", "format": "date-time"},
+						"completed_at":  map[string]any{"type": "string", "format": "date-time"},
+						"error_message": map[string]any{"type": "string"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when no matching execution exists or the lookup fails",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the get execution node.
+func (n *GetExecutionNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *GetExecutionNode) Validate(config map[string]any) error {
+	if workflowID, ok := config["workflow_id"].(string); !ok || workflowID == "" {
+		return errors.New("missing required field 'workflow_id'")
+	}
+
+	if status, ok := config["status"].(string); ok {
+		switch models.ExecutionStatus(status) {
+		case models.ExecutionStatusRunning, models.ExecutionStatusCompleted, models.ExecutionStatusFailed,
+			models.ExecutionStatusCancelled, models.ExecutionStatusTimeout, models.ExecutionStatusPaused:
+		default:
+			return fmt.Errorf("invalid status '%s'", status)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package getexecution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/persistence/file"
+)
+
+func seedExecutions(t *testing.T, executions ...*models.ExecutionContext) persistence.ExecutionContextRepository {
+	t.Helper()
+
+	repository := file.NewPersistence(t.TempDir()).ExecutionContextRepository()
+
+	for _, execution := range executions {
+		if err := repository.SaveExecutionContext(t.Context(), execution); err != nil {
+			t.Fatalf("Failed to seed execution: %v", err)
+		}
+	}
+
+	return repository
+}
+
+func newExecution(id string, status models.ExecutionStatus, createdAt time.Time, total int) *models.ExecutionContext {
+	return &models.ExecutionContext{
+		ID:         id,
+		WorkflowID: "report-workflow",
+		Status:     status,
+		NodeResults: map[string]models.NodeResult{
+			models.MakeNodeResultKey("summarize", "success"): {
+				NodeID: "summarize",
+				Data:   map[string]any{"total": total},
+				Status: string(models.NodeStatusSuccess),
+			},
+		},
+		CreatedAt: createdAt,
+	}
+}
+
+func TestNewGetExecutionNode(t *testing.T) {
+	_, err := NewGetExecutionNode("lookup", map[string]any{}, nil)
+	if err == nil {
+		t.Error("Expected error for missing workflow_id")
+	}
+
+	node, err := NewGetExecutionNode("lookup", map[string]any{"workflow_id": "report-workflow", "status": "completed"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if node.Type() != "getexecution" {
+		t.Errorf("Expected type 'getexecution', got: %s", node.Type())
+	}
+
+	if node.status != models.ExecutionStatusCompleted {
+		t.Errorf("Expected status filter 'completed', got: %s", node.status)
+	}
+}
+
+func TestGetExecutionNode_Execute_ReturnsLatest(t *testing.T) {
+	now := time.Now().UTC()
+	repository := seedExecutions(t,
+		newExecution("exec-old", models.ExecutionStatusCompleted, now.Add(-2*time.Hour), 1),
+		newExecution("exec-latest", models.ExecutionStatusCompleted, now.Add(-time.Hour), 2),
+		newExecution("exec-running", models.ExecutionStatusRunning, now, 3),
+	)
+
+	node, err := NewGetExecutionNode("lookup", map[string]any{"workflow_id": "report-workflow"}, repository)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	results, err := node.Execute(models.ExecutionContext{ID: "current"}, map[string]models.NodeResult{})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	result, ok := results[OutputPortSuccess]
+	if !ok {
+		t.Fatalf("Expected success output, got: %v", results)
+	}
+
+	if result.Data["execution_id"] != "exec-latest" {
+		t.Errorf("Expected latest finished execution 'exec-latest', got: %v", result.Data["execution_id"])
+	}
+
+	nodeResults, ok := result.Data["node_results"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected node_results map, got: %T", result.Data["node_results"])
+	}
+
+	summary, ok := nodeResults[models.MakeNodeResultKey("summarize", "success")].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected summarize result, got: %v", nodeResults)
+	}
+
+	if summary["total"] != float64(2) {
+		t.Errorf("Expected total 2, got: %v", summary["total"])
+	}
+}
+
+func TestGetExecutionNode_Execute_StatusFilter(t *testing.T) {
+	now := time.Now().UTC()
+	repository := seedExecutions(t,
+		newExecution("exec-failed", models.ExecutionStatusFailed, now.Add(-2*time.Hour), 1),
+		newExecution("exec-completed", models.ExecutionStatusCompleted, now.Add(-time.Hour), 2),
+	)
+
+	node, err := NewGetExecutionNode("lookup", map[string]any{"workflow_id": "report-workflow", "status": "failed"}, repository)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	results, err := node.Execute(models.ExecutionContext{}, map[string]models.NodeResult{})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if results[OutputPortSuccess].Data["execution_id"] != "exec-failed" {
+		t.Errorf("Expected 'exec-failed', got: %v", results[OutputPortSuccess].Data["execution_id"])
+	}
+}
+
+func TestGetExecutionNode_Execute_NoExecutions(t *testing.T) {
+	repository := seedExecutions(t,
+		newExecution("exec-running", models.ExecutionStatusRunning, time.Now().UTC(), 1),
+	)
+
+	node, err := NewGetExecutionNode("lookup", map[string]any{"workflow_id": "report-workflow"}, repository)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	results, err := node.Execute(models.ExecutionContext{}, map[string]models.NodeResult{})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if _, ok := results[OutputPortSuccess]; ok {
+		t.Error("Expected no success output when no finished executions exist")
+	}
+
+	errorResult, ok := results[OutputPortError]
+	if !ok {
+		t.Fatal("Expected error output when no executions exist")
+	}
+
+	if errorResult.Status != string(models.NodeStatusError) {
+		t.Errorf("Expected error status, got: %s", errorResult.Status)
+	}
+}
+
+func TestGetExecutionNode_Validate(t *testing.T) {
+	node := &GetExecutionNode{}
+
+	if err := node.Validate(map[string]any{"workflow_id": "report-workflow", "status": "completed"}); err != nil {
+		t.Errorf("Expected valid config, got: %v", err)
+	}
+
+	if err := node.Validate(map[string]any{"workflow_id": "report-workflow", "status": "unknown"}); err == nil {
+		t.Error("Expected error for invalid status")
+	}
+
+	if err := node.Validate(map[string]any{}); err == nil {
+		t.Error("Expected error for missing workflow_id")
+	}
+}
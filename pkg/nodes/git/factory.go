@@ -0,0 +1,126 @@
+// Package git provides Git node factory for registry integration.
+package git
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// GitNodeFactory creates GitNode instances.
+type GitNodeFactory struct{}
+
+// Create creates a new GitNode instance.
+func (f *GitNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewGitNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *GitNodeFactory) ID() string {
+	return "git"
+}
+
+// Name returns the factory name.
+func (f *GitNodeFactory) Name() string {
+	return "Git"
+}
+
+// Description returns the factory description.
+func (f *GitNodeFactory) Description() string {
+	return "Clones a Git repository to read a file or commit and push templated changes to a branch"
+}
+
+// Schema returns the JSON schema for Git node configuration.
+func (f *GitNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"repository": map[string]any{
+				"type":        "string",
+				"description": "Repository URL. Environment variables are expanded.",
+				"examples":    []string{"https://github.com/acme/deployments.git"},
+			},
+			"operation": map[string]any{
+				"type":        "string",
+				"description": "clone resolves the head of the branch; read_file reads a file; commit writes files, commits and pushes them",
+				"enum":        operations,
+			},
+			"branch": map[string]any{
+				"type":        "string",
+				"description": "Branch to operate on, the default branch when empty. A commit to a branch that does not exist creates it from the default branch. Supports templating.",
+			},
+			"token": map[string]any{
+				"type":        "string",
+				"description": "Access token for HTTPS authentication. Environment variables are expanded.",
+				"examples":    []string{"${GITHUB_TOKEN}"},
+			},
+			"username": map[string]any{
+				"type":        "string",
+				"description": "Username sent with the token",
+				"default":     defaultUsername,
+			},
+			"path": map[string]any{
+				"type":        "string",
+				"description": "File to read, relative to the repository root. Supports templating.",
+			},
+			"files": map[string]any{
+				"type":        "array",
+				"description": "Files to write and commit, relative to the repository root. Paths and contents support templating.",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"path":    map[string]any{"type": "string"},
+						"content": map[string]any{"type": "string"},
+					},
+					"required": []string{"path", "content"},
+				},
+			},
+			"message": map[string]any{
+				"type":        "string",
+				"description": "Commit message. Supports templating.",
+			},
+			"author_name": map[string]any{
+				"type":        "string",
+				"description": "Name of the commit author",
+				"default":     defaultAuthorName,
+			},
+			"author_email": map[string]any{
+				"type":        "string",
+				"description": "Email of the commit author",
+				"default":     defaultAuthorEmail,
+			},
+			"timeout": map[string]any{
+				"type":        "number",
+				"description": "Timeout of the whole operation in seconds",
+				"default":     defaultTimeout,
+			},
+		},
+		"required": []string{"repository", "operation"},
+		"examples": []map[string]any{
+			{
+				"repository": "https://github.com/acme/deployments.git",
+				"operation":  OperationReadFile,
+				"path":       "services/{{.trigger_data.service}}/values.yaml",
+				"token":      "${GITHUB_TOKEN}",
+			},
+			{
+				"repository": "https://github.com/acme/deployments.git",
+				"operation":  OperationCommit,
+				"branch":     "release/{{.trigger_data.version}}",
+				"files": []any{
+					map[string]any{
+						"path":    "services/{{.trigger_data.service}}/image.txt",
+						"content": "{{.trigger_data.image}}",
+					},
+				},
+				"message": "Deploy {{.trigger_data.service}} {{.trigger_data.version}}",
+				"token":   "${GITHUB_TOKEN}",
+			},
+		},
+	}
+}
+
+// NewGitNodeFactory creates a new factory instance.
+func NewGitNodeFactory() protocol.NodeFactory {
+	return &GitNodeFactory{}
+}
@@ -0,0 +1,368 @@
+// Package git provides a node that reads from and commits to a Git repository.
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// Git operations.
+const (
+	OperationClone    = "clone"
+	OperationReadFile = "read_file"
+	OperationCommit   = "commit"
+)
+
+const (
+	defaultUsername    = "x-access-token"
+	defaultAuthorName  = "Operion"
+	defaultAuthorEmail = "operion@localhost"
+	defaultTimeout     = 60
+)
+
+var operations = []string{OperationClone, OperationReadFile, OperationCommit}
+
+// GitNode implements the Node interface for Git repository operations.
+type GitNode struct {
+	id         string
+	operation  string
+	repository string
+	auth       transport.AuthMethod
+	branch     string
+	path       string
+	files      []File
+	message    string
+	author     Author
+	timeout    time.Duration
+}
+
+// NewGitNode creates a new Git node. The repository URL, username and token expand environment
+// variables, so "${GITHUB_TOKEN}" keeps the token out of workflow definitions.
+func NewGitNode(id string, config map[string]any) (*GitNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	setting := func(key, fallback string) string {
+		if value, ok := config[key].(string); ok && value != "" {
+			return value
+		}
+
+		return fallback
+	}
+
+	node := &GitNode{
+		id:         id,
+		operation:  setting("operation", ""),
+		repository: os.ExpandEnv(setting("repository", "")),
+		auth:       tokenAuth(os.ExpandEnv(setting("username", defaultUsername)), os.ExpandEnv(setting("token", ""))),
+		branch:     setting("branch", ""),
+		path:       setting("path", ""),
+		message:    setting("message", ""),
+		author: Author{
+			Name:  setting("author_name", defaultAuthorName),
+			Email: setting("author_email", defaultAuthorEmail),
+		},
+		timeout: defaultTimeout * time.Second,
+	}
+
+	if files, ok := config["files"].([]any); ok {
+		for _, file := range files {
+			spec, _ := file.(map[string]any)
+			path, _ := spec["path"].(string)
+			content, _ := spec["content"].(string)
+			node.files = append(node.files, File{Path: path, Content: content})
+		}
+	}
+
+	if timeout, ok := config["timeout"].(float64); ok {
+		node.timeout = time.Duration(timeout * float64(time.Second))
+	}
+
+	return node, nil
+}
+
+// ID returns the node ID.
+func (n *GitNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *GitNode) Type() string {
+	return "git"
+}
+
+// Execute clones the repository into a temporary working directory, removed afterwards, and runs
+// the operation: clone resolves the branch head, read_file reads a file and commit writes files,
+// commits them and pushes the branch, creating it from the default branch when it does not exist.
+func (n *GitNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	branch, err := template.RenderStringWithContext(n.branch, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render branch: %v", err)), nil
+	}
+
+	gitCtx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+
+	checkout, err := Clone(gitCtx, n.repository, branch, n.auth, n.operation == OperationCommit)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+	defer checkout.Close()
+
+	head, err := checkout.Head()
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	data := map[string]any{
+		"operation":  n.operation,
+		"branch":     checkout.Branch(),
+		"commit_sha": head,
+	}
+
+	switch n.operation {
+	case OperationReadFile:
+		err = n.readFile(&ctx, checkout, data)
+	case OperationCommit:
+		err = n.commit(gitCtx, &ctx, checkout, data)
+	}
+
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// readFile reads the file at the rendered path into data.
+func (n *GitNode) readFile(ctx *models.ExecutionContext, checkout *Checkout, data map[string]any) error {
+	path, err := template.RenderStringWithContext(n.path, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to render path: %w", err)
+	}
+
+	content, err := checkout.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	data["path"] = path
+	data["content"] = content
+
+	return nil
+}
+
+// commit writes the rendered files, commits and pushes them. Unchanged files create no commit.
+func (n *GitNode) commit(gitCtx context.Context, ctx *models.ExecutionContext, checkout *Checkout, data map[string]any) error {
+	files := make([]File, 0, len(n.files))
+	paths := make([]any, 0, len(n.files))
+
+	for i, file := range n.files {
+		path, err := template.RenderStringWithContext(file.Path, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to render path of files[%d]: %w", i, err)
+		}
+
+		content, err := template.RenderStringWithContext(file.Content, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to render content of files[%d]: %w", i, err)
+		}
+
+		files = append(files, File{Path: path, Content: content})
+		paths = append(paths, path)
+	}
+
+	message, err := template.RenderStringWithContext(n.message, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to render message: %w", err)
+	}
+
+	commitSHA, err := checkout.Commit(files, message, n.author)
+	if err != nil {
+		return err
+	}
+
+	data["files"] = paths
+	data["parent_sha"] = data["commit_sha"]
+	data["changed"] = commitSHA != ""
+	data["created_branch"] = checkout.NewBranch()
+
+	if commitSHA == "" && !checkout.NewBranch() {
+		data["pushed"] = false
+
+		return nil
+	}
+
+	if err := checkout.Push(gitCtx); err != nil {
+		return err
+	}
+
+	if commitSHA != "" {
+		data["commit_sha"] = commitSHA
+	}
+
+	data["pushed"] = true
+
+	return nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *GitNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *GitNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Main input for triggering the Git operation",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *GitNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Branch and commit SHA, with the file content for read_file and the commit details for commit",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"operation":      map[string]any{"type": "string"},
+						"branch":         map[string]any{"type": "string"},
+						"commit_sha":     map[string]any{"type": "string", "description": "Head of the branch, the new commit after commit"},
+						"path":           map[string]any{"type": "string"},
+						"content":        map[string]any{"type": "string"},
+						"files":          map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+						"parent_sha":     map[string]any{"type": "string"},
+						"changed":        map[string]any{"type": "boolean", "description": "Whether the files changed and a commit was created"},
+						"created_branch": map[string]any{"type": "boolean"},
+						"pushed":         map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when cloning, reading, committing or pushing fails",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the Git node.
+func (n *GitNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *GitNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the repository and operation fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	if repository, ok := config["repository"].(string); !ok || repository == "" {
+		return errors.New("missing required field 'repository'")
+	}
+
+	operation, _ := config["operation"].(string)
+	if !slices.Contains(operations, operation) {
+		return fmt.Errorf("invalid operation '%s' (supported: clone, read_file, commit)", operation)
+	}
+
+	switch operation {
+	case OperationReadFile:
+		if path, ok := config["path"].(string); !ok || path == "" {
+			return errors.New("read_file requires field 'path'")
+		}
+	case OperationCommit:
+		files, ok := config["files"].([]any)
+		if !ok || len(files) == 0 {
+			return errors.New("commit requires a non-empty 'files' array")
+		}
+
+		for i, file := range files {
+			spec, ok := file.(map[string]any)
+			if !ok {
+				return fmt.Errorf("files[%d] must be an object", i)
+			}
+
+			if path, ok := spec["path"].(string); !ok || path == "" {
+				return fmt.Errorf("files[%d]: missing required field 'path'", i)
+			}
+
+			if _, ok := spec["content"].(string); !ok {
+				return fmt.Errorf("files[%d]: missing required field 'content'", i)
+			}
+		}
+
+		if message, ok := config["message"].(string); !ok || message == "" {
+			return errors.New("commit requires field 'message'")
+		}
+	}
+
+	if timeout, exists := config["timeout"]; exists {
+		if value, ok := timeout.(float64); !ok || value <= 0 {
+			return errors.New("timeout must be a positive number of seconds")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// newBareRepository creates a local bare repository whose main branch holds the given files.
+func newBareRepository(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	bare, err := gogit.PlainInit(remote, true)
+	require.NoError(t, err)
+	require.NoError(t, bare.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main"))))
+
+	seed, err := gogit.PlainInit(t.TempDir(), false)
+	require.NoError(t, err)
+	require.NoError(t, seed.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main"))))
+
+	worktree, err := seed.Worktree()
+	require.NoError(t, err)
+
+	for path, content := range files {
+		fullPath := filepath.Join(worktree.Filesystem.Root(), path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+
+		_, err := worktree.Add(path)
+		require.NoError(t, err)
+	}
+
+	signature := &object.Signature{Name: "Seed", Email: "seed@example.com", When: time.Now()}
+	_, err = worktree.Commit("Initial commit", &gogit.CommitOptions{Author: signature})
+	require.NoError(t, err)
+
+	_, err = seed.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remote}})
+	require.NoError(t, err)
+	require.NoError(t, seed.Push(&gogit.PushOptions{RemoteName: "origin"}))
+
+	return remote
+}
+
+func executeGit(t *testing.T, config map[string]any, triggerData map[string]any) map[string]models.NodeResult {
+	t.Helper()
+
+	node, err := NewGitNodeFactory().Create(t.Context(), "gitops", config)
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{TriggerData: triggerData}, map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	return results
+}
+
+// branchHead returns the commit the branch of the bare repository points to.
+func branchHead(t *testing.T, remote, branch string) *object.Commit {
+	t.Helper()
+
+	repository, err := gogit.PlainOpen(remote)
+	require.NoError(t, err)
+
+	reference, err := repository.Reference(plumbing.NewBranchReferenceName(branch), true)
+	require.NoError(t, err)
+
+	commit, err := repository.CommitObject(reference.Hash())
+	require.NoError(t, err)
+
+	return commit
+}
+
+// fileAt returns the content of a file in a commit.
+func fileAt(t *testing.T, commit *object.Commit, path string) string {
+	t.Helper()
+
+	file, err := commit.File(path)
+	require.NoError(t, err)
+
+	content, err := file.Contents()
+	require.NoError(t, err)
+
+	return content
+}
+
+func TestNewGitNode_InvalidConfig(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{"operation": OperationClone},
+		{"repository": "https://example.com/repo.git"},
+		{"repository": "https://example.com/repo.git", "operation": "rebase"},
+		{"repository": "https://example.com/repo.git", "operation": OperationReadFile},
+		{"repository": "https://example.com/repo.git", "operation": OperationCommit, "message": "update"},
+		{"repository": "https://example.com/repo.git", "operation": OperationCommit, "files": []any{map[string]any{"path": "a.txt", "content": "a"}}},
+		{"repository": "https://example.com/repo.git", "operation": OperationCommit, "message": "update", "files": []any{map[string]any{"content": "a"}}},
+		{"repository": "https://example.com/repo.git", "operation": OperationClone, "timeout": float64(0)},
+	}
+
+	for _, config := range invalidConfigs {
+		_, err := NewGitNode("gitops", config)
+		assert.Error(t, err, "config %v", config)
+	}
+}
+
+func TestGitNode_Execute_ReadFile(t *testing.T) {
+	remote := newBareRepository(t, map[string]string{"services/api/image.txt": "api:1.0.0"})
+
+	results := executeGit(t, map[string]any{
+		"repository": remote,
+		"operation":  OperationReadFile,
+		"path":       "services/{{.trigger_data.service}}/image.txt",
+	}, map[string]any{"service": "api"})
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok, "results: %v", results)
+	assert.Equal(t, "api:1.0.0", result.Data["content"])
+	assert.Equal(t, "main", result.Data["branch"])
+	assert.Equal(t, branchHead(t, remote, "main").Hash.String(), result.Data["commit_sha"])
+
+	// Paths outside of the repository are rejected
+	results = executeGit(t, map[string]any{
+		"repository": remote,
+		"operation":  OperationReadFile,
+		"path":       "../{{.trigger_data.service}}",
+	}, map[string]any{"service": "api"})
+
+	result, ok = results[OutputPortError]
+	require.True(t, ok)
+	assert.Contains(t, result.Error, "must be relative to the repository root")
+}
+
+func TestGitNode_Execute_CommitAndPush(t *testing.T) {
+	remote := newBareRepository(t, map[string]string{"services/api/image.txt": "api:1.0.0"})
+	parent := branchHead(t, remote, "main")
+
+	config := map[string]any{
+		"repository": remote,
+		"operation":  OperationCommit,
+		"branch":     "main",
+		"files": []any{
+			map[string]any{"path": "services/{{.trigger_data.service}}/image.txt", "content": "{{.trigger_data.service}}:{{.trigger_data.version}}"},
+		},
+		"message":      "Deploy {{.trigger_data.service}} {{.trigger_data.version}}",
+		"author_name":  "Deploy Bot",
+		"author_email": "deploy@example.com",
+	}
+	triggerData := map[string]any{"service": "api", "version": "1.1.0"}
+
+	results := executeGit(t, config, triggerData)
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok, "results: %v", results)
+	assert.Equal(t, true, result.Data["changed"])
+	assert.Equal(t, true, result.Data["pushed"])
+	assert.Equal(t, parent.Hash.String(), result.Data["parent_sha"])
+
+	// The commit landed on the target branch of the remote
+	head := branchHead(t, remote, "main")
+	assert.Equal(t, head.Hash.String(), result.Data["commit_sha"])
+	assert.Equal(t, "Deploy api 1.1.0", head.Message)
+	assert.Equal(t, "Deploy Bot", head.Author.Name)
+	assert.Equal(t, []plumbing.Hash{parent.Hash}, head.ParentHashes)
+	assert.Equal(t, "api:1.1.0", fileAt(t, head, "services/api/image.txt"))
+
+	// Committing the same content again changes nothing
+	results = executeGit(t, config, triggerData)
+
+	result, ok = results[OutputPortSuccess]
+	require.True(t, ok)
+	assert.Equal(t, false, result.Data["changed"])
+	assert.Equal(t, false, result.Data["pushed"])
+	assert.Equal(t, head.Hash.String(), branchHead(t, remote, "main").Hash.String())
+}
+
+func TestGitNode_Execute_CommitToNewBranch(t *testing.T) {
+	remote := newBareRepository(t, map[string]string{"README.md": "deployments"})
+	base := branchHead(t, remote, "main")
+
+	results := executeGit(t, map[string]any{
+		"repository": remote,
+		"operation":  OperationCommit,
+		"branch":     "release/{{.trigger_data.version}}",
+		"files":      []any{map[string]any{"path": "VERSION", "content": "{{.trigger_data.version}}"}},
+		"message":    "Release {{.trigger_data.version}}",
+	}, map[string]any{"version": "2.0.0"})
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok, "results: %v", results)
+	assert.Equal(t, true, result.Data["created_branch"])
+	assert.Equal(t, "release/2.0.0", result.Data["branch"])
+
+	head := branchHead(t, remote, "release/2.0.0")
+	assert.Equal(t, head.Hash.String(), result.Data["commit_sha"])
+	assert.Equal(t, []plumbing.Hash{base.Hash}, head.ParentHashes)
+	assert.Equal(t, "2.0.0", fileAt(t, head, "VERSION"))
+	assert.Equal(t, base.Hash, branchHead(t, remote, "main").Hash, "the default branch is unchanged")
+}
+
+func TestGitNode_Execute_CloneFailure(t *testing.T) {
+	results := executeGit(t, map[string]any{
+		"repository": filepath.Join(t.TempDir(), "missing.git"),
+		"operation":  OperationClone,
+	}, nil)
+
+	result, ok := results[OutputPortError]
+	require.True(t, ok)
+	assert.Contains(t, result.Error, "failed to clone repository")
+}
@@ -0,0 +1,243 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// File is a file written by a commit, relative to the repository root.
+type File struct {
+	Path    string
+	Content string
+}
+
+// Author is the author and committer of commits.
+type Author struct {
+	Name  string
+	Email string
+}
+
+// Checkout is a clone of the repository in a temporary working directory.
+type Checkout struct {
+	dir        string
+	repository *gogit.Repository
+	auth       transport.AuthMethod
+	branch     string
+	newBranch  bool
+}
+
+// Clone clones the branch of the repository at url into a temporary working directory, or its
+// default branch when branch is empty. With create, a branch the repository does not have yet is
+// created from the default branch. The checkout must be closed to remove the directory.
+func Clone(ctx context.Context, url, branch string, auth transport.AuthMethod, create bool) (*Checkout, error) {
+	dir, err := os.MkdirTemp("", "operion-git-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create working directory: %w", err)
+	}
+
+	checkout := &Checkout{dir: dir, auth: auth, branch: branch}
+
+	options := &gogit.CloneOptions{URL: url, Auth: auth, SingleBranch: true}
+	if branch != "" {
+		options.ReferenceName = plumbing.NewBranchReferenceName(branch)
+	}
+
+	checkout.repository, err = gogit.PlainCloneContext(ctx, dir, false, options)
+	if err != nil && branch != "" && create && isMissingBranch(err) {
+		// Start the new branch from the default branch
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, fmt.Errorf("failed to clean working directory: %w", err)
+		}
+
+		options.ReferenceName = ""
+		checkout.newBranch = true
+		checkout.repository, err = gogit.PlainCloneContext(ctx, dir, false, options)
+	}
+
+	if err != nil {
+		_ = os.RemoveAll(dir)
+
+		return nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	head, err := checkout.repository.Head()
+	if err != nil {
+		_ = checkout.Close()
+
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	if checkout.newBranch {
+		if err := checkout.createBranch(head.Hash()); err != nil {
+			_ = checkout.Close()
+
+			return nil, err
+		}
+	} else if checkout.branch == "" {
+		checkout.branch = head.Name().Short()
+	}
+
+	return checkout, nil
+}
+
+// isMissingBranch reports whether a clone failed because the repository has no such branch.
+func isMissingBranch(err error) bool {
+	var noMatchingRefSpec gogit.NoMatchingRefSpecError
+
+	return errors.Is(err, plumbing.ErrReferenceNotFound) || errors.As(err, &noMatchingRefSpec)
+}
+
+// createBranch checks out the branch of the checkout, created at hash.
+func (c *Checkout) createBranch(hash plumbing.Hash) error {
+	worktree, err := c.repository.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to open worktree: %w", err)
+	}
+
+	err = worktree.Checkout(&gogit.CheckoutOptions{
+		Hash:   hash,
+		Branch: plumbing.NewBranchReferenceName(c.branch),
+		Create: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create branch '%s': %w", c.branch, err)
+	}
+
+	return nil
+}
+
+// Branch returns the checked out branch.
+func (c *Checkout) Branch() string {
+	return c.branch
+}
+
+// NewBranch reports whether the branch was created by the checkout.
+func (c *Checkout) NewBranch() bool {
+	return c.newBranch
+}
+
+// Head returns the SHA of the checked out commit.
+func (c *Checkout) Head() (string, error) {
+	head, err := c.repository.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	return head.Hash().String(), nil
+}
+
+// ReadFile returns the content of a file of the checkout.
+func (c *Checkout) ReadFile(path string) (string, error) {
+	fullPath, err := c.path(path)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+
+	return string(content), nil
+}
+
+// Commit writes the files and commits them with message. It returns the SHA of the new commit,
+// or an empty SHA when the files are unchanged.
+func (c *Checkout) Commit(files []File, message string, author Author) (string, error) {
+	worktree, err := c.repository.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to open worktree: %w", err)
+	}
+
+	for _, file := range files {
+		fullPath, err := c.path(file.Path)
+		if err != nil {
+			return "", err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+			return "", fmt.Errorf("failed to create directory of '%s': %w", file.Path, err)
+		}
+
+		if err := os.WriteFile(fullPath, []byte(file.Content), 0o644); err != nil {
+			return "", fmt.Errorf("failed to write '%s': %w", file.Path, err)
+		}
+
+		if _, err := worktree.Add(filepath.ToSlash(filepath.Clean(file.Path))); err != nil {
+			return "", fmt.Errorf("failed to stage '%s': %w", file.Path, err)
+		}
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree status: %w", err)
+	}
+
+	if status.IsClean() {
+		return "", nil
+	}
+
+	signature := &object.Signature{Name: author.Name, Email: author.Email, When: time.Now()}
+
+	hash, err := worktree.Commit(message, &gogit.CommitOptions{Author: signature, Committer: signature})
+	if err != nil {
+		return "", fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return hash.String(), nil
+}
+
+// Push pushes the checked out branch to the repository.
+func (c *Checkout) Push(ctx context.Context) error {
+	branch := plumbing.NewBranchReferenceName(c.branch)
+
+	err := c.repository.PushContext(ctx, &gogit.PushOptions{
+		Auth:     c.auth,
+		RefSpecs: []config.RefSpec{config.RefSpec(branch + ":" + branch)},
+	})
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to push branch '%s': %w", c.branch, err)
+	}
+
+	return nil
+}
+
+// Close removes the working directory.
+func (c *Checkout) Close() error {
+	return os.RemoveAll(c.dir)
+}
+
+// path returns the location in the working directory of a path relative to the repository root,
+// rejecting paths outside of it.
+func (c *Checkout) path(path string) (string, error) {
+	if !filepath.IsLocal(path) {
+		return "", fmt.Errorf("path '%s' must be relative to the repository root", path)
+	}
+
+	if clean := filepath.Clean(path); clean == ".git" || strings.HasPrefix(clean, ".git"+string(filepath.Separator)) {
+		return "", fmt.Errorf("path '%s' must not be in the .git directory", path)
+	}
+
+	return filepath.Join(c.dir, path), nil
+}
+
+// tokenAuth returns the HTTP basic authentication of a token, or nil without a token.
+func tokenAuth(username, token string) transport.AuthMethod {
+	if token == "" {
+		return nil
+	}
+
+	return &http.BasicAuth{Username: username, Password: token}
+}
@@ -0,0 +1,121 @@
+// Package httpbatch provides HTTP batch node factory for registry integration.
+package httpbatch
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// HTTPBatchNodeFactory creates HTTPBatchNode instances.
+type HTTPBatchNodeFactory struct{}
+
+// Create creates a new HTTPBatchNode instance.
+func (f *HTTPBatchNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewHTTPBatchNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *HTTPBatchNodeFactory) ID() string {
+	return "httpbatch"
+}
+
+// Name returns the factory name.
+func (f *HTTPBatchNodeFactory) Name() string {
+	return "HTTP Batch"
+}
+
+// Description returns the factory description.
+func (f *HTTPBatchNodeFactory) Description() string {
+	return "Sends a batch of templated HTTP requests with a concurrency limit and collects their results in order"
+}
+
+// Schema returns the JSON schema for HTTP batch node configuration.
+func (f *HTTPBatchNodeFactory) Schema() map[string]any {
+	requestSchema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"url": map[string]any{
+				"type":        "string",
+				"description": "HTTP URL to request. Supports templating.",
+			},
+			"method": map[string]any{
+				"type":        "string",
+				"description": "HTTP method",
+				"default":     defaultMethod,
+				"enum":        []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"},
+			},
+			"headers": map[string]any{
+				"type":                 "object",
+				"description":          "HTTP headers. Values support templating.",
+				"additionalProperties": map[string]any{"type": "string"},
+			},
+			"body": map[string]any{
+				"type":        "string",
+				"description": "Request body. Supports templating.",
+			},
+		},
+		"required": []string{"url"},
+	}
+
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"requests": map[string]any{
+				"type":        "array",
+				"description": "Fixed list of requests to send, each rendered with its position as .index",
+				"items":       requestSchema,
+			},
+			"items": map[string]any{
+				"type":        []string{"array", "string"},
+				"description": "Items to send one request for: an array, or a template rendering one with the json function",
+				"examples":    []string{"{{json .trigger_data.subscribers}}"},
+			},
+			"request": map[string]any{
+				"description": "Request sent for each of the items, rendered with the item as .item and its position as .index",
+				"allOf":       []any{requestSchema},
+			},
+			"concurrency": map[string]any{
+				"type":        "integer",
+				"description": "Maximum number of requests in flight",
+				"default":     defaultConcurrency,
+				"minimum":     1,
+			},
+			"mode": map[string]any{
+				"type":        "string",
+				"description": "collect_all sends every request and succeeds with all results; fail_fast cancels the batch at the first failed request and routes to the error port",
+				"enum":        []string{ModeCollectAll, ModeFailFast},
+				"default":     ModeCollectAll,
+			},
+			"timeout": map[string]any{
+				"type":        "number",
+				"description": "Timeout of each request in seconds",
+				"default":     defaultTimeout,
+			},
+		},
+		"examples": []map[string]any{
+			{
+				"items": "{{json .trigger_data.subscribers}}",
+				"request": map[string]any{
+					"url":     "{{.item.webhook_url}}",
+					"method":  "POST",
+					"headers": map[string]any{"X-Subscriber": "{{.item.id}}"},
+					"body":    `{"event": "{{.trigger_data.event}}"}`,
+				},
+				"concurrency": 3,
+			},
+			{
+				"requests": []any{
+					map[string]any{"url": "https://inventory.example.com/reserve", "method": "POST"},
+					map[string]any{"url": "https://billing.example.com/charge", "method": "POST"},
+				},
+				"mode": ModeFailFast,
+			},
+		},
+	}
+}
+
+// NewHTTPBatchNodeFactory creates a new factory instance.
+func NewHTTPBatchNodeFactory() protocol.NodeFactory {
+	return &HTTPBatchNodeFactory{}
+}
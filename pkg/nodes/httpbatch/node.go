@@ -0,0 +1,520 @@
+// Package httpbatch provides a node that sends a batch of HTTP requests with bounded concurrency.
+package httpbatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// Batch modes.
+const (
+	ModeCollectAll = "collect_all"
+	ModeFailFast   = "fail_fast"
+)
+
+const (
+	defaultConcurrency = 5
+	defaultTimeout     = 30
+	defaultMethod      = http.MethodGet
+)
+
+// errSkipped is the error of the requests not sent because an earlier one failed in fail_fast mode.
+var errSkipped = errors.New("skipped after an earlier request failed")
+
+// RequestSpec is the templated specification of one request of the batch.
+type RequestSpec struct {
+	URL     string
+	Method  string
+	Headers map[string]string
+	Body    string
+}
+
+// HTTPBatchNode implements the Node interface for sending a batch of HTTP requests.
+type HTTPBatchNode struct {
+	id          string
+	requests    []RequestSpec
+	items       any
+	request     RequestSpec
+	concurrency int
+	mode        string
+	client      *http.Client
+}
+
+// NewHTTPBatchNode creates a new HTTP batch node. The batch is either the fixed list of
+// "requests", or one "request" per element of "items".
+func NewHTTPBatchNode(id string, config map[string]any) (*HTTPBatchNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	node := &HTTPBatchNode{
+		id:          id,
+		items:       config["items"],
+		concurrency: defaultConcurrency,
+		mode:        ModeCollectAll,
+	}
+
+	if requests, ok := config["requests"].([]any); ok {
+		for _, request := range requests {
+			spec, _ := request.(map[string]any)
+			node.requests = append(node.requests, parseRequestSpec(spec))
+		}
+	}
+
+	if request, ok := config["request"].(map[string]any); ok {
+		node.request = parseRequestSpec(request)
+	}
+
+	if concurrency, ok := config["concurrency"].(float64); ok {
+		node.concurrency = int(concurrency)
+	}
+
+	if mode, ok := config["mode"].(string); ok && mode != "" {
+		node.mode = mode
+	}
+
+	timeout := defaultTimeout
+	if value, ok := config["timeout"].(float64); ok {
+		timeout = int(value)
+	}
+
+	node.client = &http.Client{Timeout: time.Duration(timeout) * time.Second}
+
+	return node, nil
+}
+
+// parseRequestSpec reads a request specification from its configuration.
+func parseRequestSpec(config map[string]any) RequestSpec {
+	spec := RequestSpec{
+		Method:  defaultMethod,
+		Headers: make(map[string]string),
+	}
+
+	spec.URL, _ = config["url"].(string)
+	spec.Body, _ = config["body"].(string)
+
+	if method, ok := config["method"].(string); ok && method != "" {
+		spec.Method = strings.ToUpper(method)
+	}
+
+	if headers, ok := config["headers"].(map[string]any); ok {
+		for key, value := range headers {
+			if header, ok := value.(string); ok {
+				spec.Headers[key] = header
+			}
+		}
+	}
+
+	return spec
+}
+
+// ID returns the node ID.
+func (n *HTTPBatchNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *HTTPBatchNode) Type() string {
+	return "httpbatch"
+}
+
+// Execute renders the requests of the batch and sends them, at most concurrency at a time.
+// The results keep the order of the requests. In collect_all mode every request is sent and
+// the batch succeeds whatever their outcome; in fail_fast mode the first failed request
+// cancels the others and the batch goes to the error port.
+func (n *HTTPBatchNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	requests, err := n.renderRequests(&ctx)
+	if err != nil {
+		return n.createErrorResult(err.Error(), nil), nil
+	}
+
+	results := make([]map[string]any, len(requests))
+
+	group, groupCtx := errgroup.WithContext(context.Background())
+	group.SetLimit(n.concurrency)
+
+	for i, request := range requests {
+		group.Go(func() error {
+			if groupCtx.Err() != nil {
+				results[i] = requestResult(i, request, nil, nil, errSkipped)
+
+				return nil
+			}
+
+			statusCode, body, err := n.send(groupCtx, request)
+			results[i] = requestResult(i, request, statusCode, body, err)
+
+			if err != nil && n.mode == ModeFailFast {
+				return fmt.Errorf("request %d to %s failed: %w", i, request.URL, err)
+			}
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return n.createErrorResult(err.Error(), results), nil
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   batchData(results),
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// renderRequests renders the request specifications of the batch. Each request of an items
+// batch is rendered with the item as .item and its position as .index.
+func (n *HTTPBatchNode) renderRequests(ctx *models.ExecutionContext) ([]RequestSpec, error) {
+	if n.items == nil {
+		requests := make([]RequestSpec, 0, len(n.requests))
+
+		for i, spec := range n.requests {
+			request, err := renderRequestSpec(spec, ctx, map[string]any{"index": i})
+			if err != nil {
+				return nil, fmt.Errorf("failed to render request %d: %w", i, err)
+			}
+
+			requests = append(requests, request)
+		}
+
+		return requests, nil
+	}
+
+	items, ok := n.items.([]any)
+	if expression, isTemplate := n.items.(string); isTemplate {
+		rendered, err := template.RenderWithContext(expression, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render items template: %w", err)
+		}
+
+		items, ok = rendered.([]any)
+	}
+
+	if !ok {
+		return nil, errors.New("items must be an array, e.g. {{json .trigger_data.subscribers}}")
+	}
+
+	requests := make([]RequestSpec, 0, len(items))
+
+	for i, item := range items {
+		request, err := renderRequestSpec(n.request, ctx, map[string]any{"item": item, "index": i})
+		if err != nil {
+			return nil, fmt.Errorf("failed to render request %d: %w", i, err)
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
+
+// renderRequestSpec renders the URL, headers and body of spec.
+func renderRequestSpec(spec RequestSpec, ctx *models.ExecutionContext, data map[string]any) (RequestSpec, error) {
+	rendered := RequestSpec{
+		Method:  spec.Method,
+		Headers: make(map[string]string, len(spec.Headers)),
+	}
+
+	var err error
+
+	if rendered.URL, err = template.RenderStringWithData(spec.URL, ctx, data); err != nil {
+		return RequestSpec{}, fmt.Errorf("url: %w", err)
+	}
+
+	if rendered.Body, err = template.RenderStringWithData(spec.Body, ctx, data); err != nil {
+		return RequestSpec{}, fmt.Errorf("body: %w", err)
+	}
+
+	for key, value := range spec.Headers {
+		if rendered.Headers[key], err = template.RenderStringWithData(value, ctx, data); err != nil {
+			return RequestSpec{}, fmt.Errorf("header %s: %w", key, err)
+		}
+	}
+
+	return rendered, nil
+}
+
+// send sends request and returns the status code and body of the response. A response with a
+// status of 400 or above is returned with an error.
+func (n *HTTPBatchNode) send(ctx context.Context, request RequestSpec) (*int, []byte, error) {
+	var body io.Reader
+	if request.Body != "" {
+		body = strings.NewReader(request.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, request.Method, request.URL, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, value := range request.Headers {
+		req.Header.Set(key, value)
+	}
+
+	if request.Body != "" && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &resp.StatusCode, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return &resp.StatusCode, respBody, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	return &resp.StatusCode, respBody, nil
+}
+
+// requestResult builds the result of the request at index: its status code and body, parsed
+// as JSON when possible, and its error.
+func requestResult(index int, request RequestSpec, statusCode *int, body []byte, err error) map[string]any {
+	result := map[string]any{
+		"index":   index,
+		"url":     request.URL,
+		"method":  request.Method,
+		"success": err == nil,
+	}
+
+	if statusCode != nil {
+		result["status_code"] = *statusCode
+	}
+
+	if body != nil {
+		result["body"] = string(body)
+
+		var jsonBody any
+		if json.Unmarshal(body, &jsonBody) == nil {
+			result["json"] = jsonBody
+		}
+	}
+
+	if err != nil {
+		result["error"] = err.Error()
+	}
+
+	return result
+}
+
+// batchData returns the data of a batch: its ordered results and how many succeeded and failed.
+func batchData(results []map[string]any) map[string]any {
+	items := make([]any, 0, len(results))
+	succeeded := 0
+
+	for _, result := range results {
+		if result["success"] == true {
+			succeeded++
+		}
+
+		items = append(items, result)
+	}
+
+	return map[string]any{
+		"results":   items,
+		"total":     len(items),
+		"succeeded": succeeded,
+		"failed":    len(items) - succeeded,
+	}
+}
+
+// createErrorResult creates a NodeResult for the error output port, with the results of the
+// requests when the batch was sent.
+func (n *HTTPBatchNode) createErrorResult(errorMessage string, results []map[string]any) map[string]models.NodeResult {
+	data := map[string]any{
+		"error":   errorMessage,
+		"success": false,
+	}
+
+	if results != nil {
+		maps.Copy(data, batchData(results))
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *HTTPBatchNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Triggers the batch; requests read the execution context through templates",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *HTTPBatchNode) OutputPorts() []models.OutputPort {
+	resultsSchema := map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"index":       map[string]any{"type": "integer"},
+				"url":         map[string]any{"type": "string"},
+				"method":      map[string]any{"type": "string"},
+				"status_code": map[string]any{"type": "integer"},
+				"body":        map[string]any{"type": "string"},
+				"json":        map[string]any{},
+				"error":       map[string]any{"type": "string"},
+				"success":     map[string]any{"type": "boolean"},
+			},
+		},
+	}
+
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Results of the requests in batch order, with the number that succeeded and failed",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"results":   resultsSchema,
+						"total":     map[string]any{"type": "integer"},
+						"succeeded": map[string]any{"type": "integer"},
+						"failed":    map[string]any{"type": "integer"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the requests cannot be rendered, or a request fails in fail_fast mode",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+						"results": resultsSchema,
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the HTTP batch node.
+func (n *HTTPBatchNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *HTTPBatchNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the batch fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	_, hasRequests := config["requests"]
+	_, hasItems := config["items"]
+
+	switch {
+	case hasRequests && hasItems:
+		return errors.New("configure either 'requests' or 'items' with 'request', not both")
+	case hasRequests:
+		requests, ok := config["requests"].([]any)
+		if !ok || len(requests) == 0 {
+			return errors.New("field 'requests' must be a non-empty array")
+		}
+
+		for i, request := range requests {
+			if err := validateRequestSpec(request); err != nil {
+				return fmt.Errorf("requests[%d]: %w", i, err)
+			}
+		}
+	case hasItems:
+		switch items := config["items"].(type) {
+		case string, []any:
+		default:
+			return fmt.Errorf("items must be an array or a template rendering one, got %T", items)
+		}
+
+		if err := validateRequestSpec(config["request"]); err != nil {
+			return fmt.Errorf("request: %w", err)
+		}
+	default:
+		return errors.New("missing required field 'requests' or 'items'")
+	}
+
+	if concurrency, exists := config["concurrency"]; exists {
+		if value, ok := concurrency.(float64); !ok || value < 1 || value != float64(int(value)) {
+			return errors.New("concurrency must be a positive integer")
+		}
+	}
+
+	if timeout, exists := config["timeout"]; exists {
+		if value, ok := timeout.(float64); !ok || value <= 0 {
+			return errors.New("timeout must be a positive number of seconds")
+		}
+	}
+
+	mode, _ := config["mode"].(string)
+	if mode != "" && mode != ModeCollectAll && mode != ModeFailFast {
+		return fmt.Errorf("invalid mode '%s' (supported: collect_all, fail_fast)", mode)
+	}
+
+	return nil
+}
+
+// validateRequestSpec validates the configuration of a request specification.
+func validateRequestSpec(value any) error {
+	spec, ok := value.(map[string]any)
+	if !ok {
+		return errors.New("must be an object")
+	}
+
+	if url, ok := spec["url"].(string); !ok || url == "" {
+		return errors.New("missing required field 'url'")
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package httpbatch
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrencyServer records the highest number of requests it handled at once.
+type concurrencyServer struct {
+	*httptest.Server
+
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+
+	mu     sync.Mutex
+	bodies []string
+}
+
+func newConcurrencyServer(t *testing.T, delay time.Duration) *concurrencyServer {
+	t.Helper()
+
+	server := &concurrencyServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := server.inFlight.Add(1)
+		defer server.inFlight.Add(-1)
+
+		for {
+			highest := server.maxInFlight.Load()
+			if current <= highest || server.maxInFlight.CompareAndSwap(highest, current) {
+				break
+			}
+		}
+
+		body, _ := io.ReadAll(r.Body)
+
+		server.mu.Lock()
+		server.bodies = append(server.bodies, string(body))
+		server.mu.Unlock()
+
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/fail") {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"path": %q}`, r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func executeBatch(t *testing.T, config map[string]any, triggerData map[string]any) map[string]models.NodeResult {
+	t.Helper()
+
+	node, err := NewHTTPBatchNodeFactory().Create(t.Context(), "notify", config)
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{TriggerData: triggerData}, map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	return results
+}
+
+func TestNewHTTPBatchNode_InvalidConfig(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{},
+		{"requests": []any{}},
+		{"requests": []any{map[string]any{"method": "POST"}}},
+		{"items": "{{json .trigger_data.subscribers}}"},
+		{"items": float64(3), "request": map[string]any{"url": "https://example.com"}},
+		{"requests": []any{map[string]any{"url": "https://example.com"}}, "items": []any{1}, "request": map[string]any{"url": "https://example.com"}},
+		{"requests": []any{map[string]any{"url": "https://example.com"}}, "concurrency": float64(0)},
+		{"requests": []any{map[string]any{"url": "https://example.com"}}, "mode": "best_effort"},
+	}
+
+	for _, config := range invalidConfigs {
+		_, err := NewHTTPBatchNode("notify", config)
+		assert.Error(t, err, "config %v", config)
+	}
+}
+
+func TestHTTPBatchNode_Execute_RespectsConcurrency(t *testing.T) {
+	server := newConcurrencyServer(t, 50*time.Millisecond)
+
+	subscribers := make([]any, 10)
+	for i := range subscribers {
+		subscribers[i] = map[string]any{"id": fmt.Sprintf("sub-%d", i)}
+	}
+
+	results := executeBatch(t, map[string]any{
+		"items": "{{json .trigger_data.subscribers}}",
+		"request": map[string]any{
+			"url":    server.URL + "/subscribers/{{.item.id}}",
+			"method": "POST",
+			"body":   `{"event": "{{.trigger_data.event}}", "position": {{.index}}}`,
+		},
+		"concurrency": float64(3),
+	}, map[string]any{"subscribers": subscribers, "event": "order.created"})
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok, "results: %v", results)
+	assert.Equal(t, 10, result.Data["total"])
+	assert.Equal(t, 10, result.Data["succeeded"])
+	assert.Equal(t, 0, result.Data["failed"])
+
+	requests, ok := result.Data["results"].([]any)
+	require.True(t, ok)
+	require.Len(t, requests, 10)
+
+	for i, request := range requests {
+		request := request.(map[string]any)
+		assert.Equal(t, i, request["index"], "results keep the order of the items")
+		assert.Equal(t, http.StatusOK, request["status_code"])
+		assert.Equal(t, map[string]any{"path": fmt.Sprintf("/subscribers/sub-%d", i)}, request["json"])
+	}
+
+	assert.Equal(t, int32(3), server.maxInFlight.Load(), "at most 3 requests are in flight")
+	assert.Len(t, server.bodies, 10)
+	assert.Contains(t, server.bodies, `{"event": "order.created", "position": 9}`)
+}
+
+func TestHTTPBatchNode_Execute_CollectAll(t *testing.T) {
+	server := newConcurrencyServer(t, 0)
+
+	results := executeBatch(t, map[string]any{
+		"requests": []any{
+			map[string]any{"url": server.URL + "/ok"},
+			map[string]any{"url": server.URL + "/fail"},
+			map[string]any{"url": server.URL + "/ok-again"},
+		},
+	}, nil)
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok)
+	assert.Equal(t, 2, result.Data["succeeded"])
+	assert.Equal(t, 1, result.Data["failed"])
+
+	failed := result.Data["results"].([]any)[1].(map[string]any)
+	assert.Equal(t, http.StatusServiceUnavailable, failed["status_code"])
+	assert.Equal(t, "HTTP 503", failed["error"])
+	assert.Equal(t, false, failed["success"])
+}
+
+func TestHTTPBatchNode_Execute_FailFast(t *testing.T) {
+	server := newConcurrencyServer(t, 20*time.Millisecond)
+
+	requests := []any{map[string]any{"url": server.URL + "/fail"}}
+	for i := range 5 {
+		requests = append(requests, map[string]any{"url": fmt.Sprintf("%s/ok/%d", server.URL, i)})
+	}
+
+	results := executeBatch(t, map[string]any{
+		"requests":    requests,
+		"concurrency": float64(1),
+		"mode":        ModeFailFast,
+	}, nil)
+
+	result, ok := results[OutputPortError]
+	require.True(t, ok, "results: %v", results)
+	assert.Contains(t, result.Error, "request 0 to "+server.URL+"/fail failed: HTTP 503")
+	assert.Equal(t, 6, result.Data["total"])
+	assert.Equal(t, 0, result.Data["succeeded"])
+
+	skipped := result.Data["results"].([]any)[5].(map[string]any)
+	assert.Equal(t, errSkipped.Error(), skipped["error"])
+	assert.Len(t, server.bodies, 1, "requests after the failure are not sent")
+}
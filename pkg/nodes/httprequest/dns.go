@@ -0,0 +1,195 @@
+package httprequest
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Resolver resolves host names, reporting how long the addresses may be cached.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) (addrs []string, ttl time.Duration, err error)
+}
+
+// SystemResolver resolves host names with the system resolver, honoring the hosts file and
+// search domains. The standard library does not expose record TTLs, so every answer is reported
+// with the configured TTL.
+type SystemResolver struct {
+	resolver *net.Resolver
+	ttl      time.Duration
+}
+
+// NewSystemResolver creates a system resolver reporting answers with ttl.
+func NewSystemResolver(ttl time.Duration) *SystemResolver {
+	return &SystemResolver{resolver: net.DefaultResolver, ttl: ttl}
+}
+
+// LookupHost resolves host with the system resolver.
+func (r *SystemResolver) LookupHost(ctx context.Context, host string) ([]string, time.Duration, error) {
+	addrs, err := r.resolver.LookupHost(ctx, host)
+
+	return addrs, r.ttl, err
+}
+
+// dnsCacheEntry is a cached answer, an error for failed lookups.
+type dnsCacheEntry struct {
+	host      string
+	addrs     []string
+	err       error
+	expiresAt time.Time
+}
+
+// CachingResolver caches the answers of a resolver for their TTL, and failed lookups for a
+// negative TTL, keeping at most size hosts and evicting the least recently used. Concurrent
+// lookups of a host missing from the cache share a single query, so bursts of requests to the
+// same host do not hammer DNS.
+type CachingResolver struct {
+	resolver    Resolver
+	size        int
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	lookups singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	recent  *list.List
+}
+
+// NewCachingResolver caches the answers of resolver for up to size hosts, remembering failed
+// lookups for negativeTTL.
+func NewCachingResolver(resolver Resolver, size int, negativeTTL time.Duration) *CachingResolver {
+	return &CachingResolver{
+		resolver:    resolver,
+		size:        size,
+		negativeTTL: negativeTTL,
+		now:         time.Now,
+		entries:     make(map[string]*list.Element),
+		recent:      list.New(),
+	}
+}
+
+// LookupHost returns the addresses of host, from the cache while its answer has not expired.
+func (c *CachingResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if entry, ok := c.cached(host); ok {
+		return entry.addrs, entry.err
+	}
+
+	result, err, _ := c.lookups.Do(host, func() (any, error) {
+		// The lookup is shared, so it must not be cancelled with the first caller
+		addrs, ttl, err := c.resolver.LookupHost(context.WithoutCancel(ctx), host)
+
+		if err != nil {
+			// Only hosts that do not exist are remembered, temporary failures are retried
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				c.store(&dnsCacheEntry{host: host, err: err, expiresAt: c.now().Add(c.negativeTTL)})
+			}
+
+			return nil, err
+		}
+
+		c.store(&dnsCacheEntry{host: host, addrs: addrs, expiresAt: c.now().Add(ttl)})
+
+		return addrs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, _ := result.([]string)
+
+	return addrs, nil
+}
+
+// cached returns the unexpired cached answer of host, marking it as recently used.
+func (c *CachingResolver) cached(host string) (*dnsCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[host]
+	if !ok {
+		return nil, false
+	}
+
+	entry, _ := element.Value.(*dnsCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.recent.Remove(element)
+		delete(c.entries, host)
+
+		return nil, false
+	}
+
+	c.recent.MoveToFront(element)
+
+	return entry, true
+}
+
+// store caches an answer, evicting the least recently used hosts beyond the cache size.
+func (c *CachingResolver) store(entry *dnsCacheEntry) {
+	if c.size <= 0 || !c.now().Before(entry.expiresAt) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[entry.host]; ok {
+		element.Value = entry
+		c.recent.MoveToFront(element)
+
+		return
+	}
+
+	c.entries[entry.host] = c.recent.PushFront(entry)
+
+	for c.recent.Len() > c.size {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+
+		evicted, _ := oldest.Value.(*dnsCacheEntry)
+		delete(c.entries, evicted.host)
+	}
+}
+
+// DialContext returns a dial function resolving host names through the cache and connecting to
+// their addresses in order with dialer. IP addresses are dialed directly.
+func (c *CachingResolver) DialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		if net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		addrs, err := c.LookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var dialErr error
+
+		for _, addr := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(addr, port))
+			if err == nil {
+				return conn, nil
+			}
+
+			dialErr = err
+		}
+
+		if dialErr == nil {
+			dialErr = &net.DNSError{Err: "no addresses", Name: host, IsNotFound: true}
+		}
+
+		return nil, dialErr
+	}
+}
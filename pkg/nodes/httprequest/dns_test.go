@@ -0,0 +1,179 @@
+package httprequest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// fakeResolver answers lookups from a map, counting the lookups of each host.
+type fakeResolver struct {
+	mu      sync.Mutex
+	addrs   map[string][]string
+	ttl     time.Duration
+	lookups map[string]int
+}
+
+func newFakeResolver(ttl time.Duration, addrs map[string][]string) *fakeResolver {
+	return &fakeResolver{addrs: addrs, ttl: ttl, lookups: make(map[string]int)}
+}
+
+func (r *fakeResolver) LookupHost(_ context.Context, host string) ([]string, time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lookups[host]++
+
+	addrs, ok := r.addrs[host]
+	if !ok {
+		return nil, 0, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	return addrs, r.ttl, nil
+}
+
+func (r *fakeResolver) lookupsOf(host string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.lookups[host]
+}
+
+func TestCachingResolver_ServesFromCacheWithinTTL(t *testing.T) {
+	resolver := newFakeResolver(time.Minute, map[string][]string{"api.example.com": {"10.0.0.1"}})
+	cache := NewCachingResolver(resolver, 10, 5*time.Second)
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	for range 3 {
+		addrs, err := cache.LookupHost(t.Context(), "api.example.com")
+		if err != nil {
+			t.Fatalf("LookupHost failed: %v", err)
+		}
+
+		if !slices.Equal(addrs, []string{"10.0.0.1"}) {
+			t.Fatalf("Expected the resolved address, got: %v", addrs)
+		}
+	}
+
+	if lookups := resolver.lookupsOf("api.example.com"); lookups != 1 {
+		t.Fatalf("Expected repeated lookups to be served from cache, got %d lookups", lookups)
+	}
+
+	// The host is resolved again once its TTL expired
+	resolver.addrs["api.example.com"] = []string{"10.0.0.2"}
+	now = now.Add(59 * time.Second)
+
+	if addrs, _ := cache.LookupHost(t.Context(), "api.example.com"); !slices.Equal(addrs, []string{"10.0.0.1"}) {
+		t.Fatalf("Expected the cached address within the TTL, got: %v", addrs)
+	}
+
+	now = now.Add(time.Second)
+
+	if addrs, _ := cache.LookupHost(t.Context(), "api.example.com"); !slices.Equal(addrs, []string{"10.0.0.2"}) {
+		t.Fatalf("Expected the re-resolved address after the TTL, got: %v", addrs)
+	}
+
+	if lookups := resolver.lookupsOf("api.example.com"); lookups != 2 {
+		t.Fatalf("Expected the host to be resolved again after expiry, got %d lookups", lookups)
+	}
+}
+
+func TestCachingResolver_NegativeCache(t *testing.T) {
+	resolver := newFakeResolver(time.Minute, map[string][]string{})
+	cache := NewCachingResolver(resolver, 10, 5*time.Second)
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	for range 2 {
+		_, err := cache.LookupHost(t.Context(), "missing.example.com")
+
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			t.Fatalf("Expected a not found error, got: %v", err)
+		}
+	}
+
+	if lookups := resolver.lookupsOf("missing.example.com"); lookups != 1 {
+		t.Fatalf("Expected the failed lookup to be cached, got %d lookups", lookups)
+	}
+
+	resolver.addrs["missing.example.com"] = []string{"10.0.0.3"}
+	now = now.Add(5 * time.Second)
+
+	if addrs, err := cache.LookupHost(t.Context(), "missing.example.com"); err != nil || !slices.Equal(addrs, []string{"10.0.0.3"}) {
+		t.Fatalf("Expected the host to resolve after the negative TTL, got: %v, %v", addrs, err)
+	}
+}
+
+func TestCachingResolver_EvictsLeastRecentlyUsed(t *testing.T) {
+	resolver := newFakeResolver(time.Minute, map[string][]string{
+		"a.example.com": {"10.0.0.1"},
+		"b.example.com": {"10.0.0.2"},
+		"c.example.com": {"10.0.0.3"},
+	})
+	cache := NewCachingResolver(resolver, 2, time.Second)
+
+	for _, host := range []string{"a.example.com", "b.example.com", "a.example.com", "c.example.com", "a.example.com", "b.example.com"} {
+		if _, err := cache.LookupHost(t.Context(), host); err != nil {
+			t.Fatalf("LookupHost failed: %v", err)
+		}
+	}
+
+	// b was the least recently used host when c was added
+	for host, expected := range map[string]int{"a.example.com": 1, "b.example.com": 2, "c.example.com": 1} {
+		if lookups := resolver.lookupsOf(host); lookups != expected {
+			t.Errorf("Expected %d lookups of %s, got %d", expected, host, lookups)
+		}
+	}
+}
+
+func TestHTTPRequestNode_Execute_ThroughDNSCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(r.Host))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse server URL: %v", err)
+	}
+
+	resolver := newFakeResolver(time.Minute, map[string][]string{"orders.internal": {serverURL.Hostname()}})
+	ConfigureDNSCache(NewCachingResolver(resolver, 10, time.Second))
+	t.Cleanup(func() { ConfigureDNSCache(nil) })
+
+	node, err := NewHTTPRequestNode("cached", map[string]any{
+		"url": "http://orders.internal:" + serverURL.Port() + "/orders",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	for range 3 {
+		results, err := node.Execute(models.ExecutionContext{ID: "test-execution"}, map[string]models.NodeResult{})
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+
+		if _, ok := results[OutputPortSuccess]; !ok {
+			t.Fatalf("Expected success port, got: %v", results)
+		}
+	}
+
+	if lookups := resolver.lookupsOf("orders.internal"); lookups != 1 {
+		t.Fatalf("Expected the host to be resolved once, got %d lookups", lookups)
+	}
+}
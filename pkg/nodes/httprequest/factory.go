@@ -55,6 +55,27 @@ func (f *HTTPRequestNodeFactory) Schema() map[string]any {
 				"default":     "GET",
 				"enum":        []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"},
 			},
+			"path": map[string]any{
+				"type":        "array",
+				"description": "Path segments appended to the URL. Each segment supports templating and is escaped on its own",
+				"items":       map[string]any{"type": []string{"string", "number", "boolean"}},
+				"examples": []any{
+					[]string{"users", "{{.trigger_data.webhook.body.user_id}}"},
+					[]string{"files", "{{.node_results.upload.json.name}}", "versions"},
+				},
+			},
+			"query": map[string]any{
+				"type":        "object",
+				"description": "Query parameters appended to the URL. Values support templating and are URL-encoded; array values become repeated parameters",
+				"additionalProperties": map[string]any{
+					"type":  []string{"string", "number", "boolean", "array"},
+					"items": map[string]any{"type": []string{"string", "number", "boolean"}},
+				},
+				"examples": []map[string]any{
+					{"q": "{{.trigger_data.webhook.body.search}}", "limit": 20},
+					{"tag": []string{"urgent", "{{.variables.team}}"}},
+				},
+			},
 			"headers": map[string]any{
 				"type":        "object",
 				"description": "HTTP headers. Values support templating",
@@ -71,6 +92,39 @@ func (f *HTTPRequestNodeFactory) Schema() map[string]any {
 					`{{.node_results.previous_step.json_data}}`,
 				},
 			},
+			"proxy": map[string]any{
+				"type":        "string",
+				"description": "Proxy URL requests are sent through. Supports templating; without it the HTTP_PROXY and HTTPS_PROXY environment variables apply",
+				"examples": []string{
+					"http://proxy.internal:3128",
+					"{{.env.EGRESS_PROXY_URL}}",
+				},
+			},
+			"tls": map[string]any{
+				"type":        "object",
+				"description": "Mutual TLS settings. PEM values support templating, so they can be read from variables or environment variables",
+				"properties": map[string]any{
+					"client_cert": map[string]any{
+						"type":        "string",
+						"description": "PEM encoded client certificate presented to the server",
+					},
+					"client_key": map[string]any{
+						"type":        "string",
+						"description": "PEM encoded private key of the client certificate",
+					},
+					"ca_cert": map[string]any{
+						"type":        "string",
+						"description": "PEM encoded CA bundle used to verify the server instead of the system roots",
+					},
+				},
+				"examples": []map[string]any{
+					{
+						"client_cert": "{{.env.INTERNAL_API_CLIENT_CERT}}",
+						"client_key":  "{{.env.INTERNAL_API_CLIENT_KEY}}",
+						"ca_cert":     "{{.env.INTERNAL_CA_BUNDLE}}",
+					},
+				},
+			},
 			"timeout": map[string]any{
 				"type":        "number",
 				"description": "Request timeout in seconds",
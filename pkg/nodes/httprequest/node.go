@@ -8,6 +8,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,10 +34,15 @@ type HTTPRequestNode struct {
 type HTTPRequestConfig struct {
 	URL     string            `json:"url"`
 	Method  string            `json:"method"`
+	Path    []any             `json:"path,omitempty"`
+	Query   map[string]any    `json:"query,omitempty"`
 	Headers map[string]string `json:"headers"`
 	Body    string            `json:"body,omitempty"`
 	Timeout int               `json:"timeout"`
 	Retries RetryConfig       `json:"retries"`
+
+	// Transport is read from the "proxy" and "tls" fields
+	Transport TransportConfig `json:"-"`
 }
 
 // RetryConfig defines retry behavior for HTTP requests.
@@ -65,6 +73,14 @@ func NewHTTPRequestNode(id string, config map[string]any) (*HTTPRequestNode, err
 		httpConfig.Method = strings.ToUpper(method)
 	}
 
+	if path, ok := config["path"].([]any); ok {
+		httpConfig.Path = path
+	}
+
+	if query, ok := config["query"].(map[string]any); ok {
+		httpConfig.Query = query
+	}
+
 	if headers, ok := config["headers"].(map[string]any); ok {
 		for k, v := range headers {
 			if strVal, ok := v.(string); ok {
@@ -81,6 +97,16 @@ func NewHTTPRequestNode(id string, config map[string]any) (*HTTPRequestNode, err
 		httpConfig.Timeout = int(timeout)
 	}
 
+	if proxy, ok := config["proxy"].(string); ok {
+		httpConfig.Transport.Proxy = proxy
+	}
+
+	if tlsConfig, ok := config["tls"].(map[string]any); ok {
+		httpConfig.Transport.ClientCert, _ = tlsConfig["client_cert"].(string)
+		httpConfig.Transport.ClientKey, _ = tlsConfig["client_key"].(string)
+		httpConfig.Transport.CACert, _ = tlsConfig["ca_cert"].(string)
+	}
+
 	// Parse retries
 	if retries, ok := config["retries"].(map[string]any); ok {
 		if attempts, ok := retries["attempts"].(float64); ok {
@@ -123,6 +149,12 @@ func (n *HTTPRequestNode) Execute(ctx models.ExecutionContext, inputs map[string
 		return n.createErrorResult("URL template must render to string"), nil
 	}
 
+	// Append the escaped path segments and the encoded query parameters
+	urlStr, err = n.buildURL(urlStr, &ctx)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
 	// Render body if present
 	var renderedBody string
 
@@ -149,6 +181,23 @@ func (n *HTTPRequestNode) Execute(ctx models.ExecutionContext, inputs map[string
 		}
 	}
 
+	// Route requests through the configured proxy, client certificate and DNS cache, keeping the default transport otherwise
+	var transport http.RoundTripper
+
+	if !n.config.Transport.isZero() || cachingResolver() != nil {
+		transportConfig, err := n.config.Transport.render(&ctx)
+		if err != nil {
+			return n.createErrorResult(err.Error()), nil
+		}
+
+		configured, err := transportFor(transportConfig)
+		if err != nil {
+			return n.createErrorResult(fmt.Sprintf("failed to configure transport: %v", err)), nil
+		}
+
+		transport = configured
+	}
+
 	// Perform HTTP request with retry logic
 	var lastErr error
 
@@ -157,7 +206,7 @@ func (n *HTTPRequestNode) Execute(ctx models.ExecutionContext, inputs map[string
 			time.Sleep(time.Duration(n.config.Retries.Delay) * time.Millisecond)
 		}
 
-		result, err := n.performRequest(urlStr, renderedBody, renderedHeaders)
+		result, err := n.performRequest(transport, urlStr, renderedBody, renderedHeaders)
 		if err == nil {
 			// Success - return result on success port
 			results[OutputPortSuccess] = models.NodeResult{
@@ -182,6 +231,91 @@ func (n *HTTPRequestNode) Execute(ctx models.ExecutionContext, inputs map[string
 	return n.createErrorResult(fmt.Sprintf("HTTP request failed after %d attempts: %v", n.config.Retries.Attempts, lastErr)), nil
 }
 
+// buildURL appends the rendered path segments and query parameters to the rendered URL.
+// Each path segment is escaped on its own, so a "/" in a value does not add a segment, and
+// array query values are encoded as repeated parameters.
+func (n *HTTPRequestNode) buildURL(rawURL string, ctx *models.ExecutionContext) (string, error) {
+	if len(n.config.Path) == 0 && len(n.config.Query) == 0 {
+		return rawURL, nil
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+
+	if len(n.config.Path) > 0 {
+		segments := make([]string, 0, len(n.config.Path))
+		escaped := make([]string, 0, len(n.config.Path))
+
+		for i, value := range n.config.Path {
+			segment, err := renderValue(value, ctx)
+			if err != nil {
+				return "", fmt.Errorf("failed to render path segment %d: %w", i, err)
+			}
+
+			segments = append(segments, segment)
+			escaped = append(escaped, url.PathEscape(segment))
+		}
+
+		escapedPath := strings.TrimSuffix(parsed.EscapedPath(), "/")
+		parsed.Path = strings.TrimSuffix(parsed.Path, "/") + "/" + strings.Join(segments, "/")
+		parsed.RawPath = escapedPath + "/" + strings.Join(escaped, "/")
+	}
+
+	if len(n.config.Query) > 0 {
+		query := url.Values{}
+
+		keys := make([]string, 0, len(n.config.Query))
+		for key := range n.config.Query {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			values, ok := n.config.Query[key].([]any)
+			if !ok {
+				values = []any{n.config.Query[key]}
+			}
+
+			for _, value := range values {
+				rendered, err := renderValue(value, ctx)
+				if err != nil {
+					return "", fmt.Errorf("failed to render query parameter '%s': %w", key, err)
+				}
+
+				query.Add(key, rendered)
+			}
+		}
+
+		if parsed.RawQuery != "" {
+			parsed.RawQuery += "&" + query.Encode()
+		} else {
+			parsed.RawQuery = query.Encode()
+		}
+	}
+
+	return parsed.String(), nil
+}
+
+// renderValue renders a path segment or query value: strings are templates rendered as is,
+// numbers and booleans are formatted.
+func renderValue(value any, ctx *models.ExecutionContext) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return template.RenderStringWithContext(v, ctx)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
 // HTTPError represents an HTTP error with status code.
 type HTTPError struct {
 	StatusCode int
@@ -193,7 +327,7 @@ func (e *HTTPError) Error() string {
 }
 
 // performRequest executes a single HTTP request.
-func (n *HTTPRequestNode) performRequest(url, body string, headers map[string]string) (map[string]any, error) {
+func (n *HTTPRequestNode) performRequest(transport http.RoundTripper, url, body string, headers map[string]string) (map[string]any, error) {
 	var reqBody io.Reader
 	if body != "" {
 		reqBody = strings.NewReader(body)
@@ -216,7 +350,8 @@ func (n *HTTPRequestNode) performRequest(url, body string, headers map[string]st
 
 	// Create HTTP client with timeout
 	client := &http.Client{
-		Timeout: time.Duration(n.config.Timeout) * time.Second,
+		Transport: transport,
+		Timeout:   time.Duration(n.config.Timeout) * time.Second,
 	}
 
 	// Perform request
@@ -355,6 +490,30 @@ func (n *HTTPRequestNode) Validate(config map[string]any) error {
 		}
 	}
 
+	if path, ok := config["path"]; ok {
+		if _, ok := path.([]any); !ok {
+			return errors.New("path must be an array of segments")
+		}
+	}
+
+	if query, ok := config["query"]; ok {
+		if _, ok := query.(map[string]any); !ok {
+			return errors.New("query must be an object")
+		}
+	}
+
+	if proxy, ok := config["proxy"]; ok {
+		if _, ok := proxy.(string); !ok {
+			return errors.New("proxy must be a string")
+		}
+	}
+
+	if tlsConfig, ok := config["tls"]; ok {
+		if err := validateTLSConfig(tlsConfig); err != nil {
+			return err
+		}
+	}
+
 	// Validate timeout if provided
 	if timeout, ok := config["timeout"].(float64); ok {
 		if timeout < 1 || timeout > 300 {
@@ -372,6 +531,31 @@ func (n *HTTPRequestNode) Validate(config map[string]any) error {
 	return nil
 }
 
+// validateTLSConfig validates the client certificate and CA bundle configuration.
+func validateTLSConfig(value any) error {
+	tlsConfig, ok := value.(map[string]any)
+	if !ok {
+		return errors.New("tls must be an object")
+	}
+
+	for _, field := range []string{"client_cert", "client_key", "ca_cert"} {
+		if value, ok := tlsConfig[field]; ok {
+			if _, ok := value.(string); !ok {
+				return fmt.Errorf("tls.%s must be a string", field)
+			}
+		}
+	}
+
+	_, hasCert := tlsConfig["client_cert"]
+	_, hasKey := tlsConfig["client_key"]
+
+	if hasCert != hasKey {
+		return errors.New("tls.client_cert and tls.client_key must be set together")
+	}
+
+	return nil
+}
+
 // validateRetryConfig validates retry configuration parameters.
 func validateRetryConfig(retries map[string]any) error {
 	if attempts, ok := retries["attempts"].(float64); ok {
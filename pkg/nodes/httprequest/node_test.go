@@ -3,6 +3,9 @@ package httprequest
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/dukex/operion/pkg/models"
@@ -399,3 +402,101 @@ func TestHTTPRequestNode_InputRequirements(t *testing.T) {
 		t.Errorf("Expected no timeout, got %v", requirements.Timeout)
 	}
 }
+
+func TestHTTPRequestNode_Execute_PathAndQuery(t *testing.T) {
+	var (
+		requestPath  string
+		requestQuery url.Values
+		rawQuery     string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.EscapedPath()
+		requestQuery = r.URL.Query()
+		rawQuery = r.URL.RawQuery
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := map[string]any{
+		"url":  server.URL + "/api/?version=2",
+		"path": []any{"users", "{{.trigger_data.name}}", "{{.trigger_data.file}}", float64(7)},
+		"query": map[string]any{
+			"q":      "{{.trigger_data.search}}",
+			"tag":    []any{"urgent", "{{.variables.team}}", "a&b=c"},
+			"limit":  float64(20),
+			"zip":    "{{.trigger_data.zip}}",
+			"active": true,
+		},
+	}
+
+	node, err := NewHTTPRequestNode("test-node", config)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	ctx := models.ExecutionContext{
+		ID:          "test-exec",
+		NodeResults: make(map[string]models.NodeResult),
+		Variables:   map[string]any{"team": "R&D"},
+		TriggerData: map[string]any{
+			"name":   "Ada Lovelace",
+			"file":   "reports/2024?.csv",
+			"search": "café & crème=100%",
+			"zip":    "00501",
+		},
+	}
+
+	results, err := node.Execute(ctx, make(map[string]models.NodeResult))
+	if err != nil {
+		t.Fatalf("Node execution failed: %v", err)
+	}
+
+	if _, ok := results[OutputPortSuccess]; !ok {
+		t.Fatalf("Expected success output port, got: %v", results)
+	}
+
+	// Each segment is escaped on its own, so "/" and "?" stay inside the segment
+	expectedPath := "/api/users/Ada%20Lovelace/reports%2F2024%3F.csv/7"
+	if requestPath != expectedPath {
+		t.Errorf("Expected path %q, got %q", expectedPath, requestPath)
+	}
+
+	expectedQuery := url.Values{
+		"version": {"2"},
+		"q":       {"café & crème=100%"},
+		"tag":     {"urgent", "R&D", "a&b=c"},
+		"limit":   {"20"},
+		"zip":     {"00501"},
+		"active":  {"true"},
+	}
+	if !reflect.DeepEqual(requestQuery, expectedQuery) {
+		t.Errorf("Expected query %v, got %v", expectedQuery, requestQuery)
+	}
+
+	// Array values are repeated parameters and special characters are percent-encoded
+	if !strings.Contains(rawQuery, "tag=urgent&tag=R%26D&tag=a%26b%3Dc") {
+		t.Errorf("Expected repeated, escaped tag parameters, got %q", rawQuery)
+	}
+
+	if !strings.Contains(rawQuery, "q=caf%C3%A9+%26+cr%C3%A8me%3D100%25") {
+		t.Errorf("Expected escaped q parameter, got %q", rawQuery)
+	}
+}
+
+func TestHTTPRequestNode_Validate_PathAndQuery(t *testing.T) {
+	node := &HTTPRequestNode{}
+
+	if err := node.Validate(map[string]any{"url": "https://example.com", "path": "users/1"}); err == nil {
+		t.Error("Expected error for non-array path")
+	}
+
+	if err := node.Validate(map[string]any{"url": "https://example.com", "query": "a=1"}); err == nil {
+		t.Error("Expected error for non-object query")
+	}
+
+	if err := node.Validate(map[string]any{"url": "https://example.com", "path": []any{"users"}, "query": map[string]any{"a": "1"}}); err != nil {
+		t.Errorf("Expected valid config, got: %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package httprequest
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+// TransportConfig defines how requests reach the target: through a proxy and with mutual TLS.
+// Certificates and keys are PEM encoded and support templating, so they can be read from
+// variables or environment variables instead of being stored in the workflow.
+type TransportConfig struct {
+	Proxy      string `json:"proxy,omitempty"`
+	ClientCert string `json:"client_cert,omitempty"`
+	ClientKey  string `json:"client_key,omitempty"`
+	CACert     string `json:"ca_cert,omitempty"`
+}
+
+// maxCachedTransports bounds the transports cached at once, as templated transport settings can
+// render a distinct configuration per execution.
+const maxCachedTransports = 64
+
+// cachedTransport is a transport cached under the hash of its rendered configuration.
+type cachedTransport struct {
+	key       string
+	transport *http.Transport
+}
+
+// transports caches the transports built for each distinct rendered transport configuration,
+// so connections are reused across requests instead of rebuilding a transport per request. At
+// most maxCachedTransports are kept, evicting the least recently used.
+var transports = struct {
+	sync.Mutex
+
+	byKey  map[string]*list.Element
+	recent *list.List
+}{byKey: make(map[string]*list.Element), recent: list.New()}
+
+// dnsCache is the caching resolver the transports dial through, nil to resolve every connection
+// with the system resolver.
+var dnsCache struct {
+	sync.RWMutex
+
+	resolver *CachingResolver
+}
+
+// ConfigureDNSCache makes HTTP request nodes resolve host names through resolver, nil to stop
+// caching. Transports built before are discarded, so every request uses the new resolver.
+func ConfigureDNSCache(resolver *CachingResolver) {
+	dnsCache.Lock()
+	dnsCache.resolver = resolver
+	dnsCache.Unlock()
+
+	transports.Lock()
+	clear(transports.byKey)
+	transports.recent.Init()
+	transports.Unlock()
+}
+
+// cachingResolver returns the configured caching resolver, nil when DNS answers are not cached.
+func cachingResolver() *CachingResolver {
+	dnsCache.RLock()
+	defer dnsCache.RUnlock()
+
+	return dnsCache.resolver
+}
+
+// isZero reports whether the configuration keeps the default transport.
+func (c TransportConfig) isZero() bool {
+	return c == TransportConfig{}
+}
+
+// render renders the templates in the configuration with the execution context.
+func (c TransportConfig) render(ctx *models.ExecutionContext) (TransportConfig, error) {
+	var rendered TransportConfig
+
+	fields := []struct {
+		name  string
+		value string
+		out   *string
+	}{
+		{"proxy", c.Proxy, &rendered.Proxy},
+		{"client_cert", c.ClientCert, &rendered.ClientCert},
+		{"client_key", c.ClientKey, &rendered.ClientKey},
+		{"ca_cert", c.CACert, &rendered.CACert},
+	}
+
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+
+		value, err := template.RenderStringWithContext(field.value, ctx)
+		if err != nil {
+			return TransportConfig{}, fmt.Errorf("failed to render %s template: %w", field.name, err)
+		}
+
+		*field.out = value
+	}
+
+	return rendered, nil
+}
+
+// transportFor returns the cached transport for the rendered configuration, building it on first
+// use. The idle connections of the least recently used transport evicted beyond
+// maxCachedTransports are closed; requests still using it complete.
+func transportFor(config TransportConfig) (*http.Transport, error) {
+	hash := sha256.Sum256([]byte(config.Proxy + "\x00" + config.ClientCert + "\x00" + config.ClientKey + "\x00" + config.CACert))
+	key := hex.EncodeToString(hash[:])
+
+	transports.Lock()
+	defer transports.Unlock()
+
+	if element, ok := transports.byKey[key]; ok {
+		transports.recent.MoveToFront(element)
+
+		cached, _ := element.Value.(*cachedTransport)
+
+		return cached.transport, nil
+	}
+
+	transport, err := newTransport(config)
+	if err != nil {
+		return nil, err
+	}
+
+	transports.byKey[key] = transports.recent.PushFront(&cachedTransport{key: key, transport: transport})
+
+	for transports.recent.Len() > maxCachedTransports {
+		oldest := transports.recent.Back()
+		transports.recent.Remove(oldest)
+
+		evicted, _ := oldest.Value.(*cachedTransport)
+		delete(transports.byKey, evicted.key)
+		evicted.transport.CloseIdleConnections()
+	}
+
+	return transport, nil
+}
+
+// newTransport builds a transport from the default one, resolving hosts through the DNS cache
+// when configured, routing requests through the configured proxy and presenting the configured
+// client certificate.
+func newTransport(config TransportConfig) (*http.Transport, error) {
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("default transport is not an *http.Transport")
+	}
+
+	transport := defaultTransport.Clone()
+
+	if resolver := cachingResolver(); resolver != nil {
+		transport.DialContext = resolver.DialContext(&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second})
+	}
+
+	if config.Proxy != "" {
+		proxyURL, err := url.Parse(config.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if config.ClientCert == "" && config.ClientKey == "" && config.CACert == "" {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if config.ClientCert != "" || config.ClientKey != "" {
+		certificate, err := tls.X509KeyPair([]byte(config.ClientCert), []byte(config.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if config.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(config.CACert)) {
+			return nil, errors.New("invalid CA bundle: no PEM certificates found")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
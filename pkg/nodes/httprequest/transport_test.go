@@ -0,0 +1,288 @@
+package httprequest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// newClientCertificate returns a self-signed client certificate and its key, PEM encoded.
+func newClientCertificate(t *testing.T) (*x509.Certificate, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "operion-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certificate, string(certPEM), string(keyPEM)
+}
+
+func TestHTTPRequestNode_Execute_ThroughProxy(t *testing.T) {
+	var proxiedURL string
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"via": "proxy"}`))
+	}))
+	defer proxy.Close()
+
+	node, err := NewHTTPRequestNode("proxied", map[string]any{
+		"url":   "http://internal.example/users",
+		"proxy": "{{.variables.proxy_url}}",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	execCtx := models.ExecutionContext{
+		ID:          "test-execution",
+		NodeResults: map[string]models.NodeResult{},
+		Variables:   map[string]any{"proxy_url": proxy.URL},
+	}
+
+	results, err := node.Execute(execCtx, map[string]models.NodeResult{})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	result, ok := results[OutputPortSuccess]
+	if !ok {
+		t.Fatalf("Expected success port, got: %v", results)
+	}
+
+	if proxiedURL != "http://internal.example/users" {
+		t.Errorf("Expected the proxy to receive the target URL, got: %s", proxiedURL)
+	}
+
+	if result.Data["body"] != `{"via": "proxy"}` {
+		t.Errorf("Expected the proxy response, got: %v", result.Data["body"])
+	}
+}
+
+func TestHTTPRequestNode_Execute_MutualTLS(t *testing.T) {
+	clientCertificate, clientCert, clientKey := newClientCertificate(t)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCertificate)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	execCtx := models.ExecutionContext{
+		ID:          "test-execution",
+		NodeResults: map[string]models.NodeResult{},
+		Variables: map[string]any{
+			"client_cert": clientCert,
+			"client_key":  clientKey,
+			"ca_cert":     caCert,
+		},
+	}
+
+	node, err := NewHTTPRequestNode("mtls", map[string]any{
+		"url": server.URL,
+		"tls": map[string]any{
+			"client_cert": "{{.variables.client_cert}}",
+			"client_key":  "{{.variables.client_key}}",
+			"ca_cert":     "{{.variables.ca_cert}}",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	results, err := node.Execute(execCtx, map[string]models.NodeResult{})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	result, ok := results[OutputPortSuccess]
+	if !ok {
+		t.Fatalf("Expected success port, got: %v", results)
+	}
+
+	if result.Data["body"] != "operion-client" {
+		t.Errorf("Expected the server to see the client certificate, got: %v", result.Data["body"])
+	}
+
+	// Without the client certificate the handshake is rejected
+	node, err = NewHTTPRequestNode("no-client-cert", map[string]any{
+		"url": server.URL,
+		"tls": map[string]any{"ca_cert": "{{.variables.ca_cert}}"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	results, err = node.Execute(execCtx, map[string]models.NodeResult{})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	if _, ok := results[OutputPortError]; !ok {
+		t.Errorf("Expected error port without a client certificate, got: %v", results)
+	}
+}
+
+func TestTransportFor_CachesPerConfig(t *testing.T) {
+	_, clientCert, clientKey := newClientCertificate(t)
+
+	first, err := transportFor(TransportConfig{Proxy: "http://proxy-a.example:3128"})
+	if err != nil {
+		t.Fatalf("transportFor failed: %v", err)
+	}
+
+	second, err := transportFor(TransportConfig{Proxy: "http://proxy-a.example:3128"})
+	if err != nil {
+		t.Fatalf("transportFor failed: %v", err)
+	}
+
+	if first != second {
+		t.Error("Expected the same transport for the same configuration")
+	}
+
+	other, err := transportFor(TransportConfig{
+		Proxy:      "http://proxy-a.example:3128",
+		ClientCert: clientCert,
+		ClientKey:  clientKey,
+	})
+	if err != nil {
+		t.Fatalf("transportFor failed: %v", err)
+	}
+
+	if other == first {
+		t.Error("Expected a different transport for a different configuration")
+	}
+
+	if _, err := transportFor(TransportConfig{ClientCert: clientCert, ClientKey: "invalid"}); err == nil {
+		t.Error("Expected an error for an invalid client key")
+	}
+}
+
+func TestTransportFor_EvictsLeastRecentlyUsed(t *testing.T) {
+	ConfigureDNSCache(nil)
+
+	first, err := transportFor(TransportConfig{Proxy: "http://proxy-0.example:3128"})
+	if err != nil {
+		t.Fatalf("transportFor failed: %v", err)
+	}
+
+	for i := 1; i <= maxCachedTransports; i++ {
+		if _, err := transportFor(TransportConfig{Proxy: fmt.Sprintf("http://proxy-%d.example:3128", i)}); err != nil {
+			t.Fatalf("transportFor failed: %v", err)
+		}
+	}
+
+	if got := len(transports.byKey); got != maxCachedTransports {
+		t.Errorf("Expected %d cached transports, got %d", maxCachedTransports, got)
+	}
+
+	again, err := transportFor(TransportConfig{Proxy: "http://proxy-0.example:3128"})
+	if err != nil {
+		t.Fatalf("transportFor failed: %v", err)
+	}
+
+	if again == first {
+		t.Error("Expected the least recently used transport to be evicted")
+	}
+}
+
+func TestHTTPRequestNode_Validate_Transport(t *testing.T) {
+	node := &HTTPRequestNode{id: "test-node"}
+
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{
+			name:   "proxy and tls",
+			config: map[string]any{"url": "https://example.com", "proxy": "http://proxy:3128", "tls": map[string]any{"client_cert": "c", "client_key": "k"}},
+		},
+		{
+			name:    "proxy not a string",
+			config:  map[string]any{"url": "https://example.com", "proxy": 3128.0},
+			wantErr: "proxy must be a string",
+		},
+		{
+			name:    "tls not an object",
+			config:  map[string]any{"url": "https://example.com", "tls": "on"},
+			wantErr: "tls must be an object",
+		},
+		{
+			name:    "certificate without key",
+			config:  map[string]any{"url": "https://example.com", "tls": map[string]any{"client_cert": "c"}},
+			wantErr: "must be set together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := node.Validate(tt.config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Package jwtsign provides JWT sign node factory for registry integration.
+package jwtsign
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// JWTSignNodeFactory creates JWTSignNode instances.
+type JWTSignNodeFactory struct{}
+
+// Create creates a new JWTSignNode instance.
+func (f *JWTSignNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewJWTSignNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *JWTSignNodeFactory) ID() string {
+	return "jwtsign"
+}
+
+// Name returns the factory name.
+func (f *JWTSignNodeFactory) Name() string {
+	return "Sign JWT"
+}
+
+// Description returns the factory description.
+func (f *JWTSignNodeFactory) Description() string {
+	return "Builds claims from the execution context and signs them into a JSON Web Token for downstream calls"
+}
+
+// Schema returns the JSON schema for JWT sign node configuration.
+func (f *JWTSignNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"algorithm": map[string]any{
+				"type":        "string",
+				"description": "Signing algorithm",
+				"enum":        algorithms,
+			},
+			"key": map[string]any{
+				"type":        "string",
+				"description": "PEM private key for RS256 and ES256, shared secret for HS256. Environment variables are expanded.",
+				"examples":    []string{"${SERVICE_ACCOUNT_PRIVATE_KEY}"},
+			},
+			"key_id": map[string]any{
+				"type":        "string",
+				"description": "Key ID set as the kid header, required by some providers to select the verification key",
+				"examples":    []string{"ABC123DEFG"},
+			},
+			"claims": map[string]any{
+				"type":        []string{"object", "string"},
+				"description": "Claims of the token: an object whose string values are templates, or a template rendering a JSON object. iat and exp are set by the node.",
+				"examples": []any{
+					map[string]any{"iss": "operion", "sub": "{{.trigger_data.user_id}}", "aud": "https://api.example.com"},
+				},
+			},
+			"expires_in": map[string]any{
+				"type":        "string",
+				"description": "How long the token is valid, as a duration",
+				"default":     defaultExpiresIn.String(),
+				"examples":    []string{"5m", "1h"},
+			},
+		},
+		"required": []string{"algorithm", "key"},
+		"examples": []map[string]any{
+			{
+				"algorithm":  AlgorithmRS256,
+				"key":        "${GOOGLE_SERVICE_ACCOUNT_KEY}",
+				"claims":     map[string]any{"iss": "svc@project.iam.gserviceaccount.com", "aud": "https://oauth2.googleapis.com/token"},
+				"expires_in": "1h",
+			},
+			{
+				"algorithm":  AlgorithmHS256,
+				"key":        "${INTERNAL_API_SECRET}",
+				"claims":     map[string]any{"sub": "{{.trigger_data.user_id}}"},
+				"expires_in": "5m",
+			},
+		},
+	}
+}
+
+// NewJWTSignNodeFactory creates a new factory instance.
+func NewJWTSignNodeFactory() protocol.NodeFactory {
+	return &JWTSignNodeFactory{}
+}
@@ -0,0 +1,323 @@
+// Package jwtsign provides a node that signs JSON Web Tokens for downstream calls.
+package jwtsign
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// Signing algorithms.
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmHS256 = "HS256"
+)
+
+// defaultExpiresIn is how long a token is valid when no expiry is configured.
+const defaultExpiresIn = time.Hour
+
+var algorithms = []string{AlgorithmRS256, AlgorithmES256, AlgorithmHS256}
+
+// JWTSignNode implements the Node interface for signing a JSON Web Token.
+type JWTSignNode struct {
+	id        string
+	method    jwt.SigningMethod
+	key       any
+	keyID     string
+	claims    any
+	expiresIn time.Duration
+}
+
+// NewJWTSignNode creates a new JWT sign node. The key is expanded from environment variables,
+// so "${SERVICE_ACCOUNT_KEY}" keeps it out of workflow definitions.
+func NewJWTSignNode(id string, config map[string]any) (*JWTSignNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	algorithm, _ := config["algorithm"].(string)
+	rawKey, _ := config["key"].(string)
+	keyID, _ := config["key_id"].(string)
+
+	key, err := parseKey(algorithm, os.ExpandEnv(rawKey))
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := claimsTemplate(config["claims"])
+	if err != nil {
+		return nil, err
+	}
+
+	expiresIn := defaultExpiresIn
+	if value, _ := config["expires_in"].(string); value != "" {
+		expiresIn, _ = time.ParseDuration(value)
+	}
+
+	return &JWTSignNode{
+		id:        id,
+		method:    jwt.GetSigningMethod(algorithm),
+		key:       key,
+		keyID:     keyID,
+		claims:    claims,
+		expiresIn: expiresIn,
+	}, nil
+}
+
+// parseKey parses the signing key of algorithm: a PEM private key for RS256 and ES256, the
+// shared secret itself for HS256.
+func parseKey(algorithm, key string) (any, error) {
+	if key == "" {
+		return nil, errors.New("key is empty, check the environment variable it references")
+	}
+
+	switch algorithm {
+	case AlgorithmRS256:
+		parsed, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid RS256 key: %w", err)
+		}
+
+		return parsed, nil
+	case AlgorithmES256:
+		parsed, err := jwt.ParseECPrivateKeyFromPEM([]byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ES256 key: %w", err)
+		}
+
+		return parsed, nil
+	default:
+		return []byte(key), nil
+	}
+}
+
+// claimsTemplate returns the claims of the config: a template rendering a JSON object, or an
+// object whose string values are templates. Objects are checked to encode as JSON.
+func claimsTemplate(claims any) (any, error) {
+	switch c := claims.(type) {
+	case nil, string:
+		return c, nil
+	default:
+		if _, err := json.Marshal(c); err != nil {
+			return nil, fmt.Errorf("invalid claims: %w", err)
+		}
+
+		return c, nil
+	}
+}
+
+// ID returns the node ID.
+func (n *JWTSignNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *JWTSignNode) Type() string {
+	return "jwtsign"
+}
+
+// Execute renders the claims, sets their iat and exp from the current time, and signs the token.
+func (n *JWTSignNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	claims, err := n.renderClaims(ctx)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	issuedAt := time.Now().UTC()
+	expiresAt := issuedAt.Add(n.expiresIn)
+
+	claims["iat"] = issuedAt.Unix()
+	claims["exp"] = expiresAt.Unix()
+
+	token := jwt.NewWithClaims(n.method, claims)
+	if n.keyID != "" {
+		token.Header["kid"] = n.keyID
+	}
+
+	signed, err := token.SignedString(n.key)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to sign token: %v", err)), nil
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"token":      signed,
+				"algorithm":  n.method.Alg(),
+				"expires_at": expiresAt.Format(time.RFC3339),
+			},
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// renderClaims renders the claims of the token. A template is rendered and decoded as a JSON
+// object; an object has each of its string values rendered on its own, so rendered data is never
+// parsed as JSON and cannot add claims or break the object.
+func (n *JWTSignNode) renderClaims(ctx models.ExecutionContext) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+
+	switch c := n.claims.(type) {
+	case nil:
+		return claims, nil
+	case string:
+		if c == "" {
+			return claims, nil
+		}
+
+		rendered, err := template.RenderStringWithContext(c, &ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render claims template: %w", err)
+		}
+
+		if err := json.Unmarshal([]byte(rendered), &claims); err != nil {
+			return nil, fmt.Errorf("rendered claims are not a JSON object: %w", err)
+		}
+
+		return claims, nil
+	default:
+		rendered, err := template.RenderValueWithContext(c, &ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render claims template: %w", err)
+		}
+
+		object, ok := rendered.(map[string]any)
+		if !ok {
+			return nil, errors.New("claims are not a JSON object")
+		}
+
+		return jwt.MapClaims(object), nil
+	}
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *JWTSignNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *JWTSignNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Triggers signing; claims read the execution context through templates",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *JWTSignNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Signed token, e.g. for the Authorization header of an HTTP request",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"token":      map[string]any{"type": "string"},
+						"algorithm":  map[string]any{"type": "string"},
+						"expires_at": map[string]any{"type": "string", "format": "date-time"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the claims cannot be rendered or the token signed",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the JWT sign node.
+func (n *JWTSignNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *JWTSignNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the signing fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	algorithm, ok := config["algorithm"].(string)
+	if !ok || algorithm == "" {
+		return errors.New("missing required field 'algorithm'")
+	}
+
+	if !slices.Contains(algorithms, algorithm) {
+		return fmt.Errorf("unsupported algorithm '%s' (supported: RS256, ES256, HS256)", algorithm)
+	}
+
+	if key, ok := config["key"].(string); !ok || key == "" {
+		return errors.New("missing required field 'key'")
+	}
+
+	switch claims := config["claims"].(type) {
+	case nil, string, map[string]any:
+	default:
+		return fmt.Errorf("claims must be an object or a JSON template string, got %T", claims)
+	}
+
+	if expiresIn, exists := config["expires_in"]; exists {
+		value, ok := expiresIn.(string)
+		if !ok {
+			return errors.New("expires_in must be a duration string, e.g. '15m'")
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("invalid expires_in '%s', expected a positive duration such as '15m'", value)
+		}
+	}
+
+	return nil
+}
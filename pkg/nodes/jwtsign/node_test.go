@@ -0,0 +1,187 @@
+package jwtsign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}))
+}
+
+// signToken creates a node from config, executes it and returns the signed token.
+func signToken(t *testing.T, config map[string]any) string {
+	t.Helper()
+
+	node, err := NewJWTSignNodeFactory().Create(t.Context(), "sign", config)
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{
+		TriggerData: map[string]any{"user_id": "user-42"},
+	}, map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{}},
+	})
+	require.NoError(t, err)
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok, "results: %v", results)
+	assert.Equal(t, config["algorithm"], result.Data["algorithm"])
+
+	token, ok := result.Data["token"].(string)
+	require.True(t, ok)
+
+	return token
+}
+
+// verifyToken parses token with the verification key and returns its claims.
+func verifyToken(t *testing.T, token, algorithm string, key crypto.PublicKey) (*jwt.Token, jwt.MapClaims) {
+	t.Helper()
+
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return key, nil
+	}, jwt.WithValidMethods([]string{algorithm}))
+	require.NoError(t, err)
+
+	return parsed, claims
+}
+
+func TestNewJWTSignNode_InvalidConfig(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{"key": "secret"},
+		{"algorithm": "none", "key": "secret"},
+		{"algorithm": AlgorithmHS256},
+		{"algorithm": AlgorithmHS256, "key": "secret", "expires_in": "soon"},
+		{"algorithm": AlgorithmHS256, "key": "secret", "expires_in": "-5m"},
+		{"algorithm": AlgorithmHS256, "key": "secret", "claims": []any{"sub"}},
+		{"algorithm": AlgorithmRS256, "key": "not a pem key"},
+		{"algorithm": AlgorithmHS256, "key": "${JWTSIGN_TEST_UNSET_KEY}"},
+	}
+
+	for _, config := range invalidConfigs {
+		_, err := NewJWTSignNode("sign", config)
+		assert.Error(t, err, "config %v", config)
+	}
+}
+
+func TestJWTSignNode_Execute_RS256(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	t.Setenv("JWTSIGN_TEST_RSA_KEY", encodePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(privateKey)))
+
+	token := signToken(t, map[string]any{
+		"algorithm":  AlgorithmRS256,
+		"key":        "${JWTSIGN_TEST_RSA_KEY}",
+		"key_id":     "key-1",
+		"claims":     map[string]any{"iss": "operion", "sub": "{{.trigger_data.user_id}}", "aud": "https://api.example.com"},
+		"expires_in": "15m",
+	})
+
+	parsed, claims := verifyToken(t, token, AlgorithmRS256, &privateKey.PublicKey)
+	assert.Equal(t, "key-1", parsed.Header["kid"])
+	assert.Equal(t, "operion", claims["iss"])
+	assert.Equal(t, "user-42", claims["sub"])
+	assert.Equal(t, "https://api.example.com", claims["aud"])
+
+	issuedAt, err := claims.GetIssuedAt()
+	require.NoError(t, err)
+
+	expiresAt, err := claims.GetExpirationTime()
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, expiresAt.Sub(issuedAt.Time))
+	assert.WithinDuration(t, time.Now(), issuedAt.Time, time.Minute)
+}
+
+func TestJWTSignNode_Execute_ES256(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	token := signToken(t, map[string]any{
+		"algorithm": AlgorithmES256,
+		"key":       encodePEM(t, "PRIVATE KEY", der),
+		"claims":    `{"sub": "{{.trigger_data.user_id}}"}`,
+	})
+
+	_, claims := verifyToken(t, token, AlgorithmES256, &privateKey.PublicKey)
+	assert.Equal(t, "user-42", claims["sub"])
+
+	issuedAt, err := claims.GetIssuedAt()
+	require.NoError(t, err)
+
+	expiresAt, err := claims.GetExpirationTime()
+	require.NoError(t, err)
+	assert.Equal(t, defaultExpiresIn, expiresAt.Sub(issuedAt.Time))
+}
+
+func TestJWTSignNode_Execute_HS256(t *testing.T) {
+	token := signToken(t, map[string]any{
+		"algorithm": AlgorithmHS256,
+		"key":       "shared-secret",
+		"claims":    map[string]any{"scope": "orders:read"},
+	})
+
+	_, claims := verifyToken(t, token, AlgorithmHS256, []byte("shared-secret"))
+	assert.Equal(t, "orders:read", claims["scope"])
+
+	_, err := jwt.Parse(token, func(*jwt.Token) (any, error) {
+		return []byte("other-secret"), nil
+	})
+	assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+}
+
+func TestJWTSignNode_Execute_InvalidClaims(t *testing.T) {
+	node, err := NewJWTSignNode("sign", map[string]any{
+		"algorithm": AlgorithmHS256,
+		"key":       "shared-secret",
+		"claims":    `["{{.trigger_data.user_id}}"]`,
+	})
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{
+		TriggerData: map[string]any{"user_id": "user-42"},
+	}, map[string]models.NodeResult{})
+	require.NoError(t, err)
+
+	result, ok := results[OutputPortError]
+	require.True(t, ok)
+	assert.Contains(t, result.Error, "rendered claims are not a JSON object")
+}
+
+func TestJWTSignNode_Execute_ClaimValuesCannotInjectClaims(t *testing.T) {
+	node, err := NewJWTSignNode("sign", map[string]any{
+		"algorithm": AlgorithmHS256,
+		"key":       "secret",
+		"claims":    map[string]any{"sub": "{{.trigger_data.user_id}}"},
+	})
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{
+		TriggerData: map[string]any{"user_id": `user-42", "role": "admin`},
+	}, map[string]models.NodeResult{})
+	require.NoError(t, err)
+
+	token, ok := results[OutputPortSuccess].Data["token"].(string)
+	require.True(t, ok, "results: %v", results)
+
+	_, claims := verifyToken(t, token, AlgorithmHS256, []byte("secret"))
+	assert.Equal(t, `user-42", "role": "admin`, claims["sub"])
+	assert.NotContains(t, claims, "role")
+}
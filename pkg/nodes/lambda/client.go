@@ -0,0 +1,52 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	awslambda "github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+// Client invokes Lambda functions. It is satisfied by the AWS SDK Lambda client.
+type Client interface {
+	Invoke(ctx context.Context, params *awslambda.InvokeInput, optFns ...func(*awslambda.Options)) (*awslambda.InvokeOutput, error)
+}
+
+// ClientConfig holds the AWS settings of a Lambda client. Empty fields fall back to the
+// default AWS configuration chain (AWS_REGION, AWS_ACCESS_KEY_ID, shared config files, ...).
+type ClientConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	EndpointURL     string
+}
+
+// NewClient creates an AWS SDK Lambda client from cfg.
+func NewClient(ctx context.Context, cfg ClientConfig) (Client, error) {
+	var options []func(*config.LoadOptions) error
+
+	if cfg.Region != "" {
+		options = append(options, config.WithRegion(cfg.Region))
+	}
+
+	if cfg.AccessKeyID != "" {
+		options = append(options, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken),
+		))
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	return awslambda.NewFromConfig(awsConfig, func(o *awslambda.Options) {
+		if cfg.EndpointURL != "" {
+			o.BaseEndpoint = aws.String(cfg.EndpointURL)
+		}
+	}), nil
+}
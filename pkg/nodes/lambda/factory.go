@@ -0,0 +1,164 @@
+// Package lambda provides Lambda node factory for registry integration.
+package lambda
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// LambdaNodeFactory creates LambdaNode instances, sharing one client per AWS configuration.
+type LambdaNodeFactory struct {
+	mu      sync.Mutex
+	clients map[ClientConfig]Client
+}
+
+// Create creates a new LambdaNode instance.
+func (f *LambdaNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	client, err := f.client(ctx, clientConfig(config))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLambdaNode(id, config, client)
+}
+
+// clientConfig reads the AWS settings of a node configuration. Credentials are expanded from
+// environment variables, so "${LAMBDA_SECRET_ACCESS_KEY}" keeps secrets out of workflow definitions.
+func clientConfig(config map[string]any) ClientConfig {
+	setting := func(name string) string {
+		value, _ := config[name].(string)
+
+		return os.ExpandEnv(value)
+	}
+
+	return ClientConfig{
+		Region:          setting("region"),
+		AccessKeyID:     setting("access_key_id"),
+		SecretAccessKey: setting("secret_access_key"),
+		SessionToken:    setting("session_token"),
+		EndpointURL:     setting("endpoint_url"),
+	}
+}
+
+// client returns the client for cfg, creating it on first use.
+func (f *LambdaNodeFactory) client(ctx context.Context, cfg ClientConfig) (Client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if client, exists := f.clients[cfg]; exists {
+		return client, nil
+	}
+
+	client, err := NewClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	f.clients[cfg] = client
+
+	return client, nil
+}
+
+// ID returns the factory ID.
+func (f *LambdaNodeFactory) ID() string {
+	return "lambda"
+}
+
+// Name returns the factory name.
+func (f *LambdaNodeFactory) Name() string {
+	return "AWS Lambda"
+}
+
+// Description returns the factory description.
+func (f *LambdaNodeFactory) Description() string {
+	return "Invokes an AWS Lambda function synchronously or asynchronously with a JSON payload"
+}
+
+// Schema returns the JSON schema for Lambda node configuration.
+func (f *LambdaNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"function_name": map[string]any{
+				"type":        "string",
+				"description": "Name or ARN of the function to invoke. Supports templating.",
+				"examples":    []string{"resize-image", "arn:aws:lambda:us-east-1:123456789012:function:resize-image"},
+			},
+			"qualifier": map[string]any{
+				"type":        "string",
+				"description": "Version or alias of the function",
+				"examples":    []string{"live", "7"},
+			},
+			"invocation_type": map[string]any{
+				"type":        "string",
+				"description": "sync waits for the function response, async queues the event and returns the invocation metadata",
+				"enum":        []string{InvocationTypeSync, InvocationTypeAsync},
+				"default":     InvocationTypeSync,
+			},
+			"payload": map[string]any{
+				"type":        []string{"object", "string"},
+				"description": "JSON payload sent to the function. Supports templating. Defaults to the main input data.",
+				"examples": []any{
+					map[string]any{"image_url": "{{.trigger_data.body.url}}", "width": 320},
+					`{"order_id": "{{.trigger_data.order_id}}"}`,
+				},
+			},
+			"timeout": map[string]any{
+				"type":        "number",
+				"description": "Timeout of the invocation in seconds",
+				"default":     defaultTimeout,
+			},
+			"region": map[string]any{
+				"type":        "string",
+				"description": "AWS region of the function. Defaults to AWS_REGION.",
+				"examples":    []string{"us-east-1"},
+			},
+			"access_key_id": map[string]any{
+				"type":        "string",
+				"description": "AWS access key ID. Environment variables are expanded. Defaults to the AWS credential chain.",
+				"examples":    []string{"${LAMBDA_ACCESS_KEY_ID}"},
+			},
+			"secret_access_key": map[string]any{
+				"type":        "string",
+				"description": "AWS secret access key. Environment variables are expanded.",
+				"examples":    []string{"${LAMBDA_SECRET_ACCESS_KEY}"},
+			},
+			"session_token": map[string]any{
+				"type":        "string",
+				"description": "AWS session token for temporary credentials. Environment variables are expanded.",
+			},
+			"endpoint_url": map[string]any{
+				"type":        "string",
+				"description": "Custom Lambda endpoint, e.g. for LocalStack",
+				"examples":    []string{"http://localhost:4566"},
+			},
+		},
+		"required": []string{"function_name"},
+		"examples": []map[string]any{
+			{
+				"function_name": "resize-image",
+				"region":        "us-east-1",
+				"payload":       map[string]any{"image_url": "{{.trigger_data.body.url}}", "width": 320},
+			},
+			{
+				"function_name":   "send-welcome-email",
+				"invocation_type": InvocationTypeAsync,
+				"payload":         `{"user_id": "{{.trigger_data.user_id}}"}`,
+			},
+		},
+	}
+}
+
+// NewLambdaNodeFactory creates a new factory instance.
+func NewLambdaNodeFactory() protocol.NodeFactory {
+	return &LambdaNodeFactory{
+		clients: make(map[ClientConfig]Client),
+	}
+}
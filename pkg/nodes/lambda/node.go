@@ -0,0 +1,358 @@
+// Package lambda provides a node that invokes AWS Lambda functions.
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awslambda "github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// Invocation types.
+const (
+	InvocationTypeSync  = "sync"
+	InvocationTypeAsync = "async"
+)
+
+// defaultTimeout bounds an invocation, in seconds, when the config sets no timeout.
+const defaultTimeout = 60
+
+// LambdaNode implements the Node interface for invoking an AWS Lambda function.
+type LambdaNode struct {
+	id             string
+	functionName   string
+	qualifier      string
+	invocationType string
+	payload        any
+	timeout        time.Duration
+	client         Client
+}
+
+// NewLambdaNode creates a new Lambda node invoking functions through client.
+func NewLambdaNode(id string, config map[string]any, client Client) (*LambdaNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	functionName, _ := config["function_name"].(string)
+	qualifier, _ := config["qualifier"].(string)
+
+	invocationType, _ := config["invocation_type"].(string)
+	if invocationType == "" {
+		invocationType = InvocationTypeSync
+	}
+
+	payload, err := payloadTemplate(config["payload"])
+	if err != nil {
+		return nil, err
+	}
+
+	node := &LambdaNode{
+		id:             id,
+		functionName:   functionName,
+		qualifier:      qualifier,
+		invocationType: invocationType,
+		payload:        payload,
+		timeout:        defaultTimeout * time.Second,
+		client:         client,
+	}
+
+	if timeout, ok := config["timeout"].(float64); ok {
+		node.timeout = time.Duration(timeout * float64(time.Second))
+	}
+
+	return node, nil
+}
+
+// payloadTemplate returns the payload of the config: a template rendering a JSON document, or
+// an object whose string values are templates. Objects are checked to encode as JSON.
+func payloadTemplate(payload any) (any, error) {
+	switch p := payload.(type) {
+	case nil, string:
+		return p, nil
+	default:
+		if _, err := json.Marshal(p); err != nil {
+			return nil, fmt.Errorf("invalid payload: %w", err)
+		}
+
+		return p, nil
+	}
+}
+
+// ID returns the node ID.
+func (n *LambdaNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *LambdaNode) Type() string {
+	return "lambda"
+}
+
+// Execute invokes the function with the rendered payload, or with the main input data when no
+// payload is configured.
+func (n *LambdaNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	functionName, err := template.RenderStringWithContext(n.functionName, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render function_name template: %v", err), nil), nil
+	}
+
+	payload, err := n.renderPayload(ctx, inputs)
+	if err != nil {
+		return n.createErrorResult(err.Error(), nil), nil
+	}
+
+	input := &awslambda.InvokeInput{
+		FunctionName:   aws.String(functionName),
+		Payload:        payload,
+		InvocationType: types.InvocationTypeRequestResponse,
+	}
+
+	if n.qualifier != "" {
+		input.Qualifier = aws.String(n.qualifier)
+	}
+
+	if n.invocationType == InvocationTypeAsync {
+		input.InvocationType = types.InvocationTypeEvent
+	}
+
+	invokeCtx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+
+	output, err := n.client.Invoke(invokeCtx, input)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to invoke function %s: %v", *input.FunctionName, err), nil), nil
+	}
+
+	data := map[string]any{
+		"status_code":     output.StatusCode,
+		"invocation_type": n.invocationType,
+	}
+
+	if requestID, ok := awsmiddleware.GetRequestIDMetadata(output.ResultMetadata); ok {
+		data["request_id"] = requestID
+	}
+
+	if output.ExecutedVersion != nil {
+		data["executed_version"] = *output.ExecutedVersion
+	}
+
+	if n.invocationType == InvocationTypeAsync {
+		return map[string]models.NodeResult{
+			OutputPortSuccess: {
+				NodeID: n.id,
+				Data:   data,
+				Status: string(models.NodeStatusSuccess),
+			},
+		}, nil
+	}
+
+	response := decodeResponse(output.Payload)
+
+	// Errors raised by the function come back as a successful invocation with FunctionError set
+	if output.FunctionError != nil {
+		data["function_error"] = *output.FunctionError
+		data["response"] = response
+
+		message := "function " + *input.FunctionName + " failed"
+		if details, ok := response.(map[string]any); ok && details["errorMessage"] != nil {
+			message = fmt.Sprintf("%s: %v", message, details["errorMessage"])
+		}
+
+		return n.createErrorResult(message, data), nil
+	}
+
+	data["response"] = response
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// renderPayload renders the payload into a JSON document. The string values of an object
+// payload are rendered one by one before encoding, so rendered values are never parsed as JSON
+// and cannot add fields or break the document.
+func (n *LambdaNode) renderPayload(ctx models.ExecutionContext, inputs map[string]models.NodeResult) ([]byte, error) {
+	switch p := n.payload.(type) {
+	case nil:
+		return mainInputPayload(inputs)
+	case string:
+		if p == "" {
+			return mainInputPayload(inputs)
+		}
+
+		rendered, err := template.RenderStringWithContext(p, &ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render payload template: %w", err)
+		}
+
+		payload := []byte(rendered)
+		if !json.Valid(payload) {
+			return nil, errors.New("rendered payload is not valid JSON")
+		}
+
+		return payload, nil
+	default:
+		rendered, err := template.RenderValueWithContext(p, &ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render payload template: %w", err)
+		}
+
+		return json.Marshal(rendered)
+	}
+}
+
+// mainInputPayload encodes the main input data, the payload when none is configured.
+func mainInputPayload(inputs map[string]models.NodeResult) ([]byte, error) {
+	input, ok := inputs[InputPortMain]
+	if !ok || input.Data == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(input.Data)
+}
+
+// decodeResponse decodes a JSON function response, returning other responses as a string.
+func decodeResponse(payload []byte) any {
+	if len(payload) == 0 {
+		return nil
+	}
+
+	var response any
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return string(payload)
+	}
+
+	return response
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *LambdaNode) createErrorResult(errorMessage string, details map[string]any) map[string]models.NodeResult {
+	data := map[string]any{
+		"error":   errorMessage,
+		"success": false,
+	}
+
+	maps.Copy(data, details)
+
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *LambdaNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Data sent as the payload when no payload is configured",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *LambdaNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Function response (sync) or invocation metadata (async)",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"status_code":      map[string]any{"type": "integer"},
+						"invocation_type":  map[string]any{"type": "string"},
+						"request_id":       map[string]any{"type": "string"},
+						"executed_version": map[string]any{"type": "string"},
+						"response":         map[string]any{"description": "Decoded function response"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the invocation fails or the function raises an error",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":          map[string]any{"type": "string"},
+						"function_error": map[string]any{"type": "string"},
+						"response":       map[string]any{"description": "Error details returned by the function"},
+						"success":        map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the Lambda node.
+func (n *LambdaNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *LambdaNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the invocation fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	if functionName, ok := config["function_name"].(string); !ok || functionName == "" {
+		return errors.New("missing required field 'function_name'")
+	}
+
+	invocationType, _ := config["invocation_type"].(string)
+
+	switch invocationType {
+	case "", InvocationTypeSync, InvocationTypeAsync:
+	default:
+		return fmt.Errorf("invalid invocation_type '%s' (supported: sync, async)", invocationType)
+	}
+
+	if timeout, exists := config["timeout"]; exists {
+		if value, ok := timeout.(float64); !ok || value <= 0 {
+			return errors.New("timeout must be a positive number of seconds")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awslambda "github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient records invocations and answers with a fixed output.
+type fakeClient struct {
+	output   *awslambda.InvokeOutput
+	err      error
+	inputs   []*awslambda.InvokeInput
+	deadline time.Time
+}
+
+func (c *fakeClient) Invoke(ctx context.Context, params *awslambda.InvokeInput, _ ...func(*awslambda.Options)) (*awslambda.InvokeOutput, error) {
+	c.inputs = append(c.inputs, params)
+	c.deadline, _ = ctx.Deadline()
+
+	return c.output, c.err
+}
+
+func executeLambda(t *testing.T, config map[string]any, client Client) map[string]models.NodeResult {
+	t.Helper()
+
+	node, err := NewLambdaNode("resize", config, client)
+	require.NoError(t, err)
+
+	ctx := models.ExecutionContext{TriggerData: map[string]any{"url": "https://example.com/cat.png"}}
+	inputs := map[string]models.NodeResult{InputPortMain: {NodeID: "trigger", Data: map[string]any{"id": "42"}}}
+
+	results, err := node.Execute(ctx, inputs)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	return results
+}
+
+func TestNewLambdaNode_Validation(t *testing.T) {
+	_, err := NewLambdaNode("resize", map[string]any{}, &fakeClient{})
+	require.ErrorContains(t, err, "missing required field 'function_name'")
+
+	_, err = NewLambdaNode("resize", map[string]any{"function_name": "resize-image", "invocation_type": "dry_run"}, &fakeClient{})
+	require.ErrorContains(t, err, "invalid invocation_type 'dry_run'")
+
+	_, err = NewLambdaNode("resize", map[string]any{"function_name": "resize-image", "timeout": "soon"}, &fakeClient{})
+	require.ErrorContains(t, err, "timeout must be a positive number of seconds")
+
+	node, err := NewLambdaNode("resize", map[string]any{"function_name": "resize-image"}, &fakeClient{})
+	require.NoError(t, err)
+	assert.Equal(t, InvocationTypeSync, node.invocationType)
+	assert.Equal(t, "lambda", node.Type())
+}
+
+func TestLambdaNode_Execute_Success(t *testing.T) {
+	client := &fakeClient{output: &awslambda.InvokeOutput{
+		StatusCode:      200,
+		ExecutedVersion: aws.String("$LATEST"),
+		Payload:         []byte(`{"thumbnail_url": "https://example.com/cat-320.png"}`),
+	}}
+
+	results := executeLambda(t, map[string]any{
+		"function_name": "resize-image",
+		"qualifier":     "live",
+		"payload":       map[string]any{"image_url": "{{.trigger_data.url}}", "width": 320},
+	}, client)
+
+	require.Len(t, client.inputs, 1)
+
+	input := client.inputs[0]
+	assert.Equal(t, "resize-image", *input.FunctionName)
+	assert.Equal(t, "live", *input.Qualifier)
+	assert.Equal(t, types.InvocationTypeRequestResponse, input.InvocationType)
+	assert.JSONEq(t, `{"image_url": "https://example.com/cat.png", "width": 320}`, string(input.Payload))
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok)
+	assert.Equal(t, int32(200), result.Data["status_code"])
+	assert.Equal(t, "$LATEST", result.Data["executed_version"])
+	assert.Equal(t, map[string]any{"thumbnail_url": "https://example.com/cat-320.png"}, result.Data["response"])
+}
+
+func TestLambdaNode_Execute_DefaultsPayloadToInput(t *testing.T) {
+	client := &fakeClient{output: &awslambda.InvokeOutput{StatusCode: 200, Payload: []byte(`"done"`)}}
+
+	results := executeLambda(t, map[string]any{"function_name": "resize-image"}, client)
+
+	assert.JSONEq(t, `{"id": "42"}`, string(client.inputs[0].Payload))
+	assert.Equal(t, "done", results[OutputPortSuccess].Data["response"])
+}
+
+func TestLambdaNode_Execute_FunctionError(t *testing.T) {
+	client := &fakeClient{output: &awslambda.InvokeOutput{
+		StatusCode:    200,
+		FunctionError: aws.String("Unhandled"),
+		Payload:       []byte(`{"errorMessage": "image too large", "errorType": "ValueError"}`),
+	}}
+
+	results := executeLambda(t, map[string]any{"function_name": "resize-image"}, client)
+
+	result, ok := results[OutputPortError]
+	require.True(t, ok)
+	assert.Equal(t, string(models.NodeStatusError), result.Status)
+	assert.Equal(t, "function resize-image failed: image too large", result.Error)
+	assert.Equal(t, "Unhandled", result.Data["function_error"])
+	assert.Equal(t, "ValueError", result.Data["response"].(map[string]any)["errorType"])
+	assert.Equal(t, false, result.Data["success"])
+}
+
+func TestLambdaNode_Execute_InvokeError(t *testing.T) {
+	client := &fakeClient{err: errors.New("ResourceNotFoundException: function not found")}
+
+	results := executeLambda(t, map[string]any{"function_name": "resize-image"}, client)
+
+	result, ok := results[OutputPortError]
+	require.True(t, ok)
+	assert.Contains(t, result.Data["error"], "failed to invoke function resize-image")
+}
+
+func TestLambdaNode_Execute_Async(t *testing.T) {
+	var metadata middleware.Metadata
+	awsmiddleware.SetRequestIDMetadata(&metadata, "request-1")
+
+	client := &fakeClient{output: &awslambda.InvokeOutput{StatusCode: 202, ResultMetadata: metadata}}
+
+	results := executeLambda(t, map[string]any{
+		"function_name":   "send-welcome-email",
+		"invocation_type": InvocationTypeAsync,
+		"payload":         `{"user_id": "{{.trigger_data.url}}"}`,
+	}, client)
+
+	assert.Equal(t, types.InvocationTypeEvent, client.inputs[0].InvocationType)
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok)
+	assert.Equal(t, int32(202), result.Data["status_code"])
+	assert.Equal(t, "request-1", result.Data["request_id"])
+	assert.Equal(t, InvocationTypeAsync, result.Data["invocation_type"])
+	assert.NotContains(t, result.Data, "response")
+}
+
+func TestLambdaNode_Execute_InvalidPayload(t *testing.T) {
+	client := &fakeClient{}
+
+	results := executeLambda(t, map[string]any{"function_name": "resize-image", "payload": "not json"}, client)
+
+	assert.Equal(t, "rendered payload is not valid JSON", results[OutputPortError].Data["error"])
+	assert.Empty(t, client.inputs)
+}
+
+func TestLambdaNode_Execute_RendersPayloadValues(t *testing.T) {
+	client := &fakeClient{output: &awslambda.InvokeOutput{StatusCode: 200}}
+
+	node, err := NewLambdaNode("resize", map[string]any{
+		"function_name": "resize-image",
+		"payload":       map[string]any{"image_url": "{{.trigger_data.url}}", "tags": []any{"{{.trigger_data.tag}}"}},
+	}, client)
+	require.NoError(t, err)
+
+	ctx := models.ExecutionContext{TriggerData: map[string]any{
+		"url": `x", "admin": true, "y": "`,
+		"tag": `"quoted"`,
+	}}
+
+	results, err := node.Execute(ctx, nil)
+	require.NoError(t, err)
+	require.Contains(t, results, OutputPortSuccess)
+
+	assert.JSONEq(t, `{"image_url": "x\", \"admin\": true, \"y\": \"", "tags": ["\"quoted\""]}`, string(client.inputs[0].Payload))
+}
+
+func TestLambdaNode_Execute_BoundsInvocation(t *testing.T) {
+	client := &fakeClient{output: &awslambda.InvokeOutput{StatusCode: 200}}
+
+	before := time.Now()
+
+	executeLambda(t, map[string]any{"function_name": "resize-image", "timeout": float64(5)}, client)
+
+	assert.WithinDuration(t, before.Add(5*time.Second), client.deadline, time.Second)
+}
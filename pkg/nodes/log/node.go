@@ -83,9 +83,17 @@ func (n *LogNode) Execute(ctx models.ExecutionContext, inputs map[string]models.
 
 	message := fmt.Sprintf("%v", renderedMessage)
 
-	// TODO: In a full implementation, this would use the logger from the execution context
-	// For now, we'll use the default logger
-	logger := n.logger.With("node_id", n.id, "node_type", "log")
+	// The worker provides a node-scoped logger honoring the node's log level; without one, the
+	// default logger is used
+	logger := ctx.Logger
+	if logger == nil {
+		logger = n.logger.With("node_id", n.id, "execution_id", ctx.ID)
+		if ctx.CorrelationID != "" {
+			logger = logger.With("correlation_id", ctx.CorrelationID)
+		}
+	}
+
+	logger = logger.With("node_type", "log")
 
 	// Log the message at the specified level
 	switch n.level {
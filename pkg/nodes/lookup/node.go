@@ -0,0 +1,230 @@
+// Package lookup provides a node that enriches data with records read from a key-value store.
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess  = "success"
+	OutputPortNotFound = "not_found"
+	OutputPortError    = "error"
+	InputPortMain      = "main"
+)
+
+// Behaviors when the looked-up key does not exist.
+const (
+	OnMissingFail    = "fail"
+	OnMissingSkip    = "skip"
+	OnMissingDefault = "default"
+)
+
+// LookupNode implements the Node interface for enriching data via a key-value lookup.
+type LookupNode struct {
+	id           string
+	key          string
+	onMissing    string
+	defaultValue map[string]any
+	targetField  string
+	store        Store
+}
+
+// NewLookupNode creates a new lookup node reading records from store.
+func NewLookupNode(id string, config map[string]any, store Store) (*LookupNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	key, _ := config["key"].(string)
+
+	onMissing, _ := config["on_missing"].(string)
+	if onMissing == "" {
+		onMissing = OnMissingFail
+	}
+
+	defaultValue, _ := config["default"].(map[string]any)
+	targetField, _ := config["target_field"].(string)
+
+	return &LookupNode{
+		id:           id,
+		key:          key,
+		onMissing:    onMissing,
+		defaultValue: defaultValue,
+		targetField:  targetField,
+		store:        store,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *LookupNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *LookupNode) Type() string {
+	return "lookup"
+}
+
+// Execute looks up the record for the rendered key and merges it into the input data.
+func (n *LookupNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	renderedKey, err := template.RenderWithContext(n.key, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render key template: %v", err)), nil
+	}
+
+	key := fmt.Sprintf("%v", renderedKey)
+
+	record, found, err := n.store.Get(context.Background(), key)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("lookup failed for key %s: %v", key, err)), nil
+	}
+
+	data := make(map[string]any)
+	if input, ok := inputs[InputPortMain]; ok {
+		maps.Copy(data, input.Data)
+	}
+
+	if !found {
+		switch n.onMissing {
+		case OnMissingSkip:
+			return map[string]models.NodeResult{
+				OutputPortNotFound: {
+					NodeID: n.id,
+					Data:   data,
+					Status: string(models.NodeStatusSuccess),
+				},
+			}, nil
+		case OnMissingDefault:
+			record = n.defaultValue
+		default:
+			return n.createErrorResult("no record found for key " + key), nil
+		}
+	}
+
+	if n.targetField != "" {
+		data[n.targetField] = record
+	} else {
+		maps.Copy(data, record)
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *LookupNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *LookupNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Data to enrich with the looked-up record",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *LookupNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Input data merged with the looked-up (or default) record",
+				Schema: map[string]any{
+					"type": "object",
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortNotFound),
+				NodeID:      n.id,
+				Name:        OutputPortNotFound,
+				Description: "Unchanged input data when the key is missing and on_missing is 'skip'",
+				Schema: map[string]any{
+					"type": "object",
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the lookup fails or the key is missing and on_missing is 'fail'",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the lookup node.
+func (n *LookupNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *LookupNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the lookup fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	if key, ok := config["key"].(string); !ok || key == "" {
+		return errors.New("missing required field 'key'")
+	}
+
+	onMissing, _ := config["on_missing"].(string)
+
+	switch onMissing {
+	case "", OnMissingFail, OnMissingSkip:
+	case OnMissingDefault:
+		if _, ok := config["default"].(map[string]any); !ok {
+			return errors.New("field 'default' must be an object when on_missing is 'default'")
+		}
+	default:
+		return fmt.Errorf("invalid on_missing '%s'", onMissing)
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// fakeStore is an in-memory Store for tests.
+type fakeStore struct {
+	records map[string]map[string]any
+	err     error
+	keys    []string
+}
+
+func (s *fakeStore) Get(_ context.Context, key string) (map[string]any, bool, error) {
+	s.keys = append(s.keys, key)
+
+	if s.err != nil {
+		return nil, false, s.err
+	}
+
+	record, found := s.records[key]
+
+	return record, found, nil
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		records: map[string]map[string]any{
+			"product:SKU-1": {"name": "Keyboard", "price": 49.9},
+		},
+	}
+}
+
+func executeLookup(t *testing.T, config map[string]any, store Store, sku string) map[string]models.NodeResult {
+	t.Helper()
+
+	node, err := NewLookupNode("enrich", config, store)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	ctx := models.ExecutionContext{TriggerData: map[string]any{"sku": sku}}
+	inputs := map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{"sku": sku, "quantity": 2}},
+	}
+
+	results, err := node.Execute(ctx, inputs)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("Expected exactly one output port, got: %v", results)
+	}
+
+	return results
+}
+
+func TestNewLookupNode_Validation(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{},
+		{"key": "product:1", "on_missing": "ignore"},
+		{"key": "product:1", "on_missing": OnMissingDefault},
+	}
+
+	for _, config := range invalidConfigs {
+		if _, err := NewLookupNode("enrich", config, newFakeStore()); err == nil {
+			t.Errorf("Expected error for config %v", config)
+		}
+	}
+
+	node, err := NewLookupNode("enrich", map[string]any{"key": "product:1"}, newFakeStore())
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if node.onMissing != OnMissingFail {
+		t.Errorf("Expected on_missing to default to 'fail', got: %s", node.onMissing)
+	}
+}
+
+func TestLookupNode_Execute_Hit(t *testing.T) {
+	store := newFakeStore()
+	results := executeLookup(t, map[string]any{"key": "product:{{.trigger_data.sku}}"}, store, "SKU-1")
+
+	result, ok := results[OutputPortSuccess]
+	if !ok {
+		t.Fatalf("Expected success port, got: %v", results)
+	}
+
+	if len(store.keys) != 1 || store.keys[0] != "product:SKU-1" {
+		t.Errorf("Expected rendered key 'product:SKU-1', got: %v", store.keys)
+	}
+
+	expected := map[string]any{"sku": "SKU-1", "quantity": 2, "name": "Keyboard", "price": 49.9}
+	for field, value := range expected {
+		if result.Data[field] != value {
+			t.Errorf("Expected %s=%v, got: %v", field, value, result.Data[field])
+		}
+	}
+}
+
+func TestLookupNode_Execute_HitWithTargetField(t *testing.T) {
+	results := executeLookup(t, map[string]any{
+		"key":          "product:{{.trigger_data.sku}}",
+		"target_field": "product",
+	}, newFakeStore(), "SKU-1")
+
+	result := results[OutputPortSuccess]
+
+	product, ok := result.Data["product"].(map[string]any)
+	if !ok || product["name"] != "Keyboard" {
+		t.Errorf("Expected record under 'product', got: %v", result.Data)
+	}
+
+	if _, exists := result.Data["name"]; exists {
+		t.Error("Record fields should not be merged at the top level when target_field is set")
+	}
+}
+
+func TestLookupNode_Execute_MissWithDefault(t *testing.T) {
+	results := executeLookup(t, map[string]any{
+		"key":        "product:{{.trigger_data.sku}}",
+		"on_missing": OnMissingDefault,
+		"default":    map[string]any{"name": "Unknown product", "price": 0},
+	}, newFakeStore(), "SKU-404")
+
+	result, ok := results[OutputPortSuccess]
+	if !ok {
+		t.Fatalf("Expected success port, got: %v", results)
+	}
+
+	if result.Data["name"] != "Unknown product" || result.Data["price"] != 0 || result.Data["sku"] != "SKU-404" {
+		t.Errorf("Expected input merged with default record, got: %v", result.Data)
+	}
+}
+
+func TestLookupNode_Execute_MissWithFail(t *testing.T) {
+	results := executeLookup(t, map[string]any{"key": "product:{{.trigger_data.sku}}"}, newFakeStore(), "SKU-404")
+
+	result, ok := results[OutputPortError]
+	if !ok {
+		t.Fatalf("Expected error port, got: %v", results)
+	}
+
+	if result.Status != string(models.NodeStatusError) {
+		t.Errorf("Expected error status, got: %s", result.Status)
+	}
+
+	if result.Data["error"] != "no record found for key product:SKU-404" {
+		t.Errorf("Unexpected error message: %v", result.Data["error"])
+	}
+}
+
+func TestLookupNode_Execute_MissWithSkip(t *testing.T) {
+	results := executeLookup(t, map[string]any{
+		"key":        "product:{{.trigger_data.sku}}",
+		"on_missing": OnMissingSkip,
+	}, newFakeStore(), "SKU-404")
+
+	result, ok := results[OutputPortNotFound]
+	if !ok {
+		t.Fatalf("Expected not_found port, got: %v", results)
+	}
+
+	if len(result.Data) != 2 || result.Data["sku"] != "SKU-404" {
+		t.Errorf("Expected unchanged input data, got: %v", result.Data)
+	}
+}
+
+func TestLookupNode_Execute_StoreError(t *testing.T) {
+	store := &fakeStore{err: errors.New("connection refused")}
+	results := executeLookup(t, map[string]any{"key": "product:1", "on_missing": OnMissingSkip}, store, "SKU-1")
+
+	if _, ok := results[OutputPortError]; !ok {
+		t.Errorf("Expected error port when the store fails, got: %v", results)
+	}
+}
+
+func TestLookupNodeFactory_Create(t *testing.T) {
+	factory := NewLookupNodeFactory()
+
+	_, err := factory.Create(context.Background(), "enrich", map[string]any{
+		"backend":        "memcached",
+		"connection_url": "memcached://localhost",
+		"key":            "product:1",
+	})
+	if err == nil {
+		t.Error("Expected error for unsupported backend")
+	}
+
+	_, err = factory.Create(context.Background(), "enrich", map[string]any{
+		"backend":        BackendPostgres,
+		"connection_url": "postgres://localhost/catalog",
+		"table":          "products; DROP TABLE products",
+		"key":            "product:1",
+	})
+	if err == nil {
+		t.Error("Expected error for invalid table name")
+	}
+
+	config := map[string]any{
+		"backend":        BackendRedis,
+		"connection_url": "redis://localhost:6379/0",
+		"key":            "product:{{.trigger_data.sku}}",
+	}
+
+	first, err := factory.Create(context.Background(), "enrich-1", config)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	second, err := factory.Create(context.Background(), "enrich-2", config)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if first.(*LookupNode).store != second.(*LookupNode).store {
+		t.Error("Expected nodes with the same connection to share a store")
+	}
+}
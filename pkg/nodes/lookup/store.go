@@ -0,0 +1,109 @@
+package lookup
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	BackendRedis    = "redis"
+	BackendPostgres = "postgres"
+)
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Store reads reference records by key.
+type Store interface {
+	// Get returns the record stored under key, or false when the key does not exist.
+	Get(ctx context.Context, key string) (map[string]any, bool, error)
+}
+
+// RedisStore reads records stored as JSON strings in Redis.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a store connected to the Redis instance at url (redis://host:port/db).
+func NewRedisStore(url string) (*RedisStore, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis connection_url: %w", err)
+	}
+
+	return &RedisStore{client: redis.NewClient(options)}, nil
+}
+
+// Get returns the record stored under key. Values that are not JSON objects are
+// returned under the "value" field.
+func (s *RedisStore) Get(ctx context.Context, key string) (map[string]any, bool, error) {
+	raw, err := s.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal([]byte(raw), &record); err != nil {
+		return map[string]any{"value": raw}, true, nil
+	}
+
+	return record, true, nil
+}
+
+// PostgresStore reads records from a Postgres table, one row per key.
+type PostgresStore struct {
+	db    *sql.DB
+	query string
+}
+
+// NewPostgresStore creates a store reading rows of table whose keyColumn matches the lookup key.
+func NewPostgresStore(url, table, keyColumn string) (*PostgresStore, error) {
+	if !identifierPattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name '%s'", table)
+	}
+
+	if !identifierPattern.MatchString(keyColumn) {
+		return nil, fmt.Errorf("invalid key column '%s'", keyColumn)
+	}
+
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
+	}
+
+	return &PostgresStore{
+		db:    db,
+		query: fmt.Sprintf("SELECT row_to_json(t) FROM %s t WHERE %s = $1 LIMIT 1", table, keyColumn),
+	}, nil
+}
+
+// Get returns the row whose key column equals key as a record.
+func (s *PostgresStore) Get(ctx context.Context, key string) (map[string]any, bool, error) {
+	var raw []byte
+
+	err := s.db.QueryRowContext(ctx, s.query, key).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return nil, false, fmt.Errorf("failed to decode row: %w", err)
+	}
+
+	return record, true, nil
+}
@@ -4,6 +4,8 @@ package merge
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/dukex/operion/pkg/models"
 )
@@ -91,9 +93,9 @@ func (n *MergeNode) Execute(ctx models.ExecutionContext, inputs map[string]model
 	mergedData := make(map[string]any)
 	inputsReceived := make([]string, 0, len(inputs))
 
-	// Process all provided inputs (worker already ensured they meet requirements)
-	for portName, result := range inputs {
-		mergedData[portName] = result.Data
+	// Process all provided inputs (worker already ensured they meet requirements), in port order
+	for _, portName := range slices.Sorted(maps.Keys(inputs)) {
+		mergedData[portName] = inputs[portName].Data
 		inputsReceived = append(inputsReceived, portName)
 	}
 
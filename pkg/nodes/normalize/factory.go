@@ -0,0 +1,92 @@
+// Package normalize provides normalize node factory for registry integration.
+package normalize
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// NormalizeNodeFactory creates NormalizeNode instances.
+type NormalizeNodeFactory struct{}
+
+// Create creates a new NormalizeNode instance.
+func (f *NormalizeNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewNormalizeNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *NormalizeNodeFactory) ID() string {
+	return "normalize"
+}
+
+// Name returns the factory name.
+func (f *NormalizeNodeFactory) Name() string {
+	return "Normalize Contact Fields"
+}
+
+// Description returns the factory description.
+func (f *NormalizeNodeFactory) Description() string {
+	return "Validates and normalizes phone numbers to E.164, email addresses to lowercase and URLs to a canonical form, reporting the validity of each field"
+}
+
+// Schema returns the JSON schema for normalize node configuration.
+func (f *NormalizeNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"fields": map[string]any{
+				"type":        "object",
+				"description": "Fields to normalize, keyed by the name of their normalized value",
+				"additionalProperties": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"value": map[string]any{
+							"type":        "string",
+							"description": "Value to normalize. Supports templating.",
+							"examples":    []string{"{{.trigger_data.customer.phone}}"},
+						},
+						"type": map[string]any{
+							"type":        "string",
+							"description": "Kind of value",
+							"enum":        Types,
+						},
+						"optional": map[string]any{
+							"type":        "boolean",
+							"description": "Treat an empty value as valid instead of invalid",
+							"default":     false,
+						},
+					},
+					"required": []string{"value", "type"},
+				},
+			},
+			"default_country_code": map[string]any{
+				"type":        "string",
+				"description": "Country calling code for phone numbers written without one (a leading trunk 0 is dropped)",
+				"examples":    []string{"1", "+44"},
+			},
+			"fail_on_invalid": map[string]any{
+				"type":        "boolean",
+				"description": "Route the result to the error port when any field is invalid",
+				"default":     false,
+			},
+		},
+		"required": []string{"fields"},
+		"examples": []map[string]any{
+			{
+				"fields": map[string]any{
+					"phone":   map[string]any{"value": "{{.trigger_data.phone}}", "type": "phone"},
+					"email":   map[string]any{"value": "{{.trigger_data.email}}", "type": "email"},
+					"website": map[string]any{"value": "{{.trigger_data.website}}", "type": "url", "optional": true},
+				},
+				"default_country_code": "1",
+				"fail_on_invalid":      true,
+			},
+		},
+	}
+}
+
+// NewNormalizeNodeFactory creates a new factory instance.
+func NewNormalizeNodeFactory() protocol.NodeFactory {
+	return &NormalizeNodeFactory{}
+}
@@ -0,0 +1,275 @@
+// Package normalize provides a node that validates and normalizes contact fields such as phone
+// numbers, email addresses and URLs.
+package normalize
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// countryCodePattern matches a country calling code, with or without its plus sign.
+var countryCodePattern = regexp.MustCompile(`^\+?[1-9][0-9]{0,2}$`)
+
+// field is a configured field to normalize.
+type field struct {
+	name      string
+	value     string
+	fieldType string
+	optional  bool
+}
+
+// NormalizeNode implements the Node interface for validating and normalizing contact fields.
+type NormalizeNode struct {
+	id                 string
+	fields             []field
+	defaultCountryCode string
+	failOnInvalid      bool
+}
+
+// NewNormalizeNode creates a new normalize node.
+func NewNormalizeNode(id string, config map[string]any) (*NormalizeNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	configured, _ := config["fields"].(map[string]any)
+
+	fields := make([]field, 0, len(configured))
+	for name, value := range configured {
+		fieldConfig, _ := value.(map[string]any)
+		fieldValue, _ := fieldConfig["value"].(string)
+		fieldType, _ := fieldConfig["type"].(string)
+		optional, _ := fieldConfig["optional"].(bool)
+
+		fields = append(fields, field{name: name, value: fieldValue, fieldType: fieldType, optional: optional})
+	}
+
+	slices.SortFunc(fields, func(a, b field) int { return strings.Compare(a.name, b.name) })
+
+	defaultCountryCode, _ := config["default_country_code"].(string)
+	failOnInvalid, _ := config["fail_on_invalid"].(bool)
+
+	return &NormalizeNode{
+		id:                 id,
+		fields:             fields,
+		defaultCountryCode: strings.TrimPrefix(defaultCountryCode, "+"),
+		failOnInvalid:      failOnInvalid,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *NormalizeNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *NormalizeNode) Type() string {
+	return "normalize"
+}
+
+// Execute renders each field and normalizes it, placing the normalized values and a validity
+// report in the result. Invalid fields keep their input as value; with fail_on_invalid they route
+// the result to the error port.
+func (n *NormalizeNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	values := make(map[string]any, len(n.fields))
+	report := make(map[string]any, len(n.fields))
+	invalid := []string{}
+
+	for _, f := range n.fields {
+		input, err := template.RenderStringWithContext(f.value, &ctx)
+		if err != nil {
+			return n.createErrorResult(fmt.Sprintf("failed to render field '%s': %v", f.name, err), nil), nil
+		}
+
+		input = strings.TrimSpace(input)
+		entry := map[string]any{"type": f.fieldType, "input": input}
+
+		var normalized string
+
+		switch {
+		case input == "" && f.optional:
+		case input == "":
+			err = errors.New("value is empty")
+		default:
+			normalized, err = normalizeValue(f.fieldType, input, n.defaultCountryCode)
+		}
+
+		if err != nil {
+			entry["valid"] = false
+			entry["error"] = err.Error()
+			values[f.name] = input
+			invalid = append(invalid, f.name)
+		} else {
+			entry["valid"] = true
+			entry["normalized"] = normalized
+			values[f.name] = normalized
+		}
+
+		report[f.name] = entry
+	}
+
+	data := map[string]any{
+		"values":  values,
+		"valid":   len(invalid) == 0,
+		"invalid": invalid,
+		"report":  report,
+	}
+
+	if n.failOnInvalid && len(invalid) > 0 {
+		return n.createErrorResult(fmt.Sprintf("invalid fields: %s", strings.Join(invalid, ", ")), data), nil
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// createErrorResult creates a NodeResult for the error output port, including the validity
+// report when the fields were normalized.
+func (n *NormalizeNode) createErrorResult(errorMessage string, data map[string]any) map[string]models.NodeResult {
+	errorData := map[string]any{
+		"error":   errorMessage,
+		"success": false,
+	}
+
+	for key, value := range data {
+		errorData[key] = value
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data:   errorData,
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *NormalizeNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Triggers the normalization; fields are read from the execution context through their templates",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *NormalizeNode) OutputPorts() []models.OutputPort {
+	reportProperties := map[string]any{
+		"values":  map[string]any{"type": "object"},
+		"valid":   map[string]any{"type": "boolean"},
+		"invalid": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		"report":  map[string]any{"type": "object"},
+	}
+
+	errorProperties := map[string]any{
+		"error":   map[string]any{"type": "string"},
+		"success": map[string]any{"type": "boolean"},
+	}
+	for key, value := range reportProperties {
+		errorProperties[key] = value
+	}
+
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "The normalized values and the validity report of each field",
+				Schema: map[string]any{
+					"type":       "object",
+					"properties": reportProperties,
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when a field cannot be rendered, or is invalid with fail_on_invalid",
+				Schema: map[string]any{
+					"type":       "object",
+					"properties": errorProperties,
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the normalize node.
+func (n *NormalizeNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *NormalizeNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	fields, ok := config["fields"].(map[string]any)
+	if !ok || len(fields) == 0 {
+		return errors.New("missing required field 'fields'")
+	}
+
+	for name, value := range fields {
+		fieldConfig, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("field '%s' must be an object", name)
+		}
+
+		if _, ok := fieldConfig["value"].(string); !ok {
+			return fmt.Errorf("field '%s' is missing required 'value'", name)
+		}
+
+		fieldType, _ := fieldConfig["type"].(string)
+		if !slices.Contains(Types, fieldType) {
+			return fmt.Errorf("field '%s' type '%s' must be one of %v", name, fieldType, Types)
+		}
+	}
+
+	if code, exists := config["default_country_code"]; exists {
+		if code, ok := code.(string); !ok || !countryCodePattern.MatchString(code) {
+			return fmt.Errorf("default_country_code '%v' must be a country calling code such as '1' or '+44'", code)
+		}
+	}
+
+	if failOnInvalid, exists := config["fail_on_invalid"]; exists {
+		if _, ok := failOnInvalid.(bool); !ok {
+			return errors.New("fail_on_invalid must be a boolean")
+		}
+	}
+
+	return nil
+}
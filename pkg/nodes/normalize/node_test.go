@@ -0,0 +1,155 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func execute(t *testing.T, config map[string]any, triggerData map[string]any) map[string]models.NodeResult {
+	t.Helper()
+
+	node, err := NewNormalizeNode("normalize", config)
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{
+		TriggerData: triggerData,
+	}, map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	return results
+}
+
+func TestNewNormalizeNode_InvalidConfig(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{},
+		{"fields": map[string]any{}},
+		{"fields": map[string]any{"phone": "{{.trigger_data.phone}}"}},
+		{"fields": map[string]any{"phone": map[string]any{"type": "phone"}}},
+		{"fields": map[string]any{"fax": map[string]any{"value": "{{.trigger_data.fax}}", "type": "fax"}}},
+		{"fields": map[string]any{"phone": map[string]any{"value": "x", "type": "phone"}}, "default_country_code": "US"},
+		{"fields": map[string]any{"phone": map[string]any{"value": "x", "type": "phone"}}, "fail_on_invalid": "yes"},
+	}
+
+	for _, config := range invalidConfigs {
+		_, err := NewNormalizeNode("normalize", config)
+		assert.Error(t, err, "config %v", config)
+	}
+}
+
+func TestNormalizeValue(t *testing.T) {
+	testCases := []struct {
+		fieldType string
+		input     string
+		expected  string
+		valid     bool
+	}{
+		{TypePhone, "+1 (415) 555-2671", "+14155552671", true},
+		{TypePhone, "0044 20 7946 0958", "+442079460958", true},
+		{TypePhone, "415.555.2671", "+14155552671", true},
+		{TypePhone, "+44 (0)20", "", false},
+		{TypePhone, "555-CALL-NOW", "", false},
+		{TypePhone, "+1234567890123456", "", false},
+		{TypeEmail, "  Jane.Doe@Example.COM ", "jane.doe@example.com", true},
+		{TypeEmail, "ops+alerts@sub.example.org", "ops+alerts@sub.example.org", true},
+		{TypeEmail, "Jane <jane@example.com>", "", false},
+		{TypeEmail, "jane@localhost", "", false},
+		{TypeEmail, "jane.example.com", "", false},
+		{TypeEmail, "jane@exa_mple.com", "", false},
+		{TypeURL, "HTTPS://Example.COM:443", "https://example.com/", true},
+		{TypeURL, "example.com/Docs?q=1", "https://example.com/Docs?q=1", true},
+		{TypeURL, "http://Example.com:8080/a", "http://example.com:8080/a", true},
+		{TypeURL, "http://10.0.0.1:80/health", "http://10.0.0.1/health", true},
+		{TypeURL, "ftp://example.com/file", "", false},
+		{TypeURL, "https://exa mple.com", "", false},
+		{TypeURL, "https://", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.fieldType+" "+tc.input, func(t *testing.T) {
+			normalized, err := normalizeValue(tc.fieldType, tc.input, "1")
+			if !tc.valid {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, normalized)
+		})
+	}
+}
+
+func TestNormalizeNode_Execute(t *testing.T) {
+	config := map[string]any{
+		"fields": map[string]any{
+			"phone":   map[string]any{"value": "{{.trigger_data.phone}}", "type": "phone"},
+			"email":   map[string]any{"value": "{{.trigger_data.email}}", "type": "email"},
+			"website": map[string]any{"value": "{{.trigger_data.website}}", "type": "url", "optional": true},
+		},
+		"default_country_code": "+44",
+	}
+
+	t.Run("valid fields are normalized", func(t *testing.T) {
+		results := execute(t, config, map[string]any{
+			"phone": "020 7946 0958", "email": "Ops@Example.com", "website": "",
+		})
+		require.Contains(t, results, OutputPortSuccess)
+
+		data := results[OutputPortSuccess].Data
+		assert.Equal(t, true, data["valid"])
+		assert.Equal(t, []string{}, data["invalid"])
+		assert.Equal(t, map[string]any{
+			"phone": "+442079460958", "email": "ops@example.com", "website": "",
+		}, data["values"])
+	})
+
+	t.Run("invalid fields are reported", func(t *testing.T) {
+		results := execute(t, config, map[string]any{
+			"phone": "call me", "email": "ops@example.com", "website": "ftp://example.com",
+		})
+		require.Contains(t, results, OutputPortSuccess)
+
+		data := results[OutputPortSuccess].Data
+		assert.Equal(t, false, data["valid"])
+		assert.Equal(t, []string{"phone", "website"}, data["invalid"])
+
+		values, _ := data["values"].(map[string]any)
+		assert.Equal(t, "call me", values["phone"])
+		assert.Equal(t, "ops@example.com", values["email"])
+
+		report, _ := data["report"].(map[string]any)
+		phone, _ := report["phone"].(map[string]any)
+		assert.Equal(t, false, phone["valid"])
+		assert.NotEmpty(t, phone["error"])
+	})
+
+	t.Run("fail on invalid routes to error", func(t *testing.T) {
+		failing := map[string]any{"fields": config["fields"], "fail_on_invalid": true}
+
+		results := execute(t, failing, map[string]any{
+			"phone": "+14155552671", "email": "not an email", "website": "example.com",
+		})
+		require.Contains(t, results, OutputPortError)
+
+		result := results[OutputPortError]
+		assert.Equal(t, "invalid fields: email", result.Error)
+		assert.Equal(t, []string{"email"}, result.Data["invalid"])
+
+		results = execute(t, failing, map[string]any{
+			"phone": "+14155552671", "email": "ops@example.com", "website": "example.com",
+		})
+		require.Contains(t, results, OutputPortSuccess)
+	})
+
+	t.Run("empty required field is invalid", func(t *testing.T) {
+		results := execute(t, config, map[string]any{"phone": "", "email": "ops@example.com", "website": ""})
+		require.Contains(t, results, OutputPortSuccess)
+		assert.Equal(t, []string{"phone"}, results[OutputPortSuccess].Data["invalid"])
+	})
+}
@@ -0,0 +1,131 @@
+package normalize
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Field types the node can normalize.
+const (
+	TypePhone = "phone"
+	TypeEmail = "email"
+	TypeURL   = "url"
+)
+
+// Types are the supported field types.
+var Types = []string{TypePhone, TypeEmail, TypeURL}
+
+var (
+	// e164Pattern matches an E.164 number: a plus sign and up to 15 digits, not starting with 0.
+	e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	// phoneSeparators are the characters phone numbers are commonly written with between digits.
+	phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "", "\u00a0", "")
+	// hostnamePattern matches a DNS host name of letters, digits and hyphens.
+	hostnamePattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+)
+
+// normalizeValue normalizes value as a field of fieldType.
+func normalizeValue(fieldType, value, defaultCountryCode string) (string, error) {
+	switch fieldType {
+	case TypePhone:
+		return normalizePhone(value, defaultCountryCode)
+	case TypeEmail:
+		return normalizeEmail(value)
+	case TypeURL:
+		return normalizeURL(value)
+	}
+
+	return "", fmt.Errorf("unsupported type '%s'", fieldType)
+}
+
+// normalizePhone returns a phone number in E.164 format. International numbers start with + or
+// 00; national numbers are prefixed with defaultCountryCode after dropping a leading trunk 0.
+func normalizePhone(value, defaultCountryCode string) (string, error) {
+	number := phoneSeparators.Replace(strings.TrimSpace(value))
+
+	switch {
+	case strings.HasPrefix(number, "+"):
+	case strings.HasPrefix(number, "00"):
+		number = "+" + number[2:]
+	case defaultCountryCode != "":
+		number = "+" + defaultCountryCode + strings.TrimPrefix(number, "0")
+	default:
+		return "", errors.New("phone number has no country code")
+	}
+
+	if !e164Pattern.MatchString(number) {
+		return "", fmt.Errorf("'%s' is not a valid phone number", value)
+	}
+
+	return number, nil
+}
+
+// normalizeEmail returns a bare, lowercased email address. Addresses with a display name or
+// without a dotted domain are invalid.
+func normalizeEmail(value string) (string, error) {
+	email := strings.TrimSpace(value)
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Name != "" || !strings.EqualFold(address.Address, email) {
+		return "", fmt.Errorf("'%s' is not a valid email address", value)
+	}
+
+	at := strings.LastIndex(email, "@")
+
+	domain := strings.ToLower(email[at+1:])
+	if !strings.Contains(domain, ".") || !hostnamePattern.MatchString(domain) {
+		return "", fmt.Errorf("'%s' is not a valid email address", value)
+	}
+
+	return strings.ToLower(email[:at]) + "@" + domain, nil
+}
+
+// normalizeURL returns a canonical http(s) URL: https is assumed without a scheme, the scheme and
+// host are lowercased, the default port is dropped and an empty path becomes "/".
+func normalizeURL(value string) (string, error) {
+	raw := strings.TrimSpace(value)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || strings.ContainsAny(raw, " \t\n") {
+		return "", fmt.Errorf("'%s' is not a valid URL", value)
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("'%s' is not an http or https URL", value)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host == "" || (net.ParseIP(host) == nil && !hostnamePattern.MatchString(host)) {
+		return "", fmt.Errorf("'%s' is not a valid URL", value)
+	}
+
+	port := parsed.Port()
+	if (parsed.Scheme == "http" && port == "80") || (parsed.Scheme == "https" && port == "443") {
+		port = ""
+	}
+
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+
+	if port != "" {
+		host += ":" + port
+	}
+
+	parsed.Host = host
+
+	if parsed.Path == "" {
+		parsed.Path = "/"
+	}
+
+	return parsed.String(), nil
+}
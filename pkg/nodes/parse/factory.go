@@ -0,0 +1,83 @@
+// Package parse provides parse node factory for registry integration.
+package parse
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// ParseNodeFactory creates ParseNode instances.
+type ParseNodeFactory struct{}
+
+// Create creates a new ParseNode instance.
+func (f *ParseNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewParseNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *ParseNodeFactory) ID() string {
+	return "parse"
+}
+
+// Name returns the factory name.
+func (f *ParseNodeFactory) Name() string {
+	return "Parse Text"
+}
+
+// Description returns the factory description.
+func (f *ParseNodeFactory) Description() string {
+	return "Extracts named fields from unstructured text such as log lines with a regular expression or a grok pattern"
+}
+
+// Schema returns the JSON schema for parse node configuration.
+func (f *ParseNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"input": map[string]any{
+				"type":        "string",
+				"description": "Text to parse. Supports templating.",
+				"examples":    []string{"{{.trigger_data.body}}"},
+			},
+			"regex": map[string]any{
+				"type":        "string",
+				"description": "Regular expression (RE2 syntax) whose named groups (?P<name>...) become the fields",
+			},
+			"grok": map[string]any{
+				"type":        "string",
+				"description": "Grok pattern: %{PATTERN:field} references become the fields, %{PATTERN:field:int} or :float converts them",
+			},
+			"patterns": map[string]any{
+				"type":                 "object",
+				"description":          "Custom grok patterns by name, usable in grok along with the built-in ones",
+				"additionalProperties": map[string]any{"type": "string"},
+			},
+			"multiple": map[string]any{
+				"type":        "boolean",
+				"description": "Return the fields of every match as an array instead of the first match",
+				"default":     false,
+			},
+		},
+		"required": []string{"input"},
+		"oneOf": []map[string]any{
+			{"required": []string{"regex"}},
+			{"required": []string{"grok"}},
+		},
+		"examples": []map[string]any{
+			{
+				"input": "{{.trigger_data.line}}",
+				"regex": `^(?P<time>\S+) (?P<level>[A-Z]+) (?P<message>.*)$`,
+			},
+			{
+				"input": "{{.trigger_data.line}}",
+				"grok":  `%{IPORHOST:client} %{WORD:method} %{URIPATHPARAM:path} %{NUMBER:status:int}`,
+			},
+		},
+	}
+}
+
+// NewParseNodeFactory creates a new factory instance.
+func NewParseNodeFactory() protocol.NodeFactory {
+	return &ParseNodeFactory{}
+}
@@ -0,0 +1,118 @@
+package parse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxGrokDepth bounds the nesting of grok patterns, which also stops recursive definitions.
+const maxGrokDepth = 16
+
+// grokReference matches %{PATTERN}, %{PATTERN:field} and %{PATTERN:field:type} references.
+var grokReference = regexp.MustCompile(`%\{(\w+)(?::(\w+))?(?::(int|float))?\}`)
+
+// GrokPatterns are the built-in grok patterns, a subset of the Logstash ones written for RE2.
+var GrokPatterns = map[string]string{
+	"USERNAME":          `[a-zA-Z0-9._-]+`,
+	"USER":              `%{USERNAME}`,
+	"INT":               `(?:[+-]?[0-9]+)`,
+	"BASE10NUM":         `(?:[+-]?(?:[0-9]+(?:\.[0-9]+)?|\.[0-9]+))`,
+	"NUMBER":            `(?:%{BASE10NUM})`,
+	"POSINT":            `\b(?:[1-9][0-9]*)\b`,
+	"NONNEGINT":         `\b(?:[0-9]+)\b`,
+	"WORD":              `\b\w+\b`,
+	"NOTSPACE":          `\S+`,
+	"SPACE":             `\s*`,
+	"DATA":              `.*?`,
+	"GREEDYDATA":        `.*`,
+	"QUOTEDSTRING":      `(?:"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*')`,
+	"UUID":              `[A-Fa-f0-9]{8}-(?:[A-Fa-f0-9]{4}-){3}[A-Fa-f0-9]{12}`,
+	"IPV4":              `(?:(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])`,
+	"IPV6":              `(?:[0-9A-Fa-f]{0,4}:){2,7}[0-9A-Fa-f]{0,4}`,
+	"IP":                `(?:%{IPV6}|%{IPV4})`,
+	"HOSTNAME":          `\b(?:[0-9A-Za-z][0-9A-Za-z-]{0,62})(?:\.(?:[0-9A-Za-z][0-9A-Za-z-]{0,62}))*\.?\b`,
+	"IPORHOST":          `(?:%{IP}|%{HOSTNAME})`,
+	"EMAILADDRESS":      `[a-zA-Z0-9._+-]+@%{HOSTNAME}`,
+	"URIPATH":           `(?:/[A-Za-z0-9$.+!*'(),~:;=@#%&_-]*)+`,
+	"URIPARAM":          `\?[A-Za-z0-9$.+!*'|(),~@#%&/=:;_?\[\]<>-]*`,
+	"URIPATHPARAM":      `%{URIPATH}(?:%{URIPARAM})?`,
+	"MONTH":             `\b(?:Jan(?:uary)?|Feb(?:ruary)?|Mar(?:ch)?|Apr(?:il)?|May|June?|July?|Aug(?:ust)?|Sep(?:tember)?|Oct(?:ober)?|Nov(?:ember)?|Dec(?:ember)?)\b`,
+	"MONTHNUM":          `(?:0?[1-9]|1[0-2])`,
+	"MONTHDAY":          `(?:0[1-9]|[12][0-9]|3[01]|[1-9])`,
+	"YEAR":              `(?:\d\d){1,2}`,
+	"HOUR":              `(?:2[0123]|[01]?[0-9])`,
+	"MINUTE":            `(?:[0-5][0-9])`,
+	"SECOND":            `(?:(?:[0-5]?[0-9]|60)(?:[:.,][0-9]+)?)`,
+	"TIME":              `%{HOUR}:%{MINUTE}(?::%{SECOND})?`,
+	"ISO8601_TZ":        `(?:Z|[+-]%{HOUR}(?::?%{MINUTE}))`,
+	"TIMESTAMP_ISO8601": `%{YEAR}-%{MONTHNUM}-%{MONTHDAY}[T ]%{HOUR}:?%{MINUTE}(?::?%{SECOND})?%{ISO8601_TZ}?`,
+	"HTTPDATE":          `%{MONTHDAY}/%{MONTH}/%{YEAR}:%{TIME} [+-]?\d{4}`,
+	"LOGLEVEL": `(?:[Tt]race|TRACE|[Dd]ebug|DEBUG|[Nn]otice|NOTICE|[Ii]nfo|INFO|[Ww]arn(?:ing)?|WARN(?:ING)?|` +
+		`[Ee]rr(?:or)?|ERR(?:OR)?|[Cc]rit(?:ical)?|CRIT(?:ICAL)?|[Ff]atal|FATAL|[Aa]lert|ALERT|[Ee]merg(?:ency)?|EMERG(?:ENCY)?)`,
+}
+
+// compileGrok expands the grok references of expression into a regular expression with a named
+// group per %{PATTERN:field} reference, using the custom patterns before the built-in ones. It
+// returns the expression along with the type conversions (int or float) of the typed fields.
+func compileGrok(expression string, custom map[string]string) (string, map[string]string, error) {
+	types := make(map[string]string)
+
+	expanded, err := expandGrok(expression, custom, types, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return expanded, types, nil
+}
+
+func expandGrok(expression string, custom map[string]string, types map[string]string, depth int) (string, error) {
+	if depth > maxGrokDepth {
+		return "", fmt.Errorf("grok patterns nested deeper than %d, is a pattern recursive?", maxGrokDepth)
+	}
+
+	var (
+		builder strings.Builder
+		err     error
+	)
+
+	last := 0
+
+	for _, match := range grokReference.FindAllStringSubmatchIndex(expression, -1) {
+		builder.WriteString(expression[last:match[0]])
+		last = match[1]
+
+		name := expression[match[2]:match[3]]
+
+		definition, ok := custom[name]
+		if !ok {
+			definition, ok = GrokPatterns[name]
+		}
+
+		if !ok {
+			return "", fmt.Errorf("unknown grok pattern '%s'", name)
+		}
+
+		definition, err = expandGrok(definition, custom, types, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		if match[4] < 0 {
+			builder.WriteString("(?:" + definition + ")")
+
+			continue
+		}
+
+		field := expression[match[4]:match[5]]
+		builder.WriteString("(?P<" + field + ">" + definition + ")")
+
+		if match[6] >= 0 {
+			types[field] = expression[match[6]:match[7]]
+		}
+	}
+
+	builder.WriteString(expression[last:])
+
+	return builder.String(), nil
+}
@@ -0,0 +1,305 @@
+// Package parse provides a node that extracts structured fields from text with a regular
+// expression or a grok pattern.
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// ParseNode implements the Node interface for parsing text into named fields.
+type ParseNode struct {
+	id       string
+	input    string
+	pattern  *regexp.Regexp
+	types    map[string]string
+	multiple bool
+}
+
+// NewParseNode creates a new parse node, compiling its pattern.
+func NewParseNode(id string, config map[string]any) (*ParseNode, error) {
+	pattern, types, err := compileConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	input, _ := config["input"].(string)
+	multiple, _ := config["multiple"].(bool)
+
+	return &ParseNode{
+		id:       id,
+		input:    input,
+		pattern:  pattern,
+		types:    types,
+		multiple: multiple,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *ParseNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *ParseNode) Type() string {
+	return "parse"
+}
+
+// Execute renders the input and extracts the named groups of the pattern from it: the fields of
+// the first match go to result, or the fields of every match as an array when multiple is set.
+// Input the pattern does not match goes to the error port.
+func (n *ParseNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	input, err := template.RenderStringWithContext(n.input, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render input template: %v", err)), nil
+	}
+
+	var data map[string]any
+
+	if n.multiple {
+		matches := n.pattern.FindAllStringSubmatch(input, -1)
+		if len(matches) == 0 {
+			return n.createErrorResult("input does not match the pattern"), nil
+		}
+
+		fields := make([]any, 0, len(matches))
+
+		for _, match := range matches {
+			matchFields, err := n.fields(match)
+			if err != nil {
+				return n.createErrorResult(err.Error()), nil
+			}
+
+			fields = append(fields, matchFields)
+		}
+
+		data = map[string]any{"result": fields, "count": len(fields)}
+	} else {
+		match := n.pattern.FindStringSubmatch(input)
+		if match == nil {
+			return n.createErrorResult("input does not match the pattern"), nil
+		}
+
+		fields, err := n.fields(match)
+		if err != nil {
+			return n.createErrorResult(err.Error()), nil
+		}
+
+		data = map[string]any{"result": fields}
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// fields maps the named groups of a match to their values, converting typed grok fields.
+func (n *ParseNode) fields(match []string) (map[string]any, error) {
+	fields := make(map[string]any)
+
+	for i, name := range n.pattern.SubexpNames() {
+		if name == "" {
+			continue
+		}
+
+		value := match[i]
+
+		switch n.types[name] {
+		case "int":
+			converted, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("field '%s' is not an int: %q", name, value)
+			}
+
+			fields[name] = converted
+		case "float":
+			converted, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("field '%s' is not a float: %q", name, value)
+			}
+
+			fields[name] = converted
+		default:
+			fields[name] = value
+		}
+	}
+
+	return fields, nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *ParseNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *ParseNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Triggers the parsing; the input is read from the execution context through its template",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *ParseNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "The named fields of the first match, or of every match when multiple is set",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"result": map[string]any{"type": []string{"object", "array"}},
+						"count":  map[string]any{"type": "integer", "description": "Number of matches, when multiple is set"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the input cannot be rendered or does not match the pattern",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the parse node.
+func (n *ParseNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *ParseNode) Validate(config map[string]any) error {
+	_, _, err := compileConfig(config)
+
+	return err
+}
+
+// compileConfig validates a node configuration and compiles its regex or grok pattern, returning
+// the type conversions of the typed grok fields.
+func compileConfig(config map[string]any) (*regexp.Regexp, map[string]string, error) {
+	if _, ok := config["input"].(string); !ok {
+		return nil, nil, errors.New("missing required field 'input'")
+	}
+
+	if multiple, exists := config["multiple"]; exists {
+		if _, ok := multiple.(bool); !ok {
+			return nil, nil, errors.New("field 'multiple' must be a boolean")
+		}
+	}
+
+	regex, _ := config["regex"].(string)
+	grok, _ := config["grok"].(string)
+
+	if (regex == "") == (grok == "") {
+		return nil, nil, errors.New("exactly one of 'regex' or 'grok' is required")
+	}
+
+	types := map[string]string{}
+
+	if grok != "" {
+		custom, err := customPatterns(config["patterns"])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if regex, types, err = compileGrok(grok, custom); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	pattern, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	if !hasNamedGroup(pattern) {
+		return nil, nil, errors.New("pattern must have at least one named group, e.g. (?P<name>...) or %{WORD:name}")
+	}
+
+	return pattern, types, nil
+}
+
+// customPatterns reads the "patterns" config, a map of grok pattern names to definitions.
+func customPatterns(raw any) (map[string]string, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	values, ok := raw.(map[string]any)
+	if !ok {
+		return nil, errors.New("field 'patterns' must map pattern names to definitions")
+	}
+
+	patterns := make(map[string]string, len(values))
+
+	for name, value := range values {
+		definition, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("grok pattern '%s' must be a string", name)
+		}
+
+		patterns[name] = definition
+	}
+
+	return patterns, nil
+}
+
+func hasNamedGroup(pattern *regexp.Regexp) bool {
+	for _, name := range pattern.SubexpNames() {
+		if name != "" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,113 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func execute(t *testing.T, config map[string]any, line string) map[string]models.NodeResult {
+	t.Helper()
+
+	config["input"] = "{{.trigger_data.line}}"
+
+	node, err := NewParseNode("parse", config)
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{
+		TriggerData: map[string]any{"line": line},
+	}, map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	return results
+}
+
+func TestNewParseNode_InvalidConfig(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{},
+		{"input": "{{.trigger_data.line}}"},
+		{"input": "{{.trigger_data.line}}", "regex": `(?P<a>\w+)`, "grok": "%{WORD:a}"},
+		{"input": "{{.trigger_data.line}}", "regex": `(?P<a>\w+`},
+		{"input": "{{.trigger_data.line}}", "regex": `\w+`},
+		{"input": "{{.trigger_data.line}}", "grok": "%{NOPE:a}"},
+		{"input": "{{.trigger_data.line}}", "grok": "%{LOOP:a}", "patterns": map[string]any{"LOOP": "%{LOOP}"}},
+		{"input": "{{.trigger_data.line}}", "regex": `(?P<a>\w+)`, "multiple": "yes"},
+	}
+
+	for _, config := range invalidConfigs {
+		_, err := NewParseNode("parse", config)
+		assert.Error(t, err, "config %v", config)
+	}
+}
+
+func TestParseNode_RegexNamedGroups(t *testing.T) {
+	results := execute(t, map[string]any{
+		"regex": `^(?P<time>\S+) (?P<level>[A-Z]+) \[(?P<service>[\w-]+)\] (?P<message>.*)$`,
+	}, "2025-03-01T12:00:00Z ERROR [billing-api] payment 42 declined")
+
+	require.Contains(t, results, OutputPortSuccess)
+	assert.Equal(t, map[string]any{
+		"time":    "2025-03-01T12:00:00Z",
+		"level":   "ERROR",
+		"service": "billing-api",
+		"message": "payment 42 declined",
+	}, results[OutputPortSuccess].Data["result"])
+}
+
+func TestParseNode_Grok(t *testing.T) {
+	results := execute(t, map[string]any{
+		"grok":     `%{IPORHOST:client} - %{USER:user} \[%{HTTPDATE:timestamp}\] "%{WORD:method} %{URIPATHPARAM:path}" %{NUMBER:status:int} %{DURATION:took:float}`,
+		"patterns": map[string]any{"DURATION": `%{BASE10NUM}`},
+	}, `10.0.0.7 - ada [01/Mar/2025:12:00:00 +0000] "GET /orders?page=2" 404 0.25`)
+
+	require.Contains(t, results, OutputPortSuccess)
+	assert.Equal(t, map[string]any{
+		"client":    "10.0.0.7",
+		"user":      "ada",
+		"timestamp": "01/Mar/2025:12:00:00 +0000",
+		"method":    "GET",
+		"path":      "/orders?page=2",
+		"status":    int64(404),
+		"took":      0.25,
+	}, results[OutputPortSuccess].Data["result"])
+}
+
+func TestParseNode_NoMatchRoutesToError(t *testing.T) {
+	for name, config := range map[string]map[string]any{
+		"regex":    {"regex": `^(?P<level>[A-Z]+): (?P<message>.*)$`},
+		"multiple": {"regex": `(?P<key>\w+)=(?P<value>\w+)`, "multiple": true},
+		"grok":     {"grok": `%{LOGLEVEL:level} %{NUMBER:code:int}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			results := execute(t, config, "just some text")
+
+			require.Contains(t, results, OutputPortError)
+			result := results[OutputPortError]
+			assert.Equal(t, string(models.NodeStatusError), result.Status)
+			assert.Equal(t, "input does not match the pattern", result.Error)
+			assert.Equal(t, false, result.Data["success"])
+		})
+	}
+}
+
+func TestParseNode_MultipleMatches(t *testing.T) {
+	results := execute(t, map[string]any{
+		"regex":    `(?P<key>\w+)=(?P<value>"[^"]*"|\S+)`,
+		"multiple": true,
+	}, `level=warn user=ada msg="disk almost full"`)
+
+	require.Contains(t, results, OutputPortSuccess)
+
+	data := results[OutputPortSuccess].Data
+	assert.Equal(t, 3, data["count"])
+	assert.Equal(t, []any{
+		map[string]any{"key": "level", "value": "warn"},
+		map[string]any{"key": "user", "value": "ada"},
+		map[string]any{"key": "msg", "value": `"disk almost full"`},
+	}, data["result"])
+}
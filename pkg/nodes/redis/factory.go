@@ -0,0 +1,149 @@
+// Package redis provides Redis node factory for registry integration.
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/dukex/operion/pkg/protocol"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// RedisNodeFactory creates RedisNode instances, sharing one client per connection URL.
+type RedisNodeFactory struct {
+	mu      sync.Mutex
+	clients map[string]*goredis.Client
+}
+
+// Create creates a new RedisNode instance.
+func (f *RedisNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	client, err := f.client(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRedisNode(id, config, client)
+}
+
+// client returns the client for the configured connection URL, creating it on first use. The URL
+// is expanded from environment variables, so "redis://:${REDIS_PASSWORD}@host:6379/0" keeps
+// passwords out of workflow definitions.
+func (f *RedisNodeFactory) client(config map[string]any) (*goredis.Client, error) {
+	connectionURL, _ := config["connection_url"].(string)
+	if connectionURL == "" {
+		return nil, errors.New("missing required field 'connection_url'")
+	}
+
+	connectionURL = os.ExpandEnv(connectionURL)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if client, exists := f.clients[connectionURL]; exists {
+		return client, nil
+	}
+
+	options, err := goredis.ParseURL(connectionURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis connection_url: %w", err)
+	}
+
+	client := goredis.NewClient(options)
+	f.clients[connectionURL] = client
+
+	return client, nil
+}
+
+// ID returns the factory ID.
+func (f *RedisNodeFactory) ID() string {
+	return "redis"
+}
+
+// Name returns the factory name.
+func (f *RedisNodeFactory) Name() string {
+	return "Redis"
+}
+
+// Description returns the factory description.
+func (f *RedisNodeFactory) Description() string {
+	return "Runs a Redis command for caching and coordination between executions"
+}
+
+// Schema returns the JSON schema for Redis node configuration.
+func (f *RedisNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"connection_url": map[string]any{
+				"type":        "string",
+				"description": "Connection URL of the Redis server. Environment variables are expanded.",
+				"examples": []string{
+					"redis://localhost:6379/0",
+					"redis://:${REDIS_PASSWORD}@redis:6379/1",
+				},
+			},
+			"command": map[string]any{
+				"type":        "string",
+				"description": "Command to run",
+				"enum":        Commands,
+			},
+			"key": map[string]any{
+				"type":        "string",
+				"description": "Key the command runs on. Supports templating.",
+				"examples": []string{
+					"cache:{{.trigger_data.customer_id}}",
+					"queue:orders",
+				},
+			},
+			"value": map[string]any{
+				"type":        "string",
+				"description": "Value stored by SET or pushed by LPUSH. Supports templating.",
+				"examples":    []string{"{{.node_results.fetch.body}}"},
+			},
+			"ttl": map[string]any{
+				"type":        "string",
+				"description": "Expiry of the key set by SET (optional) or EXPIRE (required)",
+				"examples":    []string{"30s", "1h"},
+			},
+		},
+		"required": []string{"connection_url", "command", "key"},
+		"allOf": []map[string]any{
+			{
+				"if":   map[string]any{"properties": map[string]any{"command": map[string]any{"enum": []string{CommandSet, CommandLPush}}}},
+				"then": map[string]any{"required": []string{"value"}},
+			},
+			{
+				"if":   map[string]any{"properties": map[string]any{"command": map[string]any{"const": CommandExpire}}},
+				"then": map[string]any{"required": []string{"ttl"}},
+			},
+		},
+		"examples": []map[string]any{
+			{
+				"connection_url": "redis://localhost:6379/0",
+				"command":        CommandSet,
+				"key":            "cache:{{.trigger_data.customer_id}}",
+				"value":          "{{.node_results.fetch.body}}",
+				"ttl":            "1h",
+			},
+			{
+				"connection_url": "redis://localhost:6379/0",
+				"command":        CommandIncr,
+				"key":            "counter:{{.execution.workflow_id}}",
+			},
+		},
+	}
+}
+
+// NewRedisNodeFactory creates a new factory instance.
+func NewRedisNodeFactory() protocol.NodeFactory {
+	return &RedisNodeFactory{
+		clients: make(map[string]*goredis.Client),
+	}
+}
@@ -0,0 +1,278 @@
+// Package redis provides a node that runs commands against a Redis server.
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// Supported commands.
+const (
+	CommandGet    = "GET"
+	CommandSet    = "SET"
+	CommandIncr   = "INCR"
+	CommandDel    = "DEL"
+	CommandExpire = "EXPIRE"
+	CommandLPush  = "LPUSH"
+	CommandRPop   = "RPOP"
+)
+
+// Commands lists the supported commands.
+var Commands = []string{CommandGet, CommandSet, CommandIncr, CommandDel, CommandExpire, CommandLPush, CommandRPop}
+
+// RedisNode implements the Node interface for running a Redis command.
+type RedisNode struct {
+	id      string
+	command string
+	key     string
+	value   string
+	ttl     time.Duration
+	client  goredis.Cmdable
+}
+
+// NewRedisNode creates a new Redis node running its command through client.
+func NewRedisNode(id string, config map[string]any, client goredis.Cmdable) (*RedisNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	command, _ := config["command"].(string)
+	key, _ := config["key"].(string)
+	value, _ := config["value"].(string)
+
+	var ttl time.Duration
+	if raw, ok := config["ttl"].(string); ok && raw != "" {
+		ttl, _ = time.ParseDuration(raw)
+	}
+
+	return &RedisNode{
+		id:      id,
+		command: strings.ToUpper(command),
+		key:     key,
+		value:   value,
+		ttl:     ttl,
+		client:  client,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *RedisNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *RedisNode) Type() string {
+	return "redis"
+}
+
+// Execute runs the command with the rendered key and value. GET and RPOP on a missing key
+// succeed with a nil result and found set to false.
+func (n *RedisNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	key, err := template.RenderStringWithContext(n.key, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render key template: %v", err)), nil
+	}
+
+	if key == "" {
+		return n.createErrorResult("rendered key is empty"), nil
+	}
+
+	value, err := template.RenderStringWithContext(n.value, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render value template: %v", err)), nil
+	}
+
+	data := map[string]any{
+		"command": n.command,
+		"key":     key,
+	}
+
+	result, err := n.run(context.Background(), key, value)
+
+	switch {
+	case errors.Is(err, goredis.Nil):
+		data["result"] = nil
+		data["found"] = false
+	case err != nil:
+		return n.createErrorResult(fmt.Sprintf("%s %s failed: %v", n.command, key, err)), nil
+	default:
+		data["result"] = result
+
+		if n.command == CommandGet || n.command == CommandRPop {
+			data["found"] = true
+		}
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// run runs the command on key and returns its reply.
+func (n *RedisNode) run(ctx context.Context, key, value string) (any, error) {
+	switch n.command {
+	case CommandGet:
+		return n.client.Get(ctx, key).Result()
+	case CommandSet:
+		return n.client.Set(ctx, key, value, n.ttl).Result()
+	case CommandIncr:
+		return n.client.Incr(ctx, key).Result()
+	case CommandDel:
+		return n.client.Del(ctx, key).Result()
+	case CommandExpire:
+		return n.client.Expire(ctx, key, n.ttl).Result()
+	case CommandLPush:
+		return n.client.LPush(ctx, key, value).Result()
+	case CommandRPop:
+		return n.client.RPop(ctx, key).Result()
+	default:
+		return nil, fmt.Errorf("unsupported command '%s'", n.command)
+	}
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *RedisNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *RedisNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Triggers the command",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *RedisNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Reply of the command",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"command": map[string]any{"type": "string"},
+						"key":     map[string]any{"type": "string"},
+						"result":  map[string]any{"description": "Reply of the command, null for a missing key"},
+						"found":   map[string]any{"type": "boolean", "description": "Whether the key existed (GET and RPOP only)"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the command cannot be run",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the Redis node.
+func (n *RedisNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *RedisNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the command fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	command, ok := config["command"].(string)
+	if !ok || command == "" {
+		return errors.New("missing required field 'command'")
+	}
+
+	command = strings.ToUpper(command)
+	if !slices.Contains(Commands, command) {
+		return fmt.Errorf("unsupported command '%s' (supported: %s)", command, strings.Join(Commands, ", "))
+	}
+
+	if key, ok := config["key"].(string); !ok || key == "" {
+		return errors.New("missing required field 'key'")
+	}
+
+	if raw, exists := config["value"]; exists {
+		if _, ok := raw.(string); !ok {
+			return errors.New("field 'value' must be a string")
+		}
+	}
+
+	if command == CommandSet || command == CommandLPush {
+		if _, exists := config["value"]; !exists {
+			return fmt.Errorf("missing required field 'value' for %s", command)
+		}
+	}
+
+	rawTTL, hasTTL := config["ttl"]
+	if hasTTL {
+		s, ok := rawTTL.(string)
+		if !ok {
+			return errors.New("field 'ttl' must be a duration string")
+		}
+
+		if ttl, err := time.ParseDuration(s); err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid ttl '%s'", s)
+		}
+	}
+
+	if command == CommandExpire && !hasTTL {
+		return errors.New("missing required field 'ttl' for EXPIRE")
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeRedis creates a node from config through the factory, against the server, and executes it.
+func executeRedis(t *testing.T, server *miniredis.Miniredis, config map[string]any) map[string]models.NodeResult {
+	t.Helper()
+
+	config["connection_url"] = "redis://" + server.Addr() + "/0"
+
+	node, err := NewRedisNodeFactory().Create(t.Context(), "cache", config)
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{
+		WorkflowID:  "orders",
+		TriggerData: map[string]any{"customer_id": "42", "name": "Ada"},
+	}, map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{}},
+	})
+	require.NoError(t, err)
+
+	return results
+}
+
+func TestNewRedisNode_InvalidConfig(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{"key": "a"},
+		{"command": "FLUSHALL", "key": "a"},
+		{"command": "GET"},
+		{"command": "SET", "key": "a"},
+		{"command": "LPUSH", "key": "a", "value": 42},
+		{"command": "EXPIRE", "key": "a"},
+		{"command": "SET", "key": "a", "value": "b", "ttl": "soon"},
+	}
+
+	for _, config := range invalidConfigs {
+		_, err := NewRedisNode("cache", config, nil)
+		assert.Error(t, err, "config %v", config)
+	}
+}
+
+func TestRedisNode_Execute_SetAndGet(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	results := executeRedis(t, server, map[string]any{
+		"command": "SET",
+		"key":     "customer:{{.trigger_data.customer_id}}",
+		"value":   `{"name": "{{.trigger_data.name}}"}`,
+		"ttl":     "1h",
+	})
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"command": CommandSet, "key": "customer:42", "result": "OK"}, result.Data)
+	assert.Equal(t, time.Hour, server.TTL("customer:42"))
+
+	results = executeRedis(t, server, map[string]any{"command": "get", "key": "customer:{{.trigger_data.customer_id}}"})
+
+	result, ok = results[OutputPortSuccess]
+	require.True(t, ok)
+	assert.Equal(t, `{"name": "Ada"}`, result.Data["result"])
+	assert.Equal(t, true, result.Data["found"])
+}
+
+func TestRedisNode_Execute_GetMissingKey(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	results := executeRedis(t, server, map[string]any{"command": "GET", "key": "customer:unknown"})
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok)
+	assert.Nil(t, result.Data["result"])
+	assert.Equal(t, false, result.Data["found"])
+}
+
+func TestRedisNode_Execute_Incr(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	for expected := int64(1); expected <= 3; expected++ {
+		results := executeRedis(t, server, map[string]any{"command": "INCR", "key": "counter:{{.execution.workflow_id}}"})
+		assert.Equal(t, expected, results[OutputPortSuccess].Data["result"])
+	}
+
+	value, err := server.Get("counter:orders")
+	require.NoError(t, err)
+	assert.Equal(t, "3", value)
+}
+
+func TestRedisNode_Execute_ListAndExpiry(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	executeRedis(t, server, map[string]any{"command": "LPUSH", "key": "queue", "value": "first"})
+	results := executeRedis(t, server, map[string]any{"command": "LPUSH", "key": "queue", "value": "second"})
+	assert.Equal(t, int64(2), results[OutputPortSuccess].Data["result"])
+
+	results = executeRedis(t, server, map[string]any{"command": "EXPIRE", "key": "queue", "ttl": "10m"})
+	assert.Equal(t, true, results[OutputPortSuccess].Data["result"])
+	assert.Equal(t, 10*time.Minute, server.TTL("queue"))
+
+	results = executeRedis(t, server, map[string]any{"command": "RPOP", "key": "queue"})
+	assert.Equal(t, "first", results[OutputPortSuccess].Data["result"])
+
+	results = executeRedis(t, server, map[string]any{"command": "DEL", "key": "queue"})
+	assert.Equal(t, int64(1), results[OutputPortSuccess].Data["result"])
+
+	results = executeRedis(t, server, map[string]any{"command": "RPOP", "key": "queue"})
+	assert.Equal(t, false, results[OutputPortSuccess].Data["found"])
+}
+
+func TestRedisNode_Execute_CommandError(t *testing.T) {
+	server := miniredis.RunT(t)
+	require.NoError(t, server.Set("name", "Ada"))
+
+	results := executeRedis(t, server, map[string]any{"command": "INCR", "key": "name"})
+
+	result, ok := results[OutputPortError]
+	require.True(t, ok)
+	assert.Equal(t, string(models.NodeStatusError), result.Status)
+	assert.Contains(t, result.Error, "INCR name failed")
+}
@@ -0,0 +1,75 @@
+// Package repeatuntil provides repeat until node factory for registry integration.
+package repeatuntil
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// RepeatUntilNodeFactory creates RepeatUntilNode instances.
+type RepeatUntilNodeFactory struct{}
+
+// Create creates a new RepeatUntilNode instance.
+func (f *RepeatUntilNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewRepeatUntilNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *RepeatUntilNodeFactory) ID() string {
+	return "repeatuntil"
+}
+
+// Name returns the factory name.
+func (f *RepeatUntilNodeFactory) Name() string {
+	return "Repeat Until"
+}
+
+// Description returns the factory description.
+func (f *RepeatUntilNodeFactory) Description() string {
+	return "Repeats a polling path until a condition holds, with a maximum number of iterations and a delay between them"
+}
+
+// Schema returns the JSON schema for Repeat Until node configuration.
+func (f *RepeatUntilNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"condition": map[string]any{
+				"type":        "string",
+				"description": "Condition that ends the loop. Supports templating and is evaluated on every iteration",
+				"examples": []string{
+					`{{eq (index .node_results "check_job::success").json.status "finished"}}`,
+					`{{ge (index .node_results "poll::success").json.count 3.0}}`,
+				},
+			},
+			"max_iterations": map[string]any{
+				"type":        "number",
+				"description": "How many times the condition is evaluated before routing to done without it holding",
+				"default":     DefaultMaxIterations,
+				"minimum":     1,
+				"maximum":     1000,
+			},
+			"delay": map[string]any{
+				"type":        "number",
+				"description": "Delay before repeating, in milliseconds",
+				"default":     0,
+				"minimum":     0,
+				"maximum":     300000,
+			},
+		},
+		"required": []string{"condition"},
+		"examples": []map[string]any{
+			{
+				"condition":      `{{eq (index .node_results "check_job::success").json.status "finished"}}`,
+				"max_iterations": 20,
+				"delay":          5000,
+			},
+		},
+	}
+}
+
+// NewRepeatUntilNodeFactory creates a new factory instance.
+func NewRepeatUntilNodeFactory() protocol.NodeFactory {
+	return &RepeatUntilNodeFactory{}
+}
@@ -0,0 +1,228 @@
+// Package repeatuntil provides a bounded loop node for poll-until-done workflows.
+package repeatuntil
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/nodes/conditional"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortRepeat = models.RepeatOutputPort
+	OutputPortDone   = "done"
+	OutputPortError  = "error"
+	InputPortMain    = "main"
+
+	// DefaultMaxIterations is how many times the condition is evaluated before the loop gives up.
+	DefaultMaxIterations = 10
+)
+
+// RepeatUntilNode implements the Node interface for bounded loops. It routes to the repeat port
+// until its condition holds, then to the done port. The repeat port either leads back to the
+// nodes that poll again or, when left unconnected, activates this node again.
+type RepeatUntilNode struct {
+	id            string
+	condition     string
+	maxIterations int
+	delay         time.Duration
+}
+
+// NewRepeatUntilNode creates a new repeat until node.
+func NewRepeatUntilNode(id string, config map[string]any) (*RepeatUntilNode, error) {
+	condition, ok := config["condition"].(string)
+	if !ok {
+		return nil, errors.New("missing required field 'condition'")
+	}
+
+	node := &RepeatUntilNode{
+		id:            id,
+		condition:     condition,
+		maxIterations: DefaultMaxIterations,
+	}
+
+	if maxIterations, ok := config["max_iterations"].(float64); ok {
+		node.maxIterations = int(maxIterations)
+	}
+
+	if delay, ok := config["delay"].(float64); ok {
+		node.delay = time.Duration(delay) * time.Millisecond
+	}
+
+	return node, nil
+}
+
+// ID returns the node ID.
+func (n *RepeatUntilNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *RepeatUntilNode) Type() string {
+	return "repeatuntil"
+}
+
+// Execute evaluates the condition and routes to the done port once it holds or the maximum number
+// of iterations is reached, and to the repeat port otherwise, due after the configured delay.
+func (n *RepeatUntilNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	// The worker counts the repeats of this node in the execution context
+	iteration := ctx.Iteration(n.id) + 1
+
+	result, err := template.RenderWithContext(n.condition, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("condition evaluation failed: %v", err)), nil
+	}
+
+	data := make(map[string]any)
+	if input, ok := inputs[InputPortMain]; ok {
+		maps.Copy(data, input.Data)
+	}
+
+	satisfied := conditional.IsTrue(result)
+
+	if satisfied || iteration >= n.maxIterations {
+		data["iterations"] = iteration
+		data["condition_result"] = satisfied
+		data["max_iterations_reached"] = !satisfied
+
+		return map[string]models.NodeResult{
+			OutputPortDone: {
+				NodeID: n.id,
+				Data:   data,
+				Status: string(models.NodeStatusSuccess),
+			},
+		}, nil
+	}
+
+	data["iteration"] = iteration
+
+	repeat := models.NodeResult{
+		NodeID: n.id,
+		Data:   data,
+		Status: string(models.NodeStatusSuccess),
+	}
+
+	// The worker holds the next iteration back for the delay, rather than this node sleeping
+	if n.delay > 0 {
+		notBefore := time.Now().UTC().Add(n.delay)
+		repeat.NotBefore = &notBefore
+	}
+
+	return map[string]models.NodeResult{OutputPortRepeat: repeat}, nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *RepeatUntilNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *RepeatUntilNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Result of the latest poll, passed on to the repeat and done ports",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *RepeatUntilNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortRepeat),
+				NodeID:      n.id,
+				Name:        OutputPortRepeat,
+				Description: "Execution path taken while the condition does not hold",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"iteration": map[string]any{"type": "number"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortDone),
+				NodeID:      n.id,
+				Name:        OutputPortDone,
+				Description: "Execution path taken once the condition holds or the maximum iterations are reached",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"iterations":             map[string]any{"type": "number"},
+						"condition_result":       map[string]any{"type": "boolean"},
+						"max_iterations_reached": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when condition evaluation fails",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the repeat until node.
+func (n *RepeatUntilNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *RepeatUntilNode) Validate(config map[string]any) error {
+	if _, ok := config["condition"].(string); !ok {
+		return errors.New("missing required field 'condition'")
+	}
+
+	if maxIterations, ok := config["max_iterations"]; ok {
+		value, ok := maxIterations.(float64)
+		if !ok || value < 1 || value > 1000 {
+			return errors.New("max_iterations must be between 1 and 1000")
+		}
+	}
+
+	if delay, ok := config["delay"]; ok {
+		value, ok := delay.(float64)
+		if !ok || value < 0 || value > 300000 {
+			return errors.New("delay must be between 0 and 300000 milliseconds")
+		}
+	}
+
+	return nil
+}
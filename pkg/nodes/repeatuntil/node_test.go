@@ -0,0 +1,94 @@
+package repeatuntil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executionWithIteration(nodeID string, iteration int) models.ExecutionContext {
+	execCtx := models.ExecutionContext{
+		ID:          "exec-1",
+		NodeResults: map[string]models.NodeResult{},
+		Variables:   map[string]any{"ready": false},
+	}
+	execCtx.SetIteration(nodeID, iteration)
+
+	return execCtx
+}
+
+func TestNewRepeatUntilNode(t *testing.T) {
+	node, err := NewRepeatUntilNode("until", map[string]any{"condition": "true"})
+	require.NoError(t, err)
+	assert.Equal(t, "repeatuntil", node.Type())
+	assert.Equal(t, DefaultMaxIterations, node.maxIterations)
+
+	_, err = NewRepeatUntilNode("until", map[string]any{})
+	assert.ErrorContains(t, err, "missing required field 'condition'")
+}
+
+func TestRepeatUntilNode_Execute_RepeatsWhileConditionDoesNotHold(t *testing.T) {
+	node, err := NewRepeatUntilNode("until", map[string]any{"condition": "{{.variables.ready}}", "max_iterations": 3.0})
+	require.NoError(t, err)
+
+	inputs := map[string]models.NodeResult{InputPortMain: {Data: map[string]any{"status": "running"}}}
+
+	results, err := node.Execute(executionWithIteration("until", 1), inputs)
+	require.NoError(t, err)
+	require.Contains(t, results, OutputPortRepeat)
+	assert.Equal(t, map[string]any{"status": "running", "iteration": 2}, results[OutputPortRepeat].Data)
+}
+
+func TestRepeatUntilNode_Execute_DelaysNextIterationWithoutSleeping(t *testing.T) {
+	node, err := NewRepeatUntilNode("until", map[string]any{"condition": "{{.variables.ready}}", "delay": 300000.0})
+	require.NoError(t, err)
+
+	started := time.Now()
+
+	results, err := node.Execute(executionWithIteration("until", 0), map[string]models.NodeResult{})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(started), time.Second)
+
+	require.NotNil(t, results[OutputPortRepeat].NotBefore)
+	assert.WithinDuration(t, started.Add(5*time.Minute), *results[OutputPortRepeat].NotBefore, time.Second)
+}
+
+func TestRepeatUntilNode_Execute_DoneWhenConditionHolds(t *testing.T) {
+	node, err := NewRepeatUntilNode("until", map[string]any{"condition": "{{.variables.ready}}"})
+	require.NoError(t, err)
+
+	execCtx := executionWithIteration("until", 4)
+	execCtx.Variables["ready"] = true
+
+	results, err := node.Execute(execCtx, map[string]models.NodeResult{InputPortMain: {Data: map[string]any{}}})
+	require.NoError(t, err)
+	require.Contains(t, results, OutputPortDone)
+	assert.Equal(t, map[string]any{
+		"iterations":             5,
+		"condition_result":       true,
+		"max_iterations_reached": false,
+	}, results[OutputPortDone].Data)
+}
+
+func TestRepeatUntilNode_Execute_DoneWhenMaxIterationsReached(t *testing.T) {
+	node, err := NewRepeatUntilNode("until", map[string]any{"condition": "{{.variables.ready}}", "max_iterations": 3.0})
+	require.NoError(t, err)
+
+	results, err := node.Execute(executionWithIteration("until", 2), map[string]models.NodeResult{})
+	require.NoError(t, err)
+	require.Contains(t, results, OutputPortDone)
+	assert.Equal(t, true, results[OutputPortDone].Data["max_iterations_reached"])
+	assert.Equal(t, 3, results[OutputPortDone].Data["iterations"])
+}
+
+func TestRepeatUntilNode_Validate(t *testing.T) {
+	node := &RepeatUntilNode{id: "until"}
+
+	assert.NoError(t, node.Validate(map[string]any{"condition": "true", "max_iterations": 5.0, "delay": 1000.0}))
+	assert.ErrorContains(t, node.Validate(map[string]any{}), "missing required field 'condition'")
+	assert.ErrorContains(t, node.Validate(map[string]any{"condition": "true", "max_iterations": 0.0}), "max_iterations")
+	assert.ErrorContains(t, node.Validate(map[string]any{"condition": "true", "delay": -1.0}), "delay")
+}
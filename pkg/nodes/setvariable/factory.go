@@ -0,0 +1,71 @@
+// Package setvariable provides set-variable node factory for registry integration.
+package setvariable
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// SetVariableNodeFactory creates SetVariableNode instances.
+type SetVariableNodeFactory struct{}
+
+// Create creates a new SetVariableNode instance.
+func (f *SetVariableNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewSetVariableNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *SetVariableNodeFactory) ID() string {
+	return "setvariable"
+}
+
+// Name returns the factory name.
+func (f *SetVariableNodeFactory) Name() string {
+	return "Set Variable"
+}
+
+// Description returns the factory description.
+func (f *SetVariableNodeFactory) Description() string {
+	return "Writes an entry of the execution state, available to later nodes as .state.<name>"
+}
+
+// Schema returns the JSON schema for Set Variable node configuration.
+func (f *SetVariableNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name": map[string]any{
+				"type":        "string",
+				"description": "Name of the state entry, optionally prefixed with 'state.'. Workflow variables cannot be set.",
+				"examples":    []string{"counter", "state.last_order_id"},
+			},
+			"value": map[string]any{
+				"description": "Value to set, or to add with the increment operation (1 by default). Strings support templating.",
+				"examples":    []any{"{{.trigger_data.order_id}}", 5},
+			},
+			"operation": map[string]any{
+				"type":        "string",
+				"description": "Whether the value replaces the entry or is added to it",
+				"enum":        []string{OperationSet, OperationIncrement},
+				"default":     OperationSet,
+			},
+		},
+		"required": []string{"name"},
+		"examples": []map[string]any{
+			{
+				"name":  "last_order_id",
+				"value": "{{.trigger_data.order_id}}",
+			},
+			{
+				"name":      "state.counter",
+				"operation": OperationIncrement,
+			},
+		},
+	}
+}
+
+// NewSetVariableNodeFactory creates a new factory instance.
+func NewSetVariableNodeFactory() protocol.NodeFactory {
+	return &SetVariableNodeFactory{}
+}
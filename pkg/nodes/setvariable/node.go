@@ -0,0 +1,259 @@
+// Package setvariable provides a node that writes to the mutable state of an execution.
+package setvariable
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/nodes/transform"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// Operations.
+const (
+	OperationSet       = "set"
+	OperationIncrement = "increment"
+)
+
+// statePrefix may prefix the name of the state entry, as it is referenced in templates.
+const statePrefix = "state."
+
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SetVariableNode implements the Node interface for writing an entry of the execution state.
+// Workflow variables are read-only at runtime; state is the only context nodes write to.
+type SetVariableNode struct {
+	id        string
+	name      string
+	value     any
+	operation string
+}
+
+// NewSetVariableNode creates a new set-variable node.
+func NewSetVariableNode(id string, config map[string]any) (*SetVariableNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	name, _ := config["name"].(string)
+
+	operation, _ := config["operation"].(string)
+	if operation == "" {
+		operation = OperationSet
+	}
+
+	value := config["value"]
+	if value == nil && operation == OperationIncrement {
+		value = float64(1)
+	}
+
+	return &SetVariableNode{
+		id:        id,
+		name:      strings.TrimPrefix(name, statePrefix),
+		value:     value,
+		operation: operation,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *SetVariableNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *SetVariableNode) Type() string {
+	return "setvariable"
+}
+
+// Execute computes the new value of the state entry and passes the main input through along with
+// the state update, which the worker applies to the execution state.
+func (n *SetVariableNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	value, err := n.renderValue(ctx)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	if n.operation == OperationIncrement {
+		value, err = increment(ctx.State[n.name], value)
+		if err != nil {
+			return n.createErrorResult(fmt.Sprintf("failed to increment state %s: %v", n.name, err)), nil
+		}
+	}
+
+	data := make(map[string]any)
+	if input, ok := inputs[InputPortMain]; ok {
+		maps.Copy(data, input.Data)
+	}
+
+	data[models.StateUpdateDataKey], err = models.EncodeResultData(models.StateUpdate{Name: n.name, Value: value})
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// renderValue renders a string value as a template; other values are used as they are.
+func (n *SetVariableNode) renderValue(ctx models.ExecutionContext) (any, error) {
+	raw, ok := n.value.(string)
+	if !ok {
+		return n.value, nil
+	}
+
+	value, err := template.RenderWithContext(raw, &ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render value template: %w", err)
+	}
+
+	return value, nil
+}
+
+// increment adds step to the current value, which counts as zero when unset.
+func increment(current, step any) (any, error) {
+	delta, ok := transform.ToFloat(step)
+	if !ok {
+		return nil, fmt.Errorf("value %v is not a number", step)
+	}
+
+	if current == nil {
+		return delta, nil
+	}
+
+	base, ok := transform.ToFloat(current)
+	if !ok {
+		return nil, fmt.Errorf("current value %v is not a number", current)
+	}
+
+	return base + delta, nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *SetVariableNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *SetVariableNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Data passed through to the success port",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *SetVariableNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Input data with the state update that was applied",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						models.StateUpdateDataKey: map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"name":  map[string]any{"type": "string"},
+								"value": map[string]any{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the value cannot be computed",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the set-variable node.
+func (n *SetVariableNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *SetVariableNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the state entry fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	name, ok := config["name"].(string)
+	if !ok || name == "" {
+		return errors.New("missing required field 'name'")
+	}
+
+	if strings.HasPrefix(name, "variables.") {
+		return fmt.Errorf("cannot set '%s': %w", name, models.ErrVariablesImmutable)
+	}
+
+	if !namePattern.MatchString(strings.TrimPrefix(name, statePrefix)) {
+		return fmt.Errorf("invalid name '%s', expected a letter or underscore followed by letters, digits or underscores", name)
+	}
+
+	operation, _ := config["operation"].(string)
+
+	switch operation {
+	case "", OperationSet:
+		if _, exists := config["value"]; !exists {
+			return errors.New("missing required field 'value'")
+		}
+	case OperationIncrement:
+	default:
+		return fmt.Errorf("unsupported operation '%s' (supported: set, increment)", operation)
+	}
+
+	return nil
+}
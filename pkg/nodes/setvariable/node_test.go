@@ -0,0 +1,71 @@
+package setvariable
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeSetVariable(t *testing.T, config map[string]any, state map[string]any) map[string]models.NodeResult {
+	t.Helper()
+
+	node, err := NewSetVariableNode("set", config)
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{
+		TriggerData: map[string]any{"order_id": "42", "items": float64(3)},
+		Variables:   map[string]any{"api_base_url": "https://api.example.com"},
+		State:       state,
+	}, map[string]models.NodeResult{
+		InputPortMain: {NodeID: "trigger", Data: map[string]any{"order_id": "42"}},
+	})
+	require.NoError(t, err)
+
+	return results
+}
+
+func TestNewSetVariableNode_InvalidConfig(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{"value": "x"},
+		{"name": "last order", "value": "x"},
+		{"name": "counter"},
+		{"name": "counter", "operation": "multiply"},
+	}
+
+	for _, config := range invalidConfigs {
+		_, err := NewSetVariableNode("set", config)
+		assert.Error(t, err, "config %v", config)
+	}
+
+	_, err := NewSetVariableNode("set", map[string]any{"name": "variables.api_base_url", "value": "https://attacker.example.com"})
+	require.ErrorIs(t, err, models.ErrVariablesImmutable)
+}
+
+func TestSetVariableNode_Execute_Set(t *testing.T) {
+	results := executeSetVariable(t, map[string]any{"name": "state.last_order", "value": "{{.trigger_data.order_id}}"}, nil)
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok)
+	assert.Equal(t, "42", result.Data["order_id"], "the input is passed through")
+	assert.Equal(t, map[string]any{"name": "last_order", "value": float64(42)}, result.Data[models.StateUpdateDataKey])
+}
+
+func TestSetVariableNode_Execute_Increment(t *testing.T) {
+	results := executeSetVariable(t, map[string]any{"name": "counter", "operation": OperationIncrement}, nil)
+	assert.Equal(t, map[string]any{"name": "counter", "value": float64(1)}, results[OutputPortSuccess].Data[models.StateUpdateDataKey])
+
+	results = executeSetVariable(t, map[string]any{
+		"name":      "counter",
+		"operation": OperationIncrement,
+		"value":     "{{.trigger_data.items}}",
+	}, map[string]any{"counter": float64(2)})
+	assert.Equal(t, map[string]any{"name": "counter", "value": float64(5)}, results[OutputPortSuccess].Data[models.StateUpdateDataKey])
+
+	results = executeSetVariable(t, map[string]any{"name": "counter", "operation": OperationIncrement}, map[string]any{"counter": "many"})
+
+	result, ok := results[OutputPortError]
+	require.True(t, ok)
+	assert.Contains(t, result.Error, "failed to increment state counter")
+}
@@ -0,0 +1,52 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// Client sends messages to SQS queues. It is satisfied by the AWS SDK SQS client.
+type Client interface {
+	SendMessage(ctx context.Context, params *awssqs.SendMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.SendMessageOutput, error)
+}
+
+// ClientConfig holds the AWS settings of an SQS client. Empty fields fall back to the
+// default AWS configuration chain (AWS_REGION, AWS_ACCESS_KEY_ID, shared config files, ...).
+type ClientConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	EndpointURL     string
+}
+
+// NewClient creates an AWS SDK SQS client from cfg.
+func NewClient(ctx context.Context, cfg ClientConfig) (Client, error) {
+	var options []func(*config.LoadOptions) error
+
+	if cfg.Region != "" {
+		options = append(options, config.WithRegion(cfg.Region))
+	}
+
+	if cfg.AccessKeyID != "" {
+		options = append(options, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, cfg.SessionToken),
+		))
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	return awssqs.NewFromConfig(awsConfig, func(o *awssqs.Options) {
+		if cfg.EndpointURL != "" {
+			o.BaseEndpoint = aws.String(cfg.EndpointURL)
+		}
+	}), nil
+}
@@ -0,0 +1,183 @@
+// Package sqs provides SQS node factory for registry integration.
+package sqs
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// SQSNodeFactory creates SQSNode instances, sharing one client per AWS configuration.
+type SQSNodeFactory struct {
+	mu      sync.Mutex
+	clients map[ClientConfig]Client
+}
+
+// Create creates a new SQSNode instance.
+func (f *SQSNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	client, err := f.client(ctx, clientConfig(config))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSQSNode(id, config, client)
+}
+
+// clientConfig reads the AWS settings of a node configuration. Credentials are expanded from
+// environment variables, so "${SQS_SECRET_ACCESS_KEY}" keeps secrets out of workflow definitions.
+func clientConfig(config map[string]any) ClientConfig {
+	setting := func(name string) string {
+		value, _ := config[name].(string)
+
+		return os.ExpandEnv(value)
+	}
+
+	return ClientConfig{
+		Region:          setting("region"),
+		AccessKeyID:     setting("access_key_id"),
+		SecretAccessKey: setting("secret_access_key"),
+		SessionToken:    setting("session_token"),
+		EndpointURL:     setting("endpoint_url"),
+	}
+}
+
+// client returns the client for cfg, creating it on first use.
+func (f *SQSNodeFactory) client(ctx context.Context, cfg ClientConfig) (Client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if client, exists := f.clients[cfg]; exists {
+		return client, nil
+	}
+
+	client, err := NewClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	f.clients[cfg] = client
+
+	return client, nil
+}
+
+// ID returns the factory ID.
+func (f *SQSNodeFactory) ID() string {
+	return "sqs"
+}
+
+// Name returns the factory name.
+func (f *SQSNodeFactory) Name() string {
+	return "AWS SQS Send Message"
+}
+
+// Description returns the factory description.
+func (f *SQSNodeFactory) Description() string {
+	return "Enqueues a message with a templated body and attributes to an AWS SQS queue, standard or FIFO"
+}
+
+// Schema returns the JSON schema for SQS node configuration.
+func (f *SQSNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"queue_url": map[string]any{
+				"type":        "string",
+				"description": "URL of the queue to send the message to. FIFO queue URLs end with .fifo. Supports templating.",
+				"examples": []string{
+					"https://sqs.us-east-1.amazonaws.com/123456789012/image-jobs",
+					"https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo",
+				},
+			},
+			"message_body": map[string]any{
+				"type":        []string{"object", "string"},
+				"description": "Message body. Objects are sent as JSON. Supports templating. Defaults to the main input data as JSON.",
+				"examples": []any{
+					map[string]any{"image_url": "{{.trigger_data.body.url}}", "width": 320},
+					"resize {{.trigger_data.body.url}}",
+				},
+			},
+			"message_attributes": map[string]any{
+				"type":        "object",
+				"description": "Message attributes. String values are sent as String attributes and support templating; numbers are sent as Number attributes.",
+				"additionalProperties": map[string]any{
+					"type": []string{"string", "number"},
+				},
+				"examples": []map[string]any{
+					{"source": "operion", "priority": 5},
+				},
+			},
+			"message_group_id": map[string]any{
+				"type":        "string",
+				"description": "Message group of FIFO queues, required by them. Messages of a group are delivered in order. Supports templating.",
+				"examples":    []string{"{{.trigger_data.customer_id}}"},
+			},
+			"message_deduplication_id": map[string]any{
+				"type":        "string",
+				"description": "Deduplication ID of FIFO queues without content-based deduplication. Supports templating.",
+				"examples":    []string{"{{.trigger_data.order_id}}"},
+			},
+			"delay_seconds": map[string]any{
+				"type":        "integer",
+				"description": "Seconds the message stays invisible after it is sent. Not supported by FIFO queues.",
+				"minimum":     0,
+				"maximum":     maxDelaySeconds,
+			},
+			"timeout": map[string]any{
+				"type":        "number",
+				"description": "Timeout of the send in seconds",
+				"default":     defaultTimeout,
+			},
+			"region": map[string]any{
+				"type":        "string",
+				"description": "AWS region of the queue. Defaults to AWS_REGION.",
+				"examples":    []string{"us-east-1"},
+			},
+			"access_key_id": map[string]any{
+				"type":        "string",
+				"description": "AWS access key ID. Environment variables are expanded. Defaults to the AWS credential chain.",
+				"examples":    []string{"${SQS_ACCESS_KEY_ID}"},
+			},
+			"secret_access_key": map[string]any{
+				"type":        "string",
+				"description": "AWS secret access key. Environment variables are expanded.",
+				"examples":    []string{"${SQS_SECRET_ACCESS_KEY}"},
+			},
+			"session_token": map[string]any{
+				"type":        "string",
+				"description": "AWS session token for temporary credentials. Environment variables are expanded.",
+			},
+			"endpoint_url": map[string]any{
+				"type":        "string",
+				"description": "Custom SQS endpoint, e.g. for LocalStack",
+				"examples":    []string{"http://localhost:4566"},
+			},
+		},
+		"required": []string{"queue_url"},
+		"examples": []map[string]any{
+			{
+				"queue_url":          "https://sqs.us-east-1.amazonaws.com/123456789012/image-jobs",
+				"message_body":       map[string]any{"image_url": "{{.trigger_data.body.url}}", "width": 320},
+				"message_attributes": map[string]any{"source": "operion"},
+				"delay_seconds":      30,
+			},
+			{
+				"queue_url":                "https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo",
+				"message_group_id":         "{{.trigger_data.customer_id}}",
+				"message_deduplication_id": "{{.trigger_data.order_id}}",
+			},
+		},
+	}
+}
+
+// NewSQSNodeFactory creates a new factory instance.
+func NewSQSNodeFactory() protocol.NodeFactory {
+	return &SQSNodeFactory{
+		clients: make(map[ClientConfig]Client),
+	}
+}
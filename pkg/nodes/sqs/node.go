@@ -0,0 +1,444 @@
+// Package sqs provides a node that sends messages to AWS SQS queues.
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// maxDelaySeconds is the longest delay SQS accepts for a message.
+const maxDelaySeconds = 900
+
+// defaultTimeout bounds a send, in seconds, when the config sets no timeout.
+const defaultTimeout = 30
+
+// fifoQueueSuffix ends the names, and so the URLs, of FIFO queues.
+const fifoQueueSuffix = ".fifo"
+
+// SQSNode implements the Node interface for sending a message to an SQS queue.
+type SQSNode struct {
+	id                     string
+	queueURL               string
+	messageBody            any
+	messageAttributes      map[string]any
+	messageGroupID         string
+	messageDeduplicationID string
+	delaySeconds           int32
+	timeout                time.Duration
+	client                 Client
+}
+
+// NewSQSNode creates a new SQS node sending messages through client.
+func NewSQSNode(id string, config map[string]any, client Client) (*SQSNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	queueURL, _ := config["queue_url"].(string)
+	messageGroupID, _ := config["message_group_id"].(string)
+	messageDeduplicationID, _ := config["message_deduplication_id"].(string)
+	messageAttributes, _ := config["message_attributes"].(map[string]any)
+	delaySeconds, _ := delaySetting(config["delay_seconds"])
+
+	messageBody, err := bodyTemplate(config["message_body"])
+	if err != nil {
+		return nil, err
+	}
+
+	node := &SQSNode{
+		id:                     id,
+		queueURL:               queueURL,
+		messageBody:            messageBody,
+		messageAttributes:      messageAttributes,
+		messageGroupID:         messageGroupID,
+		messageDeduplicationID: messageDeduplicationID,
+		delaySeconds:           delaySeconds,
+		timeout:                defaultTimeout * time.Second,
+		client:                 client,
+	}
+
+	if timeout, ok := config["timeout"].(float64); ok {
+		node.timeout = time.Duration(timeout * float64(time.Second))
+	}
+
+	return node, nil
+}
+
+// bodyTemplate returns the message body of the config: a template, or an object whose string
+// values are templates. Objects are checked to encode as JSON.
+func bodyTemplate(body any) (any, error) {
+	switch b := body.(type) {
+	case nil, string:
+		return b, nil
+	default:
+		if _, err := json.Marshal(b); err != nil {
+			return nil, fmt.Errorf("invalid message_body: %w", err)
+		}
+
+		return b, nil
+	}
+}
+
+// delaySetting converts a delay_seconds value to seconds, reporting whether it is a whole number.
+func delaySetting(value any) (int32, bool) {
+	switch v := value.(type) {
+	case nil:
+		return 0, true
+	case int:
+		return delaySetting(float64(v))
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt32 || v > math.MaxInt32 {
+			return 0, false
+		}
+
+		return int32(v), true
+	default:
+		return 0, false
+	}
+}
+
+// isFIFOQueue reports whether queueURL is the URL of a FIFO queue.
+func isFIFOQueue(queueURL string) bool {
+	return strings.HasSuffix(queueURL, fifoQueueSuffix)
+}
+
+// ID returns the node ID.
+func (n *SQSNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *SQSNode) Type() string {
+	return "sqs"
+}
+
+// Execute sends the rendered message to the queue, with the main input data as the body when
+// no message_body is configured.
+func (n *SQSNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	queueURL, err := template.RenderStringWithContext(n.queueURL, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render queue_url template: %v", err)), nil
+	}
+
+	body, err := n.renderBody(ctx, inputs)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	attributes, err := n.renderAttributes(ctx)
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	input := &awssqs.SendMessageInput{
+		QueueUrl:          aws.String(queueURL),
+		MessageBody:       aws.String(body),
+		MessageAttributes: attributes,
+		DelaySeconds:      n.delaySeconds,
+	}
+
+	if isFIFOQueue(queueURL) {
+		if err := n.setFIFOParameters(ctx, input); err != nil {
+			return n.createErrorResult(err.Error()), nil
+		}
+	}
+
+	sendCtx, cancel := context.WithTimeout(context.Background(), n.timeout)
+	defer cancel()
+
+	output, err := n.client.SendMessage(sendCtx, input)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to send message to %s: %v", queueURL, err)), nil
+	}
+
+	data := map[string]any{
+		"message_id": aws.ToString(output.MessageId),
+		"queue_url":  queueURL,
+	}
+
+	if output.MD5OfMessageBody != nil {
+		data["md5_of_message_body"] = *output.MD5OfMessageBody
+	}
+
+	if output.SequenceNumber != nil {
+		data["sequence_number"] = *output.SequenceNumber
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// setFIFOParameters sets the rendered message group and deduplication IDs of a message sent
+// to a FIFO queue, which requires a message group and refuses per-message delays.
+func (n *SQSNode) setFIFOParameters(ctx models.ExecutionContext, input *awssqs.SendMessageInput) error {
+	if n.delaySeconds != 0 {
+		return errors.New("delay_seconds is not supported by FIFO queues")
+	}
+
+	groupID, err := template.RenderStringWithContext(n.messageGroupID, &ctx)
+	if err != nil {
+		return fmt.Errorf("failed to render message_group_id template: %w", err)
+	}
+
+	if groupID == "" {
+		return errors.New("message_group_id is required by FIFO queues")
+	}
+
+	input.MessageGroupId = aws.String(groupID)
+
+	if n.messageDeduplicationID == "" {
+		return nil
+	}
+
+	deduplicationID, err := template.RenderStringWithContext(n.messageDeduplicationID, &ctx)
+	if err != nil {
+		return fmt.Errorf("failed to render message_deduplication_id template: %w", err)
+	}
+
+	input.MessageDeduplicationId = aws.String(deduplicationID)
+
+	return nil
+}
+
+// renderBody renders the message body. The string values of an object body are rendered one
+// by one before encoding, so rendered values are never parsed as JSON and cannot add fields or
+// break the document.
+func (n *SQSNode) renderBody(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (string, error) {
+	switch b := n.messageBody.(type) {
+	case nil:
+		return mainInputBody(inputs)
+	case string:
+		if b == "" {
+			return mainInputBody(inputs)
+		}
+
+		body, err := template.RenderStringWithContext(b, &ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to render message_body template: %w", err)
+		}
+
+		if body == "" {
+			return "", errors.New("rendered message_body is empty")
+		}
+
+		return body, nil
+	default:
+		rendered, err := template.RenderValueWithContext(b, &ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to render message_body template: %w", err)
+		}
+
+		body, err := json.Marshal(rendered)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode message_body: %w", err)
+		}
+
+		return string(body), nil
+	}
+}
+
+// mainInputBody encodes the main input data, the message body when none is configured.
+func mainInputBody(inputs map[string]models.NodeResult) (string, error) {
+	input, ok := inputs[InputPortMain]
+	if !ok || input.Data == nil {
+		return "{}", nil
+	}
+
+	body, err := json.Marshal(input.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode input data: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// renderAttributes renders the message attributes, sending strings as String attributes and
+// numbers as Number attributes.
+func (n *SQSNode) renderAttributes(ctx models.ExecutionContext) (map[string]types.MessageAttributeValue, error) {
+	if len(n.messageAttributes) == 0 {
+		return nil, nil
+	}
+
+	attributes := make(map[string]types.MessageAttributeValue, len(n.messageAttributes))
+
+	for _, name := range slices.Sorted(maps.Keys(n.messageAttributes)) {
+		switch value := n.messageAttributes[name].(type) {
+		case string:
+			rendered, err := template.RenderStringWithContext(value, &ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render message attribute '%s': %w", name, err)
+			}
+
+			attributes[name] = types.MessageAttributeValue{
+				DataType:    aws.String("String"),
+				StringValue: aws.String(rendered),
+			}
+		case float64:
+			attributes[name] = types.MessageAttributeValue{
+				DataType:    aws.String("Number"),
+				StringValue: aws.String(strconv.FormatFloat(value, 'f', -1, 64)),
+			}
+		case int:
+			attributes[name] = types.MessageAttributeValue{
+				DataType:    aws.String("Number"),
+				StringValue: aws.String(strconv.Itoa(value)),
+			}
+		}
+	}
+
+	return attributes, nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *SQSNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *SQSNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Data sent as the message body when no message_body is configured",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *SQSNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Identifiers of the sent message",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"message_id":          map[string]any{"type": "string"},
+						"queue_url":           map[string]any{"type": "string"},
+						"md5_of_message_body": map[string]any{"type": "string"},
+						"sequence_number":     map[string]any{"type": "string", "description": "Set for FIFO queues"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the message cannot be sent",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the SQS node.
+func (n *SQSNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *SQSNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the message fields of a node configuration. FIFO parameters of
+// templated queue URLs are checked when the message is sent.
+func validateConfig(config map[string]any) error {
+	queueURL, ok := config["queue_url"].(string)
+	if !ok || queueURL == "" {
+		return errors.New("missing required field 'queue_url'")
+	}
+
+	delaySeconds, ok := delaySetting(config["delay_seconds"])
+	if !ok || delaySeconds < 0 || delaySeconds > maxDelaySeconds {
+		return fmt.Errorf("delay_seconds must be an integer between 0 and %d", maxDelaySeconds)
+	}
+
+	if timeout, exists := config["timeout"]; exists {
+		if value, ok := timeout.(float64); !ok || value <= 0 {
+			return errors.New("timeout must be a positive number of seconds")
+		}
+	}
+
+	if attributes, exists := config["message_attributes"]; exists {
+		attributeMap, ok := attributes.(map[string]any)
+		if !ok {
+			return errors.New("message_attributes must be an object")
+		}
+
+		for name, value := range attributeMap {
+			switch value.(type) {
+			case string, float64, int:
+			default:
+				return fmt.Errorf("message attribute '%s' must be a string or a number", name)
+			}
+		}
+	}
+
+	if isFIFOQueue(queueURL) {
+		if groupID, _ := config["message_group_id"].(string); groupID == "" {
+			return errors.New("message_group_id is required by FIFO queues")
+		}
+
+		if delaySeconds != 0 {
+			return errors.New("delay_seconds is not supported by FIFO queues")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	standardQueueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/image-jobs"
+	fifoQueueURL     = "https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo"
+)
+
+// fakeClient records sent messages and answers with a fixed output.
+type fakeClient struct {
+	output   *awssqs.SendMessageOutput
+	err      error
+	inputs   []*awssqs.SendMessageInput
+	deadline time.Time
+}
+
+func (c *fakeClient) SendMessage(ctx context.Context, params *awssqs.SendMessageInput, _ ...func(*awssqs.Options)) (*awssqs.SendMessageOutput, error) {
+	c.inputs = append(c.inputs, params)
+	c.deadline, _ = ctx.Deadline()
+
+	return c.output, c.err
+}
+
+func executeSQS(t *testing.T, config map[string]any, client Client) map[string]models.NodeResult {
+	t.Helper()
+
+	node, err := NewSQSNode("enqueue", config, client)
+	require.NoError(t, err)
+
+	ctx := models.ExecutionContext{TriggerData: map[string]any{
+		"url":         "https://example.com/cat.png",
+		"customer_id": "customer-7",
+		"order_id":    "order-42",
+		"region":      "",
+	}}
+	inputs := map[string]models.NodeResult{InputPortMain: {NodeID: "trigger", Data: map[string]any{"id": "42"}}}
+
+	results, err := node.Execute(ctx, inputs)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	return results
+}
+
+func TestNewSQSNode_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{"missing queue_url", map[string]any{}, "missing required field 'queue_url'"},
+		{"fractional delay", map[string]any{"queue_url": standardQueueURL, "delay_seconds": 1.5}, "delay_seconds must be an integer between 0 and 900"},
+		{"delay too long", map[string]any{"queue_url": standardQueueURL, "delay_seconds": float64(901)}, "delay_seconds must be an integer between 0 and 900"},
+		{"invalid timeout", map[string]any{"queue_url": standardQueueURL, "timeout": float64(0)}, "timeout must be a positive number of seconds"},
+		{"invalid attribute", map[string]any{"queue_url": standardQueueURL, "message_attributes": map[string]any{"tags": []any{"a"}}}, "message attribute 'tags' must be a string or a number"},
+		{"fifo without group", map[string]any{"queue_url": fifoQueueURL}, "message_group_id is required by FIFO queues"},
+		{"fifo with delay", map[string]any{"queue_url": fifoQueueURL, "message_group_id": "orders", "delay_seconds": float64(10)}, "delay_seconds is not supported by FIFO queues"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSQSNode("enqueue", tt.config, &fakeClient{})
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	node, err := NewSQSNode("enqueue", map[string]any{"queue_url": standardQueueURL, "delay_seconds": float64(30)}, &fakeClient{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(30), node.delaySeconds)
+	assert.Equal(t, "sqs", node.Type())
+}
+
+func TestSQSNode_Execute_Success(t *testing.T) {
+	client := &fakeClient{output: &awssqs.SendMessageOutput{
+		MessageId:        aws.String("message-1"),
+		MD5OfMessageBody: aws.String("0cc175b9c0f1b6a831c399e269772661"),
+	}}
+
+	results := executeSQS(t, map[string]any{
+		"queue_url":          standardQueueURL,
+		"message_body":       map[string]any{"image_url": "{{.trigger_data.url}}", "width": 320},
+		"message_attributes": map[string]any{"source": "operion", "customer": "{{.trigger_data.customer_id}}", "priority": float64(5)},
+		"delay_seconds":      float64(30),
+	}, client)
+
+	require.Len(t, client.inputs, 1)
+
+	input := client.inputs[0]
+	assert.Equal(t, standardQueueURL, *input.QueueUrl)
+	assert.JSONEq(t, `{"image_url": "https://example.com/cat.png", "width": 320}`, *input.MessageBody)
+	assert.Equal(t, int32(30), input.DelaySeconds)
+	assert.Nil(t, input.MessageGroupId)
+	assert.Nil(t, input.MessageDeduplicationId)
+	assert.Equal(t, map[string]types.MessageAttributeValue{
+		"source":   {DataType: aws.String("String"), StringValue: aws.String("operion")},
+		"customer": {DataType: aws.String("String"), StringValue: aws.String("customer-7")},
+		"priority": {DataType: aws.String("Number"), StringValue: aws.String("5")},
+	}, input.MessageAttributes)
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok)
+	assert.Equal(t, "message-1", result.Data["message_id"])
+	assert.Equal(t, standardQueueURL, result.Data["queue_url"])
+	assert.Equal(t, "0cc175b9c0f1b6a831c399e269772661", result.Data["md5_of_message_body"])
+	assert.NotContains(t, result.Data, "sequence_number")
+}
+
+func TestSQSNode_Execute_DefaultsBodyToInput(t *testing.T) {
+	client := &fakeClient{output: &awssqs.SendMessageOutput{MessageId: aws.String("message-1")}}
+
+	executeSQS(t, map[string]any{"queue_url": standardQueueURL}, client)
+
+	assert.JSONEq(t, `{"id": "42"}`, *client.inputs[0].MessageBody)
+	assert.Nil(t, client.inputs[0].MessageAttributes)
+}
+
+func TestSQSNode_Execute_FIFO(t *testing.T) {
+	client := &fakeClient{output: &awssqs.SendMessageOutput{
+		MessageId:      aws.String("message-1"),
+		SequenceNumber: aws.String("18849496460467696128"),
+	}}
+
+	results := executeSQS(t, map[string]any{
+		"queue_url":                fifoQueueURL,
+		"message_body":             "order {{.trigger_data.order_id}} paid",
+		"message_group_id":         "{{.trigger_data.customer_id}}",
+		"message_deduplication_id": "{{.trigger_data.order_id}}",
+	}, client)
+
+	input := client.inputs[0]
+	assert.Equal(t, "order order-42 paid", *input.MessageBody)
+	assert.Equal(t, "customer-7", *input.MessageGroupId)
+	assert.Equal(t, "order-42", *input.MessageDeduplicationId)
+	assert.Equal(t, int32(0), input.DelaySeconds)
+
+	assert.Equal(t, "18849496460467696128", results[OutputPortSuccess].Data["sequence_number"])
+}
+
+func TestSQSNode_Execute_TemplatedFIFOQueueRequiresGroup(t *testing.T) {
+	client := &fakeClient{}
+
+	results := executeSQS(t, map[string]any{
+		"queue_url":        "https://sqs.us-east-1.amazonaws.com/123456789012/{{.trigger_data.customer_id}}.fifo",
+		"message_group_id": "{{.trigger_data.region}}",
+	}, client)
+
+	result, ok := results[OutputPortError]
+	require.True(t, ok)
+	assert.Equal(t, "message_group_id is required by FIFO queues", result.Error)
+	assert.Empty(t, client.inputs)
+}
+
+func TestSQSNode_Execute_SendError(t *testing.T) {
+	client := &fakeClient{err: errors.New("AWS.SimpleQueueService.NonExistentQueue: queue does not exist")}
+
+	results := executeSQS(t, map[string]any{"queue_url": standardQueueURL}, client)
+
+	result, ok := results[OutputPortError]
+	require.True(t, ok)
+	assert.Equal(t, string(models.NodeStatusError), result.Status)
+	assert.Contains(t, result.Data["error"], "failed to send message to "+standardQueueURL)
+	assert.Contains(t, result.Error, "queue does not exist")
+	assert.Equal(t, false, result.Data["success"])
+}
+
+func TestSQSNode_Execute_RendersBodyValues(t *testing.T) {
+	client := &fakeClient{output: &awssqs.SendMessageOutput{MessageId: aws.String("message-1")}}
+
+	node, err := NewSQSNode("enqueue", map[string]any{
+		"queue_url":    standardQueueURL,
+		"message_body": map[string]any{"note": "{{.trigger_data.note}}", "count": 2},
+	}, client)
+	require.NoError(t, err)
+
+	ctx := models.ExecutionContext{TriggerData: map[string]any{"note": `x", "admin": true, "y": "`}}
+
+	results, err := node.Execute(ctx, nil)
+	require.NoError(t, err)
+	require.Contains(t, results, OutputPortSuccess)
+
+	assert.JSONEq(t, `{"note": "x\", \"admin\": true, \"y\": \"", "count": 2}`, *client.inputs[0].MessageBody)
+}
+
+func TestSQSNode_Execute_BoundsSend(t *testing.T) {
+	client := &fakeClient{output: &awssqs.SendMessageOutput{MessageId: aws.String("message-1")}}
+
+	before := time.Now()
+
+	executeSQS(t, map[string]any{"queue_url": standardQueueURL, "timeout": float64(5)}, client)
+
+	assert.WithinDuration(t, before.Add(5*time.Second), client.deadline, time.Second)
+}
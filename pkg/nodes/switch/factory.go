@@ -37,7 +37,7 @@ func (f *SwitchNodeFactory) Schema() map[string]any {
 		"properties": map[string]any{
 			"value": map[string]any{
 				"type":        "string",
-				"description": "Expression to evaluate for switch routing. Supports templating.",
+				"description": "Expression to evaluate for switch routing. Supports templating. Required unless every case defines a condition.",
 				"examples": []string{
 					`{{.variables.environment}}`,
 					`{{.node_results.api_call.status}}`,
@@ -47,7 +47,7 @@ func (f *SwitchNodeFactory) Schema() map[string]any {
 			},
 			"cases": map[string]any{
 				"type":        "array",
-				"description": "Array of case objects defining value-to-output-port mappings",
+				"description": "Ordered array of cases; execution routes to the output port of the first matching case, or to 'default' when none match",
 				"items": map[string]any{
 					"type": "object",
 					"properties": map[string]any{
@@ -55,12 +55,20 @@ func (f *SwitchNodeFactory) Schema() map[string]any {
 							"type":        "string",
 							"description": "Value to match against the evaluated expression",
 						},
+						"condition": map[string]any{
+							"type":        "string",
+							"description": "Template expression that matches the case when it evaluates to true. Used instead of 'value'.",
+							"examples": []string{
+								`{{gt .variables.amount 1000.0}}`,
+								`{{eq .trigger_data.webhook.body.type "refund"}}`,
+							},
+						},
 						"output_port": map[string]any{
 							"type":        "string",
 							"description": "Output port name to route to when this value matches",
 						},
 					},
-					"required": []string{"value", "output_port"},
+					"required": []string{"output_port"},
 				},
 				"examples": [][]map[string]any{
 					{
@@ -76,7 +84,6 @@ func (f *SwitchNodeFactory) Schema() map[string]any {
 				},
 			},
 		},
-		"required": []string{"value"},
 		"examples": []map[string]any{
 			{
 				"value": `{{.variables.deployment_env}}`,
@@ -86,6 +93,13 @@ func (f *SwitchNodeFactory) Schema() map[string]any {
 					{"value": "development", "output_port": "dev_testing"},
 				},
 			},
+			{
+				"cases": []map[string]any{
+					{"condition": `{{gt .variables.amount 10000.0}}`, "output_port": "manual_review"},
+					{"condition": `{{gt .variables.amount 1000.0}}`, "output_port": "manager_approval"},
+					{"condition": `{{gt .variables.amount 0.0}}`, "output_port": "auto_approve"},
+				},
+			},
 			{
 				"value": `{{.node_results.check_status.result}}`,
 				"cases": []map[string]any{
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/nodes/conditional"
 	"github.com/dukex/operion/pkg/template"
 )
 
@@ -16,56 +17,100 @@ const (
 )
 
 // SwitchNode implements the Node interface for multi-way branching
-// Routes execution to different output ports based on a value.
+// Routes execution to the output port of the first matching case.
 type SwitchNode struct {
 	id    string
-	value string            // Expression to evaluate
-	cases map[string]string // case_value -> output_port mapping
+	value string       // Expression to evaluate for value cases
+	cases []SwitchCase // Cases evaluated in order, first match wins
 }
 
 // SwitchCase represents a single case in the switch statement.
+// A case matches either when Value equals the evaluated switch value or
+// when its Condition expression evaluates to true.
 type SwitchCase struct {
-	Value      string `json:"value"`
+	Value      string `json:"value,omitempty"`
+	Condition  string `json:"condition,omitempty"`
 	OutputPort string `json:"output_port"`
 }
 
 // NewSwitchNode creates a new switch node.
 func NewSwitchNode(id string, config map[string]any) (*SwitchNode, error) {
-	// Parse value expression (required)
-	value, ok := config["value"].(string)
-	if !ok {
+	cases, err := parseCases(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse value expression, only optional when every case has its own condition
+	value, _ := config["value"].(string)
+	if value == "" && needsValue(cases) {
 		return nil, errors.New("missing required field 'value'")
 	}
 
-	// Parse cases
-	cases := make(map[string]string)
+	return &SwitchNode{
+		id:    id,
+		value: value,
+		cases: cases,
+	}, nil
+}
 
-	if casesConfig, ok := config["cases"].([]any); ok {
-		for i, caseAny := range casesConfig {
-			caseMap, ok := caseAny.(map[string]any)
-			if !ok {
-				return nil, fmt.Errorf("case %d must be an object", i)
-			}
+// parseCases parses the ordered list of cases from the node configuration.
+func parseCases(config map[string]any) ([]SwitchCase, error) {
+	casesConfig, ok := config["cases"].([]any)
+	if !ok {
+		return nil, nil
+	}
 
-			caseValue, ok := caseMap["value"].(string)
-			if !ok {
-				return nil, fmt.Errorf("case %d missing 'value'", i)
-			}
+	cases := make([]SwitchCase, 0, len(casesConfig))
 
-			outputPort, ok := caseMap["output_port"].(string)
-			if !ok {
-				return nil, fmt.Errorf("case %d missing 'output_port'", i)
-			}
+	for i, caseAny := range casesConfig {
+		caseMap, ok := caseAny.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("case %d must be an object", i)
+		}
+
+		caseValue, hasValue := caseMap["value"].(string)
+		condition, hasCondition := caseMap["condition"].(string)
+
+		if !hasValue && !hasCondition {
+			return nil, fmt.Errorf("case %d missing 'value' or 'condition'", i)
+		}
+
+		if hasValue && hasCondition {
+			return nil, fmt.Errorf("case %d must define only one of 'value' or 'condition'", i)
+		}
+
+		outputPort, ok := caseMap["output_port"].(string)
+		if !ok || outputPort == "" {
+			return nil, fmt.Errorf("case %d missing 'output_port'", i)
+		}
 
-			cases[caseValue] = outputPort
+		if outputPort == OutputPortDefault || outputPort == OutputPortError {
+			return nil, fmt.Errorf("case %d uses reserved output port '%s'", i, outputPort)
 		}
+
+		cases = append(cases, SwitchCase{
+			Value:      caseValue,
+			Condition:  condition,
+			OutputPort: outputPort,
+		})
 	}
 
-	return &SwitchNode{
-		id:    id,
-		value: value,
-		cases: cases,
-	}, nil
+	return cases, nil
+}
+
+// needsValue reports whether any case is matched against the switch value.
+func needsValue(cases []SwitchCase) bool {
+	if len(cases) == 0 {
+		return true
+	}
+
+	for _, c := range cases {
+		if c.Condition == "" {
+			return true
+		}
+	}
+
+	return false
 }
 
 // ID returns the node ID.
@@ -78,29 +123,46 @@ func (n *SwitchNode) Type() string {
 	return "switch"
 }
 
-// Execute evaluates the value and routes to the matching output port.
+// Execute evaluates the cases in order and routes to the first matching output port.
 func (n *SwitchNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
-	// Render the value expression using the execution context
-	result, err := template.RenderWithContext(n.value, &ctx)
-	if err != nil {
-		return n.createErrorResult(fmt.Sprintf("value evaluation failed: %v", err)), nil
+	var valueStr string
+
+	if n.value != "" {
+		// Render the value expression using the execution context
+		result, err := template.RenderWithContext(n.value, &ctx)
+		if err != nil {
+			return n.createErrorResult(fmt.Sprintf("value evaluation failed: %v", err)), nil
+		}
+
+		// Convert result to string for comparison
+		valueStr = fmt.Sprintf("%v", result)
 	}
 
-	// Convert result to string for comparison
-	valueStr := fmt.Sprintf("%v", result)
-
-	// Find matching case
-	if outputPort, exists := n.cases[valueStr]; exists {
-		return map[string]models.NodeResult{
-			outputPort: {
-				NodeID: n.id,
-				Data: map[string]any{
-					"matched_value": valueStr,
-					"output_port":   outputPort,
+	for i, c := range n.cases {
+		matched := c.Condition == "" && c.Value == valueStr
+
+		if c.Condition != "" {
+			result, err := template.RenderWithContext(c.Condition, &ctx)
+			if err != nil {
+				return n.createErrorResult(fmt.Sprintf("case %d condition evaluation failed: %v", i, err)), nil
+			}
+
+			matched = conditional.IsTrue(result)
+		}
+
+		if matched {
+			return map[string]models.NodeResult{
+				c.OutputPort: {
+					NodeID: n.id,
+					Data: map[string]any{
+						"matched_value": valueStr,
+						"matched_case":  i,
+						"output_port":   c.OutputPort,
+					},
+					Status: string(models.NodeStatusSuccess),
 				},
-				Status: string(models.NodeStatusSuccess),
-			},
-		}, nil
+			}, nil
+		}
 	}
 
 	// No match found - use default port
@@ -181,9 +243,13 @@ func (n *SwitchNode) OutputPorts() []models.OutputPort {
 		},
 	}
 
-	// Add dynamic output ports for each case
-	for _, outputPort := range n.cases {
-		if outputPort != OutputPortDefault && outputPort != OutputPortError {
+	// Add dynamic output ports for each case, in case order
+	declared := make(map[string]bool)
+
+	for _, c := range n.cases {
+		outputPort := c.OutputPort
+		if !declared[outputPort] {
+			declared[outputPort] = true
 			ports = append(ports, models.OutputPort{
 				Port: models.Port{
 					ID:          models.MakePortID(n.id, outputPort),
@@ -194,6 +260,7 @@ func (n *SwitchNode) OutputPorts() []models.OutputPort {
 						"type": "object",
 						"properties": map[string]any{
 							"matched_value": map[string]any{"type": "string"},
+							"matched_case":  map[string]any{"type": "integer"},
 							"output_port":   map[string]any{"type": "string"},
 						},
 					},
@@ -217,26 +284,13 @@ func (n *SwitchNode) InputRequirements() models.InputRequirements {
 
 // Validate validates the node configuration.
 func (n *SwitchNode) Validate(config map[string]any) error {
-	if _, ok := config["value"]; !ok {
-		return errors.New("missing required field 'value'")
+	cases, err := parseCases(config)
+	if err != nil {
+		return err
 	}
 
-	// Validate cases if provided
-	if casesConfig, ok := config["cases"].([]any); ok {
-		for i, caseAny := range casesConfig {
-			caseMap, ok := caseAny.(map[string]any)
-			if !ok {
-				return fmt.Errorf("case %d must be an object", i)
-			}
-
-			if _, ok := caseMap["value"].(string); !ok {
-				return fmt.Errorf("case %d missing 'value'", i)
-			}
-
-			if _, ok := caseMap["output_port"].(string); !ok {
-				return fmt.Errorf("case %d missing 'output_port'", i)
-			}
-		}
+	if _, ok := config["value"]; !ok && needsValue(cases) {
+		return errors.New("missing required field 'value'")
 	}
 
 	return nil
@@ -406,3 +406,113 @@ func TestSwitchNode_InputRequirements(t *testing.T) {
 		t.Errorf("Expected no timeout, got %v", requirements.Timeout)
 	}
 }
+
+func TestSwitchNode_Execute_OrderedConditions(t *testing.T) {
+	config := map[string]any{
+		"cases": []any{
+			map[string]any{"condition": "{{gt .variables.amount 10000.0}}", "output_port": "manual_review"},
+			map[string]any{"condition": "{{gt .variables.amount 1000.0}}", "output_port": "manager_approval"},
+			map[string]any{"condition": "{{gt .variables.amount 0.0}}", "output_port": "auto_approve"},
+		},
+	}
+
+	node, err := NewSwitchNode("test-switch", config)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	tests := []struct {
+		amount       float64
+		expectedPort string
+	}{
+		{amount: 50000, expectedPort: "manual_review"}, // matches every case, first one wins
+		{amount: 5000, expectedPort: "manager_approval"},
+		{amount: 10, expectedPort: "auto_approve"},
+		{amount: 0, expectedPort: OutputPortDefault},
+	}
+
+	for _, tt := range tests {
+		ctx := models.ExecutionContext{
+			NodeResults: make(map[string]models.NodeResult),
+			Variables:   map[string]any{"amount": tt.amount},
+		}
+
+		results, err := node.Execute(ctx, make(map[string]models.NodeResult))
+		if err != nil {
+			t.Fatalf("Node execution failed: %v", err)
+		}
+
+		if len(results) != 1 {
+			t.Errorf("Expected exactly one output port for amount %v, got: %d", tt.amount, len(results))
+		}
+
+		if _, ok := results[tt.expectedPort]; !ok {
+			t.Errorf("Expected amount %v to route to '%s', got: %v", tt.amount, tt.expectedPort, results)
+		}
+	}
+}
+
+func TestSwitchNode_OutputPorts_CaseOrder(t *testing.T) {
+	config := map[string]any{
+		"value": "{{.variables.status}}",
+		"cases": []any{
+			map[string]any{"value": "c", "output_port": "third"},
+			map[string]any{"value": "a", "output_port": "first"},
+			map[string]any{"value": "b", "output_port": "first"},
+		},
+	}
+
+	node, err := NewSwitchNode("test-switch", config)
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	outputPorts := node.OutputPorts()
+
+	names := make([]string, 0, len(outputPorts))
+	for _, port := range outputPorts {
+		names = append(names, port.Name)
+	}
+
+	expected := []string{OutputPortDefault, OutputPortError, "third", "first"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected ports %v, got: %v", expected, names)
+	}
+
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected ports %v, got: %v", expected, names)
+
+			break
+		}
+	}
+}
+
+func TestNewSwitchNode_InvalidConditionCases(t *testing.T) {
+	configs := []map[string]any{
+		{
+			"cases": []any{
+				map[string]any{"condition": "{{true}}", "output_port": "a"},
+				map[string]any{"value": "b", "output_port": "b"}, // value case without a value expression
+			},
+		},
+		{
+			"value": "{{.variables.status}}",
+			"cases": []any{
+				map[string]any{"value": "a", "condition": "{{true}}", "output_port": "a"},
+			},
+		},
+		{
+			"value": "{{.variables.status}}",
+			"cases": []any{
+				map[string]any{"value": "a", "output_port": OutputPortDefault},
+			},
+		},
+	}
+
+	for i, config := range configs {
+		if _, err := NewSwitchNode("test-switch", config); err == nil {
+			t.Errorf("Expected error for config %d", i)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Package terminate provides terminate node factory for registry integration.
+package terminate
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// TerminateNodeFactory creates TerminateNode instances.
+type TerminateNodeFactory struct{}
+
+// Create creates a new TerminateNode instance.
+func (f *TerminateNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewTerminateNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *TerminateNodeFactory) ID() string {
+	return "terminate"
+}
+
+// Name returns the factory name.
+func (f *TerminateNodeFactory) Name() string {
+	return "Terminate"
+}
+
+// Description returns the factory description.
+func (f *TerminateNodeFactory) Description() string {
+	return "Ends the execution immediately with a completed, failed or cancelled status, activating no further nodes"
+}
+
+// Schema returns the JSON schema for Terminate node configuration.
+func (f *TerminateNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"status": map[string]any{
+				"type":        "string",
+				"description": "Status the execution ends with",
+				"enum":        []string{"completed", "failed", "cancelled"},
+				"default":     "completed",
+			},
+			"message": map[string]any{
+				"type":        "string",
+				"description": "Why the execution ended, recorded in the execution. Supports templating.",
+				"examples":    []string{"No action needed", "Order {{.trigger_data.order_id}} is invalid"},
+			},
+		},
+		"examples": []map[string]any{
+			{
+				"status":  "completed",
+				"message": "No action needed",
+			},
+			{
+				"status":  "failed",
+				"message": "Order {{.trigger_data.order_id}} is invalid",
+			},
+		},
+	}
+}
+
+// NewTerminateNodeFactory creates a new factory instance.
+func NewTerminateNodeFactory() protocol.NodeFactory {
+	return &TerminateNodeFactory{}
+}
@@ -0,0 +1,199 @@
+// Package terminate provides a node that ends its execution early with a chosen status.
+package terminate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortTerminated = "terminated"
+	OutputPortError      = "error"
+	InputPortMain        = "main"
+)
+
+// TerminateNode implements the Node interface for steps ending the execution on purpose.
+type TerminateNode struct {
+	id      string
+	status  models.ExecutionStatus
+	message string
+}
+
+// NewTerminateNode creates a new terminate node.
+func NewTerminateNode(id string, config map[string]any) (*TerminateNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	status := models.ExecutionStatusCompleted
+	if raw, ok := config["status"].(string); ok && raw != "" {
+		status = models.ExecutionStatus(raw)
+	}
+
+	message, _ := config["message"].(string)
+
+	return &TerminateNode{
+		id:      id,
+		status:  status,
+		message: message,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *TerminateNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *TerminateNode) Type() string {
+	return "terminate"
+}
+
+// Execute renders the message and requests the termination of the execution. The worker ends the
+// execution with the configured status and activates no other node.
+func (n *TerminateNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	var message string
+
+	if n.message != "" {
+		rendered, err := template.RenderWithContext(n.message, &ctx)
+		if err != nil {
+			return n.createErrorResult(fmt.Sprintf("failed to render message: %v", err)), nil
+		}
+
+		message = fmt.Sprintf("%v", rendered)
+	}
+
+	termination, err := models.EncodeResultData(models.Termination{
+		NodeID:  n.id,
+		Status:  n.status,
+		Message: message,
+	})
+	if err != nil {
+		return n.createErrorResult(err.Error()), nil
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortTerminated: {
+			NodeID: n.id,
+			Data: map[string]any{
+				models.TerminationDataKey: termination,
+			},
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *TerminateNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *TerminateNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Input that ends the execution when received",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *TerminateNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortTerminated),
+				NodeID:      n.id,
+				Name:        OutputPortTerminated,
+				Description: "Termination recorded in the execution; never activates other nodes",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"termination": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"node_id": map[string]any{"type": "string"},
+								"status":  map[string]any{"type": "string"},
+								"message": map[string]any{"type": "string"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the message cannot be rendered",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the terminate node.
+func (n *TerminateNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *TerminateNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the status and message of a node configuration.
+func validateConfig(config map[string]any) error {
+	if raw, exists := config["status"]; exists {
+		status, ok := raw.(string)
+		if !ok {
+			return errors.New("field 'status' must be a string")
+		}
+
+		if status != "" && !models.IsTerminationStatus(models.ExecutionStatus(status)) {
+			return fmt.Errorf("invalid status '%s', expected completed, failed or cancelled", status)
+		}
+	}
+
+	if raw, exists := config["message"]; exists {
+		message, ok := raw.(string)
+		if !ok {
+			return errors.New("field 'message' must be a string")
+		}
+
+		if _, err := template.Parse(message); err != nil {
+			return fmt.Errorf("invalid 'message' template: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package terminate
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerminateNode_Execute(t *testing.T) {
+	node, err := NewTerminateNode("stop", map[string]any{
+		"status":  "failed",
+		"message": "Order {{.trigger_data.order_id}} is invalid",
+	})
+	require.NoError(t, err)
+
+	outputs, err := node.Execute(models.ExecutionContext{
+		TriggerData: map[string]any{"order_id": "42"},
+	}, map[string]models.NodeResult{InputPortMain: {Data: map[string]any{}}})
+	require.NoError(t, err)
+
+	require.Contains(t, outputs, OutputPortTerminated)
+	assert.Equal(t, map[string]any{
+		"node_id": "stop",
+		"status":  string(models.ExecutionStatusFailed),
+		"message": "Order 42 is invalid",
+	}, outputs[OutputPortTerminated].Data[models.TerminationDataKey], "the termination is stored as plain data")
+}
+
+func TestTerminateNode_DefaultsToCompleted(t *testing.T) {
+	node, err := NewTerminateNode("stop", map[string]any{})
+	require.NoError(t, err)
+
+	outputs, err := node.Execute(models.ExecutionContext{}, map[string]models.NodeResult{})
+	require.NoError(t, err)
+
+	var termination models.Termination
+	require.True(t, models.DecodeResultData(outputs[OutputPortTerminated].Data, models.TerminationDataKey, &termination))
+	assert.Equal(t, models.ExecutionStatusCompleted, termination.Status)
+	assert.Empty(t, termination.Message)
+}
+
+func TestTerminateNode_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		wantErr string
+	}{
+		{name: "cancelled", config: map[string]any{"status": "cancelled", "message": "Superseded"}},
+		{name: "running is not terminal", config: map[string]any{"status": "running"}, wantErr: "invalid status"},
+		{name: "timeout is reserved", config: map[string]any{"status": "timeout"}, wantErr: "invalid status"},
+		{name: "non-string status", config: map[string]any{"status": 1}, wantErr: "'status' must be a string"},
+		{name: "invalid template", config: map[string]any{"message": "{{.trigger_data"}, wantErr: "invalid 'message' template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTerminateNode("stop", tt.config)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
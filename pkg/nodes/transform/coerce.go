@@ -0,0 +1,186 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Target types of the coerce mode.
+const (
+	TypeString = "string"
+	TypeInt    = "int"
+	TypeFloat  = "float"
+	TypeBool   = "bool"
+	TypeDate   = "date"
+)
+
+var targetTypes = []string{TypeString, TypeInt, TypeFloat, TypeBool, TypeDate}
+
+// dateLayouts are the layouts accepted for date fields, RFC 3339 timestamps and plain dates.
+var dateLayouts = []string{time.RFC3339Nano, time.DateOnly}
+
+// coerceFields returns a copy of data with each field of types converted to its target type.
+// Fields absent from data or null are left as they are.
+func coerceFields(data map[string]any, types map[string]string) (map[string]any, error) {
+	coerced := make(map[string]any, len(data))
+	for key, value := range data {
+		coerced[key] = value
+	}
+
+	for field, targetType := range types {
+		value, ok := data[field]
+		if !ok || value == nil {
+			continue
+		}
+
+		converted, err := coerceValue(value, targetType)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %w", field, err)
+		}
+
+		coerced[field] = converted
+	}
+
+	return coerced, nil
+}
+
+// coerceValue converts value to the target type.
+func coerceValue(value any, targetType string) (any, error) {
+	if number, ok := value.(json.Number); ok {
+		value = number.String()
+	}
+
+	var (
+		converted any
+		ok        bool
+	)
+
+	switch targetType {
+	case TypeString:
+		converted, ok = coerceString(value)
+	case TypeInt:
+		converted, ok = coerceInt(value)
+	case TypeFloat:
+		converted, ok = coerceFloat(value)
+	case TypeBool:
+		converted, ok = coerceBool(value)
+	case TypeDate:
+		converted, ok = coerceDate(value)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("cannot coerce %#v to %s", value, targetType)
+	}
+
+	return converted, nil
+}
+
+func coerceString(value any) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case time.Time:
+		return v.Format(time.RFC3339Nano), true
+	}
+
+	if number, ok := ToFloat(value); ok {
+		return strconv.FormatFloat(number, 'f', -1, 64), true
+	}
+
+	return nil, false
+}
+
+func coerceInt(value any) (any, bool) {
+	if text, ok := value.(string); ok {
+		text = strings.TrimSpace(text)
+		if number, err := strconv.Atoi(text); err == nil {
+			return number, true
+		}
+
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, false
+		}
+
+		value = number
+	}
+
+	number, ok := ToFloat(value)
+	if !ok || number != math.Trunc(number) || math.Abs(number) > math.MaxInt64 {
+		return nil, false
+	}
+
+	return int(number), true
+}
+
+func coerceFloat(value any) (any, bool) {
+	if text, ok := value.(string); ok {
+		number, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+
+		return number, err == nil
+	}
+
+	return ToFloat(value)
+}
+
+func coerceBool(value any) (any, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+
+		return parsed, err == nil
+	}
+
+	// Numbers are only booleans as 0 and 1
+	number, ok := ToFloat(value)
+	if !ok || (number != 0 && number != 1) {
+		return nil, false
+	}
+
+	return number == 1, true
+}
+
+func coerceDate(value any) (any, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC(), true
+	case string:
+		for _, layout := range dateLayouts {
+			if date, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return date.UTC(), true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// ToFloat returns the value of a Go or decoded JSON number.
+func ToFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+
+	return 0, false
+}
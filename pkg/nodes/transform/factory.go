@@ -37,7 +37,7 @@ func (f *TransformNodeFactory) Schema() map[string]any {
 		"properties": map[string]any{
 			"expression": map[string]any{
 				"type":        "string",
-				"description": "Go template expression for data transformation. Has access to execution context.",
+				"description": "Go template expression for data transformation. Has access to execution context. Required in template mode.",
 				"examples": []string{
 					`{"user_id": "{{.variables.user_id}}", "status": "active"}`,
 					`{{.node_results.api_call.user_name | upper}}`,
@@ -46,8 +46,21 @@ func (f *TransformNodeFactory) Schema() map[string]any {
 					`{{.node_results.calculate.value | printf "%.2f"}}`,
 				},
 			},
+			"mode": map[string]any{
+				"type":        "string",
+				"description": "template returns the rendered expression; flatten converts the object it renders (or the main input without an expression) into dot-keyed paths such as order.items[0].sku; unflatten rebuilds the nested object; coerce converts its fields to the declared types",
+				"enum":        []string{ModeTemplate, ModeFlatten, ModeUnflatten, ModeCoerce},
+				"default":     ModeTemplate,
+			},
+			"types": map[string]any{
+				"type":        "object",
+				"description": "Target type of each field in coerce mode. Values that cannot be converted fail the node with the field name; absent and null fields are left as they are.",
+				"additionalProperties": map[string]any{
+					"type": "string",
+					"enum": targetTypes,
+				},
+			},
 		},
-		"required": []string{"expression"},
 		"examples": []map[string]any{
 			{
 				"expression": `{"full_name": "{{.variables.first_name}} {{.variables.last_name}}", "timestamp": "{{now}}"}`,
@@ -58,6 +71,13 @@ func (f *TransformNodeFactory) Schema() map[string]any {
 			{
 				"expression": `{"total": {{add .node_results.sum1.value .node_results.sum2.value}}, "count": {{len .trigger_data.items}}}`,
 			},
+			{
+				"mode": ModeFlatten,
+			},
+			{
+				"mode":  ModeCoerce,
+				"types": map[string]any{"amount": TypeInt, "paid": TypeBool, "created_at": TypeDate},
+			},
 		},
 	}
 }
@@ -4,6 +4,7 @@ package transform
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/dukex/operion/pkg/models"
 	"github.com/dukex/operion/pkg/template"
@@ -15,23 +16,47 @@ const (
 	InputPortMain     = "main"
 )
 
+// Transform modes.
+const (
+	ModeTemplate  = "template"
+	ModeFlatten   = "flatten"
+	ModeUnflatten = "unflatten"
+	ModeCoerce    = "coerce"
+)
+
 // TransformNode implements the Node interface for data transformation.
 type TransformNode struct {
 	id         string
 	expression string
+	mode       string
+	types      map[string]string
 }
 
 // NewTransformNode creates a new data transformation node.
 func NewTransformNode(id string, config map[string]any) (*TransformNode, error) {
-	// Parse expression (required)
-	expression, ok := config["expression"].(string)
-	if !ok {
-		return nil, errors.New("missing required field 'expression'")
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	expression, _ := config["expression"].(string)
+
+	mode, _ := config["mode"].(string)
+	if mode == "" {
+		mode = ModeTemplate
+	}
+
+	types := make(map[string]string)
+	if fieldTypes, ok := config["types"].(map[string]any); ok {
+		for field, targetType := range fieldTypes {
+			types[field], _ = targetType.(string)
+		}
 	}
 
 	return &TransformNode{
 		id:         id,
 		expression: expression,
+		mode:       mode,
+		types:      types,
 	}, nil
 }
 
@@ -45,12 +70,44 @@ func (n *TransformNode) Type() string {
 	return "transform"
 }
 
-// Execute performs data transformation using Go templates.
+// Execute performs data transformation using Go templates. In the flatten and unflatten modes the
+// object rendered by the expression, or the main input data without one, is converted between
+// nested and dot-keyed flat forms; in the coerce mode its fields are converted to the types
+// they are declared with.
 func (n *TransformNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
-	// Render the transformation expression using the execution context
-	result, err := template.RenderWithContext(n.expression, &ctx)
-	if err != nil {
-		return n.createErrorResult(fmt.Sprintf("transformation failed: %v", err)), nil
+	var (
+		result any
+		err    error
+	)
+
+	if n.mode == ModeTemplate || n.expression != "" {
+		// Render the transformation expression using the execution context
+		result, err = template.RenderWithContext(n.expression, &ctx)
+		if err != nil {
+			return n.createErrorResult(fmt.Sprintf("transformation failed: %v", err)), nil
+		}
+	} else {
+		result = inputs[InputPortMain].Data
+	}
+
+	if n.mode != ModeTemplate {
+		data, ok := result.(map[string]any)
+		if !ok {
+			return n.createErrorResult(fmt.Sprintf("%s expects an object, got %T", n.mode, result)), nil
+		}
+
+		switch n.mode {
+		case ModeFlatten:
+			result = template.Flatten(data)
+		case ModeUnflatten:
+			if result, err = template.Unflatten(data); err != nil {
+				return n.createErrorResult(fmt.Sprintf("unflatten failed: %v", err)), nil
+			}
+		case ModeCoerce:
+			if result, err = coerceFields(data, n.types); err != nil {
+				return n.createErrorResult(fmt.Sprintf("coerce failed: %v", err)), nil
+			}
+		}
 	}
 
 	// Success - return result on success port
@@ -140,8 +197,47 @@ func (n *TransformNode) InputRequirements() models.InputRequirements {
 
 // Validate validates the node configuration.
 func (n *TransformNode) Validate(config map[string]any) error {
-	if _, ok := config["expression"]; !ok {
-		return errors.New("missing required field 'expression'")
+	return validateConfig(config)
+}
+
+// validateConfig validates the expression and mode of a node configuration.
+func validateConfig(config map[string]any) error {
+	mode, _ := config["mode"].(string)
+
+	switch mode {
+	case "", ModeTemplate:
+		// Parse expression (required)
+		if _, ok := config["expression"].(string); !ok {
+			return errors.New("missing required field 'expression'")
+		}
+	case ModeFlatten, ModeUnflatten, ModeCoerce:
+		if expression, ok := config["expression"]; ok {
+			if _, isString := expression.(string); !isString {
+				return errors.New("field 'expression' must be a string")
+			}
+		}
+	default:
+		return fmt.Errorf("invalid mode '%s' (supported: template, flatten, unflatten, coerce)", mode)
+	}
+
+	if mode == ModeCoerce {
+		return validateTypes(config["types"])
+	}
+
+	return nil
+}
+
+// validateTypes validates the field to target type map of the coerce mode.
+func validateTypes(value any) error {
+	types, ok := value.(map[string]any)
+	if !ok || len(types) == 0 {
+		return errors.New("coerce mode requires a non-empty 'types' object mapping fields to types")
+	}
+
+	for field, targetType := range types {
+		if name, _ := targetType.(string); !slices.Contains(targetTypes, name) {
+			return fmt.Errorf("invalid type %v for field '%s' (supported: string, int, float, bool, date)", targetType, field)
+		}
 	}
 
 	return nil
@@ -1,7 +1,9 @@
 package transform
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/dukex/operion/pkg/models"
 )
@@ -278,3 +280,205 @@ func TestTransformNode_InputRequirements(t *testing.T) {
 		t.Errorf("Expected no timeout, got %v", requirements.Timeout)
 	}
 }
+
+func TestTransformNode_Execute_FlattenModes(t *testing.T) {
+	flatten, err := NewTransformNode("flatten", map[string]any{"mode": ModeFlatten})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	inputs := map[string]models.NodeResult{
+		InputPortMain: {Data: map[string]any{"order": map[string]any{"items": []any{map[string]any{"sku": "A"}}}}},
+	}
+
+	results, err := flatten.Execute(models.ExecutionContext{}, inputs)
+	if err != nil {
+		t.Fatalf("Node execution failed: %v", err)
+	}
+
+	flat, ok := results[OutputPortSuccess].Data["result"].(map[string]any)
+	if !ok || flat["order.items[0].sku"] != "A" || len(flat) != 1 {
+		t.Fatalf("Expected flattened input, got: %v", results)
+	}
+
+	// The expression may render the object to convert instead of the input
+	unflatten, err := NewTransformNode("unflatten", map[string]any{
+		"mode":       ModeUnflatten,
+		"expression": `{"order.items[0].sku": "{{.variables.sku}}"}`,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	results, err = unflatten.Execute(models.ExecutionContext{Variables: map[string]any{"sku": "B"}}, nil)
+	if err != nil {
+		t.Fatalf("Node execution failed: %v", err)
+	}
+
+	nested, _ := results[OutputPortSuccess].Data["result"].(map[string]any)
+	order, _ := nested["order"].(map[string]any)
+	items, _ := order["items"].([]any)
+
+	if len(items) != 1 || items[0].(map[string]any)["sku"] != "B" {
+		t.Fatalf("Expected unflattened expression result, got: %v", results)
+	}
+
+	// Converting anything but an object fails
+	text, err := NewTransformNode("flatten", map[string]any{"mode": ModeFlatten, "expression": "plain text"})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	results, err = text.Execute(models.ExecutionContext{}, nil)
+	if err != nil {
+		t.Fatalf("Node execution failed: %v", err)
+	}
+
+	validateErrorResult(t, results[OutputPortError])
+
+	if _, err := NewTransformNode("flatten", map[string]any{"mode": "explode"}); err == nil {
+		t.Error("Expected error for an unknown mode")
+	}
+}
+
+func TestTransformNode_Execute_OrderSummary(t *testing.T) {
+	node, err := NewTransformNode("summary", map[string]any{
+		"expression": `{"total": {{ sum "amount" .trigger_data.orders }}, "average": {{ avg "amount" .trigger_data.orders }}, ` +
+			`"by_category": {{ json (groupBy "category" .trigger_data.orders) }}}`,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	orders := []any{
+		map[string]any{"category": "books", "amount": 19.99},
+		map[string]any{"category": "games", "amount": 59.9},
+		map[string]any{"category": "books", "amount": 10.01},
+	}
+
+	results, err := node.Execute(models.ExecutionContext{TriggerData: map[string]any{"orders": orders}}, nil)
+	if err != nil {
+		t.Fatalf("Node execution failed: %v", err)
+	}
+
+	summary, _ := results[OutputPortSuccess].Data["result"].(map[string]any)
+	if summary["total"] != 89.9 || summary["average"] != 29.966666666666665 {
+		t.Errorf("Expected total 89.9 and average 29.966666666666665, got: %v", summary)
+	}
+
+	byCategory, _ := summary["by_category"].(map[string]any)
+	books, _ := byCategory["books"].([]any)
+
+	if len(byCategory) != 2 || len(books) != 2 {
+		t.Errorf("Expected orders grouped by category, got: %v", summary["by_category"])
+	}
+}
+
+func TestTransformNode_Execute_Coerce(t *testing.T) {
+	node, err := NewTransformNode("normalize", map[string]any{
+		"mode": ModeCoerce,
+		"types": map[string]any{
+			"amount":     TypeInt,
+			"paid":       TypeBool,
+			"created_at": TypeDate,
+			"price":      TypeFloat,
+			"order_id":   TypeString,
+			"coupon":     TypeString,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	inputs := map[string]models.NodeResult{
+		InputPortMain: {Data: map[string]any{
+			"amount":     "100",
+			"paid":       "true",
+			"created_at": "2025-03-01T10:30:00-03:00",
+			"price":      "19.90",
+			"order_id":   float64(42),
+			"source":     "kafka",
+		}},
+	}
+
+	results, err := node.Execute(models.ExecutionContext{}, inputs)
+	if err != nil {
+		t.Fatalf("Node execution failed: %v", err)
+	}
+
+	result, ok := results[OutputPortSuccess].Data["result"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected coerced object, got: %v", results)
+	}
+
+	if result["amount"] != 100 {
+		t.Errorf("Expected amount 100 as int, got %#v", result["amount"])
+	}
+
+	if result["paid"] != true {
+		t.Errorf("Expected paid true, got %#v", result["paid"])
+	}
+
+	createdAt, ok := result["created_at"].(time.Time)
+	if !ok || !createdAt.Equal(time.Date(2025, 3, 1, 13, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected created_at as a date, got %#v", result["created_at"])
+	}
+
+	if result["price"] != 19.9 {
+		t.Errorf("Expected price 19.9, got %#v", result["price"])
+	}
+
+	if result["order_id"] != "42" {
+		t.Errorf("Expected order_id \"42\", got %#v", result["order_id"])
+	}
+
+	if _, exists := result["coupon"]; exists {
+		t.Errorf("Expected absent coupon to stay absent, got %#v", result["coupon"])
+	}
+
+	if result["source"] != "kafka" {
+		t.Errorf("Expected undeclared fields to pass through, got %#v", result["source"])
+	}
+}
+
+func TestTransformNode_Execute_CoerceFailure(t *testing.T) {
+	node, err := NewTransformNode("normalize", map[string]any{
+		"mode":  ModeCoerce,
+		"types": map[string]any{"amount": TypeInt},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	for _, amount := range []any{"one hundred", "10.5", true} {
+		results, err := node.Execute(models.ExecutionContext{}, map[string]models.NodeResult{
+			InputPortMain: {Data: map[string]any{"amount": amount}},
+		})
+		if err != nil {
+			t.Fatalf("Node execution failed: %v", err)
+		}
+
+		errorResult, ok := results[OutputPortError]
+		if !ok {
+			t.Fatalf("Expected error port for amount %#v, got: %v", amount, results)
+		}
+
+		validateErrorResult(t, errorResult)
+
+		if message, _ := errorResult.Data["error"].(string); !strings.Contains(message, "field 'amount'") {
+			t.Errorf("Expected the error to name the field, got %q", message)
+		}
+	}
+
+	invalidConfigs := []map[string]any{
+		{"mode": ModeCoerce},
+		{"mode": ModeCoerce, "types": map[string]any{}},
+		{"mode": ModeCoerce, "types": map[string]any{"amount": "decimal"}},
+	}
+
+	for _, config := range invalidConfigs {
+		if _, err := NewTransformNode("normalize", config); err == nil {
+			t.Errorf("Expected error for config %v", config)
+		}
+	}
+}
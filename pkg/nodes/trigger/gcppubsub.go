@@ -0,0 +1,187 @@
+package trigger
+
+import (
+	"errors"
+	"maps"
+	"strings"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+const (
+	GCPPubSubInputPortExternal = "external"
+	GCPPubSubOutputPortSuccess = "success"
+	GCPPubSubOutputPortError   = "error"
+)
+
+// GCPPubSubTriggerNode implements the Node interface for Google Cloud Pub/Sub triggers.
+type GCPPubSubTriggerNode struct {
+	id     string
+	config GCPPubSubTriggerConfig
+}
+
+// GCPPubSubTriggerConfig defines the configuration for Google Cloud Pub/Sub trigger nodes.
+type GCPPubSubTriggerConfig struct {
+	ProjectID    string `json:"project_id"`
+	Subscription string `json:"subscription"`
+}
+
+// NewGCPPubSubTriggerNode creates a new Google Cloud Pub/Sub trigger node.
+func NewGCPPubSubTriggerNode(id string, config map[string]any) (*GCPPubSubTriggerNode, error) {
+	pubsubConfig := GCPPubSubTriggerConfig{}
+
+	// Parse subscription (required)
+	if subscription, ok := config["subscription"].(string); ok {
+		pubsubConfig.Subscription = subscription
+	} else {
+		return nil, errors.New("subscription is required")
+	}
+
+	// Parse project_id (required unless the subscription is a full name)
+	if projectID, ok := config["project_id"].(string); ok {
+		pubsubConfig.ProjectID = projectID
+	}
+
+	return &GCPPubSubTriggerNode{
+		id:     id,
+		config: pubsubConfig,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *GCPPubSubTriggerNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *GCPPubSubTriggerNode) Type() string {
+	return models.NodeTypeTriggerGCPPubSub
+}
+
+// Execute processes the Pub/Sub message data from external input.
+func (n *GCPPubSubTriggerNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	results := make(map[string]models.NodeResult)
+
+	// Get external input
+	externalInput, exists := inputs[GCPPubSubInputPortExternal]
+	if !exists {
+		return n.createErrorResult("external input not found"), nil
+	}
+
+	// Forward the received message along with the raw trigger data
+	data := maps.Clone(externalInput.Data)
+	if data == nil {
+		data = make(map[string]any)
+	}
+
+	data["trigger_data"] = externalInput.Data
+
+	results[GCPPubSubOutputPortSuccess] = models.NodeResult{
+		NodeID: n.id,
+		Data:   data,
+		Status: string(models.NodeStatusSuccess),
+	}
+
+	return results, nil
+}
+
+// createErrorResult creates an error result for the error output port.
+func (n *GCPPubSubTriggerNode) createErrorResult(message string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		GCPPubSubOutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   message,
+				"node_id": n.id,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  message,
+		},
+	}
+}
+
+// gcpPubSubMessageSchema describes the Pub/Sub message data received and emitted by the trigger.
+func gcpPubSubMessageSchema(description string) map[string]any {
+	return map[string]any{
+		"type":        "object",
+		"description": description,
+		"properties": map[string]any{
+			"subscription":     map[string]any{"type": "string", "description": "Full subscription name"},
+			"message_id":       map[string]any{"type": "string"},
+			"data":             map[string]any{"description": "Message data, parsed when it is JSON"},
+			"attributes":       map[string]any{"type": "object"},
+			"publish_time":     map[string]any{"type": "string", "format": "date-time"},
+			"ordering_key":     map[string]any{"type": "string"},
+			"delivery_attempt": map[string]any{"type": "integer", "description": "Set when the subscription has a dead letter policy"},
+		},
+	}
+}
+
+// InputPorts returns the input ports for the Pub/Sub trigger node.
+func (n *GCPPubSubTriggerNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, GCPPubSubInputPortExternal),
+				NodeID:      n.id,
+				Name:        GCPPubSubInputPortExternal,
+				Description: "External Pub/Sub message input",
+				Schema:      gcpPubSubMessageSchema("Pub/Sub message data from external source"),
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input requirements for the Pub/Sub trigger node.
+func (n *GCPPubSubTriggerNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{GCPPubSubInputPortExternal},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// OutputPorts returns the output ports for the Pub/Sub trigger node.
+func (n *GCPPubSubTriggerNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, GCPPubSubOutputPortSuccess),
+				NodeID:      n.id,
+				Name:        GCPPubSubOutputPortSuccess,
+				Description: "Successful Pub/Sub message processing result",
+				Schema:      gcpPubSubMessageSchema("Received Pub/Sub message"),
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, GCPPubSubOutputPortError),
+				NodeID:      n.id,
+				Name:        GCPPubSubOutputPortError,
+				Description: "Pub/Sub message processing error",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"node_id": map[string]any{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the node configuration.
+func (n *GCPPubSubTriggerNode) Validate(config map[string]any) error {
+	subscription, ok := config["subscription"].(string)
+	if !ok || subscription == "" {
+		return errors.New("subscription is required and must be a non-empty string")
+	}
+
+	if projectID, ok := config["project_id"].(string); (!ok || projectID == "") && !strings.HasPrefix(subscription, "projects/") {
+		return errors.New("project_id is required unless subscription is a full subscription name")
+	}
+
+	return nil
+}
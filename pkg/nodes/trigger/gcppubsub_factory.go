@@ -0,0 +1,65 @@
+package trigger
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// GCPPubSubTriggerNodeFactory creates GCPPubSubTriggerNode instances.
+type GCPPubSubTriggerNodeFactory struct{}
+
+// NewGCPPubSubTriggerNodeFactory creates a new Google Cloud Pub/Sub trigger node factory.
+func NewGCPPubSubTriggerNodeFactory() protocol.NodeFactory {
+	return &GCPPubSubTriggerNodeFactory{}
+}
+
+// Create creates a new GCPPubSubTriggerNode instance.
+func (f *GCPPubSubTriggerNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewGCPPubSubTriggerNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *GCPPubSubTriggerNodeFactory) ID() string {
+	return models.NodeTypeTriggerGCPPubSub
+}
+
+// Name returns the factory name.
+func (f *GCPPubSubTriggerNodeFactory) Name() string {
+	return "Google Cloud Pub/Sub Trigger"
+}
+
+// Description returns the factory description.
+func (f *GCPPubSubTriggerNodeFactory) Description() string {
+	return "Starts workflow execution on the messages of a Google Cloud Pub/Sub subscription"
+}
+
+// Schema returns the JSON schema for Pub/Sub trigger node configuration.
+func (f *GCPPubSubTriggerNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"project_id": map[string]any{
+				"type":        "string",
+				"description": "Google Cloud project of the subscription (optional when subscription is a full name)",
+				"examples":    []string{"my-project"},
+			},
+			"subscription": map[string]any{
+				"type":        "string",
+				"description": "Subscription ID or full subscription name (projects/{project}/subscriptions/{subscription})",
+				"examples":    []string{"orders-sub", "projects/my-project/subscriptions/orders-sub"},
+			},
+		},
+		"required": []string{"subscription"},
+		"examples": []map[string]any{
+			{
+				"project_id":   "my-project",
+				"subscription": "orders-sub",
+			},
+			{
+				"subscription": "projects/my-project/subscriptions/orders-sub",
+			},
+		},
+	}
+}
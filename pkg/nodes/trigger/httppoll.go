@@ -0,0 +1,210 @@
+package trigger
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+const (
+	HTTPPollInputPortExternal = "external"
+	HTTPPollOutputPortSuccess = "success"
+	HTTPPollOutputPortError   = "error"
+)
+
+// HTTPPollTriggerNode implements the Node interface for HTTP polling triggers.
+type HTTPPollTriggerNode struct {
+	id     string
+	config HTTPPollTriggerConfig
+}
+
+// HTTPPollTriggerConfig defines the configuration for HTTP polling trigger nodes.
+type HTTPPollTriggerConfig struct {
+	URL             string `json:"url"`
+	Interval        string `json:"interval"`
+	ChangeDetection bool   `json:"change_detection"`
+}
+
+// NewHTTPPollTriggerNode creates a new HTTP polling trigger node.
+func NewHTTPPollTriggerNode(id string, config map[string]any) (*HTTPPollTriggerNode, error) {
+	// Parse configuration
+	pollConfig := HTTPPollTriggerConfig{
+		Interval: "1m",
+	}
+
+	// Parse url (required)
+	if rawURL, ok := config["url"].(string); ok {
+		pollConfig.URL = rawURL
+	} else {
+		return nil, errors.New("url is required")
+	}
+
+	// Parse interval
+	if interval, ok := config["interval"].(string); ok && interval != "" {
+		pollConfig.Interval = interval
+	}
+
+	// Parse change_detection
+	if changeDetection, ok := config["change_detection"].(bool); ok {
+		pollConfig.ChangeDetection = changeDetection
+	}
+
+	return &HTTPPollTriggerNode{
+		id:     id,
+		config: pollConfig,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *HTTPPollTriggerNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *HTTPPollTriggerNode) Type() string {
+	return models.NodeTypeTriggerHTTPPoll
+}
+
+// Execute processes the polled response data from external input.
+func (n *HTTPPollTriggerNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	results := make(map[string]models.NodeResult)
+
+	// Get external input
+	externalInput, exists := inputs[HTTPPollInputPortExternal]
+	if !exists {
+		return n.createErrorResult("external input not found"), nil
+	}
+
+	// Process polled response data
+	pollData := externalInput.Data
+
+	// Create success result with polled response data
+	results[HTTPPollOutputPortSuccess] = models.NodeResult{
+		NodeID: n.id,
+		Data: map[string]any{
+			"url":          n.config.URL,
+			"status_code":  pollData["status_code"],
+			"headers":      pollData["headers"],
+			"body":         pollData["body"],
+			"polled_at":    pollData["polled_at"],
+			"trigger_data": pollData,
+		},
+		Status: string(models.NodeStatusSuccess),
+	}
+
+	return results, nil
+}
+
+// createErrorResult creates an error result for the error output port.
+func (n *HTTPPollTriggerNode) createErrorResult(message string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		HTTPPollOutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   message,
+				"node_id": n.id,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  message,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the HTTP polling trigger node.
+func (n *HTTPPollTriggerNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, HTTPPollInputPortExternal),
+				NodeID:      n.id,
+				Name:        HTTPPollInputPortExternal,
+				Description: "External polled response input",
+				Schema: map[string]any{
+					"type":        "object",
+					"description": "Polled response data from external source",
+					"properties": map[string]any{
+						"url":         map[string]any{"type": "string"},
+						"status_code": map[string]any{"type": "integer"},
+						"headers":     map[string]any{"type": "object"},
+						"body":        map[string]any{"description": "Parsed JSON body or raw text"},
+						"polled_at":   map[string]any{"type": "string", "format": "date-time"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input requirements for the HTTP polling trigger node.
+func (n *HTTPPollTriggerNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{HTTPPollInputPortExternal},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// OutputPorts returns the output ports for the HTTP polling trigger node.
+func (n *HTTPPollTriggerNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, HTTPPollOutputPortSuccess),
+				NodeID:      n.id,
+				Name:        HTTPPollOutputPortSuccess,
+				Description: "Successful polled response processing result",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"url":          map[string]any{"type": "string"},
+						"status_code":  map[string]any{"type": "integer"},
+						"headers":      map[string]any{"type": "object"},
+						"body":         map[string]any{"description": "Parsed JSON body or raw text"},
+						"polled_at":    map[string]any{"type": "string", "format": "date-time"},
+						"trigger_data": map[string]any{"type": "object"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, HTTPPollOutputPortError),
+				NodeID:      n.id,
+				Name:        HTTPPollOutputPortError,
+				Description: "Polled response processing error",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"node_id": map[string]any{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the node configuration.
+func (n *HTTPPollTriggerNode) Validate(config map[string]any) error {
+	rawURL, ok := config["url"].(string)
+	if !ok || rawURL == "" {
+		return errors.New("url is required and must be a non-empty string")
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+
+	if interval, ok := config["interval"].(string); ok && interval != "" {
+		duration, err := time.ParseDuration(interval)
+		if err != nil || duration <= 0 {
+			return errors.New("invalid interval format")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package trigger
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// HTTPPollTriggerNodeFactory creates HTTPPollTriggerNode instances.
+type HTTPPollTriggerNodeFactory struct{}
+
+// NewHTTPPollTriggerNodeFactory creates a new HTTP polling trigger node factory.
+func NewHTTPPollTriggerNodeFactory() protocol.NodeFactory {
+	return &HTTPPollTriggerNodeFactory{}
+}
+
+// Create creates a new HTTPPollTriggerNode instance.
+func (f *HTTPPollTriggerNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewHTTPPollTriggerNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *HTTPPollTriggerNodeFactory) ID() string {
+	return models.NodeTypeTriggerHTTPPoll
+}
+
+// Name returns the factory name.
+func (f *HTTPPollTriggerNodeFactory) Name() string {
+	return "HTTP Poll Trigger"
+}
+
+// Description returns the factory description.
+func (f *HTTPPollTriggerNodeFactory) Description() string {
+	return "Polls an HTTP endpoint on a fixed interval and starts workflow execution with the response"
+}
+
+// Schema returns the JSON schema for HTTP polling trigger node configuration.
+func (f *HTTPPollTriggerNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"url": map[string]any{
+				"type":        "string",
+				"description": "URL requested with GET on every poll",
+				"format":      "uri",
+				"examples": []string{
+					"https://api.example.com/orders",
+					"https://status.example.com/api/v2/status.json",
+				},
+			},
+			"interval": map[string]any{
+				"type":        "string",
+				"description": "Time between polls as a Go duration",
+				"default":     "1m",
+				"examples":    []string{"30s", "5m", "1h"},
+			},
+			"change_detection": map[string]any{
+				"type":        "boolean",
+				"description": "Only trigger when the response changes, detected by ETag, Last-Modified or content hash",
+				"default":     false,
+			},
+		},
+		"required": []string{"url"},
+		"examples": []map[string]any{
+			{
+				"url":              "https://api.example.com/orders",
+				"interval":         "5m",
+				"change_detection": true,
+			},
+			{
+				"url":      "https://status.example.com/api/v2/status.json",
+				"interval": "30s",
+			},
+		},
+	}
+}
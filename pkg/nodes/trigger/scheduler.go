@@ -190,5 +190,11 @@ func (n *SchedulerTriggerNode) Validate(config map[string]any) error {
 		}
 	}
 
+	if grace, ok := config["heartbeat_grace"].(string); ok && grace != "" {
+		if duration, err := time.ParseDuration(grace); err != nil || duration <= 0 {
+			return errors.New("heartbeat_grace must be a positive duration")
+		}
+	}
+
 	return nil
 }
@@ -62,6 +62,11 @@ func (f *SchedulerTriggerNodeFactory) Schema() map[string]any {
 					"Asia/Tokyo",
 				},
 			},
+			"heartbeat_grace": map[string]any{
+				"type":        "string",
+				"description": "Enables the dead man's switch: a workflow.heartbeat.missed event is published when the workflow has not run this long after a scheduled run",
+				"examples":    []string{"5m", "1h"},
+			},
 		},
 		"required": []string{"cron_expression"},
 		"examples": []map[string]any{
@@ -73,6 +78,10 @@ func (f *SchedulerTriggerNodeFactory) Schema() map[string]any {
 				"cron_expression": "*/30 * * * *",
 				"timezone":        "UTC",
 			},
+			{
+				"cron_expression": "0 2 * * *",
+				"heartbeat_grace": "30m",
+			},
 		},
 	}
 }
@@ -0,0 +1,209 @@
+package trigger
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+const (
+	SlackInputPortExternal = "external"
+	SlackOutputPortSuccess = "success"
+	SlackOutputPortError   = "error"
+)
+
+// slackEventTypes are the Slack event types a Slack trigger can subscribe to.
+var slackEventTypes = []string{"message", "app_mention", "interaction"}
+
+// SlackTriggerNode implements the Node interface for Slack event triggers.
+type SlackTriggerNode struct {
+	id     string
+	config SlackTriggerConfig
+}
+
+// SlackTriggerConfig defines the configuration for Slack trigger nodes.
+type SlackTriggerConfig struct {
+	SigningSecret      string   `json:"signing_secret"`
+	EventTypes         []string `json:"event_types"`
+	IncludeBotMessages bool     `json:"include_bot_messages"`
+}
+
+// NewSlackTriggerNode creates a new Slack trigger node.
+func NewSlackTriggerNode(id string, config map[string]any) (*SlackTriggerNode, error) {
+	slackConfig := SlackTriggerConfig{}
+
+	// Parse signing_secret (required)
+	if signingSecret, ok := config["signing_secret"].(string); ok {
+		slackConfig.SigningSecret = signingSecret
+	} else {
+		return nil, errors.New("signing_secret is required")
+	}
+
+	// Parse event_types
+	if eventTypes, ok := config["event_types"].([]any); ok {
+		for _, eventType := range eventTypes {
+			if value, ok := eventType.(string); ok {
+				slackConfig.EventTypes = append(slackConfig.EventTypes, value)
+			}
+		}
+	}
+
+	// Parse include_bot_messages
+	if includeBotMessages, ok := config["include_bot_messages"].(bool); ok {
+		slackConfig.IncludeBotMessages = includeBotMessages
+	}
+
+	return &SlackTriggerNode{
+		id:     id,
+		config: slackConfig,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *SlackTriggerNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *SlackTriggerNode) Type() string {
+	return models.NodeTypeTriggerSlack
+}
+
+// Execute processes the Slack event data from external input.
+func (n *SlackTriggerNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	results := make(map[string]models.NodeResult)
+
+	// Get external input
+	externalInput, exists := inputs[SlackInputPortExternal]
+	if !exists {
+		return n.createErrorResult("external input not found"), nil
+	}
+
+	// Forward the parsed Slack event along with the raw trigger data
+	data := maps.Clone(externalInput.Data)
+	if data == nil {
+		data = make(map[string]any)
+	}
+
+	data["trigger_data"] = externalInput.Data
+
+	results[SlackOutputPortSuccess] = models.NodeResult{
+		NodeID: n.id,
+		Data:   data,
+		Status: string(models.NodeStatusSuccess),
+	}
+
+	return results, nil
+}
+
+// createErrorResult creates an error result for the error output port.
+func (n *SlackTriggerNode) createErrorResult(message string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		SlackOutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   message,
+				"node_id": n.id,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  message,
+		},
+	}
+}
+
+// slackEventSchema describes the Slack event data received and emitted by the trigger.
+func slackEventSchema(description string) map[string]any {
+	return map[string]any{
+		"type":        "object",
+		"description": description,
+		"properties": map[string]any{
+			"event_type": map[string]any{"type": "string", "enum": slackEventTypes},
+			"team_id":    map[string]any{"type": "string"},
+			"api_app_id": map[string]any{"type": "string"},
+			"user":       map[string]any{"description": "User ID for events, user object for interactions"},
+			"channel":    map[string]any{"description": "Channel ID for events, channel object for interactions"},
+			"text":       map[string]any{"type": "string"},
+			"ts":         map[string]any{"type": "string"},
+			"thread_ts":  map[string]any{"type": "string"},
+			"event":      map[string]any{"type": "object", "description": "Raw Events API event"},
+			"actions":    map[string]any{"type": "array", "description": "Interaction actions"},
+			"payload":    map[string]any{"type": "object", "description": "Raw interaction payload"},
+		},
+	}
+}
+
+// InputPorts returns the input ports for the Slack trigger node.
+func (n *SlackTriggerNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, SlackInputPortExternal),
+				NodeID:      n.id,
+				Name:        SlackInputPortExternal,
+				Description: "External Slack event input",
+				Schema:      slackEventSchema("Slack event data from external source"),
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input requirements for the Slack trigger node.
+func (n *SlackTriggerNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{SlackInputPortExternal},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// OutputPorts returns the output ports for the Slack trigger node.
+func (n *SlackTriggerNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, SlackOutputPortSuccess),
+				NodeID:      n.id,
+				Name:        SlackOutputPortSuccess,
+				Description: "Successful Slack event processing result",
+				Schema:      slackEventSchema("Parsed Slack event"),
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, SlackOutputPortError),
+				NodeID:      n.id,
+				Name:        SlackOutputPortError,
+				Description: "Slack event processing error",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"node_id": map[string]any{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the node configuration.
+func (n *SlackTriggerNode) Validate(config map[string]any) error {
+	signingSecret, ok := config["signing_secret"].(string)
+	if !ok || signingSecret == "" {
+		return errors.New("signing_secret is required and must be a non-empty string")
+	}
+
+	if eventTypes, ok := config["event_types"].([]any); ok {
+		for _, eventType := range eventTypes {
+			value, ok := eventType.(string)
+			if !ok || !slices.Contains(slackEventTypes, value) {
+				return errors.New("event_types must only contain message, app_mention or interaction")
+			}
+		}
+	}
+
+	return nil
+}
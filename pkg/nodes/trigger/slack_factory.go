@@ -0,0 +1,73 @@
+package trigger
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// SlackTriggerNodeFactory creates SlackTriggerNode instances.
+type SlackTriggerNodeFactory struct{}
+
+// NewSlackTriggerNodeFactory creates a new Slack trigger node factory.
+func NewSlackTriggerNodeFactory() protocol.NodeFactory {
+	return &SlackTriggerNodeFactory{}
+}
+
+// Create creates a new SlackTriggerNode instance.
+func (f *SlackTriggerNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewSlackTriggerNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *SlackTriggerNodeFactory) ID() string {
+	return models.NodeTypeTriggerSlack
+}
+
+// Name returns the factory name.
+func (f *SlackTriggerNodeFactory) Name() string {
+	return "Slack Trigger"
+}
+
+// Description returns the factory description.
+func (f *SlackTriggerNodeFactory) Description() string {
+	return "Starts workflow execution on Slack messages, app mentions or interactive component actions"
+}
+
+// Schema returns the JSON schema for Slack trigger node configuration.
+func (f *SlackTriggerNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"signing_secret": map[string]any{
+				"type":        "string",
+				"description": "Signing secret of the Slack app, used to verify Slack requests",
+			},
+			"event_types": map[string]any{
+				"type":        "array",
+				"description": "Slack event types that trigger the workflow (default: all)",
+				"items": map[string]any{
+					"type": "string",
+					"enum": slackEventTypes,
+				},
+			},
+			"include_bot_messages": map[string]any{
+				"type":        "boolean",
+				"description": "Also trigger on messages posted by bots",
+				"default":     false,
+			},
+		},
+		"required": []string{"signing_secret"},
+		"examples": []map[string]any{
+			{
+				"signing_secret": "8f742231b10e8888abcd99yyyzzz85a5",
+				"event_types":    []string{"app_mention"},
+			},
+			{
+				"signing_secret": "8f742231b10e8888abcd99yyyzzz85a5",
+				"event_types":    []string{"interaction"},
+			},
+		},
+	}
+}
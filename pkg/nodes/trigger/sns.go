@@ -0,0 +1,177 @@
+package trigger
+
+import (
+	"errors"
+	"maps"
+	"strings"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+const (
+	SNSInputPortExternal = "external"
+	SNSOutputPortSuccess = "success"
+	SNSOutputPortError   = "error"
+)
+
+// SNSTriggerNode implements the Node interface for Amazon SNS notification triggers.
+type SNSTriggerNode struct {
+	id     string
+	config SNSTriggerConfig
+}
+
+// SNSTriggerConfig defines the configuration for SNS trigger nodes.
+type SNSTriggerConfig struct {
+	TopicARN string `json:"topic_arn"`
+}
+
+// NewSNSTriggerNode creates a new SNS trigger node.
+func NewSNSTriggerNode(id string, config map[string]any) (*SNSTriggerNode, error) {
+	snsConfig := SNSTriggerConfig{}
+
+	// Parse topic_arn (required)
+	if topicARN, ok := config["topic_arn"].(string); ok {
+		snsConfig.TopicARN = topicARN
+	} else {
+		return nil, errors.New("topic_arn is required")
+	}
+
+	return &SNSTriggerNode{
+		id:     id,
+		config: snsConfig,
+	}, nil
+}
+
+// ID returns the node ID.
+func (n *SNSTriggerNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *SNSTriggerNode) Type() string {
+	return models.NodeTypeTriggerSNS
+}
+
+// Execute processes the SNS notification data from external input.
+func (n *SNSTriggerNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	results := make(map[string]models.NodeResult)
+
+	// Get external input
+	externalInput, exists := inputs[SNSInputPortExternal]
+	if !exists {
+		return n.createErrorResult("external input not found"), nil
+	}
+
+	// Forward the parsed notification along with the raw trigger data
+	data := maps.Clone(externalInput.Data)
+	if data == nil {
+		data = make(map[string]any)
+	}
+
+	data["trigger_data"] = externalInput.Data
+
+	results[SNSOutputPortSuccess] = models.NodeResult{
+		NodeID: n.id,
+		Data:   data,
+		Status: string(models.NodeStatusSuccess),
+	}
+
+	return results, nil
+}
+
+// createErrorResult creates an error result for the error output port.
+func (n *SNSTriggerNode) createErrorResult(message string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		SNSOutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   message,
+				"node_id": n.id,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  message,
+		},
+	}
+}
+
+// snsNotificationSchema describes the SNS notification data received and emitted by the trigger.
+func snsNotificationSchema(description string) map[string]any {
+	return map[string]any{
+		"type":        "object",
+		"description": description,
+		"properties": map[string]any{
+			"topic_arn":          map[string]any{"type": "string"},
+			"message_id":         map[string]any{"type": "string"},
+			"subject":            map[string]any{"type": "string"},
+			"message":            map[string]any{"description": "Message parsed as JSON, or the message text when it is not JSON"},
+			"raw_message":        map[string]any{"type": "string"},
+			"timestamp":          map[string]any{"type": "string"},
+			"message_attributes": map[string]any{"type": "object", "description": "Message attribute values by name"},
+		},
+	}
+}
+
+// InputPorts returns the input ports for the SNS trigger node.
+func (n *SNSTriggerNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, SNSInputPortExternal),
+				NodeID:      n.id,
+				Name:        SNSInputPortExternal,
+				Description: "External SNS notification input",
+				Schema:      snsNotificationSchema("SNS notification data from external source"),
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input requirements for the SNS trigger node.
+func (n *SNSTriggerNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{SNSInputPortExternal},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// OutputPorts returns the output ports for the SNS trigger node.
+func (n *SNSTriggerNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, SNSOutputPortSuccess),
+				NodeID:      n.id,
+				Name:        SNSOutputPortSuccess,
+				Description: "Successful SNS notification processing result",
+				Schema:      snsNotificationSchema("Parsed SNS notification"),
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, SNSOutputPortError),
+				NodeID:      n.id,
+				Name:        SNSOutputPortError,
+				Description: "SNS notification processing error",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"node_id": map[string]any{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Validate validates the node configuration.
+func (n *SNSTriggerNode) Validate(config map[string]any) error {
+	topicARN, ok := config["topic_arn"].(string)
+	if !ok || !strings.HasPrefix(topicARN, "arn:") || !strings.Contains(topicARN, ":sns:") {
+		return errors.New("topic_arn is required and must be an SNS topic ARN")
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package trigger
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// SNSTriggerNodeFactory creates SNSTriggerNode instances.
+type SNSTriggerNodeFactory struct{}
+
+// NewSNSTriggerNodeFactory creates a new SNS trigger node factory.
+func NewSNSTriggerNodeFactory() protocol.NodeFactory {
+	return &SNSTriggerNodeFactory{}
+}
+
+// Create creates a new SNSTriggerNode instance.
+func (f *SNSTriggerNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewSNSTriggerNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *SNSTriggerNodeFactory) ID() string {
+	return models.NodeTypeTriggerSNS
+}
+
+// Name returns the factory name.
+func (f *SNSTriggerNodeFactory) Name() string {
+	return "Amazon SNS Trigger"
+}
+
+// Description returns the factory description.
+func (f *SNSTriggerNodeFactory) Description() string {
+	return "Starts workflow execution on the notifications of an Amazon SNS topic"
+}
+
+// Schema returns the JSON schema for SNS trigger node configuration.
+func (f *SNSTriggerNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"topic_arn": map[string]any{
+				"type":        "string",
+				"description": "ARN of the SNS topic subscribed to the SNS provider endpoint (POST /sns/notifications)",
+			},
+		},
+		"required": []string{"topic_arn"},
+		"examples": []map[string]any{
+			{
+				"topic_arn": "arn:aws:sns:us-east-1:123456789012:orders",
+			},
+		},
+	}
+}
@@ -63,6 +63,23 @@ func (f *WebhookTriggerNodeFactory) Schema() map[string]any {
 					{"X-GitHub-Event": "push", "Content-Type": "application/json"},
 				},
 			},
+			"max_body_bytes": map[string]any{
+				"type":        "integer",
+				"description": "Maximum accepted request body size in bytes; larger requests are rejected with 413",
+				"default":     1048576,
+				"minimum":     1,
+			},
+			"allowed_content_types": map[string]any{
+				"type":        "array",
+				"description": "Accepted request content types; other content types are rejected with 415. JSON bodies are decoded and validated against json_schema, form-encoded bodies are parsed into fields, text and XML are kept under raw and binary bodies are kept base64 encoded under raw",
+				"items":       map[string]any{"type": "string"},
+				"default":     []string{"application/json"},
+				"examples": [][]string{
+					{"application/json"},
+					{"application/json", "text/plain"},
+					{"application/x-www-form-urlencoded", "application/xml"},
+				},
+			},
 		},
 		"required": []string{"webhook_path"},
 		"examples": []map[string]any{
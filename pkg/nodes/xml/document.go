@@ -0,0 +1,108 @@
+package xml
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+// Keys of the document map for the attributes and text of an element.
+const (
+	attributePrefix = "@"
+	textKey         = "#text"
+)
+
+// extract evaluates an XPath expression against the document. Node sets return the text of
+// their single node, or a list of texts when several match; functions such as count() return
+// their number, string or boolean as is.
+func extract(doc *xmlquery.Node, expr *xpath.Expr) any {
+	result := expr.Evaluate(xmlquery.CreateXPathNavigator(doc))
+
+	iterator, ok := result.(*xpath.NodeIterator)
+	if !ok {
+		return result
+	}
+
+	var values []any
+	for iterator.MoveNext() {
+		values = append(values, iterator.Current().Value())
+	}
+
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		return values[0]
+	default:
+		return values
+	}
+}
+
+// toMap converts the document to a map keyed by the local name of its root element, so it can
+// be read by templates such as {{.node_results.parse.document.Envelope.Body}}.
+func toMap(doc *xmlquery.Node) (map[string]any, error) {
+	root := doc.SelectElement("*")
+	if root == nil {
+		return nil, errors.New("document has no root element")
+	}
+
+	return map[string]any{root.Data: elementValue(root)}, nil
+}
+
+// elementValue converts an element to its text when it has neither attributes nor child
+// elements, and otherwise to a map of "@"-prefixed attributes, child elements by local name
+// (a list when repeated) and its text under "#text".
+func elementValue(element *xmlquery.Node) any {
+	value := make(map[string]any)
+
+	for _, attr := range element.Attr {
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+
+		value[attributePrefix+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+
+	for child := element.FirstChild; child != nil; child = child.NextSibling {
+		switch child.Type {
+		case xmlquery.ElementNode:
+			addChild(value, child.Data, elementValue(child))
+		case xmlquery.TextNode, xmlquery.CharDataNode:
+			text.WriteString(child.Data)
+		}
+	}
+
+	trimmed := strings.TrimSpace(text.String())
+
+	if len(value) == 0 {
+		return trimmed
+	}
+
+	if trimmed != "" {
+		value[textKey] = trimmed
+	}
+
+	return value
+}
+
+// addChild adds a child element to value, turning repeated elements into a list.
+func addChild(value map[string]any, name string, child any) {
+	existing, exists := value[name]
+	if !exists {
+		value[name] = child
+
+		return
+	}
+
+	if list, ok := existing.([]any); ok {
+		value[name] = append(list, child)
+
+		return
+	}
+
+	value[name] = []any{existing, child}
+}
@@ -0,0 +1,83 @@
+// Package xml provides XML node factory for registry integration.
+package xml
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// XMLNodeFactory creates XMLNode instances.
+type XMLNodeFactory struct{}
+
+// Create creates a new XMLNode instance.
+func (f *XMLNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	return NewXMLNode(id, config)
+}
+
+// ID returns the factory ID.
+func (f *XMLNodeFactory) ID() string {
+	return "xml"
+}
+
+// Name returns the factory name.
+func (f *XMLNodeFactory) Name() string {
+	return "Parse XML"
+}
+
+// Description returns the factory description.
+func (f *XMLNodeFactory) Description() string {
+	return "Parses an XML document, such as a SOAP response, and extracts values with XPath or converts it to a map for templating"
+}
+
+// Schema returns the JSON schema for XML node configuration.
+func (f *XMLNodeFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"xml": map[string]any{
+				"type":        "string",
+				"description": "XML document to parse. Supports templating.",
+				"examples":    []string{"{{.node_results.fetch_invoice.body}}"},
+			},
+			"extract": map[string]any{
+				"type":                 "object",
+				"description":          "XPath expression per output value. A single match yields its text, several a list, none null; functions such as count() yield their result. Without extract the whole document is returned as a map, attributes prefixed with @ and mixed text under #text.",
+				"additionalProperties": map[string]any{"type": "string"},
+				"examples": []any{
+					map[string]any{"total": "//inv:Invoice/inv:Total", "currency": "//inv:Invoice/inv:Total/@currency"},
+				},
+			},
+			"namespaces": map[string]any{
+				"type":                 "object",
+				"description":          "Namespace URI per prefix used in the XPath expressions",
+				"additionalProperties": map[string]any{"type": "string"},
+				"examples": []any{
+					map[string]any{"soap": "http://schemas.xmlsoap.org/soap/envelope/", "inv": "urn:example:invoice"},
+				},
+			},
+		},
+		"required": []string{"xml"},
+		"examples": []map[string]any{
+			{
+				"xml": "{{.node_results.fetch_invoice.body}}",
+				"extract": map[string]any{
+					"total":    "/soap:Envelope/soap:Body/inv:Invoice/inv:Total",
+					"currency": "/soap:Envelope/soap:Body/inv:Invoice/inv:Total/@currency",
+				},
+				"namespaces": map[string]any{
+					"soap": "http://schemas.xmlsoap.org/soap/envelope/",
+					"inv":  "urn:example:invoice",
+				},
+			},
+			{
+				"xml": "{{.trigger_data.body}}",
+			},
+		},
+	}
+}
+
+// NewXMLNodeFactory creates a new factory instance.
+func NewXMLNodeFactory() protocol.NodeFactory {
+	return &XMLNodeFactory{}
+}
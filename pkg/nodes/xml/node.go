@@ -0,0 +1,235 @@
+// Package xml provides a node that parses XML and extracts values from it with XPath.
+package xml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+const (
+	OutputPortSuccess = "success"
+	OutputPortError   = "error"
+	InputPortMain     = "main"
+)
+
+// XMLNode implements the Node interface for parsing an XML document.
+type XMLNode struct {
+	id      string
+	xml     string
+	extract map[string]*xpath.Expr
+}
+
+// NewXMLNode creates a new XML node. The XPath expressions are compiled against the configured
+// namespace prefixes, so a bad expression fails here rather than on every execution.
+func NewXMLNode(id string, config map[string]any) (*XMLNode, error) {
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	source, _ := config["xml"].(string)
+	namespaces := stringMap(config["namespaces"])
+
+	extract := make(map[string]*xpath.Expr)
+
+	for name, expression := range stringMap(config["extract"]) {
+		compiled, err := xpath.CompileWithNS(expression, namespaces)
+		if err != nil {
+			return nil, fmt.Errorf("invalid XPath for '%s': %w", name, err)
+		}
+
+		extract[name] = compiled
+	}
+
+	return &XMLNode{
+		id:      id,
+		xml:     source,
+		extract: extract,
+	}, nil
+}
+
+// stringMap converts a config object of strings, as validated by validateConfig.
+func stringMap(value any) map[string]string {
+	object, _ := value.(map[string]any)
+	result := make(map[string]string, len(object))
+
+	for key, item := range object {
+		result[key], _ = item.(string)
+	}
+
+	return result
+}
+
+// ID returns the node ID.
+func (n *XMLNode) ID() string {
+	return n.id
+}
+
+// Type returns the node type.
+func (n *XMLNode) Type() string {
+	return "xml"
+}
+
+// Execute renders and parses the XML. With extract configured, the result holds the value of
+// each XPath expression under its name; otherwise it holds the whole document as a map.
+func (n *XMLNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	rendered, err := template.RenderStringWithContext(n.xml, &ctx)
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("failed to render xml template: %v", err)), nil
+	}
+
+	if strings.TrimSpace(rendered) == "" {
+		return n.createErrorResult("xml is empty"), nil
+	}
+
+	doc, err := xmlquery.Parse(strings.NewReader(rendered))
+	if err != nil {
+		return n.createErrorResult(fmt.Sprintf("invalid XML: %v", err)), nil
+	}
+
+	data := make(map[string]any)
+
+	if len(n.extract) > 0 {
+		values := make(map[string]any, len(n.extract))
+		for name, expr := range n.extract {
+			values[name] = extract(doc, expr)
+		}
+
+		data["values"] = values
+	} else {
+		document, err := toMap(doc)
+		if err != nil {
+			return n.createErrorResult(fmt.Sprintf("invalid XML: %v", err)), nil
+		}
+
+		data["document"] = document
+	}
+
+	return map[string]models.NodeResult{
+		OutputPortSuccess: {
+			NodeID: n.id,
+			Data:   data,
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+// createErrorResult creates a NodeResult for the error output port.
+func (n *XMLNode) createErrorResult(errorMessage string) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.id,
+			Data: map[string]any{
+				"error":   errorMessage,
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}
+}
+
+// InputPorts returns the input ports for the node.
+func (n *XMLNode) InputPorts() []models.InputPort {
+	return []models.InputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, InputPortMain),
+				NodeID:      n.id,
+				Name:        InputPortMain,
+				Description: "Triggers parsing; the XML is read from the execution context through its template",
+			},
+		},
+	}
+}
+
+// OutputPorts returns the output ports for the node.
+func (n *XMLNode) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortSuccess),
+				NodeID:      n.id,
+				Name:        OutputPortSuccess,
+				Description: "Extracted values by name, or the whole document as a map when nothing is extracted",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"values":   map[string]any{"type": "object"},
+						"document": map[string]any{"type": "object"},
+					},
+				},
+			},
+		},
+		{
+			Port: models.Port{
+				ID:          models.MakePortID(n.id, OutputPortError),
+				NodeID:      n.id,
+				Name:        OutputPortError,
+				Description: "Error information when the XML cannot be rendered or is malformed",
+				Schema: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"error":   map[string]any{"type": "string"},
+						"success": map[string]any{"type": "boolean"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InputRequirements returns the input coordination requirements for the XML node.
+func (n *XMLNode) InputRequirements() models.InputRequirements {
+	return models.InputRequirements{
+		RequiredPorts: []string{InputPortMain},
+		OptionalPorts: []string{},
+		WaitMode:      models.WaitModeAll,
+		Timeout:       nil,
+	}
+}
+
+// Validate validates the node configuration.
+func (n *XMLNode) Validate(config map[string]any) error {
+	return validateConfig(config)
+}
+
+// validateConfig validates the fields of a node configuration.
+func validateConfig(config map[string]any) error {
+	if source, ok := config["xml"].(string); !ok || source == "" {
+		return errors.New("missing required field 'xml'")
+	}
+
+	for _, field := range []string{"extract", "namespaces"} {
+		value, exists := config[field]
+		if !exists {
+			continue
+		}
+
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s must be an object of strings", field)
+		}
+
+		for key, item := range object {
+			if text, ok := item.(string); !ok || text == "" {
+				return fmt.Errorf("%s '%s' must be a non-empty string", field, key)
+			}
+		}
+	}
+
+	namespaces := stringMap(config["namespaces"])
+
+	for name, expression := range stringMap(config["extract"]) {
+		if _, err := xpath.CompileWithNS(expression, namespaces); err != nil {
+			return fmt.Errorf("invalid XPath for '%s': %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package xml
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const soapResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:inv="urn:example:invoice">
+  <soap:Body>
+    <inv:GetInvoiceResponse>
+      <inv:Invoice id="INV-1001">
+        <inv:Customer>ACME Corp</inv:Customer>
+        <inv:Line sku="A-1">2</inv:Line>
+        <inv:Line sku="B-2">5</inv:Line>
+        <inv:Total currency="EUR">149.90</inv:Total>
+      </inv:Invoice>
+    </inv:GetInvoiceResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+var soapNamespaces = map[string]any{
+	"soap": "http://schemas.xmlsoap.org/soap/envelope/",
+	"inv":  "urn:example:invoice",
+}
+
+func executeXML(t *testing.T, config map[string]any, body string) map[string]models.NodeResult {
+	t.Helper()
+
+	node, err := NewXMLNode("parse", config)
+	require.NoError(t, err)
+
+	results, err := node.Execute(models.ExecutionContext{
+		NodeResults: map[string]models.NodeResult{
+			"fetch_invoice": {NodeID: "fetch_invoice", Data: map[string]any{"body": body}},
+		},
+	}, map[string]models.NodeResult{
+		InputPortMain: {NodeID: "fetch_invoice", Data: map[string]any{}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	return results
+}
+
+func TestNewXMLNode_InvalidConfig(t *testing.T) {
+	invalidConfigs := []map[string]any{
+		{},
+		{"xml": ""},
+		{"xml": "{{.trigger_data.body}}", "extract": "//Total"},
+		{"xml": "{{.trigger_data.body}}", "extract": map[string]any{"total": 42}},
+		{"xml": "{{.trigger_data.body}}", "extract": map[string]any{"total": "//Total["}},
+		{"xml": "{{.trigger_data.body}}", "namespaces": map[string]any{"inv": ""}},
+	}
+
+	for _, config := range invalidConfigs {
+		_, err := NewXMLNode("parse", config)
+		assert.Error(t, err, "config %v", config)
+	}
+}
+
+func TestXMLNode_Execute_ExtractSOAPResponse(t *testing.T) {
+	results := executeXML(t, map[string]any{
+		"xml": "{{.node_results.fetch_invoice.body}}",
+		"extract": map[string]any{
+			"customer":   "/soap:Envelope/soap:Body/inv:GetInvoiceResponse/inv:Invoice/inv:Customer",
+			"total":      "//inv:Invoice/inv:Total",
+			"currency":   "//inv:Invoice/inv:Total/@currency",
+			"invoice_id": "//inv:Invoice/@id",
+			"skus":       "//inv:Line/@sku",
+			"line_count": "count(//inv:Line)",
+			"missing":    "//inv:Discount",
+		},
+		"namespaces": soapNamespaces,
+	}, soapResponse)
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok)
+	assert.Equal(t, string(models.NodeStatusSuccess), result.Status)
+
+	assert.Equal(t, map[string]any{
+		"customer":   "ACME Corp",
+		"total":      "149.90",
+		"currency":   "EUR",
+		"invoice_id": "INV-1001",
+		"skus":       []any{"A-1", "B-2"},
+		"line_count": float64(2),
+		"missing":    nil,
+	}, result.Data["values"])
+	assert.NotContains(t, result.Data, "document")
+}
+
+func TestXMLNode_Execute_Document(t *testing.T) {
+	results := executeXML(t, map[string]any{
+		"xml": "{{.node_results.fetch_invoice.body}}",
+	}, soapResponse)
+
+	result, ok := results[OutputPortSuccess]
+	require.True(t, ok)
+
+	document, ok := result.Data["document"].(map[string]any)
+	require.True(t, ok)
+
+	envelope := document["Envelope"].(map[string]any)
+	body := envelope["Body"].(map[string]any)
+	invoice := body["GetInvoiceResponse"].(map[string]any)["Invoice"].(map[string]any)
+
+	assert.Equal(t, "INV-1001", invoice["@id"])
+	assert.Equal(t, "ACME Corp", invoice["Customer"])
+	assert.Equal(t, []any{
+		map[string]any{"@sku": "A-1", "#text": "2"},
+		map[string]any{"@sku": "B-2", "#text": "5"},
+	}, invoice["Line"])
+	assert.Equal(t, map[string]any{"@currency": "EUR", "#text": "149.90"}, invoice["Total"])
+	assert.NotContains(t, envelope, "@soap", "namespace declarations are not attributes")
+}
+
+func TestXMLNode_Execute_MalformedXML(t *testing.T) {
+	config := map[string]any{
+		"xml":     "{{.node_results.fetch_invoice.body}}",
+		"extract": map[string]any{"total": "//Total"},
+	}
+
+	for _, body := range []string{
+		"<Invoice><Total>149.90</Invoice>",
+		"",
+		"not xml at all",
+	} {
+		results := executeXML(t, config, body)
+
+		result, ok := results[OutputPortError]
+		require.True(t, ok, "body %q", body)
+		assert.Equal(t, string(models.NodeStatusError), result.Status)
+		assert.Equal(t, false, result.Data["success"])
+		assert.NotEmpty(t, result.Error)
+	}
+}
@@ -0,0 +1,77 @@
+package payloads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileStore stores payloads as files under a directory.
+type FileStore struct {
+	root string
+}
+
+// NewFileStore creates a store writing payloads under root.
+func NewFileStore(root string) *FileStore {
+	return &FileStore{root: filepath.Clean(root)}
+}
+
+// Put writes data to the file of key.
+func (s *FileStore) Put(ctx context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create payload directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write payload: %w", err)
+	}
+
+	return nil
+}
+
+// Get reads the file of key.
+func (s *FileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payload: %w", err)
+	}
+
+	return data, nil
+}
+
+// DeletePrefix deletes the directory of prefix, such as "executions/{id}/".
+func (s *FileStore) DeletePrefix(ctx context.Context, prefix string) error {
+	path, err := s.path(prefix)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete payloads: %w", err)
+	}
+
+	return nil
+}
+
+// path returns the path of key, refusing keys escaping the root directory.
+func (s *FileStore) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, s.root+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid payload key '%s'", key)
+	}
+
+	return path, nil
+}
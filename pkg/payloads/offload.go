@@ -0,0 +1,163 @@
+package payloads
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// DefaultThreshold is the JSON size in bytes above which payloads are offloaded.
+const DefaultThreshold = 256 * 1024
+
+// Offloader moves payloads larger than a threshold to a store, replacing them by a reference.
+type Offloader struct {
+	store     Store
+	threshold int
+}
+
+// NewOffloader creates an offloader writing payloads of more than threshold bytes of JSON to
+// store. A threshold of 0 or less uses DefaultThreshold.
+func NewOffloader(store Store, threshold int) *Offloader {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+
+	return &Offloader{store: store, threshold: threshold}
+}
+
+// New creates an offloader on the store of rawURL (see NewStore).
+func New(ctx context.Context, rawURL string, threshold int) (*Offloader, error) {
+	store, err := NewStore(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOffloader(store, threshold), nil
+}
+
+// Offload writes payload to the store as the object name of the execution when its JSON is
+// larger than the threshold, returning the reference of the object. Smaller payloads are kept
+// inline, returning an empty reference.
+func (o *Offloader) Offload(ctx context.Context, executionID, name string, payload map[string]any) (string, error) {
+	if payload == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	if len(data) <= o.threshold {
+		return "", nil
+	}
+
+	ref := executionPrefix(executionID) + url.PathEscape(name) + ".json"
+
+	if err := o.store.Put(ctx, ref, data); err != nil {
+		return "", err
+	}
+
+	return ref, nil
+}
+
+// Resolve returns the payload ref points to. Only the payloads of the execution can be
+// resolved, so an execution never reads the payloads of another one.
+func (o *Offloader) Resolve(ctx context.Context, executionID, ref string) (map[string]any, error) {
+	if path.Clean(ref) != ref || path.Dir(ref)+"/" != executionPrefix(executionID) {
+		return nil, fmt.Errorf("%w: %s", ErrForeignRef, ref)
+	}
+
+	data, err := o.store.Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolved map[string]any
+	if err := json.Unmarshal(data, &resolved); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload %s: %w", ref, err)
+	}
+
+	return resolved, nil
+}
+
+// Resolver returns a resolver loading the payloads of an execution under ctx, for
+// ExecutionContext.ResolvePayload.
+func (o *Offloader) Resolver(ctx context.Context, executionID string) func(ref string) (map[string]any, error) {
+	return func(ref string) (map[string]any, error) {
+		return o.Resolve(ctx, executionID, ref)
+	}
+}
+
+// OffloadResult returns result with its data offloaded as the object key of the execution when
+// it is too large to be stored inline.
+func (o *Offloader) OffloadResult(ctx context.Context, executionID, key string, result models.NodeResult) (models.NodeResult, error) {
+	if result.PayloadRef != "" {
+		return result, nil
+	}
+
+	ref, err := o.Offload(ctx, executionID, key, result.Data)
+	if err != nil || ref == "" {
+		return result, err
+	}
+
+	result.Data = nil
+	result.PayloadRef = ref
+
+	return result, nil
+}
+
+// ResolveResult returns result with its offloaded data loaded back inline.
+func (o *Offloader) ResolveResult(ctx context.Context, executionID string, result models.NodeResult) (models.NodeResult, error) {
+	if result.PayloadRef == "" {
+		return result, nil
+	}
+
+	data, err := o.Resolve(ctx, executionID, result.PayloadRef)
+	if err != nil {
+		return result, err
+	}
+
+	result.Data = data
+	result.PayloadRef = ""
+
+	return result, nil
+}
+
+// ResolveExecution loads the offloaded trigger data and node results of execution back inline.
+func (o *Offloader) ResolveExecution(ctx context.Context, execution *models.ExecutionContext) error {
+	if execution.TriggerDataRef != "" {
+		triggerData, err := o.Resolve(ctx, execution.ID, execution.TriggerDataRef)
+		if err != nil {
+			return fmt.Errorf("failed to resolve trigger data: %w", err)
+		}
+
+		execution.TriggerData = triggerData
+		execution.TriggerDataRef = ""
+	}
+
+	for key, result := range execution.NodeResults {
+		resolved, err := o.ResolveResult(ctx, execution.ID, result)
+		if err != nil {
+			return fmt.Errorf("failed to resolve result %s: %w", key, err)
+		}
+
+		execution.NodeResults[key] = resolved
+	}
+
+	return nil
+}
+
+// DeleteExecution deletes the payloads offloaded by an execution.
+func (o *Offloader) DeleteExecution(ctx context.Context, executionID string) error {
+	return o.store.DeletePrefix(ctx, executionPrefix(executionID))
+}
+
+// executionPrefix is the key prefix of the payloads of an execution.
+func executionPrefix(executionID string) string {
+	return "executions/" + url.PathEscape(executionID) + "/"
+}
@@ -0,0 +1,240 @@
+package payloads
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func largePayload(size int) map[string]any {
+	return map[string]any{"body": strings.Repeat("x", size)}
+}
+
+func TestOffloader_OffloadsPayloadsAboveThreshold(t *testing.T) {
+	ctx := context.Background()
+	offloader := NewOffloader(NewFileStore(t.TempDir()), 1024)
+
+	payload := largePayload(2048)
+
+	ref, err := offloader.Offload(ctx, "exec-1", "trigger_data", payload)
+	require.NoError(t, err)
+	assert.Equal(t, "executions/exec-1/trigger_data.json", ref)
+
+	resolved, err := offloader.Resolve(ctx, "exec-1", ref)
+	require.NoError(t, err)
+	assert.Equal(t, payload, resolved)
+}
+
+func TestOffloader_KeepsSmallPayloadsInline(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	offloader := NewOffloader(NewFileStore(root), 1024)
+
+	payload := largePayload(10)
+
+	ref, err := offloader.Offload(ctx, "exec-1", "trigger_data", payload)
+	require.NoError(t, err)
+	assert.Empty(t, ref)
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestOffloader_OffloadResult(t *testing.T) {
+	ctx := context.Background()
+	offloader := NewOffloader(NewFileStore(t.TempDir()), 1024)
+
+	result := models.NodeResult{NodeID: "fetch", Data: largePayload(2048), Status: string(models.NodeStatusSuccess)}
+
+	stored, err := offloader.OffloadResult(ctx, "exec-1", "fetch::success", result)
+	require.NoError(t, err)
+	assert.Nil(t, stored.Data)
+	assert.Equal(t, "executions/exec-1/fetch::success.json", stored.PayloadRef)
+
+	// Offloaded results are not offloaded again
+	again, err := offloader.OffloadResult(ctx, "exec-1", "fetch::success", stored)
+	require.NoError(t, err)
+	assert.Equal(t, stored, again)
+
+	resolved, err := offloader.ResolveResult(ctx, "exec-1", stored)
+	require.NoError(t, err)
+	assert.Equal(t, result, resolved)
+}
+
+func TestOffloader_DataShapedLikeReferencesStaysInline(t *testing.T) {
+	ctx := context.Background()
+	offloader := NewOffloader(NewFileStore(t.TempDir()), 1024)
+
+	_, err := offloader.Offload(ctx, "exec-2", "trigger_data", largePayload(2048))
+	require.NoError(t, err)
+
+	// Data carrying a reference is plain data, only PayloadRef is resolved
+	execution := &models.ExecutionContext{
+		ID:          "exec-1",
+		TriggerData: map[string]any{"$ref": "executions/exec-2/trigger_data.json"},
+	}
+
+	require.NoError(t, offloader.ResolveExecution(ctx, execution))
+	assert.Equal(t, map[string]any{"$ref": "executions/exec-2/trigger_data.json"}, execution.TriggerData)
+}
+
+func TestOffloader_RejectsReferencesOfOtherExecutions(t *testing.T) {
+	ctx := context.Background()
+	offloader := NewOffloader(NewFileStore(t.TempDir()), 1024)
+
+	ref, err := offloader.Offload(ctx, "exec-2", "trigger_data", largePayload(2048))
+	require.NoError(t, err)
+
+	for _, foreign := range []string{
+		ref,
+		"executions/exec-1/../exec-2/trigger_data.json",
+		"executions/exec-1/nested/trigger_data.json",
+		"executions/exec-10/trigger_data.json",
+		"executions/exec-1/",
+	} {
+		_, err := offloader.Resolve(ctx, "exec-1", foreign)
+		require.ErrorIs(t, err, ErrForeignRef, foreign)
+	}
+
+	execution := &models.ExecutionContext{ID: "exec-1", TriggerDataRef: ref}
+	require.ErrorIs(t, offloader.ResolveExecution(ctx, execution), ErrForeignRef)
+}
+
+func TestOffloader_DeleteExecution(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	offloader := NewOffloader(NewFileStore(root), 1024)
+
+	deleted, err := offloader.Offload(ctx, "exec-1", "node:success", largePayload(2048))
+	require.NoError(t, err)
+
+	kept, err := offloader.Offload(ctx, "exec-2", "node:success", largePayload(2048))
+	require.NoError(t, err)
+
+	require.NoError(t, offloader.DeleteExecution(ctx, "exec-1"))
+
+	_, err = offloader.Resolve(ctx, "exec-1", deleted)
+	require.Error(t, err)
+
+	_, err = offloader.Resolve(ctx, "exec-2", kept)
+	require.NoError(t, err)
+
+	// Deleting an execution without offloaded payloads is not an error
+	require.NoError(t, offloader.DeleteExecution(ctx, "exec-3"))
+}
+
+func TestFileStore_RejectsKeysOutsideRoot(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	_, err := store.Get(context.Background(), "../secret.json")
+	require.Error(t, err)
+
+	err = store.Put(context.Background(), "../escape.json", []byte(`{}`))
+	require.Error(t, err)
+
+	require.NoError(t, store.Put(context.Background(), "executions/exec-1/trigger_data.json", []byte(`{}`)))
+	assert.FileExists(t, filepath.Join(store.root, "executions", "exec-1", "trigger_data.json"))
+}
+
+func TestNewStore(t *testing.T) {
+	store, err := NewStore(context.Background(), "file://"+t.TempDir())
+	require.NoError(t, err)
+	assert.IsType(t, &FileStore{}, store)
+
+	_, err = NewStore(context.Background(), "ftp://host/payloads")
+	require.ErrorIs(t, err, ErrUnsupportedStore)
+
+	_, err = NewStore(context.Background(), "s3:///prefix")
+	require.Error(t, err)
+}
+
+// fakeS3 keeps objects in memory and lists them a page of pageSize objects at a time.
+type fakeS3 struct {
+	objects  map[string][]byte
+	pageSize int
+}
+
+func (f *fakeS3) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	f.objects[aws.ToString(params.Key)] = data
+
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	data, ok := f.objects[aws.ToString(params.Key)]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (f *fakeS3) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	output := &s3.ListObjectsV2Output{}
+
+	for key := range f.objects {
+		if !strings.HasPrefix(key, aws.ToString(params.Prefix)) {
+			continue
+		}
+
+		if len(output.Contents) == f.pageSize {
+			output.IsTruncated = aws.Bool(true)
+
+			break
+		}
+
+		output.Contents = append(output.Contents, types.Object{Key: aws.String(key)})
+	}
+
+	return output, nil
+}
+
+func (f *fakeS3) DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+	for _, object := range params.Delete.Objects {
+		delete(f.objects, aws.ToString(object.Key))
+	}
+
+	return &s3.DeleteObjectsOutput{}, nil
+}
+
+func TestS3Store(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeS3{objects: map[string][]byte{"other/object.json": []byte(`{}`)}, pageSize: 1}
+	offloader := NewOffloader(&S3Store{client: client, bucket: "bucket", prefix: "operion"}, 1024)
+
+	payload := largePayload(2048)
+
+	ref, err := offloader.Offload(ctx, "exec-1", "trigger_data", payload)
+	require.NoError(t, err)
+	assert.Contains(t, client.objects, "operion/"+ref)
+
+	_, err = offloader.Offload(ctx, "exec-1", "node:success", payload)
+	require.NoError(t, err)
+
+	resolved, err := offloader.Resolve(ctx, "exec-1", ref)
+	require.NoError(t, err)
+	assert.Equal(t, payload, resolved)
+
+	_, err = offloader.Resolve(ctx, "exec-1", "other/object.json")
+	require.ErrorIs(t, err, ErrForeignRef)
+
+	require.NoError(t, offloader.DeleteExecution(ctx, "exec-1"))
+	assert.Equal(t, map[string][]byte{"other/object.json": []byte(`{}`)}, client.objects)
+}
@@ -0,0 +1,118 @@
+package payloads
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// s3API is the part of the S3 client used by S3Store.
+type s3API interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
+}
+
+// S3Store stores payloads as objects of an S3 bucket, referenced by s3:// URIs.
+type S3Store struct {
+	client s3API
+	bucket string
+	prefix string
+}
+
+// NewS3Store creates a store writing payloads to bucket under prefix, configured from the AWS
+// environment.
+func NewS3Store(ctx context.Context, bucket, prefix string) (*S3Store, error) {
+	awsConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	return &S3Store{client: s3.NewFromConfig(awsConfig), bucket: bucket, prefix: prefix}, nil
+}
+
+// Put writes data to the object of key.
+func (s *S3Store) Put(ctx context.Context, key string, data []byte) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(s.objectKey(key)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write payload to s3: %w", err)
+	}
+
+	return nil
+}
+
+// Get reads the object of key.
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payload from s3: %w", err)
+	}
+	defer output.Body.Close()
+
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payload from s3: %w", err)
+	}
+
+	return data, nil
+}
+
+// DeletePrefix deletes every object whose key starts with prefix, a page of objects at a time.
+func (s *S3Store) DeletePrefix(ctx context.Context, prefix string) error {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.objectKey(prefix)),
+	}
+
+	for {
+		page, err := s.client.ListObjectsV2(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to list payloads in s3: %w", err)
+		}
+
+		if len(page.Contents) > 0 {
+			objects := make([]types.ObjectIdentifier, 0, len(page.Contents))
+			for _, object := range page.Contents {
+				objects = append(objects, types.ObjectIdentifier{Key: object.Key})
+			}
+
+			_, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+				Bucket: aws.String(s.bucket),
+				Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to delete payloads in s3: %w", err)
+			}
+		}
+
+		if !aws.ToBool(page.IsTruncated) {
+			return nil
+		}
+
+		input.ContinuationToken = page.NextContinuationToken
+	}
+}
+
+// objectKey returns the object key of key under the prefix of the store.
+func (s *S3Store) objectKey(key string) string {
+	if s.prefix == "" {
+		return key
+	}
+
+	return s.prefix + "/" + key
+}
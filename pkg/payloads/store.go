@@ -0,0 +1,60 @@
+// Package payloads offloads large execution payloads, such as trigger data and node results, to
+// an object store, leaving the key of the object in the execution context.
+package payloads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedStore is returned for a store URL whose scheme has no store.
+	ErrUnsupportedStore = errors.New("unsupported payload store")
+
+	// ErrForeignRef is returned when a reference does not point to a payload of the execution
+	// resolving it.
+	ErrForeignRef = errors.New("payload reference outside of the execution")
+)
+
+// Store holds offloaded payloads as objects under keys such as "executions/{id}/trigger_data.json".
+type Store interface {
+	// Put writes the object of key.
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get reads the object of key.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// DeletePrefix deletes every object whose key starts with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// NewStore creates the store of rawURL:
+//   - file:///var/lib/operion/payloads writes objects to files under the directory
+//   - s3://bucket/prefix writes objects to an S3 bucket, with the region, credentials and endpoint
+//     of the AWS environment (AWS_REGION, AWS_ENDPOINT_URL_S3, ...)
+func NewStore(ctx context.Context, rawURL string) (Store, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload store URL: %w", err)
+	}
+
+	switch parsed.Scheme {
+	case "file":
+		if parsed.Path == "" {
+			return nil, errors.New("file payload store requires a directory, e.g. file:///var/lib/operion/payloads")
+		}
+
+		return NewFileStore(parsed.Path), nil
+	case "s3":
+		if parsed.Host == "" {
+			return nil, errors.New("s3 payload store requires a bucket, e.g. s3://bucket/prefix")
+		}
+
+		return NewS3Store(ctx, parsed.Host, strings.Trim(parsed.Path, "/"))
+	default:
+		return nil, fmt.Errorf("%w: '%s' (supported: file, s3)", ErrUnsupportedStore, parsed.Scheme)
+	}
+}
@@ -0,0 +1,21 @@
+package persistence
+
+import (
+	"context"
+	"time"
+)
+
+// AlertRepository records when the failure rate alerting policy of workflows last alerted, so its
+// cooldown holds across worker instances and restarts, and which missed run the heartbeat monitor
+// last alerted for, so each is alerted once across activator replicas and restarts.
+type AlertRepository interface {
+	// ClaimAlert records an alert of the workflow at now, unless one was recorded less than
+	// cooldown before now. It reports whether the alert was claimed, so that concurrent workers
+	// send a single alert.
+	ClaimAlert(ctx context.Context, workflowID string, now time.Time, cooldown time.Duration) (bool, error)
+
+	// ClaimHeartbeatAlert records an alert of the scheduler trigger of a workflow for the run
+	// expected at expectedRunAt, unless that run was already alerted for. It reports whether the
+	// alert was claimed.
+	ClaimHeartbeatAlert(ctx context.Context, workflowID, triggerNodeID string, expectedRunAt time.Time) (bool, error)
+}
@@ -0,0 +1,28 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// DebounceRepository stores the pending activations of debounced trigger nodes, so the
+// activator resumes their timers after a restart, and coordinates the activator replicas
+// holding a timer for the same debounce, so that a single one activates it.
+type DebounceRepository interface {
+	// SavePendingDebounce creates or replaces a pending debounce by ID, releasing its claim.
+	SavePendingDebounce(ctx context.Context, pending *models.PendingDebounce) error
+
+	// GetPendingDebounces returns all pending debounces.
+	GetPendingDebounces(ctx context.Context) ([]*models.PendingDebounce, error)
+
+	// ClaimPendingDebounce claims a pending debounce due by now and not claimed by another
+	// replica until lease from now. It returns the stored debounce, nil when there is none, and
+	// whether it was claimed.
+	ClaimPendingDebounce(ctx context.Context, id string, now time.Time, lease time.Duration) (*models.PendingDebounce, bool, error)
+
+	// DeletePendingDebounce removes a pending debounce once it fired at fireAt, unless a newer
+	// event pushed it back since. Deleting a missing debounce is not an error.
+	DeletePendingDebounce(ctx context.Context, id string, fireAt time.Time) error
+}
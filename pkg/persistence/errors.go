@@ -0,0 +1,6 @@
+package persistence
+
+import "errors"
+
+// ErrExecutionContextNotFound is returned when an execution context does not exist.
+var ErrExecutionContextNotFound = errors.New("execution context not found")
@@ -0,0 +1,134 @@
+package file
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// alertClaims serializes the alert claims of the process, which read and rewrite the alert files.
+var alertClaims sync.Mutex
+
+// AlertRepository stores the last alert time of each workflow as a JSON file in the alerts directory.
+type AlertRepository struct {
+	root string
+}
+
+// NewAlertRepository creates a new file-based alert repository.
+func NewAlertRepository(root string) *AlertRepository {
+	return &AlertRepository{root: root}
+}
+
+// lastAlert is the content of the alert file of a workflow.
+type lastAlert struct {
+	WorkflowID  string    `json:"workflow_id"`
+	LastAlertAt time.Time `json:"last_alert_at"`
+}
+
+// path returns the alert file of a workflow, named after the hash of its ID.
+func (ar *AlertRepository) path(workflowID string) string {
+	sum := sha256.Sum256([]byte(workflowID))
+
+	return filepath.Join(ar.root, "alerts", hex.EncodeToString(sum[:])+".json")
+}
+
+// ClaimAlert records an alert of the workflow at now unless one was recorded within cooldown.
+// Claims are serialized within the process only, as the file store serves a single process.
+func (ar *AlertRepository) ClaimAlert(_ context.Context, workflowID string, now time.Time, cooldown time.Duration) (bool, error) {
+	alertClaims.Lock()
+	defer alertClaims.Unlock()
+
+	path := ar.path(workflowID)
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is a hash under the persistence root
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read alert file: %w", err)
+	}
+
+	if err == nil {
+		var last lastAlert
+		if err := json.Unmarshal(data, &last); err != nil {
+			return false, fmt.Errorf("failed to unmarshal alert file: %w", err)
+		}
+
+		if now.Sub(last.LastAlertAt) < cooldown {
+			return false, nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(ar.root, "alerts"), 0750); err != nil {
+		return false, fmt.Errorf("failed to create alerts directory: %w", err)
+	}
+
+	data, err = json.Marshal(lastAlert{WorkflowID: workflowID, LastAlertAt: now})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal alert file: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return false, fmt.Errorf("failed to write alert file: %w", err)
+	}
+
+	return true, nil
+}
+
+// heartbeatAlert is the content of the heartbeat alert file of a scheduler trigger.
+type heartbeatAlert struct {
+	WorkflowID    string    `json:"workflow_id"`
+	TriggerNodeID string    `json:"trigger_node_id"`
+	ExpectedRunAt time.Time `json:"expected_run_at"`
+}
+
+// heartbeatPath returns the heartbeat alert file of a scheduler trigger, named after the hash of
+// the workflow and node IDs.
+func (ar *AlertRepository) heartbeatPath(workflowID, triggerNodeID string) string {
+	sum := sha256.Sum256([]byte(workflowID + "\x00" + triggerNodeID))
+
+	return filepath.Join(ar.root, "heartbeat_alerts", hex.EncodeToString(sum[:])+".json")
+}
+
+// ClaimHeartbeatAlert records an alert of a scheduler trigger for the run expected at
+// expectedRunAt unless that run was already alerted for.
+func (ar *AlertRepository) ClaimHeartbeatAlert(_ context.Context, workflowID, triggerNodeID string, expectedRunAt time.Time) (bool, error) {
+	alertClaims.Lock()
+	defer alertClaims.Unlock()
+
+	path := ar.heartbeatPath(workflowID, triggerNodeID)
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is a hash under the persistence root
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read heartbeat alert file: %w", err)
+	}
+
+	if err == nil {
+		var last heartbeatAlert
+		if err := json.Unmarshal(data, &last); err != nil {
+			return false, fmt.Errorf("failed to unmarshal heartbeat alert file: %w", err)
+		}
+
+		if last.ExpectedRunAt.Equal(expectedRunAt) {
+			return false, nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(ar.root, "heartbeat_alerts"), 0750); err != nil {
+		return false, fmt.Errorf("failed to create heartbeat alerts directory: %w", err)
+	}
+
+	data, err = json.Marshal(heartbeatAlert{WorkflowID: workflowID, TriggerNodeID: triggerNodeID, ExpectedRunAt: expectedRunAt})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal heartbeat alert file: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return false, fmt.Errorf("failed to write heartbeat alert file: %w", err)
+	}
+
+	return true, nil
+}
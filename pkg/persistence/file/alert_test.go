@@ -0,0 +1,57 @@
+package file
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertRepository_ClaimAlert(t *testing.T) {
+	root := t.TempDir()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	claimed, err := NewAlertRepository(root).ClaimAlert(t.Context(), "workflow-1", now, 30*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// The cooldown outlives the repository, as it would a worker restart
+	repo := NewAlertRepository(root)
+
+	claimed, err = repo.ClaimAlert(t.Context(), "workflow-1", now.Add(20*time.Minute), 30*time.Minute)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	claimed, err = repo.ClaimAlert(t.Context(), "workflow-2", now.Add(20*time.Minute), 30*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = repo.ClaimAlert(t.Context(), "workflow-1", now.Add(30*time.Minute), 30*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
+
+func TestAlertRepository_ClaimHeartbeatAlert(t *testing.T) {
+	root := t.TempDir()
+	expectedRunAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	claimed, err := NewAlertRepository(root).ClaimHeartbeatAlert(t.Context(), "workflow-1", "schedule", expectedRunAt)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// The missed run stays alerted for across activator restarts
+	repo := NewAlertRepository(root)
+
+	claimed, err = repo.ClaimHeartbeatAlert(t.Context(), "workflow-1", "schedule", expectedRunAt)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	claimed, err = repo.ClaimHeartbeatAlert(t.Context(), "workflow-1", "nightly", expectedRunAt)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = repo.ClaimHeartbeatAlert(t.Context(), "workflow-1", "schedule", expectedRunAt.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
@@ -0,0 +1,162 @@
+package file
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// debounceClaims serializes the debounce claims and deletions of the process, which read and
+// rewrite the debounce files.
+var debounceClaims sync.Mutex
+
+// DebounceRepository stores pending debounces as JSON files in the debounces directory.
+type DebounceRepository struct {
+	root string
+}
+
+// NewDebounceRepository creates a new file-based debounce repository.
+func NewDebounceRepository(root string) *DebounceRepository {
+	return &DebounceRepository{root: root}
+}
+
+// path returns the file of a pending debounce. IDs hold user-defined keys, so the file is
+// named after their hash rather than the ID itself.
+func (dr *DebounceRepository) path(id string) string {
+	sum := sha256.Sum256([]byte(id))
+
+	return filepath.Join(dr.root, "debounces", hex.EncodeToString(sum[:])+".json")
+}
+
+// SavePendingDebounce writes a pending debounce, replacing the previous one with the same ID.
+func (dr *DebounceRepository) SavePendingDebounce(ctx context.Context, pending *models.PendingDebounce) error {
+	if pending.ID == "" {
+		return errors.New("debounce ID cannot be empty")
+	}
+
+	debounceClaims.Lock()
+	defer debounceClaims.Unlock()
+
+	return dr.write(pending)
+}
+
+// write writes the file of a pending debounce. The caller must hold debounceClaims.
+func (dr *DebounceRepository) write(pending *models.PendingDebounce) error {
+	if err := os.MkdirAll(filepath.Join(dr.root, "debounces"), 0750); err != nil {
+		return fmt.Errorf("failed to create debounces directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(pending, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending debounce: %w", err)
+	}
+
+	if err := os.WriteFile(dr.path(pending.ID), data, 0600); err != nil {
+		return fmt.Errorf("failed to write pending debounce file: %w", err)
+	}
+
+	return nil
+}
+
+// GetPendingDebounces reads all pending debounces.
+func (dr *DebounceRepository) GetPendingDebounces(ctx context.Context) ([]*models.PendingDebounce, error) {
+	dir := filepath.Join(dr.root, "debounces")
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*models.PendingDebounce{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to read debounces directory: %w", err)
+	}
+
+	pending := make([]*models.PendingDebounce, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name())) // #nosec G304 -- file listed from the debounces directory
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pending debounce file: %w", err)
+		}
+
+		var debounce models.PendingDebounce
+		if err := json.Unmarshal(data, &debounce); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal pending debounce %s: %w", entry.Name(), err)
+		}
+
+		pending = append(pending, &debounce)
+	}
+
+	return pending, nil
+}
+
+// read reads the file of a pending debounce, nil when there is none.
+func (dr *DebounceRepository) read(id string) (*models.PendingDebounce, error) {
+	data, err := os.ReadFile(dr.path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read pending debounce file: %w", err)
+	}
+
+	var debounce models.PendingDebounce
+	if err := json.Unmarshal(data, &debounce); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pending debounce %s: %w", id, err)
+	}
+
+	return &debounce, nil
+}
+
+// ClaimPendingDebounce claims a pending debounce due by now and not claimed by another replica.
+// Claims are serialized within the process only, as the file store serves a single process.
+func (dr *DebounceRepository) ClaimPendingDebounce(ctx context.Context, id string, now time.Time, lease time.Duration) (*models.PendingDebounce, bool, error) {
+	debounceClaims.Lock()
+	defer debounceClaims.Unlock()
+
+	debounce, err := dr.read(id)
+	if err != nil || debounce == nil || !debounce.Claimable(now) {
+		return debounce, false, err
+	}
+
+	claimedUntil := now.Add(lease)
+	debounce.ClaimedUntil = &claimedUntil
+
+	if err := dr.write(debounce); err != nil {
+		return nil, false, err
+	}
+
+	return debounce, true, nil
+}
+
+// DeletePendingDebounce removes the file of a pending debounce that fired at fireAt.
+func (dr *DebounceRepository) DeletePendingDebounce(ctx context.Context, id string, fireAt time.Time) error {
+	debounceClaims.Lock()
+	defer debounceClaims.Unlock()
+
+	debounce, err := dr.read(id)
+	if err != nil || debounce == nil || !debounce.FireAt.Equal(fireAt) {
+		return err
+	}
+
+	if err := os.Remove(dr.path(id)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete pending debounce file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package file
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebounceRepository_ClaimPendingDebounce(t *testing.T) {
+	repo := NewDebounceRepository(t.TempDir())
+	fireAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.SavePendingDebounce(t.Context(), &models.PendingDebounce{
+		ID:         "workflow-1:on-save:a.csv",
+		WorkflowID: "workflow-1",
+		EventData:  map[string]any{"path": "a.csv"},
+		EventCount: 1,
+		FireAt:     fireAt,
+	}))
+
+	// Not due yet
+	stored, claimed, err := repo.ClaimPendingDebounce(t.Context(), "workflow-1:on-save:a.csv", fireAt.Add(-time.Second), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	assert.Equal(t, fireAt, stored.FireAt)
+
+	stored, claimed, err = repo.ClaimPendingDebounce(t.Context(), "workflow-1:on-save:a.csv", fireAt, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, fireAt.Add(time.Minute), *stored.ClaimedUntil)
+
+	// Another replica cannot claim it until the claim lapses
+	_, claimed, err = repo.ClaimPendingDebounce(t.Context(), "workflow-1:on-save:a.csv", fireAt.Add(time.Second), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	_, claimed, err = repo.ClaimPendingDebounce(t.Context(), "workflow-1:on-save:a.csv", fireAt.Add(time.Minute), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// A debounce pushed back by a newer event is kept
+	require.NoError(t, repo.DeletePendingDebounce(t.Context(), "workflow-1:on-save:a.csv", fireAt.Add(-time.Second)))
+
+	pending, err := repo.GetPendingDebounces(t.Context())
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	require.NoError(t, repo.DeletePendingDebounce(t.Context(), "workflow-1:on-save:a.csv", fireAt))
+
+	stored, claimed, err = repo.ClaimPendingDebounce(t.Context(), "workflow-1:on-save:a.csv", fireAt, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	assert.Nil(t, stored)
+}
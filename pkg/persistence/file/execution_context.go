@@ -8,13 +8,23 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
 )
 
 // ExecutionContextRepository handles execution context-related file operations.
 type ExecutionContextRepository struct {
 	root string // File system root for storing execution contexts
+
+	// claimMu serializes approval claims, which read and rewrite the execution context file.
+	claimMu sync.Mutex
+
+	// activationsMu serializes the updates of pending activation counts, which read and rewrite
+	// their own files.
+	activationsMu sync.Mutex
 }
 
 // NewExecutionContextRepository creates a new execution context repository.
@@ -92,7 +102,7 @@ func (ecr *ExecutionContextRepository) GetExecutionContext(ctx context.Context,
 	data, err := os.ReadFile(filePath) // #nosec G304 -- filePath is validated and constructed safely
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("execution context not found: %s", executionID)
+			return nil, fmt.Errorf("%w: %s", persistence.ErrExecutionContextNotFound, executionID)
 		}
 
 		return nil, fmt.Errorf("failed to read execution context %s: %w", executionID, err)
@@ -120,6 +130,30 @@ func (ecr *ExecutionContextRepository) UpdateExecutionContext(ctx context.Contex
 	return ecr.SaveExecutionContext(ctx, execCtx)
 }
 
+// DeleteExecutionContext removes an execution context from the file system.
+func (ecr *ExecutionContextRepository) DeleteExecutionContext(ctx context.Context, executionID string) error {
+	// Validate execution ID to prevent path traversal
+	if err := ecr.validateExecutionID(executionID); err != nil {
+		return fmt.Errorf("invalid execution ID: %w", err)
+	}
+
+	filePath := filepath.Join(ecr.root, "execution_contexts", executionID+".json")
+
+	if err := os.Remove(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", persistence.ErrExecutionContextNotFound, executionID)
+		}
+
+		return fmt.Errorf("failed to delete execution context %s: %w", executionID, err)
+	}
+
+	if err := os.Remove(ecr.activationsPath(executionID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete pending activations of %s: %w", executionID, err)
+	}
+
+	return nil
+}
+
 // GetExecutionsByWorkflow retrieves all execution contexts for a specific workflow.
 func (ecr *ExecutionContextRepository) GetExecutionsByWorkflow(ctx context.Context, workflowID string) ([]*models.ExecutionContext, error) {
 	execContextsDir := filepath.Join(ecr.root, "execution_contexts")
@@ -189,3 +223,187 @@ func (ecr *ExecutionContextRepository) GetExecutionsByStatus(ctx context.Context
 
 	return executions, nil
 }
+
+// GetExecutionByApprovalToken retrieves the paused execution holding the pending approval request
+// with token. The file store reads every paused execution.
+func (ecr *ExecutionContextRepository) GetExecutionByApprovalToken(ctx context.Context, token string) (*models.ExecutionContext, error) {
+	paused, err := ecr.GetExecutionsByStatus(ctx, models.ExecutionStatusPaused)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, execCtx := range paused {
+		if execCtx.PendingApproval(token) != nil {
+			return execCtx, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: approval %s", persistence.ErrExecutionContextNotFound, token)
+}
+
+// GetExecutionsWithExpiredApprovals retrieves the paused executions holding a pending approval
+// request expired by now. The file store reads every paused execution.
+func (ecr *ExecutionContextRepository) GetExecutionsWithExpiredApprovals(ctx context.Context, now time.Time) ([]*models.ExecutionContext, error) {
+	paused, err := ecr.GetExecutionsByStatus(ctx, models.ExecutionStatusPaused)
+	if err != nil {
+		return nil, err
+	}
+
+	executions := []*models.ExecutionContext{}
+
+	for _, execCtx := range paused {
+		if execCtx.HasExpiredApprovals(now) {
+			executions = append(executions, execCtx)
+		}
+	}
+
+	return executions, nil
+}
+
+// ClaimApproval replaces the approval request with the token of request while it is still pending.
+// Claims are serialized within the process only, as the file store serves a single process.
+func (ecr *ExecutionContextRepository) ClaimApproval(ctx context.Context, executionID string, request models.ApprovalRequest) (bool, error) {
+	ecr.claimMu.Lock()
+	defer ecr.claimMu.Unlock()
+
+	execCtx, err := ecr.GetExecutionContext(ctx, executionID)
+	if err != nil {
+		return false, err
+	}
+
+	pending := execCtx.PendingApproval(request.Token)
+	if pending == nil {
+		return false, nil
+	}
+
+	*pending = request
+
+	return true, ecr.SaveExecutionContext(ctx, execCtx)
+}
+
+// ClaimRetry takes one retry from the retry budget of an execution while some is left. Claims are
+// serialized within the process only, as the file store serves a single process.
+func (ecr *ExecutionContextRepository) ClaimRetry(ctx context.Context, executionID string, budget int) (bool, error) {
+	ecr.claimMu.Lock()
+	defer ecr.claimMu.Unlock()
+
+	execCtx, err := ecr.GetExecutionContext(ctx, executionID)
+	if err != nil {
+		return false, err
+	}
+
+	if !execCtx.UseRetry(budget) {
+		return false, nil
+	}
+
+	return true, ecr.SaveExecutionContext(ctx, execCtx)
+}
+
+// activationsPath returns the file holding the pending activation count of an execution.
+func (ecr *ExecutionContextRepository) activationsPath(executionID string) string {
+	return filepath.Join(ecr.root, "pending_activations", executionID+".json")
+}
+
+// AddPendingActivations adds delta to the pending node activations of an execution, stored in a
+// file of its own next to the execution context file. Updates are serialized within the process
+// only, as the file store serves a single process.
+func (ecr *ExecutionContextRepository) AddPendingActivations(ctx context.Context, executionID string, delta int) (int, error) {
+	if _, err := ecr.GetExecutionContext(ctx, executionID); err != nil {
+		return 0, err
+	}
+
+	ecr.activationsMu.Lock()
+	defer ecr.activationsMu.Unlock()
+
+	path := ecr.activationsPath(executionID)
+
+	var count int
+
+	data, err := os.ReadFile(path) // #nosec G304 -- path is built from a validated execution ID
+	if err != nil && !os.IsNotExist(err) {
+		return 0, fmt.Errorf("failed to read pending activations of %s: %w", executionID, err)
+	}
+
+	if err == nil {
+		if err := json.Unmarshal(data, &count); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal pending activations of %s: %w", executionID, err)
+		}
+	}
+
+	count += delta
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return 0, fmt.Errorf("failed to create pending activations directory: %w", err)
+	}
+
+	data, err = json.Marshal(count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal pending activations of %s: %w", executionID, err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return 0, fmt.Errorf("failed to write pending activations of %s: %w", executionID, err)
+	}
+
+	return count, nil
+}
+
+// ListNodeResults returns a page of the node results of an execution. The file store reads the
+// whole execution context file.
+func (ecr *ExecutionContextRepository) ListNodeResults(ctx context.Context, executionID string, query models.NodeResultQuery) (*models.NodeResultPage, error) {
+	execCtx, err := ecr.GetExecutionContext(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.PageNodeResults(execCtx.NodeResults, query)
+}
+
+// GetExecutionStats aggregates the executions of a workflow created within [from, to).
+func (ecr *ExecutionContextRepository) GetExecutionStats(ctx context.Context, workflowID string, from, to time.Time) (*models.ExecutionStats, error) {
+	executions, err := ecr.GetExecutionsByWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := models.NewExecutionStats(workflowID, from, to)
+
+	var durations []float64
+
+	for _, execution := range executions {
+		if execution.CreatedAt.Before(from) || !execution.CreatedAt.Before(to) {
+			continue
+		}
+
+		stats.CountsByStatus[execution.Status]++
+
+		if execution.CompletedAt != nil {
+			durations = append(durations, float64(execution.CompletedAt.Sub(execution.CreatedAt).Milliseconds()))
+		}
+	}
+
+	stats.DurationP50Ms = models.Percentile(durations, 0.5)
+	stats.DurationP95Ms = models.Percentile(durations, 0.95)
+	stats.ComputeRates()
+
+	return stats, nil
+}
+
+// GetLastRunAt returns when the latest execution of a workflow that ran started, nil when none did.
+func (ecr *ExecutionContextRepository) GetLastRunAt(ctx context.Context, workflowID string) (*time.Time, error) {
+	executions, err := ecr.GetExecutionsByWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastRunAt *time.Time
+
+	for _, execution := range executions {
+		if execution.Status.Ran() && (lastRunAt == nil || execution.CreatedAt.After(*lastRunAt)) {
+			createdAt := execution.CreatedAt
+			lastRunAt = &createdAt
+		}
+	}
+
+	return lastRunAt, nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -300,6 +301,34 @@ func TestExecutionContextRepository_UpdateExecutionContext_NotFound(t *testing.T
 	assert.Contains(t, err.Error(), "execution context not found")
 }
 
+func TestExecutionContextRepository_DeleteExecutionContext(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	persistence := NewPersistence(tempDir)
+	ctx := context.Background()
+
+	execRepo := persistence.ExecutionContextRepository()
+
+	require.NoError(t, execRepo.SaveExecutionContext(ctx, &models.ExecutionContext{
+		ID:         "execution-1",
+		WorkflowID: "workflow-1",
+		Status:     models.ExecutionStatusCompleted,
+		CreatedAt:  time.Now(),
+	}))
+
+	// Test DeleteExecutionContext
+	require.NoError(t, execRepo.DeleteExecutionContext(ctx, "execution-1"))
+
+	// Verify
+	_, err := execRepo.GetExecutionContext(ctx, "execution-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "execution context not found")
+
+	err = execRepo.DeleteExecutionContext(ctx, "execution-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "execution context not found")
+}
+
 func TestExecutionContextRepository_EmptyRepositories(t *testing.T) {
 	// Setup
 	tempDir := t.TempDir()
@@ -352,3 +381,259 @@ func TestExecutionContextRepository_DataIsolation(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "execution context not found")
 }
+
+func TestExecutionContextRepository_GetExecutionStats(t *testing.T) {
+	persistence := NewPersistence(t.TempDir())
+	ctx := context.Background()
+	execRepo := persistence.ExecutionContextRepository()
+
+	from := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+
+	seed := func(id string, status models.ExecutionStatus, createdAt time.Time, duration time.Duration) {
+		execCtx := &models.ExecutionContext{
+			ID:          id,
+			WorkflowID:  "stats-workflow",
+			Status:      status,
+			NodeResults: make(map[string]models.NodeResult),
+			CreatedAt:   createdAt,
+		}
+
+		if duration > 0 {
+			completedAt := createdAt.Add(duration)
+			execCtx.CompletedAt = &completedAt
+		}
+
+		require.NoError(t, execRepo.SaveExecutionContext(ctx, execCtx))
+	}
+
+	seed("exec-1", models.ExecutionStatusCompleted, from.Add(time.Minute), 100*time.Millisecond)
+	seed("exec-2", models.ExecutionStatusCompleted, from.Add(2*time.Minute), 200*time.Millisecond)
+	seed("exec-3", models.ExecutionStatusCompleted, from.Add(3*time.Minute), 300*time.Millisecond)
+	seed("exec-4", models.ExecutionStatusCompleted, from.Add(4*time.Minute), 400*time.Millisecond)
+	seed("exec-5", models.ExecutionStatusFailed, from.Add(5*time.Minute), time.Second)
+	seed("exec-6", models.ExecutionStatusRunning, from.Add(6*time.Minute), 0)
+	seed("exec-outside", models.ExecutionStatusCompleted, to, 5*time.Second)
+
+	stats, err := execRepo.GetExecutionStats(ctx, "stats-workflow", from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, stats.Total)
+	assert.Equal(t, 4, stats.CountsByStatus[models.ExecutionStatusCompleted])
+	assert.Equal(t, 1, stats.CountsByStatus[models.ExecutionStatusFailed])
+	assert.Equal(t, 1, stats.CountsByStatus[models.ExecutionStatusRunning])
+	assert.InDelta(t, 4.0/6.0, stats.SuccessRate, 0.0001)
+	assert.InDelta(t, 1.0/6.0, stats.FailureRate, 0.0001)
+	assert.InDelta(t, 300, stats.DurationP50Ms, 0.0001)
+	assert.InDelta(t, 880, stats.DurationP95Ms, 0.0001)
+	assert.InDelta(t, 3, stats.ThroughputPerHour, 0.0001)
+
+	empty, err := execRepo.GetExecutionStats(ctx, "other-workflow", from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 0, empty.Total)
+	assert.Zero(t, empty.SuccessRate)
+	assert.Zero(t, empty.DurationP50Ms)
+}
+
+func TestExecutionContextRepository_ListNodeResults(t *testing.T) {
+	persistence := NewPersistence(t.TempDir())
+	ctx := context.Background()
+	execRepo := persistence.ExecutionContextRepository()
+
+	require.NoError(t, execRepo.SaveExecutionContext(ctx, &models.ExecutionContext{
+		ID:         "exec-results",
+		WorkflowID: "workflow-1",
+		Status:     models.ExecutionStatusFailed,
+		NodeResults: map[string]models.NodeResult{
+			models.MakeNodeResultKey("a", "success"): {NodeID: "a", Status: "success"},
+			models.MakeNodeResultKey("b", "error"):   {NodeID: "b", Status: "error", Error: "boom"},
+			models.MakeNodeResultKey("c", "success"): {NodeID: "c", Status: "success"},
+			models.MakeNodeResultKey("d", "error"):   {NodeID: "d", Status: "error", Error: "bang"},
+		},
+		CreatedAt: time.Now().UTC(),
+	}))
+
+	failed, err := execRepo.ListNodeResults(ctx, "exec-results", models.NodeResultQuery{Status: "error"})
+	require.NoError(t, err)
+	require.Len(t, failed.Results, 2)
+	assert.Equal(t, "b::error", failed.Results[0].Key)
+	assert.Equal(t, "error", failed.Results[0].Port)
+	assert.Equal(t, "boom", failed.Results[0].Error)
+	assert.Equal(t, "d", failed.Results[1].NodeID)
+	assert.Empty(t, failed.NextCursor)
+
+	first, err := execRepo.ListNodeResults(ctx, "exec-results", models.NodeResultQuery{Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, first.Results, 3)
+	assert.Equal(t, "c", first.Results[2].NodeID)
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := execRepo.ListNodeResults(ctx, "exec-results", models.NodeResultQuery{Limit: 3, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, second.Results, 1)
+	assert.Equal(t, "d", second.Results[0].NodeID)
+	assert.Empty(t, second.NextCursor)
+
+	_, err = execRepo.ListNodeResults(ctx, "exec-results", models.NodeResultQuery{Cursor: "not base64!"})
+	require.ErrorIs(t, err, models.ErrInvalidCursor)
+
+	_, err = execRepo.ListNodeResults(ctx, "missing", models.NodeResultQuery{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "execution context not found")
+}
+
+func TestExecutionContextRepository_NodeResultKeysRoundTrip(t *testing.T) {
+	persistence := NewPersistence(t.TempDir())
+	ctx := context.Background()
+
+	outputs := [][2]string{{"ns::fetch", "success"}, {"fetch", "http::200"}, {"a:b", `C:\out`}}
+
+	execCtx := &models.ExecutionContext{
+		ID:          "exec-separators",
+		WorkflowID:  "workflow-456",
+		Status:      models.ExecutionStatusRunning,
+		NodeResults: make(map[string]models.NodeResult),
+	}
+	for _, output := range outputs {
+		execCtx.NodeResults[models.MakeNodeResultKey(output[0], output[1])] = models.NodeResult{NodeID: output[0]}
+	}
+
+	execRepo := persistence.ExecutionContextRepository()
+	require.NoError(t, execRepo.SaveExecutionContext(ctx, execCtx))
+
+	retrieved, err := execRepo.GetExecutionContext(ctx, execCtx.ID)
+	require.NoError(t, err)
+	require.Len(t, retrieved.NodeResults, len(outputs))
+
+	for key, result := range retrieved.NodeResults {
+		nodeID, port, ok := models.ParseNodeResultKey(key)
+		require.True(t, ok, "key %q", key)
+		assert.Equal(t, result.NodeID, nodeID)
+		assert.Contains(t, outputs, [2]string{nodeID, port})
+	}
+}
+
+func TestExecutionContextRepository_Approvals(t *testing.T) {
+	persistence := NewPersistence(t.TempDir())
+	ctx := context.Background()
+	execRepo := persistence.ExecutionContextRepository()
+
+	now := time.Now().UTC()
+	expiredAt := now.Add(-time.Minute)
+	expiresAt := now.Add(time.Minute)
+
+	seed := func(id, token string, expiresAt *time.Time) {
+		require.NoError(t, execRepo.SaveExecutionContext(ctx, &models.ExecutionContext{
+			ID:         id,
+			WorkflowID: "approval-workflow",
+			Status:     models.ExecutionStatusPaused,
+			Approvals: []models.ApprovalRequest{
+				{Token: token, NodeID: "approve", Status: models.ApprovalStatusPending, ExpiresAt: expiresAt},
+			},
+		}))
+	}
+
+	seed("exec-expired", "token-expired", &expiredAt)
+	seed("exec-pending", "token-pending", &expiresAt)
+
+	execCtx, err := execRepo.GetExecutionByApprovalToken(ctx, "token-pending")
+	require.NoError(t, err)
+	assert.Equal(t, "exec-pending", execCtx.ID)
+
+	_, err = execRepo.GetExecutionByApprovalToken(ctx, "token-unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "execution context not found")
+
+	executions, err := execRepo.GetExecutionsWithExpiredApprovals(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.Equal(t, "exec-expired", executions[0].ID)
+
+	// Only the first claim of a pending request succeeds
+	request := executions[0].Approvals[0]
+	request.Status = models.ApprovalStatusExpired
+	request.DecidedAt = &now
+
+	claimed, err := execRepo.ClaimApproval(ctx, "exec-expired", request)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	request.Status = models.ApprovalStatusApproved
+
+	claimed, err = execRepo.ClaimApproval(ctx, "exec-expired", request)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	stored, err := execRepo.GetExecutionContext(ctx, "exec-expired")
+	require.NoError(t, err)
+	assert.Equal(t, models.ApprovalStatusExpired, stored.Approvals[0].Status)
+
+	executions, err = execRepo.GetExecutionsWithExpiredApprovals(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, executions)
+}
+
+func TestExecutionContextRepository_ClaimRetry(t *testing.T) {
+	persistence := NewPersistence(t.TempDir())
+	ctx := context.Background()
+	execRepo := persistence.ExecutionContextRepository()
+
+	require.NoError(t, execRepo.SaveExecutionContext(ctx, &models.ExecutionContext{
+		ID:         "exec-retry",
+		WorkflowID: "retry-workflow",
+		Status:     models.ExecutionStatusRunning,
+	}))
+
+	for range 2 {
+		claimed, err := execRepo.ClaimRetry(ctx, "exec-retry", 2)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+	}
+
+	// The budget of 2 is spent
+	claimed, err := execRepo.ClaimRetry(ctx, "exec-retry", 2)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	// A budget of 0 does not limit retries
+	claimed, err = execRepo.ClaimRetry(ctx, "exec-retry", 0)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	stored, err := execRepo.GetExecutionContext(ctx, "exec-retry")
+	require.NoError(t, err)
+	assert.Equal(t, 3, stored.Retries())
+}
+
+func TestExecutionContextRepository_AddPendingActivations(t *testing.T) {
+	ctx := context.Background()
+	execRepo := NewPersistence(t.TempDir()).ExecutionContextRepository()
+
+	_, err := execRepo.AddPendingActivations(ctx, "exec-missing", 1)
+	require.ErrorIs(t, err, persistence.ErrExecutionContextNotFound)
+
+	require.NoError(t, execRepo.SaveExecutionContext(ctx, &models.ExecutionContext{
+		ID:         "exec-pending",
+		WorkflowID: "pending-workflow",
+		Status:     models.ExecutionStatusRunning,
+	}))
+
+	pending, err := execRepo.AddPendingActivations(ctx, "exec-pending", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pending)
+
+	// Saving the execution context keeps the count
+	require.NoError(t, execRepo.UpdateExecutionContext(ctx, &models.ExecutionContext{
+		ID:         "exec-pending",
+		WorkflowID: "pending-workflow",
+		Status:     models.ExecutionStatusRunning,
+	}))
+
+	pending, err = execRepo.AddPendingActivations(ctx, "exec-pending", -1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pending)
+
+	pending, err = execRepo.AddPendingActivations(ctx, "exec-pending", -1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, pending)
+}
@@ -67,6 +67,14 @@ func (fp *Persistence) InputCoordinationRepository() persistence.InputCoordinati
 	return NewFileInputCoordinationRepository(fp.root)
 }
 
+func (fp *Persistence) DebounceRepository() persistence.DebounceRepository {
+	return NewDebounceRepository(fp.root)
+}
+
+func (fp *Persistence) AlertRepository() persistence.AlertRepository {
+	return NewAlertRepository(fp.root)
+}
+
 // Node repository implementation for file persistence
 // This works by reading workflow files and extracting node information
 
@@ -137,6 +145,35 @@ func (nr *nodeRepository) UpdateNode(ctx context.Context, workflowID string, nod
 	return nr.SaveNode(ctx, workflowID, node)
 }
 
+func (nr *nodeRepository) UpdateNodePositions(ctx context.Context, workflowID string, positions map[string]models.NodePosition) error {
+	workflow, err := nr.persistence.workflowRepo.GetByID(ctx, workflowID)
+	if err != nil {
+		return fmt.Errorf("failed to get workflow %s: %w", workflowID, err)
+	}
+
+	if workflow == nil {
+		return fmt.Errorf("workflow not found: %s", workflowID)
+	}
+
+	nodes := make(map[string]*models.WorkflowNode, len(workflow.Nodes))
+	for _, node := range workflow.Nodes {
+		nodes[node.ID] = node
+	}
+
+	for nodeID := range positions {
+		if _, exists := nodes[nodeID]; !exists {
+			return fmt.Errorf("node not found: %s in workflow %s", nodeID, workflowID)
+		}
+	}
+
+	for nodeID, position := range positions {
+		nodes[nodeID].PositionX = position.X
+		nodes[nodeID].PositionY = position.Y
+	}
+
+	return nr.persistence.workflowRepo.Save(ctx, workflow)
+}
+
 func (nr *nodeRepository) DeleteNode(ctx context.Context, workflowID, nodeID string) error {
 	workflow, err := nr.persistence.workflowRepo.GetByID(ctx, workflowID)
 	if err != nil {
@@ -1,6 +1,7 @@
 package file
 
 import (
+	"fmt"
 	"path/filepath"
 	"testing"
 	"time"
@@ -231,8 +232,34 @@ func TestPersistence_DeleteWorkflow(t *testing.T) {
 	err = persistence.WorkflowRepository().Delete(t.Context(), "delete-workflow")
 	require.NoError(t, err)
 
-	// Verify file was deleted
-	assert.NoFileExists(t, filePath)
+	// The workflow is soft deleted: hidden, but kept with its deleted_at timestamp
+	deleted, err := persistence.WorkflowRepository().GetByID(t.Context(), "delete-workflow")
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+
+	all, err := persistence.WorkflowRepository().GetAll(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	assert.FileExists(t, filePath)
+
+	deleted, err = persistence.WorkflowRepository().GetByIDIncludingDeleted(t.Context(), "delete-workflow")
+	require.NoError(t, err)
+	require.NotNil(t, deleted)
+	assert.NotNil(t, deleted.DeletedAt)
+
+	page, err := persistence.WorkflowRepository().ListWorkflows(t.Context(), models.ListOptions{IncludeDeleted: true})
+	require.NoError(t, err)
+	require.Len(t, page.Workflows, 1)
+
+	// Restore brings it back
+	err = persistence.WorkflowRepository().Restore(t.Context(), "delete-workflow")
+	require.NoError(t, err)
+
+	restored, err := persistence.WorkflowRepository().GetByID(t.Context(), "delete-workflow")
+	require.NoError(t, err)
+	require.NotNil(t, restored)
+	assert.Nil(t, restored.DeletedAt)
 }
 
 func TestPersistence_DeleteWorkflow_NotFound(t *testing.T) {
@@ -244,3 +271,77 @@ func TestPersistence_DeleteWorkflow_NotFound(t *testing.T) {
 	err := persistence.WorkflowRepository().Delete(t.Context(), "non-existent")
 	assert.NoError(t, err)
 }
+
+func TestWorkflowRepository_ListWorkflows_Cursor(t *testing.T) {
+	persistence := NewPersistence(t.TempDir())
+	repo := persistence.WorkflowRepository()
+
+	base := time.Now().UTC().Add(-time.Hour)
+
+	for i := range 25 {
+		err := repo.Save(t.Context(), &models.Workflow{
+			ID:        fmt.Sprintf("workflow-%02d", i),
+			Name:      fmt.Sprintf("Workflow %d", i),
+			Status:    models.WorkflowStatusDraft,
+			CreatedAt: base.Add(time.Duration(i/2) * time.Second), // pairs share a timestamp
+		})
+		require.NoError(t, err)
+	}
+
+	seen := make(map[string]bool)
+	cursor := ""
+	pages := 0
+
+	for {
+		page, err := repo.ListWorkflows(t.Context(), models.ListOptions{Limit: 10, Cursor: cursor})
+		require.NoError(t, err)
+
+		pages++
+
+		for _, workflow := range page.Workflows {
+			assert.False(t, seen[workflow.ID], "workflow %s returned twice", workflow.ID)
+			seen[workflow.ID] = true
+		}
+
+		// A workflow created between page fetches must not shift later pages
+		if pages == 1 {
+			err = repo.Save(t.Context(), &models.Workflow{ID: "workflow-new", Name: "New", Status: models.WorkflowStatusDraft})
+			require.NoError(t, err)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+
+		cursor = page.NextCursor
+	}
+
+	assert.Equal(t, 3, pages)
+	assert.Len(t, seen, 25)
+	assert.False(t, seen["workflow-new"])
+}
+
+func TestWorkflowRepository_ListWorkflows_Offset(t *testing.T) {
+	persistence := NewPersistence(t.TempDir())
+	repo := persistence.WorkflowRepository()
+
+	base := time.Now().UTC()
+
+	for i := range 5 {
+		err := repo.Save(t.Context(), &models.Workflow{
+			ID:        fmt.Sprintf("workflow-%d", i),
+			Name:      fmt.Sprintf("Workflow %d", i),
+			CreatedAt: base.Add(time.Duration(i) * time.Second),
+		})
+		require.NoError(t, err)
+	}
+
+	page, err := repo.ListWorkflows(t.Context(), models.ListOptions{Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Workflows, 2)
+	assert.Equal(t, "workflow-2", page.Workflows[0].ID)
+	assert.Equal(t, "workflow-1", page.Workflows[1].ID)
+
+	_, err = repo.ListWorkflows(t.Context(), models.ListOptions{Cursor: "not-a-cursor"})
+	assert.ErrorIs(t, err, models.ErrInvalidCursor)
+}
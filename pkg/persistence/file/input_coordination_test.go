@@ -0,0 +1,37 @@
+package file
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputCoordinationRepository_PortsWithSeparatorsRoundTrip(t *testing.T) {
+	repo := NewFileInputCoordinationRepository(t.TempDir())
+	ctx := context.Background()
+
+	state := &models.NodeInputState{
+		NodeID:          "ns::merge",
+		ExecutionID:     "exec-1",
+		NodeExecutionID: "node-exec-1",
+		ReceivedInputs: map[string]models.NodeResult{
+			"left::a": {NodeID: "a:b", Status: string(models.NodeStatusSuccess)},
+			`right\b`: {NodeID: "c::d", Status: string(models.NodeStatusSuccess)},
+		},
+		ReceivedFrom: map[string][]string{
+			"left::a": {"a:b"},
+			`right\b`: {"c::d"},
+		},
+	}
+
+	require.NoError(t, repo.SaveInputState(ctx, state))
+
+	retrieved, err := repo.LoadInputState(ctx, state.NodeExecutionID)
+	require.NoError(t, err)
+	assert.Equal(t, state.NodeID, retrieved.NodeID)
+	assert.Equal(t, state.ReceivedInputs, retrieved.ReceivedInputs)
+	assert.Equal(t, state.ReceivedFrom, retrieved.ReceivedFrom)
+}
@@ -274,6 +274,48 @@ func TestNodeRepository_DeleteNode(t *testing.T) {
 	assert.Contains(t, err.Error(), "node not found")
 }
 
+func TestNodeRepository_UpdateNodePositions(t *testing.T) {
+	// Setup
+	tempDir := t.TempDir()
+	persistence := NewPersistence(tempDir)
+	ctx := context.Background()
+
+	workflow := &models.Workflow{
+		ID:   "test-workflow-layout",
+		Name: "Test Workflow Layout",
+		Nodes: []*models.WorkflowNode{
+			{ID: "node1", Name: "First Node", Type: "log", Category: models.CategoryTypeAction, PositionX: 10, PositionY: 10},
+			{ID: "node2", Name: "Second Node", Type: "log", Category: models.CategoryTypeAction, PositionX: 20, PositionY: 20},
+		},
+		Status: models.WorkflowStatusDraft,
+	}
+
+	err := persistence.WorkflowRepository().Save(ctx, workflow)
+	require.NoError(t, err)
+
+	nodeRepo := persistence.NodeRepository()
+
+	// An unknown node updates nothing
+	err = nodeRepo.UpdateNodePositions(ctx, workflow.ID, map[string]models.NodePosition{
+		"node1":   {X: 99, Y: 99},
+		"missing": {X: 1, Y: 1},
+	})
+	require.Error(t, err)
+
+	err = nodeRepo.UpdateNodePositions(ctx, workflow.ID, map[string]models.NodePosition{
+		"node2": {X: 200, Y: 250},
+	})
+	require.NoError(t, err)
+
+	// Verify
+	nodes, err := nodeRepo.GetNodesByWorkflow(ctx, workflow.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, nodes[0].PositionX)
+	assert.Equal(t, 10, nodes[0].PositionY)
+	assert.Equal(t, 200, nodes[1].PositionX)
+	assert.Equal(t, 250, nodes[1].PositionY)
+}
+
 func TestNodeRepository_FindTriggerNodesBySourceEventAndProvider(t *testing.T) {
 	// Setup
 	tempDir := t.TempDir()
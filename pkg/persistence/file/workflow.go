@@ -8,6 +8,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"time"
 
@@ -24,8 +25,13 @@ func NewWorkflowRepository(root string) *WorkflowRepository {
 	return &WorkflowRepository{root: root}
 }
 
-// GetAll returns all workflows from the file system.
-func (wr *WorkflowRepository) GetAll(ctx context.Context) ([]*models.Workflow, error) {
+// GetAll returns all workflows from the file system, except deleted ones.
+func (wr *WorkflowRepository) GetAll(_ context.Context) ([]*models.Workflow, error) {
+	return wr.readAll(false)
+}
+
+// readAll reads every workflow file, skipping soft deleted workflows unless includeDeleted is set.
+func (wr *WorkflowRepository) readAll(includeDeleted bool) ([]*models.Workflow, error) {
 	root := os.DirFS(wr.root + "/workflows")
 
 	jsonFiles, err := fs.Glob(root, "*.json")
@@ -40,19 +46,84 @@ func (wr *WorkflowRepository) GetAll(ctx context.Context) ([]*models.Workflow, e
 	workflows := make([]*models.Workflow, 0, len(jsonFiles))
 
 	for _, file := range jsonFiles {
-		workflow, err := wr.GetByID(ctx, file[:len(file)-5])
+		workflow, err := wr.read(file[:len(file)-5])
 		if err != nil {
 			return nil, err
 		}
 
+		if workflow.DeletedAt != nil && !includeDeleted {
+			continue
+		}
+
 		workflows = append(workflows, workflow)
 	}
 
 	return workflows, nil
 }
 
-// GetByID retrieves a workflow by its ID from the file system.
+// ListWorkflows returns a page of workflows ordered by creation time, newest first.
+func (wr *WorkflowRepository) ListWorkflows(_ context.Context, opts models.ListOptions) (*models.WorkflowPage, error) {
+	workflows, err := wr.readAll(opts.IncludeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(workflows, func(i, j int) bool {
+		if workflows[i].CreatedAt.Equal(workflows[j].CreatedAt) {
+			return workflows[i].ID > workflows[j].ID
+		}
+
+		return workflows[i].CreatedAt.After(workflows[j].CreatedAt)
+	})
+
+	if opts.Cursor != "" {
+		cursor, err := models.DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := make([]*models.Workflow, 0, len(workflows))
+
+		for _, workflow := range workflows {
+			if cursor.Includes(workflow.CreatedAt, workflow.ID) {
+				remaining = append(remaining, workflow)
+			}
+		}
+
+		workflows = remaining
+	} else if opts.Offset > 0 {
+		workflows = workflows[min(opts.Offset, len(workflows)):]
+	}
+
+	page := &models.WorkflowPage{Workflows: workflows}
+
+	if opts.Limit > 0 && len(workflows) > opts.Limit {
+		page.Workflows = workflows[:opts.Limit]
+		last := page.Workflows[opts.Limit-1]
+		page.NextCursor = models.EncodeCursor(models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return page, nil
+}
+
+// GetByID retrieves a workflow by its ID from the file system, nil when it does not exist or is
+// deleted.
 func (wr *WorkflowRepository) GetByID(_ context.Context, workflowID string) (*models.Workflow, error) {
+	workflow, err := wr.read(workflowID)
+	if err != nil || workflow == nil || workflow.DeletedAt != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// GetByIDIncludingDeleted retrieves a workflow by its ID, even when it is soft deleted.
+func (wr *WorkflowRepository) GetByIDIncludingDeleted(_ context.Context, workflowID string) (*models.Workflow, error) {
+	return wr.read(workflowID)
+}
+
+// read reads the file of a workflow, nil when there is none.
+func (wr *WorkflowRepository) read(workflowID string) (*models.Workflow, error) {
 	filePath := filepath.Clean(path.Join(wr.root, "workflows", workflowID+".json"))
 
 	body, err := os.ReadFile(filePath)
@@ -88,6 +159,11 @@ func (wr *WorkflowRepository) Save(_ context.Context, workflow *models.Workflow)
 
 	workflow.UpdatedAt = now
 
+	// A new workflow starts its own group, like in the postgres store
+	if workflow.WorkflowGroupID == "" {
+		workflow.WorkflowGroupID = workflow.ID
+	}
+
 	data, err := json.MarshalIndent(workflow, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal workflow %s: %w", workflow.ID, err)
@@ -98,21 +174,38 @@ func (wr *WorkflowRepository) Save(_ context.Context, workflow *models.Workflow)
 	return os.WriteFile(filePath, data, 0600)
 }
 
-// Delete removes a workflow by its ID.
-func (wr *WorkflowRepository) Delete(_ context.Context, id string) error {
-	filePath := path.Join(wr.root+"/workflows", id+".json")
-
-	err := os.Remove(filePath)
+// Delete soft deletes a workflow by setting its deleted_at timestamp.
+func (wr *WorkflowRepository) Delete(ctx context.Context, id string) error {
+	workflow, err := wr.read(id)
+	if err != nil {
+		return fmt.Errorf("failed to delete workflow %s: %w", id, err)
+	}
 
-	if err != nil && os.IsNotExist(err) {
+	// Workflow doesn't exist or already deleted - this is not an error
+	if workflow == nil || workflow.DeletedAt != nil {
 		return nil
 	}
 
+	now := time.Now().UTC()
+	workflow.DeletedAt = &now
+
+	return wr.Save(ctx, workflow)
+}
+
+// Restore clears the deleted_at timestamp of a soft deleted workflow.
+func (wr *WorkflowRepository) Restore(ctx context.Context, id string) error {
+	workflow, err := wr.read(id)
 	if err != nil {
-		return fmt.Errorf("failed to delete workflow %s: %w", id, err)
+		return fmt.Errorf("failed to restore workflow %s: %w", id, err)
+	}
+
+	if workflow == nil || workflow.DeletedAt == nil {
+		return nil
 	}
 
-	return nil
+	workflow.DeletedAt = nil
+
+	return wr.Save(ctx, workflow)
 }
 
 // GetCurrentWorkflow returns the current version (published if exists, otherwise draft).
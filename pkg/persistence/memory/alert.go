@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// AlertRepository stores the last alert times of workflows and the missed runs alerted for in memory.
+type AlertRepository struct {
+	mu         sync.Mutex
+	lastAlerts map[string]time.Time
+	heartbeats map[string]time.Time
+}
+
+// NewAlertRepository creates a new, empty alert repository.
+func NewAlertRepository() *AlertRepository {
+	return &AlertRepository{lastAlerts: make(map[string]time.Time), heartbeats: make(map[string]time.Time)}
+}
+
+// ClaimAlert records an alert of the workflow at now unless one was recorded within cooldown.
+func (ar *AlertRepository) ClaimAlert(_ context.Context, workflowID string, now time.Time, cooldown time.Duration) (bool, error) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	if last, ok := ar.lastAlerts[workflowID]; ok && now.Sub(last) < cooldown {
+		return false, nil
+	}
+
+	ar.lastAlerts[workflowID] = now
+
+	return true, nil
+}
+
+// ClaimHeartbeatAlert records an alert of a scheduler trigger for the run expected at
+// expectedRunAt unless that run was already alerted for.
+func (ar *AlertRepository) ClaimHeartbeatAlert(_ context.Context, workflowID, triggerNodeID string, expectedRunAt time.Time) (bool, error) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	key := models.MakePortID(workflowID, triggerNodeID)
+	if alertedFor, ok := ar.heartbeats[key]; ok && alertedFor.Equal(expectedRunAt) {
+		return false, nil
+	}
+
+	ar.heartbeats[key] = expectedRunAt
+
+	return true, nil
+}
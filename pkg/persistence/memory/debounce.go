@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// DebounceRepository stores pending debounces in memory.
+type DebounceRepository struct {
+	mu      sync.Mutex
+	pending map[string][]byte
+}
+
+// NewDebounceRepository creates a new, empty debounce repository.
+func NewDebounceRepository() *DebounceRepository {
+	return &DebounceRepository{pending: make(map[string][]byte)}
+}
+
+// SavePendingDebounce stores a pending debounce, replacing the previous one with the same ID.
+func (dr *DebounceRepository) SavePendingDebounce(_ context.Context, pending *models.PendingDebounce) error {
+	if pending.ID == "" {
+		return errors.New("debounce ID cannot be empty")
+	}
+
+	data, err := encode(pending)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending debounce: %w", err)
+	}
+
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+
+	dr.pending[pending.ID] = data
+
+	return nil
+}
+
+// GetPendingDebounces returns all pending debounces, ordered by ID.
+func (dr *DebounceRepository) GetPendingDebounces(_ context.Context) ([]*models.PendingDebounce, error) {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+
+	pending := make([]*models.PendingDebounce, 0, len(dr.pending))
+
+	for id, data := range dr.pending {
+		debounce, err := decode[models.PendingDebounce](data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal pending debounce %s: %w", id, err)
+		}
+
+		pending = append(pending, debounce)
+	}
+
+	sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+
+	return pending, nil
+}
+
+// ClaimPendingDebounce claims a pending debounce due by now and not claimed by another replica.
+func (dr *DebounceRepository) ClaimPendingDebounce(_ context.Context, id string, now time.Time, lease time.Duration) (*models.PendingDebounce, bool, error) {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+
+	data, ok := dr.pending[id]
+	if !ok {
+		return nil, false, nil
+	}
+
+	debounce, err := decode[models.PendingDebounce](data)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal pending debounce %s: %w", id, err)
+	}
+
+	if !debounce.Claimable(now) {
+		return debounce, false, nil
+	}
+
+	claimedUntil := now.Add(lease)
+	debounce.ClaimedUntil = &claimedUntil
+
+	if dr.pending[id], err = encode(debounce); err != nil {
+		return nil, false, fmt.Errorf("failed to marshal pending debounce: %w", err)
+	}
+
+	return debounce, true, nil
+}
+
+// DeletePendingDebounce removes a pending debounce that fired at fireAt.
+func (dr *DebounceRepository) DeletePendingDebounce(_ context.Context, id string, fireAt time.Time) error {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+
+	data, ok := dr.pending[id]
+	if !ok {
+		return nil
+	}
+
+	debounce, err := decode[models.PendingDebounce](data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal pending debounce %s: %w", id, err)
+	}
+
+	if debounce.FireAt.Equal(fireAt) {
+		delete(dr.pending, id)
+	}
+
+	return nil
+}
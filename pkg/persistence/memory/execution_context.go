@@ -0,0 +1,284 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+)
+
+// ExecutionContextRepository stores execution contexts in memory.
+type ExecutionContextRepository struct {
+	mu          sync.RWMutex
+	executions  map[string][]byte
+	activations map[string]int // Pending node activations by execution ID
+}
+
+// NewExecutionContextRepository creates a new, empty execution context repository.
+func NewExecutionContextRepository() *ExecutionContextRepository {
+	return &ExecutionContextRepository{executions: make(map[string][]byte), activations: make(map[string]int)}
+}
+
+// SaveExecutionContext stores an execution context, replacing the previous one with the same ID.
+func (ecr *ExecutionContextRepository) SaveExecutionContext(_ context.Context, execCtx *models.ExecutionContext) error {
+	if execCtx.ID == "" {
+		return errors.New("execution ID cannot be empty")
+	}
+
+	ecr.mu.Lock()
+	defer ecr.mu.Unlock()
+
+	return ecr.write(execCtx)
+}
+
+// write stores an execution context with empty maps instead of nil ones, like the file store.
+// The caller holds the write lock.
+func (ecr *ExecutionContextRepository) write(execCtx *models.ExecutionContext) error {
+	contextToSave := *execCtx
+	if contextToSave.NodeResults == nil {
+		contextToSave.NodeResults = make(map[string]models.NodeResult)
+	}
+
+	if contextToSave.Variables == nil {
+		contextToSave.Variables = make(map[string]any)
+	}
+
+	if contextToSave.TriggerData == nil {
+		contextToSave.TriggerData = make(map[string]any)
+	}
+
+	if contextToSave.Metadata == nil {
+		contextToSave.Metadata = make(map[string]any)
+	}
+
+	data, err := encode(contextToSave)
+	if err != nil {
+		return fmt.Errorf("failed to marshal execution context %s: %w", execCtx.ID, err)
+	}
+
+	ecr.executions[execCtx.ID] = data
+
+	return nil
+}
+
+// GetExecutionContext retrieves an execution context by its ID.
+func (ecr *ExecutionContextRepository) GetExecutionContext(_ context.Context, executionID string) (*models.ExecutionContext, error) {
+	ecr.mu.RLock()
+	defer ecr.mu.RUnlock()
+
+	return ecr.read(executionID)
+}
+
+// read returns a copy of an execution context. The caller holds the lock.
+func (ecr *ExecutionContextRepository) read(executionID string) (*models.ExecutionContext, error) {
+	data, exists := ecr.executions[executionID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", persistence.ErrExecutionContextNotFound, executionID)
+	}
+
+	execCtx, err := decode[models.ExecutionContext](data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal execution context %s: %w", executionID, err)
+	}
+
+	return execCtx, nil
+}
+
+// UpdateExecutionContext replaces an existing execution context.
+func (ecr *ExecutionContextRepository) UpdateExecutionContext(_ context.Context, execCtx *models.ExecutionContext) error {
+	ecr.mu.Lock()
+	defer ecr.mu.Unlock()
+
+	if _, exists := ecr.executions[execCtx.ID]; !exists {
+		return fmt.Errorf("%w: %s", persistence.ErrExecutionContextNotFound, execCtx.ID)
+	}
+
+	return ecr.write(execCtx)
+}
+
+// DeleteExecutionContext removes an execution context.
+func (ecr *ExecutionContextRepository) DeleteExecutionContext(_ context.Context, executionID string) error {
+	ecr.mu.Lock()
+	defer ecr.mu.Unlock()
+
+	if _, exists := ecr.executions[executionID]; !exists {
+		return fmt.Errorf("%w: %s", persistence.ErrExecutionContextNotFound, executionID)
+	}
+
+	delete(ecr.executions, executionID)
+	delete(ecr.activations, executionID)
+
+	return nil
+}
+
+// GetExecutionsByWorkflow retrieves all execution contexts of a workflow, ordered by ID.
+func (ecr *ExecutionContextRepository) GetExecutionsByWorkflow(_ context.Context, workflowID string) ([]*models.ExecutionContext, error) {
+	return ecr.filter(func(execCtx *models.ExecutionContext) bool { return execCtx.WorkflowID == workflowID })
+}
+
+// GetExecutionsByStatus retrieves all execution contexts with a status, ordered by ID.
+func (ecr *ExecutionContextRepository) GetExecutionsByStatus(_ context.Context, status models.ExecutionStatus) ([]*models.ExecutionContext, error) {
+	return ecr.filter(func(execCtx *models.ExecutionContext) bool { return execCtx.Status == status })
+}
+
+// filter returns copies of the execution contexts matching keep, ordered by ID.
+func (ecr *ExecutionContextRepository) filter(keep func(*models.ExecutionContext) bool) ([]*models.ExecutionContext, error) {
+	ecr.mu.RLock()
+	defer ecr.mu.RUnlock()
+
+	executions := []*models.ExecutionContext{}
+
+	for executionID := range ecr.executions {
+		execCtx, err := ecr.read(executionID)
+		if err != nil {
+			return nil, err
+		}
+
+		if keep(execCtx) {
+			executions = append(executions, execCtx)
+		}
+	}
+
+	sort.Slice(executions, func(i, j int) bool { return executions[i].ID < executions[j].ID })
+
+	return executions, nil
+}
+
+// GetExecutionByApprovalToken retrieves the paused execution holding the pending approval request with token.
+func (ecr *ExecutionContextRepository) GetExecutionByApprovalToken(_ context.Context, token string) (*models.ExecutionContext, error) {
+	executions, err := ecr.filter(func(execCtx *models.ExecutionContext) bool {
+		return execCtx.Status == models.ExecutionStatusPaused && execCtx.PendingApproval(token) != nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(executions) == 0 {
+		return nil, fmt.Errorf("%w: approval %s", persistence.ErrExecutionContextNotFound, token)
+	}
+
+	return executions[0], nil
+}
+
+// GetExecutionsWithExpiredApprovals retrieves the paused executions holding a pending approval
+// request expired by now, ordered by ID.
+func (ecr *ExecutionContextRepository) GetExecutionsWithExpiredApprovals(_ context.Context, now time.Time) ([]*models.ExecutionContext, error) {
+	return ecr.filter(func(execCtx *models.ExecutionContext) bool {
+		return execCtx.Status == models.ExecutionStatusPaused && execCtx.HasExpiredApprovals(now)
+	})
+}
+
+// ClaimApproval replaces the approval request with the token of request while it is still pending.
+func (ecr *ExecutionContextRepository) ClaimApproval(_ context.Context, executionID string, request models.ApprovalRequest) (bool, error) {
+	ecr.mu.Lock()
+	defer ecr.mu.Unlock()
+
+	execCtx, err := ecr.read(executionID)
+	if err != nil {
+		return false, err
+	}
+
+	pending := execCtx.PendingApproval(request.Token)
+	if pending == nil {
+		return false, nil
+	}
+
+	*pending = request
+
+	return true, ecr.write(execCtx)
+}
+
+// ClaimRetry takes one retry from the retry budget of an execution while some is left.
+func (ecr *ExecutionContextRepository) ClaimRetry(_ context.Context, executionID string, budget int) (bool, error) {
+	ecr.mu.Lock()
+	defer ecr.mu.Unlock()
+
+	execCtx, err := ecr.read(executionID)
+	if err != nil {
+		return false, err
+	}
+
+	if !execCtx.UseRetry(budget) {
+		return false, nil
+	}
+
+	return true, ecr.write(execCtx)
+}
+
+// AddPendingActivations adds delta to the pending node activations of an execution.
+func (ecr *ExecutionContextRepository) AddPendingActivations(_ context.Context, executionID string, delta int) (int, error) {
+	ecr.mu.Lock()
+	defer ecr.mu.Unlock()
+
+	if _, exists := ecr.executions[executionID]; !exists {
+		return 0, fmt.Errorf("%w: %s", persistence.ErrExecutionContextNotFound, executionID)
+	}
+
+	ecr.activations[executionID] += delta
+
+	return ecr.activations[executionID], nil
+}
+
+// ListNodeResults returns a page of the node results of an execution.
+func (ecr *ExecutionContextRepository) ListNodeResults(ctx context.Context, executionID string, query models.NodeResultQuery) (*models.NodeResultPage, error) {
+	execCtx, err := ecr.GetExecutionContext(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.PageNodeResults(execCtx.NodeResults, query)
+}
+
+// GetExecutionStats aggregates the executions of a workflow created within [from, to).
+func (ecr *ExecutionContextRepository) GetExecutionStats(ctx context.Context, workflowID string, from, to time.Time) (*models.ExecutionStats, error) {
+	executions, err := ecr.GetExecutionsByWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := models.NewExecutionStats(workflowID, from, to)
+
+	var durations []float64
+
+	for _, execution := range executions {
+		if execution.CreatedAt.Before(from) || !execution.CreatedAt.Before(to) {
+			continue
+		}
+
+		stats.CountsByStatus[execution.Status]++
+
+		if execution.CompletedAt != nil {
+			durations = append(durations, float64(execution.CompletedAt.Sub(execution.CreatedAt).Milliseconds()))
+		}
+	}
+
+	stats.DurationP50Ms = models.Percentile(durations, 0.5)
+	stats.DurationP95Ms = models.Percentile(durations, 0.95)
+	stats.ComputeRates()
+
+	return stats, nil
+}
+
+// GetLastRunAt returns when the latest execution of a workflow that ran started, nil when none did.
+func (ecr *ExecutionContextRepository) GetLastRunAt(ctx context.Context, workflowID string) (*time.Time, error) {
+	executions, err := ecr.GetExecutionsByWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastRunAt *time.Time
+
+	for _, execution := range executions {
+		if execution.Status.Ran() && (lastRunAt == nil || execution.CreatedAt.After(*lastRunAt)) {
+			createdAt := execution.CreatedAt
+			lastRunAt = &createdAt
+		}
+	}
+
+	return lastRunAt, nil
+}
@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// storedInputState is an input state with the time it was last saved, which expiry is based on.
+type storedInputState struct {
+	data    []byte
+	savedAt time.Time
+}
+
+// InputCoordinationRepository stores the input states of nodes in memory.
+type InputCoordinationRepository struct {
+	mu     sync.Mutex
+	states map[string]storedInputState
+}
+
+// NewInputCoordinationRepository creates a new, empty input coordination repository.
+func NewInputCoordinationRepository() *InputCoordinationRepository {
+	return &InputCoordinationRepository{states: make(map[string]storedInputState)}
+}
+
+// SaveInputState stores the input state of a node execution.
+func (r *InputCoordinationRepository) SaveInputState(_ context.Context, state *models.NodeInputState) error {
+	if state.NodeExecutionID == "" {
+		return errors.New("node execution ID cannot be empty")
+	}
+
+	data, err := encode(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal input state: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.states[state.NodeExecutionID] = storedInputState{data: data, savedAt: time.Now()}
+
+	return nil
+}
+
+// LoadInputState retrieves the input state of a node execution.
+func (r *InputCoordinationRepository) LoadInputState(_ context.Context, nodeExecutionID string) (*models.NodeInputState, error) {
+	r.mu.Lock()
+	stored, exists := r.states[nodeExecutionID]
+	r.mu.Unlock()
+
+	if !exists {
+		return nil, fmt.Errorf("input state not found for node execution %s", nodeExecutionID)
+	}
+
+	state, err := decode[models.NodeInputState](stored.data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal input state: %w", err)
+	}
+
+	return state, nil
+}
+
+// FindPendingNodeExecution finds the oldest pending execution for a node (for FIFO loop handling).
+func (r *InputCoordinationRepository) FindPendingNodeExecution(_ context.Context, nodeID, executionID string) (*models.NodeInputState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var oldestPending *models.NodeInputState
+
+	for _, stored := range r.states {
+		state, err := decode[models.NodeInputState](stored.data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal input state: %w", err)
+		}
+
+		if state.NodeID != nodeID || state.ExecutionID != executionID {
+			continue
+		}
+
+		if oldestPending == nil || state.CreatedAt.Before(oldestPending.CreatedAt) ||
+			(state.CreatedAt.Equal(oldestPending.CreatedAt) && state.NodeExecutionID < oldestPending.NodeExecutionID) {
+			oldestPending = state
+		}
+	}
+
+	return oldestPending, nil
+}
+
+// DeleteInputState removes the input state of a node execution. Deleting a missing state is not
+// an error.
+func (r *InputCoordinationRepository) DeleteInputState(_ context.Context, nodeExecutionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.states, nodeExecutionID)
+
+	return nil
+}
+
+// CleanupExpiredStates removes the input states last saved more than maxAge ago.
+func (r *InputCoordinationRepository) CleanupExpiredStates(_ context.Context, maxAge time.Duration) error {
+	cutoffTime := time.Now().Add(-maxAge)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for nodeExecutionID, stored := range r.states {
+		if stored.savedAt.Before(cutoffTime) {
+			delete(r.states, nodeExecutionID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// Package memory provides an in-memory persistence implementation for tests and ephemeral
+// single-node deployments. Nothing outlives the process.
+package memory
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/dukex/operion/pkg/persistence"
+)
+
+// Persistence implements the persistence.Persistence interface with maps guarded by mutexes.
+type Persistence struct {
+	workflowRepo          *WorkflowRepository
+	executionContextRepo  *ExecutionContextRepository
+	inputCoordinationRepo *InputCoordinationRepository
+	debounceRepo          *DebounceRepository
+	alertRepo             *AlertRepository
+}
+
+// NewPersistence creates a new, empty in-memory persistence.
+func NewPersistence() persistence.Persistence {
+	return &Persistence{
+		workflowRepo:          NewWorkflowRepository(),
+		executionContextRepo:  NewExecutionContextRepository(),
+		inputCoordinationRepo: NewInputCoordinationRepository(),
+		debounceRepo:          NewDebounceRepository(),
+		alertRepo:             NewAlertRepository(),
+	}
+}
+
+// Close performs any necessary cleanup. For in-memory persistence, there is nothing to clean up.
+func (mp *Persistence) Close(_ context.Context) error {
+	return nil
+}
+
+// HealthCheck always succeeds, as the in-memory persistence has no external dependency.
+func (mp *Persistence) HealthCheck(_ context.Context) error {
+	return nil
+}
+
+// WorkflowRepository returns the workflow repository implementation for in-memory persistence.
+func (mp *Persistence) WorkflowRepository() persistence.WorkflowRepository {
+	return mp.workflowRepo
+}
+
+// NodeRepository returns the node repository, which reads and updates the nodes stored within
+// their workflows.
+func (mp *Persistence) NodeRepository() persistence.NodeRepository {
+	return &nodeRepository{workflows: mp.workflowRepo}
+}
+
+// ConnectionRepository returns the connection repository, which reads and updates the
+// connections stored within their workflows.
+func (mp *Persistence) ConnectionRepository() persistence.ConnectionRepository {
+	return &connectionRepository{nodes: nodeRepository{workflows: mp.workflowRepo}}
+}
+
+func (mp *Persistence) ExecutionContextRepository() persistence.ExecutionContextRepository {
+	return mp.executionContextRepo
+}
+
+func (mp *Persistence) InputCoordinationRepository() persistence.InputCoordinationRepository {
+	return mp.inputCoordinationRepo
+}
+
+func (mp *Persistence) DebounceRepository() persistence.DebounceRepository {
+	return mp.debounceRepo
+}
+
+func (mp *Persistence) AlertRepository() persistence.AlertRepository {
+	return mp.alertRepo
+}
+
+// encode serializes a value for storage. Values are kept as JSON, like in the file store, so
+// callers never share memory with the stored copy and see the same round trip as other stores.
+func encode(value any) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// decode returns a new copy of a stored value.
+func decode[T any](data []byte) (*T, error) {
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
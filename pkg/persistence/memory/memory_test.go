@@ -0,0 +1,291 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestWorkflow creates a complete workflow for integration testing.
+func createTestWorkflow() *models.Workflow {
+	stringPtr := func(s string) *string { return &s }
+
+	return &models.Workflow{
+		ID:   "integration-workflow-complete",
+		Name: "Integration Test Complete Workflow",
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:         "kafka-trigger",
+				Name:       "Kafka Order Trigger",
+				Type:       "trigger:kafka",
+				Category:   models.CategoryTypeTrigger,
+				SourceID:   stringPtr("kafka-source"),
+				EventType:  stringPtr("kafka_message"),
+				ProviderID: stringPtr("kafka"),
+				Config:     map[string]any{"topic": "orders", "consumer_group": "order-processor"},
+				Enabled:    true,
+			},
+			{
+				ID:       "transform-order",
+				Name:     "Transform Order Data",
+				Type:     "transform",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"expression": `{"orderId": "{{.trigger_data.message.id}}"}`},
+				Enabled:  true,
+			},
+			{
+				ID:       "validate-order",
+				Name:     "Validate Order",
+				Type:     "httprequest",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"url": "https://api.example.com/validate", "method": "POST"},
+				Enabled:  true,
+			},
+			{
+				ID:       "log-result",
+				Name:     "Log Processing Result",
+				Type:     "log",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"message": "Order processed", "level": "info"},
+				Enabled:  true,
+			},
+		},
+		Connections: []*models.Connection{
+			{ID: "trigger-to-transform", SourcePort: "kafka-trigger:success", TargetPort: "transform-order:main"},
+			{ID: "transform-to-validate", SourcePort: "transform-order:success", TargetPort: "validate-order:main"},
+			{ID: "validate-to-log", SourcePort: "validate-order:success", TargetPort: "log-result:main"},
+		},
+		Variables: map[string]any{"api_timeout": 30, "retry_count": 3},
+		Metadata:  map[string]any{"version": "1.0"},
+		Status:    models.WorkflowStatusPublished,
+	}
+}
+
+func TestNodeBasedWorkflowExecution_CompleteFlow(t *testing.T) {
+	p := NewPersistence()
+	ctx := context.Background()
+
+	workflow := createTestWorkflow()
+	require.NoError(t, p.WorkflowRepository().Save(ctx, workflow))
+
+	// Nodes
+	nodeRepo := p.NodeRepository()
+
+	allNodes, err := nodeRepo.GetNodesByWorkflow(ctx, workflow.ID)
+	require.NoError(t, err)
+	assert.Len(t, allNodes, 4)
+
+	triggerNode, err := nodeRepo.GetNodeByWorkflow(ctx, workflow.ID, "kafka-trigger")
+	require.NoError(t, err)
+	assert.Equal(t, models.CategoryTypeTrigger, triggerNode.Category)
+	assert.Equal(t, "kafka-source", *triggerNode.SourceID)
+
+	triggerMatches, err := nodeRepo.FindTriggerNodesBySourceEventAndProvider(
+		ctx, "kafka-source", "kafka_message", "kafka", models.WorkflowStatusPublished)
+	require.NoError(t, err)
+	require.Len(t, triggerMatches, 1)
+	assert.Equal(t, workflow.ID, triggerMatches[0].WorkflowID)
+	assert.Equal(t, "kafka-trigger", triggerMatches[0].TriggerNode.ID)
+
+	// Connections
+	connRepo := p.ConnectionRepository()
+
+	allConnections, err := connRepo.GetConnectionsByWorkflow(ctx, workflow.ID)
+	require.NoError(t, err)
+	assert.Len(t, allConnections, 3)
+
+	triggerConnections, err := connRepo.GetConnectionsBySourceNode(ctx, workflow.ID, "kafka-trigger")
+	require.NoError(t, err)
+	require.Len(t, triggerConnections, 1)
+	assert.Equal(t, "transform-order:main", triggerConnections[0].TargetPort)
+
+	transformConnections, err := connRepo.GetConnectionsByTargetNode(ctx, workflow.ID, "transform-order")
+	require.NoError(t, err)
+	require.Len(t, transformConnections, 1)
+	assert.Equal(t, "trigger-to-transform", transformConnections[0].ID)
+
+	// Execution contexts
+	execRepo := p.ExecutionContextRepository()
+
+	executionCtx := &models.ExecutionContext{
+		ID:         "exec-integration-test",
+		WorkflowID: workflow.ID,
+		Status:     models.ExecutionStatusRunning,
+		NodeResults: map[string]models.NodeResult{
+			"kafka-trigger::success": {NodeID: "kafka-trigger", Data: map[string]any{"message_id": "msg-12345"}, Status: string(models.NodeStatusSuccess)},
+		},
+		TriggerData: map[string]any{"message": map[string]any{"id": "order-67890", "total": 99.99}},
+		CreatedAt:   time.Now().Add(-time.Minute),
+	}
+	require.NoError(t, execRepo.SaveExecutionContext(ctx, executionCtx))
+
+	retrievedCtx, err := execRepo.GetExecutionContext(ctx, executionCtx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, executionCtx.WorkflowID, retrievedCtx.WorkflowID)
+	assert.Equal(t, models.ExecutionStatusRunning, retrievedCtx.Status)
+	assert.Equal(t, executionCtx.NodeResults, retrievedCtx.NodeResults)
+
+	executionCtx.Status = models.ExecutionStatusCompleted
+	require.NoError(t, execRepo.UpdateExecutionContext(ctx, executionCtx))
+
+	workflowExecutions, err := execRepo.GetExecutionsByWorkflow(ctx, workflow.ID)
+	require.NoError(t, err)
+	require.Len(t, workflowExecutions, 1)
+	assert.Equal(t, models.ExecutionStatusCompleted, workflowExecutions[0].Status)
+
+	completedExecutions, err := execRepo.GetExecutionsByStatus(ctx, models.ExecutionStatusCompleted)
+	require.NoError(t, err)
+	require.Len(t, completedExecutions, 1)
+	assert.Equal(t, "exec-integration-test", completedExecutions[0].ID)
+
+	page, err := execRepo.ListNodeResults(ctx, executionCtx.ID, models.NodeResultQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Results, 1)
+	assert.Equal(t, "kafka-trigger", page.Results[0].NodeID)
+}
+
+func TestNodeBasedWorkflowExecution_ErrorScenarios(t *testing.T) {
+	p := NewPersistence()
+	ctx := context.Background()
+
+	_, err := p.NodeRepository().GetNodesByWorkflow(ctx, "non-existent-workflow")
+	require.Error(t, err)
+
+	_, err = p.NodeRepository().GetNodeByWorkflow(ctx, "non-existent-workflow", "some-node")
+	require.Error(t, err)
+
+	matches, err := p.NodeRepository().FindTriggerNodesBySourceEventAndProvider(
+		ctx, "any-source", "any-event", "any-provider", models.WorkflowStatusPublished)
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+
+	executions, err := p.ExecutionContextRepository().GetExecutionsByWorkflow(ctx, "any-workflow")
+	require.NoError(t, err)
+	assert.Empty(t, executions)
+
+	_, err = p.ExecutionContextRepository().GetExecutionContext(ctx, "missing")
+	require.ErrorIs(t, err, persistence.ErrExecutionContextNotFound)
+
+	err = p.ExecutionContextRepository().UpdateExecutionContext(ctx, &models.ExecutionContext{ID: "missing"})
+	require.ErrorIs(t, err, persistence.ErrExecutionContextNotFound)
+
+	_, err = p.InputCoordinationRepository().LoadInputState(ctx, "missing")
+	require.Error(t, err)
+}
+
+func TestWorkflowRepository_VersionsAndSoftDelete(t *testing.T) {
+	p := NewPersistence()
+	ctx := context.Background()
+	repo := p.WorkflowRepository()
+
+	workflow := &models.Workflow{ID: "orders", Name: "Orders", Status: models.WorkflowStatusDraft}
+	require.NoError(t, repo.Save(ctx, workflow))
+	require.NoError(t, repo.PublishWorkflow(ctx, "orders"))
+
+	draft, err := repo.CreateDraftFromPublished(ctx, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, models.WorkflowStatusDraft, draft.Status)
+	assert.Equal(t, "orders", draft.WorkflowGroupID)
+
+	require.NoError(t, repo.PublishWorkflow(ctx, draft.ID))
+
+	published, err := repo.GetPublishedWorkflow(ctx, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, draft.ID, published.ID)
+
+	previous, err := repo.GetByID(ctx, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, models.WorkflowStatusUnpublished, previous.Status)
+
+	require.NoError(t, repo.Delete(ctx, "orders"))
+
+	deleted, err := repo.GetByID(ctx, "orders")
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+
+	versions, err := repo.GetWorkflowVersions(ctx, "orders")
+	require.NoError(t, err)
+	assert.Len(t, versions, 1)
+
+	require.NoError(t, repo.Restore(ctx, "orders"))
+
+	page, err := repo.ListWorkflows(ctx, models.ListOptions{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, page.Workflows, 1)
+	assert.NotEmpty(t, page.NextCursor)
+}
+
+func TestPersistence_ReturnsCopies(t *testing.T) {
+	p := NewPersistence()
+	ctx := context.Background()
+
+	workflow := createTestWorkflow()
+	require.NoError(t, p.WorkflowRepository().Save(ctx, workflow))
+
+	// Changing the saved or the returned workflow does not change the stored one
+	workflow.Name = "changed after save"
+
+	retrieved, err := p.WorkflowRepository().GetByID(ctx, workflow.ID)
+	require.NoError(t, err)
+	retrieved.Nodes[0].Config["topic"] = "changed after get"
+
+	stored, err := p.WorkflowRepository().GetByID(ctx, workflow.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Integration Test Complete Workflow", stored.Name)
+	assert.Equal(t, "orders", stored.Nodes[0].Config["topic"])
+}
+
+func TestPersistence_ConcurrentAccess(t *testing.T) {
+	p := NewPersistence()
+	ctx := context.Background()
+
+	require.NoError(t, p.WorkflowRepository().Save(ctx, &models.Workflow{ID: "concurrent", Status: models.WorkflowStatusPublished}))
+
+	const workers = 50
+
+	var wg sync.WaitGroup
+
+	for i := range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			nodeID := fmt.Sprintf("node-%d", i)
+			assert.NoError(t, p.NodeRepository().SaveNode(ctx, "concurrent", &models.WorkflowNode{ID: nodeID, Type: "log"}))
+
+			assert.NoError(t, p.ExecutionContextRepository().SaveExecutionContext(ctx, &models.ExecutionContext{
+				ID: "exec-" + nodeID, WorkflowID: "concurrent", Status: models.ExecutionStatusRunning,
+			}))
+
+			assert.NoError(t, p.InputCoordinationRepository().SaveInputState(ctx, &models.NodeInputState{
+				NodeExecutionID: "state-" + nodeID, NodeID: nodeID, ExecutionID: "exec-" + nodeID, CreatedAt: time.Now(),
+			}))
+
+			_, err := p.WorkflowRepository().GetAll(ctx)
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	// No node added concurrently to the same workflow is lost
+	nodes, err := p.NodeRepository().GetNodesByWorkflow(ctx, "concurrent")
+	require.NoError(t, err)
+	assert.Len(t, nodes, workers)
+
+	executions, err := p.ExecutionContextRepository().GetExecutionsByWorkflow(ctx, "concurrent")
+	require.NoError(t, err)
+	assert.Len(t, executions, workers)
+
+	state, err := p.InputCoordinationRepository().FindPendingNodeExecution(ctx, "node-7", "exec-node-7")
+	require.NoError(t, err)
+	assert.Equal(t, "state-node-7", state.NodeExecutionID)
+}
@@ -0,0 +1,213 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// nodeRepository reads and updates the nodes stored within their workflows.
+type nodeRepository struct {
+	workflows *WorkflowRepository
+}
+
+// workflow returns a workflow that exists and is not deleted.
+func (nr *nodeRepository) workflow(ctx context.Context, workflowID string) (*models.Workflow, error) {
+	workflow, err := nr.workflows.GetByID(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow %s: %w", workflowID, err)
+	}
+
+	if workflow == nil {
+		return nil, fmt.Errorf("workflow not found: %s", workflowID)
+	}
+
+	return workflow, nil
+}
+
+func (nr *nodeRepository) GetNodesByWorkflow(ctx context.Context, workflowID string) ([]*models.WorkflowNode, error) {
+	workflow, err := nr.workflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	return workflow.Nodes, nil
+}
+
+func (nr *nodeRepository) GetNodeByWorkflow(ctx context.Context, workflowID, nodeID string) (*models.WorkflowNode, error) {
+	workflow, err := nr.workflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range workflow.Nodes {
+		if node.ID == nodeID {
+			return node, nil
+		}
+	}
+
+	return nil, fmt.Errorf("node not found: %s in workflow %s", nodeID, workflowID)
+}
+
+func (nr *nodeRepository) SaveNode(_ context.Context, workflowID string, node *models.WorkflowNode) error {
+	return nr.workflows.update(workflowID, func(workflow *models.Workflow) error {
+		for i, existingNode := range workflow.Nodes {
+			if existingNode.ID == node.ID {
+				workflow.Nodes[i] = node
+
+				return nil
+			}
+		}
+
+		workflow.Nodes = append(workflow.Nodes, node)
+
+		return nil
+	})
+}
+
+func (nr *nodeRepository) UpdateNode(ctx context.Context, workflowID string, node *models.WorkflowNode) error {
+	return nr.SaveNode(ctx, workflowID, node)
+}
+
+func (nr *nodeRepository) UpdateNodePositions(_ context.Context, workflowID string, positions map[string]models.NodePosition) error {
+	return nr.workflows.update(workflowID, func(workflow *models.Workflow) error {
+		nodes := make(map[string]*models.WorkflowNode, len(workflow.Nodes))
+		for _, node := range workflow.Nodes {
+			nodes[node.ID] = node
+		}
+
+		for nodeID := range positions {
+			if _, exists := nodes[nodeID]; !exists {
+				return fmt.Errorf("node not found: %s in workflow %s", nodeID, workflowID)
+			}
+		}
+
+		for nodeID, position := range positions {
+			nodes[nodeID].PositionX = position.X
+			nodes[nodeID].PositionY = position.Y
+		}
+
+		return nil
+	})
+}
+
+func (nr *nodeRepository) DeleteNode(_ context.Context, workflowID, nodeID string) error {
+	return nr.workflows.update(workflowID, func(workflow *models.Workflow) error {
+		for i, node := range workflow.Nodes {
+			if node.ID == nodeID {
+				workflow.Nodes = append(workflow.Nodes[:i], workflow.Nodes[i+1:]...)
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("node not found: %s in workflow %s", nodeID, workflowID)
+	})
+}
+
+func (nr *nodeRepository) FindTriggerNodesBySourceEventAndProvider(ctx context.Context, sourceID, eventType, providerID string, status models.WorkflowStatus) ([]*models.TriggerNodeMatch, error) {
+	workflows, err := nr.workflows.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflows: %w", err)
+	}
+
+	var matches []*models.TriggerNodeMatch
+
+	for _, workflow := range workflows {
+		if status != "" && workflow.Status != status {
+			continue
+		}
+
+		for _, node := range workflow.Nodes {
+			if node.Category == models.CategoryTypeTrigger &&
+				node.SourceID != nil && *node.SourceID == sourceID &&
+				node.EventType != nil && *node.EventType == eventType &&
+				node.ProviderID != nil && *node.ProviderID == providerID &&
+				node.Enabled {
+				matches = append(matches, &models.TriggerNodeMatch{
+					WorkflowID:  workflow.ID,
+					TriggerNode: node,
+				})
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// connectionRepository reads and updates the connections stored within their workflows.
+type connectionRepository struct {
+	nodes nodeRepository
+}
+
+func (cr *connectionRepository) GetConnectionsByWorkflow(ctx context.Context, workflowID string) ([]*models.Connection, error) {
+	workflow, err := cr.nodes.workflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	return workflow.Connections, nil
+}
+
+func (cr *connectionRepository) GetConnectionsBySourceNode(ctx context.Context, workflowID, sourceNodeID string) ([]*models.Connection, error) {
+	return cr.connectionsOfNode(ctx, workflowID, sourceNodeID, func(conn *models.Connection) string { return conn.SourcePort })
+}
+
+func (cr *connectionRepository) GetConnectionsByTargetNode(ctx context.Context, workflowID, targetNodeID string) ([]*models.Connection, error) {
+	return cr.connectionsOfNode(ctx, workflowID, targetNodeID, func(conn *models.Connection) string { return conn.TargetPort })
+}
+
+// connectionsOfNode returns the connections of a workflow whose port, as selected by port,
+// belongs to nodeID.
+func (cr *connectionRepository) connectionsOfNode(ctx context.Context, workflowID, nodeID string, port func(*models.Connection) string) ([]*models.Connection, error) {
+	workflow, err := cr.nodes.workflow(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var connections []*models.Connection
+
+	for _, conn := range workflow.Connections {
+		portNodeID, _, ok := models.ParsePortID(port(conn))
+		if ok && portNodeID == nodeID {
+			connections = append(connections, conn)
+		}
+	}
+
+	return connections, nil
+}
+
+func (cr *connectionRepository) SaveConnection(_ context.Context, workflowID string, connection *models.Connection) error {
+	return cr.nodes.workflows.update(workflowID, func(workflow *models.Workflow) error {
+		for i, existingConn := range workflow.Connections {
+			if existingConn.ID == connection.ID {
+				workflow.Connections[i] = connection
+
+				return nil
+			}
+		}
+
+		workflow.Connections = append(workflow.Connections, connection)
+
+		return nil
+	})
+}
+
+func (cr *connectionRepository) UpdateConnection(ctx context.Context, workflowID string, connection *models.Connection) error {
+	return cr.SaveConnection(ctx, workflowID, connection)
+}
+
+func (cr *connectionRepository) DeleteConnection(_ context.Context, workflowID, connectionID string) error {
+	return cr.nodes.workflows.update(workflowID, func(workflow *models.Workflow) error {
+		for i, conn := range workflow.Connections {
+			if conn.ID == connectionID {
+				workflow.Connections = append(workflow.Connections[:i], workflow.Connections[i+1:]...)
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("connection not found: %s in workflow %s", connectionID, workflowID)
+	})
+}
@@ -0,0 +1,379 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// WorkflowRepository stores workflows in memory.
+type WorkflowRepository struct {
+	mu        sync.RWMutex
+	workflows map[string][]byte
+}
+
+// NewWorkflowRepository creates a new, empty workflow repository.
+func NewWorkflowRepository() *WorkflowRepository {
+	return &WorkflowRepository{workflows: make(map[string][]byte)}
+}
+
+// GetAll returns all workflows, except deleted ones.
+func (wr *WorkflowRepository) GetAll(_ context.Context) ([]*models.Workflow, error) {
+	wr.mu.RLock()
+	defer wr.mu.RUnlock()
+
+	return wr.readAll(false)
+}
+
+// readAll returns every workflow ordered by ID, skipping soft deleted workflows unless
+// includeDeleted is set. The caller holds the lock.
+func (wr *WorkflowRepository) readAll(includeDeleted bool) ([]*models.Workflow, error) {
+	workflows := make([]*models.Workflow, 0, len(wr.workflows))
+
+	for id := range wr.workflows {
+		workflow, err := wr.read(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if workflow.DeletedAt != nil && !includeDeleted {
+			continue
+		}
+
+		workflows = append(workflows, workflow)
+	}
+
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].ID < workflows[j].ID })
+
+	return workflows, nil
+}
+
+// read returns a copy of a workflow, nil when there is none. The caller holds the lock.
+func (wr *WorkflowRepository) read(workflowID string) (*models.Workflow, error) {
+	data, exists := wr.workflows[workflowID]
+	if !exists {
+		return nil, nil
+	}
+
+	workflow, err := decode[models.Workflow](data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workflow %s: %w", workflowID, err)
+	}
+
+	return workflow, nil
+}
+
+// write stores a workflow, stamping its timestamps and group like the other stores. The caller
+// holds the write lock.
+func (wr *WorkflowRepository) write(workflow *models.Workflow) error {
+	now := time.Now().UTC()
+	if workflow.CreatedAt.IsZero() {
+		workflow.CreatedAt = now
+	}
+
+	workflow.UpdatedAt = now
+
+	// A new workflow starts its own group, like in the postgres store
+	if workflow.WorkflowGroupID == "" {
+		workflow.WorkflowGroupID = workflow.ID
+	}
+
+	data, err := encode(workflow)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workflow %s: %w", workflow.ID, err)
+	}
+
+	wr.workflows[workflow.ID] = data
+
+	return nil
+}
+
+// update applies fn to a copy of a workflow that is not deleted and stores the result, holding
+// the lock throughout so concurrent updates of the same workflow are not lost.
+func (wr *WorkflowRepository) update(workflowID string, fn func(workflow *models.Workflow) error) error {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	workflow, err := wr.read(workflowID)
+	if err != nil {
+		return fmt.Errorf("failed to get workflow %s: %w", workflowID, err)
+	}
+
+	if workflow == nil || workflow.DeletedAt != nil {
+		return fmt.Errorf("workflow not found: %s", workflowID)
+	}
+
+	if err := fn(workflow); err != nil {
+		return err
+	}
+
+	return wr.write(workflow)
+}
+
+// ListWorkflows returns a page of workflows ordered by creation time, newest first.
+func (wr *WorkflowRepository) ListWorkflows(_ context.Context, opts models.ListOptions) (*models.WorkflowPage, error) {
+	wr.mu.RLock()
+	workflows, err := wr.readAll(opts.IncludeDeleted)
+	wr.mu.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(workflows, func(i, j int) bool {
+		if workflows[i].CreatedAt.Equal(workflows[j].CreatedAt) {
+			return workflows[i].ID > workflows[j].ID
+		}
+
+		return workflows[i].CreatedAt.After(workflows[j].CreatedAt)
+	})
+
+	if opts.Cursor != "" {
+		cursor, err := models.DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := make([]*models.Workflow, 0, len(workflows))
+
+		for _, workflow := range workflows {
+			if cursor.Includes(workflow.CreatedAt, workflow.ID) {
+				remaining = append(remaining, workflow)
+			}
+		}
+
+		workflows = remaining
+	} else if opts.Offset > 0 {
+		workflows = workflows[min(opts.Offset, len(workflows)):]
+	}
+
+	page := &models.WorkflowPage{Workflows: workflows}
+
+	if opts.Limit > 0 && len(workflows) > opts.Limit {
+		page.Workflows = workflows[:opts.Limit]
+		last := page.Workflows[opts.Limit-1]
+		page.NextCursor = models.EncodeCursor(models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return page, nil
+}
+
+// GetByID retrieves a workflow by its ID, nil when it does not exist or is deleted.
+func (wr *WorkflowRepository) GetByID(_ context.Context, workflowID string) (*models.Workflow, error) {
+	wr.mu.RLock()
+	defer wr.mu.RUnlock()
+
+	workflow, err := wr.read(workflowID)
+	if err != nil || workflow == nil || workflow.DeletedAt != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// GetByIDIncludingDeleted retrieves a workflow by its ID, even when it is soft deleted.
+func (wr *WorkflowRepository) GetByIDIncludingDeleted(_ context.Context, workflowID string) (*models.Workflow, error) {
+	wr.mu.RLock()
+	defer wr.mu.RUnlock()
+
+	return wr.read(workflowID)
+}
+
+// Save stores a workflow, replacing the previous one with the same ID.
+func (wr *WorkflowRepository) Save(_ context.Context, workflow *models.Workflow) error {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	return wr.write(workflow)
+}
+
+// Delete soft deletes a workflow by setting its deleted_at timestamp.
+func (wr *WorkflowRepository) Delete(_ context.Context, id string) error {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	workflow, err := wr.read(id)
+	if err != nil {
+		return fmt.Errorf("failed to delete workflow %s: %w", id, err)
+	}
+
+	// Workflow doesn't exist or already deleted - this is not an error
+	if workflow == nil || workflow.DeletedAt != nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	workflow.DeletedAt = &now
+
+	return wr.write(workflow)
+}
+
+// Restore clears the deleted_at timestamp of a soft deleted workflow.
+func (wr *WorkflowRepository) Restore(_ context.Context, id string) error {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	workflow, err := wr.read(id)
+	if err != nil {
+		return fmt.Errorf("failed to restore workflow %s: %w", id, err)
+	}
+
+	if workflow == nil || workflow.DeletedAt == nil {
+		return nil
+	}
+
+	workflow.DeletedAt = nil
+
+	return wr.write(workflow)
+}
+
+// GetCurrentWorkflow returns the current version (published if exists, otherwise draft).
+func (wr *WorkflowRepository) GetCurrentWorkflow(ctx context.Context, workflowGroupID string) (*models.Workflow, error) {
+	published, err := wr.GetPublishedWorkflow(ctx, workflowGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if published != nil {
+		return published, nil
+	}
+
+	return wr.GetDraftWorkflow(ctx, workflowGroupID)
+}
+
+// GetDraftWorkflow returns the latest draft version of a workflow group.
+func (wr *WorkflowRepository) GetDraftWorkflow(_ context.Context, workflowGroupID string) (*models.Workflow, error) {
+	wr.mu.RLock()
+	defer wr.mu.RUnlock()
+
+	return wr.latest(workflowGroupID, models.WorkflowStatusDraft)
+}
+
+// GetPublishedWorkflow returns the published version of a workflow group.
+func (wr *WorkflowRepository) GetPublishedWorkflow(_ context.Context, workflowGroupID string) (*models.Workflow, error) {
+	wr.mu.RLock()
+	defer wr.mu.RUnlock()
+
+	return wr.latest(workflowGroupID, models.WorkflowStatusPublished)
+}
+
+// latest returns the most recently created workflow of a group with the given status. The
+// caller holds the lock.
+func (wr *WorkflowRepository) latest(workflowGroupID string, status models.WorkflowStatus) (*models.Workflow, error) {
+	workflows, err := wr.readAll(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all workflows: %w", err)
+	}
+
+	var latest *models.Workflow
+
+	for _, workflow := range workflows {
+		if workflow.WorkflowGroupID == workflowGroupID && workflow.Status == status {
+			if latest == nil || workflow.CreatedAt.After(latest.CreatedAt) {
+				latest = workflow
+			}
+		}
+	}
+
+	return latest, nil
+}
+
+// PublishWorkflow publishes a workflow and unpublishes the other published versions of its group.
+func (wr *WorkflowRepository) PublishWorkflow(_ context.Context, workflowID string) error {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	workflow, err := wr.read(workflowID)
+	if err != nil {
+		return fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	if workflow == nil || workflow.DeletedAt != nil {
+		return fmt.Errorf("workflow not found: %s", workflowID)
+	}
+
+	allWorkflows, err := wr.readAll(false)
+	if err != nil {
+		return fmt.Errorf("failed to get all workflows: %w", err)
+	}
+
+	for _, wf := range allWorkflows {
+		if wf.WorkflowGroupID == workflow.WorkflowGroupID && wf.Status == models.WorkflowStatusPublished {
+			wf.Status = models.WorkflowStatusUnpublished
+			if err := wr.write(wf); err != nil {
+				return fmt.Errorf("failed to unpublish workflow %s: %w", wf.ID, err)
+			}
+		}
+	}
+
+	workflow.Status = models.WorkflowStatusPublished
+
+	if workflow.PublishedAt == nil {
+		now := time.Now().UTC()
+		workflow.PublishedAt = &now
+	}
+
+	return wr.write(workflow)
+}
+
+// CreateDraftFromPublished creates a draft copy of the published version of a workflow group,
+// or returns the existing draft.
+func (wr *WorkflowRepository) CreateDraftFromPublished(_ context.Context, workflowGroupID string) (*models.Workflow, error) {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+
+	existingDraft, err := wr.latest(workflowGroupID, models.WorkflowStatusDraft)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing draft: %w", err)
+	}
+
+	if existingDraft != nil {
+		return existingDraft, nil
+	}
+
+	draftWorkflow, err := wr.latest(workflowGroupID, models.WorkflowStatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get published workflow: %w", err)
+	}
+
+	if draftWorkflow == nil {
+		return nil, fmt.Errorf("no published workflow found for group: %s", workflowGroupID)
+	}
+
+	draftWorkflow.ID = workflowGroupID + "-draft-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	draftWorkflow.Status = models.WorkflowStatusDraft
+	draftWorkflow.CreatedAt = time.Now().UTC()
+	draftWorkflow.PublishedAt = nil
+
+	if err := wr.write(draftWorkflow); err != nil {
+		return nil, fmt.Errorf("failed to save draft workflow: %w", err)
+	}
+
+	return draftWorkflow, nil
+}
+
+// GetWorkflowVersions returns all versions of a workflow by group ID.
+func (wr *WorkflowRepository) GetWorkflowVersions(_ context.Context, workflowGroupID string) ([]*models.Workflow, error) {
+	wr.mu.RLock()
+	defer wr.mu.RUnlock()
+
+	workflows, err := wr.readAll(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all workflows: %w", err)
+	}
+
+	var versions []*models.Workflow
+
+	for _, workflow := range workflows {
+		if workflow.WorkflowGroupID == workflowGroupID {
+			versions = append(versions, workflow)
+		}
+	}
+
+	return versions, nil
+}
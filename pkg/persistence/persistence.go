@@ -3,6 +3,7 @@ package persistence
 
 import (
 	"context"
+	"time"
 
 	"github.com/dukex/operion/pkg/models"
 )
@@ -17,6 +18,8 @@ type Persistence interface {
 	ConnectionRepository() ConnectionRepository
 	ExecutionContextRepository() ExecutionContextRepository
 	InputCoordinationRepository() InputCoordinationRepository
+	DebounceRepository() DebounceRepository
+	AlertRepository() AlertRepository
 
 	Close(ctx context.Context) error
 }
@@ -25,9 +28,14 @@ type Persistence interface {
 type WorkflowRepository interface {
 	// Basic CRUD operations
 	GetAll(ctx context.Context) ([]*models.Workflow, error)
+	ListWorkflows(ctx context.Context, opts models.ListOptions) (*models.WorkflowPage, error)
 	Save(ctx context.Context, workflow *models.Workflow) error
 	GetByID(ctx context.Context, id string) (*models.Workflow, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string) error // soft delete, setting deleted_at
+
+	// Soft delete recovery
+	GetByIDIncludingDeleted(ctx context.Context, id string) (*models.Workflow, error)
+	Restore(ctx context.Context, id string) error // clears deleted_at
 
 	// Simplified versioning methods
 	GetWorkflowVersions(ctx context.Context, workflowGroupID string) ([]*models.Workflow, error)
@@ -49,6 +57,10 @@ type NodeRepository interface {
 	UpdateNode(ctx context.Context, workflowID string, node *models.WorkflowNode) error
 	DeleteNode(ctx context.Context, workflowID, nodeID string) error
 
+	// UpdateNodePositions sets the editor positions of nodes by ID in one write, leaving the
+	// rest of the nodes unchanged. Every node must exist, otherwise nothing is updated.
+	UpdateNodePositions(ctx context.Context, workflowID string, positions map[string]models.NodePosition) error
+
 	// Trigger node operations
 	FindTriggerNodesBySourceEventAndProvider(ctx context.Context, sourceID, eventType, providerID string, status models.WorkflowStatus) ([]*models.TriggerNodeMatch, error)
 }
@@ -71,6 +83,40 @@ type ExecutionContextRepository interface {
 	SaveExecutionContext(ctx context.Context, execCtx *models.ExecutionContext) error
 	GetExecutionContext(ctx context.Context, executionID string) (*models.ExecutionContext, error)
 	UpdateExecutionContext(ctx context.Context, execCtx *models.ExecutionContext) error
+	DeleteExecutionContext(ctx context.Context, executionID string) error
 	GetExecutionsByWorkflow(ctx context.Context, workflowID string) ([]*models.ExecutionContext, error)
 	GetExecutionsByStatus(ctx context.Context, status models.ExecutionStatus) ([]*models.ExecutionContext, error)
+	GetExecutionStats(ctx context.Context, workflowID string, from, to time.Time) (*models.ExecutionStats, error)
+
+	// GetLastRunAt returns when the latest execution of a workflow that ran started, without
+	// loading its executions where the store allows it, or nil when the workflow never ran.
+	GetLastRunAt(ctx context.Context, workflowID string) (*time.Time, error)
+
+	// ListNodeResults returns a page of the node results of an execution, without loading the rest
+	// of the execution context where the store allows it.
+	ListNodeResults(ctx context.Context, executionID string, query models.NodeResultQuery) (*models.NodeResultPage, error)
+
+	// GetExecutionByApprovalToken returns the paused execution holding the pending approval
+	// request with token, or ErrExecutionContextNotFound.
+	GetExecutionByApprovalToken(ctx context.Context, token string) (*models.ExecutionContext, error)
+
+	// GetExecutionsWithExpiredApprovals returns the paused executions holding a pending approval
+	// request expired by now.
+	GetExecutionsWithExpiredApprovals(ctx context.Context, now time.Time) ([]*models.ExecutionContext, error)
+
+	// ClaimApproval replaces the approval request of an execution with the same token by request,
+	// only while the stored request is still pending. It reports whether the request was claimed,
+	// so that a single process acts on each decision.
+	ClaimApproval(ctx context.Context, executionID string, request models.ApprovalRequest) (bool, error)
+
+	// ClaimRetry takes one retry from the retry budget of an execution in a single atomic update,
+	// reporting false without taking it once budget retries were used, so that nodes retrying at
+	// the same time never exceed the budget. A budget of 0 or less does not limit retries.
+	ClaimRetry(ctx context.Context, executionID string, budget int) (bool, error)
+
+	// AddPendingActivations adds delta to the count of node activations of an execution published
+	// and not handled yet, in a single atomic update, and returns the new count. The count is kept
+	// apart from the execution context, so saving a context never overwrites it. The worker
+	// completes a running execution once its count falls back to zero.
+	AddPendingActivations(ctx context.Context, executionID string, delta int) (int, error)
 }
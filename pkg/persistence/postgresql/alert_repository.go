@@ -0,0 +1,66 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// AlertRepository handles the last alert times of workflows in PostgreSQL.
+type AlertRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+// NewAlertRepository creates a new alert repository.
+func NewAlertRepository(db *sql.DB, logger *slog.Logger) *AlertRepository {
+	return &AlertRepository{db: db, logger: logger}
+}
+
+// ClaimAlert records an alert of the workflow at now with a single upsert, which leaves a last
+// alert recorded within cooldown untouched.
+func (ar *AlertRepository) ClaimAlert(ctx context.Context, workflowID string, now time.Time, cooldown time.Duration) (bool, error) {
+	query := `
+		INSERT INTO workflow_alerts (workflow_id, last_alert_at)
+		VALUES ($1, $2)
+		ON CONFLICT (workflow_id) DO UPDATE SET last_alert_at = EXCLUDED.last_alert_at
+		WHERE workflow_alerts.last_alert_at <= $3
+	`
+
+	result, err := ar.db.ExecContext(ctx, query, workflowID, now, now.Add(-cooldown))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim alert: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// ClaimHeartbeatAlert records an alert of a scheduler trigger for the run expected at
+// expectedRunAt with a single upsert, which leaves a trigger already alerted for that run untouched.
+func (ar *AlertRepository) ClaimHeartbeatAlert(ctx context.Context, workflowID, triggerNodeID string, expectedRunAt time.Time) (bool, error) {
+	query := `
+		INSERT INTO heartbeat_alerts (workflow_id, trigger_node_id, expected_run_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (workflow_id, trigger_node_id) DO UPDATE SET expected_run_at = EXCLUDED.expected_run_at
+		WHERE heartbeat_alerts.expected_run_at <> EXCLUDED.expected_run_at
+	`
+
+	result, err := ar.db.ExecContext(ctx, query, workflowID, triggerNodeID, expectedRunAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim heartbeat alert: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
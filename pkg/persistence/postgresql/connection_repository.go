@@ -24,7 +24,7 @@ func NewConnectionRepository(db *sql.DB, logger *slog.Logger) *ConnectionReposit
 // GetConnectionsBySourceNode retrieves connections from a workflow filtered by source node ID.
 func (cr *ConnectionRepository) GetConnectionsBySourceNode(ctx context.Context, workflowID, sourceNodeID string) ([]*models.Connection, error) {
 	query := `
-		SELECT id, source_node_id, source_port, target_node_id, target_port
+		SELECT id, source_node_id, source_port, target_node_id, target_port, transform
 		FROM workflow_connections
 		WHERE workflow_id = $1 AND source_node_id = $2
 		ORDER BY created_at
@@ -62,7 +62,7 @@ func (cr *ConnectionRepository) GetConnectionsBySourceNode(ctx context.Context,
 // GetConnectionsByTargetNode retrieves connections from a workflow filtered by target node ID.
 func (cr *ConnectionRepository) GetConnectionsByTargetNode(ctx context.Context, workflowID, targetNodeID string) ([]*models.Connection, error) {
 	query := `
-		SELECT id, source_node_id, source_port, target_node_id, target_port
+		SELECT id, source_node_id, source_port, target_node_id, target_port, transform
 		FROM workflow_connections
 		WHERE workflow_id = $1 AND target_node_id = $2
 		ORDER BY created_at
@@ -111,13 +111,14 @@ func (cr *ConnectionRepository) SaveConnection(ctx context.Context, workflowID s
 	}
 
 	query := `
-		INSERT INTO workflow_connections (id, workflow_id, source_node_id, source_port, target_node_id, target_port, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		INSERT INTO workflow_connections (id, workflow_id, source_node_id, source_port, target_node_id, target_port, transform, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
 		ON CONFLICT (id, workflow_id) DO UPDATE SET
 			source_node_id = EXCLUDED.source_node_id,
 			source_port = EXCLUDED.source_port,
 			target_node_id = EXCLUDED.target_node_id,
 			target_port = EXCLUDED.target_port,
+			transform = EXCLUDED.transform,
 			updated_at = EXCLUDED.updated_at
 	`
 
@@ -128,6 +129,7 @@ func (cr *ConnectionRepository) SaveConnection(ctx context.Context, workflowID s
 		sourcePortName,
 		targetNodeID,
 		targetPortName,
+		sql.NullString{String: connection.Transform, Valid: connection.Transform != ""},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save connection: %w", err)
@@ -172,7 +174,7 @@ func (cr *ConnectionRepository) DeleteConnection(ctx context.Context, workflowID
 // GetConnectionsByWorkflow retrieves all connections for a specific workflow.
 func (cr *ConnectionRepository) GetConnectionsByWorkflow(ctx context.Context, workflowID string) ([]*models.Connection, error) {
 	query := `
-		SELECT id, source_node_id, source_port, target_node_id, target_port
+		SELECT id, source_node_id, source_port, target_node_id, target_port, transform
 		FROM workflow_connections
 		WHERE workflow_id = $1
 		ORDER BY created_at
@@ -210,7 +212,7 @@ func (cr *ConnectionRepository) GetConnectionsByWorkflow(ctx context.Context, wo
 // getConnectionByID retrieves a specific connection by its ID from a workflow.
 func (cr *ConnectionRepository) getConnectionByID(ctx context.Context, workflowID, connectionID string) (*models.Connection, error) {
 	query := `
-		SELECT id, source_node_id, source_port, target_node_id, target_port
+		SELECT id, source_node_id, source_port, target_node_id, target_port, transform
 		FROM workflow_connections
 		WHERE workflow_id = $1 AND id = $2
 	`
@@ -236,6 +238,7 @@ func (cr *ConnectionRepository) scanConnection(scanner interface {
 	var (
 		connection                                         models.Connection
 		sourceNodeID, sourcePort, targetNodeID, targetPort string
+		transform                                          sql.NullString
 	)
 
 	err := scanner.Scan(
@@ -244,6 +247,7 @@ func (cr *ConnectionRepository) scanConnection(scanner interface {
 		&sourcePort,
 		&targetNodeID,
 		&targetPort,
+		&transform,
 	)
 	if err != nil {
 		return nil, err
@@ -252,6 +256,7 @@ func (cr *ConnectionRepository) scanConnection(scanner interface {
 	// Convert database format to new Connection struct format
 	connection.SourcePort = sourceNodeID + ":" + sourcePort
 	connection.TargetPort = targetNodeID + ":" + targetPort
+	connection.Transform = transform.String
 
 	return &connection, nil
 }
@@ -93,6 +93,7 @@ func TestConnectionRepository_SaveAndGetConnection(t *testing.T) {
 		ID:         "new_conn",
 		SourcePort: "log1:success",
 		TargetPort: "error_handler:notify",
+		Transform:  `{"message": "{{ .data.message }}"}`,
 	}
 
 	err = connRepo.SaveConnection(ctx, workflow.ID, newConnection)
@@ -119,6 +120,7 @@ func TestConnectionRepository_SaveAndGetConnection(t *testing.T) {
 	assert.Equal(t, newConnection.ID, foundConnection.ID)
 	assert.Equal(t, newConnection.SourcePort, foundConnection.SourcePort)
 	assert.Equal(t, newConnection.TargetPort, foundConnection.TargetPort)
+	assert.Equal(t, newConnection.Transform, foundConnection.Transform)
 }
 
 func TestConnectionRepository_GetConnectionsBySourceNode(t *testing.T) {
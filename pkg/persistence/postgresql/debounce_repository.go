@@ -0,0 +1,166 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// DebounceRepository handles the pending activations of debounced trigger nodes in PostgreSQL.
+type DebounceRepository struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+// NewDebounceRepository creates a new debounce repository.
+func NewDebounceRepository(db *sql.DB, logger *slog.Logger) *DebounceRepository {
+	return &DebounceRepository{db: db, logger: logger}
+}
+
+// SavePendingDebounce creates or replaces a pending debounce by ID.
+func (dr *DebounceRepository) SavePendingDebounce(ctx context.Context, pending *models.PendingDebounce) error {
+	eventDataJSON, err := json.Marshal(pending.EventData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+
+	query := `
+		INSERT INTO pending_debounces (id, workflow_id, trigger_node_id, debounce_key, event_data, correlation_id, event_count, fire_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET
+			event_data = EXCLUDED.event_data,
+			correlation_id = EXCLUDED.correlation_id,
+			event_count = EXCLUDED.event_count,
+			fire_at = EXCLUDED.fire_at,
+			updated_at = EXCLUDED.updated_at,
+			claimed_until = NULL
+	`
+
+	_, err = dr.db.ExecContext(ctx, query,
+		pending.ID,
+		pending.WorkflowID,
+		pending.TriggerNodeID,
+		pending.Key,
+		eventDataJSON,
+		pending.CorrelationID,
+		pending.EventCount,
+		pending.FireAt,
+		pending.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save pending debounce: %w", err)
+	}
+
+	return nil
+}
+
+// pendingDebounceColumns are the columns scanned by scanPendingDebounce.
+const pendingDebounceColumns = `id, workflow_id, trigger_node_id, debounce_key, event_data, correlation_id,
+	event_count, fire_at, updated_at, claimed_until`
+
+// GetPendingDebounces returns all pending debounces, the earliest to fire first.
+func (dr *DebounceRepository) GetPendingDebounces(ctx context.Context) ([]*models.PendingDebounce, error) {
+	query := `SELECT ` + pendingDebounceColumns + ` FROM pending_debounces ORDER BY fire_at`
+
+	rows, err := dr.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending debounces: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	pending := make([]*models.PendingDebounce, 0)
+
+	for rows.Next() {
+		debounce, err := scanPendingDebounce(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		pending = append(pending, debounce)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate pending debounces: %w", err)
+	}
+
+	return pending, nil
+}
+
+// ClaimPendingDebounce claims a pending debounce in a single conditional update, which matches no
+// row before it is due or while another replica holds the claim. Unclaimed, the stored debounce
+// is read back for the caller to know when to try again.
+func (dr *DebounceRepository) ClaimPendingDebounce(ctx context.Context, id string, now time.Time, lease time.Duration) (*models.PendingDebounce, bool, error) {
+	query := `
+		UPDATE pending_debounces SET claimed_until = $3
+		WHERE id = $1 AND fire_at <= $2 AND (claimed_until IS NULL OR claimed_until <= $2)
+		RETURNING ` + pendingDebounceColumns
+
+	debounce, err := scanPendingDebounce(dr.db.QueryRowContext(ctx, query, id, now, now.Add(lease)))
+	if err == nil {
+		return debounce, true, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, err
+	}
+
+	query = `SELECT ` + pendingDebounceColumns + ` FROM pending_debounces WHERE id = $1`
+
+	debounce, err = scanPendingDebounce(dr.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+
+	return debounce, false, err
+}
+
+// DeletePendingDebounce removes a pending debounce that fired at fireAt.
+func (dr *DebounceRepository) DeletePendingDebounce(ctx context.Context, id string, fireAt time.Time) error {
+	if _, err := dr.db.ExecContext(ctx, `DELETE FROM pending_debounces WHERE id = $1 AND fire_at = $2`, id, fireAt); err != nil {
+		return fmt.Errorf("failed to delete pending debounce: %w", err)
+	}
+
+	return nil
+}
+
+// scanPendingDebounce scans a row of pendingDebounceColumns.
+func scanPendingDebounce(row interface{ Scan(dest ...any) error }) (*models.PendingDebounce, error) {
+	var (
+		debounce      models.PendingDebounce
+		eventDataJSON []byte
+		claimedUntil  sql.NullTime
+	)
+
+	err := row.Scan(
+		&debounce.ID,
+		&debounce.WorkflowID,
+		&debounce.TriggerNodeID,
+		&debounce.Key,
+		&eventDataJSON,
+		&debounce.CorrelationID,
+		&debounce.EventCount,
+		&debounce.FireAt,
+		&debounce.UpdatedAt,
+		&claimedUntil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan pending debounce: %w", err)
+	}
+
+	if err := json.Unmarshal(eventDataJSON, &debounce.EventData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event data: %w", err)
+	}
+
+	if claimedUntil.Valid {
+		debounce.ClaimedUntil = &claimedUntil.Time
+	}
+
+	return &debounce, nil
+}
@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
 )
 
 // ExecutionContextRepository handles execution context-related database operations.
@@ -45,12 +47,34 @@ func (ecr *ExecutionContextRepository) SaveExecutionContext(ctx context.Context,
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
+	approvalsJSON, err := json.Marshal(execCtx.Approvals)
+	if err != nil {
+		return fmt.Errorf("failed to marshal approvals: %w", err)
+	}
+
+	stateJSON, err := json.Marshal(execCtx.State)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	eventWaitsJSON, err := json.Marshal(execCtx.EventWaits)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event waits: %w", err)
+	}
+
+	variableChangesJSON, err := json.Marshal(execCtx.VariableChanges)
+	if err != nil {
+		return fmt.Errorf("failed to marshal variable changes: %w", err)
+	}
+
 	query := `
 		INSERT INTO execution_contexts (
 			id, workflow_id, status, node_results, variables, 
-			trigger_data, metadata, error_message, created_at, completed_at
+			trigger_data, metadata, error_message, created_at, completed_at,
+			approvals, correlation_id, state, event_waits, ordering_key, variable_changes,
+			trigger_data_ref
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 		ON CONFLICT (id) DO UPDATE SET
 			workflow_id = EXCLUDED.workflow_id,
 			status = EXCLUDED.status,
@@ -59,7 +83,14 @@ func (ecr *ExecutionContextRepository) SaveExecutionContext(ctx context.Context,
 			trigger_data = EXCLUDED.trigger_data,
 			metadata = EXCLUDED.metadata,
 			error_message = EXCLUDED.error_message,
-			completed_at = EXCLUDED.completed_at
+			completed_at = EXCLUDED.completed_at,
+			approvals = EXCLUDED.approvals,
+			correlation_id = EXCLUDED.correlation_id,
+			state = EXCLUDED.state,
+			event_waits = EXCLUDED.event_waits,
+			ordering_key = EXCLUDED.ordering_key,
+			variable_changes = EXCLUDED.variable_changes,
+			trigger_data_ref = EXCLUDED.trigger_data_ref
 	`
 
 	_, err = ecr.db.ExecContext(ctx, query,
@@ -73,6 +104,13 @@ func (ecr *ExecutionContextRepository) SaveExecutionContext(ctx context.Context,
 		execCtx.ErrorMessage,
 		execCtx.CreatedAt,
 		execCtx.CompletedAt,
+		approvalsJSON,
+		execCtx.CorrelationID,
+		stateJSON,
+		eventWaitsJSON,
+		execCtx.OrderingKey,
+		variableChangesJSON,
+		execCtx.TriggerDataRef,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save execution context: %w", err)
@@ -85,7 +123,9 @@ func (ecr *ExecutionContextRepository) SaveExecutionContext(ctx context.Context,
 func (ecr *ExecutionContextRepository) GetExecutionContext(ctx context.Context, executionID string) (*models.ExecutionContext, error) {
 	query := `
 		SELECT id, workflow_id, status, node_results, variables, 
-			   trigger_data, metadata, error_message, created_at, completed_at
+			   trigger_data, metadata, error_message, created_at, completed_at,
+			   approvals, correlation_id, state, event_waits, ordering_key, variable_changes,
+			   trigger_data_ref
 		FROM execution_contexts
 		WHERE id = $1
 	`
@@ -95,7 +135,7 @@ func (ecr *ExecutionContextRepository) GetExecutionContext(ctx context.Context,
 	execCtx, err := ecr.scanExecutionContext(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("execution context not found: %s", executionID)
+			return nil, fmt.Errorf("%w: %s", persistence.ErrExecutionContextNotFound, executionID)
 		}
 
 		return nil, fmt.Errorf("failed to scan execution context: %w", err)
@@ -116,11 +156,32 @@ func (ecr *ExecutionContextRepository) UpdateExecutionContext(ctx context.Contex
 	return ecr.SaveExecutionContext(ctx, execCtx)
 }
 
+// DeleteExecutionContext removes an execution context from the database.
+func (ecr *ExecutionContextRepository) DeleteExecutionContext(ctx context.Context, executionID string) error {
+	result, err := ecr.db.ExecContext(ctx, "DELETE FROM execution_contexts WHERE id = $1", executionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete execution context: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("%w: %s", persistence.ErrExecutionContextNotFound, executionID)
+	}
+
+	return nil
+}
+
 // GetExecutionsByWorkflow retrieves all execution contexts for a specific workflow.
 func (ecr *ExecutionContextRepository) GetExecutionsByWorkflow(ctx context.Context, workflowID string) ([]*models.ExecutionContext, error) {
 	query := `
 		SELECT id, workflow_id, status, node_results, variables, 
-			   trigger_data, metadata, error_message, created_at, completed_at
+			   trigger_data, metadata, error_message, created_at, completed_at,
+			   approvals, correlation_id, state, event_waits, ordering_key, variable_changes,
+			   trigger_data_ref
 		FROM execution_contexts
 		WHERE workflow_id = $1
 		ORDER BY created_at DESC
@@ -159,7 +220,9 @@ func (ecr *ExecutionContextRepository) GetExecutionsByWorkflow(ctx context.Conte
 func (ecr *ExecutionContextRepository) GetExecutionsByStatus(ctx context.Context, status models.ExecutionStatus) ([]*models.ExecutionContext, error) {
 	query := `
 		SELECT id, workflow_id, status, node_results, variables, 
-			   trigger_data, metadata, error_message, created_at, completed_at
+			   trigger_data, metadata, error_message, created_at, completed_at,
+			   approvals, correlation_id, state, event_waits, ordering_key, variable_changes,
+			   trigger_data_ref
 		FROM execution_contexts
 		WHERE status = $1
 		ORDER BY created_at DESC
@@ -194,13 +257,351 @@ func (ecr *ExecutionContextRepository) GetExecutionsByStatus(ctx context.Context
 	return executions, nil
 }
 
+// GetExecutionByApprovalToken retrieves the paused execution holding the pending approval request
+// with token, through the approvals index.
+func (ecr *ExecutionContextRepository) GetExecutionByApprovalToken(ctx context.Context, token string) (*models.ExecutionContext, error) {
+	query := `
+		SELECT id, workflow_id, status, node_results, variables, 
+			   trigger_data, metadata, error_message, created_at, completed_at,
+			   approvals, correlation_id, state, event_waits, ordering_key, variable_changes,
+			   trigger_data_ref
+		FROM execution_contexts
+		WHERE status = $1 AND approvals @> $2
+		LIMIT 1
+	`
+
+	row := ecr.db.QueryRowContext(ctx, query, models.ExecutionStatusPaused, pendingApprovalFilter(token))
+
+	execCtx, err := ecr.scanExecutionContext(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: approval %s", persistence.ErrExecutionContextNotFound, token)
+		}
+
+		return nil, fmt.Errorf("failed to scan execution context: %w", err)
+	}
+
+	return execCtx, nil
+}
+
+// GetExecutionsWithExpiredApprovals retrieves the paused executions holding a pending approval
+// request expired by now.
+func (ecr *ExecutionContextRepository) GetExecutionsWithExpiredApprovals(ctx context.Context, now time.Time) ([]*models.ExecutionContext, error) {
+	query := `
+		SELECT id, workflow_id, status, node_results, variables, 
+			   trigger_data, metadata, error_message, created_at, completed_at,
+			   approvals, correlation_id, state, event_waits, ordering_key, variable_changes,
+			   trigger_data_ref
+		FROM execution_contexts
+		WHERE status = $1 AND EXISTS (
+			SELECT 1
+			FROM jsonb_array_elements(CASE WHEN jsonb_typeof(approvals) = 'array' THEN approvals ELSE '[]' END) AS approval
+			WHERE approval->>'status' = $2
+			  AND approval->>'expires_at' IS NOT NULL
+			  AND (approval->>'expires_at')::timestamptz <= $3
+		)
+		ORDER BY created_at
+	`
+
+	rows, err := ecr.db.QueryContext(ctx, query, models.ExecutionStatusPaused, models.ApprovalStatusPending, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query execution contexts: %w", err)
+	}
+
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			ecr.logger.ErrorContext(ctx, "failed to close rows", "error", closeErr)
+		}
+	}()
+
+	var executions []*models.ExecutionContext
+
+	for rows.Next() {
+		execCtx, err := ecr.scanExecutionContext(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan execution context: %w", err)
+		}
+
+		executions = append(executions, execCtx)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating execution contexts: %w", err)
+	}
+
+	return executions, nil
+}
+
+// ClaimApproval replaces the approval request with the token of request in a single conditional
+// update, which only matches while the stored request is still pending.
+func (ecr *ExecutionContextRepository) ClaimApproval(ctx context.Context, executionID string, request models.ApprovalRequest) (bool, error) {
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal approval request: %w", err)
+	}
+
+	query := `
+		UPDATE execution_contexts
+		SET approvals = (
+			SELECT jsonb_agg(CASE WHEN approval->>'token' = $2 THEN $3::jsonb ELSE approval END ORDER BY position)
+			FROM jsonb_array_elements(approvals) WITH ORDINALITY AS elements(approval, position)
+		)
+		WHERE id = $1 AND approvals @> $4
+	`
+
+	result, err := ecr.db.ExecContext(ctx, query, executionID, request.Token, requestJSON, pendingApprovalFilter(request.Token))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim approval request: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// ClaimRetry increments the retries of an execution in a single conditional update, which matches
+// no row once budget retries were used.
+func (ecr *ExecutionContextRepository) ClaimRetry(ctx context.Context, executionID string, budget int) (bool, error) {
+	query := `
+		UPDATE execution_contexts
+		SET metadata = jsonb_set(
+			CASE WHEN jsonb_typeof(metadata) = 'object' THEN metadata ELSE '{}'::jsonb END, $2::text[],
+			to_jsonb(COALESCE((metadata->>$3)::int, 0) + 1)
+		)
+		WHERE id = $1 AND ($4 <= 0 OR COALESCE((metadata->>$3)::int, 0) < $4)
+	`
+
+	path := "{" + models.RetriesMetadataKey + "}"
+
+	result, err := ecr.db.ExecContext(ctx, query, executionID, path, models.RetriesMetadataKey, budget)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim retry: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// AddPendingActivations adds delta to the pending_activations column of an execution, which
+// execution context saves do not write, in a single update returning the new count.
+func (ecr *ExecutionContextRepository) AddPendingActivations(ctx context.Context, executionID string, delta int) (int, error) {
+	query := `
+		UPDATE execution_contexts
+		SET pending_activations = pending_activations + $2
+		WHERE id = $1
+		RETURNING pending_activations
+	`
+
+	var count int
+
+	err := ecr.db.QueryRowContext(ctx, query, executionID, delta).Scan(&count)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%w: %s", persistence.ErrExecutionContextNotFound, executionID)
+		}
+
+		return 0, fmt.Errorf("failed to add pending activations: %w", err)
+	}
+
+	return count, nil
+}
+
+// pendingApprovalFilter returns the JSONB containment filter matching the approvals of an
+// execution holding the pending approval request with token.
+func pendingApprovalFilter(token string) []byte {
+	filter, _ := json.Marshal([]map[string]string{{"token": token, "status": string(models.ApprovalStatusPending)}})
+
+	return filter
+}
+
+// GetExecutionStats aggregates the executions of a workflow created within [from, to)
+// with a single grouped query: one row per status plus a grand total row carrying
+// the duration percentiles. Durations only cover executions with a completion time:
+// executions still running or paused, and executions left running without one, are
+// counted by status but left out of the percentiles.
+func (ecr *ExecutionContextRepository) GetExecutionStats(ctx context.Context, workflowID string, from, to time.Time) (*models.ExecutionStats, error) {
+	query := `
+		SELECT status, GROUPING(status) AS is_total, COUNT(*),
+			   COALESCE(percentile_cont(0.5) WITHIN GROUP (
+				   ORDER BY EXTRACT(EPOCH FROM (completed_at - created_at)) * 1000)
+				   FILTER (WHERE completed_at IS NOT NULL), 0),
+			   COALESCE(percentile_cont(0.95) WITHIN GROUP (
+				   ORDER BY EXTRACT(EPOCH FROM (completed_at - created_at)) * 1000)
+				   FILTER (WHERE completed_at IS NOT NULL), 0)
+		FROM execution_contexts
+		WHERE workflow_id = $1 AND created_at >= $2 AND created_at < $3
+		GROUP BY GROUPING SETS ((status), ())
+	`
+
+	rows, err := ecr.db.QueryContext(ctx, query, workflowID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query execution stats: %w", err)
+	}
+
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			ecr.logger.ErrorContext(ctx, "failed to close rows", "error", closeErr)
+		}
+	}()
+
+	stats := models.NewExecutionStats(workflowID, from, to)
+
+	for rows.Next() {
+		var (
+			status   sql.NullString
+			isTotal  int
+			count    int
+			p50, p95 float64
+		)
+
+		if err := rows.Scan(&status, &isTotal, &count, &p50, &p95); err != nil {
+			return nil, fmt.Errorf("failed to scan execution stats: %w", err)
+		}
+
+		if isTotal == 1 {
+			stats.DurationP50Ms = p50
+			stats.DurationP95Ms = p95
+
+			continue
+		}
+
+		stats.CountsByStatus[models.ExecutionStatus(status.String)] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating execution stats: %w", err)
+	}
+
+	stats.ComputeRates()
+
+	return stats, nil
+}
+
+// GetLastRunAt returns when the latest execution of a workflow that ran started, with a single
+// aggregate over the executions of the workflow.
+func (ecr *ExecutionContextRepository) GetLastRunAt(ctx context.Context, workflowID string) (*time.Time, error) {
+	query := `
+		SELECT MAX(created_at)
+		FROM execution_contexts
+		WHERE workflow_id = $1 AND status NOT IN ($2, $3, $4)
+	`
+
+	var lastRunAt sql.NullTime
+
+	err := ecr.db.QueryRowContext(ctx, query, workflowID,
+		models.ExecutionStatusFailed, models.ExecutionStatusCancelled, models.ExecutionStatusTimeout,
+	).Scan(&lastRunAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query last run: %w", err)
+	}
+
+	if !lastRunAt.Valid {
+		return nil, nil
+	}
+
+	return &lastRunAt.Time, nil
+}
+
+// ListNodeResults returns a page of the node results of an execution. The node_results column is
+// expanded and filtered in the database, so only the results of the page are read.
+func (ecr *ExecutionContextRepository) ListNodeResults(ctx context.Context, executionID string, query models.NodeResultQuery) (*models.NodeResultPage, error) {
+	var after string
+
+	if query.Cursor != "" {
+		key, err := models.DecodeNodeResultCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		after = key
+	}
+
+	var exists bool
+
+	err := ecr.db.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM execution_contexts WHERE id = $1)", executionID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check execution context: %w", err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", persistence.ErrExecutionContextNotFound, executionID)
+	}
+
+	// Keys compare bytewise, as in the file store
+	sqlQuery := `
+		SELECT result.key, result.value
+		FROM execution_contexts, jsonb_each(COALESCE(node_results, '{}'::jsonb)) AS result
+		WHERE id = $1
+		  AND ($2 = '' OR result.value->>'status' = $2)
+		  AND result.key COLLATE "C" > $3
+		ORDER BY result.key COLLATE "C"
+	`
+	args := []any{executionID, query.Status, after}
+
+	if query.Limit > 0 {
+		sqlQuery += " LIMIT $4"
+
+		args = append(args, query.Limit+1)
+	}
+
+	rows, err := ecr.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node results: %w", err)
+	}
+
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			ecr.logger.ErrorContext(ctx, "failed to close rows", "error", closeErr)
+		}
+	}()
+
+	page := &models.NodeResultPage{Results: make([]models.NodeResultEntry, 0)}
+
+	for rows.Next() {
+		var (
+			key        string
+			resultJSON []byte
+			result     models.NodeResult
+		)
+
+		if err := rows.Scan(&key, &resultJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan node result: %w", err)
+		}
+
+		if err := json.Unmarshal(resultJSON, &result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal node result %s: %w", key, err)
+		}
+
+		page.Results = append(page.Results, models.NewNodeResultEntry(key, result))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating node results: %w", err)
+	}
+
+	if query.Limit > 0 && len(page.Results) > query.Limit {
+		page.Results = page.Results[:query.Limit]
+		page.NextCursor = models.EncodeNodeResultCursor(page.Results[query.Limit-1].Key)
+	}
+
+	return page, nil
+}
+
 // scanExecutionContext scans an execution context from a database row.
 func (ecr *ExecutionContextRepository) scanExecutionContext(scanner interface {
 	Scan(dest ...any) error
 }) (*models.ExecutionContext, error) {
 	var (
-		execCtx                                                       models.ExecutionContext
-		nodeResultsJSON, variablesJSON, triggerDataJSON, metadataJSON []byte
+		execCtx                                                                                                                      models.ExecutionContext
+		nodeResultsJSON, variablesJSON, triggerDataJSON, metadataJSON, approvalsJSON, stateJSON, eventWaitsJSON, variableChangesJSON []byte
 	)
 
 	err := scanner.Scan(
@@ -214,6 +615,13 @@ func (ecr *ExecutionContextRepository) scanExecutionContext(scanner interface {
 		&execCtx.ErrorMessage,
 		&execCtx.CreatedAt,
 		&execCtx.CompletedAt,
+		&approvalsJSON,
+		&execCtx.CorrelationID,
+		&stateJSON,
+		&eventWaitsJSON,
+		&execCtx.OrderingKey,
+		&variableChangesJSON,
+		&execCtx.TriggerDataRef,
 	)
 	if err != nil {
 		return nil, err
@@ -254,5 +662,33 @@ func (ecr *ExecutionContextRepository) scanExecutionContext(scanner interface {
 		}
 	}
 
+	if approvalsJSON != nil {
+		err := json.Unmarshal(approvalsJSON, &execCtx.Approvals)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal approvals: %w", err)
+		}
+	}
+
+	if stateJSON != nil {
+		err := json.Unmarshal(stateJSON, &execCtx.State)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+		}
+	}
+
+	if eventWaitsJSON != nil {
+		err := json.Unmarshal(eventWaitsJSON, &execCtx.EventWaits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event waits: %w", err)
+		}
+	}
+
+	if variableChangesJSON != nil {
+		err := json.Unmarshal(variableChangesJSON, &execCtx.VariableChanges)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal variable changes: %w", err)
+		}
+	}
+
 	return &execCtx, nil
 }
@@ -1,10 +1,12 @@
 package postgresql_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -277,6 +279,248 @@ func TestExecutionContextRepository_GetExecutionsByStatus(t *testing.T) {
 	assert.Len(t, cancelledExecutions, 0)
 }
 
+func TestExecutionContextRepository_ClaimRetry(t *testing.T) {
+	p, ctx, _ := setupTestDB(t)
+
+	workflow := createTestWorkflowForNodes(t)
+	require.NoError(t, p.WorkflowRepository().Save(ctx, workflow))
+
+	execRepo := p.ExecutionContextRepository()
+
+	execCtx := createTestExecutionContext(t, workflow.ID)
+	require.NoError(t, execRepo.SaveExecutionContext(ctx, execCtx))
+
+	for range 2 {
+		claimed, err := execRepo.ClaimRetry(ctx, execCtx.ID, 2)
+		require.NoError(t, err)
+		assert.True(t, claimed)
+	}
+
+	// The budget of 2 is spent
+	claimed, err := execRepo.ClaimRetry(ctx, execCtx.ID, 2)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	// A budget of 0 does not limit retries
+	claimed, err = execRepo.ClaimRetry(ctx, execCtx.ID, 0)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	stored, err := execRepo.GetExecutionContext(ctx, execCtx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stored.Retries())
+}
+
+func TestExecutionContextRepository_AddPendingActivations(t *testing.T) {
+	p, ctx, _ := setupTestDB(t)
+
+	workflow := createTestWorkflowForNodes(t)
+	require.NoError(t, p.WorkflowRepository().Save(ctx, workflow))
+
+	execRepo := p.ExecutionContextRepository()
+
+	_, err := execRepo.AddPendingActivations(ctx, "exec-missing", 1)
+	require.ErrorIs(t, err, persistence.ErrExecutionContextNotFound)
+
+	execCtx := createTestExecutionContext(t, workflow.ID)
+	require.NoError(t, execRepo.SaveExecutionContext(ctx, execCtx))
+
+	pending, err := execRepo.AddPendingActivations(ctx, execCtx.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pending)
+
+	// Saving the execution context keeps the count
+	require.NoError(t, execRepo.UpdateExecutionContext(ctx, execCtx))
+
+	pending, err = execRepo.AddPendingActivations(ctx, execCtx.ID, -2)
+	require.NoError(t, err)
+	assert.Equal(t, 0, pending)
+}
+
+func TestExecutionContextRepository_Approvals(t *testing.T) {
+	p, ctx, _ := setupTestDB(t)
+
+	workflow := createTestWorkflowForNodes(t)
+	require.NoError(t, p.WorkflowRepository().Save(ctx, workflow))
+
+	execRepo := p.ExecutionContextRepository()
+
+	now := time.Now().UTC()
+	expiredAt := now.Add(-time.Minute)
+	expiresAt := now.Add(time.Minute)
+
+	expired := createTestExecutionContext(t, workflow.ID)
+	expired.Status = models.ExecutionStatusPaused
+	expired.Approvals = []models.ApprovalRequest{
+		{Token: "token-expired", NodeID: "approve", Status: models.ApprovalStatusPending, ExpiresAt: &expiredAt},
+	}
+	require.NoError(t, execRepo.SaveExecutionContext(ctx, expired))
+
+	pending := createTestExecutionContext(t, workflow.ID)
+	pending.Status = models.ExecutionStatusPaused
+	pending.Approvals = []models.ApprovalRequest{
+		{Token: "token-other", NodeID: "approve", Status: models.ApprovalStatusApproved},
+		{Token: "token-pending", NodeID: "approve", Status: models.ApprovalStatusPending, ExpiresAt: &expiresAt},
+	}
+	require.NoError(t, execRepo.SaveExecutionContext(ctx, pending))
+
+	running := createTestExecutionContext(t, workflow.ID)
+	require.NoError(t, execRepo.SaveExecutionContext(ctx, running))
+
+	found, err := execRepo.GetExecutionByApprovalToken(ctx, "token-pending")
+	require.NoError(t, err)
+	assert.Equal(t, pending.ID, found.ID)
+
+	_, err = execRepo.GetExecutionByApprovalToken(ctx, "token-other")
+	require.ErrorIs(t, err, persistence.ErrExecutionContextNotFound)
+
+	executions, err := execRepo.GetExecutionsWithExpiredApprovals(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.Equal(t, expired.ID, executions[0].ID)
+
+	// Only the first claim of a pending request succeeds
+	request := pending.Approvals[1]
+	request.Status = models.ApprovalStatusApproved
+	request.DecidedAt = &now
+
+	claimed, err := execRepo.ClaimApproval(ctx, pending.ID, request)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	request.Status = models.ApprovalStatusRejected
+
+	claimed, err = execRepo.ClaimApproval(ctx, pending.ID, request)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	stored, err := execRepo.GetExecutionContext(ctx, pending.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Approvals, 2)
+	assert.Equal(t, "token-other", stored.Approvals[0].Token)
+	assert.Equal(t, models.ApprovalStatusApproved, stored.Approvals[1].Status)
+}
+
+func TestExecutionContextRepository_GetExecutionStats(t *testing.T) {
+	p, ctx, _ := setupTestDB(t)
+
+	workflow := createTestWorkflowForNodes(t)
+	err := p.WorkflowRepository().Save(ctx, workflow)
+	require.NoError(t, err)
+
+	execRepo := p.ExecutionContextRepository()
+
+	from := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Second)
+	to := from.Add(2 * time.Hour)
+
+	seeds := []struct {
+		status   models.ExecutionStatus
+		duration time.Duration
+	}{
+		{models.ExecutionStatusCompleted, 100 * time.Millisecond},
+		{models.ExecutionStatusCompleted, 200 * time.Millisecond},
+		{models.ExecutionStatusCompleted, 300 * time.Millisecond},
+		{models.ExecutionStatusCompleted, 400 * time.Millisecond},
+		{models.ExecutionStatusFailed, time.Second},
+		{models.ExecutionStatusRunning, 0},
+	}
+
+	for i, seed := range seeds {
+		execCtx := createTestExecutionContext(t, workflow.ID)
+		execCtx.Status = seed.status
+		execCtx.CreatedAt = from.Add(time.Duration(i+1) * time.Minute)
+
+		if seed.duration > 0 {
+			completedAt := execCtx.CreatedAt.Add(seed.duration)
+			execCtx.CompletedAt = &completedAt
+		}
+
+		err = execRepo.SaveExecutionContext(ctx, execCtx)
+		require.NoError(t, err)
+	}
+
+	outside := createTestExecutionContext(t, workflow.ID)
+	outside.Status = models.ExecutionStatusCompleted
+	outside.CreatedAt = from.Add(-time.Minute)
+	err = execRepo.SaveExecutionContext(ctx, outside)
+	require.NoError(t, err)
+
+	stats, err := execRepo.GetExecutionStats(ctx, workflow.ID, from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, stats.Total)
+	assert.Equal(t, 4, stats.CountsByStatus[models.ExecutionStatusCompleted])
+	assert.Equal(t, 1, stats.CountsByStatus[models.ExecutionStatusFailed])
+	assert.Equal(t, 1, stats.CountsByStatus[models.ExecutionStatusRunning])
+	assert.InDelta(t, 4.0/6.0, stats.SuccessRate, 0.0001)
+	assert.InDelta(t, 1.0/6.0, stats.FailureRate, 0.0001)
+	// The running execution has no completion time and is left out of the durations
+	assert.InDelta(t, 300, stats.DurationP50Ms, 0.01)
+	assert.InDelta(t, 880, stats.DurationP95Ms, 0.01)
+	assert.InDelta(t, 3, stats.ThroughputPerHour, 0.0001)
+}
+
+func TestExecutionContextRepository_ListNodeResults(t *testing.T) {
+	p, ctx, _ := setupTestDB(t)
+
+	workflow := createTestWorkflowForNodes(t)
+	err := p.WorkflowRepository().Save(ctx, workflow)
+	require.NoError(t, err)
+
+	execRepo := p.ExecutionContextRepository()
+
+	execCtx := createTestExecutionContext(t, workflow.ID)
+	execCtx.NodeResults = make(map[string]models.NodeResult)
+
+	for i := range 120 {
+		nodeID := fmt.Sprintf("item-%03d", i)
+		result := models.NodeResult{NodeID: nodeID, Data: map[string]any{"index": float64(i)}, Status: "success"}
+
+		if i%40 == 3 {
+			result.Status = "error"
+			result.Error = "item rejected"
+		}
+
+		execCtx.NodeResults[models.MakeNodeResultKey(nodeID, "success")] = result
+	}
+
+	err = execRepo.SaveExecutionContext(ctx, execCtx)
+	require.NoError(t, err)
+
+	failed, err := execRepo.ListNodeResults(ctx, execCtx.ID, models.NodeResultQuery{Status: "error"})
+	require.NoError(t, err)
+	require.Len(t, failed.Results, 3)
+	assert.Equal(t, "item-003", failed.Results[0].NodeID)
+	assert.Equal(t, "item rejected", failed.Results[0].Error)
+	assert.Empty(t, failed.NextCursor)
+
+	var seen []string
+
+	query := models.NodeResultQuery{Limit: 50}
+
+	for {
+		page, err := execRepo.ListNodeResults(ctx, execCtx.ID, query)
+		require.NoError(t, err)
+
+		for _, entry := range page.Results {
+			seen = append(seen, entry.NodeID)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+
+		query.Cursor = page.NextCursor
+	}
+
+	require.Len(t, seen, 120)
+	assert.Equal(t, "item-000", seen[0])
+	assert.Equal(t, "item-119", seen[119])
+
+	_, err = execRepo.ListNodeResults(ctx, uuid.New().String(), models.NodeResultQuery{})
+	require.ErrorIs(t, err, persistence.ErrExecutionContextNotFound)
+}
+
 func TestExecutionContextRepository_ComplexDataTypes(t *testing.T) {
 	p, ctx, _ := setupTestDB(t)
 
@@ -453,3 +697,35 @@ func TestExecutionContextRepository_ErrorCases(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "violates foreign key constraint")
 }
+
+func TestExecutionContextRepository_NodeResultKeysRoundTrip(t *testing.T) {
+	p, ctx, _ := setupTestDB(t)
+
+	workflow := createTestWorkflowForNodes(t)
+	err := p.WorkflowRepository().Save(ctx, workflow)
+	require.NoError(t, err)
+
+	outputs := [][2]string{{"ns::fetch", "success"}, {"fetch", "http::200"}, {"a:b", `C:\out`}}
+
+	execCtx := createTestExecutionContext(t, workflow.ID)
+	execCtx.NodeResults = make(map[string]models.NodeResult)
+
+	for _, output := range outputs {
+		execCtx.NodeResults[models.MakeNodeResultKey(output[0], output[1])] = models.NodeResult{NodeID: output[0]}
+	}
+
+	execRepo := p.ExecutionContextRepository()
+	err = execRepo.SaveExecutionContext(ctx, execCtx)
+	require.NoError(t, err)
+
+	retrieved, err := execRepo.GetExecutionContext(ctx, execCtx.ID)
+	require.NoError(t, err)
+	require.Len(t, retrieved.NodeResults, len(outputs))
+
+	for key, result := range retrieved.NodeResults {
+		nodeID, port, ok := models.ParseNodeResultKey(key)
+		require.True(t, ok, "key %q", key)
+		assert.Equal(t, result.NodeID, nodeID)
+		assert.Contains(t, outputs, [2]string{nodeID, port})
+	}
+}
@@ -0,0 +1,8 @@
+package postgresql
+
+import "database/sql"
+
+// DB exposes the underlying database handle to external tests.
+func (p *Persistence) DB() *sql.DB {
+	return p.db
+}
@@ -31,6 +31,11 @@ func (icr *InputCoordinationRepository) SaveInputState(ctx context.Context, stat
 		return fmt.Errorf("failed to marshal received inputs: %w", err)
 	}
 
+	receivedFromJSON, err := json.Marshal(state.ReceivedFrom)
+	if err != nil {
+		return fmt.Errorf("failed to marshal received from: %w", err)
+	}
+
 	requirementsJSON, err := json.Marshal(state.Requirements)
 	if err != nil {
 		return fmt.Errorf("failed to marshal requirements: %w", err)
@@ -39,11 +44,12 @@ func (icr *InputCoordinationRepository) SaveInputState(ctx context.Context, stat
 	query := `
 		INSERT INTO input_coordination_states (
 			node_id, execution_id, node_execution_id, 
-			received_inputs, requirements, created_at, last_updated_at
+			received_inputs, received_from, requirements, created_at, last_updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		ON CONFLICT (node_execution_id) DO UPDATE SET
 			received_inputs = EXCLUDED.received_inputs,
+			received_from = EXCLUDED.received_from,
 			requirements = EXCLUDED.requirements,
 			last_updated_at = EXCLUDED.last_updated_at
 	`
@@ -53,6 +59,7 @@ func (icr *InputCoordinationRepository) SaveInputState(ctx context.Context, stat
 		state.ExecutionID,
 		state.NodeExecutionID,
 		receivedInputsJSON,
+		receivedFromJSON,
 		requirementsJSON,
 		state.CreatedAt,
 		state.LastUpdatedAt,
@@ -68,7 +75,7 @@ func (icr *InputCoordinationRepository) SaveInputState(ctx context.Context, stat
 func (icr *InputCoordinationRepository) LoadInputState(ctx context.Context, nodeExecutionID string) (*models.NodeInputState, error) {
 	query := `
 		SELECT node_id, execution_id, node_execution_id, 
-			   received_inputs, requirements, created_at, last_updated_at
+			   received_inputs, received_from, requirements, created_at, last_updated_at
 		FROM input_coordination_states
 		WHERE node_execution_id = $1
 	`
@@ -91,7 +98,7 @@ func (icr *InputCoordinationRepository) LoadInputState(ctx context.Context, node
 func (icr *InputCoordinationRepository) FindPendingNodeExecution(ctx context.Context, nodeID, executionID string) (*models.NodeInputState, error) {
 	query := `
 		SELECT node_id, execution_id, node_execution_id, 
-			   received_inputs, requirements, created_at, last_updated_at
+			   received_inputs, received_from, requirements, created_at, last_updated_at
 		FROM input_coordination_states
 		WHERE node_id = $1 AND execution_id = $2
 		ORDER BY created_at ASC
@@ -161,8 +168,8 @@ func (icr *InputCoordinationRepository) scanInputState(scanner interface {
 	Scan(dest ...any) error
 }) (*models.NodeInputState, error) {
 	var (
-		state                                models.NodeInputState
-		receivedInputsJSON, requirementsJSON []byte
+		state                                                  models.NodeInputState
+		receivedInputsJSON, receivedFromJSON, requirementsJSON []byte
 	)
 
 	err := scanner.Scan(
@@ -170,6 +177,7 @@ func (icr *InputCoordinationRepository) scanInputState(scanner interface {
 		&state.ExecutionID,
 		&state.NodeExecutionID,
 		&receivedInputsJSON,
+		&receivedFromJSON,
 		&requirementsJSON,
 		&state.CreatedAt,
 		&state.LastUpdatedAt,
@@ -180,6 +188,7 @@ func (icr *InputCoordinationRepository) scanInputState(scanner interface {
 
 	// Initialize maps to avoid nil pointer dereferences
 	state.ReceivedInputs = make(map[string]models.NodeResult)
+	state.ReceivedFrom = make(map[string][]string)
 
 	// Unmarshal JSON fields
 	if receivedInputsJSON != nil {
@@ -189,6 +198,13 @@ func (icr *InputCoordinationRepository) scanInputState(scanner interface {
 		}
 	}
 
+	if receivedFromJSON != nil {
+		err := json.Unmarshal(receivedFromJSON, &state.ReceivedFrom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal received from: %w", err)
+		}
+	}
+
 	if requirementsJSON != nil {
 		err := json.Unmarshal(requirementsJSON, &state.Requirements)
 		if err != nil {
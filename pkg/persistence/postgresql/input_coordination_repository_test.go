@@ -427,3 +427,36 @@ func TestInputCoordinationRepository_ErrorCases(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "violates foreign key constraint")
 }
+
+func TestInputCoordinationRepository_PortsWithSeparatorsRoundTrip(t *testing.T) {
+	p, ctx, _ := setupTestDB(t)
+
+	workflow := createTestWorkflowForNodes(t)
+	err := p.WorkflowRepository().Save(ctx, workflow)
+	require.NoError(t, err)
+
+	execCtx := createTestExecutionContext(t, workflow.ID)
+	err = p.ExecutionContextRepository().SaveExecutionContext(ctx, execCtx)
+	require.NoError(t, err)
+
+	inputRepo := p.InputCoordinationRepository()
+
+	inputState := createTestNodeInputState(t, workflow.ID, "ns::merge", execCtx.ID)
+	inputState.ReceivedInputs = map[string]models.NodeResult{
+		"left::a": {NodeID: "a:b", Status: "success"},
+		`right\b`: {NodeID: "c::d", Status: "success"},
+	}
+	inputState.ReceivedFrom = map[string][]string{
+		"left::a": {"a:b"},
+		`right\b`: {"c::d"},
+	}
+
+	err = inputRepo.SaveInputState(ctx, inputState)
+	require.NoError(t, err)
+
+	retrieved, err := inputRepo.LoadInputState(ctx, inputState.NodeExecutionID)
+	require.NoError(t, err)
+	assert.Equal(t, inputState.NodeID, retrieved.NodeID)
+	assert.Equal(t, inputState.ReceivedInputs, retrieved.ReceivedInputs)
+	assert.Equal(t, inputState.ReceivedFrom, retrieved.ReceivedFrom)
+}
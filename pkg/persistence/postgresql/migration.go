@@ -102,5 +102,130 @@ func migrations() map[int]string {
 			CREATE INDEX idx_input_coordination_execution ON input_coordination_states(execution_id);
 			CREATE INDEX idx_input_coordination_created_at ON input_coordination_states(created_at);
 		`,
+		2: `
+			-- Migration 2: Per-node variable overrides
+			ALTER TABLE workflow_nodes ADD COLUMN variable_overrides JSONB;
+		`,
+		3: `
+			-- Migration 3: Trigger configuration errors reported by providers
+			ALTER TABLE workflows ADD COLUMN trigger_errors JSONB;
+		`,
+		4: `
+			-- Migration 4: Workflow-level catch-all error handler node
+			ALTER TABLE workflows ADD COLUMN error_handler_node_id VARCHAR(255);
+		`,
+		5: `
+			-- Migration 5: Approval requests of executions paused by approval nodes
+			ALTER TABLE execution_contexts ADD COLUMN approvals JSONB;
+		`,
+		6: `
+			-- Migration 6: Data transforms applied along workflow connections
+			ALTER TABLE workflow_connections ADD COLUMN transform TEXT;
+		`,
+		7: `
+			-- Migration 7: Correlation IDs tracing executions back to the request that triggered them
+			ALTER TABLE execution_contexts ADD COLUMN correlation_id TEXT NOT NULL DEFAULT '';
+		`,
+		8: `
+			-- Migration 8: Source nodes of the inputs collected for joins
+			ALTER TABLE input_coordination_states ADD COLUMN received_from JSONB NOT NULL DEFAULT '{}';
+		`,
+		9: `
+			-- Migration 9: Templates reshaping the results of workflow nodes
+			ALTER TABLE workflow_nodes ADD COLUMN output_template TEXT;
+		`,
+		10: `
+			-- Migration 10: Mutable state written by set-variable nodes
+			ALTER TABLE execution_contexts ADD COLUMN state JSONB;
+		`,
+		11: `
+			-- Migration 11: Log level overrides of workflow nodes
+			ALTER TABLE workflow_nodes ADD COLUMN log_level TEXT;
+		`,
+		12: `
+			-- Migration 12: Saga rollbacks compensating completed nodes on failure
+			ALTER TABLE workflow_nodes ADD COLUMN compensation JSONB;
+			ALTER TABLE workflows ADD COLUMN rollback_on_failure BOOLEAN NOT NULL DEFAULT FALSE;
+		`,
+		13: `
+			-- Migration 13: Pending activations of debounced trigger nodes
+			CREATE TABLE pending_debounces (
+				id TEXT PRIMARY KEY,
+				workflow_id VARCHAR(255) NOT NULL,
+				trigger_node_id VARCHAR(255) NOT NULL,
+				debounce_key TEXT NOT NULL,
+				event_data JSONB NOT NULL,
+				correlation_id TEXT NOT NULL DEFAULT '',
+				event_count INTEGER NOT NULL,
+				fire_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+			);
+		`,
+		14: `
+			-- Migration 14: Node retries limited by an execution-wide retry budget
+			ALTER TABLE workflow_nodes ADD COLUMN retry JSONB;
+			ALTER TABLE workflows ADD COLUMN retry_budget INTEGER NOT NULL DEFAULT 0;
+		`,
+		15: `
+			-- Migration 15: Executions paused until a correlated source event arrives
+			ALTER TABLE execution_contexts ADD COLUMN event_waits JSONB;
+		`,
+		16: `
+			-- Migration 16: Ordering key of the source event behind an execution
+			ALTER TABLE execution_contexts ADD COLUMN ordering_key TEXT NOT NULL DEFAULT '';
+		`,
+		17: `
+			-- Migration 17: Named template partials shared by the nodes of a workflow
+			ALTER TABLE workflows ADD COLUMN templates JSONB;
+		`,
+		18: `
+			-- Migration 18: Audit trail of operator changes to the variables of paused executions
+			ALTER TABLE execution_contexts ADD COLUMN variable_changes JSONB;
+		`,
+		19: `
+			-- Migration 19: How the trigger activations of a workflow share executions
+			ALTER TABLE workflows ADD COLUMN trigger_mode TEXT NOT NULL DEFAULT '';
+		`,
+		20: `
+			-- Migration 20: JSON schema validating the inputs of a node before it executes
+			ALTER TABLE workflow_nodes ADD COLUMN input_schema JSONB;
+		`,
+		21: `
+			-- Migration 21: Nodes joining every upstream node that can fire before they execute
+			ALTER TABLE workflow_nodes ADD COLUMN join_inputs BOOLEAN NOT NULL DEFAULT FALSE;
+		`,
+		22: `
+			-- Migration 22: Object holding the trigger data of an execution offloaded to a payload store
+			ALTER TABLE execution_contexts ADD COLUMN trigger_data_ref TEXT NOT NULL DEFAULT '';
+		`,
+		23: `
+			-- Migration 23: Lookup of paused executions by the token of their pending approval requests
+			CREATE INDEX idx_execution_contexts_approvals ON execution_contexts USING GIN (approvals jsonb_path_ops);
+		`,
+		24: `
+			-- Migration 24: Last alert of the failure rate alerting policy of workflows
+			CREATE TABLE workflow_alerts (
+				workflow_id TEXT PRIMARY KEY,
+				last_alert_at TIMESTAMP WITH TIME ZONE NOT NULL
+			);
+		`,
+		25: `
+			-- Migration 25: Claim of the activator replica activating a pending debounce
+			ALTER TABLE pending_debounces ADD COLUMN claimed_until TIMESTAMP WITH TIME ZONE;
+		`,
+		26: `
+			-- Migration 26: Missed run of scheduler triggers the heartbeat monitor last alerted for
+			CREATE TABLE heartbeat_alerts (
+				workflow_id TEXT NOT NULL,
+				trigger_node_id TEXT NOT NULL,
+				expected_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				PRIMARY KEY (workflow_id, trigger_node_id)
+			);
+		`,
+		27: `
+			-- Migration 27: Node activations of executions published and not handled yet, kept out
+			-- of the columns execution context saves write
+			ALTER TABLE execution_contexts ADD COLUMN pending_activations INTEGER NOT NULL DEFAULT 0;
+		`,
 	}
 }
@@ -25,7 +25,7 @@ func NewNodeRepository(db *sql.DB, logger *slog.Logger) *NodeRepository {
 // GetNodesByWorkflow retrieves all nodes from a workflow.
 func (nr *NodeRepository) GetNodesByWorkflow(ctx context.Context, workflowID string) ([]*models.WorkflowNode, error) {
 	query := `
-		SELECT id, type, category, name, config, enabled, position_x, position_y, source_id, provider_id, event_type
+		SELECT id, type, category, name, config, enabled, position_x, position_y, source_id, provider_id, event_type, variable_overrides, output_template, log_level, compensation, retry, input_schema, join_inputs
 		FROM workflow_nodes
 		WHERE workflow_id = $1
 		ORDER BY created_at
@@ -63,7 +63,7 @@ func (nr *NodeRepository) GetNodesByWorkflow(ctx context.Context, workflowID str
 // GetNodeByWorkflow retrieves a specific node from a workflow.
 func (nr *NodeRepository) GetNodeByWorkflow(ctx context.Context, workflowID, nodeID string) (*models.WorkflowNode, error) {
 	query := `
-		SELECT id, type, category, name, config, enabled, position_x, position_y, source_id, provider_id, event_type
+		SELECT id, type, category, name, config, enabled, position_x, position_y, source_id, provider_id, event_type, variable_overrides, output_template, log_level, compensation, retry, input_schema, join_inputs
 		FROM workflow_nodes
 		WHERE workflow_id = $1 AND id = $2
 	`
@@ -89,9 +89,29 @@ func (nr *NodeRepository) SaveNode(ctx context.Context, workflowID string, node
 		return fmt.Errorf("failed to marshal node configuration: %w", err)
 	}
 
+	overridesJSON, err := marshalVariableOverrides(node.VariableOverrides)
+	if err != nil {
+		return err
+	}
+
+	compensationJSON, err := marshalCompensation(node.Compensation)
+	if err != nil {
+		return err
+	}
+
+	retryJSON, err := marshalRetryPolicy(node.Retry)
+	if err != nil {
+		return err
+	}
+
+	inputSchemaJSON, err := marshalInputSchema(node.InputSchema)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO workflow_nodes (id, workflow_id, type, category, name, config, enabled, position_x, position_y, source_id, provider_id, event_type, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), NOW())
+		INSERT INTO workflow_nodes (id, workflow_id, type, category, name, config, enabled, position_x, position_y, source_id, provider_id, event_type, variable_overrides, output_template, log_level, compensation, retry, input_schema, join_inputs, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NOW(), NOW())
 		ON CONFLICT (id, workflow_id) DO UPDATE SET
 			type = EXCLUDED.type,
 			category = EXCLUDED.category,
@@ -103,6 +123,13 @@ func (nr *NodeRepository) SaveNode(ctx context.Context, workflowID string, node
 			source_id = EXCLUDED.source_id,
 			provider_id = EXCLUDED.provider_id,
 			event_type = EXCLUDED.event_type,
+			variable_overrides = EXCLUDED.variable_overrides,
+			output_template = EXCLUDED.output_template,
+			log_level = EXCLUDED.log_level,
+			compensation = EXCLUDED.compensation,
+			retry = EXCLUDED.retry,
+			input_schema = EXCLUDED.input_schema,
+			join_inputs = EXCLUDED.join_inputs,
 			updated_at = EXCLUDED.updated_at
 	`
 
@@ -119,6 +146,13 @@ func (nr *NodeRepository) SaveNode(ctx context.Context, workflowID string, node
 		node.SourceID,
 		node.ProviderID,
 		node.EventType,
+		overridesJSON,
+		sql.NullString{String: node.OutputTemplate, Valid: node.OutputTemplate != ""},
+		sql.NullString{String: node.LogLevel, Valid: node.LogLevel != ""},
+		compensationJSON,
+		retryJSON,
+		inputSchemaJSON,
+		node.Join,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save node: %w", err)
@@ -139,6 +173,51 @@ func (nr *NodeRepository) UpdateNode(ctx context.Context, workflowID string, nod
 	return nr.SaveNode(ctx, workflowID, node)
 }
 
+// UpdateNodePositions updates the positions of the given nodes in a single transaction,
+// rolling back when any of them does not exist.
+func (nr *NodeRepository) UpdateNodePositions(ctx context.Context, workflowID string, positions map[string]models.NodePosition) error {
+	tx, err := nr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `UPDATE workflow_nodes SET position_x = $3, position_y = $4, updated_at = NOW() WHERE workflow_id = $1 AND id = $2`
+
+	for nodeID, position := range positions {
+		var result sql.Result
+
+		result, err = tx.ExecContext(ctx, query, workflowID, nodeID, position.X, position.Y)
+		if err != nil {
+			return fmt.Errorf("failed to update node position: %w", err)
+		}
+
+		var rowsAffected int64
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			err = fmt.Errorf("node not found: %s in workflow %s", nodeID, workflowID)
+
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // DeleteNode removes a node from the database.
 func (nr *NodeRepository) DeleteNode(ctx context.Context, workflowID, nodeID string) error {
 	query := `DELETE FROM workflow_nodes WHERE workflow_id = $1 AND id = $2`
@@ -251,8 +330,9 @@ func (nr *NodeRepository) scanNode(scanner interface {
 	Scan(dest ...any) error
 }) (*models.WorkflowNode, error) {
 	var (
-		node       models.WorkflowNode
-		configJSON []byte
+		node                                                                    models.WorkflowNode
+		configJSON, overridesJSON, compensationJSON, retryJSON, inputSchemaJSON []byte
+		outputTemplate, logLevel                                                sql.NullString
 	)
 
 	err := scanner.Scan(
@@ -267,11 +347,45 @@ func (nr *NodeRepository) scanNode(scanner interface {
 		&node.SourceID,
 		&node.ProviderID,
 		&node.EventType,
+		&overridesJSON,
+		&outputTemplate,
+		&logLevel,
+		&compensationJSON,
+		&retryJSON,
+		&inputSchemaJSON,
+		&node.Join,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	node.OutputTemplate = outputTemplate.String
+	node.LogLevel = logLevel.String
+
+	if overridesJSON != nil {
+		if err := json.Unmarshal(overridesJSON, &node.VariableOverrides); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal node variable overrides: %w", err)
+		}
+	}
+
+	if compensationJSON != nil {
+		if err := json.Unmarshal(compensationJSON, &node.Compensation); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal node compensation: %w", err)
+		}
+	}
+
+	if retryJSON != nil {
+		if err := json.Unmarshal(retryJSON, &node.Retry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal node retry policy: %w", err)
+		}
+	}
+
+	if inputSchemaJSON != nil {
+		if err := json.Unmarshal(inputSchemaJSON, &node.InputSchema); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal node input schema: %w", err)
+		}
+	}
+
 	if configJSON != nil {
 		err := json.Unmarshal(configJSON, &node.Config)
 		if err != nil {
@@ -283,3 +397,59 @@ func (nr *NodeRepository) scanNode(scanner interface {
 
 	return &node, nil
 }
+
+// marshalCompensation encodes a node compensation, storing NULL when the node has none.
+func marshalCompensation(compensation *models.Compensation) ([]byte, error) {
+	if compensation == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(compensation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal node compensation: %w", err)
+	}
+
+	return data, nil
+}
+
+// marshalRetryPolicy encodes a node retry policy, storing NULL when the node has none.
+func marshalRetryPolicy(retry *models.RetryPolicy) ([]byte, error) {
+	if retry == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(retry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal node retry policy: %w", err)
+	}
+
+	return data, nil
+}
+
+// marshalInputSchema encodes a node input schema, storing NULL when the node has none.
+func marshalInputSchema(schema map[string]any) ([]byte, error) {
+	if schema == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal node input schema: %w", err)
+	}
+
+	return data, nil
+}
+
+// marshalVariableOverrides encodes node variable overrides, storing NULL when there are none.
+func marshalVariableOverrides(overrides map[string]any) ([]byte, error) {
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(overrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal node variable overrides: %w", err)
+	}
+
+	return data, nil
+}
@@ -308,6 +308,39 @@ func TestNodeRepository_FindTriggerNodesBySourceEventAndProvider(t *testing.T) {
 	assert.Len(t, matches, 0)
 }
 
+func TestNodeRepository_VariableOverrides(t *testing.T) {
+	p, ctx, _ := setupTestDB(t)
+
+	workflow := createTestWorkflowForNodes(t)
+	workflow.Nodes[1].VariableOverrides = map[string]any{"timeout": float64(120)}
+
+	err := p.WorkflowRepository().Save(ctx, workflow)
+	require.NoError(t, err)
+
+	nodeRepo := p.NodeRepository()
+
+	node, err := nodeRepo.GetNodeByWorkflow(ctx, workflow.ID, "action1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"timeout": float64(120)}, node.VariableOverrides)
+
+	trigger, err := nodeRepo.GetNodeByWorkflow(ctx, workflow.ID, "trigger1")
+	require.NoError(t, err)
+	assert.Nil(t, trigger.VariableOverrides)
+
+	node.VariableOverrides = map[string]any{"timeout": float64(5)}
+	err = nodeRepo.UpdateNode(ctx, workflow.ID, node)
+	require.NoError(t, err)
+
+	loaded, err := p.WorkflowRepository().GetByID(ctx, workflow.ID)
+	require.NoError(t, err)
+
+	for _, loadedNode := range loaded.Nodes {
+		if loadedNode.ID == "action1" {
+			assert.Equal(t, map[string]any{"timeout": float64(5)}, loadedNode.VariableOverrides)
+		}
+	}
+}
+
 func TestNodeRepository_ErrorCases(t *testing.T) {
 	p, ctx, _ := setupTestDB(t)
 
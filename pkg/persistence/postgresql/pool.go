@@ -0,0 +1,136 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Database URL query parameters used to tune the connection pool. Each one can
+// also be set through its environment variable; the URL takes precedence.
+const (
+	paramMaxOpenConns     = "max_open_conns"
+	paramMaxIdleConns     = "max_idle_conns"
+	paramConnMaxLifetime  = "conn_max_lifetime"
+	paramStatementTimeout = "statement_timeout"
+
+	envMaxOpenConns     = "POSTGRES_MAX_OPEN_CONNS"
+	envMaxIdleConns     = "POSTGRES_MAX_IDLE_CONNS"
+	envConnMaxLifetime  = "POSTGRES_CONN_MAX_LIFETIME"
+	envStatementTimeout = "POSTGRES_STATEMENT_TIMEOUT"
+)
+
+// PoolConfig holds the connection pool and session settings applied to the database.
+// Zero values leave the database/sql and PostgreSQL defaults untouched.
+type PoolConfig struct {
+	MaxOpenConns     int
+	MaxIdleConns     int
+	ConnMaxLifetime  time.Duration
+	StatementTimeout time.Duration
+}
+
+// parsePoolConfig extracts the pool settings from the database URL and environment.
+// It returns the connection string to hand to the driver, with the pool-only
+// parameters removed and statement_timeout normalized to milliseconds. Keyword/value
+// connection strings ("host=localhost dbname=operion") are not parsed: they take the
+// pool settings from the environment only, and are handed to the driver unchanged
+// but for the statement_timeout of the environment appended to them.
+func parsePoolConfig(databaseURL string) (string, PoolConfig, error) {
+	var (
+		config PoolConfig
+		parsed *url.URL
+		query  = url.Values{}
+		err    error
+	)
+
+	if strings.HasPrefix(databaseURL, "postgres://") || strings.HasPrefix(databaseURL, "postgresql://") {
+		if parsed, err = url.Parse(databaseURL); err != nil {
+			return "", config, fmt.Errorf("invalid database URL: %w", err)
+		}
+
+		query = parsed.Query()
+	}
+
+	lookup := func(param, env string) string {
+		value := query.Get(param)
+		query.Del(param)
+
+		if value == "" {
+			value = os.Getenv(env)
+		}
+
+		return value
+	}
+
+	if value := lookup(paramMaxOpenConns, envMaxOpenConns); value != "" {
+		if config.MaxOpenConns, err = strconv.Atoi(value); err != nil || config.MaxOpenConns < 0 {
+			return "", config, fmt.Errorf("invalid %s: %q", paramMaxOpenConns, value)
+		}
+	}
+
+	if value := lookup(paramMaxIdleConns, envMaxIdleConns); value != "" {
+		if config.MaxIdleConns, err = strconv.Atoi(value); err != nil || config.MaxIdleConns < 0 {
+			return "", config, fmt.Errorf("invalid %s: %q", paramMaxIdleConns, value)
+		}
+	}
+
+	if value := lookup(paramConnMaxLifetime, envConnMaxLifetime); value != "" {
+		if config.ConnMaxLifetime, err = parseDuration(value); err != nil {
+			return "", config, fmt.Errorf("invalid %s: %q", paramConnMaxLifetime, value)
+		}
+	}
+
+	if value := lookup(paramStatementTimeout, envStatementTimeout); value != "" {
+		if config.StatementTimeout, err = parseDuration(value); err != nil {
+			return "", config, fmt.Errorf("invalid %s: %q", paramStatementTimeout, value)
+		}
+
+		// lib/pq forwards unknown parameters as run-time settings for every session
+		query.Set(paramStatementTimeout, strconv.FormatInt(config.StatementTimeout.Milliseconds(), 10))
+	}
+
+	if parsed == nil {
+		if query.Has(paramStatementTimeout) {
+			return databaseURL + " " + paramStatementTimeout + "=" + query.Get(paramStatementTimeout), config, nil
+		}
+
+		return databaseURL, config, nil
+	}
+
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String(), config, nil
+}
+
+// parseDuration accepts either a Go duration ("30s") or a number of milliseconds.
+func parseDuration(value string) (time.Duration, error) {
+	if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid duration: %q", value)
+	}
+
+	return duration, nil
+}
+
+// apply configures the connection pool of the given database.
+func (c PoolConfig) apply(database *sql.DB) {
+	if c.MaxOpenConns > 0 {
+		database.SetMaxOpenConns(c.MaxOpenConns)
+	}
+
+	if c.MaxIdleConns > 0 {
+		database.SetMaxIdleConns(c.MaxIdleConns)
+	}
+
+	if c.ConnMaxLifetime > 0 {
+		database.SetConnMaxLifetime(c.ConnMaxLifetime)
+	}
+}
@@ -23,15 +23,24 @@ type Persistence struct {
 	connectionRepo        *ConnectionRepository
 	executionContextRepo  *ExecutionContextRepository
 	inputCoordinationRepo *InputCoordinationRepository
+	debounceRepo          *DebounceRepository
+	alertRepo             *AlertRepository
 }
 
 // NewPersistence creates a new PostgreSQL persistence layer.
 func NewPersistence(ctx context.Context, logger *slog.Logger, databaseURL string) (*Persistence, error) {
-	database, err := sql.Open("postgres", databaseURL)
+	connectionString, poolConfig, err := parsePoolConfig(databaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := sql.Open("postgres", connectionString)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to PostgreSQL database: %w", err)
 	}
 
+	poolConfig.apply(database)
+
 	err = database.PingContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
@@ -44,6 +53,8 @@ func NewPersistence(ctx context.Context, logger *slog.Logger, databaseURL string
 	connectionRepo := NewConnectionRepository(database, logger)
 	executionContextRepo := NewExecutionContextRepository(database, logger)
 	inputCoordinationRepo := NewInputCoordinationRepository(database, logger)
+	debounceRepo := NewDebounceRepository(database, logger)
+	alertRepo := NewAlertRepository(database, logger)
 
 	postgres := &Persistence{
 		db:                    database,
@@ -53,6 +64,8 @@ func NewPersistence(ctx context.Context, logger *slog.Logger, databaseURL string
 		connectionRepo:        connectionRepo,
 		executionContextRepo:  executionContextRepo,
 		inputCoordinationRepo: inputCoordinationRepo,
+		debounceRepo:          debounceRepo,
+		alertRepo:             alertRepo,
 	}
 
 	// Run migrations on initialization
@@ -134,3 +147,11 @@ func (p *Persistence) ExecutionContextRepository() persistence.ExecutionContextR
 func (p *Persistence) InputCoordinationRepository() persistence.InputCoordinationRepository {
 	return p.inputCoordinationRepo
 }
+
+func (p *Persistence) DebounceRepository() persistence.DebounceRepository {
+	return p.debounceRepo
+}
+
+func (p *Persistence) AlertRepository() persistence.AlertRepository {
+	return p.alertRepo
+}
@@ -26,7 +26,7 @@ func dropDb(ctx context.Context, t *testing.T, databaseURL string) {
 	require.NoError(t, err)
 
 	// Drop tables in reverse dependency order (children first, parents last)
-	for _, table := range []string{"input_coordination_states", "execution_contexts", "workflow_connections", "workflow_nodes", "workflows", "schema_migrations"} {
+	for _, table := range []string{"pending_debounces", "input_coordination_states", "execution_contexts", "workflow_connections", "workflow_nodes", "workflows", "schema_migrations"} {
 		_, err = db.ExecContext(ctx, "DROP TABLE IF EXISTS "+table+" CASCADE")
 		require.NoError(t, err)
 	}
@@ -106,6 +106,32 @@ information_schema.tables WHERE table_name = 'schema_migrations')`).Scan(&exists
 	assert.Equal(t, 1, version)
 }
 
+func TestNewPersistence_PoolSettings(t *testing.T) {
+	_, ctx, databaseURL := setupTestDB(t)
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	p, err := postgresql.NewPersistence(ctx, logger, databaseURL+"&max_open_conns=7&max_idle_conns=3&statement_timeout=200ms")
+	require.NoError(t, err)
+
+	defer func() {
+		err := p.Close(ctx)
+		require.NoError(t, err)
+	}()
+
+	assert.Equal(t, 7, p.DB().Stats().MaxOpenConnections)
+
+	var timeout string
+
+	err = p.DB().QueryRowContext(ctx, "SHOW statement_timeout").Scan(&timeout)
+	require.NoError(t, err)
+	assert.Equal(t, "200ms", timeout)
+
+	_, err = p.DB().ExecContext(ctx, "SELECT pg_sleep(2)")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "statement timeout")
+}
+
 func TestNewPersistence_HealthCheck(t *testing.T) {
 	p, ctx, _ := setupTestDB(t)
 
@@ -335,6 +361,48 @@ func TestNewPersistence_ListWorkflows(t *testing.T) {
 	assert.Len(t, retrieved, len(workflows))
 }
 
+func TestNewPersistence_ListWorkflows_Cursor(t *testing.T) {
+	p, ctx, _ := setupTestDB(t)
+
+	base := time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+
+	for i := range 25 {
+		err := p.WorkflowRepository().Save(ctx, &models.Workflow{
+			Name:        "Paged Workflow",
+			Description: "Paged",
+			Status:      models.WorkflowStatusDraft,
+			CreatedAt:   base.Add(time.Duration(i/2) * time.Second),
+		})
+		require.NoError(t, err)
+	}
+
+	seen := make(map[string]bool)
+	cursor := ""
+
+	for {
+		page, err := p.WorkflowRepository().ListWorkflows(ctx, models.ListOptions{Limit: 10, Cursor: cursor})
+		require.NoError(t, err)
+
+		for _, workflow := range page.Workflows {
+			assert.False(t, seen[workflow.ID], "workflow %s returned twice", workflow.ID)
+			seen[workflow.ID] = true
+		}
+
+		if cursor == "" {
+			err = p.WorkflowRepository().Save(ctx, &models.Workflow{Name: "New Workflow", Description: "New", Status: models.WorkflowStatusDraft})
+			require.NoError(t, err)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+
+		cursor = page.NextCursor
+	}
+
+	assert.Len(t, seen, 25)
+}
+
 func TestNewPersistence_DeleteWorkflow(t *testing.T) {
 	p, ctx, _ := setupTestDB(t)
 
@@ -389,6 +457,23 @@ func TestNewPersistence_DeleteWorkflow(t *testing.T) {
 	require.NoError(t, err)
 	assert.Nil(t, deleted)
 
+	deleted, err = p.WorkflowRepository().GetByIDIncludingDeleted(ctx, workflow.ID)
+	require.NoError(t, err)
+	require.NotNil(t, deleted)
+	assert.NotNil(t, deleted.DeletedAt)
+
+	// Restore brings it back
+	err = p.WorkflowRepository().Restore(ctx, workflow.ID)
+	require.NoError(t, err)
+
+	restored, err := p.WorkflowRepository().GetByID(ctx, workflow.ID)
+	require.NoError(t, err)
+	require.NotNil(t, restored)
+	assert.Nil(t, restored.DeletedAt)
+
+	err = p.WorkflowRepository().Delete(ctx, workflow.ID)
+	require.NoError(t, err)
+
 	// Delete non-existent workflow (should not error)
 	err = p.WorkflowRepository().Delete(ctx, uuid.NewString())
 	assert.NoError(t, err)
@@ -7,12 +7,18 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/dukex/operion/pkg/models"
 	"github.com/google/uuid"
 )
 
+// querier runs queries on the database or within a transaction.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
 // WorkflowRepository handles workflow-related database operations.
 type WorkflowRepository struct {
 	db     *sql.DB
@@ -40,6 +46,12 @@ func (r *WorkflowRepository) GetAll(ctx context.Context) ([]*models.Workflow, er
 		  , created_at
 		  , updated_at
 		  , deleted_at
+		  , trigger_errors
+		  , error_handler_node_id
+		  , rollback_on_failure
+		  , retry_budget
+		  , templates
+		  , trigger_mode
 		FROM workflows
 		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
@@ -65,7 +77,7 @@ func (r *WorkflowRepository) GetAll(ctx context.Context) ([]*models.Workflow, er
 			return nil, fmt.Errorf("failed to scan workflow: %w", err)
 		}
 
-		err = r.loadWorkflowNodes(ctx, workflow)
+		err = r.loadWorkflowNodes(ctx, r.db, workflow)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load workflow triggers and nodes: %w", err)
 		}
@@ -81,7 +93,137 @@ func (r *WorkflowRepository) GetAll(ctx context.Context) ([]*models.Workflow, er
 	return workflows, nil
 }
 
+// ListWorkflows returns a page of workflows ordered by creation time, newest first.
+func (r *WorkflowRepository) ListWorkflows(ctx context.Context, opts models.ListOptions) (*models.WorkflowPage, error) {
+	query, args, err := buildListQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workflows: %w", err)
+	}
+
+	defer func(ctx context.Context, r *WorkflowRepository) {
+		err := rows.Close()
+		if err != nil {
+			r.logger.ErrorContext(ctx, "failed to close rows", "error", err)
+		}
+	}(ctx, r)
+
+	workflows := make([]*models.Workflow, 0)
+
+	for rows.Next() {
+		workflow, err := r.scanWorkflowBase(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan workflow: %w", err)
+		}
+
+		workflows = append(workflows, workflow)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error iterating workflows: %w", err)
+	}
+
+	page := &models.WorkflowPage{Workflows: workflows}
+
+	// One extra row is fetched to know whether another page exists
+	if opts.Limit > 0 && len(workflows) > opts.Limit {
+		page.Workflows = workflows[:opts.Limit]
+		last := page.Workflows[opts.Limit-1]
+		page.NextCursor = models.EncodeCursor(models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	for _, workflow := range page.Workflows {
+		err = r.loadWorkflowNodes(ctx, r.db, workflow)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load workflow triggers and nodes: %w", err)
+		}
+	}
+
+	return page, nil
+}
+
+// buildListQuery builds the workflow list query for the given pagination options.
+// A cursor selects the rows after the last seen (created_at, id) pair; without one
+// the query falls back to OFFSET pagination.
+func buildListQuery(opts models.ListOptions) (string, []any, error) {
+	query := `
+		SELECT
+			id
+		  , name
+		  , description
+		  , variables
+		  , status
+		  , metadata
+		  , owner
+		  , workflow_group_id
+		  , published_at
+		  , created_at
+		  , updated_at
+		  , deleted_at
+		  , trigger_errors
+		  , error_handler_node_id
+		  , rollback_on_failure
+		  , retry_budget
+		  , templates
+		  , trigger_mode
+		FROM workflows`
+
+	args := make([]any, 0, 3)
+	conditions := make([]string, 0, 2)
+
+	if !opts.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	if opts.Cursor != "" {
+		cursor, err := models.DecodeCursor(opts.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, cursor.CreatedAt, cursor.ID)
+		conditions = append(conditions, "(created_at, id) < ($1, $2)")
+	}
+
+	if len(conditions) > 0 {
+		query += `
+		WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	query += `
+		ORDER BY created_at DESC, id DESC`
+
+	if opts.Limit > 0 {
+		args = append(args, opts.Limit+1)
+		query += fmt.Sprintf(`
+		LIMIT $%d`, len(args))
+	}
+
+	if opts.Cursor == "" && opts.Offset > 0 {
+		args = append(args, opts.Offset)
+		query += fmt.Sprintf(`
+		OFFSET $%d`, len(args))
+	}
+
+	return query, args, nil
+}
+
+// GetByID retrieves a workflow by its ID, nil when it does not exist or is deleted.
 func (r *WorkflowRepository) GetByID(ctx context.Context, id string) (*models.Workflow, error) {
+	return r.getByID(ctx, id, false)
+}
+
+// GetByIDIncludingDeleted retrieves a workflow by its ID, even when it is soft deleted.
+func (r *WorkflowRepository) GetByIDIncludingDeleted(ctx context.Context, id string) (*models.Workflow, error) {
+	return r.getByID(ctx, id, true)
+}
+
+func (r *WorkflowRepository) getByID(ctx context.Context, id string, includeDeleted bool) (*models.Workflow, error) {
 	query := `
 		SELECT
 			id
@@ -96,11 +238,27 @@ func (r *WorkflowRepository) GetByID(ctx context.Context, id string) (*models.Wo
 		  , created_at
 		  , updated_at
 		  , deleted_at
+		  , trigger_errors
+		  , error_handler_node_id
+		  , rollback_on_failure
+		  , retry_budget
+		  , templates
+		  , trigger_mode
 		FROM workflows
-		WHERE id = $1 AND deleted_at IS NULL
+		WHERE id = $1 AND (deleted_at IS NULL OR $2)
 	`
 
-	row := r.db.QueryRowContext(ctx, query, id)
+	// The workflow and its nodes are read from one snapshot, so a concurrent save never mixes the
+	// workflow settings of one version with the nodes of another, such as the compensations a
+	// rollback runs
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	row := tx.QueryRowContext(ctx, query, id, includeDeleted)
 
 	workflow, err := r.scanWorkflowBase(row)
 	if err != nil {
@@ -111,10 +269,14 @@ func (r *WorkflowRepository) GetByID(ctx context.Context, id string) (*models.Wo
 		return nil, fmt.Errorf("failed to scan workflow: %w", err)
 	}
 
-	if err := r.loadWorkflowNodes(ctx, workflow); err != nil {
+	if err := r.loadWorkflowNodes(ctx, tx, workflow); err != nil {
 		return nil, fmt.Errorf("failed to load workflow triggers and nodes: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return workflow, nil
 }
 
@@ -165,11 +327,21 @@ func (r *WorkflowRepository) Save(ctx context.Context, workflow *models.Workflow
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
+	triggerErrorsJSON, err := json.Marshal(workflow.TriggerErrors)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trigger errors: %w", err)
+	}
+
+	templatesJSON, err := json.Marshal(workflow.Templates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal templates: %w", err)
+	}
+
 	// Save workflow base data
 	workflowQuery := `
 		INSERT INTO workflows (id, name, description,
-variables, status, metadata, owner, workflow_group_id, published_at, created_at, updated_at, deleted_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+variables, status, metadata, owner, workflow_group_id, published_at, created_at, updated_at, deleted_at, trigger_errors, error_handler_node_id, rollback_on_failure, retry_budget, templates, trigger_mode)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		ON CONFLICT (id) DO UPDATE SET
 			name = EXCLUDED.name,
 			description = EXCLUDED.description,
@@ -180,7 +352,13 @@ variables, status, metadata, owner, workflow_group_id, published_at, created_at,
 			workflow_group_id = EXCLUDED.workflow_group_id,
 			published_at = EXCLUDED.published_at,
 			updated_at = EXCLUDED.updated_at,
-			deleted_at = EXCLUDED.deleted_at
+			deleted_at = EXCLUDED.deleted_at,
+			trigger_errors = EXCLUDED.trigger_errors,
+			error_handler_node_id = EXCLUDED.error_handler_node_id,
+			rollback_on_failure = EXCLUDED.rollback_on_failure,
+			retry_budget = EXCLUDED.retry_budget,
+			templates = EXCLUDED.templates,
+			trigger_mode = EXCLUDED.trigger_mode
 	`
 
 	// Convert empty UUID strings to NULL for PostgreSQL compatibility
@@ -204,6 +382,12 @@ variables, status, metadata, owner, workflow_group_id, published_at, created_at,
 		workflow.CreatedAt,
 		workflow.UpdatedAt,
 		workflow.DeletedAt,
+		triggerErrorsJSON,
+		sql.NullString{String: workflow.ErrorHandlerNodeID, Valid: workflow.ErrorHandlerNodeID != ""},
+		workflow.RollbackOnFailure,
+		workflow.RetryBudget,
+		templatesJSON,
+		workflow.TriggerMode,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save workflow base: %w", err)
@@ -259,6 +443,18 @@ func (r *WorkflowRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// Restore clears the deleted_at timestamp of a soft deleted workflow.
+func (r *WorkflowRepository) Restore(ctx context.Context, id string) error {
+	query := `UPDATE workflows SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore workflow: %w", err)
+	}
+
+	return nil
+}
+
 // GetCurrentWorkflow returns the current version (published if exists, otherwise draft).
 func (r *WorkflowRepository) GetCurrentWorkflow(ctx context.Context, workflowGroupID string) (*models.Workflow, error) {
 	// Try published first, then draft
@@ -276,6 +472,12 @@ func (r *WorkflowRepository) GetCurrentWorkflow(ctx context.Context, workflowGro
 		  , created_at
 		  , updated_at
 		  , deleted_at
+		  , trigger_errors
+		  , error_handler_node_id
+		  , rollback_on_failure
+		  , retry_budget
+		  , templates
+		  , trigger_mode
 		FROM workflows 
 		WHERE workflow_group_id = $1 AND status IN ('published', 'draft') AND deleted_at IS NULL 
 		ORDER BY CASE WHEN status = 'published' THEN 0 ELSE 1 END
@@ -293,7 +495,7 @@ func (r *WorkflowRepository) GetCurrentWorkflow(ctx context.Context, workflowGro
 		return nil, fmt.Errorf("failed to scan workflow: %w", err)
 	}
 
-	if err := r.loadWorkflowNodes(ctx, workflow); err != nil {
+	if err := r.loadWorkflowNodes(ctx, r.db, workflow); err != nil {
 		return nil, fmt.Errorf("failed to load workflow nodes: %w", err)
 	}
 
@@ -316,6 +518,12 @@ func (r *WorkflowRepository) GetDraftWorkflow(ctx context.Context, workflowGroup
 		  , created_at
 		  , updated_at
 		  , deleted_at
+		  , trigger_errors
+		  , error_handler_node_id
+		  , rollback_on_failure
+		  , retry_budget
+		  , templates
+		  , trigger_mode
 		FROM workflows
 		WHERE workflow_group_id = $1 AND status = 'draft' AND deleted_at IS NULL
 		ORDER BY created_at DESC
@@ -333,7 +541,7 @@ func (r *WorkflowRepository) GetDraftWorkflow(ctx context.Context, workflowGroup
 		return nil, fmt.Errorf("failed to scan workflow: %w", err)
 	}
 
-	if err := r.loadWorkflowNodes(ctx, workflow); err != nil {
+	if err := r.loadWorkflowNodes(ctx, r.db, workflow); err != nil {
 		return nil, fmt.Errorf("failed to load workflow nodes: %w", err)
 	}
 
@@ -356,6 +564,12 @@ func (r *WorkflowRepository) GetPublishedWorkflow(ctx context.Context, workflowG
 		  , created_at
 		  , updated_at
 		  , deleted_at
+		  , trigger_errors
+		  , error_handler_node_id
+		  , rollback_on_failure
+		  , retry_budget
+		  , templates
+		  , trigger_mode
 		FROM workflows
 		WHERE workflow_group_id = $1 AND status = 'published' AND deleted_at IS NULL
 		ORDER BY created_at DESC
@@ -373,7 +587,7 @@ func (r *WorkflowRepository) GetPublishedWorkflow(ctx context.Context, workflowG
 		return nil, fmt.Errorf("failed to scan workflow: %w", err)
 	}
 
-	if err := r.loadWorkflowNodes(ctx, workflow); err != nil {
+	if err := r.loadWorkflowNodes(ctx, r.db, workflow); err != nil {
 		return nil, fmt.Errorf("failed to load workflow nodes: %w", err)
 	}
 
@@ -467,18 +681,18 @@ func (r *WorkflowRepository) CreateDraftFromPublished(ctx context.Context, workf
 	return &draftWorkflow, nil
 }
 
-func (r *WorkflowRepository) loadWorkflowNodes(ctx context.Context, workflow *models.Workflow) error {
+func (r *WorkflowRepository) loadWorkflowNodes(ctx context.Context, db querier, workflow *models.Workflow) error {
 	// Triggers are now part of nodes, so we only load nodes with all trigger fields
 
 	// Load nodes with trigger fields
 	nodesQuery := `
-		SELECT id, type, category, name, config, enabled, position_x, position_y, source_id, provider_id, event_type
+		SELECT id, type, category, name, config, enabled, position_x, position_y, source_id, provider_id, event_type, variable_overrides, output_template, log_level, compensation, retry, input_schema, join_inputs
 		FROM workflow_nodes
 		WHERE workflow_id = $1
 		ORDER BY created_at
 	`
 
-	rows, err := r.db.QueryContext(ctx, nodesQuery, workflow.ID)
+	rows, err := db.QueryContext(ctx, nodesQuery, workflow.ID)
 	if err != nil {
 		return fmt.Errorf("failed to query workflow nodes: %w", err)
 	}
@@ -494,8 +708,9 @@ func (r *WorkflowRepository) loadWorkflowNodes(ctx context.Context, workflow *mo
 
 	for rows.Next() {
 		var (
-			node       models.WorkflowNode
-			configJSON []byte
+			node                                                                    models.WorkflowNode
+			configJSON, overridesJSON, compensationJSON, retryJSON, inputSchemaJSON []byte
+			outputTemplate, logLevel                                                sql.NullString
 		)
 
 		err := rows.Scan(
@@ -510,11 +725,45 @@ func (r *WorkflowRepository) loadWorkflowNodes(ctx context.Context, workflow *mo
 			&node.SourceID,
 			&node.ProviderID,
 			&node.EventType,
+			&overridesJSON,
+			&outputTemplate,
+			&logLevel,
+			&compensationJSON,
+			&retryJSON,
+			&inputSchemaJSON,
+			&node.Join,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to scan node: %w", err)
 		}
 
+		node.OutputTemplate = outputTemplate.String
+		node.LogLevel = logLevel.String
+
+		if overridesJSON != nil {
+			if err := json.Unmarshal(overridesJSON, &node.VariableOverrides); err != nil {
+				return fmt.Errorf("failed to unmarshal node variable overrides: %w", err)
+			}
+		}
+
+		if compensationJSON != nil {
+			if err := json.Unmarshal(compensationJSON, &node.Compensation); err != nil {
+				return fmt.Errorf("failed to unmarshal node compensation: %w", err)
+			}
+		}
+
+		if retryJSON != nil {
+			if err := json.Unmarshal(retryJSON, &node.Retry); err != nil {
+				return fmt.Errorf("failed to unmarshal node retry policy: %w", err)
+			}
+		}
+
+		if inputSchemaJSON != nil {
+			if err := json.Unmarshal(inputSchemaJSON, &node.InputSchema); err != nil {
+				return fmt.Errorf("failed to unmarshal node input schema: %w", err)
+			}
+		}
+
 		if configJSON != nil {
 			err := json.Unmarshal(configJSON, &node.Config)
 			if err != nil {
@@ -533,13 +782,13 @@ func (r *WorkflowRepository) loadWorkflowNodes(ctx context.Context, workflow *mo
 
 	// Load connections
 	connectionsQuery := `
-		SELECT id, source_node_id, source_port, target_node_id, target_port
+		SELECT id, source_node_id, source_port, target_node_id, target_port, transform
 		FROM workflow_connections
 		WHERE workflow_id = $1
 		ORDER BY created_at
 	`
 
-	rows, err = r.db.QueryContext(ctx, connectionsQuery, workflow.ID)
+	rows, err = db.QueryContext(ctx, connectionsQuery, workflow.ID)
 	if err != nil {
 		return fmt.Errorf("failed to query workflow connections: %w", err)
 	}
@@ -557,6 +806,7 @@ func (r *WorkflowRepository) loadWorkflowNodes(ctx context.Context, workflow *mo
 		var (
 			connection                                         models.Connection
 			sourceNodeID, sourcePort, targetNodeID, targetPort string
+			transform                                          sql.NullString
 		)
 
 		err := rows.Scan(
@@ -565,6 +815,7 @@ func (r *WorkflowRepository) loadWorkflowNodes(ctx context.Context, workflow *mo
 			&sourcePort,
 			&targetNodeID,
 			&targetPort,
+			&transform,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to scan connection: %w", err)
@@ -573,6 +824,7 @@ func (r *WorkflowRepository) loadWorkflowNodes(ctx context.Context, workflow *mo
 		// Convert old database format to new Connection struct format
 		connection.SourcePort = sourceNodeID + ":" + sourcePort
 		connection.TargetPort = targetNodeID + ":" + targetPort
+		connection.Transform = transform.String
 
 		connections = append(connections, &connection)
 	}
@@ -596,9 +848,29 @@ func (r *WorkflowRepository) saveWorkflowNodes(ctx context.Context, tx *sql.Tx,
 			return fmt.Errorf("failed to marshal node configuration: %w", err)
 		}
 
+		overridesJSON, err := marshalVariableOverrides(node.VariableOverrides)
+		if err != nil {
+			return err
+		}
+
+		compensationJSON, err := marshalCompensation(node.Compensation)
+		if err != nil {
+			return err
+		}
+
+		retryJSON, err := marshalRetryPolicy(node.Retry)
+		if err != nil {
+			return err
+		}
+
+		inputSchemaJSON, err := marshalInputSchema(node.InputSchema)
+		if err != nil {
+			return err
+		}
+
 		query := `
-			INSERT INTO workflow_nodes (id, workflow_id, type, category, name, config, enabled, position_x, position_y, source_id, provider_id, event_type)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			INSERT INTO workflow_nodes (id, workflow_id, type, category, name, config, enabled, position_x, position_y, source_id, provider_id, event_type, variable_overrides, output_template, log_level, compensation, retry, input_schema, join_inputs)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		`
 
 		_, err = tx.ExecContext(ctx, query,
@@ -614,6 +886,13 @@ func (r *WorkflowRepository) saveWorkflowNodes(ctx context.Context, tx *sql.Tx,
 			node.SourceID,
 			node.ProviderID,
 			node.EventType,
+			overridesJSON,
+			sql.NullString{String: node.OutputTemplate, Valid: node.OutputTemplate != ""},
+			sql.NullString{String: node.LogLevel, Valid: node.LogLevel != ""},
+			compensationJSON,
+			retryJSON,
+			inputSchemaJSON,
+			node.Join,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to save node: %w", err)
@@ -638,8 +917,8 @@ func (r *WorkflowRepository) saveWorkflowConnections(ctx context.Context, tx *sq
 		}
 
 		query := `
-			INSERT INTO workflow_connections (id, workflow_id, source_node_id, source_port, target_node_id, target_port)
-			VALUES ($1, $2, $3, $4, $5, $6)
+			INSERT INTO workflow_connections (id, workflow_id, source_node_id, source_port, target_node_id, target_port, transform)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
 		`
 
 		_, err := tx.ExecContext(ctx, query,
@@ -649,6 +928,7 @@ func (r *WorkflowRepository) saveWorkflowConnections(ctx context.Context, tx *sq
 			sourcePortName,
 			targetNodeID,
 			targetPortName,
+			sql.NullString{String: connection.Transform, Valid: connection.Transform != ""},
 		)
 		if err != nil {
 			return fmt.Errorf("failed to save connection: %w", err)
@@ -662,9 +942,9 @@ func (r *WorkflowRepository) scanWorkflowBase(scanner interface {
 	Scan(dest ...any) error
 }) (*models.Workflow, error) {
 	var (
-		workflow                    models.Workflow
-		variablesJSON, metadataJSON []byte
-		workflowGroupID             sql.NullString
+		workflow                                                      models.Workflow
+		variablesJSON, metadataJSON, triggerErrorsJSON, templatesJSON []byte
+		workflowGroupID, errorHandlerNodeID                           sql.NullString
 	)
 
 	err := scanner.Scan(
@@ -680,6 +960,12 @@ func (r *WorkflowRepository) scanWorkflowBase(scanner interface {
 		&workflow.CreatedAt,
 		&workflow.UpdatedAt,
 		&workflow.DeletedAt,
+		&triggerErrorsJSON,
+		&errorHandlerNodeID,
+		&workflow.RollbackOnFailure,
+		&workflow.RetryBudget,
+		&templatesJSON,
+		&workflow.TriggerMode,
 	)
 	if err != nil {
 		return nil, err
@@ -690,6 +976,10 @@ func (r *WorkflowRepository) scanWorkflowBase(scanner interface {
 		workflow.WorkflowGroupID = workflowGroupID.String
 	}
 
+	if errorHandlerNodeID.Valid {
+		workflow.ErrorHandlerNodeID = errorHandlerNodeID.String
+	}
+
 	// Unmarshal JSON fields
 	if variablesJSON != nil {
 		err := json.Unmarshal(variablesJSON, &workflow.Variables)
@@ -705,6 +995,20 @@ func (r *WorkflowRepository) scanWorkflowBase(scanner interface {
 		}
 	}
 
+	if triggerErrorsJSON != nil {
+		err := json.Unmarshal(triggerErrorsJSON, &workflow.TriggerErrors)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal trigger errors: %w", err)
+		}
+	}
+
+	if templatesJSON != nil {
+		err := json.Unmarshal(templatesJSON, &workflow.Templates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal templates: %w", err)
+		}
+	}
+
 	return &workflow, nil
 }
 
@@ -724,6 +1028,12 @@ func (r *WorkflowRepository) GetWorkflowVersions(ctx context.Context, workflowGr
 		  , created_at
 		  , updated_at
 		  , deleted_at
+		  , trigger_errors
+		  , error_handler_node_id
+		  , rollback_on_failure
+		  , retry_budget
+		  , templates
+		  , trigger_mode
 		FROM workflows
 		WHERE workflow_group_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
@@ -749,7 +1059,7 @@ func (r *WorkflowRepository) GetWorkflowVersions(ctx context.Context, workflowGr
 			return nil, fmt.Errorf("failed to scan workflow: %w", err)
 		}
 
-		err = r.loadWorkflowNodes(ctx, workflow)
+		err = r.loadWorkflowNodes(ctx, r.db, workflow)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load workflow nodes: %w", err)
 		}
@@ -0,0 +1,45 @@
+package postgresql
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildListQuery(t *testing.T) {
+	query, args, err := buildListQuery(models.ListOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, query, "LIMIT")
+	assert.NotContains(t, query, "OFFSET")
+	assert.Contains(t, query, "WHERE deleted_at IS NULL")
+	assert.Empty(t, args)
+
+	query, _, err = buildListQuery(models.ListOptions{IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.NotContains(t, query, "WHERE")
+
+	query, args, err = buildListQuery(models.ListOptions{Limit: 10, Offset: 20})
+	require.NoError(t, err)
+	assert.Contains(t, query, "LIMIT $1")
+	assert.Contains(t, query, "OFFSET $2")
+	assert.Equal(t, []any{11, 20}, args)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	cursor := models.EncodeCursor(models.Cursor{CreatedAt: createdAt, ID: "workflow-1"})
+
+	// The cursor takes precedence over the offset
+	query, args, err = buildListQuery(models.ListOptions{Limit: 10, Offset: 20, Cursor: cursor})
+	require.NoError(t, err)
+	assert.Contains(t, query, "WHERE deleted_at IS NULL AND (created_at, id) < ($1, $2)")
+	assert.Contains(t, query, "ORDER BY created_at DESC, id DESC")
+	assert.Contains(t, query, "LIMIT $3")
+	assert.False(t, strings.Contains(query, "OFFSET"))
+	assert.Equal(t, []any{createdAt, "workflow-1", 11}, args)
+
+	_, _, err = buildListQuery(models.ListOptions{Cursor: "invalid"})
+	assert.ErrorIs(t, err, models.ErrInvalidCursor)
+}
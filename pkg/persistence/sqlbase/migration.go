@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"slices"
 )
 
 // MigrationManager handles database schema migrations.
@@ -13,9 +14,11 @@ type MigrationManager struct {
 	db         *sql.DB
 	logger     *slog.Logger
 	migrations map[int]string
+	component  string
 }
 
-// NewMigrationManager creates a new migration manager.
+// NewMigrationManager creates a new migration manager for the core schema, whose applied
+// versions are tracked in the schema_migrations table.
 func NewMigrationManager(logger *slog.Logger, db *sql.DB, migrations map[int]string) *MigrationManager {
 	return &MigrationManager{
 		db:         db,
@@ -24,6 +27,19 @@ func NewMigrationManager(logger *slog.Logger, db *sql.DB, migrations map[int]str
 	}
 }
 
+// NewComponentMigrationManager creates a migration manager for a component sharing the database,
+// such as a provider persistence. Its applied versions are tracked per component in the
+// component_schema_migrations table, so components number their migrations from 1 without
+// colliding with the core schema or with each other.
+func NewComponentMigrationManager(logger *slog.Logger, db *sql.DB, component string, migrations map[int]string) *MigrationManager {
+	return &MigrationManager{
+		db:         db,
+		logger:     logger.With("migration_component", component),
+		migrations: migrations,
+		component:  component,
+	}
+}
+
 // getTargetSchemaVersion returns the highest version number from the migrations map.
 func (m *MigrationManager) getTargetSchemaVersion() int {
 	maxVersion := 0
@@ -74,6 +90,17 @@ func (m *MigrationManager) createMigrationsTable(ctx context.Context) error {
 		);
 	`
 
+	if m.component != "" {
+		createMigrationsSQL = `
+			CREATE TABLE IF NOT EXISTS component_schema_migrations (
+				component VARCHAR(255) NOT NULL,
+				version INTEGER NOT NULL,
+				applied_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+				PRIMARY KEY (component, version)
+			);
+		`
+	}
+
 	_, err := m.db.ExecContext(ctx, createMigrationsSQL)
 	if err != nil {
 		m.logger.ErrorContext(ctx, "Failed to create schema_migrations table", "error", err)
@@ -90,7 +117,13 @@ func (m *MigrationManager) createMigrationsTable(ctx context.Context) error {
 func (m *MigrationManager) getCurrentSchemaVersion(ctx context.Context) (int, error) {
 	var version int
 
-	err := m.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	row := m.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations")
+	if m.component != "" {
+		row = m.db.QueryRowContext(ctx,
+			"SELECT COALESCE(MAX(version), 0) FROM component_schema_migrations WHERE component = $1", m.component)
+	}
+
+	err := row.Scan(&version)
 	if err != nil {
 		return 0, fmt.Errorf("failed to query current schema version: %w", err)
 	}
@@ -98,39 +131,68 @@ func (m *MigrationManager) getCurrentSchemaVersion(ctx context.Context) (int, er
 	return version, nil
 }
 
-// applyMigrations applies all migrations from the current version to the latest.
-func (m *MigrationManager) applyMigrations(ctx context.Context, fromVersion int) error {
-	for version, migration := range m.migrations {
+// pendingVersions returns the versions newer than fromVersion, in ascending order.
+func (m *MigrationManager) pendingVersions(fromVersion int) []int {
+	versions := make([]int, 0, len(m.migrations))
+
+	for version := range m.migrations {
 		if version > fromVersion {
-			m.logger.InfoContext(ctx, "Applying migration", "version", version)
+			versions = append(versions, version)
+		}
+	}
+
+	slices.Sort(versions)
+
+	return versions
+}
 
-			transaction, err := m.db.BeginTx(ctx, nil)
-			if err != nil {
-				return fmt.Errorf("failed to begin transaction for migration %d: %w", version, err)
-			}
+// recordMigration records version as applied within transaction.
+func (m *MigrationManager) recordMigration(ctx context.Context, transaction *sql.Tx, version int) error {
+	if m.component != "" {
+		_, err := transaction.ExecContext(ctx,
+			"INSERT INTO component_schema_migrations (component, version) VALUES ($1, $2)", m.component, version)
 
-			_, err = transaction.ExecContext(ctx, migration)
-			if err != nil {
-				_ = transaction.Rollback()
+		return err
+	}
 
-				return fmt.Errorf("failed to execute migration %d: %w", version, err)
-			}
+	_, err := transaction.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version)
 
-			// Record migration
-			_, err = transaction.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version)
-			if err != nil {
-				_ = transaction.Rollback()
+	return err
+}
+
+// applyMigrations applies all migrations from the current version to the latest, in version order.
+func (m *MigrationManager) applyMigrations(ctx context.Context, fromVersion int) error {
+	for _, version := range m.pendingVersions(fromVersion) {
+		migration := m.migrations[version]
 
-				return fmt.Errorf("failed to record migration %d: %w", version, err)
-			}
+		m.logger.InfoContext(ctx, "Applying migration", "version", version)
 
-			err = transaction.Commit()
-			if err != nil {
-				return fmt.Errorf("failed to commit migration %d: %w", version, err)
-			}
+		transaction, err := m.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction for migration %d: %w", version, err)
+		}
+
+		_, err = transaction.ExecContext(ctx, migration)
+		if err != nil {
+			_ = transaction.Rollback()
 
-			m.logger.InfoContext(ctx, "Migration applied successfully", "version", version)
+			return fmt.Errorf("failed to execute migration %d: %w", version, err)
 		}
+
+		// Record migration
+		err = m.recordMigration(ctx, transaction, version)
+		if err != nil {
+			_ = transaction.Rollback()
+
+			return fmt.Errorf("failed to record migration %d: %w", version, err)
+		}
+
+		err = transaction.Commit()
+		if err != nil {
+			return fmt.Errorf("failed to commit migration %d: %w", version, err)
+		}
+
+		m.logger.InfoContext(ctx, "Migration applied successfully", "version", version)
 	}
 
 	return nil
@@ -0,0 +1,183 @@
+//go:build integration
+// +build integration
+
+package sqlbase
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+var postgresContainer *postgres.PostgresContainer
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+
+	if postgresContainer != nil {
+		_ = postgresContainer.Terminate(context.Background())
+	}
+
+	os.Exit(code)
+}
+
+// setupTestDB returns a connection to an empty test database.
+func setupTestDB(t *testing.T) (*sql.DB, context.Context) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	if postgresContainer == nil || !postgresContainer.IsRunning() {
+		var err error
+
+		postgresContainer, err = postgres.Run(ctx,
+			"postgres:16-alpine",
+			postgres.WithDatabase("operion_migration_test"),
+			postgres.WithUsername("operion"),
+			postgres.WithPassword("operion"),
+			postgres.BasicWaitStrategies(),
+		)
+		require.NoError(t, err)
+	}
+
+	databaseURL, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	db, err := sql.Open("postgres", databaseURL)
+	require.NoError(t, err)
+
+	for _, table := range []string{"applied_order", "alpha_items", "beta_items", "component_schema_migrations", "schema_migrations"} {
+		_, err = db.ExecContext(ctx, "DROP TABLE IF EXISTS "+table+" CASCADE")
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, ctx
+}
+
+func appliedOrder(ctx context.Context, t *testing.T, db *sql.DB) []int {
+	t.Helper()
+
+	rows, err := db.QueryContext(ctx, "SELECT version FROM applied_order ORDER BY id")
+	require.NoError(t, err)
+
+	defer func() { _ = rows.Close() }()
+
+	var versions []int
+
+	for rows.Next() {
+		var version int
+		require.NoError(t, rows.Scan(&version))
+
+		versions = append(versions, version)
+	}
+
+	require.NoError(t, rows.Err())
+
+	return versions
+}
+
+func componentVersions(ctx context.Context, t *testing.T, db *sql.DB, component string) []int {
+	t.Helper()
+
+	rows, err := db.QueryContext(ctx,
+		"SELECT version FROM component_schema_migrations WHERE component = $1 ORDER BY version", component)
+	require.NoError(t, err)
+
+	defer func() { _ = rows.Close() }()
+
+	var versions []int
+
+	for rows.Next() {
+		var version int
+		require.NoError(t, rows.Scan(&version))
+
+		versions = append(versions, version)
+	}
+
+	require.NoError(t, rows.Err())
+
+	return versions
+}
+
+func TestMigrationManager_AppliesMigrationsInVersionOrder(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	migrations := map[int]string{
+		1:  "CREATE TABLE applied_order (id SERIAL PRIMARY KEY, version INTEGER NOT NULL); INSERT INTO applied_order (version) VALUES (1);",
+		2:  "INSERT INTO applied_order (version) VALUES (2);",
+		3:  "INSERT INTO applied_order (version) VALUES (3);",
+		10: "INSERT INTO applied_order (version) VALUES (10);",
+	}
+
+	require.NoError(t, NewComponentMigrationManager(logger, db, "ordered", migrations).RunMigrations(ctx))
+
+	assert.Equal(t, []int{1, 2, 3, 10}, appliedOrder(ctx, t, db))
+	assert.Equal(t, []int{1, 2, 3, 10}, componentVersions(ctx, t, db, "ordered"))
+}
+
+func TestMigrationManager_RerunIsIdempotent(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	migrations := map[int]string{
+		1: "CREATE TABLE applied_order (id SERIAL PRIMARY KEY, version INTEGER NOT NULL); INSERT INTO applied_order (version) VALUES (1);",
+		2: "INSERT INTO applied_order (version) VALUES (2);",
+	}
+
+	require.NoError(t, NewComponentMigrationManager(logger, db, "rerun", migrations).RunMigrations(ctx))
+	require.NoError(t, NewComponentMigrationManager(logger, db, "rerun", migrations).RunMigrations(ctx))
+
+	assert.Equal(t, []int{1, 2}, appliedOrder(ctx, t, db))
+
+	// A new version is the only one applied on the next run
+	migrations[3] = "INSERT INTO applied_order (version) VALUES (3);"
+
+	require.NoError(t, NewComponentMigrationManager(logger, db, "rerun", migrations).RunMigrations(ctx))
+
+	assert.Equal(t, []int{1, 2, 3}, appliedOrder(ctx, t, db))
+	assert.Equal(t, []int{1, 2, 3}, componentVersions(ctx, t, db, "rerun"))
+}
+
+func TestMigrationManager_ComponentVersionsAreIsolated(t *testing.T) {
+	db, ctx := setupTestDB(t)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	// The core schema is at version 2 before the components run their own version 1 and 2
+	require.NoError(t, NewMigrationManager(logger, db, map[int]string{
+		1: "SELECT 1;",
+		2: "SELECT 2;",
+	}).RunMigrations(ctx))
+
+	require.NoError(t, NewComponentMigrationManager(logger, db, "alpha", map[int]string{
+		1: "CREATE TABLE alpha_items (id INTEGER);",
+	}).RunMigrations(ctx))
+
+	require.NoError(t, NewComponentMigrationManager(logger, db, "beta", map[int]string{
+		1: "CREATE TABLE beta_items (id INTEGER);",
+		2: "ALTER TABLE beta_items ADD COLUMN name TEXT;",
+	}).RunMigrations(ctx))
+
+	assert.Equal(t, []int{1}, componentVersions(ctx, t, db, "alpha"))
+	assert.Equal(t, []int{1, 2}, componentVersions(ctx, t, db, "beta"))
+
+	var coreVersion int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&coreVersion))
+	assert.Equal(t, 2, coreVersion)
+
+	for _, table := range []string{"alpha_items", "beta_items"} {
+		var exists bool
+		require.NoError(t, db.QueryRowContext(ctx,
+			"SELECT EXISTS (SELECT FROM information_schema.tables WHERE table_name = $1)", table).Scan(&exists))
+		assert.True(t, exists, "%s should exist", table)
+	}
+}
@@ -0,0 +1,21 @@
+package sqlbase
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationManager_PendingVersionsInOrder(t *testing.T) {
+	manager := NewComponentMigrationManager(slog.Default(), nil, "test", map[int]string{
+		10: "SELECT 10",
+		2:  "SELECT 2",
+		1:  "SELECT 1",
+		3:  "SELECT 3",
+	})
+
+	assert.Equal(t, []int{1, 2, 3, 10}, manager.pendingVersions(0))
+	assert.Equal(t, []int{3, 10}, manager.pendingVersions(2))
+	assert.Empty(t, manager.pendingVersions(10))
+}
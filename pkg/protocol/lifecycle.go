@@ -2,9 +2,13 @@ package protocol
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"sort"
+	"time"
 
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/tracer"
 )
 
 // ProviderLifecycle defines the lifecycle management interface for source providers.
@@ -18,6 +22,8 @@ type ProviderLifecycle interface {
 	// Configure configures the provider based on current workflow definitions.
 	// Called after Initialize() and whenever workflows change.
 	// Returns a map of triggerID -> sourceID for workflows that were configured.
+	// Triggers that could not be configured are reported through a *ConfigureError
+	// returned alongside the map of the triggers that were configured.
 	Configure(workflows []*models.Workflow) (map[string]string, error)
 
 	// Prepare performs final preparation before starting the provider.
@@ -28,5 +34,55 @@ type ProviderLifecycle interface {
 // Dependencies contains the common dependencies that providers need.
 type Dependencies struct {
 	Logger *slog.Logger
+	// Metrics records the source events providers drop before publishing them, may be nil
+	Metrics *tracer.SourceMetrics
 	// Note: No shared persistence - providers manage their own data
 }
+
+// ConfigureError reports the trigger nodes a provider failed to configure, keyed by workflow ID.
+// It is not fatal: the triggers that were configured are still returned by Configure.
+type ConfigureError struct {
+	ProviderID string
+	Workflows  map[string][]models.TriggerError
+}
+
+// NewConfigureError creates an empty ConfigureError for the given provider.
+func NewConfigureError(providerID string) *ConfigureError {
+	return &ConfigureError{
+		ProviderID: providerID,
+		Workflows:  make(map[string][]models.TriggerError),
+	}
+}
+
+// Add records that the trigger node of a workflow could not be configured.
+func (e *ConfigureError) Add(workflowID, triggerID string, err error) {
+	e.Workflows[workflowID] = append(e.Workflows[workflowID], models.TriggerError{
+		TriggerID:  triggerID,
+		ProviderID: e.ProviderID,
+		Message:    err.Error(),
+		OccurredAt: time.Now().UTC(),
+	})
+}
+
+// ErrOrNil returns the error if any trigger failed, nil otherwise.
+func (e *ConfigureError) ErrOrNil() error {
+	if len(e.Workflows) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+func (e *ConfigureError) Error() string {
+	workflowIDs := make([]string, 0, len(e.Workflows))
+	count := 0
+
+	for workflowID, triggerErrors := range e.Workflows {
+		workflowIDs = append(workflowIDs, workflowID)
+		count += len(triggerErrors)
+	}
+
+	sort.Strings(workflowIDs)
+
+	return fmt.Sprintf("provider %s failed to configure %d trigger(s) in workflows %v", e.ProviderID, count, workflowIDs)
+}
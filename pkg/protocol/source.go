@@ -3,12 +3,37 @@ package protocol
 import (
 	"context"
 	"log/slog"
+	"time"
+
+	"github.com/dukex/operion/pkg/tracer"
 )
 
 // SourceEventCallback is called when a source provider emits an event.
 // The callback should publish the event to the event bus for activator consumption.
 type SourceEventCallback func(ctx context.Context, sourceID, providerID, eventType string, eventData map[string]any) error
 
+// InstrumentSourceEventCallback wraps callback to record each event it publishes as processed,
+// with the time publishing took, and each event it fails to publish as dropped.
+func InstrumentSourceEventCallback(callback SourceEventCallback, metrics *tracer.SourceMetrics) SourceEventCallback {
+	if metrics == nil {
+		return callback
+	}
+
+	return func(ctx context.Context, sourceID, providerID, eventType string, eventData map[string]any) error {
+		startedAt := time.Now()
+
+		if err := callback(ctx, sourceID, providerID, eventType, eventData); err != nil {
+			metrics.RecordDropped(ctx, providerID, sourceID, tracer.DropReasonPublishFailed)
+
+			return err
+		}
+
+		metrics.RecordProcessed(ctx, providerID, sourceID, time.Since(startedAt))
+
+		return nil
+	}
+}
+
 // Provider represents a running instance of a source provider that can emit events.
 // Source providers are long-running processes that monitor external systems and emit
 // events when specific conditions are met (e.g., scheduled time, webhook received, etc.).
@@ -0,0 +1,69 @@
+package gcppubsub
+
+import (
+	"log/slog"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// PubSubProviderFactory creates instances of PubSubProvider.
+type PubSubProviderFactory struct{}
+
+// NewPubSubProviderFactory creates a new factory instance.
+func NewPubSubProviderFactory() *PubSubProviderFactory {
+	return &PubSubProviderFactory{}
+}
+
+// Create instantiates a new centralized PubSubProvider orchestrator.
+func (f *PubSubProviderFactory) Create(config map[string]any, logger *slog.Logger) (protocol.Provider, error) {
+	// Persistence is initialized and Pub/Sub clients are created during the lifecycle methods
+	return &PubSubProvider{
+		config: config,
+		logger: logger.With("module", "gcp_pubsub_provider"),
+	}, nil
+}
+
+// ID returns the unique identifier for this source provider type.
+func (f *PubSubProviderFactory) ID() string {
+	return ProviderID
+}
+
+// Name returns a human-readable name for this source provider.
+func (f *PubSubProviderFactory) Name() string {
+	return "Google Cloud Pub/Sub"
+}
+
+// Description returns a detailed description of what this source provider does.
+func (f *PubSubProviderFactory) Description() string {
+	return "Receives the messages of Google Cloud Pub/Sub subscriptions and emits them as source events with their parsed data and attributes. A message is acknowledged once every source of its subscription published it and redelivered otherwise. Sources sharing a subscription are served by a single receiver."
+}
+
+// Schema returns a JSON Schema that describes the orchestrator configuration.
+func (f *PubSubProviderFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"credentials_file": map[string]any{
+				"type":        "string",
+				"description": "Service account or refresh token JSON credentials file (default: application default credentials)",
+				"examples":    []string{"/etc/operion/pubsub-credentials.json"},
+			},
+			"emulator_host": map[string]any{
+				"type":        "string",
+				"description": "Host and port of a Pub/Sub emulator to connect to without authentication (default: PUBSUB_EMULATOR_HOST)",
+				"examples":    []string{"localhost:8085"},
+			},
+		},
+		"required":             []string{},
+		"additionalProperties": false,
+		"description":          "Centralized Pub/Sub orchestrator configuration. Projects and subscriptions are defined in workflow triggers, not here.",
+	}
+}
+
+// EventTypes returns a list of event types that this source provider can emit.
+func (f *PubSubProviderFactory) EventTypes() []string {
+	return []string{EventTypeMessageReceived}
+}
+
+// Ensure interface compliance.
+var _ protocol.ProviderFactory = (*PubSubProviderFactory)(nil)
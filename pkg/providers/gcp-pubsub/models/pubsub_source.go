@@ -0,0 +1,90 @@
+// Package models defines the data structures used by the Google Cloud Pub/Sub provider.
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPubSubSource is returned when Pub/Sub source validation fails.
+var ErrInvalidPubSubSource = errors.New("invalid pubsub source")
+
+// PubSubSource represents a workflow trigger fed by the messages of a Pub/Sub subscription.
+// Sources sharing a subscription receive every message of it.
+type PubSubSource struct {
+	// ID is the source identifier used in workflows
+	ID string `json:"id" validate:"required"`
+
+	// ProjectID is the Google Cloud project of the subscription
+	ProjectID string `json:"project_id" validate:"required"`
+
+	// Subscription is the ID of the subscription within the project
+	Subscription string `json:"subscription" validate:"required"`
+
+	// CreatedAt is the timestamp when this source was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt is the timestamp when this source was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Active indicates if this source should receive messages
+	Active bool `json:"active"`
+}
+
+// NewPubSubSource creates a new Pub/Sub source from a trigger node configuration.
+func NewPubSubSource(sourceID string, configuration map[string]any) (*PubSubSource, error) {
+	now := time.Now().UTC()
+
+	source := &PubSubSource{
+		ID:        sourceID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Active:    true,
+	}
+
+	if err := source.UpdateConfiguration(configuration); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// UpdateConfiguration applies the "project_id" and "subscription" settings. The subscription can
+// also be given by its full name, projects/{project}/subscriptions/{subscription}, in which case
+// the project ID is optional.
+func (ps *PubSubSource) UpdateConfiguration(configuration map[string]any) error {
+	projectID, _ := configuration["project_id"].(string)
+	subscription, _ := configuration["subscription"].(string)
+
+	if parts := strings.Split(subscription, "/"); len(parts) == 4 && parts[0] == "projects" && parts[2] == "subscriptions" {
+		if projectID != "" && projectID != parts[1] {
+			return fmt.Errorf("%w: subscription %s is not in project %s", ErrInvalidPubSubSource, subscription, projectID)
+		}
+
+		projectID = parts[1]
+		subscription = parts[3]
+	}
+
+	ps.ProjectID = projectID
+	ps.Subscription = subscription
+	ps.UpdatedAt = time.Now().UTC()
+
+	return ps.Validate()
+}
+
+// Validate performs validation on the Pub/Sub source structure.
+func (ps *PubSubSource) Validate() error {
+	if ps.ID == "" || ps.ProjectID == "" || ps.Subscription == "" || strings.Contains(ps.Subscription, "/") {
+		return ErrInvalidPubSubSource
+	}
+
+	return nil
+}
+
+// SubscriptionName returns the full name of the subscription of the source, which also groups
+// the sources sharing it.
+func (ps *PubSubSource) SubscriptionName() string {
+	return "projects/" + ps.ProjectID + "/subscriptions/" + ps.Subscription
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPubSubSource(t *testing.T) {
+	source, err := NewPubSubSource("source-1", map[string]any{"project_id": "shop", "subscription": "orders-sub"})
+	require.NoError(t, err)
+
+	assert.True(t, source.Active)
+	assert.Equal(t, "projects/shop/subscriptions/orders-sub", source.SubscriptionName())
+
+	source, err = NewPubSubSource("source-2", map[string]any{"subscription": "projects/shop/subscriptions/orders-sub"})
+	require.NoError(t, err)
+	assert.Equal(t, "shop", source.ProjectID)
+	assert.Equal(t, "orders-sub", source.Subscription)
+
+	testCases := []map[string]any{
+		{},
+		{"project_id": "shop"},
+		{"subscription": "orders-sub"},
+		{"project_id": "shop", "subscription": "projects/billing/subscriptions/orders-sub"},
+		{"project_id": "shop", "subscription": "topics/orders"},
+	}
+
+	for _, config := range testCases {
+		_, err := NewPubSubSource("source-1", config)
+		assert.ErrorIs(t, err, ErrInvalidPubSubSource, "config %v", config)
+	}
+}
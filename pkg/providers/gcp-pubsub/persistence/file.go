@@ -0,0 +1,163 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dukex/operion/pkg/providers/gcp-pubsub/models"
+)
+
+// FilePersistence implements PubSubPersistence using JSON files.
+type FilePersistence struct {
+	dataDir       string
+	mu            sync.RWMutex
+	pubsubSources map[string]*models.PubSubSource // ID -> PubSubSource mapping
+}
+
+// NewFilePersistence creates a new file-based Pub/Sub persistence.
+func NewFilePersistence(dataDir string) (*FilePersistence, error) {
+	if err := os.MkdirAll(dataDir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	fp := &FilePersistence{
+		dataDir:       dataDir,
+		pubsubSources: make(map[string]*models.PubSubSource),
+	}
+
+	// Load existing pubsub sources
+	if err := fp.loadPubSubSources(); err != nil {
+		return nil, fmt.Errorf("failed to load pubsub sources: %w", err)
+	}
+
+	return fp, nil
+}
+
+// SavePubSubSource saves a pubsub source to the file system.
+func (fp *FilePersistence) SavePubSubSource(source *models.PubSubSource) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	fp.pubsubSources[source.ID] = source
+
+	return fp.savePubSubSourcesToFile()
+}
+
+// PubSubSourceByID retrieves a pubsub source by its ID.
+func (fp *FilePersistence) PubSubSourceByID(id string) (*models.PubSubSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	source, exists := fp.pubsubSources[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return source, nil
+}
+
+// PubSubSources returns all pubsub sources.
+func (fp *FilePersistence) PubSubSources() ([]*models.PubSubSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	sources := make([]*models.PubSubSource, 0, len(fp.pubsubSources))
+	for _, source := range fp.pubsubSources {
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+// ActivePubSubSources returns only active pubsub sources.
+func (fp *FilePersistence) ActivePubSubSources() ([]*models.PubSubSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	var activeSources []*models.PubSubSource
+
+	for _, source := range fp.pubsubSources {
+		if source.Active {
+			activeSources = append(activeSources, source)
+		}
+	}
+
+	return activeSources, nil
+}
+
+// DeletePubSubSource removes a pubsub source by its ID.
+func (fp *FilePersistence) DeletePubSubSource(id string) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	delete(fp.pubsubSources, id)
+
+	return fp.savePubSubSourcesToFile()
+}
+
+// HealthCheck verifies that the persistence layer is healthy.
+func (fp *FilePersistence) HealthCheck() error {
+	if _, err := os.Stat(fp.dataDir); os.IsNotExist(err) {
+		return fmt.Errorf("data directory does not exist: %s", fp.dataDir)
+	}
+
+	return nil
+}
+
+// Close cleans up resources.
+func (fp *FilePersistence) Close() error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	return fp.savePubSubSourcesToFile()
+}
+
+// loadPubSubSources loads pubsub sources from the file system.
+func (fp *FilePersistence) loadPubSubSources() error {
+	sourcesFile := filepath.Join(fp.dataDir, "pubsub_sources.json")
+
+	if _, err := os.Stat(sourcesFile); os.IsNotExist(err) {
+		// File doesn't exist, start with empty sources
+		return nil
+	}
+
+	data, err := os.ReadFile(sourcesFile) // #nosec G304 -- sourcesFile is constructed from controlled dataDir
+	if err != nil {
+		return fmt.Errorf("failed to read pubsub sources file: %w", err)
+	}
+
+	var sources []*models.PubSubSource
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return fmt.Errorf("failed to unmarshal pubsub sources: %w", err)
+	}
+
+	for _, source := range sources {
+		fp.pubsubSources[source.ID] = source
+	}
+
+	return nil
+}
+
+// savePubSubSourcesToFile saves all pubsub sources to the file system.
+func (fp *FilePersistence) savePubSubSourcesToFile() error {
+	sourcesFile := filepath.Join(fp.dataDir, "pubsub_sources.json")
+
+	sources := make([]*models.PubSubSource, 0, len(fp.pubsubSources))
+	for _, source := range fp.pubsubSources {
+		sources = append(sources, source)
+	}
+
+	data, err := json.MarshalIndent(sources, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pubsub sources: %w", err)
+	}
+
+	if err := os.WriteFile(sourcesFile, data, 0600); err != nil {
+		return fmt.Errorf("failed to write pubsub sources file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+// Package persistence provides storage for Google Cloud Pub/Sub sources.
+package persistence
+
+import (
+	"github.com/dukex/operion/pkg/providers/gcp-pubsub/models"
+)
+
+// PubSubPersistence defines the persistence interface for the Pub/Sub provider.
+// This interface is specific to Pub/Sub source needs and isolated from core persistence.
+type PubSubPersistence interface {
+	// PubSubSource operations
+	SavePubSubSource(source *models.PubSubSource) error
+	PubSubSourceByID(id string) (*models.PubSubSource, error)
+	PubSubSources() ([]*models.PubSubSource, error)
+	ActivePubSubSources() ([]*models.PubSubSource, error)
+	DeletePubSubSource(id string) error
+
+	// Health and lifecycle
+	HealthCheck() error
+	Close() error
+}
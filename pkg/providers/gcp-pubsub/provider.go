@@ -0,0 +1,505 @@
+// Package gcppubsub provides a source provider that receives the messages of Google Cloud
+// Pub/Sub subscriptions.
+package gcppubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2"
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/google/uuid"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	pubsubModels "github.com/dukex/operion/pkg/providers/gcp-pubsub/models"
+	pubsubPersistence "github.com/dukex/operion/pkg/providers/gcp-pubsub/persistence"
+)
+
+const (
+	// ProviderID identifies the Pub/Sub provider in trigger nodes and source events.
+	ProviderID = "gcppubsub"
+
+	// EventTypeMessageReceived is the type of the source events emitted for Pub/Sub messages.
+	EventTypeMessageReceived = "MessageReceived"
+
+	// receiveRetryBackoff is how long a receiver waits before receiving again after an error.
+	receiveRetryBackoff = 5 * time.Second
+)
+
+var (
+	// ErrSubscriptionNotFound is returned by Prepare when a configured subscription does not exist.
+	ErrSubscriptionNotFound = errors.New("pubsub subscription not found")
+
+	// ErrCredentials is returned by Validate and Prepare when Pub/Sub cannot be reached with the
+	// configured credentials.
+	ErrCredentials = errors.New("pubsub credentials error")
+)
+
+// subscriptionGroup is a set of sources sharing the same subscription, served by a single receiver.
+type subscriptionGroup struct {
+	projectID string
+	name      string
+	sourceIDs []string
+}
+
+// PubSubProvider implements a centralized Pub/Sub orchestrator that receives the messages of the
+// configured subscriptions and converts them to source events.
+type PubSubProvider struct {
+	config            map[string]any
+	logger            *slog.Logger
+	callback          protocol.SourceEventCallback
+	pubsubPersistence pubsubPersistence.PubSubPersistence
+	clients           map[string]*pubsub.Client // projectID -> client
+	groups            map[string]*subscriptionGroup
+	cancel            context.CancelFunc
+	wg                sync.WaitGroup
+	started           bool
+	mu                sync.RWMutex
+}
+
+// Start begins receiving the messages of every configured subscription.
+func (p *PubSubProvider) Start(ctx context.Context, callback protocol.SourceEventCallback) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.started {
+		return nil
+	}
+
+	p.callback = callback
+	p.logger.Info("Starting Pub/Sub orchestrator", "subscriptions", len(p.groups))
+
+	receiveCtx, cancel := context.WithCancel(ctx)
+	p.cancel = cancel
+
+	for _, group := range p.groups {
+		p.wg.Add(1)
+
+		go p.runGroup(receiveCtx, group)
+	}
+
+	p.started = true
+	p.logger.Info("Pub/Sub orchestrator started successfully")
+
+	return nil
+}
+
+// Stop gracefully shuts down all receivers and closes the Pub/Sub clients.
+func (p *PubSubProvider) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.started {
+		return nil
+	}
+
+	p.logger.Info("Stopping Pub/Sub orchestrator")
+
+	p.cancel()
+	p.wg.Wait()
+	p.closeClients()
+
+	p.started = false
+	p.logger.Info("Pub/Sub orchestrator stopped successfully")
+
+	return nil
+}
+
+// Validate checks if the Pub/Sub orchestrator configuration is valid.
+func (p *PubSubProvider) Validate() error {
+	if p.pubsubPersistence == nil {
+		return errors.New("pubsub persistence not initialized")
+	}
+
+	if credentialsFile := p.credentialsFile(); credentialsFile != "" {
+		if _, err := os.Stat(credentialsFile); err != nil {
+			return fmt.Errorf("%w: %w", ErrCredentials, err)
+		}
+	}
+
+	return nil
+}
+
+// ProviderLifecycle interface implementation
+
+// Initialize sets up the provider with required dependencies.
+func (p *PubSubProvider) Initialize(ctx context.Context, deps protocol.Dependencies) error {
+	p.logger = deps.Logger
+
+	persistenceURL := os.Getenv("GCP_PUBSUB_PERSISTENCE_URL")
+	if persistenceURL == "" {
+		return errors.New("pubsub provider requires GCP_PUBSUB_PERSISTENCE_URL environment variable (e.g., file://./data/gcp-pubsub)")
+	}
+
+	persistence, err := p.createPersistence(persistenceURL)
+	if err != nil {
+		return err
+	}
+
+	p.pubsubPersistence = persistence
+
+	p.logger.Info("Pub/Sub provider initialized", "persistence", persistenceURL, "emulator_host", p.emulatorHost())
+
+	return nil
+}
+
+// Configure configures the provider based on current workflow definitions.
+func (p *PubSubProvider) Configure(workflows []*models.Workflow) (map[string]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.logger.Info("Configuring Pub/Sub provider with workflows", "workflow_count", len(workflows))
+
+	triggerToSource := make(map[string]string)
+	configureErr := protocol.NewConfigureError(ProviderID)
+
+	for _, wf := range workflows {
+		if wf.Status != models.WorkflowStatusPublished {
+			continue
+		}
+
+		// Filter trigger nodes with pubsub provider
+		for _, node := range wf.Nodes {
+			if node.IsTriggerNode() && node.ProviderID != nil && *node.ProviderID == ProviderID {
+				sourceID, err := p.processPubSubTriggerNode(wf.ID, node)
+				if err != nil {
+					configureErr.Add(wf.ID, node.ID, err)
+
+					continue
+				}
+
+				triggerToSource[node.ID] = sourceID
+			}
+		}
+	}
+
+	p.logger.Info("Pub/Sub configuration completed", "configured_sources", len(triggerToSource))
+
+	return triggerToSource, configureErr.ErrOrNil()
+}
+
+// Prepare groups the active sources by subscription and connects to Pub/Sub, checking that the
+// credentials are accepted and that every subscription exists.
+func (p *PubSubProvider) Prepare(ctx context.Context) error {
+	if p.pubsubPersistence == nil {
+		return errors.New("pubsub provider not initialized")
+	}
+
+	sources, err := p.pubsubPersistence.ActivePubSubSources()
+	if err != nil {
+		return err
+	}
+
+	p.closeClients()
+
+	groups := groupSources(sources)
+	clients := make(map[string]*pubsub.Client)
+
+	var errs []error
+
+	for _, group := range groups {
+		client, exists := clients[group.projectID]
+		if !exists {
+			client, err = p.newClient(ctx, group.projectID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%w: project %s: %w", ErrCredentials, group.projectID, err))
+
+				continue
+			}
+
+			clients[group.projectID] = client
+		}
+
+		if err := checkSubscription(ctx, client, group.name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	p.clients = clients
+	p.groups = groups
+
+	if len(errs) > 0 {
+		p.closeClients()
+
+		return errors.Join(errs...)
+	}
+
+	p.logger.Info("Pub/Sub provider prepared and ready",
+		"sources", len(sources),
+		"subscriptions", len(groups))
+
+	return nil
+}
+
+// groupSources groups sources by subscription so each subscription is received once.
+func groupSources(sources []*pubsubModels.PubSubSource) map[string]*subscriptionGroup {
+	groups := make(map[string]*subscriptionGroup)
+
+	for _, source := range sources {
+		name := source.SubscriptionName()
+
+		group, exists := groups[name]
+		if !exists {
+			group = &subscriptionGroup{projectID: source.ProjectID, name: name}
+			groups[name] = group
+		}
+
+		group.sourceIDs = append(group.sourceIDs, source.ID)
+	}
+
+	return groups
+}
+
+// checkSubscription returns ErrSubscriptionNotFound if the subscription does not exist and
+// ErrCredentials if the credentials are not allowed to read it.
+func checkSubscription(ctx context.Context, client *pubsub.Client, name string) error {
+	_, err := client.SubscriptionAdminClient.GetSubscription(ctx, &pubsubpb.GetSubscriptionRequest{Subscription: name})
+
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.NotFound:
+		return fmt.Errorf("%w: %s", ErrSubscriptionNotFound, name)
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return fmt.Errorf("%w: subscription %s: %w", ErrCredentials, name, err)
+	default:
+		return fmt.Errorf("failed to get subscription %s: %w", name, err)
+	}
+}
+
+// runGroup receives the messages of a subscription until the context is cancelled, receiving
+// again after an error.
+func (p *PubSubProvider) runGroup(ctx context.Context, group *subscriptionGroup) {
+	defer p.wg.Done()
+
+	subscriber := p.clients[group.projectID].Subscriber(group.name)
+	logger := p.logger.With("subscription", group.name)
+
+	for {
+		err := subscriber.Receive(ctx, func(ctx context.Context, message *pubsub.Message) {
+			p.handleMessage(ctx, group, message)
+		})
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.Error("Pub/Sub receive error", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(receiveRetryBackoff):
+		}
+	}
+}
+
+// handleMessage emits a message for every source of its subscription. The message is
+// acknowledged once every source published it, and negatively acknowledged otherwise so Pub/Sub
+// redelivers it to all of them.
+func (p *PubSubProvider) handleMessage(ctx context.Context, group *subscriptionGroup, message *pubsub.Message) {
+	eventData := messageEventData(group.name, message)
+
+	if message.OrderingKey != "" {
+		ctx = events.WithOrderingKey(ctx, message.OrderingKey)
+	}
+
+	published := true
+
+	for _, sourceID := range group.sourceIDs {
+		if err := p.callback(ctx, sourceID, ProviderID, EventTypeMessageReceived, eventData); err != nil {
+			p.logger.Error("Failed to publish Pub/Sub message",
+				"source_id", sourceID,
+				"subscription", group.name,
+				"message_id", message.ID,
+				"error", err)
+
+			published = false
+		}
+	}
+
+	if !published {
+		message.Nack()
+
+		return
+	}
+
+	message.Ack()
+}
+
+// messageEventData builds the source event data of a message. JSON data is parsed, other data is
+// passed as a string.
+func messageEventData(subscription string, message *pubsub.Message) map[string]any {
+	var data any
+
+	if err := json.Unmarshal(message.Data, &data); err != nil {
+		data = string(message.Data)
+	}
+
+	attributes := make(map[string]any, len(message.Attributes))
+	for key, value := range message.Attributes {
+		attributes[key] = value
+	}
+
+	eventData := map[string]any{
+		"subscription": subscription,
+		"message_id":   message.ID,
+		"data":         data,
+		"attributes":   attributes,
+		"publish_time": message.PublishTime.UTC().Format(time.RFC3339Nano),
+	}
+
+	if message.OrderingKey != "" {
+		eventData["ordering_key"] = message.OrderingKey
+	}
+
+	if message.DeliveryAttempt != nil {
+		eventData["delivery_attempt"] = *message.DeliveryAttempt
+	}
+
+	return eventData
+}
+
+// processPubSubTriggerNode creates or updates the Pub/Sub source of a trigger node.
+// Returns the sourceID if the source was successfully saved, an error otherwise.
+func (p *PubSubProvider) processPubSubTriggerNode(workflowID string, node *models.WorkflowNode) (string, error) {
+	sourceID := ""
+	if node.SourceID != nil {
+		sourceID = *node.SourceID
+	}
+
+	if sourceID == "" {
+		// Generate a new UUID for the sourceID
+		sourceID = uuid.New().String()
+		p.logger.Info("Generated source_id for pubsub trigger node",
+			"workflow_id", workflowID,
+			"node_id", node.ID,
+			"generated_source_id", sourceID)
+	}
+
+	existingSource, err := p.pubsubPersistence.PubSubSourceByID(sourceID)
+	if err != nil {
+		p.logger.Error("Failed to check existing pubsub source",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", fmt.Errorf("failed to check existing pubsub source: %w", err)
+	}
+
+	source := existingSource
+	if source != nil {
+		err = source.UpdateConfiguration(node.Config)
+	} else {
+		source, err = pubsubModels.NewPubSubSource(sourceID, node.Config)
+	}
+
+	if err != nil {
+		p.logger.Error("Invalid pubsub source configuration",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", err
+	}
+
+	if err := p.pubsubPersistence.SavePubSubSource(source); err != nil {
+		p.logger.Error("Failed to save pubsub source",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", fmt.Errorf("failed to save pubsub source: %w", err)
+	}
+
+	p.logger.Info("Configured pubsub source",
+		"source_id", sourceID,
+		"subscription", source.SubscriptionName())
+
+	return sourceID, nil
+}
+
+// newClient creates a Pub/Sub client for a project, connecting to the emulator when one is
+// configured and authenticating with the configured credentials file otherwise.
+func (p *PubSubProvider) newClient(ctx context.Context, projectID string) (*pubsub.Client, error) {
+	var opts []option.ClientOption
+
+	if emulatorHost := p.emulatorHost(); emulatorHost != "" {
+		opts = append(opts,
+			option.WithEndpoint(emulatorHost),
+			option.WithoutAuthentication(),
+			option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+		)
+	} else if credentialsFile := p.credentialsFile(); credentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credentialsFile))
+	}
+
+	return pubsub.NewClient(ctx, projectID, opts...)
+}
+
+// closeClients closes the Pub/Sub clients of the prepared subscriptions.
+func (p *PubSubProvider) closeClients() {
+	for projectID, client := range p.clients {
+		if err := client.Close(); err != nil {
+			p.logger.Warn("Failed to close Pub/Sub client", "project_id", projectID, "error", err)
+		}
+	}
+
+	p.clients = nil
+}
+
+// emulatorHost gets the Pub/Sub emulator address from configuration or environment.
+func (p *PubSubProvider) emulatorHost() string {
+	if emulatorHost, ok := p.config["emulator_host"].(string); ok && emulatorHost != "" {
+		return emulatorHost
+	}
+
+	return os.Getenv("PUBSUB_EMULATOR_HOST")
+}
+
+// credentialsFile gets the credentials file from configuration.
+func (p *PubSubProvider) credentialsFile() string {
+	credentialsFile, _ := p.config["credentials_file"].(string)
+
+	return credentialsFile
+}
+
+// createPersistence creates the appropriate persistence implementation based on URL scheme.
+func (p *PubSubProvider) createPersistence(persistenceURL string) (pubsubPersistence.PubSubPersistence, error) {
+	scheme := p.parsePersistenceScheme(persistenceURL)
+	p.logger.Info("Initializing pubsub persistence", "scheme", scheme, "url", persistenceURL)
+
+	switch scheme {
+	case "file":
+		// Extract path from file://path
+		path := strings.TrimPrefix(persistenceURL, "file://")
+
+		return pubsubPersistence.NewFilePersistence(path)
+	case "postgres", "postgresql":
+		// Future: implement database persistence
+		return nil, errors.New("postgres persistence for pubsub not yet implemented")
+	default:
+		return nil, errors.New("unsupported persistence scheme: " + scheme + " (supported: file)")
+	}
+}
+
+// parsePersistenceScheme extracts the scheme from a persistence URL.
+func (p *PubSubProvider) parsePersistenceScheme(persistenceURL string) string {
+	parts := strings.SplitN(persistenceURL, "://", 2)
+	if len(parts) < 2 {
+		return "unknown"
+	}
+
+	return parts[0]
+}
@@ -0,0 +1,54 @@
+//go:build integration
+// +build integration
+
+package gcppubsub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go/modules/gcloud/pubsub"
+)
+
+// setupEmulatorContainer starts the Pub/Sub emulator of the Google Cloud SDK and returns its address.
+func setupEmulatorContainer(t *testing.T) string {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := pubsub.Run(ctx,
+		"gcr.io/google.com/cloudsdktool/cloud-sdk:emulators",
+		pubsub.WithProjectID(testProjectID),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, container.Terminate(ctx))
+	})
+
+	return container.URI()
+}
+
+func TestPubSubProvider_Integration_DeliversMessages(t *testing.T) {
+	host := setupEmulatorContainer(t)
+
+	runDeliveryScenario(t, host)
+}
+
+func TestPubSubProvider_Integration_RedeliversOnCallbackFailure(t *testing.T) {
+	host := setupEmulatorContainer(t)
+
+	runRedeliveryScenario(t, host)
+}
+
+func TestPubSubProvider_Integration_PrepareSubscriptionNotFound(t *testing.T) {
+	host := setupEmulatorContainer(t)
+
+	provider, _ := createTestProvider(t, host, map[string]map[string]any{
+		"missing": {"project_id": testProjectID, "subscription": "missing-sub"},
+	})
+
+	require.ErrorIs(t, provider.Prepare(t.Context()), ErrSubscriptionNotFound)
+}
@@ -0,0 +1,315 @@
+package gcppubsub
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2"
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/v2/pstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	pubsubPersistence "github.com/dukex/operion/pkg/providers/gcp-pubsub/persistence"
+)
+
+const testProjectID = "test-project"
+
+// recordedEvents collects the source events published by the provider. The first failures
+// publishes fail.
+type recordedEvents struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	events   map[string][]map[string]any
+}
+
+func (r *recordedEvents) callback(_ context.Context, sourceID, providerID, eventType string, eventData map[string]any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls++
+	if r.calls <= r.failures {
+		return errors.New("event bus unavailable")
+	}
+
+	r.events[sourceID] = append(r.events[sourceID], eventData)
+
+	return nil
+}
+
+func (r *recordedEvents) received(sourceID string) []map[string]any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]map[string]any(nil), r.events[sourceID]...)
+}
+
+func (r *recordedEvents) callCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.calls
+}
+
+func createTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// startFakeEmulator starts an in-memory Pub/Sub emulator and returns it with its address.
+func startFakeEmulator(t *testing.T) (*pstest.Server, string) {
+	t.Helper()
+
+	server := pstest.NewServer()
+	t.Cleanup(func() { _ = server.Close() })
+
+	return server, server.Addr
+}
+
+// newEmulatorClient connects a Pub/Sub client to the emulator at host.
+func newEmulatorClient(t *testing.T, host string) *pubsub.Client {
+	t.Helper()
+
+	client, err := pubsub.NewClient(t.Context(), testProjectID,
+		option.WithEndpoint(host),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+// createSubscription creates a topic and a subscription to it on the emulator.
+func createSubscription(t *testing.T, client *pubsub.Client, topic, subscription string) {
+	t.Helper()
+
+	topicName := "projects/" + testProjectID + "/topics/" + topic
+
+	_, err := client.TopicAdminClient.CreateTopic(t.Context(), &pubsubpb.Topic{Name: topicName})
+	require.NoError(t, err)
+
+	_, err = client.SubscriptionAdminClient.CreateSubscription(t.Context(), &pubsubpb.Subscription{
+		Name:               "projects/" + testProjectID + "/subscriptions/" + subscription,
+		Topic:              topicName,
+		AckDeadlineSeconds: 10,
+	})
+	require.NoError(t, err)
+}
+
+// publish publishes a message to a topic and returns its ID.
+func publish(t *testing.T, client *pubsub.Client, topic string, message *pubsub.Message) string {
+	t.Helper()
+
+	publisher := client.Publisher(topic)
+	defer publisher.Stop()
+
+	id, err := publisher.Publish(t.Context(), message).Get(t.Context())
+	require.NoError(t, err)
+
+	return id
+}
+
+// createTestProvider creates a provider connected to the emulator at host, with a trigger node
+// per entry of triggers mapping a node ID to its config.
+func createTestProvider(t *testing.T, host string, triggers map[string]map[string]any) (*PubSubProvider, map[string]string) {
+	t.Helper()
+
+	persistence, err := pubsubPersistence.NewFilePersistence(t.TempDir())
+	require.NoError(t, err)
+
+	provider := &PubSubProvider{
+		config:            map[string]any{"emulator_host": host},
+		logger:            createTestLogger(),
+		pubsubPersistence: persistence,
+	}
+
+	providerID := ProviderID
+	workflow := &models.Workflow{ID: "wf-1", Status: models.WorkflowStatusPublished}
+
+	for nodeID, config := range triggers {
+		workflow.Nodes = append(workflow.Nodes, &models.WorkflowNode{
+			ID: nodeID, Category: models.CategoryTypeTrigger, ProviderID: &providerID, Config: config,
+		})
+	}
+
+	triggerToSource, err := provider.Configure([]*models.Workflow{workflow})
+	require.NoError(t, err)
+
+	return provider, triggerToSource
+}
+
+// startTestProvider prepares and starts the provider, stopping it when the test ends.
+func startTestProvider(t *testing.T, provider *PubSubProvider, recorder *recordedEvents) {
+	t.Helper()
+
+	require.NoError(t, provider.Validate())
+	require.NoError(t, provider.Prepare(t.Context()))
+	require.NoError(t, provider.Start(context.Background(), recorder.callback))
+
+	t.Cleanup(func() { _ = provider.Stop(context.Background()) })
+}
+
+// runDeliveryScenario publishes a message to a subscription shared by two triggers, with a third
+// trigger on another subscription, and asserts both sharing triggers receive it once with its
+// data and attributes. It returns the ID of the message.
+func runDeliveryScenario(t *testing.T, host string) string {
+	t.Helper()
+
+	client := newEmulatorClient(t, host)
+	createSubscription(t, client, "orders", "orders-sub")
+	createSubscription(t, client, "invoices", "invoices-sub")
+
+	provider, triggerToSource := createTestProvider(t, host, map[string]map[string]any{
+		"audit":    {"project_id": testProjectID, "subscription": "orders-sub"},
+		"shipping": {"subscription": "projects/" + testProjectID + "/subscriptions/orders-sub"},
+		"billing":  {"project_id": testProjectID, "subscription": "invoices-sub"},
+	})
+
+	recorder := &recordedEvents{events: make(map[string][]map[string]any)}
+	startTestProvider(t, provider, recorder)
+	assert.Len(t, provider.groups, 2, "sources are grouped by subscription")
+
+	messageID := publish(t, client, "orders", &pubsub.Message{
+		Data:       []byte(`{"order_id": "42", "total": 99.5}`),
+		Attributes: map[string]string{"origin": "checkout", "version": "2"},
+	})
+
+	for _, nodeID := range []string{"audit", "shipping"} {
+		require.Eventually(t, func() bool {
+			return len(recorder.received(triggerToSource[nodeID])) > 0
+		}, 10*time.Second, 20*time.Millisecond, "trigger %s received the message", nodeID)
+	}
+
+	// An acknowledged message is not redelivered
+	time.Sleep(500 * time.Millisecond)
+
+	for _, nodeID := range []string{"audit", "shipping"} {
+		received := recorder.received(triggerToSource[nodeID])
+		require.Len(t, received, 1)
+
+		event := received[0]
+		assert.Equal(t, messageID, event["message_id"])
+		assert.Equal(t, "projects/"+testProjectID+"/subscriptions/orders-sub", event["subscription"])
+		assert.Equal(t, map[string]any{"order_id": "42", "total": 99.5}, event["data"])
+		assert.Equal(t, map[string]any{"origin": "checkout", "version": "2"}, event["attributes"])
+		assert.NotEmpty(t, event["publish_time"])
+	}
+
+	assert.Empty(t, recorder.received(triggerToSource["billing"]))
+
+	return messageID
+}
+
+// runRedeliveryScenario publishes a message whose first publish fails and asserts it is
+// redelivered until it is published. It returns the ID of the message.
+func runRedeliveryScenario(t *testing.T, host string) string {
+	t.Helper()
+
+	client := newEmulatorClient(t, host)
+	createSubscription(t, client, "payments", "payments-sub")
+
+	provider, triggerToSource := createTestProvider(t, host, map[string]map[string]any{
+		"payments": {"project_id": testProjectID, "subscription": "payments-sub"},
+	})
+
+	recorder := &recordedEvents{failures: 1, events: make(map[string][]map[string]any)}
+	startTestProvider(t, provider, recorder)
+
+	messageID := publish(t, client, "payments", &pubsub.Message{Data: []byte("not json")})
+
+	require.Eventually(t, func() bool {
+		return len(recorder.received(triggerToSource["payments"])) == 1
+	}, 20*time.Second, 20*time.Millisecond, "the message is redelivered after the failed publish")
+
+	assert.Equal(t, 2, recorder.callCount())
+	assert.Equal(t, "not json", recorder.received(triggerToSource["payments"])[0]["data"])
+
+	return messageID
+}
+
+func TestPubSubProvider_DeliversMessages(t *testing.T) {
+	server, host := startFakeEmulator(t)
+
+	messageID := runDeliveryScenario(t, host)
+
+	// One ack for the message, sent once every source published it
+	require.Eventually(t, func() bool {
+		return server.Message(messageID).Acks == 1
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestPubSubProvider_RedeliversOnCallbackFailure(t *testing.T) {
+	server, host := startFakeEmulator(t)
+
+	messageID := runRedeliveryScenario(t, host)
+
+	// Only the successful delivery is acknowledged
+	require.Eventually(t, func() bool {
+		return server.Message(messageID).Acks == 1
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, 2, server.Message(messageID).Deliveries)
+}
+
+func TestPubSubProvider_PrepareSubscriptionNotFound(t *testing.T) {
+	_, host := startFakeEmulator(t)
+
+	provider, _ := createTestProvider(t, host, map[string]map[string]any{
+		"missing": {"project_id": testProjectID, "subscription": "missing-sub"},
+	})
+
+	err := provider.Prepare(t.Context())
+	require.ErrorIs(t, err, ErrSubscriptionNotFound)
+	assert.Contains(t, err.Error(), "projects/"+testProjectID+"/subscriptions/missing-sub")
+}
+
+func TestPubSubProvider_CredentialsErrors(t *testing.T) {
+	provider, _ := createTestProvider(t, "", map[string]map[string]any{
+		"orders": {"project_id": testProjectID, "subscription": "orders-sub"},
+	})
+
+	// Validate refuses a credentials file that does not exist
+	provider.config = map[string]any{"credentials_file": filepath.Join(t.TempDir(), "missing.json")}
+	require.ErrorIs(t, provider.Validate(), ErrCredentials)
+
+	// Prepare refuses credentials Pub/Sub clients cannot be created with
+	credentialsFile := filepath.Join(t.TempDir(), "credentials.json")
+	require.NoError(t, os.WriteFile(credentialsFile, []byte(`{"type": "unknown"}`), 0600))
+
+	provider.config = map[string]any{"credentials_file": credentialsFile}
+	require.NoError(t, provider.Validate())
+	require.ErrorIs(t, provider.Prepare(t.Context()), ErrCredentials)
+}
+
+func TestPubSubProvider_ConfigureRejectsInvalidTriggers(t *testing.T) {
+	provider, _ := createTestProvider(t, "", nil)
+	providerID := ProviderID
+
+	triggerToSource, err := provider.Configure([]*models.Workflow{{
+		ID:     "wf-2",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "valid", Category: models.CategoryTypeTrigger, ProviderID: &providerID, Config: map[string]any{"project_id": "shop", "subscription": "orders-sub"}},
+			{ID: "invalid", Category: models.CategoryTypeTrigger, ProviderID: &providerID, Config: map[string]any{"subscription": "orders-sub"}},
+		},
+	}})
+
+	var configureErr *protocol.ConfigureError
+	require.ErrorAs(t, err, &configureErr)
+	require.Len(t, configureErr.Workflows["wf-2"], 1)
+	assert.Equal(t, "invalid", configureErr.Workflows["wf-2"][0].TriggerID)
+	assert.Len(t, triggerToSource, 1)
+}
@@ -0,0 +1,65 @@
+package httppoll
+
+import (
+	"log/slog"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// HTTPPollProviderFactory creates instances of HTTPPollProvider.
+type HTTPPollProviderFactory struct{}
+
+// NewHTTPPollProviderFactory creates a new factory instance.
+func NewHTTPPollProviderFactory() *HTTPPollProviderFactory {
+	return &HTTPPollProviderFactory{}
+}
+
+// Create instantiates a new centralized HTTPPollProvider orchestrator.
+func (f *HTTPPollProviderFactory) Create(config map[string]any, logger *slog.Logger) (protocol.Provider, error) {
+	// Persistence and HTTP client are initialized during the Initialize lifecycle method
+	return &HTTPPollProvider{
+		config: config,
+		logger: logger.With("module", "centralized_http_poll"),
+	}, nil
+}
+
+// ID returns the unique identifier for this source provider type.
+func (f *HTTPPollProviderFactory) ID() string {
+	return "httppoll"
+}
+
+// Name returns a human-readable name for this source provider.
+func (f *HTTPPollProviderFactory) Name() string {
+	return "HTTP Poll"
+}
+
+// Description returns a detailed description of what this source provider does.
+func (f *HTTPPollProviderFactory) Description() string {
+	return "A centralized HTTP polling orchestrator that periodically requests configured URLs with GET and emits the responses as source events, optionally only when the response changes (ETag, Last-Modified or content hash). Sources sharing a URL and interval are served by a single request."
+}
+
+// Schema returns a JSON Schema that describes the orchestrator configuration.
+func (f *HTTPPollProviderFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"timeout": map[string]any{
+				"type":        "string",
+				"description": "HTTP request timeout for each poll (default: 30s)",
+				"examples":    []string{"10s", "30s", "1m"},
+				"default":     "30s",
+			},
+		},
+		"required":             []string{},
+		"additionalProperties": false,
+		"description":          "Centralized HTTP poll orchestrator configuration. Individual URLs and intervals are defined in workflow triggers, not here.",
+	}
+}
+
+// EventTypes returns a list of event types that this source provider can emit.
+func (f *HTTPPollProviderFactory) EventTypes() []string {
+	return []string{"ResponseReceived"}
+}
+
+// Ensure interface compliance.
+var _ protocol.ProviderFactory = (*HTTPPollProviderFactory)(nil)
@@ -0,0 +1,151 @@
+// Package models defines the data structures used by the HTTP polling provider.
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"time"
+)
+
+// DefaultInterval is the polling interval used when a source does not configure one.
+const DefaultInterval = time.Minute
+
+// ErrInvalidPollSource is returned when poll source validation fails.
+var ErrInvalidPollSource = errors.New("invalid poll source")
+
+// PollSource represents an HTTP endpoint polled on a fixed interval.
+// It also stores the last-seen response state used for change detection.
+type PollSource struct {
+	// ID is the source identifier used in workflows
+	ID string `json:"id" validate:"required"`
+
+	// URL is the endpoint requested with GET on every poll
+	URL string `json:"url" validate:"required,url"`
+
+	// Interval is the time between two polls
+	Interval time.Duration `json:"interval" validate:"required"`
+
+	// ChangeDetection emits events only when the response differs from the last seen one
+	ChangeDetection bool `json:"change_detection"`
+
+	// ETag is the entity tag of the last seen response
+	ETag string `json:"etag,omitempty"`
+
+	// LastModified is the Last-Modified header of the last seen response
+	LastModified string `json:"last_modified,omitempty"`
+
+	// ContentHash is the SHA-256 of the last seen response body
+	ContentHash string `json:"content_hash,omitempty"`
+
+	// LastPolledAt is the timestamp of the last successful poll
+	LastPolledAt *time.Time `json:"last_polled_at,omitempty"`
+
+	// CreatedAt is the timestamp when this source was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt is the timestamp when this source was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Active indicates if this source should be polled
+	Active bool `json:"active"`
+}
+
+// NewPollSource creates a new poll source from a trigger node configuration.
+func NewPollSource(sourceID string, configuration map[string]any) (*PollSource, error) {
+	now := time.Now().UTC()
+
+	source := &PollSource{
+		ID:        sourceID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Active:    true,
+	}
+
+	if err := source.UpdateConfiguration(configuration); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// UpdateConfiguration applies the "url", "interval" and "change_detection" settings.
+// The last-seen state is reset when the URL changes.
+func (ps *PollSource) UpdateConfiguration(configuration map[string]any) error {
+	rawURL, _ := configuration["url"].(string)
+
+	interval := DefaultInterval
+
+	if rawInterval, ok := configuration["interval"].(string); ok && rawInterval != "" {
+		parsed, err := time.ParseDuration(rawInterval)
+		if err != nil {
+			return ErrInvalidPollSource
+		}
+
+		interval = parsed
+	}
+
+	changeDetection, _ := configuration["change_detection"].(bool)
+
+	if rawURL != ps.URL {
+		ps.ETag = ""
+		ps.LastModified = ""
+		ps.ContentHash = ""
+	}
+
+	ps.URL = rawURL
+	ps.Interval = interval
+	ps.ChangeDetection = changeDetection
+	ps.UpdatedAt = time.Now().UTC()
+
+	return ps.Validate()
+}
+
+// Validate performs validation on the poll source structure.
+func (ps *PollSource) Validate() error {
+	if ps.ID == "" || ps.Interval <= 0 {
+		return ErrInvalidPollSource
+	}
+
+	parsed, err := url.Parse(ps.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidPollSource
+	}
+
+	return nil
+}
+
+// GroupKey identifies the sources that share a single request: same URL and interval.
+func (ps *PollSource) GroupKey() string {
+	return ps.Interval.String() + " " + ps.URL
+}
+
+// HasChanged reports whether a response differs from the last seen one.
+// ETag is compared first, then Last-Modified, then the body hash.
+func (ps *PollSource) HasChanged(etag, lastModified string, body []byte) bool {
+	switch {
+	case etag != "":
+		return etag != ps.ETag
+	case lastModified != "":
+		return lastModified != ps.LastModified
+	default:
+		return HashContent(body) != ps.ContentHash
+	}
+}
+
+// RecordResponse stores a response as the last seen state.
+func (ps *PollSource) RecordResponse(etag, lastModified string, body []byte, polledAt time.Time) {
+	ps.ETag = etag
+	ps.LastModified = lastModified
+	ps.ContentHash = HashContent(body)
+	ps.LastPolledAt = &polledAt
+	ps.UpdatedAt = polledAt
+}
+
+// HashContent returns the hex encoded SHA-256 of a response body.
+func HashContent(body []byte) string {
+	sum := sha256.Sum256(body)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPollSource(t *testing.T) {
+	source, err := NewPollSource("source-1", map[string]any{"url": "https://api.example.com/items"})
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultInterval, source.Interval)
+	assert.False(t, source.ChangeDetection)
+	assert.True(t, source.Active)
+
+	testCases := []map[string]any{
+		{},
+		{"url": "ftp://example.com/file"},
+		{"url": "https://api.example.com/items", "interval": "soon"},
+		{"url": "https://api.example.com/items", "interval": "-1m"},
+	}
+
+	for _, config := range testCases {
+		_, err := NewPollSource("source-1", config)
+		assert.ErrorIs(t, err, ErrInvalidPollSource, "config %v", config)
+	}
+}
+
+func TestPollSource_HasChanged(t *testing.T) {
+	source := &PollSource{}
+	source.RecordResponse(`"abc"`, "", []byte("body"), time.Now())
+
+	assert.False(t, source.HasChanged(`"abc"`, "", []byte("other body")), "ETag takes precedence over content")
+	assert.True(t, source.HasChanged(`"def"`, "", []byte("body")))
+
+	source.RecordResponse("", "Wed, 21 Oct 2015 07:28:00 GMT", []byte("body"), time.Now())
+	assert.False(t, source.HasChanged("", "Wed, 21 Oct 2015 07:28:00 GMT", []byte("other body")))
+	assert.True(t, source.HasChanged("", "Thu, 22 Oct 2015 07:28:00 GMT", []byte("body")))
+
+	source.RecordResponse("", "", []byte("body"), time.Now())
+	assert.False(t, source.HasChanged("", "", []byte("body")))
+	assert.True(t, source.HasChanged("", "", []byte("other body")))
+}
+
+func TestPollSource_UpdateConfiguration_ResetsStateOnURLChange(t *testing.T) {
+	source, err := NewPollSource("source-1", map[string]any{"url": "https://api.example.com/a", "change_detection": true})
+	require.NoError(t, err)
+
+	source.RecordResponse(`"abc"`, "", []byte("body"), time.Now())
+
+	require.NoError(t, source.UpdateConfiguration(map[string]any{"url": "https://api.example.com/a", "change_detection": true}))
+	assert.Equal(t, `"abc"`, source.ETag)
+
+	require.NoError(t, source.UpdateConfiguration(map[string]any{"url": "https://api.example.com/b"}))
+	assert.Empty(t, source.ETag)
+	assert.Empty(t, source.ContentHash)
+}
@@ -0,0 +1,163 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dukex/operion/pkg/providers/http-poll/models"
+)
+
+// FilePersistence implements HTTPPollPersistence using JSON files.
+type FilePersistence struct {
+	dataDir     string
+	mu          sync.RWMutex
+	pollSources map[string]*models.PollSource // ID -> PollSource mapping
+}
+
+// NewFilePersistence creates a new file-based polling persistence.
+func NewFilePersistence(dataDir string) (*FilePersistence, error) {
+	if err := os.MkdirAll(dataDir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	fp := &FilePersistence{
+		dataDir:     dataDir,
+		pollSources: make(map[string]*models.PollSource),
+	}
+
+	// Load existing poll sources
+	if err := fp.loadPollSources(); err != nil {
+		return nil, fmt.Errorf("failed to load poll sources: %w", err)
+	}
+
+	return fp, nil
+}
+
+// SavePollSource saves a poll source to the file system.
+func (fp *FilePersistence) SavePollSource(source *models.PollSource) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	fp.pollSources[source.ID] = source
+
+	return fp.savePollSourcesToFile()
+}
+
+// PollSourceByID retrieves a poll source by its ID.
+func (fp *FilePersistence) PollSourceByID(id string) (*models.PollSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	source, exists := fp.pollSources[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return source, nil
+}
+
+// PollSources returns all poll sources.
+func (fp *FilePersistence) PollSources() ([]*models.PollSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	sources := make([]*models.PollSource, 0, len(fp.pollSources))
+	for _, source := range fp.pollSources {
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+// ActivePollSources returns only active poll sources.
+func (fp *FilePersistence) ActivePollSources() ([]*models.PollSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	var activeSources []*models.PollSource
+
+	for _, source := range fp.pollSources {
+		if source.Active {
+			activeSources = append(activeSources, source)
+		}
+	}
+
+	return activeSources, nil
+}
+
+// DeletePollSource removes a poll source by its ID.
+func (fp *FilePersistence) DeletePollSource(id string) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	delete(fp.pollSources, id)
+
+	return fp.savePollSourcesToFile()
+}
+
+// HealthCheck verifies that the persistence layer is healthy.
+func (fp *FilePersistence) HealthCheck() error {
+	if _, err := os.Stat(fp.dataDir); os.IsNotExist(err) {
+		return fmt.Errorf("data directory does not exist: %s", fp.dataDir)
+	}
+
+	return nil
+}
+
+// Close cleans up resources.
+func (fp *FilePersistence) Close() error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	return fp.savePollSourcesToFile()
+}
+
+// loadPollSources loads poll sources from the file system.
+func (fp *FilePersistence) loadPollSources() error {
+	sourcesFile := filepath.Join(fp.dataDir, "poll_sources.json")
+
+	if _, err := os.Stat(sourcesFile); os.IsNotExist(err) {
+		// File doesn't exist, start with empty sources
+		return nil
+	}
+
+	data, err := os.ReadFile(sourcesFile) // #nosec G304 -- sourcesFile is constructed from controlled dataDir
+	if err != nil {
+		return fmt.Errorf("failed to read poll sources file: %w", err)
+	}
+
+	var sources []*models.PollSource
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return fmt.Errorf("failed to unmarshal poll sources: %w", err)
+	}
+
+	for _, source := range sources {
+		fp.pollSources[source.ID] = source
+	}
+
+	return nil
+}
+
+// savePollSourcesToFile saves all poll sources to the file system.
+func (fp *FilePersistence) savePollSourcesToFile() error {
+	sourcesFile := filepath.Join(fp.dataDir, "poll_sources.json")
+
+	sources := make([]*models.PollSource, 0, len(fp.pollSources))
+	for _, source := range fp.pollSources {
+		sources = append(sources, source)
+	}
+
+	data, err := json.MarshalIndent(sources, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal poll sources: %w", err)
+	}
+
+	if err := os.WriteFile(sourcesFile, data, 0600); err != nil {
+		return fmt.Errorf("failed to write poll sources file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+// Package persistence provides storage for HTTP polling sources and their last-seen state.
+package persistence
+
+import (
+	"github.com/dukex/operion/pkg/providers/http-poll/models"
+)
+
+// HTTPPollPersistence defines the persistence interface for the HTTP polling provider.
+// This interface is specific to polling source needs and isolated from core persistence.
+type HTTPPollPersistence interface {
+	// PollSource operations
+	SavePollSource(source *models.PollSource) error
+	PollSourceByID(id string) (*models.PollSource, error)
+	PollSources() ([]*models.PollSource, error)
+	ActivePollSources() ([]*models.PollSource, error)
+	DeletePollSource(id string) error
+
+	// Health and lifecycle
+	HealthCheck() error
+	Close() error
+}
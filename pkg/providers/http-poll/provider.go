@@ -0,0 +1,478 @@
+// Package httppoll provides a source provider that polls HTTP endpoints on a schedule,
+// covering APIs that do not offer webhooks.
+package httppoll
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	pollModels "github.com/dukex/operion/pkg/providers/http-poll/models"
+	pollPersistence "github.com/dukex/operion/pkg/providers/http-poll/persistence"
+)
+
+const (
+	// maxResponseBytes limits how much of a polled response body is read.
+	maxResponseBytes = 10 * 1024 * 1024 // 10MB
+
+	// defaultRequestTimeout is the HTTP client timeout used when none is configured.
+	defaultRequestTimeout = 30 * time.Second
+)
+
+// pollGroup is a set of sources sharing the same URL and interval, served by a single request.
+type pollGroup struct {
+	url       string
+	interval  time.Duration
+	sourceIDs []string
+}
+
+// HTTPPollProvider implements a centralized HTTP polling orchestrator that periodically
+// requests configured URLs and converts responses to source events.
+type HTTPPollProvider struct {
+	config          map[string]any
+	logger          *slog.Logger
+	callback        protocol.SourceEventCallback
+	pollPersistence pollPersistence.HTTPPollPersistence
+	client          *http.Client
+	groups          map[string]*pollGroup
+	cancel          context.CancelFunc
+	wg              sync.WaitGroup
+	started         bool
+	mu              sync.RWMutex
+}
+
+// Start begins polling every configured group on its own interval.
+func (p *HTTPPollProvider) Start(ctx context.Context, callback protocol.SourceEventCallback) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.started {
+		return nil
+	}
+
+	p.callback = callback
+	p.logger.Info("Starting HTTP poll orchestrator", "groups", len(p.groups))
+
+	pollCtx, cancel := context.WithCancel(ctx)
+	p.cancel = cancel
+
+	for _, group := range p.groups {
+		p.wg.Add(1)
+
+		go p.runGroup(pollCtx, group)
+	}
+
+	p.started = true
+	p.logger.Info("HTTP poll orchestrator started successfully")
+
+	return nil
+}
+
+// Stop gracefully shuts down all pollers.
+func (p *HTTPPollProvider) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.started {
+		return nil
+	}
+
+	p.logger.Info("Stopping HTTP poll orchestrator")
+
+	p.cancel()
+	p.wg.Wait()
+
+	p.started = false
+	p.logger.Info("HTTP poll orchestrator stopped successfully")
+
+	return nil
+}
+
+// Validate checks if the HTTP poll orchestrator configuration is valid.
+func (p *HTTPPollProvider) Validate() error {
+	if p.pollPersistence == nil {
+		return errors.New("http poll persistence not initialized")
+	}
+
+	return nil
+}
+
+// ProviderLifecycle interface implementation
+
+// Initialize sets up the provider with required dependencies.
+func (p *HTTPPollProvider) Initialize(ctx context.Context, deps protocol.Dependencies) error {
+	p.logger = deps.Logger
+
+	persistenceURL := os.Getenv("HTTP_POLL_PERSISTENCE_URL")
+	if persistenceURL == "" {
+		return errors.New("http poll provider requires HTTP_POLL_PERSISTENCE_URL environment variable (e.g., file://./data/http-poll)")
+	}
+
+	persistence, err := p.createPersistence(persistenceURL)
+	if err != nil {
+		return err
+	}
+
+	p.pollPersistence = persistence
+	p.client = &http.Client{Timeout: p.requestTimeout()}
+
+	p.logger.Info("HTTP poll provider initialized", "persistence", persistenceURL)
+
+	return nil
+}
+
+// Configure configures the provider based on current workflow definitions.
+func (p *HTTPPollProvider) Configure(workflows []*models.Workflow) (map[string]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.logger.Info("Configuring HTTP poll provider with workflows", "workflow_count", len(workflows))
+
+	triggerToSource := make(map[string]string)
+	configureErr := protocol.NewConfigureError("httppoll")
+
+	for _, wf := range workflows {
+		if wf.Status != models.WorkflowStatusPublished {
+			continue
+		}
+
+		// Filter trigger nodes with http poll provider
+		for _, node := range wf.Nodes {
+			if node.IsTriggerNode() && node.ProviderID != nil && *node.ProviderID == "httppoll" {
+				sourceID, err := p.processPollTriggerNode(wf.ID, node)
+				if err != nil {
+					configureErr.Add(wf.ID, node.ID, err)
+
+					continue
+				}
+
+				triggerToSource[node.ID] = sourceID
+			}
+		}
+	}
+
+	p.logger.Info("HTTP poll configuration completed", "configured_sources", len(triggerToSource))
+
+	return triggerToSource, configureErr.ErrOrNil()
+}
+
+// Prepare groups the active sources by URL and interval before starting the provider.
+func (p *HTTPPollProvider) Prepare(ctx context.Context) error {
+	if p.pollPersistence == nil {
+		return errors.New("http poll persistence not initialized")
+	}
+
+	sources, err := p.pollPersistence.ActivePollSources()
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.groups = groupSources(sources)
+	p.mu.Unlock()
+
+	p.logger.Info("HTTP poll provider prepared and ready",
+		"sources", len(sources),
+		"groups", len(p.groups))
+
+	return nil
+}
+
+// groupSources groups sources by URL and interval so each group issues one request per tick.
+func groupSources(sources []*pollModels.PollSource) map[string]*pollGroup {
+	groups := make(map[string]*pollGroup)
+
+	for _, source := range sources {
+		key := source.GroupKey()
+
+		group, exists := groups[key]
+		if !exists {
+			group = &pollGroup{url: source.URL, interval: source.Interval}
+			groups[key] = group
+		}
+
+		group.sourceIDs = append(group.sourceIDs, source.ID)
+	}
+
+	for _, group := range groups {
+		sort.Strings(group.sourceIDs)
+	}
+
+	return groups
+}
+
+// runGroup polls a group on its interval until the context is cancelled.
+func (p *HTTPPollProvider) runGroup(ctx context.Context, group *pollGroup) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(group.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.pollGroup(ctx, group)
+		}
+	}
+}
+
+// pollGroup requests the group URL once and publishes an event for every source
+// whose response should be emitted.
+func (p *HTTPPollProvider) pollGroup(ctx context.Context, group *pollGroup) {
+	sources := make([]*pollModels.PollSource, 0, len(group.sourceIDs))
+
+	for _, sourceID := range group.sourceIDs {
+		source, err := p.pollPersistence.PollSourceByID(sourceID)
+		if err != nil || source == nil {
+			p.logger.Warn("Skipping unknown poll source", "source_id", sourceID, "error", err)
+
+			continue
+		}
+
+		sources = append(sources, source)
+	}
+
+	if len(sources) == 0 {
+		return
+	}
+
+	resp, body, err := p.fetch(ctx, group.url, sources)
+	if err != nil {
+		p.logger.Error("Failed to poll URL", "url", group.url, "error", err)
+
+		return
+	}
+
+	polledAt := time.Now().UTC()
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+
+	for _, source := range sources {
+		if resp.StatusCode == http.StatusNotModified {
+			source.LastPolledAt = &polledAt
+			p.saveSource(source)
+
+			continue
+		}
+
+		if source.ChangeDetection && !source.HasChanged(etag, lastModified, body) {
+			source.LastPolledAt = &polledAt
+			p.saveSource(source)
+
+			continue
+		}
+
+		if err := p.publishPollEvent(ctx, source, resp, body, polledAt); err != nil {
+			p.logger.Error("Failed to publish poll event",
+				"source_id", source.ID,
+				"error", err)
+
+			continue
+		}
+
+		source.RecordResponse(etag, lastModified, body, polledAt)
+		p.saveSource(source)
+	}
+}
+
+// fetch issues the GET request for a group. Conditional headers are only sent when every
+// source uses change detection and agrees on the last seen validators, since a 304
+// response carries no body for the sources that need one.
+func (p *HTTPPollProvider) fetch(ctx context.Context, url string, sources []*pollModels.PollSource) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if etag, lastModified, ok := sharedValidators(sources); ok {
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+
+		if lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp, body, nil
+}
+
+// sharedValidators returns the ETag and Last-Modified values shared by all sources.
+func sharedValidators(sources []*pollModels.PollSource) (string, string, bool) {
+	first := sources[0]
+
+	for _, source := range sources {
+		if !source.ChangeDetection || source.ETag != first.ETag || source.LastModified != first.LastModified {
+			return "", "", false
+		}
+	}
+
+	return first.ETag, first.LastModified, first.ETag != "" || first.LastModified != ""
+}
+
+// publishPollEvent publishes a source event for a polled response.
+func (p *HTTPPollProvider) publishPollEvent(ctx context.Context, source *pollModels.PollSource, resp *http.Response, body []byte, polledAt time.Time) error {
+	headers := make(map[string]any, len(resp.Header))
+	for name := range resp.Header {
+		headers[name] = resp.Header.Get(name)
+	}
+
+	eventData := map[string]any{
+		"url":         source.URL,
+		"status_code": resp.StatusCode,
+		"headers":     headers,
+		"body":        decodeBody(body),
+		"polled_at":   polledAt.Format(time.RFC3339),
+	}
+
+	return p.callback(ctx, source.ID, "httppoll", "response_received", eventData)
+}
+
+// decodeBody returns the parsed JSON body when possible and the raw string otherwise.
+func decodeBody(body []byte) any {
+	var parsed any
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		return parsed
+	}
+
+	return string(body)
+}
+
+// saveSource persists the last-seen state of a source.
+func (p *HTTPPollProvider) saveSource(source *pollModels.PollSource) {
+	if err := p.pollPersistence.SavePollSource(source); err != nil {
+		p.logger.Error("Failed to save poll source state",
+			"source_id", source.ID,
+			"error", err)
+	}
+}
+
+// processPollTriggerNode creates or updates the poll source of a trigger node.
+// Returns the sourceID if the source was successfully saved, an error otherwise.
+func (p *HTTPPollProvider) processPollTriggerNode(workflowID string, node *models.WorkflowNode) (string, error) {
+	sourceID := ""
+	if node.SourceID != nil {
+		sourceID = *node.SourceID
+	}
+
+	if sourceID == "" {
+		// Generate a new UUID for the sourceID
+		sourceID = uuid.New().String()
+		p.logger.Info("Generated source_id for http poll trigger node",
+			"workflow_id", workflowID,
+			"node_id", node.ID,
+			"generated_source_id", sourceID)
+	}
+
+	existingSource, err := p.pollPersistence.PollSourceByID(sourceID)
+	if err != nil {
+		p.logger.Error("Failed to check existing poll source",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", fmt.Errorf("failed to check existing poll source: %w", err)
+	}
+
+	source := existingSource
+	if source != nil {
+		err = source.UpdateConfiguration(node.Config)
+	} else {
+		source, err = pollModels.NewPollSource(sourceID, node.Config)
+	}
+
+	if err != nil {
+		p.logger.Error("Invalid http poll source configuration",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", err
+	}
+
+	if err := p.pollPersistence.SavePollSource(source); err != nil {
+		p.logger.Error("Failed to save poll source",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", fmt.Errorf("failed to save poll source: %w", err)
+	}
+
+	p.logger.Info("Configured poll source",
+		"source_id", sourceID,
+		"url", source.URL,
+		"interval", source.Interval)
+
+	return sourceID, nil
+}
+
+// requestTimeout returns the HTTP client timeout from the "timeout" configuration.
+func (p *HTTPPollProvider) requestTimeout() time.Duration {
+	if raw, ok := p.config["timeout"].(string); ok {
+		if timeout, err := time.ParseDuration(raw); err == nil && timeout > 0 {
+			return timeout
+		}
+	}
+
+	return defaultRequestTimeout
+}
+
+// createPersistence creates the appropriate persistence implementation based on URL scheme.
+func (p *HTTPPollProvider) createPersistence(persistenceURL string) (pollPersistence.HTTPPollPersistence, error) {
+	scheme := p.parsePersistenceScheme(persistenceURL)
+	p.logger.Info("Initializing http poll persistence", "scheme", scheme, "url", persistenceURL)
+
+	switch scheme {
+	case "file":
+		// Extract path from file://path
+		path := strings.TrimPrefix(persistenceURL, "file://")
+
+		return pollPersistence.NewFilePersistence(path)
+	case "postgres", "postgresql":
+		// Future: implement database persistence
+		return nil, errors.New("postgres persistence for http poll not yet implemented")
+	default:
+		return nil, errors.New("unsupported persistence scheme: " + scheme + " (supported: file)")
+	}
+}
+
+// parsePersistenceScheme extracts the scheme from a persistence URL.
+func (p *HTTPPollProvider) parsePersistenceScheme(persistenceURL string) string {
+	parts := strings.SplitN(persistenceURL, "://", 2)
+	if len(parts) < 2 {
+		return "unknown"
+	}
+
+	return parts[0]
+}
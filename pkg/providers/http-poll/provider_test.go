@@ -0,0 +1,239 @@
+package httppoll
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	pollModels "github.com/dukex/operion/pkg/providers/http-poll/models"
+	pollPersistence "github.com/dukex/operion/pkg/providers/http-poll/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedEvents collects the source events published by the provider.
+type recordedEvents struct {
+	mu     sync.Mutex
+	events map[string][]map[string]any
+}
+
+func (r *recordedEvents) callback(_ context.Context, sourceID, providerID, eventType string, eventData map[string]any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events[sourceID] = append(r.events[sourceID], eventData)
+
+	return nil
+}
+
+func (r *recordedEvents) count(sourceID string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.events[sourceID])
+}
+
+func createTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func createTestProvider(t *testing.T) (*HTTPPollProvider, *recordedEvents) {
+	t.Helper()
+
+	persistence, err := pollPersistence.NewFilePersistence(t.TempDir())
+	require.NoError(t, err)
+
+	recorder := &recordedEvents{events: make(map[string][]map[string]any)}
+
+	provider := &HTTPPollProvider{
+		logger:          createTestLogger(),
+		pollPersistence: persistence,
+		client:          http.DefaultClient,
+		callback:        recorder.callback,
+	}
+
+	return provider, recorder
+}
+
+func saveSource(t *testing.T, provider *HTTPPollProvider, id string, config map[string]any) {
+	t.Helper()
+
+	source, err := pollModels.NewPollSource(id, config)
+	require.NoError(t, err)
+	require.NoError(t, provider.pollPersistence.SavePollSource(source))
+}
+
+func pollOnce(t *testing.T, provider *HTTPPollProvider) {
+	t.Helper()
+
+	require.NoError(t, provider.Prepare(t.Context()))
+
+	for _, group := range provider.groups {
+		provider.pollGroup(t.Context(), group)
+	}
+}
+
+func TestHTTPPollProvider_ChangeDetection_ContentHash(t *testing.T) {
+	var version atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"version": %d}`, version.Load())
+	}))
+	defer server.Close()
+
+	provider, recorder := createTestProvider(t)
+	saveSource(t, provider, "on-change", map[string]any{"url": server.URL, "interval": "1m", "change_detection": true})
+	saveSource(t, provider, "always", map[string]any{"url": server.URL, "interval": "1m"})
+
+	pollOnce(t, provider)
+	pollOnce(t, provider)
+
+	assert.Equal(t, 1, recorder.count("on-change"), "unchanged response should not emit again")
+	assert.Equal(t, 2, recorder.count("always"))
+
+	version.Store(1)
+	pollOnce(t, provider)
+
+	assert.Equal(t, 2, recorder.count("on-change"), "changed response should emit")
+	assert.Equal(t, 3, recorder.count("always"))
+
+	last := recorder.events["on-change"][1]
+	assert.Equal(t, map[string]any{"version": float64(1)}, last["body"])
+	assert.Equal(t, http.StatusOK, last["status_code"])
+
+	stored, err := provider.pollPersistence.PollSourceByID("on-change")
+	require.NoError(t, err)
+	assert.Equal(t, pollModels.HashContent([]byte(`{"version": 1}`)), stored.ContentHash)
+	assert.NotNil(t, stored.LastPolledAt)
+}
+
+func TestHTTPPollProvider_ChangeDetection_ETag(t *testing.T) {
+	var (
+		etag        atomic.Value
+		conditional atomic.Int32
+	)
+
+	etag.Store(`"v1"`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current, _ := etag.Load().(string)
+		if r.Header.Get("If-None-Match") == current {
+			conditional.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", current)
+		_, _ = w.Write([]byte("status: ok"))
+	}))
+	defer server.Close()
+
+	provider, recorder := createTestProvider(t)
+	saveSource(t, provider, "etag-source", map[string]any{"url": server.URL, "interval": "30s", "change_detection": true})
+
+	pollOnce(t, provider)
+	pollOnce(t, provider)
+
+	assert.Equal(t, 1, recorder.count("etag-source"))
+	assert.Equal(t, int32(1), conditional.Load(), "second poll should be answered with 304")
+	assert.Equal(t, "status: ok", recorder.events["etag-source"][0]["body"])
+
+	etag.Store(`"v2"`)
+	pollOnce(t, provider)
+
+	assert.Equal(t, 2, recorder.count("etag-source"))
+
+	stored, err := provider.pollPersistence.PollSourceByID("etag-source")
+	require.NoError(t, err)
+	assert.Equal(t, `"v2"`, stored.ETag)
+}
+
+func TestHTTPPollProvider_GroupsSourcesByURLAndInterval(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	provider, recorder := createTestProvider(t)
+	saveSource(t, provider, "source-a", map[string]any{"url": server.URL, "interval": "1m"})
+	saveSource(t, provider, "source-b", map[string]any{"url": server.URL, "interval": "1m"})
+	saveSource(t, provider, "source-c", map[string]any{"url": server.URL, "interval": "5m"})
+
+	pollOnce(t, provider)
+
+	assert.Len(t, provider.groups, 2)
+	assert.Equal(t, int32(2), requests.Load(), "one request per URL and interval")
+	assert.Equal(t, 1, recorder.count("source-a"))
+	assert.Equal(t, 1, recorder.count("source-b"))
+	assert.Equal(t, 1, recorder.count("source-c"))
+}
+
+func TestHTTPPollProvider_Configure(t *testing.T) {
+	provider, _ := createTestProvider(t)
+
+	providerID := "httppoll"
+	sourceID := "poll-source"
+	workflow := &models.Workflow{
+		ID:     "workflow-1",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:         "trigger-1",
+				Type:       models.NodeTypeTriggerHTTPPoll,
+				Category:   models.CategoryTypeTrigger,
+				Config:     map[string]any{"url": "https://api.example.com/orders", "interval": "5m", "change_detection": true},
+				SourceID:   &sourceID,
+				ProviderID: &providerID,
+				Enabled:    true,
+			},
+			{
+				ID:         "trigger-invalid",
+				Type:       models.NodeTypeTriggerHTTPPoll,
+				Category:   models.CategoryTypeTrigger,
+				Config:     map[string]any{"url": "not a url"},
+				ProviderID: &providerID,
+				Enabled:    true,
+			},
+		},
+	}
+
+	triggerToSource, err := provider.Configure([]*models.Workflow{workflow})
+
+	var configureErr *protocol.ConfigureError
+	require.ErrorAs(t, err, &configureErr)
+	require.Len(t, configureErr.Workflows["workflow-1"], 1)
+	assert.Equal(t, "trigger-invalid", configureErr.Workflows["workflow-1"][0].TriggerID)
+	assert.Equal(t, map[string]string{"trigger-1": sourceID}, triggerToSource)
+
+	source, err := provider.pollPersistence.PollSourceByID(sourceID)
+	require.NoError(t, err)
+	require.NotNil(t, source)
+	assert.Equal(t, "https://api.example.com/orders", source.URL)
+	assert.True(t, source.ChangeDetection)
+	assert.Equal(t, "5m0s", source.Interval.String())
+}
+
+func TestHTTPPollProvider_Initialize_RequiresPersistenceURL(t *testing.T) {
+	t.Setenv("HTTP_POLL_PERSISTENCE_URL", "")
+
+	provider := &HTTPPollProvider{}
+	err := provider.Initialize(t.Context(), protocol.Dependencies{Logger: createTestLogger()})
+	require.Error(t, err)
+
+	t.Setenv("HTTP_POLL_PERSISTENCE_URL", "file://"+t.TempDir())
+	require.NoError(t, provider.Initialize(t.Context(), protocol.Dependencies{Logger: createTestLogger()}))
+	assert.NoError(t, provider.Validate())
+}
@@ -15,10 +15,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/dukex/operion/pkg/events"
 	"github.com/dukex/operion/pkg/models"
 	"github.com/dukex/operion/pkg/protocol"
 	kafkaModels "github.com/dukex/operion/pkg/providers/kafka/models"
 	kafkaPersistence "github.com/dukex/operion/pkg/providers/kafka/persistence"
+	"github.com/dukex/operion/pkg/tracer"
 )
 
 // ConsumerManager manages a Kafka consumer and its associated sources.
@@ -37,6 +39,7 @@ type KafkaProvider struct {
 	config      map[string]any
 	logger      *slog.Logger
 	callback    protocol.SourceEventCallback
+	metrics     *tracer.SourceMetrics
 	persistence kafkaPersistence.KafkaPersistence
 	consumers   map[string]*ConsumerManager // connectionDetailsID -> ConsumerManager
 	started     bool
@@ -123,6 +126,7 @@ func (k *KafkaProvider) Validate() error {
 // Initialize sets up the provider with required dependencies.
 func (k *KafkaProvider) Initialize(ctx context.Context, deps protocol.Dependencies) error {
 	k.logger = deps.Logger
+	k.metrics = deps.Metrics
 	k.consumers = make(map[string]*ConsumerManager)
 
 	// Initialize Kafka-specific persistence based on URL
@@ -151,6 +155,7 @@ func (k *KafkaProvider) Configure(workflows []*models.Workflow) (map[string]stri
 	k.logger.Info("Configuring Kafka provider with workflows", "workflow_count", len(workflows))
 
 	triggerToSource := make(map[string]string)
+	configureErr := protocol.NewConfigureError("kafka")
 	sourceCount := 0
 
 	for _, wf := range workflows {
@@ -161,10 +166,15 @@ func (k *KafkaProvider) Configure(workflows []*models.Workflow) (map[string]stri
 		// Filter trigger nodes with kafka provider
 		for _, node := range wf.Nodes {
 			if node.IsTriggerNode() && node.ProviderID != nil && *node.ProviderID == "kafka" {
-				if sourceID := k.processKafkaTriggerNode(wf.ID, node); sourceID != "" {
-					triggerToSource[node.ID] = sourceID
-					sourceCount++
+				sourceID, err := k.processKafkaTriggerNode(wf.ID, node)
+				if err != nil {
+					configureErr.Add(wf.ID, node.ID, err)
+
+					continue
 				}
+
+				triggerToSource[node.ID] = sourceID
+				sourceCount++
 			}
 		}
 	}
@@ -180,7 +190,7 @@ func (k *KafkaProvider) Configure(workflows []*models.Workflow) (map[string]stri
 		"created_sources", sourceCount,
 		"consumer_managers", len(k.consumers))
 
-	return triggerToSource, nil
+	return triggerToSource, configureErr.ErrOrNil()
 }
 
 // Prepare performs final preparation before starting the provider.
@@ -195,8 +205,8 @@ func (k *KafkaProvider) Prepare(ctx context.Context) error {
 }
 
 // processKafkaTriggerNode handles the creation of a Kafka source for a trigger node with Kafka type.
-// Returns the sourceID if a source was successfully created, empty string otherwise.
-func (k *KafkaProvider) processKafkaTriggerNode(workflowID string, node *models.WorkflowNode) string {
+// Returns the sourceID if a source was successfully created, an error otherwise.
+func (k *KafkaProvider) processKafkaTriggerNode(workflowID string, node *models.WorkflowNode) (string, error) {
 	sourceID := ""
 	if node.SourceID != nil {
 		sourceID = *node.SourceID
@@ -218,7 +228,7 @@ func (k *KafkaProvider) processKafkaTriggerNode(workflowID string, node *models.
 			"source_id", sourceID,
 			"error", err)
 
-		return ""
+		return "", fmt.Errorf("failed to check existing Kafka source: %w", err)
 	}
 
 	if existingSource != nil {
@@ -229,7 +239,7 @@ func (k *KafkaProvider) processKafkaTriggerNode(workflowID string, node *models.
 				"source_id", sourceID,
 				"error", err)
 
-			return ""
+			return "", fmt.Errorf("invalid Kafka configuration: %w", err)
 		}
 
 		// Save updated source to persistence
@@ -239,7 +249,7 @@ func (k *KafkaProvider) processKafkaTriggerNode(workflowID string, node *models.
 				"error", err)
 		}
 
-		return sourceID // Return existing sourceID
+		return sourceID, nil // Return existing sourceID
 	}
 
 	// Create new Kafka source
@@ -249,7 +259,7 @@ func (k *KafkaProvider) processKafkaTriggerNode(workflowID string, node *models.
 			"source_id", sourceID,
 			"error", err)
 
-		return ""
+		return "", fmt.Errorf("invalid Kafka configuration: %w", err)
 	}
 
 	// Save source to persistence
@@ -258,7 +268,7 @@ func (k *KafkaProvider) processKafkaTriggerNode(workflowID string, node *models.
 			"source_id", sourceID,
 			"error", err)
 
-		return ""
+		return "", fmt.Errorf("failed to save Kafka source: %w", err)
 	}
 
 	k.logger.Info("Created Kafka source",
@@ -267,7 +277,7 @@ func (k *KafkaProvider) processKafkaTriggerNode(workflowID string, node *models.
 		"topic", source.ConnectionDetails.Topic,
 		"consumer_group", source.GetConsumerGroup())
 
-	return sourceID
+	return sourceID, nil
 }
 
 // updateConsumerManagers creates or updates consumer managers based on active sources.
@@ -531,6 +541,7 @@ func (h *kafkaConsumerGroupHandler) processMessage(ctx context.Context, sourceID
 			h.manager.logger.Warn("Message failed JSON schema validation",
 				"source_id", sourceID,
 				"error", err)
+			h.provider.metrics.RecordDropped(ctx, "kafka", sourceID, tracer.DropReasonInvalid)
 
 			return nil // Discard invalid message as required by PRP
 		}
@@ -553,6 +564,9 @@ func (h *kafkaConsumerGroupHandler) processMessage(ctx context.Context, sourceID
 		"headers":   headers,
 	}
 
+	// Messages with the same key keep their relative order through the executions they trigger
+	ctx = events.WithOrderingKey(ctx, messageKey)
+
 	// Publish source event
 	return h.provider.callback(ctx, sourceID, "kafka", "message_received", eventData)
 }
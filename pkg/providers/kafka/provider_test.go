@@ -10,10 +10,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
+	"github.com/dukex/operion/pkg/events"
 	"github.com/dukex/operion/pkg/models"
 	"github.com/dukex/operion/pkg/protocol"
 	kafkaModels "github.com/dukex/operion/pkg/providers/kafka/models"
+	"github.com/dukex/operion/pkg/tracer"
 )
 
 const (
@@ -323,6 +328,62 @@ func TestKafkaProvider_Configure(t *testing.T) {
 	}
 }
 
+func TestKafkaProvider_Configure_ReportsTriggerErrors(t *testing.T) {
+	t.Setenv("KAFKA_PERSISTENCE_URL", "file://"+t.TempDir()+"/kafka_configure_errors_test")
+
+	provider := &KafkaProvider{
+		config: map[string]any{},
+		logger: createTestLogger(),
+	}
+
+	err := provider.Initialize(context.Background(), protocol.Dependencies{Logger: createTestLogger()})
+	require.NoError(t, err)
+
+	workflows := []*models.Workflow{
+		createTestWorkflow("workflow-1", []*models.WorkflowNode{
+			createKafkaTriggerNode("trigger-1", "source-1", map[string]any{
+				"topic":   "orders",
+				"brokers": []string{"localhost:9092"},
+			}),
+		}),
+		createTestWorkflow("workflow-2", []*models.WorkflowNode{
+			createKafkaTriggerNode("trigger-2", "source-2", map[string]any{
+				"topic":   "payments",
+				"brokers": []string{"localhost:9092"},
+			}),
+			createKafkaTriggerNode("trigger-invalid", "source-invalid", map[string]any{
+				"brokers": []string{"localhost:9092"},
+			}),
+		}),
+		createTestWorkflow("workflow-3", []*models.WorkflowNode{
+			createKafkaTriggerNode("trigger-3", "source-3", map[string]any{
+				"topic":   "invoices",
+				"brokers": []string{"localhost:9092"},
+			}),
+		}),
+	}
+
+	triggerToSource, err := provider.Configure(workflows)
+
+	var configureErr *protocol.ConfigureError
+	require.ErrorAs(t, err, &configureErr)
+	assert.Equal(t, kafkaProviderType, configureErr.ProviderID)
+	require.Len(t, configureErr.Workflows, 1)
+	require.Len(t, configureErr.Workflows["workflow-2"], 1)
+
+	triggerErr := configureErr.Workflows["workflow-2"][0]
+	assert.Equal(t, "trigger-invalid", triggerErr.TriggerID)
+	assert.Equal(t, kafkaProviderType, triggerErr.ProviderID)
+	assert.Contains(t, triggerErr.Message, "topic is required")
+
+	// The remaining triggers are still configured
+	assert.Equal(t, map[string]string{
+		"trigger-1": "source-1",
+		"trigger-2": "source-2",
+		"trigger-3": "source-3",
+	}, triggerToSource)
+}
+
 // Consumer Manager Tests
 
 func TestKafkaProvider_UpdateConsumerManagers(t *testing.T) {
@@ -561,6 +622,9 @@ func TestKafkaConsumerGroupHandler_ProcessMessage(t *testing.T) {
 	assert.Equal(t, "order-key", eventData["key"])
 	assert.NotEmpty(t, eventData["timestamp"])
 
+	// The message key travels as the ordering key of the source event
+	assert.Equal(t, "order-key", events.OrderingKey(call.Arguments[0].(context.Context)))
+
 	// Verify message was parsed as JSON
 	messageData := eventData["message"].(map[string]any)
 	assert.Equal(t, "12345", messageData["order_id"])
@@ -660,6 +724,95 @@ func TestKafkaConsumerGroupHandler_ProcessMessageWithSchema(t *testing.T) {
 	}
 }
 
+// sumCounter returns the totals of a counter by the attributes of its data points.
+func sumCounter(t *testing.T, reader *sdkmetric.ManualReader, name string) map[attribute.Distinct]int64 {
+	t.Helper()
+
+	var collected metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &collected))
+
+	totals := make(map[attribute.Distinct]int64)
+
+	for _, scope := range collected.ScopeMetrics {
+		for _, exported := range scope.Metrics {
+			if exported.Name != name {
+				continue
+			}
+
+			sum, ok := exported.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+
+			for _, point := range sum.DataPoints {
+				totals[point.Attributes.Equivalent()] += point.Value
+			}
+		}
+	}
+
+	return totals
+}
+
+// attributeSet returns the key of a metric data point with the given attributes.
+func attributeSet(attributes ...attribute.KeyValue) attribute.Distinct {
+	set := attribute.NewSet(attributes...)
+
+	return set.Equivalent()
+}
+
+func TestKafkaConsumerGroupHandler_ProcessMessageMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	metrics, err := tracer.NewSourceMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+
+	mockCallback := &MockSourceEventCallback{}
+	mockCallback.On("Call", mock.Anything, "orders-source", "kafka", "message_received", mock.Anything).Return(nil)
+
+	// The source manager instruments the callback it starts providers with
+	provider := &KafkaProvider{
+		logger:   createTestLogger(),
+		metrics:  metrics,
+		callback: protocol.InstrumentSourceEventCallback(mockCallback.Call, metrics),
+	}
+
+	handler := &kafkaConsumerGroupHandler{
+		provider: provider,
+		manager:  &ConsumerManager{sources: make(map[string]*kafkaModels.KafkaSource), logger: createTestLogger()},
+	}
+
+	source, err := kafkaModels.NewKafkaSource("orders-source", map[string]any{
+		"topic":   "orders",
+		"brokers": []string{"localhost:9092"},
+		"json_schema": map[string]any{
+			"type":     "object",
+			"required": []string{"order_id"},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, value := range []string{`{"order_id": "1"}`, `{"amount": 100}`, `{"order_id": "2"}`, `{"amount": 200}`, `{"amount": 300}`} {
+		message := &sarama.ConsumerMessage{Topic: "orders", Value: []byte(value)}
+		require.NoError(t, handler.processMessage(t.Context(), "orders-source", source, message))
+	}
+
+	mockCallback.AssertNumberOfCalls(t, "Call", 2)
+
+	processed := sumCounter(t, reader, tracer.SourceEventsProcessedMetric)
+	assert.Equal(t, map[attribute.Distinct]int64{
+		attributeSet(
+			attribute.String("provider.id", "kafka"),
+			attribute.String("source.id", "orders-source"),
+		): 2,
+	}, processed)
+
+	dropped := sumCounter(t, reader, tracer.SourceEventsDroppedMetric)
+	assert.Equal(t, map[attribute.Distinct]int64{
+		attributeSet(
+			attribute.String("provider.id", "kafka"),
+			attribute.String("source.id", "orders-source"),
+			attribute.String("drop.reason", tracer.DropReasonInvalid),
+		): 3,
+	}, dropped)
+}
+
 // Mock types for testing
 
 type mockKafkaPersistence struct {
@@ -62,6 +62,21 @@ func (s *Schedule) UpdateNextDueAt() error {
 	return s.calculateNextDueAt(time.Now().UTC())
 }
 
+// Reschedule replaces the cron expression of the schedule and calculates its next execution time
+// from now. The schedule is left unchanged when the expression is invalid.
+func (s *Schedule) Reschedule(cronExpression string) error {
+	previous := *s
+
+	s.CronExpression = cronExpression
+	if err := s.calculateNextDueAt(time.Now().UTC()); err != nil {
+		*s = previous
+
+		return err
+	}
+
+	return nil
+}
+
 // calculateNextDueAt is the shared logic for calculating next execution time.
 // referenceTime is the time to calculate the next execution from.
 func (s *Schedule) calculateNextDueAt(referenceTime time.Time) error {
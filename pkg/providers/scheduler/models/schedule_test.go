@@ -209,6 +209,23 @@ func TestSchedule_UpdateNextDueAt_InvalidCron(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSchedule_Reschedule(t *testing.T) {
+	schedule, err := NewSchedule("test-id", "source-123", "0 0 1 1 *")
+	require.NoError(t, err)
+
+	yearly := schedule.NextDueAt
+
+	require.NoError(t, schedule.Reschedule("*/5 * * * *"))
+	assert.Equal(t, "*/5 * * * *", schedule.CronExpression)
+	assert.True(t, schedule.NextDueAt.Before(yearly))
+	assert.False(t, schedule.NextDueAt.After(time.Now().UTC().Add(5*time.Minute)))
+
+	rescheduled := *schedule
+
+	require.Error(t, schedule.Reschedule("invalid cron expression"))
+	assert.Equal(t, rescheduled, *schedule, "an invalid expression leaves the schedule unchanged")
+}
+
 // IsDue Tests
 
 func TestSchedule_IsDue_ActiveAndDue(t *testing.T) {
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"strings"
@@ -109,7 +110,11 @@ func (s *SchedulerProvider) pollSchedules(ctx context.Context) {
 
 // processDueSchedules queries database for ALL due schedules and publishes events
 // This is the core orchestrator method that handles schedules with different cron expressions.
+// It holds the provider lock so a schedule removed or rescheduled by Configure is not saved back.
 func (s *SchedulerProvider) processDueSchedules(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	now := time.Now().UTC()
 
 	// Query database for ALL schedules that are due, regardless of cron expression
@@ -210,7 +215,10 @@ func (s *SchedulerProvider) Initialize(ctx context.Context, deps protocol.Depend
 	return nil
 }
 
-// Configure configures the provider based on current workflow definitions.
+// Configure configures the provider based on current workflow definitions. It can be called again
+// on a running provider: schedules whose cron expression changed are rescheduled, and schedules no
+// trigger of a published workflow configures (unpublished or deleted workflows, removed triggers,
+// triggers that failed to configure) are removed, so they stop firing from the next poll.
 func (s *SchedulerProvider) Configure(workflows []*models.Workflow) (map[string]string, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -218,6 +226,7 @@ func (s *SchedulerProvider) Configure(workflows []*models.Workflow) (map[string]
 	s.logger.Info("Configuring scheduler provider with workflows", "workflow_count", len(workflows))
 
 	triggerToSource := make(map[string]string)
+	configureErr := protocol.NewConfigureError("scheduler")
 	scheduleCount := 0
 
 	for _, wf := range workflows {
@@ -228,19 +237,69 @@ func (s *SchedulerProvider) Configure(workflows []*models.Workflow) (map[string]
 		// Filter trigger nodes with scheduler provider
 		for _, node := range wf.Nodes {
 			if node.IsTriggerNode() && node.ProviderID != nil && *node.ProviderID == "scheduler" {
-				if cronExpr, exists := node.Config["cron_expression"]; exists {
-					if sourceID := s.processScheduleTriggerNode(wf.ID, node, cronExpr); sourceID != "" {
-						triggerToSource[node.ID] = sourceID
-						scheduleCount++
-					}
+				cronExpr, exists := node.Config["cron_expression"]
+				if !exists {
+					configureErr.Add(wf.ID, node.ID, errors.New("cron_expression is required"))
+
+					continue
 				}
+
+				sourceID, err := s.processScheduleTriggerNode(wf.ID, node, cronExpr)
+				if err != nil {
+					configureErr.Add(wf.ID, node.ID, err)
+
+					continue
+				}
+
+				triggerToSource[node.ID] = sourceID
+				scheduleCount++
 			}
 		}
 	}
 
-	s.logger.Info("Scheduler configuration completed", "created_schedules", scheduleCount)
+	removed := s.removeStaleSchedules(triggerToSource)
 
-	return triggerToSource, nil
+	s.logger.Info("Scheduler configuration completed", "configured_schedules", scheduleCount, "removed_schedules", removed)
+
+	return triggerToSource, configureErr.ErrOrNil()
+}
+
+// removeStaleSchedules deletes the schedules whose source is not in triggerToSource and returns
+// how many were deleted.
+func (s *SchedulerProvider) removeStaleSchedules(triggerToSource map[string]string) int {
+	configured := make(map[string]bool, len(triggerToSource))
+	for _, sourceID := range triggerToSource {
+		configured[sourceID] = true
+	}
+
+	schedules, err := s.schedulerPersistence.Schedules()
+	if err != nil {
+		s.logger.Error("Failed to list schedules to remove", "error", err)
+
+		return 0
+	}
+
+	removed := 0
+
+	for _, schedule := range schedules {
+		if configured[schedule.SourceID] {
+			continue
+		}
+
+		if err := s.schedulerPersistence.DeleteScheduleBySourceID(schedule.SourceID); err != nil {
+			s.logger.Error("Failed to remove schedule",
+				"source_id", schedule.SourceID,
+				"error", err)
+
+			continue
+		}
+
+		removed++
+
+		s.logger.Info("Removed schedule", "source_id", schedule.SourceID, "cron", schedule.CronExpression)
+	}
+
+	return removed
 }
 
 // Prepare performs final preparation before starting the provider.
@@ -254,9 +313,9 @@ func (s *SchedulerProvider) Prepare(ctx context.Context) error {
 	return nil
 }
 
-// processScheduleTriggerNode handles the creation of a schedule for a trigger node with cron_expression.
-// Returns the sourceID if a schedule was successfully created, empty string otherwise.
-func (s *SchedulerProvider) processScheduleTriggerNode(workflowID string, node *models.WorkflowNode, cronExpr any) string {
+// processScheduleTriggerNode creates or reschedules the schedule of a trigger node with cron_expression.
+// Returns the sourceID if the schedule was successfully saved, an error otherwise.
+func (s *SchedulerProvider) processScheduleTriggerNode(workflowID string, node *models.WorkflowNode, cronExpr any) (string, error) {
 	sourceID := ""
 	if node.SourceID != nil {
 		sourceID = *node.SourceID
@@ -271,6 +330,15 @@ func (s *SchedulerProvider) processScheduleTriggerNode(workflowID string, node *
 			"generated_source_id", sourceID)
 	}
 
+	cronStr, ok := cronExpr.(string)
+	if !ok {
+		s.logger.Warn("Invalid cron_expression type",
+			"source_id", sourceID,
+			"type", cronExpr)
+
+		return "", fmt.Errorf("invalid cron_expression type %T", cronExpr)
+	}
+
 	// Check if schedule already exists
 	existingSchedule, err := s.schedulerPersistence.ScheduleBySourceID(sourceID)
 	if err != nil {
@@ -278,25 +346,14 @@ func (s *SchedulerProvider) processScheduleTriggerNode(workflowID string, node *
 			"source_id", sourceID,
 			"error", err)
 
-		return ""
+		return "", fmt.Errorf("failed to check existing schedule: %w", err)
 	}
 
 	if existingSchedule != nil {
-		s.logger.Debug("Schedule already exists", "source_id", sourceID)
-
-		return sourceID // Return existing sourceID
+		return s.rescheduleIfChanged(existingSchedule, cronStr)
 	}
 
 	// Create new schedule
-	cronStr, ok := cronExpr.(string)
-	if !ok {
-		s.logger.Warn("Invalid cron_expression type",
-			"source_id", sourceID,
-			"type", cronExpr)
-
-		return ""
-	}
-
 	schedule, err := schedulerModels.NewSchedule(sourceID, sourceID, cronStr)
 	if err != nil {
 		s.logger.Error("Failed to create schedule",
@@ -304,7 +361,7 @@ func (s *SchedulerProvider) processScheduleTriggerNode(workflowID string, node *
 			"cron", cronStr,
 			"error", err)
 
-		return ""
+		return "", fmt.Errorf("invalid cron_expression: %w", err)
 	}
 
 	if err := s.schedulerPersistence.SaveSchedule(schedule); err != nil {
@@ -312,7 +369,7 @@ func (s *SchedulerProvider) processScheduleTriggerNode(workflowID string, node *
 			"source_id", sourceID,
 			"error", err)
 
-		return ""
+		return "", fmt.Errorf("failed to save schedule: %w", err)
 	}
 
 	s.logger.Info("Created schedule",
@@ -320,7 +377,35 @@ func (s *SchedulerProvider) processScheduleTriggerNode(workflowID string, node *
 		"cron", cronStr,
 		"next_due_at", schedule.NextDueAt)
 
-	return sourceID
+	return sourceID, nil
+}
+
+// rescheduleIfChanged moves an existing schedule to a new cron expression, calculating its next
+// execution time from now. Returns the sourceID of the schedule.
+func (s *SchedulerProvider) rescheduleIfChanged(schedule *schedulerModels.Schedule, cronStr string) (string, error) {
+	if schedule.CronExpression == cronStr {
+		s.logger.Debug("Schedule already exists", "source_id", schedule.SourceID)
+
+		return schedule.SourceID, nil
+	}
+
+	previous := schedule.CronExpression
+
+	if err := schedule.Reschedule(cronStr); err != nil {
+		return "", fmt.Errorf("invalid cron_expression: %w", err)
+	}
+
+	if err := s.schedulerPersistence.SaveSchedule(schedule); err != nil {
+		return "", fmt.Errorf("failed to save schedule: %w", err)
+	}
+
+	s.logger.Info("Rescheduled schedule",
+		"source_id", schedule.SourceID,
+		"previous_cron", previous,
+		"cron", cronStr,
+		"next_due_at", schedule.NextDueAt)
+
+	return schedule.SourceID, nil
 }
 
 // createPersistence creates the appropriate persistence implementation based on URL scheme.
@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	schedulerPersistence "github.com/dukex/operion/pkg/providers/scheduler/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestProvider(t *testing.T) (*SchedulerProvider, *[]string) {
+	t.Helper()
+
+	persistence, err := schedulerPersistence.NewFilePersistence(t.TempDir())
+	require.NoError(t, err)
+
+	var fired []string
+
+	return &SchedulerProvider{
+		logger:               slog.New(slog.DiscardHandler),
+		schedulerPersistence: persistence,
+		callback: func(_ context.Context, sourceID, providerID, eventType string, eventData map[string]any) error {
+			fired = append(fired, sourceID)
+
+			return nil
+		},
+	}, &fired
+}
+
+func scheduledWorkflow(cron string) *models.Workflow {
+	providerID := "scheduler"
+
+	return &models.Workflow{
+		ID:     "wf-1",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "every", Category: models.CategoryTypeTrigger, ProviderID: &providerID, Config: map[string]any{"cron_expression": cron}},
+		},
+	}
+}
+
+// configure configures the provider with wf and stores the source IDs on its triggers, as the
+// source manager does.
+func configure(t *testing.T, provider *SchedulerProvider, wf *models.Workflow) {
+	t.Helper()
+
+	triggerToSource, err := provider.Configure([]*models.Workflow{wf})
+	require.NoError(t, err)
+
+	for _, node := range wf.Nodes {
+		if sourceID, ok := triggerToSource[node.ID]; ok {
+			node.SourceID = &sourceID
+		}
+	}
+}
+
+// makeDue moves every schedule of the provider to the past.
+func makeDue(t *testing.T, provider *SchedulerProvider) {
+	t.Helper()
+
+	schedules, err := provider.schedulerPersistence.Schedules()
+	require.NoError(t, err)
+
+	for _, schedule := range schedules {
+		schedule.NextDueAt = time.Now().UTC().Add(-time.Minute)
+		require.NoError(t, provider.schedulerPersistence.SaveSchedule(schedule))
+	}
+}
+
+func TestSchedulerProvider_Configure_RemovesScheduleOfUnpublishedWorkflow(t *testing.T) {
+	provider, fired := createTestProvider(t)
+	wf := scheduledWorkflow("* * * * *")
+
+	configure(t, provider, wf)
+
+	makeDue(t, provider)
+	provider.processDueSchedules(t.Context())
+	assert.Equal(t, []string{*wf.Nodes[0].SourceID}, *fired)
+
+	makeDue(t, provider)
+
+	wf.Status = models.WorkflowStatusUnpublished
+	configure(t, provider, wf)
+
+	schedules, err := provider.schedulerPersistence.Schedules()
+	require.NoError(t, err)
+	assert.Empty(t, schedules)
+
+	provider.processDueSchedules(t.Context())
+	assert.Len(t, *fired, 1, "the schedule no longer fires")
+}
+
+func TestSchedulerProvider_Configure_ReschedulesChangedCron(t *testing.T) {
+	provider, _ := createTestProvider(t)
+	wf := scheduledWorkflow("0 0 1 1 *")
+
+	configure(t, provider, wf)
+
+	sourceID := *wf.Nodes[0].SourceID
+
+	yearly, err := provider.schedulerPersistence.ScheduleBySourceID(sourceID)
+	require.NoError(t, err)
+
+	yearlyDueAt := yearly.NextDueAt
+
+	wf.Nodes[0].Config["cron_expression"] = "*/5 * * * *"
+	configure(t, provider, wf)
+
+	assert.Equal(t, sourceID, *wf.Nodes[0].SourceID, "the trigger keeps its source")
+
+	schedules, err := provider.schedulerPersistence.Schedules()
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+
+	schedule := schedules[0]
+	assert.Equal(t, sourceID, schedule.SourceID)
+	assert.Equal(t, "*/5 * * * *", schedule.CronExpression)
+	assert.True(t, schedule.NextDueAt.Before(yearlyDueAt))
+	assert.False(t, schedule.NextDueAt.After(time.Now().UTC().Add(5*time.Minute)))
+
+	// An invalid cron is reported and its schedule removed rather than left firing on the old one
+	wf.Nodes[0].Config["cron_expression"] = "not a cron"
+
+	_, err = provider.Configure([]*models.Workflow{wf})
+	require.Error(t, err)
+
+	schedules, err = provider.schedulerPersistence.Schedules()
+	require.NoError(t, err)
+	assert.Empty(t, schedules)
+}
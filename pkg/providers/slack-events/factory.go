@@ -0,0 +1,66 @@
+package slackevents
+
+import (
+	"log/slog"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// SlackEventsProviderFactory creates instances of SlackEventsProvider.
+type SlackEventsProviderFactory struct{}
+
+// NewSlackEventsProviderFactory creates a new factory instance.
+func NewSlackEventsProviderFactory() *SlackEventsProviderFactory {
+	return &SlackEventsProviderFactory{}
+}
+
+// Create instantiates a new centralized SlackEventsProvider orchestrator.
+func (f *SlackEventsProviderFactory) Create(config map[string]any, logger *slog.Logger) (protocol.Provider, error) {
+	// Persistence and HTTP server are initialized during the Initialize lifecycle method
+	return &SlackEventsProvider{
+		config: config,
+		logger: logger.With("module", "centralized_slack_events"),
+	}, nil
+}
+
+// ID returns the unique identifier for this source provider type.
+func (f *SlackEventsProviderFactory) ID() string {
+	return "slack"
+}
+
+// Name returns a human-readable name for this source provider.
+func (f *SlackEventsProviderFactory) Name() string {
+	return "Slack Events"
+}
+
+// Description returns a detailed description of what this source provider does.
+func (f *SlackEventsProviderFactory) Description() string {
+	return "Receives Slack Events API callbacks and interactive component payloads, verifies them with the Slack app signing secret and emits message, app mention and interaction source events. Sources sharing a signing secret are served as one Slack app."
+}
+
+// Schema returns a JSON Schema that describes the orchestrator configuration.
+func (f *SlackEventsProviderFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"port": map[string]any{
+				"type":        "integer",
+				"description": "Port number for the Slack callback HTTP server (default: 8086)",
+				"minimum":     1,
+				"maximum":     65535,
+				"default":     8086,
+			},
+		},
+		"required":             []string{},
+		"additionalProperties": false,
+		"description":          "Centralized Slack events orchestrator configuration. Signing secrets and event types are defined in workflow triggers, not here.",
+	}
+}
+
+// EventTypes returns a list of event types that this source provider can emit.
+func (f *SlackEventsProviderFactory) EventTypes() []string {
+	return []string{"MessageReceived", "AppMentionReceived", "InteractionReceived"}
+}
+
+// Ensure interface compliance.
+var _ protocol.ProviderFactory = (*SlackEventsProviderFactory)(nil)
@@ -0,0 +1,123 @@
+// Package models defines the data structures used by the Slack events provider.
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"time"
+)
+
+// Event types a Slack source can subscribe to.
+const (
+	EventTypeMessage     = "message"
+	EventTypeAppMention  = "app_mention"
+	EventTypeInteraction = "interaction"
+)
+
+// SupportedEventTypes lists every event type a Slack source can subscribe to.
+var SupportedEventTypes = []string{EventTypeMessage, EventTypeAppMention, EventTypeInteraction}
+
+// ErrInvalidSlackSource is returned when Slack source validation fails.
+var ErrInvalidSlackSource = errors.New("invalid slack source")
+
+// SlackSource represents a workflow trigger fed by the callbacks of a Slack app.
+// Sources sharing a signing secret belong to the same Slack app.
+type SlackSource struct {
+	// ID is the source identifier used in workflows
+	ID string `json:"id" validate:"required"`
+
+	// SigningSecret is the Slack app signing secret used to verify callbacks
+	SigningSecret string `json:"signing_secret" validate:"required"`
+
+	// EventTypes are the event types this source emits; empty means all of them
+	EventTypes []string `json:"event_types,omitempty"`
+
+	// IncludeBotMessages emits message events posted by bots, which are skipped by default
+	IncludeBotMessages bool `json:"include_bot_messages"`
+
+	// CreatedAt is the timestamp when this source was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt is the timestamp when this source was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Active indicates if this source should receive events
+	Active bool `json:"active"`
+}
+
+// NewSlackSource creates a new Slack source from a trigger node configuration.
+func NewSlackSource(sourceID string, configuration map[string]any) (*SlackSource, error) {
+	now := time.Now().UTC()
+
+	source := &SlackSource{
+		ID:        sourceID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Active:    true,
+	}
+
+	if err := source.UpdateConfiguration(configuration); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// UpdateConfiguration applies the "signing_secret", "event_types" and "include_bot_messages" settings.
+func (ss *SlackSource) UpdateConfiguration(configuration map[string]any) error {
+	signingSecret, _ := configuration["signing_secret"].(string)
+
+	var eventTypes []string
+
+	switch raw := configuration["event_types"].(type) {
+	case []string:
+		eventTypes = raw
+	case []any:
+		for _, value := range raw {
+			eventType, ok := value.(string)
+			if !ok {
+				return ErrInvalidSlackSource
+			}
+
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	includeBotMessages, _ := configuration["include_bot_messages"].(bool)
+
+	ss.SigningSecret = signingSecret
+	ss.EventTypes = eventTypes
+	ss.IncludeBotMessages = includeBotMessages
+	ss.UpdatedAt = time.Now().UTC()
+
+	return ss.Validate()
+}
+
+// Validate performs validation on the Slack source structure.
+func (ss *SlackSource) Validate() error {
+	if ss.ID == "" || ss.SigningSecret == "" {
+		return ErrInvalidSlackSource
+	}
+
+	for _, eventType := range ss.EventTypes {
+		if !slices.Contains(SupportedEventTypes, eventType) {
+			return ErrInvalidSlackSource
+		}
+	}
+
+	return nil
+}
+
+// Accepts reports whether the source emits events of the given type.
+func (ss *SlackSource) Accepts(eventType string) bool {
+	return len(ss.EventTypes) == 0 || slices.Contains(ss.EventTypes, eventType)
+}
+
+// GroupKey identifies the Slack app of the source without exposing its signing secret.
+func (ss *SlackSource) GroupKey() string {
+	sum := sha256.Sum256([]byte(ss.SigningSecret))
+
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSlackSource(t *testing.T) {
+	source, err := NewSlackSource("source-1", map[string]any{
+		"signing_secret": "secret",
+		"event_types":    []any{"app_mention", "interaction"},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, source.Active)
+	assert.False(t, source.IncludeBotMessages)
+	assert.True(t, source.Accepts(EventTypeAppMention))
+	assert.False(t, source.Accepts(EventTypeMessage))
+
+	testCases := []map[string]any{
+		{},
+		{"signing_secret": ""},
+		{"signing_secret": "secret", "event_types": []any{"reaction_added"}},
+		{"signing_secret": "secret", "event_types": []any{42}},
+	}
+
+	for _, config := range testCases {
+		_, err := NewSlackSource("source-1", config)
+		assert.ErrorIs(t, err, ErrInvalidSlackSource, "config %v", config)
+	}
+}
+
+func TestSlackSource_GroupKey(t *testing.T) {
+	first, err := NewSlackSource("first", map[string]any{"signing_secret": "app-a"})
+	require.NoError(t, err)
+
+	second, err := NewSlackSource("second", map[string]any{"signing_secret": "app-a"})
+	require.NoError(t, err)
+
+	other, err := NewSlackSource("other", map[string]any{"signing_secret": "app-b"})
+	require.NoError(t, err)
+
+	assert.Equal(t, first.GroupKey(), second.GroupKey())
+	assert.NotEqual(t, first.GroupKey(), other.GroupKey())
+	assert.NotContains(t, first.GroupKey(), "app-a")
+	assert.True(t, first.Accepts(EventTypeMessage), "no event types accepts every type")
+}
@@ -0,0 +1,163 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dukex/operion/pkg/providers/slack-events/models"
+)
+
+// FilePersistence implements SlackEventsPersistence using JSON files.
+type FilePersistence struct {
+	dataDir      string
+	mu           sync.RWMutex
+	slackSources map[string]*models.SlackSource // ID -> SlackSource mapping
+}
+
+// NewFilePersistence creates a new file-based Slack events persistence.
+func NewFilePersistence(dataDir string) (*FilePersistence, error) {
+	if err := os.MkdirAll(dataDir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	fp := &FilePersistence{
+		dataDir:      dataDir,
+		slackSources: make(map[string]*models.SlackSource),
+	}
+
+	// Load existing slack sources
+	if err := fp.loadSlackSources(); err != nil {
+		return nil, fmt.Errorf("failed to load slack sources: %w", err)
+	}
+
+	return fp, nil
+}
+
+// SaveSlackSource saves a slack source to the file system.
+func (fp *FilePersistence) SaveSlackSource(source *models.SlackSource) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	fp.slackSources[source.ID] = source
+
+	return fp.saveSlackSourcesToFile()
+}
+
+// SlackSourceByID retrieves a slack source by its ID.
+func (fp *FilePersistence) SlackSourceByID(id string) (*models.SlackSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	source, exists := fp.slackSources[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return source, nil
+}
+
+// SlackSources returns all slack sources.
+func (fp *FilePersistence) SlackSources() ([]*models.SlackSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	sources := make([]*models.SlackSource, 0, len(fp.slackSources))
+	for _, source := range fp.slackSources {
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+// ActiveSlackSources returns only active slack sources.
+func (fp *FilePersistence) ActiveSlackSources() ([]*models.SlackSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	var activeSources []*models.SlackSource
+
+	for _, source := range fp.slackSources {
+		if source.Active {
+			activeSources = append(activeSources, source)
+		}
+	}
+
+	return activeSources, nil
+}
+
+// DeleteSlackSource removes a slack source by its ID.
+func (fp *FilePersistence) DeleteSlackSource(id string) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	delete(fp.slackSources, id)
+
+	return fp.saveSlackSourcesToFile()
+}
+
+// HealthCheck verifies that the persistence layer is healthy.
+func (fp *FilePersistence) HealthCheck() error {
+	if _, err := os.Stat(fp.dataDir); os.IsNotExist(err) {
+		return fmt.Errorf("data directory does not exist: %s", fp.dataDir)
+	}
+
+	return nil
+}
+
+// Close cleans up resources.
+func (fp *FilePersistence) Close() error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	return fp.saveSlackSourcesToFile()
+}
+
+// loadSlackSources loads slack sources from the file system.
+func (fp *FilePersistence) loadSlackSources() error {
+	sourcesFile := filepath.Join(fp.dataDir, "slack_sources.json")
+
+	if _, err := os.Stat(sourcesFile); os.IsNotExist(err) {
+		// File doesn't exist, start with empty sources
+		return nil
+	}
+
+	data, err := os.ReadFile(sourcesFile) // #nosec G304 -- sourcesFile is constructed from controlled dataDir
+	if err != nil {
+		return fmt.Errorf("failed to read slack sources file: %w", err)
+	}
+
+	var sources []*models.SlackSource
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return fmt.Errorf("failed to unmarshal slack sources: %w", err)
+	}
+
+	for _, source := range sources {
+		fp.slackSources[source.ID] = source
+	}
+
+	return nil
+}
+
+// saveSlackSourcesToFile saves all slack sources to the file system.
+func (fp *FilePersistence) saveSlackSourcesToFile() error {
+	sourcesFile := filepath.Join(fp.dataDir, "slack_sources.json")
+
+	sources := make([]*models.SlackSource, 0, len(fp.slackSources))
+	for _, source := range fp.slackSources {
+		sources = append(sources, source)
+	}
+
+	data, err := json.MarshalIndent(sources, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack sources: %w", err)
+	}
+
+	if err := os.WriteFile(sourcesFile, data, 0600); err != nil {
+		return fmt.Errorf("failed to write slack sources file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+// Package persistence provides storage for Slack event sources.
+package persistence
+
+import (
+	"github.com/dukex/operion/pkg/providers/slack-events/models"
+)
+
+// SlackEventsPersistence defines the persistence interface for the Slack events provider.
+// This interface is specific to Slack source needs and isolated from core persistence.
+type SlackEventsPersistence interface {
+	// SlackSource operations
+	SaveSlackSource(source *models.SlackSource) error
+	SlackSourceByID(id string) (*models.SlackSource, error)
+	SlackSources() ([]*models.SlackSource, error)
+	ActiveSlackSources() ([]*models.SlackSource, error)
+	DeleteSlackSource(id string) error
+
+	// Health and lifecycle
+	HealthCheck() error
+	Close() error
+}
@@ -0,0 +1,305 @@
+// Package slackevents provides a source provider that receives Slack Events API callbacks
+// and interactive component payloads.
+package slackevents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	slackModels "github.com/dukex/operion/pkg/providers/slack-events/models"
+	slackPersistence "github.com/dukex/operion/pkg/providers/slack-events/persistence"
+)
+
+// defaultPort is the Slack callback server port used when none is configured.
+const defaultPort = 8086
+
+// SlackEventsProvider implements a centralized Slack callback orchestrator that verifies
+// Slack requests and converts them to source events.
+type SlackEventsProvider struct {
+	config           map[string]any
+	logger           *slog.Logger
+	callback         protocol.SourceEventCallback
+	server           *SlackServer
+	slackPersistence slackPersistence.SlackEventsPersistence
+	port             int
+	started          bool
+	mu               sync.RWMutex
+}
+
+// Start begins serving Slack callbacks.
+func (p *SlackEventsProvider) Start(ctx context.Context, callback protocol.SourceEventCallback) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.started {
+		return nil
+	}
+
+	p.callback = callback
+	p.logger.Info("Starting Slack events orchestrator", "port", p.port)
+
+	p.server.SetCallback(callback)
+
+	if err := p.server.Start(ctx); err != nil {
+		return err
+	}
+
+	p.started = true
+	p.logger.Info("Slack events orchestrator started successfully", "port", p.port)
+
+	return nil
+}
+
+// Stop gracefully shuts down the Slack events orchestrator.
+func (p *SlackEventsProvider) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.started {
+		return nil
+	}
+
+	p.logger.Info("Stopping Slack events orchestrator")
+
+	if err := p.server.Stop(ctx); err != nil {
+		p.logger.Error("Error stopping Slack events server", "error", err)
+
+		return err
+	}
+
+	p.started = false
+	p.logger.Info("Slack events orchestrator stopped successfully")
+
+	return nil
+}
+
+// Validate checks if the Slack events orchestrator configuration is valid.
+func (p *SlackEventsProvider) Validate() error {
+	if p.server == nil {
+		return errors.New("slack events server not initialized")
+	}
+
+	if p.slackPersistence == nil {
+		return errors.New("slack events persistence not initialized")
+	}
+
+	return nil
+}
+
+// ProviderLifecycle interface implementation
+
+// Initialize sets up the provider with required dependencies.
+func (p *SlackEventsProvider) Initialize(ctx context.Context, deps protocol.Dependencies) error {
+	p.logger = deps.Logger
+
+	persistenceURL := os.Getenv("SLACK_EVENTS_PERSISTENCE_URL")
+	if persistenceURL == "" {
+		return errors.New("slack events provider requires SLACK_EVENTS_PERSISTENCE_URL environment variable (e.g., file://./data/slack-events)")
+	}
+
+	persistence, err := p.createPersistence(persistenceURL)
+	if err != nil {
+		return err
+	}
+
+	p.slackPersistence = persistence
+	p.port = p.serverPort()
+	p.server = NewSlackServer(p.port, p.logger)
+
+	p.logger.Info("Slack events provider initialized", "port", p.port, "persistence", persistenceURL)
+
+	return nil
+}
+
+// Configure configures the provider based on current workflow definitions.
+func (p *SlackEventsProvider) Configure(workflows []*models.Workflow) (map[string]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.logger.Info("Configuring Slack events provider with workflows", "workflow_count", len(workflows))
+
+	triggerToSource := make(map[string]string)
+	configureErr := protocol.NewConfigureError("slack")
+
+	for _, wf := range workflows {
+		if wf.Status != models.WorkflowStatusPublished {
+			continue
+		}
+
+		// Filter trigger nodes with slack provider
+		for _, node := range wf.Nodes {
+			if node.IsTriggerNode() && node.ProviderID != nil && *node.ProviderID == "slack" {
+				sourceID, err := p.processSlackTriggerNode(wf.ID, node)
+				if err != nil {
+					configureErr.Add(wf.ID, node.ID, err)
+
+					continue
+				}
+
+				triggerToSource[node.ID] = sourceID
+			}
+		}
+	}
+
+	p.logger.Info("Slack events configuration completed", "configured_sources", len(triggerToSource))
+
+	return triggerToSource, configureErr.ErrOrNil()
+}
+
+// Prepare groups the active sources by Slack app before starting the provider.
+func (p *SlackEventsProvider) Prepare(ctx context.Context) error {
+	if p.server == nil || p.slackPersistence == nil {
+		return errors.New("slack events provider not initialized")
+	}
+
+	sources, err := p.slackPersistence.ActiveSlackSources()
+	if err != nil {
+		return err
+	}
+
+	apps := groupSources(sources)
+	p.server.setApps(apps)
+
+	p.logger.Info("Slack events provider prepared and ready",
+		"sources", len(sources),
+		"apps", len(apps))
+
+	return nil
+}
+
+// groupSources groups sources by signing secret so each Slack app is verified once per request.
+func groupSources(sources []*slackModels.SlackSource) map[string]*slackApp {
+	apps := make(map[string]*slackApp)
+
+	for _, source := range sources {
+		key := source.GroupKey()
+
+		app, exists := apps[key]
+		if !exists {
+			app = &slackApp{signingSecret: source.SigningSecret}
+			apps[key] = app
+		}
+
+		app.sources = append(app.sources, source)
+	}
+
+	return apps
+}
+
+// processSlackTriggerNode creates or updates the Slack source of a trigger node.
+// Returns the sourceID if the source was successfully saved, an error otherwise.
+func (p *SlackEventsProvider) processSlackTriggerNode(workflowID string, node *models.WorkflowNode) (string, error) {
+	sourceID := ""
+	if node.SourceID != nil {
+		sourceID = *node.SourceID
+	}
+
+	if sourceID == "" {
+		// Generate a new UUID for the sourceID
+		sourceID = uuid.New().String()
+		p.logger.Info("Generated source_id for slack trigger node",
+			"workflow_id", workflowID,
+			"node_id", node.ID,
+			"generated_source_id", sourceID)
+	}
+
+	existingSource, err := p.slackPersistence.SlackSourceByID(sourceID)
+	if err != nil {
+		p.logger.Error("Failed to check existing slack source",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", fmt.Errorf("failed to check existing slack source: %w", err)
+	}
+
+	source := existingSource
+	if source != nil {
+		err = source.UpdateConfiguration(node.Config)
+	} else {
+		source, err = slackModels.NewSlackSource(sourceID, node.Config)
+	}
+
+	if err != nil {
+		p.logger.Error("Invalid slack source configuration",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", err
+	}
+
+	if err := p.slackPersistence.SaveSlackSource(source); err != nil {
+		p.logger.Error("Failed to save slack source",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", fmt.Errorf("failed to save slack source: %w", err)
+	}
+
+	p.logger.Info("Configured slack source",
+		"source_id", sourceID,
+		"app", source.GroupKey(),
+		"event_types", source.EventTypes)
+
+	return sourceID, nil
+}
+
+// serverPort gets the Slack callback server port from configuration or environment.
+func (p *SlackEventsProvider) serverPort() int {
+	switch port := p.config["port"].(type) {
+	case int:
+		if port > 0 && port <= 65535 {
+			return port
+		}
+	case string:
+		if parsed, err := strconv.Atoi(port); err == nil && parsed > 0 && parsed <= 65535 {
+			return parsed
+		}
+	}
+
+	if portEnv := os.Getenv("SLACK_EVENTS_PORT"); portEnv != "" {
+		if port, err := strconv.Atoi(portEnv); err == nil && port > 0 && port <= 65535 {
+			return port
+		}
+	}
+
+	return defaultPort
+}
+
+// createPersistence creates the appropriate persistence implementation based on URL scheme.
+func (p *SlackEventsProvider) createPersistence(persistenceURL string) (slackPersistence.SlackEventsPersistence, error) {
+	scheme := p.parsePersistenceScheme(persistenceURL)
+	p.logger.Info("Initializing slack events persistence", "scheme", scheme, "url", persistenceURL)
+
+	switch scheme {
+	case "file":
+		// Extract path from file://path
+		path := strings.TrimPrefix(persistenceURL, "file://")
+
+		return slackPersistence.NewFilePersistence(path)
+	case "postgres", "postgresql":
+		// Future: implement database persistence
+		return nil, errors.New("postgres persistence for slack events not yet implemented")
+	default:
+		return nil, errors.New("unsupported persistence scheme: " + scheme + " (supported: file)")
+	}
+}
+
+// parsePersistenceScheme extracts the scheme from a persistence URL.
+func (p *SlackEventsProvider) parsePersistenceScheme(persistenceURL string) string {
+	parts := strings.SplitN(persistenceURL, "://", 2)
+	if len(parts) < 2 {
+		return "unknown"
+	}
+
+	return parts[0]
+}
@@ -0,0 +1,57 @@
+package slackevents
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	slackPersistence "github.com/dukex/operion/pkg/providers/slack-events/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestProvider(t *testing.T) *SlackEventsProvider {
+	t.Helper()
+
+	persistence, err := slackPersistence.NewFilePersistence(t.TempDir())
+	require.NoError(t, err)
+
+	return &SlackEventsProvider{
+		logger:           createTestLogger(),
+		slackPersistence: persistence,
+		server:           NewSlackServer(0, createTestLogger()),
+	}
+}
+
+func TestSlackEventsProvider_ConfigureAndPrepare(t *testing.T) {
+	provider := createTestProvider(t)
+	slackProvider := "slack"
+	otherProvider := "webhook"
+
+	workflows := []*models.Workflow{
+		{
+			ID:     "wf-1",
+			Status: models.WorkflowStatusPublished,
+			Nodes: []*models.WorkflowNode{
+				{ID: "mentions", Category: models.CategoryTypeTrigger, ProviderID: &slackProvider, Config: map[string]any{"signing_secret": "app-a", "event_types": []any{"app_mention"}}},
+				{ID: "buttons", Category: models.CategoryTypeTrigger, ProviderID: &slackProvider, Config: map[string]any{"signing_secret": "app-a", "event_types": []any{"interaction"}}},
+				{ID: "other-app", Category: models.CategoryTypeTrigger, ProviderID: &slackProvider, Config: map[string]any{"signing_secret": "app-b"}},
+				{ID: "invalid", Category: models.CategoryTypeTrigger, ProviderID: &slackProvider, Config: map[string]any{}},
+				{ID: "webhook", Category: models.CategoryTypeTrigger, ProviderID: &otherProvider, Config: map[string]any{}},
+			},
+		},
+	}
+
+	triggerToSource, err := provider.Configure(workflows)
+
+	var configureErr *protocol.ConfigureError
+	require.ErrorAs(t, err, &configureErr)
+	require.Len(t, configureErr.Workflows["wf-1"], 1)
+	assert.Equal(t, "invalid", configureErr.Workflows["wf-1"][0].TriggerID)
+
+	assert.Len(t, triggerToSource, 3)
+	assert.NotContains(t, triggerToSource, "webhook")
+
+	require.NoError(t, provider.Prepare(t.Context()))
+	assert.Len(t, provider.server.apps, 2, "sources are grouped by signing secret")
+}
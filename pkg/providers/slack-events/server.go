@@ -0,0 +1,417 @@
+package slackevents
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/protocol"
+	"github.com/dukex/operion/pkg/providers/slack-events/models"
+)
+
+const (
+	// Server configuration constants.
+	slackReadTimeout     = 30 * time.Second
+	slackWriteTimeout    = 30 * time.Second
+	slackIdleTimeout     = 60 * time.Second
+	slackShutdownTimeout = 5 * time.Second
+
+	// maxRequestBytes limits the size of a Slack callback body.
+	maxRequestBytes = 1024 * 1024 // 1MB
+
+	// maxRequestAge rejects signed requests older than this to prevent replays.
+	maxRequestAge = 5 * time.Minute
+
+	// signatureVersion is the version prefix of Slack request signatures.
+	signatureVersion = "v0"
+
+	headerSignature = "X-Slack-Signature"
+	headerTimestamp = "X-Slack-Request-Timestamp"
+)
+
+var (
+	errMissingSignature = errors.New("missing slack signature headers")
+	errStaleRequest     = errors.New("slack request timestamp is too old")
+	errInvalidSignature = errors.New("invalid slack signature")
+)
+
+// sourceEventTypes maps the Slack event types to the source event types emitted for them.
+var sourceEventTypes = map[string]string{
+	models.EventTypeMessage:     "message_received",
+	models.EventTypeAppMention:  "app_mention_received",
+	models.EventTypeInteraction: "interaction_received",
+}
+
+// slackApp is a set of sources sharing the signing secret of one Slack app.
+type slackApp struct {
+	signingSecret string
+	sources       []*models.SlackSource
+}
+
+// SlackServer receives Slack Events API and interactivity callbacks.
+type SlackServer struct {
+	server   *http.Server
+	port     int
+	apps     map[string]*slackApp
+	callback protocol.SourceEventCallback
+	logger   *slog.Logger
+	now      func() time.Time
+	mu       sync.RWMutex
+	started  bool
+}
+
+// NewSlackServer creates a new Slack callback server instance.
+func NewSlackServer(port int, logger *slog.Logger) *SlackServer {
+	return &SlackServer{
+		port:   port,
+		apps:   make(map[string]*slackApp),
+		logger: logger.With("module", "slack_events_server", "port", port),
+		now:    time.Now,
+	}
+}
+
+// SetCallback sets the callback function for publishing source events.
+func (s *SlackServer) SetCallback(callback protocol.SourceEventCallback) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.callback = callback
+}
+
+// setApps replaces the Slack apps whose callbacks are accepted.
+func (s *SlackServer) setApps(apps map[string]*slackApp) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.apps = apps
+}
+
+// Handler returns the HTTP handler serving the Slack callback endpoints.
+func (s *SlackServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slack/events", s.handleEvents)
+	mux.HandleFunc("/slack/interactions", s.handleInteractions)
+	mux.HandleFunc("/health", s.handleHealth)
+
+	return mux
+}
+
+// Start starts the HTTP server and begins handling Slack callbacks.
+func (s *SlackServer) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return nil
+	}
+
+	s.server = &http.Server{
+		Addr:         fmt.Sprintf(":%d", s.port),
+		Handler:      s.Handler(),
+		ReadTimeout:  slackReadTimeout,
+		WriteTimeout: slackWriteTimeout,
+		IdleTimeout:  slackIdleTimeout,
+	}
+
+	s.started = true
+	s.logger.Info("Starting Slack events server", "addr", s.server.Addr)
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("Slack events server error", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully shuts down the Slack callback server.
+func (s *SlackServer) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		return nil
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, slackShutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	s.started = false
+	s.logger.Info("Slack events server stopped successfully")
+
+	return nil
+}
+
+// handleEvents handles Events API callbacks, including the URL verification handshake.
+func (s *SlackServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	body, app, ok := s.readSignedRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var envelope struct {
+		Type      string         `json:"type"`
+		Challenge string         `json:"challenge"`
+		TeamID    string         `json:"team_id"`
+		APIAppID  string         `json:"api_app_id"`
+		EventID   string         `json:"event_id"`
+		EventTime int64          `json:"event_time"`
+		Event     map[string]any `json:"event"`
+	}
+
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+
+		return
+	}
+
+	switch envelope.Type {
+	case "url_verification":
+		s.writeJSON(w, http.StatusOK, map[string]any{"challenge": envelope.Challenge})
+
+		return
+	case "event_callback":
+	default:
+		s.writeJSON(w, http.StatusOK, map[string]any{"status": "ignored"})
+
+		return
+	}
+
+	eventType, _ := envelope.Event["type"].(string)
+	if eventType != models.EventTypeMessage && eventType != models.EventTypeAppMention {
+		s.writeJSON(w, http.StatusOK, map[string]any{"status": "ignored"})
+
+		return
+	}
+
+	eventData := map[string]any{
+		"team_id":    envelope.TeamID,
+		"api_app_id": envelope.APIAppID,
+		"event_id":   envelope.EventID,
+		"event_time": envelope.EventTime,
+		"event":      envelope.Event,
+		"user":       envelope.Event["user"],
+		"text":       envelope.Event["text"],
+		"channel":    envelope.Event["channel"],
+		"ts":         envelope.Event["ts"],
+		"thread_ts":  envelope.Event["thread_ts"],
+	}
+
+	skip := func(source *models.SlackSource) bool {
+		return eventType == models.EventTypeMessage && isBotMessage(envelope.Event) && !source.IncludeBotMessages
+	}
+
+	s.dispatch(w, r, app, eventType, eventData, skip)
+}
+
+// handleInteractions handles interactive component payloads (buttons, menus, modals, shortcuts).
+func (s *SlackServer) handleInteractions(w http.ResponseWriter, r *http.Request) {
+	body, app, ok := s.readSignedRequest(w, r)
+	if !ok {
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil || form.Get("payload") == "" {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Missing interaction payload")
+
+		return
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(form.Get("payload")), &payload); err != nil {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON in interaction payload")
+
+		return
+	}
+
+	team, _ := payload["team"].(map[string]any)
+
+	eventData := map[string]any{
+		"type":         payload["type"],
+		"team_id":      team["id"],
+		"api_app_id":   payload["api_app_id"],
+		"user":         payload["user"],
+		"channel":      payload["channel"],
+		"actions":      payload["actions"],
+		"callback_id":  payload["callback_id"],
+		"trigger_id":   payload["trigger_id"],
+		"response_url": payload["response_url"],
+		"payload":      payload,
+	}
+
+	s.dispatch(w, r, app, models.EventTypeInteraction, eventData, nil)
+}
+
+// dispatch publishes a source event for every source of the app subscribed to the event type.
+func (s *SlackServer) dispatch(
+	w http.ResponseWriter,
+	r *http.Request,
+	app *slackApp,
+	eventType string,
+	eventData map[string]any,
+	skip func(*models.SlackSource) bool,
+) {
+	s.mu.RLock()
+	callback := s.callback
+	s.mu.RUnlock()
+
+	eventData["event_type"] = eventType
+	published := 0
+
+	for _, source := range app.sources {
+		if !source.Accepts(eventType) || (skip != nil && skip(source)) || callback == nil {
+			continue
+		}
+
+		if err := callback(r.Context(), source.ID, "slack", sourceEventTypes[eventType], eventData); err != nil {
+			s.logger.Error("Error publishing source event", "source_id", source.ID, "error", err)
+			s.writeErrorResponse(w, http.StatusInternalServerError, "Error processing Slack event")
+
+			return
+		}
+
+		published++
+	}
+
+	s.logger.Info("Slack event processed", "event_type", eventType, "sources", published)
+	s.writeJSON(w, http.StatusOK, map[string]any{"status": "success"})
+}
+
+// readSignedRequest reads the body of a POST request and finds the app whose signing secret
+// verifies it. It writes the error response and returns false when the request is rejected.
+func (s *SlackServer) readSignedRequest(w http.ResponseWriter, r *http.Request) ([]byte, *slackApp, bool) {
+	if r.Method != http.MethodPost {
+		s.writeErrorResponse(w, http.StatusMethodNotAllowed, "Only POST method allowed")
+
+		return nil, nil, false
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusRequestEntityTooLarge, "Request body too large")
+
+		return nil, nil, false
+	}
+
+	app, err := s.authenticate(r.Header, body)
+	if err != nil {
+		s.logger.Warn("Rejected Slack request", "remote_addr", r.RemoteAddr, "error", err)
+		s.writeErrorResponse(w, http.StatusUnauthorized, "Invalid Slack signature")
+
+		return nil, nil, false
+	}
+
+	return body, app, true
+}
+
+// authenticate returns the app whose signing secret produced the request signature.
+func (s *SlackServer) authenticate(header http.Header, body []byte) (*slackApp, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	timestamp := header.Get(headerTimestamp)
+	signature := header.Get(headerSignature)
+
+	for _, app := range s.apps {
+		err := verifySignature(app.signingSecret, timestamp, signature, body, s.now())
+		if err == nil {
+			return app, nil
+		}
+
+		if !errors.Is(err, errInvalidSignature) {
+			return nil, err
+		}
+	}
+
+	return nil, errInvalidSignature
+}
+
+// verifySignature checks a request signature as documented by Slack: the hex encoded
+// HMAC-SHA256 of "v0:{timestamp}:{body}" keyed by the signing secret, prefixed by "v0=".
+func verifySignature(signingSecret, timestamp, signature string, body []byte, now time.Time) error {
+	if timestamp == "" || signature == "" {
+		return errMissingSignature
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errMissingSignature
+	}
+
+	if age := now.Sub(time.Unix(seconds, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return errStaleRequest
+	}
+
+	expected := computeSignature(signingSecret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return errInvalidSignature
+	}
+
+	return nil
+}
+
+// computeSignature returns the Slack signature of a request body.
+func computeSignature(signingSecret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	_, _ = mac.Write([]byte(signatureVersion + ":" + timestamp + ":"))
+	_, _ = mac.Write(body)
+
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// isBotMessage reports whether a message event was posted by a bot.
+func isBotMessage(event map[string]any) bool {
+	botID, _ := event["bot_id"].(string)
+	subtype, _ := event["subtype"].(string)
+
+	return botID != "" || subtype == "bot_message"
+}
+
+// handleHealth handles health check requests.
+func (s *SlackServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	apps := len(s.apps)
+	s.mu.RUnlock()
+
+	s.writeJSON(w, http.StatusOK, map[string]any{
+		"status":    "healthy",
+		"apps":      apps,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// writeErrorResponse writes a JSON error response.
+func (s *SlackServer) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	s.writeJSON(w, statusCode, map[string]any{
+		"status":  "error",
+		"message": message,
+		"code":    statusCode,
+	})
+}
+
+// writeJSON writes a JSON response.
+func (s *SlackServer) writeJSON(w http.ResponseWriter, statusCode int, body map[string]any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.logger.Error("Error encoding response", "error", err)
+	}
+}
@@ -0,0 +1,210 @@
+package slackevents
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/providers/slack-events/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publishedEvent is a source event published by the Slack server.
+type publishedEvent struct {
+	sourceID  string
+	eventType string
+	data      map[string]any
+}
+
+// recordedEvents collects the source events published by the server.
+type recordedEvents struct {
+	mu     sync.Mutex
+	events []publishedEvent
+}
+
+func (r *recordedEvents) callback(_ context.Context, sourceID, providerID, eventType string, eventData map[string]any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, publishedEvent{sourceID: sourceID, eventType: eventType, data: eventData})
+
+	return nil
+}
+
+var testNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func createTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// createTestServer returns a server for the given sources, grouped by signing secret.
+func createTestServer(t *testing.T, configs map[string]map[string]any) (*SlackServer, *recordedEvents) {
+	t.Helper()
+
+	sources := make([]*models.SlackSource, 0, len(configs))
+
+	for id, config := range configs {
+		source, err := models.NewSlackSource(id, config)
+		require.NoError(t, err)
+
+		sources = append(sources, source)
+	}
+
+	recorder := &recordedEvents{}
+
+	server := NewSlackServer(0, createTestLogger())
+	server.now = func() time.Time { return testNow }
+	server.setApps(groupSources(sources))
+	server.SetCallback(recorder.callback)
+
+	return server, recorder
+}
+
+// signedRequest builds a request signed with the given secret at the test time.
+func signedRequest(path, contentType, body, signingSecret string) *http.Request {
+	timestamp := strconv.FormatInt(testNow.Unix(), 10)
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(headerTimestamp, timestamp)
+	req.Header.Set(headerSignature, computeSignature(signingSecret, timestamp, []byte(body)))
+
+	return req
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"type":"event_callback"}`)
+	timestamp := strconv.FormatInt(testNow.Unix(), 10)
+	signature := computeSignature("secret", timestamp, body)
+
+	require.NoError(t, verifySignature("secret", timestamp, signature, body, testNow))
+	require.NoError(t, verifySignature("secret", timestamp, signature, body, testNow.Add(4*time.Minute)))
+
+	require.ErrorIs(t, verifySignature("other-secret", timestamp, signature, body, testNow), errInvalidSignature)
+	require.ErrorIs(t, verifySignature("secret", timestamp, signature, []byte(`{"type":"tampered"}`), testNow), errInvalidSignature)
+	require.ErrorIs(t, verifySignature("secret", timestamp, signature, body, testNow.Add(6*time.Minute)), errStaleRequest)
+	require.ErrorIs(t, verifySignature("secret", "", signature, body, testNow), errMissingSignature)
+	require.ErrorIs(t, verifySignature("secret", timestamp, "", body, testNow), errMissingSignature)
+}
+
+func TestSlackServer_RejectsInvalidSignature(t *testing.T) {
+	server, recorder := createTestServer(t, map[string]map[string]any{
+		"source-1": {"signing_secret": "app-secret"},
+	})
+
+	body := `{"type":"event_callback","event":{"type":"app_mention","text":"hi"}}`
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, signedRequest("/slack/events", "application/json", body, "wrong-secret"))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Empty(t, recorder.events)
+}
+
+func TestSlackServer_URLVerificationChallenge(t *testing.T) {
+	server, recorder := createTestServer(t, map[string]map[string]any{
+		"source-1": {"signing_secret": "app-secret"},
+	})
+
+	body := `{"token":"legacy","challenge":"3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P","type":"url_verification"}`
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, signedRequest("/slack/events", "application/json", body, "app-secret"))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P", response["challenge"])
+	assert.Empty(t, recorder.events, "the handshake does not emit events")
+}
+
+func TestSlackServer_EventCallback_GroupsSourcesByApp(t *testing.T) {
+	server, recorder := createTestServer(t, map[string]map[string]any{
+		"mentions":     {"signing_secret": "app-a", "event_types": []any{"app_mention"}},
+		"all-events":   {"signing_secret": "app-a"},
+		"bot-messages": {"signing_secret": "app-a", "event_types": []any{"message"}, "include_bot_messages": true},
+		"other-app":    {"signing_secret": "app-b"},
+	})
+
+	mention := `{"type":"event_callback","team_id":"T1","api_app_id":"A1","event_id":"Ev1","event_time":1700000000,` +
+		`"event":{"type":"app_mention","user":"U1","text":"<@B1> deploy","channel":"C1","ts":"1700000000.000100"}}`
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, signedRequest("/slack/events", "application/json", mention, "app-a"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	published := make(map[string]publishedEvent)
+	for _, event := range recorder.events {
+		published[event.sourceID] = event
+	}
+
+	assert.Len(t, recorder.events, 2)
+	assert.Contains(t, published, "mentions")
+	assert.Contains(t, published, "all-events")
+	assert.Equal(t, "app_mention_received", published["mentions"].eventType)
+	assert.Equal(t, "<@B1> deploy", published["mentions"].data["text"])
+	assert.Equal(t, "C1", published["mentions"].data["channel"])
+	assert.Equal(t, "app_mention", published["mentions"].data["event_type"])
+
+	// Bot messages only reach sources that opt in
+	recorder.events = nil
+	botMessage := `{"type":"event_callback","event":{"type":"message","bot_id":"B1","text":"done","channel":"C1"}}`
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, signedRequest("/slack/events", "application/json", botMessage, "app-a"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	require.Len(t, recorder.events, 1)
+	assert.Equal(t, "bot-messages", recorder.events[0].sourceID)
+	assert.Equal(t, "message_received", recorder.events[0].eventType)
+}
+
+func TestSlackServer_InteractionPayload(t *testing.T) {
+	server, recorder := createTestServer(t, map[string]map[string]any{
+		"approvals": {"signing_secret": "app-secret", "event_types": []any{"interaction"}},
+		"mentions":  {"signing_secret": "app-secret", "event_types": []any{"app_mention"}},
+	})
+
+	payload := `{"type":"block_actions","api_app_id":"A1","team":{"id":"T1","domain":"acme"},` +
+		`"user":{"id":"U1","username":"ada"},"channel":{"id":"C1","name":"deploys"},"trigger_id":"123.456",` +
+		`"response_url":"https://hooks.slack.com/actions/T1/1/abc",` +
+		`"actions":[{"action_id":"approve","block_id":"b1","value":"release-42","type":"button"}]}`
+	body := url.Values{"payload": {payload}}.Encode()
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, signedRequest("/slack/interactions", "application/x-www-form-urlencoded", body, "app-secret"))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	require.Len(t, recorder.events, 1)
+
+	event := recorder.events[0]
+	assert.Equal(t, "approvals", event.sourceID)
+	assert.Equal(t, "interaction_received", event.eventType)
+	assert.Equal(t, "block_actions", event.data["type"])
+	assert.Equal(t, "T1", event.data["team_id"])
+	assert.Equal(t, "https://hooks.slack.com/actions/T1/1/abc", event.data["response_url"])
+
+	actions, ok := event.data["actions"].([]any)
+	require.True(t, ok)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "release-42", actions[0].(map[string]any)["value"])
+}
+
+func TestSlackServer_InteractionWithoutPayload(t *testing.T) {
+	server, recorder := createTestServer(t, map[string]map[string]any{
+		"approvals": {"signing_secret": "app-secret"},
+	})
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, signedRequest("/slack/interactions", "application/x-www-form-urlencoded", "foo=bar", "app-secret"))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, recorder.events)
+}
@@ -0,0 +1,66 @@
+package sns
+
+import (
+	"log/slog"
+
+	"github.com/dukex/operion/pkg/protocol"
+)
+
+// SNSProviderFactory creates instances of SNSProvider.
+type SNSProviderFactory struct{}
+
+// NewSNSProviderFactory creates a new factory instance.
+func NewSNSProviderFactory() *SNSProviderFactory {
+	return &SNSProviderFactory{}
+}
+
+// Create instantiates a new centralized SNSProvider orchestrator.
+func (f *SNSProviderFactory) Create(config map[string]any, logger *slog.Logger) (protocol.Provider, error) {
+	// Persistence and HTTP server are initialized during the Initialize lifecycle method
+	return &SNSProvider{
+		config: config,
+		logger: logger.With("module", "centralized_sns"),
+	}, nil
+}
+
+// ID returns the unique identifier for this source provider type.
+func (f *SNSProviderFactory) ID() string {
+	return "sns"
+}
+
+// Name returns a human-readable name for this source provider.
+func (f *SNSProviderFactory) Name() string {
+	return "Amazon SNS"
+}
+
+// Description returns a detailed description of what this source provider does.
+func (f *SNSProviderFactory) Description() string {
+	return "Receives the notifications of Amazon SNS topics on an HTTP(S) subscription endpoint, confirms the subscriptions, verifies the message signatures with the SNS signing certificate and emits notification source events with the parsed message. Sources sharing a topic ARN are served by one subscription."
+}
+
+// Schema returns a JSON Schema that describes the orchestrator configuration.
+func (f *SNSProviderFactory) Schema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"port": map[string]any{
+				"type":        "integer",
+				"description": "Port number for the SNS subscription endpoint HTTP server (default: 8087)",
+				"minimum":     1,
+				"maximum":     65535,
+				"default":     8087,
+			},
+		},
+		"required":             []string{},
+		"additionalProperties": false,
+		"description":          "Centralized SNS orchestrator configuration. Topic ARNs are defined in workflow triggers, not here.",
+	}
+}
+
+// EventTypes returns a list of event types that this source provider can emit.
+func (f *SNSProviderFactory) EventTypes() []string {
+	return []string{"NotificationReceived"}
+}
+
+// Ensure interface compliance.
+var _ protocol.ProviderFactory = (*SNSProviderFactory)(nil)
@@ -0,0 +1,76 @@
+// Package models defines the data structures used by the SNS provider.
+package models
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// ErrInvalidSNSSource is returned when SNS source validation fails.
+var ErrInvalidSNSSource = errors.New("invalid sns source")
+
+// topicARNPattern matches SNS topic ARNs of every AWS partition, e.g.
+// arn:aws:sns:us-east-1:123456789012:orders.
+var topicARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:sns:[a-z0-9-]+:\d{12}:[A-Za-z0-9_-]+(\.fifo)?$`)
+
+// SNSSource represents a workflow trigger fed by the notifications of an SNS topic.
+// Sources sharing a topic ARN are served by the same HTTP subscription.
+type SNSSource struct {
+	// ID is the source identifier used in workflows
+	ID string `json:"id" validate:"required"`
+
+	// TopicARN is the ARN of the SNS topic whose notifications trigger the source
+	TopicARN string `json:"topic_arn" validate:"required"`
+
+	// CreatedAt is the timestamp when this source was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt is the timestamp when this source was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Active indicates if this source should receive notifications
+	Active bool `json:"active"`
+}
+
+// NewSNSSource creates a new SNS source from a trigger node configuration.
+func NewSNSSource(sourceID string, configuration map[string]any) (*SNSSource, error) {
+	now := time.Now().UTC()
+
+	source := &SNSSource{
+		ID:        sourceID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Active:    true,
+	}
+
+	if err := source.UpdateConfiguration(configuration); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// UpdateConfiguration applies the "topic_arn" setting.
+func (s *SNSSource) UpdateConfiguration(configuration map[string]any) error {
+	topicARN, _ := configuration["topic_arn"].(string)
+
+	s.TopicARN = topicARN
+	s.UpdatedAt = time.Now().UTC()
+
+	return s.Validate()
+}
+
+// Validate performs validation on the SNS source structure.
+func (s *SNSSource) Validate() error {
+	if s.ID == "" || !topicARNPattern.MatchString(s.TopicARN) {
+		return ErrInvalidSNSSource
+	}
+
+	return nil
+}
+
+// GroupKey identifies the SNS topic of the source.
+func (s *SNSSource) GroupKey() string {
+	return s.TopicARN
+}
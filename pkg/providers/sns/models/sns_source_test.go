@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSNSSource(t *testing.T) {
+	source, err := NewSNSSource("source-1", map[string]any{
+		"topic_arn": "arn:aws:sns:us-east-1:123456789012:orders",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, source.Active)
+	assert.Equal(t, "arn:aws:sns:us-east-1:123456789012:orders", source.GroupKey())
+
+	_, err = NewSNSSource("source-2", map[string]any{"topic_arn": "arn:aws-cn:sns:cn-north-1:123456789012:orders.fifo"})
+	require.NoError(t, err)
+
+	testCases := []map[string]any{
+		{},
+		{"topic_arn": ""},
+		{"topic_arn": "orders"},
+		{"topic_arn": "arn:aws:sqs:us-east-1:123456789012:orders"},
+		{"topic_arn": "arn:aws:sns:us-east-1:123:orders"},
+	}
+
+	for _, config := range testCases {
+		_, err := NewSNSSource("source-1", config)
+		assert.ErrorIs(t, err, ErrInvalidSNSSource, "config %v", config)
+	}
+}
@@ -0,0 +1,163 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dukex/operion/pkg/providers/sns/models"
+)
+
+// FilePersistence implements SNSPersistence using JSON files.
+type FilePersistence struct {
+	dataDir    string
+	mu         sync.RWMutex
+	snsSources map[string]*models.SNSSource // ID -> SNSSource mapping
+}
+
+// NewFilePersistence creates a new file-based SNS persistence.
+func NewFilePersistence(dataDir string) (*FilePersistence, error) {
+	if err := os.MkdirAll(dataDir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	fp := &FilePersistence{
+		dataDir:    dataDir,
+		snsSources: make(map[string]*models.SNSSource),
+	}
+
+	// Load existing sns sources
+	if err := fp.loadSNSSources(); err != nil {
+		return nil, fmt.Errorf("failed to load sns sources: %w", err)
+	}
+
+	return fp, nil
+}
+
+// SaveSNSSource saves a sns source to the file system.
+func (fp *FilePersistence) SaveSNSSource(source *models.SNSSource) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	fp.snsSources[source.ID] = source
+
+	return fp.saveSNSSourcesToFile()
+}
+
+// SNSSourceByID retrieves a sns source by its ID.
+func (fp *FilePersistence) SNSSourceByID(id string) (*models.SNSSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	source, exists := fp.snsSources[id]
+	if !exists {
+		return nil, nil
+	}
+
+	return source, nil
+}
+
+// SNSSources returns all sns sources.
+func (fp *FilePersistence) SNSSources() ([]*models.SNSSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	sources := make([]*models.SNSSource, 0, len(fp.snsSources))
+	for _, source := range fp.snsSources {
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+// ActiveSNSSources returns only active sns sources.
+func (fp *FilePersistence) ActiveSNSSources() ([]*models.SNSSource, error) {
+	fp.mu.RLock()
+	defer fp.mu.RUnlock()
+
+	var activeSources []*models.SNSSource
+
+	for _, source := range fp.snsSources {
+		if source.Active {
+			activeSources = append(activeSources, source)
+		}
+	}
+
+	return activeSources, nil
+}
+
+// DeleteSNSSource removes a sns source by its ID.
+func (fp *FilePersistence) DeleteSNSSource(id string) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	delete(fp.snsSources, id)
+
+	return fp.saveSNSSourcesToFile()
+}
+
+// HealthCheck verifies that the persistence layer is healthy.
+func (fp *FilePersistence) HealthCheck() error {
+	if _, err := os.Stat(fp.dataDir); os.IsNotExist(err) {
+		return fmt.Errorf("data directory does not exist: %s", fp.dataDir)
+	}
+
+	return nil
+}
+
+// Close cleans up resources.
+func (fp *FilePersistence) Close() error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	return fp.saveSNSSourcesToFile()
+}
+
+// loadSNSSources loads sns sources from the file system.
+func (fp *FilePersistence) loadSNSSources() error {
+	sourcesFile := filepath.Join(fp.dataDir, "sns_sources.json")
+
+	if _, err := os.Stat(sourcesFile); os.IsNotExist(err) {
+		// File doesn't exist, start with empty sources
+		return nil
+	}
+
+	data, err := os.ReadFile(sourcesFile) // #nosec G304 -- sourcesFile is constructed from controlled dataDir
+	if err != nil {
+		return fmt.Errorf("failed to read sns sources file: %w", err)
+	}
+
+	var sources []*models.SNSSource
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return fmt.Errorf("failed to unmarshal sns sources: %w", err)
+	}
+
+	for _, source := range sources {
+		fp.snsSources[source.ID] = source
+	}
+
+	return nil
+}
+
+// saveSNSSourcesToFile saves all sns sources to the file system.
+func (fp *FilePersistence) saveSNSSourcesToFile() error {
+	sourcesFile := filepath.Join(fp.dataDir, "sns_sources.json")
+
+	sources := make([]*models.SNSSource, 0, len(fp.snsSources))
+	for _, source := range fp.snsSources {
+		sources = append(sources, source)
+	}
+
+	data, err := json.MarshalIndent(sources, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sns sources: %w", err)
+	}
+
+	if err := os.WriteFile(sourcesFile, data, 0600); err != nil {
+		return fmt.Errorf("failed to write sns sources file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+// Package persistence provides storage for SNS notification sources.
+package persistence
+
+import (
+	"github.com/dukex/operion/pkg/providers/sns/models"
+)
+
+// SNSPersistence defines the persistence interface for the SNS provider.
+// This interface is specific to SNS source needs and isolated from core persistence.
+type SNSPersistence interface {
+	// SNSSource operations
+	SaveSNSSource(source *models.SNSSource) error
+	SNSSourceByID(id string) (*models.SNSSource, error)
+	SNSSources() ([]*models.SNSSource, error)
+	ActiveSNSSources() ([]*models.SNSSource, error)
+	DeleteSNSSource(id string) error
+
+	// Health and lifecycle
+	HealthCheck() error
+	Close() error
+}
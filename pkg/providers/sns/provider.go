@@ -0,0 +1,304 @@
+// Package sns provides a source provider that receives the notifications of Amazon SNS topics
+// through an HTTP(S) subscription endpoint.
+package sns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	snsModels "github.com/dukex/operion/pkg/providers/sns/models"
+	snsPersistence "github.com/dukex/operion/pkg/providers/sns/persistence"
+)
+
+// defaultPort is the SNS subscription endpoint port used when none is configured.
+const defaultPort = 8087
+
+// SNSProvider implements a centralized SNS subscription endpoint that confirms subscriptions,
+// verifies message signatures and converts notifications to source events.
+type SNSProvider struct {
+	config         map[string]any
+	logger         *slog.Logger
+	callback       protocol.SourceEventCallback
+	server         *SNSServer
+	snsPersistence snsPersistence.SNSPersistence
+	port           int
+	started        bool
+	mu             sync.RWMutex
+}
+
+// Start begins serving SNS messages.
+func (p *SNSProvider) Start(ctx context.Context, callback protocol.SourceEventCallback) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.started {
+		return nil
+	}
+
+	p.callback = callback
+	p.logger.Info("Starting SNS orchestrator", "port", p.port)
+
+	p.server.SetCallback(callback)
+
+	if err := p.server.Start(ctx); err != nil {
+		return err
+	}
+
+	p.started = true
+	p.logger.Info("SNS orchestrator started successfully", "port", p.port)
+
+	return nil
+}
+
+// Stop gracefully shuts down the SNS orchestrator.
+func (p *SNSProvider) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.started {
+		return nil
+	}
+
+	p.logger.Info("Stopping SNS orchestrator")
+
+	if err := p.server.Stop(ctx); err != nil {
+		p.logger.Error("Error stopping SNS server", "error", err)
+
+		return err
+	}
+
+	p.started = false
+	p.logger.Info("SNS orchestrator stopped successfully")
+
+	return nil
+}
+
+// Validate checks if the SNS orchestrator configuration is valid.
+func (p *SNSProvider) Validate() error {
+	if p.server == nil {
+		return errors.New("sns server not initialized")
+	}
+
+	if p.snsPersistence == nil {
+		return errors.New("sns persistence not initialized")
+	}
+
+	return nil
+}
+
+// ProviderLifecycle interface implementation
+
+// Initialize sets up the provider with required dependencies.
+func (p *SNSProvider) Initialize(ctx context.Context, deps protocol.Dependencies) error {
+	p.logger = deps.Logger
+
+	persistenceURL := os.Getenv("SNS_PERSISTENCE_URL")
+	if persistenceURL == "" {
+		return errors.New("sns provider requires SNS_PERSISTENCE_URL environment variable (e.g., file://./data/sns)")
+	}
+
+	persistence, err := p.createPersistence(persistenceURL)
+	if err != nil {
+		return err
+	}
+
+	p.snsPersistence = persistence
+	p.port = p.serverPort()
+	p.server = NewSNSServer(p.port, p.logger)
+
+	p.logger.Info("SNS provider initialized", "port", p.port, "persistence", persistenceURL)
+
+	return nil
+}
+
+// Configure configures the provider based on current workflow definitions.
+func (p *SNSProvider) Configure(workflows []*models.Workflow) (map[string]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.logger.Info("Configuring SNS provider with workflows", "workflow_count", len(workflows))
+
+	triggerToSource := make(map[string]string)
+	configureErr := protocol.NewConfigureError("sns")
+
+	for _, wf := range workflows {
+		if wf.Status != models.WorkflowStatusPublished {
+			continue
+		}
+
+		// Filter trigger nodes with sns provider
+		for _, node := range wf.Nodes {
+			if node.IsTriggerNode() && node.ProviderID != nil && *node.ProviderID == "sns" {
+				sourceID, err := p.processSNSTriggerNode(wf.ID, node)
+				if err != nil {
+					configureErr.Add(wf.ID, node.ID, err)
+
+					continue
+				}
+
+				triggerToSource[node.ID] = sourceID
+			}
+		}
+	}
+
+	p.logger.Info("SNS configuration completed", "configured_sources", len(triggerToSource))
+
+	return triggerToSource, configureErr.ErrOrNil()
+}
+
+// Prepare groups the active sources by topic ARN before starting the provider.
+func (p *SNSProvider) Prepare(ctx context.Context) error {
+	if p.server == nil || p.snsPersistence == nil {
+		return errors.New("sns provider not initialized")
+	}
+
+	sources, err := p.snsPersistence.ActiveSNSSources()
+	if err != nil {
+		return err
+	}
+
+	topics := groupSources(sources)
+	p.server.setTopics(topics)
+
+	p.logger.Info("SNS provider prepared and ready",
+		"sources", len(sources),
+		"topics", len(topics))
+
+	return nil
+}
+
+// groupSources groups sources by topic ARN so each notification is verified once for all of them.
+func groupSources(sources []*snsModels.SNSSource) map[string]*snsTopic {
+	topics := make(map[string]*snsTopic)
+
+	for _, source := range sources {
+		key := source.GroupKey()
+
+		topic, exists := topics[key]
+		if !exists {
+			topic = &snsTopic{arn: source.TopicARN}
+			topics[key] = topic
+		}
+
+		topic.sources = append(topic.sources, source)
+	}
+
+	return topics
+}
+
+// processSNSTriggerNode creates or updates the SNS source of a trigger node.
+// Returns the sourceID if the source was successfully saved, an error otherwise.
+func (p *SNSProvider) processSNSTriggerNode(workflowID string, node *models.WorkflowNode) (string, error) {
+	sourceID := ""
+	if node.SourceID != nil {
+		sourceID = *node.SourceID
+	}
+
+	if sourceID == "" {
+		// Generate a new UUID for the sourceID
+		sourceID = uuid.New().String()
+		p.logger.Info("Generated source_id for sns trigger node",
+			"workflow_id", workflowID,
+			"node_id", node.ID,
+			"generated_source_id", sourceID)
+	}
+
+	existingSource, err := p.snsPersistence.SNSSourceByID(sourceID)
+	if err != nil {
+		p.logger.Error("Failed to check existing sns source",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", fmt.Errorf("failed to check existing sns source: %w", err)
+	}
+
+	source := existingSource
+	if source != nil {
+		err = source.UpdateConfiguration(node.Config)
+	} else {
+		source, err = snsModels.NewSNSSource(sourceID, node.Config)
+	}
+
+	if err != nil {
+		p.logger.Error("Invalid sns source configuration",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", err
+	}
+
+	if err := p.snsPersistence.SaveSNSSource(source); err != nil {
+		p.logger.Error("Failed to save sns source",
+			"source_id", sourceID,
+			"error", err)
+
+		return "", fmt.Errorf("failed to save sns source: %w", err)
+	}
+
+	p.logger.Info("Configured sns source",
+		"source_id", sourceID,
+		"topic_arn", source.TopicARN)
+
+	return sourceID, nil
+}
+
+// serverPort gets the SNS subscription endpoint port from configuration or environment.
+func (p *SNSProvider) serverPort() int {
+	switch port := p.config["port"].(type) {
+	case int:
+		if port > 0 && port <= 65535 {
+			return port
+		}
+	case string:
+		if parsed, err := strconv.Atoi(port); err == nil && parsed > 0 && parsed <= 65535 {
+			return parsed
+		}
+	}
+
+	if portEnv := os.Getenv("SNS_PORT"); portEnv != "" {
+		if port, err := strconv.Atoi(portEnv); err == nil && port > 0 && port <= 65535 {
+			return port
+		}
+	}
+
+	return defaultPort
+}
+
+// createPersistence creates the appropriate persistence implementation based on URL scheme.
+func (p *SNSProvider) createPersistence(persistenceURL string) (snsPersistence.SNSPersistence, error) {
+	scheme := p.parsePersistenceScheme(persistenceURL)
+	p.logger.Info("Initializing sns persistence", "scheme", scheme, "url", persistenceURL)
+
+	switch scheme {
+	case "file":
+		// Extract path from file://path
+		path := strings.TrimPrefix(persistenceURL, "file://")
+
+		return snsPersistence.NewFilePersistence(path)
+	case "postgres", "postgresql":
+		// Future: implement database persistence
+		return nil, errors.New("postgres persistence for sns not yet implemented")
+	default:
+		return nil, errors.New("unsupported persistence scheme: " + scheme + " (supported: file)")
+	}
+}
+
+// parsePersistenceScheme extracts the scheme from a persistence URL.
+func (p *SNSProvider) parsePersistenceScheme(persistenceURL string) string {
+	parts := strings.SplitN(persistenceURL, "://", 2)
+	if len(parts) < 2 {
+		return "unknown"
+	}
+
+	return parts[0]
+}
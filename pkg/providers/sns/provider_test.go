@@ -0,0 +1,58 @@
+package sns
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	snsPersistence "github.com/dukex/operion/pkg/providers/sns/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestProvider(t *testing.T) *SNSProvider {
+	t.Helper()
+
+	persistence, err := snsPersistence.NewFilePersistence(t.TempDir())
+	require.NoError(t, err)
+
+	return &SNSProvider{
+		logger:         createTestLogger(),
+		snsPersistence: persistence,
+		server:         NewSNSServer(0, createTestLogger()),
+	}
+}
+
+func TestSNSProvider_ConfigureAndPrepare(t *testing.T) {
+	provider := createTestProvider(t)
+	snsProvider := "sns"
+	otherProvider := "webhook"
+
+	workflows := []*models.Workflow{
+		{
+			ID:     "wf-1",
+			Status: models.WorkflowStatusPublished,
+			Nodes: []*models.WorkflowNode{
+				{ID: "orders", Category: models.CategoryTypeTrigger, ProviderID: &snsProvider, Config: map[string]any{"topic_arn": testTopicARN}},
+				{ID: "orders-audit", Category: models.CategoryTypeTrigger, ProviderID: &snsProvider, Config: map[string]any{"topic_arn": testTopicARN}},
+				{ID: "payments", Category: models.CategoryTypeTrigger, ProviderID: &snsProvider, Config: map[string]any{"topic_arn": "arn:aws:sns:eu-west-1:123456789012:payments"}},
+				{ID: "invalid", Category: models.CategoryTypeTrigger, ProviderID: &snsProvider, Config: map[string]any{"topic_arn": "orders"}},
+				{ID: "webhook", Category: models.CategoryTypeTrigger, ProviderID: &otherProvider, Config: map[string]any{}},
+			},
+		},
+	}
+
+	triggerToSource, err := provider.Configure(workflows)
+
+	var configureErr *protocol.ConfigureError
+	require.ErrorAs(t, err, &configureErr)
+	require.Len(t, configureErr.Workflows["wf-1"], 1)
+	assert.Equal(t, "invalid", configureErr.Workflows["wf-1"][0].TriggerID)
+
+	assert.Len(t, triggerToSource, 3)
+	assert.NotContains(t, triggerToSource, "webhook")
+
+	require.NoError(t, provider.Prepare(t.Context()))
+	require.Len(t, provider.server.topics, 2, "sources are grouped by topic ARN")
+	assert.Len(t, provider.server.topics[testTopicARN].sources, 2)
+}
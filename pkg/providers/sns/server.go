@@ -0,0 +1,524 @@
+package sns
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1" // #nosec G505 -- SNS SignatureVersion 1 signs messages with SHA1withRSA
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/protocol"
+	"github.com/dukex/operion/pkg/providers/sns/models"
+)
+
+const (
+	// Server configuration constants.
+	snsReadTimeout     = 30 * time.Second
+	snsWriteTimeout    = 30 * time.Second
+	snsIdleTimeout     = 60 * time.Second
+	snsShutdownTimeout = 5 * time.Second
+
+	// snsClientTimeout bounds the requests made to SNS for signing certificates and confirmations.
+	snsClientTimeout = 10 * time.Second
+
+	// maxRequestBytes limits the size of an SNS message; notifications carry at most 256KB.
+	maxRequestBytes = 1024 * 1024 // 1MB
+
+	// maxCertificateBytes limits the size of a downloaded signing certificate.
+	maxCertificateBytes = 64 * 1024
+
+	// maxMessageAge bounds the age of a message, so that a captured message cannot be replayed later.
+	maxMessageAge = time.Hour
+
+	headerMessageType = "X-Amz-Sns-Message-Type"
+
+	messageTypeNotification             = "Notification"
+	messageTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	messageTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+var (
+	errInvalidSignature  = errors.New("invalid sns signature")
+	errUntrustedURL      = errors.New("url is not an https url of an sns endpoint")
+	errUnsupportedSigner = errors.New("unsupported sns signature version")
+	errStaleMessage      = errors.New("sns message timestamp is missing or not recent")
+	errCertificateExpiry = errors.New("sns signing certificate is not valid at this time")
+)
+
+// snsHostPattern matches the hosts SNS serves signing certificates and subscription
+// confirmations from, e.g. sns.us-east-1.amazonaws.com.
+var snsHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// snsMessage is an HTTP(S) delivery from SNS: a notification or a (un)subscription confirmation.
+type snsMessage struct {
+	Type              string                      `json:"Type"`
+	MessageID         string                      `json:"MessageId"`
+	Token             string                      `json:"Token"`
+	TopicArn          string                      `json:"TopicArn"`
+	Subject           string                      `json:"Subject"`
+	Message           string                      `json:"Message"`
+	Timestamp         string                      `json:"Timestamp"`
+	SignatureVersion  string                      `json:"SignatureVersion"`
+	Signature         string                      `json:"Signature"`
+	SigningCertURL    string                      `json:"SigningCertURL"`
+	SubscribeURL      string                      `json:"SubscribeURL"`
+	MessageAttributes map[string]messageAttribute `json:"MessageAttributes"`
+}
+
+// messageAttribute is a message attribute of a notification.
+type messageAttribute struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
+
+// snsTopic is a set of sources fed by the notifications of one SNS topic.
+type snsTopic struct {
+	arn     string
+	sources []*models.SNSSource
+}
+
+// SNSServer receives the messages SNS delivers to an HTTP(S) subscription.
+type SNSServer struct {
+	server       *http.Server
+	port         int
+	topics       map[string]*snsTopic
+	callback     protocol.SourceEventCallback
+	logger       *slog.Logger
+	client       *http.Client
+	certificates map[string]*x509.Certificate
+	now          func() time.Time
+	mu           sync.RWMutex
+	started      bool
+}
+
+// NewSNSServer creates a new SNS subscription endpoint server instance.
+func NewSNSServer(port int, logger *slog.Logger) *SNSServer {
+	return &SNSServer{
+		port:         port,
+		topics:       make(map[string]*snsTopic),
+		logger:       logger.With("module", "sns_server", "port", port),
+		client:       &http.Client{Timeout: snsClientTimeout},
+		certificates: make(map[string]*x509.Certificate),
+		now:          time.Now,
+	}
+}
+
+// SetCallback sets the callback function for publishing source events.
+func (s *SNSServer) SetCallback(callback protocol.SourceEventCallback) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.callback = callback
+}
+
+// setTopics replaces the SNS topics whose messages are accepted.
+func (s *SNSServer) setTopics(topics map[string]*snsTopic) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.topics = topics
+}
+
+// Handler returns the HTTP handler serving the SNS subscription endpoint.
+func (s *SNSServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sns/notifications", s.handleMessage)
+	mux.HandleFunc("/health", s.handleHealth)
+
+	return mux
+}
+
+// Start starts the HTTP server and begins handling SNS messages.
+func (s *SNSServer) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return nil
+	}
+
+	s.server = &http.Server{
+		Addr:         fmt.Sprintf(":%d", s.port),
+		Handler:      s.Handler(),
+		ReadTimeout:  snsReadTimeout,
+		WriteTimeout: snsWriteTimeout,
+		IdleTimeout:  snsIdleTimeout,
+	}
+
+	s.started = true
+	s.logger.Info("Starting SNS server", "addr", s.server.Addr)
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("SNS server error", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully shuts down the SNS server.
+func (s *SNSServer) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		return nil
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, snsShutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	s.started = false
+	s.logger.Info("SNS server stopped successfully")
+
+	return nil
+}
+
+// handleMessage handles a message delivered by SNS: it verifies the signature, confirms
+// subscriptions to configured topics and publishes notifications as source events.
+func (s *SNSServer) handleMessage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeErrorResponse(w, http.StatusMethodNotAllowed, "Only POST method allowed")
+
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusRequestEntityTooLarge, "Request body too large")
+
+		return
+	}
+
+	var message snsMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+
+		return
+	}
+
+	if messageType := r.Header.Get(headerMessageType); messageType != "" && messageType != message.Type {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Message type does not match the message type header")
+
+		return
+	}
+
+	s.mu.RLock()
+	topic, exists := s.topics[message.TopicArn]
+	s.mu.RUnlock()
+
+	if !exists {
+		s.logger.Warn("Rejected message of unknown SNS topic", "topic_arn", message.TopicArn)
+		s.writeErrorResponse(w, http.StatusNotFound, "Unknown SNS topic")
+
+		return
+	}
+
+	if err := s.verify(r.Context(), &message); err != nil {
+		s.logger.Warn("Rejected SNS message", "remote_addr", r.RemoteAddr, "topic_arn", message.TopicArn, "error", err)
+		s.writeErrorResponse(w, http.StatusUnauthorized, "Invalid SNS signature")
+
+		return
+	}
+
+	switch message.Type {
+	case messageTypeSubscriptionConfirmation:
+		s.confirmSubscription(w, r, &message)
+	case messageTypeUnsubscribeConfirmation:
+		s.logger.Info("SNS subscription removed", "topic_arn", message.TopicArn)
+		s.writeJSON(w, http.StatusOK, map[string]any{"status": "ignored"})
+	case messageTypeNotification:
+		s.dispatch(w, r, topic, &message)
+	default:
+		s.writeErrorResponse(w, http.StatusBadRequest, "Unsupported SNS message type")
+	}
+}
+
+// confirmSubscription visits the SubscribeURL of a subscription confirmation, which
+// confirms the subscription of this endpoint to the topic.
+func (s *SNSServer) confirmSubscription(w http.ResponseWriter, r *http.Request, message *snsMessage) {
+	subscribeURL, err := trustedURL(message.SubscribeURL)
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid SubscribeURL")
+
+		return
+	}
+
+	if err := s.get(r.Context(), subscribeURL, io.Discard, 0); err != nil {
+		s.logger.Error("Failed to confirm SNS subscription", "topic_arn", message.TopicArn, "error", err)
+		s.writeErrorResponse(w, http.StatusBadGateway, "Failed to confirm SNS subscription")
+
+		return
+	}
+
+	s.logger.Info("SNS subscription confirmed", "topic_arn", message.TopicArn)
+	s.writeJSON(w, http.StatusOK, map[string]any{"status": "confirmed"})
+}
+
+// dispatch publishes a source event for every source of the topic.
+func (s *SNSServer) dispatch(w http.ResponseWriter, r *http.Request, topic *snsTopic, message *snsMessage) {
+	s.mu.RLock()
+	callback := s.callback
+	s.mu.RUnlock()
+
+	attributes := make(map[string]any, len(message.MessageAttributes))
+	for name, attribute := range message.MessageAttributes {
+		attributes[name] = attribute.Value
+	}
+
+	// Messages published as JSON are parsed so workflows can reach their fields
+	var payload any = message.Message
+
+	var parsed any
+	if err := json.Unmarshal([]byte(message.Message), &parsed); err == nil {
+		payload = parsed
+	}
+
+	eventData := map[string]any{
+		"topic_arn":          message.TopicArn,
+		"message_id":         message.MessageID,
+		"subject":            message.Subject,
+		"message":            payload,
+		"raw_message":        message.Message,
+		"timestamp":          message.Timestamp,
+		"message_attributes": attributes,
+	}
+
+	published := 0
+
+	for _, source := range topic.sources {
+		if callback == nil {
+			continue
+		}
+
+		if err := callback(r.Context(), source.ID, "sns", "notification_received", eventData); err != nil {
+			s.logger.Error("Error publishing source event", "source_id", source.ID, "error", err)
+			s.writeErrorResponse(w, http.StatusInternalServerError, "Error processing SNS notification")
+
+			return
+		}
+
+		published++
+	}
+
+	s.logger.Info("SNS notification processed", "topic_arn", topic.arn, "message_id", message.MessageID, "sources", published)
+	s.writeJSON(w, http.StatusOK, map[string]any{"status": "success"})
+}
+
+// verify checks the signature of a message with the certificate SNS signed it with, and that
+// both the message timestamp and the certificate are current.
+func (s *SNSServer) verify(ctx context.Context, message *snsMessage) error {
+	var (
+		hash   crypto.Hash
+		digest []byte
+	)
+
+	now := s.now()
+
+	timestamp, err := time.Parse(time.RFC3339, message.Timestamp)
+	if err != nil {
+		return errStaleMessage
+	}
+
+	if age := now.Sub(timestamp); age > maxMessageAge || age < -maxMessageAge {
+		return errStaleMessage
+	}
+
+	signed := []byte(stringToSign(message))
+
+	switch message.SignatureVersion {
+	case "1":
+		sum := sha1.Sum(signed) // #nosec G401 -- required by SNS SignatureVersion 1
+		hash, digest = crypto.SHA1, sum[:]
+	case "2":
+		sum := sha256.Sum256(signed)
+		hash, digest = crypto.SHA256, sum[:]
+	default:
+		return errUnsupportedSigner
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(message.Signature)
+	if err != nil {
+		return errInvalidSignature
+	}
+
+	certificate, err := s.certificate(ctx, message.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	if now.Before(certificate.NotBefore) || now.After(certificate.NotAfter) {
+		return errCertificateExpiry
+	}
+
+	publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errInvalidSignature
+	}
+
+	if err := rsa.VerifyPKCS1v15(publicKey, hash, digest, signature); err != nil {
+		return errInvalidSignature
+	}
+
+	return nil
+}
+
+// stringToSign returns the canonical form of a message SNS signs: the name and value of the
+// signed fields of its type, in alphabetical order, each followed by a newline.
+func stringToSign(message *snsMessage) string {
+	var fields [][2]string
+
+	if message.Type == messageTypeNotification {
+		fields = append(fields, [2]string{"Message", message.Message}, [2]string{"MessageId", message.MessageID})
+		if message.Subject != "" {
+			fields = append(fields, [2]string{"Subject", message.Subject})
+		}
+
+		fields = append(fields, [2]string{"Timestamp", message.Timestamp})
+	} else {
+		fields = append(fields,
+			[2]string{"Message", message.Message},
+			[2]string{"MessageId", message.MessageID},
+			[2]string{"SubscribeURL", message.SubscribeURL},
+			[2]string{"Timestamp", message.Timestamp},
+			[2]string{"Token", message.Token},
+		)
+	}
+
+	fields = append(fields, [2]string{"TopicArn", message.TopicArn}, [2]string{"Type", message.Type})
+
+	var builder strings.Builder
+	for _, field := range fields {
+		builder.WriteString(field[0] + "\n" + field[1] + "\n")
+	}
+
+	return builder.String()
+}
+
+// certificate returns the signing certificate at certURL, downloading it on first use.
+func (s *SNSServer) certificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	trusted, err := trustedURL(certURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	certificate, cached := s.certificates[trusted]
+	s.mu.RUnlock()
+
+	if cached {
+		return certificate, nil
+	}
+
+	var data bytes.Buffer
+	if err := s.get(ctx, trusted, &data, maxCertificateBytes); err != nil {
+		return nil, fmt.Errorf("failed to download signing certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(data.Bytes())
+	if block == nil {
+		return nil, errors.New("signing certificate is not PEM encoded")
+	}
+
+	certificate, err = x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing certificate: %w", err)
+	}
+
+	s.mu.Lock()
+	s.certificates[trusted] = certificate
+	s.mu.Unlock()
+
+	return certificate, nil
+}
+
+// get sends a GET request to an SNS endpoint and copies up to limit bytes (all when zero) of a
+// successful response to dst.
+func (s *SNSServer) get(ctx context.Context, target string, dst io.Writer, limit int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var body io.Reader = resp.Body
+	if limit > 0 {
+		body = io.LimitReader(resp.Body, limit)
+	}
+
+	_, err = io.Copy(dst, body)
+
+	return err
+}
+
+// trustedURL returns rawURL when it is an https URL of an SNS endpoint, so a forged message
+// cannot make the server fetch a certificate or visit a URL of its choosing.
+func trustedURL(rawURL string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || !snsHostPattern.MatchString(parsed.Host) {
+		return "", errUntrustedURL
+	}
+
+	return parsed.String(), nil
+}
+
+// handleHealth handles health check requests.
+func (s *SNSServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	topics := len(s.topics)
+	s.mu.RUnlock()
+
+	s.writeJSON(w, http.StatusOK, map[string]any{
+		"status":    "healthy",
+		"topics":    topics,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// writeErrorResponse writes a JSON error response.
+func (s *SNSServer) writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	s.writeJSON(w, statusCode, map[string]any{
+		"status":  "error",
+		"message": message,
+		"code":    statusCode,
+	})
+}
+
+// writeJSON writes a JSON response.
+func (s *SNSServer) writeJSON(w http.ResponseWriter, statusCode int, body map[string]any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.logger.Error("Error encoding response", "error", err)
+	}
+}
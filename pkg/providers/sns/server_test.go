@@ -0,0 +1,342 @@
+package sns
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/providers/sns/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTopicARN   = "arn:aws:sns:us-east-1:123456789012:orders"
+	testCertURL    = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-test.pem"
+	testConfirmURL = "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription&TopicArn=" + testTopicARN + "&Token=token-1"
+)
+
+// testNow is the time the test server receives messages at, shortly after their timestamp.
+var testNow = time.Date(2025, 3, 1, 12, 0, 30, 0, time.UTC)
+
+// publishedEvent is a source event published by the SNS server.
+type publishedEvent struct {
+	sourceID  string
+	eventType string
+	data      map[string]any
+}
+
+// recordedEvents collects the source events published by the server.
+type recordedEvents struct {
+	mu     sync.Mutex
+	events []publishedEvent
+}
+
+func (r *recordedEvents) callback(_ context.Context, sourceID, providerID, eventType string, eventData map[string]any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, publishedEvent{sourceID: sourceID, eventType: eventType, data: eventData})
+
+	return nil
+}
+
+// fakeSNS serves the signing certificate and records the URLs requested from SNS.
+type fakeSNS struct {
+	mu        sync.Mutex
+	key       *rsa.PrivateKey
+	cert      []byte
+	requested []string
+}
+
+func newFakeSNS(t *testing.T) *fakeSNS {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    testNow.Add(-24 * time.Hour),
+		NotAfter:     testNow.Add(24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return &fakeSNS{key: key, cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+func (f *fakeSNS) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	f.requested = append(f.requested, req.URL.String())
+	f.mu.Unlock()
+
+	body := []byte("<ConfirmSubscriptionResponse/>")
+	if req.URL.String() == testCertURL {
+		body = f.cert
+	}
+
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body)), Request: req}, nil
+}
+
+// sign sets the SignatureVersion 2 signature of message made with the fake SNS key.
+func (f *fakeSNS) sign(t *testing.T, message *snsMessage) {
+	t.Helper()
+
+	message.SignatureVersion = "2"
+	message.SigningCertURL = testCertURL
+
+	digest := sha256.Sum256([]byte(stringToSign(message)))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, f.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	message.Signature = base64.StdEncoding.EncodeToString(signature)
+}
+
+func createTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+// createTestServer returns a server for the given sources, grouped by topic ARN, talking to a fake SNS.
+func createTestServer(t *testing.T, configs map[string]map[string]any) (*SNSServer, *recordedEvents, *fakeSNS) {
+	t.Helper()
+
+	sources := make([]*models.SNSSource, 0, len(configs))
+
+	for id, config := range configs {
+		source, err := models.NewSNSSource(id, config)
+		require.NoError(t, err)
+
+		sources = append(sources, source)
+	}
+
+	recorder := &recordedEvents{}
+	fake := newFakeSNS(t)
+
+	server := NewSNSServer(0, createTestLogger())
+	server.client = &http.Client{Transport: fake}
+	server.now = func() time.Time { return testNow }
+	server.setTopics(groupSources(sources))
+	server.SetCallback(recorder.callback)
+
+	return server, recorder, fake
+}
+
+func deliver(t *testing.T, server *SNSServer, message *snsMessage) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := json.Marshal(message)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/sns/notifications", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "text/plain; charset=UTF-8")
+	req.Header.Set(headerMessageType, message.Type)
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+
+	return rec
+}
+
+func notification(message string) *snsMessage {
+	return &snsMessage{
+		Type:      messageTypeNotification,
+		MessageID: "22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324",
+		TopicArn:  testTopicARN,
+		Subject:   "Order placed",
+		Message:   message,
+		Timestamp: "2025-03-01T12:00:00.000Z",
+		MessageAttributes: map[string]messageAttribute{
+			"region": {Type: "String", Value: "eu"},
+		},
+	}
+}
+
+func TestSNSServer_SubscriptionConfirmation(t *testing.T) {
+	server, recorder, fake := createTestServer(t, map[string]map[string]any{
+		"orders": {"topic_arn": testTopicARN},
+	})
+
+	message := &snsMessage{
+		Type:         messageTypeSubscriptionConfirmation,
+		MessageID:    "165545c9-2a5c-472c-8df2-7ff2be2b3b1b",
+		Token:        "token-1",
+		TopicArn:     testTopicARN,
+		Message:      "You have chosen to subscribe to the topic " + testTopicARN,
+		SubscribeURL: testConfirmURL,
+		Timestamp:    "2025-03-01T12:00:00.000Z",
+	}
+	fake.sign(t, message)
+
+	rec := deliver(t, server, message)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	assert.Equal(t, []string{testCertURL, testConfirmURL}, fake.requested, "the certificate is fetched, then the subscription confirmed")
+	assert.Empty(t, recorder.events, "the handshake does not emit events")
+
+	// A confirmation pointing outside SNS is rejected without being visited
+	fake.requested = nil
+	message.SubscribeURL = "https://attacker.example.com/confirm"
+	fake.sign(t, message)
+
+	rec = deliver(t, server, message)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, fake.requested, "the certificate is cached and the untrusted URL is not visited")
+}
+
+func TestSNSServer_SignatureVerification(t *testing.T) {
+	server, recorder, fake := createTestServer(t, map[string]map[string]any{
+		"orders": {"topic_arn": testTopicARN},
+	})
+
+	valid := notification(`{"order_id":42}`)
+	fake.sign(t, valid)
+
+	rec := deliver(t, server, valid)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Len(t, recorder.events, 1)
+
+	recorder.events = nil
+
+	tampered := notification(`{"order_id":42}`)
+	fake.sign(t, tampered)
+	tampered.Message = `{"order_id":43}`
+
+	untrustedCert := notification(`{"order_id":42}`)
+	fake.sign(t, untrustedCert)
+	untrustedCert.SigningCertURL = "https://attacker.example.com/cert.pem"
+
+	unsupportedVersion := notification(`{"order_id":42}`)
+	fake.sign(t, unsupportedVersion)
+	unsupportedVersion.SignatureVersion = "3"
+
+	unsigned := notification(`{"order_id":42}`)
+	unsigned.SignatureVersion = "2"
+	unsigned.SigningCertURL = testCertURL
+
+	// A replayed message keeps its original timestamp, which it cannot change without its signature
+	stale := notification(`{"order_id":42}`)
+	stale.Timestamp = testNow.Add(-2 * time.Hour).Format(time.RFC3339)
+	fake.sign(t, stale)
+
+	future := notification(`{"order_id":42}`)
+	future.Timestamp = testNow.Add(2 * time.Hour).Format(time.RFC3339)
+	fake.sign(t, future)
+
+	noTimestamp := notification(`{"order_id":42}`)
+	noTimestamp.Timestamp = ""
+	fake.sign(t, noTimestamp)
+
+	for name, message := range map[string]*snsMessage{
+		"tampered":            tampered,
+		"untrusted cert":      untrustedCert,
+		"unsupported version": unsupportedVersion,
+		"unsigned":            unsigned,
+		"stale":               stale,
+		"future":              future,
+		"no timestamp":        noTimestamp,
+	} {
+		rec := deliver(t, server, message)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, name)
+	}
+
+	assert.Empty(t, recorder.events)
+
+	unknownTopic := notification(`{"order_id":42}`)
+	unknownTopic.TopicArn = "arn:aws:sns:us-east-1:123456789012:other"
+	fake.sign(t, unknownTopic)
+
+	rec = deliver(t, server, unknownTopic)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestSNSServer_RejectsExpiredCertificates(t *testing.T) {
+	server, recorder, fake := createTestServer(t, map[string]map[string]any{
+		"orders": {"topic_arn": testTopicARN},
+	})
+
+	message := notification(`{"order_id":42}`)
+	fake.sign(t, message)
+
+	// The certificate is cached by the first message, then expires
+	rec := deliver(t, server, message)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	server.now = func() time.Time { return testNow.Add(48 * time.Hour) }
+	message.Timestamp = testNow.Add(48 * time.Hour).Format(time.RFC3339)
+	fake.sign(t, message)
+
+	rec = deliver(t, server, message)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// And a certificate is not valid before its validity period
+	server.now = func() time.Time { return testNow.Add(-48 * time.Hour) }
+	message.Timestamp = testNow.Add(-48 * time.Hour).Format(time.RFC3339)
+	fake.sign(t, message)
+
+	rec = deliver(t, server, message)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Len(t, recorder.events, 1)
+}
+
+func TestSNSServer_Notification_EmitsSourceEvents(t *testing.T) {
+	server, recorder, fake := createTestServer(t, map[string]map[string]any{
+		"orders":       {"topic_arn": testTopicARN},
+		"orders-audit": {"topic_arn": testTopicARN},
+		"other-topic":  {"topic_arn": "arn:aws:sns:us-east-1:123456789012:other"},
+	})
+
+	message := notification(`{"order_id":42,"items":["book"]}`)
+	fake.sign(t, message)
+
+	rec := deliver(t, server, message)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	published := make(map[string]publishedEvent)
+	for _, event := range recorder.events {
+		published[event.sourceID] = event
+	}
+
+	require.Len(t, recorder.events, 2)
+	require.Contains(t, published, "orders")
+	assert.Contains(t, published, "orders-audit")
+
+	event := published["orders"]
+	assert.Equal(t, "notification_received", event.eventType)
+	assert.Equal(t, testTopicARN, event.data["topic_arn"])
+	assert.Equal(t, "Order placed", event.data["subject"])
+	assert.Equal(t, map[string]any{"order_id": float64(42), "items": []any{"book"}}, event.data["message"])
+	assert.JSONEq(t, `{"order_id":42,"items":["book"]}`, event.data["raw_message"].(string))
+	assert.Equal(t, map[string]any{"region": "eu"}, event.data["message_attributes"])
+
+	// Messages that are not JSON are passed as text
+	recorder.events = nil
+	text := notification("order 42 placed")
+	text.Subject = ""
+	fake.sign(t, text)
+
+	rec = deliver(t, server, text)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Len(t, recorder.events, 2)
+	assert.Equal(t, "order 42 placed", recorder.events[0].data["message"])
+}
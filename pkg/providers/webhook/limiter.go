@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// concurrencyLimiter bounds the webhook requests processed at once. Requests beyond the limit
+// wait in a bounded queue for a free slot; once the queue is full, or a queued request waited
+// too long, they are rejected so a traffic spike cannot overload the event bus.
+type concurrencyLimiter struct {
+	slots        chan struct{}
+	queued       atomic.Int64
+	queueSize    int64
+	queueTimeout time.Duration
+}
+
+func newConcurrencyLimiter(maxConcurrent, queueSize int, queueTimeout time.Duration) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		slots:        make(chan struct{}, maxConcurrent),
+		queueSize:    int64(max(queueSize, 0)),
+		queueTimeout: queueTimeout,
+	}
+}
+
+// acquire takes a processing slot, waiting in the queue when all slots are busy. It reports false
+// when the request is rejected; otherwise the caller must release the slot.
+func (l *concurrencyLimiter) acquire(ctx context.Context) bool {
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if l.queued.Add(1) > l.queueSize {
+		l.queued.Add(-1)
+
+		return false
+	}
+	defer l.queued.Add(-1)
+
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees a slot taken by acquire.
+func (l *concurrencyLimiter) release() {
+	<-l.slots
+}
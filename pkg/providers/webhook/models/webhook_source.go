@@ -1,8 +1,14 @@
 package models
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +17,34 @@ import (
 // ErrInvalidWebhookSource is returned when webhook source validation fails.
 var ErrInvalidWebhookSource = errors.New("invalid webhook source")
 
+var (
+	// ErrMissingSigningSecret is returned when signing a URL of a source without a signing secret.
+	ErrMissingSigningSecret = errors.New("webhook source has no signing secret")
+
+	// ErrInvalidSignature is returned when a signed URL is missing its signature or it does not match.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+
+	// ErrSignatureExpired is returned when a signed URL is used after it expired.
+	ErrSignatureExpired = errors.New("webhook signature expired")
+)
+
+const (
+	// DefaultMaxBodyBytes is the request body limit used when a source does not configure one.
+	DefaultMaxBodyBytes int64 = 1024 * 1024 // 1MB
+
+	// DefaultContentType is the only content type accepted when a source does not configure any.
+	DefaultContentType = "application/json"
+
+	// DefaultDeliveryIDTTL is how long delivery IDs are remembered when a source does not configure it.
+	DefaultDeliveryIDTTL = 24 * time.Hour
+
+	// SignatureQueryParam and ExpiresQueryParam are the query parameters of signed webhook URLs.
+	SignatureQueryParam = "signature"
+	ExpiresQueryParam   = "expires"
+
+	signingSecretBytes = 32
+)
+
 // WebhookSource represents a webhook endpoint configuration with external ID-based security mapping.
 // Each webhook source maps an external ID to an internal source ID for security.
 type WebhookSource struct {
@@ -34,6 +68,9 @@ type WebhookSource struct {
 
 	// Active indicates if this webhook source is active and should receive requests
 	Active bool `json:"active"`
+
+	// SigningSecret is the HMAC key of signed webhook URLs; rotating it invalidates every URL signed before
+	SigningSecret string `json:"signing_secret,omitempty"`
 }
 
 // NewWebhookSource creates a new webhook source with the given parameters.
@@ -47,6 +84,11 @@ func NewWebhookSource(sourceID string, configuration map[string]any) (*WebhookSo
 		configuration = make(map[string]any)
 	}
 
+	signingSecret, err := newSigningSecret()
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now().UTC()
 
 	source := &WebhookSource{
@@ -56,6 +98,7 @@ func NewWebhookSource(sourceID string, configuration map[string]any) (*WebhookSo
 		CreatedAt:     now,
 		UpdatedAt:     now,
 		Active:        true,
+		SigningSecret: signingSecret,
 	}
 
 	// Extract optional JSON schema from configuration
@@ -86,11 +129,159 @@ func (ws *WebhookSource) GetWebhookURL() string {
 	return "/webhook/" + ws.ExternalID.String()
 }
 
+// SignedWebhookURL returns the webhook URL path for this source signed with its signing secret,
+// valid until expiresAt.
+func (ws *WebhookSource) SignedWebhookURL(expiresAt time.Time) (string, error) {
+	if ws.SigningSecret == "" {
+		return "", ErrMissingSigningSecret
+	}
+
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set(ExpiresQueryParam, expires)
+	query.Set(SignatureQueryParam, ws.sign(expires))
+
+	return ws.GetWebhookURL() + "?" + query.Encode(), nil
+}
+
+// VerifySignedURL checks the expiry and signature query parameters of a signed webhook URL at now.
+func (ws *WebhookSource) VerifySignedURL(query url.Values, now time.Time) error {
+	expires := query.Get(ExpiresQueryParam)
+	signature := query.Get(SignatureQueryParam)
+
+	if ws.SigningSecret == "" || expires == "" || signature == "" {
+		return ErrInvalidSignature
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(ws.sign(expires))) {
+		return ErrInvalidSignature
+	}
+
+	if !now.Before(time.Unix(expiresAt, 0)) {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+// RequiresSignedURL returns true when the "require_signed_url" configuration only accepts
+// requests to signed webhook URLs.
+func (ws *WebhookSource) RequiresSignedURL() bool {
+	required, _ := ws.Configuration["require_signed_url"].(bool)
+
+	return required
+}
+
+// RotateSigningSecret replaces the signing secret, invalidating every URL signed before.
+func (ws *WebhookSource) RotateSigningSecret() error {
+	signingSecret, err := newSigningSecret()
+	if err != nil {
+		return err
+	}
+
+	ws.SigningSecret = signingSecret
+	ws.UpdatedAt = time.Now().UTC()
+
+	return nil
+}
+
+// sign returns the hex encoded HMAC-SHA256 of the external ID and expiry.
+func (ws *WebhookSource) sign(expires string) string {
+	mac := hmac.New(sha256.New, []byte(ws.SigningSecret))
+	mac.Write([]byte(ws.ExternalID.String() + "." + expires))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newSigningSecret generates a random signing secret.
+func newSigningSecret() (string, error) {
+	secret := make([]byte, signingSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
 // HasJSONSchema returns true if this webhook source has JSON schema validation configured.
 func (ws *WebhookSource) HasJSONSchema() bool {
 	return len(ws.JSONSchema) > 0
 }
 
+// MaxBodyBytes returns the maximum accepted request body size from the
+// "max_body_bytes" configuration, falling back to DefaultMaxBodyBytes.
+func (ws *WebhookSource) MaxBodyBytes() int64 {
+	var maxBytes int64
+
+	switch value := ws.Configuration["max_body_bytes"].(type) {
+	case int:
+		maxBytes = int64(value)
+	case int64:
+		maxBytes = value
+	case float64:
+		maxBytes = int64(value)
+	case json.Number:
+		maxBytes, _ = value.Int64()
+	}
+
+	if maxBytes <= 0 {
+		return DefaultMaxBodyBytes
+	}
+
+	return maxBytes
+}
+
+// AllowedContentTypes returns the media types accepted for request bodies from the
+// "allowed_content_types" configuration, falling back to DefaultContentType.
+func (ws *WebhookSource) AllowedContentTypes() []string {
+	var contentTypes []string
+
+	switch value := ws.Configuration["allowed_content_types"].(type) {
+	case []string:
+		contentTypes = value
+	case []any:
+		for _, item := range value {
+			if contentType, ok := item.(string); ok && contentType != "" {
+				contentTypes = append(contentTypes, contentType)
+			}
+		}
+	}
+
+	if len(contentTypes) == 0 {
+		return []string{DefaultContentType}
+	}
+
+	return contentTypes
+}
+
+// DeliveryIDHeader returns the request header carrying the sender's delivery ID, such as
+// X-GitHub-Delivery, from the "delivery_id_header" configuration. Requests repeating a delivery
+// ID are not triggered again; an empty header disables deduplication.
+func (ws *WebhookSource) DeliveryIDHeader() string {
+	header, _ := ws.Configuration["delivery_id_header"].(string)
+
+	return header
+}
+
+// DeliveryIDTTL returns how long delivery IDs are remembered from the "delivery_id_ttl"
+// configuration, a duration such as "72h", falling back to DefaultDeliveryIDTTL.
+func (ws *WebhookSource) DeliveryIDTTL() time.Duration {
+	value, _ := ws.Configuration["delivery_id_ttl"].(string)
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return DefaultDeliveryIDTTL
+	}
+
+	return ttl
+}
+
 // UpdateConfiguration updates the webhook source configuration and timestamp.
 func (ws *WebhookSource) UpdateConfiguration(config map[string]any) {
 	ws.Configuration = config
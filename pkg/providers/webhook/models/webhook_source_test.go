@@ -461,3 +461,52 @@ func TestWebhookSource_ConfigurationEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhookSource_RequestLimits(t *testing.T) {
+	source, err := NewWebhookSource("source-limits", map[string]any{})
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultMaxBodyBytes, source.MaxBodyBytes())
+	assert.Equal(t, []string{DefaultContentType}, source.AllowedContentTypes())
+
+	// Values decoded from JSON configuration
+	source.UpdateConfiguration(map[string]any{
+		"max_body_bytes":        float64(512),
+		"allowed_content_types": []any{"application/json", "text/plain"},
+	})
+
+	assert.Equal(t, int64(512), source.MaxBodyBytes())
+	assert.Equal(t, []string{"application/json", "text/plain"}, source.AllowedContentTypes())
+
+	// Invalid values fall back to defaults
+	source.UpdateConfiguration(map[string]any{
+		"max_body_bytes":        -1,
+		"allowed_content_types": []any{},
+	})
+
+	assert.Equal(t, DefaultMaxBodyBytes, source.MaxBodyBytes())
+	assert.Equal(t, []string{DefaultContentType}, source.AllowedContentTypes())
+}
+
+func TestWebhookSource_DeliveryID(t *testing.T) {
+	source, err := NewWebhookSource("source-delivery", map[string]any{})
+	require.NoError(t, err)
+
+	assert.Empty(t, source.DeliveryIDHeader())
+	assert.Equal(t, DefaultDeliveryIDTTL, source.DeliveryIDTTL())
+
+	source.UpdateConfiguration(map[string]any{
+		"delivery_id_header": "X-GitHub-Delivery",
+		"delivery_id_ttl":    "72h",
+	})
+
+	assert.Equal(t, "X-GitHub-Delivery", source.DeliveryIDHeader())
+	assert.Equal(t, 72*time.Hour, source.DeliveryIDTTL())
+
+	// Invalid durations fall back to the default
+	source.UpdateConfiguration(map[string]any{"delivery_id_ttl": "soon"})
+	assert.Equal(t, DefaultDeliveryIDTTL, source.DeliveryIDTTL())
+
+	source.UpdateConfiguration(map[string]any{"delivery_id_ttl": "-1h"})
+	assert.Equal(t, DefaultDeliveryIDTTL, source.DeliveryIDTTL())
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/dukex/operion/pkg/providers/webhook/models"
 )
@@ -15,6 +16,20 @@ type FilePersistence struct {
 	dataDir        string
 	mu             sync.RWMutex
 	webhookSources map[string]*models.WebhookSource // ID -> WebhookSource mapping
+	deliveries     map[deliveryKey]time.Time        // Delivery -> expiry mapping
+}
+
+// deliveryKey identifies a delivery ID received by a webhook source.
+type deliveryKey struct {
+	SourceID   string `json:"source_id"`
+	DeliveryID string `json:"delivery_id"`
+}
+
+// deliveryRecord is a recorded delivery ID as stored in the deliveries file.
+type deliveryRecord struct {
+	deliveryKey
+
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // NewFilePersistence creates a new file-based webhook persistence.
@@ -26,6 +41,7 @@ func NewFilePersistence(dataDir string) (*FilePersistence, error) {
 	fp := &FilePersistence{
 		dataDir:        dataDir,
 		webhookSources: make(map[string]*models.WebhookSource),
+		deliveries:     make(map[deliveryKey]time.Time),
 	}
 
 	// Load existing webhook sources
@@ -33,6 +49,10 @@ func NewFilePersistence(dataDir string) (*FilePersistence, error) {
 		return nil, fmt.Errorf("failed to load webhook sources: %w", err)
 	}
 
+	if err := fp.loadDeliveries(); err != nil {
+		return nil, fmt.Errorf("failed to load webhook deliveries: %w", err)
+	}
+
 	return fp, nil
 }
 
@@ -112,6 +132,38 @@ func (fp *FilePersistence) DeleteWebhookSource(id string) error {
 	return fp.saveWebhookSourcesToFile()
 }
 
+// MarkDelivery records a delivery ID of a source until expiresAt. It returns false when the
+// delivery ID was already recorded and has not expired at now. Expired delivery IDs are dropped.
+func (fp *FilePersistence) MarkDelivery(sourceID, deliveryID string, now, expiresAt time.Time) (bool, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	for key, expiry := range fp.deliveries {
+		if !expiry.After(now) {
+			delete(fp.deliveries, key)
+		}
+	}
+
+	key := deliveryKey{SourceID: sourceID, DeliveryID: deliveryID}
+	if _, seen := fp.deliveries[key]; seen {
+		return false, nil
+	}
+
+	fp.deliveries[key] = expiresAt
+
+	return true, fp.saveDeliveriesToFile()
+}
+
+// ForgetDelivery removes a recorded delivery ID, so the delivery is accepted again.
+func (fp *FilePersistence) ForgetDelivery(sourceID, deliveryID string) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	delete(fp.deliveries, deliveryKey{SourceID: sourceID, DeliveryID: deliveryID})
+
+	return fp.saveDeliveriesToFile()
+}
+
 // HealthCheck verifies that the persistence layer is healthy.
 func (fp *FilePersistence) HealthCheck() error {
 	// Check if data directory is accessible
@@ -178,3 +230,49 @@ func (fp *FilePersistence) saveWebhookSourcesToFile() error {
 
 	return nil
 }
+
+// loadDeliveries loads recorded delivery IDs from the file system.
+func (fp *FilePersistence) loadDeliveries() error {
+	deliveriesFile := filepath.Join(fp.dataDir, "webhook_deliveries.json")
+
+	if _, err := os.Stat(deliveriesFile); os.IsNotExist(err) {
+		return nil
+	}
+
+	data, err := os.ReadFile(deliveriesFile) // #nosec G304 -- deliveriesFile is constructed from controlled dataDir
+	if err != nil {
+		return fmt.Errorf("failed to read webhook deliveries file: %w", err)
+	}
+
+	var records []deliveryRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("failed to unmarshal webhook deliveries: %w", err)
+	}
+
+	for _, record := range records {
+		fp.deliveries[record.deliveryKey] = record.ExpiresAt
+	}
+
+	return nil
+}
+
+// saveDeliveriesToFile saves recorded delivery IDs to the file system.
+func (fp *FilePersistence) saveDeliveriesToFile() error {
+	deliveriesFile := filepath.Join(fp.dataDir, "webhook_deliveries.json")
+
+	records := make([]deliveryRecord, 0, len(fp.deliveries))
+	for key, expiresAt := range fp.deliveries {
+		records = append(records, deliveryRecord{deliveryKey: key, ExpiresAt: expiresAt})
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook deliveries: %w", err)
+	}
+
+	if err := os.WriteFile(deliveriesFile, data, 0600); err != nil {
+		return fmt.Errorf("failed to write webhook deliveries file: %w", err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/dukex/operion/pkg/providers/webhook/models"
 	"github.com/stretchr/testify/assert"
@@ -198,3 +199,53 @@ func TestFilePersistence_HealthCheck(t *testing.T) {
 	err = fp.HealthCheck()
 	assert.Error(t, err)
 }
+
+func TestFilePersistence_MarkDelivery(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	fp, err := NewFilePersistence(tmpDir)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	isNew, err := fp.MarkDelivery("source-1", "delivery-1", now, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, isNew)
+
+	// Same delivery of the same source is a duplicate, other sources are independent
+	isNew, err = fp.MarkDelivery("source-1", "delivery-1", now, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, isNew)
+
+	isNew, err = fp.MarkDelivery("source-2", "delivery-1", now, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, isNew)
+
+	// Deliveries survive a restart
+	require.NoError(t, fp.Close())
+
+	fp, err = NewFilePersistence(tmpDir)
+	require.NoError(t, err)
+
+	defer func() {
+		_ = fp.Close()
+	}()
+
+	isNew, err = fp.MarkDelivery("source-1", "delivery-1", now, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, isNew)
+
+	// Expired deliveries are accepted again
+	later := now.Add(2 * time.Hour)
+
+	isNew, err = fp.MarkDelivery("source-1", "delivery-1", later, later.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, isNew)
+
+	// Forgotten deliveries are accepted again
+	require.NoError(t, fp.ForgetDelivery("source-2", "delivery-1"))
+
+	isNew, err = fp.MarkDelivery("source-2", "delivery-1", later, later.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, isNew)
+}
@@ -1,6 +1,8 @@
 package persistence
 
 import (
+	"time"
+
 	"github.com/dukex/operion/pkg/providers/webhook/models"
 )
 
@@ -15,6 +17,11 @@ type WebhookPersistence interface {
 	ActiveWebhookSources() ([]*models.WebhookSource, error)
 	DeleteWebhookSource(id string) error
 
+	// Delivery deduplication: MarkDelivery records a delivery ID of a source until expiresAt and
+	// reports false when it was already recorded and has not expired at now
+	MarkDelivery(sourceID, deliveryID string, now, expiresAt time.Time) (bool, error)
+	ForgetDelivery(sourceID, deliveryID string) error
+
 	// Health and lifecycle
 	HealthCheck() error
 	Close() error
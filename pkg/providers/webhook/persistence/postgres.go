@@ -34,8 +34,8 @@ func NewPostgresPersistence(ctx context.Context, logger *slog.Logger, databaseUR
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// Initialize migration manager with version 4 migrations
-	migrationManager := sqlbase.NewMigrationManager(logger, database, webhookMigrations())
+	// Webhook migrations are versioned on their own, apart from the core schema
+	migrationManager := sqlbase.NewComponentMigrationManager(logger, database, webhookMigrationComponent, webhookMigrations())
 
 	postgres := &PostgresPersistence{
 		db:     database,
@@ -60,15 +60,16 @@ func (p *PostgresPersistence) SaveWebhookSource(source *webhookModels.WebhookSou
 	query := `
 		INSERT INTO webhook_sources (
 			id, external_id, json_schema, configuration, 
-			created_at, updated_at, active
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			created_at, updated_at, active, signing_secret
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		ON CONFLICT (id) 
 		DO UPDATE SET
 			external_id = EXCLUDED.external_id,
 			json_schema = EXCLUDED.json_schema,
 			configuration = EXCLUDED.configuration,
 			updated_at = EXCLUDED.updated_at,
-			active = EXCLUDED.active
+			active = EXCLUDED.active,
+			signing_secret = EXCLUDED.signing_secret
 	`
 
 	// Handle optional JSON schema
@@ -108,6 +109,7 @@ func (p *PostgresPersistence) SaveWebhookSource(source *webhookModels.WebhookSou
 		source.CreatedAt,
 		source.UpdatedAt,
 		source.Active,
+		source.SigningSecret,
 	)
 	if err != nil {
 		p.logger.ErrorContext(ctx, "Failed to save webhook source", "source_id", source.ID, "error", err)
@@ -125,7 +127,7 @@ func (p *PostgresPersistence) WebhookSourceByID(id string) (*webhookModels.Webho
 	ctx := context.Background()
 
 	query := `
-		SELECT id, external_id, json_schema, configuration, created_at, updated_at, active
+		SELECT id, external_id, json_schema, configuration, created_at, updated_at, active, signing_secret
 		FROM webhook_sources 
 		WHERE id = $1
 	`
@@ -147,6 +149,7 @@ func (p *PostgresPersistence) WebhookSourceByID(id string) (*webhookModels.Webho
 		&source.CreatedAt,
 		&source.UpdatedAt,
 		&source.Active,
+		&source.SigningSecret,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -188,7 +191,7 @@ func (p *PostgresPersistence) WebhookSourceByExternalID(externalID string) (*web
 	}
 
 	query := `
-		SELECT id, external_id, json_schema, configuration, created_at, updated_at, active
+		SELECT id, external_id, json_schema, configuration, created_at, updated_at, active, signing_secret
 		FROM webhook_sources 
 		WHERE external_id = $1
 	`
@@ -210,6 +213,7 @@ func (p *PostgresPersistence) WebhookSourceByExternalID(externalID string) (*web
 		&source.CreatedAt,
 		&source.UpdatedAt,
 		&source.Active,
+		&source.SigningSecret,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -242,7 +246,7 @@ func (p *PostgresPersistence) WebhookSources() ([]*webhookModels.WebhookSource,
 	ctx := context.Background()
 
 	query := `
-		SELECT id, external_id, json_schema, configuration, created_at, updated_at, active
+		SELECT id, external_id, json_schema, configuration, created_at, updated_at, active, signing_secret
 		FROM webhook_sources 
 		ORDER BY created_at ASC
 	`
@@ -275,7 +279,7 @@ func (p *PostgresPersistence) ActiveWebhookSources() ([]*webhookModels.WebhookSo
 	ctx := context.Background()
 
 	query := `
-		SELECT id, external_id, json_schema, configuration, created_at, updated_at, active
+		SELECT id, external_id, json_schema, configuration, created_at, updated_at, active, signing_secret
 		FROM webhook_sources 
 		WHERE active = true
 		ORDER BY created_at ASC
@@ -327,6 +331,53 @@ func (p *PostgresPersistence) DeleteWebhookSource(id string) error {
 	return nil
 }
 
+// MarkDelivery records a delivery ID of a source until expiresAt. It returns false when the
+// delivery ID was already recorded and has not expired at now. Expired delivery IDs are dropped.
+func (p *PostgresPersistence) MarkDelivery(sourceID, deliveryID string, now, expiresAt time.Time) (bool, error) {
+	ctx := context.Background()
+
+	_, err := p.db.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE expires_at <= $1", now)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "Failed to delete expired webhook deliveries", "error", err)
+
+		return false, fmt.Errorf("failed to delete expired webhook deliveries: %w", err)
+	}
+
+	// The insert only takes effect when the delivery ID is not recorded yet
+	result, err := p.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (source_id, delivery_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (source_id, delivery_id) DO NOTHING
+	`, sourceID, deliveryID, expiresAt, now)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "Failed to record webhook delivery", "source_id", sourceID, "error", err)
+
+		return false, fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return inserted > 0, nil
+}
+
+// ForgetDelivery removes a recorded delivery ID, so the delivery is accepted again.
+func (p *PostgresPersistence) ForgetDelivery(sourceID, deliveryID string) error {
+	ctx := context.Background()
+
+	_, err := p.db.ExecContext(ctx,
+		"DELETE FROM webhook_deliveries WHERE source_id = $1 AND delivery_id = $2", sourceID, deliveryID)
+	if err != nil {
+		p.logger.ErrorContext(ctx, "Failed to forget webhook delivery", "source_id", sourceID, "error", err)
+
+		return fmt.Errorf("failed to forget webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
 // HealthCheck verifies the database connection is healthy.
 func (p *PostgresPersistence) HealthCheck() error {
 	ctx := context.Background()
@@ -391,6 +442,7 @@ func (p *PostgresPersistence) scanWebhookSourceRows(ctx context.Context, rows *s
 			&source.CreatedAt,
 			&source.UpdatedAt,
 			&source.Active,
+			&source.SigningSecret,
 		)
 		if err != nil {
 			p.logger.ErrorContext(ctx, "Failed to scan webhook source row", "error", err)
@@ -419,12 +471,17 @@ func (p *PostgresPersistence) scanWebhookSourceRows(ctx context.Context, rows *s
 	return sources, nil
 }
 
+// webhookMigrationComponent is the component the webhook migration versions are tracked under.
+const webhookMigrationComponent = "webhook"
+
 // webhookMigrations returns the migration scripts for Webhook-specific tables.
+// Databases set up before webhook migrations were tracked on their own already hold these
+// tables, so the first migration only creates what does not exist yet.
 func webhookMigrations() map[int]string {
 	return map[int]string{
-		4: `
+		1: `
 			-- Create webhook_sources table for Webhook provider persistence
-			CREATE TABLE webhook_sources (
+			CREATE TABLE IF NOT EXISTS webhook_sources (
 				id VARCHAR(255) PRIMARY KEY,
 				external_id UUID NOT NULL UNIQUE,
 				json_schema JSONB,
@@ -435,13 +492,29 @@ func webhookMigrations() map[int]string {
 			);
 
 			-- Create indexes for better query performance
-			CREATE INDEX idx_webhook_sources_external_id ON webhook_sources(external_id);
-			CREATE INDEX idx_webhook_sources_active ON webhook_sources(active);
-			CREATE INDEX idx_webhook_sources_created_at ON webhook_sources(created_at);
-			CREATE INDEX idx_webhook_sources_updated_at ON webhook_sources(updated_at);
+			CREATE INDEX IF NOT EXISTS idx_webhook_sources_external_id ON webhook_sources(external_id);
+			CREATE INDEX IF NOT EXISTS idx_webhook_sources_active ON webhook_sources(active);
+			CREATE INDEX IF NOT EXISTS idx_webhook_sources_created_at ON webhook_sources(created_at);
+			CREATE INDEX IF NOT EXISTS idx_webhook_sources_updated_at ON webhook_sources(updated_at);
 			
 			-- Unique index for external ID lookups (critical for webhook URL resolution)
-			CREATE UNIQUE INDEX idx_webhook_sources_external_id_unique ON webhook_sources(external_id);
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_sources_external_id_unique ON webhook_sources(external_id);
+		`,
+		2: `
+			-- Create webhook_deliveries table remembering delivery IDs for deduplication
+			CREATE TABLE webhook_deliveries (
+				source_id VARCHAR(255) NOT NULL,
+				delivery_id VARCHAR(255) NOT NULL,
+				expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				PRIMARY KEY (source_id, delivery_id)
+			);
+
+			CREATE INDEX idx_webhook_deliveries_expires_at ON webhook_deliveries(expires_at);
+		`,
+		3: `
+			-- Add the signing secret of signed webhook URLs, sources created before get one when first signed
+			ALTER TABLE webhook_sources ADD COLUMN signing_secret VARCHAR(255) NOT NULL DEFAULT '';
 		`,
 	}
 }
@@ -16,10 +16,10 @@ import (
 func TestWebhookMigrations(t *testing.T) {
 	migrations := webhookMigrations()
 
-	// Test that migration version 4 exists
-	migration, exists := migrations[4]
-	assert.True(t, exists, "Migration version 4 should exist")
-	assert.Contains(t, migration, "CREATE TABLE webhook_sources", "Should create webhook_sources table")
+	// Test that migration version 1 exists
+	migration, exists := migrations[1]
+	assert.True(t, exists, "Migration version 1 should exist")
+	assert.Contains(t, migration, "CREATE TABLE IF NOT EXISTS webhook_sources", "Should create webhook_sources table")
 	assert.Contains(t, migration, "idx_webhook_sources_external_id_unique", "Should create unique external ID index")
 }
 
@@ -49,7 +49,7 @@ func TestScanWebhookSourceRows_EmptyRows(t *testing.T) {
 
 func TestWebhookMigrationContent(t *testing.T) {
 	migrations := webhookMigrations()
-	migration := migrations[4]
+	migration := migrations[1]
 
 	// Verify all required indexes are present
 	requiredIndexes := []string{
@@ -81,7 +81,7 @@ func TestWebhookMigrationContent(t *testing.T) {
 
 	// Verify unique constraint for external ID
 	assert.Contains(t, migration, "external_id UUID NOT NULL UNIQUE", "Should have unique constraint on external_id")
-	assert.Contains(t, migration, "CREATE UNIQUE INDEX idx_webhook_sources_external_id_unique", "Should have unique index for external ID")
+	assert.Contains(t, migration, "CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_sources_external_id_unique", "Should have unique index for external ID")
 }
 
 func TestPostgresPersistence_MethodSignatures(t *testing.T) {
@@ -134,7 +134,7 @@ func TestWebhookMigrationVersioning(t *testing.T) {
 	migrations := webhookMigrations()
 
 	// Test that we have exactly the expected migrations
-	expectedVersions := []int{4}
+	expectedVersions := []int{1, 2, 3}
 
 	assert.Len(t, migrations, len(expectedVersions), "Should have expected number of migrations")
 
@@ -146,8 +146,11 @@ func TestWebhookMigrationVersioning(t *testing.T) {
 	// Test that the migration content is not empty
 	for version, content := range migrations {
 		assert.NotEmpty(t, content, "Migration %d should have content", version)
-		assert.Contains(t, content, "CREATE TABLE", "Migration %d should create tables", version)
 	}
+
+	assert.Contains(t, migrations[1], "CREATE TABLE", "Migration 1 should create tables")
+	assert.Contains(t, migrations[2], "CREATE TABLE", "Migration 2 should create tables")
+	assert.Contains(t, migrations[3], "ADD COLUMN signing_secret", "Migration 3 should add the signing secret")
 }
 
 func TestWebhookPersistence_UUIDValidation(t *testing.T) {
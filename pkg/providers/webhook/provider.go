@@ -3,11 +3,13 @@ package webhook
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -17,6 +19,9 @@ import (
 	webhookPersistence "github.com/dukex/operion/pkg/providers/webhook/persistence"
 )
 
+// ErrWebhookSourceNotFound is returned when no webhook source has the given source ID.
+var ErrWebhookSourceNotFound = errors.New("webhook source not found")
+
 // WebhookProvider implements a centralized webhook orchestrator that manages
 // HTTP webhook endpoints and converts incoming requests to source events.
 type WebhookProvider struct {
@@ -129,8 +134,13 @@ func (w *WebhookProvider) Initialize(ctx context.Context, deps protocol.Dependen
 
 	// Initialize webhook server
 	w.server = NewWebhookServer(w.port, w.logger)
+	w.server.SetMetrics(deps.Metrics)
 	w.server.SetPersistence(w.webhookPersistence)
 
+	if err := w.configureConcurrencyLimit(); err != nil {
+		return err
+	}
+
 	w.logger.Info("Webhook provider initialized", "port", w.port, "persistence", persistenceURL)
 
 	return nil
@@ -144,6 +154,7 @@ func (w *WebhookProvider) Configure(workflows []*models.Workflow) (map[string]st
 	w.logger.Info("Configuring webhook provider with workflows", "workflow_count", len(workflows))
 
 	triggerToSource := make(map[string]string)
+	configureErr := protocol.NewConfigureError("webhook")
 	sourceCount := 0
 
 	for _, wf := range workflows {
@@ -154,10 +165,15 @@ func (w *WebhookProvider) Configure(workflows []*models.Workflow) (map[string]st
 		// Filter trigger nodes with webhook provider
 		for _, node := range wf.Nodes {
 			if node.IsTriggerNode() && node.ProviderID != nil && *node.ProviderID == "webhook" {
-				if sourceID := w.processWebhookTriggerNode(wf.ID, node); sourceID != "" {
-					triggerToSource[node.ID] = sourceID
-					sourceCount++
+				sourceID, err := w.processWebhookTriggerNode(wf.ID, node)
+				if err != nil {
+					configureErr.Add(wf.ID, node.ID, err)
+
+					continue
 				}
+
+				triggerToSource[node.ID] = sourceID
+				sourceCount++
 			}
 		}
 	}
@@ -173,7 +189,7 @@ func (w *WebhookProvider) Configure(workflows []*models.Workflow) (map[string]st
 			"total_sources", len(totalSources))
 	}
 
-	return triggerToSource, nil
+	return triggerToSource, configureErr.ErrOrNil()
 }
 
 // Prepare performs final preparation before starting the provider.
@@ -206,8 +222,8 @@ func (w *WebhookProvider) Prepare(ctx context.Context) error {
 }
 
 // processWebhookTriggerNode handles the creation of a webhook source for a trigger node with webhook type.
-// Returns the sourceID if a source was successfully created, empty string otherwise.
-func (w *WebhookProvider) processWebhookTriggerNode(workflowID string, node *models.WorkflowNode) string {
+// Returns the sourceID if a source was successfully created, an error otherwise.
+func (w *WebhookProvider) processWebhookTriggerNode(workflowID string, node *models.WorkflowNode) (string, error) {
 	sourceID := ""
 	if node.SourceID != nil {
 		sourceID = *node.SourceID
@@ -229,7 +245,7 @@ func (w *WebhookProvider) processWebhookTriggerNode(workflowID string, node *mod
 			"source_id", sourceID,
 			"error", err)
 
-		return ""
+		return "", fmt.Errorf("failed to check existing webhook source: %w", err)
 	}
 
 	if existingSource != nil {
@@ -244,7 +260,7 @@ func (w *WebhookProvider) processWebhookTriggerNode(workflowID string, node *mod
 				"error", err)
 		}
 
-		return sourceID // Return existing sourceID
+		return sourceID, nil // Return existing sourceID
 	}
 
 	// Create new webhook source
@@ -254,7 +270,7 @@ func (w *WebhookProvider) processWebhookTriggerNode(workflowID string, node *mod
 			"source_id", sourceID,
 			"error", err)
 
-		return ""
+		return "", fmt.Errorf("invalid webhook configuration: %w", err)
 	}
 
 	// Save source to persistence
@@ -263,7 +279,7 @@ func (w *WebhookProvider) processWebhookTriggerNode(workflowID string, node *mod
 			"source_id", sourceID,
 			"error", err)
 
-		return ""
+		return "", fmt.Errorf("failed to save webhook source: %w", err)
 	}
 
 	w.logger.Info("Created webhook source",
@@ -271,7 +287,49 @@ func (w *WebhookProvider) processWebhookTriggerNode(workflowID string, node *mod
 		"external_id", source.ExternalID.String(),
 		"webhook_url", source.GetWebhookURL())
 
-	return sourceID
+	return sourceID, nil
+}
+
+// configureConcurrencyLimit bounds the requests the webhook server processes at once from
+// WEBHOOK_MAX_CONCURRENCY, with WEBHOOK_QUEUE_SIZE requests (default 0) waiting up to
+// WEBHOOK_QUEUE_TIMEOUT (default 5s) for a slot. Without WEBHOOK_MAX_CONCURRENCY requests are
+// not limited.
+func (w *WebhookProvider) configureConcurrencyLimit() error {
+	rawMax := os.Getenv("WEBHOOK_MAX_CONCURRENCY")
+	if rawMax == "" {
+		return nil
+	}
+
+	maxConcurrent, err := strconv.Atoi(rawMax)
+	if err != nil || maxConcurrent <= 0 {
+		return fmt.Errorf("invalid WEBHOOK_MAX_CONCURRENCY '%s': must be a positive integer", rawMax)
+	}
+
+	queueSize := 0
+
+	if rawQueueSize := os.Getenv("WEBHOOK_QUEUE_SIZE"); rawQueueSize != "" {
+		queueSize, err = strconv.Atoi(rawQueueSize)
+		if err != nil || queueSize < 0 {
+			return fmt.Errorf("invalid WEBHOOK_QUEUE_SIZE '%s': must be a non-negative integer", rawQueueSize)
+		}
+	}
+
+	queueTimeout := DefaultQueueTimeout
+
+	if rawQueueTimeout := os.Getenv("WEBHOOK_QUEUE_TIMEOUT"); rawQueueTimeout != "" {
+		queueTimeout, err = time.ParseDuration(rawQueueTimeout)
+		if err != nil || queueTimeout <= 0 {
+			return fmt.Errorf("invalid WEBHOOK_QUEUE_TIMEOUT '%s': must be a positive duration such as '5s'", rawQueueTimeout)
+		}
+	}
+
+	w.server.SetConcurrencyLimit(maxConcurrent, queueSize, queueTimeout)
+	w.logger.Info("Webhook concurrency limit enabled",
+		"max_concurrency", maxConcurrent,
+		"queue_size", queueSize,
+		"queue_timeout", queueTimeout)
+
+	return nil
 }
 
 // getWebhookPort gets the webhook server port from configuration or environment.
@@ -331,6 +389,60 @@ func (w *WebhookProvider) GetWebhookURL(sourceID string) string {
 	return source.GetWebhookURL()
 }
 
+// GetSignedWebhookURL returns the webhook URL for a given source ID signed to expire after ttl.
+// Sources created before URLs were signed get a signing secret first.
+func (w *WebhookProvider) GetSignedWebhookURL(sourceID string, ttl time.Duration) (string, error) {
+	source, err := w.webhookPersistence.WebhookSourceByID(sourceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get webhook source: %w", err)
+	}
+
+	if source == nil {
+		return "", ErrWebhookSourceNotFound
+	}
+
+	if source.SigningSecret == "" {
+		if err := w.saveRotatedSigningSecret(source); err != nil {
+			return "", err
+		}
+	}
+
+	return source.SignedWebhookURL(time.Now().Add(ttl))
+}
+
+// RotateSigningSecret replaces the signing secret of a source, invalidating every URL signed before.
+func (w *WebhookProvider) RotateSigningSecret(sourceID string) error {
+	source, err := w.webhookPersistence.WebhookSourceByID(sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook source: %w", err)
+	}
+
+	if source == nil {
+		return ErrWebhookSourceNotFound
+	}
+
+	if err := w.saveRotatedSigningSecret(source); err != nil {
+		return err
+	}
+
+	w.logger.Info("Rotated webhook signing secret", "source_id", sourceID)
+
+	return nil
+}
+
+// saveRotatedSigningSecret gives source a new signing secret and saves it.
+func (w *WebhookProvider) saveRotatedSigningSecret(source *webhookModels.WebhookSource) error {
+	if err := source.RotateSigningSecret(); err != nil {
+		return fmt.Errorf("failed to generate signing secret: %w", err)
+	}
+
+	if err := w.webhookPersistence.SaveWebhookSource(source); err != nil {
+		return fmt.Errorf("failed to save webhook source: %w", err)
+	}
+
+	return nil
+}
+
 // createPersistence creates the appropriate persistence implementation based on URL scheme.
 func (w *WebhookProvider) createPersistence(ctx context.Context, persistenceURL string) (webhookPersistence.WebhookPersistence, error) {
 	scheme := w.parsePersistenceScheme(persistenceURL)
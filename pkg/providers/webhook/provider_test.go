@@ -3,7 +3,9 @@ package webhook
 import (
 	"context"
 	"log/slog"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -161,6 +163,53 @@ func TestWebhookProvider_Initialize(t *testing.T) {
 	}
 }
 
+func TestWebhookProvider_Initialize_ConcurrencyLimit(t *testing.T) {
+	testCases := []struct {
+		name    string
+		envVars map[string]string
+		limited bool
+		invalid bool
+	}{
+		{name: "unlimited by default", envVars: map[string]string{}},
+		{name: "limit with queue", envVars: map[string]string{"WEBHOOK_MAX_CONCURRENCY": "50", "WEBHOOK_QUEUE_SIZE": "100", "WEBHOOK_QUEUE_TIMEOUT": "2s"}, limited: true},
+		{name: "invalid limit", envVars: map[string]string{"WEBHOOK_MAX_CONCURRENCY": "0"}, invalid: true},
+		{name: "invalid queue size", envVars: map[string]string{"WEBHOOK_MAX_CONCURRENCY": "50", "WEBHOOK_QUEUE_SIZE": "-1"}, invalid: true},
+		{name: "invalid queue timeout", envVars: map[string]string{"WEBHOOK_MAX_CONCURRENCY": "50", "WEBHOOK_QUEUE_TIMEOUT": "soon"}, invalid: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("WEBHOOK_PERSISTENCE_URL", "file://"+t.TempDir()+"/webhook_test")
+
+			for key, value := range tc.envVars {
+				t.Setenv(key, value)
+			}
+
+			provider := &WebhookProvider{config: map[string]any{}}
+
+			err := provider.Initialize(context.Background(), protocol.Dependencies{Logger: createTestLogger()})
+			if tc.invalid {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			if !tc.limited {
+				assert.Nil(t, provider.server.limiter)
+
+				return
+			}
+
+			require.NotNil(t, provider.server.limiter)
+			assert.Equal(t, 50, cap(provider.server.limiter.slots))
+			assert.Equal(t, int64(100), provider.server.limiter.queueSize)
+			assert.Equal(t, 2*time.Second, provider.server.limiter.queueTimeout)
+		})
+	}
+}
+
 // Configure Tests
 
 func TestWebhookProvider_Configure(t *testing.T) {
@@ -499,6 +548,51 @@ func TestWebhookProvider_GetWebhookURL(t *testing.T) {
 	assert.Empty(t, url)
 }
 
+func TestWebhookProvider_SignedWebhookURLRotation(t *testing.T) {
+	t.Setenv("WEBHOOK_PERSISTENCE_URL", "file://"+t.TempDir()+"/webhook_signed_url_test")
+
+	provider := &WebhookProvider{
+		logger: createTestLogger(),
+	}
+
+	err := provider.Initialize(context.Background(), protocol.Dependencies{Logger: createTestLogger()})
+	require.NoError(t, err)
+
+	// Sources saved before URLs were signed have no signing secret
+	source, err := webhookModels.NewWebhookSource("source-123", map[string]any{})
+	require.NoError(t, err)
+
+	source.SigningSecret = ""
+	require.NoError(t, provider.webhookPersistence.SaveWebhookSource(source))
+
+	signedURL, err := provider.GetSignedWebhookURL("source-123", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signedURL, "/webhook/"+source.ExternalID.String()+"?"))
+
+	parsed, err := url.Parse(signedURL)
+	require.NoError(t, err)
+
+	stored, err := provider.webhookPersistence.WebhookSourceByID("source-123")
+	require.NoError(t, err)
+	assert.NotEmpty(t, stored.SigningSecret, "the signing secret is persisted")
+	require.NoError(t, stored.VerifySignedURL(parsed.Query(), time.Now()))
+
+	previousSecret := stored.SigningSecret
+
+	// Rotation persists a new secret invalidating the URL
+	require.NoError(t, provider.RotateSigningSecret("source-123"))
+
+	rotated, err := provider.webhookPersistence.WebhookSourceByID("source-123")
+	require.NoError(t, err)
+	assert.NotEqual(t, previousSecret, rotated.SigningSecret)
+	assert.ErrorIs(t, rotated.VerifySignedURL(parsed.Query(), time.Now()), webhookModels.ErrInvalidSignature)
+
+	// Unknown sources
+	_, err = provider.GetSignedWebhookURL("non-existing", time.Hour)
+	assert.ErrorIs(t, err, ErrWebhookSourceNotFound)
+	assert.ErrorIs(t, provider.RotateSigningSecret("non-existing"), ErrWebhookSourceNotFound)
+}
+
 func TestWebhookProvider_GetRegisteredSources(t *testing.T) {
 	// Set up persistence for test
 	t.Setenv("WEBHOOK_PERSISTENCE_URL", "file://"+t.TempDir()+"/webhook_sources_test")
@@ -2,17 +2,25 @@ package webhook
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
+	"github.com/dukex/operion/pkg/events"
 	"github.com/dukex/operion/pkg/protocol"
 	"github.com/dukex/operion/pkg/providers/webhook/models"
+	"github.com/dukex/operion/pkg/tracer"
+	"github.com/google/uuid"
 	"github.com/xeipuuv/gojsonschema"
 )
 
@@ -22,7 +30,13 @@ const (
 	webhookWriteTimeout    = 30 * time.Second
 	webhookIdleTimeout     = 60 * time.Second
 	webhookShutdownTimeout = 5 * time.Second
-	maxRequestBodySize     = 1024 * 1024 // 1MB max request body
+
+	// DefaultQueueTimeout is how long a request waits for a processing slot when the server is
+	// at its concurrency limit.
+	DefaultQueueTimeout = 5 * time.Second
+
+	// overloadRetryAfterSeconds is the Retry-After sent with requests rejected under overload.
+	overloadRetryAfterSeconds = "1"
 )
 
 // WebhookServer manages the HTTP server for webhook requests.
@@ -31,17 +45,22 @@ type WebhookServer struct {
 	port        int
 	persistence WebhookPersistence // Interface for webhook persistence
 	callback    protocol.SourceEventCallback
+	metrics     *tracer.SourceMetrics
+	limiter     *concurrencyLimiter
 	logger      *slog.Logger
 	mu          sync.RWMutex
 	started     bool
 	done        chan struct{}
 	doneOnce    sync.Once
+	now         func() time.Time
 }
 
 // WebhookPersistence defines minimal interface needed by server for webhook operations.
 type WebhookPersistence interface {
 	WebhookSourceByExternalID(externalID string) (*models.WebhookSource, error)
 	WebhookSources() ([]*models.WebhookSource, error)
+	MarkDelivery(sourceID, deliveryID string, now, expiresAt time.Time) (bool, error)
+	ForgetDelivery(sourceID, deliveryID string) error
 }
 
 // NewWebhookServer creates a new webhook server instance.
@@ -50,6 +69,7 @@ func NewWebhookServer(port int, logger *slog.Logger) *WebhookServer {
 		port:   port,
 		logger: logger.With("module", "webhook_server", "port", port),
 		done:   make(chan struct{}),
+		now:    time.Now,
 	}
 }
 
@@ -58,6 +78,24 @@ func (s *WebhookServer) SetPersistence(persistence WebhookPersistence) {
 	s.persistence = persistence
 }
 
+// SetMetrics sets where the requests dropped for failing validation are recorded.
+func (s *WebhookServer) SetMetrics(metrics *tracer.SourceMetrics) {
+	s.metrics = metrics
+}
+
+// SetConcurrencyLimit bounds the webhook requests processed at once to maxConcurrent, with up to
+// queueSize more waiting up to queueTimeout for a slot. Requests beyond that are rejected with
+// 429 Too Many Requests. A maxConcurrent of zero or less removes the limit.
+func (s *WebhookServer) SetConcurrencyLimit(maxConcurrent, queueSize int, queueTimeout time.Duration) {
+	if maxConcurrent <= 0 {
+		s.limiter = nil
+
+		return
+	}
+
+	s.limiter = newConcurrencyLimiter(maxConcurrent, queueSize, queueTimeout)
+}
+
 // RegisterSource logs webhook source registration (sources are now managed via persistence).
 func (s *WebhookServer) RegisterSource(source *models.WebhookSource) error {
 	s.logger.Info("Webhook source available for requests",
@@ -203,49 +241,136 @@ func (s *WebhookServer) handleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Bound the requests processed at once, so a spike is pushed back to the senders instead of
+	// overloading the event bus
+	if s.limiter != nil {
+		if !s.limiter.acquire(r.Context()) {
+			s.logger.Warn("Webhook request rejected, server overloaded", "source_id", source.ID)
+			s.metrics.RecordDropped(r.Context(), "webhook", source.ID, tracer.DropReasonOverloaded)
+			w.Header().Set("Retry-After", overloadRetryAfterSeconds)
+			s.writeErrorResponse(w, http.StatusTooManyRequests, "Too many concurrent webhook requests, retry later")
+
+			return
+		}
+		defer s.limiter.release()
+	}
+
+	// Signed URLs are checked whenever they carry a signature, and are the only ones accepted
+	// by sources requiring them
+	query := r.URL.Query()
+	if source.RequiresSignedURL() || query.Has(models.SignatureQueryParam) {
+		if err := source.VerifySignedURL(query, s.now()); err != nil {
+			s.logger.Warn("Webhook request with invalid signed URL", "source_id", source.ID, "error", err)
+			s.writeErrorResponse(w, http.StatusForbidden, "Invalid or expired webhook signature")
+
+			return
+		}
+	}
+
+	// Reject disallowed content types before reading the body
+	mediaType, allowed := s.checkContentType(r, source.AllowedContentTypes())
+	if !allowed {
+		s.logger.Warn("Webhook request with unsupported content type",
+			"source_id", source.ID, "content_type", r.Header.Get("Content-Type"))
+		s.writeErrorResponse(w, http.StatusUnsupportedMediaType, "Unsupported content type")
+
+		return
+	}
+
 	// Limit request body size
-	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+	maxBodyBytes := source.MaxBodyBytes()
+	if r.ContentLength > maxBodyBytes {
+		s.logger.Warn("Webhook request body too large", "source_id", source.ID, "content_length", r.ContentLength)
+		s.writeErrorResponse(w, http.StatusRequestEntityTooLarge, "Request body too large")
+
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
 
 	// Read and parse request body
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			s.logger.Warn("Webhook request body too large", "source_id", source.ID, "limit", maxBodyBytes)
+			s.writeErrorResponse(w, http.StatusRequestEntityTooLarge, "Request body too large")
+
+			return
+		}
+
 		s.logger.Error("Error reading request body", "source_id", source.ID, "error", err)
 		s.writeErrorResponse(w, http.StatusBadRequest, "Error reading request body")
 
 		return
 	}
 
-	// Parse JSON body
-	var eventData map[string]any
-	if len(body) > 0 {
-		if err := json.Unmarshal(body, &eventData); err != nil {
-			s.logger.Error("Error parsing JSON body", "source_id", source.ID, "error", err)
-			s.writeErrorResponse(w, http.StatusBadRequest, "Invalid JSON in request body")
+	// Parse body according to its content type
+	eventData, err := parseBody(mediaType, body)
+	if err != nil {
+		s.logger.Error("Error parsing request body", "source_id", source.ID, "content_type", mediaType, "error", err)
+		s.metrics.RecordDropped(r.Context(), "webhook", source.ID, tracer.DropReasonInvalid)
+		s.writeErrorResponse(w, http.StatusBadRequest, err.Error())
 
-			return
-		}
-	} else {
-		eventData = make(map[string]any)
+		return
 	}
 
-	// Validate against JSON schema if configured
-	if source.HasJSONSchema() {
+	// Validate JSON payloads against the JSON schema if configured
+	if source.HasJSONSchema() && isJSONMediaType(mediaType) {
 		if err := s.validateJSONSchema(eventData, source.JSONSchema); err != nil {
 			s.logger.Warn("JSON schema validation failed", "source_id", source.ID, "error", err)
+			s.metrics.RecordDropped(r.Context(), "webhook", source.ID, tracer.DropReasonInvalid)
 			s.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Schema validation failed: %v", err))
 
 			return
 		}
 	}
 
+	// Trace the executions this request triggers with the caller's correlation ID or a new one
+	correlationID := requestCorrelationID(r)
+	w.Header().Set(events.CorrelationIDHeader, correlationID)
+
+	// Skip deliveries already received, so sender retries do not trigger workflows again
+	deliveryID := ""
+	if header := source.DeliveryIDHeader(); header != "" {
+		deliveryID = r.Header.Get(header)
+	}
+
+	if deliveryID != "" {
+		now := s.now()
+
+		isNew, err := s.persistence.MarkDelivery(source.ID, deliveryID, now, now.Add(source.DeliveryIDTTL()))
+		if err != nil {
+			s.logger.Error("Error recording webhook delivery", "source_id", source.ID, "delivery_id", deliveryID, "error", err)
+			s.writeErrorResponse(w, http.StatusInternalServerError, "Error processing webhook")
+
+			return
+		}
+
+		if !isNew {
+			s.logger.Info("Skipping duplicate webhook delivery", "source_id", source.ID, "delivery_id", deliveryID)
+			s.writeSuccessResponse(w, "duplicate", "Webhook delivery already received", "")
+
+			return
+		}
+	}
+
 	// Add request metadata to event data
-	enrichedEventData := s.enrichEventData(eventData, r)
+	enrichedEventData := s.enrichEventData(eventData, mediaType, r)
 
 	// Publish source event if callback is available
 	if s.callback != nil {
-		ctx := r.Context()
+		ctx := events.WithCorrelationID(r.Context(), correlationID)
 		if err := s.callback(ctx, source.ID, "webhook", "webhook_received", enrichedEventData); err != nil {
 			s.logger.Error("Error publishing source event", "source_id", source.ID, "error", err)
+
+			// Let the sender's retry of this delivery through
+			if deliveryID != "" {
+				if err := s.persistence.ForgetDelivery(source.ID, deliveryID); err != nil {
+					s.logger.Error("Error forgetting webhook delivery", "source_id", source.ID, "delivery_id", deliveryID, "error", err)
+				}
+			}
+
 			s.writeErrorResponse(w, http.StatusInternalServerError, "Error processing webhook")
 
 			return
@@ -256,18 +381,41 @@ func (s *WebhookServer) handleWebhook(w http.ResponseWriter, r *http.Request) {
 	s.logger.Info("Webhook processed successfully",
 		"source_id", source.ID,
 		"external_id", uuid,
+		"correlation_id", correlationID,
 		"remote_addr", r.RemoteAddr,
 		"user_agent", r.UserAgent(),
 		"content_length", r.ContentLength)
 
 	// Return success response
+	s.writeSuccessResponse(w, "success", "Webhook received and processed", correlationID)
+}
+
+// requestCorrelationID returns the correlation ID sent by the caller, or a new one when the caller
+// sent none or an invalid one.
+func requestCorrelationID(r *http.Request) string {
+	if correlationID := r.Header.Get(events.CorrelationIDHeader); events.IsValidCorrelationID(correlationID) {
+		return correlationID
+	}
+
+	return uuid.NewString()
+}
+
+// writeSuccessResponse writes a JSON success response, with the correlation ID of the
+// triggered executions when there is one.
+func (s *WebhookServer) writeSuccessResponse(w http.ResponseWriter, status, message, correlationID string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	if err := json.NewEncoder(w).Encode(map[string]string{
-		"status":  "success",
-		"message": "Webhook received and processed",
-	}); err != nil {
+	response := map[string]string{
+		"status":  status,
+		"message": message,
+	}
+
+	if correlationID != "" {
+		response["correlation_id"] = correlationID
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		s.logger.Error("Error encoding success response", "error", err)
 	}
 }
@@ -295,6 +443,92 @@ func (s *WebhookServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// checkContentType reports the request media type and whether it is allowed.
+// Requests without a body and without a Content-Type header are always allowed.
+func (s *WebhookServer) checkContentType(r *http.Request, allowedContentTypes []string) (string, bool) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return "", r.ContentLength == 0
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	for _, allowed := range allowedContentTypes {
+		if strings.EqualFold(mediaType, allowed) {
+			return mediaType, true
+		}
+	}
+
+	return mediaType, false
+}
+
+// formMediaType is the media type of HTML form submissions.
+const formMediaType = "application/x-www-form-urlencoded"
+
+// isJSONMediaType reports whether the media type carries a JSON payload.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// isTextMediaType reports whether the media type carries text, such as plain text or XML.
+func isTextMediaType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") ||
+		mediaType == "application/xml" ||
+		strings.HasSuffix(mediaType, "+xml")
+}
+
+// parseBody turns a request body into event data according to its media type. JSON objects are
+// decoded, form-encoded fields become a map (a list for repeated fields), and text such as XML is
+// kept as a string under "raw". Any other body is binary and kept base64 encoded under "raw", with
+// "encoding" set to base64, for templates to decode with base64Decode.
+func parseBody(mediaType string, body []byte) (map[string]any, error) {
+	switch {
+	case len(body) == 0:
+		return make(map[string]any), nil
+	case isJSONMediaType(mediaType):
+		var data map[string]any
+		if err := json.Unmarshal(body, &data); err != nil {
+			return nil, errors.New("invalid JSON in request body")
+		}
+
+		return data, nil
+	case mediaType == formMediaType:
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, errors.New("invalid form data in request body")
+		}
+
+		data := make(map[string]any, len(values))
+
+		for name, fieldValues := range values {
+			if len(fieldValues) == 1 {
+				data[name] = fieldValues[0]
+
+				continue
+			}
+
+			list := make([]any, len(fieldValues))
+			for i, value := range fieldValues {
+				list[i] = value
+			}
+
+			data[name] = list
+		}
+
+		return data, nil
+	case isTextMediaType(mediaType) && utf8.Valid(body):
+		return map[string]any{"raw": string(body)}, nil
+	default:
+		return map[string]any{
+			"raw":      base64.StdEncoding.EncodeToString(body),
+			"encoding": "base64",
+		}, nil
+	}
+}
+
 // validateJSONSchema validates event data against the provided JSON schema.
 func (s *WebhookServer) validateJSONSchema(eventData map[string]any, schema map[string]any) error {
 	schemaLoader := gojsonschema.NewGoLoader(schema)
@@ -318,13 +552,14 @@ func (s *WebhookServer) validateJSONSchema(eventData map[string]any, schema map[
 }
 
 // enrichEventData adds request metadata to the event data.
-func (s *WebhookServer) enrichEventData(originalData map[string]any, r *http.Request) map[string]any {
+func (s *WebhookServer) enrichEventData(originalData map[string]any, mediaType string, r *http.Request) map[string]any {
 	enriched := map[string]any{
 		"webhook": map[string]any{
 			"method":         r.Method,
 			"url":            r.URL.String(),
 			"remote_addr":    r.RemoteAddr,
 			"user_agent":     r.UserAgent(),
+			"content_type":   mediaType,
 			"content_length": r.ContentLength,
 			"timestamp":      time.Now().UTC().Format(time.RFC3339),
 			"headers":        s.extractHeaders(r),
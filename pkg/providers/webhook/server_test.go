@@ -0,0 +1,535 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	webhookModels "github.com/dukex/operion/pkg/providers/webhook/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubWebhookPersistence serves a fixed set of webhook sources and records deliveries in memory.
+type stubWebhookPersistence struct {
+	sources    []*webhookModels.WebhookSource
+	deliveries map[string]time.Time
+}
+
+func (p *stubWebhookPersistence) WebhookSourceByExternalID(externalID string) (*webhookModels.WebhookSource, error) {
+	for _, source := range p.sources {
+		if source.ExternalID.String() == externalID {
+			return source, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (p *stubWebhookPersistence) WebhookSources() ([]*webhookModels.WebhookSource, error) {
+	return p.sources, nil
+}
+
+func (p *stubWebhookPersistence) MarkDelivery(sourceID, deliveryID string, now, expiresAt time.Time) (bool, error) {
+	if p.deliveries == nil {
+		p.deliveries = make(map[string]time.Time)
+	}
+
+	key := sourceID + "/" + deliveryID
+	if expires, ok := p.deliveries[key]; ok && expires.After(now) {
+		return false, nil
+	}
+
+	p.deliveries[key] = expiresAt
+
+	return true, nil
+}
+
+func (p *stubWebhookPersistence) ForgetDelivery(sourceID, deliveryID string) error {
+	delete(p.deliveries, sourceID+"/"+deliveryID)
+
+	return nil
+}
+
+func setupTestServer(t *testing.T, configuration map[string]any) (*WebhookServer, *webhookModels.WebhookSource, *MockSourceEventCallback) {
+	t.Helper()
+
+	source, err := webhookModels.NewWebhookSource("source-123", configuration)
+	require.NoError(t, err)
+
+	callback := &MockSourceEventCallback{}
+
+	server := NewWebhookServer(0, createTestLogger())
+	server.SetPersistence(&stubWebhookPersistence{sources: []*webhookModels.WebhookSource{source}})
+	server.SetCallback(func(ctx context.Context, sourceID, providerID, eventType string, eventData map[string]any) error {
+		return callback.Call(ctx, sourceID, providerID, eventType, eventData)
+	})
+
+	return server, source, callback
+}
+
+func TestWebhookServer_HandleWebhook_Valid(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{})
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, source.GetWebhookURL(), strings.NewReader(`{"order_id":"42"}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	recorder := httptest.NewRecorder()
+	server.handleWebhook(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	callback.AssertNumberOfCalls(t, "Call", 1)
+
+	eventData, ok := callback.Calls[0].Arguments.Get(4).(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"order_id": "42"}, eventData["body"])
+}
+
+func TestWebhookServer_HandleWebhook_BodyTooLarge(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{"max_body_bytes": float64(16)})
+
+	req := httptest.NewRequest(http.MethodPost, source.GetWebhookURL(), strings.NewReader(`{"payload":"`+strings.Repeat("x", 64)+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	server.handleWebhook(recorder, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	callback.AssertNotCalled(t, "Call", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// Bodies without a declared length are cut off while reading
+	req = httptest.NewRequest(http.MethodPost, source.GetWebhookURL(), strings.NewReader(`{"payload":"`+strings.Repeat("x", 64)+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = -1
+
+	recorder = httptest.NewRecorder()
+	server.handleWebhook(recorder, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	callback.AssertNotCalled(t, "Call", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWebhookServer_HandleWebhook_UnsupportedContentType(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{})
+
+	req := httptest.NewRequest(http.MethodPost, source.GetWebhookURL(), strings.NewReader("order_id=42"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	recorder := httptest.NewRecorder()
+	server.handleWebhook(recorder, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+	callback.AssertNotCalled(t, "Call", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWebhookServer_HandleWebhook_ConfiguredContentType(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{
+		"allowed_content_types": []any{"text/plain"},
+	})
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, source.GetWebhookURL(), strings.NewReader("hello"))
+	req.Header.Set("Content-Type", "text/plain")
+
+	recorder := httptest.NewRecorder()
+	server.handleWebhook(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	eventData, ok := callback.Calls[0].Arguments.Get(4).(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"raw": "hello"}, eventData["body"])
+}
+
+// postWebhook posts body with the content type to the source and returns the response and the
+// event data of the published source event, nil when none was published.
+func postWebhook(t *testing.T, server *WebhookServer, source *webhookModels.WebhookSource, callback *MockSourceEventCallback, contentType, body string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	calls := len(callback.Calls)
+
+	req := httptest.NewRequest(http.MethodPost, source.GetWebhookURL(), strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+
+	recorder := httptest.NewRecorder()
+	server.handleWebhook(recorder, req)
+
+	if len(callback.Calls) == calls {
+		return recorder, nil
+	}
+
+	eventData, ok := callback.Calls[calls].Arguments.Get(4).(map[string]any)
+	require.True(t, ok)
+
+	return recorder, eventData
+}
+
+func TestWebhookServer_HandleWebhook_FormEncoded(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{
+		"allowed_content_types": []any{"application/x-www-form-urlencoded"},
+	})
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(nil)
+
+	recorder, eventData := postWebhook(t, server, source, callback,
+		"application/x-www-form-urlencoded", "order_id=42&customer=Ana+Silva&tag=new&tag=priority")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	require.NotNil(t, eventData)
+	assert.Equal(t, map[string]any{
+		"order_id": "42",
+		"customer": "Ana Silva",
+		"tag":      []any{"new", "priority"},
+	}, eventData["body"])
+	assert.Equal(t, "application/x-www-form-urlencoded", eventData["webhook"].(map[string]any)["content_type"])
+}
+
+func TestWebhookServer_HandleWebhook_XMLAndBinary(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{
+		"allowed_content_types": []any{"application/xml", "application/octet-stream"},
+		"json_schema":           map[string]any{"type": "object", "required": []any{"order_id"}},
+	})
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(nil)
+
+	// XML is kept raw and not validated against the JSON schema
+	recorder, eventData := postWebhook(t, server, source, callback,
+		"application/xml; charset=utf-8", `<order id="42"/>`)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	require.NotNil(t, eventData)
+	assert.Equal(t, map[string]any{"raw": `<order id="42"/>`}, eventData["body"])
+	assert.Equal(t, "application/xml", eventData["webhook"].(map[string]any)["content_type"])
+
+	// Binary bodies are kept base64 encoded
+	recorder, eventData = postWebhook(t, server, source, callback, "application/octet-stream", "\x89PNG\x00\xff")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	require.NotNil(t, eventData)
+	assert.Equal(t, map[string]any{"raw": "iVBORwD/", "encoding": "base64"}, eventData["body"])
+	assert.Equal(t, "application/octet-stream", eventData["webhook"].(map[string]any)["content_type"])
+}
+
+func TestWebhookServer_HandleWebhook_JSONSchemaValidation(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{
+		"json_schema": map[string]any{"type": "object", "required": []any{"order_id"}},
+	})
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(nil)
+
+	recorder, eventData := postWebhook(t, server, source, callback, "application/json", `{"order_id":"42"}`)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	require.NotNil(t, eventData)
+	assert.Equal(t, map[string]any{"order_id": "42"}, eventData["body"])
+	assert.Equal(t, "application/json", eventData["webhook"].(map[string]any)["content_type"])
+
+	recorder, eventData = postWebhook(t, server, source, callback, "application/json", `{"customer":"Ana"}`)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Nil(t, eventData)
+
+	recorder, eventData = postWebhook(t, server, source, callback, "application/json", `{"order_id":`)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid JSON in request body")
+	assert.Nil(t, eventData)
+}
+
+func TestWebhookServer_HandleWebhook_DuplicateDelivery(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{
+		"delivery_id_header": "X-GitHub-Delivery",
+		"delivery_id_ttl":    "1h",
+	})
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(nil)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	server.now = func() time.Time { return now }
+
+	send := func(deliveryID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, source.GetWebhookURL(), strings.NewReader(`{"order_id":"42"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Delivery", deliveryID)
+
+		recorder := httptest.NewRecorder()
+		server.handleWebhook(recorder, req)
+
+		return recorder
+	}
+
+	recorder := send("delivery-1")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	callback.AssertNumberOfCalls(t, "Call", 1)
+
+	// A retry of the same delivery is acknowledged without triggering again
+	recorder = send("delivery-1")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"status":"duplicate"`)
+	callback.AssertNumberOfCalls(t, "Call", 1)
+
+	// Different deliveries trigger independently
+	send("delivery-2")
+	callback.AssertNumberOfCalls(t, "Call", 2)
+
+	// Once the delivery ID expires the same ID triggers again
+	now = now.Add(2 * time.Hour)
+
+	send("delivery-1")
+	callback.AssertNumberOfCalls(t, "Call", 3)
+}
+
+func TestWebhookServer_HandleWebhook_FailedDeliveryCanBeRetried(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{"delivery_id_header": "X-Request-ID"})
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(assert.AnError).Once()
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(nil)
+
+	send := func() int {
+		req := httptest.NewRequest(http.MethodPost, source.GetWebhookURL(), strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", "delivery-1")
+
+		recorder := httptest.NewRecorder()
+		server.handleWebhook(recorder, req)
+
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, send())
+	assert.Equal(t, http.StatusOK, send())
+	callback.AssertNumberOfCalls(t, "Call", 2)
+}
+
+func TestWebhookServer_HandleWebhook_NoDeliveryIDHeader(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{"delivery_id_header": "X-Request-ID"})
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(nil)
+
+	for range 2 {
+		req := httptest.NewRequest(http.MethodPost, source.GetWebhookURL(), strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		server.handleWebhook(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	}
+
+	// Requests without the header are never deduplicated
+	callback.AssertNumberOfCalls(t, "Call", 2)
+}
+
+func TestWebhookServer_HandleWebhook_CorrelationID(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{})
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(nil)
+
+	send := func(correlationID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, source.GetWebhookURL(), strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		if correlationID != "" {
+			req.Header.Set(events.CorrelationIDHeader, correlationID)
+		}
+
+		recorder := httptest.NewRecorder()
+		server.handleWebhook(recorder, req)
+
+		return recorder
+	}
+
+	// The caller's correlation ID is kept and handed to the callback
+	recorder := send("corr-123")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "corr-123", recorder.Header().Get(events.CorrelationIDHeader))
+	assert.Contains(t, recorder.Body.String(), `"correlation_id":"corr-123"`)
+
+	ctx, ok := callback.Calls[0].Arguments.Get(0).(context.Context)
+	require.True(t, ok)
+	assert.Equal(t, "corr-123", events.CorrelationID(ctx))
+
+	// Without one a new correlation ID is generated
+	recorder = send("")
+	generated := recorder.Header().Get(events.CorrelationIDHeader)
+	assert.NotEmpty(t, generated)
+
+	ctx, ok = callback.Calls[1].Arguments.Get(0).(context.Context)
+	require.True(t, ok)
+	assert.Equal(t, generated, events.CorrelationID(ctx))
+
+	// An invalid correlation ID is replaced by a new one
+	for _, invalid := range []string{"corr 123\r\nX-Injected: 1", "<script>", strings.Repeat("a", 129)} {
+		recorder = send(invalid)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		generated = recorder.Header().Get(events.CorrelationIDHeader)
+		assert.NotEqual(t, invalid, generated)
+		assert.True(t, events.IsValidCorrelationID(generated))
+	}
+}
+
+func TestWebhookServer_HandleWebhook_SignedURL(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{"require_signed_url": true})
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(nil)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	server.now = func() time.Time { return now }
+
+	send := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{"order_id":"42"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		server.handleWebhook(recorder, req)
+
+		return recorder
+	}
+
+	signedURL, err := source.SignedWebhookURL(now.Add(time.Hour))
+	require.NoError(t, err)
+
+	// A valid signed URL triggers
+	assert.Equal(t, http.StatusOK, send(signedURL).Code)
+	callback.AssertNumberOfCalls(t, "Call", 1)
+
+	// Unsigned URLs are rejected by sources requiring signed ones
+	assert.Equal(t, http.StatusForbidden, send(source.GetWebhookURL()).Code)
+
+	// A tampered signature or expiry is rejected
+	tamperedSignature := signedURL[:len(signedURL)-1] + "0"
+	if strings.HasSuffix(signedURL, "0") {
+		tamperedSignature = signedURL[:len(signedURL)-1] + "1"
+	}
+
+	assert.Equal(t, http.StatusForbidden, send(tamperedSignature).Code)
+
+	tamperedExpiry := strings.Replace(signedURL, "expires=", "expires=9", 1)
+	assert.Equal(t, http.StatusForbidden, send(tamperedExpiry).Code)
+
+	// An expired signed URL is rejected
+	expiredURL, err := source.SignedWebhookURL(now.Add(-time.Minute))
+	require.NoError(t, err)
+
+	recorder := send(expiredURL)
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid or expired webhook signature")
+
+	// Rotating the signing secret invalidates URLs signed before
+	require.NoError(t, source.RotateSigningSecret())
+	assert.Equal(t, http.StatusForbidden, send(signedURL).Code)
+
+	rotatedURL, err := source.SignedWebhookURL(now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, send(rotatedURL).Code)
+	callback.AssertNumberOfCalls(t, "Call", 2)
+}
+
+func TestWebhookServer_HandleWebhook_SignatureOptional(t *testing.T) {
+	server, source, callback := setupTestServer(t, map[string]any{})
+	callback.On("Call", mock.Anything, "source-123", "webhook", "webhook_received", mock.Anything).Return(nil)
+
+	send := func(target string) int {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		server.handleWebhook(recorder, req)
+
+		return recorder.Code
+	}
+
+	// Sources not requiring signed URLs accept unsigned ones, but still check a signature sent
+	assert.Equal(t, http.StatusOK, send(source.GetWebhookURL()))
+	assert.Equal(t, http.StatusForbidden, send(source.GetWebhookURL()+"?expires=4102444800&signature=forged"))
+	callback.AssertNumberOfCalls(t, "Call", 1)
+}
+
+// blockingCallback holds every webhook request in the callback until released, counting the
+// requests that reached it.
+type blockingCallback struct {
+	entered chan struct{}
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func newBlockingCallback() *blockingCallback {
+	return &blockingCallback{entered: make(chan struct{}, 100), release: make(chan struct{})}
+}
+
+func (c *blockingCallback) call(ctx context.Context, sourceID, providerID, eventType string, eventData map[string]any) error {
+	c.calls.Add(1)
+	c.entered <- struct{}{}
+	<-c.release
+
+	return nil
+}
+
+// sendWebhook handles a webhook request in the background, returning its recorder once done.
+func sendWebhook(server *WebhookServer, source *webhookModels.WebhookSource) <-chan *httptest.ResponseRecorder {
+	done := make(chan *httptest.ResponseRecorder, 1)
+
+	go func() {
+		req := httptest.NewRequest(http.MethodPost, source.GetWebhookURL(), strings.NewReader(`{"order_id":"42"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		server.handleWebhook(recorder, req)
+		done <- recorder
+	}()
+
+	return done
+}
+
+func TestWebhookServer_HandleWebhook_ConcurrencyLimit(t *testing.T) {
+	server, source, _ := setupTestServer(t, map[string]any{})
+	server.SetConcurrencyLimit(2, 1, 5*time.Second)
+
+	callback := newBlockingCallback()
+	server.SetCallback(callback.call)
+
+	// Two requests take both slots and a third waits in the queue
+	accepted := []<-chan *httptest.ResponseRecorder{sendWebhook(server, source), sendWebhook(server, source)}
+	<-callback.entered
+	<-callback.entered
+
+	accepted = append(accepted, sendWebhook(server, source))
+	require.Eventually(t, func() bool { return server.limiter.queued.Load() == 1 }, time.Second, time.Millisecond)
+
+	// With the slots and the queue full, further requests are rejected right away
+	for range 3 {
+		recorder := <-sendWebhook(server, source)
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+	}
+
+	close(callback.release)
+
+	for _, done := range accepted {
+		assert.Equal(t, http.StatusOK, (<-done).Code)
+	}
+
+	assert.Equal(t, int32(3), callback.calls.Load(), "only requests within the limit trigger workflows")
+
+	// Freed slots accept requests again
+	assert.Equal(t, http.StatusOK, (<-sendWebhook(server, source)).Code)
+}
+
+func TestWebhookServer_HandleWebhook_QueueTimeout(t *testing.T) {
+	server, source, _ := setupTestServer(t, map[string]any{})
+	server.SetConcurrencyLimit(1, 1, 50*time.Millisecond)
+
+	callback := newBlockingCallback()
+	server.SetCallback(callback.call)
+
+	held := sendWebhook(server, source)
+	<-callback.entered
+
+	recorder := <-sendWebhook(server, source)
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+
+	close(callback.release)
+	assert.Equal(t, http.StatusOK, (<-held).Code)
+	assert.Equal(t, int32(1), callback.calls.Load())
+}
@@ -2,13 +2,35 @@
 package registry
 
 import (
+	"github.com/dukex/operion/pkg/nodes/approval"
+	"github.com/dukex/operion/pkg/nodes/assertion"
+	"github.com/dukex/operion/pkg/nodes/awaitevent"
 	"github.com/dukex/operion/pkg/nodes/conditional"
+	"github.com/dukex/operion/pkg/nodes/convertcurrency"
+	"github.com/dukex/operion/pkg/nodes/dedupe"
+	"github.com/dukex/operion/pkg/nodes/encode"
+	"github.com/dukex/operion/pkg/nodes/geoip"
+	"github.com/dukex/operion/pkg/nodes/getexecution"
+	"github.com/dukex/operion/pkg/nodes/git"
+	"github.com/dukex/operion/pkg/nodes/httpbatch"
 	"github.com/dukex/operion/pkg/nodes/httprequest"
+	"github.com/dukex/operion/pkg/nodes/jwtsign"
+	"github.com/dukex/operion/pkg/nodes/lambda"
 	"github.com/dukex/operion/pkg/nodes/log"
+	"github.com/dukex/operion/pkg/nodes/lookup"
 	"github.com/dukex/operion/pkg/nodes/merge"
+	"github.com/dukex/operion/pkg/nodes/normalize"
+	"github.com/dukex/operion/pkg/nodes/parse"
+	"github.com/dukex/operion/pkg/nodes/redis"
+	"github.com/dukex/operion/pkg/nodes/repeatuntil"
+	"github.com/dukex/operion/pkg/nodes/setvariable"
+	"github.com/dukex/operion/pkg/nodes/sqs"
 	switchnode "github.com/dukex/operion/pkg/nodes/switch"
+	"github.com/dukex/operion/pkg/nodes/terminate"
 	"github.com/dukex/operion/pkg/nodes/transform"
 	"github.com/dukex/operion/pkg/nodes/trigger"
+	xmlnode "github.com/dukex/operion/pkg/nodes/xml"
+	"github.com/dukex/operion/pkg/persistence"
 )
 
 // RegisterDefaultNodes registers all built-in node factories with the registry.
@@ -31,8 +53,78 @@ func (r *Registry) RegisterDefaultNodes() {
 	// Register Merge node
 	r.RegisterNode(merge.NewMergeNodeFactory())
 
+	// Register Lookup node
+	r.RegisterNode(lookup.NewLookupNodeFactory())
+
+	// Register Dedupe node
+	r.RegisterNode(dedupe.NewDedupeNodeFactory())
+
+	// Register Approval node
+	r.RegisterNode(approval.NewApprovalNodeFactory())
+
+	// Register Await Event node
+	r.RegisterNode(awaitevent.NewAwaitEventNodeFactory())
+
+	// Register Terminate node
+	r.RegisterNode(terminate.NewTerminateNodeFactory())
+
+	// Register Repeat Until node
+	r.RegisterNode(repeatuntil.NewRepeatUntilNodeFactory())
+
+	// Register Assert node
+	r.RegisterNode(assertion.NewAssertNodeFactory())
+
+	// Register Lambda node
+	r.RegisterNode(lambda.NewLambdaNodeFactory())
+
+	// Register SQS node
+	r.RegisterNode(sqs.NewSQSNodeFactory())
+
+	// Register Redis node
+	r.RegisterNode(redis.NewRedisNodeFactory())
+
+	// Register Set Variable node
+	r.RegisterNode(setvariable.NewSetVariableNodeFactory())
+
+	// Register JWT Sign node
+	r.RegisterNode(jwtsign.NewJWTSignNodeFactory())
+
+	// Register GeoIP node
+	r.RegisterNode(geoip.NewGeoIPNodeFactory())
+
+	// Register HTTP Batch node
+	r.RegisterNode(httpbatch.NewHTTPBatchNodeFactory())
+
+	// Register Git node
+	r.RegisterNode(git.NewGitNodeFactory())
+
+	// Register XML node
+	r.RegisterNode(xmlnode.NewXMLNodeFactory())
+
+	// Register Encode node
+	r.RegisterNode(encode.NewEncodeNodeFactory())
+
+	// Register Parse node
+	r.RegisterNode(parse.NewParseNodeFactory())
+
+	// Register Convert Currency node
+	r.RegisterNode(convertcurrency.NewConvertCurrencyNodeFactory())
+
+	// Register Normalize node
+	r.RegisterNode(normalize.NewNormalizeNodeFactory())
+
 	// Register Trigger nodes
 	r.RegisterNode(trigger.NewWebhookTriggerNodeFactory())
 	r.RegisterNode(trigger.NewSchedulerTriggerNodeFactory())
 	r.RegisterNode(trigger.NewKafkaTriggerNodeFactory())
+	r.RegisterNode(trigger.NewHTTPPollTriggerNodeFactory())
+	r.RegisterNode(trigger.NewSlackTriggerNodeFactory())
+	r.RegisterNode(trigger.NewGCPPubSubTriggerNodeFactory())
+	r.RegisterNode(trigger.NewSNSTriggerNodeFactory())
+}
+
+// RegisterPersistenceNodes registers built-in node factories that need access to persistence.
+func (r *Registry) RegisterPersistenceNodes(p persistence.Persistence) {
+	// Register Get Execution node
+	r.RegisterNode(getexecution.NewGetExecutionNodeFactory(p.ExecutionContextRepository()))
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"log/slog"
 	"testing"
+
+	"github.com/dukex/operion/pkg/persistence/file"
 )
 
 func TestRegisterDefaultNodes(t *testing.T) {
@@ -22,9 +24,33 @@ func TestRegisterDefaultNodes(t *testing.T) {
 		"conditional",
 		"switch",
 		"merge",
+		"lookup",
+		"dedupe",
+		"approval",
+		"awaitevent",
+		"terminate",
+		"repeatuntil",
+		"assert",
+		"lambda",
+		"sqs",
+		"redis",
+		"setvariable",
+		"jwtsign",
+		"geoip",
+		"httpbatch",
+		"git",
+		"xml",
+		"encode",
+		"parse",
+		"convertcurrency",
+		"normalize",
 		"trigger:webhook",
 		"trigger:scheduler",
 		"trigger:kafka",
+		"trigger:httppoll",
+		"trigger:slack",
+		"trigger:gcppubsub",
+		"trigger:sns",
 	}
 
 	availableNodes := registry.AvailableNodes()
@@ -50,6 +76,22 @@ func TestRegisterDefaultNodes(t *testing.T) {
 	}
 }
 
+func TestRegisterPersistenceNodes(t *testing.T) {
+	registry := NewRegistry(slog.Default())
+	registry.RegisterPersistenceNodes(file.NewPersistence(t.TempDir()))
+
+	node, err := registry.CreateNode(context.Background(), "getexecution", "lookup", map[string]any{
+		"workflow_id": "report-workflow",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create get execution node: %v", err)
+	}
+
+	if node.Type() != "getexecution" {
+		t.Errorf("Expected node type 'getexecution', got: %s", node.Type())
+	}
+}
+
 func TestCreateNode_HTTPRequest(t *testing.T) {
 	// Create registry and register nodes
 	registry := NewRegistry(slog.Default())
@@ -0,0 +1,515 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/rpc"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+)
+
+const (
+	OutputPortError = "error"
+)
+
+var (
+	// ErrPluginCrashed is returned when the plugin process exits while it serves a call.
+	ErrPluginCrashed = errors.New("plugin process crashed")
+	// ErrPluginTimeout is returned when a node execution outlives Options.ExecuteTimeout.
+	ErrPluginTimeout = errors.New("plugin execution timed out")
+)
+
+// defaultEnv are the environment variables of the worker every plugin process receives.
+var defaultEnv = []string{"PATH", "TMPDIR", "TZ"}
+
+// Options limits the resources of plugin processes.
+type Options struct {
+	// ExecuteTimeout stops a node execution running longer, 0 for no limit. Executions with a
+	// timeout run in a plugin process of their own, so stopping one never fails other calls.
+	ExecuteTimeout time.Duration
+	// MemoryLimit caps the memory of plugin processes (RLIMIT_DATA, e.g. "256MiB"); it is also
+	// their GOMEMLIMIT, so the garbage collector works to stay under it. Linux only.
+	MemoryLimit string
+	// Env names the environment variables of the worker passed to plugin processes, in addition
+	// to PATH, TMPDIR and TZ. Other variables, such as credentials, are not passed.
+	Env []string
+}
+
+// ProcessNodeFactory is a protocol.NodeFactory whose nodes are created and executed by a plugin
+// process. The process is started again when a call finds it exited.
+type ProcessNodeFactory struct {
+	path        string
+	options     Options
+	memoryLimit uint64
+	logger      *slog.Logger
+	info        *FactoryInfo
+
+	mu     sync.Mutex
+	client *plugin.Client
+	rpc    *rpcClient
+}
+
+// LoadPlugins starts the plugin executables in dir and returns their node factories. A missing
+// directory has no plugins.
+func LoadPlugins(ctx context.Context, dir string, options Options, logger *slog.Logger) ([]*ProcessNodeFactory, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugins directory: %w", err)
+	}
+
+	factories := make([]*ProcessNodeFactory, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		factory, err := NewProcessNodeFactory(filepath.Join(dir, entry.Name()), options, logger)
+		if err != nil {
+			for _, started := range factories {
+				started.Close()
+			}
+
+			return nil, err
+		}
+
+		logger.InfoContext(ctx, "Loaded sandboxed plugin", "plugin", entry.Name(), "node_type", factory.ID())
+
+		factories = append(factories, factory)
+	}
+
+	return factories, nil
+}
+
+// NewProcessNodeFactory starts the plugin executable at path and reads its factory metadata.
+func NewProcessNodeFactory(path string, options Options, logger *slog.Logger) (*ProcessNodeFactory, error) {
+	memoryLimit, err := parseMemoryLimit(options.MemoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &ProcessNodeFactory{
+		path:        path,
+		options:     options,
+		memoryLimit: memoryLimit,
+		logger:      logger.With("module", "sandbox", "plugin", filepath.Base(path)),
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	client, err := f.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.Info()
+	if err != nil {
+		f.stop()
+
+		return nil, fmt.Errorf("failed to read plugin %s: %w", path, err)
+	}
+
+	f.info = info
+
+	return f, nil
+}
+
+// connect returns the RPC client of the shared plugin process, starting the process when it is
+// not running. The caller holds f.mu.
+func (f *ProcessNodeFactory) connect() (*rpcClient, error) {
+	if f.client != nil && !f.client.Exited() {
+		return f.rpc, nil
+	}
+
+	f.stop()
+
+	client, rpc, err := f.start()
+	if err != nil {
+		return nil, err
+	}
+
+	f.client = client
+	f.rpc = rpc
+
+	return f.rpc, nil
+}
+
+// start starts a plugin process with the allowed environment and memory limit, and returns its
+// client along with its RPC client.
+func (f *ProcessNodeFactory) start() (*plugin.Client, *rpcClient, error) {
+	cmd := exec.Command(f.path) //nolint:gosec // plugins are executables installed by the operator
+	cmd.Env = f.environment()
+
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  Handshake,
+		Plugins:          plugin.PluginSet{pluginName: &nodeFactoryPlugin{}},
+		Cmd:              cmd,
+		SkipHostEnv:      true,
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolNetRPC},
+		Managed:          true,
+		Logger: hclog.FromStandardLogger(
+			slog.NewLogLogger(f.logger.Handler(), slog.LevelWarn),
+			&hclog.LoggerOptions{Name: "plugin", Level: hclog.Warn},
+		),
+	})
+
+	protocolClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+
+		return nil, nil, fmt.Errorf("failed to start plugin %s: %w", f.path, err)
+	}
+
+	// The limit applies once the process answered the handshake, before it serves any call
+	if f.memoryLimit > 0 {
+		if err := limitMemory(client.ReattachConfig().Pid, f.memoryLimit); err != nil {
+			client.Kill()
+
+			return nil, nil, fmt.Errorf("failed to limit the memory of plugin %s: %w", f.path, err)
+		}
+	}
+
+	raw, err := protocolClient.Dispense(pluginName)
+	if err != nil {
+		client.Kill()
+
+		return nil, nil, fmt.Errorf("failed to connect to plugin %s: %w", f.path, err)
+	}
+
+	return client, raw.(*rpcClient), nil //nolint:forcetypeassert // dispensed by nodeFactoryPlugin.Client
+}
+
+// environment returns the environment of plugin processes: the allowed variables of the worker
+// and the GOMEMLIMIT of the memory limit.
+func (f *ProcessNodeFactory) environment() []string {
+	env := make([]string, 0, len(defaultEnv)+len(f.options.Env)+1)
+
+	for _, name := range append(slices.Clone(defaultEnv), f.options.Env...) {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+
+	if f.memoryLimit > 0 {
+		env = append(env, "GOMEMLIMIT="+strconv.FormatUint(f.memoryLimit, 10))
+	}
+
+	return env
+}
+
+// memoryUnits are the suffixes of memory limits, as accepted by GOMEMLIMIT.
+var memoryUnits = []struct {
+	suffix string
+	bytes  uint64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+	{"B", 1},
+}
+
+// parseMemoryLimit parses a memory limit such as "256MiB" into bytes, 0 when limit is empty.
+func parseMemoryLimit(limit string) (uint64, error) {
+	if limit == "" {
+		return 0, nil
+	}
+
+	number, unit := limit, uint64(1)
+
+	for _, candidate := range memoryUnits {
+		if trimmed, ok := strings.CutSuffix(limit, candidate.suffix); ok {
+			number, unit = trimmed, candidate.bytes
+
+			break
+		}
+	}
+
+	value, err := strconv.ParseUint(number, 10, 64)
+	if err != nil || value == 0 {
+		return 0, fmt.Errorf("invalid memory limit '%s' (e.g. 256MiB)", limit)
+	}
+
+	return value * unit, nil
+}
+
+// stop kills the plugin process. The caller holds f.mu.
+func (f *ProcessNodeFactory) stop() {
+	if f.client != nil {
+		f.client.Kill()
+	}
+
+	f.client = nil
+	f.rpc = nil
+}
+
+// Close stops the plugin process.
+func (f *ProcessNodeFactory) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.stop()
+}
+
+// call runs fn with the RPC client of the shared plugin process, restarting the process first
+// when it exited. Errors of a process that exits during the call become ErrPluginCrashed.
+func (f *ProcessNodeFactory) call(fn func(*rpcClient) error) error {
+	f.mu.Lock()
+	client, err := f.connect()
+	pluginClient := f.client
+	f.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	err = crashError(fn(client))
+	if errors.Is(err, ErrPluginCrashed) {
+		f.restartAfter(pluginClient)
+	}
+
+	return err
+}
+
+// callIsolated runs fn with the RPC client of a plugin process of its own, stopped once the call
+// returns. A call outliving timeout fails with ErrPluginTimeout; stopping its process leaves the
+// calls served by other processes running.
+func (f *ProcessNodeFactory) callIsolated(timeout time.Duration, fn func(*rpcClient) error) error {
+	pluginClient, client, err := f.start()
+	if err != nil {
+		return err
+	}
+	defer pluginClient.Kill()
+
+	done := make(chan error, 1)
+
+	go func() { done <- fn(client) }()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return crashError(err)
+	case <-timer.C:
+		return fmt.Errorf("%w after %s", ErrPluginTimeout, timeout)
+	}
+}
+
+// crashError returns the error of a call. Errors returned by the node itself come back as server
+// errors; any other error means the connection to the process was lost, and is ErrPluginCrashed.
+func crashError(err error) error {
+	var serverErr rpc.ServerError
+	if err == nil || errors.As(err, &serverErr) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", ErrPluginCrashed, err)
+}
+
+// restartAfter stops the plugin process of client, if it is still the current one, so the next
+// call starts a new process.
+func (f *ProcessNodeFactory) restartAfter(client *plugin.Client) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.client == client {
+		f.stop()
+	}
+}
+
+// Create creates a node through the plugin process.
+func (f *ProcessNodeFactory) Create(ctx context.Context, id string, config map[string]any) (protocol.Node, error) {
+	encodedConfig, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	args := CreateArgs{ID: id, Config: encodedConfig}
+
+	var info *NodeInfo
+
+	err = f.call(func(client *rpcClient) error {
+		info, err = client.Create(args)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	node := &processNode{factory: f, args: args, nodeType: info.Type, requirements: models.DefaultInputRequirements()}
+
+	if err := json.Unmarshal(info.InputPorts, &node.inputPorts); err != nil {
+		return nil, fmt.Errorf("failed to decode input ports: %w", err)
+	}
+
+	if err := json.Unmarshal(info.OutputPorts, &node.outputPorts); err != nil {
+		return nil, fmt.Errorf("failed to decode output ports: %w", err)
+	}
+
+	if len(info.InputRequirements) > 0 {
+		if err := json.Unmarshal(info.InputRequirements, &node.requirements); err != nil {
+			return nil, fmt.Errorf("failed to decode input requirements: %w", err)
+		}
+	}
+
+	return node, nil
+}
+
+// ID returns the ID of the plugin's factory.
+func (f *ProcessNodeFactory) ID() string {
+	return f.info.ID
+}
+
+// Name returns the name of the plugin's factory.
+func (f *ProcessNodeFactory) Name() string {
+	return f.info.Name
+}
+
+// Description returns the description of the plugin's factory.
+func (f *ProcessNodeFactory) Description() string {
+	return f.info.Description
+}
+
+// Schema returns the JSON schema of the plugin's factory.
+func (f *ProcessNodeFactory) Schema() map[string]any {
+	var schema map[string]any
+
+	if err := json.Unmarshal(f.info.Schema, &schema); err != nil {
+		f.logger.Warn("Invalid plugin schema", "error", err)
+	}
+
+	return schema
+}
+
+// processNode is a node executed by a plugin process.
+type processNode struct {
+	factory      *ProcessNodeFactory
+	args         CreateArgs
+	nodeType     string
+	inputPorts   []models.InputPort
+	outputPorts  []models.OutputPort
+	requirements models.InputRequirements
+}
+
+// ID returns the node ID.
+func (n *processNode) ID() string {
+	return n.args.ID
+}
+
+// Type returns the node type.
+func (n *processNode) Type() string {
+	return n.nodeType
+}
+
+// Execute executes the node in the plugin process. A crash or timeout of the process fails the
+// node on its error port, so it is routed like any other node failure.
+func (n *processNode) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	encodedCtx, err := json.Marshal(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode execution context: %w", err)
+	}
+
+	encodedInputs, err := json.Marshal(inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode inputs: %w", err)
+	}
+
+	args := ExecuteArgs{CreateArgs: n.args, Context: encodedCtx, MissingKeys: ctx.MissingKeys, Inputs: encodedInputs}
+
+	var reply *ExecuteReply
+
+	execute := func(client *rpcClient) error {
+		reply, err = client.Execute(args)
+
+		return err
+	}
+
+	if timeout := n.factory.options.ExecuteTimeout; timeout > 0 {
+		err = n.factory.callIsolated(timeout, execute)
+	} else {
+		err = n.factory.call(execute)
+	}
+
+	switch {
+	case errors.Is(err, ErrPluginCrashed), errors.Is(err, ErrPluginTimeout):
+		return n.createErrorResult(err), nil
+	case err != nil:
+		return nil, err
+	case reply.Error != "":
+		return nil, errors.New(reply.Error)
+	}
+
+	var outputs map[string]models.NodeResult
+	if err := json.Unmarshal(reply.Outputs, &outputs); err != nil {
+		return nil, fmt.Errorf("failed to decode outputs: %w", err)
+	}
+
+	return outputs, nil
+}
+
+// createErrorResult creates a NodeResult for the error output port from a plugin process failure.
+func (n *processNode) createErrorResult(err error) map[string]models.NodeResult {
+	return map[string]models.NodeResult{
+		OutputPortError: {
+			NodeID: n.args.ID,
+			Data: map[string]any{
+				"error":   err.Error(),
+				"crashed": errors.Is(err, ErrPluginCrashed),
+				"success": false,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  err.Error(),
+		},
+	}
+}
+
+// InputPorts returns the input ports of the node.
+func (n *processNode) InputPorts() []models.InputPort {
+	return n.inputPorts
+}
+
+// OutputPorts returns the output ports of the node.
+func (n *processNode) OutputPorts() []models.OutputPort {
+	return n.outputPorts
+}
+
+// InputRequirements returns the input requirements of the node.
+func (n *processNode) InputRequirements() models.InputRequirements {
+	return n.requirements
+}
+
+// Validate validates config in the plugin process.
+func (n *processNode) Validate(config map[string]any) error {
+	encodedConfig, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return n.factory.call(func(client *rpcClient) error {
+		return client.Validate(CreateArgs{ID: n.args.ID, Config: encodedConfig})
+	})
+}
+
+// Ensure interface compliance.
+var _ protocol.NodeFactory = (*ProcessNodeFactory)(nil)
@@ -0,0 +1,200 @@
+package sandbox
+
+import (
+	"io"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildCrashPlugin builds the crash plugin of testdata into dir and returns its path.
+func buildCrashPlugin(t *testing.T, dir string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, "crash")
+
+	output, err := exec.Command("go", "build", "-o", path, "./testdata/crashplugin").CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	return path
+}
+
+func newCrashFactory(t *testing.T, options Options) *ProcessNodeFactory {
+	t.Helper()
+
+	factory, err := NewProcessNodeFactory(buildCrashPlugin(t, t.TempDir()), options, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	t.Cleanup(factory.Close)
+
+	return factory
+}
+
+func executeCrashNode(t *testing.T, factory *ProcessNodeFactory, config, input map[string]any) map[string]models.NodeResult {
+	t.Helper()
+
+	node, err := factory.Create(t.Context(), "crasher", config)
+	require.NoError(t, err)
+
+	outputs, err := node.Execute(
+		models.ExecutionContext{TriggerData: map[string]any{"order_id": "order-42"}},
+		map[string]models.NodeResult{"main": {NodeID: "trigger", Data: input, Status: string(models.NodeStatusSuccess)}},
+	)
+	require.NoError(t, err)
+
+	return outputs
+}
+
+func TestProcessNodeFactory_ExecutesInPluginProcess(t *testing.T) {
+	factory := newCrashFactory(t, Options{})
+
+	assert.Equal(t, "crash", factory.ID())
+	assert.Equal(t, "Crash", factory.Name())
+	assert.Equal(t, "object", factory.Schema()["type"])
+
+	node, err := factory.Create(t.Context(), "crasher", map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, "crash", node.Type())
+	assert.Len(t, node.OutputPorts(), 2)
+	require.NoError(t, node.Validate(map[string]any{}))
+
+	outputs := executeCrashNode(t, factory, map[string]any{}, map[string]any{"item": "book"})
+
+	result, ok := outputs["success"]
+	require.True(t, ok)
+	assert.Equal(t, "order-42", result.Data["order"])
+	assert.Equal(t, map[string]any{"item": "book"}, result.Data["input"])
+}
+
+func TestProcessNodeFactory_CrashRoutesToErrorAndRestarts(t *testing.T) {
+	factory := newCrashFactory(t, Options{})
+
+	before := executeCrashNode(t, factory, map[string]any{}, map[string]any{})["success"].Data["pid"]
+
+	outputs := executeCrashNode(t, factory, map[string]any{}, map[string]any{"panic": true})
+
+	result, ok := outputs[OutputPortError]
+	require.True(t, ok, "a crash fails the node on its error port")
+	assert.Equal(t, string(models.NodeStatusError), result.Status)
+	assert.Contains(t, result.Error, ErrPluginCrashed.Error())
+	assert.Equal(t, true, result.Data["crashed"])
+
+	// The next execution runs in a new plugin process
+	after := executeCrashNode(t, factory, map[string]any{}, map[string]any{})["success"].Data["pid"]
+	assert.NotEqual(t, before, after)
+}
+
+func TestProcessNodeFactory_ExecuteTimeout(t *testing.T) {
+	factory := newCrashFactory(t, Options{ExecuteTimeout: 200 * time.Millisecond})
+
+	outputs := executeCrashNode(t, factory, map[string]any{"sleep": "5s"}, map[string]any{})
+
+	result, ok := outputs[OutputPortError]
+	require.True(t, ok)
+	assert.Contains(t, result.Error, ErrPluginTimeout.Error())
+	assert.Equal(t, false, result.Data["crashed"])
+
+	outputs = executeCrashNode(t, factory, map[string]any{}, map[string]any{})
+	assert.Contains(t, outputs, "success")
+}
+
+func TestProcessNodeFactory_ExecuteTimeoutStopsOnlyTheTimedOutCall(t *testing.T) {
+	factory := newCrashFactory(t, Options{ExecuteTimeout: time.Second})
+
+	slow := make(chan map[string]models.NodeResult, 1)
+
+	go func() {
+		node, err := factory.Create(t.Context(), "slow", map[string]any{"sleep": "500ms"})
+		if err != nil {
+			slow <- nil
+
+			return
+		}
+
+		outputs, _ := node.Execute(models.ExecutionContext{}, map[string]models.NodeResult{"main": {}})
+		slow <- outputs
+	}()
+
+	outputs := executeCrashNode(t, factory, map[string]any{"sleep": "5s"}, map[string]any{})
+	assert.Contains(t, outputs[OutputPortError].Error, ErrPluginTimeout.Error())
+
+	// The execution running next to the timed-out one completes
+	assert.Contains(t, <-slow, "success")
+}
+
+func TestProcessNodeFactory_PassesOnlyAllowedEnvironment(t *testing.T) {
+	t.Setenv("SANDBOX_TEST_SECRET", "s3cr3t")
+	t.Setenv("SANDBOX_TEST_ALLOWED", "visible")
+
+	factory := newCrashFactory(t, Options{Env: []string{"SANDBOX_TEST_ALLOWED"}})
+
+	env := executeCrashNode(t, factory, map[string]any{}, map[string]any{})["success"].Data["env"]
+
+	assert.Contains(t, env, "SANDBOX_TEST_ALLOWED=visible")
+	assert.NotContains(t, env, "SANDBOX_TEST_SECRET=s3cr3t")
+}
+
+func TestProcessNodeFactory_MemoryLimit(t *testing.T) {
+	factory := newCrashFactory(t, Options{MemoryLimit: "256MiB"})
+
+	outputs := executeCrashNode(t, factory, map[string]any{"allocate_mb": float64(16)}, map[string]any{})
+	require.Contains(t, outputs, "success")
+	assert.Contains(t, outputs["success"].Data["env"], "GOMEMLIMIT=268435456")
+
+	// Allocating past the limit crashes the plugin process, not the worker
+	outputs = executeCrashNode(t, factory, map[string]any{"allocate_mb": float64(512)}, map[string]any{})
+
+	result, ok := outputs[OutputPortError]
+	require.True(t, ok)
+	assert.Equal(t, true, result.Data["crashed"])
+}
+
+func TestParseMemoryLimit(t *testing.T) {
+	tests := []struct {
+		limit    string
+		expected uint64
+	}{
+		{"", 0},
+		{"1048576", 1 << 20},
+		{"512B", 512},
+		{"64KiB", 64 << 10},
+		{"256MiB", 256 << 20},
+		{"2GiB", 2 << 30},
+	}
+
+	for _, tt := range tests {
+		limit, err := parseMemoryLimit(tt.limit)
+		require.NoError(t, err, tt.limit)
+		assert.Equal(t, tt.expected, limit, tt.limit)
+	}
+
+	for _, invalid := range []string{"lots", "256MB", "0MiB", "-1GiB"} {
+		_, err := parseMemoryLimit(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestLoadPlugins(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	factories, err := LoadPlugins(t.Context(), filepath.Join(t.TempDir(), "missing"), Options{}, logger)
+	require.NoError(t, err)
+	assert.Empty(t, factories)
+
+	dir := t.TempDir()
+	buildCrashPlugin(t, dir)
+
+	factories, err = LoadPlugins(t.Context(), dir, Options{}, logger)
+	require.NoError(t, err)
+	require.Len(t, factories, 1)
+
+	t.Cleanup(factories[0].Close)
+
+	assert.Equal(t, "crash", factories[0].ID())
+}
@@ -0,0 +1,9 @@
+package sandbox
+
+import "golang.org/x/sys/unix"
+
+// limitMemory caps the data segment of the process pid, its heap included, to limit bytes.
+// Allocations beyond it fail, so a Go plugin exceeding the limit crashes.
+func limitMemory(pid int, limit uint64) error {
+	return unix.Prlimit(pid, unix.RLIMIT_DATA, &unix.Rlimit{Cur: limit, Max: limit}, nil)
+}
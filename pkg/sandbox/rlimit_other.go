@@ -0,0 +1,10 @@
+//go:build !linux
+
+package sandbox
+
+import "errors"
+
+// limitMemory fails: the memory of plugin processes can only be limited on Linux.
+func limitMemory(int, uint64) error {
+	return errors.New("memory limits of plugin processes require Linux")
+}
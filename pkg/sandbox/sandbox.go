@@ -0,0 +1,224 @@
+// Package sandbox runs node factories in separate plugin processes, so a node that panics or
+// exhausts its resources cannot take the worker down with it. Plugins are executables serving a
+// protocol.NodeFactory with Serve; the worker reaches them over a local RPC connection through
+// ProcessNodeFactory, which nodes are created and executed with like in-process ones.
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/rpc"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	"github.com/hashicorp/go-plugin"
+)
+
+// Handshake is shared by the worker and its plugins, so executables that are not operion
+// plugins, or speak another protocol version, are refused.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "OPERION_PLUGIN",
+	MagicCookieValue: "node-factory",
+}
+
+// pluginName is the name the node factory is dispensed under.
+const pluginName = "node_factory"
+
+// Serve serves factory to the worker that started the plugin process. It is called from the
+// main function of the plugin and returns when the worker stops the plugin.
+func Serve(factory protocol.NodeFactory) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         plugin.PluginSet{pluginName: &nodeFactoryPlugin{factory: factory}},
+	})
+}
+
+// nodeFactoryPlugin serves a node factory over net/rpc in the plugin process and returns its
+// RPC client in the worker.
+type nodeFactoryPlugin struct {
+	factory protocol.NodeFactory
+}
+
+func (p *nodeFactoryPlugin) Server(*plugin.MuxBroker) (any, error) {
+	return &rpcServer{factory: p.factory}, nil
+}
+
+func (p *nodeFactoryPlugin) Client(_ *plugin.MuxBroker, client *rpc.Client) (any, error) {
+	return &rpcClient{client: client}, nil
+}
+
+// FactoryInfo describes the node factory served by a plugin.
+type FactoryInfo struct {
+	ID          string
+	Name        string
+	Description string
+	Schema      []byte // JSON
+}
+
+// NodeInfo describes a node created by a plugin.
+type NodeInfo struct {
+	Type              string
+	InputPorts        []byte // JSON
+	OutputPorts       []byte // JSON
+	InputRequirements []byte // JSON, empty for the default requirements
+}
+
+// CreateArgs identifies a node of the plugin's factory by its ID and configuration.
+type CreateArgs struct {
+	ID     string
+	Config []byte // JSON
+}
+
+// ExecuteArgs executes a node created from CreateArgs. The execution context is sent as JSON,
+// so its fields excluded from JSON, but the missing keys mode, do not reach the plugin.
+type ExecuteArgs struct {
+	CreateArgs
+
+	Context     []byte // JSON
+	MissingKeys string
+	Inputs      []byte // JSON
+}
+
+// ExecuteReply holds the outputs of a node, or the error its execution returned.
+type ExecuteReply struct {
+	Outputs []byte // JSON
+	Error   string
+}
+
+// rpcServer creates the nodes of a factory in the plugin process. Nodes are created for every
+// call, so the plugin keeps no state a restart would lose.
+type rpcServer struct {
+	factory protocol.NodeFactory
+}
+
+func (s *rpcServer) Info(_ struct{}, reply *FactoryInfo) error {
+	schema, err := json.Marshal(s.factory.Schema())
+	if err != nil {
+		return fmt.Errorf("failed to encode schema: %w", err)
+	}
+
+	*reply = FactoryInfo{
+		ID:          s.factory.ID(),
+		Name:        s.factory.Name(),
+		Description: s.factory.Description(),
+		Schema:      schema,
+	}
+
+	return nil
+}
+
+func (s *rpcServer) Create(args CreateArgs, reply *NodeInfo) error {
+	node, err := s.create(args)
+	if err != nil {
+		return err
+	}
+
+	inputPorts, err := json.Marshal(node.InputPorts())
+	if err != nil {
+		return fmt.Errorf("failed to encode input ports: %w", err)
+	}
+
+	outputPorts, err := json.Marshal(node.OutputPorts())
+	if err != nil {
+		return fmt.Errorf("failed to encode output ports: %w", err)
+	}
+
+	*reply = NodeInfo{Type: node.Type(), InputPorts: inputPorts, OutputPorts: outputPorts}
+
+	if requirementsNode, ok := node.(models.NodeInputRequirements); ok {
+		reply.InputRequirements, err = json.Marshal(requirementsNode.InputRequirements())
+		if err != nil {
+			return fmt.Errorf("failed to encode input requirements: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *rpcServer) Execute(args ExecuteArgs, reply *ExecuteReply) error {
+	node, err := s.create(args.CreateArgs)
+	if err != nil {
+		return err
+	}
+
+	var (
+		execCtx models.ExecutionContext
+		inputs  map[string]models.NodeResult
+	)
+
+	if err := json.Unmarshal(args.Context, &execCtx); err != nil {
+		return fmt.Errorf("failed to decode execution context: %w", err)
+	}
+
+	if err := json.Unmarshal(args.Inputs, &inputs); err != nil {
+		return fmt.Errorf("failed to decode inputs: %w", err)
+	}
+
+	execCtx.MissingKeys = args.MissingKeys
+
+	outputs, err := node.Execute(execCtx, inputs)
+	if err != nil {
+		reply.Error = err.Error()
+
+		return nil
+	}
+
+	reply.Outputs, err = json.Marshal(outputs)
+	if err != nil {
+		return fmt.Errorf("failed to encode outputs: %w", err)
+	}
+
+	return nil
+}
+
+func (s *rpcServer) Validate(args CreateArgs, _ *struct{}) error {
+	node, err := s.create(args)
+	if err != nil {
+		return err
+	}
+
+	var config map[string]any
+	if err := json.Unmarshal(args.Config, &config); err != nil {
+		return fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	return node.Validate(config)
+}
+
+func (s *rpcServer) create(args CreateArgs) (protocol.Node, error) {
+	var config map[string]any
+	if err := json.Unmarshal(args.Config, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	return s.factory.Create(context.Background(), args.ID, config)
+}
+
+// rpcClient calls the rpcServer of a plugin process.
+type rpcClient struct {
+	client *rpc.Client
+}
+
+func (c *rpcClient) Info() (*FactoryInfo, error) {
+	var reply FactoryInfo
+
+	return &reply, c.client.Call("Plugin.Info", struct{}{}, &reply)
+}
+
+func (c *rpcClient) Create(args CreateArgs) (*NodeInfo, error) {
+	var reply NodeInfo
+
+	return &reply, c.client.Call("Plugin.Create", args, &reply)
+}
+
+func (c *rpcClient) Execute(args ExecuteArgs) (*ExecuteReply, error) {
+	var reply ExecuteReply
+
+	return &reply, c.client.Call("Plugin.Execute", args, &reply)
+}
+
+func (c *rpcClient) Validate(args CreateArgs) error {
+	return c.client.Call("Plugin.Validate", args, &struct{}{})
+}
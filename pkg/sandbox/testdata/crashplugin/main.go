@@ -0,0 +1,85 @@
+// Command crashplugin is a sandboxed plugin serving the "crash" node type, used by tests. Its
+// nodes panic, and so crash the plugin process, when their input asks them to; they sleep for
+// the duration of their "sleep" config and allocate the megabytes of their "allocate_mb" config
+// before executing, and return the environment of the process.
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	"github.com/dukex/operion/pkg/sandbox"
+)
+
+type factory struct{}
+
+func (factory) Create(_ context.Context, id string, config map[string]any) (protocol.Node, error) {
+	sleep, _ := config["sleep"].(string)
+	duration, _ := time.ParseDuration(sleep)
+	allocate, _ := config["allocate_mb"].(float64)
+
+	return &node{id: id, sleep: duration, allocate: int(allocate)}, nil
+}
+
+func (factory) ID() string          { return "crash" }
+func (factory) Name() string        { return "Crash" }
+func (factory) Description() string { return "Panics when its input has panic set" }
+
+func (factory) Schema() map[string]any {
+	return map[string]any{"type": "object", "properties": map[string]any{"sleep": map[string]any{"type": "string"}}}
+}
+
+type node struct {
+	id       string
+	sleep    time.Duration
+	allocate int
+}
+
+func (n *node) ID() string   { return n.id }
+func (n *node) Type() string { return "crash" }
+
+func (n *node) Execute(ctx models.ExecutionContext, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	time.Sleep(n.sleep)
+
+	memory := make([]byte, n.allocate<<20)
+	for i := range memory {
+		memory[i] = 1
+	}
+
+	if panics, _ := inputs["main"].Data["panic"].(bool); panics {
+		panic("crash node asked to panic")
+	}
+
+	return map[string]models.NodeResult{
+		"success": {
+			NodeID: n.id,
+			Data: map[string]any{
+				"pid":   os.Getpid(),
+				"order": ctx.TriggerData["order_id"],
+				"input": inputs["main"].Data,
+				"env":   os.Environ(),
+			},
+			Status: string(models.NodeStatusSuccess),
+		},
+	}, nil
+}
+
+func (n *node) InputPorts() []models.InputPort {
+	return []models.InputPort{{Port: models.Port{ID: models.MakePortID(n.id, "main"), NodeID: n.id, Name: "main"}}}
+}
+
+func (n *node) OutputPorts() []models.OutputPort {
+	return []models.OutputPort{
+		{Port: models.Port{ID: models.MakePortID(n.id, "success"), NodeID: n.id, Name: "success"}},
+		{Port: models.Port{ID: models.MakePortID(n.id, "error"), NodeID: n.id, Name: "error"}},
+	}
+}
+
+func (n *node) Validate(map[string]any) error { return nil }
+
+func main() {
+	sandbox.Serve(factory{})
+}
@@ -0,0 +1,201 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// aggregateFuncs are the template functions computing summaries over arrays, such as the total
+// amount of a list of orders: {{ sum "amount" .trigger_data.orders }}. The field selects the value
+// of each item, with dots for nested fields ("price.total"); an empty field uses the items
+// themselves. Items whose value is missing or not a number are skipped.
+func aggregateFuncs() map[string]any {
+	return map[string]any{
+		"sum": func(field string, items any) (float64, error) {
+			values, err := numericValues(field, items)
+			if err != nil {
+				return 0, err
+			}
+
+			total, _ := sumRat(values).Float64()
+
+			return total, nil
+		},
+		"avg": func(field string, items any) (float64, error) {
+			values, err := numericValues(field, items)
+			if err != nil || len(values) == 0 {
+				return 0, err
+			}
+
+			total := sumRat(values)
+			mean, _ := total.Quo(total, big.NewRat(int64(len(values)), 1)).Float64()
+
+			return mean, nil
+		},
+		"min": func(field string, items any) (any, error) {
+			return extreme(field, items, -1)
+		},
+		"max": func(field string, items any) (any, error) {
+			return extreme(field, items, 1)
+		},
+		"count": func(items any) (int, error) {
+			list, err := toList(items)
+
+			return len(list), err
+		},
+		"groupBy": groupBy,
+	}
+}
+
+// toList returns the items of an array, or nil for a nil value.
+func toList(items any) ([]any, error) {
+	if items == nil {
+		return nil, nil
+	}
+
+	if list, ok := items.([]any); ok {
+		return list, nil
+	}
+
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected an array, got %T", items)
+	}
+
+	list := make([]any, value.Len())
+	for i := range list {
+		list[i] = value.Index(i).Interface()
+	}
+
+	return list, nil
+}
+
+// fieldValue returns the value of the dotted field of item, or item itself for an empty field.
+func fieldValue(item any, field string) (any, bool) {
+	if field == "" {
+		return item, true
+	}
+
+	value := item
+
+	for name := range strings.SplitSeq(field, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		if value, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// numericValues returns the values of field in items as exact rationals, skipping the items whose
+// value is missing or not a number. Numbers are taken by their shortest decimal representation, so
+// 0.1 + 0.2 sums to 0.3.
+func numericValues(field string, items any) ([]*big.Rat, error) {
+	list, err := toList(items)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]*big.Rat, 0, len(list))
+
+	for _, item := range list {
+		value, ok := fieldValue(item, field)
+		if !ok {
+			continue
+		}
+
+		if number, ok := toRat(value); ok {
+			values = append(values, number)
+		}
+	}
+
+	return values, nil
+}
+
+func toRat(value any) (*big.Rat, bool) {
+	var decimal string
+
+	switch v := value.(type) {
+	case float64:
+		decimal = strconv.FormatFloat(v, 'g', -1, 64)
+	case float32:
+		decimal = strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case int:
+		decimal = strconv.Itoa(v)
+	case int64:
+		decimal = strconv.FormatInt(v, 10)
+	case int32:
+		decimal = strconv.FormatInt(int64(v), 10)
+	case json.Number:
+		decimal = v.String()
+	case string:
+		decimal = strings.TrimSpace(v)
+	default:
+		return nil, false
+	}
+
+	number, ok := new(big.Rat).SetString(decimal)
+
+	return number, ok
+}
+
+func sumRat(values []*big.Rat) *big.Rat {
+	total := new(big.Rat)
+	for _, value := range values {
+		total.Add(total, value)
+	}
+
+	return total
+}
+
+// extreme returns the smallest (sign -1) or largest (sign 1) numeric value of field in items, or
+// nil when there is none.
+func extreme(field string, items any, sign int) (any, error) {
+	values, err := numericValues(field, items)
+	if err != nil || len(values) == 0 {
+		return nil, err
+	}
+
+	best := values[0]
+	for _, value := range values[1:] {
+		if value.Cmp(best) == sign {
+			best = value
+		}
+	}
+
+	result, _ := best.Float64()
+
+	return result, nil
+}
+
+// groupBy groups items by the value of field, returning a map of each value, as text, to the items
+// having it, in their original order. Items without the field are grouped under "".
+func groupBy(field string, items any) (map[string]any, error) {
+	list, err := toList(items)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]any)
+
+	for _, item := range list {
+		var key string
+		if value, ok := fieldValue(item, field); ok && value != nil {
+			key = fmt.Sprint(value)
+		}
+
+		group, _ := groups[key].([]any)
+		groups[key] = append(group, item)
+	}
+
+	return groups, nil
+}
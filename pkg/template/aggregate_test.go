@@ -0,0 +1,101 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ordersContext() *models.ExecutionContext {
+	return &models.ExecutionContext{
+		TriggerData: map[string]any{
+			"orders": []any{
+				map[string]any{"id": "o1", "category": "books", "amount": 0.1, "shipping": map[string]any{"cost": 5.0}},
+				map[string]any{"id": "o2", "category": "games", "amount": 0.2, "shipping": map[string]any{"cost": 7.5}},
+				map[string]any{"id": "o3", "category": "books", "amount": "0.7"},
+				map[string]any{"id": "o4", "category": "music", "amount": "n/a"},
+				map[string]any{"id": "o5", "amount": nil},
+			},
+			"empty":  []any{},
+			"scores": []any{3.0, 1.5, 9.25},
+		},
+	}
+}
+
+func TestAggregateFunctions_SumAndAverage(t *testing.T) {
+	execCtx := ordersContext()
+
+	// 0.1 + 0.2 + 0.7 without floating point drift; the non-numeric amounts are skipped
+	result, err := RenderWithContext(`{{ sum "amount" .trigger_data.orders }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, result)
+
+	result, err = RenderWithContext(`{{ sum "amount" (slice .trigger_data.orders 0 2) }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, result)
+
+	result, err = RenderWithContext(`{{ avg "amount" .trigger_data.orders }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0/3.0, result)
+
+	result, err = RenderWithContext(`{{ .trigger_data.orders | sum "shipping.cost" }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 12.5, result)
+
+	result, err = RenderWithContext(`{{ avg "" .trigger_data.scores }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 4.583333333333333, result)
+}
+
+func TestAggregateFunctions_MinMaxCount(t *testing.T) {
+	execCtx := ordersContext()
+
+	result, err := RenderWithContext(`{{ min "amount" .trigger_data.orders }}/{{ max "amount" .trigger_data.orders }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "0.1/0.7", result)
+
+	result, err = RenderWithContext(`{{ count .trigger_data.orders }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, result)
+}
+
+func TestAggregateFunctions_EmptyArrays(t *testing.T) {
+	execCtx := ordersContext()
+
+	result, err := RenderWithContext(`{{ sum "amount" .trigger_data.empty }}|{{ avg "amount" .trigger_data.empty }}|{{ count .trigger_data.empty }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "0|0|0", result)
+
+	result, err = RenderWithContext(`{{ json (max "amount" .trigger_data.empty) }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "null", result)
+
+	result, err = RenderWithContext(`{{ sum "amount" .trigger_data.missing }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, result)
+
+	_, err = RenderWithContext(`{{ sum "amount" (index .trigger_data.orders 0) }}`, execCtx)
+	require.ErrorContains(t, err, "expected an array, got map[string]interface {}")
+}
+
+func TestAggregateFunctions_GroupBy(t *testing.T) {
+	execCtx := ordersContext()
+
+	result, err := RenderWithContext(`{{ json (groupBy "category" .trigger_data.orders) }}`, execCtx)
+	require.NoError(t, err)
+
+	orders := execCtx.TriggerData["orders"].([]any)
+
+	assert.Equal(t, map[string]any{
+		"books": []any{orders[0], orders[2]},
+		"games": []any{orders[1]},
+		"music": []any{orders[3]},
+		"":      []any{orders[4]},
+	}, result)
+
+	result, err = RenderWithContext(`{{ range $category, $items := groupBy "category" .trigger_data.orders }}{{ if $category }}{{ $category }}={{ sum "amount" $items }};{{ end }}{{ end }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "books=0.8;games=0.2;music=0;", result)
+}
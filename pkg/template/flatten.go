@@ -0,0 +1,175 @@
+package template
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Flatten converts a nested object into a flat one keyed by dot-separated paths, with array
+// elements addressed in bracket notation: {"order": {"items": [{"sku": "A"}]}} becomes
+// {"order.items[0].sku": "A"}. Empty objects and arrays are kept as values so they survive a
+// round trip through Unflatten.
+func Flatten(data map[string]any) map[string]any {
+	flat := make(map[string]any)
+	flattenInto(flat, "", data)
+
+	return flat
+}
+
+func flattenInto(flat map[string]any, path string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 && path != "" {
+			flat[path] = v
+
+			return
+		}
+
+		for key, item := range v {
+			if path == "" {
+				flattenInto(flat, key, item)
+			} else {
+				flattenInto(flat, path+"."+key, item)
+			}
+		}
+	case []any:
+		if len(v) == 0 {
+			flat[path] = v
+
+			return
+		}
+
+		for i, item := range v {
+			flattenInto(flat, fmt.Sprintf("%s[%d]", path, i), item)
+		}
+	default:
+		flat[path] = v
+	}
+}
+
+// Unflatten rebuilds the nested object of a map produced by Flatten. Array elements missing
+// from the flat map are left nil.
+func Unflatten(flat map[string]any) (map[string]any, error) {
+	var root any = make(map[string]any)
+
+	for key, value := range flat {
+		segments, err := parsePath(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if root, err = setPath(root, segments, value); err != nil {
+			return nil, fmt.Errorf("invalid key '%s': %w", key, err)
+		}
+	}
+
+	nested, _ := root.(map[string]any)
+
+	return nested, nil
+}
+
+// pathSegment is an object key or, when isIndex is set, an array index.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parsePath splits a flat key such as "order.items[0].sku" into its segments.
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+
+	for part := range strings.SplitSeq(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key == "" && len(segments) == 0 {
+			return nil, fmt.Errorf("invalid key '%s': must start with an object key", path)
+		}
+
+		if key != "" {
+			segments = append(segments, pathSegment{key: key})
+		}
+
+		for rest != "" {
+			indexText, after, found := strings.Cut(rest, "]")
+
+			index, err := strconv.Atoi(indexText)
+			if !found || err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid array index in key '%s'", path)
+			}
+
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+
+	return segments, nil
+}
+
+// setPath sets value at segments below current, creating the objects and arrays on the way,
+// and returns the updated container.
+func setPath(current any, segments []pathSegment, value any) (any, error) {
+	if len(segments) == 0 {
+		// Containers built from other keys win over the empty ones Flatten keeps as values
+		switch v := value.(type) {
+		case map[string]any:
+			if len(v) == 0 {
+				if current != nil {
+					return current, nil
+				}
+
+				return make(map[string]any), nil
+			}
+		case []any:
+			if len(v) == 0 {
+				if current != nil {
+					return current, nil
+				}
+
+				return []any{}, nil
+			}
+		}
+
+		return value, nil
+	}
+
+	segment := segments[0]
+
+	if segment.isIndex {
+		list, ok := current.([]any)
+		if current != nil && !ok {
+			return nil, fmt.Errorf("expected an object, found array index %d", segment.index)
+		}
+
+		if len(list) <= segment.index {
+			list = append(list, make([]any, segment.index+1-len(list))...)
+		}
+
+		child, err := setPath(list[segment.index], segments[1:], value)
+		if err != nil {
+			return nil, err
+		}
+
+		list[segment.index] = child
+
+		return list, nil
+	}
+
+	object, ok := current.(map[string]any)
+	if current != nil && !ok {
+		return nil, fmt.Errorf("expected an array, found key '%s'", segment.key)
+	}
+
+	if object == nil {
+		object = make(map[string]any)
+	}
+
+	child, err := setPath(object[segment.key], segments[1:], value)
+	if err != nil {
+		return nil, err
+	}
+
+	object[segment.key] = child
+
+	return object, nil
+}
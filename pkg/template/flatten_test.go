@@ -0,0 +1,77 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nestedOrder() map[string]any {
+	return map[string]any{
+		"order": map[string]any{
+			"id": "42",
+			"customer": map[string]any{
+				"email": "ada@example.com",
+				"tags":  []any{"vip", "beta"},
+			},
+			"items": []any{
+				map[string]any{"sku": "A", "quantity": 2.0},
+				map[string]any{"sku": "B", "quantity": 1.0, "options": []any{[]any{"red", "large"}}},
+			},
+			"notes":    []any{},
+			"metadata": map[string]any{},
+			"paid":     true,
+			"coupon":   nil,
+		},
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	assert.Equal(t, map[string]any{
+		"order.id":                     "42",
+		"order.customer.email":         "ada@example.com",
+		"order.customer.tags[0]":       "vip",
+		"order.customer.tags[1]":       "beta",
+		"order.items[0].sku":           "A",
+		"order.items[0].quantity":      2.0,
+		"order.items[1].sku":           "B",
+		"order.items[1].quantity":      1.0,
+		"order.items[1].options[0][0]": "red",
+		"order.items[1].options[0][1]": "large",
+		"order.notes":                  []any{},
+		"order.metadata":               map[string]any{},
+		"order.paid":                   true,
+		"order.coupon":                 nil,
+	}, Flatten(nestedOrder()))
+}
+
+func TestFlatten_UnflattenRoundTrip(t *testing.T) {
+	nested, err := Unflatten(Flatten(nestedOrder()))
+	require.NoError(t, err)
+	assert.Equal(t, nestedOrder(), nested)
+}
+
+func TestUnflatten_InvalidKeys(t *testing.T) {
+	for _, key := range []string{"[0].sku", "items[x]", "items[1", "items[-1]"} {
+		_, err := Unflatten(map[string]any{key: "A"})
+		assert.Error(t, err, key)
+	}
+
+	// A path cannot be both an object and an array
+	_, err := Unflatten(map[string]any{"items[0]": "A", "items.sku": "B"})
+	assert.Error(t, err)
+}
+
+func TestRender_FlattenFunctions(t *testing.T) {
+	execCtx := &models.ExecutionContext{TriggerData: nestedOrder()}
+
+	result, err := RenderStringWithContext(`{{ index (flatten .trigger_data) "order.customer.email" }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com", result)
+
+	result, err = RenderStringWithContext(`{{ (unflatten (flatten .trigger_data)).order.items | len }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "2", result)
+}
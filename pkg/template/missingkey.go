@@ -0,0 +1,174 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// Functions the templates of the missing key modes are rewritten to call.
+const (
+	// optionalFunc looks up fields in its data, yielding nil when one is missing.
+	optionalFunc = "missingKeyOptional"
+	// emptyFunc renders a missing value as empty.
+	emptyFunc = "missingKeyEmpty"
+)
+
+// missingKeyFuncs are the functions called by the templates rewritten for a missing key mode.
+func missingKeyFuncs() template.FuncMap {
+	return template.FuncMap{
+		optionalFunc: func(data any, fields ...string) any {
+			for _, field := range fields {
+				object, ok := data.(map[string]any)
+				if !ok {
+					return nil
+				}
+
+				data = object[field]
+			}
+
+			return data
+		},
+		emptyFunc: func(value any) any {
+			if value == nil {
+				return ""
+			}
+
+			return value
+		},
+	}
+}
+
+// applyMissingKeyMode sets up tmpl and its partials to treat references to missing fields according
+// to the missing key mode. Strict templates fail with missingkey=error, except for the fields read
+// by the default function, which receives them as nil to fall back. Lenient templates pipe every
+// action to a function rendering missing values as empty, since the zero value of the interfaces
+// the data holds would still print as "<no value>" with missingkey=zero.
+func applyMissingKeyMode(tmpl *template.Template, mode string) {
+	switch mode {
+	case MissingKeyStrict:
+		tmpl.Option("missingkey=error")
+
+		for _, t := range tmpl.Templates() {
+			walk(t.Root, optionalDefaultOperands)
+		}
+	case MissingKeyLenient:
+		for _, t := range tmpl.Templates() {
+			walk(t.Root, emptyMissingOutput)
+		}
+	}
+}
+
+// missingKeyError wraps err with ErrMissingKey when the template failed on a missing field.
+func missingKeyError(err error) error {
+	var execErr template.ExecError
+	if errors.As(err, &execErr) && strings.Contains(execErr.Err.Error(), "no entry for key") {
+		return fmt.Errorf("%w: %w", ErrMissingKey, err)
+	}
+
+	return err
+}
+
+// optionalDefaultOperands rewrites the fields passed to the default function in a pipeline, as
+// arguments or piped into it, to yield nil instead of failing when missing.
+func optionalDefaultOperands(node parse.Node) {
+	pipe, ok := node.(*parse.PipeNode)
+	if !ok {
+		return
+	}
+
+	for i, cmd := range pipe.Cmds {
+		if identifier, ok := cmd.Args[0].(*parse.IdentifierNode); !ok || identifier.Ident != "default" {
+			continue
+		}
+
+		for j, arg := range cmd.Args[1:] {
+			if field, ok := arg.(*parse.FieldNode); ok {
+				cmd.Args[j+1] = optionalField(field)
+			}
+		}
+
+		if i > 0 && len(pipe.Cmds[i-1].Args) == 1 {
+			if field, ok := pipe.Cmds[i-1].Args[0].(*parse.FieldNode); ok {
+				pipe.Cmds[i-1].Args[0] = optionalField(field)
+			}
+		}
+	}
+}
+
+// optionalField returns the pipeline (missingKeyOptional . "a" "b") looking up the field .a.b.
+func optionalField(field *parse.FieldNode) *parse.PipeNode {
+	args := []parse.Node{
+		parse.NewIdentifier(optionalFunc).SetPos(field.Pos),
+		&parse.DotNode{NodeType: parse.NodeDot, Pos: field.Pos},
+	}
+
+	for _, name := range field.Ident {
+		args = append(args, &parse.StringNode{NodeType: parse.NodeString, Pos: field.Pos, Quoted: strconv.Quote(name), Text: name})
+	}
+
+	return &parse.PipeNode{
+		NodeType: parse.NodePipe,
+		Pos:      field.Pos,
+		Cmds:     []*parse.CommandNode{{NodeType: parse.NodeCommand, Pos: field.Pos, Args: args}},
+	}
+}
+
+// emptyMissingOutput pipes the output of an action to missingKeyEmpty.
+func emptyMissingOutput(node parse.Node) {
+	action, ok := node.(*parse.ActionNode)
+	if !ok || len(action.Pipe.Decl) > 0 {
+		return
+	}
+
+	action.Pipe.Cmds = append(action.Pipe.Cmds, &parse.CommandNode{
+		NodeType: parse.NodeCommand,
+		Pos:      action.Pos,
+		Args:     []parse.Node{parse.NewIdentifier(emptyFunc).SetPos(action.Pos)},
+	})
+}
+
+// walk calls visit with node and every node under it.
+func walk(node parse.Node, visit func(parse.Node)) {
+	visit(node)
+
+	switch n := node.(type) {
+	case *parse.ListNode:
+		for _, child := range n.Nodes {
+			walk(child, visit)
+		}
+	case *parse.ActionNode:
+		walk(n.Pipe, visit)
+	case *parse.IfNode:
+		walkBranch(&n.BranchNode, visit)
+	case *parse.RangeNode:
+		walkBranch(&n.BranchNode, visit)
+	case *parse.WithNode:
+		walkBranch(&n.BranchNode, visit)
+	case *parse.TemplateNode:
+		if n.Pipe != nil {
+			walk(n.Pipe, visit)
+		}
+	case *parse.PipeNode:
+		for _, cmd := range n.Cmds {
+			walk(cmd, visit)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			walk(arg, visit)
+		}
+	}
+}
+
+// walkBranch walks the pipeline and lists of an if, range or with action.
+func walkBranch(branch *parse.BranchNode, visit func(parse.Node)) {
+	walk(branch.Pipe, visit)
+	walk(branch.List, visit)
+
+	if branch.ElseList != nil {
+		walk(branch.ElseList, visit)
+	}
+}
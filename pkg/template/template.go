@@ -3,8 +3,11 @@ package template
 
 import (
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"strconv"
 	"strings"
@@ -14,32 +17,231 @@ import (
 	"github.com/dukex/operion/pkg/models"
 )
 
+// Missing key modes select how templates render references to fields missing from their data.
+const (
+	// MissingKeyDefault renders a missing field as "<no value>".
+	MissingKeyDefault = ""
+	// MissingKeyStrict fails the rendering of a template that outputs a missing field.
+	MissingKeyStrict = "strict"
+	// MissingKeyLenient renders a missing field as empty.
+	MissingKeyLenient = "lenient"
+)
+
+// rootName is the name of the template being rendered, which partials cannot take.
+const rootName = "transform"
+
+var (
+	ErrMissingKey            = errors.New("template references a missing field")
+	ErrInvalidMissingKeyMode = errors.New("invalid missing key mode")
+	ErrInvalidPartial        = errors.New("invalid template partial")
+)
+
+// ValidateMissingKeyMode returns ErrInvalidMissingKeyMode if mode is not a missing key mode.
+func ValidateMissingKeyMode(mode string) error {
+	switch mode {
+	case MissingKeyDefault, MissingKeyStrict, MissingKeyLenient:
+		return nil
+	default:
+		return fmt.Errorf("%w '%s', expected %s or %s", ErrInvalidMissingKeyMode, mode, MissingKeyStrict, MissingKeyLenient)
+	}
+}
+
 func RenderWithContext(input string, executionCtx *models.ExecutionContext) (any, error) {
+	data, err := contextData(executionCtx)
+	if err != nil {
+		logEvaluation(executionCtx, input, nil, err)
+
+		return nil, err
+	}
+
+	output, err := render(input, data, executionCtx.MissingKeys, executionCtx.Templates)
+	logEvaluation(executionCtx, input, output, err)
+
+	return output, err
+}
+
+// RenderStringWithContext renders the input string as a template with the execution context
+// and returns the output as is, without converting it to JSON, numbers or booleans.
+func RenderStringWithContext(input string, executionCtx *models.ExecutionContext) (string, error) {
+	return RenderStringWithData(input, executionCtx, nil)
+}
+
+// RenderStringWithData renders the input string like RenderStringWithContext, with the fields of
+// data, such as the item a node is processing, exposed next to the execution context.
+func RenderStringWithData(input string, executionCtx *models.ExecutionContext, data map[string]any) (string, error) {
+	tmpl, err := parseWithMissingKeys(input, executionCtx.Templates, executionCtx.MissingKeys)
+	if err != nil {
+		logEvaluation(executionCtx, input, nil, err)
+
+		return "", err
+	}
+
+	templateData, err := contextData(executionCtx)
+	if err != nil {
+		logEvaluation(executionCtx, input, nil, err)
+
+		return "", err
+	}
+
+	maps.Copy(templateData, data)
+
+	var buf strings.Builder
+
+	if err := tmpl.Execute(&buf, templateData); err != nil {
+		err = fmt.Errorf("failed to execute template '%s': %w", input, missingKeyError(err))
+		logEvaluation(executionCtx, input, nil, err)
+
+		return "", err
+	}
+
+	output := buf.String()
+	logEvaluation(executionCtx, input, output, nil)
+
+	return output, nil
+}
+
+// RenderValueWithContext renders every string in value, a config value decoded from JSON, with
+// RenderStringWithContext, walking into objects and arrays, and returns the rendered copy. Strings
+// stay strings whatever they render to, so rendered data cannot change the structure of value.
+func RenderValueWithContext(value any, executionCtx *models.ExecutionContext) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return RenderStringWithContext(v, executionCtx)
+	case map[string]any:
+		rendered := make(map[string]any, len(v))
+
+		for key, item := range v {
+			renderedItem, err := RenderValueWithContext(item, executionCtx)
+			if err != nil {
+				return nil, err
+			}
+
+			rendered[key] = renderedItem
+		}
+
+		return rendered, nil
+	case []any:
+		rendered := make([]any, len(v))
+
+		for i, item := range v {
+			renderedItem, err := RenderValueWithContext(item, executionCtx)
+			if err != nil {
+				return nil, err
+			}
+
+			rendered[i] = renderedItem
+		}
+
+		return rendered, nil
+	default:
+		return value, nil
+	}
+}
+
+// logEvaluation logs the evaluation of a template at debug level to the node-scoped logger of the
+// execution context, so it shows up for nodes whose log level is debug.
+func logEvaluation(executionCtx *models.ExecutionContext, input string, output any, err error) {
+	if executionCtx.Logger == nil {
+		return
+	}
+
+	if err != nil {
+		executionCtx.Logger.Debug("Template evaluation failed", "template", input, "error", err)
+
+		return
+	}
+
+	executionCtx.Logger.Debug("Template evaluated", "template", input, "output", output)
+}
+
+// contextData returns the data exposed to templates rendered with an execution context. Offloaded
+// trigger data and node results are loaded through the ResolvePayload of the context.
+func contextData(executionCtx *models.ExecutionContext) (map[string]any, error) {
+	triggerData, err := resolvePayload(executionCtx, executionCtx.TriggerDataRef, executionCtx.TriggerData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve trigger data: %w", err)
+	}
+
 	// Flatten node results for easier template access
 	flattenedNodeResults := make(map[string]any)
 	for nodeID, result := range executionCtx.NodeResults {
-		flattenedNodeResults[nodeID] = result.Data
+		data, err := resolvePayload(executionCtx, result.PayloadRef, result.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve result %s: %w", nodeID, err)
+		}
+
+		flattenedNodeResults[nodeID] = data
 	}
 
-	enhancedData := map[string]any{
+	return map[string]any{
 		"node_results": flattenedNodeResults,
 		"variables":    executionCtx.Variables,
-		"trigger_data": executionCtx.TriggerData,
+		"state":        executionCtx.State,
+		"trigger_data": triggerData,
 		"metadata":     executionCtx.Metadata,
 		"env":          getEnvVars(),
 		"execution": map[string]any{
 			"id":          executionCtx.ID,
 			"workflow_id": executionCtx.WorkflowID,
 		},
+	}, nil
+}
+
+// resolvePayload loads the payload offloaded as ref through the ResolvePayload of the execution
+// context. Inline payloads, and references without a resolver, are returned as is.
+func resolvePayload(executionCtx *models.ExecutionContext, ref string, payload map[string]any) (map[string]any, error) {
+	if ref == "" || executionCtx.ResolvePayload == nil {
+		return payload, nil
 	}
 
-	return Render(input, enhancedData)
+	return executionCtx.ResolvePayload(ref)
 }
 
 // Parse parses the input string as a template and returns the parsed template.
 func Parse(input string) (*template.Template, error) {
-	tmpl, err := template.
-		New("transform").
+	return ParseWithPartials(input, nil)
+}
+
+// ParseWithPartials parses the input string like Parse, with the named partials available to it
+// through {{template "name"}}, or {{template "name" .}} to render the partial with the same data.
+func ParseWithPartials(input string, partials map[string]string) (*template.Template, error) {
+	return parseWithMissingKeys(input, partials, MissingKeyDefault)
+}
+
+// parseWithMissingKeys parses the input string like ParseWithPartials, set up to treat references
+// to missing fields according to the missing key mode.
+func parseWithMissingKeys(input string, partials map[string]string, mode string) (*template.Template, error) {
+	tmpl, err := newTemplate().Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template '%s': %w", input, err)
+	}
+
+	for name, body := range partials {
+		if name == rootName {
+			return nil, fmt.Errorf("%w: the name '%s' is reserved", ErrInvalidPartial, name)
+		}
+
+		if _, err := tmpl.New(name).Parse(body); err != nil {
+			return nil, fmt.Errorf("%w '%s': %w", ErrInvalidPartial, name, err)
+		}
+	}
+
+	applyMissingKeyMode(tmpl, mode)
+
+	return tmpl, nil
+}
+
+// ValidatePartials returns ErrInvalidPartial if a partial does not parse or takes a reserved name.
+func ValidatePartials(partials map[string]string) error {
+	_, err := ParseWithPartials("", partials)
+
+	return err
+}
+
+// newTemplate creates an empty template with the template functions.
+func newTemplate() *template.Template {
+	return template.
+		New(rootName).
 		Funcs(template.FuncMap{
 			"now": func() string {
 				return time.Now().UTC().Format(time.RFC3339)
@@ -56,32 +258,78 @@ func Parse(input string) (*template.Template, error) {
 
 				return int32(num[0]) % maxPossible
 			},
-		}).Parse(input)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse template '%s': %w", input, err)
-	}
+			"flatten": func(value any) (map[string]any, error) {
+				data, ok := value.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("flatten expects an object, got %T", value)
+				}
 
-	return tmpl, nil
+				return Flatten(data), nil
+			},
+			"json": func(value any) (string, error) {
+				encoded, err := json.Marshal(value)
+
+				return string(encoded), err
+			},
+			"default": func(fallback, value any) any {
+				if value == nil || value == "" {
+					return fallback
+				}
+
+				return value
+			},
+			"unflatten": func(value any) (map[string]any, error) {
+				data, ok := value.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("unflatten expects an object, got %T", value)
+				}
+
+				return Unflatten(data)
+			},
+			"base64Decode": func(value any) (string, error) {
+				text, ok := value.(string)
+				if !ok {
+					return "", fmt.Errorf("base64Decode expects a string, got %T", value)
+				}
+
+				decoded, err := base64.StdEncoding.DecodeString(text)
+				if err != nil {
+					return "", fmt.Errorf("base64Decode: %w", err)
+				}
+
+				return string(decoded), nil
+			},
+		}).
+		Funcs(aggregateFuncs()).
+		Funcs(missingKeyFuncs())
 }
 
 // Render renders the input string as a template with the provided data.
 func Render(templateStr string, data any) (any, error) {
-	tmpl, err := Parse(templateStr)
+	return RenderWithMissingKeys(templateStr, data, MissingKeyDefault)
+}
+
+// RenderWithMissingKeys renders the input string like Render, treating references to fields
+// missing from data according to the missing key mode.
+func RenderWithMissingKeys(templateStr string, data any, mode string) (any, error) {
+	return render(templateStr, data, mode, nil)
+}
+
+func render(templateStr string, data any, mode string, partials map[string]string) (any, error) {
+	tmpl, err := parseWithMissingKeys(templateStr, partials, mode)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse template '%s': %w", templateStr, err)
+		return nil, err
 	}
 
 	var buf strings.Builder
 
 	err = tmpl.Execute(&buf, data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute template '%s': %w", templateStr, err)
+		return nil, fmt.Errorf("failed to execute template '%s': %w", templateStr, missingKeyError(err))
 	}
 
-	result := buf.String()
-
 	// Try to parse as JSON if it looks like JSON
-	result = strings.TrimSpace(result)
+	result := strings.TrimSpace(buf.String())
 	if (strings.HasPrefix(result, "{") && strings.HasSuffix(result, "}")) ||
 		(strings.HasPrefix(result, "[") && strings.HasSuffix(result, "]")) {
 		var jsonResult any
@@ -3,6 +3,7 @@ package template
 import (
 	"testing"
 
+	"github.com/dukex/operion/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -158,3 +159,224 @@ func TestRender_StringInterpolation(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "https://api.example.com/users/123", result)
 }
+
+func TestRenderStringWithContext_KeepsOutputAsIs(t *testing.T) {
+	execCtx := &models.ExecutionContext{
+		TriggerData: map[string]any{"zip": "00501", "flag": "true", "items": "[1, 2]"},
+	}
+
+	result, err := RenderStringWithContext("{{ .trigger_data.zip }}", execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "00501", result)
+
+	result, err = RenderStringWithContext("{{ .trigger_data.flag }}/{{ .trigger_data.items }}", execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "true/[1, 2]", result)
+
+	_, err = RenderStringWithContext("{{ .trigger_data", execCtx)
+	require.Error(t, err)
+}
+
+func TestRender_JSONFunction(t *testing.T) {
+	execCtx := &models.ExecutionContext{
+		TriggerData: map[string]any{"subscribers": []any{map[string]any{"url": "https://a.example.com"}}},
+	}
+
+	result, err := RenderWithContext("{{ json .trigger_data.subscribers }}", execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"url": "https://a.example.com"}}, result)
+}
+
+func TestRender_Base64DecodeFunction(t *testing.T) {
+	execCtx := &models.ExecutionContext{
+		TriggerData: map[string]any{"body": map[string]any{"raw": "PG9yZGVyIGlkPSI0MiIvPg==", "encoding": "base64"}},
+	}
+
+	result, err := RenderStringWithContext("{{ base64Decode .trigger_data.body.raw }}", execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, `<order id="42"/>`, result)
+
+	_, err = RenderStringWithContext("{{ base64Decode .trigger_data.body.encoding }}", execCtx)
+	require.Error(t, err)
+}
+
+func TestRenderStringWithData_ExposesData(t *testing.T) {
+	execCtx := &models.ExecutionContext{
+		TriggerData: map[string]any{"event": "order.created"},
+	}
+
+	result, err := RenderStringWithData("{{ .item.url }}?event={{ .trigger_data.event }}&n={{ .index }}", execCtx, map[string]any{
+		"item":  map[string]any{"url": "https://a.example.com/hook"},
+		"index": 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://a.example.com/hook?event=order.created&n=2", result)
+}
+
+func TestRenderWithContext_ResolvesOffloadedPayloads(t *testing.T) {
+	payloads := map[string]map[string]any{
+		"executions/exec-1/trigger_data.json": {"order": map[string]any{"id": "order-1"}},
+		"executions/exec-1/fetch.json":        {"status": "shipped"},
+	}
+
+	executionCtx := &models.ExecutionContext{
+		ID:             "exec-1",
+		TriggerDataRef: "executions/exec-1/trigger_data.json",
+		NodeResults: map[string]models.NodeResult{
+			models.MakeNodeResultKey("fetch", "success"): {
+				NodeID:     "fetch",
+				PayloadRef: "executions/exec-1/fetch.json",
+				Status:     string(models.NodeStatusSuccess),
+			},
+			models.MakeNodeResultKey("echo", "success"): {
+				NodeID: "echo",
+				Data:   map[string]any{"$ref": "executions/exec-1/fetch.json"},
+				Status: string(models.NodeStatusSuccess),
+			},
+		},
+		ResolvePayload: func(ref string) (map[string]any, error) {
+			return payloads[ref], nil
+		},
+	}
+
+	result, err := RenderWithContext("{{ .trigger_data.order.id }}", executionCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "order-1", result)
+
+	result, err = RenderWithContext(`{{ (index .node_results "`+models.MakeNodeResultKey("fetch", "success")+`").status }}`, executionCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "shipped", result)
+
+	// Data shaped like a reference is never resolved
+	result, err = RenderWithContext(`{{ index (index .node_results "`+models.MakeNodeResultKey("echo", "success")+`") "$ref" }}`, executionCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "executions/exec-1/fetch.json", result)
+}
+
+func TestRenderWithContext_MissingKeys(t *testing.T) {
+	execCtx := &models.ExecutionContext{
+		TriggerData: map[string]any{"webhook": map[string]any{"order_id": "42"}},
+	}
+
+	const missing = "order {{ .trigger_data.webhook.order_id }}: {{ .trigger_data.webhook.nonexistent.field }}"
+
+	// By default a missing field renders as <no value>
+	result, err := RenderWithContext(missing, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "order 42: <no value>", result)
+
+	execCtx.MissingKeys = MissingKeyLenient
+
+	result, err = RenderWithContext(missing, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "order 42:", result)
+
+	output, err := RenderStringWithContext(missing, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "order 42: ", output)
+
+	execCtx.MissingKeys = MissingKeyStrict
+
+	_, err = RenderWithContext(missing, execCtx)
+	require.ErrorIs(t, err, ErrMissingKey)
+
+	_, err = RenderStringWithContext(missing, execCtx)
+	require.ErrorIs(t, err, ErrMissingKey)
+
+	// Missing fields are told apart from data that reads like a missing field
+	execCtx.TriggerData["note"] = "<no value>"
+	execCtx.Templates = map[string]string{"note": "{{ .trigger_data.note }}{{ .trigger_data.nonexistent }}"}
+
+	output, err = RenderStringWithContext(`{{ .trigger_data.note }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "<no value>", output)
+
+	_, err = RenderStringWithContext(`{{ template "note" . }}`, execCtx)
+	require.ErrorIs(t, err, ErrMissingKey, "partials fail on missing fields too")
+
+	execCtx.MissingKeys = MissingKeyLenient
+
+	output, err = RenderStringWithContext(`{{ template "note" . }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "<no value>", output)
+}
+
+func TestRenderWithContext_Partials(t *testing.T) {
+	execCtx := &models.ExecutionContext{
+		TriggerData: map[string]any{"order_id": "42", "total": 99.5},
+		Templates: map[string]string{
+			"order_body": `{"order": "{{ .trigger_data.order_id }}", "total": {{ .trigger_data.total }}}`,
+			"signature":  "-- sent by operion",
+		},
+	}
+
+	result, err := RenderWithContext(`{{ template "order_body" . }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"order": "42", "total": 99.5}, result)
+
+	output, err := RenderStringWithContext(`Order {{ .trigger_data.order_id }} {{ template "signature" }}`, execCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "Order 42 -- sent by operion", output)
+
+	_, err = RenderWithContext(`{{ template "unknown" . }}`, execCtx)
+	require.Error(t, err)
+}
+
+func TestValidatePartials(t *testing.T) {
+	require.NoError(t, ValidatePartials(nil))
+	require.NoError(t, ValidatePartials(map[string]string{
+		"header": "Order {{ .trigger_data.order_id }}",
+		"page":   `{{ template "header" . }} details`,
+	}))
+
+	err := ValidatePartials(map[string]string{"broken": "{{ .trigger_data"})
+	require.ErrorIs(t, err, ErrInvalidPartial)
+
+	err = ValidatePartials(map[string]string{"transform": "reserved"})
+	require.ErrorIs(t, err, ErrInvalidPartial)
+}
+
+func TestRender_DefaultFunction(t *testing.T) {
+	const fallback = `{{ default "guest" .trigger_data.user.name }} {{ .trigger_data.plan | default "free" }}`
+
+	for _, mode := range []string{MissingKeyDefault, MissingKeyStrict, MissingKeyLenient} {
+		t.Run("mode "+mode, func(t *testing.T) {
+			result, err := RenderWithMissingKeys(fallback, map[string]any{"trigger_data": map[string]any{}}, mode)
+			require.NoError(t, err)
+			assert.Equal(t, "guest free", result)
+
+			result, err = RenderWithMissingKeys(fallback, map[string]any{
+				"trigger_data": map[string]any{"user": map[string]any{"name": "Ada"}, "plan": ""},
+			}, mode)
+			require.NoError(t, err)
+			assert.Equal(t, "Ada free", result, "an empty string falls back too")
+		})
+	}
+}
+
+func TestValidateMissingKeyMode(t *testing.T) {
+	for _, mode := range []string{MissingKeyDefault, MissingKeyStrict, MissingKeyLenient} {
+		require.NoError(t, ValidateMissingKeyMode(mode))
+	}
+
+	assert.ErrorIs(t, ValidateMissingKeyMode("zero"), ErrInvalidMissingKeyMode)
+}
+
+func TestRenderValueWithContext_RendersStringLeaves(t *testing.T) {
+	execCtx := &models.ExecutionContext{
+		TriggerData: map[string]any{"name": `Ada", "role": "admin`},
+	}
+
+	rendered, err := RenderValueWithContext(map[string]any{
+		"sub":    "{{ .trigger_data.name }}",
+		"scopes": []any{"read", "{{ .trigger_data.name }}"},
+		"level":  float64(2),
+	}, execCtx)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"sub":    `Ada", "role": "admin`,
+		"scopes": []any{"read", `Ada", "role": "admin`},
+		"level":  float64(2),
+	}, rendered)
+}
@@ -0,0 +1,267 @@
+package tracer
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otlpmetrichttp "go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+const (
+	meterName = "github.com/dukex/operion"
+
+	// NodeExecutionDurationMetric is the histogram of node execution durations, in seconds.
+	NodeExecutionDurationMetric = "operion.node.execution.duration"
+
+	// SourceEventsProcessedMetric counts source events published by providers.
+	SourceEventsProcessedMetric = "operion.source.events.processed"
+
+	// SourceEventsDroppedMetric counts source events providers dropped, by reason.
+	SourceEventsDroppedMetric = "operion.source.events.dropped"
+
+	// SourceEventDurationMetric is the histogram of source event publishing durations, in seconds.
+	SourceEventDurationMetric = "operion.source.event.duration"
+
+	// WorkerBacklogMetric is the gauge of node activations a worker has not processed, by state.
+	WorkerBacklogMetric = "operion.worker.backlog"
+
+	// PersistenceDegradedMetric is the gauge of executions a worker buffers because their
+	// persistence writes timed out, 0 while the persistence keeps up.
+	PersistenceDegradedMetric = "operion.worker.persistence_degraded"
+
+	// EventBusRejectedMetric counts event bus messages consumers rejected, by bus and reason.
+	EventBusRejectedMetric = "operion.eventbus.events.rejected"
+)
+
+// Reasons source events are dropped for.
+const (
+	// DropReasonInvalid is used for events failing the validation of their source, such as its JSON schema.
+	DropReasonInvalid = "invalid"
+
+	// DropReasonPublishFailed is used for events that could not be published to the source event bus.
+	DropReasonPublishFailed = "publish_failed"
+
+	// DropReasonOverloaded is used for events rejected because their source was at its concurrency limit.
+	DropReasonOverloaded = "overloaded"
+)
+
+// Reasons event bus messages are rejected for.
+const (
+	// RejectReasonUnsigned is used for messages without a signature on a bus that signs them.
+	RejectReasonUnsigned = "unsigned"
+
+	// RejectReasonInvalidSignature is used for messages whose signature does not match.
+	RejectReasonInvalidSignature = "invalid_signature"
+
+	// RejectReasonStaleSignature is used for messages signed longer ago than the signature maximum age.
+	RejectReasonStaleSignature = "stale_signature"
+)
+
+// InitMeter configures an OTLP/HTTP metrics exporter and registers it as the global meter provider.
+// The collector endpoint and push interval follow the standard OTEL_EXPORTER_OTLP_METRICS_ENDPOINT
+// (or OTEL_EXPORTER_OTLP_ENDPOINT) and OTEL_METRIC_EXPORT_INTERVAL environment variables.
+func InitMeter(ctx context.Context, serviceName string) (*sdkmetric.MeterProvider, error) {
+	r, err := newResource(serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	exporter, err := otlpmetrichttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
+		sdkmetric.WithResource(r),
+	)
+
+	otel.SetMeterProvider(mp)
+
+	return mp, nil
+}
+
+// NodeMetrics records node execution metrics.
+type NodeMetrics struct {
+	executionDuration metric.Float64Histogram
+}
+
+// NewNodeMetrics creates the node execution instruments on the given meter provider.
+func NewNodeMetrics(provider metric.MeterProvider) (*NodeMetrics, error) {
+	executionDuration, err := provider.Meter(meterName).Float64Histogram(
+		NodeExecutionDurationMetric,
+		metric.WithDescription("Duration of node executions"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NodeMetrics{executionDuration: executionDuration}, nil
+}
+
+// RecordExecution records how long a node of the given type took to execute and whether it succeeded.
+// It is a no-op on a nil NodeMetrics.
+func (m *NodeMetrics) RecordExecution(ctx context.Context, nodeType, status string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.executionDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		attribute.String("node.type", nodeType),
+		attribute.String("node.status", status),
+	))
+}
+
+// SourceMetrics records the source events of each provider and source.
+type SourceMetrics struct {
+	processed metric.Int64Counter
+	dropped   metric.Int64Counter
+	duration  metric.Float64Histogram
+}
+
+// NewSourceMetrics creates the source event instruments on the given meter provider.
+func NewSourceMetrics(provider metric.MeterProvider) (*SourceMetrics, error) {
+	meter := provider.Meter(meterName)
+
+	processed, err := meter.Int64Counter(
+		SourceEventsProcessedMetric,
+		metric.WithDescription("Source events published by providers"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dropped, err := meter.Int64Counter(
+		SourceEventsDroppedMetric,
+		metric.WithDescription("Source events dropped by providers"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram(
+		SourceEventDurationMetric,
+		metric.WithDescription("Duration of source event publishing"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SourceMetrics{processed: processed, dropped: dropped, duration: duration}, nil
+}
+
+// RecordProcessed records a source event the provider published and how long publishing it took.
+// It is a no-op on a nil SourceMetrics.
+func (m *SourceMetrics) RecordProcessed(ctx context.Context, providerID, sourceID string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	attributes := metric.WithAttributes(
+		attribute.String("provider.id", providerID),
+		attribute.String("source.id", sourceID),
+	)
+
+	m.processed.Add(ctx, 1, attributes)
+	m.duration.Record(ctx, duration.Seconds(), attributes)
+}
+
+// RecordDropped records a source event the provider dropped for the given reason.
+// It is a no-op on a nil SourceMetrics.
+func (m *SourceMetrics) RecordDropped(ctx context.Context, providerID, sourceID, reason string) {
+	if m == nil {
+		return
+	}
+
+	m.dropped.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("provider.id", providerID),
+		attribute.String("source.id", sourceID),
+		attribute.String("drop.reason", reason),
+	))
+}
+
+// EventBusMetrics records the messages event bus consumers reject.
+type EventBusMetrics struct {
+	rejected metric.Int64Counter
+}
+
+// NewEventBusMetrics creates the event bus instruments on the given meter provider.
+func NewEventBusMetrics(provider metric.MeterProvider) (*EventBusMetrics, error) {
+	rejected, err := provider.Meter(meterName).Int64Counter(
+		EventBusRejectedMetric,
+		metric.WithDescription("Event bus messages rejected by consumers"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventBusMetrics{rejected: rejected}, nil
+}
+
+// RecordRejected records a message of the named bus a consumer rejected for the given reason.
+// It is a no-op on a nil EventBusMetrics.
+func (m *EventBusMetrics) RecordRejected(ctx context.Context, bus, eventType, reason string) {
+	if m == nil {
+		return
+	}
+
+	m.rejected.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("eventbus.name", bus),
+		attribute.String("event.type", eventType),
+		attribute.String("reject.reason", reason),
+	))
+}
+
+// ObserveWorkerBacklog registers the worker backlog gauge, reporting the counts observe returns by
+// backlog state, such as queued or consumer_lag, each time metrics are collected.
+func ObserveWorkerBacklog(provider metric.MeterProvider, workerID string, observe func(ctx context.Context) (map[string]int64, error)) error {
+	meter := provider.Meter(meterName)
+
+	backlog, err := meter.Int64ObservableGauge(
+		WorkerBacklogMetric,
+		metric.WithDescription("Node activations the worker has not processed"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+		counts, err := observe(ctx)
+		if err != nil {
+			return err
+		}
+
+		for state, count := range counts {
+			observer.ObserveInt64(backlog, count, metric.WithAttributes(
+				attribute.String("worker.id", workerID),
+				attribute.String("backlog.state", state),
+			))
+		}
+
+		return nil
+	}, backlog)
+
+	return err
+}
+
+// ObservePersistenceDegraded registers the persistence degraded gauge, reporting the number of
+// executions observe returns each time metrics are collected.
+func ObservePersistenceDegraded(provider metric.MeterProvider, workerID string, observe func() int) error {
+	_, err := provider.Meter(meterName).Int64ObservableGauge(
+		PersistenceDegradedMetric,
+		metric.WithDescription("Executions buffered by the worker because their persistence writes timed out"),
+		metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+			observer.Observe(int64(observe()), metric.WithAttributes(attribute.String("worker.id", workerID)))
+
+			return nil
+		}),
+	)
+
+	return err
+}
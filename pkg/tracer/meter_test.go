@@ -0,0 +1,124 @@
+package tracer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNodeMetrics_RecordExecution(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	metrics, err := NewNodeMetrics(provider)
+	require.NoError(t, err)
+
+	metrics.RecordExecution(t.Context(), "log", "success", 250*time.Millisecond)
+	metrics.RecordExecution(t.Context(), "log", "success", 750*time.Millisecond)
+
+	var collected metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &collected))
+	require.Len(t, collected.ScopeMetrics, 1)
+	require.Len(t, collected.ScopeMetrics[0].Metrics, 1)
+
+	exported := collected.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, NodeExecutionDurationMetric, exported.Name)
+	assert.Equal(t, "s", exported.Unit)
+
+	histogram, ok := exported.Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, histogram.DataPoints, 1)
+
+	point := histogram.DataPoints[0]
+	assert.Equal(t, uint64(2), point.Count)
+	assert.InDelta(t, 1.0, point.Sum, 1e-9)
+
+	nodeType, _ := point.Attributes.Value(attribute.Key("node.type"))
+	assert.Equal(t, "log", nodeType.AsString())
+}
+
+func TestNodeMetrics_NilIsNoop(t *testing.T) {
+	var metrics *NodeMetrics
+
+	assert.NotPanics(t, func() {
+		metrics.RecordExecution(t.Context(), "log", "success", time.Second)
+	})
+}
+
+func TestSourceMetrics_Record(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+
+	metrics, err := NewSourceMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+
+	metrics.RecordProcessed(t.Context(), "webhook", "orders", 100*time.Millisecond)
+	metrics.RecordDropped(t.Context(), "webhook", "orders", DropReasonPublishFailed)
+
+	var collected metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &collected))
+	require.Len(t, collected.ScopeMetrics, 1)
+
+	exported := make(map[string]metricdata.Metrics)
+	for _, m := range collected.ScopeMetrics[0].Metrics {
+		exported[m.Name] = m
+	}
+
+	processed, ok := exported[SourceEventsProcessedMetric].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, processed.DataPoints, 1)
+	assert.Equal(t, int64(1), processed.DataPoints[0].Value)
+
+	dropped, ok := exported[SourceEventsDroppedMetric].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, dropped.DataPoints, 1)
+
+	reason, _ := dropped.DataPoints[0].Attributes.Value(attribute.Key("drop.reason"))
+	assert.Equal(t, DropReasonPublishFailed, reason.AsString())
+
+	duration, ok := exported[SourceEventDurationMetric].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 1)
+	assert.InDelta(t, 0.1, duration.DataPoints[0].Sum, 1e-9)
+
+	var nilMetrics *SourceMetrics
+
+	assert.NotPanics(t, func() {
+		nilMetrics.RecordProcessed(t.Context(), "webhook", "orders", time.Second)
+		nilMetrics.RecordDropped(t.Context(), "webhook", "orders", DropReasonInvalid)
+	})
+}
+
+func TestInitMeter_PushesToConfiguredEndpoint(t *testing.T) {
+	var exports atomic.Int32
+
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/metrics" {
+			exports.Add(1)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", collector.URL+"/v1/metrics")
+
+	provider, err := InitMeter(t.Context(), "operion-test")
+	require.NoError(t, err)
+
+	metrics, err := NewNodeMetrics(provider)
+	require.NoError(t, err)
+
+	metrics.RecordExecution(t.Context(), "log", "success", time.Second)
+
+	require.NoError(t, provider.ForceFlush(t.Context()))
+	require.NoError(t, provider.Shutdown(t.Context()))
+	assert.Positive(t, exports.Load())
+}
@@ -13,13 +13,7 @@ import (
 )
 
 func InitTracer(ctx context.Context, serviceName string) (*sdktrace.TracerProvider, error) {
-	r, err := resource.Merge(
-		resource.Default(),
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceName(serviceName),
-		),
-	)
+	r, err := newResource(serviceName)
 	if err != nil {
 		return nil, err
 	}
@@ -40,3 +34,14 @@ func InitTracer(ctx context.Context, serviceName string) (*sdktrace.TracerProvid
 
 	return tp, nil
 }
+
+// newResource describes the service in exported telemetry.
+func newResource(serviceName string) (*resource.Resource, error) {
+	return resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+		),
+	)
+}
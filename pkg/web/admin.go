@@ -0,0 +1,29 @@
+package web
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// ConfigureAdminToken sets the bearer token admin-only requests must carry. Without a token every
+// admin-only request is refused.
+func (h *APIHandlers) ConfigureAdminToken(token string) {
+	h.adminToken = token
+}
+
+// isAdmin reports whether the request carries the admin bearer token.
+func (h *APIHandlers) isAdmin(c fiber.Ctx) bool {
+	if h.adminToken == "" {
+		return false
+	}
+
+	token, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+
+	return found && subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) == 1
+}
+
+func forbidden(c fiber.Ctx, detail string) error {
+	return writeError(c, fiber.StatusForbidden, ErrorCodeForbidden, detail, nil)
+}
@@ -1,33 +1,118 @@
 package web
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/dukex/operion/pkg/workflow"
+	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v3"
-	"github.com/moogar0880/problems"
 )
 
+// Machine-readable error codes returned in the error envelope.
+const (
+	ErrorCodeValidation  = "validation_error"
+	ErrorCodeNotFound    = "not_found"
+	ErrorCodeForbidden   = "forbidden"
+	ErrorCodeConflict    = "conflict"
+	ErrorCodeInternal    = "internal_error"
+	ErrorCodeTooLarge    = "payload_too_large"
+	ErrorCodeEvaluation  = "evaluation_error"
+	ErrorCodeUnavailable = "service_unavailable"
+)
+
+// ErrorResponse is the JSON envelope of every API error response.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes an API error.
+type ErrorBody struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError describes a request field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// writeError writes the error envelope with the given status.
+func writeError(c fiber.Ctx, status int, code, message string, fields []FieldError) error {
+	return c.Status(status).JSON(ErrorResponse{
+		Error: ErrorBody{
+			Code:    code,
+			Message: message,
+			Fields:  fields,
+		},
+	})
+}
+
 func badRequest(c fiber.Ctx, detail string) error {
-	problem := problems.NewStatusProblem(400).
-		WithInstance(c.Path()).
-		WithType("validation_error").
-		WithDetail(detail)
+	return writeError(c, fiber.StatusBadRequest, ErrorCodeValidation, detail, nil)
+}
 
-	return c.Status(fiber.StatusBadRequest).JSON(problem)
+// validationError writes a bad request listing the fields rejected by the validator.
+func validationError(c fiber.Ctx, err error) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return badRequest(c, err.Error())
+	}
+
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fields = append(fields, FieldError{
+			Field:   fieldErr.Field(),
+			Rule:    fieldErr.Tag(),
+			Message: fieldErrorMessage(fieldErr),
+		})
+	}
+
+	return writeError(c, fiber.StatusBadRequest, ErrorCodeValidation, "Request validation failed", fields)
+}
+
+// nodeConfigError writes a bad request listing the config fields of a node that do not match the
+// schema of its node type.
+func nodeConfigError(c fiber.Ctx, err *workflow.NodeConfigError) error {
+	fields := make([]FieldError, 0, len(err.Fields))
+	for _, field := range err.Fields {
+		fields = append(fields, FieldError{
+			Field:   "config." + field.Field,
+			Rule:    field.Rule,
+			Message: field.Message,
+		})
+	}
+
+	return writeError(c, fiber.StatusBadRequest, ErrorCodeValidation, "Node config does not match the schema of its type", fields)
+}
+
+// fieldErrorMessage describes a validator failure in plain words.
+func fieldErrorMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return fieldErr.Field() + " is required"
+	case "min":
+		return fmt.Sprintf("%s must be at least %s characters long", fieldErr.Field(), fieldErr.Param())
+	default:
+		return fmt.Sprintf("%s failed the '%s' validation", fieldErr.Field(), fieldErr.Tag())
+	}
 }
 
 func notFound(c fiber.Ctx, detail string) error {
-	problem := problems.NewStatusProblem(404).
-		WithInstance(c.Path()).
-		WithType("not_found").
-		WithDetail(detail)
+	return writeError(c, fiber.StatusNotFound, ErrorCodeNotFound, detail, nil)
+}
 
-	return c.Status(fiber.StatusNotFound).JSON(problem)
+func conflict(c fiber.Ctx, detail string) error {
+	return writeError(c, fiber.StatusConflict, ErrorCodeConflict, detail, nil)
 }
 
-func internalError(c fiber.Ctx, err error) error {
-	problem := problems.NewStatusProblem(500).
-		WithInstance(c.Path()).
-		WithType("internal_error").
-		WithError(err)
+func unavailable(c fiber.Ctx, detail string) error {
+	return writeError(c, fiber.StatusServiceUnavailable, ErrorCodeUnavailable, detail, nil)
+}
 
-	return c.Status(fiber.StatusInternalServerError).JSON(problem)
+func internalError(c fiber.Ctx, err error) error {
+	return writeError(c, fiber.StatusInternalServerError, ErrorCodeInternal, err.Error(), nil)
 }
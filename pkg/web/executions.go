@@ -0,0 +1,68 @@
+package web
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Heavy execution fields that GetExecution returns only when included.
+const (
+	executionFieldNodeResults = "node_results"
+	executionFieldTriggerData = "trigger_data"
+	executionFieldVariables   = "variables"
+
+	// redactedValue replaces values masked by redact.
+	redactedValue = "[REDACTED]"
+)
+
+// executionFields includes every heavy execution field, the default view.
+var executionFields = map[string]bool{
+	executionFieldNodeResults: true,
+	executionFieldTriggerData: true,
+	executionFieldVariables:   true,
+}
+
+// parseExecutionFields parses a comma-separated list of heavy execution fields.
+// "none" and an empty list include none of them.
+func parseExecutionFields(raw string) (map[string]bool, error) {
+	include := make(map[string]bool)
+
+	for field := range strings.SplitSeq(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || field == "none" {
+			continue
+		}
+
+		if !executionFields[field] {
+			return nil, fmt.Errorf("invalid include field %q (supported: node_results, trigger_data, variables, none)", field)
+		}
+
+		include[field] = true
+	}
+
+	return include, nil
+}
+
+// redact replaces every scalar in value with a placeholder, keeping map keys and list lengths.
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, item := range v {
+			redacted[key] = redact(item)
+		}
+
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redact(item)
+		}
+
+		return redacted
+	case nil:
+		return nil
+	default:
+		return redactedValue
+	}
+}
@@ -2,30 +2,63 @@
 package web
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/dukex/operion/pkg/models"
 	"github.com/dukex/operion/pkg/registry"
 	"github.com/dukex/operion/pkg/workflow"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v3"
 )
 
+const (
+	// nextCursorHeader carries the cursor of the next page of execution node results.
+	nextCursorHeader = "X-Next-Cursor"
+
+	// defaultStatsWindow is the window used by workflow stats when no 'from' is given.
+	defaultStatsWindow = 24 * time.Hour
+
+	// defaultNodeResultsLimit and maxNodeResultsLimit bound the pages of execution node results.
+	defaultNodeResultsLimit = 100
+	maxNodeResultsLimit     = 1000
+)
+
 type APIHandlers struct {
-	repository *workflow.Repository
-	validator  *validator.Validate
-	registry   *registry.Registry
+	repository       *workflow.Repository
+	executionService *workflow.ExecutionService
+	nodeService      *workflow.NodeService
+	heartbeats       *workflow.HeartbeatMonitor
+	validator        *validator.Validate
+	registry         *registry.Registry
+
+	streamPollInterval      time.Duration
+	streamHeartbeatInterval time.Duration
+
+	adminToken string
 }
 
 func NewAPIHandlers(
 	repository *workflow.Repository,
+	executionService *workflow.ExecutionService,
+	nodeService *workflow.NodeService,
+	heartbeats *workflow.HeartbeatMonitor,
 	validator *validator.Validate,
 	registry *registry.Registry,
 ) *APIHandlers {
 	return &APIHandlers{
-		repository: repository,
-		validator:  validator,
-		registry:   registry,
+		repository:       repository,
+		executionService: executionService,
+		nodeService:      nodeService,
+		heartbeats:       heartbeats,
+		validator:        validator,
+		registry:         registry,
+
+		streamPollInterval:      DefaultStreamPollInterval,
+		streamHeartbeatInterval: DefaultStreamHeartbeatInterval,
 	}
 }
 
@@ -83,13 +116,74 @@ func NewAPIHandlers(
 // 	return nil
 // }
 
+// GetWorkflows lists the workflows. Without query parameters it returns them all; with a limit,
+// offset, cursor or include_deleted it returns a page of them, with the cursor of the next page.
 func (h *APIHandlers) GetWorkflows(c fiber.Ctx) error {
-	workflows, err := h.repository.FetchAll(c.Context())
+	limit := fiber.Query[int](c, "limit")
+	offset := fiber.Query[int](c, "offset")
+	cursor := c.Query("cursor")
+	includeDeleted := fiber.Query[bool](c, "include_deleted")
+
+	if includeDeleted && !h.isAdmin(c) {
+		return forbidden(c, "Listing deleted workflows requires the admin token")
+	}
+
+	if limit == 0 && offset == 0 && cursor == "" && !includeDeleted {
+		workflows, err := h.repository.FetchAll(c.Context())
+		if err != nil {
+			return internalError(c, err)
+		}
+
+		return c.JSON(workflows)
+	}
+
+	if limit < 0 || offset < 0 {
+		return badRequest(c, "limit and offset must not be negative")
+	}
+
+	page, err := h.repository.List(c.Context(), models.ListOptions{
+		Limit:          limit,
+		Offset:         offset,
+		Cursor:         cursor,
+		IncludeDeleted: includeDeleted,
+	})
 	if err != nil {
+		if errors.Is(err, models.ErrInvalidCursor) {
+			return badRequest(c, "Invalid cursor")
+		}
+
 		return internalError(c, err)
 	}
 
-	return c.JSON(workflows)
+	return c.JSON(page)
+}
+
+// RestoreWorkflow undeletes a soft deleted workflow. It is admin-only.
+func (h *APIHandlers) RestoreWorkflow(c fiber.Ctx) error {
+	if !h.isAdmin(c) {
+		return forbidden(c, "Restoring workflows requires the admin token")
+	}
+
+	id := c.Params("id")
+
+	if id == "" {
+		return badRequest(c, "Workflow ID is required")
+	}
+
+	restored, err := h.repository.Restore(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, workflow.ErrWorkflowNotFound) {
+			return notFound(c, "Workflow not found")
+		}
+
+		if errors.Is(err, workflow.ErrWorkflowNotDeleted) {
+			return conflict(c, "Workflow is not deleted")
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.JSON(restored)
 }
 
 func (h *APIHandlers) GetWorkflow(c fiber.Ctx) error {
@@ -111,6 +205,557 @@ func (h *APIHandlers) GetWorkflow(c fiber.Ctx) error {
 	return c.JSON(workflow)
 }
 
+// GetWorkflowHeartbeats returns when each scheduled trigger of the published workflows last ran
+// and when it is expected to run next, flagging monitored triggers that missed their schedule.
+func (h *APIHandlers) GetWorkflowHeartbeats(c fiber.Ctx) error {
+	statuses, err := h.heartbeats.Status(c.Context(), time.Now().UTC())
+	if err != nil {
+		return internalError(c, err)
+	}
+
+	if statuses == nil {
+		statuses = []workflow.HeartbeatStatus{}
+	}
+
+	return c.JSON(statuses)
+}
+
+// GetWorkflowDocs describes the trigger data the workflow expects and what each of its nodes produces.
+func (h *APIHandlers) GetWorkflowDocs(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	if id == "" {
+		return badRequest(c, "Workflow ID is required")
+	}
+
+	wf, err := h.repository.FetchByID(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, workflow.ErrWorkflowNotFound) {
+			return notFound(c, "Workflow not found")
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.JSON(workflow.GenerateDocs(c.Context(), h.registry, wf))
+}
+
+// GetNodeTemplateContext lists the template context paths the config of a workflow node can read,
+// inferred from the schemas of its upstream nodes, for editor autocomplete.
+func (h *APIHandlers) GetNodeTemplateContext(c fiber.Ctx) error {
+	id := c.Params("id")
+	nodeID := c.Params("nodeId")
+
+	if id == "" || nodeID == "" {
+		return badRequest(c, "Workflow ID and node ID are required")
+	}
+
+	wf, err := h.repository.FetchByID(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, workflow.ErrWorkflowNotFound) {
+			return notFound(c, "Workflow not found")
+		}
+
+		return internalError(c, err)
+	}
+
+	paths, err := workflow.TemplateContextPaths(c.Context(), h.registry, wf, nodeID)
+	if err != nil {
+		if errors.Is(err, workflow.ErrNodeNotFound) {
+			return notFound(c, "Node not found")
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.JSON(paths)
+}
+
+// GetWorkflowStats returns execution statistics for a workflow within a time window.
+// The window defaults to the last 24 hours; from and to are RFC3339 timestamps.
+func (h *APIHandlers) GetWorkflowStats(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	if id == "" {
+		return badRequest(c, "Workflow ID is required")
+	}
+
+	to := time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return badRequest(c, "Invalid 'to' timestamp, expected RFC3339")
+		}
+
+		to = parsed
+	}
+
+	from := to.Add(-defaultStatsWindow)
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return badRequest(c, "Invalid 'from' timestamp, expected RFC3339")
+		}
+
+		from = parsed
+	}
+
+	if !from.Before(to) {
+		return badRequest(c, "'from' must be before 'to'")
+	}
+
+	stats, err := h.repository.Stats(c.Context(), id, from, to)
+	if err != nil {
+		if errors.Is(err, workflow.ErrWorkflowNotFound) {
+			return notFound(c, "Workflow not found")
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.JSON(stats)
+}
+
+// GetWorkflowGroups lists the workflow groups, each a logical workflow with its current
+// published and draft versions.
+func (h *APIHandlers) GetWorkflowGroups(c fiber.Ctx) error {
+	groups, err := h.repository.ListGroups(c.Context())
+	if err != nil {
+		return internalError(c, err)
+	}
+
+	return c.JSON(groups)
+}
+
+// GetWorkflowGroup returns the published and draft versions of a workflow group and the
+// versions it published before.
+func (h *APIHandlers) GetWorkflowGroup(c fiber.Ctx) error {
+	id := c.Params("groupId")
+
+	if id == "" {
+		return badRequest(c, "Workflow group ID is required")
+	}
+
+	group, err := h.repository.FetchGroup(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, workflow.ErrWorkflowGroupNotFound) {
+			return notFound(c, "Workflow group not found")
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.JSON(group)
+}
+
+// ImportWorkflow creates a draft workflow from a JSON or YAML definition.
+// YAML is selected with a Content-Type of application/yaml.
+func (h *APIHandlers) ImportWorkflow(c fiber.Ctx) error {
+	definition, err := workflow.DecodeDefinition(c.Body(), workflow.IsYAMLContentType(c.Get(fiber.HeaderContentType)))
+	if err != nil {
+		return badRequest(c, err.Error())
+	}
+
+	if err := workflow.PrepareImport(definition); err != nil {
+		return badRequest(c, err.Error())
+	}
+
+	if err := h.validator.Struct(definition); err != nil {
+		return validationError(c, err)
+	}
+
+	imported, err := h.repository.Create(c.Context(), definition)
+	if err != nil {
+		return internalError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(imported)
+}
+
+// CreateWorkflowNode adds a node to a workflow. The node config is validated against the schema
+// of its node type, listing the missing or mistyped config fields when it does not match.
+func (h *APIHandlers) CreateWorkflowNode(c fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return badRequest(c, "Workflow ID is required")
+	}
+
+	var node models.WorkflowNode
+	if err := c.Bind().JSON(&node); err != nil {
+		return badRequest(c, "Invalid JSON format")
+	}
+
+	if err := h.validator.Struct(node); err != nil {
+		return validationError(c, err)
+	}
+
+	created, err := h.nodeService.CreateNode(c.Context(), id, &node)
+	if err != nil {
+		var configErr *workflow.NodeConfigError
+
+		switch {
+		case errors.As(err, &configErr):
+			return nodeConfigError(c, configErr)
+		case errors.Is(err, workflow.ErrWorkflowNotFound):
+			return notFound(c, "Workflow not found")
+		case errors.Is(err, workflow.ErrInvalidNode):
+			return badRequest(c, err.Error())
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// PatchWorkflowNode partially updates a workflow node with a JSON Merge Patch (RFC 7396):
+// only the provided keys change and null removes a key.
+func (h *APIHandlers) PatchWorkflowNode(c fiber.Ctx) error {
+	id := c.Params("id")
+	nodeID := c.Params("nodeId")
+
+	if id == "" || nodeID == "" {
+		return badRequest(c, "Workflow ID and node ID are required")
+	}
+
+	var patch map[string]any
+	if err := json.Unmarshal(c.Body(), &patch); err != nil || patch == nil {
+		return badRequest(c, "Request body must be a JSON object")
+	}
+
+	node, err := h.nodeService.PatchNode(c.Context(), id, nodeID, patch)
+	if err != nil {
+		var configErr *workflow.NodeConfigError
+
+		switch {
+		case errors.As(err, &configErr):
+			return nodeConfigError(c, configErr)
+		case errors.Is(err, workflow.ErrWorkflowNotFound):
+			return notFound(c, "Workflow not found")
+		case errors.Is(err, workflow.ErrNodeNotFound):
+			return notFound(c, "Node not found")
+		case errors.Is(err, workflow.ErrInvalidNodePatch):
+			return badRequest(c, err.Error())
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.JSON(node)
+}
+
+// layoutPosition is the position of a node in a layout update; both coordinates are required.
+type layoutPosition struct {
+	X *int `json:"x"`
+	Y *int `json:"y"`
+}
+
+// PatchWorkflowLayout moves workflow nodes to new editor positions. The body maps node IDs to
+// {"x", "y"}; only the positions of the listed nodes change.
+func (h *APIHandlers) PatchWorkflowLayout(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	if id == "" {
+		return badRequest(c, "Workflow ID is required")
+	}
+
+	var layout map[string]layoutPosition
+	if err := json.Unmarshal(c.Body(), &layout); err != nil || layout == nil {
+		return badRequest(c, "Request body must be a JSON object of node positions")
+	}
+
+	if len(layout) == 0 {
+		return badRequest(c, "At least one node position is required")
+	}
+
+	positions := make(map[string]models.NodePosition, len(layout))
+
+	for nodeID, position := range layout {
+		if position.X == nil || position.Y == nil {
+			return badRequest(c, "Position of node '"+nodeID+"' requires x and y")
+		}
+
+		positions[nodeID] = models.NodePosition{X: *position.X, Y: *position.Y}
+	}
+
+	if err := h.nodeService.UpdateLayout(c.Context(), id, positions); err != nil {
+		switch {
+		case errors.Is(err, workflow.ErrWorkflowNotFound):
+			return notFound(c, "Workflow not found")
+		case errors.Is(err, workflow.ErrNodeNotFound):
+			return notFound(c, err.Error())
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.JSON(positions)
+}
+
+// GetExecution returns an execution with its node results, trigger data and variables.
+// ?include= lists the heavy fields to return (node_results, trigger_data, variables); the
+// lightweight view ?include=none returns only status, timestamps and error message.
+// ?redact_trigger_data=true masks every trigger data value while keeping its keys.
+func (h *APIHandlers) GetExecution(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	if id == "" {
+		return badRequest(c, "Execution ID is required")
+	}
+
+	include := executionFields
+	if c.RequestCtx().QueryArgs().Has("include") {
+		var err error
+
+		include, err = parseExecutionFields(c.Query("include"))
+		if err != nil {
+			return badRequest(c, err.Error())
+		}
+	}
+
+	execution, err := h.executionService.GetExecution(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, workflow.ErrExecutionNotFound) {
+			return notFound(c, "Execution not found")
+		}
+
+		return internalError(c, err)
+	}
+
+	if !include[executionFieldNodeResults] {
+		execution.NodeResults = nil
+	}
+
+	if !include[executionFieldTriggerData] {
+		execution.TriggerData = nil
+	} else if fiber.Query[bool](c, "redact_trigger_data") {
+		execution.TriggerData, _ = redact(execution.TriggerData).(map[string]any)
+	}
+
+	if !include[executionFieldVariables] {
+		execution.Variables = nil
+	}
+
+	return c.JSON(execution)
+}
+
+// CompareExecutions returns the differences between the node results and trigger data of the
+// executions given by the a and b query parameters, to debug workflows diverging across runs.
+func (h *APIHandlers) CompareExecutions(c fiber.Ctx) error {
+	idA, idB := c.Query("a"), c.Query("b")
+
+	if idA == "" || idB == "" {
+		return badRequest(c, "Query parameters 'a' and 'b' with the IDs of the executions to compare are required")
+	}
+
+	comparison, err := h.executionService.CompareExecutions(c.Context(), idA, idB)
+	if err != nil {
+		if errors.Is(err, workflow.ErrExecutionNotFound) {
+			return notFound(c, err.Error())
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.JSON(comparison)
+}
+
+// GetExecutionNodeResults returns a page of the node results of an execution ordered by key,
+// optionally only those with the given status, with the cursor of the next page in X-Next-Cursor.
+func (h *APIHandlers) GetExecutionNodeResults(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	if id == "" {
+		return badRequest(c, "Execution ID is required")
+	}
+
+	limit := fiber.Query(c, "limit", defaultNodeResultsLimit)
+	if limit <= 0 || limit > maxNodeResultsLimit {
+		return badRequest(c, fmt.Sprintf("limit must be between 1 and %d", maxNodeResultsLimit))
+	}
+
+	page, err := h.executionService.ListNodeResults(c.Context(), id, models.NodeResultQuery{
+		Status: c.Query("status"),
+		Limit:  limit,
+		Cursor: c.Query("cursor"),
+	})
+	if err != nil {
+		if errors.Is(err, workflow.ErrExecutionNotFound) {
+			return notFound(c, "Execution not found")
+		}
+
+		if errors.Is(err, models.ErrInvalidCursor) {
+			return badRequest(c, "Invalid cursor")
+		}
+
+		return internalError(c, err)
+	}
+
+	if page.NextCursor != "" {
+		c.Set(nextCursorHeader, page.NextCursor)
+	}
+
+	return c.JSON(page.Results)
+}
+
+// DeleteExecution deletes an execution along with its offloaded payloads.
+func (h *APIHandlers) DeleteExecution(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	if id == "" {
+		return badRequest(c, "Execution ID is required")
+	}
+
+	if err := h.executionService.DeleteExecution(c.Context(), id); err != nil {
+		if errors.Is(err, workflow.ErrExecutionNotFound) {
+			return notFound(c, "Execution not found")
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ResumeExecutionFromNode starts a new execution that re-runs a prior execution from the given node.
+func (h *APIHandlers) ResumeExecutionFromNode(c fiber.Ctx) error {
+	id := c.Params("id")
+	nodeID := c.Params("nodeId")
+
+	if id == "" || nodeID == "" {
+		return badRequest(c, "Execution ID and node ID are required")
+	}
+
+	execution, err := h.executionService.ResumeFromNode(c.Context(), id, nodeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, workflow.ErrExecutionNotFound):
+			return notFound(c, "Execution not found")
+		case errors.Is(err, workflow.ErrWorkflowNotFound):
+			return notFound(c, "Workflow not found")
+		case errors.Is(err, workflow.ErrNodeNotFound):
+			return notFound(c, "Node not found")
+		case errors.Is(err, workflow.ErrUnsatisfiedInputs):
+			return badRequest(c, err.Error())
+		case errors.Is(err, workflow.ErrEventBusNotConfigured):
+			return unavailable(c, "Resuming executions requires an event bus")
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(execution)
+}
+
+// PatchVariablesRequest is the body of a change to the variables of a paused execution.
+type PatchVariablesRequest struct {
+	Variables map[string]any `json:"variables"  validate:"required,min=1"`
+	ChangedBy string         `json:"changed_by"`
+}
+
+// PatchExecutionVariables merges values into the variables of a paused execution before it
+// resumes, e.g. to correct a value while it awaits approval.
+func (h *APIHandlers) PatchExecutionVariables(c fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return badRequest(c, "Execution ID is required")
+	}
+
+	var request PatchVariablesRequest
+	if err := c.Bind().JSON(&request); err != nil {
+		return badRequest(c, "Invalid JSON format")
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		return validationError(c, err)
+	}
+
+	execution, err := h.executionService.PatchVariables(c.Context(), id, request.Variables, request.ChangedBy)
+	if err != nil {
+		switch {
+		case errors.Is(err, workflow.ErrExecutionNotFound):
+			return notFound(c, "Execution not found")
+		case errors.Is(err, workflow.ErrExecutionNotPaused):
+			return conflict(c, "Execution is not paused")
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.JSON(execution)
+}
+
+// ApprovalDecisionRequest is the body of an approval decision.
+type ApprovalDecisionRequest struct {
+	Decision string `json:"decision" validate:"required,oneof=approve reject"`
+	Comment  string `json:"comment"`
+}
+
+// DecideApproval approves or rejects the pending approval request identified by its token,
+// resuming the paused execution on the approval node's approved or rejected port.
+func (h *APIHandlers) DecideApproval(c fiber.Ctx) error {
+	token := c.Params("token")
+	if token == "" {
+		return badRequest(c, "Approval token is required")
+	}
+
+	var request ApprovalDecisionRequest
+	if err := c.Bind().JSON(&request); err != nil {
+		return badRequest(c, "Invalid JSON format")
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		return validationError(c, err)
+	}
+
+	execution, err := h.executionService.DecideApproval(c.Context(), token, request.Decision == "approve", request.Comment)
+	if err != nil {
+		switch {
+		case errors.Is(err, workflow.ErrApprovalNotFound):
+			return notFound(c, "Approval request not found")
+		case errors.Is(err, workflow.ErrApprovalExpired):
+			return conflict(c, "Approval request expired")
+		case errors.Is(err, workflow.ErrEventBusNotConfigured):
+			return unavailable(c, "Deciding approvals requires an event bus")
+		}
+
+		return internalError(c, err)
+	}
+
+	return c.JSON(execution)
+}
+
+// ConditionTestRequest is the body of a condition test.
+type ConditionTestRequest struct {
+	Language   string         `json:"language"`
+	Expression string         `json:"expression" validate:"required"`
+	Context    map[string]any `json:"context"`
+}
+
+// TestCondition evaluates a condition expression against the given context the way a conditional
+// node would, so the editor can check an expression before saving it.
+func (h *APIHandlers) TestCondition(c fiber.Ctx) error {
+	var request ConditionTestRequest
+	if err := c.Bind().JSON(&request); err != nil {
+		return badRequest(c, "Invalid JSON format")
+	}
+
+	if err := h.validator.Struct(request); err != nil {
+		return validationError(c, err)
+	}
+
+	result, err := workflow.TestCondition(request.Language, request.Expression, request.Context)
+	if err != nil {
+		if errors.Is(err, workflow.ErrUnsupportedConditionLanguage) {
+			return badRequest(c, err.Error())
+		}
+
+		return writeError(c, fiber.StatusUnprocessableEntity, ErrorCodeEvaluation, err.Error(), nil)
+	}
+
+	return c.JSON(result)
+}
+
 // func (h *APIHandlers) CreateWorkflow(c fiber.Ctx) error {
 // 	var workflow models.Workflow
 // 	if err := c.Bind().JSON(&workflow); err != nil {
@@ -118,7 +763,7 @@ func (h *APIHandlers) GetWorkflow(c fiber.Ctx) error {
 // 	}
 
 // 	if err := h.validator.Struct(workflow); err != nil {
-// 		return badRequest(c, err.Error())
+// 		return validationError(c, err)
 // 	}
 
 // 	createdWorkflow, err := h.repository.Create(&workflow)
@@ -175,7 +820,7 @@ func (h *APIHandlers) GetWorkflow(c fiber.Ctx) error {
 // 	}
 
 // 	if err := h.validator.Struct(patchedWorkflow); err != nil {
-// 		return badRequestWithError(c, err)
+// 		return validationError(c, err)
 // 	}
 
 // 	updatedWorkflow, err := h.repository.Update(id, &patchedWorkflow)
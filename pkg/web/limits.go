@@ -0,0 +1,30 @@
+package web
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// DefaultBodyLimit is the default maximum size in bytes of create and update request bodies.
+const DefaultBodyLimit = 1024 * 1024
+
+// BodyLimit rejects POST, PUT and PATCH requests whose body is larger than limit bytes with
+// 413 Payload Too Large. A limit of 0 or less accepts bodies of any size.
+func BodyLimit(limit int) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if limit <= 0 {
+			return c.Next()
+		}
+
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch:
+			if size := len(c.Body()); size > limit {
+				return writeError(c, fiber.StatusRequestEntityTooLarge, ErrorCodeTooLarge,
+					fmt.Sprintf("Request body of %d bytes exceeds the limit of %d bytes", size, limit), nil)
+			}
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,190 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/workflow"
+	"github.com/gofiber/fiber/v3"
+)
+
+const (
+	// DefaultStreamPollInterval is how often an execution stream checks the execution for changes.
+	DefaultStreamPollInterval = 500 * time.Millisecond
+
+	// DefaultStreamHeartbeatInterval is how often an execution stream sends a comment, so proxies
+	// do not close it while the execution is idle.
+	DefaultStreamHeartbeatInterval = 15 * time.Second
+)
+
+// Execution stream events.
+const (
+	streamEventStatus       = "status"
+	streamEventNodeFinished = "node_finished"
+	streamEventError        = "error"
+	streamEventEnd          = "end"
+)
+
+// streamEvent is a server-sent event of an execution stream.
+type streamEvent struct {
+	Name string
+	Data any
+}
+
+// executionStream tracks what a stream already sent about an execution.
+type executionStream struct {
+	sent   map[string]bool
+	status models.ExecutionStatus
+}
+
+// changes returns the events describing what changed in execution since the last call: its status,
+// and the node results stored since, in the order they were produced.
+func (s *executionStream) changes(execution *models.ExecutionContext) []streamEvent {
+	var events []streamEvent
+
+	if execution.Status != s.status {
+		s.status = execution.Status
+		events = append(events, streamEvent{Name: streamEventStatus, Data: map[string]any{"status": execution.Status}})
+	}
+
+	var keys []string
+
+	for key := range execution.NodeResults {
+		if !s.sent[key] {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b string) int {
+		if order := execution.NodeResults[a].Timestamp.Compare(execution.NodeResults[b].Timestamp); order != 0 {
+			return order
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	for _, key := range keys {
+		s.sent[key] = true
+		nodeID, port, _ := models.ParseNodeResultKey(key)
+		result := execution.NodeResults[key]
+
+		events = append(events, streamEvent{Name: streamEventNodeFinished, Data: map[string]any{
+			"node_id": nodeID,
+			"port":    port,
+			"status":  result.Status,
+			"error":   result.Error,
+			"data":    result.Data,
+		}})
+	}
+
+	return events
+}
+
+// ConfigureStreaming sets how often execution streams check for changes and send heartbeats.
+func (h *APIHandlers) ConfigureStreaming(pollInterval, heartbeatInterval time.Duration) {
+	h.streamPollInterval = pollInterval
+	h.streamHeartbeatInterval = heartbeatInterval
+}
+
+// StreamExecution streams the progress of an execution as server-sent events: a status event when
+// its status changes, a node_finished event per stored node result and an end event once it reaches
+// a terminal status, after which the stream is closed.
+func (h *APIHandlers) StreamExecution(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	execution, err := h.executionService.GetExecution(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, workflow.ErrExecutionNotFound) {
+			return notFound(c, "Execution not found")
+		}
+
+		return internalError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	return c.SendStreamWriter(func(w *bufio.Writer) {
+		h.streamExecution(w, id, execution)
+	})
+}
+
+// streamExecution writes the events of the execution until it ends or the client disconnects,
+// which surfaces as a failed write.
+func (h *APIHandlers) streamExecution(w *bufio.Writer, id string, execution *models.ExecutionContext) {
+	poll := time.NewTicker(h.streamPollInterval)
+	defer poll.Stop()
+
+	heartbeat := time.NewTicker(h.streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	stream := &executionStream{sent: make(map[string]bool)}
+
+	for {
+		for _, event := range stream.changes(execution) {
+			if err := writeStreamEvent(w, event); err != nil {
+				return
+			}
+		}
+
+		if execution.Status.IsTerminal() {
+			_ = writeStreamEvent(w, streamEvent{Name: streamEventEnd, Data: map[string]any{
+				"status":        execution.Status,
+				"error_message": execution.ErrorMessage,
+			}})
+
+			return
+		}
+
+		select {
+		case <-heartbeat.C:
+			if err := writeStreamComment(w, "heartbeat"); err != nil {
+				return
+			}
+		case <-poll.C:
+			latest, err := h.executionService.GetExecution(context.Background(), id)
+			if err != nil {
+				if writeStreamEvent(w, streamEvent{Name: streamEventError, Data: map[string]any{"error": err.Error()}}) != nil ||
+					errors.Is(err, workflow.ErrExecutionNotFound) {
+					return
+				}
+
+				continue
+			}
+
+			execution = latest
+		}
+	}
+}
+
+// writeStreamEvent writes and flushes a server-sent event.
+func writeStreamEvent(w *bufio.Writer, event streamEvent) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Name, data); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// writeStreamComment writes and flushes a server-sent event comment, ignored by clients.
+func writeStreamComment(w *bufio.Writer, comment string) error {
+	if _, err := fmt.Fprintf(w, ": %s\n\n", comment); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
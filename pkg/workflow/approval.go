@@ -0,0 +1,243 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+)
+
+var (
+	// ErrApprovalNotFound is returned when no pending approval request matches a token.
+	ErrApprovalNotFound = errors.New("approval request not found")
+
+	// ErrApprovalExpired is returned when a decision arrives after the request expired.
+	ErrApprovalExpired = errors.New("approval request expired")
+)
+
+// approvalResumedBy identifies approval decisions in WorkflowExecutionResumed events.
+const approvalResumedBy = "approval"
+
+// DecideApproval approves or rejects the pending approval request identified by token and
+// resumes its execution on the node's approved or rejected port. A request past its expiry
+// is resumed on its timeout port instead and ErrApprovalExpired is returned. Without an event bus
+// to resume the execution on, nothing is decided and ErrEventBusNotConfigured is returned.
+func (s *ExecutionService) DecideApproval(ctx context.Context, token string, approved bool, comment string) (*models.ExecutionContext, error) {
+	if s.eventBus == nil {
+		return nil, ErrEventBusNotConfigured
+	}
+
+	execCtx, request, err := s.findPendingApproval(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	if request.IsExpired(now) {
+		if err := s.resumeApproval(ctx, execCtx, request, models.ApprovalStatusExpired, "", now); err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrApprovalExpired, token)
+	}
+
+	status := models.ApprovalStatusRejected
+	if approved {
+		status = models.ApprovalStatusApproved
+	}
+
+	if err := s.resumeApproval(ctx, execCtx, request, status, comment, now); err != nil {
+		return nil, err
+	}
+
+	return execCtx, nil
+}
+
+// ExpireApprovals resumes every pending approval request that expired by now on its
+// timeout port, returning how many requests expired. A request failing to resume does not
+// hold back the others: their errors are joined.
+func (s *ExecutionService) ExpireApprovals(ctx context.Context, now time.Time) (int, error) {
+	executions, err := s.persistence.ExecutionContextRepository().GetExecutionsWithExpiredApprovals(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get executions with expired approvals: %w", err)
+	}
+
+	expired := 0
+
+	var errs []error
+
+	for _, execCtx := range executions {
+		for i := range execCtx.Approvals {
+			request := &execCtx.Approvals[i]
+			if !request.IsExpired(now) {
+				continue
+			}
+
+			err := s.resumeApproval(ctx, execCtx, request, models.ApprovalStatusExpired, "", now)
+			if errors.Is(err, ErrApprovalNotFound) {
+				// Decided or expired by another process in the meantime
+				continue
+			}
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to expire approval request of execution %s: %w", execCtx.ID, err))
+
+				continue
+			}
+
+			expired++
+		}
+	}
+
+	return expired, errors.Join(errs...)
+}
+
+// findPendingApproval returns the paused execution holding the pending approval request for token.
+func (s *ExecutionService) findPendingApproval(ctx context.Context, token string) (*models.ExecutionContext, *models.ApprovalRequest, error) {
+	if token == "" {
+		return nil, nil, ErrApprovalNotFound
+	}
+
+	execCtx, err := s.persistence.ExecutionContextRepository().GetExecutionByApprovalToken(ctx, token)
+	if errors.Is(err, persistence.ErrExecutionContextNotFound) {
+		return nil, nil, ErrApprovalNotFound
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get execution of approval request: %w", err)
+	}
+
+	request := execCtx.PendingApproval(token)
+	if request == nil {
+		return nil, nil, ErrApprovalNotFound
+	}
+
+	return execCtx, request, nil
+}
+
+// resumeApproval claims request with the decision, stores the approval node's result on the
+// decision port and activates the nodes connected to that port. It returns ErrApprovalNotFound
+// when another process claimed the request first.
+func (s *ExecutionService) resumeApproval(
+	ctx context.Context,
+	execCtx *models.ExecutionContext,
+	request *models.ApprovalRequest,
+	status models.ApprovalStatus,
+	comment string,
+	now time.Time,
+) error {
+	request.Status = status
+	request.Comment = comment
+	request.DecidedAt = &now
+
+	claimed, err := s.persistence.ExecutionContextRepository().ClaimApproval(ctx, execCtx.ID, *request)
+	if err != nil {
+		return fmt.Errorf("failed to claim approval request: %w", err)
+	}
+
+	if !claimed {
+		return fmt.Errorf("%w: %s", ErrApprovalNotFound, request.Token)
+	}
+
+	port := request.Port()
+
+	data := make(map[string]any, len(request.Data)+1)
+	maps.Copy(data, request.Data)
+	data[models.ApprovalDataKey] = map[string]any{
+		"decision":   string(status),
+		"comment":    comment,
+		"decided_at": now.Format(time.RFC3339),
+	}
+
+	if execCtx.NodeResults == nil {
+		execCtx.NodeResults = make(map[string]models.NodeResult)
+	}
+
+	execCtx.NodeResults[models.MakeNodeResultKey(request.NodeID, port)] = models.NodeResult{
+		NodeID:    request.NodeID,
+		Data:      data,
+		Status:    string(models.NodeStatusSuccess),
+		Timestamp: now,
+	}
+
+	if !execCtx.HasPendingApprovals() && !execCtx.HasPendingEventWaits() {
+		execCtx.Status = models.ExecutionStatusRunning
+	}
+
+	if err := s.persistence.ExecutionContextRepository().UpdateExecutionContext(ctx, execCtx); err != nil {
+		return fmt.Errorf("failed to update execution context: %w", err)
+	}
+
+	resumedEvent := &events.WorkflowExecutionResumed{
+		BaseEvent:       events.NewBaseEvent(events.WorkflowExecutionResumedEvent, execCtx.WorkflowID),
+		ExecutionID:     execCtx.ID,
+		Status:          string(execCtx.Status),
+		ResumedBy:       approvalResumedBy,
+		PauseDurationMs: now.Sub(request.CreatedAt).Milliseconds(),
+		ApprovalResult:  string(status),
+	}
+	resumedEvent.CorrelationID = execCtx.CorrelationID
+
+	if err := s.eventBus.Publish(ctx, request.NodeID+":"+execCtx.ID, resumedEvent); err != nil {
+		return fmt.Errorf("failed to publish execution resumed event: %w", err)
+	}
+
+	return s.activatePort(ctx, execCtx, request.NodeID, port, data)
+}
+
+// activatePort activates the nodes connected to port of nodeID with data, for a paused node
+// resuming the execution.
+func (s *ExecutionService) activatePort(ctx context.Context, execCtx *models.ExecutionContext, nodeID, port string, data map[string]any) error {
+	connections, err := s.persistence.ConnectionRepository().GetConnectionsBySourceNode(ctx, execCtx.WorkflowID, nodeID)
+	if err != nil {
+		return fmt.Errorf("failed to get connections for node %s: %w", nodeID, err)
+	}
+
+	priority := 0
+	if workflow, err := s.persistence.WorkflowRepository().GetByID(ctx, execCtx.WorkflowID); err == nil && workflow != nil {
+		priority = workflow.Priority()
+	}
+
+	var activations []*events.NodeActivation
+
+	for _, conn := range connections {
+		_, sourcePort, ok := models.ParsePortID(conn.SourcePort)
+		if !ok || sourcePort != port {
+			continue
+		}
+
+		targetNodeID, targetPort, ok := models.ParsePortID(conn.TargetPort)
+		if !ok {
+			continue
+		}
+
+		inputData, err := ApplyConnectionTransform(conn, data)
+		if err != nil {
+			return err
+		}
+
+		activation := &events.NodeActivation{
+			BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, execCtx.WorkflowID),
+			ExecutionID: execCtx.ID,
+			NodeID:      targetNodeID,
+			WorkflowID:  execCtx.WorkflowID,
+			InputPort:   targetPort,
+			InputData:   inputData,
+			SourceNode:  nodeID,
+			SourcePort:  port,
+			OrderingKey: execCtx.OrderingKey,
+			Priority:    priority,
+		}
+		activation.CorrelationID = execCtx.CorrelationID
+
+		activations = append(activations, activation)
+	}
+
+	return s.publishActivations(ctx, execCtx.ID, activations)
+}
@@ -0,0 +1,252 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/mocks"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupPausedExecution stores a trigger -> approve -> (ship | cancel | escalate) workflow whose
+// execution is paused at the approve node with the given token.
+func setupPausedExecution(t *testing.T, p persistence.Persistence, token string, expiresAt *time.Time) *models.ExecutionContext {
+	t.Helper()
+
+	workflow := &models.Workflow{
+		ID:     "approval-workflow",
+		Name:   "Approval Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "trigger", Name: "Trigger", Type: models.NodeTypeTriggerWebhook, Category: models.CategoryTypeTrigger, Config: map[string]any{}, Enabled: true},
+			{ID: "approve", Name: "Approve", Type: "approval", Category: models.CategoryTypeAction, Config: map[string]any{}, Enabled: true},
+			{ID: "ship", Name: "Ship", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "ship"}, Enabled: true},
+			{ID: "cancel", Name: "Cancel", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "cancel"}, Enabled: true},
+			{ID: "escalate", Name: "Escalate", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "escalate"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "c1", SourcePort: "trigger:success", TargetPort: "approve:main"},
+			{ID: "c2", SourcePort: "approve:approved", TargetPort: "ship:main"},
+			{ID: "c3", SourcePort: "approve:rejected", TargetPort: "cancel:main"},
+			{ID: "c4", SourcePort: "approve:timeout", TargetPort: "escalate:main"},
+		},
+	}
+	require.NoError(t, p.WorkflowRepository().Save(t.Context(), workflow))
+
+	execCtx := &models.ExecutionContext{
+		ID:         "exec-paused",
+		WorkflowID: workflow.ID,
+		Status:     models.ExecutionStatusPaused,
+		NodeResults: map[string]models.NodeResult{
+			models.MakeNodeResultKey("trigger", "success"): {
+				NodeID: "trigger", Data: map[string]any{"order_id": "42"}, Status: string(models.NodeStatusSuccess), Timestamp: time.Now(),
+			},
+		},
+		Approvals: []models.ApprovalRequest{
+			{
+				Token:       token,
+				NodeID:      "approve",
+				Data:        map[string]any{"order_id": "42"},
+				TimeoutPort: "timeout",
+				Status:      models.ApprovalStatusPending,
+				CreatedAt:   time.Now().UTC().Add(-time.Hour),
+				ExpiresAt:   expiresAt,
+			},
+		},
+		CreatedAt: time.Now().UTC().Add(-time.Hour),
+	}
+	require.NoError(t, p.ExecutionContextRepository().SaveExecutionContext(t.Context(), execCtx))
+
+	return execCtx
+}
+
+// publishedActivations returns the node activations published on the mock event bus.
+func publishedActivations(eventBus *mocks.MockEventBus) []*events.NodeActivation {
+	var activations []*events.NodeActivation
+
+	for _, call := range eventBus.Calls {
+		if call.Method != "Publish" {
+			continue
+		}
+
+		if activation, ok := call.Arguments.Get(2).(*events.NodeActivation); ok {
+			activations = append(activations, activation)
+		}
+	}
+
+	return activations
+}
+
+func TestExecutionService_DecideApproval_Approve(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupPausedExecution(t, p, "token-approve", nil)
+	service, eventBus := newTestExecutionService(t, p)
+
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	execution, err := service.DecideApproval(t.Context(), "token-approve", true, "looks good")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusRunning, execution.Status)
+
+	activations := publishedActivations(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "ship", activations[0].NodeID)
+	assert.Equal(t, "main", activations[0].InputPort)
+	assert.Equal(t, "approve", activations[0].SourceNode)
+	assert.Equal(t, "approved", activations[0].SourcePort)
+
+	data, ok := activations[0].InputData.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "42", data["order_id"])
+
+	decision, ok := data[models.ApprovalDataKey].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "approved", decision["decision"])
+	assert.Equal(t, "looks good", decision["comment"])
+
+	stored, err := p.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-paused")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusRunning, stored.Status)
+	assert.Equal(t, models.ApprovalStatusApproved, stored.Approvals[0].Status)
+	assert.Contains(t, stored.NodeResults, models.MakeNodeResultKey("approve", "approved"))
+
+	// A decided request cannot be decided again
+	_, err = service.DecideApproval(t.Context(), "token-approve", false, "")
+	require.ErrorIs(t, err, ErrApprovalNotFound)
+}
+
+func TestExecutionService_DecideApproval_Reject(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupPausedExecution(t, p, "token-reject", nil)
+	service, eventBus := newTestExecutionService(t, p)
+
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.DecideApproval(t.Context(), "token-reject", false, "too expensive")
+	require.NoError(t, err)
+
+	activations := publishedActivations(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "cancel", activations[0].NodeID)
+	assert.Equal(t, "rejected", activations[0].SourcePort)
+
+	decision := activations[0].InputData.(map[string]any)[models.ApprovalDataKey].(map[string]any)
+	assert.Equal(t, "rejected", decision["decision"])
+	assert.Equal(t, "too expensive", decision["comment"])
+
+	stored, err := p.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-paused")
+	require.NoError(t, err)
+	assert.Equal(t, models.ApprovalStatusRejected, stored.Approvals[0].Status)
+	assert.Equal(t, "too expensive", stored.Approvals[0].Comment)
+}
+
+func TestExecutionService_ExpireApprovals(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	expiresAt := time.Now().UTC().Add(time.Minute)
+	setupPausedExecution(t, p, "token-expiry", &expiresAt)
+	service, eventBus := newTestExecutionService(t, p)
+
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	expired, err := service.ExpireApprovals(t.Context(), time.Now().UTC())
+	require.NoError(t, err)
+	assert.Zero(t, expired)
+	assert.Empty(t, publishedActivations(eventBus))
+
+	expired, err = service.ExpireApprovals(t.Context(), expiresAt.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	activations := publishedActivations(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "escalate", activations[0].NodeID)
+	assert.Equal(t, "timeout", activations[0].SourcePort)
+
+	decision := activations[0].InputData.(map[string]any)[models.ApprovalDataKey].(map[string]any)
+	assert.Equal(t, "expired", decision["decision"])
+
+	stored, err := p.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-paused")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusRunning, stored.Status)
+	assert.Equal(t, models.ApprovalStatusExpired, stored.Approvals[0].Status)
+}
+
+func TestExecutionService_ExpireApprovals_ContinuesPastErrors(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	expiresAt := time.Now().UTC().Add(-time.Minute)
+	execCtx := setupPausedExecution(t, p, "token-a", &expiresAt)
+
+	other := *execCtx
+	other.ID = "exec-other"
+	other.Approvals = []models.ApprovalRequest{execCtx.Approvals[0]}
+	other.Approvals[0].Token = "token-b"
+	require.NoError(t, p.ExecutionContextRepository().SaveExecutionContext(t.Context(), &other))
+
+	service, eventBus := newTestExecutionService(t, p)
+
+	eventBus.On("Publish", mock.Anything, "approve:exec-other", mock.Anything).Return(errors.New("bus down"))
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	expired, err := service.ExpireApprovals(t.Context(), time.Now().UTC())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exec-other")
+	assert.Equal(t, 1, expired)
+
+	activations := publishedActivations(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "exec-paused", activations[0].ExecutionID)
+}
+
+func TestExecutionService_DecideApproval_ClaimedByAnotherProcess(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	stale := setupPausedExecution(t, p, "token-race", nil)
+	service, eventBus := newTestExecutionService(t, p)
+
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.DecideApproval(t.Context(), "token-race", true, "")
+	require.NoError(t, err)
+
+	// A process holding the execution from before the decision loses the claim
+	err = service.resumeApproval(t.Context(), stale, &stale.Approvals[0], models.ApprovalStatusRejected, "", time.Now().UTC())
+	require.ErrorIs(t, err, ErrApprovalNotFound)
+
+	activations := publishedActivations(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "ship", activations[0].NodeID)
+}
+
+func TestExecutionService_DecideApproval_Expired(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	expiresAt := time.Now().UTC().Add(-time.Minute)
+	setupPausedExecution(t, p, "token-late", &expiresAt)
+	service, eventBus := newTestExecutionService(t, p)
+
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.DecideApproval(t.Context(), "token-late", true, "")
+	require.ErrorIs(t, err, ErrApprovalExpired)
+
+	// The late decision is ignored and the execution resumes on the timeout port
+	activations := publishedActivations(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "escalate", activations[0].NodeID)
+}
+
+func TestExecutionService_DecideApproval_NotFound(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupPausedExecution(t, p, "token-known", nil)
+	service, eventBus := newTestExecutionService(t, p)
+
+	_, err := service.DecideApproval(t.Context(), "token-unknown", true, "")
+	require.ErrorIs(t, err, ErrApprovalNotFound)
+
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
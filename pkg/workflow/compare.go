@@ -0,0 +1,183 @@
+package workflow
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+// ExecutionComparison is the structured difference between two executions, A and B, used to
+// debug workflows behaving differently across runs.
+type ExecutionComparison struct {
+	A ComparedExecution `json:"a"`
+	B ComparedExecution `json:"b"`
+	// Identical is true when both executions ran the same nodes with the same results on the
+	// same trigger data.
+	Identical bool `json:"identical"`
+	// Nodes lists the nodes that ran in both executions with different results.
+	Nodes []NodeDifference `json:"nodes"`
+	// OnlyInA and OnlyInB list the nodes that ran in one execution but not the other.
+	OnlyInA []string `json:"only_in_a"`
+	OnlyInB []string `json:"only_in_b"`
+	// TriggerData lists the differences between the trigger data of the executions.
+	TriggerData []ValueDifference `json:"trigger_data"`
+}
+
+// ComparedExecution identifies a compared execution.
+type ComparedExecution struct {
+	ID         string                 `json:"id"`
+	WorkflowID string                 `json:"workflow_id"`
+	Status     models.ExecutionStatus `json:"status"`
+}
+
+// NodeDifference lists the differences between the results of a node in two executions.
+type NodeDifference struct {
+	NodeID      string            `json:"node_id"`
+	Differences []ValueDifference `json:"differences"`
+}
+
+// ValueDifference is a value differing between two executions. Path is the dotted path of the
+// value as flattened by template.Flatten, prefixed with the output port for node results (e.g.
+// "success.data.items[0]"); a value missing from one execution is null on that side.
+type ValueDifference struct {
+	Path string `json:"path"`
+	A    any    `json:"a"`
+	B    any    `json:"b"`
+}
+
+// CompareExecutions compares the executions identified by idA and idB. Offloaded payloads are
+// resolved first, so only the data itself is compared.
+func (s *ExecutionService) CompareExecutions(ctx context.Context, idA, idB string) (*ExecutionComparison, error) {
+	executionA, err := s.comparableExecution(ctx, idA)
+	if err != nil {
+		return nil, err
+	}
+
+	executionB, err := s.comparableExecution(ctx, idB)
+	if err != nil {
+		return nil, err
+	}
+
+	return CompareExecutions(executionA, executionB), nil
+}
+
+// comparableExecution returns the execution identified by executionID with its offloaded
+// payloads resolved.
+func (s *ExecutionService) comparableExecution(ctx context.Context, executionID string) (*models.ExecutionContext, error) {
+	execution, err := s.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.payloads == nil {
+		return execution, nil
+	}
+
+	if err := s.payloads.ResolveExecution(ctx, execution); err != nil {
+		return nil, err
+	}
+
+	return execution, nil
+}
+
+// CompareExecutions returns the differences between the node results and trigger data of two
+// executions. Node results are compared per node by output port, status, error and data;
+// timestamps are ignored as they always differ.
+func CompareExecutions(a, b *models.ExecutionContext) *ExecutionComparison {
+	comparison := &ExecutionComparison{
+		A:           ComparedExecution{ID: a.ID, WorkflowID: a.WorkflowID, Status: a.Status},
+		B:           ComparedExecution{ID: b.ID, WorkflowID: b.WorkflowID, Status: b.Status},
+		Nodes:       []NodeDifference{},
+		OnlyInA:     []string{},
+		OnlyInB:     []string{},
+		TriggerData: compareValues(a.TriggerData, b.TriggerData),
+	}
+
+	nodesA := nodeOutputs(a.NodeResults)
+	nodesB := nodeOutputs(b.NodeResults)
+
+	for _, nodeID := range sortedKeys(nodesA, nodesB) {
+		outputsA, inA := nodesA[nodeID]
+		outputsB, inB := nodesB[nodeID]
+
+		switch {
+		case !inB:
+			comparison.OnlyInA = append(comparison.OnlyInA, nodeID)
+		case !inA:
+			comparison.OnlyInB = append(comparison.OnlyInB, nodeID)
+		default:
+			if differences := compareValues(outputsA, outputsB); len(differences) > 0 {
+				comparison.Nodes = append(comparison.Nodes, NodeDifference{NodeID: nodeID, Differences: differences})
+			}
+		}
+	}
+
+	comparison.Identical = len(comparison.Nodes) == 0 && len(comparison.OnlyInA) == 0 &&
+		len(comparison.OnlyInB) == 0 && len(comparison.TriggerData) == 0
+
+	return comparison
+}
+
+// nodeOutputs groups node results by node ID, keyed by output port, keeping the compared fields.
+func nodeOutputs(nodeResults map[string]models.NodeResult) map[string]map[string]any {
+	nodes := make(map[string]map[string]any)
+
+	for key, result := range nodeResults {
+		// Keys that are not port-scoped are compared as a node of their own
+		nodeID, port, ok := models.ParseNodeResultKey(key)
+		if !ok {
+			nodeID, port = key, key
+		}
+
+		output := map[string]any{"status": result.Status, "data": result.Data}
+		if result.Error != "" {
+			output["error"] = result.Error
+		}
+
+		if nodes[nodeID] == nil {
+			nodes[nodeID] = make(map[string]any)
+		}
+
+		nodes[nodeID][port] = output
+	}
+
+	return nodes
+}
+
+// compareValues returns the differences between two objects by dotted path, in path order.
+func compareValues(a, b map[string]any) []ValueDifference {
+	flatA := template.Flatten(a)
+	flatB := template.Flatten(b)
+
+	differences := []ValueDifference{}
+
+	for _, path := range sortedKeys(flatA, flatB) {
+		if !reflect.DeepEqual(flatA[path], flatB[path]) {
+			differences = append(differences, ValueDifference{Path: path, A: flatA[path], B: flatB[path]})
+		}
+	}
+
+	return differences
+}
+
+// sortedKeys returns the keys of both maps, sorted and without duplicates.
+func sortedKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+
+	for key := range a {
+		keys = append(keys, key)
+	}
+
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
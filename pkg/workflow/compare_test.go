@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// comparedRun returns an execution of the orders workflow fetching an order and pricing it.
+func comparedRun(id string, total float64, started time.Time) *models.ExecutionContext {
+	return &models.ExecutionContext{
+		ID:         id,
+		WorkflowID: "workflow-orders",
+		Status:     models.ExecutionStatusCompleted,
+		TriggerData: map[string]any{
+			"webhook": map[string]any{"order_id": "42"},
+		},
+		NodeResults: map[string]models.NodeResult{
+			models.MakeNodeResultKey("fetch", "success"): {
+				NodeID: "fetch", Status: "success", Timestamp: started,
+				Data: map[string]any{"order": map[string]any{"id": "42", "items": []any{"book", "pen"}}},
+			},
+			models.MakeNodeResultKey("price", "success"): {
+				NodeID: "price", Status: "success", Timestamp: started.Add(time.Second),
+				Data: map[string]any{"total": total, "currency": "EUR"},
+			},
+		},
+		CreatedAt: started,
+	}
+}
+
+func TestCompareExecutions(t *testing.T) {
+	a := comparedRun("exec-a", 19.9, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	b := comparedRun("exec-b", 21.5, time.Date(2025, 6, 2, 9, 30, 0, 0, time.UTC))
+
+	// Identical runs differ only by timestamps, which are ignored
+	same := CompareExecutions(a, comparedRun("exec-c", 19.9, time.Now()))
+	assert.True(t, same.Identical)
+	assert.Empty(t, same.Nodes)
+
+	comparison := CompareExecutions(a, b)
+
+	assert.False(t, comparison.Identical)
+	assert.Equal(t, ComparedExecution{ID: "exec-a", WorkflowID: "workflow-orders", Status: models.ExecutionStatusCompleted}, comparison.A)
+	assert.Equal(t, []NodeDifference{{
+		NodeID:      "price",
+		Differences: []ValueDifference{{Path: "success.data.total", A: 19.9, B: 21.5}},
+	}}, comparison.Nodes)
+	assert.Empty(t, comparison.OnlyInA)
+	assert.Empty(t, comparison.OnlyInB)
+	assert.Empty(t, comparison.TriggerData)
+
+	// A node taking another path shows up as a port difference, nodes run once as one-sided
+	b.NodeResults[models.MakeNodeResultKey("notify", "success")] = models.NodeResult{NodeID: "notify", Status: "success"}
+	b.NodeResults[models.MakeNodeResultKey("fetch", "error")] = models.NodeResult{
+		NodeID: "fetch", Status: "error", Error: "order not found", Data: map[string]any{},
+	}
+	delete(b.NodeResults, models.MakeNodeResultKey("fetch", "success"))
+	delete(b.NodeResults, models.MakeNodeResultKey("price", "success"))
+	b.TriggerData["webhook"].(map[string]any)["order_id"] = "43"
+
+	comparison = CompareExecutions(a, b)
+
+	require.Len(t, comparison.Nodes, 1)
+	assert.Equal(t, "fetch", comparison.Nodes[0].NodeID)
+	assert.Equal(t, []ValueDifference{
+		{Path: "error.data", A: nil, B: map[string]any{}},
+		{Path: "error.error", A: nil, B: "order not found"},
+		{Path: "error.status", A: nil, B: "error"},
+		{Path: "success.data.order.id", A: "42", B: nil},
+		{Path: "success.data.order.items[0]", A: "book", B: nil},
+		{Path: "success.data.order.items[1]", A: "pen", B: nil},
+		{Path: "success.status", A: "success", B: nil},
+	}, comparison.Nodes[0].Differences)
+	assert.Equal(t, []string{"price"}, comparison.OnlyInA)
+	assert.Equal(t, []string{"notify"}, comparison.OnlyInB)
+	assert.Equal(t, []ValueDifference{{Path: "webhook.order_id", A: "42", B: "43"}}, comparison.TriggerData)
+}
+
+func TestExecutionService_CompareExecutions(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	started := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, p.ExecutionContextRepository().SaveExecutionContext(t.Context(), comparedRun("exec-a", 19.9, started)))
+	require.NoError(t, p.ExecutionContextRepository().SaveExecutionContext(t.Context(), comparedRun("exec-b", 21.5, started)))
+
+	service, _ := newTestExecutionService(t, p)
+
+	comparison, err := service.CompareExecutions(t.Context(), "exec-a", "exec-b")
+	require.NoError(t, err)
+	require.Len(t, comparison.Nodes, 1)
+	assert.Equal(t, "price", comparison.Nodes[0].NodeID)
+
+	_, err = service.CompareExecutions(t.Context(), "exec-a", "missing")
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+}
@@ -0,0 +1,81 @@
+package workflow
+
+import (
+	"slices"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// compensationNodeSuffix is appended to the ID of a node to form the ID of its compensation.
+const compensationNodeSuffix = "-compensation"
+
+// CompensationStep is the compensation of a completed node, with the result the node completed with.
+type CompensationStep struct {
+	Node   *models.WorkflowNode
+	Result models.NodeResult
+}
+
+// CompletedPort returns the port through which a node completed successfully with outputs. A node
+// that failed, paused or repeats has not completed. When a node succeeded on several ports, the
+// first in alphabetical order is returned.
+func CompletedPort(outputs map[string]models.NodeResult) (string, bool) {
+	if _, repeats := outputs[models.RepeatOutputPort]; repeats {
+		return "", false
+	}
+
+	var completed []string
+
+	for port, result := range outputs {
+		if result.Status != string(models.NodeStatusSuccess) {
+			return "", false
+		}
+
+		completed = append(completed, port)
+	}
+
+	if len(completed) == 0 {
+		return "", false
+	}
+
+	return slices.Min(completed), true
+}
+
+// CompensationSteps returns the compensations to run to roll the execution back: those of the
+// nodes that completed and have one, the most recently completed first.
+func CompensationSteps(workflow *models.Workflow, execCtx *models.ExecutionContext) []CompensationStep {
+	completed := execCtx.CompletedNodes()
+	steps := make([]CompensationStep, 0, len(completed))
+
+	for _, resultKey := range slices.Backward(completed) {
+		nodeID, _, _ := models.ParseNodeResultKey(resultKey)
+
+		index := slices.IndexFunc(workflow.Nodes, func(node *models.WorkflowNode) bool {
+			return node.ID == nodeID
+		})
+		if index < 0 || workflow.Nodes[index].Compensation == nil {
+			continue
+		}
+
+		steps = append(steps, CompensationStep{
+			Node:   workflow.Nodes[index],
+			Result: execCtx.NodeResults[resultKey],
+		})
+	}
+
+	return steps
+}
+
+// CompensationNode returns the node executing the compensation of the step. It shares the variable
+// overrides and log level of the compensated node.
+func (s CompensationStep) CompensationNode() *models.WorkflowNode {
+	return &models.WorkflowNode{
+		ID:                s.Node.ID + compensationNodeSuffix,
+		Type:              s.Node.Compensation.Type,
+		Category:          models.CategoryTypeAction,
+		Config:            s.Node.Compensation.Config,
+		Name:              s.Node.Name + " (compensation)",
+		Enabled:           true,
+		VariableOverrides: s.Node.VariableOverrides,
+		LogLevel:          s.Node.LogLevel,
+	}
+}
@@ -0,0 +1,68 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletedPort(t *testing.T) {
+	success := models.NodeResult{Status: string(models.NodeStatusSuccess)}
+
+	port, completed := CompletedPort(map[string]models.NodeResult{"true": success})
+	assert.True(t, completed)
+	assert.Equal(t, "true", port)
+
+	port, completed = CompletedPort(map[string]models.NodeResult{"success": success, "new": success})
+	assert.True(t, completed)
+	assert.Equal(t, "new", port)
+
+	notCompleted := []map[string]models.NodeResult{
+		{},
+		{"error": {Status: string(models.NodeStatusError)}},
+		{"success": success, "error": {Status: string(models.NodeStatusError)}},
+		{"approval": {Status: string(models.NodeStatusPaused)}},
+		{models.RepeatOutputPort: success},
+	}
+
+	for _, outputs := range notCompleted {
+		_, completed := CompletedPort(outputs)
+		assert.False(t, completed, "outputs %v", outputs)
+	}
+}
+
+func TestCompensationSteps(t *testing.T) {
+	refund := &models.Compensation{Type: "httprequest", Config: map[string]any{"method": "POST"}}
+	workflow := &models.Workflow{
+		Nodes: []*models.WorkflowNode{
+			{ID: "reserve", Name: "Reserve", Compensation: &models.Compensation{Type: "log"}},
+			{ID: "notify", Name: "Notify"},
+			{ID: "charge", Name: "Charge", Compensation: refund, LogLevel: "debug"},
+		},
+	}
+
+	execCtx := &models.ExecutionContext{
+		NodeResults: map[string]models.NodeResult{
+			"reserve::success": {NodeID: "reserve", Data: map[string]any{"id": "r-1"}},
+			"notify::success":  {NodeID: "notify"},
+			"charge::success":  {NodeID: "charge", Data: map[string]any{"id": "c-1"}},
+		},
+	}
+	execCtx.MarkCompleted("reserve::success")
+	execCtx.MarkCompleted("notify::success")
+	execCtx.MarkCompleted("charge::success")
+	execCtx.MarkCompleted("reserve::success")
+
+	steps := CompensationSteps(workflow, execCtx)
+	assert.Len(t, steps, 2)
+	assert.Equal(t, "charge", steps[0].Node.ID)
+	assert.Equal(t, map[string]any{"id": "c-1"}, steps[0].Result.Data)
+	assert.Equal(t, "reserve", steps[1].Node.ID)
+
+	node := steps[0].CompensationNode()
+	assert.Equal(t, "charge-compensation", node.ID)
+	assert.Equal(t, "httprequest", node.Type)
+	assert.Equal(t, refund.Config, node.Config)
+	assert.Equal(t, "debug", node.LogLevel)
+}
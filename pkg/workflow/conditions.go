@@ -0,0 +1,44 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dukex/operion/pkg/nodes/conditional"
+	"github.com/dukex/operion/pkg/template"
+)
+
+// ConditionLanguageTemplate is the language of the conditions of conditional nodes: a template
+// whose output is converted to a boolean.
+const ConditionLanguageTemplate = "template"
+
+var ErrUnsupportedConditionLanguage = errors.New("unsupported condition language")
+
+// ConditionTestResult is the outcome of evaluating a condition expression.
+type ConditionTestResult struct {
+	Result bool `json:"result"`
+	Value  any  `json:"value"`
+}
+
+// TestCondition evaluates expression in language against data, which holds the fields a condition
+// sees at execution time (trigger_data, node_results, variables, ...), the same way a conditional
+// node does. An empty language is the template language.
+func TestCondition(language, expression string, data map[string]any) (*ConditionTestResult, error) {
+	if language != "" && language != ConditionLanguageTemplate {
+		return nil, fmt.Errorf("%w '%s', supported: %s", ErrUnsupportedConditionLanguage, language, ConditionLanguageTemplate)
+	}
+
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	value, err := template.Render(expression, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConditionTestResult{
+		Result: conditional.IsTrue(value),
+		Value:  value,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestCondition(t *testing.T) {
+	data := map[string]any{"trigger_data": map[string]any{"items": []any{"book"}}}
+
+	result, err := TestCondition("", "{{ len .trigger_data.items }}", data)
+	require.NoError(t, err)
+	assert.True(t, result.Result)
+	assert.Equal(t, float64(1), result.Value)
+
+	result, err = TestCondition(ConditionLanguageTemplate, "{{ eq (len .trigger_data.items) 2 }}", data)
+	require.NoError(t, err)
+	assert.False(t, result.Result)
+
+	_, err = TestCondition(ConditionLanguageTemplate, "{{ if }}", data)
+	require.Error(t, err)
+
+	_, err = TestCondition("js", "items.length > 0", data)
+	assert.ErrorIs(t, err, ErrUnsupportedConditionLanguage)
+}
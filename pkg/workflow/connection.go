@@ -0,0 +1,56 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+// templateResultKey holds the result of a transform or output template that is not an object.
+const templateResultKey = "result"
+
+// ApplyConnectionTransform returns the data delivered to the target of conn. Without a transform
+// the source output passes through unchanged; otherwise the transform template is rendered with the
+// source output as .data. An object result replaces the data, any other value is wrapped as "result".
+func ApplyConnectionTransform(conn *models.Connection, data map[string]any) (map[string]any, error) {
+	if conn.Transform == "" {
+		return data, nil
+	}
+
+	transformed, err := renderData(conn.Transform, map[string]any{"data": data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform data on connection %s: %w", conn.ID, err)
+	}
+
+	return transformed, nil
+}
+
+// renderData renders a transform or output template with context into node data: an object result
+// is the data, any other value is wrapped as "result".
+func renderData(tmpl string, context map[string]any) (map[string]any, error) {
+	result, err := template.Render(tmpl, context)
+	if err != nil {
+		return nil, err
+	}
+
+	if object, ok := result.(map[string]any); ok {
+		return object, nil
+	}
+
+	return map[string]any{templateResultKey: result}, nil
+}
+
+// ValidateConnectionTransform checks that the transform template of conn parses, so a workflow
+// never publishes a connection whose data cannot be delivered.
+func ValidateConnectionTransform(conn *models.Connection) error {
+	if conn.Transform == "" {
+		return nil
+	}
+
+	if _, err := template.Parse(conn.Transform); err != nil {
+		return fmt.Errorf("transform of connection '%s' is invalid: %w", conn.ID, err)
+	}
+
+	return nil
+}
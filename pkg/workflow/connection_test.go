@@ -0,0 +1,57 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyConnectionTransform(t *testing.T) {
+	data := map[string]any{"user": map[string]any{"name": "Ada", "age": float64(36)}}
+
+	tests := []struct {
+		name      string
+		transform string
+		expected  map[string]any
+	}{
+		{
+			name:     "no transform passes data through",
+			expected: data,
+		},
+		{
+			name:      "object result replaces data",
+			transform: `{"name": "{{ .data.user.name }}"}`,
+			expected:  map[string]any{"name": "Ada"},
+		},
+		{
+			name:      "scalar result is wrapped",
+			transform: `{{ .data.user.age }}`,
+			expected:  map[string]any{"result": float64(36)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ApplyConnectionTransform(&models.Connection{ID: "c1", Transform: tt.transform}, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestApplyConnectionTransform_InvalidTemplate(t *testing.T) {
+	_, err := ApplyConnectionTransform(&models.Connection{ID: "c1", Transform: "{{ .data"}, map[string]any{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection c1")
+}
+
+func TestValidateConnectionTransform(t *testing.T) {
+	require.NoError(t, ValidateConnectionTransform(&models.Connection{ID: "c1"}))
+	require.NoError(t, ValidateConnectionTransform(&models.Connection{ID: "c1", Transform: `{"id": "{{ .data.id }}"}`}))
+
+	err := ValidateConnectionTransform(&models.Connection{ID: "c1", Transform: "{{ .data"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "transform of connection 'c1' is invalid")
+}
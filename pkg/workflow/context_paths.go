@@ -0,0 +1,186 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/registry"
+)
+
+// maxContextPathDepth bounds how deep nested object schemas are listed.
+const maxContextPathDepth = 4
+
+// templateIdentifier matches the keys a template can read with a field access (.key).
+var templateIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// TemplateContextPaths lists the template context paths the config of node nodeID of wf can read,
+// for editor autocomplete: the trigger data of the upstream trigger nodes, from the schema of the
+// port their event arrives on, the results of every upstream node, from the schemas of its output
+// ports, and the workflow variables. Nodes downstream of, or unconnected to, the node are left out
+// since their results are not set when it runs.
+func TemplateContextPaths(ctx context.Context, reg *registry.Registry, wf *models.Workflow, nodeID string) (*models.TemplateContext, error) {
+	if !slices.ContainsFunc(wf.Nodes, func(node *models.WorkflowNode) bool { return node.ID == nodeID }) {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
+	}
+
+	upstream := upstreamNodes(wf, nodeID)
+	result := &models.TemplateContext{WorkflowID: wf.ID, NodeID: nodeID, Paths: []models.TemplateContextPath{}}
+
+	var triggerPaths, resultPaths []models.TemplateContextPath
+
+	for _, node := range wf.Nodes {
+		if !upstream[node.ID] {
+			continue
+		}
+
+		created, err := reg.CreateNode(ctx, node.Type, node.ID, node.Config)
+		if err != nil {
+			continue
+		}
+
+		if inputs := created.InputPorts(); node.IsTriggerNode() && len(inputs) > 0 {
+			triggerPaths = appendSchemaPaths(triggerPaths, node.ID, "trigger_data", nil, inputs[0].Schema, 0)
+		}
+
+		for _, port := range created.OutputPorts() {
+			key := models.MakeNodeResultKey(node.ID, port.Name)
+			resultPaths = appendSchemaPaths(resultPaths, node.ID, "node_results", []string{key}, port.Schema, 0)
+		}
+	}
+
+	result.Paths = append(result.Paths, triggerPaths...)
+	result.Paths = append(result.Paths, resultPaths...)
+
+	names := make([]string, 0, len(wf.Variables))
+	for name := range wf.Variables {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		result.Paths = append(result.Paths, contextPath("", "variables", []string{name}, valueType(wf.Variables[name]), ""))
+	}
+
+	return result, nil
+}
+
+// upstreamNodes returns the IDs of the nodes nodeID is reachable from through the connections of wf.
+func upstreamNodes(wf *models.Workflow, nodeID string) map[string]bool {
+	sources := make(map[string][]string)
+
+	for _, conn := range wf.Connections {
+		sourceNodeID, _, sourceOK := models.ParsePortID(conn.SourcePort)
+		targetNodeID, _, targetOK := models.ParsePortID(conn.TargetPort)
+
+		if sourceOK && targetOK {
+			sources[targetNodeID] = append(sources[targetNodeID], sourceNodeID)
+		}
+	}
+
+	upstream := make(map[string]bool)
+	queue := []string{nodeID}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, source := range sources[current] {
+			if !upstream[source] && source != nodeID {
+				upstream[source] = true
+				queue = append(queue, source)
+			}
+		}
+	}
+
+	return upstream
+}
+
+// appendSchemaPaths appends the path of a value described by schema and, for objects, the paths
+// of its properties in name order.
+func appendSchemaPaths(
+	paths []models.TemplateContextPath,
+	nodeID, root string,
+	segments []string,
+	schema map[string]any,
+	depth int,
+) []models.TemplateContextPath {
+	types := schemaTypes(schema["type"])
+
+	if len(segments) > 0 {
+		description, _ := schema["description"].(string)
+		paths = append(paths, contextPath(nodeID, root, segments, strings.Join(types, ","), description))
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	if depth >= maxContextPathDepth || len(properties) == 0 {
+		return paths
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		property, _ := properties[name].(map[string]any)
+		paths = appendSchemaPaths(paths, nodeID, root, append(slices.Clone(segments), name), property, depth+1)
+	}
+
+	return paths
+}
+
+// contextPath builds the path of segments under root, with the template expression reading it:
+// a field access when every segment is an identifier, an index call otherwise.
+func contextPath(nodeID, root string, segments []string, valueType, description string) models.TemplateContextPath {
+	expression := "{{." + root + "." + strings.Join(segments, ".") + "}}"
+
+	if slices.ContainsFunc(segments, func(segment string) bool { return !templateIdentifier.MatchString(segment) }) {
+		quoted := make([]string, 0, len(segments))
+		for _, segment := range segments {
+			quoted = append(quoted, strconv.Quote(segment))
+		}
+
+		expression = "{{index ." + root + " " + strings.Join(quoted, " ") + "}}"
+	}
+
+	return models.TemplateContextPath{
+		Path:        root + "." + strings.Join(segments, "."),
+		Expression:  expression,
+		Type:        valueType,
+		Description: description,
+		NodeID:      nodeID,
+	}
+}
+
+// valueType returns the JSON schema type of a variable value.
+func valueType(value any) string {
+	switch {
+	case value == nil:
+		return "null"
+	case isInteger(value):
+		return "integer"
+	case isNumber(value):
+		return "number"
+	case isArray(value):
+		return "array"
+	}
+
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "object"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,80 @@
+package workflow
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateContextPaths(t *testing.T) {
+	reg := registry.NewRegistry(slog.Default())
+	reg.RegisterDefaultNodes()
+
+	providerID := "webhook"
+
+	wf := &models.Workflow{
+		ID:        "orders",
+		Variables: map[string]any{"api_base": "https://api.example.com", "retries": 3},
+		Nodes: []*models.WorkflowNode{
+			{
+				ID: "trigger", Type: models.NodeTypeTriggerWebhook, Category: models.CategoryTypeTrigger,
+				Config: map[string]any{"webhook_path": "/orders"}, ProviderID: &providerID, Enabled: true,
+			},
+			{
+				ID: "fetch", Type: "httprequest", Category: models.CategoryTypeAction,
+				Config: map[string]any{"url": "https://api.example.com/orders", "method": "GET"}, Enabled: true,
+			},
+			{ID: "notify", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "order"}, Enabled: true},
+			{ID: "done", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "done"}, Enabled: true},
+			{ID: "unconnected", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "idle"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "c1", SourcePort: "trigger:success", TargetPort: "fetch:main"},
+			{ID: "c2", SourcePort: "fetch:success", TargetPort: "notify:main"},
+			{ID: "c3", SourcePort: "notify:success", TargetPort: "done:main"},
+		},
+	}
+
+	paths, err := TemplateContextPaths(t.Context(), reg, wf, "notify")
+	require.NoError(t, err)
+
+	assert.Equal(t, "orders", paths.WorkflowID)
+	assert.Equal(t, "notify", paths.NodeID)
+
+	byPath := make(map[string]models.TemplateContextPath)
+	nodes := make(map[string]bool)
+
+	for _, path := range paths.Paths {
+		byPath[path.Path] = path
+		nodes[path.NodeID] = true
+	}
+
+	// Trigger fields come from the schema of the port the webhook event arrives on
+	require.Contains(t, byPath, "trigger_data.body")
+	assert.Equal(t, "{{.trigger_data.body}}", byPath["trigger_data.body"].Expression)
+	assert.Equal(t, "trigger", byPath["trigger_data.body"].NodeID)
+
+	// Output fields of upstream nodes are read by their result key
+	fetchSuccess := models.MakeNodeResultKey("fetch", "success")
+	require.Contains(t, byPath, "node_results."+fetchSuccess+".status_code")
+	assert.Equal(t, `{{index .node_results "`+fetchSuccess+`" "status_code"}}`, byPath["node_results."+fetchSuccess+".status_code"].Expression)
+	assert.Contains(t, byPath, "node_results."+models.MakeNodeResultKey("fetch", "error"))
+
+	assert.Equal(t, "string", byPath["variables.api_base"].Type)
+	assert.Equal(t, "{{.variables.retries}}", byPath["variables.retries"].Expression)
+	assert.Equal(t, "integer", byPath["variables.retries"].Type)
+
+	// Downstream, unconnected and the target node itself produce no results the target can read
+	assert.True(t, nodes["trigger"])
+	assert.True(t, nodes["fetch"])
+	assert.False(t, nodes["notify"])
+	assert.False(t, nodes["done"])
+	assert.False(t, nodes["unconnected"])
+
+	_, err = TemplateContextPaths(t.Context(), reg, wf, "missing")
+	require.ErrorIs(t, err, ErrNodeNotFound)
+}
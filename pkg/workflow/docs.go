@@ -0,0 +1,131 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/protocol"
+	"github.com/dukex/operion/pkg/registry"
+)
+
+// GenerateDocs describes wf from the node types registered in reg: the event and trigger data each
+// trigger node expects and the configuration, inputs and outputs of each action node, with the
+// ports connected to every port. Nodes are listed in workflow order; a node whose type is not
+// registered or whose config is invalid is listed with the error and without ports.
+func GenerateDocs(ctx context.Context, reg *registry.Registry, wf *models.Workflow) *models.WorkflowDocs {
+	docs := &models.WorkflowDocs{
+		WorkflowID:  wf.ID,
+		Name:        wf.Name,
+		Description: wf.Description,
+		Triggers:    []models.TriggerDoc{},
+		Nodes:       []models.NodeDoc{},
+	}
+
+	factories := make(map[string]protocol.NodeFactory)
+	for _, factory := range reg.AvailableNodes() {
+		factories[factory.ID()] = factory
+	}
+
+	connected := connectedPorts(wf)
+
+	for _, node := range wf.Nodes {
+		created, err := reg.CreateNode(ctx, node.Type, node.ID, node.Config)
+
+		var inputs, outputs []models.PortDoc
+		if err == nil {
+			inputs = inputPortDocs(created.InputPorts(), connected)
+			outputs = outputPortDocs(created.OutputPorts(), connected)
+		}
+
+		if node.IsTriggerNode() {
+			docs.Triggers = append(docs.Triggers, triggerDoc(node, inputs, outputs))
+
+			continue
+		}
+
+		doc := models.NodeDoc{
+			NodeID:  node.ID,
+			Name:    node.Name,
+			Type:    node.Type,
+			Inputs:  inputs,
+			Outputs: outputs,
+		}
+
+		if factory, ok := factories[node.Type]; ok {
+			doc.Description = factory.Description()
+			doc.ConfigSchema = factory.Schema()
+		}
+
+		if err != nil {
+			doc.Error = err.Error()
+		}
+
+		docs.Nodes = append(docs.Nodes, doc)
+	}
+
+	return docs
+}
+
+// triggerDoc describes a trigger node, taking its trigger data from the schema of the input port
+// the event arrives on.
+func triggerDoc(node *models.WorkflowNode, inputs, outputs []models.PortDoc) models.TriggerDoc {
+	doc := models.TriggerDoc{
+		NodeID:  node.ID,
+		Name:    node.Name,
+		Type:    node.Type,
+		Outputs: outputs,
+	}
+
+	if node.ProviderID != nil {
+		doc.ProviderID = *node.ProviderID
+	}
+
+	if node.EventType != nil {
+		doc.EventType = *node.EventType
+	}
+
+	if len(inputs) > 0 {
+		doc.TriggerData = inputs[0].Schema
+	}
+
+	return doc
+}
+
+// connectedPorts returns the port IDs connected to each port ID of wf, in both directions.
+func connectedPorts(wf *models.Workflow) map[string][]string {
+	connected := make(map[string][]string)
+
+	for _, conn := range wf.Connections {
+		connected[conn.SourcePort] = append(connected[conn.SourcePort], conn.TargetPort)
+		connected[conn.TargetPort] = append(connected[conn.TargetPort], conn.SourcePort)
+	}
+
+	return connected
+}
+
+func inputPortDocs(ports []models.InputPort, connected map[string][]string) []models.PortDoc {
+	docs := make([]models.PortDoc, 0, len(ports))
+	for _, port := range ports {
+		docs = append(docs, portDoc(port.Port, connected))
+	}
+
+	return docs
+}
+
+func outputPortDocs(ports []models.OutputPort, connected map[string][]string) []models.PortDoc {
+	docs := make([]models.PortDoc, 0, len(ports))
+	for _, port := range ports {
+		docs = append(docs, portDoc(port.Port, connected))
+	}
+
+	return docs
+}
+
+func portDoc(port models.Port, connected map[string][]string) models.PortDoc {
+	return models.PortDoc{
+		Name:        port.Name,
+		Description: port.Description,
+		Schema:      port.Schema,
+		Connections: connected[models.MakePortID(port.NodeID, port.Name)],
+	}
+}
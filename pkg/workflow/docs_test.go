@@ -0,0 +1,86 @@
+package workflow
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateDocs(t *testing.T) {
+	reg := registry.NewRegistry(slog.Default())
+	reg.RegisterDefaultNodes()
+
+	providerID := "webhook"
+	eventType := "WebhookReceived"
+
+	wf := &models.Workflow{
+		ID:          "orders",
+		Name:        "Orders",
+		Description: "Posts new orders to the fulfilment service",
+		Nodes: []*models.WorkflowNode{
+			{
+				ID: "trigger", Name: "Order Webhook", Type: models.NodeTypeTriggerWebhook, Category: models.CategoryTypeTrigger,
+				Config: map[string]any{"webhook_path": "/orders"}, ProviderID: &providerID, EventType: &eventType, Enabled: true,
+			},
+			{
+				ID: "fulfil", Name: "Fulfil", Type: "httprequest", Category: models.CategoryTypeAction,
+				Config: map[string]any{"url": "https://fulfilment.example.com/orders", "method": "POST"}, Enabled: true,
+			},
+			{ID: "done", Name: "Done", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "done"}, Enabled: true},
+			{ID: "unknown", Name: "Unknown", Type: "does-not-exist", Category: models.CategoryTypeAction, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "c1", SourcePort: "trigger:success", TargetPort: "fulfil:main"},
+			{ID: "c2", SourcePort: "fulfil:success", TargetPort: "done:main"},
+		},
+	}
+
+	docs := GenerateDocs(t.Context(), reg, wf)
+
+	assert.Equal(t, "orders", docs.WorkflowID)
+	assert.Equal(t, "Posts new orders to the fulfilment service", docs.Description)
+
+	require.Len(t, docs.Triggers, 1)
+	trigger := docs.Triggers[0]
+	assert.Equal(t, "webhook", trigger.ProviderID)
+	assert.Equal(t, "WebhookReceived", trigger.EventType)
+	assert.Contains(t, trigger.TriggerData["properties"], "body")
+	require.NotEmpty(t, trigger.Outputs)
+	assert.Equal(t, "success", trigger.Outputs[0].Name)
+	assert.Equal(t, []string{"fulfil:main"}, trigger.Outputs[0].Connections)
+
+	require.Len(t, docs.Nodes, 3)
+
+	fulfil := docs.Nodes[0]
+	assert.Equal(t, "httprequest", fulfil.Type)
+	assert.NotEmpty(t, fulfil.Description)
+	assert.Contains(t, fulfil.ConfigSchema["properties"], "url")
+	assert.Empty(t, fulfil.Error)
+	assert.Equal(t, []string{"main"}, portNames(fulfil.Inputs))
+	assert.Equal(t, []string{"trigger:success"}, fulfil.Inputs[0].Connections)
+	assert.Equal(t, []string{"success", "error"}, portNames(fulfil.Outputs))
+	assert.Equal(t, []string{"done:main"}, fulfil.Outputs[0].Connections)
+	assert.NotEmpty(t, fulfil.Outputs[0].Schema)
+
+	done := docs.Nodes[1]
+	assert.Equal(t, []string{"main"}, portNames(done.Inputs))
+	assert.Equal(t, []string{"fulfil:success"}, done.Inputs[0].Connections)
+
+	unknown := docs.Nodes[2]
+	assert.Contains(t, unknown.Error, "not registered")
+	assert.Empty(t, unknown.Inputs)
+	assert.Empty(t, unknown.Outputs)
+}
+
+func portNames(ports []models.PortDoc) []string {
+	names := make([]string, 0, len(ports))
+	for _, port := range ports {
+		names = append(names, port.Name)
+	}
+
+	return names
+}
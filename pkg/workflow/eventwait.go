@@ -0,0 +1,157 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/template"
+)
+
+// eventWaitResumedBy identifies received or expired event waits in WorkflowExecutionResumed events.
+const eventWaitResumedBy = "event"
+
+// ReceiveSourceEvent resumes, on their received port, the pending event waits the source event
+// matches: waits on its provider and event type whose event key, rendered over the event data,
+// equals their correlation key. Only executions of workflows accepted by owns are resumed; a nil
+// owns accepts every workflow. It returns how many waits were resumed.
+func (s *ExecutionService) ReceiveSourceEvent(ctx context.Context, event *events.SourceEvent, owns func(workflowID string) bool) (int, error) {
+	paused, err := s.persistence.ExecutionContextRepository().GetExecutionsByStatus(ctx, models.ExecutionStatusPaused)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get paused executions: %w", err)
+	}
+
+	now := time.Now().UTC()
+	received := 0
+
+	for _, execCtx := range paused {
+		if owns != nil && !owns(execCtx.WorkflowID) {
+			continue
+		}
+
+		for i := range execCtx.EventWaits {
+			wait := &execCtx.EventWaits[i]
+			if !wait.Awaits(event.ProviderID, event.EventType) || wait.IsExpired(now) {
+				continue
+			}
+
+			key, err := renderEventKey(wait.EventKey, event.EventData)
+			if err != nil || key != wait.CorrelationKey {
+				continue
+			}
+
+			if err := s.resumeEventWait(ctx, execCtx, wait, models.EventWaitStatusReceived, event.EventData, now); err != nil {
+				return received, err
+			}
+
+			received++
+		}
+	}
+
+	return received, nil
+}
+
+// ExpireEventWaits resumes every pending event wait that expired by now on its timeout port,
+// returning how many waits expired.
+func (s *ExecutionService) ExpireEventWaits(ctx context.Context, now time.Time) (int, error) {
+	paused, err := s.persistence.ExecutionContextRepository().GetExecutionsByStatus(ctx, models.ExecutionStatusPaused)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get paused executions: %w", err)
+	}
+
+	expired := 0
+
+	for _, execCtx := range paused {
+		for i := range execCtx.EventWaits {
+			wait := &execCtx.EventWaits[i]
+			if !wait.IsExpired(now) {
+				continue
+			}
+
+			if err := s.resumeEventWait(ctx, execCtx, wait, models.EventWaitStatusExpired, nil, now); err != nil {
+				return expired, err
+			}
+
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+// renderEventKey renders the event key template of a wait with the event data as .event_data.
+func renderEventKey(eventKey string, eventData map[string]any) (string, error) {
+	tmpl, err := template.Parse(eventKey)
+	if err != nil {
+		return "", err
+	}
+
+	var key strings.Builder
+	if err := tmpl.Execute(&key, map[string]any{"event_data": eventData}); err != nil {
+		return "", fmt.Errorf("failed to render event key: %w", err)
+	}
+
+	return key.String(), nil
+}
+
+// resumeEventWait ends wait with status, stores the node's result on the received or timeout
+// port and activates the nodes connected to that port. A received event is added to the data
+// the node waited with as "event".
+func (s *ExecutionService) resumeEventWait(
+	ctx context.Context,
+	execCtx *models.ExecutionContext,
+	wait *models.EventWait,
+	status models.EventWaitStatus,
+	eventData map[string]any,
+	now time.Time,
+) error {
+	wait.Status = status
+	wait.ResolvedAt = &now
+
+	port := wait.Port()
+
+	data := make(map[string]any, len(wait.Data)+1)
+	maps.Copy(data, wait.Data)
+
+	if status == models.EventWaitStatusReceived {
+		data["event"] = eventData
+	}
+
+	if execCtx.NodeResults == nil {
+		execCtx.NodeResults = make(map[string]models.NodeResult)
+	}
+
+	execCtx.NodeResults[models.MakeNodeResultKey(wait.NodeID, port)] = models.NodeResult{
+		NodeID:    wait.NodeID,
+		Data:      data,
+		Status:    string(models.NodeStatusSuccess),
+		Timestamp: now,
+	}
+
+	if !execCtx.HasPendingApprovals() && !execCtx.HasPendingEventWaits() {
+		execCtx.Status = models.ExecutionStatusRunning
+	}
+
+	if err := s.persistence.ExecutionContextRepository().UpdateExecutionContext(ctx, execCtx); err != nil {
+		return fmt.Errorf("failed to update execution context: %w", err)
+	}
+
+	resumedEvent := &events.WorkflowExecutionResumed{
+		BaseEvent:       events.NewBaseEvent(events.WorkflowExecutionResumedEvent, execCtx.WorkflowID),
+		ExecutionID:     execCtx.ID,
+		Status:          string(execCtx.Status),
+		ResumedBy:       eventWaitResumedBy,
+		PauseDurationMs: now.Sub(wait.CreatedAt).Milliseconds(),
+	}
+	resumedEvent.CorrelationID = execCtx.CorrelationID
+
+	if err := s.eventBus.Publish(ctx, wait.NodeID+":"+execCtx.ID, resumedEvent); err != nil {
+		return fmt.Errorf("failed to publish execution resumed event: %w", err)
+	}
+
+	return s.activatePort(ctx, execCtx, wait.NodeID, port, data)
+}
@@ -0,0 +1,159 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupWaitingExecution stores a trigger -> wait -> (ship | escalate) workflow whose execution is
+// paused at the wait node until a webhook event for order 42 arrives.
+func setupWaitingExecution(t *testing.T, p persistence.Persistence, expiresAt *time.Time) *models.ExecutionContext {
+	t.Helper()
+
+	workflow := &models.Workflow{
+		ID:     "await-workflow",
+		Name:   "Await Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "trigger", Name: "Trigger", Type: models.NodeTypeTriggerWebhook, Category: models.CategoryTypeTrigger, Config: map[string]any{}, Enabled: true},
+			{ID: "wait", Name: "Wait", Type: "awaitevent", Category: models.CategoryTypeAction, Config: map[string]any{}, Enabled: true},
+			{ID: "ship", Name: "Ship", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "ship"}, Enabled: true},
+			{ID: "escalate", Name: "Escalate", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "escalate"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "c1", SourcePort: "trigger:success", TargetPort: "wait:main"},
+			{ID: "c2", SourcePort: "wait:received", TargetPort: "ship:main"},
+			{ID: "c3", SourcePort: "wait:timeout", TargetPort: "escalate:main"},
+		},
+	}
+	require.NoError(t, p.WorkflowRepository().Save(t.Context(), workflow))
+
+	execCtx := &models.ExecutionContext{
+		ID:         "exec-waiting",
+		WorkflowID: workflow.ID,
+		Status:     models.ExecutionStatusPaused,
+		NodeResults: map[string]models.NodeResult{
+			models.MakeNodeResultKey("trigger", "success"): {
+				NodeID: "trigger", Data: map[string]any{"order_id": "42"}, Status: string(models.NodeStatusSuccess), Timestamp: time.Now(),
+			},
+		},
+		EventWaits: []models.EventWait{
+			{
+				ID:             "wait-1",
+				NodeID:         "wait",
+				ProviderID:     "webhook",
+				EventType:      "WebhookReceived",
+				EventKey:       "{{.event_data.body.order_id}}",
+				CorrelationKey: "42",
+				Data:           map[string]any{"order_id": "42"},
+				Status:         models.EventWaitStatusPending,
+				CreatedAt:      time.Now().UTC().Add(-time.Hour),
+				ExpiresAt:      expiresAt,
+			},
+		},
+		CreatedAt: time.Now().UTC().Add(-time.Hour),
+	}
+	require.NoError(t, p.ExecutionContextRepository().SaveExecutionContext(t.Context(), execCtx))
+
+	return execCtx
+}
+
+func webhookEvent(orderID string) *events.SourceEvent {
+	return &events.SourceEvent{
+		SourceID:   "source-1",
+		ProviderID: "webhook",
+		EventType:  "WebhookReceived",
+		EventData:  map[string]any{"body": map[string]any{"order_id": orderID, "status": "paid"}},
+	}
+}
+
+func TestExecutionService_ReceiveSourceEvent(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupWaitingExecution(t, p, nil)
+	service, eventBus := newTestExecutionService(t, p)
+
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// Events of another order, provider or type do not end the wait
+	other := webhookEvent("42")
+	other.EventType = "WebhookFailed"
+
+	for _, event := range []*events.SourceEvent{webhookEvent("7"), other} {
+		received, err := service.ReceiveSourceEvent(t.Context(), event, nil)
+		require.NoError(t, err)
+		assert.Zero(t, received)
+	}
+
+	// Executions of workflows owned elsewhere are left waiting
+	received, err := service.ReceiveSourceEvent(t.Context(), webhookEvent("42"), func(string) bool { return false })
+	require.NoError(t, err)
+	assert.Zero(t, received)
+	assert.Empty(t, publishedActivations(eventBus))
+
+	received, err = service.ReceiveSourceEvent(t.Context(), webhookEvent("42"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, received)
+
+	activations := publishedActivations(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "ship", activations[0].NodeID)
+	assert.Equal(t, "wait", activations[0].SourceNode)
+	assert.Equal(t, "received", activations[0].SourcePort)
+
+	data, ok := activations[0].InputData.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "42", data["order_id"])
+	assert.Equal(t, map[string]any{"body": map[string]any{"order_id": "42", "status": "paid"}}, data["event"])
+
+	stored, err := p.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-waiting")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusRunning, stored.Status)
+	assert.Equal(t, models.EventWaitStatusReceived, stored.EventWaits[0].Status)
+	assert.NotNil(t, stored.EventWaits[0].ResolvedAt)
+	assert.Contains(t, stored.NodeResults, models.MakeNodeResultKey("wait", "received"))
+
+	// A received wait is not resumed again
+	received, err = service.ReceiveSourceEvent(t.Context(), webhookEvent("42"), nil)
+	require.NoError(t, err)
+	assert.Zero(t, received)
+}
+
+func TestExecutionService_ExpireEventWaits(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	expiresAt := time.Now().UTC().Add(time.Minute)
+	setupWaitingExecution(t, p, &expiresAt)
+	service, eventBus := newTestExecutionService(t, p)
+
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	expired, err := service.ExpireEventWaits(t.Context(), time.Now().UTC())
+	require.NoError(t, err)
+	assert.Zero(t, expired)
+
+	expired, err = service.ExpireEventWaits(t.Context(), expiresAt.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	activations := publishedActivations(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "escalate", activations[0].NodeID)
+	assert.Equal(t, "timeout", activations[0].SourcePort)
+
+	stored, err := p.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-waiting")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusRunning, stored.Status)
+	assert.Equal(t, models.EventWaitStatusExpired, stored.EventWaits[0].Status)
+
+	// The event arriving after the timeout no longer resumes the execution
+	received, err := service.ReceiveSourceEvent(t.Context(), webhookEvent("42"), nil)
+	require.NoError(t, err)
+	assert.Zero(t, received)
+}
@@ -0,0 +1,406 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dukex/operion/pkg/eventbus"
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/payloads"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/registry"
+)
+
+var (
+	// ErrExecutionNotFound is returned when an execution is not found.
+	ErrExecutionNotFound = errors.New("execution not found")
+
+	// ErrNodeNotFound is returned when a node is not part of the workflow.
+	ErrNodeNotFound = errors.New("node not found")
+
+	// ErrUnsatisfiedInputs is returned when the prior execution does not hold
+	// enough upstream results to activate the requested node.
+	ErrUnsatisfiedInputs = errors.New("node inputs cannot be satisfied from the prior execution")
+
+	// ErrEventBusNotConfigured is returned when resuming an execution without an event bus to
+	// publish its node activations on.
+	ErrEventBusNotConfigured = errors.New("event bus not configured")
+)
+
+const (
+	// MetadataResumedFromExecutionID references the execution a resumed execution was created from.
+	MetadataResumedFromExecutionID = "resumed_from_execution_id"
+
+	// MetadataResumedFromNodeID references the node a resumed execution was started at.
+	MetadataResumedFromNodeID = "resumed_from_node_id"
+
+	triggerInputPort = "external"
+)
+
+// ExecutionService handles operations on workflow executions.
+type ExecutionService struct {
+	persistence persistence.Persistence
+	eventBus    eventbus.EventBus
+	registry    *registry.Registry
+	payloads    *payloads.Offloader
+}
+
+// NewExecutionService creates a new execution service.
+func NewExecutionService(
+	persistence persistence.Persistence,
+	eventBus eventbus.EventBus,
+	registry *registry.Registry,
+) *ExecutionService {
+	return &ExecutionService{
+		persistence: persistence,
+		eventBus:    eventBus,
+		registry:    registry,
+	}
+}
+
+// ConfigurePayloadOffloading sets the offloader holding the large payloads of executions, so
+// they are resolved when resuming and deleted with their execution.
+func (s *ExecutionService) ConfigurePayloadOffloading(offloader *payloads.Offloader) {
+	s.payloads = offloader
+}
+
+// GetExecution returns the execution identified by executionID with all its node results.
+func (s *ExecutionService) GetExecution(ctx context.Context, executionID string) (*models.ExecutionContext, error) {
+	execution, err := s.persistence.ExecutionContextRepository().GetExecutionContext(ctx, executionID)
+	if err != nil {
+		if errors.Is(err, persistence.ErrExecutionContextNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrExecutionNotFound, executionID)
+		}
+
+		return nil, fmt.Errorf("failed to get execution: %w", err)
+	}
+
+	if execution == nil {
+		return nil, fmt.Errorf("%w: %s", ErrExecutionNotFound, executionID)
+	}
+
+	return execution, nil
+}
+
+// ListNodeResults returns a page of the node results of the execution identified by executionID.
+func (s *ExecutionService) ListNodeResults(
+	ctx context.Context,
+	executionID string,
+	query models.NodeResultQuery,
+) (*models.NodeResultPage, error) {
+	page, err := s.persistence.ExecutionContextRepository().ListNodeResults(ctx, executionID, query)
+	if err != nil {
+		if errors.Is(err, persistence.ErrExecutionContextNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrExecutionNotFound, executionID)
+		}
+
+		if errors.Is(err, models.ErrInvalidCursor) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("failed to list node results: %w", err)
+	}
+
+	return page, nil
+}
+
+// DeleteExecution deletes the execution identified by executionID and its offloaded payloads.
+func (s *ExecutionService) DeleteExecution(ctx context.Context, executionID string) error {
+	err := s.persistence.ExecutionContextRepository().DeleteExecutionContext(ctx, executionID)
+	if err != nil {
+		if errors.Is(err, persistence.ErrExecutionContextNotFound) {
+			return fmt.Errorf("%w: %s", ErrExecutionNotFound, executionID)
+		}
+
+		return fmt.Errorf("failed to delete execution: %w", err)
+	}
+
+	if s.payloads != nil {
+		if err := s.payloads.DeleteExecution(ctx, executionID); err != nil {
+			return fmt.Errorf("failed to delete execution payloads: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ResumeFromNode creates a new execution of the workflow behind executionID that
+// starts at nodeID. Successful results of nodes that are not downstream of nodeID
+// are carried over, so those nodes are not executed again. It fails with
+// ErrEventBusNotConfigured when the service has no event bus.
+func (s *ExecutionService) ResumeFromNode(ctx context.Context, executionID, nodeID string) (*models.ExecutionContext, error) {
+	if s.eventBus == nil {
+		return nil, ErrEventBusNotConfigured
+	}
+
+	prior, err := s.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.persistence.WorkflowRepository().GetByID(ctx, prior.WorkflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	if workflow == nil {
+		return nil, ErrWorkflowNotFound
+	}
+
+	var node *models.WorkflowNode
+
+	for _, n := range workflow.Nodes {
+		if n.ID == nodeID {
+			node = n
+
+			break
+		}
+	}
+
+	if node == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
+	}
+
+	downstream := downstreamNodes(workflow.Connections, nodeID)
+
+	// Keep successful results from nodes that will not run again
+	nodeResults := make(map[string]models.NodeResult)
+
+	for key, result := range prior.NodeResults {
+		resultNodeID, _, ok := models.ParseNodeResultKey(key)
+		if !ok || downstream[resultNodeID] || result.Status == string(models.NodeStatusError) {
+			continue
+		}
+
+		nodeResults[key] = result
+	}
+
+	triggerData := prior.TriggerData
+
+	// Offloaded payloads are read back, as inputs and transforms need the data itself
+	if s.payloads != nil {
+		carried := &models.ExecutionContext{
+			ID:             prior.ID,
+			TriggerData:    triggerData,
+			TriggerDataRef: prior.TriggerDataRef,
+			NodeResults:    nodeResults,
+		}
+
+		if err := s.payloads.ResolveExecution(ctx, carried); err != nil {
+			return nil, err
+		}
+
+		triggerData = carried.TriggerData
+	}
+
+	inputs, err := s.collectInputs(workflow.Connections, node, triggerData, nodeResults)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.getNodeInputRequirements(ctx, node).SatisfiedBy(inputs) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsatisfiedInputs, nodeID)
+	}
+
+	resumedID := s.eventBus.GenerateID(ctx)
+
+	// Carried payloads are offloaded again under the resumed execution, which must not
+	// depend on the blobs of the prior execution
+	storedTriggerData := triggerData
+	storedResults := nodeResults
+
+	var triggerDataRef string
+
+	if s.payloads != nil {
+		if triggerDataRef, err = s.payloads.Offload(ctx, resumedID, "trigger_data", triggerData); err != nil {
+			return nil, fmt.Errorf("failed to offload trigger data: %w", err)
+		}
+
+		if triggerDataRef != "" {
+			storedTriggerData = nil
+		}
+
+		storedResults = make(map[string]models.NodeResult, len(nodeResults))
+
+		for key, result := range nodeResults {
+			if storedResults[key], err = s.payloads.OffloadResult(ctx, resumedID, key, result); err != nil {
+				return nil, fmt.Errorf("failed to offload result %s: %w", key, err)
+			}
+		}
+	}
+
+	execCtx := &models.ExecutionContext{
+		ID:             resumedID,
+		WorkflowID:     prior.WorkflowID,
+		CorrelationID:  resumedID,
+		OrderingKey:    prior.OrderingKey,
+		Status:         models.ExecutionStatusRunning,
+		NodeResults:    storedResults,
+		TriggerData:    storedTriggerData,
+		TriggerDataRef: triggerDataRef,
+		Variables:      prior.Variables,
+		Metadata: map[string]any{
+			MetadataResumedFromExecutionID: prior.ID,
+			MetadataResumedFromNodeID:      nodeID,
+		},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := s.persistence.ExecutionContextRepository().SaveExecutionContext(ctx, execCtx); err != nil {
+		return nil, fmt.Errorf("failed to save execution context: %w", err)
+	}
+
+	ports := make([]string, 0, len(inputs))
+	for port := range inputs {
+		ports = append(ports, port)
+	}
+
+	sort.Strings(ports)
+
+	activations := make([]*events.NodeActivation, 0, len(ports))
+
+	for _, port := range ports {
+		input := inputs[port]
+
+		event := &events.NodeActivation{
+			BaseEvent:   events.NewBaseEvent(events.NodeActivationEvent, execCtx.WorkflowID),
+			ExecutionID: execCtx.ID,
+			NodeID:      nodeID,
+			WorkflowID:  execCtx.WorkflowID,
+			InputPort:   port,
+			InputData:   input.Data,
+			SourceNode:  input.NodeID,
+			OrderingKey: execCtx.OrderingKey,
+			Priority:    workflow.Priority(),
+		}
+		event.CorrelationID = execCtx.CorrelationID
+
+		activations = append(activations, event)
+	}
+
+	if err := s.publishActivations(ctx, execCtx.ID, activations); err != nil {
+		return nil, err
+	}
+
+	return execCtx, nil
+}
+
+// publishActivations publishes node activations of an execution, counting them all as pending
+// before publishing any, so that the execution does not complete while they are published. The
+// activations that could not be published are no longer counted.
+func (s *ExecutionService) publishActivations(ctx context.Context, executionID string, activations []*events.NodeActivation) error {
+	if len(activations) == 0 {
+		return nil
+	}
+
+	repository := s.persistence.ExecutionContextRepository()
+
+	if _, err := repository.AddPendingActivations(ctx, executionID, len(activations)); err != nil {
+		return fmt.Errorf("failed to count pending activations: %w", err)
+	}
+
+	for i, activation := range activations {
+		if err := s.eventBus.Publish(ctx, activation.NodeID+":"+executionID, activation); err != nil {
+			_, _ = repository.AddPendingActivations(ctx, executionID, i-len(activations))
+
+			return fmt.Errorf("failed to publish node activation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// collectInputs rebuilds the inputs of node, keyed by input port name, from the
+// retained results of its upstream nodes. It fails when a connection transform fails.
+func (s *ExecutionService) collectInputs(
+	connections []*models.Connection,
+	node *models.WorkflowNode,
+	triggerData map[string]any,
+	nodeResults map[string]models.NodeResult,
+) (map[string]models.NodeResult, error) {
+	inputs := make(map[string]models.NodeResult)
+
+	if node.IsTriggerNode() {
+		inputs[triggerInputPort] = models.NodeResult{
+			Data:   triggerData,
+			Status: string(models.NodeStatusSuccess),
+		}
+
+		return inputs, nil
+	}
+
+	for _, conn := range connections {
+		targetNodeID, targetPort, ok := models.ParsePortID(conn.TargetPort)
+		if !ok || targetNodeID != node.ID {
+			continue
+		}
+
+		sourceNodeID, sourcePort, ok := models.ParsePortID(conn.SourcePort)
+		if !ok {
+			continue
+		}
+
+		result, exists := nodeResults[models.MakeNodeResultKey(sourceNodeID, sourcePort)]
+		if !exists {
+			continue
+		}
+
+		data, err := ApplyConnectionTransform(conn, result.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		result.NodeID = sourceNodeID
+		result.Data = data
+		inputs[targetPort] = result
+	}
+
+	return inputs, nil
+}
+
+// getNodeInputRequirements gets the input requirements declared by a node,
+// falling back to the defaults when the node cannot be created.
+func (s *ExecutionService) getNodeInputRequirements(ctx context.Context, node *models.WorkflowNode) models.InputRequirements {
+	nodeImpl, err := s.registry.CreateNode(ctx, node.Type, node.ID, node.Config)
+	if err != nil {
+		return models.DefaultInputRequirements()
+	}
+
+	if reqNode, ok := nodeImpl.(models.NodeInputRequirements); ok {
+		return reqNode.InputRequirements()
+	}
+
+	return models.DefaultInputRequirements()
+}
+
+// downstreamNodes returns the set of nodes reachable from nodeID, including nodeID itself.
+func downstreamNodes(connections []*models.Connection, nodeID string) map[string]bool {
+	visited := map[string]bool{nodeID: true}
+	queue := []string{nodeID}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, conn := range connections {
+			sourceNodeID, _, ok := models.ParsePortID(conn.SourcePort)
+			if !ok || sourceNodeID != current {
+				continue
+			}
+
+			targetNodeID, _, ok := models.ParsePortID(conn.TargetPort)
+			if !ok || visited[targetNodeID] {
+				continue
+			}
+
+			visited[targetNodeID] = true
+			queue = append(queue, targetNodeID)
+		}
+	}
+
+	return visited
+}
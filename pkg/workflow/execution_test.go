@@ -0,0 +1,204 @@
+package workflow
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/mocks"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/payloads"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/dukex/operion/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExecutionService(t *testing.T, p persistence.Persistence) (*ExecutionService, *mocks.MockEventBus) {
+	t.Helper()
+
+	reg := registry.NewRegistry(slog.Default())
+	reg.RegisterDefaultNodes()
+
+	eventBus := &mocks.MockEventBus{}
+
+	return NewExecutionService(p, eventBus, reg), eventBus
+}
+
+// setupFailedExecution stores a trigger -> fetch -> notify workflow whose
+// execution failed at the fetch node.
+func setupFailedExecution(t *testing.T, p persistence.Persistence) *models.ExecutionContext {
+	t.Helper()
+
+	workflow := &models.Workflow{
+		ID:     "resume-workflow",
+		Name:   "Resume Workflow",
+		Status: models.WorkflowStatusPublished,
+		Nodes: []*models.WorkflowNode{
+			{ID: "trigger", Name: "Trigger", Type: models.NodeTypeTriggerWebhook, Category: models.CategoryTypeTrigger, Config: map[string]any{}, Enabled: true},
+			{ID: "fetch", Name: "Fetch", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "fetch"}, Enabled: true},
+			{ID: "notify", Name: "Notify", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "notify"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "c1", SourcePort: "trigger:success", TargetPort: "fetch:main"},
+			{ID: "c2", SourcePort: "fetch:success", TargetPort: "notify:main"},
+		},
+	}
+	require.NoError(t, p.WorkflowRepository().Save(t.Context(), workflow))
+
+	execCtx := &models.ExecutionContext{
+		ID:         "exec-failed",
+		WorkflowID: workflow.ID,
+		Status:     models.ExecutionStatusFailed,
+		NodeResults: map[string]models.NodeResult{
+			models.MakeNodeResultKey("trigger", "success"): {
+				NodeID: "trigger", Data: map[string]any{"order_id": "42"}, Status: string(models.NodeStatusSuccess), Timestamp: time.Now(),
+			},
+			models.MakeNodeResultKey("fetch", "error"): {
+				NodeID: "fetch", Data: map[string]any{}, Status: string(models.NodeStatusError), Error: "timeout", Timestamp: time.Now(),
+			},
+		},
+		TriggerData: map[string]any{"order_id": "42"},
+		Variables:   map[string]any{"env": "test"},
+		CreatedAt:   time.Now().UTC(),
+	}
+	require.NoError(t, p.ExecutionContextRepository().SaveExecutionContext(t.Context(), execCtx))
+
+	return execCtx
+}
+
+func TestExecutionService_ResumeFromNode_FailedTransform(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	prior := setupFailedExecution(t, p)
+	service, eventBus := newTestExecutionService(t, p)
+
+	workflow, err := p.WorkflowRepository().GetByID(t.Context(), prior.WorkflowID)
+	require.NoError(t, err)
+
+	workflow.Connections[0].Transform = `{{ .data.order_id.nope }}`
+	require.NoError(t, p.WorkflowRepository().Save(t.Context(), workflow))
+
+	_, err = service.ResumeFromNode(t.Context(), prior.ID, "fetch")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to transform data on connection c1")
+
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExecutionService_ResumeFromNode(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	prior := setupFailedExecution(t, p)
+	service, eventBus := newTestExecutionService(t, p)
+
+	eventBus.On("GenerateID", mock.Anything).Return("exec-resumed")
+	eventBus.On("Publish", mock.Anything, "fetch:exec-resumed", mock.Anything).Return(nil)
+
+	resumed, err := service.ResumeFromNode(t.Context(), prior.ID, "fetch")
+	require.NoError(t, err)
+
+	assert.Equal(t, "exec-resumed", resumed.ID)
+	assert.Equal(t, prior.WorkflowID, resumed.WorkflowID)
+	assert.Equal(t, models.ExecutionStatusRunning, resumed.Status)
+	assert.Equal(t, prior.ID, resumed.Metadata[MetadataResumedFromExecutionID])
+	assert.Equal(t, "fetch", resumed.Metadata[MetadataResumedFromNodeID])
+
+	// Upstream trigger result is retained, failed node result is dropped
+	assert.Contains(t, resumed.NodeResults, models.MakeNodeResultKey("trigger", "success"))
+	assert.NotContains(t, resumed.NodeResults, models.MakeNodeResultKey("fetch", "error"))
+
+	// Only the resumed node is activated, fed by the trigger's retained output
+	eventBus.AssertNumberOfCalls(t, "Publish", 1)
+
+	activation, ok := eventBus.Calls[1].Arguments.Get(2).(*events.NodeActivation)
+	require.True(t, ok)
+	assert.Equal(t, "fetch", activation.NodeID)
+	assert.Equal(t, "main", activation.InputPort)
+	assert.Equal(t, "trigger", activation.SourceNode)
+	assert.Equal(t, map[string]any{"order_id": "42"}, activation.InputData)
+
+	stored, err := p.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-resumed")
+	require.NoError(t, err)
+	assert.Len(t, stored.NodeResults, 1)
+}
+
+func TestExecutionService_ResumeFromNode_UnsatisfiedInputs(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	prior := setupFailedExecution(t, p)
+	service, eventBus := newTestExecutionService(t, p)
+
+	// notify depends on fetch, which has no successful result to replay
+	_, err := service.ResumeFromNode(t.Context(), prior.ID, "notify")
+	require.ErrorIs(t, err, ErrUnsatisfiedInputs)
+
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExecutionService_ResumeFromNode_NotFound(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	prior := setupFailedExecution(t, p)
+	service, _ := newTestExecutionService(t, p)
+
+	_, err := service.ResumeFromNode(t.Context(), "missing-exec", "fetch")
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+
+	_, err = service.ResumeFromNode(t.Context(), prior.ID, "missing-node")
+	require.ErrorIs(t, err, ErrNodeNotFound)
+}
+
+func TestExecutionService_ResumeFromNode_WithoutEventBus(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	prior := setupFailedExecution(t, p)
+	service := NewExecutionService(p, nil, registry.NewRegistry(slog.Default()))
+
+	_, err := service.ResumeFromNode(t.Context(), prior.ID, "fetch")
+	require.ErrorIs(t, err, ErrEventBusNotConfigured)
+
+	executions, err := p.ExecutionContextRepository().GetExecutionsByWorkflow(t.Context(), prior.WorkflowID)
+	require.NoError(t, err)
+	assert.Len(t, executions, 1, "no execution is created")
+}
+
+func TestExecutionService_ResumeFromNode_OffloadedPayloads(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	prior := setupFailedExecution(t, p)
+	service, eventBus := newTestExecutionService(t, p)
+
+	offloader := payloads.NewOffloader(payloads.NewFileStore(t.TempDir()), 16)
+	service.ConfigurePayloadOffloading(offloader)
+
+	// The prior execution stored the trigger result as a reference
+	key := models.MakeNodeResultKey("trigger", "success")
+	result := prior.NodeResults[key]
+	result.Data = map[string]any{"order_id": "42", "document": strings.Repeat("x", 64)}
+
+	result, err := offloader.OffloadResult(t.Context(), prior.ID, key, result)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.PayloadRef)
+
+	prior.NodeResults[key] = result
+	require.NoError(t, p.ExecutionContextRepository().UpdateExecutionContext(t.Context(), prior))
+
+	eventBus.On("GenerateID", mock.Anything).Return("exec-resumed")
+	eventBus.On("Publish", mock.Anything, "fetch:exec-resumed", mock.Anything).Return(nil)
+
+	resumed, err := service.ResumeFromNode(t.Context(), prior.ID, "fetch")
+	require.NoError(t, err)
+
+	// The resumed node is activated with the resolved data
+	activation, ok := eventBus.Calls[1].Arguments.Get(2).(*events.NodeActivation)
+	require.True(t, ok)
+	assert.Equal(t, "42", activation.InputData.(map[string]any)["order_id"])
+
+	// The carried result is offloaded again under the resumed execution, surviving the prior one
+	assert.Contains(t, resumed.NodeResults[key].PayloadRef, "executions/exec-resumed/")
+
+	require.NoError(t, service.DeleteExecution(t.Context(), prior.ID))
+
+	data, err := offloader.Resolve(t.Context(), "exec-resumed", resumed.NodeResults[key].PayloadRef)
+	require.NoError(t, err)
+	assert.Equal(t, "42", data["order_id"])
+}
@@ -0,0 +1,250 @@
+package workflow
+
+import (
+	"slices"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// InputSources returns the upstream nodes feeding each input port of nodeID in wf, keyed by port
+// name and sorted. A node connected to a port through several of its output ports counts once.
+// Connections closing a loop are left out: only one of the ways into a loop fires at a time, so a
+// join must not wait for both.
+func InputSources(wf *models.Workflow, nodeID string) map[string][]string {
+	loopBacks := loopBackConnections(wf)
+	sources := make(map[string][]string)
+
+	for _, conn := range wf.Connections {
+		sourceNodeID, _, sourceOK := models.ParsePortID(conn.SourcePort)
+		targetNodeID, targetPort, targetOK := models.ParsePortID(conn.TargetPort)
+
+		if !sourceOK || !targetOK || targetNodeID != nodeID || loopBacks[conn] {
+			continue
+		}
+
+		if !slices.Contains(sources[targetPort], sourceNodeID) {
+			sources[targetPort] = append(sources[targetPort], sourceNodeID)
+		}
+	}
+
+	for port := range sources {
+		slices.Sort(sources[port])
+	}
+
+	return sources
+}
+
+// ExclusiveSources returns, for each upstream node of nodeID in wf, the other upstream nodes that
+// cannot send it an input in the same execution, keyed by node ID and sorted. As a node fires a
+// single output port per execution, two upstream nodes are exclusive when they are only reached
+// through different output ports of a common node, such as the true and false ports of a
+// conditional.
+func ExclusiveSources(wf *models.Workflow, nodeID string) map[string][]string {
+	loopBacks := loopBackConnections(wf)
+	condition := firingConditions(wf, loopBacks)
+
+	// An upstream node feeds nodeID only when it fires one of the ports connected to it
+	sourceConditions := make(map[string]map[string]bool)
+
+	for _, conn := range wf.Connections {
+		sourceNodeID, _, sourceOK := models.ParsePortID(conn.SourcePort)
+		targetNodeID, _, targetOK := models.ParsePortID(conn.TargetPort)
+
+		if !sourceOK || !targetOK || targetNodeID != nodeID || loopBacks[conn] {
+			continue
+		}
+
+		sourceCondition := withPort(condition(sourceNodeID), conn.SourcePort)
+		if existing, ok := sourceConditions[sourceNodeID]; ok {
+			sourceCondition = intersectPorts(existing, sourceCondition)
+		}
+
+		sourceConditions[sourceNodeID] = sourceCondition
+	}
+
+	exclusive := make(map[string][]string)
+
+	for source, sourceCondition := range sourceConditions {
+		for other, otherCondition := range sourceConditions {
+			if source != other && conflictingPorts(sourceCondition, otherCondition) {
+				exclusive[source] = append(exclusive[source], other)
+			}
+		}
+
+		slices.Sort(exclusive[source])
+	}
+
+	return exclusive
+}
+
+// firingConditions returns a function giving the output ports, as port IDs, that fire on every
+// path from an entry node of wf to a node. Loop-back connections are left out, so the walk ends.
+func firingConditions(wf *models.Workflow, loopBacks map[*models.Connection]bool) func(nodeID string) map[string]bool {
+	incoming := make(map[string][]*models.Connection)
+
+	for _, conn := range wf.Connections {
+		_, _, sourceOK := models.ParsePortID(conn.SourcePort)
+		targetNodeID, _, targetOK := models.ParsePortID(conn.TargetPort)
+
+		if sourceOK && targetOK && !loopBacks[conn] {
+			incoming[targetNodeID] = append(incoming[targetNodeID], conn)
+		}
+	}
+
+	conditions := make(map[string]map[string]bool)
+
+	var condition func(nodeID string) map[string]bool
+
+	condition = func(nodeID string) map[string]bool {
+		if known, ok := conditions[nodeID]; ok {
+			return known
+		}
+
+		// Only the ports common to every way into the node are required for it to fire
+		var required map[string]bool
+
+		for _, conn := range incoming[nodeID] {
+			sourceNodeID, _, _ := models.ParsePortID(conn.SourcePort)
+
+			viaConn := withPort(condition(sourceNodeID), conn.SourcePort)
+			if required == nil {
+				required = viaConn
+			} else {
+				required = intersectPorts(required, viaConn)
+			}
+		}
+
+		if required == nil {
+			required = make(map[string]bool)
+		}
+
+		conditions[nodeID] = required
+
+		return required
+	}
+
+	return condition
+}
+
+// withPort returns a copy of ports with portID added.
+func withPort(ports map[string]bool, portID string) map[string]bool {
+	result := make(map[string]bool, len(ports)+1)
+	for port := range ports {
+		result[port] = true
+	}
+
+	result[portID] = true
+
+	return result
+}
+
+// intersectPorts returns the port IDs present in both a and b.
+func intersectPorts(a, b map[string]bool) map[string]bool {
+	result := make(map[string]bool)
+
+	for port := range a {
+		if b[port] {
+			result[port] = true
+		}
+	}
+
+	return result
+}
+
+// conflictingPorts reports whether a and b require different output ports of the same node.
+func conflictingPorts(a, b map[string]bool) bool {
+	for portA := range a {
+		nodeA, nameA, _ := models.ParsePortID(portA)
+
+		for portB := range b {
+			nodeB, nameB, _ := models.ParsePortID(portB)
+			if nodeA == nodeB && nameA != nameB {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// loopBackConnections returns the connections of wf leading back to a node they are reached from,
+// found by a depth-first walk starting at the nodes without incoming connections.
+func loopBackConnections(wf *models.Workflow) map[*models.Connection]bool {
+	outgoing := make(map[string][]*models.Connection)
+	hasIncoming := make(map[string]bool)
+
+	var nodeIDs []string
+
+	addNode := func(nodeID string) {
+		if !slices.Contains(nodeIDs, nodeID) {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+
+	for _, node := range wf.Nodes {
+		addNode(node.ID)
+	}
+
+	for _, conn := range wf.Connections {
+		sourceNodeID, _, sourceOK := models.ParsePortID(conn.SourcePort)
+		targetNodeID, _, targetOK := models.ParsePortID(conn.TargetPort)
+
+		if !sourceOK || !targetOK {
+			continue
+		}
+
+		addNode(sourceNodeID)
+		addNode(targetNodeID)
+
+		outgoing[sourceNodeID] = append(outgoing[sourceNodeID], conn)
+		hasIncoming[targetNodeID] = true
+	}
+
+	// Walk from the entry nodes first, so loops are entered the way executions enter them
+	slices.SortStableFunc(nodeIDs, func(a, b string) int {
+		switch {
+		case hasIncoming[a] == hasIncoming[b]:
+			return 0
+		case hasIncoming[a]:
+			return 1
+		default:
+			return -1
+		}
+	})
+
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+
+	state := make(map[string]int)
+	loopBacks := make(map[*models.Connection]bool)
+
+	var visit func(nodeID string)
+
+	visit = func(nodeID string) {
+		state[nodeID] = onPath
+
+		for _, conn := range outgoing[nodeID] {
+			targetNodeID, _, _ := models.ParsePortID(conn.TargetPort)
+
+			switch state[targetNodeID] {
+			case onPath:
+				loopBacks[conn] = true
+			case unvisited:
+				visit(targetNodeID)
+			}
+		}
+
+		state[nodeID] = done
+	}
+
+	for _, nodeID := range nodeIDs {
+		if state[nodeID] == unvisited {
+			visit(nodeID)
+		}
+	}
+
+	return loopBacks
+}
@@ -0,0 +1,81 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInputSources_Join(t *testing.T) {
+	wf := &models.Workflow{
+		Connections: []*models.Connection{
+			{SourcePort: "trigger:success", TargetPort: "fetch_a:main"},
+			{SourcePort: "trigger:success", TargetPort: "fetch_b:main"},
+			{SourcePort: "trigger:success", TargetPort: "fetch_c:main"},
+			{SourcePort: "fetch_c:success", TargetPort: "join:main"},
+			{SourcePort: "fetch_a:success", TargetPort: "join:main"},
+			{SourcePort: "fetch_b:success", TargetPort: "join:main"},
+			{SourcePort: "fetch_b:error", TargetPort: "join:main"},
+			{SourcePort: "fetch_a:success", TargetPort: "join:extra"},
+		},
+	}
+
+	assert.Equal(t, map[string][]string{
+		"main":  {"fetch_a", "fetch_b", "fetch_c"},
+		"extra": {"fetch_a"},
+	}, InputSources(wf, "join"))
+	assert.Equal(t, map[string][]string{"main": {"trigger"}}, InputSources(wf, "fetch_a"))
+	assert.Empty(t, InputSources(wf, "trigger"))
+}
+
+func TestInputSources_IgnoresLoopBackConnections(t *testing.T) {
+	wf := &models.Workflow{
+		Connections: []*models.Connection{
+			{SourcePort: "trigger:success", TargetPort: "poll:main"},
+			{SourcePort: "poll:success", TargetPort: "until:main"},
+			{SourcePort: "until:repeat", TargetPort: "poll:main"},
+		},
+	}
+
+	assert.Equal(t, map[string][]string{"main": {"trigger"}}, InputSources(wf, "poll"))
+	assert.Equal(t, map[string][]string{"main": {"poll"}}, InputSources(wf, "until"))
+}
+
+func TestExclusiveSources_ConditionalBranches(t *testing.T) {
+	wf := &models.Workflow{
+		Connections: []*models.Connection{
+			{SourcePort: "trigger:success", TargetPort: "check:main"},
+			{SourcePort: "trigger:success", TargetPort: "audit:main"},
+			{SourcePort: "check:true", TargetPort: "yes:main"},
+			{SourcePort: "check:false", TargetPort: "no:main"},
+			{SourcePort: "no:success", TargetPort: "notify:main"},
+			{SourcePort: "check:true", TargetPort: "join:main"},
+			{SourcePort: "yes:success", TargetPort: "join:main"},
+			{SourcePort: "notify:success", TargetPort: "join:main"},
+			{SourcePort: "audit:success", TargetPort: "join:main"},
+			{SourcePort: "audit:error", TargetPort: "join:main"},
+		},
+	}
+
+	assert.Equal(t, map[string][]string{
+		"check":  {"notify"},
+		"yes":    {"notify"},
+		"notify": {"check", "yes"},
+	}, ExclusiveSources(wf, "join"))
+}
+
+func TestExclusiveSources_RejoinedBranchIsNotExclusive(t *testing.T) {
+	wf := &models.Workflow{
+		Connections: []*models.Connection{
+			{SourcePort: "check:true", TargetPort: "yes:main"},
+			{SourcePort: "check:false", TargetPort: "no:main"},
+			{SourcePort: "yes:success", TargetPort: "merge:main"},
+			{SourcePort: "no:success", TargetPort: "merge:main"},
+			{SourcePort: "merge:merged", TargetPort: "join:main"},
+			{SourcePort: "yes:success", TargetPort: "join:main"},
+		},
+	}
+
+	assert.Empty(t, ExclusiveSources(wf, "join"))
+}
@@ -0,0 +1,122 @@
+package workflow
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// ErrWorkflowGroupNotFound is returned when no workflow belongs to a workflow group.
+var ErrWorkflowGroupNotFound = errors.New("workflow group not found")
+
+// ListGroups returns every workflow group with its published and draft versions, most
+// recently updated first.
+func (r *Repository) ListGroups(ctx context.Context) ([]models.WorkflowGroup, error) {
+	workflows, err := r.FetchAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string][]*models.Workflow)
+	for _, workflow := range workflows {
+		id := groupID(workflow)
+		versions[id] = append(versions[id], workflow)
+	}
+
+	groups := make([]models.WorkflowGroup, 0, len(versions))
+	for id, groupVersions := range versions {
+		groups = append(groups, newGroupDetail(id, groupVersions).WorkflowGroup)
+	}
+
+	slices.SortFunc(groups, func(a, b models.WorkflowGroup) int {
+		if order := b.UpdatedAt.Compare(a.UpdatedAt); order != 0 {
+			return order
+		}
+
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return groups, nil
+}
+
+// FetchGroup returns a workflow group with its published and draft versions and the versions
+// it published before.
+func (r *Repository) FetchGroup(ctx context.Context, id string) (*models.WorkflowGroupDetail, error) {
+	versions, err := r.persistence.WorkflowRepository().GetWorkflowVersions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		// Workflows saved before groups were assigned are a group of their own
+		workflow, err := r.persistence.WorkflowRepository().GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if workflow == nil || groupID(workflow) != id {
+			return nil, ErrWorkflowGroupNotFound
+		}
+
+		versions = []*models.Workflow{workflow}
+	}
+
+	detail := newGroupDetail(id, versions)
+
+	return &detail, nil
+}
+
+// groupID returns the ID of the group of workflow.
+func groupID(workflow *models.Workflow) string {
+	if workflow.WorkflowGroupID == "" {
+		return workflow.ID
+	}
+
+	return workflow.WorkflowGroupID
+}
+
+// newGroupDetail builds the group of versions. As in the persistence layer, the latest created
+// draft and published versions are the current ones.
+func newGroupDetail(id string, versions []*models.Workflow) models.WorkflowGroupDetail {
+	slices.SortFunc(versions, func(a, b *models.Workflow) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	detail := models.WorkflowGroupDetail{
+		WorkflowGroup: models.WorkflowGroup{ID: id},
+		History:       []*models.Workflow{},
+	}
+
+	for _, version := range versions {
+		switch version.Status {
+		case models.WorkflowStatusPublished:
+			if detail.Published == nil {
+				detail.Published = version
+			}
+		case models.WorkflowStatusDraft:
+			if detail.Draft == nil {
+				detail.Draft = version
+			}
+		case models.WorkflowStatusUnpublished:
+			detail.History = append(detail.History, version)
+		}
+
+		if version.UpdatedAt.After(detail.UpdatedAt) {
+			detail.UpdatedAt = version.UpdatedAt
+		}
+	}
+
+	switch {
+	case detail.Published != nil:
+		detail.Name = detail.Published.Name
+	case detail.Draft != nil:
+		detail.Name = detail.Draft.Name
+	case len(versions) > 0:
+		detail.Name = versions[0].Name
+	}
+
+	return detail
+}
@@ -0,0 +1,167 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dukex/operion/pkg/eventbus"
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/robfig/cron/v3"
+)
+
+// HeartbeatGraceKey is the scheduler trigger setting that enables the dead man's switch of a
+// workflow: when it does not run within this duration after a scheduled run, an alert is published.
+const HeartbeatGraceKey = "heartbeat_grace"
+
+// HeartbeatStatus describes when a scheduled trigger of a published workflow last ran and when
+// it is expected to run next.
+type HeartbeatStatus struct {
+	WorkflowID     string     `json:"workflow_id"`
+	WorkflowName   string     `json:"workflow_name"`
+	TriggerNodeID  string     `json:"trigger_node_id"`
+	CronExpression string     `json:"cron_expression"`
+	LastRunAt      *time.Time `json:"last_run_at,omitempty"`
+	ExpectedRunAt  time.Time  `json:"expected_run_at"`
+	GracePeriodMs  int64      `json:"grace_period_ms,omitempty"`
+	Monitored      bool       `json:"monitored"`
+	Overdue        bool       `json:"overdue"`
+}
+
+// HeartbeatMonitor tracks the runs of scheduled workflows and alerts with a
+// workflow.heartbeat.missed event when a monitored workflow misses its schedule.
+type HeartbeatMonitor struct {
+	persistence persistence.Persistence
+	eventBus    eventbus.EventBus
+}
+
+// NewHeartbeatMonitor creates a new heartbeat monitor.
+func NewHeartbeatMonitor(persistence persistence.Persistence, eventBus eventbus.EventBus) *HeartbeatMonitor {
+	return &HeartbeatMonitor{
+		persistence: persistence,
+		eventBus:    eventBus,
+	}
+}
+
+// Status returns the heartbeat of every enabled scheduler trigger of the published workflows, as
+// of now. A workflow last ran when its latest execution that did not fail, time out or get
+// cancelled started; a workflow that never ran is expected from the time it was published.
+func (m *HeartbeatMonitor) Status(ctx context.Context, now time.Time) ([]HeartbeatStatus, error) {
+	workflows, err := m.persistence.WorkflowRepository().GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflows: %w", err)
+	}
+
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+	var statuses []HeartbeatStatus
+
+	for _, wf := range workflows {
+		if wf.Status != models.WorkflowStatusPublished {
+			continue
+		}
+
+		var (
+			lastRunAt *time.Time
+			queried   bool
+		)
+
+		for _, node := range wf.Nodes {
+			if node.Type != models.NodeTypeTriggerScheduler || !node.Enabled {
+				continue
+			}
+
+			cronExpression, _ := node.Config["cron_expression"].(string)
+
+			schedule, err := parser.Parse(cronExpression)
+			if err != nil {
+				continue
+			}
+
+			if !queried {
+				if lastRunAt, err = m.persistence.ExecutionContextRepository().GetLastRunAt(ctx, wf.ID); err != nil {
+					return nil, fmt.Errorf("failed to get last run of workflow %s: %w", wf.ID, err)
+				}
+
+				queried = true
+			}
+
+			status := HeartbeatStatus{
+				WorkflowID:     wf.ID,
+				WorkflowName:   wf.Name,
+				TriggerNodeID:  node.ID,
+				CronExpression: cronExpression,
+				LastRunAt:      lastRunAt,
+			}
+
+			since := wf.CreatedAt
+			if wf.PublishedAt != nil {
+				since = *wf.PublishedAt
+			}
+
+			if lastRunAt != nil && lastRunAt.After(since) {
+				since = *lastRunAt
+			}
+
+			status.ExpectedRunAt = schedule.Next(since)
+
+			if raw, ok := node.Config[HeartbeatGraceKey].(string); ok {
+				if grace, err := time.ParseDuration(raw); err == nil {
+					status.GracePeriodMs = grace.Milliseconds()
+					status.Monitored = true
+					status.Overdue = now.After(status.ExpectedRunAt.Add(grace))
+				}
+			}
+
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses, nil
+}
+
+// Check publishes a workflow.heartbeat.missed event for every monitored trigger overdue at now,
+// once per missed run across activator replicas and restarts, among the workflows owns accepts
+// (every workflow when nil). It returns the number of alerts published.
+func (m *HeartbeatMonitor) Check(ctx context.Context, now time.Time, owns func(workflowID string) bool) (int, error) {
+	statuses, err := m.Status(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	alerts := 0
+
+	for _, status := range statuses {
+		if !status.Overdue || (owns != nil && !owns(status.WorkflowID)) {
+			continue
+		}
+
+		claimed, err := m.persistence.AlertRepository().ClaimHeartbeatAlert(ctx, status.WorkflowID, status.TriggerNodeID, status.ExpectedRunAt)
+		if err != nil {
+			return alerts, fmt.Errorf("failed to claim missed heartbeat alert of workflow %s: %w", status.WorkflowID, err)
+		}
+
+		if !claimed {
+			continue
+		}
+
+		event := &events.WorkflowHeartbeatMissed{
+			BaseEvent:      events.NewBaseEvent(events.WorkflowHeartbeatMissedEvent, status.WorkflowID),
+			TriggerNodeID:  status.TriggerNodeID,
+			CronExpression: status.CronExpression,
+			LastRunAt:      status.LastRunAt,
+			ExpectedRunAt:  status.ExpectedRunAt,
+			GracePeriodMs:  status.GracePeriodMs,
+		}
+
+		if err := m.eventBus.Publish(ctx, status.WorkflowID, event); err != nil {
+			return alerts, fmt.Errorf("failed to publish missed heartbeat of workflow %s: %w", status.WorkflowID, err)
+		}
+
+		alerts++
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,132 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dukex/operion/pkg/events"
+	"github.com/dukex/operion/pkg/mocks"
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupScheduledWorkflow stores an hourly workflow published at publishedAt, with a heartbeat
+// grace of 10 minutes, and a run at lastRunAt when given.
+func setupScheduledWorkflow(t *testing.T, p persistence.Persistence, publishedAt time.Time, lastRunAt *time.Time) {
+	t.Helper()
+
+	workflow := &models.Workflow{
+		ID:          "hourly-report",
+		Name:        "Hourly Report",
+		Status:      models.WorkflowStatusPublished,
+		PublishedAt: &publishedAt,
+		CreatedAt:   publishedAt,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID: "schedule", Name: "Schedule", Type: models.NodeTypeTriggerScheduler, Category: models.CategoryTypeTrigger,
+				Config:  map[string]any{"cron_expression": "0 * * * *", HeartbeatGraceKey: "10m"},
+				Enabled: true,
+			},
+			{ID: "report", Name: "Report", Type: "log", Category: models.CategoryTypeAction, Config: map[string]any{"message": "report"}, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "c1", SourcePort: "schedule:success", TargetPort: "report:main"},
+		},
+	}
+	require.NoError(t, p.WorkflowRepository().Save(t.Context(), workflow))
+
+	if lastRunAt != nil {
+		require.NoError(t, p.ExecutionContextRepository().SaveExecutionContext(t.Context(), &models.ExecutionContext{
+			ID:          "exec-last-run",
+			WorkflowID:  workflow.ID,
+			Status:      models.ExecutionStatusRunning,
+			NodeResults: map[string]models.NodeResult{},
+			CreatedAt:   *lastRunAt,
+		}))
+	}
+}
+
+func TestHeartbeatMonitor_Status(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	publishedAt := time.Date(2025, 6, 1, 8, 30, 0, 0, time.UTC)
+	lastRunAt := time.Date(2025, 6, 1, 10, 0, 5, 0, time.UTC)
+	setupScheduledWorkflow(t, p, publishedAt, &lastRunAt)
+
+	monitor := NewHeartbeatMonitor(p, &mocks.MockEventBus{})
+
+	statuses, err := monitor.Status(t.Context(), time.Date(2025, 6, 1, 11, 5, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+
+	status := statuses[0]
+	assert.Equal(t, "hourly-report", status.WorkflowID)
+	assert.Equal(t, "schedule", status.TriggerNodeID)
+	require.NotNil(t, status.LastRunAt)
+	assert.True(t, lastRunAt.Equal(*status.LastRunAt))
+	assert.True(t, time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC).Equal(status.ExpectedRunAt))
+	assert.Equal(t, (10 * time.Minute).Milliseconds(), status.GracePeriodMs)
+	assert.True(t, status.Monitored)
+	assert.False(t, status.Overdue)
+}
+
+func TestHeartbeatMonitor_Check_MissedRun(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	publishedAt := time.Date(2025, 6, 1, 8, 30, 0, 0, time.UTC)
+	lastRunAt := time.Date(2025, 6, 1, 10, 0, 5, 0, time.UTC)
+	setupScheduledWorkflow(t, p, publishedAt, &lastRunAt)
+
+	eventBus := &mocks.MockEventBus{}
+	monitor := NewHeartbeatMonitor(p, eventBus)
+
+	// The 11:00 run is still within its grace period
+	alerts, err := monitor.Check(t.Context(), time.Date(2025, 6, 1, 11, 9, 0, 0, time.UTC), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, alerts)
+
+	var missed *events.WorkflowHeartbeatMissed
+
+	eventBus.On("Publish", mock.Anything, "hourly-report", mock.Anything).Run(func(args mock.Arguments) {
+		missed, _ = args.Get(2).(*events.WorkflowHeartbeatMissed)
+	}).Return(nil)
+
+	alerts, err = monitor.Check(t.Context(), time.Date(2025, 6, 1, 11, 11, 0, 0, time.UTC), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, alerts)
+
+	require.NotNil(t, missed)
+	assert.Equal(t, events.WorkflowHeartbeatMissedEvent, missed.Type)
+	assert.Equal(t, "hourly-report", missed.WorkflowID)
+	assert.Equal(t, "schedule", missed.TriggerNodeID)
+	assert.True(t, time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC).Equal(missed.ExpectedRunAt))
+	require.NotNil(t, missed.LastRunAt)
+	assert.True(t, lastRunAt.Equal(*missed.LastRunAt))
+
+	// The same missed run is alerted once, also by a restarted activator
+	alerts, err = NewHeartbeatMonitor(p, eventBus).Check(t.Context(), time.Date(2025, 6, 1, 11, 30, 0, 0, time.UTC), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, alerts)
+	eventBus.AssertNumberOfCalls(t, "Publish", 1)
+}
+
+func TestHeartbeatMonitor_Check_NeverRan(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupScheduledWorkflow(t, p, time.Date(2025, 6, 1, 8, 30, 0, 0, time.UTC), nil)
+
+	eventBus := &mocks.MockEventBus{}
+	eventBus.On("Publish", mock.Anything, "hourly-report", mock.Anything).Return(nil)
+
+	monitor := NewHeartbeatMonitor(p, eventBus)
+
+	// Workflows owned by another activator are not alerted on
+	alerts, err := monitor.Check(t.Context(), time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC), func(string) bool { return false })
+	require.NoError(t, err)
+	assert.Equal(t, 0, alerts)
+
+	alerts, err = monitor.Check(t.Context(), time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, alerts)
+}
@@ -0,0 +1,120 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/dukex/operion/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Default ports used when an imported connection references a node without a port.
+const (
+	defaultImportSourcePort = "success"
+	defaultImportTargetPort = "main"
+)
+
+// ErrInvalidDefinition is returned when an imported workflow definition cannot be decoded or is inconsistent.
+var ErrInvalidDefinition = errors.New("invalid workflow definition")
+
+// IsYAMLContentType reports whether the content type denotes a YAML document.
+func IsYAMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return true
+	default:
+		return false
+	}
+}
+
+// DecodeDefinition decodes a workflow definition from JSON, or from YAML when yamlFormat is set.
+// YAML documents are converted to JSON first, so both formats decode into the same structs.
+func DecodeDefinition(data []byte, yamlFormat bool) (*models.Workflow, error) {
+	if yamlFormat {
+		var document any
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidDefinition, err)
+		}
+
+		converted, err := json.Marshal(document)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidDefinition, err)
+		}
+
+		data = converted
+	}
+
+	var workflow models.Workflow
+	if err := json.Unmarshal(data, &workflow); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDefinition, err)
+	}
+
+	return &workflow, nil
+}
+
+// PrepareImport resets the environment-specific state of an imported workflow and rewrites its
+// connection ports to "{node_id}:{port_name}" references. A port given as a bare node ID uses the
+// node's default port ("success" for sources, "main" for targets).
+func PrepareImport(workflow *models.Workflow) error {
+	nodeIDs := make(map[string]bool, len(workflow.Nodes))
+
+	for _, node := range workflow.Nodes {
+		if nodeIDs[node.ID] {
+			return fmt.Errorf("%w: duplicate node ID '%s'", ErrInvalidDefinition, node.ID)
+		}
+
+		nodeIDs[node.ID] = true
+
+		// Sources belong to the providers of the environment the workflow was exported from
+		node.SourceID = nil
+	}
+
+	for i, conn := range workflow.Connections {
+		sourcePort, err := rewriteImportPort(conn.SourcePort, defaultImportSourcePort, nodeIDs)
+		if err != nil {
+			return err
+		}
+
+		targetPort, err := rewriteImportPort(conn.TargetPort, defaultImportTargetPort, nodeIDs)
+		if err != nil {
+			return err
+		}
+
+		conn.SourcePort = sourcePort
+		conn.TargetPort = targetPort
+
+		if conn.ID == "" {
+			conn.ID = fmt.Sprintf("conn-%d", i+1)
+		}
+	}
+
+	workflow.Status = models.WorkflowStatusDraft
+	workflow.PublishedAt = nil
+	workflow.WorkflowGroupID = ""
+	workflow.DeletedAt = nil
+	workflow.TriggerErrors = nil
+
+	return nil
+}
+
+// rewriteImportPort returns the port ID for an imported connection port, checking its node exists.
+func rewriteImportPort(port, defaultPort string, nodeIDs map[string]bool) (string, error) {
+	nodeID, portName, ok := models.ParsePortID(port)
+	if !ok {
+		nodeID, portName = strings.TrimSpace(port), defaultPort
+	}
+
+	if !nodeIDs[nodeID] {
+		return "", fmt.Errorf("%w: connection references unknown node '%s'", ErrInvalidDefinition, nodeID)
+	}
+
+	return models.MakePortID(nodeID, portName), nil
+}
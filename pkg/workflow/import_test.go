@@ -0,0 +1,172 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonDefinition = `{
+  "name": "Order enrichment",
+  "description": "Enrich incoming orders",
+  "status": "published",
+  "variables": {"threshold": 100, "tags": ["orders", "enrichment"]},
+  "metadata": {"team": "payments"},
+  "nodes": [
+    {
+      "id": "webhook",
+      "type": "trigger:webhook",
+      "category": "trigger",
+      "name": "Order received",
+      "config": {"path": "/orders"},
+      "source_id": "source-from-another-environment",
+      "provider_id": "webhook",
+      "event_type": "webhook_received",
+      "enabled": true
+    },
+    {
+      "id": "fetch",
+      "type": "httprequest",
+      "category": "action",
+      "name": "Fetch customer",
+      "config": {"url": "https://api.example.com/customers/{{.trigger_data.customer_id}}", "method": "GET", "retries": {"attempts": 3}},
+      "position_x": 100,
+      "position_y": 50,
+      "enabled": true
+    },
+    {
+      "id": "log",
+      "type": "log",
+      "category": "action",
+      "name": "Log order",
+      "config": {"message": "order enriched"},
+      "enabled": true,
+      "variable_overrides": {"threshold": 10}
+    }
+  ],
+  "connections": [
+    {"id": "conn-1", "source_port": "webhook:output", "target_port": "fetch:main"},
+    {"source_port": "fetch:success", "target_port": "log:main"}
+  ]
+}`
+
+// yamlDefinition describes the same workflow as jsonDefinition, referencing default ports by node ID.
+const yamlDefinition = `
+name: Order enrichment
+description: Enrich incoming orders
+status: published
+variables:
+  threshold: 100
+  tags: [orders, enrichment]
+metadata:
+  team: payments
+nodes:
+  - id: webhook
+    type: trigger:webhook
+    category: trigger
+    name: Order received
+    config:
+      path: /orders
+    source_id: source-from-another-environment
+    provider_id: webhook
+    event_type: webhook_received
+    enabled: true
+  - id: fetch
+    type: httprequest
+    category: action
+    name: Fetch customer
+    config:
+      url: "https://api.example.com/customers/{{.trigger_data.customer_id}}"
+      method: GET
+      retries:
+        attempts: 3
+    position_x: 100
+    position_y: 50
+    enabled: true
+  - id: log
+    type: log
+    category: action
+    name: Log order
+    config:
+      message: order enriched
+    enabled: true
+    variable_overrides:
+      threshold: 10
+connections:
+  - id: conn-1
+    source_port: webhook:output
+    target_port: fetch
+  - source_port: fetch
+    target_port: log:main
+`
+
+func importDefinition(t *testing.T, data string, yamlFormat bool) *models.Workflow {
+	t.Helper()
+
+	definition, err := DecodeDefinition([]byte(data), yamlFormat)
+	require.NoError(t, err)
+	require.NoError(t, PrepareImport(definition))
+
+	return definition
+}
+
+func TestDecodeDefinition_YAMLMatchesJSON(t *testing.T) {
+	fromJSON := importDefinition(t, jsonDefinition, false)
+	fromYAML := importDefinition(t, yamlDefinition, true)
+
+	assert.Equal(t, fromJSON, fromYAML)
+}
+
+func TestPrepareImport(t *testing.T) {
+	imported := importDefinition(t, jsonDefinition, false)
+
+	assert.Equal(t, models.WorkflowStatusDraft, imported.Status)
+	assert.Nil(t, imported.Nodes[0].SourceID, "source IDs are environment specific")
+	assert.Equal(t, "webhook", *imported.Nodes[0].ProviderID)
+
+	require.Len(t, imported.Connections, 2)
+	assert.Equal(t, "conn-2", imported.Connections[1].ID)
+	assert.Equal(t, "fetch:success", imported.Connections[1].SourcePort)
+	assert.Equal(t, "log:main", imported.Connections[1].TargetPort)
+}
+
+func TestPrepareImport_InvalidGraph(t *testing.T) {
+	unknownNode := &models.Workflow{
+		Nodes:       []*models.WorkflowNode{{ID: "a"}},
+		Connections: []*models.Connection{{SourcePort: "a:success", TargetPort: "missing:main"}},
+	}
+	require.ErrorIs(t, PrepareImport(unknownNode), ErrInvalidDefinition)
+
+	duplicateNode := &models.Workflow{
+		Nodes: []*models.WorkflowNode{{ID: "a"}, {ID: "a"}},
+	}
+	require.ErrorIs(t, PrepareImport(duplicateNode), ErrInvalidDefinition)
+
+	_, err := DecodeDefinition([]byte("name: [unterminated"), true)
+	require.ErrorIs(t, err, ErrInvalidDefinition)
+}
+
+func TestImport_YAMLAndJSONCreateIdenticalGraphs(t *testing.T) {
+	repo := NewRepository(file.NewPersistence(t.TempDir()))
+
+	fromJSON, err := repo.Create(t.Context(), importDefinition(t, jsonDefinition, false))
+	require.NoError(t, err)
+
+	fromYAML, err := repo.Create(t.Context(), importDefinition(t, yamlDefinition, true))
+	require.NoError(t, err)
+
+	storedJSON, err := repo.FetchByID(t.Context(), fromJSON.ID)
+	require.NoError(t, err)
+
+	storedYAML, err := repo.FetchByID(t.Context(), fromYAML.ID)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, storedJSON.ID, storedYAML.ID)
+	assert.Equal(t, storedJSON.Nodes, storedYAML.Nodes)
+	assert.Equal(t, storedJSON.Connections, storedYAML.Connections)
+	assert.Equal(t, storedJSON.Variables, storedYAML.Variables)
+	assert.Equal(t, storedJSON.Metadata, storedYAML.Metadata)
+}
@@ -0,0 +1,73 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// ValidateInputSchema returns an error when the input schema of node is not a valid JSON schema.
+func ValidateInputSchema(node *models.WorkflowNode) error {
+	if node.InputSchema == nil {
+		return nil
+	}
+
+	if _, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(node.InputSchema)); err != nil {
+		return fmt.Errorf("input_schema of node '%s' is not a valid JSON schema: %w", node.ID, err)
+	}
+
+	return nil
+}
+
+// ValidateInputs checks the inputs a node received against its input schema, before the node
+// executes. The schema validates an object holding the data of each input port by port name,
+// e.g. {"main": {...}}. It returns nil when the node has no schema or the inputs satisfy it;
+// otherwise the result routing the node to its invalid-input port, with the validation errors
+// and the rejected inputs, so bad input is told apart from a failed action.
+func ValidateInputs(node *models.WorkflowNode, inputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	if node.InputSchema == nil {
+		return nil, nil
+	}
+
+	document := make(map[string]any, len(inputs))
+	for port, input := range inputs {
+		document[port] = input.Data
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(node.InputSchema), gojsonschema.NewGoLoader(document))
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate inputs of node %s: %w", node.ID, err)
+	}
+
+	if result.Valid() {
+		return nil, nil
+	}
+
+	validationErrors := make([]any, 0, len(result.Errors()))
+	messages := make([]string, 0, len(result.Errors()))
+
+	for _, desc := range result.Errors() {
+		validationErrors = append(validationErrors, map[string]any{
+			"field":   desc.Field(),
+			"message": desc.Description(),
+		})
+		messages = append(messages, desc.String())
+	}
+
+	errorMessage := "invalid input: " + strings.Join(messages, "; ")
+
+	return map[string]models.NodeResult{
+		models.InvalidInputPort: {
+			NodeID: node.ID,
+			Data: map[string]any{
+				"error":  errorMessage,
+				"errors": validationErrors,
+				"inputs": document,
+			},
+			Status: string(models.NodeStatusError),
+			Error:  errorMessage,
+		},
+	}, nil
+}
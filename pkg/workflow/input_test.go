@@ -0,0 +1,60 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInputs(t *testing.T) {
+	node := &models.WorkflowNode{
+		ID: "charge",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"main": map[string]any{
+					"type":     "object",
+					"required": []any{"amount"},
+					"properties": map[string]any{
+						"amount": map[string]any{"type": "number", "minimum": 0},
+					},
+				},
+			},
+		},
+	}
+
+	valid := map[string]models.NodeResult{"main": {NodeID: "cart", Data: map[string]any{"amount": 12.5}}}
+
+	outputs, err := ValidateInputs(node, valid)
+	require.NoError(t, err)
+	assert.Nil(t, outputs)
+
+	invalid := map[string]models.NodeResult{"main": {NodeID: "cart", Data: map[string]any{"amount": -1}}}
+
+	outputs, err = ValidateInputs(node, invalid)
+	require.NoError(t, err)
+	require.Contains(t, outputs, models.InvalidInputPort)
+
+	result := outputs[models.InvalidInputPort]
+	assert.Equal(t, "charge", result.NodeID)
+	assert.Equal(t, string(models.NodeStatusError), result.Status)
+	assert.Contains(t, result.Error, "invalid input")
+	assert.Equal(t, map[string]any{"main": map[string]any{"amount": -1}}, result.Data["inputs"])
+
+	validationErrors, _ := result.Data["errors"].([]any)
+	require.Len(t, validationErrors, 1)
+	assert.Equal(t, "main.amount", validationErrors[0].(map[string]any)["field"])
+
+	// Nodes without an input schema always execute
+	outputs, err = ValidateInputs(&models.WorkflowNode{ID: "log"}, invalid)
+	require.NoError(t, err)
+	assert.Nil(t, outputs)
+}
+
+func TestValidateInputSchema(t *testing.T) {
+	require.NoError(t, ValidateInputSchema(&models.WorkflowNode{ID: "log"}))
+	require.NoError(t, ValidateInputSchema(&models.WorkflowNode{ID: "log", InputSchema: map[string]any{"type": "object"}}))
+	assert.Error(t, ValidateInputSchema(&models.WorkflowNode{ID: "log", InputSchema: map[string]any{"type": "banana"}}))
+}
@@ -0,0 +1,223 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/registry"
+)
+
+var (
+	// ErrInvalidNodePatch is returned when a node patch cannot be applied.
+	ErrInvalidNodePatch = errors.New("invalid node patch")
+
+	// ErrInvalidNode is returned when a node to create is incomplete, of an unknown type or
+	// conflicts with a node of the workflow.
+	ErrInvalidNode = errors.New("invalid node")
+)
+
+// NodeService handles operations on the nodes of a workflow.
+type NodeService struct {
+	persistence persistence.Persistence
+	registry    *registry.Registry
+}
+
+// NewNodeService creates a new node service. Node configs are validated against the schemas of
+// the node types registered in registry.
+func NewNodeService(persistence persistence.Persistence, registry *registry.Registry) *NodeService {
+	return &NodeService{
+		persistence: persistence,
+		registry:    registry,
+	}
+}
+
+// CreateNode adds a node to a workflow. Its config must match the schema of its node type,
+// otherwise a *NodeConfigError listing the offending fields is returned.
+func (s *NodeService) CreateNode(ctx context.Context, workflowID string, node *models.WorkflowNode) (*models.WorkflowNode, error) {
+	workflow, err := s.persistence.WorkflowRepository().GetByID(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	if workflow == nil {
+		return nil, ErrWorkflowNotFound
+	}
+
+	if node.ID == "" || node.Name == "" || node.Type == "" || node.Category == "" {
+		return nil, fmt.Errorf("%w: id, name, type and category are required", ErrInvalidNode)
+	}
+
+	if slices.ContainsFunc(workflow.Nodes, func(n *models.WorkflowNode) bool { return n.ID == node.ID }) {
+		return nil, fmt.Errorf("%w: node '%s' already exists", ErrInvalidNode, node.ID)
+	}
+
+	schema, ok := s.configSchema(node.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown node type '%s'", ErrInvalidNode, node.Type)
+	}
+
+	if node.Config == nil {
+		node.Config = make(map[string]any)
+	}
+
+	if err := validateNodeConfig(node, schema); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateInputSchema(node); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNode, err)
+	}
+
+	if err := s.persistence.NodeRepository().SaveNode(ctx, workflowID, node); err != nil {
+		return nil, fmt.Errorf("failed to save node: %w", err)
+	}
+
+	return node, nil
+}
+
+// PatchNode applies a JSON Merge Patch (RFC 7396) to a node of a workflow. Only the keys
+// present in patch change, a null value removes the key, and nested objects such as the
+// node config are merged recursively. The node ID, type and category cannot be changed, and
+// the patched config of a registered node type must still match its schema.
+func (s *NodeService) PatchNode(ctx context.Context, workflowID, nodeID string, patch map[string]any) (*models.WorkflowNode, error) {
+	workflow, err := s.persistence.WorkflowRepository().GetByID(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	if workflow == nil {
+		return nil, ErrWorkflowNotFound
+	}
+
+	var existing *models.WorkflowNode
+
+	for _, n := range workflow.Nodes {
+		if n.ID == nodeID {
+			existing = n
+
+			break
+		}
+	}
+
+	if existing == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
+	}
+
+	encoded, err := json.Marshal(existing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode node: %w", err)
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return nil, fmt.Errorf("failed to decode node: %w", err)
+	}
+
+	merged, err := json.Marshal(mergePatch(document, patch))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNodePatch, err)
+	}
+
+	var node models.WorkflowNode
+	if err := json.Unmarshal(merged, &node); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNodePatch, err)
+	}
+
+	if node.ID != existing.ID || node.Type != existing.Type || node.Category != existing.Category {
+		return nil, fmt.Errorf("%w: id, type and category cannot be changed", ErrInvalidNodePatch)
+	}
+
+	if node.Name == "" {
+		return nil, fmt.Errorf("%w: name cannot be removed", ErrInvalidNodePatch)
+	}
+
+	if node.Config == nil {
+		node.Config = make(map[string]any)
+	}
+
+	if schema, ok := s.configSchema(node.Type); ok {
+		if err := validateNodeConfig(&node, schema); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ValidateInputSchema(&node); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNodePatch, err)
+	}
+
+	if err := s.persistence.NodeRepository().UpdateNode(ctx, workflowID, &node); err != nil {
+		return nil, fmt.Errorf("failed to update node: %w", err)
+	}
+
+	return &node, nil
+}
+
+// UpdateLayout moves nodes of a workflow to the given editor positions in a single write. Only
+// the positions change: configs and connections are untouched and the workflow is not
+// revalidated, which keeps rearranging nodes in the editor cheap.
+func (s *NodeService) UpdateLayout(ctx context.Context, workflowID string, positions map[string]models.NodePosition) error {
+	workflow, err := s.persistence.WorkflowRepository().GetByID(ctx, workflowID)
+	if err != nil {
+		return fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	if workflow == nil {
+		return ErrWorkflowNotFound
+	}
+
+	for nodeID := range positions {
+		if !slices.ContainsFunc(workflow.Nodes, func(n *models.WorkflowNode) bool { return n.ID == nodeID }) {
+			return fmt.Errorf("%w: %s", ErrNodeNotFound, nodeID)
+		}
+	}
+
+	if err := s.persistence.NodeRepository().UpdateNodePositions(ctx, workflowID, positions); err != nil {
+		return fmt.Errorf("failed to update node positions: %w", err)
+	}
+
+	return nil
+}
+
+// configSchema returns the config schema of a registered node type.
+func (s *NodeService) configSchema(nodeType string) (map[string]any, bool) {
+	for _, factory := range s.registry.AvailableNodes() {
+		if factory.ID() == nodeType {
+			return factory.Schema(), true
+		}
+	}
+
+	return nil, false
+}
+
+// mergePatch applies patch to target following RFC 7396 and returns the result.
+// Objects are merged key by key, null removes a key, and any other value replaces it.
+func mergePatch(target map[string]any, patch map[string]any) map[string]any {
+	if target == nil {
+		target = make(map[string]any, len(patch))
+	}
+
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+
+			continue
+		}
+
+		patchObject, ok := value.(map[string]any)
+		if !ok {
+			target[key] = value
+
+			continue
+		}
+
+		targetObject, _ := target[key].(map[string]any)
+		target[key] = mergePatch(targetObject, patchObject)
+	}
+
+	return target
+}
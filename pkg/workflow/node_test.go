@@ -0,0 +1,226 @@
+package workflow
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/dukex/operion/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestNodeService creates a node service validating configs against the default nodes.
+func newTestNodeService(p persistence.Persistence) *NodeService {
+	reg := registry.NewRegistry(slog.Default())
+	reg.RegisterDefaultNodes()
+
+	return NewNodeService(p, reg)
+}
+
+// setupNodeWorkflow stores a workflow with a single HTTP request node.
+func setupNodeWorkflow(t *testing.T, p persistence.Persistence) {
+	t.Helper()
+
+	workflow := &models.Workflow{
+		ID:     "patch-workflow",
+		Name:   "Patch Workflow",
+		Status: models.WorkflowStatusDraft,
+		Nodes: []*models.WorkflowNode{
+			{
+				ID:       "fetch",
+				Name:     "Fetch",
+				Type:     "httprequest",
+				Category: models.CategoryTypeAction,
+				Config: map[string]any{
+					"url":    "https://example.com",
+					"method": "GET",
+					"headers": map[string]any{
+						"Accept":        "application/json",
+						"Authorization": "Bearer token",
+					},
+					"retries": map[string]any{"attempts": float64(3), "delay": float64(1000)},
+				},
+				Enabled: true,
+			},
+		},
+	}
+	require.NoError(t, p.WorkflowRepository().Save(t.Context(), workflow))
+}
+
+func TestNodeService_PatchNode_UpdatesOneKey(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupNodeWorkflow(t, p)
+	service := newTestNodeService(p)
+
+	node, err := service.PatchNode(t.Context(), "patch-workflow", "fetch", map[string]any{
+		"config": map[string]any{"method": "POST"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "POST", node.Config["method"])
+	assert.Equal(t, "https://example.com", node.Config["url"])
+	assert.Equal(t, "Fetch", node.Name)
+	assert.True(t, node.Enabled)
+
+	stored, err := p.NodeRepository().GetNodeByWorkflow(t.Context(), "patch-workflow", "fetch")
+	require.NoError(t, err)
+	assert.Equal(t, "POST", stored.Config["method"])
+	assert.Equal(t, "https://example.com", stored.Config["url"])
+	assert.Len(t, stored.Config["headers"], 2)
+}
+
+func TestNodeService_PatchNode_NullRemovesKey(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupNodeWorkflow(t, p)
+	service := newTestNodeService(p)
+
+	node, err := service.PatchNode(t.Context(), "patch-workflow", "fetch", map[string]any{
+		"config": map[string]any{"retries": nil},
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, node.Config, "retries")
+	assert.Equal(t, "GET", node.Config["method"])
+}
+
+func TestNodeService_PatchNode_MergesNestedObjects(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupNodeWorkflow(t, p)
+	service := newTestNodeService(p)
+
+	node, err := service.PatchNode(t.Context(), "patch-workflow", "fetch", map[string]any{
+		"enabled": false,
+		"config": map[string]any{
+			"headers": map[string]any{
+				"Authorization": nil,
+				"X-Request-ID":  "abc",
+			},
+			"retries": map[string]any{"attempts": float64(5)},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, node.Enabled)
+	assert.Equal(t, map[string]any{"Accept": "application/json", "X-Request-ID": "abc"}, node.Config["headers"])
+	assert.Equal(t, map[string]any{"attempts": float64(5), "delay": float64(1000)}, node.Config["retries"])
+}
+
+func TestNodeService_PatchNode_Invalid(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupNodeWorkflow(t, p)
+	service := newTestNodeService(p)
+
+	_, err := service.PatchNode(t.Context(), "missing-workflow", "fetch", map[string]any{})
+	require.ErrorIs(t, err, ErrWorkflowNotFound)
+
+	_, err = service.PatchNode(t.Context(), "patch-workflow", "missing-node", map[string]any{})
+	require.ErrorIs(t, err, ErrNodeNotFound)
+
+	_, err = service.PatchNode(t.Context(), "patch-workflow", "fetch", map[string]any{"type": "log"})
+	require.ErrorIs(t, err, ErrInvalidNodePatch)
+
+	_, err = service.PatchNode(t.Context(), "patch-workflow", "fetch", map[string]any{"name": nil})
+	require.ErrorIs(t, err, ErrInvalidNodePatch)
+
+	_, err = service.PatchNode(t.Context(), "patch-workflow", "fetch", map[string]any{"position_x": "left"})
+	require.ErrorIs(t, err, ErrInvalidNodePatch)
+
+	_, err = service.PatchNode(t.Context(), "patch-workflow", "fetch", map[string]any{"input_schema": map[string]any{"type": "banana"}})
+	require.ErrorIs(t, err, ErrInvalidNodePatch)
+}
+
+func TestNodeService_CreateNode_MissingRequiredConfig(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupNodeWorkflow(t, p)
+	service := newTestNodeService(p)
+
+	_, err := service.CreateNode(t.Context(), "patch-workflow", &models.WorkflowNode{
+		ID:       "log",
+		Name:     "Log",
+		Type:     "log",
+		Category: models.CategoryTypeAction,
+		Config:   map[string]any{"level": "info"},
+	})
+	require.ErrorIs(t, err, ErrInvalidNodeConfig)
+
+	var configErr *NodeConfigError
+	require.ErrorAs(t, err, &configErr)
+	assert.Equal(t, []ConfigFieldError{{Field: "message", Rule: "required", Message: "message is required"}}, configErr.Fields)
+
+	_, err = p.NodeRepository().GetNodeByWorkflow(t.Context(), "patch-workflow", "log")
+	require.Error(t, err)
+}
+
+func TestNodeService_CreateNode_Valid(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupNodeWorkflow(t, p)
+	service := newTestNodeService(p)
+
+	node, err := service.CreateNode(t.Context(), "patch-workflow", &models.WorkflowNode{
+		ID:       "log",
+		Name:     "Log",
+		Type:     "log",
+		Category: models.CategoryTypeAction,
+		Config:   map[string]any{"message": "fetched {{.node_results.fetch.status}}"},
+		Enabled:  true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "log", node.ID)
+
+	stored, err := p.NodeRepository().GetNodeByWorkflow(t.Context(), "patch-workflow", "log")
+	require.NoError(t, err)
+	assert.Equal(t, "fetched {{.node_results.fetch.status}}", stored.Config["message"])
+
+	// A node ID is unique within the workflow
+	_, err = service.CreateNode(t.Context(), "patch-workflow", node)
+	require.ErrorIs(t, err, ErrInvalidNode)
+}
+
+func TestNodeService_CreateNode_InvalidNode(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupNodeWorkflow(t, p)
+	service := newTestNodeService(p)
+
+	_, err := service.CreateNode(t.Context(), "patch-workflow", &models.WorkflowNode{
+		ID: "mystery", Name: "Mystery", Type: "mystery", Category: models.CategoryTypeAction,
+	})
+	require.ErrorIs(t, err, ErrInvalidNode)
+
+	_, err = service.CreateNode(t.Context(), "patch-workflow", &models.WorkflowNode{
+		ID: "log", Name: "Log", Type: "log", Category: models.CategoryTypeAction,
+		Config:      map[string]any{"message": "hello"},
+		InputSchema: map[string]any{"required": "main"},
+	})
+	require.ErrorIs(t, err, ErrInvalidNode)
+
+	_, err = service.CreateNode(t.Context(), "missing", &models.WorkflowNode{
+		ID: "log", Name: "Log", Type: "log", Category: models.CategoryTypeAction,
+	})
+	require.ErrorIs(t, err, ErrWorkflowNotFound)
+}
+
+func TestNodeService_PatchNode_ConfigTypeMismatch(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupNodeWorkflow(t, p)
+	service := newTestNodeService(p)
+
+	_, err := service.PatchNode(t.Context(), "patch-workflow", "fetch", map[string]any{
+		"config": map[string]any{"url": nil, "method": float64(1)},
+	})
+
+	var configErr *NodeConfigError
+	require.ErrorAs(t, err, &configErr)
+	assert.Equal(t, "fetch", configErr.NodeID)
+	require.Len(t, configErr.Fields, 2)
+	assert.Equal(t, "url", configErr.Fields[0].Field)
+	assert.Equal(t, "required", configErr.Fields[0].Rule)
+	assert.Equal(t, "method", configErr.Fields[1].Field)
+	assert.Equal(t, "type", configErr.Fields[1].Rule)
+
+	stored, err := p.NodeRepository().GetNodeByWorkflow(t.Context(), "patch-workflow", "fetch")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", stored.Config["url"])
+}
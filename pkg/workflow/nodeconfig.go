@@ -0,0 +1,174 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// ErrInvalidNodeConfig is returned when a node config does not match the schema of its node type.
+var ErrInvalidNodeConfig = errors.New("invalid node config")
+
+// ConfigFieldError describes a config field of a node that does not match its schema.
+type ConfigFieldError struct {
+	Field   string
+	Rule    string // "required" or "type"
+	Message string
+}
+
+// NodeConfigError lists the config fields of a node that do not match the schema of its type.
+type NodeConfigError struct {
+	NodeID string
+	Fields []ConfigFieldError
+}
+
+func (e *NodeConfigError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Message)
+	}
+
+	return fmt.Sprintf("%s of node '%s': %s", ErrInvalidNodeConfig, e.NodeID, strings.Join(messages, "; "))
+}
+
+func (e *NodeConfigError) Unwrap() error {
+	return ErrInvalidNodeConfig
+}
+
+// validateNodeConfig checks the config of node against the JSON schema of its node type: the
+// required fields must be set and the top-level fields must have the declared types. Template
+// strings are accepted for any type since they are only rendered when the node executes.
+func validateNodeConfig(node *models.WorkflowNode, schema map[string]any) error {
+	var fields []ConfigFieldError
+
+	required, _ := schema["required"].([]string)
+	for _, key := range required {
+		if isEmptyConfigValue(node.Config[key]) {
+			fields = append(fields, ConfigFieldError{Field: key, Rule: "required", Message: key + " is required"})
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+
+	keys := make([]string, 0, len(node.Config))
+	for key := range node.Config {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		property, _ := properties[key].(map[string]any)
+
+		types := schemaTypes(property["type"])
+		if len(types) == 0 || matchesSchemaType(node.Config[key], types) {
+			continue
+		}
+
+		fields = append(fields, ConfigFieldError{
+			Field:   key,
+			Rule:    "type",
+			Message: fmt.Sprintf("%s must be of type %s", key, strings.Join(types, " or ")),
+		})
+	}
+
+	if len(fields) > 0 {
+		return &NodeConfigError{NodeID: node.ID, Fields: fields}
+	}
+
+	return nil
+}
+
+// schemaTypes returns the types allowed by the "type" keyword of a schema, nil when any is allowed.
+func schemaTypes(keyword any) []string {
+	var types []string
+
+	switch t := keyword.(type) {
+	case string:
+		types = []string{t}
+	case []string:
+		types = t
+	case []any:
+		for _, value := range t {
+			if s, ok := value.(string); ok {
+				types = append(types, s)
+			}
+		}
+	}
+
+	if slices.Contains(types, "any") {
+		return nil
+	}
+
+	return types
+}
+
+// matchesSchemaType reports whether a config value decoded from JSON (or set in Go) has one of types.
+func matchesSchemaType(value any, types []string) bool {
+	if value == nil {
+		return true
+	}
+
+	if s, ok := value.(string); ok && strings.Contains(s, "{{") {
+		return true
+	}
+
+	for _, t := range types {
+		var matches bool
+
+		switch t {
+		case "string":
+			_, matches = value.(string)
+		case "boolean":
+			_, matches = value.(bool)
+		case "number":
+			matches = isNumber(value)
+		case "integer":
+			matches = isInteger(value)
+		case "object":
+			_, matches = value.(map[string]any)
+		case "array":
+			matches = isArray(value)
+		default:
+			matches = true
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNumber(value any) bool {
+	switch value.(type) {
+	case float64, float32, int, int32, int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isInteger(value any) bool {
+	switch v := value.(type) {
+	case int, int32, int64:
+		return true
+	case float64:
+		return v == float64(int64(v))
+	default:
+		return false
+	}
+}
+
+func isArray(value any) bool {
+	switch value.(type) {
+	case []any, []string, []map[string]any:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,36 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// ApplyOutputTemplate returns the outputs of node with its output template applied. Without a
+// template the outputs are returned unchanged; otherwise the template is rendered for every
+// successful output except repeats, with the raw data as .result. An object result replaces the
+// data, any other value is wrapped as "result".
+func ApplyOutputTemplate(node *models.WorkflowNode, outputs map[string]models.NodeResult) (map[string]models.NodeResult, error) {
+	if node.OutputTemplate == "" {
+		return outputs, nil
+	}
+
+	shaped := maps.Clone(outputs)
+
+	for port, output := range outputs {
+		if port == models.RepeatOutputPort || output.Status != string(models.NodeStatusSuccess) {
+			continue
+		}
+
+		data, err := renderData(node.OutputTemplate, map[string]any{"result": output.Data})
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply output template of node %s: %w", node.ID, err)
+		}
+
+		output.Data = data
+		shaped[port] = output
+	}
+
+	return shaped, nil
+}
@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOutputTemplate(t *testing.T) {
+	outputs := map[string]models.NodeResult{
+		"success": {
+			NodeID: "fetch",
+			Data:   map[string]any{"status_code": float64(200), "json": map[string]any{"id": float64(7), "name": "Ada"}},
+			Status: string(models.NodeStatusSuccess),
+		},
+		"error": {
+			NodeID: "fetch",
+			Data:   map[string]any{"status_code": float64(500)},
+			Status: string(models.NodeStatusError),
+			Error:  "server error",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected map[string]any
+	}{
+		{
+			name:     "no template keeps the raw result",
+			expected: outputs["success"].Data,
+		},
+		{
+			name:     "object result replaces data",
+			template: `{"name": "{{ .result.json.name }}"}`,
+			expected: map[string]any{"name": "Ada"},
+		},
+		{
+			name:     "scalar result is wrapped",
+			template: `{{ .result.json.id }}`,
+			expected: map[string]any{"result": float64(7)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shaped, err := ApplyOutputTemplate(&models.WorkflowNode{ID: "fetch", OutputTemplate: tt.template}, outputs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, shaped["success"].Data)
+			assert.Equal(t, outputs["error"], shaped["error"], "failed results are left as is")
+		})
+	}
+
+	assert.Equal(t, "Ada", outputs["success"].Data["json"].(map[string]any)["name"], "the raw outputs are not modified")
+}
+
+func TestApplyOutputTemplate_InvalidTemplate(t *testing.T) {
+	_, err := ApplyOutputTemplate(&models.WorkflowNode{ID: "fetch", OutputTemplate: "{{ .result"}, map[string]models.NodeResult{
+		"success": {Data: map[string]any{}, Status: string(models.NodeStatusSuccess)},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "node fetch")
+}
@@ -0,0 +1,102 @@
+package workflow
+
+import (
+	"strings"
+
+	"github.com/dukex/operion/pkg/models"
+)
+
+// LiveNodeResults returns the node results of an execution of wf that pending nodes may still read.
+// A node is pending while it has no result in nodeResults; activeNodeIDs are pending even when they
+// already have results, as happens to nodes running again inside a loop. The result of a node is kept
+// while a pending node is connected to it or references it through node_results in its configuration.
+// nodeResults must hold every persisted result of the execution, not a previously pruned set.
+func LiveNodeResults(
+	wf *models.Workflow,
+	nodeResults map[string]models.NodeResult,
+	activeNodeIDs ...string,
+) map[string]models.NodeResult {
+	completed := make(map[string]bool)
+
+	for key := range nodeResults {
+		if nodeID, _, ok := models.ParseNodeResultKey(key); ok {
+			completed[nodeID] = true
+		}
+	}
+
+	pending := make(map[string]*models.WorkflowNode)
+
+	for _, node := range wf.Nodes {
+		if !completed[node.ID] {
+			pending[node.ID] = node
+		}
+	}
+
+	for _, nodeID := range activeNodeIDs {
+		for _, node := range wf.Nodes {
+			if node.ID == nodeID {
+				pending[node.ID] = node
+			}
+		}
+	}
+
+	referenced := make(map[string]bool)
+
+	for _, conn := range wf.Connections {
+		sourceNodeID, _, sourceOK := models.ParsePortID(conn.SourcePort)
+		targetNodeID, _, targetOK := models.ParsePortID(conn.TargetPort)
+
+		if sourceOK && targetOK && pending[targetNodeID] != nil {
+			referenced[sourceNodeID] = true
+		}
+	}
+
+	for nodeID := range completed {
+		if referenced[nodeID] {
+			continue
+		}
+
+		for _, node := range pending {
+			if referencesNodeResult(node.Config, nodeID) {
+				referenced[nodeID] = true
+
+				break
+			}
+		}
+	}
+
+	live := make(map[string]models.NodeResult)
+
+	for key, result := range nodeResults {
+		nodeID, _, ok := models.ParseNodeResultKey(key)
+		if ok && referenced[nodeID] {
+			live[key] = result
+		}
+	}
+
+	return live
+}
+
+// referencesNodeResult reports whether a template in config reads a result of nodeID,
+// either as .node_results.<node_id> or by its "<node_id>::<port>" key.
+func referencesNodeResult(value any, nodeID string) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.Contains(v, "node_results."+nodeID) ||
+			strings.Contains(v, `"`+models.MakeNodeResultKey(nodeID, ""))
+	case map[string]any:
+		for _, item := range v {
+			if referencesNodeResult(item, nodeID) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if referencesNodeResult(item, nodeID) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
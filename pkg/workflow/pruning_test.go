@@ -0,0 +1,69 @@
+package workflow
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// chainWorkflow returns a workflow whose nodes n1..n<length> are connected one after the other.
+func chainWorkflow(length int) *models.Workflow {
+	wf := &models.Workflow{ID: "chain"}
+
+	for i := 1; i <= length; i++ {
+		wf.Nodes = append(wf.Nodes, &models.WorkflowNode{
+			ID:     fmt.Sprintf("n%d", i),
+			Type:   "log",
+			Config: map[string]any{"message": "step"},
+		})
+
+		if i > 1 {
+			wf.Connections = append(wf.Connections, &models.Connection{
+				ID:         fmt.Sprintf("c%d", i),
+				SourcePort: fmt.Sprintf("n%d:success", i-1),
+				TargetPort: fmt.Sprintf("n%d:main", i),
+			})
+		}
+	}
+
+	return wf
+}
+
+func completedResults(nodeIDs ...string) map[string]models.NodeResult {
+	results := make(map[string]models.NodeResult)
+	for _, nodeID := range nodeIDs {
+		results[models.MakeNodeResultKey(nodeID, "success")] = models.NodeResult{NodeID: nodeID}
+	}
+
+	return results
+}
+
+func TestLiveNodeResults_KeepsResultsOfPendingNodeSources(t *testing.T) {
+	wf := chainWorkflow(10)
+
+	live := LiveNodeResults(wf, completedResults("n1", "n2", "n3", "n4", "n5", "n6"))
+
+	assert.Equal(t, completedResults("n6"), live)
+}
+
+func TestLiveNodeResults_KeepsResultsReferencedByPendingNodes(t *testing.T) {
+	wf := chainWorkflow(10)
+	wf.Nodes[9].Config = map[string]any{"message": `{{ .node_results.n2 }}`}
+	wf.Nodes[8].Config = map[string]any{"lines": []any{`{{ index .node_results "n3::success" }}`}}
+
+	live := LiveNodeResults(wf, completedResults("n1", "n2", "n3", "n4", "n5", "n6"))
+
+	assert.Equal(t, completedResults("n2", "n3", "n6"), live)
+}
+
+func TestLiveNodeResults_ActiveNodesKeepTheirSources(t *testing.T) {
+	wf := chainWorkflow(4)
+
+	// n2 runs again, as inside a loop, after every node already completed
+	live := LiveNodeResults(wf, completedResults("n1", "n2", "n3", "n4"), "n2")
+
+	assert.Equal(t, completedResults("n1"), live)
+	assert.Empty(t, LiveNodeResults(wf, completedResults("n1", "n2", "n3", "n4")))
+}
@@ -5,20 +5,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/dukex/operion/pkg/models"
 	"github.com/dukex/operion/pkg/persistence"
+	"github.com/dukex/operion/pkg/registry"
+	"github.com/dukex/operion/pkg/template"
 )
 
+// variableReferencePattern matches `.variables.<name>` references in templates, rooted at the
+// template context: fields named variables of other values, such as `.step_results.fetch.variables.x`
+// or `$item.variables.x`, are not workflow variables.
+var variableReferencePattern = regexp.MustCompile(`(?:^|[^\w.$\]])\.variables\.([A-Za-z_][A-Za-z0-9_]*)\b`)
+
 // PublishingService handles workflow publishing operations with simplified versioning.
 type PublishingService struct {
 	persistence persistence.Persistence
+	registry    *registry.Registry
 }
 
-// NewPublishingService creates a new workflow publishing service.
-func NewPublishingService(persistence persistence.Persistence) *PublishingService {
+// NewPublishingService creates a new workflow publishing service validating trigger nodes
+// against the providers and node types of registry.
+func NewPublishingService(persistence persistence.Persistence, registry *registry.Registry) *PublishingService {
 	return &PublishingService{
 		persistence: persistence,
+		registry:    registry,
 	}
 }
 
@@ -96,5 +110,177 @@ func (s *PublishingService) validateForPublishing(workflow *models.Workflow) err
 		return errors.New("workflow must have at least one enabled trigger node")
 	}
 
+	for _, node := range workflow.Nodes {
+		if node.Category != models.CategoryTypeTrigger {
+			continue
+		}
+
+		if err := s.validateTrigger(node); err != nil {
+			return err
+		}
+	}
+
+	if workflow.RetryBudget < 0 {
+		return errors.New("workflow retry_budget cannot be negative")
+	}
+
+	switch workflow.TriggerMode {
+	case "", models.TriggerModeIndependent, models.TriggerModeSharedLatest:
+	default:
+		return fmt.Errorf("workflow trigger_mode '%s' must be '%s' or '%s'",
+			workflow.TriggerMode, models.TriggerModeIndependent, models.TriggerModeSharedLatest)
+	}
+
+	if err := template.ValidatePartials(workflow.Templates); err != nil {
+		return err
+	}
+
+	if workflow.ErrorHandlerNodeID != "" && !slices.ContainsFunc(workflow.Nodes, func(node *models.WorkflowNode) bool {
+		return node.ID == workflow.ErrorHandlerNodeID
+	}) {
+		return fmt.Errorf("error handler node '%s' does not exist", workflow.ErrorHandlerNodeID)
+	}
+
+	for _, node := range workflow.Nodes {
+		if node.Compensation != nil && node.Compensation.Type == "" {
+			return fmt.Errorf("compensation of node '%s' has no type", node.ID)
+		}
+
+		if node.Retry != nil {
+			if node.Retry.MaxRetries < 0 {
+				return fmt.Errorf("retry of node '%s' has negative max_retries", node.ID)
+			}
+
+			if _, err := time.ParseDuration(node.Retry.Delay); node.Retry.Delay != "" && err != nil {
+				return fmt.Errorf("retry of node '%s' has invalid delay '%s'", node.ID, node.Retry.Delay)
+			}
+		}
+	}
+
+	for _, conn := range workflow.Connections {
+		if err := ValidateConnectionTransform(conn); err != nil {
+			return err
+		}
+	}
+
+	if missing := undeclaredVariables(workflow); len(missing) > 0 {
+		return fmt.Errorf("workflow references undeclared variables: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// validateTrigger ensures a trigger node names an event type and a registered provider, and sets
+// the config its node type requires (e.g. topic and brokers for Kafka triggers).
+func (s *PublishingService) validateTrigger(node *models.WorkflowNode) error {
+	if node.ProviderID == nil || *node.ProviderID == "" {
+		return fmt.Errorf("trigger node '%s' has no provider_id", node.ID)
+	}
+
+	if node.EventType == nil || *node.EventType == "" {
+		return fmt.Errorf("trigger node '%s' has no event_type", node.ID)
+	}
+
+	if _, ok := s.registry.GetProviders()[*node.ProviderID]; !ok {
+		return fmt.Errorf("trigger node '%s' references unknown provider '%s'", node.ID, *node.ProviderID)
+	}
+
+	for _, factory := range s.registry.AvailableNodes() {
+		if factory.ID() != node.Type {
+			continue
+		}
+
+		required, _ := factory.Schema()["required"].([]string)
+
+		var missing []string
+
+		for _, key := range required {
+			if isEmptyConfigValue(node.Config[key]) {
+				missing = append(missing, key)
+			}
+		}
+
+		if len(missing) > 0 {
+			return fmt.Errorf("trigger node '%s' is missing required config: %s", node.ID, strings.Join(missing, ", "))
+		}
+	}
+
 	return nil
 }
+
+// isEmptyConfigValue reports whether a config value is missing, an empty string or an empty list.
+func isEmptyConfigValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case []string:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// undeclaredVariables returns the sorted names of variables referenced by node configs and
+// connection transforms that are neither workflow variables nor overridden by the node using them.
+func undeclaredVariables(workflow *models.Workflow) []string {
+	var missing []string
+
+	check := func(template string, overrides map[string]any) {
+		for _, match := range variableReferencePattern.FindAllStringSubmatch(template, -1) {
+			name := match[1]
+			if _, ok := workflow.Variables[name]; ok {
+				continue
+			}
+
+			if _, ok := overrides[name]; ok {
+				continue
+			}
+
+			missing = append(missing, name)
+		}
+	}
+
+	for _, node := range workflow.Nodes {
+		for _, template := range configStrings(node.Config) {
+			check(template, node.VariableOverrides)
+		}
+	}
+
+	for _, connection := range workflow.Connections {
+		check(connection.Transform, nil)
+	}
+
+	slices.Sort(missing)
+
+	return slices.Compact(missing)
+}
+
+// configStrings returns every string found in a node config, including nested maps and lists.
+func configStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case map[string]any:
+		var values []string
+
+		for _, item := range v {
+			values = append(values, configStrings(item)...)
+		}
+
+		return values
+	case []any:
+		var values []string
+
+		for _, item := range v {
+			values = append(values, configStrings(item)...)
+		}
+
+		return values
+	default:
+		return nil
+	}
+}
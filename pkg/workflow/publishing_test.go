@@ -2,11 +2,17 @@ package workflow
 
 import (
 	"context"
+	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/nodes/trigger"
 	"github.com/dukex/operion/pkg/persistence"
+	kafkaProvider "github.com/dukex/operion/pkg/providers/kafka"
+	webhookProvider "github.com/dukex/operion/pkg/providers/webhook"
+	"github.com/dukex/operion/pkg/registry"
+	"github.com/dukex/operion/pkg/template"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,6 +32,15 @@ func (r *testWorkflowRepository) GetAll(ctx context.Context) ([]*models.Workflow
 	return workflows, nil
 }
 
+func (r *testWorkflowRepository) ListWorkflows(ctx context.Context, opts models.ListOptions) (*models.WorkflowPage, error) {
+	workflows, err := r.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WorkflowPage{Workflows: workflows}, nil
+}
+
 func (r *testWorkflowRepository) Save(ctx context.Context, workflow *models.Workflow) error {
 	r.workflows[workflow.ID] = workflow
 
@@ -46,6 +61,14 @@ func (r *testWorkflowRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (r *testWorkflowRepository) GetByIDIncludingDeleted(ctx context.Context, id string) (*models.Workflow, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *testWorkflowRepository) Restore(ctx context.Context, id string) error {
+	return nil
+}
+
 func (r *testWorkflowRepository) GetWorkflowVersions(ctx context.Context, workflowGroupID string) ([]*models.Workflow, error) {
 	var versions []*models.Workflow
 
@@ -157,6 +180,8 @@ func (p *testPersistence) ExecutionContextRepository() persistence.ExecutionCont
 func (p *testPersistence) InputCoordinationRepository() persistence.InputCoordinationRepository {
 	return nil
 }
+func (p *testPersistence) DebounceRepository() persistence.DebounceRepository { return nil }
+func (p *testPersistence) AlertRepository() persistence.AlertRepository       { return nil }
 
 func createTestPersistence() *testPersistence {
 	return &testPersistence{
@@ -166,9 +191,38 @@ func createTestPersistence() *testPersistence {
 	}
 }
 
+// newTestRegistry creates a registry with the webhook and Kafka providers and trigger nodes.
+func newTestRegistry() *registry.Registry {
+	reg := registry.NewRegistry(slog.Default())
+	reg.RegisterProvider(webhookProvider.NewWebhookProviderFactory())
+	reg.RegisterProvider(kafkaProvider.NewKafkaProviderFactory())
+	reg.RegisterNode(trigger.NewWebhookTriggerNodeFactory())
+	reg.RegisterNode(trigger.NewKafkaTriggerNodeFactory())
+
+	return reg
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+// webhookTrigger returns a complete, enabled webhook trigger node.
+func webhookTrigger(id string) *models.WorkflowNode {
+	return &models.WorkflowNode{
+		ID:         id,
+		Type:       models.NodeTypeTriggerWebhook,
+		Category:   models.CategoryTypeTrigger,
+		SourceID:   stringPtr("source-" + id),
+		ProviderID: stringPtr("webhook"),
+		EventType:  stringPtr("WebhookReceived"),
+		Config:     map[string]any{"webhook_path": "/orders"},
+		Enabled:    true,
+	}
+}
+
 func TestPublishingService_PublishWorkflow_Success(t *testing.T) {
 	persistence := createTestPersistence()
-	service := NewPublishingService(persistence)
+	service := NewPublishingService(persistence, newTestRegistry())
 
 	// Create a valid draft workflow
 	workflow := &models.Workflow{
@@ -178,11 +232,7 @@ func TestPublishingService_PublishWorkflow_Success(t *testing.T) {
 		Status:          models.WorkflowStatusDraft,
 		WorkflowGroupID: "test-group",
 		Nodes: []*models.WorkflowNode{
-			{
-				ID:       "trigger-1",
-				Category: models.CategoryTypeTrigger,
-				Enabled:  true,
-			},
+			webhookTrigger("trigger-1"),
 		},
 	}
 
@@ -202,7 +252,7 @@ func TestPublishingService_PublishWorkflow_Success(t *testing.T) {
 
 func TestPublishingService_PublishWorkflow_ValidationError(t *testing.T) {
 	persistence := createTestPersistence()
-	service := NewPublishingService(persistence)
+	service := NewPublishingService(persistence, newTestRegistry())
 
 	// Create an invalid workflow (no trigger nodes)
 	workflow := &models.Workflow{
@@ -224,9 +274,58 @@ func TestPublishingService_PublishWorkflow_ValidationError(t *testing.T) {
 	assert.Contains(t, err.Error(), "must have at least one node")
 }
 
+func TestPublishingService_PublishWorkflow_UnknownErrorHandler(t *testing.T) {
+	persistence := createTestPersistence()
+	service := NewPublishingService(persistence, newTestRegistry())
+
+	workflow := &models.Workflow{
+		ID:                 "handler-workflow",
+		Name:               "Handler Workflow",
+		Description:        "Test description",
+		Status:             models.WorkflowStatusDraft,
+		WorkflowGroupID:    "test-group",
+		ErrorHandlerNodeID: "missing-node",
+		Nodes: []*models.WorkflowNode{
+			webhookTrigger("trigger-1"),
+		},
+	}
+
+	err := persistence.workflowRepo.Save(context.Background(), workflow)
+	require.NoError(t, err)
+
+	_, err = service.PublishWorkflow(context.Background(), "handler-workflow")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error handler node 'missing-node' does not exist")
+}
+
+func TestPublishingService_PublishWorkflow_CompensationWithoutType(t *testing.T) {
+	persistence := createTestPersistence()
+	service := NewPublishingService(persistence, newTestRegistry())
+
+	workflow := &models.Workflow{
+		ID:                "saga-workflow",
+		Name:              "Saga Workflow",
+		Description:       "Test description",
+		Status:            models.WorkflowStatusDraft,
+		WorkflowGroupID:   "test-group",
+		RollbackOnFailure: true,
+		Nodes: []*models.WorkflowNode{
+			webhookTrigger("trigger-1"),
+			{ID: "reserve", Category: models.CategoryTypeAction, Enabled: true, Compensation: &models.Compensation{}},
+		},
+	}
+
+	err := persistence.workflowRepo.Save(context.Background(), workflow)
+	require.NoError(t, err)
+
+	_, err = service.PublishWorkflow(context.Background(), "saga-workflow")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "compensation of node 'reserve' has no type")
+}
+
 func TestPublishingService_GetPublishedWorkflow(t *testing.T) {
 	persistence := createTestPersistence()
-	service := NewPublishingService(persistence)
+	service := NewPublishingService(persistence, newTestRegistry())
 
 	// Create and save a published workflow
 	workflow := &models.Workflow{
@@ -248,7 +347,7 @@ func TestPublishingService_GetPublishedWorkflow(t *testing.T) {
 
 func TestPublishingService_CreateDraftFromPublished(t *testing.T) {
 	persistence := createTestPersistence()
-	service := NewPublishingService(persistence)
+	service := NewPublishingService(persistence, newTestRegistry())
 
 	// Create and save a published workflow
 	published := &models.Workflow{
@@ -274,3 +373,224 @@ func TestPublishingService_CreateDraftFromPublished(t *testing.T) {
 	assert.Nil(t, draft.PublishedAt)
 	assert.Len(t, draft.Nodes, 1) // Should copy nodes
 }
+
+func variablesWorkflow(id string, variables map[string]any) *models.Workflow {
+	return &models.Workflow{
+		ID:              id,
+		Name:            "Variables Workflow",
+		Status:          models.WorkflowStatusDraft,
+		WorkflowGroupID: id,
+		Variables:       variables,
+		Nodes: []*models.WorkflowNode{
+			webhookTrigger("trigger-1"),
+			{
+				ID:       "fetch",
+				Type:     "httprequest",
+				Category: models.CategoryTypeAction,
+				Config: map[string]any{
+					"url":     "{{ .variables.api_base_url }}/orders",
+					"headers": map[string]any{"Authorization": "Bearer {{ .variables.api_token }}"},
+				},
+				VariableOverrides: map[string]any{"timeout": 30},
+				Enabled:           true,
+			},
+			{
+				ID:       "notify",
+				Type:     "log",
+				Category: models.CategoryTypeAction,
+				Config:   map[string]any{"message": "fetched {{ .step_results.fetch.variables.region }} with timeout {{.variables.timeout}}"},
+				Enabled:  true,
+			},
+		},
+		Connections: []*models.Connection{
+			{ID: "fetch-notify", SourcePort: "fetch:success", TargetPort: "notify:main", Transform: `{"env": "{{ .variables.environment }}"}`},
+		},
+	}
+}
+
+func TestPublishingService_PublishWorkflow_UndeclaredVariables(t *testing.T) {
+	persistence := createTestPersistence()
+	service := NewPublishingService(persistence, newTestRegistry())
+
+	// timeout is only overridden by fetch, so notify still needs it declared
+	workflow := variablesWorkflow("undeclared-workflow", map[string]any{"api_base_url": "https://api.example.com"})
+	require.NoError(t, persistence.workflowRepo.Save(context.Background(), workflow))
+
+	_, err := service.PublishWorkflow(context.Background(), "undeclared-workflow")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "workflow references undeclared variables: api_token, environment, timeout")
+}
+
+func TestPublishingService_PublishWorkflow_DeclaredVariables(t *testing.T) {
+	persistence := createTestPersistence()
+	service := NewPublishingService(persistence, newTestRegistry())
+
+	workflow := variablesWorkflow("declared-workflow", map[string]any{
+		"api_base_url": "https://api.example.com",
+		"api_token":    "secret",
+		"environment":  "production",
+		"timeout":      10,
+	})
+	require.NoError(t, persistence.workflowRepo.Save(context.Background(), workflow))
+
+	published, err := service.PublishWorkflow(context.Background(), "declared-workflow")
+	require.NoError(t, err)
+	assert.Equal(t, models.WorkflowStatusPublished, published.Status)
+}
+
+func publishTrigger(t *testing.T, node *models.WorkflowNode) error {
+	t.Helper()
+
+	persistence := createTestPersistence()
+	service := NewPublishingService(persistence, newTestRegistry())
+
+	workflow := &models.Workflow{
+		ID:              "trigger-workflow",
+		Name:            "Trigger Workflow",
+		Status:          models.WorkflowStatusDraft,
+		WorkflowGroupID: "trigger-workflow",
+		Nodes:           []*models.WorkflowNode{node},
+	}
+	require.NoError(t, persistence.workflowRepo.Save(context.Background(), workflow))
+
+	_, err := service.PublishWorkflow(context.Background(), "trigger-workflow")
+
+	return err
+}
+
+func TestPublishingService_PublishWorkflow_TriggerWithoutEventType(t *testing.T) {
+	node := webhookTrigger("on-order")
+	node.EventType = nil
+
+	err := publishTrigger(t, node)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trigger node 'on-order' has no event_type")
+}
+
+func TestPublishingService_PublishWorkflow_TriggerWithUnknownProvider(t *testing.T) {
+	node := webhookTrigger("on-order")
+	node.ProviderID = stringPtr("carrier-pigeon")
+
+	err := publishTrigger(t, node)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trigger node 'on-order' references unknown provider 'carrier-pigeon'")
+}
+
+func TestPublishingService_PublishWorkflow_TriggerMissingProviderConfig(t *testing.T) {
+	node := &models.WorkflowNode{
+		ID:         "on-message",
+		Type:       models.NodeTypeTriggerKafka,
+		Category:   models.CategoryTypeTrigger,
+		ProviderID: stringPtr("kafka"),
+		EventType:  stringPtr("message_received"),
+		Config:     map[string]any{"consumer_group": "orders", "brokers": []any{}},
+		Enabled:    true,
+	}
+
+	err := publishTrigger(t, node)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trigger node 'on-message' is missing required config: topic, brokers")
+
+	node.Config["topic"] = "orders"
+	node.Config["brokers"] = []any{"localhost:9092"}
+
+	require.NoError(t, publishTrigger(t, node))
+}
+
+func TestPublishingService_PublishWorkflow_CompleteTrigger(t *testing.T) {
+	require.NoError(t, publishTrigger(t, webhookTrigger("on-order")))
+}
+
+func TestPublishingService_PublishWorkflow_InvalidRetryDelay(t *testing.T) {
+	persistence := createTestPersistence()
+	service := NewPublishingService(persistence, newTestRegistry())
+
+	workflow := &models.Workflow{
+		ID:              "retry-workflow",
+		Name:            "Retry Workflow",
+		Status:          models.WorkflowStatusDraft,
+		WorkflowGroupID: "retry-workflow",
+		RetryBudget:     5,
+		Nodes: []*models.WorkflowNode{
+			webhookTrigger("trigger-1"),
+			{ID: "fetch", Category: models.CategoryTypeAction, Enabled: true, Retry: &models.RetryPolicy{MaxRetries: 3, Delay: "soon"}},
+		},
+	}
+	require.NoError(t, persistence.workflowRepo.Save(context.Background(), workflow))
+
+	_, err := service.PublishWorkflow(context.Background(), "retry-workflow")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "retry of node 'fetch' has invalid delay 'soon'")
+}
+
+func TestPublishingService_PublishWorkflow_InvalidTemplatePartial(t *testing.T) {
+	persistence := createTestPersistence()
+	service := NewPublishingService(persistence, newTestRegistry())
+
+	workflow := &models.Workflow{
+		ID:              "partials-workflow",
+		Name:            "Partials Workflow",
+		Status:          models.WorkflowStatusDraft,
+		WorkflowGroupID: "partials-workflow",
+		Templates:       map[string]string{"order_body": `{"id": "{{.trigger_data.id"}`},
+		Nodes: []*models.WorkflowNode{
+			webhookTrigger("trigger-1"),
+		},
+	}
+	require.NoError(t, persistence.workflowRepo.Save(context.Background(), workflow))
+
+	_, err := service.PublishWorkflow(context.Background(), "partials-workflow")
+	require.ErrorIs(t, err, template.ErrInvalidPartial)
+	assert.Contains(t, err.Error(), "invalid template partial 'order_body'")
+}
+
+func TestPublishingService_PublishWorkflow_InvalidConnectionTransform(t *testing.T) {
+	persistence := createTestPersistence()
+	service := NewPublishingService(persistence, newTestRegistry())
+
+	workflow := &models.Workflow{
+		ID:              "transform-workflow",
+		Name:            "Transform Workflow",
+		Status:          models.WorkflowStatusDraft,
+		WorkflowGroupID: "transform-workflow",
+		Nodes: []*models.WorkflowNode{
+			webhookTrigger("trigger-1"),
+			{ID: "log", Category: models.CategoryTypeAction, Enabled: true},
+		},
+		Connections: []*models.Connection{
+			{ID: "to-log", SourcePort: "trigger-1:success", TargetPort: "log:main", Transform: `{"id": "{{ .data.id }"}`},
+		},
+	}
+	require.NoError(t, persistence.workflowRepo.Save(context.Background(), workflow))
+
+	_, err := service.PublishWorkflow(context.Background(), "transform-workflow")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "transform of connection 'to-log' is invalid")
+}
+
+func TestPublishingService_PublishWorkflow_InvalidTriggerMode(t *testing.T) {
+	persistence := createTestPersistence()
+	service := NewPublishingService(persistence, newTestRegistry())
+
+	workflow := &models.Workflow{
+		ID:              "mode-workflow",
+		Name:            "Mode Workflow",
+		Status:          models.WorkflowStatusDraft,
+		WorkflowGroupID: "mode-workflow",
+		TriggerMode:     "latest",
+		Nodes: []*models.WorkflowNode{
+			webhookTrigger("trigger-1"),
+		},
+	}
+	require.NoError(t, persistence.workflowRepo.Save(context.Background(), workflow))
+
+	_, err := service.PublishWorkflow(context.Background(), "mode-workflow")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "workflow trigger_mode 'latest' must be 'independent' or 'shared-latest'")
+
+	workflow.TriggerMode = models.TriggerModeSharedLatest
+	require.NoError(t, persistence.workflowRepo.Save(context.Background(), workflow))
+
+	_, err = service.PublishWorkflow(context.Background(), "mode-workflow")
+	require.NoError(t, err)
+}
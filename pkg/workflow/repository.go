@@ -14,6 +14,8 @@ import (
 var (
 	// ErrWorkflowNotFound is returned when a workflow is not found.
 	ErrWorkflowNotFound = errors.New("workflow not found")
+	// ErrWorkflowNotDeleted is returned when restoring a workflow that is not deleted.
+	ErrWorkflowNotDeleted = errors.New("workflow is not deleted")
 )
 
 type Repository struct {
@@ -51,6 +53,20 @@ func (r *Repository) FetchAll(ctx context.Context) ([]*models.Workflow, error) {
 	return workflows, nil
 }
 
+// List retrieves a page of workflows, newest first.
+func (r *Repository) List(ctx context.Context, opts models.ListOptions) (*models.WorkflowPage, error) {
+	return r.persistence.WorkflowRepository().ListWorkflows(ctx, opts)
+}
+
+// Stats aggregates the executions of a workflow created within [from, to).
+func (r *Repository) Stats(ctx context.Context, workflowID string, from, to time.Time) (*models.ExecutionStats, error) {
+	if _, err := r.FetchByID(ctx, workflowID); err != nil {
+		return nil, err
+	}
+
+	return r.persistence.ExecutionContextRepository().GetExecutionStats(ctx, workflowID, from, to)
+}
+
 // FetchByID retrieves a workflow by its ID.
 func (r *Repository) FetchByID(ctx context.Context, id string) (*models.Workflow, error) {
 	workflow, err := r.persistence.WorkflowRepository().GetByID(ctx, id)
@@ -129,3 +145,26 @@ func (r *Repository) Delete(ctx context.Context, workflowID string) error {
 
 	return nil
 }
+
+// Restore undeletes a soft deleted workflow and returns it.
+func (r *Repository) Restore(ctx context.Context, workflowID string) (*models.Workflow, error) {
+	existing, err := r.persistence.WorkflowRepository().GetByIDIncludingDeleted(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return nil, ErrWorkflowNotFound
+	}
+
+	if existing.DeletedAt == nil {
+		return nil, ErrWorkflowNotDeleted
+	}
+
+	err = r.persistence.WorkflowRepository().Restore(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore workflow: %w", err)
+	}
+
+	return r.FetchByID(ctx, workflowID)
+}
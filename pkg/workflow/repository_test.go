@@ -235,6 +235,35 @@ func TestRepository_Delete(t *testing.T) {
 	assert.Nil(t, fetched)
 }
 
+func TestRepository_Restore(t *testing.T) {
+	persistence := file.NewPersistence(t.TempDir())
+	repo := NewRepository(persistence)
+
+	workflowCreated, err := repo.Create(t.Context(), &models.Workflow{Name: "Restore Test Workflow"})
+	require.NoError(t, err)
+
+	// An active workflow cannot be restored
+	_, err = repo.Restore(t.Context(), workflowCreated.ID)
+	require.ErrorIs(t, err, ErrWorkflowNotDeleted)
+
+	require.NoError(t, repo.Delete(t.Context(), workflowCreated.ID))
+
+	_, err = repo.FetchByID(t.Context(), workflowCreated.ID)
+	require.ErrorIs(t, err, ErrWorkflowNotFound)
+
+	restored, err := repo.Restore(t.Context(), workflowCreated.ID)
+	require.NoError(t, err)
+	assert.Equal(t, workflowCreated.ID, restored.ID)
+	assert.Nil(t, restored.DeletedAt)
+
+	fetched, err := repo.FetchByID(t.Context(), workflowCreated.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Restore Test Workflow", fetched.Name)
+
+	_, err = repo.Restore(t.Context(), "non-existent")
+	require.ErrorIs(t, err, ErrWorkflowNotFound)
+}
+
 func TestRepository_Delete_NotFound(t *testing.T) {
 	testDir := t.TempDir()
 	persistence := file.NewPersistence(testDir)
@@ -0,0 +1,64 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence"
+)
+
+// ErrExecutionNotPaused is returned when changing the variables of an execution that is not paused.
+var ErrExecutionNotPaused = errors.New("execution is not paused")
+
+// PatchVariables merges values into the variables of a paused execution, so the nodes activated
+// once it resumes see them. The change is recorded in the execution's VariableChanges.
+func (s *ExecutionService) PatchVariables(
+	ctx context.Context,
+	executionID string,
+	values map[string]any,
+	changedBy string,
+) (*models.ExecutionContext, error) {
+	execCtx, err := s.persistence.ExecutionContextRepository().GetExecutionContext(ctx, executionID)
+	if err != nil {
+		if errors.Is(err, persistence.ErrExecutionContextNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrExecutionNotFound, executionID)
+		}
+
+		return nil, fmt.Errorf("failed to get execution: %w", err)
+	}
+
+	if execCtx.Status != models.ExecutionStatusPaused {
+		return nil, fmt.Errorf("%w: %s is %s", ErrExecutionNotPaused, executionID, execCtx.Status)
+	}
+
+	if execCtx.Variables == nil {
+		execCtx.Variables = make(map[string]any, len(values))
+	}
+
+	previous := make(map[string]any)
+
+	for name := range values {
+		if value, ok := execCtx.Variables[name]; ok {
+			previous[name] = value
+		}
+	}
+
+	maps.Copy(execCtx.Variables, values)
+
+	execCtx.VariableChanges = append(execCtx.VariableChanges, models.VariableChange{
+		Values:    values,
+		Previous:  previous,
+		ChangedBy: changedBy,
+		ChangedAt: time.Now().UTC(),
+	})
+
+	if err := s.persistence.ExecutionContextRepository().UpdateExecutionContext(ctx, execCtx); err != nil {
+		return nil, fmt.Errorf("failed to update execution context: %w", err)
+	}
+
+	return execCtx, nil
+}
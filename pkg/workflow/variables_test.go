@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/dukex/operion/pkg/models"
+	"github.com/dukex/operion/pkg/persistence/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionService_PatchVariables_Paused(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	paused := setupPausedExecution(t, p, "token-patch", nil)
+	paused.Variables = map[string]any{"discount": 10, "region": "eu"}
+	require.NoError(t, p.ExecutionContextRepository().UpdateExecutionContext(t.Context(), paused))
+
+	service, _ := newTestExecutionService(t, p)
+
+	execution, err := service.PatchVariables(t.Context(), "exec-paused", map[string]any{"discount": 15, "carrier": "dhl"}, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"discount": 15, "region": "eu", "carrier": "dhl"}, execution.Variables)
+
+	stored, err := p.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-paused")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusPaused, stored.Status)
+	assert.InDelta(t, 15, stored.Variables["discount"], 0)
+	assert.Equal(t, "eu", stored.Variables["region"])
+	assert.Equal(t, "dhl", stored.Variables["carrier"])
+
+	// The change is audited with the replaced values
+	require.Len(t, stored.VariableChanges, 1)
+	change := stored.VariableChanges[0]
+	assert.Equal(t, "alice", change.ChangedBy)
+	assert.InDelta(t, 15, change.Values["discount"], 0)
+	assert.Equal(t, map[string]any{"discount": float64(10)}, change.Previous)
+	assert.False(t, change.ChangedAt.IsZero())
+}
+
+func TestExecutionService_PatchVariables_NotPaused(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	paused := setupPausedExecution(t, p, "token-running", nil)
+	paused.Status = models.ExecutionStatusRunning
+	require.NoError(t, p.ExecutionContextRepository().UpdateExecutionContext(t.Context(), paused))
+
+	service, _ := newTestExecutionService(t, p)
+
+	_, err := service.PatchVariables(t.Context(), "exec-paused", map[string]any{"discount": 15}, "")
+	require.ErrorIs(t, err, ErrExecutionNotPaused)
+
+	stored, err := p.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-paused")
+	require.NoError(t, err)
+	assert.NotContains(t, stored.Variables, "discount")
+	assert.Empty(t, stored.VariableChanges)
+
+	_, err = service.PatchVariables(t.Context(), "missing", map[string]any{"discount": 15}, "")
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+}
+
+func TestExecutionService_PatchVariables_SeenAfterResume(t *testing.T) {
+	p := file.NewPersistence(t.TempDir())
+	setupPausedExecution(t, p, "token-resume", nil)
+	service, eventBus := newTestExecutionService(t, p)
+
+	eventBus.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.PatchVariables(t.Context(), "exec-paused", map[string]any{"address": "corrected"}, "")
+	require.NoError(t, err)
+
+	execution, err := service.DecideApproval(t.Context(), "token-resume", true, "")
+	require.NoError(t, err)
+	assert.Equal(t, models.ExecutionStatusRunning, execution.Status)
+	assert.Equal(t, "corrected", execution.Variables["address"])
+
+	// The resumed node is activated for the execution whose stored variables the worker loads
+	activations := publishedActivations(eventBus)
+	require.Len(t, activations, 1)
+	assert.Equal(t, "exec-paused", activations[0].ExecutionID)
+
+	stored, err := p.ExecutionContextRepository().GetExecutionContext(t.Context(), "exec-paused")
+	require.NoError(t, err)
+	assert.Equal(t, "corrected", stored.Variables["address"])
+	assert.Len(t, stored.VariableChanges, 1)
+}